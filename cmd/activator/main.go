@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/signals"
+
+	"github.com/kserve/kserve/pkg/activator"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var (
+	port           = flag.Int("port", int(constants.ActivatorPort), "Activator port")
+	target         = flag.String("target", "", "The URL the requests are proxied to")
+	host           = flag.String("host", "", "The host the requests are proxied with, the host of the requests when empty")
+	deployment     = flag.String("deployment", "", "The deployment scaled from zero by the activator, the requests are only proxied when empty")
+	namespace      = flag.String("namespace", "", "The namespace of the deployment")
+	lease          = flag.String("lease", "", "The lease the replicas of the activator record their requests in, the activator is not replicated when empty")
+	gracePeriod    = flag.Duration("scale-to-zero-grace-period", 5*time.Minute, "How long the deployment receives no requests before it is scaled to zero")
+	requestTimeout = flag.Duration("request-timeout", 5*time.Minute, "How long the requests are buffered while the deployment is scaled from zero")
+)
+
+func main() {
+	flag.Parse()
+	zapLogger, _ := zap.NewProduction()
+	logger := zapLogger.Sugar()
+	defer func() { _ = logger.Sync() }()
+
+	targetURL, err := url.Parse(*target)
+	if err != nil || targetURL.Host == "" {
+		logger.Fatalw("Invalid target URL", "target", *target, "error", err)
+	}
+	var scaler activator.Scaler
+	var activity activator.Activity
+	if *deployment != "" {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			logger.Fatalw("Failed to load the in-cluster configuration", "error", err)
+		}
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			logger.Fatalw("Failed to create the Kubernetes client", "error", err)
+		}
+		scaler = &activator.DeploymentScaler{
+			Clientset:    clientset,
+			Namespace:    *namespace,
+			Name:         *deployment,
+			PollInterval: activator.DefaultPollInterval,
+		}
+		if *lease != "" {
+			identity, err := os.Hostname()
+			if err != nil {
+				logger.Fatalw("Failed to get the hostname", "error", err)
+			}
+			activity = &activator.LeaseActivity{
+				Clientset: clientset,
+				Namespace: *namespace,
+				Name:      *lease,
+				Identity:  identity,
+			}
+		}
+	}
+
+	ctx := signals.NewContext()
+	a := activator.New(targetURL, *host, scaler, activity, *requestTimeout, *gracePeriod, logger)
+	go a.Run(ctx, activator.DefaultPollInterval)
+
+	server := &http.Server{
+		Addr:              ":" + strconv.Itoa(*port),
+		Handler:           a,
+		ReadHeaderTimeout: time.Minute,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			logger.Errorw("Failed to shutdown the activator", "error", err)
+		}
+	}()
+	logger.Infow("Starting the activator", "port", *port, "target", *target, "deployment", *deployment)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatalw("Failed to serve", "error", err)
+	}
+}
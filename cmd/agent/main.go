@@ -33,6 +33,7 @@ import (
 	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/networking/pkg/http/header"
 	proxy "knative.dev/networking/pkg/http/proxy"
 	pkglogging "knative.dev/pkg/logging"
@@ -45,10 +46,25 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/kserve/kserve/pkg/agent"
+	"github.com/kserve/kserve/pkg/agent/backpressure"
+	"github.com/kserve/kserve/pkg/agent/dualprotocol"
+	"github.com/kserve/kserve/pkg/agent/gpumemory"
+	agentheaders "github.com/kserve/kserve/pkg/agent/headers"
+	"github.com/kserve/kserve/pkg/agent/inspector"
+	agentmetrics "github.com/kserve/kserve/pkg/agent/metrics"
+	"github.com/kserve/kserve/pkg/agent/sampling"
+	"github.com/kserve/kserve/pkg/agent/slowstart"
 	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/agent/transcoding"
+	"github.com/kserve/kserve/pkg/agent/watchdog"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/batcher"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/faultinjection"
 	kfslogger "github.com/kserve/kserve/pkg/logger"
+	"github.com/kserve/kserve/pkg/profiling"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 )
 
 var (
@@ -64,17 +80,78 @@ var (
 	sourceUri           = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
 	logMode             = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
 	logStorePath        = flag.String("log-store-path", "", "The path to the log output")
-	logStoreFormat      = flag.String("log-store-format", "json", "Format for log output, 'json' or 'yaml'")
+	logStoreFormat      = flag.String("log-store-format", "json", "Format for log output, 'json' for an object per request, 'ndjson' or 'parquet' for batches of requests")
+	logKafkaSecretDir   = flag.String("log-kafka-secret-dir", "", "Directory of the mounted secret with the SASL and TLS settings of the kafka log-url brokers")
 	inferenceService    = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
 	namespace           = flag.String("namespace", "", "The namespace to add as header to log events")
 	endpoint            = flag.String("endpoint", "", "The endpoint name to add as header to log events")
 	component           = flag.String("component", "", "The component name (predictor, explainer, transformer) to add as header to log events")
 	metadataHeaders     = flag.StringSlice("metadata-headers", nil, "Allow list of headers that will be passed down as metadata")
 	metadataAnnotations = flag.StringSlice("metadata-annotations", nil, "Allow list of metadata annotation to be passed with payload logging")
+
+	logStoreFlushInterval = flag.Duration("log-store-flush-interval", kfslogger.DefaultBatchFlushInterval, "Longest time a request is buffered before its ndjson or parquet batch is uploaded")
+	logStoreFlushSize     = flag.String("log-store-flush-size", "", "Size of the buffered payloads, e.g. 16Mi, above which the ndjson or parquet batch is uploaded")
+
+	logSamplePercent      = flag.Int("log-sample-percent", 100, "Percentage of the requests logged along with their responses")
+	logErrorSamplePercent = flag.Int("log-error-sample-percent", -1, "Percentage of the requests answered with an error logged along with their responses, the sample percent when negative")
+	logHeaderFilters      = flag.StringSlice("log-header-filters", nil, "Headers, as name=value or name, the logged requests must match, all the names and one of the values of each name")
+	logStatusCodes        = flag.StringSlice("log-status-codes", nil, "Status codes of the responses, e.g. 200 or 5xx, whose requests are logged")
+	logRedactFields       = flag.StringSlice("log-redact-fields", nil, "Dot separated paths of the JSON fields of the payloads redacted from the logs")
+	logEncryptionKMSKey   = flag.String("log-encryption-kms-key", "", "ARN, id or alias of the AWS KMS key the payloads of the logs are envelope encrypted with")
+	logEncryptionPubKey   = flag.String("log-encryption-public-key", "", "Path of the PEM encoded RSA public key the payloads of the logs are envelope encrypted with")
+	// explainer sampling flags
+	explainerUrl             = flag.String("explainer-url", "", "The URL of the explainer the sampled prediction requests are sent to")
+	explainerSamplingPercent = flag.Int("explainer-sampling-percent", 0, "Percentage of the prediction requests sent to the explainer")
+	// transcoding flags
+	grpcTranscodingPort = flag.Int("grpc-transcoding-port", 0, "Port of the v2 gRPC service of the component the REST v2 requests are transcoded to, disabled when 0")
+
+	dualProtocol = flag.String("dual-protocol", "", "Native REST protocol of the component, v1 or v2, the requests of the other protocol are translated to, disabled when empty")
+	// header flags
+	denyHeaders = flag.StringArray("deny-headers", nil, "Pattern of the request headers stripped before the requests reach the component, repeated for each pattern")
+	// request inspector flags
+	inspectRequests        = flag.Int("inspect-requests", 0, "Number of the last sampled requests listed by the request inspector endpoint of the profiling server, disabled when 0")
+	inspectSamplingPercent = flag.Int("inspect-sampling-percent", 100, "Percentage of the requests sampled by the request inspector")
+	inspectMaxBodyBytes    = flag.Int("inspect-max-body-bytes", inspector.DefaultMaxBodyBytes, "Size the request and response bodies are truncated to by the request inspector")
+	inspectRedactPatterns  = flag.StringSlice("inspect-redact-patterns", inspector.DefaultRedactPatterns, "Patterns of the JSON fields redacted from the bodies by the request inspector")
+	// fault injection flags
+	faultInjection = flag.String("fault-injection", "", "JSON list of the rules injecting errors and latency into the requests, disabled when empty")
+
+	enableBackpressure    = flag.Bool("enable-backpressure", false, "Add the back-pressure headers to the 429 and 503 responses")
+	backpressureSignals   = flag.StringSlice("backpressure-signals", nil, "Back-pressure signals exposed, queue-depth, estimated-wait and retry-after, all of them when empty")
+	backpressureOnSuccess = flag.Bool("backpressure-on-success", false, "Add the back-pressure headers to the successful responses as well")
+	backpressureQueue     = flag.StringSlice("backpressure-queue-metrics", backpressure.DefaultQueueMetrics, "Component metrics reporting the requests waiting in the queue of the runtime, the first reported is used")
+
+	watchdogConfig = flag.String("watchdog", "", "JSON watchdog spec of the serving runtime detecting the hung runtimes, disabled when empty")
+	// GPU memory telemetry flags
+	enableGPUMemoryTelemetry     = flag.Bool("enable-gpu-memory-telemetry", false, "Sample the GPU memory reported in the component metrics and recommend restarting the fragmented or leaking runtimes")
+	gpuMemoryAllocatedMetric     = flag.String("gpu-memory-allocated-metric", gpumemory.DefaultAllocatedMetric, "Component metric reporting the GPU memory allocated by the runtime")
+	gpuMemoryReservedMetric      = flag.String("gpu-memory-reserved-metric", gpumemory.DefaultReservedMetric, "Component metric reporting the GPU memory reserved by the runtime")
+	gpuMemorySampleInterval      = flag.Duration("gpu-memory-sample-interval", gpumemory.DefaultConfig.Interval, "Interval between the GPU memory samples")
+	gpuMemoryWindow              = flag.Duration("gpu-memory-window", gpumemory.DefaultConfig.Window, "Window the GPU memory leak rate is estimated over")
+	gpuMemoryFragmentationThresh = flag.Float64("gpu-memory-fragmentation-threshold", gpumemory.DefaultConfig.FragmentationThreshold, "Share of the reserved GPU memory not allocated recommending a restart")
+	gpuMemoryLeakThreshold       = flag.Float64("gpu-memory-leak-threshold", gpumemory.DefaultConfig.LeakThreshold, "Share of the reserved GPU memory the allocated memory grows by over the window recommending a restart")
+
+	// slow start flags
+	slowStartWindow         = flag.Duration("slow-start-window", 0, "Window over which the concurrency admitted by the new replica ramps up, disabled when 0")
+	slowStartInitialPercent = flag.Int("slow-start-initial-percent", 10, "Share of the slow start max concurrency admitted at the beginning of the window")
+	slowStartMaxConcurrency = flag.Int64("slow-start-max-concurrency", 1, "Concurrency admitted at the end of the slow start window")
+
+	artifactOutputUri = flag.String("artifact-output-uri", "", "The storage URI the artifacts written by the component are uploaded to, disabled when empty")
+	artifactDir       = flag.String("artifact-dir", constants.DefaultArtifactDir, "Directory of the artifacts written by the component")
 	// batcher flags
 	enableBatcher = flag.Bool("enable-batcher", false, "Enable request batcher")
 	maxBatchSize  = flag.String("max-batchsize", "32", "Max Batch Size")
 	maxLatency    = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	latencySLO    = flag.Int("latency-slo", 0, "Target p95 latency in milliseconds the batches are adjusted to, the batches are not adjusted when 0")
+	minBatchSize  = flag.Int("min-batchsize", 1, "Max batch size the adaptive batcher never shrinks the batches below")
+	// profiling flags
+	enableProfiling = flag.Bool("enable-profiling", false, "Serve the pprof and trace endpoints, secured by the PROFILING_TOKEN bearer token")
+	profilingPort   = flag.Int("profiling-port", profiling.DefaultPort, "Profiling port")
+	// metrics relabeling flags
+	enableMetricsRelabeling = flag.Bool("enable-metrics-relabeling", false, "Serve the component metrics with the per-model labels normalized to the model, version and isvc labels")
+	metricsRelabelingPort   = flag.Int("metrics-relabeling-port", constants.MetricsRelabelingPort, "Port serving the relabeled metrics")
+	componentMetricsPort    = flag.Int("component-metrics-port", 8080, "Port the component metrics are scraped from")
+	componentMetricsPath    = flag.String("component-metrics-path", constants.DefaultPrometheusPath, "Path the component metrics are scraped from")
 	// probing flags
 	readinessProbeTimeout = flag.Duration("probe-period", -1, "run readiness probe with given timeout") //nolint: unused
 	// This creates an abstract socket instead of an actual file.
@@ -123,14 +200,21 @@ type loggerArgs struct {
 	annotations      map[string]string
 	certName         string
 	tlsSkipVerify    bool
+	filter           *kfslogger.Filter
+	// batchStore is flushed on shutdown
+	batchStore *kfslogger.BatchStore
 }
 
 type batcherArgs struct {
 	maxBatchSize int
 	maxLatency   int
+	latencySLO   int
+	minBatchSize int
 }
 
 func main() {
+	var tlsOpts tlspolicy.Options
+	tlsOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	// Parse the environment.
 	var env config
@@ -141,6 +225,14 @@ func main() {
 
 	logger, _ := pkglogging.NewLogger(env.ServingLoggingConfig, env.ServingLoggingLevel)
 	ctrl.SetLogger(zapr.NewLogger(logger.Desugar()))
+	tlsPolicy, err := tlspolicy.New(tlsOpts)
+	if err != nil {
+		logger.Fatalw("Invalid TLS policy", zap.Error(err))
+	}
+	if tlsPolicy.FIPS() && *TlsSkipVerify {
+		logger.Fatal("The logger TLS verification cannot be skipped in FIPS mode")
+	}
+	kfslogger.TLSPolicy = tlsPolicy
 	// Setup probe to run for checking user container healthiness.
 	probe := func() bool { return true }
 	if env.ServingReadinessProbe != "" {
@@ -163,12 +255,45 @@ func main() {
 		logger.Info("Starting batcher")
 		batcherArgs = startBatcher(logger)
 	}
-	logger.Info("Starting agent http server...")
+	var artifactUploader *agent.ArtifactUploader
+	if *artifactOutputUri != "" {
+		logger.Infof("Uploading the artifacts of %s to %s", *artifactDir, *artifactOutputUri)
+		artifactUploader = startArtifactUploader(logger)
+	}
 	ctx := signals.NewContext()
-	mainServer, drain := buildServer(*port, *componentPort, loggerArgs, batcherArgs, probe, logger)
+	var runtimeWatchdog *watchdog.Watchdog
+	if *watchdogConfig != "" {
+		runtimeWatchdog, probe = startWatchdog(ctx, probe, logger)
+	}
+	var gpuMemoryMonitor *gpumemory.Monitor
+	if *enableGPUMemoryTelemetry {
+		gpuMemoryMonitor = startGPUMemoryMonitor(ctx, logger)
+	}
+	var runtimeQueue *backpressure.RuntimeQueue
+	if *enableBackpressure {
+		runtimeQueue = startRuntimeQueue(ctx, logger)
+	}
+	logger.Info("Starting agent http server...")
+	mainServer, drain, requestInspector := buildServer(*port, *componentPort, loggerArgs, batcherArgs, probe, runtimeWatchdog,
+		gpuMemoryMonitor, runtimeQueue, logger)
 	servers := map[string]*http.Server{
 		"main": mainServer,
 	}
+	if requestInspector != nil {
+		logger.Infof("Serving the last %d sampled requests on the %s endpoint of the profiling server", *inspectRequests, inspector.Path)
+	}
+	if profilingServer := buildProfilingServer(*enableProfiling, *profilingPort, os.Getenv(profiling.TokenEnvVar), requestInspector); profilingServer != nil {
+		logger.Infof("Serving profiling endpoints on port %d", *profilingPort)
+		servers["profiling"] = profilingServer
+	}
+	if *enableMetricsRelabeling {
+		logger.Infof("Serving relabeled metrics on port %d", *metricsRelabelingPort)
+		servers["metrics"] = buildMetricsRelabelingServer(*metricsRelabelingPort, logger)
+	}
+	if artifactUploader != nil {
+		logger.Infof("Serving the artifact upload endpoint on port %d", constants.ArtifactUploadPort)
+		servers["artifacts"] = buildArtifactUploadServer(artifactUploader)
+	}
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	for name, server := range servers {
@@ -243,6 +368,18 @@ func main() {
 				logger.Errorw("Failed to shutdown server", zap.String("server", serverName), zap.Error(err))
 			}
 		}
+		// Upload the payloads still buffered in the log batches
+		if loggerArgs != nil && loggerArgs.batchStore != nil {
+			if err := loggerArgs.batchStore.Close(); err != nil {
+				logger.Errorw("Failed to upload the log batches", zap.Error(err))
+			}
+		}
+		// Upload the artifacts written until the component stopped serving
+		if artifactUploader != nil {
+			if _, err := artifactUploader.Upload(); err != nil {
+				logger.Errorw("Failed to upload artifacts", zap.Error(err))
+			}
+		}
 		logger.Info("Shutdown complete, exiting...")
 	}
 }
@@ -260,9 +397,16 @@ func startBatcher(logger *zap.SugaredLogger) *batcherArgs {
 		os.Exit(1)
 	}
 
+	if *latencySLO < 0 || *minBatchSize <= 0 || *minBatchSize > maxBatchSizeInt {
+		logger.Error(errors.New("Invalid adaptive batcher latency SLO or min batch size"), *latencySLO, *minBatchSize)
+		os.Exit(1)
+	}
+
 	return &batcherArgs{
 		maxLatency:   maxLatencyInt,
 		maxBatchSize: maxBatchSizeInt,
+		latencySLO:   *latencySLO,
+		minBatchSize: *minBatchSize,
 	}
 }
 
@@ -302,9 +446,48 @@ func startLogger(workers int, logStorePath *string, logStoreFormat *string, log
 		}
 	}
 
+	var filter *kfslogger.Filter
+	if *logSamplePercent != 100 || *logErrorSamplePercent >= 0 || len(*logHeaderFilters) > 0 || len(*logStatusCodes) > 0 ||
+		len(*logRedactFields) > 0 {
+		filter, err = kfslogger.NewFilter(*logSamplePercent, *logErrorSamplePercent, *logHeaderFilters, *logStatusCodes, *logRedactFields)
+		if err != nil {
+			log.Errorw("Malformed logger filter", zap.Error(err))
+			os.Exit(-1)
+		}
+	}
+
 	var store kfslogger.Store
-	if kfslogger.GetStorageStrategy(*logUrl) != kfslogger.HttpStorage {
-		if logStoreFormat != nil && *logStoreFormat != "" && logStorePath != nil && *logStorePath != "" {
+	var batchStore *kfslogger.BatchStore
+	switch kfslogger.GetStorageStrategy(*logUrl) {
+	case kfslogger.HttpStorage:
+	case kfslogger.KafkaStorage:
+		log.Infow("Logger kafka sink is enabled", "url", *logUrl)
+		store, err = kfslogger.NewKafkaStore(logUrlParsed, *logKafkaSecretDir, kfslogger.TLSPolicy, log)
+		if err != nil {
+			log.Errorw("Error creating logger kafka store", zap.Error(err))
+			os.Exit(-1)
+		}
+	default:
+		if logStoreFormat != nil && kfslogger.IsBatchFormat(*logStoreFormat) && logStorePath != nil && *logStorePath != "" {
+			flushSize := int64(kfslogger.DefaultBatchFlushSize)
+			if *logStoreFlushSize != "" {
+				quantity, err := resource.ParseQuantity(*logStoreFlushSize)
+				if err != nil {
+					log.Errorf("Malformed log-store-flush-size %s", *logStoreFlushSize)
+					os.Exit(-1)
+				}
+				flushSize = quantity.Value()
+			}
+			log.Infow("Logger batch storage is enabled", "path", *logStorePath, "logStoreFormat", *logStoreFormat,
+				"flushInterval", *logStoreFlushInterval, "flushSize", flushSize)
+			batchStore, err = kfslogger.NewBatchStoreForScheme(logUrlParsed.Scheme, *logStorePath, *logStoreFormat,
+				*logStoreFlushInterval, int(flushSize), log)
+			if err != nil {
+				log.Errorw("Error creating logger batch store", zap.Error(err))
+				os.Exit(-1)
+			}
+			store = batchStore
+		} else if logStoreFormat != nil && *logStoreFormat != "" && logStorePath != nil && *logStorePath != "" {
 			log.Infow("Logger storage is enabled", "path", logStorePath, "logStoreFormat", logStoreFormat)
 			store, err = kfslogger.NewStoreForScheme(logUrlParsed.Scheme, *logStorePath, *logStoreFormat, log)
 			if err != nil {
@@ -314,6 +497,19 @@ func startLogger(workers int, logStorePath *string, logStoreFormat *string, log
 		}
 	}
 
+	switch {
+	case *logEncryptionKMSKey != "":
+		log.Infow("Logger payload encryption is enabled", "kmsKey", *logEncryptionKMSKey)
+		kfslogger.PayloadEncryptor, err = kfslogger.NewKMSEncryptor(*logEncryptionKMSKey)
+	case *logEncryptionPubKey != "":
+		log.Infow("Logger payload encryption is enabled", "publicKey", *logEncryptionPubKey)
+		kfslogger.PayloadEncryptor, err = kfslogger.NewPublicKeyEncryptor(*logEncryptionPubKey)
+	}
+	if err != nil {
+		log.Errorw("Error creating logger payload encryptor", zap.Error(err))
+		os.Exit(-1)
+	}
+
 	log.Info("Starting the log dispatcher")
 	kfslogger.StartDispatcher(workers, store, log)
 	return &loggerArgs{
@@ -328,6 +524,8 @@ func startLogger(workers int, logStorePath *string, logStoreFormat *string, log
 		annotations:      annotationKVPair,
 		certName:         *CaCertFile,
 		tlsSkipVerify:    *TlsSkipVerify,
+		filter:           filter,
+		batchStore:       batchStore,
 	}
 }
 
@@ -343,6 +541,73 @@ func startModelPuller(logger *zap.SugaredLogger) {
 	go watcher.Start()
 }
 
+func startArtifactUploader(logger *zap.SugaredLogger) *agent.ArtifactUploader {
+	if _, _, err := agent.ParseArtifactOutputUri(*artifactOutputUri); err != nil {
+		logger.Errorw("Malformed artifact-output-uri", zap.Error(err))
+		os.Exit(-1)
+	}
+	// Reuse the credentials of the storage spec the model is downloaded with
+	if err := storage.ApplyStorageSpecConfig(); err != nil {
+		logger.Errorw("Failed to apply the storage spec config", zap.Error(err))
+		os.Exit(-1)
+	}
+	return &agent.ArtifactUploader{
+		OutputUri:   *artifactOutputUri,
+		ArtifactDir: *artifactDir,
+		Providers:   map[storage.Protocol]storage.Provider{},
+		Logger:      logger,
+	}
+}
+
+// startWatchdog starts probing the idle runtime, and returns the watchdog with the readiness probe of the pod, which
+// fails while the runtime is hung when the watchdog marks the pod unready
+func startWatchdog(ctx context.Context, probe func() bool, logger *zap.SugaredLogger) (*watchdog.Watchdog, func() bool) {
+	config, err := watchdog.ParseConfig(*watchdogConfig)
+	if err != nil {
+		logger.Fatalw("Agent failed to parse the watchdog config", zap.Error(err))
+	}
+	logger.Infow("Watching the runtime for the stalled requests", "stallThreshold", config.StallThreshold.Duration,
+		"action", config.Action)
+	runtimeWatchdog := watchdog.New(config, logger)
+	componentURL := &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort("127.0.0.1", strconv.Itoa(*componentPort)),
+	}
+	go runtimeWatchdog.Run(ctx, componentURL, probe)
+	if config.Action != v1alpha1.WatchdogUnreadyAction {
+		return runtimeWatchdog, probe
+	}
+	return runtimeWatchdog, func() bool {
+		return !runtimeWatchdog.Hung() && probe()
+	}
+}
+
+// startGPUMemoryMonitor starts sampling the GPU memory reported in the component metrics
+func startGPUMemoryMonitor(ctx context.Context, logger *zap.SugaredLogger) *gpumemory.Monitor {
+	config := gpumemory.DefaultConfig
+	config.MetricsURL = componentMetricsURL()
+	config.AllocatedMetric = *gpuMemoryAllocatedMetric
+	config.ReservedMetric = *gpuMemoryReservedMetric
+	config.Interval = *gpuMemorySampleInterval
+	config.Window = *gpuMemoryWindow
+	config.FragmentationThreshold = *gpuMemoryFragmentationThresh
+	config.LeakThreshold = *gpuMemoryLeakThreshold
+	logger.Infow("Sampling the GPU memory of the runtime", "metricsURL", config.MetricsURL, "interval", config.Interval,
+		"window", config.Window)
+	monitor := gpumemory.New(config, logger)
+	go monitor.Run(ctx)
+	return monitor
+}
+
+// startRuntimeQueue starts sampling the queue of the runtime reported in the component metrics
+func startRuntimeQueue(ctx context.Context, logger *zap.SugaredLogger) *backpressure.RuntimeQueue {
+	metricsURL := componentMetricsURL()
+	logger.Infow("Sampling the queue of the runtime", "metricsURL", metricsURL, "metrics", *backpressureQueue)
+	runtimeQueue := backpressure.NewRuntimeQueue(metricsURL, *backpressureQueue, backpressure.DefaultSampleInterval, logger)
+	go runtimeQueue.Run(ctx)
+	return runtimeQueue
+}
+
 func buildProbe(logger *zap.SugaredLogger, probeJSON string, autodetectHTTP2 bool, multiContainerProbes bool) *readiness.Probe {
 	coreProbes, err := readiness.DecodeProbes(probeJSON, multiContainerProbes)
 	if err != nil {
@@ -362,8 +627,9 @@ func buildProbe(logger *zap.SugaredLogger, probeJSON string, autodetectHTTP2 boo
 }
 
 func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs *batcherArgs,
-	probeContainer func() bool, logging *zap.SugaredLogger,
-) (server *http.Server, drain func()) {
+	probeContainer func() bool, runtimeWatchdog *watchdog.Watchdog, gpuMemoryMonitor *gpumemory.Monitor,
+	runtimeQueue *backpressure.RuntimeQueue, logging *zap.SugaredLogger,
+) (server *http.Server, drain func(), requestInspector *inspector.Inspector) {
 	logging.Infof("Building server user port %d port %s", userPort, port)
 	target := &url.URL{
 		Scheme: "http",
@@ -381,14 +647,89 @@ func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs
 	// Create handler chain.
 	// Note: innermost handlers are specified first, ie. the last handler in the chain will be executed first.
 	var composedHandler http.Handler = httpProxy
+	if runtimeWatchdog != nil {
+		composedHandler = runtimeWatchdog.Handler(composedHandler)
+	}
+	if gpuMemoryMonitor != nil {
+		composedHandler = gpuMemoryMonitor.Handler(composedHandler)
+	}
+	// The requests waiting for the ramp are not observed by the watchdog and the GPU memory monitor
+	if *slowStartWindow > 0 {
+		slowStart, err := slowstart.NewHandler(*slowStartWindow, *slowStartInitialPercent, *slowStartMaxConcurrency, composedHandler)
+		if err != nil {
+			logging.Fatalw("Agent failed to configure the slow start", zap.Error(err))
+		}
+		composedHandler = slowStart
+	}
 
+	if *grpcTranscodingPort > 0 {
+		transcoder, err := transcoding.NewTranscoder(net.JoinHostPort("127.0.0.1", strconv.Itoa(*grpcTranscodingPort)),
+			composedHandler, logging)
+		if err != nil {
+			logging.Fatalw("Agent failed to configure the gRPC transcoding", zap.Error(err))
+		}
+		composedHandler = transcoder
+	}
+	if *dualProtocol != "" {
+		adapter, err := dualprotocol.NewAdapter(constants.InferenceServiceProtocol(*dualProtocol), composedHandler, logging)
+		if err != nil {
+			logging.Fatalw("Agent failed to configure the dual protocol", zap.Error(err))
+		}
+		composedHandler = adapter
+	}
 	if batcherArgs != nil {
-		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		if batcherArgs.latencySLO > 0 {
+			composedHandler = batcher.NewAdaptive(batcherArgs.maxBatchSize, batcherArgs.maxLatency, batcherArgs.latencySLO,
+				batcherArgs.minBatchSize, composedHandler, logging)
+		} else {
+			composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		}
 	}
 	if loggerArgs != nil {
 		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType,
 			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, composedHandler,
-			loggerArgs.metadataHeaders, loggerArgs.certName, loggerArgs.annotations, loggerArgs.tlsSkipVerify)
+			loggerArgs.metadataHeaders, loggerArgs.certName, loggerArgs.annotations, loggerArgs.tlsSkipVerify, loggerArgs.filter)
+	}
+
+	if *explainerUrl != "" && *explainerSamplingPercent > 0 {
+		sampler, err := sampling.NewExplainerSampler(*explainerUrl, *explainerSamplingPercent, composedHandler,
+			explanationHandler(loggerArgs, logging), logging)
+		if err != nil {
+			logging.Fatalw("Agent failed to configure the explainer sampling", zap.Error(err))
+		}
+		composedHandler = sampler
+	}
+	if len(*denyHeaders) > 0 {
+		denyHandler, err := agentheaders.NewDenyHandler(*denyHeaders, composedHandler, logging)
+		if err != nil {
+			logging.Fatalw("Agent failed to compile the deny header patterns", zap.Error(err))
+		}
+		composedHandler = denyHandler
+	}
+	if *faultInjection != "" {
+		rules, err := faultinjection.ParseRules(*faultInjection)
+		if err != nil {
+			logging.Fatalw("Agent failed to parse the fault injection rules", zap.Error(err))
+		}
+		logging.Warnw("Fault injection is enabled, the requests may be failed or delayed on purpose", "rules", *faultInjection)
+		composedHandler = faultinjection.NewHandler(rules, composedHandler)
+	}
+	if *inspectRequests > 0 {
+		var err error
+		requestInspector, err = inspector.New(*inspectRequests, *inspectSamplingPercent, *inspectMaxBodyBytes,
+			*inspectRedactPatterns, composedHandler)
+		if err != nil {
+			logging.Fatalw("Agent failed to configure the request inspector", zap.Error(err))
+		}
+		composedHandler = requestInspector
+	}
+
+	if *enableBackpressure {
+		signals, err := backpressure.ParseSignals(*backpressureSignals)
+		if err != nil {
+			logging.Fatalw("Agent failed to parse the back-pressure signals", zap.Error(err))
+		}
+		composedHandler = backpressure.NewHandler(signals, *backpressureOnSuccess, runtimeQueue, composedHandler)
 	}
 
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
@@ -401,5 +742,91 @@ func buildServer(port string, userPort int, loggerArgs *loggerArgs, batcherArgs
 		HealthCheck:           health.ProbeHandler(probeContainer, false),
 	}
 	composedHandler = drainer
-	return pkgnet.NewServer(":"+port, composedHandler), drainer.Drain
+	// The liveness probe of the kserve-container restarting the hung runtime is not answered by the drainer
+	if runtimeWatchdog != nil {
+		composedHandler = runtimeWatchdog.HealthHandler(composedHandler)
+	}
+	return pkgnet.NewServer(":"+port, composedHandler), drainer.Drain, requestInspector
+}
+
+// explanationHandler returns how the explanations of the sampled prediction requests are logged, as CloudEvents sent
+// to the logger url when the logger is enabled, or else to the agent logs
+func explanationHandler(loggerArgs *loggerArgs, logging *zap.SugaredLogger) sampling.ExplanationHandler {
+	if loggerArgs == nil {
+		return func(id string, contentType string, explanation []byte) {
+			logging.Infow("Explained a sampled prediction request", "id", id, "explanation", string(explanation))
+		}
+	}
+	return func(id string, contentType string, explanation []byte) {
+		if err := kfslogger.QueueLogRequest(kfslogger.LogRequest{
+			Url:              loggerArgs.logUrl,
+			Bytes:            &explanation,
+			ContentType:      contentType,
+			ReqType:          kfslogger.CEInferenceExplanation,
+			Id:               id,
+			SourceUri:        loggerArgs.sourceUrl,
+			InferenceService: loggerArgs.inferenceService,
+			Namespace:        loggerArgs.namespace,
+			Endpoint:         loggerArgs.endpoint,
+			Component:        loggerArgs.component,
+			Annotations:      loggerArgs.annotations,
+			CertName:         loggerArgs.certName,
+			TlsSkipVerify:    loggerArgs.tlsSkipVerify,
+		}); err != nil {
+			logging.Errorw("Failed to log the explanation", "id", id, zap.Error(err))
+		}
+	}
+}
+
+// componentMetricsURL returns the url the component metrics are scraped from
+func componentMetricsURL() string {
+	return (&url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort("127.0.0.1", strconv.Itoa(*componentMetricsPort)),
+		Path:   *componentMetricsPath,
+	}).String()
+}
+
+// buildProfilingServer returns the server of the profiling endpoints and of the request inspector endpoint, which
+// serves only the inspector endpoint when profiling is disabled, or nil when neither is enabled
+func buildProfilingServer(enableProfiling bool, port int, token string, requestInspector *inspector.Inspector) *http.Server {
+	var debugEndpoints []profiling.Endpoint
+	if requestInspector != nil {
+		debugEndpoints = append(debugEndpoints, profiling.Endpoint{Path: inspector.Path, Handler: requestInspector.Handler()})
+	}
+	switch {
+	case enableProfiling:
+		return profiling.NewHTTPServer(port, token, debugEndpoints...)
+	case requestInspector != nil:
+		return profiling.NewEndpointsHTTPServer(port, token, debugEndpoints...)
+	default:
+		return nil
+	}
+}
+
+func buildMetricsRelabelingServer(port int, logging *zap.SugaredLogger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(constants.DefaultPrometheusPath, agentmetrics.NewRelabelHandler(componentMetricsURL(), *inferenceService, logging))
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           mux,
+		ReadHeaderTimeout: time.Minute,
+		WriteTimeout:      time.Minute,
+		IdleTimeout:       3 * time.Minute,
+	}
+}
+
+// buildArtifactUploadServer serves the on demand artifact upload endpoint on the loopback interface, so that only the
+// containers of the pod can trigger an upload.
+func buildArtifactUploadServer(uploader *agent.ArtifactUploader) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(constants.ArtifactUploadPath, uploader)
+	return &http.Server{
+		Addr:              net.JoinHostPort("127.0.0.1", strconv.Itoa(constants.ArtifactUploadPort)),
+		Handler:           mux,
+		ReadHeaderTimeout: time.Minute,
+		// large artifacts such as compiled engines take a while to upload
+		WriteTimeout: 30 * time.Minute,
+		IdleTimeout:  3 * time.Minute,
+	}
 }
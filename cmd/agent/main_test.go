@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/kserve/kserve/pkg/agent/inspector"
+)
+
+func TestBuildProfilingServer(t *testing.T) {
+	requestInspector, err := inspector.New(10, 100, 1024, nil, http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("failed to create the request inspector: %v", err)
+	}
+	get := func(server *http.Server, path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	scenarios := map[string]struct {
+		enableProfiling  bool
+		requestInspector *inspector.Inspector
+		expectedStatus   map[string]int
+	}{
+		"profiling and request inspector": {
+			enableProfiling:  true,
+			requestInspector: requestInspector,
+			expectedStatus:   map[string]int{"/debug/pprof/": http.StatusOK, inspector.Path: http.StatusOK},
+		},
+		"request inspector only": {
+			requestInspector: requestInspector,
+			expectedStatus: map[string]int{
+				"/debug/pprof/":        http.StatusNotFound,
+				"/debug/pprof/cmdline": http.StatusNotFound,
+				"/debug/pprof/capture": http.StatusNotFound,
+				inspector.Path:         http.StatusOK,
+			},
+		},
+		"profiling only": {
+			enableProfiling: true,
+			expectedStatus:  map[string]int{"/debug/pprof/": http.StatusOK, inspector.Path: http.StatusNotFound},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			server := buildProfilingServer(scenario.enableProfiling, 8008, "secret", scenario.requestInspector)
+			g.Expect(server).NotTo(gomega.BeNil())
+			for path, status := range scenario.expectedStatus {
+				g.Expect(get(server, path)).To(gomega.Equal(status), path)
+			}
+		})
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(buildProfilingServer(false, 8008, "secret", nil)).To(gomega.BeNil())
+	// The debug endpoints are not served without a token
+	g.Expect(buildProfilingServer(false, 8008, "", requestInspector)).To(gomega.BeNil())
+}
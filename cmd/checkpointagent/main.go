@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The KServe Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	modelcheckpointcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/modelcheckpoint"
+)
+
+var setupLog = ctrl.Log.WithName("setup")
+
+const (
+	LeaderLockName = "kserve-checkpoint-agent-manager-leader-lock"
+)
+
+// Options defines the program configurable options that may be passed on the command line.
+type Options struct {
+	metricsAddr          string
+	enableLeaderElection bool
+	probeAddr            string
+	kubeletPort          int
+	kubeletCAFile        string
+	kubeletInsecureTLS   bool
+	tokenFile            string
+	insecureRegistry     bool
+	zapOpts              zap.Options
+}
+
+// DefaultOptions returns the default values for the program options.
+func DefaultOptions() Options {
+	return Options{
+		metricsAddr:          ":8080",
+		enableLeaderElection: false,
+		probeAddr:            ":8081",
+		kubeletPort:          10250,
+		kubeletCAFile:        "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+		kubeletInsecureTLS:   false,
+		tokenFile:            "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		insecureRegistry:     false,
+		zapOpts:              zap.Options{},
+	}
+}
+
+// GetOptions parses the program flags and returns them as Options.
+func GetOptions() Options {
+	opts := DefaultOptions()
+	flag.BoolVar(&opts.enableLeaderElection, "leader-elect", opts.enableLeaderElection,
+		"Enable leader election for kserve controller manager. "+
+			"Enabling this will ensure there is only one active kserve controller manager.")
+	flag.IntVar(&opts.kubeletPort, "kubelet-port", opts.kubeletPort, "The port of the kubelet API of the node.")
+	flag.StringVar(&opts.kubeletCAFile, "kubelet-ca-file", opts.kubeletCAFile,
+		"The CA file verifying the serving certificate of the kubelet.")
+	flag.BoolVar(&opts.kubeletInsecureTLS, "kubelet-insecure-tls", opts.kubeletInsecureTLS,
+		"Do not verify the serving certificate of the kubelet.")
+	flag.StringVar(&opts.tokenFile, "token-file", opts.tokenFile,
+		"The service account token authenticating the agent to the kubelet.")
+	flag.BoolVar(&opts.insecureRegistry, "insecure-registry", opts.insecureRegistry,
+		"Push the checkpoint images to the registry over plain HTTP.")
+	opts.zapOpts.BindFlags(flag.CommandLine)
+	flag.Parse()
+	return opts
+}
+
+func main() {
+	options := GetOptions()
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&options.zapOpts)))
+
+	nodeName := os.Getenv("NODE_NAME")
+	nodeIP := os.Getenv("NODE_IP")
+	if nodeName == "" || nodeIP == "" {
+		setupLog.Error(nil, "NODE_NAME and NODE_IP environment variables must be set")
+		os.Exit(1)
+	}
+
+	// Get a config to talk to the apiserver
+	setupLog.Info("Setting up client for manager")
+	cfg, err := config.GetConfig()
+	if err != nil {
+		setupLog.Error(err, "unable to set up client config")
+		os.Exit(1)
+	}
+
+	// Setup clientset to directly talk to the api server
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to create clientSet")
+		os.Exit(1)
+	}
+
+	// Create a new Cmd to provide shared dependencies and start components
+	setupLog.Info("Setting up manager")
+	mgr, err := manager.New(cfg, manager.Options{
+		Metrics: metricsserver.Options{
+			BindAddress: options.metricsAddr,
+		},
+		LeaderElection:         options.enableLeaderElection,
+		LeaderElectionID:       LeaderLockName,
+		HealthProbeBindAddress: options.probeAddr,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to set up overall controller manager")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Registering Components.")
+
+	setupLog.Info("Setting up KServe v1alpha1 scheme")
+	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "unable to add KServe v1alpha1 to scheme")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up core scheme")
+	if err := corev1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "unable to add Core APIs to scheme")
+		os.Exit(1)
+	}
+
+	checkpointer, err := modelcheckpointcontroller.NewKubeletCheckpointer(nodeIP, options.kubeletPort,
+		options.kubeletCAFile, options.kubeletInsecureTLS, options.tokenFile)
+	if err != nil {
+		setupLog.Error(err, "unable to set up the kubelet client")
+		os.Exit(1)
+	}
+
+	// Setup ModelCheckpoint controller
+	eventBroadcaster := record.NewBroadcaster()
+	setupLog.Info("Setting up v1alpha1 ModelCheckpoint controller")
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+	if err = (&modelcheckpointcontroller.ModelCheckpointReconciler{
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName("v1alpha1Controllers").WithName("ModelCheckpoint"),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "CheckpointAgent", Host: nodeName}),
+		NodeName:     nodeName,
+		Checkpointer: checkpointer,
+		Pusher:       modelcheckpointcontroller.NewRegistryPusher(options.insecureRegistry),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "ModelCheckpoint")
+		os.Exit(1)
+	}
+
+	// Start the Cmd
+	setupLog.Info("Starting the Cmd.")
+	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "unable to run the manager")
+		os.Exit(1)
+	}
+}
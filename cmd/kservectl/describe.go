@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// failureHints explain how to resolve the last failure of the model of the predictor
+var failureHints = map[v1beta1.FailureReason]string{
+	v1beta1.ModelLoadFailed:      "the model server failed to load the model, check the storage URI and the logs of the predictor",
+	v1beta1.RuntimeUnhealthy:     "the containers of the runtime failed to start or are unhealthy, check the logs of the predictor",
+	v1beta1.RuntimeDisabled:      "the selected ServingRuntime is disabled, enable it or select another runtime",
+	v1beta1.NoSupportingRuntime:  "no ServingRuntime supports the model format, check the model format and its version",
+	v1beta1.RuntimeNotRecognized: "the ServingRuntime of the predictor does not exist in the namespace or in the cluster",
+	v1beta1.InvalidPredictorSpec: "the predictor spec is invalid or not supported by the runtime",
+}
+
+// components are the components of the InferenceServices, in the order they receive the requests
+var components = []v1beta1.ComponentType{
+	v1beta1.TransformerComponent,
+	v1beta1.PredictorComponent,
+	v1beta1.ExplainerComponent,
+}
+
+func newListCommand(o *options) *cobra.Command {
+	allNamespaces := false
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the InferenceServices with their URL and readiness",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.list(cmd.Context(), allNamespaces)
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the InferenceServices of all the namespaces")
+	return cmd
+}
+
+func (o *options) list(ctx context.Context, allNamespaces bool) error {
+	isvcs := &v1beta1.InferenceServiceList{}
+	listOptions := []client.ListOption{}
+	if !allNamespaces {
+		listOptions = append(listOptions, client.InNamespace(o.namespace))
+	}
+	if err := o.client.List(ctx, isvcs, listOptions...); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 3, ' ', 0)
+	if allNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tURL\tREADY\tREASON\tAGE")
+	for _, isvc := range isvcs.Items {
+		if allNamespaces {
+			fmt.Fprintf(w, "%s\t", isvc.Namespace)
+		}
+		ready, reason := readiness(&isvc)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", isvc.Name, urlOrNone(isvc.Status.URL), ready, reason, age(isvc.CreationTimestamp))
+	}
+	return w.Flush()
+}
+
+func newDescribeCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Describe an InferenceService, its components and why it is not ready",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isvc, err := o.getInferenceService(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return describe(o.out, isvc)
+		},
+	}
+}
+
+func (o *options) getInferenceService(ctx context.Context, name string) (*v1beta1.InferenceService, error) {
+	isvc := &v1beta1.InferenceService{}
+	if err := o.client.Get(ctx, types.NamespacedName{Namespace: o.namespace, Name: name}, isvc); err != nil {
+		return nil, err
+	}
+	return isvc, nil
+}
+
+func describe(out io.Writer, isvc *v1beta1.InferenceService) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", isvc.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", isvc.Namespace)
+	fmt.Fprintf(w, "URL:\t%s\n", urlOrNone(isvc.Status.URL))
+	if isvc.Status.Address != nil {
+		fmt.Fprintf(w, "Address:\t%s\n", urlOrNone(isvc.Status.Address.URL))
+	}
+	if isvc.Status.DeploymentMode != "" {
+		fmt.Fprintf(w, "Deployment Mode:\t%s\n", isvc.Status.DeploymentMode)
+	}
+	if runtime := runtimeName(isvc); runtime != "" {
+		fmt.Fprintf(w, "Runtime:\t%s\n", runtime)
+	}
+	ready, reason := readiness(isvc)
+	fmt.Fprintf(w, "Ready:\t%s\t%s\n", ready, reason)
+
+	for _, component := range components {
+		status, ok := isvc.Status.Components[component]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", strings.ToUpper(string(component[:1]))+string(component[1:]))
+		fmt.Fprintf(w, "  URL:\t%s\n", urlOrNone(status.URL))
+		if status.LatestRolledoutRevision != "" {
+			fmt.Fprintf(w, "  Latest Rolled Out Revision:\t%s\n", status.LatestRolledoutRevision)
+		}
+		if status.PreviousRolledoutRevision != "" {
+			fmt.Fprintf(w, "  Previous Rolled Out Revision:\t%s\n", status.PreviousRolledoutRevision)
+		}
+		for _, target := range status.Traffic {
+			percent := int64(0)
+			if target.Percent != nil {
+				percent = *target.Percent
+			}
+			line := fmt.Sprintf("  Traffic:\t%d%% %s", percent, target.RevisionName)
+			if target.Tag != "" {
+				line += fmt.Sprintf(" (tag %s)", target.Tag)
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	if len(isvc.Status.Conditions) > 0 {
+		fmt.Fprintln(w, "Conditions:")
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, condition := range isvc.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+
+	if explanations := explain(isvc); len(explanations) > 0 {
+		fmt.Fprintln(w, "Explanation:")
+		for _, explanation := range explanations {
+			fmt.Fprintf(w, "  - %s\n", explanation)
+		}
+	}
+	return w.Flush()
+}
+
+// readiness returns the status of the Ready condition and the reason it is not true
+func readiness(isvc *v1beta1.InferenceService) (corev1.ConditionStatus, string) {
+	if isvc.Status.IsConditionReady(v1beta1.Stopped) {
+		return corev1.ConditionFalse, v1beta1.StoppedISVCReason
+	}
+	condition := isvc.Status.GetCondition(apis.ConditionReady)
+	if condition == nil {
+		return corev1.ConditionUnknown, ""
+	}
+	if condition.IsTrue() {
+		return corev1.ConditionTrue, ""
+	}
+	return condition.Status, condition.Reason
+}
+
+// explain describes why the InferenceService is not ready, and how to resolve it
+func explain(isvc *v1beta1.InferenceService) []string {
+	explanations := []string{}
+	if isvc.Status.IsConditionReady(v1beta1.Stopped) {
+		return append(explanations, fmt.Sprintf("the InferenceService is stopped, remove the %s annotation or set it "+
+			"to false to start it", constants.StopAnnotationKey))
+	}
+	if failure := isvc.Status.ModelStatus.LastFailureInfo; failure != nil {
+		explanation := string(failure.Reason)
+		if hint, ok := failureHints[failure.Reason]; ok {
+			explanation = hint
+		}
+		if failure.Message != "" {
+			explanation += ": " + failure.Message
+		}
+		if failure.Location != "" {
+			explanation += fmt.Sprintf(" (in %s)", failure.Location)
+		}
+		if failure.ExitCode != 0 {
+			explanation += fmt.Sprintf(", the container exited with the code %d", failure.ExitCode)
+		}
+		explanations = append(explanations, explanation)
+	}
+	for _, condition := range isvc.Status.Conditions {
+		if condition.IsTrue() || condition.Type == apis.ConditionReady || condition.Type == v1beta1.Stopped {
+			continue
+		}
+		// the readiness of the components is aggregated in the conditions of their routes and their revisions
+		if slices.Contains([]apis.ConditionType{v1beta1.RoutesReady, v1beta1.LatestDeploymentReady}, condition.Type) {
+			continue
+		}
+		explanation := fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			explanation += fmt.Sprintf(" because of %s", condition.Reason)
+		}
+		if condition.Message != "" {
+			explanation += ": " + condition.Message
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+// runtimeName is the ServingRuntime or the ClusterServingRuntime selected for the predictor
+func runtimeName(isvc *v1beta1.InferenceService) string {
+	switch {
+	case isvc.Status.ServingRuntimeName != "":
+		return isvc.Status.ServingRuntimeName
+	case isvc.Status.ClusterServingRuntimeName != "":
+		return isvc.Status.ClusterServingRuntimeName + " (cluster)"
+	case isvc.Spec.Predictor.Model != nil && isvc.Spec.Predictor.Model.Runtime != nil:
+		return *isvc.Spec.Predictor.Model.Runtime
+	}
+	return ""
+}
+
+func age(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(timestamp.Time))
+}
+
+func urlOrNone(url *apis.URL) string {
+	if url == nil {
+		return "<none>"
+	}
+	return url.String()
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// protocolOpenAI is the OpenAI compatible API served by the generative runtimes, e.g. huggingface and vLLM
+	protocolOpenAI      constants.InferenceServiceProtocol = "openai"
+	openAIChatPath                                         = "/openai/v1/chat/completions"
+	defaultOpenAIPrompt                                    = "Hello!"
+)
+
+type inferOptions struct {
+	protocol string
+	path     string
+	data     string
+	file     string
+	model    string
+	url      string
+	token    string
+	insecure bool
+	timeout  time.Duration
+}
+
+func newInferCommand(o *options) *cobra.Command {
+	infer := &inferOptions{}
+	cmd := &cobra.Command{
+		Use:   "infer NAME",
+		Short: "Send a test inference request to an InferenceService",
+		Long: "Send a test inference request to an InferenceService with the v1, the v2 or the OpenAI protocol. " +
+			"The request is sent to the URL of the InferenceService, or to the URL of the ingress gateway with the " +
+			"--url flag, e.g. when it is port-forwarded, in which case the host of the InferenceService is set in " +
+			"the Host header.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isvc, err := o.getInferenceService(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			req, err := infer.request(cmd.Context(), isvc, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return infer.send(req, o.out)
+		},
+	}
+	cmd.Flags().StringVarP(&infer.protocol, "protocol", "p", "",
+		"The protocol of the request: v1, v2 or openai, defaults to the protocol of the predictor")
+	cmd.Flags().StringVar(&infer.path, "path", "", "The path of the request, defaults to the inference path of the protocol")
+	cmd.Flags().StringVarP(&infer.data, "data", "d", "", "The body of the request")
+	cmd.Flags().StringVarP(&infer.file, "file", "f", "", "The file containing the body of the request, - for the standard input")
+	cmd.Flags().StringVar(&infer.model, "model", "", "The name of the model, defaults to the name of the InferenceService")
+	cmd.Flags().StringVar(&infer.url, "url", "", "The URL the request is sent to instead of the URL of the InferenceService")
+	cmd.Flags().StringVar(&infer.token, "token", "", "The bearer token of the request")
+	cmd.Flags().BoolVar(&infer.insecure, "insecure", false, "Skip the verification of the TLS certificate")
+	cmd.Flags().DurationVar(&infer.timeout, "timeout", time.Minute, "The timeout of the request")
+	return cmd
+}
+
+// request builds the inference request of the InferenceService
+func (i *inferOptions) request(ctx context.Context, isvc *v1beta1.InferenceService, stdin io.Reader) (*http.Request, error) {
+	if isvc.Status.URL == nil {
+		return nil, fmt.Errorf("the InferenceService %q has no URL yet", isvc.Name)
+	}
+	model := i.model
+	if model == "" {
+		model = isvc.Name
+	}
+	protocol := constants.InferenceServiceProtocol(i.protocol)
+	if protocol == constants.ProtocolUnknown {
+		protocol = predictorProtocol(isvc)
+	}
+
+	path := i.path
+	if path == "" {
+		switch protocol {
+		case constants.ProtocolV1, constants.ProtocolV2:
+			path = constants.PredictPath(model, protocol)
+		case protocolOpenAI:
+			path = openAIChatPath
+		default:
+			return nil, fmt.Errorf("the protocol %q is not supported, use v1, v2 or openai", protocol)
+		}
+	}
+
+	body, err := i.body(stdin)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		if protocol != protocolOpenAI {
+			return nil, errors.New("the body of the request is required, set it with --data or --file")
+		}
+		body, err = json.Marshal(map[string]any{
+			"model":    model,
+			"messages": []map[string]string{{"role": "user", "content": defaultOpenAIPrompt}},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	target := isvc.Status.URL.URL()
+	if i.url != "" {
+		if target, err = url.Parse(i.url); err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", i.url, err)
+		}
+	}
+	target = target.JoinPath(path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if i.url != "" {
+		req.Host = isvc.Status.URL.Host
+	}
+	if i.token != "" {
+		req.Header.Set("Authorization", "Bearer "+i.token)
+	}
+	return req, nil
+}
+
+// body is the body of the request set with the flags, nil when it is not set
+func (i *inferOptions) body(stdin io.Reader) ([]byte, error) {
+	switch {
+	case i.data != "" && i.file != "":
+		return nil, errors.New("only one of --data and --file can be set")
+	case i.data != "":
+		return []byte(i.data), nil
+	case i.file == "-":
+		return io.ReadAll(stdin)
+	case i.file != "":
+		return os.ReadFile(i.file)
+	}
+	return nil, nil
+}
+
+func (i *inferOptions) send(req *http.Request, out io.Writer) error {
+	httpClient := &http.Client{Timeout: i.timeout}
+	if i.insecure {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the request failed with the status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, err = fmt.Fprintln(out, strings.TrimSpace(string(body)))
+	return err
+}
+
+// predictorProtocol is the REST protocol of the predictor, the gRPC predictors are sent v2 REST requests through the
+// transcoding of the agent
+func predictorProtocol(isvc *v1beta1.InferenceService) constants.InferenceServiceProtocol {
+	if len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+		return constants.ProtocolV1
+	}
+	switch protocol := isvc.Spec.Predictor.GetImplementation().GetProtocol(); protocol {
+	case constants.ProtocolV2, constants.ProtocolGRPCV2:
+		return constants.ProtocolV2
+	default:
+		return constants.ProtocolV1
+	}
+}
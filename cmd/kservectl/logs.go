@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+type logsOptions struct {
+	component string
+	container string
+	follow    bool
+	tail      int64
+}
+
+func newLogsCommand(o *options) *cobra.Command {
+	logs := &logsOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs NAME",
+		Short: "Print the logs of the pods of a component of an InferenceService",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.logs(cmd.Context(), args[0], logs)
+		},
+	}
+	cmd.Flags().StringVar(&logs.component, "component", string(v1beta1.PredictorComponent),
+		"The component of the InferenceService: predictor, transformer or explainer")
+	cmd.Flags().StringVarP(&logs.container, "container", "c", constants.InferenceServiceContainerName,
+		"The container of the pods")
+	cmd.Flags().BoolVarP(&logs.follow, "follow", "f", false, "Stream the logs")
+	cmd.Flags().Int64Var(&logs.tail, "tail", -1, "The number of recent lines to print, all the lines when negative")
+	return cmd
+}
+
+func (o *options) logs(ctx context.Context, name string, logs *logsOptions) error {
+	selector := labels.SelectorFromSet(labels.Set{
+		constants.InferenceServicePodLabelKey: name,
+		constants.KServiceComponentLabel:      logs.component,
+	})
+	pods, err := o.clientset.CoreV1().Pods(o.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no pods found for the %s of the InferenceService %q", logs.component, name)
+	}
+
+	podLogOptions := &corev1.PodLogOptions{Container: logs.container, Follow: logs.follow}
+	if logs.tail >= 0 {
+		podLogOptions.TailLines = &logs.tail
+	}
+	// the lines of the pods are prefixed with their name when the logs of several pods are printed
+	prefix := len(pods.Items) > 1
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make([]error, len(pods.Items))
+	)
+	for i, pod := range pods.Items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := o.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, podLogOptions).Stream(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get the logs of the pod %s: %w", pod.Name, err)
+				return
+			}
+			defer stream.Close()
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				mu.Lock()
+				if prefix {
+					fmt.Fprintf(o.out, "[%s] ", pod.Name)
+				}
+				fmt.Fprintln(o.out, scanner.Text())
+				mu.Unlock()
+			}
+			errs[i] = scanner.Err()
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kservectl is the command line client of the InferenceServices. It is built from the libraries of the controller,
+// so that the URLs, the statuses and the rendered objects it shows are the ones the controller computes.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(knservingv1.AddToScheme(scheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	utilruntime.Must(otelv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gwapiv1.Install(scheme))
+}
+
+// options are the global flags of the commands, and the clients they build from the kubeconfig
+type options struct {
+	kubeconfig string
+	context    string
+	namespace  string
+
+	out       io.Writer
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+// clientConfig loads the kubeconfig, from the flag, the KUBECONFIG environment variable or the home directory
+func (o *options) clientConfig() clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+}
+
+// complete builds the clients and resolves the namespace, the clients already set, e.g. by the tests, are kept
+func (o *options) complete() error {
+	config := o.clientConfig()
+	if o.namespace == "" {
+		namespace, _, err := config.Namespace()
+		if err != nil {
+			return err
+		}
+		o.namespace = namespace
+	}
+	if o.client != nil && o.clientset != nil {
+		return nil
+	}
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	if o.client, err = client.New(restConfig, client.Options{Scheme: scheme}); err != nil {
+		return err
+	}
+	if o.clientset, err = kubernetes.NewForConfig(restConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newRootCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "kservectl",
+		Short:         "kservectl manages the KServe InferenceServices",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			o.out = cmd.OutOrStdout()
+			return o.complete()
+		},
+	}
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.PersistentFlags().StringVar(&o.context, "context", "", "The kubeconfig context to use")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the InferenceServices")
+
+	cmd.AddCommand(
+		newListCommand(o),
+		newDescribeCommand(o),
+		newLogsCommand(o),
+		newInferCommand(o),
+		newRollbackCommand(o),
+		newRenderCommand(o),
+	)
+	return cmd
+}
+
+func main() {
+	if err := newRootCommand(&options{}).Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newInferenceService(name string, deploymentMode constants.DeploymentModeType) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "sklearn"},
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						ProtocolVersion: ptr.To(constants.ProtocolV2),
+					},
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			URL:            apis.HTTP(name + "-default.example.com"),
+			DeploymentMode: string(deploymentMode),
+		},
+	}
+}
+
+// run runs kservectl with the given objects in the cluster and returns its output
+func run(t *testing.T, objects []client.Object, args ...string) (string, client.Client, error) {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(objects...).Build()
+	out := &bytes.Buffer{}
+	cmd := newRootCommand(&options{client: c, clientset: k8sfake.NewSimpleClientset()})
+	cmd.SetArgs(append(args, "--namespace", "default"))
+	cmd.SetOut(out)
+	err := cmd.Execute()
+	return out.String(), c, err
+}
+
+func TestList(t *testing.T) {
+	ready := newInferenceService("ready", constants.Standard)
+	ready.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	failed := newInferenceService("failed", constants.Standard)
+	failed.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionFalse, Reason: "RevisionFailed"}}
+
+	out, _, err := run(t, []client.Object{ready, failed}, "list")
+	require.NoError(t, err)
+	assert.Regexp(t, `NAME\s+URL\s+READY\s+REASON\s+AGE`, out)
+	assert.Regexp(t, `failed\s+http://failed-default.example.com\s+False\s+RevisionFailed`, out)
+	assert.Regexp(t, `ready\s+http://ready-default.example.com\s+True`, out)
+}
+
+func TestDescribe(t *testing.T) {
+	isvc := newInferenceService("sklearn", constants.Knative)
+	isvc.Status.ServingRuntimeName = "kserve-sklearnserver"
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {
+			URL:                       apis.HTTP("sklearn-predictor-default.example.com"),
+			LatestRolledoutRevision:   "sklearn-predictor-00002",
+			PreviousRolledoutRevision: "sklearn-predictor-00001",
+			Traffic: []knservingv1.TrafficTarget{
+				{RevisionName: "sklearn-predictor-00002", Percent: ptr.To[int64](10), Tag: "latest"},
+				{RevisionName: "sklearn-predictor-00001", Percent: ptr.To[int64](90), Tag: "prev"},
+			},
+		},
+	}
+	isvc.Status.SetCondition(apis.ConditionReady, &apis.Condition{Status: corev1.ConditionFalse, Reason: "RevisionFailed"})
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{
+		Status:  corev1.ConditionFalse,
+		Reason:  "RevisionFailed",
+		Message: "Revision \"sklearn-predictor-00002\" failed with message: Container failed with: OOMKilled.",
+	})
+	isvc.Status.ModelStatus.LastFailureInfo = &v1beta1.FailureInfo{
+		Reason:   v1beta1.ModelLoadFailed,
+		Message:  "the model file is missing",
+		Location: "sklearn-predictor-00002-deployment-5d8f7",
+		ExitCode: 1,
+	}
+
+	out, _, err := run(t, []client.Object{isvc}, "describe", "sklearn")
+	require.NoError(t, err)
+	assert.Regexp(t, `Deployment Mode:\s+Knative`, out)
+	assert.Regexp(t, `Runtime:\s+kserve-sklearnserver`, out)
+	assert.Regexp(t, `Ready:\s+False\s+RevisionFailed`, out)
+	assert.Regexp(t, `Previous Rolled Out Revision:\s+sklearn-predictor-00001`, out)
+	assert.Regexp(t, `Traffic:\s+90% sklearn-predictor-00001 \(tag prev\)`, out)
+	assert.Contains(t, out, "- the model server failed to load the model, check the storage URI and the logs of the "+
+		"predictor: the model file is missing (in sklearn-predictor-00002-deployment-5d8f7), the container exited "+
+		"with the code 1")
+	assert.Contains(t, out, "- PredictorReady is False because of RevisionFailed: Revision")
+
+	stopped := newInferenceService("stopped", constants.Standard)
+	stopped.Status.SetCondition(v1beta1.Stopped, &apis.Condition{Status: corev1.ConditionTrue})
+	out, _, err = run(t, []client.Object{stopped}, "describe", "stopped")
+	require.NoError(t, err)
+	assert.Regexp(t, `Ready:\s+False\s+Stopped`, out)
+	assert.Contains(t, out, "the InferenceService is stopped")
+
+	_, _, err = run(t, nil, "describe", "missing")
+	require.Error(t, err)
+}
+
+func TestRollback(t *testing.T) {
+	isvc := newInferenceService("sklearn", constants.Knative)
+	isvc.Spec.Predictor.CanaryTrafficPercent = ptr.To[int64](10)
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {PreviousRolledoutRevision: "sklearn-predictor-00001"},
+	}
+	out, c, err := run(t, []client.Object{isvc}, "rollback", "sklearn")
+	require.NoError(t, err)
+	assert.Contains(t, out, "rolled back to the revision sklearn-predictor-00001")
+	updated := &v1beta1.InferenceService{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "sklearn"}, updated))
+	assert.Equal(t, ptr.To[int64](0), updated.Spec.Predictor.CanaryTrafficPercent)
+
+	_, _, err = run(t, []client.Object{isvc}, "rollback", "sklearn", "--component", "transformer")
+	require.EqualError(t, err, `the transformer of the InferenceService "sklearn" has no previous rolled out revision`)
+
+	standard := newInferenceService("standard", constants.Standard)
+	_, _, err = run(t, []client.Object{standard}, "rollback", "standard")
+	require.ErrorContains(t, err, "only the revisions of the Knative mode can be rolled back")
+}
+
+func TestInfer(t *testing.T) {
+	var host, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		host, path, body = r.Host, r.URL.Path, string(content)
+		_, _ = w.Write([]byte(`{"outputs": []}`))
+	}))
+	defer server.Close()
+	isvc := newInferenceService("sklearn", constants.Standard)
+	isvc.Status.Address = &duckv1.Addressable{URL: apis.HTTP("sklearn.default.svc.cluster.local")}
+
+	out, _, err := run(t, []client.Object{isvc}, "infer", "sklearn", "--url", server.URL, "-d", `{"inputs": []}`)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"outputs\": []}\n", out)
+	assert.Equal(t, "sklearn-default.example.com", host)
+	assert.Equal(t, "/v2/models/sklearn/infer", path)
+	assert.JSONEq(t, `{"inputs": []}`, body)
+
+	_, _, err = run(t, []client.Object{isvc}, "infer", "sklearn", "--url", server.URL, "-p", "openai", "--model", "llm")
+	require.NoError(t, err)
+	assert.Equal(t, "/openai/v1/chat/completions", path)
+	assert.JSONEq(t, `{"model": "llm", "messages": [{"role": "user", "content": "Hello!"}]}`, body)
+
+	_, _, err = run(t, []client.Object{isvc}, "infer", "sklearn", "--url", server.URL, "-p", "v1")
+	require.EqualError(t, err, "the body of the request is required, set it with --data or --file")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/preview"
+)
+
+func newRenderCommand(o *options) *cobra.Command {
+	file := ""
+	cmd := &cobra.Command{
+		Use:   "render -f FILE",
+		Short: "Render the objects the controller would create for an InferenceService manifest",
+		Long: "Default, validate and render an InferenceService manifest with the runtimes and the configuration of " +
+			"the cluster, as the webhooks and the controller would, without creating anything.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := readManifest(file, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return o.render(cmd.Context(), manifest)
+		},
+	}
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "The InferenceService manifest, - for the standard input")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func readManifest(file string, stdin io.Reader) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(file)
+}
+
+func (o *options) render(ctx context.Context, manifest []byte) error {
+	isvc := &v1beta1.InferenceService{}
+	if err := yaml.Unmarshal(manifest, isvc); err != nil {
+		return fmt.Errorf("invalid InferenceService manifest: %w", err)
+	}
+	if isvc.Namespace == "" {
+		isvc.Namespace = o.namespace
+	}
+
+	// the clients use the credentials of the user, the lookups of the runtimes and of the configuration are
+	// authorized by the API server
+	previewer := &preview.Previewer{
+		Client:    o.client,
+		Clientset: o.clientset,
+		Scheme:    scheme,
+		Defaulter: &v1beta1.InferenceServiceDefaulter{Client: o.client},
+		Validator: &v1beta1.InferenceServiceValidator{Client: o.client, Clientset: o.clientset},
+	}
+	result := previewer.Preview(ctx, isvc)
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", warning)
+	}
+	if !result.Allowed {
+		return fmt.Errorf("the InferenceService is not admitted: %s", result.Error)
+	}
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+
+	for _, obj := range result.Objects {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(o.out, "---\n%s", content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
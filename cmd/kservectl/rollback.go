@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newRollbackCommand(o *options) *cobra.Command {
+	component := string(v1beta1.PredictorComponent)
+	cmd := &cobra.Command{
+		Use:   "rollback NAME",
+		Short: "Route the traffic of a component back to its previous rolled out revision",
+		Long: "Route the traffic of a component back to its previous rolled out revision by setting its canary " +
+			"traffic percent to 0. The revisions are only kept in the Knative deployment mode.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.rollback(cmd.Context(), args[0], v1beta1.ComponentType(component))
+		},
+	}
+	cmd.Flags().StringVar(&component, "component", component,
+		"The component of the InferenceService: predictor, transformer or explainer")
+	return cmd
+}
+
+func (o *options) rollback(ctx context.Context, name string, component v1beta1.ComponentType) error {
+	if !slices.Contains(components, component) {
+		return fmt.Errorf("unknown component %q", component)
+	}
+	isvc, err := o.getInferenceService(ctx, name)
+	if err != nil {
+		return err
+	}
+	deploymentMode := constants.DeploymentModeType(isvc.Status.DeploymentMode)
+	if deploymentMode != constants.Knative && deploymentMode != constants.LegacyServerless {
+		return fmt.Errorf("the InferenceService %q is deployed in the %s mode, only the revisions of the %s mode "+
+			"can be rolled back", name, deploymentMode, constants.Knative)
+	}
+	status, ok := isvc.Status.Components[component]
+	if !ok || status.PreviousRolledoutRevision == "" {
+		return fmt.Errorf("the %s of the InferenceService %q has no previous rolled out revision", component, name)
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			string(component): map[string]any{"canaryTrafficPercent": 0},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := o.client.Patch(ctx, isvc, client.RawPatch(client.Merge.Type(), patch)); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.out, "The traffic of the %s of the InferenceService %q is rolled back to the revision %s\n",
+		component, name, status.PreviousRolledoutRevision)
+	return err
+}
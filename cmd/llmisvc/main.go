@@ -40,6 +40,7 @@ import (
 	llmisvcvalidation "github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc/validation"
 
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 )
 
 var (
@@ -61,6 +62,7 @@ type Options struct {
 	metricsSecure        bool
 	enableHTTP2          bool
 	zapOpts              zap.Options
+	tlsOpts              tlspolicy.Options
 }
 
 func DefaultOptions() Options {
@@ -86,6 +88,7 @@ func GetOptions() Options {
 	flag.StringVar(&opts.probeAddr, "health-probe-addr", opts.probeAddr, "The address the probe endpoint binds to.")
 	flag.BoolVar(&opts.metricsSecure, "metrics-secure", opts.metricsSecure, "Whether to serve metric via HTTPS.")
 	flag.BoolVar(&opts.enableHTTP2, "enable-http2", false, "If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	opts.tlsOpts.BindFlags(flag.CommandLine)
 	opts.zapOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	return opts
@@ -120,7 +123,12 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	var tlsOpts []func(*tls.Config)
+	tlsPolicy, err := tlspolicy.New(options.tlsOpts)
+	if err != nil {
+		setupLog.Error(err, "invalid TLS policy")
+		os.Exit(1)
+	}
+	tlsOpts := []func(*tls.Config){tlsPolicy.Apply}
 	if !options.enableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
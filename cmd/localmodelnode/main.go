@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -35,6 +36,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
 	localmodelnodecontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/localmodelnode"
 )
 
@@ -132,15 +134,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Serve the local models to the peer nodes when peer distribution is enabled
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(context.Background(), clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
+		os.Exit(1)
+	}
+	localModelConfig, err := v1beta1.NewLocalModelConfig(isvcConfigMap)
+	if err != nil {
+		setupLog.Error(err, "unable to get local model config")
+		os.Exit(1)
+	}
+	var peerDistributor *localmodelnodecontroller.PeerDistributor
+	if localModelConfig.PeerDistribution != nil && localModelConfig.PeerDistribution.Enabled {
+		setupLog.Info("Setting up local model peer distribution")
+		peerDistributor = localmodelnodecontroller.NewPeerDistributor(clientSet, localModelConfig.PeerDistribution.Port)
+		if err := mgr.Add(peerDistributor); err != nil {
+			setupLog.Error(err, "unable to set up local model peer distribution")
+			os.Exit(1)
+		}
+	}
+
 	// Setup LocalModelNode controller
 	localModelNodeEventBroadcaster := record.NewBroadcaster()
 	setupLog.Info("Setting up v1alpha1 LocalModelNode controller")
 	localModelNodeEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&localmodelnodecontroller.LocalModelNodeReconciler{
-		Client:    mgr.GetClient(),
-		Clientset: clientSet,
-		Log:       ctrl.Log.WithName("v1alpha1Controllers").WithName("LocalModelNode"),
-		Scheme:    mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Clientset:       clientSet,
+		Log:             ctrl.Log.WithName("v1alpha1Controllers").WithName("LocalModelNode"),
+		Scheme:          mgr.GetScheme(),
+		PeerDistributor: peerDistributor,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "LocalModelNode")
 		os.Exit(1)
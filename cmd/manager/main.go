@@ -18,21 +18,29 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"net/http"
 	"os"
+	"time"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	istio_networking "istio.io/api/networking/v1alpha3"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -46,13 +54,24 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	graphcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph"
+	personarolecontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/personarole"
+	podmutatorcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/podmutator"
+	servingruntimecontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/servingruntime"
+	catalogcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/servingruntimecatalog"
 	trainedmodelcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
+	warmpoolcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/warmpool"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/configwatcher"
 	v1beta1controller "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/preview"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 	"github.com/kserve/kserve/pkg/utils"
+	"github.com/kserve/kserve/pkg/webhook/admission/gpucapability"
 	"github.com/kserve/kserve/pkg/webhook/admission/localmodelcache"
 	"github.com/kserve/kserve/pkg/webhook/admission/pod"
+	"github.com/kserve/kserve/pkg/webhook/admission/servingquota"
 	"github.com/kserve/kserve/pkg/webhook/admission/servingruntime"
+	"github.com/kserve/kserve/pkg/webhook/admission/trainedmodel"
 )
 
 var setupLog = ctrl.Log.WithName("setup")
@@ -63,21 +82,29 @@ const (
 
 // Options defines the program configurable options that may be passed on the command line.
 type Options struct {
-	metricsAddr          string
-	webhookPort          int
-	enableLeaderElection bool
-	probeAddr            string
-	zapOpts              zap.Options
+	metricsAddr             string
+	webhookPort             int
+	enableLeaderElection    bool
+	probeAddr               string
+	zapOpts                 zap.Options
+	modelConfigBatchWindow  time.Duration
+	trainedModelConcurrency int
+	enablePersonaRoles      bool
+	tlsOpts                 tlspolicy.Options
 }
 
 // DefaultOptions returns the default values for the program options.
 func DefaultOptions() Options {
 	return Options{
-		metricsAddr:          ":8080",
-		webhookPort:          9443,
-		enableLeaderElection: false,
-		probeAddr:            ":8081",
-		zapOpts:              zap.Options{},
+		metricsAddr:             ":8080",
+		webhookPort:             9443,
+		enableLeaderElection:    false,
+		probeAddr:               ":8081",
+		zapOpts:                 zap.Options{},
+		modelConfigBatchWindow:  time.Second,
+		trainedModelConcurrency: 10,
+		enablePersonaRoles:      true,
+		tlsOpts:                 tlspolicy.Options{MinVersion: tlspolicy.DefaultMinVersion},
 	}
 }
 
@@ -90,6 +117,14 @@ func GetOptions() Options {
 		"Enable leader election for kserve controller manager. "+
 			"Enabling this will ensure there is only one active kserve controller manager.")
 	flag.StringVar(&opts.probeAddr, "health-probe-addr", opts.probeAddr, "The address the probe endpoint binds to.")
+	flag.DurationVar(&opts.modelConfigBatchWindow, "modelconfig-batch-window", opts.modelConfigBatchWindow,
+		"The window within which the TrainedModel changes of a model config are coalesced into a single update. "+
+			"Set to 0 to update the model config for each TrainedModel.")
+	flag.IntVar(&opts.trainedModelConcurrency, "trainedmodel-concurrency", opts.trainedModelConcurrency,
+		"The number of TrainedModels reconciled concurrently.")
+	flag.BoolVar(&opts.enablePersonaRoles, "enable-persona-roles", opts.enablePersonaRoles,
+		"Manage the kserve-viewer, kserve-editor and kserve-runtime-admin ClusterRoles from the KServe CRDs installed.")
+	opts.tlsOpts.BindFlags(flag.CommandLine)
 	opts.zapOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	return opts
@@ -104,6 +139,12 @@ func init() {
 func main() {
 	options := GetOptions()
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&options.zapOpts)))
+	tlsPolicy, err := tlspolicy.New(options.tlsOpts)
+	if err != nil {
+		setupLog.Error(err, "invalid TLS policy")
+		os.Exit(1)
+	}
+	setupLog.Info("Enforcing the TLS policy", "minVersion", options.tlsOpts.MinVersion, "fips", tlsPolicy.FIPS())
 
 	// Get a config to talk to the apiserver
 	setupLog.Info("Setting up client for manager")
@@ -120,14 +161,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The controllers only read the pods of the InferenceServices, including the warm pods which keep the label,
+	// so the other pods of the cluster are not cached. The same goes for the secrets, only the serving certificates
+	// of the predictors are labeled with their InferenceService, and for the leases of the activators, which are not
+	// mixed up with the leases of the nodes.
+	isvcPodRequirement, err := labels.NewRequirement(constants.InferenceServicePodLabelKey, selection.Exists, nil)
+	if err != nil {
+		setupLog.Error(err, "unable to create the pod label selector")
+		os.Exit(1)
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
 	setupLog.Info("Setting up manager")
 	mgr, err := manager.New(cfg, manager.Options{
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Pod{}:           {Label: labels.NewSelector().Add(*isvcPodRequirement)},
+				&corev1.Secret{}:        {Label: labels.NewSelector().Add(*isvcPodRequirement)},
+				&coordinationv1.Lease{}: {Label: labels.NewSelector().Add(*isvcPodRequirement)},
+			},
+		},
 		Metrics: metricsserver.Options{
 			BindAddress: options.metricsAddr,
 		},
 		WebhookServer: webhook.NewServer(webhook.Options{
-			Port: options.webhookPort,
+			Port:    options.webhookPort,
+			TLSOpts: []func(*tls.Config){tlsPolicy.Apply},
 		}),
 		LeaderElection:         options.enableLeaderElection,
 		LeaderElectionID:       LeaderLockName,
@@ -244,6 +303,28 @@ func main() {
 	setupLog.Info("Setting up v1beta1 controller")
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+
+	// Reload the configuration when the ConfigMap changes instead of restarting the manager
+	setupLog.Info("Setting up config watcher")
+	configWatcher := configwatcher.NewConfigWatcher(clientSet,
+		eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "ConfigWatcher"}), isvcConfigMap)
+	if err := mgr.Add(configWatcher); err != nil {
+		setupLog.Error(err, "unable to set up config watcher")
+		os.Exit(1)
+	}
+
+	// The inferenceservice-config ConfigMaps of the namespaces are cached for the namespace overrides
+	setupLog.Info("Setting up namespace config cache")
+	namespaceConfigCache, err := configwatcher.NewNamespaceConfigCache(cfg, mgr.GetScheme(), mgr.GetRESTMapper())
+	if err != nil {
+		setupLog.Error(err, "unable to create namespace config cache")
+		os.Exit(1)
+	}
+	if err := mgr.Add(namespaceConfigCache); err != nil {
+		setupLog.Error(err, "unable to set up namespace config cache")
+		os.Exit(1)
+	}
+
 	if err = (&v1beta1controller.InferenceServiceReconciler{
 		Client:    mgr.GetClient(),
 		Clientset: clientSet,
@@ -251,6 +332,8 @@ func main() {
 		Scheme:    mgr.GetScheme(),
 		Recorder: eventBroadcaster.NewRecorder(
 			mgr.GetScheme(), corev1.EventSource{Component: "v1beta1Controllers"}),
+		ConfigReloads:       configWatcher.Subscribe(),
+		NamespaceConfigMaps: namespaceConfigCache,
 	}).SetupWithManager(mgr, deployConfig, ingressConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1beta1Controller", "InferenceService")
 		os.Exit(1)
@@ -261,11 +344,13 @@ func main() {
 	setupLog.Info("Setting up v1beta1 TrainedModel controller")
 	trainedModelEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&trainedmodelcontroller.TrainedModelReconciler{
-		Client:                mgr.GetClient(),
-		Log:                   ctrl.Log.WithName("v1beta1Controllers").WithName("TrainedModel"),
-		Scheme:                mgr.GetScheme(),
-		Recorder:              eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "v1beta1Controllers"}),
-		ModelConfigReconciler: modelconfig.NewModelConfigReconciler(mgr.GetClient(), clientSet, mgr.GetScheme()),
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1beta1Controllers").WithName("TrainedModel"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "v1beta1Controllers"}),
+		ModelConfigReconciler: modelconfig.NewBatchingModelConfigReconciler(mgr.GetClient(), clientSet, mgr.GetScheme(),
+			options.modelConfigBatchWindow),
+		MaxConcurrentReconciles: options.trainedModelConcurrency,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "TrainedModel")
 		os.Exit(1)
@@ -276,22 +361,110 @@ func main() {
 	setupLog.Info("Setting up InferenceGraph controller")
 	inferenceGraphEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&graphcontroller.InferenceGraphReconciler{
-		Client:    mgr.GetClient(),
-		Clientset: clientSet,
-		Log:       ctrl.Log.WithName("v1alpha1Controllers").WithName("InferenceGraph"),
-		Scheme:    mgr.GetScheme(),
-		Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "InferenceGraphController"}),
+		Client:        mgr.GetClient(),
+		Clientset:     clientSet,
+		Log:           ctrl.Log.WithName("v1alpha1Controllers").WithName("InferenceGraph"),
+		Scheme:        mgr.GetScheme(),
+		Recorder:      eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "InferenceGraphController"}),
+		ConfigReloads: configWatcher.Subscribe(),
+		TLSPolicy:     tlsPolicy,
 	}).SetupWithManager(mgr, deployConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "InferenceGraph")
 		os.Exit(1)
 	}
 
+	// Deploy the shared cache of the inference graphs and of the LLM routers while it is enabled
+	setupLog.Info("Setting up shared cache reconciler")
+	if err := mgr.Add(&graphcontroller.SharedCacheReconciler{
+		Client:        mgr.GetClient(),
+		Clientset:     clientSet,
+		ConfigReloads: configWatcher.Subscribe(),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up shared cache reconciler")
+		os.Exit(1)
+	}
+
+	// Setup ServingRuntimeCatalog controller
+	setupLog.Info("Setting up ServingRuntimeCatalog controller")
+	if err = (&catalogcontroller.ServingRuntimeCatalogReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1alpha1Controllers").WithName("ServingRuntimeCatalog"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "ServingRuntimeCatalogController"}),
+		Fetcher:  catalogcontroller.NewHTTPFetcher(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "ServingRuntimeCatalog")
+		os.Exit(1)
+	}
+
+	// Setup WarmPool controller
+	setupLog.Info("Setting up WarmPool controller")
+	if err = (&warmpoolcontroller.WarmPoolReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1alpha1Controllers").WithName("WarmPool"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "WarmPoolController"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "WarmPool")
+		os.Exit(1)
+	}
+
+	// Setup persona role controller
+	if options.enablePersonaRoles {
+		setupLog.Info("Setting up persona role controller")
+		if err := apiextensionsv1.AddToScheme(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "unable to add API extensions APIs to scheme")
+			os.Exit(1)
+		}
+		if err = (&personarolecontroller.PersonaRoleReconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("v1alpha1Controllers").WithName("PersonaRole"),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "PersonaRole")
+			os.Exit(1)
+		}
+	}
+
+	// Setup deprecated ServingRuntime controller
+	setupLog.Info("Setting up deprecated ServingRuntime controller")
+	if err = (&servingruntimecontroller.DeprecatedRuntimeReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("v1alpha1Controllers").WithName("DeprecatedServingRuntime"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "ServingRuntimeController"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "DeprecatedServingRuntime")
+		os.Exit(1)
+	}
+
 	setupLog.Info("setting up webhook server")
 	hookServer := mgr.GetWebhookServer()
 
+	// Setup pod mutator controller
+	setupLog.Info("Setting up pod mutator controller")
+	if err = (&podmutatorcontroller.PodMutatorReconciler{
+		Client:         mgr.GetClient(),
+		Clientset:      clientSet,
+		Log:            ctrl.Log.WithName("v1alpha1Controllers").WithName("PodMutator"),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "PodMutatorController"}),
+		WebhookStarted: hookServer.StartedChecker(),
+		ConfigReloads:  configWatcher.Subscribe(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "v1alpha1Controllers", "PodMutator")
+		os.Exit(1)
+	}
+
 	setupLog.Info("registering webhooks to the webhook server")
 	hookServer.Register("/mutate-pods", &webhook.Admission{
-		Handler: &pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		Handler: &pod.Mutator{
+			Client:              mgr.GetClient(),
+			Clientset:           clientSet,
+			NamespaceConfigMaps: namespaceConfigCache,
+			Decoder:             admission.NewDecoder(mgr.GetScheme()),
+			TLSPolicy:           tlsPolicy,
+		},
 	})
 
 	setupLog.Info("registering cluster serving runtime validator webhook to the webhook server")
@@ -304,9 +477,19 @@ func main() {
 		Handler: &servingruntime.ServingRuntimeValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
 	})
 
+	setupLog.Info("registering inference service quota validator webhook to the webhook server")
+	hookServer.Register("/validate-serving-kserve-io-v1beta1-inferenceservice-quota", &webhook.Admission{
+		Handler: &servingquota.InferenceServiceQuotaValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+
+	setupLog.Info("registering inference service GPU capability validator webhook to the webhook server")
+	hookServer.Register("/validate-serving-kserve-io-v1beta1-inferenceservice-gpu", &webhook.Admission{
+		Handler: &gpucapability.InferenceServiceGPUValidator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
+
 	if err = ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.TrainedModel{}).
-		WithValidator(&v1alpha1.TrainedModelValidator{}).
+		WithValidator(&trainedmodel.TrainedModelValidator{Client: mgr.GetClient()}).
 		Complete(); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "v1alpha1")
 		os.Exit(1)
@@ -320,10 +503,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	setupLog.Info("registering inference service preview endpoint to the webhook server")
+	hookServer.Register(preview.PreviewPath, &preview.Previewer{
+		Client:              mgr.GetClient(),
+		Clientset:           clientSet,
+		NamespaceConfigMaps: namespaceConfigCache,
+		Scheme:              mgr.GetScheme(),
+		Defaulter:           &v1beta1.InferenceServiceDefaulter{Client: mgr.GetClient()},
+		Validator:           &v1beta1.InferenceServiceValidator{Client: mgr.GetClient(), Clientset: clientSet},
+		Handlers: []admission.Handler{
+			&servingquota.InferenceServiceQuotaValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+			&gpucapability.InferenceServiceGPUValidator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		},
+	})
+
+	setupLog.Info("registering autoscaling simulation endpoint to the webhook server")
+	hookServer.Register(preview.SimulationPath, &preview.Simulator{Clientset: clientSet})
+
 	if err = ctrl.NewWebhookManagedBy(mgr).
 		For(&v1beta1.InferenceService{}).
-		WithDefaulter(&v1beta1.InferenceServiceDefaulter{}).
-		WithValidator(&v1beta1.InferenceServiceValidator{}).
+		WithDefaulter(&v1beta1.InferenceServiceDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&v1beta1.InferenceServiceValidator{Client: mgr.GetClient(), Clientset: clientSet}).
 		Complete(); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "v1beta1")
 		os.Exit(1)
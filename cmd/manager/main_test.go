@@ -20,6 +20,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -40,44 +41,60 @@ func TestGetOptions(t *testing.T) {
 			"withWebhookPort",
 			[]string{"-webhook-port=8000"},
 			Options{
-				metricsAddr:          defaults.metricsAddr,
-				webhookPort:          8000,
-				enableLeaderElection: defaults.enableLeaderElection,
-				probeAddr:            defaults.probeAddr,
-				zapOpts:              defaults.zapOpts,
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             8000,
+				enableLeaderElection:    defaults.enableLeaderElection,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
 			"withMetricsAddr",
 			[]string{"-metrics-addr=:9090"},
 			Options{
-				metricsAddr:          ":9090",
-				webhookPort:          defaults.webhookPort,
-				enableLeaderElection: defaults.enableLeaderElection,
-				probeAddr:            defaults.probeAddr,
-				zapOpts:              defaults.zapOpts,
+				metricsAddr:             ":9090",
+				webhookPort:             defaults.webhookPort,
+				enableLeaderElection:    defaults.enableLeaderElection,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
 			"withEnableLeaderElection",
 			[]string{"-leader-elect=true"},
 			Options{
-				metricsAddr:          defaults.metricsAddr,
-				webhookPort:          defaults.webhookPort,
-				enableLeaderElection: true,
-				probeAddr:            defaults.probeAddr,
-				zapOpts:              defaults.zapOpts,
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             defaults.webhookPort,
+				enableLeaderElection:    true,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
 			"withHealthProbeAddr",
 			[]string{"-health-probe-addr=:8090"},
 			Options{
-				metricsAddr:          defaults.metricsAddr,
-				webhookPort:          defaults.webhookPort,
-				enableLeaderElection: defaults.enableLeaderElection,
-				probeAddr:            ":8090",
-				zapOpts:              defaults.zapOpts,
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             defaults.webhookPort,
+				enableLeaderElection:    defaults.enableLeaderElection,
+				probeAddr:               ":8090",
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
@@ -91,17 +108,55 @@ func TestGetOptions(t *testing.T) {
 				zapOpts: zap.Options{
 					Development: true,
 				},
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
 			"withSeveral",
 			[]string{"-webhook-port=8000", "-leader-elect=true"},
 			Options{
-				metricsAddr:          defaults.metricsAddr,
-				webhookPort:          8000,
-				enableLeaderElection: true,
-				probeAddr:            defaults.probeAddr,
-				zapOpts:              defaults.zapOpts,
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             8000,
+				enableLeaderElection:    true,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
+			},
+		},
+		{
+			"withTrainedModelBatching",
+			[]string{"-modelconfig-batch-window=500ms", "-trainedmodel-concurrency=4"},
+			Options{
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             defaults.webhookPort,
+				enableLeaderElection:    defaults.enableLeaderElection,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  500 * time.Millisecond,
+				trainedModelConcurrency: 4,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
+			},
+		},
+		{
+			"withoutPersonaRoles",
+			[]string{"-enable-persona-roles=false"},
+			Options{
+				metricsAddr:             defaults.metricsAddr,
+				webhookPort:             defaults.webhookPort,
+				enableLeaderElection:    defaults.enableLeaderElection,
+				probeAddr:               defaults.probeAddr,
+				zapOpts:                 defaults.zapOpts,
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      false,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 		{
@@ -115,6 +170,10 @@ func TestGetOptions(t *testing.T) {
 				zapOpts: zap.Options{
 					Development: true,
 				},
+				modelConfigBatchWindow:  defaults.modelConfigBatchWindow,
+				trainedModelConcurrency: defaults.trainedModelConcurrency,
+				enablePersonaRoles:      defaults.enablePersonaRoles,
+				tlsOpts:                 defaults.tlsOpts,
 			},
 		},
 	}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+var budgetSkippedSteps = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kserve_inference_graph_budget_skipped_steps_total",
+	Help: "Number of steps of an InferenceGraph Switch node skipped because their latency cost exceeds the request budget",
+}, []string{"node", "step"})
+
+func init() {
+	prometheus.MustRegister(budgetSkippedSteps)
+}
+
+// requestBudget returns the latency budget of the request in milliseconds read from the budget header of the node
+func requestBudget(node v1alpha1.InferenceRouter, headers http.Header) (int64, bool) {
+	if node.BudgetHeader == "" {
+		return 0, false
+	}
+	value := headers.Get(node.BudgetHeader)
+	if value == "" {
+		return 0, false
+	}
+	budget, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || budget < 0 {
+		log.Info("Ignoring the invalid latency budget of the request", "header", node.BudgetHeader, "value", value)
+		return 0, false
+	}
+	return budget, true
+}
+
+// stepsWithinBudget returns the steps of a Switch node whose latency cost does not exceed the budget of the request
+func stepsWithinBudget(nodeName string, budget int64, routes []v1alpha1.InferenceStep) []v1alpha1.InferenceStep {
+	steps := make([]v1alpha1.InferenceStep, 0, len(routes))
+	for _, route := range routes {
+		if route.LatencyCostMs != nil && *route.LatencyCostMs > budget {
+			stepName := route.StepName
+			if stepName == "" {
+				stepName = route.NodeName + route.ServiceURL
+			}
+			log.Info("Skipping the step exceeding the latency budget", "node", nodeName, "stepName", stepName,
+				"latencyCostMs", *route.LatencyCostMs, "budgetMs", budget)
+			budgetSkippedSteps.WithLabelValues(nodeName, stepName).Inc()
+			continue
+		}
+		steps = append(steps, route)
+	}
+	return steps
+}
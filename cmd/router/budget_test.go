@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestRequestBudget(t *testing.T) {
+	node := v1alpha1.InferenceRouter{RouterType: v1alpha1.Switch, BudgetHeader: "X-Latency-Budget-Ms"}
+	scenarios := map[string]struct {
+		node     v1alpha1.InferenceRouter
+		headers  http.Header
+		budget   int64
+		expected bool
+	}{
+		"budget": {
+			node:     node,
+			headers:  http.Header{"X-Latency-Budget-Ms": {"150"}},
+			budget:   150,
+			expected: true,
+		},
+		"no budget header on the node": {
+			node:    v1alpha1.InferenceRouter{RouterType: v1alpha1.Switch},
+			headers: http.Header{"X-Latency-Budget-Ms": {"150"}},
+		},
+		"no budget header in the request": {
+			node:    node,
+			headers: http.Header{},
+		},
+		"invalid budget": {
+			node:    node,
+			headers: http.Header{"X-Latency-Budget-Ms": {"fast"}},
+		},
+		"negative budget": {
+			node:    node,
+			headers: http.Header{"X-Latency-Budget-Ms": {"-1"}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			budget, ok := requestBudget(scenario.node, scenario.headers)
+			assert.Equal(t, scenario.expected, ok)
+			assert.Equal(t, scenario.budget, budget)
+		})
+	}
+}
+
+func TestSwitchNodeWithBudget(t *testing.T) {
+	newService := func(prediction string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte(`{"predictions": ["` + prediction + `"]}`))
+		}))
+	}
+	reranker := newService("reranked")
+	defer reranker.Close()
+	retriever := newService("retrieved")
+	defer retriever.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"search": {
+				RouterType:   v1alpha1.Switch,
+				BudgetHeader: "X-Latency-Budget-Ms",
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "rerank",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: reranker.URL},
+						Condition:       "instances",
+						LatencyCostMs:   Int64Ptr(200),
+					},
+					{
+						StepName:        "retrieve",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: retriever.URL},
+						Condition:       "instances",
+						LatencyCostMs:   Int64Ptr(20),
+					},
+				},
+			},
+		},
+	}
+	input := []byte(`{"instances": ["query"]}`)
+
+	scenarios := map[string]struct {
+		headers  http.Header
+		expected string
+	}{
+		"no budget": {
+			headers:  http.Header{},
+			expected: `{"predictions": ["reranked"]}`,
+		},
+		"budget above the cost of the re-ranker": {
+			headers:  http.Header{"X-Latency-Budget-Ms": {"500"}},
+			expected: `{"predictions": ["reranked"]}`,
+		},
+		"tight budget": {
+			headers:  http.Header{"X-Latency-Budget-Ms": {"50"}},
+			expected: `{"predictions": ["retrieved"]}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			response, statusCode, err := routeStep("search", graphSpec, input, scenario.headers, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 200, statusCode)
+			assert.JSONEq(t, scenario.expected, string(response))
+		})
+	}
+	assert.InDelta(t, 1, testutil.ToFloat64(budgetSkippedSteps.WithLabelValues("search", "rerank")), 0)
+
+	_, statusCode, err := routeStep("search", graphSpec, input, http.Header{"X-Latency-Budget-Ms": {"10"}}, nil)
+	require.Error(t, err)
+	assert.Equal(t, 404, statusCode)
+}
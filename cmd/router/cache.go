@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/sharedcache"
+)
+
+const defaultNodeCacheMaxEntries = 1000
+
+var (
+	nodeCacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inference_graph_node_cache_hits_total",
+		Help: "Number of requests to an InferenceGraph node served from the node cache",
+	}, []string{"node"})
+	nodeCacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inference_graph_node_cache_misses_total",
+		Help: "Number of requests to an InferenceGraph node not found in the node cache",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(nodeCacheHits, nodeCacheMisses)
+}
+
+// responseCache caches the responses of a node, in the router or in the shared cache
+type responseCache interface {
+	get(key string) ([]byte, int, bool)
+	set(key string, response []byte, statusCode int)
+}
+
+type nodeCacheEntry struct {
+	response   []byte
+	statusCode int
+	expiresAt  time.Time
+}
+
+// nodeCache caches the successful responses of a router node keyed on the hash of the node input
+type nodeCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]nodeCacheEntry
+	// keys in insertion order, the oldest entry is evicted first when the cache is full
+	keys []string
+	now  func() time.Time
+}
+
+func newNodeCache(spec *v1alpha1.InferenceRouterCache) *nodeCache {
+	maxEntries := defaultNodeCacheMaxEntries
+	if spec.MaxEntries != nil {
+		maxEntries = int(*spec.MaxEntries)
+	}
+	return &nodeCache{
+		ttl:        time.Duration(spec.TTLSeconds) * time.Second,
+		maxEntries: maxEntries,
+		entries:    map[string]nodeCacheEntry{},
+		now:        time.Now,
+	}
+}
+
+// nodeCacheKey hashes the node input with the values of the key headers of the request
+func nodeCacheKey(input []byte, headers http.Header, keyHeaders []string) string {
+	hash := sha256.New()
+	hash.Write(input)
+	for _, name := range keyHeaders {
+		hash.Write([]byte{0})
+		hash.Write([]byte(http.CanonicalHeaderKey(name)))
+		for _, value := range headers.Values(name) {
+			hash.Write([]byte{0})
+			hash.Write([]byte(value))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *nodeCache) get(key string) ([]byte, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, 0, false
+	}
+	return entry.response, entry.statusCode, true
+}
+
+func (c *nodeCache) set(key string, response []byte, statusCode int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.evict()
+		c.keys = append(c.keys, key)
+	}
+	c.entries[key] = nodeCacheEntry{
+		response:   response,
+		statusCode: statusCode,
+		expiresAt:  c.now().Add(c.ttl),
+	}
+}
+
+// evict removes the expired entries and, if the cache is still full, the oldest entries to make room for a new one
+func (c *nodeCache) evict() {
+	now := c.now()
+	keys := c.keys[:0]
+	for _, key := range c.keys {
+		if now.Before(c.entries[key].expiresAt) {
+			keys = append(keys, key)
+		} else {
+			delete(c.entries, key)
+		}
+	}
+	for len(keys) >= c.maxEntries {
+		delete(c.entries, keys[0])
+		keys = keys[1:]
+	}
+	c.keys = keys
+}
+
+// sharedCacheClient is the client of the shared cache of the cluster, nil when the shared cache is not enabled
+var sharedCacheClient *sharedcache.Client
+
+// sharedNodeCache caches the responses of a node in the shared cache under the shared key of the node. Only the
+// responses with the status 200 are cached, as the status is not stored. The shared cache is best effort: its errors
+// are logged, and the request is routed to the node as if the response was not cached.
+type sharedNodeCache struct {
+	client    *sharedcache.Client
+	sharedKey string
+	ttl       time.Duration
+}
+
+func (c *sharedNodeCache) get(key string) ([]byte, int, bool) {
+	response, ok, err := c.client.Get(context.Background(), c.sharedKey+"/"+key)
+	if err != nil {
+		log.Error(err, "Failed to read the shared cache", "sharedKey", c.sharedKey)
+		return nil, 0, false
+	}
+	if !ok {
+		return nil, 0, false
+	}
+	return response, http.StatusOK, true
+}
+
+func (c *sharedNodeCache) set(key string, response []byte, statusCode int) {
+	if statusCode != http.StatusOK {
+		return
+	}
+	if err := c.client.Set(context.Background(), c.sharedKey+"/"+key, response, c.ttl); err != nil {
+		log.Error(err, "Failed to write the shared cache", "sharedKey", c.sharedKey)
+	}
+}
+
+var (
+	// the caches are keyed by the cache settings of the nodes, which are distinct for each loaded routing spec, so
+	// that a node of the canary routing spec does not serve the responses cached for the same node of the rolled out
+	// routing spec
+	nodeCaches     = map[*v1alpha1.InferenceRouterCache]responseCache{}
+	nodeCachesLock sync.Mutex
+)
+
+// getNodeCache returns the cache of the node, nil when the responses of the node are not cached. The nodes with a
+// shared key use the shared cache when it is enabled, and a cache of the router otherwise.
+func getNodeCache(node v1alpha1.InferenceRouter) responseCache {
+	if node.Cache == nil {
+		return nil
+	}
+	nodeCachesLock.Lock()
+	defer nodeCachesLock.Unlock()
+	cache, ok := nodeCaches[node.Cache]
+	if !ok {
+		if node.Cache.SharedKey != "" && sharedCacheClient != nil {
+			cache = &sharedNodeCache{
+				client:    sharedCacheClient,
+				sharedKey: node.Cache.SharedKey,
+				ttl:       time.Duration(node.Cache.TTLSeconds) * time.Second,
+			}
+		} else {
+			cache = newNodeCache(node.Cache)
+		}
+		nodeCaches[node.Cache] = cache
+	}
+	return cache
+}
+
+// routeStepWithCache serves the node response from the node cache when the node input was already seen with the same
+// key headers within the cache TTL, otherwise it routes the request and caches the successful responses.
+func routeStepWithCache(nodeName string, cache responseCache, input []byte, headers http.Header, keyHeaders []string,
+	route func() ([]byte, int, error),
+) ([]byte, int, error) {
+	key := nodeCacheKey(input, headers, keyHeaders)
+	if response, statusCode, ok := cache.get(key); ok {
+		nodeCacheHits.WithLabelValues(nodeName).Inc()
+		log.Info("Serving the node response from the cache", "node", nodeName)
+		return response, statusCode, nil
+	}
+	nodeCacheMisses.WithLabelValues(nodeName).Inc()
+	response, statusCode, err := route()
+	if err == nil && isSuccessFul(statusCode) {
+		cache.set(key, response, statusCode)
+	}
+	return response, statusCode, err
+}
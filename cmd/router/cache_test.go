@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/sharedcache"
+)
+
+func TestNodeCacheExpiryAndEviction(t *testing.T) {
+	now := time.Now()
+	cache := newNodeCache(&v1alpha1.InferenceRouterCache{TTLSeconds: 10, MaxEntries: ptr.To(int32(2))})
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", []byte("a"), 200)
+	response, statusCode, ok := cache.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), response)
+	assert.Equal(t, 200, statusCode)
+
+	// the oldest entry is evicted when the cache is full
+	cache.set("b", []byte("b"), 200)
+	cache.set("c", []byte("c"), 200)
+	_, _, ok = cache.get("a")
+	assert.False(t, ok)
+	_, _, ok = cache.get("b")
+	assert.True(t, ok)
+	_, _, ok = cache.get("c")
+	assert.True(t, ok)
+
+	// the entries expire after the ttl
+	now = now.Add(10 * time.Second)
+	_, _, ok = cache.get("c")
+	assert.False(t, ok)
+	cache.set("d", []byte("d"), 200)
+	assert.Equal(t, []string{"d"}, cache.keys)
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestCachedNode(t *testing.T) {
+	var calls atomic.Int32
+	embedding := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		if req.Header.Get("Fail") == "true" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.2]]}`))
+	}))
+	defer embedding.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "ranking-a", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "cached-embedding"}},
+					{StepName: "ranking-b", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "cached-embedding"}},
+				},
+			},
+			"cached-embedding": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "embedding", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedding.URL}},
+				},
+				Cache: &v1alpha1.InferenceRouterCache{TTLSeconds: 60},
+			},
+		},
+	}
+
+	response, statusCode, err := routeStep("root", graphSpec, []byte(`{"instances": ["query"]}`), http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": [[0.1, 0.2]]}`, string(response))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.InDelta(t, 1, testutil.ToFloat64(nodeCacheHits.WithLabelValues("cached-embedding")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(nodeCacheMisses.WithLabelValues("cached-embedding")), 0)
+
+	// a different input is not served from the cache
+	_, _, err = routeStep("cached-embedding", graphSpec, []byte(`{"instances": ["other"]}`), http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// the requests differing on a key header do not share the cached responses
+	keyedNode := graphSpec.Nodes["cached-embedding"]
+	keyedNode.Cache = &v1alpha1.InferenceRouterCache{TTLSeconds: 60, KeyHeaders: []string{"X-Tenant"}}
+	graphSpec.Nodes["keyed-embedding"] = keyedNode
+	for _, tenant := range []string{"a", "b", "a"} {
+		_, _, err = routeStep("keyed-embedding", graphSpec, []byte(`{"instances": ["query"]}`), http.Header{"X-Tenant": {tenant}}, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), calls.Load())
+	assert.InDelta(t, 1, testutil.ToFloat64(nodeCacheHits.WithLabelValues("keyed-embedding")), 0)
+
+	// unsuccessful responses are not cached
+	compiledHeaderPatterns, err = compilePatterns([]string{"Fail"})
+	require.NoError(t, err)
+	defer func() { compiledHeaderPatterns = nil }()
+	for range 2 {
+		_, statusCode, err = routeStep("cached-embedding", graphSpec, []byte(`{"instances": ["failing"]}`), http.Header{"Fail": {"true"}}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 500, statusCode)
+	}
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestSharedCachedNode(t *testing.T) {
+	var calls atomic.Int32
+	embedding := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.2]]}`))
+	}))
+	defer embedding.Close()
+	// the API server authenticates the token of the router as a service account of the test namespace
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		review.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "system:serviceaccount:test:default"}}
+		return true, review, nil
+	})
+	cache := httptest.NewServer(sharedcache.New(1<<20, nil, 1).Handler(clientset))
+	defer cache.Close()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token"), 0o600))
+	sharedCacheClient = sharedcache.NewClient(cache.URL, tokenPath)
+	defer func() { sharedCacheClient = nil }()
+
+	// the embedding nodes of two graphs share their responses through the shared cache
+	newGraph := func(nodeName string) v1alpha1.InferenceGraphSpec {
+		return v1alpha1.InferenceGraphSpec{
+			Nodes: map[string]v1alpha1.InferenceRouter{
+				nodeName: {
+					RouterType: v1alpha1.Sequence,
+					Steps: []v1alpha1.InferenceStep{
+						{StepName: "embedding", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedding.URL}},
+					},
+					Cache: &v1alpha1.InferenceRouterCache{TTLSeconds: 60, SharedKey: "embedding"},
+				},
+			},
+		}
+	}
+	input := []byte(`{"instances": ["query"]}`)
+
+	response, statusCode, err := routeStep("shared-embedding-a", newGraph("shared-embedding-a"), input, http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": [[0.1, 0.2]]}`, string(response))
+
+	response, statusCode, err = routeStep("shared-embedding-b", newGraph("shared-embedding-b"), input, http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": [[0.1, 0.2]]}`, string(response))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.InDelta(t, 1, testutil.ToFloat64(nodeCacheMisses.WithLabelValues("shared-embedding-a")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(nodeCacheHits.WithLabelValues("shared-embedding-b")), 0)
+
+	// the node is still routed when the shared cache is unavailable
+	cache.Close()
+	_, statusCode, err = routeStep("shared-embedding-c", newGraph("shared-embedding-c"), input, http.Header{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var (
+	graphLock sync.RWMutex
+	// canaryGraph is the routing spec of the canary revision of the graph nodes, nil when no canary is rolled out
+	canaryGraph *v1alpha1.InferenceGraphSpec
+)
+
+// getInferenceGraph returns the routing spec currently served by the router
+func getInferenceGraph() v1alpha1.InferenceGraphSpec {
+	graphLock.RLock()
+	defer graphLock.RUnlock()
+	return *inferenceGraph
+}
+
+// pickupInferenceGraph returns the routing spec serving a request, the canary spec for the canary traffic percent of
+// the requests when a canary revision is rolled out
+func pickupInferenceGraph() v1alpha1.InferenceGraphSpec {
+	graphLock.RLock()
+	defer graphLock.RUnlock()
+	if canaryGraph == nil || canaryGraph.CanaryTrafficPercent == nil {
+		return *inferenceGraph
+	}
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(100))
+	if err != nil {
+		panic(err)
+	}
+	if randomNumber.Int64() < *canaryGraph.CanaryTrafficPercent {
+		return *canaryGraph
+	}
+	return *inferenceGraph
+}
+
+func setInferenceGraph(graph *v1alpha1.InferenceGraphSpec) {
+	setInferenceGraphs(graph, nil)
+}
+
+func setInferenceGraphs(graph *v1alpha1.InferenceGraphSpec, canary *v1alpha1.InferenceGraphSpec) {
+	graphLock.Lock()
+	defer graphLock.Unlock()
+	inferenceGraph = graph
+	canaryGraph = canary
+	// the node caches are reset as the cache settings or the steps of the nodes may have changed
+	nodeCachesLock.Lock()
+	nodeCaches = map[*v1alpha1.InferenceRouterCache]responseCache{}
+	nodeCachesLock.Unlock()
+}
+
+func loadGraphConfigFile(path string) (*v1alpha1.InferenceGraphSpec, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the graph config file %s", path)
+	}
+	graph := &v1alpha1.InferenceGraphSpec{}
+	if err := json.Unmarshal(bytes, graph); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the graph config file %s", path)
+	}
+	return graph, nil
+}
+
+// loadCanaryGraphConfigFile loads the canary routing spec written next to the graph config file, nil when no canary
+// revision is rolled out
+func loadCanaryGraphConfigFile(path string) (*v1alpha1.InferenceGraphSpec, error) {
+	canaryPath := filepath.Join(filepath.Dir(path), constants.RouterCanaryGraphConfigFileName)
+	if _, err := os.Stat(canaryPath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return loadGraphConfigFile(canaryPath)
+}
+
+// loadGraphConfigFiles loads the routing spec and the canary routing spec of the graph
+func loadGraphConfigFiles(path string) (*v1alpha1.InferenceGraphSpec, *v1alpha1.InferenceGraphSpec, error) {
+	graph, err := loadGraphConfigFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	canary, err := loadCanaryGraphConfigFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return graph, canary, nil
+}
+
+// watchGraphConfigFile reloads the routing spec when the graph config file changes. The directory of the file is
+// watched rather than the file itself, as the files of a mounted ConfigMap are replaced through a symlink swap.
+// The server timeouts are only read at startup, changes to the router timeouts require a restart of the router.
+func watchGraphConfigFile(ctx context.Context, path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				graph, canary, err := loadGraphConfigFiles(path)
+				if err != nil {
+					// keep serving the last valid spec, the file may be in the middle of an update
+					log.Error(err, "Failed to reload the graph config file")
+					continue
+				}
+				setInferenceGraphs(graph, canary)
+				log.Info("Reloaded the graph config file", "path", path, "canary", canary != nil)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error(err, "Error watching the graph config file")
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	sequenceGraph = `{"nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://model1.example.com"}]}}}`
+	splitterGraph = `{"nodes":{"root":{"routerType":"Splitter","steps":[{"serviceUrl":"http://model2.example.com","weight":100}]}}}`
+)
+
+// writeConfigMapVersion mimics the kubelet update of a mounted ConfigMap: the files are written in a new timestamped
+// directory and the ..data symlink is atomically swapped to point to it
+func writeConfigMapVersion(t *testing.T, dir string, version string, content string) {
+	versionDir := filepath.Join(dir, version)
+	require.NoError(t, os.MkdirAll(versionDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "graph.json"), []byte(content), 0o600))
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(version, tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+}
+
+func TestGraphConfigFileReload(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigMapVersion(t, dir, "..v1", sequenceGraph)
+	path := filepath.Join(dir, "graph.json")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "graph.json"), path))
+
+	graph, err := loadGraphConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.Sequence, graph.Nodes["root"].RouterType)
+	setInferenceGraph(graph)
+	t.Cleanup(func() { inferenceGraph = nil })
+
+	require.NoError(t, watchGraphConfigFile(t.Context(), path))
+
+	writeConfigMapVersion(t, dir, "..v2", splitterGraph)
+	assert.Eventually(t, func() bool {
+		return getInferenceGraph().Nodes["root"].RouterType == v1alpha1.Splitter
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid spec is ignored and the last valid spec is kept
+	writeConfigMapVersion(t, dir, "..v3", "{invalid")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, v1alpha1.Splitter, getInferenceGraph().Nodes["root"].RouterType)
+	assert.Equal(t, "http://model2.example.com", getInferenceGraph().Nodes["root"].Steps[0].ServiceURL)
+}
+
+func TestCanaryGraphConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigMapVersion(t, dir, "..v1", sequenceGraph)
+	path := filepath.Join(dir, "graph.json")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "graph.json"), path))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "graph-canary.json"), filepath.Join(dir, "graph-canary.json")))
+
+	graph, canary, err := loadGraphConfigFiles(path)
+	require.NoError(t, err)
+	assert.Nil(t, canary)
+	setInferenceGraphs(graph, canary)
+	t.Cleanup(func() { setInferenceGraphs(nil, nil) })
+
+	require.NoError(t, watchGraphConfigFile(t.Context(), path))
+
+	// the canary revision is written next to the rolled out revision
+	versionDir := filepath.Join(dir, "..v2")
+	require.NoError(t, os.Mkdir(versionDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(versionDir, "graph-canary.json"),
+		[]byte(`{"canaryTrafficPercent":30,`+splitterGraph[1:]), 0o600))
+	writeConfigMapVersion(t, dir, "..v2", sequenceGraph)
+	assert.Eventually(t, func() bool {
+		graphLock.RLock()
+		defer graphLock.RUnlock()
+		return canaryGraph != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	picked := map[v1alpha1.InferenceRouterType]int{}
+	for range 10000 {
+		picked[pickupInferenceGraph().Nodes["root"].RouterType]++
+	}
+	assert.InDelta(t, 7000, picked[v1alpha1.Sequence], 300)
+	assert.InDelta(t, 3000, picked[v1alpha1.Splitter], 300)
+
+	// the canary revision is removed once it is rolled out
+	writeConfigMapVersion(t, dir, "..v3", splitterGraph)
+	assert.Eventually(t, func() bool {
+		graphLock.RLock()
+		defer graphLock.RUnlock()
+		return canaryGraph == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, v1alpha1.Splitter, pickupInferenceGraph().Nodes["root"].RouterType)
+}
+
+func TestLoadGraphConfigFileErrors(t *testing.T) {
+	_, err := loadGraphConfigFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "failed to read the graph config file")
+
+	path := filepath.Join(t.TempDir(), "graph.json")
+	require.NoError(t, os.WriteFile(path, []byte("{invalid"), 0o600))
+	_, err = loadGraphConfigFile(path)
+	require.ErrorContains(t, err, "failed to unmarshal the graph config file")
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/tls"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
@@ -36,6 +37,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"github.com/tidwall/gjson"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -43,6 +45,10 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/faultinjection"
+	"github.com/kserve/kserve/pkg/profiling"
+	"github.com/kserve/kserve/pkg/sharedcache"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 )
 
 // _isInMesh is an auxiliary global variable for isInIstioMesh function.
@@ -132,7 +138,7 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 	var headersToPropagate []string
 	for _, p := range compiledHeaderPatterns {
 		for h, values := range headers {
-			if _, ok := matchedHeaders[h]; !ok && p.MatchString(h) {
+			if _, ok := matchedHeaders[h]; !ok && p.MatchString(h) && !isDeniedHeader(h) {
 				matchedHeaders[h] = true
 				headersToPropagate = append(headersToPropagate, h)
 				for _, v := range values {
@@ -182,7 +188,10 @@ func pickupRoute(routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
 		panic(err)
 	}
 	// generate num [0,100)
-	point := int(randomNumber.Int64())
+	return pickupRouteAt(int(randomNumber.Int64()), routes)
+}
+
+func pickupRouteAt(point int, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
 	end := 0
 	for _, route := range routes {
 		end += int(*route.Weight)
@@ -216,7 +225,7 @@ type EnsembleStepOutput struct {
 }
 
 // See if reviewer suggests a better name for this function
-func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, trace *executionTrace) ([]byte, int, error) {
 	var statusCode int
 	var responseBytes []byte
 	var err error
@@ -225,7 +234,7 @@ func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.In
 		stepType = "node"
 	}
 	log.Info("Starting execution of step", "type", stepType, "stepName", route.StepName)
-	if responseBytes, statusCode, err = executeStep(route, graph, input, headers); err != nil {
+	if responseBytes, statusCode, err = executeStep(route, graph, input, headers, trace); err != nil {
 		return nil, 500, err
 	}
 
@@ -240,24 +249,42 @@ type sequenceReqRes struct {
 	Instances   []interface{} `json:"instances,omitempty"`
 }
 
-func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, trace *executionTrace) ([]byte, int, error) {
 	defer timeTrack(time.Now(), "node", nodeName)
 	currentNode := graph.Nodes[nodeName]
 
+	if cache := getNodeCache(currentNode); cache != nil {
+		return routeStepWithCache(nodeName, cache, input, headers, currentNode.Cache.KeyHeaders, func() ([]byte, int, error) {
+			return routeNode(nodeName, currentNode, graph, input, headers, trace)
+		})
+	}
+	return routeNode(nodeName, currentNode, graph, input, headers, trace)
+}
+
+func routeNode(nodeName string, currentNode v1alpha1.InferenceRouter, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, trace *executionTrace) ([]byte, int, error) {
 	if currentNode.RouterType == v1alpha1.Splitter {
-		route := pickupRoute(currentNode.Steps)
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		var route *v1alpha1.InferenceStep
+		if key := headers.Get(currentNode.SplitKeyHeader); currentNode.SplitKeyHeader != "" && key != "" {
+			route = pickupRouteByKey(key, currentNode.Steps)
+		} else {
+			route = pickupRoute(currentNode.Steps)
+		}
+		return handleSplitNode(nodeName, route, graph, input, headers, trace)
 	}
 	if currentNode.RouterType == v1alpha1.Switch {
 		var err error
-		route := pickupRouteByCondition(input, currentNode.Steps)
+		steps := currentNode.Steps
+		if budget, ok := requestBudget(currentNode, headers); ok {
+			steps = stepsWithinBudget(nodeName, budget, steps)
+		}
+		route := pickupRouteByCondition(input, steps)
 		if route == nil {
 			errorMessage := "None of the routes matched with the switch condition"
 			err = errors.New(errorMessage)
 			log.Error(err, errorMessage)
 			return nil, 404, err
 		}
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		return handleSplitterORSwitchNode(route, graph, input, headers, trace)
 	}
 	if currentNode.RouterType == v1alpha1.Ensemble {
 		ensembleRes := make([]chan EnsembleStepOutput, len(currentNode.Steps))
@@ -272,7 +299,7 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			resultChan := make(chan EnsembleStepOutput)
 			ensembleRes[i] = resultChan
 			go func() {
-				output, statusCode, err := executeStep(step, graph, input, headers)
+				output, statusCode, err := executeStep(step, graph, input, headers, trace)
 				if err == nil {
 					var res map[string]interface{}
 					if err = json.Unmarshal(output, &res); err == nil {
@@ -350,7 +377,7 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 					return responseBytes, 200, nil
 				}
 			}
-			if responseBytes, statusCode, err = executeStep(step, graph, request, headers); err != nil {
+			if responseBytes, statusCode, err = executeStep(step, graph, request, headers, trace); err != nil {
 				return nil, 500, err
 			}
 			/*
@@ -378,10 +405,14 @@ func isSuccessFul(statusCode int) bool {
 	return false
 }
 
-func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, trace *executionTrace) (response []byte, statusCode int, err error) {
+	start := time.Now()
+	defer func() {
+		trace.record(step, start, statusCode, err)
+	}()
 	if step.NodeName != "" {
 		// when nodeName is specified make a recursive call for routing to next step
-		return routeStep(step.NodeName, graph, input, headers)
+		return routeStep(step.NodeName, graph, input, headers, trace)
 	}
 	return callService(step.ServiceURL, input, headers)
 }
@@ -400,7 +431,10 @@ func prepareErrorResponse(err error, errorMessage string) []byte {
 
 func graphHandler(w http.ResponseWriter, req *http.Request) {
 	inputBytes, _ := io.ReadAll(req.Body)
-	if response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header); err != nil {
+	trace := newExecutionTrace(req.Header)
+	response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, pickupInferenceGraph(), inputBytes, req.Header, trace)
+	trace.writeHeader(w.Header())
+	if err != nil {
 		log.Error(err, "failed to process request")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
@@ -418,6 +452,17 @@ func graphHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// isDeniedHeader returns whether a header matches one of the deny patterns, the sensitive headers are never
+// propagated to the steps even when they match a propagate pattern
+func isDeniedHeader(header string) bool {
+	for _, p := range compiledDenyHeaderPatterns {
+		if p.MatchString(header) {
+			return true
+		}
+	}
+	return false
+}
+
 func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
 	var allErrors []error
 	var compiled []*regexp.Regexp
@@ -473,18 +518,40 @@ func readyHandler(w http.ResponseWriter, req *http.Request) {
 
 var (
 	jsonGraph                                           = flag.String("graph-json", "", "serialized json graph def")
+	graphConfigFile                                     = flag.String("graph-config-file", "", "path of the json graph def file, reloaded when the file changes")
+	enableProfiling                                     = flag.Bool("enable-profiling", false, "Serve the pprof and trace endpoints, secured by the PROFILING_TOKEN bearer token")
+	profilingPort                                       = flag.Int("profiling-port", profiling.DefaultPort, "Profiling port")
 	inferenceGraph         *v1alpha1.InferenceGraphSpec = nil
 	compiledHeaderPatterns []*regexp.Regexp
-	isShuttingDown                                               = false
-	drainSleepDuration                                           = 30 * time.Second
-	routerTimeouts         *v1alpha1.InfereceGraphRouterTimeouts = nil
-	log                                                          = logf.Log.WithName("InferenceGraphRouter")
-	signalChan                                                   = make(chan os.Signal, 1)
+	// compiledDenyHeaderPatterns match the headers that are not propagated
+	compiledDenyHeaderPatterns []*regexp.Regexp
+	isShuttingDown                                                   = false
+	drainSleepDuration                                               = 30 * time.Second
+	routerTimeouts             *v1alpha1.InfereceGraphRouterTimeouts = nil
+	log                                                              = logf.Log.WithName("InferenceGraphRouter")
+	signalChan                                                       = make(chan os.Signal, 1)
 )
 
+// tlsOpts are bound once, main is run several times by the tests
+var tlsOpts tlspolicy.Options
+
+func init() {
+	tlsOpts.BindFlags(flag.CommandLine)
+}
+
 func main() {
 	flag.Parse()
 	logf.SetLogger(zap.New())
+	tlsPolicy, err := tlspolicy.New(tlsOpts)
+	if err != nil {
+		log.Error(err, "invalid TLS policy")
+		os.Exit(1)
+	}
+	// The service clients use the default transport, the TLS policy is enforced on the calls to the https steps
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	tlsPolicy.Apply(transport.TLSClientConfig)
+	http.DefaultTransport = transport
 	if headersToPropagateEnvVar, ok := os.LookupEnv(constants.RouterHeadersPropagateEnvVar); ok {
 		var err error
 		log.Info("The headers that will match these patterns will be propagated by the router to all the steps",
@@ -494,17 +561,63 @@ func main() {
 			log.Error(err, "Failed to compile some header patterns")
 		}
 	}
+	if headersToDenyEnvVar, ok := os.LookupEnv(constants.RouterHeadersDenyEnvVar); ok {
+		var err error
+		log.Info("The headers that will match these patterns will not be propagated by the router to the steps",
+			"headersToDenyEnvVar", headersToDenyEnvVar)
+		var headersToDeny []string
+		if err = json.Unmarshal([]byte(headersToDenyEnvVar), &headersToDeny); err != nil {
+			log.Error(err, "Failed to parse the header deny patterns")
+		} else if compiledDenyHeaderPatterns, err = compilePatterns(headersToDeny); err != nil {
+			log.Error(err, "Failed to compile some header patterns")
+		}
+	}
 
-	inferenceGraph = &v1alpha1.InferenceGraphSpec{}
-	err := json.Unmarshal([]byte(*jsonGraph), inferenceGraph)
-	if err != nil {
-		log.Error(err, "failed to unmarshall inference graph json")
-		os.Exit(1)
+	if traceEnvVar, ok := os.LookupEnv(constants.RouterTraceEnvVar); ok {
+		traceEnabled, _ = strconv.ParseBool(traceEnvVar)
+		log.Info("The requests with the debug header will get the execution trace of the graph in the response headers",
+			"enabled", traceEnabled, "debugHeader", constants.RouterTraceDebugHeader)
+	}
+
+	if sharedCacheURL, ok := os.LookupEnv(constants.RouterSharedCacheURLEnvVar); ok {
+		sharedCacheClient = sharedcache.NewClient(sharedCacheURL, sharedcache.TokenPath)
+		log.Info("The nodes with a shared cache key cache their responses in the shared cache", "url", sharedCacheURL)
+	}
+
+	if *graphConfigFile != "" {
+		inferenceGraph, canaryGraph, err = loadGraphConfigFiles(*graphConfigFile)
+		if err != nil {
+			log.Error(err, "failed to load inference graph config file")
+			os.Exit(1)
+		}
+		if err = watchGraphConfigFile(context.Background(), *graphConfigFile); err != nil {
+			log.Error(err, "failed to watch inference graph config file")
+			os.Exit(1)
+		}
+	} else {
+		inferenceGraph = &v1alpha1.InferenceGraphSpec{}
+		err = json.Unmarshal([]byte(*jsonGraph), inferenceGraph)
+		if err != nil {
+			log.Error(err, "failed to unmarshall inference graph json")
+			os.Exit(1)
+		}
 	}
 	initTimeouts(*inferenceGraph)
 
-	http.HandleFunc("/", graphHandler)
+	var rootHandler http.Handler = http.HandlerFunc(graphHandler)
+	if faultInjectionEnvVar, ok := os.LookupEnv(constants.RouterFaultInjectionEnvVar); ok {
+		rules, err := faultinjection.ParseRules(faultInjectionEnvVar)
+		if err != nil {
+			log.Error(err, "Failed to parse the fault injection rules, the fault injection is disabled")
+		} else {
+			log.Info("Fault injection is enabled, the requests may be failed or delayed on purpose",
+				"faultInjectionEnvVar", faultInjectionEnvVar)
+			rootHandler = faultinjection.NewHandler(rules, rootHandler)
+		}
+	}
+	http.Handle("/", rootHandler)
 	http.HandleFunc(constants.RouterReadinessEndpoint, readyHandler)
+	http.Handle(constants.DefaultPrometheusPath, promhttp.Handler())
 
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(constants.RouterPort),
@@ -522,6 +635,17 @@ func main() {
 		}
 	}()
 
+	if *enableProfiling {
+		if profilingServer := profiling.NewHTTPServer(*profilingPort, os.Getenv(profiling.TokenEnvVar)); profilingServer != nil {
+			go func() {
+				log.Info("Serving profiling endpoints", "port", *profilingPort)
+				if err := profilingServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					log.Error(err, fmt.Sprintf("Failed to serve profiling endpoints on address %v", profilingServer.Addr))
+				}
+			}()
+		}
+	}
+
 	// Blocks until SIGTERM or SIGINT is received
 	handleSignals(server)
 }
@@ -25,6 +25,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -140,7 +141,7 @@ func TestSimpleModelChainer(t *testing.T) {
 		"Authorization": {"Bearer Token"},
 	}
 
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, nil)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
@@ -230,7 +231,7 @@ func TestSimpleModelEnsemble(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, nil)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
@@ -442,7 +443,7 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep("root", graphSpec, jsonBytes, headers, nil)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
@@ -538,7 +539,7 @@ func TestInferenceGraphSequenceWithUnmetCondition(t *testing.T) {
 	}
 	jsonBytes, _ := json.Marshal(input)
 	headers := http.Header{}
-	res, statusCode, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, statusCode, err := routeStep("root", graphSpec, jsonBytes, headers, nil)
 	if err != nil {
 		t.Fatalf("routeStep failed: %v", err)
 	}
@@ -840,6 +841,61 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingPatterns(t *testing.T) {
 	require.Equal(t, expectedResponse, response)
 }
 
+func TestCallServiceWhenHeadersToDeny(t *testing.T) {
+	// Start a local HTTP server
+	model1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		if err != nil {
+			return
+		}
+		// Putting headers as part of response so that we can assert the headers' presence later
+		response := make(map[string]interface{})
+		response["predictions"] = "1"
+		for h, values := range req.Header {
+			if strings.HasPrefix(h, "Test-") || h == "Authorization" {
+				response[h] = values[0]
+			}
+		}
+		responseBytes, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("failed to marshal response: %v", err)
+		}
+		_, err = rw.Write(responseBytes)
+		if err != nil {
+			t.Fatalf("failed to write response: %v", err)
+		}
+	}))
+	model1Url, err := apis.ParseURL(model1.URL)
+	require.NoError(t, err)
+	defer model1.Close()
+
+	jsonBytes, _ := json.Marshal(map[string]interface{}{"instances": []string{"test"}})
+	headers := http.Header{
+		"Authorization":      {"Bearer Token"},
+		"Test-Trace-Id":      {"trace"},
+		"Test-Session-Token": {"secret"},
+	}
+	// The denied headers are not propagated even when they match a propagate pattern
+	compiledHeaderPatterns, err = compilePatterns([]string{"Test-*", "Authorization"})
+	require.NoError(t, err)
+	compiledDenyHeaderPatterns, err = compilePatterns([]string{"^Authorization$", "-Token$"})
+	require.NoError(t, err)
+	defer func() {
+		compiledHeaderPatterns = nil
+		compiledDenyHeaderPatterns = nil
+	}()
+
+	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	require.NoError(t, err)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(res, &response))
+	expectedResponse := map[string]interface{}{
+		"predictions":   "1",
+		"Test-Trace-Id": "trace",
+	}
+	require.Equal(t, expectedResponse, response)
+}
+
 func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing.T) {
 	// Start a local HTTP server
 	model1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+var (
+	splitRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_inference_graph_split_requests_total",
+		Help: "Number of requests routed to a step of an InferenceGraph Splitter node",
+	}, []string{"node", "step", "code"})
+	splitRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_inference_graph_split_request_duration_seconds",
+		Help:    "Latency of the requests routed to a step of an InferenceGraph Splitter node",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"node", "step"})
+)
+
+func init() {
+	prometheus.MustRegister(splitRequests, splitRequestDuration)
+}
+
+// pickupRouteByKey consistently routes the requests with the same key to the same step according to the weights
+func pickupRouteByKey(key string, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return pickupRouteAt(int(hash.Sum32()%100), routes)
+}
+
+// handleSplitNode executes the step picked by a Splitter node and records the requests and latency of the step,
+// so that the alternative branches of the node can be compared.
+func handleSplitNode(nodeName string, route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header, trace *executionTrace) ([]byte, int, error) {
+	if route == nil {
+		err := errors.New("no step picked by the splitter node, the step weights do not sum to 100")
+		log.Error(err, "Failed to route the request", "node", nodeName)
+		return nil, 500, err
+	}
+	stepName := route.StepName
+	if stepName == "" {
+		stepName = route.NodeName + route.ServiceURL
+	}
+	start := time.Now()
+	response, statusCode, err := handleSplitterORSwitchNode(route, graph, input, headers, trace)
+	splitRequestDuration.WithLabelValues(nodeName, stepName).Observe(time.Since(start).Seconds())
+	splitRequests.WithLabelValues(nodeName, stepName, strconv.Itoa(statusCode)).Inc()
+	return response, statusCode, err
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestPickupRouteByKey(t *testing.T) {
+	routes := []v1alpha1.InferenceStep{
+		{StepName: "old", Weight: Int64Ptr(90)},
+		{StepName: "new", Weight: Int64Ptr(10)},
+	}
+	picked := map[string]int{}
+	for i := range 10000 {
+		key := "user-" + strconv.Itoa(i)
+		route := pickupRouteByKey(key, routes)
+		require.NotNil(t, route)
+		// the same key is always routed to the same step
+		assert.Equal(t, route.StepName, pickupRouteByKey(key, routes).StepName)
+		picked[route.StepName]++
+	}
+	assert.InDelta(t, 9000, picked["old"], 300)
+	assert.InDelta(t, 1000, picked["new"], 300)
+}
+
+func TestSplitterNodeWithSplitKey(t *testing.T) {
+	newService := func(prediction string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte(`{"predictions": ["` + prediction + `"]}`))
+		}))
+	}
+	oldRanking := newService("old")
+	defer oldRanking.Close()
+	newRanking := newService("new")
+	defer newRanking.Close()
+
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"ranking-ab": {
+				RouterType:     v1alpha1.Splitter,
+				SplitKeyHeader: "User-Id",
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "old-ranking", Weight: Int64Ptr(50), InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: oldRanking.URL}},
+					{StepName: "new-ranking", Weight: Int64Ptr(50), InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: newRanking.URL}},
+				},
+			},
+		},
+	}
+
+	for i := range 20 {
+		headers := http.Header{"User-Id": {"user-" + strconv.Itoa(i)}}
+		expected := pickupRouteByKey(headers.Get("User-Id"), graphSpec.Nodes["ranking-ab"].Steps)
+		for range 3 {
+			response, statusCode, err := routeStep("ranking-ab", graphSpec, []byte(`{"instances": []}`), headers, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 200, statusCode)
+			if expected.StepName == "old-ranking" {
+				assert.JSONEq(t, `{"predictions": ["old"]}`, string(response))
+			} else {
+				assert.JSONEq(t, `{"predictions": ["new"]}`, string(response))
+			}
+		}
+	}
+
+	oldRequests := testutil.ToFloat64(splitRequests.WithLabelValues("ranking-ab", "old-ranking", "200"))
+	newRequests := testutil.ToFloat64(splitRequests.WithLabelValues("ranking-ab", "new-ranking", "200"))
+	assert.InDelta(t, 60, oldRequests+newRequests, 0)
+	assert.Positive(t, oldRequests)
+	assert.Positive(t, newRequests)
+	assert.Equal(t, 2, testutil.CollectAndCount(splitRequestDuration))
+}
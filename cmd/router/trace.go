@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// traceEnabled allows the requests to ask for the execution trace of the graph, it is set by the controller when the
+// namespace of the graph is allowed to trace its requests
+var traceEnabled = false
+
+// stepTrace is the execution of a step of the graph for a request
+type stepTrace struct {
+	Step      string `json:"step,omitempty"`
+	Target    string `json:"target"`
+	LatencyMs int64  `json:"latencyMs"`
+	Status    int    `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// executionTrace records the steps executed for a request, in the order they complete. A nil trace records nothing.
+type executionTrace struct {
+	mu    sync.Mutex
+	steps []stepTrace
+}
+
+// newExecutionTrace returns a trace of the request when the tracing is enabled and the request carries the debug header
+func newExecutionTrace(headers http.Header) *executionTrace {
+	if !traceEnabled {
+		return nil
+	}
+	if enabled, err := strconv.ParseBool(headers.Get(constants.RouterTraceDebugHeader)); err != nil || !enabled {
+		return nil
+	}
+	return &executionTrace{}
+}
+
+// record adds the execution of the step started at start to the trace
+func (t *executionTrace) record(step *v1alpha1.InferenceStep, start time.Time, statusCode int, err error) {
+	if t == nil {
+		return
+	}
+	entry := stepTrace{
+		Step:      step.StepName,
+		Target:    step.ServiceURL,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Status:    statusCode,
+	}
+	if step.NodeName != "" {
+		entry.Target = step.NodeName
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, entry)
+}
+
+// writeHeader sets the trace header of the response to the JSON list of the steps executed
+func (t *executionTrace) writeHeader(header http.Header) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	steps := t.steps
+	if steps == nil {
+		steps = []stepTrace{}
+	}
+	value, err := json.Marshal(steps)
+	if err != nil {
+		log.Error(err, "failed to marshal the execution trace")
+		return
+	}
+	header.Set(constants.RouterTraceHeader, string(value))
+}
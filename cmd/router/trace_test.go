@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestNewExecutionTrace(t *testing.T) {
+	t.Cleanup(func() { traceEnabled = false })
+	scenarios := map[string]struct {
+		enabled  bool
+		headers  http.Header
+		expected bool
+	}{
+		"disabled for the namespace": {
+			headers: http.Header{constants.RouterTraceDebugHeader: {"true"}},
+		},
+		"no debug header": {
+			enabled: true,
+			headers: http.Header{},
+		},
+		"debug header turned off": {
+			enabled: true,
+			headers: http.Header{constants.RouterTraceDebugHeader: {"false"}},
+		},
+		"invalid debug header": {
+			enabled: true,
+			headers: http.Header{constants.RouterTraceDebugHeader: {"yes please"}},
+		},
+		"debug header": {
+			enabled:  true,
+			headers:  http.Header{constants.RouterTraceDebugHeader: {"true"}},
+			expected: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			traceEnabled = scenario.enabled
+			assert.Equal(t, scenario.expected, newExecutionTrace(scenario.headers) != nil)
+		})
+	}
+}
+
+func TestGraphHandlerTrace(t *testing.T) {
+	embedding := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"predictions": [[0.1, 0.2]]}`))
+	}))
+	defer embedding.Close()
+	ranking := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = rw.Write([]byte(`{"error": "overloaded"}`))
+	}))
+	defer ranking.Close()
+
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "embed", InferenceTarget: v1alpha1.InferenceTarget{NodeName: "embedding"}},
+					{StepName: "rank", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: ranking.URL}, Data: "$response"},
+				},
+			},
+			"embedding": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "encode", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: embedding.URL}},
+				},
+			},
+		},
+	}
+	t.Cleanup(func() {
+		inferenceGraph = nil
+		traceEnabled = false
+	})
+
+	serve := func(headers http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": ["query"]}`))
+		req.Header = headers
+		rec := httptest.NewRecorder()
+		graphHandler(rec, req)
+		return rec
+	}
+
+	rec := serve(http.Header{constants.RouterTraceDebugHeader: {"true"}})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Empty(t, rec.Header().Get(constants.RouterTraceHeader))
+
+	traceEnabled = true
+	rec = serve(http.Header{})
+	assert.Empty(t, rec.Header().Get(constants.RouterTraceHeader))
+
+	rec = serve(http.Header{constants.RouterTraceDebugHeader: {"true"}})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var steps []stepTrace
+	require.NoError(t, json.Unmarshal([]byte(rec.Header().Get(constants.RouterTraceHeader)), &steps))
+	require.Len(t, steps, 3)
+	assert.Equal(t, "encode", steps[0].Step)
+	assert.Equal(t, embedding.URL, steps[0].Target)
+	assert.Equal(t, http.StatusOK, steps[0].Status)
+	assert.Equal(t, "embed", steps[1].Step)
+	assert.Equal(t, "embedding", steps[1].Target)
+	assert.Equal(t, http.StatusOK, steps[1].Status)
+	assert.Equal(t, "rank", steps[2].Step)
+	assert.Equal(t, ranking.URL, steps[2].Target)
+	assert.Equal(t, http.StatusServiceUnavailable, steps[2].Status)
+	for _, step := range steps {
+		assert.GreaterOrEqual(t, step.LatencyMs, int64(0))
+		assert.Empty(t, step.Error)
+	}
+}
+
+func TestExecutionTraceRecordsErrors(t *testing.T) {
+	trace := &executionTrace{}
+	graph := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"root": {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "broken", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: "http://invalid\x7f"}},
+				},
+			},
+		},
+	}
+	_, statusCode, err := routeStep("root", graph, []byte(`{}`), http.Header{}, trace)
+	require.Error(t, err)
+	assert.Equal(t, 500, statusCode)
+
+	header := http.Header{}
+	trace.writeHeader(header)
+	var steps []stepTrace
+	require.NoError(t, json.Unmarshal([]byte(header.Get(constants.RouterTraceHeader)), &steps))
+	require.Len(t, steps, 1)
+	assert.Equal(t, "broken", steps[0].Step)
+	assert.Equal(t, 500, steps[0].Status)
+	assert.NotEmpty(t, steps[0].Error)
+}
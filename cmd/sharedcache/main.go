@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/signals"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/sharedcache"
+)
+
+var (
+	port               = flag.Int("port", int(constants.SharedCachePort), "Shared cache port")
+	defaultTenantQuota = flag.Int64("default-tenant-quota", 64<<20, "The maximum size in bytes of the entries of a tenant")
+	tenantQuotas       = flag.StringToInt64("tenant-quotas", nil, "The quotas in bytes overriding the default quota of the given tenants, e.g. team-a=1073741824")
+	maxTenants         = flag.Int("max-tenants", constants.DefaultSharedCacheMaxTenants, "The maximum number of tenants the entries are held of")
+)
+
+func main() {
+	flag.Parse()
+	zapLogger, _ := zap.NewProduction()
+	logger := zapLogger.Sugar()
+	defer func() { _ = logger.Sync() }()
+
+	if *defaultTenantQuota <= 0 {
+		logger.Fatalw("The default tenant quota must be positive", "defaultTenantQuota", *defaultTenantQuota)
+	}
+	if *maxTenants <= 0 {
+		logger.Fatalw("The maximum number of tenants must be positive", "maxTenants", *maxTenants)
+	}
+	cache := sharedcache.New(*defaultTenantQuota, *tenantQuotas, *maxTenants)
+
+	// the tokens of the clients are reviewed with the API server to derive their tenant
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Fatalw("Failed to load the in-cluster configuration", "error", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logger.Fatalw("Failed to create the Kubernetes client", "error", err)
+	}
+
+	ctx := signals.NewContext()
+	server := &http.Server{
+		Addr:              ":" + strconv.Itoa(*port),
+		Handler:           cache.Handler(clientset),
+		ReadHeaderTimeout: time.Minute,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			logger.Errorw("Failed to shutdown the shared cache", "error", err)
+		}
+	}()
+	logger.Infow("Starting the shared cache", "port", *port, "defaultTenantQuota", *defaultTenantQuota, "tenantQuotas", *tenantQuotas,
+		"maxTenants", *maxTenants)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatalw("Failed to serve", "error", err)
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package activator buffers the requests of a component of an InferenceService scaled to zero in Standard deployment
+// mode while it is scaled from zero, and scales it back to zero once it no longer receives requests
+package activator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	DefaultPollInterval = time.Second
+	// DefaultRecordInterval is the interval between the records of the requests of a replica of the activator
+	DefaultRecordInterval = 10 * time.Second
+)
+
+// Scaler scales the component from and to zero
+type Scaler interface {
+	// Activate returns once the component has a ready replica
+	Activate(ctx context.Context) error
+	// Deactivate scales the component to zero
+	Deactivate(ctx context.Context) error
+}
+
+// Activity records the requests received by all the replicas of the activator, so that a replica does not scale the
+// component to zero while the others still receive requests
+type Activity interface {
+	// Record records a request received at the time
+	Record(ctx context.Context, at time.Time) error
+	// Idle returns whether no replica recorded a request since the time, a request recorded between the check and
+	// the reservation of the scaling to zero makes it return false
+	Idle(ctx context.Context, since time.Time) (bool, error)
+}
+
+// Activator proxies the requests to the component, the requests are held while the component is scaled from zero by
+// the scaler. Without a scaler, e.g. when the requests are forwarded to the interceptor of the KEDA HTTP add-on which
+// scales the component and buffers the requests itself, the activator only proxies the requests.
+type Activator struct {
+	proxy          *httputil.ReverseProxy
+	scaler         Scaler
+	activity       Activity
+	requestTimeout time.Duration
+	gracePeriod    time.Duration
+	recordInterval time.Duration
+	log            *zap.SugaredLogger
+
+	// activation serializes the scaling of the component, the requests buffered while the component is scaled from
+	// zero wait for the activation in progress
+	activation sync.Mutex
+	// mu guards the state of the requests, which is checked at once before the component is scaled to zero
+	mu          sync.Mutex
+	active      bool
+	activated   time.Time
+	inFlight    int64
+	lastRequest time.Time
+	recorded    time.Time
+}
+
+// New creates an activator proxying the requests to the target, with the host of the requests set to host when it is
+// not empty. The activity is shared by the replicas of the activator, it is nil when there is a single replica.
+func New(target *url.URL, host string, scaler Scaler, activity Activity, requestTimeout, gracePeriod time.Duration, log *zap.SugaredLogger) *Activator {
+	a := &Activator{
+		scaler:         scaler,
+		activity:       activity,
+		requestTimeout: requestTimeout,
+		gracePeriod:    gracePeriod,
+		recordInterval: DefaultRecordInterval,
+		log:            log,
+		lastRequest:    time.Now(),
+	}
+	a.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.SetXForwarded()
+			if host != "" {
+				r.Out.Host = host
+			} else {
+				r.Out.Host = r.In.Host
+			}
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			// the component is scaled from zero again by the next request when it was scaled to zero externally
+			a.mu.Lock()
+			a.active = false
+			a.mu.Unlock()
+			a.log.Errorw("Failed to proxy the request", "error", err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+	return a
+}
+
+func (a *Activator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the request is counted before the component is activated so that it is not scaled to zero in between. A replica
+	// idle for the grace period activates the component again, another replica may have scaled it to zero meanwhile.
+	now := time.Now()
+	a.mu.Lock()
+	active := a.active && !a.idle(now)
+	a.inFlight++
+	a.lastRequest = now
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.inFlight--
+		a.lastRequest = time.Now()
+		a.mu.Unlock()
+	}()
+	if !active {
+		if err := a.activate(r.Context(), now); err != nil {
+			a.log.Errorw("Failed to activate the component", "error", err)
+			http.Error(w, fmt.Sprintf("the component could not be scaled from zero: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	a.proxy.ServeHTTP(w, r)
+}
+
+// idle returns whether the replica has received no request for the grace period, the caller holds mu
+func (a *Activator) idle(now time.Time) bool {
+	return a.inFlight == 0 && now.Sub(a.lastRequest) >= a.gracePeriod
+}
+
+// activate scales the component from zero unless it was activated by another request since the time
+func (a *Activator) activate(ctx context.Context, since time.Time) error {
+	if a.scaler == nil {
+		return nil
+	}
+	a.activation.Lock()
+	defer a.activation.Unlock()
+	a.mu.Lock()
+	activated := a.active && a.activated.After(since)
+	a.mu.Unlock()
+	if activated {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.requestTimeout)
+	defer cancel()
+	if a.activity != nil {
+		// the other replicas no longer scale the component to zero once the request is recorded
+		if err := a.activity.Record(ctx, since); err != nil {
+			return err
+		}
+	}
+	a.log.Info("Scaling the component from zero")
+	if err := a.scaler.Activate(ctx); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.active = true
+	a.activated = time.Now()
+	a.mu.Unlock()
+	return nil
+}
+
+// Run scales the component to zero once it has received no requests for the grace period, until the context is done
+func (a *Activator) Run(ctx context.Context, interval time.Duration) {
+	if a.scaler == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.recordRequests(ctx); err != nil {
+				a.log.Errorw("Failed to record the requests", "error", err)
+			}
+			if err := a.deactivateIdle(ctx); err != nil {
+				a.log.Errorw("Failed to scale the component to zero", "error", err)
+			}
+		}
+	}
+}
+
+// recordRequests records the last request of the replica for the other replicas, at most once per record interval
+func (a *Activator) recordRequests(ctx context.Context) error {
+	if a.activity == nil {
+		return nil
+	}
+	now := time.Now()
+	a.mu.Lock()
+	last := a.lastRequest
+	if a.inFlight > 0 {
+		last = now
+	}
+	if last.Sub(a.recorded) < a.recordInterval {
+		a.mu.Unlock()
+		return nil
+	}
+	a.recorded = last
+	a.mu.Unlock()
+	return a.activity.Record(ctx, last)
+}
+
+func (a *Activator) deactivateIdle(ctx context.Context) error {
+	// the component is being activated, it is not idle
+	if !a.activation.TryLock() {
+		return nil
+	}
+	defer a.activation.Unlock()
+	now := time.Now()
+	a.mu.Lock()
+	if !a.active || !a.idle(now) {
+		a.mu.Unlock()
+		return nil
+	}
+	// the requests received from now on activate the component again, they wait for the deactivation
+	a.active = false
+	a.mu.Unlock()
+	if a.activity != nil {
+		// the requests of the other replicas are recorded with a delay of up to the record interval
+		idle, err := a.activity.Idle(ctx, now.Add(-a.gracePeriod-a.recordInterval))
+		if err != nil || !idle {
+			return err
+		}
+	}
+	a.log.Info("Scaling the component to zero")
+	return a.scaler.Deactivate(ctx)
+}
+
+// DeploymentScaler scales a deployment through its scale subresource
+type DeploymentScaler struct {
+	Clientset    kubernetes.Interface
+	Namespace    string
+	Name         string
+	PollInterval time.Duration
+}
+
+var _ Scaler = &DeploymentScaler{}
+
+func (s *DeploymentScaler) Activate(ctx context.Context) error {
+	if err := s.scale(ctx, 1); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+	for {
+		deployment, err := s.Clientset.AppsV1().Deployments(s.Namespace).Get(ctx, s.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if deployment.Status.ReadyReplicas > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the deployment %s has no ready replica: %w", s.Name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DeploymentScaler) Deactivate(ctx context.Context) error {
+	return s.scale(ctx, 0)
+}
+
+// scale sets the replicas of the deployment, the replicas are only scaled from zero to one when activated so that the
+// replicas set by the HPA are kept
+func (s *DeploymentScaler) scale(ctx context.Context, replicas int32) error {
+	deployments := s.Clientset.AppsV1().Deployments(s.Namespace)
+	scale, err := deployments.GetScale(ctx, s.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if (replicas > 0) == (scale.Spec.Replicas > 0) {
+		return nil
+	}
+	_, err = deployments.UpdateScale(ctx, s.Name, &autoscalingv1.Scale{
+		ObjectMeta: scale.ObjectMeta,
+		Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+	}, metav1.UpdateOptions{})
+	return err
+}
+
+// LeaseActivity records the requests of the replicas of the activator in the renew time of a lease
+type LeaseActivity struct {
+	Clientset kubernetes.Interface
+	Namespace string
+	Name      string
+	// Identity is the replica of the activator, the holder of the lease is the last replica which updated it
+	Identity string
+}
+
+var _ Activity = &LeaseActivity{}
+
+func (l *LeaseActivity) Record(ctx context.Context, at time.Time) error {
+	leases := l.Clientset.CoordinationV1().Leases(l.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lease, err := leases.Get(ctx, l.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if lease.Spec.RenewTime != nil && !lease.Spec.RenewTime.Time.Before(at) {
+			return nil
+		}
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: at}
+		lease.Spec.HolderIdentity = &l.Identity
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Idle reserves the scaling to zero by setting the acquire time of the lease, the update fails on a conflict when
+// another replica recorded a request since the lease was read
+func (l *LeaseActivity) Idle(ctx context.Context, since time.Time) (bool, error) {
+	leases := l.Clientset.CoordinationV1().Leases(l.Namespace)
+	lease, err := leases.Get(ctx, l.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if lease.Spec.RenewTime != nil && lease.Spec.RenewTime.Time.After(since) {
+		return false, nil
+	}
+	lease.Spec.AcquireTime = &metav1.MicroTime{Time: time.Now()}
+	lease.Spec.HolderIdentity = &l.Identity
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		if apierr.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type fakeScaler struct {
+	mu          sync.Mutex
+	activations int
+	err         error
+	replicas    int32
+}
+
+func (s *fakeScaler) Activate(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activations++
+	if s.err != nil {
+		return s.err
+	}
+	s.replicas = 1
+	return nil
+}
+
+func (s *fakeScaler) Deactivate(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replicas = 0
+	return nil
+}
+
+type fakeActivity struct {
+	mu       sync.Mutex
+	recorded time.Time
+}
+
+func (f *fakeActivity) Record(ctx context.Context, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if at.After(f.recorded) {
+		f.recorded = at
+	}
+	return nil
+}
+
+func (f *fakeActivity) Idle(ctx context.Context, since time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.recorded.After(since), nil
+}
+
+func newActivator(t *testing.T, scaler Scaler, activity Activity, gracePeriod time.Duration) (*Activator, *string) {
+	t.Helper()
+	host := new(string)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*host = r.Host
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(backend.Close)
+	target, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	return New(target, "sklearn-predictor-private.default.svc.cluster.local", scaler, activity, time.Second, gracePeriod,
+		zap.NewNop().Sugar()), host
+}
+
+func serve(a *Activator) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://sklearn-predictor.default/v1/models/sklearn:predict", nil))
+	return recorder
+}
+
+func TestActivator(t *testing.T) {
+	scaler := &fakeScaler{}
+	a, host := newActivator(t, scaler, nil, time.Hour)
+
+	resp := serve(a)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "ok", resp.Body.String())
+	assert.Equal(t, "sklearn-predictor-private.default.svc.cluster.local", *host)
+	resp = serve(a)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 1, scaler.activations, "the active component is only activated once")
+
+	a.lastRequest = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(0), scaler.replicas)
+	serve(a)
+	assert.Equal(t, 2, scaler.activations, "the component is activated again after it is scaled to zero")
+	assert.Equal(t, int32(1), scaler.replicas)
+}
+
+func TestActivatorGracePeriod(t *testing.T) {
+	scaler := &fakeScaler{}
+	a, _ := newActivator(t, scaler, nil, time.Hour)
+	serve(a)
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(1), scaler.replicas, "the component is kept during the grace period")
+
+	a.inFlight++
+	a.lastRequest = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(1), scaler.replicas, "the component is kept while a request is in flight")
+	assert.True(t, a.active)
+	a.inFlight--
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(0), scaler.replicas)
+}
+
+func TestActivatorConcurrentDeactivation(t *testing.T) {
+	scaler := &fakeScaler{}
+	a, _ := newActivator(t, scaler, nil, time.Hour)
+	serve(a)
+	a.lastRequest = time.Now().Add(-2 * time.Hour)
+
+	// the requests arriving while the component is scaled to zero activate it again once scaled to zero
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, http.StatusOK, serve(a).Code)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, a.deactivateIdle(t.Context()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), scaler.replicas, "the component is active after the last request")
+	assert.True(t, a.active)
+}
+
+func TestActivatorReplicas(t *testing.T) {
+	scaler := &fakeScaler{}
+	activity := &fakeActivity{}
+	a, _ := newActivator(t, scaler, activity, time.Hour)
+	serve(a)
+	assert.False(t, activity.recorded.IsZero(), "the activation is recorded for the other replicas")
+
+	// another replica received a request during the grace period
+	a.lastRequest = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, activity.Record(t.Context(), time.Now()))
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(1), scaler.replicas, "the component is kept while another replica receives requests")
+	assert.False(t, a.active, "the idle replica activates the component again on its next request")
+	serve(a)
+	assert.Equal(t, 2, scaler.activations)
+
+	a.lastRequest = time.Now().Add(-2 * time.Hour)
+	activity.recorded = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, a.deactivateIdle(t.Context()))
+	assert.Equal(t, int32(0), scaler.replicas)
+}
+
+func TestActivatorRecordRequests(t *testing.T) {
+	activity := &fakeActivity{}
+	a, _ := newActivator(t, &fakeScaler{}, activity, time.Hour)
+	a.recordInterval = time.Hour
+	a.recorded = time.Now()
+	a.lastRequest = time.Now()
+	require.NoError(t, a.recordRequests(t.Context()))
+	assert.True(t, activity.recorded.IsZero(), "the requests are recorded at most once per interval")
+
+	a.inFlight++
+	a.recorded = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, a.recordRequests(t.Context()))
+	assert.WithinDuration(t, time.Now(), activity.recorded, time.Minute, "the requests in flight are recorded")
+}
+
+func TestActivatorFailure(t *testing.T) {
+	a, _ := newActivator(t, &fakeScaler{err: errors.New("quota exceeded")}, nil, 0)
+	resp := serve(a)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Contains(t, resp.Body.String(), "quota exceeded")
+}
+
+func TestActivatorWithoutScaler(t *testing.T) {
+	a, host := newActivator(t, nil, nil, 0)
+	resp := serve(a)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "sklearn-predictor-private.default.svc.cluster.local", *host)
+}
+
+func TestDeploymentScaler(t *testing.T) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}}
+	clientset := fake.NewSimpleClientset(deployment)
+	replicas := int32(0)
+	clientset.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "scale" {
+			return true, &autoscalingv1.Scale{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"},
+				Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+			}, nil
+		}
+		ready := deployment.DeepCopy()
+		ready.Status.ReadyReplicas = replicas
+		return true, ready, nil
+	})
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		replicas = scale.Spec.Replicas
+		return true, scale, nil
+	})
+	scaler := &DeploymentScaler{Clientset: clientset, Namespace: "default", Name: "sklearn-predictor", PollInterval: time.Millisecond}
+
+	require.NoError(t, scaler.Activate(t.Context()))
+	assert.Equal(t, int32(1), replicas)
+	replicas = 3
+	require.NoError(t, scaler.Activate(t.Context()))
+	assert.Equal(t, int32(3), replicas, "the replicas set by the HPA are kept")
+	require.NoError(t, scaler.Deactivate(t.Context()))
+	assert.Equal(t, int32(0), replicas)
+}
+
+func TestLeaseActivity(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-activator", Namespace: "default"},
+	})
+	activity := &LeaseActivity{Clientset: clientset, Namespace: "default", Name: "sklearn-predictor-activator", Identity: "activator-0"}
+	now := time.Now()
+
+	idle, err := activity.Idle(t.Context(), now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, idle, "the component without recorded requests is idle")
+
+	require.NoError(t, activity.Record(t.Context(), now))
+	require.NoError(t, activity.Record(t.Context(), now.Add(-time.Minute)))
+	lease, err := clientset.CoordinationV1().Leases("default").Get(t.Context(), "sklearn-predictor-activator", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(now), "the older requests are not recorded")
+	assert.Equal(t, "activator-0", *lease.Spec.HolderIdentity)
+
+	idle, err = activity.Idle(t.Context(), now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.False(t, idle)
+	idle, err = activity.Idle(t.Context(), now.Add(time.Second))
+	require.NoError(t, err)
+	assert.True(t, idle)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backpressure
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"knative.dev/pkg/network"
+)
+
+// Signal is a back-pressure signal the agent can expose on the responses
+type Signal string
+
+const (
+	// QueueDepthSignal is the number of the requests waiting in the queue of the runtime
+	QueueDepthSignal Signal = "queue-depth"
+	// EstimatedWaitSignal is the time the queued requests take to be served at the observed latency
+	EstimatedWaitSignal Signal = "estimated-wait"
+	// RetryAfterSignal is the standard Retry-After header of the 429 and 503 responses
+	RetryAfterSignal Signal = "retry-after"
+)
+
+// AllSignals are the signals exposed when none is configured
+var AllSignals = []Signal{QueueDepthSignal, EstimatedWaitSignal, RetryAfterSignal}
+
+// Back-pressure headers
+const (
+	QueueDepthHeader    = "X-Kserve-Queue-Depth"
+	EstimatedWaitHeader = "X-Kserve-Estimated-Wait-Ms"
+	RetryAfterHeader    = "Retry-After"
+)
+
+// latencyWeight is the weight of the last response in the moving average of the latency
+const latencyWeight = 0.2
+
+// Handler adds back-pressure headers to the responses of the component, derived from the queue of the runtime and the
+// latency of the successful responses observed by the agent, so that the clients can adapt their sending rate. The
+// queue depth falls back to the other requests in flight through the agent while the runtime reports no queue. The
+// headers are added to the 429 and 503 responses, and to the other responses as well when onSuccess is set.
+type Handler struct {
+	next      http.Handler
+	signals   map[Signal]bool
+	onSuccess bool
+	queue     *RuntimeQueue
+	inFlight  atomic.Int64
+	mu        sync.Mutex
+	latency   time.Duration
+}
+
+// ParseSignals returns the signals of the names, all the signals when there is no name
+func ParseSignals(names []string) ([]Signal, error) {
+	if len(names) == 0 {
+		return AllSignals, nil
+	}
+	signals := make([]Signal, 0, len(names))
+	for _, name := range names {
+		signal := Signal(name)
+		if signal != QueueDepthSignal && signal != EstimatedWaitSignal && signal != RetryAfterSignal {
+			return nil, fmt.Errorf("unknown back-pressure signal %q, expected one of %v", name, AllSignals)
+		}
+		signals = append(signals, signal)
+	}
+	return signals, nil
+}
+
+// NewHandler returns a handler exposing the signals on the responses of the next handler, the queue of the runtime is
+// optional
+func NewHandler(signals []Signal, onSuccess bool, queue *RuntimeQueue, next http.Handler) *Handler {
+	enabled := make(map[Signal]bool, len(signals))
+	for _, signal := range signals {
+		enabled[signal] = true
+	}
+	return &Handler{next: next, signals: enabled, onSuccess: onSuccess, queue: queue}
+}
+
+// signalWriter adds the back-pressure headers before the status code is written
+type signalWriter struct {
+	http.ResponseWriter
+	handler     *Handler
+	start       time.Time
+	wroteHeader bool
+}
+
+func (w *signalWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		// The rejections and the errors are answered without being served, they would lower the latency
+		if statusCode >= 200 && statusCode < 300 {
+			w.handler.observe(time.Since(w.start))
+		}
+		w.handler.addHeaders(w.Header(), statusCode)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *signalWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *signalWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	h.inFlight.Add(1)
+	defer h.inFlight.Add(-1)
+	h.next.ServeHTTP(&signalWriter{ResponseWriter: w, handler: h, start: time.Now()}, r)
+}
+
+// observe adds the latency of a successful response to the moving average
+func (h *Handler) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.latency == 0 {
+		h.latency = latency
+		return
+	}
+	h.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(h.latency))
+}
+
+// estimatedWait returns the time the queued requests take to be served one after the other
+func (h *Handler) estimatedWait(queueDepth int64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Duration(queueDepth) * h.latency
+}
+
+// queueDepth returns the depth of the queue of the runtime, the other requests in flight when the runtime reports none
+func (h *Handler) queueDepth() int64 {
+	if h.queue != nil {
+		if depth, ok := h.queue.Depth(); ok {
+			return depth
+		}
+	}
+	// The request being answered is not waiting
+	return max(h.inFlight.Load()-1, 0)
+}
+
+func (h *Handler) addHeaders(header http.Header, statusCode int) {
+	overloaded := statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+	if !overloaded && !h.onSuccess {
+		return
+	}
+	queueDepth := h.queueDepth()
+	wait := h.estimatedWait(queueDepth)
+	if h.signals[QueueDepthSignal] {
+		header.Set(QueueDepthHeader, strconv.FormatInt(queueDepth, 10))
+	}
+	if h.signals[EstimatedWaitSignal] {
+		header.Set(EstimatedWaitHeader, strconv.FormatInt(wait.Milliseconds(), 10))
+	}
+	// The Retry-After set by the component takes precedence
+	if overloaded && h.signals[RetryAfterSignal] && header.Get(RetryAfterHeader) == "" {
+		header.Set(RetryAfterHeader, strconv.Itoa(max(int(math.Ceil(wait.Seconds())), 1)))
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backpressure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func respond(statusCode int, retryAfter string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set(RetryAfterHeader, retryAfter)
+		}
+		w.WriteHeader(statusCode)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	scenarios := map[string]struct {
+		signals            []Signal
+		onSuccess          bool
+		statusCode         int
+		componentRetry     string
+		expectedQueueDepth string
+		expectedWait       string
+		expectedRetryAfter string
+	}{
+		"Overloaded": {
+			signals:            AllSignals,
+			statusCode:         http.StatusServiceUnavailable,
+			expectedQueueDepth: "3",
+			expectedWait:       "6000",
+			expectedRetryAfter: "6",
+		},
+		"TooManyRequestsWithComponentRetryAfter": {
+			signals:            AllSignals,
+			statusCode:         http.StatusTooManyRequests,
+			componentRetry:     "30",
+			expectedQueueDepth: "3",
+			expectedWait:       "6000",
+			expectedRetryAfter: "30",
+		},
+		"SuccessNotSignaled": {
+			signals:    AllSignals,
+			statusCode: http.StatusOK,
+		},
+		"SuccessSignaled": {
+			signals:            AllSignals,
+			onSuccess:          true,
+			statusCode:         http.StatusOK,
+			expectedQueueDepth: "3",
+			expectedWait:       "4800",
+		},
+		"RetryAfterOnly": {
+			signals:            []Signal{RetryAfterSignal},
+			statusCode:         http.StatusServiceUnavailable,
+			expectedRetryAfter: "6",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			h := NewHandler(scenario.signals, scenario.onSuccess, nil, respond(scenario.statusCode, scenario.componentRetry))
+			// 3 other requests are in flight, the component answered in 2s on average, only the immediate successful
+			// response lowers it
+			h.inFlight.Store(3)
+			h.latency = 2 * time.Second
+
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+			assert.Equal(t, scenario.statusCode, resp.Code)
+			assert.Equal(t, scenario.expectedQueueDepth, resp.Header().Get(QueueDepthHeader))
+			assert.Equal(t, scenario.expectedWait, resp.Header().Get(EstimatedWaitHeader))
+			assert.Equal(t, scenario.expectedRetryAfter, resp.Header().Get(RetryAfterHeader))
+			assert.Equal(t, int64(3), h.inFlight.Load())
+		})
+	}
+}
+
+func TestHandlerIdle(t *testing.T) {
+	h := NewHandler(AllSignals, false, nil, respond(http.StatusServiceUnavailable, ""))
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+	assert.Equal(t, "0", resp.Header().Get(QueueDepthHeader))
+	assert.Equal(t, "0", resp.Header().Get(EstimatedWaitHeader))
+	// the clients always wait before retrying
+	assert.Equal(t, "1", resp.Header().Get(RetryAfterHeader))
+}
+
+func TestHandlerRuntimeQueue(t *testing.T) {
+	runtime := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# TYPE vllm:num_requests_waiting gauge\n" +
+			"vllm:num_requests_waiting{model_name=\"a\"} 5\n" +
+			"vllm:num_requests_waiting{model_name=\"b\"} 2\n"))
+	}))
+	defer runtime.Close()
+	queue := NewRuntimeQueue(runtime.URL, DefaultQueueMetrics, DefaultSampleInterval, zap.NewNop().Sugar())
+	h := NewHandler(AllSignals, false, queue, respond(http.StatusServiceUnavailable, ""))
+	h.inFlight.Store(3)
+	h.latency = time.Second
+
+	// the requests in flight are used until the runtime is sampled
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+	assert.Equal(t, "3", resp.Header().Get(QueueDepthHeader))
+
+	require.NoError(t, queue.Sample(context.Background()))
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil))
+	assert.Equal(t, "7", resp.Header().Get(QueueDepthHeader))
+	assert.Equal(t, "7000", resp.Header().Get(EstimatedWaitHeader))
+	// the rejection does not change the latency
+	assert.Equal(t, time.Second, h.latency)
+}
+
+func TestRuntimeQueueNoMetric(t *testing.T) {
+	runtime := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# TYPE vllm:num_requests_running gauge\nvllm:num_requests_running 4\n"))
+	}))
+	defer runtime.Close()
+	queue := NewRuntimeQueue(runtime.URL, DefaultQueueMetrics, DefaultSampleInterval, zap.NewNop().Sugar())
+	require.Error(t, queue.Sample(context.Background()))
+	_, ok := queue.Depth()
+	assert.False(t, ok)
+}
+
+func TestParseSignals(t *testing.T) {
+	signals, err := ParseSignals(nil)
+	require.NoError(t, err)
+	assert.Equal(t, AllSignals, signals)
+
+	signals, err = ParseSignals([]string{"queue-depth", "retry-after"})
+	require.NoError(t, err)
+	assert.Equal(t, []Signal{QueueDepthSignal, RetryAfterSignal}, signals)
+
+	_, err = ParseSignals([]string{"queue-length"})
+	require.Error(t, err)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backpressure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+// DefaultQueueMetrics are the runtime metrics reporting the requests waiting to be scheduled, of vLLM, Triton and
+// the text generation inference server
+var DefaultQueueMetrics = []string{"vllm:num_requests_waiting", "nv_inference_pending_request_count", "tgi_queue_size"}
+
+// DefaultSampleInterval is the interval between the samples of the queue of the runtime
+const DefaultSampleInterval = time.Second
+
+// RuntimeQueue samples the depth of the queue of the runtime from its metrics, the first of the queue metrics the
+// runtime reports is used
+type RuntimeQueue struct {
+	metricsURL string
+	metrics    []string
+	interval   time.Duration
+	httpClient *http.Client
+	logger     *zap.SugaredLogger
+	// depth is the last sampled depth, negative while the runtime reports none of the queue metrics
+	depth atomic.Int64
+}
+
+// NewRuntimeQueue creates the sampler of the queue the runtime reports in the metrics served at the url
+func NewRuntimeQueue(metricsURL string, metrics []string, interval time.Duration, logger *zap.SugaredLogger) *RuntimeQueue {
+	q := &RuntimeQueue{
+		metricsURL: metricsURL,
+		metrics:    metrics,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: interval},
+		logger:     logger,
+	}
+	q.depth.Store(-1)
+	return q
+}
+
+// Run samples the queue of the runtime at every interval until the context is done
+func (q *RuntimeQueue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+	for {
+		if err := q.Sample(ctx); err != nil {
+			q.logger.Debugw("Failed to sample the queue of the runtime", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample scrapes the queue depth from the runtime metrics, the depth is unknown once a sample fails
+func (q *RuntimeQueue) Sample(ctx context.Context) error {
+	depth, err := q.scrape(ctx)
+	if err != nil {
+		q.depth.Store(-1)
+		return err
+	}
+	q.depth.Store(depth)
+	return nil
+}
+
+// Depth returns the last sampled depth of the queue of the runtime, and whether it is known
+func (q *RuntimeQueue) Depth() (int64, bool) {
+	depth := q.depth.Load()
+	return depth, depth >= 0
+}
+
+func (q *RuntimeQueue) scrape(ctx context.Context) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, q.metricsURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	// only the text format is parsed, do not let the runtime negotiate the OpenMetrics or protobuf formats
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := q.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range q.metrics {
+		if family, ok := families[name]; ok && len(family.GetMetric()) > 0 {
+			return int64(sum(family)), nil
+		}
+	}
+	return 0, fmt.Errorf("the runtime reports none of the queue metrics %v", q.metrics)
+}
+
+// sum returns the sum of the series of a gauge, for the runtimes reporting a queue per model
+func sum(family *io_prometheus_client.MetricFamily) float64 {
+	total := 0.0
+	for _, metric := range family.GetMetric() {
+		switch {
+		case metric.GetGauge() != nil:
+			total += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			total += metric.GetUntyped().GetValue()
+		}
+	}
+	return total
+}
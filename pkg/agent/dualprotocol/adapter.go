@@ -0,0 +1,435 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dualprotocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/agent/transcoding"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	v1ModelsPrefix = "/v1/models/"
+	v2ModelsPrefix = "/v2/models/"
+	// inputName and outputName name the single tensor of the translated v2 requests and responses
+	inputName  = "input-0"
+	outputName = "output-0"
+)
+
+// Adapter serves the REST protocol the component does not support by translating its requests to the native protocol
+// of the component, so that one predictor serves both the /v1/models/... and the /v2/models/... paths. The v1
+// instances are sent as a single v2 input tensor, and the v2 requests are translated when they have a single input.
+// The other requests are passed to next unchanged.
+type Adapter struct {
+	native constants.InferenceServiceProtocol
+	next   http.Handler
+	logger *zap.SugaredLogger
+}
+
+// NewAdapter returns a handler translating the requests of the other REST protocol to the native protocol, v1 or v2
+func NewAdapter(native constants.InferenceServiceProtocol, next http.Handler, logger *zap.SugaredLogger) (*Adapter, error) {
+	if native != constants.ProtocolV1 && native != constants.ProtocolV2 {
+		return nil, fmt.Errorf("unsupported native protocol %q, it must be %s or %s", native, constants.ProtocolV1, constants.ProtocolV2)
+	}
+	return &Adapter{
+		native: native,
+		next:   next,
+		logger: logger,
+	}, nil
+}
+
+func (a *Adapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch a.native {
+	case constants.ProtocolV2:
+		if name, action, ok := parseV1Path(path); ok {
+			switch {
+			case action == "predict" && r.Method == http.MethodPost:
+				a.v1Predict(w, r, name)
+				return
+			case action == "" && r.Method == http.MethodGet:
+				a.v1ModelReady(w, r, name)
+				return
+			}
+		}
+	case constants.ProtocolV1:
+		if name, action, ok := parseV2Path(path); ok {
+			switch {
+			case action == "infer" && r.Method == http.MethodPost:
+				a.v2Infer(w, r, name)
+				return
+			case action == "ready" && r.Method == http.MethodGet:
+				a.v2ModelReady(w, r, name)
+				return
+			}
+		}
+	}
+	a.next.ServeHTTP(w, r)
+}
+
+// parseV1Path parses /v1/models/{name}[:{action}]
+func parseV1Path(path string) (name string, action string, ok bool) {
+	if !strings.HasPrefix(path, v1ModelsPrefix) {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(path, v1ModelsPrefix)
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name, action = name[:i], name[i+1:]
+	}
+	return name, action, name != "" && !strings.Contains(name, "/")
+}
+
+// parseV2Path parses /v2/models/{name}/{action}, the versioned paths are not translated as the v1 protocol has no
+// model versions
+func parseV2Path(path string) (name string, action string, ok bool) {
+	if !strings.HasPrefix(path, v2ModelsPrefix) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(path, v2ModelsPrefix), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// v1Predict translates a v1 predict request to a v2 infer request of the component
+func (a *Adapter) v1Predict(w http.ResponseWriter, r *http.Request, name string) {
+	var body struct {
+		Instances any `json:"instances"`
+	}
+	if err := decode(r.Body, &body); err != nil || body.Instances == nil {
+		writeError(w, http.StatusBadRequest, "the v1 request must be a JSON object with instances")
+		return
+	}
+	input, err := toTensor(inputName, body.Instances)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid instances: "+err.Error())
+		return
+	}
+	resp := a.forward(r, http.MethodPost, constants.PredictPath(name, constants.ProtocolV2),
+		&transcoding.InferRequest{Inputs: []transcoding.InferTensor{input}})
+	if resp.Code != http.StatusOK {
+		copyResponse(w, resp)
+		return
+	}
+	inferResponse := &transcoding.InferResponse{}
+	if err := decode(resp.Body, inferResponse); err != nil {
+		a.badGateway(w, "the v2 response of the component is invalid", err)
+		return
+	}
+	predictions, err := toPredictions(inferResponse.Outputs)
+	if err != nil {
+		a.badGateway(w, "the v2 response of the component cannot be translated", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"predictions": predictions})
+}
+
+// v1ModelReady translates a v1 model status request to a v2 model ready request of the component
+func (a *Adapter) v1ModelReady(w http.ResponseWriter, r *http.Request, name string) {
+	resp := a.forward(r, http.MethodGet, constants.ModelReadyPath(name, constants.ProtocolV2), nil)
+	if resp.Code != http.StatusOK {
+		copyResponse(w, resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"name": name, "ready": true})
+}
+
+// v2Infer translates a v2 infer request with a single input to a v1 predict request of the component
+func (a *Adapter) v2Infer(w http.ResponseWriter, r *http.Request, name string) {
+	inferRequest := &transcoding.InferRequest{}
+	if err := decode(r.Body, inferRequest); err != nil {
+		writeError(w, http.StatusBadRequest, "the v2 request is not a valid infer request")
+		return
+	}
+	if len(inferRequest.Inputs) != 1 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("the v2 requests are translated to the v1 protocol when they have a single input, got %d", len(inferRequest.Inputs)))
+		return
+	}
+	input := inferRequest.Inputs[0]
+	if len(input.Shape) == 0 {
+		writeError(w, http.StatusBadRequest, "the input must have at least one dimension to be sent as v1 instances")
+		return
+	}
+	instances, err := reshape(flattenData(input.Data), input.Shape)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid input: "+err.Error())
+		return
+	}
+	resp := a.forward(r, http.MethodPost, constants.PredictPath(name, constants.ProtocolV1), map[string]any{"instances": instances})
+	if resp.Code != http.StatusOK {
+		copyResponse(w, resp)
+		return
+	}
+	var body struct {
+		Predictions any `json:"predictions"`
+	}
+	if err := decode(resp.Body, &body); err != nil || body.Predictions == nil {
+		a.badGateway(w, "the v1 response of the component has no predictions", err)
+		return
+	}
+	output, err := toTensor(outputName, body.Predictions)
+	if err != nil {
+		a.badGateway(w, "the v1 response of the component cannot be translated", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &transcoding.InferResponse{
+		ModelName: name,
+		ID:        inferRequest.ID,
+		Outputs:   []transcoding.InferTensor{output},
+	})
+}
+
+// v2ModelReady translates a v2 model ready request to a v1 model status request of the component
+func (a *Adapter) v2ModelReady(w http.ResponseWriter, r *http.Request, name string) {
+	resp := a.forward(r, http.MethodGet, constants.ModelReadyPath(name, constants.ProtocolV1), nil)
+	if resp.Code != http.StatusOK {
+		copyResponse(w, resp)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// forward sends the translated request to the component, keeping the headers of the original request
+func (a *Adapter) forward(r *http.Request, method string, path string, body any) *httptest.ResponseRecorder {
+	req := r.Clone(r.Context())
+	req.Method = method
+	req.URL.Path = path
+	req.URL.RawPath = ""
+	req.Header.Del("Content-Length")
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			resp := httptest.NewRecorder()
+			writeError(resp, http.StatusBadRequest, err.Error())
+			return resp
+		}
+		req.Body = io.NopCloser(bytes.NewReader(payload))
+		req.ContentLength = int64(len(payload))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp := httptest.NewRecorder()
+	a.next.ServeHTTP(resp, req)
+	return resp
+}
+
+func (a *Adapter) badGateway(w http.ResponseWriter, message string, err error) {
+	if err != nil {
+		message = message + ": " + err.Error()
+	}
+	a.logger.Errorw("Failed to translate the response of the component", "error", message)
+	writeError(w, http.StatusBadGateway, message)
+}
+
+// decode decodes a JSON body keeping the numbers as json.Number, so that the integers and floats are told apart
+func decode(body io.Reader, v any) error {
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// toTensor converts a nested JSON array to a v2 tensor, inferring its shape and datatype
+func toTensor(name string, value any) (transcoding.InferTensor, error) {
+	var shape []int64
+	for element := value; ; {
+		list, ok := element.([]any)
+		if !ok {
+			break
+		}
+		shape = append(shape, int64(len(list)))
+		if len(list) == 0 {
+			break
+		}
+		element = list[0]
+	}
+	data, err := flatten(value, shape)
+	if err != nil {
+		return transcoding.InferTensor{}, err
+	}
+	datatype, err := inferDatatype(data)
+	if err != nil {
+		return transcoding.InferTensor{}, err
+	}
+	return transcoding.InferTensor{Name: name, Shape: shape, Datatype: datatype, Data: data}, nil
+}
+
+// flatten returns the elements of a nested JSON array of the given shape in row-major order
+func flatten(value any, shape []int64) ([]any, error) {
+	if len(shape) == 0 {
+		if _, ok := value.([]any); ok {
+			return nil, errors.New("the nested arrays must have the same number of dimensions")
+		}
+		return []any{value}, nil
+	}
+	list, ok := value.([]any)
+	if !ok || int64(len(list)) != shape[0] {
+		return nil, errors.New("the nested arrays must have the same length in each dimension")
+	}
+	data := []any{}
+	for _, element := range list {
+		elements, err := flatten(element, shape[1:])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, elements...)
+	}
+	return data, nil
+}
+
+// inferDatatype returns the v2 datatype of the elements: BOOL, INT64 when all the numbers are integers, FP64 for the
+// other numbers or BYTES
+func inferDatatype(data []any) (string, error) {
+	datatype := ""
+	for _, element := range data {
+		elementType := ""
+		switch v := element.(type) {
+		case bool:
+			elementType = "BOOL"
+		case json.Number:
+			elementType = "INT64"
+			if _, err := strconv.ParseInt(v.String(), 10, 64); err != nil {
+				elementType = "FP64"
+			}
+		case string:
+			elementType = "BYTES"
+		default:
+			return "", fmt.Errorf("unsupported element %v, the elements must be booleans, numbers or strings", element)
+		}
+		switch {
+		case datatype == "" || datatype == elementType:
+			datatype = elementType
+		case (datatype == "INT64" && elementType == "FP64") || (datatype == "FP64" && elementType == "INT64"):
+			datatype = "FP64"
+		default:
+			return "", fmt.Errorf("the elements mix the %s and %s datatypes", datatype, elementType)
+		}
+	}
+	if datatype == "" {
+		datatype = "FP32"
+	}
+	return datatype, nil
+}
+
+// reshape converts the flat data of a v2 tensor to a nested JSON array of the given shape
+func reshape(data []any, shape []int64) (any, error) {
+	size := int64(1)
+	for _, dim := range shape {
+		if dim < 0 {
+			return nil, fmt.Errorf("invalid shape %v", shape)
+		}
+		size *= dim
+	}
+	if size != int64(len(data)) {
+		return nil, fmt.Errorf("the shape %v does not match the %d elements of the data", shape, len(data))
+	}
+	return nest(data, shape), nil
+}
+
+// flattenData flattens the data of a v2 tensor, which the protocol allows to be nested
+func flattenData(data []any) []any {
+	flat := make([]any, 0, len(data))
+	for _, element := range data {
+		if list, ok := element.([]any); ok {
+			flat = append(flat, flattenData(list)...)
+		} else {
+			flat = append(flat, element)
+		}
+	}
+	return flat
+}
+
+func nest(data []any, shape []int64) any {
+	if len(shape) == 0 {
+		return data[0]
+	}
+	list := make([]any, shape[0])
+	if shape[0] == 0 {
+		return list
+	}
+	stride := int64(len(data)) / shape[0]
+	for i := range list {
+		list[i] = nest(data[int64(i)*stride:int64(i+1)*stride], shape[1:])
+	}
+	return list
+}
+
+// toPredictions converts the outputs of a v2 response to v1 predictions. A single output is returned as a nested
+// array, the instances of multiple outputs are returned as objects keyed by the output names.
+func toPredictions(outputs []transcoding.InferTensor) (any, error) {
+	if len(outputs) == 0 {
+		return nil, errors.New("the response has no outputs")
+	}
+	if len(outputs) == 1 {
+		return reshape(flattenData(outputs[0].Data), outputs[0].Shape)
+	}
+	var predictions []map[string]any
+	for _, output := range outputs {
+		value, err := reshape(flattenData(output.Data), output.Shape)
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", output.Name, err)
+		}
+		instances, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("output %s has no batch dimension", output.Name)
+		}
+		if predictions == nil {
+			predictions = make([]map[string]any, len(instances))
+			for i := range predictions {
+				predictions[i] = map[string]any{}
+			}
+		}
+		if len(instances) != len(predictions) {
+			return nil, fmt.Errorf("the outputs have different batch sizes %d and %d", len(predictions), len(instances))
+		}
+		for i, instance := range instances {
+			predictions[i][output.Name] = instance
+		}
+	}
+	return predictions, nil
+}
+
+// copyResponse passes an unsuccessful response of the component to the client
+func copyResponse(w http.ResponseWriter, resp *httptest.ResponseRecorder) {
+	for key, values := range resp.Header() {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(resp.Code)
+	_, _ = w.Write(resp.Body.Bytes())
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
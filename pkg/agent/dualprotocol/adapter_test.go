@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dualprotocol
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// component fakes a model server, recording the last request it received
+type component struct {
+	path     string
+	body     string
+	status   int
+	response string
+}
+
+func (c *component) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.path, c.body = r.URL.Path, string(body)
+	w.WriteHeader(c.status)
+	_, _ = w.Write([]byte(c.response))
+}
+
+func serve(t *testing.T, native constants.InferenceServiceProtocol, c *component, method string, path string, body string) *httptest.ResponseRecorder {
+	adapter, err := NewAdapter(native, c, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	adapter.ServeHTTP(resp, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return resp
+}
+
+func TestAdapterV1ToV2(t *testing.T) {
+	c := &component{
+		status:   http.StatusOK,
+		response: `{"model_name": "sklearn", "outputs": [{"name": "predict", "shape": [2], "datatype": "INT64", "data": [1, 0]}]}`,
+	}
+	resp := serve(t, constants.ProtocolV2, c, http.MethodPost, "/v1/models/sklearn:predict",
+		`{"instances": [[6.8, 2.8, 4.8, 1.4], [6, 3, 4, 1]]}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "/v2/models/sklearn/infer", c.path)
+	assert.JSONEq(t, `{"inputs": [{"name": "input-0", "shape": [2, 4], "datatype": "FP64", "data": [6.8, 2.8, 4.8, 1.4, 6, 3, 4, 1]}]}`, c.body)
+	assert.JSONEq(t, `{"predictions": [1, 0]}`, resp.Body.String())
+
+	c.response = `{"model_name": "sklearn", "outputs": [
+		{"name": "label", "shape": [2], "datatype": "INT64", "data": [1, 0]},
+		{"name": "scores", "shape": [2, 2], "datatype": "FP32", "data": [0.2, 0.8, 0.9, 0.1]}]}`
+	resp = serve(t, constants.ProtocolV2, c, http.MethodPost, "/v1/models/sklearn:predict", `{"instances": [[1, 2], [3, 4]]}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"inputs": [{"name": "input-0", "shape": [2, 2], "datatype": "INT64", "data": [1, 2, 3, 4]}]}`, c.body)
+	assert.JSONEq(t, `{"predictions": [{"label": 1, "scores": [0.2, 0.8]}, {"label": 0, "scores": [0.9, 0.1]}]}`, resp.Body.String())
+
+	c.response = ""
+	resp = serve(t, constants.ProtocolV2, c, http.MethodGet, "/v1/models/sklearn", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "/v2/models/sklearn/ready", c.path)
+	assert.JSONEq(t, `{"name": "sklearn", "ready": true}`, resp.Body.String())
+
+	// the native v2 requests are passed through
+	resp = serve(t, constants.ProtocolV2, c, http.MethodGet, "/v2/models/sklearn/ready", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "/v2/models/sklearn/ready", c.path)
+	assert.Empty(t, resp.Body.String())
+}
+
+func TestAdapterV2ToV1(t *testing.T) {
+	c := &component{status: http.StatusOK, response: `{"predictions": [[0.2, 0.8], [0.9, 0.1]]}`}
+	resp := serve(t, constants.ProtocolV1, c, http.MethodPost, "/v2/models/sklearn/infer",
+		`{"id": "42", "inputs": [{"name": "input", "shape": [2, 2], "datatype": "FP32", "data": [1.5, 2, 3, 4]}]}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "/v1/models/sklearn:predict", c.path)
+	assert.JSONEq(t, `{"instances": [[1.5, 2], [3, 4]]}`, c.body)
+	assert.JSONEq(t, `{"model_name": "sklearn", "id": "42", "outputs": [{"name": "output-0", "shape": [2, 2], "datatype": "FP64", "data": [0.2, 0.8, 0.9, 0.1]}]}`,
+		resp.Body.String())
+
+	c.response = `{"name": "sklearn", "ready": true}`
+	resp = serve(t, constants.ProtocolV1, c, http.MethodGet, "/v2/models/sklearn/ready", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "/v1/models/sklearn", c.path)
+	assert.Empty(t, resp.Body.String())
+
+	// the versioned paths have no v1 equivalent and are passed through
+	serve(t, constants.ProtocolV1, c, http.MethodPost, "/v2/models/sklearn/versions/1/infer", `{"inputs": []}`)
+	assert.Equal(t, "/v2/models/sklearn/versions/1/infer", c.path)
+}
+
+func TestAdapterErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		native         constants.InferenceServiceProtocol
+		component      *component
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"MissingInstances": {
+			native:         constants.ProtocolV2,
+			component:      &component{status: http.StatusOK},
+			path:           "/v1/models/sklearn:predict",
+			body:           `{"inputs": [[1, 2]]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "the v1 request must be a JSON object with instances"}`,
+		},
+		"RaggedInstances": {
+			native:         constants.ProtocolV2,
+			component:      &component{status: http.StatusOK},
+			path:           "/v1/models/sklearn:predict",
+			body:           `{"instances": [[1, 2], [3]]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid instances: the nested arrays must have the same length in each dimension"}`,
+		},
+		"ComponentError": {
+			native:         constants.ProtocolV2,
+			component:      &component{status: http.StatusNotFound, response: `{"error": "Model with name sklearn does not exist."}`},
+			path:           "/v1/models/sklearn:predict",
+			body:           `{"instances": [[1, 2]]}`,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "Model with name sklearn does not exist."}`,
+		},
+		"InvalidComponentResponse": {
+			native:         constants.ProtocolV2,
+			component:      &component{status: http.StatusOK, response: `{"outputs": [{"name": "predict", "shape": [3], "datatype": "INT64", "data": [1, 0]}]}`},
+			path:           "/v1/models/sklearn:predict",
+			body:           `{"instances": [[1, 2]]}`,
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"error": "the v2 response of the component cannot be translated: the shape [3] does not match the 2 elements of the data"}`,
+		},
+		"MultipleInputs": {
+			native:    constants.ProtocolV1,
+			component: &component{status: http.StatusOK},
+			path:      "/v2/models/sklearn/infer",
+			body: `{"inputs": [{"name": "a", "shape": [1], "datatype": "INT64", "data": [1]},
+				{"name": "b", "shape": [1], "datatype": "INT64", "data": [2]}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "the v2 requests are translated to the v1 protocol when they have a single input, got 2"}`,
+		},
+		"MismatchedShape": {
+			native:         constants.ProtocolV1,
+			component:      &component{status: http.StatusOK},
+			path:           "/v2/models/sklearn/infer",
+			body:           `{"inputs": [{"name": "a", "shape": [2, 2], "datatype": "INT64", "data": [1, 2, 3]}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid input: the shape [2 2] does not match the 3 elements of the data"}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			resp := serve(t, scenario.native, scenario.component, http.MethodPost, scenario.path, scenario.body)
+			assert.Equal(t, scenario.expectedStatus, resp.Code)
+			assert.JSONEq(t, scenario.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestNewAdapter(t *testing.T) {
+	_, err := NewAdapter(constants.ProtocolGRPCV2, http.NotFoundHandler(), zap.NewNop().Sugar())
+	require.Error(t, err)
+}
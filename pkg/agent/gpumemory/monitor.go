@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpumemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+// The paths the agent serves the GPU memory telemetry on
+const (
+	StatusPath  = "/gpu-memory/status"
+	MetricsPath = "/gpu-memory/metrics"
+)
+
+// Default names of the runtime metrics reporting the GPU memory allocated to tensors and the GPU memory reserved by
+// the caching allocator of the runtime, summed over the GPUs
+const (
+	DefaultAllocatedMetric = "gpu_memory_allocated_bytes"
+	DefaultReservedMetric  = "gpu_memory_reserved_bytes"
+)
+
+// Reasons of the restart recommendations
+const (
+	FragmentedReason = "GPUMemoryFragmented"
+	LeakingReason    = "GPUMemoryLeaking"
+)
+
+// Config configures how the GPU memory of the runtime is sampled and when a restart is recommended
+type Config struct {
+	// MetricsURL is the url the runtime metrics are scraped from
+	MetricsURL      string
+	AllocatedMetric string
+	ReservedMetric  string
+	// Interval between the samples
+	Interval time.Duration
+	// Window is how long the samples are kept, the leak rate is estimated over the window
+	Window time.Duration
+	// FragmentationThreshold is the share of the reserved memory not allocated, which recommends a restart when every
+	// one of the last MinSamples samples exceeds it
+	FragmentationThreshold float64
+	// LeakThreshold is the share of the reserved memory the allocated memory grows by over the window, which
+	// recommends a restart when exceeded
+	LeakThreshold float64
+	// MinSamples is the number of samples required before a restart is recommended
+	MinSamples int
+}
+
+// DefaultConfig samples the GPU memory every minute over a day
+var DefaultConfig = Config{
+	AllocatedMetric:        DefaultAllocatedMetric,
+	ReservedMetric:         DefaultReservedMetric,
+	Interval:               time.Minute,
+	Window:                 24 * time.Hour,
+	FragmentationThreshold: 0.3,
+	LeakThreshold:          0.2,
+	MinSamples:             10,
+}
+
+// Report describes the GPU memory of the runtime and whether a restart is recommended
+type Report struct {
+	AllocatedBytes float64 `json:"allocatedBytes"`
+	ReservedBytes  float64 `json:"reservedBytes"`
+	// FragmentationRatio is the share of the reserved memory not allocated in the last sample
+	FragmentationRatio float64 `json:"fragmentationRatio"`
+	// LeakBytesPerHour is the growth rate of the allocated memory over the window
+	LeakBytesPerHour   float64 `json:"leakBytesPerHour"`
+	Samples            int     `json:"samples"`
+	RestartRecommended bool    `json:"restartRecommended"`
+	Reason             string  `json:"reason,omitempty"`
+	Message            string  `json:"message,omitempty"`
+}
+
+type sample struct {
+	time      time.Time
+	allocated float64
+	reserved  float64
+}
+
+// Monitor samples the GPU memory the runtime reports in its metrics, and tells the fragmentation of the memory
+// reserved by the caching allocator and the leaks of the allocated memory apart, which both degrade the long-running
+// runtimes until they are restarted
+type Monitor struct {
+	config     Config
+	httpClient *http.Client
+	logger     *zap.SugaredLogger
+	registry   *prometheus.Registry
+	now        func() time.Time
+
+	mu      sync.Mutex
+	samples []sample
+}
+
+// New creates the monitor of the GPU memory of the runtime
+func New(config Config, logger *zap.SugaredLogger) *Monitor {
+	m := &Monitor{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		registry:   prometheus.NewRegistry(),
+		now:        time.Now,
+	}
+	for _, gauge := range []struct {
+		name  string
+		help  string
+		value func(report Report) float64
+	}{
+		{"allocated_bytes", "GPU memory allocated by the runtime in the last sample", func(r Report) float64 { return r.AllocatedBytes }},
+		{"reserved_bytes", "GPU memory reserved by the runtime in the last sample", func(r Report) float64 { return r.ReservedBytes }},
+		{"fragmentation_ratio", "Share of the reserved GPU memory not allocated in the last sample", func(r Report) float64 { return r.FragmentationRatio }},
+		{"leak_bytes_per_hour", "Growth rate of the allocated GPU memory over the sampling window", func(r Report) float64 { return r.LeakBytesPerHour }},
+		{"restart_recommended", "Whether a restart of the runtime is recommended", func(r Report) float64 {
+			if r.RestartRecommended {
+				return 1
+			}
+			return 0
+		}},
+	} {
+		value := gauge.value
+		m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "kserve_agent",
+			Subsystem: "gpu_memory",
+			Name:      gauge.name,
+			Help:      gauge.help,
+		}, func() float64 { return value(m.Report()) }))
+	}
+	return m
+}
+
+// Run samples the GPU memory of the runtime at every interval until the context is done
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		if err := m.Sample(ctx); err != nil {
+			m.logger.Debugw("Failed to sample the GPU memory of the runtime", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample scrapes the GPU memory metrics of the runtime and drops the samples older than the window
+func (m *Monitor) Sample(ctx context.Context) error {
+	families, err := m.scrape(ctx)
+	if err != nil {
+		return err
+	}
+	allocated, ok := sum(families[m.config.AllocatedMetric])
+	if !ok {
+		return fmt.Errorf("the runtime does not report the metric %s", m.config.AllocatedMetric)
+	}
+	reserved, _ := sum(families[m.config.ReservedMetric])
+
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.samples = append(m.samples, sample{time: now, allocated: allocated, reserved: reserved})
+	expired := 0
+	for expired < len(m.samples) && now.Sub(m.samples[expired].time) > m.config.Window {
+		expired++
+	}
+	m.samples = m.samples[expired:]
+	return nil
+}
+
+func (m *Monitor) scrape(ctx context.Context) (map[string]*io_prometheus_client.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.config.MetricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// only the text format is parsed, do not let the runtime negotiate the OpenMetrics or protobuf formats
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// sum returns the sum of the series of a gauge, for the runtimes reporting the memory of every GPU
+func sum(family *io_prometheus_client.MetricFamily) (float64, bool) {
+	if family == nil || len(family.GetMetric()) == 0 {
+		return 0, false
+	}
+	total := 0.0
+	for _, metric := range family.GetMetric() {
+		switch {
+		case metric.GetGauge() != nil:
+			total += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			total += metric.GetUntyped().GetValue()
+		case metric.GetCounter() != nil:
+			total += metric.GetCounter().GetValue()
+		}
+	}
+	return total, true
+}
+
+// Report returns the GPU memory of the last sample and the indicators computed over the window
+func (m *Monitor) Report() Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := Report{Samples: len(m.samples)}
+	if len(m.samples) == 0 {
+		return report
+	}
+	last := m.samples[len(m.samples)-1]
+	report.AllocatedBytes = last.allocated
+	report.ReservedBytes = last.reserved
+	report.FragmentationRatio = fragmentation(last)
+	report.LeakBytesPerHour = slope(m.samples) * float64(time.Hour)
+	if len(m.samples) < m.config.MinSamples {
+		return report
+	}
+
+	fragmented := true
+	for _, s := range m.samples[len(m.samples)-m.config.MinSamples:] {
+		fragmented = fragmented && fragmentation(s) > m.config.FragmentationThreshold
+	}
+	span := last.time.Sub(m.samples[0].time)
+	growth := report.LeakBytesPerHour * span.Hours()
+	switch {
+	case fragmented:
+		report.RestartRecommended = true
+		report.Reason = FragmentedReason
+		report.Message = fmt.Sprintf("the fragmentation ratio of the reserved GPU memory is %.2f", report.FragmentationRatio)
+	case last.reserved > 0 && growth > m.config.LeakThreshold*last.reserved:
+		report.RestartRecommended = true
+		report.Reason = LeakingReason
+		report.Message = fmt.Sprintf("the allocated GPU memory grew by %.0f bytes per hour over %s", report.LeakBytesPerHour, span.Round(time.Minute))
+	}
+	return report
+}
+
+func fragmentation(s sample) float64 {
+	if s.reserved <= 0 || s.allocated >= s.reserved {
+		return 0
+	}
+	return (s.reserved - s.allocated) / s.reserved
+}
+
+// slope returns the least squares slope of the allocated memory over time, in bytes per nanosecond
+func slope(samples []sample) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	var meanX, meanY float64
+	for _, s := range samples {
+		meanX += float64(s.time.Sub(samples[0].time))
+		meanY += s.allocated
+	}
+	meanX /= float64(len(samples))
+	meanY /= float64(len(samples))
+	var covariance, variance float64
+	for _, s := range samples {
+		dx := float64(s.time.Sub(samples[0].time)) - meanX
+		covariance += dx * (s.allocated - meanY)
+		variance += dx * dx
+	}
+	if variance == 0 {
+		return 0
+	}
+	return covariance / variance
+}
+
+// Handler serves the GPU memory report and metrics of the runtime, and passes the other requests to the next handler
+func (m *Monitor) Handler(next http.Handler) http.Handler {
+	metricsHandler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StatusPath:
+			rw.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(rw).Encode(m.Report()); err != nil {
+				m.logger.Errorw("Failed to write the GPU memory report", zap.Error(err))
+			}
+		case MetricsPath:
+			metricsHandler.ServeHTTP(rw, r)
+		default:
+			next.ServeHTTP(rw, r)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpumemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeRuntime reports the GPU memory of two GPUs, each holding half of the memory
+type fakeRuntime struct {
+	mu        sync.Mutex
+	allocated float64
+	reserved  float64
+}
+
+func (f *fakeRuntime) set(allocated, reserved float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.allocated, f.reserved = allocated, reserved
+}
+
+func (f *fakeRuntime) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, gpu := range []string{"0", "1"} {
+		fmt.Fprintf(rw, "gpu_memory_allocated_bytes{gpu=%q} %f\n", gpu, f.allocated/2)
+		fmt.Fprintf(rw, "gpu_memory_reserved_bytes{gpu=%q} %f\n", gpu, f.reserved/2)
+	}
+}
+
+func newTestMonitor(t *testing.T) (*Monitor, *fakeRuntime, *time.Time) {
+	runtime := &fakeRuntime{}
+	server := httptest.NewServer(runtime)
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig
+	config.MetricsURL = server.URL
+	config.Window = time.Hour
+	config.MinSamples = 3
+	m := New(config, zap.NewNop().Sugar())
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m, runtime, &now
+}
+
+func TestReport(t *testing.T) {
+	const gib = float64(1 << 30)
+	scenarios := map[string]struct {
+		// samples are the allocated and reserved memory, sampled every 10 minutes
+		samples            [][2]float64
+		restartRecommended bool
+		reason             string
+	}{
+		"Healthy": {
+			samples: [][2]float64{{8 * gib, 10 * gib}, {8 * gib, 10 * gib}, {8 * gib, 10 * gib}},
+		},
+		"TooFewSamples": {
+			samples: [][2]float64{{2 * gib, 10 * gib}, {2 * gib, 10 * gib}},
+		},
+		"Fragmented": {
+			samples:            [][2]float64{{8 * gib, 10 * gib}, {5 * gib, 10 * gib}, {5 * gib, 10 * gib}, {6 * gib, 10 * gib}},
+			restartRecommended: true,
+			reason:             FragmentedReason,
+		},
+		"FragmentationRecovered": {
+			samples: [][2]float64{{5 * gib, 10 * gib}, {5 * gib, 10 * gib}, {5 * gib, 6 * gib}},
+		},
+		"Leaking": {
+			samples:            [][2]float64{{7 * gib, 10 * gib}, {8 * gib, 10 * gib}, {9 * gib, 10 * gib}, {10 * gib, 10 * gib}},
+			restartRecommended: true,
+			reason:             LeakingReason,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			m, runtime, now := newTestMonitor(t)
+			for _, s := range scenario.samples {
+				runtime.set(s[0], s[1])
+				require.NoError(t, m.Sample(context.Background()))
+				*now = now.Add(10 * time.Minute)
+			}
+			report := m.Report()
+			last := scenario.samples[len(scenario.samples)-1]
+			assert.Equal(t, len(scenario.samples), report.Samples)
+			assert.InDelta(t, last[0], report.AllocatedBytes, 1)
+			assert.InDelta(t, last[1], report.ReservedBytes, 1)
+			assert.Equal(t, scenario.restartRecommended, report.RestartRecommended)
+			assert.Equal(t, scenario.reason, report.Reason)
+		})
+	}
+}
+
+func TestSampleWindow(t *testing.T) {
+	m, runtime, now := newTestMonitor(t)
+	runtime.set(1<<30, 1<<30)
+	for range 10 {
+		require.NoError(t, m.Sample(context.Background()))
+		*now = now.Add(20 * time.Minute)
+	}
+	// the samples older than the window of an hour are dropped
+	assert.Equal(t, 4, m.Report().Samples)
+	assert.Zero(t, m.Report().LeakBytesPerHour)
+}
+
+func TestSampleMissingMetric(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(rw, "requests_total 3")
+	}))
+	defer server.Close()
+	config := DefaultConfig
+	config.MetricsURL = server.URL
+	m := New(config, zap.NewNop().Sugar())
+	require.ErrorContains(t, m.Sample(context.Background()), "does not report the metric gpu_memory_allocated_bytes")
+	assert.Zero(t, m.Report().Samples)
+}
+
+func TestHandler(t *testing.T) {
+	m, runtime, _ := newTestMonitor(t)
+	runtime.set(3<<30, 4<<30)
+	require.NoError(t, m.Sample(context.Background()))
+	server := httptest.NewServer(m.Handler(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + StatusPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var report Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.InDelta(t, 0.25, report.FragmentationRatio, 0.001)
+	assert.Equal(t, 1, report.Samples)
+
+	resp, err = http.Get(server.URL + MetricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "kserve_agent_gpu_memory_fragmentation_ratio 0.25")
+	assert.Contains(t, string(body), "kserve_agent_gpu_memory_restart_recommended 0")
+
+	resp, err = http.Get(server.URL + "/v1/models/test:predict")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+}
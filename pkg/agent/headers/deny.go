@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"go.uber.org/zap"
+)
+
+// DenyHandler strips the sensitive request headers before the requests reach the component, so that only the
+// client headers which are safe to share, e.g. the correlation headers, flow through the transformer, predictor
+// and explainer.
+type DenyHandler struct {
+	next     http.Handler
+	patterns []*regexp.Regexp
+	logger   *zap.SugaredLogger
+}
+
+// NewDenyHandler returns a handler stripping the request headers matching one of the patterns, which are either
+// exact header names or regular expressions like the propagate patterns of the router
+func NewDenyHandler(patterns []string, next http.Handler, logger *zap.SugaredLogger) (*DenyHandler, error) {
+	var allErrors []error
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		c, err := regexp.Compile(p)
+		if err != nil {
+			allErrors = append(allErrors, fmt.Errorf("failed to compile pattern %q: %w", p, err))
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	if err := errors.Join(allErrors...); err != nil {
+		return nil, err
+	}
+	return &DenyHandler{next: next, patterns: compiled, logger: logger}, nil
+}
+
+// Denied returns whether the header is stripped from the requests
+func (h *DenyHandler) Denied(header string) bool {
+	for _, p := range h.patterns {
+		if p.MatchString(header) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *DenyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for header := range r.Header {
+		if h.Denied(header) {
+			h.logger.Debugf("Stripping the denied header %s", header)
+			r.Header.Del(header)
+		}
+	}
+	h.next.ServeHTTP(w, r)
+}
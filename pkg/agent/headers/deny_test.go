@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+func TestDenyHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var received http.Header
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	})
+	handler, err := NewDenyHandler([]string{"^Cookie$", "^Proxy-Authorization$", "-Token$"}, next, zap.NewNop().Sugar())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "1234")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("Set-Cookie-Name", "session")
+	req.Header.Set("Proxy-Authorization", "Basic secret")
+	req.Header.Set("X-Session-Token", "secret")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	g.Expect(received).To(gomega.Equal(http.Header{
+		"Content-Type":    {"application/json"},
+		"X-Request-Id":    {"1234"},
+		"Set-Cookie-Name": {"session"},
+	}))
+}
+
+func TestNewDenyHandlerInvalidPattern(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := NewDenyHandler([]string{"^Cookie$", "*Token"}, http.NotFoundHandler(), zap.NewNop().Sugar())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`failed to compile pattern "*Token"`)))
+}
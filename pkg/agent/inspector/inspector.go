@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"knative.dev/pkg/network"
+)
+
+const (
+	// Path is the debug endpoint listing the sampled requests, newest first
+	Path = "/debug/requests"
+	// DefaultMaxBodyBytes is the default size the bodies are truncated to
+	DefaultMaxBodyBytes = 1024
+	// MaxCaptureBytes bounds the bytes of a body buffered for the redaction, the larger bodies are omitted
+	MaxCaptureBytes = 64 * 1024
+	// Redacted replaces the values of the redacted fields
+	Redacted = "[REDACTED]"
+)
+
+// DefaultRedactPatterns match the keys of the JSON fields redacted from the bodies by default
+var DefaultRedactPatterns = []string{"(?i)password", "(?i)secret", "(?i)token", "(?i)api[-_]?key", "(?i)authorization"}
+
+// Summary describes a sampled request and its response
+type Summary struct {
+	Time          time.Time `json:"time"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	Status        int       `json:"status"`
+	LatencyMillis int64     `json:"latencyMillis"`
+	RequestBytes  int64     `json:"requestBytes"`
+	ResponseBytes int64     `json:"responseBytes"`
+	// RequestBody and ResponseBody are the redacted bodies truncated to the max body size, omitted when they are
+	// not JSON or too large to be redacted
+	RequestBody  string `json:"requestBody,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+}
+
+// Inspector keeps the summaries of the last sampled requests in a ring buffer, so that the traffic of a component
+// can be inspected without enabling the payload logging
+type Inspector struct {
+	next           http.Handler
+	percent        int
+	maxBodyBytes   int
+	redactPatterns []*regexp.Regexp
+	random         func() float64
+
+	mu        sync.Mutex
+	summaries []Summary
+	// position is the index of the ring buffer the next summary is written to
+	position int
+	full     bool
+}
+
+// New returns a handler keeping the summaries of the last size requests, sampled with the given percentage
+func New(size int, percent int, maxBodyBytes int, redactPatterns []string, next http.Handler) (*Inspector, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("the number of inspected requests must be positive, got %d", size)
+	}
+	if percent < 0 || percent > 100 {
+		return nil, fmt.Errorf("the inspector sampling percent must be between 0 and 100, got %d", percent)
+	}
+	if maxBodyBytes < 0 {
+		return nil, fmt.Errorf("the inspector max body bytes must not be negative, got %d", maxBodyBytes)
+	}
+	compiled := make([]*regexp.Regexp, 0, len(redactPatterns))
+	for _, pattern := range redactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return &Inspector{
+		next:           next,
+		percent:        percent,
+		maxBodyBytes:   maxBodyBytes,
+		redactPatterns: compiled,
+		random:         rand.Float64,
+		summaries:      make([]Summary, size),
+	}, nil
+}
+
+// captureReader records the first bytes read from a body and counts the bytes read
+type captureReader struct {
+	io.ReadCloser
+	captured bytes.Buffer
+	size     int64
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.size += int64(n)
+	if remaining := MaxCaptureBytes + 1 - r.captured.Len(); remaining > 0 {
+		r.captured.Write(p[:min(n, remaining)])
+	}
+	return n, err
+}
+
+// captureWriter records the status code and the first bytes written to a response and counts the bytes written
+type captureWriter struct {
+	http.ResponseWriter
+	statusCode int
+	captured   bytes.Buffer
+	size       int64
+}
+
+func (w *captureWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+	if remaining := MaxCaptureBytes + 1 - w.captured.Len(); remaining > 0 {
+		w.captured.Write(p[:min(n, remaining)])
+	}
+	return n, err
+}
+
+func (w *captureWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (i *Inspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) || i.random()*100 >= float64(i.percent) {
+		i.next.ServeHTTP(w, r)
+		return
+	}
+	start := time.Now()
+	reader := &captureReader{ReadCloser: r.Body}
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = reader
+	}
+	writer := &captureWriter{ResponseWriter: w, statusCode: http.StatusOK}
+	i.next.ServeHTTP(writer, r)
+
+	i.record(Summary{
+		Time:          start.UTC(),
+		Method:        r.Method,
+		Path:          r.URL.Path,
+		Status:        writer.statusCode,
+		LatencyMillis: time.Since(start).Milliseconds(),
+		RequestBytes:  reader.size,
+		ResponseBytes: writer.size,
+		RequestBody:   i.redact(reader.captured.Bytes()),
+		ResponseBody:  i.redact(writer.captured.Bytes()),
+	})
+}
+
+func (i *Inspector) record(summary Summary) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.summaries[i.position] = summary
+	i.position = (i.position + 1) % len(i.summaries)
+	if i.position == 0 {
+		i.full = true
+	}
+}
+
+// Summaries returns the summaries of the sampled requests, newest first
+func (i *Inspector) Summaries() []Summary {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	count := i.position
+	if i.full {
+		count = len(i.summaries)
+	}
+	summaries := make([]Summary, 0, count)
+	for n := 1; n <= count; n++ {
+		summaries = append(summaries, i.summaries[(i.position-n+len(i.summaries))%len(i.summaries)])
+	}
+	return summaries
+}
+
+// Handler returns the debug endpoint listing the sampled requests
+func (i *Inspector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string][]Summary{"requests": i.Summaries()}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// redact returns the body with the values of the fields matching the redact patterns replaced, truncated to the
+// max body size. The bodies which are not JSON or too large to be parsed are omitted as they can not be redacted.
+func (i *Inspector) redact(body []byte) string {
+	if len(body) == 0 || len(body) > MaxCaptureBytes || i.maxBodyBytes == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return ""
+	}
+	redacted, err := json.Marshal(i.redactValue(value))
+	if err != nil {
+		return ""
+	}
+	if len(redacted) > i.maxBodyBytes {
+		return strings.ToValidUTF8(string(redacted[:i.maxBodyBytes]), "") + "..."
+	}
+	return string(redacted)
+}
+
+func (i *Inspector) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if i.redacted(key) {
+				v[key] = Redacted
+			} else {
+				v[key] = i.redactValue(field)
+			}
+		}
+	case []interface{}:
+		for index, item := range v {
+			v[index] = i.redactValue(item)
+		}
+	}
+	return value
+}
+
+func (i *Inspector) redacted(key string) bool {
+	for _, re := range i.redactPatterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
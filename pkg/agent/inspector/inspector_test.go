@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspector
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func newTestInspector(t *testing.T, size int, maxBodyBytes int) *Inspector {
+	component := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "fail") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(`{"predictions": [1], "token": "abc"}`))
+	})
+	inspector, err := New(size, 100, maxBodyBytes, DefaultRedactPatterns, component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inspector
+}
+
+func send(inspector *Inspector, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/models/iris:predict", strings.NewReader(body)))
+	return recorder
+}
+
+func TestInspectorSummaries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inspector := newTestInspector(t, 2, DefaultMaxBodyBytes)
+
+	g.Expect(inspector.Summaries()).To(gomega.BeEmpty())
+	recorder := send(inspector, `{"instances": [1], "apiKey": "secret-value"}`)
+	g.Expect(recorder.Body.String()).To(gomega.Equal(`{"predictions": [1], "token": "abc"}`))
+
+	summaries := inspector.Summaries()
+	g.Expect(summaries).To(gomega.HaveLen(1))
+	g.Expect(summaries[0].Method).To(gomega.Equal(http.MethodPost))
+	g.Expect(summaries[0].Path).To(gomega.Equal("/v1/models/iris:predict"))
+	g.Expect(summaries[0].Status).To(gomega.Equal(http.StatusOK))
+	g.Expect(summaries[0].RequestBytes).To(gomega.Equal(int64(len(`{"instances": [1], "apiKey": "secret-value"}`))))
+	g.Expect(summaries[0].ResponseBytes).To(gomega.Equal(int64(len(`{"predictions": [1], "token": "abc"}`))))
+	g.Expect(summaries[0].RequestBody).To(gomega.Equal(`{"apiKey":"[REDACTED]","instances":[1]}`))
+	g.Expect(summaries[0].ResponseBody).To(gomega.Equal(`{"predictions":[1],"token":"[REDACTED]"}`))
+
+	// the oldest summaries are overwritten once the ring buffer is full
+	send(inspector, `{"instances": ["fail"]}`)
+	send(inspector, `not json`)
+	summaries = inspector.Summaries()
+	g.Expect(summaries).To(gomega.HaveLen(2))
+	g.Expect(summaries[0].Status).To(gomega.Equal(http.StatusOK))
+	g.Expect(summaries[0].RequestBody).To(gomega.BeEmpty())
+	g.Expect(summaries[1].Status).To(gomega.Equal(http.StatusBadRequest))
+	g.Expect(summaries[1].RequestBody).To(gomega.Equal(`{"instances":["fail"]}`))
+}
+
+func TestInspectorTruncatesBodies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inspector := newTestInspector(t, 1, 10)
+	send(inspector, `{"instances": [1, 2, 3, 4, 5]}`)
+	g.Expect(inspector.Summaries()[0].RequestBody).To(gomega.Equal(`{"instance...`))
+}
+
+func TestInspectorSampling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inspector := newTestInspector(t, 10, DefaultMaxBodyBytes)
+	inspector.percent = 50
+	inspector.random = func() float64 { return 0.6 }
+	send(inspector, `{}`)
+	g.Expect(inspector.Summaries()).To(gomega.BeEmpty())
+	inspector.random = func() float64 { return 0.4 }
+	send(inspector, `{}`)
+	g.Expect(inspector.Summaries()).To(gomega.HaveLen(1))
+}
+
+func TestInspectorHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	inspector := newTestInspector(t, 10, DefaultMaxBodyBytes)
+	send(inspector, `{"instances": [1]}`)
+
+	recorder := httptest.NewRecorder()
+	inspector.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	response := map[string][]Summary{}
+	g.Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(gomega.Succeed())
+	g.Expect(response["requests"]).To(gomega.HaveLen(1))
+
+	recorder = httptest.NewRecorder()
+	inspector.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, Path, nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusMethodNotAllowed))
+}
+
+func TestNewInspectorValidation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := New(0, 100, DefaultMaxBodyBytes, nil, http.NotFoundHandler())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("must be positive")))
+	_, err = New(10, 101, DefaultMaxBodyBytes, nil, http.NotFoundHandler())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("between 0 and 100")))
+	_, err = New(10, 100, DefaultMaxBodyBytes, []string{"("}, http.NotFoundHandler())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid redact pattern")))
+}
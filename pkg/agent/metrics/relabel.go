@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// The label set every per-model metric carries once relabeled, regardless of the runtime which emitted it.
+const (
+	ModelLabel            = "model"
+	VersionLabel          = "version"
+	InferenceServiceLabel = "isvc"
+)
+
+// LabelContract lists the labels the runtimes use for the model name and version, in order of precedence.
+type LabelContract struct {
+	ModelLabels   []string
+	VersionLabels []string
+}
+
+// DefaultLabelContract covers the labels emitted by Triton (model, version), MLServer, vLLM and the KServe
+// model server (model_name, model_version) and TorchServe (ModelName, ModelVersion).
+var DefaultLabelContract = LabelContract{
+	ModelLabels:   []string{"model", "model_name", "ModelName"},
+	VersionLabels: []string{"version", "model_version", "ModelVersion"},
+}
+
+// RelabelHandler scrapes the metrics of the component and serves them with the per-model labels normalized
+// to the label contract.
+type RelabelHandler struct {
+	componentURL     string
+	inferenceService string
+	contract         LabelContract
+	httpClient       *http.Client
+	logger           *zap.SugaredLogger
+}
+
+func NewRelabelHandler(componentURL string, inferenceService string, logger *zap.SugaredLogger) *RelabelHandler {
+	return &RelabelHandler{
+		componentURL:     componentURL,
+		inferenceService: inferenceService,
+		contract:         DefaultLabelContract,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+		logger:           logger,
+	}
+}
+
+func (h *RelabelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	families, err := h.scrape(r)
+	if err != nil {
+		h.logger.Errorw("Failed to scrape the component metrics", "url", h.componentURL, zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, family := range families {
+		Relabel(family, h.contract, h.inferenceService)
+		if _, err := expfmt.MetricFamilyToText(&buf, family); err != nil {
+			h.logger.Errorw("Failed to encode the metrics", "metric", family.GetName(), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		h.logger.Errorw("Failed to write the metrics", zap.Error(err))
+	}
+}
+
+// scrape returns the metric families of the component sorted by name
+func (h *RelabelHandler) scrape(r *http.Request) ([]*io_prometheus_client.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, h.componentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// only the text format is parsed, do not let the runtime negotiate the OpenMetrics or protobuf formats
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	familiesByName, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics: %w", err)
+	}
+	families := make([]*io_prometheus_client.MetricFamily, 0, len(familiesByName))
+	for _, family := range familiesByName {
+		families = append(families, family)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	return families, nil
+}
+
+// Relabel renames the model name and version labels of the metrics to the model and version labels of the
+// contract and adds the isvc label. The metrics which do not carry a model label are only given the isvc label.
+func Relabel(family *io_prometheus_client.MetricFamily, contract LabelContract, inferenceService string) {
+	for _, metric := range family.GetMetric() {
+		labels := make([]*io_prometheus_client.LabelPair, 0, len(metric.GetLabel())+1)
+		values := make(map[string]string, len(metric.GetLabel()))
+		for _, label := range metric.GetLabel() {
+			values[label.GetName()] = label.GetValue()
+		}
+		model, hasModel := firstLabel(values, contract.ModelLabels)
+		version, hasVersion := firstLabel(values, contract.VersionLabels)
+		for _, label := range metric.GetLabel() {
+			name := label.GetName()
+			if name == InferenceServiceLabel ||
+				(hasModel && slices.Contains(contract.ModelLabels, name)) ||
+				(hasVersion && slices.Contains(contract.VersionLabels, name)) {
+				continue
+			}
+			labels = append(labels, label)
+		}
+		if hasModel {
+			labels = append(labels, labelPair(ModelLabel, model))
+		}
+		if hasVersion {
+			labels = append(labels, labelPair(VersionLabel, version))
+		}
+		if inferenceService != "" {
+			labels = append(labels, labelPair(InferenceServiceLabel, inferenceService))
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			return labels[i].GetName() < labels[j].GetName()
+		})
+		metric.Label = labels
+	}
+}
+
+func firstLabel(values map[string]string, names []string) (string, bool) {
+	for _, name := range names {
+		if value, ok := values[name]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func labelPair(name string, value string) *io_prometheus_client.LabelPair {
+	return &io_prometheus_client.LabelPair{Name: proto.String(name), Value: proto.String(value)}
+}
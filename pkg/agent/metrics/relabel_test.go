@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+func TestRelabelHandler(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	scenarios := map[string]struct {
+		metrics  string
+		expected string
+	}{
+		"triton": {
+			metrics: `# HELP nv_inference_request_success Number of successful inference requests
+# TYPE nv_inference_request_success counter
+nv_inference_request_success{model="densenet_onnx",version="1"} 12
+`,
+			expected: `# HELP nv_inference_request_success Number of successful inference requests
+# TYPE nv_inference_request_success counter
+nv_inference_request_success{isvc="my-isvc",model="densenet_onnx",version="1"} 12
+`,
+		},
+		"mlserver": {
+			metrics: `# HELP model_infer_request_success Model infer request success count
+# TYPE model_infer_request_success counter
+model_infer_request_success{model_name="iris",model_version="v0.1.0"} 3
+`,
+			expected: `# HELP model_infer_request_success Model infer request success count
+# TYPE model_infer_request_success counter
+model_infer_request_success{isvc="my-isvc",model="iris",version="v0.1.0"} 3
+`,
+		},
+		"vllm": {
+			metrics: `# HELP vllm:num_requests_running Number of requests currently running on GPU.
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{engine="0",model_name="llama"} 2
+`,
+			expected: `# HELP vllm:num_requests_running Number of requests currently running on GPU.
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{engine="0",isvc="my-isvc",model="llama"} 2
+`,
+		},
+		"torchserve": {
+			metrics: `# HELP ts_inference_requests_total Total number of inference requests.
+# TYPE ts_inference_requests_total counter
+ts_inference_requests_total{ModelName="mnist",ModelVersion="1.0",Hostname="pod"} 5
+`,
+			expected: `# HELP ts_inference_requests_total Total number of inference requests.
+# TYPE ts_inference_requests_total counter
+ts_inference_requests_total{Hostname="pod",isvc="my-isvc",model="mnist",version="1.0"} 5
+`,
+		},
+		"metrics without model label": {
+			metrics: `# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total 1.5
+# TYPE up gauge
+up{isvc="other"} 1
+`,
+			expected: `# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total{isvc="my-isvc"} 1.5
+# TYPE up gauge
+up{isvc="my-isvc"} 1
+`,
+		},
+		"canonical label takes precedence": {
+			metrics: `# TYPE requests counter
+requests{model="canonical",model_name="alias"} 1
+`,
+			expected: `# TYPE requests counter
+requests{isvc="my-isvc",model="canonical"} 1
+`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			component := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(scenario.metrics))
+			}))
+			defer component.Close()
+
+			handler := NewRelabelHandler(component.URL+"/metrics", "my-isvc", logger)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			body, _ := io.ReadAll(recorder.Body)
+			g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+			g.Expect(string(body)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestRelabelHandlerComponentError(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	component := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer component.Close()
+
+	handler := NewRelabelHandler(component.URL+"/metrics", "my-isvc", zap.NewNop().Sugar())
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusBadGateway))
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"sync"
 	"syscall"
 
@@ -45,6 +46,11 @@ type Puller struct {
 	waitGroup   WaitGroupWrapper
 	Downloader  *Downloader
 	logger      *zap.SugaredLogger
+	// startupPriority is the priority of the models loaded on startup in flight, the startup loads of lower priority
+	// are deferred until they complete
+	startupPriority int32
+	startupInFlight int
+	deferredOps     []*ModelOp
 }
 
 type ModelOp struct {
@@ -52,6 +58,8 @@ type ModelOp struct {
 	ModelName string
 	Op        OpType
 	Spec      *v1alpha1.ModelSpec
+	// Priority of the model, the models added on startup are loaded by decreasing priority
+	Priority int32
 }
 
 type WaitGroupWrapper struct {
@@ -84,7 +92,7 @@ func (p *Puller) processCommands(commands <-chan ModelOp) {
 		select {
 		case modelOp, ok := <-commands:
 			if ok {
-				p.enqueueModelOp(&modelOp)
+				p.scheduleModelOp(&modelOp)
 			} else {
 				commands = nil
 			}
@@ -99,6 +107,43 @@ type ModelChannel struct {
 	opsInFlight int
 }
 
+// scheduleModelOp enqueues the model op, deferring the models added on startup until the models of higher priority are
+// loaded so that the most critical models are served first
+func (p *Puller) scheduleModelOp(modelOp *ModelOp) {
+	if modelOp.OnStartup && modelOp.Op == Add {
+		if p.startupInFlight > 0 && modelOp.Priority < p.startupPriority {
+			p.deferredOps = append(p.deferredOps, modelOp)
+			return
+		}
+		if p.startupInFlight == 0 {
+			p.startupPriority = modelOp.Priority
+		}
+		p.startupInFlight += 1
+	}
+	p.enqueueModelOp(modelOp)
+}
+
+// releaseDeferredOps enqueues the deferred startup loads of the highest priority once the startup loads in flight
+// completed
+func (p *Puller) releaseDeferredOps() {
+	if p.startupInFlight > 0 || len(p.deferredOps) == 0 {
+		return
+	}
+	slices.SortStableFunc(p.deferredOps, func(a, b *ModelOp) int {
+		return int(b.Priority) - int(a.Priority)
+	})
+	next := 0
+	for next < len(p.deferredOps) && p.deferredOps[next].Priority == p.deferredOps[0].Priority {
+		next++
+	}
+	released := p.deferredOps[:next]
+	p.deferredOps = p.deferredOps[next:]
+	p.logger.Infof("loading the %d startup models of priority %d", len(released), released[0].Priority)
+	for _, modelOp := range released {
+		p.scheduleModelOp(modelOp)
+	}
+}
+
 func (p *Puller) enqueueModelOp(modelOp *ModelOp) {
 	modelChan, ok := p.channelMap[modelOp.ModelName]
 	if !ok {
@@ -129,7 +174,7 @@ func (p *Puller) modelOpComplete(modelOp *ModelOp, closed bool) {
 		if modelChan.opsInFlight == 0 {
 			close(modelChan.modelOps)
 			delete(p.channelMap, modelOp.ModelName)
-			if closed && len(p.channelMap) == 0 {
+			if closed && len(p.channelMap) == 0 && len(p.deferredOps) == 0 {
 				// this was the final completion, close the channel
 				close(p.completions)
 			}
@@ -138,6 +183,10 @@ func (p *Puller) modelOpComplete(modelOp *ModelOp, closed bool) {
 	} else {
 		p.logger.Infof("Op completion event did not find channel for %s", modelOp.ModelName)
 	}
+	if modelOp.OnStartup && modelOp.Op == Add {
+		p.startupInFlight -= 1
+		p.releaseDeferredOps()
+	}
 }
 
 func (p *Puller) modelProcessor(modelName string, ops <-chan *ModelOp) {
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sampling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"go.uber.org/zap"
+	"knative.dev/pkg/network"
+
+	kfslogger "github.com/kserve/kserve/pkg/logger"
+)
+
+const (
+	predictVerb = ":predict"
+	explainVerb = ":explain"
+	// MaxInFlightExplanations bounds the explanations computed at the same time, the sampled requests are dropped
+	// while the explainer is busy so that the sampling never slows down the predictions
+	MaxInFlightExplanations = 10
+	// ExplanationTimeout is the timeout of the requests to the explainer
+	ExplanationTimeout = 60 * time.Second
+)
+
+// ExplanationHandler receives the explanations of the sampled prediction requests, with the id of the request
+type ExplanationHandler func(id string, contentType string, explanation []byte)
+
+// ExplainerSampler sends a sample of the successful prediction requests to the explainer asynchronously, so that
+// the explanations are produced without the clients calling the explain endpoint
+type ExplainerSampler struct {
+	next          http.Handler
+	explainerURL  *url.URL
+	percent       int
+	onExplanation ExplanationHandler
+	client        *http.Client
+	inFlight      chan struct{}
+	random        func() float64
+	logger        *zap.SugaredLogger
+}
+
+// NewExplainerSampler returns a handler sending the given percentage of the prediction requests to the explainer
+func NewExplainerSampler(explainerURL string, percent int, next http.Handler, onExplanation ExplanationHandler,
+	logger *zap.SugaredLogger,
+) (*ExplainerSampler, error) {
+	if percent < 0 || percent > 100 {
+		return nil, fmt.Errorf("the explainer sampling percent must be between 0 and 100, got %d", percent)
+	}
+	parsedURL, err := url.Parse(explainerURL)
+	if err != nil {
+		return nil, fmt.Errorf("malformed explainer url %s: %w", explainerURL, err)
+	}
+	return &ExplainerSampler{
+		next:          next,
+		explainerURL:  parsedURL,
+		percent:       percent,
+		onExplanation: onExplanation,
+		client:        &http.Client{Timeout: ExplanationTimeout},
+		inFlight:      make(chan struct{}, MaxInFlightExplanations),
+		random:        rand.Float64,
+		logger:        logger,
+	}, nil
+}
+
+// statusRecorder records the status code of the response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *ExplainerSampler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) || r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, predictVerb) ||
+		s.random()*100 >= float64(s.percent) {
+		s.next.ServeHTTP(w, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+	// The id correlates the explanation with the request and response logged by the logger
+	id := r.Header.Get(kfslogger.CloudEventsIdHeader)
+	if id == "" {
+		id = guuid.New().String()
+		r.Header.Set(kfslogger.CloudEventsIdHeader, id)
+	}
+	header := r.Header.Clone()
+	path := strings.TrimSuffix(r.URL.Path, predictVerb) + explainVerb
+
+	recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+	s.next.ServeHTTP(recorder, r)
+	if recorder.statusCode != http.StatusOK {
+		return
+	}
+	select {
+	case s.inFlight <- struct{}{}:
+		go func() {
+			defer func() { <-s.inFlight }()
+			s.explain(id, path, header, body)
+		}()
+	default:
+		s.logger.Debugw("Dropping the sampled request, too many explanations are in flight", "id", id)
+	}
+}
+
+// explain sends the sampled request to the explainer and passes the explanation to the explanation handler
+func (s *ExplainerSampler) explain(id string, path string, header http.Header, body []byte) {
+	explainURL := s.explainerURL.JoinPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), ExplanationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, explainURL.String(), bytes.NewReader(body))
+	if err != nil {
+		s.logger.Errorw("Failed to create the explain request", "id", id, zap.Error(err))
+		return
+	}
+	req.Header = header
+	req.Header.Del("Content-Length")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Errorw("Failed to explain the sampled request", "id", id, zap.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+	explanation, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.logger.Errorw("Failed to read the explanation", "id", id, zap.Error(err))
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		s.logger.Errorw("The explainer failed to explain the sampled request", "id", id, "status", resp.StatusCode,
+			"response", string(explanation))
+		return
+	}
+	s.onExplanation(id, resp.Header.Get("Content-Type"), explanation)
+}
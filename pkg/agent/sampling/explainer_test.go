@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sampling
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	kfslogger "github.com/kserve/kserve/pkg/logger"
+)
+
+type explanation struct {
+	id          string
+	contentType string
+	body        string
+}
+
+func newTestSampler(t *testing.T, percent int, predictorStatus int) (*ExplainerSampler, chan explanation, chan *http.Request) {
+	explainRequests := make(chan *http.Request, 1)
+	explainer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		explainRequests <- r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"explanations": [` + string(body) + `]}`))
+	}))
+	t.Cleanup(explainer.Close)
+	predictor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(predictorStatus)
+		_, _ = w.Write([]byte(`{"predictions": [1]}`))
+	})
+	explanations := make(chan explanation, 1)
+	sampler, err := NewExplainerSampler(explainer.URL, percent, predictor, func(id string, contentType string, body []byte) {
+		explanations <- explanation{id: id, contentType: contentType, body: string(body)}
+	}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sampler, explanations, explainRequests
+}
+
+func TestExplainerSampler_ExplainsSampledRequests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	sampler, explanations, explainRequests := newTestSampler(t, 100, http.StatusOK)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", strings.NewReader(`{"instances": [[1, 2]]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "1234")
+	resp := httptest.NewRecorder()
+	sampler.ServeHTTP(resp, req)
+	g.Expect(resp.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(resp.Body.String()).To(gomega.Equal(`{"predictions": [1]}`))
+
+	var explainRequest *http.Request
+	g.Eventually(explainRequests, time.Second).Should(gomega.Receive(&explainRequest))
+	g.Expect(explainRequest.URL.Path).To(gomega.Equal("/v1/models/sklearn:explain"))
+	g.Expect(explainRequest.Header.Get("X-Request-Id")).To(gomega.Equal("1234"))
+	id := explainRequest.Header.Get(kfslogger.CloudEventsIdHeader)
+	g.Expect(id).ToNot(gomega.BeEmpty())
+	// The logger of the prediction request uses the same id
+	g.Expect(req.Header.Get(kfslogger.CloudEventsIdHeader)).To(gomega.Equal(id))
+
+	var received explanation
+	g.Eventually(explanations, time.Second).Should(gomega.Receive(&received))
+	g.Expect(received).To(gomega.Equal(explanation{
+		id:          id,
+		contentType: "application/json",
+		body:        `{"explanations": [{"instances": [[1, 2]]}]}`,
+	}))
+}
+
+func TestExplainerSampler_SkipsRequests(t *testing.T) {
+	scenarios := map[string]struct {
+		percent         int
+		random          float64
+		method          string
+		path            string
+		predictorStatus int
+	}{
+		"not sampled": {
+			percent:         10,
+			random:          0.1,
+			method:          http.MethodPost,
+			path:            "/v1/models/sklearn:predict",
+			predictorStatus: http.StatusOK,
+		},
+		"not a prediction": {
+			percent:         100,
+			method:          http.MethodGet,
+			path:            "/v1/models/sklearn",
+			predictorStatus: http.StatusOK,
+		},
+		"failed prediction": {
+			percent:         100,
+			method:          http.MethodPost,
+			path:            "/v1/models/sklearn:predict",
+			predictorStatus: http.StatusInternalServerError,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			sampler, explanations, explainRequests := newTestSampler(t, scenario.percent, scenario.predictorStatus)
+			sampler.random = func() float64 { return scenario.random }
+
+			resp := httptest.NewRecorder()
+			sampler.ServeHTTP(resp, httptest.NewRequest(scenario.method, scenario.path, strings.NewReader(`{"instances": [[1, 2]]}`)))
+			g.Expect(resp.Code).To(gomega.Equal(scenario.predictorStatus))
+			g.Consistently(explainRequests, 100*time.Millisecond).ShouldNot(gomega.Receive())
+			g.Expect(explanations).ToNot(gomega.Receive())
+		})
+	}
+}
+
+func TestNewExplainerSamplerInvalidPercent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := NewExplainerSampler("http://sklearn-explainer.default.svc.cluster.local", 101, http.NotFoundHandler(), nil, zap.NewNop().Sugar())
+	g.Expect(err).To(gomega.MatchError("the explainer sampling percent must be between 0 and 100, got 101"))
+}
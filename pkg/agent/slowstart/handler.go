@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowstart
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"knative.dev/pkg/network"
+)
+
+// pollInterval bounds the time a waiting request takes to notice the concurrency grew with the ramp
+const pollInterval = 100 * time.Millisecond
+
+// Handler ramps up the concurrent requests admitted to the component over a window starting with the first request
+// the replica receives, from a share of the max concurrency to the max concurrency. The requests over the admitted
+// concurrency wait for a slot, so that the load balancers route the traffic to the warm replicas with less requests
+// in flight while a replica scaled from zero still serves all the requests. The requests are not limited anymore
+// once the window elapsed, the component concurrency is then bounded by the containerConcurrency.
+type Handler struct {
+	next           http.Handler
+	window         time.Duration
+	initialPercent int
+	maxConcurrency int64
+	now            func() time.Time
+
+	rampedUp atomic.Bool
+	mu       sync.Mutex
+	start    time.Time
+	inFlight int64
+	// released is closed when a request completes, waking up the waiting requests
+	released chan struct{}
+}
+
+// NewHandler returns a handler ramping up the concurrency admitted to the next handler over the window
+func NewHandler(window time.Duration, initialPercent int, maxConcurrency int64, next http.Handler) (*Handler, error) {
+	if window < time.Millisecond {
+		return nil, fmt.Errorf("the slow start window %v must be at least 1ms", window)
+	}
+	if initialPercent < 1 || initialPercent > 100 {
+		return nil, fmt.Errorf("the slow start initial percent %d must be between 1 and 100", initialPercent)
+	}
+	if maxConcurrency < 1 {
+		return nil, fmt.Errorf("the slow start max concurrency %d must be positive", maxConcurrency)
+	}
+	return &Handler{
+		next:           next,
+		window:         window,
+		initialPercent: initialPercent,
+		maxConcurrency: maxConcurrency,
+		now:            time.Now,
+		released:       make(chan struct{}),
+	}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.rampedUp.Load() || network.IsKubeletProbe(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	admitted, err := h.admit(r)
+	if err != nil {
+		// The client gave up while the request was waiting for a slot
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if admitted {
+		defer h.release()
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// admit waits for a slot in the concurrency of the ramp, it returns false when the window elapsed and the request was
+// not counted
+func (h *Handler) admit(r *http.Request) (bool, error) {
+	for {
+		h.mu.Lock()
+		now := h.now()
+		if h.start.IsZero() {
+			h.start = now
+		}
+		elapsed := now.Sub(h.start)
+		if elapsed >= h.window {
+			h.rampedUp.Store(true)
+			h.mu.Unlock()
+			return false, nil
+		}
+		if h.inFlight < h.Concurrency(elapsed) {
+			h.inFlight++
+			h.mu.Unlock()
+			return true, nil
+		}
+		released := h.released
+		h.mu.Unlock()
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-released:
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return false, r.Context().Err()
+		}
+		timer.Stop()
+	}
+}
+
+func (h *Handler) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.inFlight--
+	close(h.released)
+	h.released = make(chan struct{})
+}
+
+// Concurrency returns the number of concurrent requests admitted once the elapsed time of the window passed, growing
+// linearly from the initial percent of the max concurrency, at least one request
+func (h *Handler) Concurrency(elapsed time.Duration) int64 {
+	if elapsed >= h.window {
+		return h.maxConcurrency
+	}
+	// maxConcurrency * (initialPercent + (100 - initialPercent) * elapsed / window) / 100, rounded up
+	window, elapsedMs := h.window.Milliseconds(), max(elapsed.Milliseconds(), 0)
+	numerator := h.maxConcurrency * (int64(h.initialPercent)*window + int64(100-h.initialPercent)*elapsedMs)
+	denominator := 100 * window
+	return max((numerator+denominator-1)/denominator, 1)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowstart
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"knative.dev/pkg/network"
+)
+
+// clock is a fake clock advanced by the tests
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestConcurrency(t *testing.T) {
+	handler, err := NewHandler(100*time.Second, 10, 20, http.NotFoundHandler())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), handler.Concurrency(0))
+	assert.Equal(t, int64(11), handler.Concurrency(50*time.Second))
+	assert.Equal(t, int64(20), handler.Concurrency(99*time.Second))
+	assert.Equal(t, int64(20), handler.Concurrency(time.Hour))
+
+	// at least one request is admitted
+	handler, err = NewHandler(time.Minute, 1, 4, http.NotFoundHandler())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), handler.Concurrency(0))
+
+	_, err = NewHandler(0, 10, 4, http.NotFoundHandler())
+	require.Error(t, err)
+	_, err = NewHandler(time.Minute, 0, 4, http.NotFoundHandler())
+	require.Error(t, err)
+	_, err = NewHandler(time.Minute, 10, 0, http.NotFoundHandler())
+	require.Error(t, err)
+}
+
+func TestHandlerRampUp(t *testing.T) {
+	fakeClock := &clock{now: time.Now()}
+	block := make(chan struct{})
+	served := make(chan struct{}, 10)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served <- struct{}{}
+		if !network.IsKubeletProbe(r) {
+			<-block
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	handler, err := NewHandler(time.Minute, 10, 10, next)
+	require.NoError(t, err)
+	handler.now = fakeClock.Now
+
+	serve := func() <-chan int {
+		code := make(chan int, 1)
+		go func() {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/models/model:predict", nil))
+			code <- recorder.Code
+		}()
+		return code
+	}
+
+	// a single request is admitted at the beginning of the window
+	first := serve()
+	<-served
+	second := serve()
+	select {
+	case <-served:
+		t.Fatal("the second request was admitted over the concurrency of the ramp")
+	case <-time.After(2 * pollInterval):
+	}
+
+	// the kubelet probes are not limited
+	probe := httptest.NewRequest(http.MethodGet, "/", nil)
+	probe.Header.Set(network.UserAgentKey, network.KubeProbeUAPrefix+"1.30")
+	handler.ServeHTTP(httptest.NewRecorder(), probe)
+	<-served
+
+	// half way through the window six requests are admitted
+	fakeClock.Advance(30 * time.Second)
+	<-served
+	block <- struct{}{}
+	block <- struct{}{}
+	assert.Equal(t, http.StatusOK, <-first)
+	assert.Equal(t, http.StatusOK, <-second)
+
+	// the requests are not limited once the window elapsed
+	fakeClock.Advance(time.Minute)
+	codes := make([]<-chan int, 0, 10)
+	for range 10 {
+		codes = append(codes, serve())
+	}
+	for range 10 {
+		<-served
+	}
+	assert.True(t, handler.rampedUp.Load())
+	close(block)
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, <-code)
+	}
+}
+
+func TestHandlerClientGone(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	handler, err := NewHandler(time.Minute, 10, 1, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-block
+	}))
+	require.NoError(t, err)
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	require.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return handler.inFlight == 1
+	}, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*pollInterval)
+	defer cancel()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
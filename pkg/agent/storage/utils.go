@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -262,3 +263,53 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 
 	return providers[protocol], nil
 }
+
+// Env variables of the storage spec secret entry and its override parameters set by the credential builder, which can
+// not be imported since its tests depend on this package.
+const (
+	storageConfigEnvKey         = "STORAGE_CONFIG"
+	storageOverrideConfigEnvKey = "STORAGE_OVERRIDE_CONFIG"
+)
+
+// storageSpecS3Envs maps the s3 entries of the storage spec secret to the env variables of the S3 client, the same
+// way the storage initializer does.
+var storageSpecS3Envs = []struct{ envKey, configKey string }{
+	{s3credential.AWSEndpointUrl, "endpoint_url"},
+	{s3credential.AWSAccessKeyId, "access_key_id"},
+	{s3credential.AWSSecretAccessKey, "secret_access_key"},
+	{s3credential.AWSRegion, "region"},
+	{s3credential.AWSCABundle, "ca_bundle"},
+	{s3credential.S3VerifySSL, "verify_ssl"},
+	{s3credential.AWSAnonymousCredential, "anonymous"},
+}
+
+// ApplyStorageSpecConfig sets the s3 env variables from the storage spec secret and its override parameters passed
+// down by the storage spec of the InferenceService, so that the providers reuse the credentials of the storage spec.
+func ApplyStorageSpecConfig() error {
+	config := map[string]string{}
+	if value := os.Getenv(storageConfigEnvKey); value != "" {
+		if err := json.Unmarshal([]byte(value), &config); err != nil {
+			return fmt.Errorf("invalid %s: %w", storageConfigEnvKey, err)
+		}
+	}
+	if value := os.Getenv(storageOverrideConfigEnvKey); value != "" {
+		overrides := map[string]string{}
+		if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+			return fmt.Errorf("invalid %s: %w", storageOverrideConfigEnvKey, err)
+		}
+		for key, value := range overrides {
+			config[key] = value
+		}
+	}
+	if config["type"] != "s3" {
+		return nil
+	}
+	for _, env := range storageSpecS3Envs {
+		if value, ok := config[env.configKey]; ok {
+			if err := os.Setenv(env.envKey, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
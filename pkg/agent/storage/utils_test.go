@@ -26,6 +26,7 @@ import (
 	"github.com/onsi/gomega"
 
 	"github.com/kserve/kserve/pkg/agent/mocks"
+	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
 )
 
 func TestCreate(t *testing.T) {
@@ -129,3 +130,23 @@ func TestGetProvider(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyStorageSpecConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	t.Setenv("STORAGE_CONFIG", `{"type": "s3", "access_key_id": "minio", "secret_access_key": "minio123", "endpoint_url": "http://minio:9000"}`)
+	t.Setenv("STORAGE_OVERRIDE_CONFIG", `{"region": "us-west-1", "bucket": "models"}`)
+	t.Setenv(s3credential.AWSAccessKeyId, "")
+	t.Setenv(s3credential.AWSSecretAccessKey, "")
+	t.Setenv(s3credential.AWSEndpointUrl, "")
+	t.Setenv(s3credential.AWSRegion, "")
+
+	g.Expect(ApplyStorageSpecConfig()).To(gomega.Succeed())
+	g.Expect(os.Getenv(s3credential.AWSAccessKeyId)).To(gomega.Equal("minio"))
+	g.Expect(os.Getenv(s3credential.AWSSecretAccessKey)).To(gomega.Equal("minio123"))
+	g.Expect(os.Getenv(s3credential.AWSEndpointUrl)).To(gomega.Equal("http://minio:9000"))
+	g.Expect(os.Getenv(s3credential.AWSRegion)).To(gomega.Equal("us-west-1"))
+
+	t.Setenv("STORAGE_CONFIG", "not json")
+	g.Expect(ApplyStorageSpecConfig()).NotTo(gomega.Succeed())
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transcoding
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// InferTensor is a tensor of the REST v2 protocol
+type InferTensor struct {
+	Name       string         `json:"name"`
+	Shape      []int64        `json:"shape"`
+	Datatype   string         `json:"datatype"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Data       []any          `json:"data"`
+}
+
+// RequestedOutput is an output requested by a REST v2 inference request
+type RequestedOutput struct {
+	Name       string         `json:"name"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// InferRequest is the body of a REST v2 inference request
+type InferRequest struct {
+	ID         string            `json:"id,omitempty"`
+	Parameters map[string]any    `json:"parameters,omitempty"`
+	Inputs     []InferTensor     `json:"inputs"`
+	Outputs    []RequestedOutput `json:"outputs,omitempty"`
+}
+
+// InferResponse is the body of a REST v2 inference response
+type InferResponse struct {
+	ModelName    string         `json:"model_name"`
+	ModelVersion string         `json:"model_version,omitempty"`
+	ID           string         `json:"id,omitempty"`
+	Parameters   map[string]any `json:"parameters,omitempty"`
+	Outputs      []InferTensor  `json:"outputs"`
+}
+
+// contentsFields are the fields of InferTensorContents holding the elements of each datatype
+var contentsFields = map[string]protoreflect.Name{
+	"BOOL":   "bool_contents",
+	"INT8":   "int_contents",
+	"INT16":  "int_contents",
+	"INT32":  "int_contents",
+	"INT64":  "int64_contents",
+	"UINT8":  "uint_contents",
+	"UINT16": "uint_contents",
+	"UINT32": "uint_contents",
+	"UINT64": "uint64_contents",
+	"FP32":   "fp32_contents",
+	"FP64":   "fp64_contents",
+	"BYTES":  "bytes_contents",
+}
+
+// rawSizes are the sizes in bytes of the elements of the fixed size datatypes in the raw contents
+var rawSizes = map[string]int{
+	"BOOL":   1,
+	"INT8":   1,
+	"INT16":  2,
+	"INT32":  4,
+	"INT64":  8,
+	"UINT8":  1,
+	"UINT16": 2,
+	"UINT32": 4,
+	"UINT64": 8,
+	"FP16":   2,
+	"FP32":   4,
+	"FP64":   8,
+}
+
+func get(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+	return m.Get(m.Descriptor().Fields().ByName(name))
+}
+
+func set(m protoreflect.Message, name protoreflect.Name, value protoreflect.Value) {
+	m.Set(m.Descriptor().Fields().ByName(name), value)
+}
+
+func mutable(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+	return m.Mutable(m.Descriptor().Fields().ByName(name))
+}
+
+// toModelInferRequest fills a ModelInferRequest from a REST v2 inference request
+func toModelInferRequest(req *InferRequest, modelName string, modelVersion string, msg protoreflect.Message) error {
+	set(msg, "model_name", protoreflect.ValueOfString(modelName))
+	set(msg, "model_version", protoreflect.ValueOfString(modelVersion))
+	set(msg, "id", protoreflect.ValueOfString(req.ID))
+	if err := toParameters(req.Parameters, mutable(msg, "parameters").Map()); err != nil {
+		return err
+	}
+
+	inputs := mutable(msg, "inputs").List()
+	for _, input := range req.Inputs {
+		tensor := inputs.NewElement().Message()
+		set(tensor, "name", protoreflect.ValueOfString(input.Name))
+		set(tensor, "datatype", protoreflect.ValueOfString(input.Datatype))
+		shape := mutable(tensor, "shape").List()
+		for _, dim := range input.Shape {
+			shape.Append(protoreflect.ValueOfInt64(dim))
+		}
+		if err := toParameters(input.Parameters, mutable(tensor, "parameters").Map()); err != nil {
+			return fmt.Errorf("input %s: %w", input.Name, err)
+		}
+		fieldName, ok := contentsFields[input.Datatype]
+		if !ok {
+			return fmt.Errorf("input %s: unsupported datatype %s", input.Name, input.Datatype)
+		}
+		contents := mutable(tensor, "contents").Message()
+		field := contents.Descriptor().Fields().ByName(fieldName)
+		elements := contents.Mutable(field).List()
+		for _, element := range flatten(input.Data, nil) {
+			value, err := toValue(field.Kind(), element)
+			if err != nil {
+				return fmt.Errorf("input %s: %w", input.Name, err)
+			}
+			elements.Append(value)
+		}
+		inputs.Append(protoreflect.ValueOfMessage(tensor))
+	}
+
+	outputs := mutable(msg, "outputs").List()
+	for _, output := range req.Outputs {
+		tensor := outputs.NewElement().Message()
+		set(tensor, "name", protoreflect.ValueOfString(output.Name))
+		if err := toParameters(output.Parameters, mutable(tensor, "parameters").Map()); err != nil {
+			return fmt.Errorf("output %s: %w", output.Name, err)
+		}
+		outputs.Append(protoreflect.ValueOfMessage(tensor))
+	}
+	return nil
+}
+
+// fromModelInferResponse returns the REST v2 inference response of a ModelInferResponse
+func fromModelInferResponse(msg protoreflect.Message) (*InferResponse, error) {
+	resp := &InferResponse{
+		ModelName:    get(msg, "model_name").String(),
+		ModelVersion: get(msg, "model_version").String(),
+		ID:           get(msg, "id").String(),
+		Parameters:   fromParameters(get(msg, "parameters").Map()),
+		Outputs:      []InferTensor{},
+	}
+	outputs := get(msg, "outputs").List()
+	rawContents := get(msg, "raw_output_contents").List()
+	for i := range outputs.Len() {
+		tensor := outputs.Get(i).Message()
+		output := InferTensor{
+			Name:       get(tensor, "name").String(),
+			Datatype:   get(tensor, "datatype").String(),
+			Parameters: fromParameters(get(tensor, "parameters").Map()),
+			Shape:      []int64{},
+			Data:       []any{},
+		}
+		shape := get(tensor, "shape").List()
+		for j := range shape.Len() {
+			output.Shape = append(output.Shape, shape.Get(j).Int())
+		}
+		var err error
+		// The raw contents, when used, hold the outputs in order
+		if i < rawContents.Len() {
+			output.Data, err = fromRawContents(output.Datatype, rawContents.Get(i).Bytes())
+		} else {
+			output.Data, err = fromContents(output.Datatype, get(tensor, "contents").Message())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", output.Name, err)
+		}
+		resp.Outputs = append(resp.Outputs, output)
+	}
+	return resp, nil
+}
+
+// flatten returns the elements of the data in row-major order, the data of a tensor can be nested
+func flatten(data []any, elements []any) []any {
+	for _, element := range data {
+		if nested, ok := element.([]any); ok {
+			elements = flatten(nested, elements)
+		} else {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// toValue converts a JSON element, decoded with numbers, to the kind of the contents field
+func toValue(kind protoreflect.Kind, element any) (protoreflect.Value, error) {
+	switch kind {
+	case protoreflect.BoolKind:
+		if b, ok := element.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+	case protoreflect.BytesKind:
+		if s, ok := element.(string); ok {
+			return protoreflect.ValueOfBytes([]byte(s)), nil
+		}
+	default:
+		number, ok := element.(json.Number)
+		if !ok {
+			break
+		}
+		switch kind {
+		case protoreflect.Int32Kind:
+			i, err := strconv.ParseInt(number.String(), 10, 32)
+			return protoreflect.ValueOfInt32(int32(i)), err
+		case protoreflect.Int64Kind:
+			i, err := strconv.ParseInt(number.String(), 10, 64)
+			return protoreflect.ValueOfInt64(i), err
+		case protoreflect.Uint32Kind:
+			u, err := strconv.ParseUint(number.String(), 10, 32)
+			return protoreflect.ValueOfUint32(uint32(u)), err
+		case protoreflect.Uint64Kind:
+			u, err := strconv.ParseUint(number.String(), 10, 64)
+			return protoreflect.ValueOfUint64(u), err
+		case protoreflect.FloatKind:
+			f, err := strconv.ParseFloat(number.String(), 32)
+			return protoreflect.ValueOfFloat32(float32(f)), err
+		case protoreflect.DoubleKind:
+			f, err := strconv.ParseFloat(number.String(), 64)
+			return protoreflect.ValueOfFloat64(f), err
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("invalid %s element %v", kind, element)
+}
+
+// fromContents returns the elements of the contents field of the datatype
+func fromContents(datatype string, contents protoreflect.Message) ([]any, error) {
+	fieldName, ok := contentsFields[datatype]
+	if !ok {
+		return nil, fmt.Errorf("unsupported datatype %s", datatype)
+	}
+	elements := get(contents, fieldName).List()
+	data := make([]any, 0, elements.Len())
+	for i := range elements.Len() {
+		value := elements.Get(i)
+		switch v := value.Interface().(type) {
+		case bool:
+			data = append(data, v)
+		case []byte:
+			data = append(data, string(v))
+		case float32:
+			data = append(data, json.Number(strconv.FormatFloat(float64(v), 'g', -1, 32)))
+		case float64:
+			data = append(data, json.Number(strconv.FormatFloat(v, 'g', -1, 64)))
+		case int32, int64, uint32, uint64:
+			data = append(data, json.Number(fmt.Sprint(v)))
+		}
+	}
+	return data, nil
+}
+
+// fromRawContents returns the elements of the raw contents of the datatype, the elements of fixed size are little
+// endian and the BYTES elements are prefixed by their 4 bytes length
+func fromRawContents(datatype string, raw []byte) ([]any, error) {
+	var data []any
+	if datatype == "BYTES" {
+		for len(raw) > 0 {
+			if len(raw) < 4 {
+				return nil, fmt.Errorf("truncated raw BYTES contents")
+			}
+			size := binary.LittleEndian.Uint32(raw)
+			if uint64(len(raw)-4) < uint64(size) {
+				return nil, fmt.Errorf("truncated raw BYTES contents")
+			}
+			data = append(data, string(raw[4:4+size]))
+			raw = raw[4+size:]
+		}
+		return data, nil
+	}
+
+	size, ok := rawSizes[datatype]
+	if !ok {
+		return nil, fmt.Errorf("unsupported datatype %s", datatype)
+	}
+	if len(raw)%size != 0 {
+		return nil, fmt.Errorf("raw contents of %d bytes are not a multiple of the %d bytes of %s", len(raw), size, datatype)
+	}
+	data = make([]any, 0, len(raw)/size)
+	for i := 0; i < len(raw); i += size {
+		element := raw[i : i+size]
+		switch datatype {
+		case "BOOL":
+			data = append(data, element[0] != 0)
+		case "INT8":
+			data = append(data, json.Number(strconv.FormatInt(int64(int8(element[0])), 10)))
+		case "INT16":
+			data = append(data, json.Number(strconv.FormatInt(int64(int16(binary.LittleEndian.Uint16(element))), 10)))
+		case "INT32":
+			data = append(data, json.Number(strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(element))), 10)))
+		case "INT64":
+			data = append(data, json.Number(strconv.FormatInt(int64(binary.LittleEndian.Uint64(element)), 10)))
+		case "UINT8":
+			data = append(data, json.Number(strconv.FormatUint(uint64(element[0]), 10)))
+		case "UINT16":
+			data = append(data, json.Number(strconv.FormatUint(uint64(binary.LittleEndian.Uint16(element)), 10)))
+		case "UINT32":
+			data = append(data, json.Number(strconv.FormatUint(uint64(binary.LittleEndian.Uint32(element)), 10)))
+		case "UINT64":
+			data = append(data, json.Number(strconv.FormatUint(binary.LittleEndian.Uint64(element), 10)))
+		case "FP16":
+			f := halfToFloat32(binary.LittleEndian.Uint16(element))
+			data = append(data, json.Number(strconv.FormatFloat(float64(f), 'g', -1, 32)))
+		case "FP32":
+			f := math.Float32frombits(binary.LittleEndian.Uint32(element))
+			data = append(data, json.Number(strconv.FormatFloat(float64(f), 'g', -1, 32)))
+		case "FP64":
+			f := math.Float64frombits(binary.LittleEndian.Uint64(element))
+			data = append(data, json.Number(strconv.FormatFloat(f, 'g', -1, 64)))
+		}
+	}
+	return data, nil
+}
+
+// halfToFloat32 converts an IEEE 754 half precision float
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exponent := uint32(h>>10) & 0x1f
+	mantissa := uint32(h) & 0x3ff
+	switch {
+	case exponent == 0x1f:
+		// infinity or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mantissa<<13)
+	case exponent == 0 && mantissa == 0:
+		return math.Float32frombits(sign)
+	case exponent == 0:
+		// subnormal, normalized for the float32 exponent
+		exponent = 127 - 15 + 1
+		for mantissa&0x400 == 0 {
+			mantissa <<= 1
+			exponent--
+		}
+		return math.Float32frombits(sign | exponent<<23 | (mantissa&0x3ff)<<13)
+	default:
+		return math.Float32frombits(sign | (exponent+127-15)<<23 | mantissa<<13)
+	}
+}
+
+// toParameters converts REST parameters to InferParameter messages, numbers without a fraction are int64 parameters
+// and the other numbers are kept as strings as the protocol has no floating point parameters
+func toParameters(parameters map[string]any, params protoreflect.Map) error {
+	for key, value := range parameters {
+		param := params.NewValue().Message()
+		switch v := value.(type) {
+		case bool:
+			set(param, "bool_param", protoreflect.ValueOfBool(v))
+		case string:
+			set(param, "string_param", protoreflect.ValueOfString(v))
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				set(param, "int64_param", protoreflect.ValueOfInt64(i))
+			} else {
+				set(param, "string_param", protoreflect.ValueOfString(v.String()))
+			}
+		default:
+			return fmt.Errorf("unsupported value of the parameter %s: %v", key, value)
+		}
+		params.Set(protoreflect.ValueOfString(key).MapKey(), protoreflect.ValueOfMessage(param))
+	}
+	return nil
+}
+
+func fromParameters(params protoreflect.Map) map[string]any {
+	if params.Len() == 0 {
+		return nil
+	}
+	parameters := make(map[string]any, params.Len())
+	params.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		param := value.Message()
+		if field := param.WhichOneof(param.Descriptor().Oneofs().ByName("parameter_choice")); field != nil {
+			parameters[key.String()] = param.Get(field).Interface()
+		}
+		return true
+	})
+	return parameters
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transcoding
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+const modelsPrefix = "/v2/models/"
+
+// skippedHeaders are the request headers that are not forwarded as gRPC metadata
+var skippedHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"content-type":      true,
+	"host":              true,
+	"accept-encoding":   true,
+	"keep-alive":        true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// Transcoder serves the REST v2 endpoints of the open inference protocol by calling the v2 gRPC service of the
+// component, so that REST clients can use a runtime only serving gRPC. The other requests are passed to next.
+type Transcoder struct {
+	next    http.Handler
+	conn    *grpc.ClientConn
+	service protoreflect.ServiceDescriptor
+	logger  *zap.SugaredLogger
+}
+
+// NewTranscoder returns a handler transcoding the REST v2 requests to gRPC calls to the target, e.g. 127.0.0.1:8081
+func NewTranscoder(target string, next http.Handler, logger *zap.SugaredLogger) (*Transcoder, error) {
+	service, err := protocol.GrpcInferenceService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the open inference protocol descriptors: %w", err)
+	}
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32), grpc.MaxCallSendMsgSize(math.MaxInt32)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the gRPC client of %s: %w", target, err)
+	}
+	return &Transcoder{
+		next:    next,
+		conn:    conn,
+		service: service,
+		logger:  logger,
+	}, nil
+}
+
+// Close closes the gRPC connection to the component
+func (t *Transcoder) Close() error {
+	return t.conn.Close()
+}
+
+func (t *Transcoder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "/v2" && r.Method == http.MethodGet:
+		t.serverMetadata(w, r)
+	case path == "/v2/health/live" && r.Method == http.MethodGet:
+		t.health(w, r, "ServerLive", "live")
+	case path == "/v2/health/ready" && r.Method == http.MethodGet:
+		t.health(w, r, "ServerReady", "ready")
+	case strings.HasPrefix(path, modelsPrefix):
+		name, version, action := parseModelPath(strings.TrimPrefix(path, modelsPrefix))
+		switch {
+		case action == "" && r.Method == http.MethodGet:
+			t.modelMetadata(w, r, name, version)
+		case action == "ready" && r.Method == http.MethodGet:
+			t.modelReady(w, r, name, version)
+		case action == "infer" && r.Method == http.MethodPost:
+			t.modelInfer(w, r, name, version)
+		default:
+			t.next.ServeHTTP(w, r)
+		}
+	default:
+		t.next.ServeHTTP(w, r)
+	}
+}
+
+// parseModelPath parses {name}[/versions/{version}][/{action}]
+func parseModelPath(path string) (name string, version string, action string) {
+	parts := strings.Split(path, "/")
+	name, parts = parts[0], parts[1:]
+	if len(parts) >= 2 && parts[0] == "versions" {
+		version, parts = parts[1], parts[2:]
+	}
+	return name, version, strings.Join(parts, "/")
+}
+
+// invoke calls a method of the gRPC service, the request headers are forwarded as metadata
+func (t *Transcoder) invoke(r *http.Request, method protoreflect.Name, fill func(req protoreflect.Message) error) (protoreflect.Message, error) {
+	methodDescriptor := t.service.Methods().ByName(method)
+	req := dynamicpb.NewMessage(methodDescriptor.Input())
+	if fill != nil {
+		if err := fill(req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	resp := dynamicpb.NewMessage(methodDescriptor.Output())
+
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		if key := strings.ToLower(name); !skippedHeaders[key] && !strings.HasPrefix(key, "grpc-") {
+			md.Append(key, values...)
+		}
+	}
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+	fullMethod := fmt.Sprintf("/%s/%s", t.service.FullName(), method)
+	if err := t.conn.Invoke(ctx, fullMethod, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *Transcoder) serverMetadata(w http.ResponseWriter, r *http.Request) {
+	resp, err := t.invoke(r, "ServerMetadata", nil)
+	if err != nil {
+		t.writeError(w, err)
+		return
+	}
+	extensions := []string{}
+	list := get(resp, "extensions").List()
+	for i := range list.Len() {
+		extensions = append(extensions, list.Get(i).String())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"name":       get(resp, "name").String(),
+		"version":    get(resp, "version").String(),
+		"extensions": extensions,
+	})
+}
+
+func (t *Transcoder) health(w http.ResponseWriter, r *http.Request, method protoreflect.Name, field protoreflect.Name) {
+	resp, err := t.invoke(r, method, nil)
+	if err != nil {
+		t.writeError(w, err)
+		return
+	}
+	healthy := get(resp, field).Bool()
+	writeJSON(w, healthStatus(healthy), map[string]any{string(field): healthy})
+}
+
+func (t *Transcoder) modelReady(w http.ResponseWriter, r *http.Request, name string, version string) {
+	resp, err := t.invoke(r, "ModelReady", func(req protoreflect.Message) error {
+		set(req, "name", protoreflect.ValueOfString(name))
+		set(req, "version", protoreflect.ValueOfString(version))
+		return nil
+	})
+	if err != nil {
+		t.writeError(w, err)
+		return
+	}
+	ready := get(resp, "ready").Bool()
+	writeJSON(w, healthStatus(ready), map[string]any{"name": name, "ready": ready})
+}
+
+func (t *Transcoder) modelMetadata(w http.ResponseWriter, r *http.Request, name string, version string) {
+	resp, err := t.invoke(r, "ModelMetadata", func(req protoreflect.Message) error {
+		set(req, "name", protoreflect.ValueOfString(name))
+		set(req, "version", protoreflect.ValueOfString(version))
+		return nil
+	})
+	if err != nil {
+		t.writeError(w, err)
+		return
+	}
+	versions := []string{}
+	list := get(resp, "versions").List()
+	for i := range list.Len() {
+		versions = append(versions, list.Get(i).String())
+	}
+	tensors := func(field protoreflect.Name) []map[string]any {
+		result := []map[string]any{}
+		list := get(resp, field).List()
+		for i := range list.Len() {
+			tensor := list.Get(i).Message()
+			shape := []int64{}
+			dims := get(tensor, "shape").List()
+			for j := range dims.Len() {
+				shape = append(shape, dims.Get(j).Int())
+			}
+			result = append(result, map[string]any{
+				"name":     get(tensor, "name").String(),
+				"datatype": get(tensor, "datatype").String(),
+				"shape":    shape,
+			})
+		}
+		return result
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"name":     get(resp, "name").String(),
+		"versions": versions,
+		"platform": get(resp, "platform").String(),
+		"inputs":   tensors("inputs"),
+		"outputs":  tensors("outputs"),
+	})
+}
+
+func (t *Transcoder) modelInfer(w http.ResponseWriter, r *http.Request, name string, version string) {
+	inferRequest := &InferRequest{}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(inferRequest); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid inference request: %v", err)})
+		return
+	}
+
+	resp, err := t.invoke(r, "ModelInfer", func(req protoreflect.Message) error {
+		return toModelInferRequest(inferRequest, name, version, req)
+	})
+	if err != nil {
+		t.writeError(w, err)
+		return
+	}
+	inferResponse, err := fromModelInferResponse(resp)
+	if err != nil {
+		t.writeError(w, status.Error(codes.Internal, fmt.Sprintf("invalid inference response: %v", err)))
+		return
+	}
+	writeJSON(w, http.StatusOK, inferResponse)
+}
+
+func healthStatus(healthy bool) int {
+	if healthy {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+func (t *Transcoder) writeError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	if code := httpStatusFromCode(s.Code()); code >= http.StatusInternalServerError {
+		t.logger.Errorw("gRPC call to the component failed", "code", s.Code().String(), "error", s.Message())
+	}
+	writeJSON(w, httpStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+}
+
+// httpStatusFromCode maps the gRPC status codes to the HTTP status codes
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transcoding
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+// methodHandler implements a method of the fake gRPC service
+type methodHandler func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error
+
+// startGrpcServer serves the open inference protocol with the given method handlers, the other methods are
+// unimplemented
+func startGrpcServer(t *testing.T, handlers map[protoreflect.Name]methodHandler) string {
+	service, err := protocol.GrpcInferenceService()
+	require.NoError(t, err)
+	desc := &grpc.ServiceDesc{ServiceName: string(service.FullName()), HandlerType: (*any)(nil)}
+	for name, handler := range handlers {
+		method := service.Methods().ByName(name)
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: string(name),
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				req := dynamicpb.NewMessage(method.Input())
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				resp := dynamicpb.NewMessage(method.Output())
+				return resp, handler(ctx, req, resp)
+			},
+		})
+	}
+	server := grpc.NewServer()
+	server.RegisterService(desc, struct{}{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func newTestTranscoder(t *testing.T, handlers map[protoreflect.Name]methodHandler) *Transcoder {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	transcoder, err := NewTranscoder(startGrpcServer(t, handlers), next, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = transcoder.Close() })
+	return transcoder
+}
+
+func serve(transcoder *Transcoder, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Request-Id", "request-1")
+	transcoder.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestTranscoderModelInfer(t *testing.T) {
+	transcoder := newTestTranscoder(t, map[protoreflect.Name]methodHandler{
+		"ModelInfer": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			md, _ := metadata.FromIncomingContext(ctx)
+			assert.Equal(t, []string{"request-1"}, md.Get("x-request-id"))
+			assert.Equal(t, "sklearn", get(req, "model_name").String())
+			assert.Equal(t, "2", get(req, "model_version").String())
+			assert.Equal(t, "1", get(req, "id").String())
+			assert.Equal(t, int64(3), get(req, "parameters").Map().Get(protoreflect.ValueOfString("top_k").MapKey()).Message().
+				Get(req.Descriptor().Fields().ByName("parameters").MapValue().Message().Fields().ByName("int64_param")).Int())
+
+			input := get(req, "inputs").List().Get(0).Message()
+			assert.Equal(t, "input-0", get(input, "name").String())
+			assert.Equal(t, "FP32", get(input, "datatype").String())
+			contents := get(get(input, "contents").Message(), "fp32_contents").List()
+			assert.Equal(t, 4, contents.Len())
+			assert.InDelta(t, 6.8, contents.Get(0).Float(), 1e-6)
+
+			outputs := mutable(resp, "outputs").List()
+			// An output in the typed contents
+			labels := outputs.NewElement().Message()
+			set(labels, "name", protoreflect.ValueOfString("labels"))
+			set(labels, "datatype", protoreflect.ValueOfString("BYTES"))
+			mutable(labels, "shape").List().Append(protoreflect.ValueOfInt64(1))
+			mutable(mutable(labels, "contents").Message(), "bytes_contents").List().Append(protoreflect.ValueOfBytes([]byte("setosa")))
+			outputs.Append(protoreflect.ValueOfMessage(labels))
+			set(resp, "model_name", protoreflect.ValueOfString("sklearn"))
+			set(resp, "id", protoreflect.ValueOfString("1"))
+			return nil
+		},
+	})
+
+	resp := serve(transcoder, http.MethodPost, "/v2/models/sklearn/versions/2/infer",
+		`{"id": "1", "parameters": {"top_k": 3}, "inputs": [{"name": "input-0", "shape": [2, 2], "datatype": "FP32", "data": [[6.8, 2.8], [6.0, 3.4]]}]}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"model_name": "sklearn", "id": "1", "outputs": [{"name": "labels", "shape": [1], "datatype": "BYTES", "data": ["setosa"]}]}`,
+		resp.Body.String())
+}
+
+func TestTranscoderModelInferRawOutputs(t *testing.T) {
+	transcoder := newTestTranscoder(t, map[protoreflect.Name]methodHandler{
+		"ModelInfer": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			// The integers are not converted to floats
+			input := get(req, "inputs").List().Get(0).Message()
+			assert.Equal(t, int64(9007199254740993), get(get(input, "contents").Message(), "int64_contents").List().Get(0).Int())
+
+			output := mutable(resp, "outputs").List().NewElement().Message()
+			set(output, "name", protoreflect.ValueOfString("output-0"))
+			set(output, "datatype", protoreflect.ValueOfString("FP32"))
+			mutable(output, "shape").List().Append(protoreflect.ValueOfInt64(2))
+			mutable(resp, "outputs").List().Append(protoreflect.ValueOfMessage(output))
+			raw := binary.LittleEndian.AppendUint32(nil, math.Float32bits(0.5))
+			raw = binary.LittleEndian.AppendUint32(raw, math.Float32bits(-1.25))
+			mutable(resp, "raw_output_contents").List().Append(protoreflect.ValueOfBytes(raw))
+			set(resp, "model_name", protoreflect.ValueOfString("triton"))
+			return nil
+		},
+	})
+
+	resp := serve(transcoder, http.MethodPost, "/v2/models/triton/infer",
+		`{"inputs": [{"name": "input-0", "shape": [1], "datatype": "INT64", "data": [9007199254740993]}]}`)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"model_name": "triton", "outputs": [{"name": "output-0", "shape": [2], "datatype": "FP32", "data": [0.5, -1.25]}]}`,
+		resp.Body.String())
+}
+
+func TestTranscoderErrors(t *testing.T) {
+	transcoder := newTestTranscoder(t, map[protoreflect.Name]methodHandler{
+		"ModelInfer": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			return status.Error(codes.NotFound, "model unknown is not found")
+		},
+	})
+
+	scenarios := map[string]struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		"gRPC status": {
+			method:       http.MethodPost,
+			path:         "/v2/models/unknown/infer",
+			body:         `{"inputs": []}`,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error": "model unknown is not found"}`,
+		},
+		"malformed request": {
+			method:       http.MethodPost,
+			path:         "/v2/models/sklearn/infer",
+			body:         `{"inputs": `,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error": "invalid inference request: unexpected EOF"}`,
+		},
+		"unsupported datatype": {
+			method:       http.MethodPost,
+			path:         "/v2/models/sklearn/infer",
+			body:         `{"inputs": [{"name": "input-0", "shape": [1], "datatype": "FP16", "data": [1.0]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error": "input input-0: unsupported datatype FP16"}`,
+		},
+		"element not matching the datatype": {
+			method:       http.MethodPost,
+			path:         "/v2/models/sklearn/infer",
+			body:         `{"inputs": [{"name": "input-0", "shape": [1], "datatype": "INT32", "data": ["one"]}]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error": "input input-0: invalid int32 element one"}`,
+		},
+		"unimplemented method": {
+			method:       http.MethodGet,
+			path:         "/v2/models/sklearn",
+			expectedCode: http.StatusNotImplemented,
+			expectedBody: `{"error": "unknown method ModelMetadata for service inference.GRPCInferenceService"}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			resp := serve(transcoder, scenario.method, scenario.path, scenario.body)
+			assert.Equal(t, scenario.expectedCode, resp.Code)
+			assert.JSONEq(t, scenario.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestTranscoderHealthAndMetadata(t *testing.T) {
+	transcoder := newTestTranscoder(t, map[protoreflect.Name]methodHandler{
+		"ServerLive": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			set(resp, "live", protoreflect.ValueOfBool(true))
+			return nil
+		},
+		"ServerReady": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			set(resp, "ready", protoreflect.ValueOfBool(false))
+			return nil
+		},
+		"ModelReady": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			set(resp, "ready", protoreflect.ValueOfBool(get(req, "name").String() == "sklearn"))
+			return nil
+		},
+		"ModelMetadata": func(ctx context.Context, req protoreflect.Message, resp protoreflect.Message) error {
+			set(resp, "name", get(req, "name"))
+			set(resp, "platform", protoreflect.ValueOfString("sklearn"))
+			input := mutable(resp, "inputs").List().NewElement().Message()
+			set(input, "name", protoreflect.ValueOfString("input-0"))
+			set(input, "datatype", protoreflect.ValueOfString("FP32"))
+			mutable(input, "shape").List().Append(protoreflect.ValueOfInt64(-1))
+			mutable(resp, "inputs").List().Append(protoreflect.ValueOfMessage(input))
+			return nil
+		},
+	})
+
+	scenarios := map[string]struct {
+		method       string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		"server live": {
+			method:       http.MethodGet,
+			path:         "/v2/health/live",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"live": true}`,
+		},
+		"server not ready": {
+			method:       http.MethodGet,
+			path:         "/v2/health/ready",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"ready": false}`,
+		},
+		"model ready": {
+			method:       http.MethodGet,
+			path:         "/v2/models/sklearn/ready",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name": "sklearn", "ready": true}`,
+		},
+		"model metadata": {
+			method:       http.MethodGet,
+			path:         "/v2/models/sklearn/",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"name": "sklearn", "versions": [], "platform": "sklearn",
+				"inputs": [{"name": "input-0", "datatype": "FP32", "shape": [-1]}], "outputs": []}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			resp := serve(transcoder, scenario.method, scenario.path, "")
+			assert.Equal(t, scenario.expectedCode, resp.Code)
+			assert.JSONEq(t, scenario.expectedBody, resp.Body.String())
+		})
+	}
+
+	// The other requests are passed to the next handler
+	for _, path := range []string{"/v1/models/sklearn:predict", "/v2/models/sklearn/generate", "/metrics"} {
+		assert.Equal(t, http.StatusTeapot, serve(transcoder, http.MethodPost, path, "{}").Code, path)
+	}
+}
+
+func TestFromRawContents(t *testing.T) {
+	bytesRaw := binary.LittleEndian.AppendUint32(nil, 2)
+	bytesRaw = append(bytesRaw, "ab"...)
+	bytesRaw = binary.LittleEndian.AppendUint32(bytesRaw, 0)
+
+	data, err := fromRawContents("BYTES", bytesRaw)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"ab", ""}, data)
+
+	_, err = fromRawContents("BYTES", bytesRaw[:5])
+	require.Error(t, err)
+
+	data, err = fromRawContents("INT16", []byte{0xff, 0xff, 0x02, 0x00})
+	require.NoError(t, err)
+	assert.Equal(t, []any{json.Number("-1"), json.Number("2")}, data)
+
+	// 1.0 and -2.0 in half precision
+	data, err = fromRawContents("FP16", []byte{0x00, 0x3c, 0x00, 0xc0})
+	require.NoError(t, err)
+	assert.Equal(t, []any{json.Number("1"), json.Number("-2")}, data)
+
+	_, err = fromRawContents("INT32", []byte{0x01})
+	require.Error(t, err)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+)
+
+// ArtifactUploader uploads the artifacts the runtime writes to the artifact directory, e.g. tuned adapters, compiled
+// engines or explanation outputs, under the prefix of the output uri. Only the files which changed since the last
+// upload are uploaded again.
+type ArtifactUploader struct {
+	OutputUri   string
+	ArtifactDir string
+	Providers   map[storage.Protocol]storage.Provider
+	Logger      *zap.SugaredLogger
+
+	// only one upload runs at a time
+	mu       sync.Mutex
+	uploaded map[string]artifactVersion
+}
+
+type artifactVersion struct {
+	size    int64
+	modTime int64
+}
+
+// UploadResult is the response of the on demand upload endpoint
+type UploadResult struct {
+	Uploaded int `json:"uploaded"`
+	Skipped  int `json:"skipped"`
+}
+
+// ParseArtifactOutputUri validates the output uri the artifacts are uploaded to
+func ParseArtifactOutputUri(outputUri string) (storage.Protocol, *url.URL, error) {
+	u, err := url.Parse(outputUri)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid artifact output uri %q: %w", outputUri, err)
+	}
+	protocol := storage.Protocol(u.Scheme + "://")
+	switch protocol {
+	case storage.S3, storage.GCS, storage.AZURE:
+	default:
+		return "", nil, fmt.Errorf("unsupported artifact output uri %q, supported protocols are %s, %s and %s",
+			outputUri, storage.S3, storage.GCS, storage.AZURE)
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("no bucket specified in artifact output uri %q", outputUri)
+	}
+	return protocol, u, nil
+}
+
+// Upload uploads the new and changed artifacts, returning the number of uploaded and skipped files.
+func (u *ArtifactUploader) Upload() (*UploadResult, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	protocol, outputUrl, err := ParseArtifactOutputUri(u.OutputUri)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := storage.GetProvider(u.Providers, protocol)
+	if err != nil {
+		return nil, err
+	}
+	if u.uploaded == nil {
+		u.uploaded = map[string]artifactVersion{}
+	}
+
+	result := &UploadResult{}
+	prefix := strings.TrimPrefix(outputUrl.Path, "/")
+	err = filepath.WalkDir(u.ArtifactDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == u.ArtifactDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(u.ArtifactDir, filePath)
+		if err != nil {
+			return err
+		}
+		version := artifactVersion{size: info.Size(), modTime: info.ModTime().UnixNano()}
+		if previous, ok := u.uploaded[relPath]; ok && previous == version {
+			result.Skipped++
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		key := path.Join(prefix, filepath.ToSlash(relPath))
+		if err := provider.UploadObject(outputUrl.Host, key, data); err != nil {
+			return fmt.Errorf("failed to upload artifact %s: %w", relPath, err)
+		}
+		u.Logger.Infof("Uploaded artifact %s to %s", relPath, u.OutputUri)
+		u.uploaded[relPath] = version
+		result.Uploaded++
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	u.Logger.Infof("Uploaded %d artifacts to %s, %d artifacts are unchanged", result.Uploaded, u.OutputUri, result.Skipped)
+	return result, nil
+}
+
+// ServeHTTP uploads the artifacts on demand, e.g. once the runtime finished writing a tuned adapter.
+func (u *ArtifactUploader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	result, err := u.Upload()
+	if err != nil {
+		u.Logger.Errorw("Failed to upload artifacts", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		u.Logger.Errorw("Failed to write the upload result", zap.Error(err))
+	}
+}
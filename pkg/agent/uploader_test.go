@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+)
+
+// recordingProvider records the objects uploaded to each bucket
+type recordingProvider struct {
+	uploads map[string][]byte
+}
+
+func (p *recordingProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	return nil
+}
+
+func (p *recordingProvider) UploadObject(bucket string, key string, object []byte) error {
+	p.uploads[bucket+"/"+key] = object
+	return nil
+}
+
+var _ = Describe("ArtifactUploader", func() {
+	var artifactDir string
+	var provider *recordingProvider
+	var uploader *ArtifactUploader
+	BeforeEach(func() {
+		artifactDir = GinkgoT().TempDir()
+		provider = &recordingProvider{uploads: map[string][]byte{}}
+		zapLogger, _ := zap.NewProduction()
+		uploader = &ArtifactUploader{
+			OutputUri:   "s3://artifacts/sklearn/v1",
+			ArtifactDir: artifactDir,
+			Providers:   map[storage.Protocol]storage.Provider{storage.S3: provider},
+			Logger:      zapLogger.Sugar(),
+		}
+	})
+
+	It("uploads the new and changed artifacts", func() {
+		Expect(os.MkdirAll(filepath.Join(artifactDir, "adapters"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "adapters", "lora.safetensors"), []byte("lora"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "engine.plan"), []byte("engine"), 0o600)).To(Succeed())
+
+		result, err := uploader.Upload()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&UploadResult{Uploaded: 2}))
+		Expect(provider.uploads).To(Equal(map[string][]byte{
+			"artifacts/sklearn/v1/adapters/lora.safetensors": []byte("lora"),
+			"artifacts/sklearn/v1/engine.plan":               []byte("engine"),
+		}))
+
+		// Only the changed engine is uploaded again
+		provider.uploads = map[string][]byte{}
+		enginePath := filepath.Join(artifactDir, "engine.plan")
+		Expect(os.WriteFile(enginePath, []byte("engine-v2"), 0o600)).To(Succeed())
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(enginePath, later, later)).To(Succeed())
+		result, err = uploader.Upload()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&UploadResult{Uploaded: 1, Skipped: 1}))
+		Expect(provider.uploads).To(Equal(map[string][]byte{
+			"artifacts/sklearn/v1/engine.plan": []byte("engine-v2"),
+		}))
+	})
+
+	It("succeeds when the runtime wrote no artifact", func() {
+		uploader.ArtifactDir = filepath.Join(artifactDir, "missing")
+		result, err := uploader.Upload()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(&UploadResult{}))
+	})
+
+	It("uploads the artifacts on demand", func() {
+		Expect(os.WriteFile(filepath.Join(artifactDir, "explanation.json"), []byte("{}"), 0o600)).To(Succeed())
+
+		recorder := httptest.NewRecorder()
+		uploader.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/artifacts/upload", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`{"uploaded": 1, "skipped": 0}`))
+		Expect(provider.uploads).To(HaveKey("artifacts/sklearn/v1/explanation.json"))
+
+		recorder = httptest.NewRecorder()
+		uploader.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/artifacts/upload", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	DescribeTable("validates the output uri",
+		func(outputUri string, valid bool) {
+			_, _, err := ParseArtifactOutputUri(outputUri)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("s3", "s3://artifacts/prefix", true),
+		Entry("gcs", "gs://artifacts", true),
+		Entry("https", "https://example.com/artifacts", false),
+		Entry("missing bucket", "s3:///prefix", false),
+	)
+})
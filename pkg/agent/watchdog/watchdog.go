@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/network"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	// DefaultStallThreshold is how long the requests can stall before the runtime is hung when the runtime does not
+	// set a threshold
+	DefaultStallThreshold = 5 * time.Minute
+	// DefaultProbePeriodSeconds is how long the runtime stays idle before it is probed when the probe does not set a
+	// period
+	DefaultProbePeriodSeconds = 60
+	// HealthPath is the path of the agent endpoint the liveness probe of the kserve-container is sent to when the
+	// hung runtime is restarted
+	HealthPath = "/watchdog/healthz"
+	// checkInterval is how often the watchdog checks whether the runtime is idle or restarting, short enough for the
+	// probe timing out before a restart not to fail the liveness probe of the restarted runtime
+	checkInterval = 5 * time.Second
+)
+
+// ParseConfig parses and validates the JSON watchdog spec of the serving runtime, and sets its defaults
+func ParseConfig(value string) (*v1alpha1.WatchdogSpec, error) {
+	config := &v1alpha1.WatchdogSpec{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, fmt.Errorf("failed to parse the watchdog config: %w", err)
+	}
+	if config.StallThreshold == nil {
+		config.StallThreshold = &metav1.Duration{Duration: DefaultStallThreshold}
+	} else if config.StallThreshold.Duration <= 0 {
+		return nil, fmt.Errorf("the watchdog stallThreshold must be positive, got %s", config.StallThreshold.Duration)
+	}
+	switch config.Action {
+	case "":
+		config.Action = v1alpha1.WatchdogRestartAction
+	case v1alpha1.WatchdogRestartAction, v1alpha1.WatchdogUnreadyAction:
+	default:
+		return nil, fmt.Errorf("unknown watchdog action %q, expected %s or %s", config.Action,
+			v1alpha1.WatchdogRestartAction, v1alpha1.WatchdogUnreadyAction)
+	}
+	if config.Probe != nil {
+		if !strings.HasPrefix(config.Probe.Path, "/") {
+			return nil, fmt.Errorf("the watchdog probe path %q must start with /", config.Probe.Path)
+		}
+		if config.Probe.PeriodSeconds < 0 {
+			return nil, fmt.Errorf("the watchdog probe periodSeconds must be positive, got %d", config.Probe.PeriodSeconds)
+		}
+		if config.Probe.PeriodSeconds == 0 {
+			config.Probe.PeriodSeconds = DefaultProbePeriodSeconds
+		}
+	}
+	return config, nil
+}
+
+// Watchdog detects the runtime hanging while its health endpoint is still up. The runtime is hung when the requests
+// in flight get no response for the stall threshold, or when the probe request sent to the idle runtime times out.
+// The runtime recovers as soon as it answers a request again.
+type Watchdog struct {
+	config    *v1alpha1.WatchdogSpec
+	client    *http.Client
+	logger    *zap.SugaredLogger
+	now       func() time.Time
+	mu        sync.Mutex
+	inFlight  int
+	progress  time.Time
+	probeHung bool
+	hung      bool
+}
+
+// New returns a watchdog of the runtime with the parsed config
+func New(config *v1alpha1.WatchdogSpec, logger *zap.SugaredLogger) *Watchdog {
+	return &Watchdog{
+		config:   config,
+		client:   &http.Client{Timeout: config.StallThreshold.Duration},
+		logger:   logger,
+		now:      time.Now,
+		progress: time.Now(),
+	}
+}
+
+// Handler tracks the requests of the next handler forwarded to the runtime
+func (w *Watchdog) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if network.IsKubeletProbe(r) {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		w.start()
+		next.ServeHTTP(rw, r)
+		// The requests canceled by the clients say nothing about the runtime
+		w.end(r.Context().Err() == nil)
+	})
+}
+
+// HealthHandler serves the health endpoint of the watchdog, failing while the runtime is hung, and the other
+// requests with the next handler
+func (w *Watchdog) HealthHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != HealthPath {
+			next.ServeHTTP(rw, r)
+			return
+		}
+		if w.Hung() {
+			http.Error(rw, "the runtime is hung", http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	})
+}
+
+// Hung returns whether the runtime is hung
+func (w *Watchdog) Hung() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	hung := w.probeHung || (w.inFlight > 0 && w.now().Sub(w.progress) > w.config.StallThreshold.Duration)
+	if hung != w.hung {
+		if hung {
+			w.logger.Warnw("The runtime is hung", "inFlight", w.inFlight, "lastResponse", w.progress,
+				"action", w.config.Action)
+		} else {
+			w.logger.Info("The runtime answers again")
+		}
+		w.hung = hung
+	}
+	return hung
+}
+
+// Run probes the runtime at the component url whenever it stays idle for the probe period while it is ready, until
+// the context is done. A runtime which is not ready, e.g. restarting, is no longer hung.
+func (w *Watchdog) Run(ctx context.Context, componentURL *url.URL, ready func() bool) {
+	if w.config.Probe == nil {
+		return
+	}
+	period := time.Duration(w.config.Probe.PeriodSeconds) * time.Second
+	target := componentURL.JoinPath(w.config.Probe.Path).String()
+	ticker := time.NewTicker(min(period, checkInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !ready() {
+				w.clearProbe()
+			} else if w.idle(period) {
+				w.probe(ctx, target)
+			}
+		}
+	}
+}
+
+func (w *Watchdog) start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// The stall of the requests is measured from the last response, or from the end of the idle period
+	if w.inFlight == 0 {
+		w.progress = w.now()
+	}
+	w.inFlight++
+}
+
+func (w *Watchdog) end(answered bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.inFlight--
+	if answered {
+		w.progress = w.now()
+		w.probeHung = false
+	}
+}
+
+// idle returns whether no request was in flight or answered for the period
+func (w *Watchdog) idle(period time.Duration) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.inFlight == 0 && w.now().Sub(w.progress) >= period
+}
+
+func (w *Watchdog) clearProbe() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.probeHung = false
+}
+
+// probe sends the probe request to the runtime. The runtime is hung when the probe times out, the other errors,
+// e.g. the runtime restarting, are left to the readiness probe.
+func (w *Watchdog) probe(ctx context.Context, target string) {
+	method, body := http.MethodGet, io.Reader(nil)
+	if w.config.Probe.Body != "" {
+		method, body = http.MethodPost, strings.NewReader(w.config.Probe.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		w.logger.Errorw("Failed to build the watchdog probe", "url", target, zap.Error(err))
+		return
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := w.client.Do(req)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			w.probeHung = true
+			return
+		}
+		w.probeHung = false
+		w.logger.Infow("The watchdog probe failed", "url", target, zap.Error(err))
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	w.progress = w.now()
+	w.probeHung = false
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchdog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// fakeClock is the time of the watchdog, advanced by the tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestWatchdog(t *testing.T, config string) (*Watchdog, *fakeClock) {
+	parsed, err := ParseConfig(config)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	w := New(parsed, zap.NewNop().Sugar())
+	w.now = clock.Now
+	w.progress = clock.Now()
+	return w, clock
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig(`{"probe": {"path": "/v2/health/live"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStallThreshold, config.StallThreshold.Duration)
+	assert.Equal(t, v1alpha1.WatchdogRestartAction, config.Action)
+	assert.Equal(t, int32(DefaultProbePeriodSeconds), config.Probe.PeriodSeconds)
+
+	config, err = ParseConfig(`{"stallThreshold": "30s", "action": "Unready"}`)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, config.StallThreshold.Duration)
+	assert.Equal(t, v1alpha1.WatchdogUnreadyAction, config.Action)
+	assert.Nil(t, config.Probe)
+
+	for config, expected := range map[string]string{
+		`{"stallThreshold": "0s"}`:                      "stallThreshold must be positive",
+		`{"action": "Reboot"}`:                          `unknown watchdog action "Reboot"`,
+		`{"probe": {"path": "health"}}`:                 `probe path "health" must start with /`,
+		`{"probe": {"path": "/", "periodSeconds": -1}}`: "periodSeconds must be positive",
+		`[]`: "failed to parse the watchdog config",
+	} {
+		_, err := ParseConfig(config)
+		assert.ErrorContains(t, err, expected, config)
+	}
+}
+
+func TestWatchdogStalledRequests(t *testing.T) {
+	w, clock := newTestWatchdog(t, `{"stallThreshold": "1m"}`)
+	release := make(chan struct{})
+	handler := w.Handler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	var served sync.WaitGroup
+	serve := func() {
+		served.Add(1)
+		go func() {
+			defer served.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/models/model:predict", nil))
+		}()
+	}
+
+	// An idle runtime is never hung
+	clock.Advance(time.Hour)
+	assert.False(t, w.Hung())
+
+	serve()
+	require.Eventually(t, func() bool { return !w.idle(0) }, time.Second, time.Millisecond)
+	clock.Advance(59 * time.Second)
+	assert.False(t, w.Hung())
+	clock.Advance(2 * time.Second)
+	assert.True(t, w.Hung())
+
+	// The runtime answering the requests recovers
+	close(release)
+	served.Wait()
+	assert.False(t, w.Hung())
+}
+
+func TestWatchdogIgnoresKubeletProbes(t *testing.T) {
+	w, clock := newTestWatchdog(t, `{"stallThreshold": "1m"}`)
+	handler := w.Handler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		clock.Advance(time.Hour)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("User-Agent", "kube-probe/1.30")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, w.idle(0))
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), w.progress)
+}
+
+func TestWatchdogHealthHandler(t *testing.T) {
+	w, _ := newTestWatchdog(t, `{}`)
+	handler := w.HealthHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	}))
+	serve := func(path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(HealthPath))
+	assert.Equal(t, http.StatusTeapot, serve("/v1/models/model"))
+	w.probeHung = true
+	assert.Equal(t, http.StatusServiceUnavailable, serve(HealthPath))
+}
+
+func TestWatchdogProbe(t *testing.T) {
+	hang := make(chan struct{})
+	var hung bool
+	var mu sync.Mutex
+	var requests []*http.Request
+	runtime := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		hanging := hung
+		mu.Unlock()
+		if hanging {
+			<-hang
+		}
+	}))
+	defer runtime.Close()
+	// The hanging requests are released before the runtime is closed
+	defer close(hang)
+	target := runtime.URL + "/v2/models/model/infer"
+
+	w, clock := newTestWatchdog(t, `{"stallThreshold": "100ms", "probe": {"path": "/v2/models/model/infer", "body": "{}"}}`)
+	w.probe(context.Background(), target)
+	assert.False(t, w.Hung())
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/v2/models/model/infer", requests[0].URL.Path)
+	assert.Equal(t, clock.Now(), w.progress)
+
+	mu.Lock()
+	hung = true
+	mu.Unlock()
+	w.probe(context.Background(), target)
+	assert.True(t, w.Hung())
+
+	// The runtime restarting is not hung
+	runtime.CloseClientConnections()
+	w.probe(context.Background(), "http://127.0.0.1:1/v2/models/model/infer")
+	assert.False(t, w.Hung())
+}
+
+func TestWatchdogRun(t *testing.T) {
+	probed := make(chan struct{}, 10)
+	runtime := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		probed <- struct{}{}
+	}))
+	defer runtime.Close()
+	componentURL, err := url.Parse(runtime.URL)
+	require.NoError(t, err)
+
+	config, err := ParseConfig(`{"probe": {"path": "/v2/health/live", "periodSeconds": 1}}`)
+	require.NoError(t, err)
+	w := New(config, zap.NewNop().Sugar())
+	w.progress = time.Now().Add(-time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx, componentURL, func() bool { return true })
+
+	select {
+	case <-probed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the idle runtime was not probed")
+	}
+}
@@ -18,9 +18,11 @@ package agent
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/google/go-cmp/cmp"
@@ -49,8 +51,7 @@ func NewWatcher(configDir string, modelDir string, logger *zap.SugaredLogger) Wa
 		ModelEvents:  make(chan ModelOp, 100),
 		logger:       logger,
 	}
-	modelConfigFile := fmt.Sprintf("%s/%s", configDir, constants.ModelConfigFileName)
-	err = watcher.syncModelConfig(modelConfigFile, true)
+	err = watcher.syncModelConfig(configDir, true)
 	if err != nil {
 		logger.Errorf("Failed to sync model config file %v", err)
 	}
@@ -62,19 +63,25 @@ type modelWrapper struct {
 	stale bool
 }
 
-func (w *Watcher) syncModelConfig(modelConfigFile string, initializing bool) error {
-	file, err := os.ReadFile(modelConfigFile)
-	if err != nil {
-		return err
-	} else {
-		modelConfigs := make(modelconfig.ModelConfigs, 0)
-		err = json.Unmarshal(file, &modelConfigs)
+// syncModelConfig reads the models of all the parts of the model config in the config dir. The first part is
+// required, the other parts only exist when the models do not fit in a single ConfigMap.
+func (w *Watcher) syncModelConfig(configDir string, initializing bool) error {
+	modelConfigs := make(modelconfig.ModelConfigs, 0)
+	for part := 0; part < constants.ModelConfigMaxParts; part++ {
+		file, err := os.ReadFile(filepath.Join(configDir, constants.ModelConfigPartFileName(part)))
 		if err != nil {
+			if part > 0 && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
 			return err
-		} else {
-			w.parseConfig(modelConfigs, initializing)
 		}
+		partConfigs := make(modelconfig.ModelConfigs, 0)
+		if err := json.Unmarshal(file, &partConfigs); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", constants.ModelConfigPartFileName(part), err)
+		}
+		modelConfigs = append(modelConfigs, partConfigs...)
 	}
+	w.parseConfig(modelConfigs, initializing)
 	return nil
 }
 
@@ -109,8 +116,7 @@ func (w *Watcher) Start() {
 				if isDataDir && isCreate {
 					w.logger.Infof("Processing event %s", event)
 					symlink, _ := filepath.EvalSymlinks(eventPath)
-					modelConfigFile := filepath.Join(symlink, constants.ModelConfigFileName)
-					err := w.syncModelConfig(modelConfigFile, false)
+					err := w.syncModelConfig(symlink, false)
 					if err != nil {
 						w.logger.Error(err, "Failed to sync model config file")
 					}
@@ -136,14 +142,18 @@ func (w *Watcher) Start() {
 }
 
 func (w *Watcher) parseConfig(modelConfigs modelconfig.ModelConfigs, initializing bool) {
+	// add the models of higher priority first, the puller loads them before the models of lower priority on startup
+	slices.SortStableFunc(modelConfigs, func(a, b modelconfig.ModelConfig) int {
+		return int(b.Priority) - int(a.Priority)
+	})
 	for _, modelConfig := range modelConfigs {
-		name, spec := modelConfig.Name, modelConfig.Spec
+		name, spec, priority := modelConfig.Name, modelConfig.Spec, modelConfig.Priority
 		existing, exists := w.ModelTracker[name]
 		switch {
 		case !exists:
 			// New - add
 			w.ModelTracker[name] = modelWrapper{Spec: &spec}
-			w.modelAdded(name, &spec, initializing)
+			w.modelAdded(name, &spec, priority, initializing)
 		case !cmp.Equal(spec, *existing.Spec):
 			w.ModelTracker[name] = modelWrapper{
 				Spec:  existing.Spec,
@@ -151,7 +161,7 @@ func (w *Watcher) parseConfig(modelConfigs modelconfig.ModelConfigs, initializin
 			}
 			// Changed - replace
 			w.modelRemoved(name)
-			w.modelAdded(name, &spec, initializing)
+			w.modelAdded(name, &spec, priority, initializing)
 		default:
 			// This model didn't change, mark the stale flag to false
 			w.ModelTracker[name] = modelWrapper{
@@ -177,13 +187,14 @@ func (w *Watcher) parseConfig(modelConfigs modelconfig.ModelConfigs, initializin
 	}
 }
 
-func (w *Watcher) modelAdded(name string, spec *v1alpha1.ModelSpec, initializing bool) {
+func (w *Watcher) modelAdded(name string, spec *v1alpha1.ModelSpec, priority int32, initializing bool) {
 	w.logger.Infof("adding model %s", name)
 	w.ModelEvents <- ModelOp{
 		OnStartup: initializing,
 		ModelName: name,
 		Op:        Add,
 		Spec:      spec,
+		Priority:  priority,
 	}
 }
 
@@ -66,6 +66,36 @@ var _ = Describe("Watcher", func() {
 		logger.Printf("Deleted temp dir %v\n", modelDir)
 	})
 
+	Describe("Sync models config split in parts", func() {
+		Context("Reading the parts of the model config", func() {
+			It("should add the models of all the parts", func() {
+				configDir, err := os.MkdirTemp("", "configs")
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(func() {
+					os.RemoveAll(configDir)
+				})
+				for part, name := range []string{"model1", "model2"} {
+					file, _ := json.Marshal(modelconfig.ModelConfigs{
+						{
+							Name: name,
+							Spec: v1alpha1.ModelSpec{
+								StorageURI: "s3://models/" + name,
+								Framework:  "sklearn",
+								Memory:     resource.MustParse("100Mi"),
+							},
+						},
+					})
+					Expect(os.WriteFile(filepath.Join(configDir, constants.ModelConfigPartFileName(part)), file, 0o600)).To(Succeed())
+				}
+
+				watcher := NewWatcher(configDir, modelDir, sugar)
+				Expect(watcher.ModelTracker).To(HaveKey("model1"))
+				Expect(watcher.ModelTracker).To(HaveKey("model2"))
+				Expect(watcher.ModelEvents).To(HaveLen(2))
+			})
+		})
+	})
+
 	Describe("Sync models config on startup", func() {
 		Context("Getting new model events", func() {
 			It("should download and load the new models", func() {
@@ -141,6 +171,73 @@ var _ = Describe("Watcher", func() {
 		})
 	})
 
+	Describe("Load models by priority on startup", func() {
+		Context("Parsing the model config on startup", func() {
+			It("should add the models by decreasing priority", func() {
+				watcher := NewWatcher("/tmp/configs", modelDir, sugar)
+				watcher.parseConfig(modelconfig.ModelConfigs{
+					{Name: "low", Spec: v1alpha1.ModelSpec{StorageURI: "s3://models/low", Framework: "sklearn"}},
+					{Name: "high", Priority: 10, Spec: v1alpha1.ModelSpec{StorageURI: "s3://models/high", Framework: "sklearn"}},
+					{Name: "negative", Priority: -1, Spec: v1alpha1.ModelSpec{StorageURI: "s3://models/negative", Framework: "sklearn"}},
+				}, true)
+				names := []string{}
+				for range 3 {
+					modelOp := <-watcher.ModelEvents
+					names = append(names, modelOp.ModelName)
+				}
+				Expect(names).To(Equal([]string{"high", "low", "negative"}))
+			})
+		})
+
+		Context("Scheduling the startup loads", func() {
+			It("should defer the models of lower priority until the models of higher priority are loaded", func() {
+				puller := Puller{
+					channelMap:  make(map[string]*ModelChannel),
+					completions: make(chan *ModelOp, 4),
+					opStats:     make(map[string]map[OpType]int),
+					waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
+					Downloader: &Downloader{
+						ModelDir: modelDir + "/priority",
+						Providers: map[storage.Protocol]storage.Provider{
+							storage.S3: &storage.S3Provider{
+								Client:     &mocks.MockS3Client{},
+								Downloader: &mocks.MockS3Downloader{},
+							},
+						},
+						Logger: sugar,
+					},
+					logger: sugar,
+				}
+				newOp := func(name string, priority int32) *ModelOp {
+					return &ModelOp{
+						OnStartup: true,
+						ModelName: name,
+						Op:        Add,
+						Priority:  priority,
+						Spec:      &v1alpha1.ModelSpec{StorageURI: "s3://models/" + name, Framework: "sklearn"},
+					}
+				}
+				puller.waitGroup.wg.Add(3)
+				puller.scheduleModelOp(newOp("critical1", 10))
+				puller.scheduleModelOp(newOp("critical2", 10))
+				puller.scheduleModelOp(newOp("batch", 0))
+				Expect(puller.channelMap).To(HaveKey("critical1"))
+				Expect(puller.channelMap).To(HaveKey("critical2"))
+				Expect(puller.channelMap).ToNot(HaveKey("batch"))
+
+				// the models of the same priority load in parallel, the next priority waits for all of them
+				puller.modelOpComplete(<-puller.completions, false)
+				Expect(puller.channelMap).ToNot(HaveKey("batch"))
+				puller.modelOpComplete(<-puller.completions, false)
+				Expect(puller.channelMap).To(HaveKey("batch"))
+				puller.modelOpComplete(<-puller.completions, false)
+				puller.waitGroup.wg.Wait()
+				Expect(puller.channelMap).To(BeEmpty())
+				Expect(puller.deferredOps).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Watch model config changes", func() {
 		Context("When new models are added", func() {
 			It("Should download and load the new models", func() {
@@ -19,8 +19,11 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 // InferenceGraph is the Schema for the InferenceGraph API for multiple models
@@ -86,6 +89,41 @@ type InferenceGraphSpec struct {
 	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// CanaryTrafficPercent defines the percentage of the traffic routed to the latest revision of the graph nodes,
+	// the remaining traffic is routed to the last rolled out revision. When unset or 100, changes to the graph nodes
+	// are rolled out to all the traffic.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
+	// InferenceServices declares the InferenceServices of the graph steps inline, so that a whole pipeline is deployed
+	// as one resource. They are created in the order of their dependencies, owned by the InferenceGraph and deleted
+	// along with it.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	InferenceServices []InferenceServiceTemplate `json:"inferenceServices,omitempty"`
+}
+
+// InferenceServiceTemplate declares an InferenceService created and owned by the InferenceGraph
+// +k8s:openapi-gen=true
+type InferenceServiceTemplate struct {
+	// Name of the InferenceService, referenced by the serviceName of the graph steps.
+	Name string `json:"name"`
+	// Labels of the InferenceService.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations of the InferenceService.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Spec of the InferenceService, as the spec of a serving.kserve.io/v1beta1 InferenceService.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+	// DependsOn lists the names of the inline InferenceServices which must be ready before this InferenceService is
+	// created, e.g. the feature transformer queried by the model.
+	// +optional
+	// +listType=set
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // ScaleMetric enum
@@ -258,7 +296,8 @@ type InferenceRouter struct {
 	//
 	// - `Sequence:` chain multiple inference steps with input/output from previous step
 	//
-	// - `Splitter:` randomly routes to the target service according to the weight
+	// - `Splitter:` randomly routes to the target service according to the weight, or consistently
+	// by request key when splitKeyHeader is set
 	//
 	// - `Ensemble:` routes the request to multiple models and then merge the responses
 	//
@@ -269,6 +308,55 @@ type InferenceRouter struct {
 	// Steps defines destinations for the current router node
 	// +optional
 	Steps []InferenceStep `json:"steps,omitempty"`
+
+	// SplitKeyHeader is the name of the request header holding the key used to consistently route the requests
+	// with the same key, e.g. a user id, to the same step of a Splitter node. The requests without the header
+	// are routed randomly according to the weights.
+	// +optional
+	SplitKeyHeader string `json:"splitKeyHeader,omitempty"`
+
+	// BudgetHeader is the name of the request header holding the latency budget of the request in milliseconds.
+	// A Switch node skips the steps whose latencyCostMs exceeds the budget, e.g. a re-ranker when the budget is
+	// tight, and routes the request to the next step matching its condition. The requests without the header
+	// are routed by condition only.
+	// +optional
+	BudgetHeader string `json:"budgetHeader,omitempty"`
+
+	// Cache the responses of the node, so that repeated requests to the node, e.g. a shared
+	// embedding step of several pipelines, are not recomputed
+	// +optional
+	Cache *InferenceRouterCache `json:"cache,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// InferenceRouterCache caches the successful responses of a router node keyed on the hash of the node input and of
+// the key headers of the request
+type InferenceRouterCache struct {
+	// Time to live of the cached responses in seconds
+	// +kubebuilder:validation:Minimum=1
+	TTLSeconds int64 `json:"ttlSeconds"`
+
+	// Maximum number of cached responses of the node, the oldest response is evicted when the cache is full.
+	// Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+
+	// SharedKey caches the responses in the shared cache of the cluster under this key instead of the router, so
+	// that the nodes of all the graphs of the namespace setting the same key, e.g. the embedding step of several
+	// graphs calling the same embedding model, reuse the responses of each other. The entries of a namespace are
+	// bounded by its quota in the shared cache, maxEntries does not apply. The responses are cached by the router
+	// when the shared cache is not enabled.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	SharedKey string `json:"sharedKey,omitempty"`
+
+	// KeyHeaders are the request headers included with the node input in the cache key, so that the requests which
+	// differ on them, e.g. the tenant or the user of the request, do not share their cached responses
+	// +optional
+	// +listType=set
+	KeyHeaders []string `json:"keyHeaders,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -335,6 +423,12 @@ type InferenceStep struct {
 	// to decide whether a step is a hard or a soft dependency in the Inference Graph
 	// +optional
 	Dependency InferenceStepDependencyType `json:"dependency,omitempty"`
+
+	// the expected latency of the step in milliseconds, only used by Switch nodes with a budgetHeader
+	// to skip the step when the latency budget of the request is lower
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LatencyCostMs *int64 `json:"latencyCostMs,omitempty"`
 }
 
 // InferenceGraphStatus defines the InferenceGraph conditions and status
@@ -347,6 +441,89 @@ type InferenceGraphStatus struct {
 	URL *apis.URL `json:"url,omitempty"`
 	// InferenceGraph DeploymentMode
 	DeploymentMode string `json:"deploymentMode,omitempty"`
+	// Latest revision of the graph nodes
+	// +optional
+	LatestCreatedRevision string `json:"latestCreatedRevision,omitempty"`
+	// Revision of the graph nodes serving the traffic not routed to the canary revision
+	// +optional
+	LatestRolledoutRevision string `json:"latestRolledoutRevision,omitempty"`
+	// Topology of the graph, e.g. to render the graph in a UI or to generate its documentation
+	// +optional
+	Topology *InferenceGraphTopology `json:"topology,omitempty"`
+}
+
+// InferenceGraphTopologyNodeType is the type of a node of the graph topology
+// +k8s:openapi-gen=true
+type InferenceGraphTopologyNodeType string
+
+// InferenceGraphTopologyNodeType Enum
+const (
+	// TopologyRouterNode is a router node of the graph
+	TopologyRouterNode InferenceGraphTopologyNodeType = "Router"
+	// TopologyInferenceServiceNode is an InferenceService targeted by a step
+	TopologyInferenceServiceNode InferenceGraphTopologyNodeType = "InferenceService"
+	// TopologyURLNode is a service url targeted by a step
+	TopologyURLNode InferenceGraphTopologyNodeType = "URL"
+)
+
+// InferenceGraphTopology is the machine readable topology of the graph, the router nodes and the step targets
+// connected by the steps
+// +k8s:openapi-gen=true
+type InferenceGraphTopology struct {
+	// Nodes of the graph, sorted by id
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Nodes []InferenceGraphTopologyNode `json:"nodes,omitempty"`
+	// Edges of the graph, one for each step in the order of the steps of the router nodes
+	// +optional
+	// +listType=atomic
+	Edges []InferenceGraphTopologyEdge `json:"edges,omitempty"`
+}
+
+// InferenceGraphTopologyNode is a router node or a step target of the graph
+// +k8s:openapi-gen=true
+type InferenceGraphTopologyNode struct {
+	// ID of the node, unique within the topology, e.g. router/root, isvc/my-model or url/http://my-model
+	ID string `json:"id"`
+	// Type of the node
+	Type InferenceGraphTopologyNodeType `json:"type"`
+	// Name of the router node or of the InferenceService
+	// +optional
+	Name string `json:"name,omitempty"`
+	// RouterType of a router node
+	// +optional
+	RouterType InferenceRouterType `json:"routerType,omitempty"`
+	// Protocol of the InferenceService
+	// +optional
+	Protocol constants.InferenceServiceProtocol `json:"protocol,omitempty"`
+	// URL the requests to the InferenceService or service url are sent to
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// InferenceGraphTopologyEdge is a step of a router node
+// +k8s:openapi-gen=true
+type InferenceGraphTopologyEdge struct {
+	// ID of the router node of the step
+	From string `json:"from"`
+	// ID of the target of the step
+	To string `json:"to"`
+	// Name of the step
+	// +optional
+	StepName string `json:"stepName,omitempty"`
+	// Weight of the step of a Splitter node
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+	// Condition of the step of a Switch node
+	// +optional
+	Condition string `json:"condition,omitempty"`
+	// Data sent to the target of the step
+	// +optional
+	Data string `json:"data,omitempty"`
+	// Dependency of the step
+	// +optional
+	Dependency InferenceStepDependencyType `json:"dependency,omitempty"`
 }
 
 // InferenceGraphList contains a list of InferenceGraph
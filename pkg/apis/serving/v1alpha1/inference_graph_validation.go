@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	utils "github.com/kserve/kserve/pkg/utils"
 
@@ -40,12 +41,29 @@ const (
 	WeightNotProvidedError = "InferenceGraph[%s] Node[%s] Route[%s] missing the 'Weight'"
 	// InvalidWeightError defines the error message for sum of traffic weight is not 100
 	InvalidWeightError = "InferenceGraph[%s] Node[%s] splitter node: the sum of traffic weights for all routing targets should be 100"
+	// SplitKeyHeaderNotSupportedError defines the error message for split key header set on a node which is not a splitter node
+	SplitKeyHeaderNotSupportedError = "InferenceGraph[%s] Node[%s] splitKeyHeader is only supported by Splitter nodes"
+	// BudgetHeaderNotSupportedError defines the error message for budget header set on a node which is not a switch node
+	BudgetHeaderNotSupportedError = "InferenceGraph[%s] Node[%s] budgetHeader is only supported by Switch nodes"
+	// CacheNotSupportedError defines the error message for cache set on a splitter node, whose responses depend on the
+	// randomly picked route
+	CacheNotSupportedError = "InferenceGraph[%s] Node[%s] cache is not supported by Splitter nodes"
 	// DuplicateStepNameError defines the error message for more than one step contains same name
 	DuplicateStepNameError = "Node \"%s\" of InferenceGraph \"%s\" contains more than one step with name \"%s\""
 	// TargetNotProvidedError defines the error message for inference graph target not specified
 	TargetNotProvidedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" does not specify an inference target"
 	// InvalidTargetError defines the error message for inference graph target specifies more than one of nodeName, serviceName, serviceUrl
 	InvalidTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" specifies more than one of nodeName, serviceName, serviceUrl"
+	// InvalidInferenceServiceTemplateNameError defines the error message for invalid inline inference service name
+	InvalidInferenceServiceTemplateNameError = "InferenceService \"%s\" of InferenceGraph \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
+	// DuplicateInferenceServiceTemplateError defines the error message for more than one inline inference service with same name
+	DuplicateInferenceServiceTemplateError = "InferenceGraph \"%s\" declares more than one InferenceService with name \"%s\""
+	// InferenceServiceTemplateSpecNotProvidedError defines the error message for inline inference service without spec
+	InferenceServiceTemplateSpecNotProvidedError = "InferenceService \"%s\" of InferenceGraph \"%s\" does not specify a spec"
+	// UnknownInferenceServiceDependencyError defines the error message for dependency on an undeclared inference service
+	UnknownInferenceServiceDependencyError = "InferenceService \"%s\" of InferenceGraph \"%s\" depends on InferenceService \"%s\" which is not declared by the graph"
+	// InferenceServiceDependencyCycleError defines the error message for cyclic dependencies between inline inference services
+	InferenceServiceDependencyCycleError = "InferenceGraph \"%s\" declares InferenceServices with cyclic dependencies: %s"
 )
 
 const (
@@ -126,6 +144,18 @@ func validateInferenceGraph(ig *InferenceGraph) (admission.Warnings, error) {
 	if err := validateInferenceGraphSplitterWeight(ig); err != nil {
 		return nil, err
 	}
+
+	if err := validateInferenceGraphBudgetHeader(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphCache(ig); err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceGraphInferenceServices(ig); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -198,6 +228,9 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	nodes := ig.Spec.Nodes
 	for name, node := range nodes {
 		weight := 0
+		if node.SplitKeyHeader != "" && node.RouterType != Splitter {
+			return fmt.Errorf(SplitKeyHeaderNotSupportedError, ig.Name, name)
+		}
 		if node.RouterType == Splitter {
 			for _, route := range node.Steps {
 				if route.Weight == nil {
@@ -212,3 +245,90 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	}
 	return nil
 }
+
+// Validation of latency budget routing
+func validateInferenceGraphBudgetHeader(ig *InferenceGraph) error {
+	for name, node := range ig.Spec.Nodes {
+		if node.BudgetHeader != "" && node.RouterType != Switch {
+			return fmt.Errorf(BudgetHeaderNotSupportedError, ig.Name, name)
+		}
+	}
+	return nil
+}
+
+// Validation of node response caching
+func validateInferenceGraphCache(ig *InferenceGraph) error {
+	for name, node := range ig.Spec.Nodes {
+		if node.Cache != nil && node.RouterType == Splitter {
+			return fmt.Errorf(CacheNotSupportedError, ig.Name, name)
+		}
+	}
+	return nil
+}
+
+// Validation of inline inference services
+func validateInferenceGraphInferenceServices(ig *InferenceGraph) error {
+	nameSet := sets.NewString()
+	for _, template := range ig.Spec.InferenceServices {
+		if !GraphRegexp.MatchString(template.Name) {
+			return fmt.Errorf(InvalidInferenceServiceTemplateNameError, template.Name, ig.Name, GraphNameFmt)
+		}
+		if nameSet.Has(template.Name) {
+			return fmt.Errorf(DuplicateInferenceServiceTemplateError, ig.Name, template.Name)
+		}
+		nameSet.Insert(template.Name)
+		if len(template.Spec.Raw) == 0 {
+			return fmt.Errorf(InferenceServiceTemplateSpecNotProvidedError, template.Name, ig.Name)
+		}
+	}
+	_, err := SortInferenceServiceTemplates(ig.Name, ig.Spec.InferenceServices)
+	return err
+}
+
+// SortInferenceServiceTemplates returns the inline inference services of the graph in the order of their
+// dependencies, so that an InferenceService comes after the ones it depends on. The declaration order is kept
+// otherwise.
+func SortInferenceServiceTemplates(graphName string, templates []InferenceServiceTemplate) ([]InferenceServiceTemplate, error) {
+	byName := make(map[string]int, len(templates))
+	for i, template := range templates {
+		byName[template.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(templates))
+	sorted := make([]InferenceServiceTemplate, 0, len(templates))
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf(InferenceServiceDependencyCycleError, graphName,
+				strings.Join(append(path, templates[i].Name), " -> "))
+		}
+		state[i] = visiting
+		path = append(path, templates[i].Name)
+		for _, dependency := range templates[i].DependsOn {
+			j, ok := byName[dependency]
+			if !ok {
+				return fmt.Errorf(UnknownInferenceServiceDependencyError, templates[i].Name, graphName, dependency)
+			}
+			if err := visit(j, path); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		sorted = append(sorted, templates[i])
+		return nil
+	}
+	for i := range templates {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func makeTestInferenceGraph() InferenceGraph {
@@ -260,6 +261,119 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 			errMatcher:      gomega.MatchError(fmt.Errorf(DuplicateStepNameError, GraphRootNodeName, "foo-bar", "step1")),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"split key header on splitter node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:     Splitter,
+					SplitKeyHeader: "User-Id",
+					Steps: []InferenceStep{
+						{
+							StepName: "old-ranking",
+							Weight:   proto.Int64(90),
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "new-ranking",
+							Weight:   proto.Int64(10),
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"split key header on sequence node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:     Sequence,
+					SplitKeyHeader: "User-Id",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(SplitKeyHeaderNotSupportedError, "foo-bar", GraphRootNodeName)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"budget header on switch node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:   Switch,
+					BudgetHeader: "X-Latency-Budget-Ms",
+					Steps: []InferenceStep{
+						{
+							StepName: "rerank",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "reranker",
+							},
+							Condition:     "instances",
+							LatencyCostMs: proto.Int64(200),
+						},
+						{
+							StepName: "retrieve",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "retriever",
+							},
+							Condition: "instances",
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"budget header on sequence node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType:   Sequence,
+					BudgetHeader: "X-Latency-Budget-Ms",
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(BudgetHeaderNotSupportedError, "foo-bar", GraphRootNodeName)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"cache on splitter node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Cache:      &InferenceRouterCache{TTLSeconds: 60},
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(100),
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(CacheNotSupportedError, "foo-bar", GraphRootNodeName)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	validator := InferenceGraphValidator{}
@@ -364,3 +478,94 @@ func (ig *InferenceGraph) update(igField string, value string) {
 		ig.Name = value
 	}
 }
+
+func TestInferenceGraph_ValidateInferenceServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := runtime.RawExtension{Raw: []byte(`{"predictor":{"model":{"modelFormat":{"name":"sklearn"}}}}`)}
+	scenarios := map[string]struct {
+		inferenceServices []InferenceServiceTemplate
+		errMatcher        types.GomegaMatcher
+	}{
+		"no inference services": {
+			errMatcher: gomega.MatchError(nil),
+		},
+		"ordered dependencies": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "model", Spec: spec, DependsOn: []string{"transformer"}},
+				{Name: "transformer", Spec: spec},
+			},
+			errMatcher: gomega.MatchError(nil),
+		},
+		"invalid name": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "Model", Spec: spec},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidInferenceServiceTemplateNameError, "Model", "foo-bar", GraphNameFmt)),
+		},
+		"duplicate name": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "model", Spec: spec},
+				{Name: "model", Spec: spec},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(DuplicateInferenceServiceTemplateError, "foo-bar", "model")),
+		},
+		"missing spec": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "model"},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InferenceServiceTemplateSpecNotProvidedError, "model", "foo-bar")),
+		},
+		"unknown dependency": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "model", Spec: spec, DependsOn: []string{"transformer"}},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnknownInferenceServiceDependencyError, "model", "foo-bar", "transformer")),
+		},
+		"self dependency": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "model", Spec: spec, DependsOn: []string{"model"}},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InferenceServiceDependencyCycleError, "foo-bar", "model -> model")),
+		},
+		"dependency cycle": {
+			inferenceServices: []InferenceServiceTemplate{
+				{Name: "a", Spec: spec, DependsOn: []string{"b"}},
+				{Name: "b", Spec: spec, DependsOn: []string{"c"}},
+				{Name: "c", Spec: spec, DependsOn: []string{"a"}},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InferenceServiceDependencyCycleError, "foo-bar", "a -> b -> c -> a")),
+		},
+	}
+
+	validator := InferenceGraphValidator{}
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Spec.Nodes = map[string]InferenceRouter{
+				GraphRootNodeName: {},
+			}
+			ig.Spec.InferenceServices = scenario.inferenceServices
+			_, err := validator.ValidateCreate(t.Context(), &ig)
+			if !g.Expect(gomega.MatchError(err)).To(gomega.Equal(scenario.errMatcher)) {
+				t.Errorf("got %t, want %t", err, scenario.errMatcher)
+			}
+		})
+	}
+}
+
+func TestSortInferenceServiceTemplates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	templates := []InferenceServiceTemplate{
+		{Name: "ensemble", DependsOn: []string{"model-a", "model-b"}},
+		{Name: "model-a", DependsOn: []string{"transformer"}},
+		{Name: "model-b"},
+		{Name: "transformer"},
+	}
+	sorted, err := SortInferenceServiceTemplates("foo-bar", templates)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	names := make([]string, 0, len(sorted))
+	for _, template := range sorted {
+		names = append(names, template.Name)
+	}
+	g.Expect(names).To(gomega.Equal([]string{"transformer", "model-a", "model-b", "ensemble"}))
+}
@@ -44,6 +44,10 @@ const (
 	InferencePoolReady apis.ConditionType = "InferencePoolReady"
 )
 
+const (
+	LoRAAdaptersReady apis.ConditionType = "LoRAAdaptersReady"
+)
+
 var llmInferenceServiceCondSet = apis.NewLivingConditionSet(
 	WorkloadReady,
 	RouterReady,
@@ -168,3 +172,17 @@ func (in *LLMInferenceService) DetermineRouterReadiness() {
 	}
 	in.GetConditionSet().Manage(in.GetStatus()).MarkTrue(RouterReady)
 }
+
+func (in *LLMInferenceService) MarkLoRAAdaptersReady() {
+	in.GetConditionSet().Manage(in.GetStatus()).MarkTrue(LoRAAdaptersReady)
+}
+
+func (in *LLMInferenceService) MarkLoRAAdaptersNotReady(reason, messageFormat string, messageA ...interface{}) {
+	in.GetConditionSet().Manage(in.GetStatus()).MarkFalse(LoRAAdaptersReady, reason, messageFormat, messageA...)
+}
+
+// ClearLoRAAdapters removes the status of the LoRA adapters when none are configured.
+func (in *LLMInferenceService) ClearLoRAAdapters() {
+	in.Status.Adapters = nil
+	_ = in.GetConditionSet().Manage(in.GetStatus()).ClearCondition(LoRAAdaptersReady)
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -91,10 +92,16 @@ type LLMInferenceServiceSpec struct {
 // WorkloadSpec defines the configuration for a deployment workload, such as replicas and pod specifications.
 type WorkloadSpec struct {
 	// Number of replicas for the deployment.
+	// It is the initial number of replicas when autoscaling is configured.
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Autoscaling configures a HorizontalPodAutoscaler scaling the replicas of the deployment.
+	// In a disaggregated deployment, the 'decode' and 'prefill' workloads are scaled independently, each on its own metric.
+	// +optional
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// Parallelism configurations for the runtime, such as tensor and pipeline parallelism.
 	// These values are used to configure the underlying inference runtime (e.g., vLLM).
 	// +optional
@@ -126,6 +133,13 @@ type LLMModelSpec struct {
 	// +optional
 	Name *string `json:"name,omitempty"`
 
+	// Aliases are the additional names the model is served as, e.g. the names of the OpenAI models the clients are
+	// built for. They are passed to the model server along with the name, and the requests of the OpenAI-compatible
+	// route are routed by any of them.
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Aliases []string `json:"aliases,omitempty"`
+
 	// Criticality defines how important it is to serve the model compared to other models.
 	// This is used by the Inference Gateway scheduler.
 	// +optional
@@ -140,7 +154,9 @@ type LLMModelSpec struct {
 // LoRASpec defines the configuration for LoRA adapters.
 type LoRASpec struct {
 	// Adapters is the static specification for one or more LoRA adapters.
-	// Each adapter is defined by its own ModelSpec.
+	// Each adapter is defined by its own ModelSpec, with a required name and an hf:// URI.
+	// The adapters are loaded and unloaded at runtime through the admin API of the model server, so that changing
+	// them does not restart the workload, and their readiness is reported in the status.
 	// +optional
 	// This type is recursive https://github.com/kubernetes-sigs/controller-tools/issues/585
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -178,6 +194,32 @@ type GatewayRoutesSpec struct {
 	// HTTP route configuration.
 	// +optional
 	HTTP *HTTPRouteSpec `json:"http,omitempty"`
+
+	// OpenAI exposes the OpenAI-compatible endpoints at the root of the gateway, so that the clients built for the
+	// OpenAI API can call the model without a custom gateway.
+	// If an empty object `{}` is provided, the chat completions, completions and embeddings endpoints are exposed.
+	// +optional
+	OpenAI *OpenAIRouteSpec `json:"openai,omitempty"`
+}
+
+// OpenAIEndpoint is an endpoint of the OpenAI API, relative to /v1.
+// +kubebuilder:validation:Enum=chat/completions;completions;embeddings
+type OpenAIEndpoint string
+
+const (
+	OpenAIChatCompletionsEndpoint OpenAIEndpoint = "chat/completions"
+	OpenAICompletionsEndpoint     OpenAIEndpoint = "completions"
+	OpenAIEmbeddingsEndpoint      OpenAIEndpoint = "embeddings"
+)
+
+// OpenAIRouteSpec defines the OpenAI-compatible HTTPRoute managed by the controller.
+// The endpoints of every model share the same paths on the gateway, so the requests are matched by the model name
+// header the body-based routing extension of the Inference Gateway sets from the "model" parameter of the request.
+type OpenAIRouteSpec struct {
+	// Endpoints are the OpenAI API endpoints exposed.
+	// If omitted, the chat completions, completions and embeddings endpoints are exposed.
+	// +optional
+	Endpoints []OpenAIEndpoint `json:"endpoints,omitempty"`
 }
 
 // HTTPRouteSpec defines configurations for a Gateway API HTTPRoute.
@@ -225,12 +267,39 @@ type SchedulerSpec struct {
 	// +optional
 	Pool *InferencePoolSpec `json:"pool,omitempty"`
 
+	// Scoring configures how the Endpoint Picker (EPP) scores the endpoints of the pool, when its configuration
+	// is generated by the controller.
+	// +optional
+	Scoring *SchedulerScoringSpec `json:"scoring,omitempty"`
+
 	// Template for the Inference Gateway Extension pod spec.
 	// This configures the Endpoint Picker (EPP) Deployment.
 	// +optional
 	Template *corev1.PodSpec `json:"template,omitempty"`
 }
 
+// SchedulerScoringSpec defines the weights of the scorers of the Endpoint Picker (EPP), which routes each request
+// to the endpoint with the highest weighted score. A weight of 0 disables the scorer.
+type SchedulerScoringSpec struct {
+	// PrefixCacheWeight is the weight of the KV-cache locality, which favors the endpoints holding the longest
+	// prefix of the prompt in their KV-cache. Defaults to 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PrefixCacheWeight *int32 `json:"prefixCacheWeight,omitempty"`
+
+	// QueueDepthWeight is the weight of the queue depth, which favors the endpoints with the fewest waiting
+	// requests. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	QueueDepthWeight *int32 `json:"queueDepthWeight,omitempty"`
+
+	// KVCacheUtilizationWeight is the weight of the KV-cache utilization, which favors the endpoints with the most
+	// free KV-cache. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	KVCacheUtilizationWeight *int32 `json:"kvCacheUtilizationWeight,omitempty"`
+}
+
 // InferencePoolSpec defines the configuration for an InferencePool.
 // 'Spec' and 'Ref' are mutually exclusive.
 type InferencePoolSpec struct {
@@ -243,6 +312,30 @@ type InferencePoolSpec struct {
 	Ref *corev1.LocalObjectReference `json:"ref,omitempty"`
 }
 
+// AutoscalingSpec defines the HorizontalPodAutoscaler of a workload.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must be less than or equal to maxReplicas"
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit of the replicas. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit of the replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Metric the replicas are scaled on. "cpu" and "memory" scale on the utilization of the resource requests of the
+	// pods, any other metric is a pods metric of the custom metrics API, e.g. the vllm:num_requests_waiting metric of
+	// the model server exposed by the Prometheus adapter. Defaults to "cpu".
+	// +optional
+	Metric string `json:"metric,omitempty"`
+
+	// Target is the average value of the metric across the pods, a utilization percentage for "cpu" and "memory".
+	// Defaults to 80 for "cpu" and "memory", and is required for the other metrics.
+	// +optional
+	Target *resource.Quantity `json:"target,omitempty"`
+}
+
 // ParallelismSpec defines the parallelism parameters for distributed inference.
 type ParallelismSpec struct {
 	// Tensor parallelism size.
@@ -292,6 +385,26 @@ type LLMInferenceServiceStatus struct {
 
 	// Addressable endpoint for the service, including cluster-local URLs.
 	duckv1.AddressStatus `json:",inline,omitempty"`
+
+	// Adapters is the status of the LoRA adapters loaded by the model server.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Adapters []LoRAAdapterStatus `json:"adapters,omitempty"`
+}
+
+// LoRAAdapterStatus is the status of a LoRA adapter loaded at runtime by the model server.
+type LoRAAdapterStatus struct {
+	// Name of the adapter, as set in the "model" parameter for an incoming request.
+	Name string `json:"name"`
+
+	// URI of the adapter weights.
+	// +optional
+	URI string `json:"uri,omitempty"`
+
+	// Conditions of the adapter. Ready is True once every ready replica of the model server has loaded it.
+	// +optional
+	Conditions duckv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
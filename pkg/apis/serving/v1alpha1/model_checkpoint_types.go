@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelCheckpointSpec defines the container checkpointed by the checkpoint agent of a node.
+// ModelCheckpoints are created by the InferenceService controller for the current ReplicaSet of a predictor, and are
+// named after it.
+// +k8s:openapi-gen=true
+type ModelCheckpointSpec struct {
+	// PodName is the ready predictor pod whose container is checkpointed.
+	PodName string `json:"podName"`
+	// ContainerName is the container of the pod which is checkpointed.
+	ContainerName string `json:"containerName"`
+	// NodeName is the node of the pod, whose checkpoint agent checkpoints the container.
+	NodeName string `json:"nodeName"`
+	// Image is the reference the checkpoint image is pushed to.
+	Image string `json:"image"`
+}
+
+// ModelCheckpointPhase is the phase of a ModelCheckpoint
+// +kubebuilder:validation:Enum=Pending;Completed;Failed
+type ModelCheckpointPhase string
+
+// ModelCheckpointPhase Enum
+const (
+	// ModelCheckpointPending is waiting for the checkpoint agent of the node to checkpoint the container
+	ModelCheckpointPending ModelCheckpointPhase = "Pending"
+	// ModelCheckpointCompleted has pushed the checkpoint image, the new pods of the ReplicaSet are restored from it
+	ModelCheckpointCompleted ModelCheckpointPhase = "Completed"
+	// ModelCheckpointFailed failed to checkpoint the container, it is not retried until the ModelCheckpoint is deleted
+	ModelCheckpointFailed ModelCheckpointPhase = "Failed"
+)
+
+// ModelCheckpointStatus defines the observed state of a ModelCheckpoint
+// +k8s:openapi-gen=true
+type ModelCheckpointStatus struct {
+	// +optional
+	Phase ModelCheckpointPhase `json:"phase,omitempty"`
+	// Image is the checkpoint image pinned by digest, set once completed.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Message describes why the checkpoint failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// CompletionTime is the time the checkpoint image was pushed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ModelCheckpoint is the Schema for the ModelCheckpoints API
+// +k8s:openapi-gen=true
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pod",type="string",JSONPath=".spec.podName"
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=modelcheckpoints
+type ModelCheckpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModelCheckpointSpec   `json:"spec,omitempty"`
+	Status ModelCheckpointStatus `json:"status,omitempty"`
+}
+
+// ModelCheckpointList contains a list of ModelCheckpoint
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type ModelCheckpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelCheckpoint `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelCheckpoint{}, &ModelCheckpointList{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServingQuotaEnforcement controls what happens when an InferenceService would exceed the quota
+// +kubebuilder:validation:Enum=Reject;Warn
+type ServingQuotaEnforcement string
+
+// ServingQuotaEnforcement Enum
+const (
+	// ServingQuotaReject rejects InferenceServices that would exceed the quota
+	ServingQuotaReject ServingQuotaEnforcement = "Reject"
+	// ServingQuotaWarn admits InferenceServices that would exceed the quota with an admission warning
+	ServingQuotaWarn ServingQuotaEnforcement = "Warn"
+)
+
+// ServingQuotaSpec defines the accelerator budget and the replica ceiling of the InferenceServices in a namespace
+// +k8s:openapi-gen=true
+type ServingQuotaSpec struct {
+	// Hard is the total amount of each resource (e.g. nvidia.com/gpu) that all InferenceServices in the
+	// namespace may request, summed across components and at their maximum replica count.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+	// MaxReplicas is the ceiling of the replicas of each component of the InferenceServices in the namespace.
+	// The autoscalers created for the components never scale above it, whatever the enforcement, and the
+	// components without a maxReplicas are bounded by it.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+	// Enforcement specifies whether an InferenceService exceeding the budget is rejected or admitted with a warning.
+	// Defaults to Reject.
+	// +optional
+	Enforcement ServingQuotaEnforcement `json:"enforcement,omitempty"`
+}
+
+// ServingQuota is the Schema for the ServingQuotas API. The quotas are checked at admission only in the namespaces
+// labeled serving.kserve.io/serving-quota=enabled.
+// +k8s:openapi-gen=true
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Enforcement",type="string",JSONPath=".spec.enforcement"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=servingquotas
+type ServingQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServingQuotaSpec `json:"spec,omitempty"`
+}
+
+// ServingQuotaList contains a list of ServingQuota
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type ServingQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServingQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServingQuota{}, &ServingQuotaList{})
+}
+
+// IsWarnOnly returns true if the quota only warns when exceeded
+func (spec *ServingQuotaSpec) IsWarnOnly() bool {
+	return spec.Enforcement == ServingQuotaWarn
+}
+
+// MaxReplicasCeiling returns the lowest replica ceiling of the quotas, nil when none of them sets one
+func (list *ServingQuotaList) MaxReplicasCeiling() *int32 {
+	var ceiling *int32
+	for i := range list.Items {
+		maxReplicas := list.Items[i].Spec.MaxReplicas
+		if maxReplicas != nil && (ceiling == nil || *maxReplicas < *ceiling) {
+			ceiling = maxReplicas
+		}
+	}
+	return ceiling
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServingRuntimeCatalogSource is the location of the ClusterServingRuntime definitions of a catalog.
+// Exactly one of OCI or URL must be specified.
+// +kubebuilder:validation:XValidation:rule="has(self.oci) != has(self.url)",message="exactly one of oci or url must be specified"
+type ServingRuntimeCatalogSource struct {
+	// OCI pulls the catalog from the layers of an OCI artifact, e.g. ghcr.io/acme/runtimes:v1.2.0.
+	// Layers are either YAML documents or gzipped tarballs of YAML files.
+	// +optional
+	OCI *OCICatalogSource `json:"oci,omitempty"`
+	// URL pulls the catalog from a YAML document or a gzipped tarball served over HTTP(S). Catalogs kept in
+	// Git are consumed through the raw or archive URL of a revision, e.g.
+	// https://raw.githubusercontent.com/acme/runtimes/v1.2.0/runtimes.yaml
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// OCICatalogSource is an OCI artifact holding a catalog
+type OCICatalogSource struct {
+	// Image is the reference of the artifact, either tagged or pinned by digest.
+	Image string `json:"image"`
+	// Insecure pulls the artifact over plain HTTP.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// ServingRuntimeCatalogSpec defines where ClusterServingRuntimes are synced from
+// +k8s:openapi-gen=true
+type ServingRuntimeCatalogSpec struct {
+	Source ServingRuntimeCatalogSource `json:"source"`
+	// Interval between two syncs of the catalog. Defaults to 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Suspend stops the catalog from being synced.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// DryRun only records the changes that a sync would apply to the ClusterServingRuntimes as events,
+	// without applying them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ServingRuntimeCatalogStatus defines the observed state of a ServingRuntimeCatalog
+// +k8s:openapi-gen=true
+type ServingRuntimeCatalogStatus struct {
+	// ObservedGeneration is the generation of the spec that was last synced.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is the time of the last successful sync.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Revision is the digest of the last synced catalog.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Runtimes are the names of the ClusterServingRuntimes defined by the last synced catalog.
+	// +optional
+	Runtimes []string `json:"runtimes,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ServingRuntimeCatalog is the Schema for the ServingRuntimeCatalogs API
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Revision",type="string",JSONPath=".status.revision"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=servingruntimecatalogs,scope="Cluster"
+type ServingRuntimeCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServingRuntimeCatalogSpec   `json:"spec,omitempty"`
+	Status ServingRuntimeCatalogStatus `json:"status,omitempty"`
+}
+
+// ServingRuntimeCatalogList contains a list of ServingRuntimeCatalog
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type ServingRuntimeCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServingRuntimeCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServingRuntimeCatalog{}, &ServingRuntimeCatalogList{})
+}
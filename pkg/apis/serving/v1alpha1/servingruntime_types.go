@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	"errors"
+	"slices"
+	"sort"
 
 	"gopkg.in/go-playground/validator.v9"
 	corev1 "k8s.io/api/core/v1"
@@ -113,6 +115,11 @@ type ServingRuntimePodSpec struct {
 	// +optional
 	HostIPC bool `json:"hostIPC,omitempty" protobuf:"varint,13,opt,name=hostIPC"`
 
+	// ServiceAccountName is the default ServiceAccount of the pods running the runtime, used when the
+	// InferenceService does not set one. It takes precedence over the namespace and the cluster defaults.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
 	// Possibly other things here
 }
 
@@ -132,14 +139,41 @@ type ServingRuntimeSpec struct {
 	// +optional
 	Disabled *bool `json:"disabled,omitempty"`
 
+	// Set to true to mark this runtime as deprecated. A deprecated runtime can still be used, but a warning is
+	// returned when an InferenceService selects it, and the InferenceServices using it are listed in its status.
+	// +optional
+	Deprecated *bool `json:"deprecated,omitempty"`
+
+	// Name of the runtime replacing this deprecated runtime, which the InferenceServices using it should migrate to
+	// +optional
+	ReplacedBy *string `json:"replacedBy,omitempty"`
+
 	// Supported protocol versions (i.e. v1 or v2 or grpc-v1 or grpc-v2)
 	// +optional
 	ProtocolVersions []constants.InferenceServiceProtocol `json:"protocolVersions,omitempty"`
 
+	// Architectures of the nodes supported by the runtime, e.g. amd64 or arm64, following the kubernetes.io/arch
+	// node label. The predictor pods are scheduled on the nodes of these architectures. Defaults to the
+	// architectures of ArchitectureImages, the runtime supports all the architectures when both are empty.
+	// +optional
+	// +listType=set
+	SupportedArchitectures []string `json:"supportedArchitectures,omitempty"`
+
+	// Images of the kserve-container per architecture, for the runtimes which are not published as multi-arch
+	// images. The image of the architecture selected by the kubernetes.io/arch node selector of the predictor
+	// replaces the image of the container.
+	// +optional
+	ArchitectureImages map[string]string `json:"architectureImages,omitempty"`
+
 	// Set WorkerSpec to enable multi-node/multi-gpu
 	// +optional
 	WorkerSpec *WorkerSpec `json:"workerSpec,omitempty"`
 
+	// Watchdog detects the hung runtimes, whose health endpoint is up while the inference requests stall, and
+	// restarts the kserve-container or marks the pod unready
+	// +optional
+	Watchdog *WatchdogSpec `json:"watchdog,omitempty"`
+
 	ServingRuntimePodSpec `json:",inline"`
 
 	// The following fields apply to ModelMesh deployments.
@@ -175,7 +209,12 @@ type ServingRuntimeSpec struct {
 
 // ServingRuntimeStatus defines the observed state of ServingRuntime
 // +k8s:openapi-gen=true
-type ServingRuntimeStatus struct{}
+type ServingRuntimeStatus struct {
+	// InferenceServices using the runtime while it is deprecated, in the namespace/name format, to plan their
+	// migration to the replacement runtime
+	// +optional
+	DeprecatedRuntimeUsers []string `json:"deprecatedRuntimeUsers,omitempty"`
+}
 
 // ServerType constant for specifying the runtime name
 // +k8s:openapi-gen=true
@@ -279,6 +318,49 @@ type WorkerSpec struct {
 	TensorParallelSize *int `json:"tensorParallelSize,omitempty"`
 }
 
+// WatchdogAction is what the agent does when the runtime is hung
+// +kubebuilder:validation:Enum=Restart;Unready
+type WatchdogAction string
+
+const (
+	// WatchdogRestartAction fails the liveness probe of the kserve-container, so that the kubelet restarts it
+	WatchdogRestartAction WatchdogAction = "Restart"
+	// WatchdogUnreadyAction fails the readiness probe of the pod until the runtime answers again
+	WatchdogUnreadyAction WatchdogAction = "Unready"
+)
+
+// WatchdogSpec configures the detection of the hung runtimes by the agent
+// +k8s:openapi-gen=true
+type WatchdogSpec struct {
+	// StallThreshold is how long the inference requests can be in flight without any response of the runtime before
+	// the runtime is hung. It must exceed the latency of the slowest requests. Defaults to 5m.
+	// +optional
+	StallThreshold *metav1.Duration `json:"stallThreshold,omitempty"`
+	// Action taken when the runtime is hung. Defaults to Restart, which only replaces a liveness probe the
+	// kserve-container does not already have.
+	// +optional
+	Action WatchdogAction `json:"action,omitempty"`
+	// Probe is an inference request sent to the runtime when it is idle, so that the runtime hanging without
+	// traffic is detected before the first requests stall
+	// +optional
+	Probe *WatchdogProbe `json:"probe,omitempty"`
+}
+
+// WatchdogProbe is the inference request the agent sends to the idle runtime
+// +k8s:openapi-gen=true
+type WatchdogProbe struct {
+	// Path of the inference endpoint of the runtime, e.g. /v2/models/model/infer
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+	// Body of the POST request, a GET request is sent when empty
+	// +optional
+	Body string `json:"body,omitempty"`
+	// PeriodSeconds is how long the runtime stays idle before it is probed. Defaults to 60.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ServingRuntime{}, &ServingRuntimeList{})
 	SchemeBuilder.Register(&ClusterServingRuntime{}, &ClusterServingRuntimeList{})
@@ -288,6 +370,10 @@ func (srSpec *ServingRuntimeSpec) IsDisabled() bool {
 	return srSpec.Disabled != nil && *srSpec.Disabled
 }
 
+func (srSpec *ServingRuntimeSpec) IsDeprecated() bool {
+	return srSpec.Deprecated != nil && *srSpec.Deprecated
+}
+
 func (srSpec *ServingRuntimeSpec) IsMultiModelRuntime() bool {
 	return srSpec.MultiModel != nil && *srSpec.MultiModel
 }
@@ -308,6 +394,28 @@ func (srSpec *ServingRuntimeSpec) IsProtocolVersionSupported(modelProtocolVersio
 	return false
 }
 
+// GetSupportedArchitectures returns the architectures supported by the runtime, an empty list means that the runtime
+// supports all the architectures.
+func (srSpec *ServingRuntimeSpec) GetSupportedArchitectures() []string {
+	if len(srSpec.SupportedArchitectures) > 0 {
+		return srSpec.SupportedArchitectures
+	}
+	architectures := make([]string, 0, len(srSpec.ArchitectureImages))
+	for architecture := range srSpec.ArchitectureImages {
+		architectures = append(architectures, architecture)
+	}
+	sort.Strings(architectures)
+	return architectures
+}
+
+func (srSpec *ServingRuntimeSpec) IsArchitectureSupported(architecture string) bool {
+	supported := srSpec.GetSupportedArchitectures()
+	if len(architecture) == 0 || len(supported) == 0 {
+		return true
+	}
+	return slices.Contains(supported, architecture)
+}
+
 // GetPriority returns the priority of the specified model. It returns nil if priority is not set or the model is not found.
 func (srSpec *ServingRuntimeSpec) GetPriority(modelName string) *int32 {
 	for _, model := range srSpec.SupportedModelFormats {
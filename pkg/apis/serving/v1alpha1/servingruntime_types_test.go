@@ -634,6 +634,52 @@ func TestServingRuntimeSpec_IsProtocolVersionSupported(t *testing.T) {
 	}
 }
 
+func TestServingRuntimeSpec_IsArchitectureSupported(t *testing.T) {
+	scenarios := map[string]struct {
+		spec         ServingRuntimeSpec
+		architecture string
+		res          bool
+	}{
+		"supported architecture": {
+			spec:         ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			architecture: "arm64",
+			res:          true,
+		},
+		"unsupported architecture": {
+			spec:         ServingRuntimeSpec{SupportedArchitectures: []string{"amd64"}},
+			architecture: "arm64",
+			res:          false,
+		},
+		"architecture of an image": {
+			spec:         ServingRuntimeSpec{ArchitectureImages: map[string]string{"arm64": "image-arm64"}},
+			architecture: "arm64",
+			res:          true,
+		},
+		"architecture without an image": {
+			spec:         ServingRuntimeSpec{ArchitectureImages: map[string]string{"arm64": "image-arm64"}},
+			architecture: "amd64",
+			res:          false,
+		},
+		"no architecture selected": {
+			spec:         ServingRuntimeSpec{SupportedArchitectures: []string{"amd64"}},
+			architecture: "",
+			res:          true,
+		},
+		"runtime supporting all the architectures": {
+			spec:         ServingRuntimeSpec{},
+			architecture: "s390x",
+			res:          true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if res := scenario.spec.IsArchitectureSupported(scenario.architecture); res != scenario.res {
+				t.Errorf("Expected %t, got %t", scenario.res, res)
+			}
+		})
+	}
+}
+
 func TestServingRuntimeSpec_GetPriority(t *testing.T) {
 	endpoint := "endpoint"
 	version := "1.0"
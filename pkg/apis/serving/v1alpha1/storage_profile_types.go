@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// S3SignatureVersion is the signature version used to sign the requests to an S3 compatible endpoint
+// +kubebuilder:validation:Enum=s3v4;s3
+type S3SignatureVersion string
+
+// S3SignatureVersion Enum
+const (
+	// S3SignatureV4 signs the requests with AWS Signature Version 4
+	S3SignatureV4 S3SignatureVersion = "s3v4"
+	// S3SignatureV2 signs the requests with the legacy AWS Signature Version 2, required by some older Ceph gateways
+	S3SignatureV2 S3SignatureVersion = "s3"
+)
+
+// StorageProfileSpec defines the connection details of an S3 compatible object store
+// +k8s:openapi-gen=true
+type StorageProfileSpec struct {
+	// Endpoint is the URL of the S3 compatible endpoint, e.g. https://minio.minio-system.svc:9000.
+	// The scheme selects whether https is used, https is assumed when there is no scheme.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region of the object store.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// SignatureVersion is the signature version used to sign the requests, defaults to the one of the client.
+	// +optional
+	SignatureVersion S3SignatureVersion `json:"signatureVersion,omitempty"`
+	// CABundleConfigMap is the name of the ConfigMap holding the CA bundle used to verify the endpoint certificate.
+	// +optional
+	CABundleConfigMap string `json:"caBundleConfigMap,omitempty"`
+	// Anonymous disables the signing of the requests, for publicly readable buckets.
+	// +optional
+	Anonymous *bool `json:"anonymous,omitempty"`
+}
+
+// StorageProfile is the Schema for the StorageProfiles API. It describes an S3 compatible object store that the
+// storage uris reference, so that the endpoint details are not encoded in the storage secret of every namespace.
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=storageprofiles,scope="Cluster"
+type StorageProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StorageProfileSpec `json:"spec,omitempty"`
+}
+
+// StorageProfileList contains a list of StorageProfile
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type StorageProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StorageProfile{}, &StorageProfileList{})
+}
@@ -56,6 +56,11 @@ type TrainedModelSpec struct {
 	// Predictor model spec
 	// +required
 	Model ModelSpec `json:"model"`
+	// Priority of the model when a replica of the InferenceService starts: the models of higher priority are loaded
+	// first, the models of the same priority are loaded in parallel once all the models of higher priority are loaded.
+	// Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ModelSpec describes a TrainedModel
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WarmPoolSpec defines the pre-initialized predictor pods kept for an InferenceService
+// +k8s:openapi-gen=true
+type WarmPoolSpec struct {
+	// InferenceService is the name of the InferenceService in the namespace of the pool whose predictor pods are
+	// pre-initialized. Only the RawDeployment mode is supported.
+	InferenceService string `json:"inferenceService"`
+	// Size is the number of warm pods kept in the pool. Warm pods have pulled the image and loaded the model, but
+	// are not selected by the predictor service until claimed by a scale-up of the predictor.
+	// +kubebuilder:validation:Minimum=0
+	Size int32 `json:"size"`
+}
+
+// WarmPoolStatus defines the observed state of a WarmPool
+// +k8s:openapi-gen=true
+type WarmPoolStatus struct {
+	// ObservedGeneration is the generation of the spec that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Ready is the number of warm pods of the pool ready to be claimed.
+	// +optional
+	Ready int32 `json:"ready,omitempty"`
+	// Claimed is the total number of warm pods claimed by scale-ups of the predictor.
+	// +optional
+	Claimed int64 `json:"claimed,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// WarmPool is the Schema for the WarmPools API
+// +k8s:openapi-gen=true
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="InferenceService",type="string",JSONPath=".spec.inferenceService"
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".spec.size"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Claimed",type="integer",JSONPath=".status.claimed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=warmpools
+type WarmPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WarmPoolSpec   `json:"spec,omitempty"`
+	Status WarmPoolStatus `json:"status,omitempty"`
+}
+
+// WarmPoolList contains a list of WarmPool
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type WarmPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WarmPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WarmPool{}, &WarmPoolList{})
+}
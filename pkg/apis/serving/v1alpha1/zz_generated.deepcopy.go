@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"github.com/kserve/kserve/pkg/constants"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -30,6 +31,31 @@ import (
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuiltInAdapter) DeepCopyInto(out *BuiltInAdapter) {
 	*out = *in
@@ -58,7 +84,7 @@ func (in *ClusterServingRuntime) DeepCopyInto(out *ClusterServingRuntime) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServingRuntime.
@@ -182,6 +208,11 @@ func (in *GatewayRoutesSpec) DeepCopyInto(out *GatewayRoutesSpec) {
 		*out = new(HTTPRouteSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRoutesSpec.
@@ -388,6 +419,18 @@ func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.CanaryTrafficPercent != nil {
+		in, out := &in.CanaryTrafficPercent, &out.CanaryTrafficPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InferenceServices != nil {
+		in, out := &in.InferenceServices, &out.InferenceServices
+		*out = make([]InferenceServiceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphSpec.
@@ -409,6 +452,11 @@ func (in *InferenceGraphStatus) DeepCopyInto(out *InferenceGraphStatus) {
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(InferenceGraphTopology)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphStatus.
@@ -421,6 +469,68 @@ func (in *InferenceGraphStatus) DeepCopy() *InferenceGraphStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphTopology) DeepCopyInto(out *InferenceGraphTopology) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]InferenceGraphTopologyNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Edges != nil {
+		in, out := &in.Edges, &out.Edges
+		*out = make([]InferenceGraphTopologyEdge, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphTopology.
+func (in *InferenceGraphTopology) DeepCopy() *InferenceGraphTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphTopologyEdge) DeepCopyInto(out *InferenceGraphTopologyEdge) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphTopologyEdge.
+func (in *InferenceGraphTopologyEdge) DeepCopy() *InferenceGraphTopologyEdge {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphTopologyEdge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphTopologyNode) DeepCopyInto(out *InferenceGraphTopologyNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphTopologyNode.
+func (in *InferenceGraphTopologyNode) DeepCopy() *InferenceGraphTopologyNode {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphTopologyNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferencePoolSpec) DeepCopyInto(out *InferencePoolSpec) {
 	*out = *in
@@ -456,6 +566,11 @@ func (in *InferenceRouter) DeepCopyInto(out *InferenceRouter) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(InferenceRouterCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouter.
@@ -468,6 +583,66 @@ func (in *InferenceRouter) DeepCopy() *InferenceRouter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceRouterCache) DeepCopyInto(out *InferenceRouterCache) {
+	*out = *in
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeyHeaders != nil {
+		in, out := &in.KeyHeaders, &out.KeyHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouterCache.
+func (in *InferenceRouterCache) DeepCopy() *InferenceRouterCache {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceRouterCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceTemplate) DeepCopyInto(out *InferenceServiceTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceTemplate.
+func (in *InferenceServiceTemplate) DeepCopy() *InferenceServiceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
 	*out = *in
@@ -477,6 +652,11 @@ func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.LatencyCostMs != nil {
+		in, out := &in.LatencyCostMs, &out.LatencyCostMs
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStep.
@@ -683,6 +863,13 @@ func (in *LLMInferenceServiceStatus) DeepCopyInto(out *LLMInferenceServiceStatus
 	}
 	in.Status.DeepCopyInto(&out.Status)
 	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]LoRAAdapterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMInferenceServiceStatus.
@@ -704,6 +891,11 @@ func (in *LLMModelSpec) DeepCopyInto(out *LLMModelSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Criticality != nil {
 		in, out := &in.Criticality, &out.Criticality
 		*out = new(v1alpha2.Criticality)
@@ -726,6 +918,28 @@ func (in *LLMModelSpec) DeepCopy() *LLMModelSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoRAAdapterStatus) DeepCopyInto(out *LoRAAdapterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(duckv1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoRAAdapterStatus.
+func (in *LoRAAdapterStatus) DeepCopy() *LoRAAdapterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoRAAdapterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoRASpec) DeepCopyInto(out *LoRASpec) {
 	*out = *in
@@ -1070,6 +1284,100 @@ func (in *LocalModelNodeStatus) DeepCopy() *LocalModelNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCheckpoint) DeepCopyInto(out *ModelCheckpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCheckpoint.
+func (in *ModelCheckpoint) DeepCopy() *ModelCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelCheckpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCheckpointList) DeepCopyInto(out *ModelCheckpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelCheckpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCheckpointList.
+func (in *ModelCheckpointList) DeepCopy() *ModelCheckpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCheckpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelCheckpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCheckpointSpec) DeepCopyInto(out *ModelCheckpointSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCheckpointSpec.
+func (in *ModelCheckpointSpec) DeepCopy() *ModelCheckpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCheckpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCheckpointStatus) DeepCopyInto(out *ModelCheckpointStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCheckpointStatus.
+func (in *ModelCheckpointStatus) DeepCopy() *ModelCheckpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCheckpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
 	*out = *in
@@ -1116,6 +1424,41 @@ func (in *NamespacedName) DeepCopy() *NamespacedName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCICatalogSource) DeepCopyInto(out *OCICatalogSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCICatalogSource.
+func (in *OCICatalogSource) DeepCopy() *OCICatalogSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OCICatalogSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIRouteSpec) DeepCopyInto(out *OpenAIRouteSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]OpenAIEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIRouteSpec.
+func (in *OpenAIRouteSpec) DeepCopy() *OpenAIRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParallelismSpec) DeepCopyInto(out *ParallelismSpec) {
 	*out = *in
@@ -1192,14 +1535,49 @@ func (in *RouterSpec) DeepCopy() *RouterSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
+func (in *SchedulerScoringSpec) DeepCopyInto(out *SchedulerScoringSpec) {
 	*out = *in
-	if in.Pool != nil {
-		in, out := &in.Pool, &out.Pool
-		*out = new(InferencePoolSpec)
-		(*in).DeepCopyInto(*out)
+	if in.PrefixCacheWeight != nil {
+		in, out := &in.PrefixCacheWeight, &out.PrefixCacheWeight
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Template != nil {
+	if in.QueueDepthWeight != nil {
+		in, out := &in.QueueDepthWeight, &out.QueueDepthWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KVCacheUtilizationWeight != nil {
+		in, out := &in.KVCacheUtilizationWeight, &out.KVCacheUtilizationWeight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerScoringSpec.
+func (in *SchedulerScoringSpec) DeepCopy() *SchedulerScoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerScoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
+	*out = *in
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = new(InferencePoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scoring != nil {
+		in, out := &in.Scoring, &out.Scoring
+		*out = new(SchedulerScoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(v1.PodSpec)
 		(*in).DeepCopyInto(*out)
@@ -1216,13 +1594,98 @@ func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingQuota) DeepCopyInto(out *ServingQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingQuota.
+func (in *ServingQuota) DeepCopy() *ServingQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServingQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingQuotaList) DeepCopyInto(out *ServingQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServingQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingQuotaList.
+func (in *ServingQuotaList) DeepCopy() *ServingQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServingQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingQuotaSpec) DeepCopyInto(out *ServingQuotaSpec) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingQuotaSpec.
+func (in *ServingQuotaSpec) DeepCopy() *ServingQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingRuntime) DeepCopyInto(out *ServingRuntime) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntime.
@@ -1243,6 +1706,138 @@ func (in *ServingRuntime) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingRuntimeCatalog) DeepCopyInto(out *ServingRuntimeCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeCatalog.
+func (in *ServingRuntimeCatalog) DeepCopy() *ServingRuntimeCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingRuntimeCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServingRuntimeCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingRuntimeCatalogList) DeepCopyInto(out *ServingRuntimeCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServingRuntimeCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeCatalogList.
+func (in *ServingRuntimeCatalogList) DeepCopy() *ServingRuntimeCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingRuntimeCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServingRuntimeCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingRuntimeCatalogSource) DeepCopyInto(out *ServingRuntimeCatalogSource) {
+	*out = *in
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCICatalogSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeCatalogSource.
+func (in *ServingRuntimeCatalogSource) DeepCopy() *ServingRuntimeCatalogSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingRuntimeCatalogSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingRuntimeCatalogSpec) DeepCopyInto(out *ServingRuntimeCatalogSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeCatalogSpec.
+func (in *ServingRuntimeCatalogSpec) DeepCopy() *ServingRuntimeCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingRuntimeCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingRuntimeCatalogStatus) DeepCopyInto(out *ServingRuntimeCatalogStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeCatalogStatus.
+func (in *ServingRuntimeCatalogStatus) DeepCopy() *ServingRuntimeCatalogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingRuntimeCatalogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingRuntimeList) DeepCopyInto(out *ServingRuntimeList) {
 	*out = *in
@@ -1362,16 +1957,43 @@ func (in *ServingRuntimeSpec) DeepCopyInto(out *ServingRuntimeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReplacedBy != nil {
+		in, out := &in.ReplacedBy, &out.ReplacedBy
+		*out = new(string)
+		**out = **in
+	}
 	if in.ProtocolVersions != nil {
 		in, out := &in.ProtocolVersions, &out.ProtocolVersions
 		*out = make([]constants.InferenceServiceProtocol, len(*in))
 		copy(*out, *in)
 	}
+	if in.SupportedArchitectures != nil {
+		in, out := &in.SupportedArchitectures, &out.SupportedArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArchitectureImages != nil {
+		in, out := &in.ArchitectureImages, &out.ArchitectureImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.WorkerSpec != nil {
 		in, out := &in.WorkerSpec, &out.WorkerSpec
 		*out = new(WorkerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Watchdog != nil {
+		in, out := &in.Watchdog, &out.Watchdog
+		*out = new(WatchdogSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ServingRuntimePodSpec.DeepCopyInto(&out.ServingRuntimePodSpec)
 	if in.GrpcMultiModelManagementEndpoint != nil {
 		in, out := &in.GrpcMultiModelManagementEndpoint, &out.GrpcMultiModelManagementEndpoint
@@ -1418,6 +2040,11 @@ func (in *ServingRuntimeSpec) DeepCopy() *ServingRuntimeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingRuntimeStatus) DeepCopyInto(out *ServingRuntimeStatus) {
 	*out = *in
+	if in.DeprecatedRuntimeUsers != nil {
+		in, out := &in.DeprecatedRuntimeUsers, &out.DeprecatedRuntimeUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeStatus.
@@ -1471,6 +2098,84 @@ func (in *StorageHelper) DeepCopy() *StorageHelper {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfile.
+func (in *StorageProfile) DeepCopy() *StorageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProfileList) DeepCopyInto(out *StorageProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfileList.
+func (in *StorageProfileList) DeepCopy() *StorageProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
+	*out = *in
+	if in.Anonymous != nil {
+		in, out := &in.Anonymous, &out.Anonymous
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfileSpec.
+func (in *StorageProfileSpec) DeepCopy() *StorageProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SupportedModelFormat) DeepCopyInto(out *SupportedModelFormat) {
 	*out = *in
@@ -1648,6 +2353,142 @@ func (in *UntypedObjectReference) DeepCopy() *UntypedObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPool) DeepCopyInto(out *WarmPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPool.
+func (in *WarmPool) DeepCopy() *WarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WarmPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPoolList) DeepCopyInto(out *WarmPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WarmPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPoolList.
+func (in *WarmPoolList) DeepCopy() *WarmPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WarmPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPoolSpec) DeepCopyInto(out *WarmPoolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPoolSpec.
+func (in *WarmPoolSpec) DeepCopy() *WarmPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmPoolStatus) DeepCopyInto(out *WarmPoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPoolStatus.
+func (in *WarmPoolStatus) DeepCopy() *WarmPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchdogProbe) DeepCopyInto(out *WatchdogProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchdogProbe.
+func (in *WatchdogProbe) DeepCopy() *WatchdogProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchdogProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchdogSpec) DeepCopyInto(out *WatchdogSpec) {
+	*out = *in
+	if in.StallThreshold != nil {
+		in, out := &in.StallThreshold, &out.StallThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(WatchdogProbe)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchdogSpec.
+func (in *WatchdogSpec) DeepCopy() *WatchdogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchdogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 	*out = *in
@@ -1682,6 +2523,11 @@ func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Parallelism != nil {
 		in, out := &in.Parallelism, &out.Parallelism
 		*out = new(ParallelismSpec)
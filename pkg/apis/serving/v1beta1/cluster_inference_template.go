@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InferenceTemplateReference references a ClusterInferenceTemplate
+type InferenceTemplateReference struct {
+	// Name of the ClusterInferenceTemplate
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ClusterInferenceTemplateSpec defines the partial InferenceService spec of a template
+// +k8s:openapi-gen=true
+type ClusterInferenceTemplateSpec struct {
+	// Predictor defines the predictor defaults of the template
+	Predictor PredictorTemplateSpec `json:"predictor"`
+}
+
+// PredictorTemplateSpec defines the predictor fields set by a template. The fields set by the InferenceService take
+// precedence over the ones of the template.
+// +k8s:openapi-gen=true
+type PredictorTemplateSpec struct {
+	// ModelFormat of the model served by the predictor.
+	// +optional
+	ModelFormat *ModelFormat `json:"modelFormat,omitempty"`
+	// Runtime is the name of the ClusterServingRuntime/ServingRuntime serving the model.
+	// +optional
+	Runtime *string `json:"runtime,omitempty"`
+	// Resources of the model server container. The resources are merged per resource name.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Env of the model server container. The variables are merged by name.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Minimum number of replicas.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Maximum number of replicas for autoscaling.
+	// +optional
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for.
+	// +optional
+	ScaleTarget *int32 `json:"scaleTarget,omitempty"`
+	// ScaleMetric defines the scaling metric type watched by autoscaler.
+	// +optional
+	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
+}
+
+// ClusterInferenceTemplate is the Schema for the ClusterInferenceTemplates API. It holds the predictor defaults of a
+// common serving pattern, which the InferenceServices referencing it through spec.templateRef are defaulted with.
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="ModelFormat",type="string",JSONPath=".spec.predictor.modelFormat.name"
+// +kubebuilder:printcolumn:name="Runtime",type="string",JSONPath=".spec.predictor.runtime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterinferencetemplates,scope="Cluster"
+type ClusterInferenceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterInferenceTemplateSpec `json:"spec,omitempty"`
+}
+
+// ClusterInferenceTemplateList contains a list of ClusterInferenceTemplate
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type ClusterInferenceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterInferenceTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterInferenceTemplate{}, &ClusterInferenceTemplateList{})
+}
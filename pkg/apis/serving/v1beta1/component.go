@@ -19,12 +19,17 @@ package v1beta1
 import (
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -41,6 +46,10 @@ const (
 	UnsupportedStorageSpecFormatError                = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported"
 	InvalidLoggerType                                = "invalid logger type"
 	InvalidLoggerStorageConfigError                  = "invalid logger storage configuration"
+	InvalidLoggerKafkaConfigError                    = "invalid logger kafka configuration: %s"
+	InvalidLoggerStorageBatchError                   = "invalid logger storage batching: %s"
+	InvalidLoggerFilterError                         = "invalid logger filter: %s"
+	InvalidLoggerEncryptionError                     = "invalid logger encryption: %s"
 	InvalidISVCNameFormatError                       = "the InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
 	InvalidProtocol                                  = "invalid protocol %s. Must be one of [%s]"
 	MissingStorageURI                                = "the InferenceService %q is invalid: StorageURI must be set for multinode enabled"
@@ -52,6 +61,39 @@ const (
 	DisallowedMultipleContainersInWorkerSpecError    = "the InferenceService %q is invalid: setting multiple containers in workerSpec is not allowed"
 	DisallowedWorkerSpecPipelineParallelSizeEnvError = "the InferenceService %q is invalid: setting PIPELINE_PARALLEL_SIZE in environment variables is not allowed"
 	DisallowedWorkerSpecTensorParallelSizeEnvError   = "the InferenceService %q is invalid: setting TENSOR_PARALLEL_SIZE in environment variables is not allowed"
+	InvalidFailoverTargetSelfError                   = "the InferenceService %q is invalid: failover target must reference another InferenceService"
+	InvalidABTestError                               = "the InferenceService %q is invalid: the A/B test %s"
+	InvalidDependencySelfError                       = "the InferenceService %q is invalid: dependsOn must reference another InferenceService"
+	DuplicateDependencyError                         = "the InferenceService %q is invalid: the dependency on the %s %q is declared more than once"
+	InferenceTemplateNotFoundError                   = "the InferenceService %q is invalid: the ClusterInferenceTemplate %q does not exist"
+	InvalidPredictorModelsStorageUriError            = "the InferenceService %q is invalid: predictor models can not be set together with a predictor storageUri"
+	DuplicatePredictorModelNameError                 = "the InferenceService %q is invalid: predictor model %q is declared more than once"
+	InvalidCapacityWorkerSpecError                   = "the InferenceService %q is invalid: predictor capacity can not be set together with workerSpec"
+	UnsupportedDualProtocolError                     = "the InferenceService %q is invalid: dualProtocol requires a predictor serving the v1 or v2 REST protocol, got %q"
+	InvalidIdleTimeoutDurationError                  = "the InferenceService %q is invalid: the idle timeout duration %s must be at least %s"
+	UnsupportedIdleTimeoutError                      = "the InferenceService %q is invalid: the idle timeout is only supported in the Knative deployment mode, the requests of the %s deployment mode are not counted by the queue-proxy"
+	UnsupportedServerTLSError                        = "the InferenceService %q is invalid: the predictor server TLS is not supported with %s, which call the predictor over HTTP"
+	UnsupportedServerTLSDeploymentModeError          = "the InferenceService %q is invalid: the predictor server TLS is only supported in the Standard deployment mode, got %s"
+	InvalidSPIREServerTLSError                       = "the InferenceService %q is invalid: the SPIRE serving certificates are issued by the SPIRE server and always reloaded, issuerRef and the Restart rotation are not supported"
+	InvalidStorageFilePatternError                   = "the InferenceService %q is invalid: storage %s pattern %q is not a valid glob"
+	SharedModelLibraryAccessModeError                = "the InferenceService %q is invalid: the shared model library PersistentVolumeClaim %q must be ReadWriteMany or ReadOnlyMany"
+	ServiceAccountNotFoundError                      = "the InferenceService %q is invalid: the ServiceAccount %q of the %s does not exist in the namespace %q"
+	UnknownAnnotationsError                          = "the InferenceService %q is invalid: %s"
+	SharedModelLibrarySubPathError                   = "the InferenceService %q is invalid: the storage URI %q must reference a sub path of the shared model library PersistentVolumeClaim %q"
+	SharedModelLibraryReadOnlyError                  = "the InferenceService %q is invalid: the shared model library PersistentVolumeClaim %q can only be mounted read-only"
+	SharedModelLibraryPathCollisionError             = "the InferenceService %q is invalid: the path %q of the shared model library PersistentVolumeClaim %q collides with the path %q of the InferenceService %q"
+	InvalidActivatorTypeError                        = "the InferenceService %q is invalid: the activator %q is not supported, use builtin or keda-http"
+	UnsupportedActivatorError                        = "the InferenceService %q is invalid: the activator %s"
+	DuplicateScaleScheduleWindowError                = "scaleSchedule window %q is declared more than once"
+	InvalidScaleScheduleWindowError                  = "invalid scaleSchedule window %q: %v"
+	InvalidSlowStartError                            = "invalid slowStart: %s"
+	InvalidAdaptiveBatcherError                      = "invalid batcher adaptive: %s"
+	UnsupportedCanaryAnalysisError                   = "the InferenceService %q is invalid: the canary analysis of the %s %s"
+	UnsupportedShadowTrafficError                    = "the InferenceService %q is invalid: the shadow traffic %s"
+	UnsupportedBlueGreenError                        = "the InferenceService %q is invalid: the blue-green strategy of the %s %s"
+	UnsupportedContainerLifecycleError               = "the InferenceService %q is invalid: the lifecycle hooks and the restart policy of the %s container are only supported in the Standard deployment mode"
+	UnsupportedMonitoringError                       = "the InferenceService %q is invalid: the predictor monitoring %s"
+	UnsupportedObservabilityError                    = "the InferenceService %q is invalid: the OpenTelemetry Collector sidecar is only supported in the Standard deployment mode"
 )
 
 // SupportedStorageSpecURIPrefixList Constants
@@ -102,6 +144,12 @@ type ComponentExtensionSpec struct {
 	// AutoScaling autoscaling spec which is backed up HPA or KEDA.
 	// +optional
 	AutoScaling *AutoScalingSpec `json:"autoScaling,omitempty"`
+	// ScaleSchedule raises the minimum replicas of the component during recurring time windows, for example to
+	// provision capacity ahead of business hours. Only applicable for raw deployment mode with the HPA or KEDA.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ScaleSchedule []ScaleScheduleWindow `json:"scaleSchedule,omitempty"`
 	// ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container
 	// concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).
 	// +optional
@@ -112,6 +160,26 @@ type ComponentExtensionSpec struct {
 	// CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision
 	// +optional
 	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
+	// CanaryAnalysis promotes the canary revision step by step while its metrics queried from Prometheus meet the
+	// thresholds, starting from the canaryTrafficPercent, and rolls it back when they do not. Only applicable for
+	// Knative deployment mode.
+	// +optional
+	CanaryAnalysis *CanaryAnalysisSpec `json:"canaryAnalysis,omitempty"`
+	// ShadowTrafficPercent mirrors the percentage of the traffic to the candidate revision, whose responses are
+	// discarded, while the last rolled out revision keeps serving all the traffic. Only applicable for the predictor
+	// in Knative deployment mode with the Istio virtual host.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ShadowTrafficPercent *int64 `json:"shadowTrafficPercent,omitempty"`
+	// WarmStandby keeps replicas of the previous rolled out revision warm once the traffic is fully shifted to the
+	// latest revision, so that a rollback does not wait for the cold start of the previous revision
+	// +optional
+	WarmStandby *WarmStandbySpec `json:"warmStandby,omitempty"`
+	// SlowStart ramps up the concurrent requests the agent of a new replica admits to the component, so that the
+	// replicas whose caches are cold or whose kernels are not compiled yet are not sent the full traffic at once
+	// +optional
+	SlowStart *SlowStartSpec `json:"slowStart,omitempty"`
 	// Activate request/response logging and logger configurations
 	// +optional
 	Logger *LoggerSpec `json:"logger,omitempty"`
@@ -127,8 +195,78 @@ type ComponentExtensionSpec struct {
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 	// The deployment strategy to use to replace existing pods with new ones. Only applicable for raw deployment mode.
+	// The BlueGreen type replaces the pods with the blue-green strategy configured by blueGreen.
 	// +optional
 	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+	// BlueGreen configures the blue-green strategy of the deploymentStrategy of type BlueGreen
+	// +optional
+	BlueGreen *BlueGreenSpec `json:"blueGreen,omitempty"`
+}
+
+// BlueGreenDeploymentStrategyType is the deploymentStrategy type of the components replaced with the blue-green
+// strategy. The new pods are deployed by a preview Deployment, and the Service is switched to them at once when they
+// are ready and pass the smoke test. The previous pods keep running for the rollback window.
+const BlueGreenDeploymentStrategyType appsv1.DeploymentStrategyType = "BlueGreen"
+
+// DefaultBlueGreenRollbackWindowSeconds is how long the previous pods are kept after the Service is switched
+const DefaultBlueGreenRollbackWindowSeconds int64 = 600
+
+// BlueGreenSpec configures the blue-green strategy of a component
+type BlueGreenSpec struct {
+	// RollbackWindowSeconds is how long the previous pods keep running after the Service is switched to the new
+	// pods, reverting the spec of the component during the window switches the Service back to them at once.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RollbackWindowSeconds *int64 `json:"rollbackWindowSeconds,omitempty"`
+	// SmokeTest is a request sent to a new pod once the preview Deployment is ready, the Service is only switched to
+	// the new pods when it is answered successfully
+	// +optional
+	SmokeTest *BlueGreenSmokeTest `json:"smokeTest,omitempty"`
+}
+
+// BlueGreenSmokeTest is the request sent to a new pod before the Service is switched to the new pods
+type BlueGreenSmokeTest struct {
+	// Path of the request, e.g. /v1/models/model:predict
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+	// Body of the POST request, a GET request is sent when empty
+	// +optional
+	Body string `json:"body,omitempty"`
+	// TimeoutSeconds of the request. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// IsBlueGreen returns whether the pods of the component are replaced with the blue-green strategy
+func (s *ComponentExtensionSpec) IsBlueGreen() bool {
+	return s != nil && s.DeploymentStrategy != nil && s.DeploymentStrategy.Type == BlueGreenDeploymentStrategyType
+}
+
+// GetBlueGreenRollbackWindow returns how long the previous pods are kept after the Service is switched
+func (s *ComponentExtensionSpec) GetBlueGreenRollbackWindow() time.Duration {
+	seconds := DefaultBlueGreenRollbackWindowSeconds
+	if s.BlueGreen != nil && s.BlueGreen.RollbackWindowSeconds != nil {
+		seconds = *s.BlueGreen.RollbackWindowSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ScaleScheduleWindow is a recurring time window during which the component keeps a minimum number of replicas
+type ScaleScheduleWindow struct {
+	// Name of the window, unique in the schedule.
+	Name string `json:"name"`
+	// Start is the cron expression of the opening of the window, in the standard five fields format.
+	Start string `json:"start"`
+	// End is the cron expression of the closing of the window, in the standard five fields format.
+	End string `json:"end"`
+	// Timezone is the IANA time zone name of the cron expressions, defaults to UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// MinReplicas is the minimum number of replicas of the component while the window is open.
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas"`
 }
 
 type AutoScalingSpec struct {
@@ -142,7 +280,7 @@ type AutoScalingSpec struct {
 // MetricsSpec specifies how to scale based on a single metric
 // (only `type` and one other matching field should be set at once).
 type MetricsSpec struct {
-	// type is the type of metric source.  It should be one of "Resource", "External", "PodMetric".
+	// type is the type of metric source.  It should be one of "Resource", "External", "PodMetric", "Runtime".
 	// "Resource" or "External" each mapping to a matching field in the object.
 	Type MetricSourceType `json:"type"`
 
@@ -167,10 +305,16 @@ type MetricsSpec struct {
 	// averaged together before being compared to the target value.
 	// +optional
 	PodMetric *PodMetricSource `json:"podmetric,omitempty"`
+
+	// runtime refers to a metric exported by the model server of the predictor
+	// (for example the requests waiting in the queue of vLLM), whose query is
+	// templated for the model server detected in the container of the ServingRuntime.
+	// +optional
+	Runtime *RuntimeMetricSource `json:"runtime,omitempty"`
 }
 
 // MetricSourceType indicates the type of metric.
-// +kubebuilder:validation:Enum=Resource;External;PodMetric
+// +kubebuilder:validation:Enum=Resource;External;PodMetric;Runtime
 type MetricSourceType string
 
 const (
@@ -190,6 +334,10 @@ const (
 	// scale target (for example, transactions-processed-per-second).  The values
 	// will be averaged together before being compared to the target value.
 	PodMetricSourceType MetricSourceType = "PodMetric"
+	// RuntimeMetricSourceType is a metric exported by the model server of the
+	// predictor, such as its queue depth, averaged across the pods of the
+	// predictor before being compared to the target value.
+	RuntimeMetricSourceType MetricSourceType = "Runtime"
 )
 
 type ResourceMetricSource struct {
@@ -225,6 +373,40 @@ type PodMetricSource struct {
 	Target MetricTarget `json:"target"`
 }
 
+// RuntimeMetricSource indicates how to scale on a metric exported by the model
+// server of the predictor. The Prometheus query of the metric is templated by the
+// controller for the model server detected in the container of the ServingRuntime,
+// among vLLM, SGLang and Text Generation Inference.
+type RuntimeMetricSource struct {
+	// metric is the model server metric
+	Metric RuntimeMetric `json:"metric"`
+
+	// serverAddress is the address of the Prometheus server scraping the model server.
+	// Defaults to the prometheusServerAddress of the autoscaler config.
+	// +optional
+	ServerAddress string `json:"serverAddress,omitempty"`
+
+	// authenticationRef is a reference to the authentication information of the Prometheus server
+	// +optional
+	Authentication *ExtMetricAuthentication `json:"authenticationRef,omitempty"`
+
+	// target specifies the target average value of the metric across the pods, of the AverageValue type
+	Target MetricTarget `json:"target"`
+}
+
+// RuntimeMetric is a metric of the model servers
+// +kubebuilder:validation:Enum=queue-depth;batch-occupancy;kv-cache-usage
+type RuntimeMetric string
+
+const (
+	// RuntimeMetricQueueDepth is the number of requests waiting to be scheduled, e.g. vllm:num_requests_waiting
+	RuntimeMetricQueueDepth RuntimeMetric = "queue-depth"
+	// RuntimeMetricBatchOccupancy is the number of requests in the running batch, e.g. vllm:num_requests_running
+	RuntimeMetricBatchOccupancy RuntimeMetric = "batch-occupancy"
+	// RuntimeMetricKVCacheUsage is the fraction of the KV cache in use between 0 and 1, e.g. vllm:gpu_cache_usage_perc
+	RuntimeMetricKVCacheUsage RuntimeMetric = "kv-cache-usage"
+)
+
 type AuthenticationRef struct {
 	// name is the name of the authentication secret
 	Name string `json:"name"`
@@ -278,6 +460,86 @@ const (
 	AverageValueMetricType MetricTargetType = "AverageValue"
 )
 
+// WarmStandbySpec configures the warm standby of the previous rolled out revision of a component
+type WarmStandbySpec struct {
+	// Replicas is the minimum number of replicas of the previous revision kept warm. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// SoakSeconds is how long the previous revision is kept warm after the traffic is fully shifted to the latest
+	// revision
+	// +kubebuilder:validation:Minimum=1
+	SoakSeconds int64 `json:"soakSeconds"`
+}
+
+// DefaultSlowStartInitialPercent is the share of the concurrency admitted by a new replica when it starts serving
+const DefaultSlowStartInitialPercent int32 = 10
+
+// SlowStartSpec configures the ramp-up of the requests admitted by the new replicas of a component. The ramp starts
+// with the first request the replica receives, the requests over the ramped up concurrency wait for a slot instead of
+// being rejected so that a replica scaled from zero still serves all the traffic.
+type SlowStartSpec struct {
+	// WindowSeconds is how long the ramp-up lasts, the admitted concurrency grows linearly from the initial percent
+	// to the max concurrency over the window
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds int32 `json:"windowSeconds"`
+	// InitialPercent is the share of the max concurrency admitted at the beginning of the window. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	InitialPercent *int32 `json:"initialPercent,omitempty"`
+	// MaxConcurrency is the number of concurrent requests admitted at the end of the window. Defaults to the
+	// containerConcurrency of the component.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrency *int64 `json:"maxConcurrency,omitempty"`
+}
+
+// GetSlowStartMaxConcurrency returns the number of concurrent requests admitted once the replica is ramped up, 0 when
+// neither the slow start max concurrency nor the container concurrency are set
+func (s *ComponentExtensionSpec) GetSlowStartMaxConcurrency() int64 {
+	if s.SlowStart != nil && s.SlowStart.MaxConcurrency != nil {
+		return *s.SlowStart.MaxConcurrency
+	}
+	if s.ContainerConcurrency != nil {
+		return *s.ContainerConcurrency
+	}
+	return 0
+}
+
+// CanaryAnalysisSpec configures the automated promotion of the canary revision of a component from its metrics
+type CanaryAnalysisSpec struct {
+	// Interval between the evaluations of the metrics of the canary revision, the metrics are queried over the
+	// interval. Defaults to 1m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// StepPercent is the percentage of the traffic added to the canary revision after each successful evaluation.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepPercent *int64 `json:"stepPercent,omitempty"`
+	// MaxSteps is the number of successful evaluations after which the canary revision is promoted, whatever its
+	// traffic percentage. The canary revision is promoted once it receives all the traffic otherwise.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSteps *int32 `json:"maxSteps,omitempty"`
+	// MinSuccessRate is the lowest percentage of the requests of the canary revision answered without a server error
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinSuccessRate *int32 `json:"minSuccessRate,omitempty"`
+	// MaxLatencyMilliseconds is the highest 99th percentile of the latency of the requests of the canary revision
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxLatencyMilliseconds *int64 `json:"maxLatencyMilliseconds,omitempty"`
+	// FailureThreshold is the number of failed evaluations after which the canary revision is rolled back.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
 type ExternalMetrics struct {
 	// MetricsBackend defines the scaling metric type watched by autoscaler
 	// possible values are prometheus, graphite.
@@ -286,7 +548,10 @@ type ExternalMetrics struct {
 	// Address of MetricsBackend server.
 	// +optional
 	ServerAddress string `json:"serverAddress,omitempty"`
-	// Query to run to get metrics from MetricsBackend
+	// Query to run to get metrics from MetricsBackend. The query is a Go template rendered with the namespace, the
+	// InferenceService, the component and the deployment names of the scaled component, e.g.
+	// sum(rate(request_total{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"}[1m])).
+	// The rendered Prometheus queries are checked against the server when the autoscaler is reconciled.
 	// +optional
 	Query string `json:"query,omitempty"`
 	// For namespaced query
@@ -342,12 +607,67 @@ func (s *ComponentExtensionSpec) Validate() error {
 	return utils.FirstNonNilError([]error{
 		validateContainerConcurrency(s.ContainerConcurrency),
 		validateReplicas(s.MinReplicas, s.MaxReplicas),
+		validateScaleSchedule(s.ScaleSchedule, s.MaxReplicas),
 		validateLogger(s.Logger),
+		validateSlowStart(s),
+		validateAdaptiveBatcher(s.Batcher),
 	})
 }
 
+func validateAdaptiveBatcher(batcher *Batcher) error {
+	if batcher == nil || batcher.Adaptive == nil {
+		return nil
+	}
+	if batcher.Adaptive.LatencySLO < 1 {
+		return fmt.Errorf(InvalidAdaptiveBatcherError, "latencySLO must be at least 1")
+	}
+	if minBatchSize := batcher.Adaptive.MinBatchSize; minBatchSize != nil {
+		if *minBatchSize < 1 {
+			return fmt.Errorf(InvalidAdaptiveBatcherError, "minBatchSize must be at least 1")
+		}
+		if batcher.MaxBatchSize != nil && *minBatchSize > *batcher.MaxBatchSize {
+			return fmt.Errorf(InvalidAdaptiveBatcherError, "minBatchSize cannot be greater than maxBatchSize")
+		}
+	}
+	return nil
+}
+
+func validateSlowStart(s *ComponentExtensionSpec) error {
+	if s.SlowStart == nil {
+		return nil
+	}
+	if s.SlowStart.WindowSeconds < 1 {
+		return fmt.Errorf(InvalidSlowStartError, "windowSeconds must be at least 1")
+	}
+	if s.SlowStart.InitialPercent != nil && (*s.SlowStart.InitialPercent < 1 || *s.SlowStart.InitialPercent > 100) {
+		return fmt.Errorf(InvalidSlowStartError, "initialPercent must be between 1 and 100")
+	}
+	if s.GetSlowStartMaxConcurrency() < 1 {
+		return fmt.Errorf(InvalidSlowStartError, "maxConcurrency or containerConcurrency must be set")
+	}
+	return nil
+}
+
+func validateScaleSchedule(schedule []ScaleScheduleWindow, maxReplicas int32) error {
+	names := map[string]bool{}
+	for _, window := range schedule {
+		if names[window.Name] {
+			return fmt.Errorf(DuplicateScaleScheduleWindowError, window.Name)
+		}
+		names[window.Name] = true
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf(InvalidScaleScheduleWindowError, window.Name, err)
+		}
+		if maxReplicas > 0 && window.MinReplicas > maxReplicas {
+			return fmt.Errorf(InvalidScaleScheduleWindowError, window.Name,
+				fmt.Errorf("minReplicas %d cannot be greater than maxReplicas %d", window.MinReplicas, maxReplicas))
+		}
+	}
+	return nil
+}
+
 func validateStorageSpec(storageSpec *ModelStorageSpec, storageURI *string) error {
-	if storageSpec == nil {
+	if storageSpec == nil || storageSpec.FiltersFilesOnly() {
 		return nil
 	}
 	if storageURI != nil {
@@ -406,9 +726,118 @@ func validateLogger(logger *LoggerSpec) error {
 			if logger.Storage.Path == nil || logger.Storage.Parameters == nil || logger.Storage.StorageKey == nil {
 				return errors.New(InvalidLoggerStorageConfigError)
 			}
+			if err := validateLoggerStorageBatch(*logger.Storage.Parameters); err != nil {
+				return err
+			}
+		}
+		if err := validateLoggerFilter(logger); err != nil {
+			return err
+		}
+		if err := validateLoggerEncryption(logger.Encryption); err != nil {
+			return err
+		}
+		if logger.Kafka != nil {
+			return validateLoggerKafka(logger)
+		}
+	}
+
+	return nil
+}
+
+// loggerStatusCodeRegex matches a status code or a class of status codes, e.g. 404 or 5xx
+var loggerStatusCodeRegex = regexp.MustCompile(`^[1-5]([0-9]{2}|xx)$`)
+
+// validateLoggerFilter validates the sampling, the filters and the redacted fields of the logger, which are passed on
+// to the agent as comma separated lists
+func validateLoggerFilter(logger *LoggerSpec) error {
+	if logger.Sampling != nil {
+		if percent := logger.Sampling.Percent; percent != nil && (*percent < 0 || *percent > 100) {
+			return fmt.Errorf(InvalidLoggerFilterError, "the sampling percent must be between 0 and 100")
+		}
+		if percent := logger.Sampling.ErrorPercent; percent != nil && (*percent < 0 || *percent > 100) {
+			return fmt.Errorf(InvalidLoggerFilterError, "the sampling errorPercent must be between 0 and 100")
+		}
+	}
+	for _, filter := range logger.HeaderFilters {
+		if filter.Name == "" || strings.ContainsAny(filter.Name, "=, ") {
+			return fmt.Errorf(InvalidLoggerFilterError, fmt.Sprintf("the header name %q is invalid", filter.Name))
+		}
+		for _, value := range filter.Values {
+			if strings.Contains(value, ",") {
+				return fmt.Errorf(InvalidLoggerFilterError, fmt.Sprintf("the value %q of the header %s must not contain a comma", value, filter.Name))
+			}
 		}
 	}
+	for _, statusCode := range logger.StatusCodes {
+		if !loggerStatusCodeRegex.MatchString(statusCode) {
+			return fmt.Errorf(InvalidLoggerFilterError, fmt.Sprintf("the status code %q must be a status code or a class of status codes, e.g. 404 or 5xx", statusCode))
+		}
+	}
+	for _, field := range logger.RedactFields {
+		if field == "" || strings.Contains(field, ",") || slices.Contains(strings.Split(field, "."), "") {
+			return fmt.Errorf(InvalidLoggerFilterError, fmt.Sprintf("the redacted field %q must be a dot separated path", field))
+		}
+	}
+	return nil
+}
 
+// validateLoggerStorageBatch validates the flush interval and size of the payloads batched into ndjson or parquet
+// files
+func validateLoggerStorageBatch(parameters map[string]string) error {
+	flushInterval, hasFlushInterval := parameters[constants.LoggerFlushIntervalKey]
+	flushSize, hasFlushSize := parameters[constants.LoggerFlushSizeKey]
+	if !hasFlushInterval && !hasFlushSize {
+		return nil
+	}
+	format := parameters[constants.LoggerFormatKey]
+	if format != constants.LoggerNDJSONFormat && format != constants.LoggerParquetFormat {
+		return fmt.Errorf(InvalidLoggerStorageBatchError, fmt.Sprintf("the %s and %s parameters require the %s or %s format",
+			constants.LoggerFlushIntervalKey, constants.LoggerFlushSizeKey, constants.LoggerNDJSONFormat, constants.LoggerParquetFormat))
+	}
+	if hasFlushInterval {
+		if interval, err := time.ParseDuration(flushInterval); err != nil || interval <= 0 {
+			return fmt.Errorf(InvalidLoggerStorageBatchError, fmt.Sprintf("the %s %q must be a positive duration", constants.LoggerFlushIntervalKey, flushInterval))
+		}
+	}
+	if hasFlushSize {
+		if size, err := resource.ParseQuantity(flushSize); err != nil || size.Sign() <= 0 {
+			return fmt.Errorf(InvalidLoggerStorageBatchError, fmt.Sprintf("the %s %q must be a positive quantity", constants.LoggerFlushSizeKey, flushSize))
+		}
+	}
+	return nil
+}
+
+func validateLoggerEncryption(encryption *LoggerEncryptionSpec) error {
+	if encryption == nil {
+		return nil
+	}
+	if (encryption.KMSKeyID == nil) == (encryption.PublicKeySecretRef == nil) {
+		return fmt.Errorf(InvalidLoggerEncryptionError, "exactly one of kmsKeyId and publicKeySecretRef must be set")
+	}
+	if encryption.KMSKeyID != nil && *encryption.KMSKeyID == "" {
+		return fmt.Errorf(InvalidLoggerEncryptionError, "the kmsKeyId must not be empty")
+	}
+	if ref := encryption.PublicKeySecretRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf(InvalidLoggerEncryptionError, "the publicKeySecretRef must reference a key of a secret")
+	}
+	return nil
+}
+
+func validateLoggerKafka(logger *LoggerSpec) error {
+	if logger.Storage != nil {
+		return fmt.Errorf(InvalidLoggerKafkaConfigError, "the storage and the kafka sink are exclusive")
+	}
+	if len(logger.Kafka.Brokers) == 0 {
+		return fmt.Errorf(InvalidLoggerKafkaConfigError, "at least one broker must be set")
+	}
+	for _, broker := range logger.Kafka.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil || strings.ContainsAny(broker, ",/") {
+			return fmt.Errorf(InvalidLoggerKafkaConfigError, fmt.Sprintf("the broker %q must be a host:port", broker))
+		}
+	}
+	if logger.Kafka.Topic == "" || strings.Contains(logger.Kafka.Topic, "/") {
+		return fmt.Errorf(InvalidLoggerKafkaConfigError, fmt.Sprintf("the topic %q is invalid", logger.Kafka.Topic))
+	}
 	return nil
 }
 
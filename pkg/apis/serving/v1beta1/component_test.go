@@ -25,7 +25,10 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 func TestComponentExtensionSpec_Validate(t *testing.T) {
@@ -48,6 +51,80 @@ func TestComponentExtensionSpec_Validate(t *testing.T) {
 			},
 			matcher: gomega.Not(gomega.BeNil()),
 		},
+		"ValidScaleSchedule": {
+			spec: ComponentExtensionSpec{
+				MaxReplicas: 4,
+				ScaleSchedule: []ScaleScheduleWindow{
+					{Name: "business-hours", Start: "0 8 * * MON-FRI", End: "0 18 * * MON-FRI", Timezone: "Europe/Paris", MinReplicas: 4},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"DuplicateScaleScheduleWindow": {
+			spec: ComponentExtensionSpec{
+				ScaleSchedule: []ScaleScheduleWindow{
+					{Name: "peak", Start: "0 8 * * *", End: "0 18 * * *", MinReplicas: 2},
+					{Name: "peak", Start: "0 20 * * *", End: "0 22 * * *", MinReplicas: 2},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(DuplicateScaleScheduleWindowError, "peak")),
+		},
+		"InvalidScaleScheduleCron": {
+			spec: ComponentExtensionSpec{
+				ScaleSchedule: []ScaleScheduleWindow{{Name: "peak", Start: "0 25 * * *", End: "0 18 * * *", MinReplicas: 2}},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring(`invalid scaleSchedule window "peak"`)),
+		},
+		"InvalidScaleScheduleTimezone": {
+			spec: ComponentExtensionSpec{
+				ScaleSchedule: []ScaleScheduleWindow{{Name: "peak", Start: "0 8 * * *", End: "0 18 * * *", Timezone: "Europe/Atlantis", MinReplicas: 2}},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("unknown time zone")),
+		},
+		"ScaleScheduleAboveMaxReplicas": {
+			spec: ComponentExtensionSpec{
+				MaxReplicas:   2,
+				ScaleSchedule: []ScaleScheduleWindow{{Name: "peak", Start: "0 8 * * *", End: "0 18 * * *", MinReplicas: 3}},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("cannot be greater than maxReplicas 2")),
+		},
+		"SlowStartWithContainerConcurrency": {
+			spec: ComponentExtensionSpec{
+				ContainerConcurrency: ptr.To(int64(8)),
+				SlowStart:            &SlowStartSpec{WindowSeconds: 60},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"SlowStartWithoutConcurrency": {
+			spec: ComponentExtensionSpec{
+				SlowStart: &SlowStartSpec{WindowSeconds: 60, InitialPercent: ptr.To(int32(20))},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidSlowStartError, "maxConcurrency or containerConcurrency must be set")),
+		},
+		"SlowStartInvalidInitialPercent": {
+			spec: ComponentExtensionSpec{
+				SlowStart: &SlowStartSpec{WindowSeconds: 60, InitialPercent: ptr.To(int32(0)), MaxConcurrency: ptr.To(int64(8))},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidSlowStartError, "initialPercent must be between 1 and 100")),
+		},
+		"ValidAdaptiveBatcher": {
+			spec: ComponentExtensionSpec{
+				Batcher: &Batcher{MaxBatchSize: ptr.To(32), Adaptive: &AdaptiveBatcher{LatencySLO: 200, MinBatchSize: ptr.To(4)}},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"AdaptiveBatcherWithoutLatencySLO": {
+			spec: ComponentExtensionSpec{
+				Batcher: &Batcher{Adaptive: &AdaptiveBatcher{}},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidAdaptiveBatcherError, "latencySLO must be at least 1")),
+		},
+		"AdaptiveBatcherMinBatchSizeAboveMax": {
+			spec: ComponentExtensionSpec{
+				Batcher: &Batcher{MaxBatchSize: ptr.To(8), Adaptive: &AdaptiveBatcher{LatencySLO: 200, MinBatchSize: ptr.To(16)}},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidAdaptiveBatcherError, "minBatchSize cannot be greater than maxBatchSize")),
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -186,6 +263,126 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			},
 			matcher: gomega.MatchError(errors.New(InvalidLoggerStorageConfigError)),
 		},
+		"KafkaSink": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Kafka: &LoggerKafkaSpec{
+					Brokers:   []string{"kafka-0.kafka:9092", "kafka-1.kafka:9092"},
+					Topic:     "payloads",
+					SecretRef: &corev1.LocalObjectReference{Name: "kafka-sasl"},
+				},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"KafkaSinkBrokerWithoutPort": {
+			logger: &LoggerSpec{
+				Mode:  LogAll,
+				Kafka: &LoggerKafkaSpec{Brokers: []string{"kafka"}, Topic: "payloads"},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerKafkaConfigError, `the broker "kafka" must be a host:port`)),
+		},
+		"KafkaSinkWithoutTopic": {
+			logger: &LoggerSpec{
+				Mode:  LogAll,
+				Kafka: &LoggerKafkaSpec{Brokers: []string{"kafka:9092"}},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerKafkaConfigError, `the topic "" is invalid`)),
+		},
+		"EncryptionWithKMSKey": {
+			logger: &LoggerSpec{
+				Mode:       LogAll,
+				Encryption: &LoggerEncryptionSpec{KMSKeyID: ptr.To("alias/payload-logs")},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"EncryptionWithKMSKeyAndPublicKey": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Encryption: &LoggerEncryptionSpec{
+					KMSKeyID: ptr.To("alias/payload-logs"),
+					PublicKeySecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "payload-logs"},
+						Key:                  "public.pem",
+					},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerEncryptionError, "exactly one of kmsKeyId and publicKeySecretRef must be set")),
+		},
+		"EncryptionWithoutPublicKeySecretKey": {
+			logger: &LoggerSpec{
+				Mode: LogAll,
+				Encryption: &LoggerEncryptionSpec{
+					PublicKeySecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "payload-logs"}},
+				},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerEncryptionError, "the publicKeySecretRef must reference a key of a secret")),
+		},
+		"BatchedStorage": {
+			logger: &LoggerSpec{
+				Mode:    LogAll,
+				Storage: batchedStorage(constants.LoggerNDJSONFormat, "30s", "64Mi"),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"BatchedStorageWithJSONFormat": {
+			logger: &LoggerSpec{
+				Mode:    LogAll,
+				Storage: batchedStorage(constants.LoggerDefaultFormat, "30s", ""),
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring("require the ndjson or parquet format")),
+		},
+		"BatchedStorageInvalidFlushInterval": {
+			logger: &LoggerSpec{
+				Mode:    LogAll,
+				Storage: batchedStorage(constants.LoggerParquetFormat, "0s", ""),
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerStorageBatchError, `the flushInterval "0s" must be a positive duration`)),
+		},
+		"BatchedStorageInvalidFlushSize": {
+			logger: &LoggerSpec{
+				Mode:    LogAll,
+				Storage: batchedStorage(constants.LoggerParquetFormat, "", "big"),
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerStorageBatchError, `the flushSize "big" must be a positive quantity`)),
+		},
+		"SamplingAndFilters": {
+			logger: &LoggerSpec{
+				Mode:          LogAll,
+				Sampling:      &LoggerSamplingSpec{Percent: ptr.To(int32(1)), ErrorPercent: ptr.To(int32(100))},
+				HeaderFilters: []LoggerHeaderFilter{{Name: "X-Tenant", Values: []string{"a", "b"}}, {Name: "X-Debug"}},
+				StatusCodes:   []string{"200", "4xx", "5xx"},
+				RedactFields:  []string{"instances.ssn", "parameters"},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"SamplingPercentAbove100": {
+			logger: &LoggerSpec{
+				Mode:     LogAll,
+				Sampling: &LoggerSamplingSpec{ErrorPercent: ptr.To(int32(101))},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerFilterError, "the sampling errorPercent must be between 0 and 100")),
+		},
+		"HeaderFilterValueWithComma": {
+			logger: &LoggerSpec{
+				Mode:          LogAll,
+				HeaderFilters: []LoggerHeaderFilter{{Name: "X-Tenant", Values: []string{"a,b"}}},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerFilterError, `the value "a,b" of the header X-Tenant must not contain a comma`)),
+		},
+		"InvalidStatusCode": {
+			logger: &LoggerSpec{
+				Mode:        LogAll,
+				StatusCodes: []string{"6xx"},
+			},
+			matcher: gomega.MatchError(gomega.ContainSubstring(`the status code "6xx"`)),
+		},
+		"InvalidRedactField": {
+			logger: &LoggerSpec{
+				Mode:         LogAll,
+				RedactFields: []string{"instances..ssn"},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidLoggerFilterError, `the redacted field "instances..ssn" must be a dot separated path`)),
+		},
 	}
 	for name, scenario := range scenarios {
 		t.Run(name, func(t *testing.T) {
@@ -194,6 +391,23 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 	}
 }
 
+func batchedStorage(format string, flushInterval string, flushSize string) *LoggerStorageSpec {
+	parameters := map[string]string{constants.LoggerFormatKey: format}
+	if flushInterval != "" {
+		parameters[constants.LoggerFlushIntervalKey] = flushInterval
+	}
+	if flushSize != "" {
+		parameters[constants.LoggerFlushSizeKey] = flushSize
+	}
+	return &LoggerStorageSpec{
+		StorageSpec: StorageSpec{
+			Path:       ptr.To("/logger"),
+			Parameters: &parameters,
+			StorageKey: ptr.To("credentials"),
+		},
+	}
+}
+
 func TestFirstNonNilComponent(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	spec := PredictorSpec{
@@ -21,14 +21,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"text/template"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/types"
@@ -49,14 +57,70 @@ const (
 	OtelCollectorConfigName            = "opentelemetryCollector"
 	StorageInitializerConfigMapKeyName = "storageInitializer"
 	AutoscalerConfigName               = "autoscaler"
+	CheckpointRestoreConfigName        = "checkpointRestore"
+	CostEstimationConfigName           = "costEstimation"
+	CloudEventsConfigName              = "cloudEvents"
+	KueueConfigName                    = "kueue"
+	CapacityConfigName                 = "capacity"
+	PodMonitorConfigName               = "podMonitor"
+	MetricsAggregatorConfigName        = "metricsAggregator"
+	GrafanaDashboardsConfigName        = "grafanaDashboards"
+	PodMutatorConfigName               = "podMutator"
+	PrometheusConfigName               = "prometheus"
+	IdleTimeoutConfigName              = "idleTimeout"
+	CanaryAnalysisConfigName           = "canaryAnalysis"
+	LoggerConfigName                   = "logger"
+	NamespaceOverridesConfigName       = "namespaceOverrides"
+	ServerTLSConfigName                = "serverTLS"
+	ProvenanceConfigName               = "provenance"
+	ActivatorConfigName                = "activator"
+	ExternalDNSConfigName              = "externalDNS"
+	MIGPartitioningConfigName          = "migPartitioning"
+	ConfigSnapshotConfigName           = "configSnapshot"
+	MonitoringConfigName               = "monitoring"
+	CrashDiagnosticsConfigName         = "crashDiagnostics"
+	SharedCacheConfigName              = "sharedCache"
 )
 
 const (
 	DefaultDomainTemplate = "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"
 	DefaultIngressDomain  = "example.com"
 	DefaultUrlScheme      = "http"
+	DefaultCostCurrency   = "USD"
+	DefaultHoursPerMonth  = 730
+	// DefaultCapacityTypeNodeLabel is the node label of the capacity type set by Karpenter
+	DefaultCapacityTypeNodeLabel = "karpenter.sh/capacity-type"
+	// DefaultIdleRequestCountQuery counts the requests of an InferenceService from the queue-proxy metrics labeled by
+	// its pod monitor
+	DefaultIdleRequestCountQuery = `sum(increase(revision_request_count{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"}[{{ .Window }}]))`
+	DefaultIdleCheckInterval     = 5 * time.Minute
+	// DefaultCanarySuccessRateQuery is the percentage of the requests of a canary revision answered without a server
+	// error, from the queue-proxy metrics labeled by its pod monitor
+	DefaultCanarySuccessRateQuery = `100 * sum(rate(revision_request_count{namespace="{{ .Namespace }}", revision="{{ .Revision }}", response_code_class!="5xx"}[{{ .Interval }}])) / sum(rate(revision_request_count{namespace="{{ .Namespace }}", revision="{{ .Revision }}"}[{{ .Interval }}]))`
+	// DefaultCanaryLatencyQuery is the 99th percentile of the latency of the requests of a canary revision in
+	// milliseconds, from the queue-proxy metrics labeled by its pod monitor
+	DefaultCanaryLatencyQuery = `histogram_quantile(0.99, sum by (le) (rate(revision_request_latencies_bucket{namespace="{{ .Namespace }}", revision="{{ .Revision }}"}[{{ .Interval }}])))`
+	// DefaultActivatorDuration is the default scale to zero grace period and request timeout of the activator
+	DefaultActivatorDuration = 5 * time.Minute
+	// DefaultDriftQuery is the highest drift reported by the detector of an InferenceService over the interval, from
+	// the is_drift gauge of the detector labeled by its pod monitor
+	DefaultDriftQuery         = `max(max_over_time(is_drift{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"}[{{ .Interval }}]))`
+	DefaultDriftCheckInterval = time.Minute
+	DefaultDriftBatchSize     = 1000
 )
 
+// DefaultNamespaceOverridableKeys are the configuration keys the namespaces may override by default. Only the domain
+// template and the url scheme of the ingress may be overridden, the gateways and the ingress domain are shared.
+var DefaultNamespaceOverridableKeys = []string{
+	StorageInitializerConfigMapKeyName,
+	IngressConfigKeyName + ".domainTemplate",
+	IngressConfigKeyName + ".urlScheme",
+	LoggerConfigName,
+}
+
+// DefaultMIGProducts are the GPU products whose MIG partitions are orchestrated by default
+var DefaultMIGProducts = []string{"A100", "H100"}
+
 // Error messages
 const (
 	ErrKserveIngressGatewayRequired         = "invalid ingress config - kserveIngressGateway is required"
@@ -82,12 +146,16 @@ type OtelCollectorConfig struct {
 	ScrapeInterval         string         `json:"scrapeInterval,omitempty"`
 	MetricReceiverEndpoint string         `json:"metricReceiverEndpoint,omitempty"`
 	MetricScalerEndpoint   string         `json:"metricScalerEndpoint,omitempty"`
-	Resource               ResourceConfig `json:"resource,omitempty"` // Resource configuration for otel collector
+	MetricForwardEndpoint  string         `json:"metricForwardEndpoint,omitempty"` // Default endpoint the metrics of the ISVC observability sidecar are forwarded to
+	Resource               ResourceConfig `json:"resource,omitempty"`              // Resource configuration for otel collector
 }
 
 type AutoscalerConfig struct {
 	ScaleUpStabilizationWindowSeconds   string `json:"scaleUpStabilizationWindowSeconds,omitempty"`
 	ScaleDownStabilizationWindowSeconds string `json:"scaleDownStabilizationWindowSeconds,omitempty"`
+	// PrometheusServerAddress is the address of the Prometheus server the runtime metrics are queried from by the
+	// KEDA autoscaler, unless the metric sets its own server address
+	PrometheusServerAddress string `json:"prometheusServerAddress,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -101,8 +169,46 @@ type InferenceServicesConfig struct {
 	ServiceLabelDisallowedList []string `json:"serviceLabelDisallowedList,omitempty"`
 	// Resource configurations
 	Resource ResourceConfig `json:"resource,omitempty"`
+	// GPUCapabilityValidation configures how an InferenceService whose model cannot fit on the GPUs of the targeted
+	// nodes is admitted. Defaults to warn.
+	GPUCapabilityValidation GPUCapabilityValidationPolicy `json:"gpuCapabilityValidation,omitempty"`
+	// ExcludeWindowsNodes keeps the predictor pods off the Windows nodes of mixed-OS clusters, unless the operating
+	// system is selected by the node selector of the predictor
+	ExcludeWindowsNodes bool `json:"excludeWindowsNodes,omitempty"`
+	// DefaultServiceAccountName is the ServiceAccount of the components which do not set one, when neither the
+	// runtime of the predictor nor the namespace set a default
+	DefaultServiceAccountName string `json:"defaultServiceAccountName,omitempty"`
+	// UnknownAnnotationValidation configures how an InferenceService with serving.kserve.io annotations which are
+	// not recognized by the controller is admitted. Defaults to warn.
+	UnknownAnnotationValidation UnknownAnnotationValidationPolicy `json:"unknownAnnotationValidation,omitempty"`
 }
 
+// GPUCapabilityValidationPolicy defines how the model requirements are validated against the GPUs of the nodes
+type GPUCapabilityValidationPolicy string
+
+// GPUCapabilityValidationPolicy Enum
+const (
+	// GPUCapabilityValidationWarn admits the InferenceService with a warning
+	GPUCapabilityValidationWarn GPUCapabilityValidationPolicy = "warn"
+	// GPUCapabilityValidationEnforce rejects the InferenceService
+	GPUCapabilityValidationEnforce GPUCapabilityValidationPolicy = "enforce"
+	// GPUCapabilityValidationDisabled does not validate the model requirements
+	GPUCapabilityValidationDisabled GPUCapabilityValidationPolicy = "disabled"
+)
+
+// UnknownAnnotationValidationPolicy defines how the unknown serving.kserve.io annotations are validated
+type UnknownAnnotationValidationPolicy string
+
+// UnknownAnnotationValidationPolicy Enum
+const (
+	// UnknownAnnotationValidationWarn admits the InferenceService with a warning
+	UnknownAnnotationValidationWarn UnknownAnnotationValidationPolicy = "warn"
+	// UnknownAnnotationValidationEnforce rejects the InferenceService
+	UnknownAnnotationValidationEnforce UnknownAnnotationValidationPolicy = "enforce"
+	// UnknownAnnotationValidationDisabled does not validate the annotations
+	UnknownAnnotationValidationDisabled UnknownAnnotationValidationPolicy = "disabled"
+)
+
 // +kubebuilder:object:generate=false
 type MultiNodeConfig struct {
 	// CustomGPUResourceTypeList is a list of custom GPU resource types that are allowed to be used in the ServingRuntime and inferenceService
@@ -125,6 +231,10 @@ type IngressConfig struct {
 	DisableIstioVirtualHost    bool      `json:"disableIstioVirtualHost,omitempty"`
 	PathTemplate               string    `json:"pathTemplate,omitempty"`
 	DisableIngressCreation     bool      `json:"disableIngressCreation,omitempty"`
+	// NamespaceSubdomain is the <namespace>.<ingress domain> subdomain the hosts must be rendered in when a namespace
+	// overrides the domain template, so that a namespace cannot claim the hosts of the other namespaces. It is set
+	// on the merged ConfigMap of the namespace, the one of the namespace ConfigMap is dropped.
+	NamespaceSubdomain string `json:"namespaceSubdomain,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -159,6 +269,361 @@ type LocalModelConfig struct {
 	JobTTLSecondsAfterFinished   *int32 `json:"jobTTLSecondsAfterFinished,omitempty"`
 	ReconcilationFrequencyInSecs *int64 `json:"reconcilationFrequencyInSecs,omitempty"`
 	DisableVolumeManagement      bool   `json:"disableVolumeManagement,omitempty"`
+	// PeerDistribution configures the local model agents to fetch the models from the peer nodes which already
+	// downloaded them, instead of downloading them from the storage
+	PeerDistribution *LocalModelPeerDistributionConfig `json:"peerDistribution,omitempty"`
+	// CachedNodeAffinity configures the node affinity added to the predictor pods of a cached model for the nodes
+	// which downloaded the model. Defaults to preferred.
+	CachedNodeAffinity CachedNodeAffinityPolicy `json:"cachedNodeAffinity,omitempty"`
+}
+
+// CachedNodeAffinityPolicy defines how the predictor pods of a cached model are scheduled on the nodes holding the model
+type CachedNodeAffinityPolicy string
+
+// CachedNodeAffinityPolicy Enum
+const (
+	// CachedNodeAffinityPreferred prefers the nodes holding the model but still schedules the pods on other nodes
+	CachedNodeAffinityPreferred CachedNodeAffinityPolicy = "preferred"
+	// CachedNodeAffinityRequired only schedules the pods on the nodes holding the model
+	CachedNodeAffinityRequired CachedNodeAffinityPolicy = "required"
+	// CachedNodeAffinityDisabled does not add any node affinity
+	CachedNodeAffinityDisabled CachedNodeAffinityPolicy = "disabled"
+)
+
+// +kubebuilder:object:generate=false
+type LocalModelPeerDistributionConfig struct {
+	Enabled bool `json:"enabled"`
+	// Port on which the local model agents serve the downloaded models to the peer nodes
+	Port int32 `json:"port,omitempty"`
+}
+
+// CheckpointRestoreConfig configures the experimental restore of the predictor pods from checkpoints of the model
+// server container
+// +kubebuilder:object:generate=false
+type CheckpointRestoreConfig struct {
+	Enabled bool `json:"enabled"`
+	// Repository the checkpoint images are pushed to, e.g. registry.example.com/kserve/checkpoints
+	Repository string `json:"repository,omitempty"`
+}
+
+// MIGPartitioningConfig configures the orchestration of the MIG partitions requested by the predictors, e.g. with
+// nvidia.com/mig-3g.40gb. The nodes are partitioned by the MIG manager of the NVIDIA GPU operator, which applies the
+// configuration of their nvidia.com/mig.config label.
+// +kubebuilder:object:generate=false
+type MIGPartitioningConfig struct {
+	Enabled bool `json:"enabled"`
+	// Products are the GPU products which can be partitioned, matched as substrings of the nvidia.com/gpu.product
+	// label of the nodes. Defaults to A100 and H100.
+	Products []string `json:"products,omitempty"`
+	// DefaultConfig is the MIG configuration the nodes are restored to once released, when they had none before being
+	// claimed. Defaults to all-disabled.
+	DefaultConfig string `json:"defaultConfig,omitempty"`
+}
+
+// DefaultConfigSnapshotRetention is the default number of revisions of a component whose config snapshots are kept
+const DefaultConfigSnapshotRetention = 10
+
+// ConfigSnapshotConfig configures the immutable ConfigMaps snapshotting the resolved configuration each revision of
+// the components was rendered with: the InferenceService spec with the defaults applied, the serving runtime spec and
+// the inferenceservice-config values
+// +kubebuilder:object:generate=false
+type ConfigSnapshotConfig struct {
+	Enabled bool `json:"enabled"`
+	// Retention is the number of the latest revisions of each component whose snapshots are kept. Defaults to 10.
+	Retention int `json:"retention,omitempty"`
+}
+
+const (
+	// DefaultCrashLogLines is the default number of the last lines of the logs of a crashed container captured
+	DefaultCrashLogLines = 50
+	// MaxCrashLogLines bounds the lines of the logs captured, which are stored in the InferenceService status
+	MaxCrashLogLines = 1000
+)
+
+// CrashDiagnosticsConfig configures the capture of the diagnostics of the crashes of the runtime containers into the
+// lastCrashInfo status and an event of the InferenceServices
+// +kubebuilder:object:generate=false
+type CrashDiagnosticsConfig struct {
+	Enabled bool `json:"enabled"`
+	// LogLines is the number of the last lines of the logs of the crashed container captured. Defaults to 50.
+	LogLines int `json:"logLines,omitempty"`
+}
+
+// SharedCacheConfig configures the shared cache deployed once in the KServe namespace, which the InferenceGraph nodes
+// setting cache.sharedKey and the LLM routers cache their results in, e.g. embeddings and tokenizations, so that they
+// are reused across resources. The entries are partitioned by tenant, the namespace of the service account the caller
+// authenticates with, and the least recently used entries of a tenant are evicted once the tenant exceeds its quota.
+// +kubebuilder:object:generate=false
+type SharedCacheConfig struct {
+	Enabled bool `json:"enabled"`
+	// Image of the shared cache. Defaults to DefaultSharedCacheImage.
+	Image         string `json:"image,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+	// DefaultTenantQuota is the maximum size of the entries of a tenant. Defaults to DefaultSharedCacheTenantQuota.
+	DefaultTenantQuota string `json:"defaultTenantQuota,omitempty"`
+	// TenantQuotas override the default quota of the given tenants
+	TenantQuotas map[string]string `json:"tenantQuotas,omitempty"`
+	// MaxTenants is the maximum number of tenants the entries are held of, the entries of the other tenants are
+	// rejected. Defaults to DefaultSharedCacheMaxTenants.
+	MaxTenants int `json:"maxTenants,omitempty"`
+	// DefaultTenantQuotaBytes and TenantQuotasBytes are the parsed quotas
+	DefaultTenantQuotaBytes int64            `json:"-"`
+	TenantQuotasBytes       map[string]int64 `json:"-"`
+}
+
+// CostEstimationConfig configures the estimation of the steady-state cost of the InferenceServices, returned as an
+// admission warning so that accidentally large resource requests are caught, e.g. with a server-side dry-run
+// +kubebuilder:object:generate=false
+type CostEstimationConfig struct {
+	Enabled bool `json:"enabled"`
+	// Currency of the prices, only used in the warnings. Defaults to USD.
+	Currency string `json:"currency,omitempty"`
+	// HoursPerMonth is the number of hours the monthly cost is estimated over. Defaults to 730.
+	HoursPerMonth float64 `json:"hoursPerMonth,omitempty"`
+	// Prices is the hourly price of each resource, per core for cpu, per GiB for memory and ephemeral-storage and
+	// per unit for the other resources, e.g. nvidia.com/gpu. The resources without price are free.
+	Prices map[corev1.ResourceName]float64 `json:"prices,omitempty"`
+	// WarningThreshold is the monthly cost from which the warning is returned. Defaults to 0, always warning.
+	WarningThreshold float64 `json:"warningThreshold,omitempty"`
+}
+
+// CloudEventsConfig configures the CloudEvents sent by the controller on the lifecycle changes of the
+// InferenceServices, e.g. when a revision becomes ready or the traffic is shifted
+// +kubebuilder:object:generate=false
+type CloudEventsConfig struct {
+	Enabled bool `json:"enabled"`
+	// Sink is the URL the events are sent to, e.g. a Knative Eventing broker. The namespaces annotated with
+	// serving.kserve.io/cloudevents-sink send their events to the annotated sink instead.
+	Sink string `json:"sink,omitempty"`
+}
+
+// KueueConfig configures the admission by Kueue of the InferenceServices labeled with kueue.x-k8s.io/queue-name in
+// Standard deployment mode, so that inference shares the quota of the LocalQueues with the training jobs
+// +kubebuilder:object:generate=false
+type KueueConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// CapacityConfig configures the default node placement of the predictors splitting their replicas between spot
+// and on-demand nodes. The node selectors default to the karpenter.sh/capacity-type node label.
+// +kubebuilder:object:generate=false
+type CapacityConfig struct {
+	SpotNodeSelector     map[string]string   `json:"spotNodeSelector,omitempty"`
+	SpotTolerations      []corev1.Toleration `json:"spotTolerations,omitempty"`
+	OnDemandNodeSelector map[string]string   `json:"onDemandNodeSelector,omitempty"`
+}
+
+// PodMonitorConfig configures the Prometheus Operator PodMonitors scraping the queue-proxy metrics of the
+// InferenceServices in Knative deployment mode, aggregated with the metrics of the runtime when the metrics
+// aggregation is enabled
+// +kubebuilder:object:generate=false
+type PodMonitorConfig struct {
+	Enabled bool `json:"enabled"`
+	// Interval between the scrapes, defaults to the scrape interval of the Prometheus instance
+	Interval string `json:"interval,omitempty"`
+	// Labels added to the PodMonitors, e.g. to match the podMonitorSelector of the Prometheus instance
+	Labels map[string]string `json:"labels,omitempty"`
+	// DefaultMetricAggregation is whether the metrics are aggregated for the InferenceServices without the
+	// serving.kserve.io/enable-metric-aggregation annotation, read from the metricsAggregator configuration
+	DefaultMetricAggregation bool `json:"-"`
+}
+
+// GrafanaDashboardsConfig configures the KServe Grafana dashboards created in the namespaces of the
+// InferenceServices, once the dashboards sidecar of Grafana is detected from the labeled configmaps of the cluster
+// +kubebuilder:object:generate=false
+type GrafanaDashboardsConfig struct {
+	Enabled bool `json:"enabled"`
+	// Label the dashboards sidecar loads the configmaps with. Defaults to grafana_dashboard.
+	Label string `json:"label,omitempty"`
+	// LabelValue is the value of the label. Defaults to 1.
+	LabelValue string `json:"labelValue,omitempty"`
+	// FolderAnnotation is the annotation the dashboards sidecar reads the folder of the dashboards from, the
+	// dashboards of a namespace are put in a folder named after the namespace when set
+	FolderAnnotation string `json:"folderAnnotation,omitempty"`
+}
+
+// PrometheusConfig configures the Prometheus instance the idle timeouts, the drift and the canary analysis query the
+// metrics of the InferenceServices from
+// +kubebuilder:object:generate=false
+type PrometheusConfig struct {
+	// URL is the address of the Prometheus API, the metrics of the InferenceServices are not queried when empty
+	URL string `json:"url,omitempty"`
+}
+
+// IdleTimeoutConfig configures the detection of the idle InferenceServices setting spec.idleTimeout, from the count of
+// their inference requests queried from Prometheus
+// +kubebuilder:object:generate=false
+type IdleTimeoutConfig struct {
+	// Query is the Go template of the PromQL query counting the requests of an InferenceService over a window,
+	// rendered with the Namespace, the InferenceService and the Window. Defaults to DefaultIdleRequestCountQuery.
+	Query string `json:"query,omitempty"`
+	// CheckInterval between the request counts of an active InferenceService. Defaults to 5m.
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// CheckIntervalDuration is the parsed CheckInterval
+	CheckIntervalDuration time.Duration `json:"-"`
+}
+
+// CanaryAnalysisConfig configures the Prometheus queries evaluating the canary revisions of the components setting
+// canaryAnalysis
+// +kubebuilder:object:generate=false
+type CanaryAnalysisConfig struct {
+	// SuccessRateQuery is the Go template of the PromQL query of the percentage of the requests of a canary revision
+	// answered without a server error, rendered with the Namespace, the InferenceService, the Component, the Revision
+	// and the Interval. Defaults to DefaultCanarySuccessRateQuery.
+	SuccessRateQuery string `json:"successRateQuery,omitempty"`
+	// LatencyQuery is the Go template of the PromQL query of the 99th percentile of the latency of the requests of a
+	// canary revision in milliseconds, rendered with the same values. Defaults to DefaultCanaryLatencyQuery.
+	LatencyQuery string `json:"latencyQuery,omitempty"`
+}
+
+// MonitoringConfig configures the detectors injected next to the predictors setting monitoring, and the Prometheus
+// query of the drift they report
+// +kubebuilder:object:generate=false
+type MonitoringConfig struct {
+	// Image of the detector server. Defaults to DefaultDetectorImage.
+	Image         string `json:"image,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+	// DriftBatchSize is the number of inputs the drift is tested on. Defaults to 1000.
+	DriftBatchSize int32 `json:"driftBatchSize,omitempty"`
+	// DriftQuery is the Go template of the PromQL query of the drift reported by the detector of an InferenceService,
+	// positive once drifted, rendered with the Namespace, the InferenceService and the Interval. Defaults to
+	// DefaultDriftQuery.
+	DriftQuery string `json:"driftQuery,omitempty"`
+	// CheckInterval between the drift queries. Defaults to 1m.
+	CheckInterval string `json:"checkInterval,omitempty"`
+	// CheckIntervalDuration is the parsed CheckInterval
+	CheckIntervalDuration time.Duration `json:"-"`
+}
+
+// PodMutatorConfig configures the scope and the failure policy of the pod mutating webhook, which the manager
+// applies to the webhook configuration
+// +kubebuilder:object:generate=false
+type PodMutatorConfig struct {
+	// NamespaceSelector restricts the pod mutation to the namespaces matching the selector. The namespaces labeled
+	// control-plane are always excluded. All the other namespaces are selected when unset.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// FailOpen admits the pods when the webhook is unavailable instead of rejecting them. The InferenceService pods
+	// admitted without mutation are evicted by the manager once the webhook is available, to be recreated mutated.
+	FailOpen bool `json:"failOpen,omitempty"`
+}
+
+// ServerTLSConfig configures the provisioning of the serving certificates of the predictors setting
+// spec.transport.serverTLS
+// +kubebuilder:object:generate=false
+type ServerTLSConfig struct {
+	// IssuerRef is the cert-manager issuer of the serving certificates of the InferenceServices without issuerRef
+	IssuerRef *CertificateIssuerReference `json:"issuerRef,omitempty"`
+	// Duration of the cert-manager certificates, defaults to the duration of cert-manager
+	Duration string `json:"duration,omitempty"`
+	// RenewBefore is the time before the expiry the cert-manager certificates are renewed, defaults to the renewal
+	// time of cert-manager
+	RenewBefore string `json:"renewBefore,omitempty"`
+	// SpiffeHelperImage is the image of the spiffe-helper sidecar writing the SVIDs of the SPIRE agent to the files
+	// of the runtime. Defaults to DefaultSpiffeHelperImage.
+	SpiffeHelperImage string `json:"spiffeHelperImage,omitempty"`
+	// SpiffeCSIDriver is the CSI driver mounting the Workload API socket of the SPIRE agent. Defaults to csi.spiffe.io.
+	SpiffeCSIDriver string `json:"spiffeCSIDriver,omitempty"`
+	// SpireAgentSocket is the file name of the Workload API socket in the CSI volume. Defaults to spire-agent.sock.
+	SpireAgentSocket string `json:"spireAgentSocket,omitempty"`
+}
+
+// ProvenanceConfig configures the provenance annotations of the InferenceService pods, recording the digests of their
+// images, the references of the SBOMs of the images and the version of KServe the pods were injected by
+// +kubebuilder:object:generate=false
+type ProvenanceConfig struct {
+	Enabled bool `json:"enabled"`
+	// RequiredAttestations are the attestations the images must have, published with the tag scheme of cosign next
+	// to the images, among ProvenanceAttestationKinds
+	RequiredAttestations []string `json:"requiredAttestations,omitempty"`
+	// Enforce rejects the pods whose images lack a required attestation or cannot be resolved, otherwise the pods are
+	// only labeled as not verified
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// ActivatorConfig configures the activator deployed in front of the components scaled to zero in Standard deployment
+// mode with the serving.kserve.io/activator annotation, which buffers their requests while they are scaled from zero
+// +kubebuilder:object:generate=false
+type ActivatorConfig struct {
+	// Image of the activator. Defaults to DefaultActivatorImage.
+	Image         string `json:"image,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+	// ScaleToZeroGracePeriod is how long a component receives no requests before it is scaled to zero. Defaults to 5m.
+	ScaleToZeroGracePeriod string `json:"scaleToZeroGracePeriod,omitempty"`
+	// RequestTimeout is how long the requests are buffered while the component is scaled from zero. Defaults to 5m.
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+	// KedaHTTPInterceptorService is the address of the interceptor proxy of the KEDA HTTP add-on the keda-http
+	// activators forward the requests to. Defaults to DefaultKedaHTTPInterceptorService.
+	KedaHTTPInterceptorService string `json:"kedaHttpInterceptorService,omitempty"`
+	// ScaleToZeroGracePeriodDuration and RequestTimeoutDuration are the parsed durations
+	ScaleToZeroGracePeriodDuration time.Duration `json:"-"`
+	RequestTimeoutDuration         time.Duration `json:"-"`
+}
+
+// ExternalDNSConfig configures the DNSEndpoints published for the external hosts of the InferenceServices, so that
+// their custom domains resolve to the ingress gateway once ExternalDNS is installed with the CRD source
+// +kubebuilder:object:generate=false
+type ExternalDNSConfig struct {
+	Enabled bool `json:"enabled"`
+	// Targets of the DNS records, the addresses of the load balancer of the ingress gateway. The records are A or AAAA
+	// records when the targets are IP addresses, CNAME records otherwise.
+	Targets []string `json:"targets,omitempty"`
+	// RecordTTL is the TTL of the DNS records in seconds, defaults to the TTL of the DNS provider
+	RecordTTL int64 `json:"recordTTL,omitempty"`
+	// Zone is the DNS zone the hosts are published in, the hosts outside the zone of their namespace are not
+	// published. All the external hosts are published when empty.
+	Zone string `json:"zone,omitempty"`
+	// NamespaceZones are the zones of the namespaces publishing their hosts in another zone than Zone
+	NamespaceZones map[string]string `json:"namespaceZones,omitempty"`
+	// Labels of the DNSEndpoints, e.g. to select them with the label filter of the ExternalDNS instance of a zone
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ZoneFor returns the DNS zone the hosts of the InferenceServices of the namespace are published in
+func (c *ExternalDNSConfig) ZoneFor(namespace string) string {
+	if zone, ok := c.NamespaceZones[namespace]; ok {
+		return zone
+	}
+	return c.Zone
+}
+
+// RecordType returns the type of the DNS records of the targets
+func (c *ExternalDNSConfig) RecordType() string {
+	if len(c.Targets) == 0 {
+		return ""
+	}
+	ip := net.ParseIP(c.Targets[0])
+	switch {
+	case ip == nil:
+		return "CNAME"
+	case ip.To4() != nil:
+		return "A"
+	default:
+		return "AAAA"
+	}
+}
+
+// ProvenanceAttestationKinds are the suffixes of the tags cosign publishes the attestations of an image with
+var ProvenanceAttestationKinds = []string{"sbom", "sig", "att"}
+
+// NamespaceOverridesConfig configures the merge of the inferenceservice-config ConfigMap of the namespace of an
+// InferenceService on top of the global one, so that the tenants of a cluster can set their own defaults
+// +kubebuilder:object:generate=false
+type NamespaceOverridesConfig struct {
+	// Enabled turns on the namespace overrides
+	Enabled bool `json:"enabled,omitempty"`
+	// Keys are the configuration keys the namespaces may override, either a whole key, e.g. logger, or a single
+	// field of a key, e.g. ingress.domainTemplate. The other keys and fields of the namespace ConfigMaps are ignored.
+	// Defaults to DefaultNamespaceOverridableKeys.
+	Keys []string `json:"keys,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -181,7 +646,19 @@ type ServiceConfig struct {
 	ServiceClusterIPNone bool `json:"serviceClusterIPNone,omitempty"`
 }
 
+// reloadedConfigMap is the last valid inferenceservice-config ConfigMap loaded by the config watcher of the manager
+var reloadedConfigMap atomic.Pointer[corev1.ConfigMap]
+
+// SetInferenceServiceConfigMap sets the ConfigMap returned by GetInferenceServiceConfigMap instead of the one of the
+// API server, so that an invalid ConfigMap is not used until it is fixed. Setting nil reads the API server again.
+func SetInferenceServiceConfigMap(configMap *corev1.ConfigMap) {
+	reloadedConfigMap.Store(configMap)
+}
+
 func GetInferenceServiceConfigMap(ctx context.Context, clientset kubernetes.Interface) (*corev1.ConfigMap, error) {
+	if configMap := reloadedConfigMap.Load(); configMap != nil {
+		return configMap.DeepCopy(), nil
+	}
 	if configMap, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(
 		ctx, constants.InferenceServiceConfigMapName, metav1.GetOptions{}); err != nil {
 		return nil, err
@@ -190,6 +667,153 @@ func GetInferenceServiceConfigMap(ctx context.Context, clientset kubernetes.Inte
 	}
 }
 
+// getNamespaceConfigMap returns the inferenceservice-config ConfigMap of the namespace, read from the reader when it
+// is set, e.g. the cache the manager keeps on the namespace ConfigMaps, or from the API server otherwise
+func getNamespaceConfigMap(ctx context.Context, clientset kubernetes.Interface, reader client.Reader, namespace string) (*corev1.ConfigMap, error) {
+	if reader != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: constants.InferenceServiceConfigMapName}, configMap); err != nil {
+			return nil, err
+		}
+		return configMap, nil
+	}
+	return clientset.CoreV1().ConfigMaps(namespace).Get(ctx, constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+}
+
+// GetInferenceServiceConfigMapForNamespace returns the global inferenceservice-config ConfigMap with the
+// inferenceservice-config ConfigMap of the namespace merged on top of it when the namespace overrides are enabled.
+// The namespace ConfigMap is read from the namespaceConfigMaps reader, or from the API server when it is nil.
+func GetInferenceServiceConfigMapForNamespace(ctx context.Context, clientset kubernetes.Interface, namespaceConfigMaps client.Reader,
+	namespace string,
+) (*corev1.ConfigMap, error) {
+	configMap, err := GetInferenceServiceConfigMap(ctx, clientset)
+	if err != nil {
+		return nil, err
+	}
+	overridesConfig, err := NewNamespaceOverridesConfig(configMap)
+	if err != nil {
+		return nil, err
+	}
+	if !overridesConfig.Enabled || namespace == "" || namespace == constants.KServeNamespace {
+		return configMap, nil
+	}
+	namespaceConfigMap, err := getNamespaceConfigMap(ctx, clientset, namespaceConfigMaps, namespace)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return configMap, nil
+		}
+		return nil, err
+	}
+	merged, err := MergeInferenceServiceConfigMaps(configMap, namespaceConfigMap, overridesConfig.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if err := setNamespaceSubdomain(configMap, merged, namespace); err != nil {
+		return nil, fmt.Errorf("invalid %s config of the %s/%s ConfigMap: %w", IngressConfigKeyName, namespace,
+			constants.InferenceServiceConfigMapName, err)
+	}
+	return merged, nil
+}
+
+// MergeInferenceServiceConfigMaps returns a copy of the global ConfigMap with the keys of the namespace ConfigMap
+// merged on top of it. The JSON objects are merged field by field, so that a namespace only sets the fields it
+// overrides, e.g. {"image": "registry.team-a.example.com/storage-initializer:v0.16.0"} for the storage initializer.
+// A key.field entry of the keys only merges that field of the key.
+func MergeInferenceServiceConfigMaps(configMap *corev1.ConfigMap, namespaceConfigMap *corev1.ConfigMap, keys []string) (*corev1.ConfigMap, error) {
+	merged := configMap.DeepCopy()
+	if merged.Data == nil {
+		merged.Data = map[string]string{}
+	}
+	// the fields of the keys which may be overridden, nil when the whole key may be
+	overridableFields := map[string][]string{}
+	for _, key := range keys {
+		if key, field, ok := strings.Cut(key, "."); ok {
+			if fields, exists := overridableFields[key]; !exists || fields != nil {
+				overridableFields[key] = append(fields, field)
+			}
+			continue
+		}
+		overridableFields[key] = nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(overridableFields)) {
+		override, ok := namespaceConfigMap.Data[key]
+		if !ok {
+			continue
+		}
+		var overrideValue map[string]interface{}
+		if err := json.Unmarshal([]byte(override), &overrideValue); err != nil {
+			return nil, fmt.Errorf("invalid %s config of the %s/%s ConfigMap: %w", key, namespaceConfigMap.Namespace, namespaceConfigMap.Name, err)
+		}
+		if fields := overridableFields[key]; fields != nil {
+			maps.DeleteFunc(overrideValue, func(field string, _ interface{}) bool {
+				return !slices.Contains(fields, field)
+			})
+		}
+		baseValue := map[string]interface{}{}
+		if base, ok := merged.Data[key]; ok {
+			if err := json.Unmarshal([]byte(base), &baseValue); err != nil {
+				return nil, fmt.Errorf("invalid %s config of the %s/%s ConfigMap: %w", key, configMap.Namespace, configMap.Name, err)
+			}
+		}
+		value, err := json.Marshal(mergeJSONObjects(baseValue, overrideValue))
+		if err != nil {
+			return nil, err
+		}
+		merged.Data[key] = string(value)
+	}
+	return merged, nil
+}
+
+// setNamespaceSubdomain sets the namespace subdomain of the merged ingress config when the namespace overrides the
+// domain template or the ingress domain, so that the hosts of its InferenceServices are checked once rendered. The
+// subdomain is the one of the global ingress domain.
+func setNamespaceSubdomain(configMap *corev1.ConfigMap, merged *corev1.ConfigMap, namespace string) error {
+	globalIngress := &IngressConfig{}
+	if ingress, ok := configMap.Data[IngressConfigKeyName]; ok {
+		if err := json.Unmarshal([]byte(ingress), globalIngress); err != nil {
+			return err
+		}
+	}
+	mergedIngress := map[string]interface{}{}
+	if ingress, ok := merged.Data[IngressConfigKeyName]; ok {
+		if err := json.Unmarshal([]byte(ingress), &mergedIngress); err != nil {
+			return err
+		}
+	}
+	_, hasSubdomain := mergedIngress["namespaceSubdomain"]
+	delete(mergedIngress, "namespaceSubdomain")
+	domainTemplate, _ := mergedIngress["domainTemplate"].(string)
+	ingressDomain, _ := mergedIngress["ingressDomain"].(string)
+	overridden := domainTemplate != globalIngress.DomainTemplate || ingressDomain != globalIngress.IngressDomain
+	if !overridden && !hasSubdomain {
+		return nil
+	}
+	if overridden {
+		mergedIngress["namespaceSubdomain"] = namespace + "." + globalIngress.IngressDomain
+	}
+	value, err := json.Marshal(mergedIngress)
+	if err != nil {
+		return err
+	}
+	merged.Data[IngressConfigKeyName] = string(value)
+	return nil
+}
+
+// mergeJSONObjects merges the override into the base recursively, the other values of the override replace the ones
+// of the base
+func mergeJSONObjects(base map[string]interface{}, override map[string]interface{}) map[string]interface{} {
+	for field, value := range override {
+		overrideObject, isObject := value.(map[string]interface{})
+		baseObject, baseIsObject := base[field].(map[string]interface{})
+		if isObject && baseIsObject {
+			base[field] = mergeJSONObjects(baseObject, overrideObject)
+		} else {
+			base[field] = value
+		}
+	}
+	return base
+}
+
 func NewOtelCollectorConfig(isvcConfigMap *corev1.ConfigMap) (*OtelCollectorConfig, error) {
 	otelConfig := &OtelCollectorConfig{}
 	if otel, ok := isvcConfigMap.Data[OtelCollectorConfigName]; ok {
@@ -201,6 +825,15 @@ func NewOtelCollectorConfig(isvcConfigMap *corev1.ConfigMap) (*OtelCollectorConf
 	return otelConfig, nil
 }
 
+// ForwardEndpoint returns the OTLP endpoint the metrics scraped by the OpenTelemetry Collector sidecar of an
+// InferenceService are forwarded to, or an empty string when neither the sidecar nor the config sets one.
+func (c *OtelCollectorConfig) ForwardEndpoint(sidecar *OtelCollectorSidecarSpec) string {
+	if sidecar != nil && sidecar.Endpoint != "" {
+		return sidecar.Endpoint
+	}
+	return c.MetricForwardEndpoint
+}
+
 func NewAutoscalerConfig(isvcConfigMap *corev1.ConfigMap) (*AutoscalerConfig, error) {
 	autoscalerConfig := &AutoscalerConfig{}
 	if autoscaler, ok := isvcConfigMap.Data[AutoscalerConfigName]; ok {
@@ -265,6 +898,25 @@ func NewMultiNodeConfig(isvcConfigMap *corev1.ConfigMap) (*MultiNodeConfig, erro
 	return mncfg, nil
 }
 
+// WithNamespaceOverrides returns a copy of the ingress config with the gateways and the ingress class replaced by
+// the ones selected through the annotations of the namespace, so that the traffic of a tenant can be isolated on its own gateway.
+func (c *IngressConfig) WithNamespaceOverrides(annotations map[string]string) (*IngressConfig, error) {
+	overridden := *c
+	if gateway, ok := annotations[constants.IngressGatewayNamespaceAnnotationKey]; ok && gateway != "" {
+		overridden.IngressGateway = gateway
+	}
+	if gateway, ok := annotations[constants.KServeIngressGatewayNamespaceAnnotationKey]; ok && gateway != "" {
+		overridden.KserveIngressGateway = gateway
+		if err := validateIngressGateway(&overridden); err != nil {
+			return nil, fmt.Errorf("invalid %s namespace annotation: %w", constants.KServeIngressGatewayNamespaceAnnotationKey, err)
+		}
+	}
+	if className, ok := annotations[constants.IngressClassNameNamespaceAnnotationKey]; ok && className != "" {
+		overridden.IngressClassName = &className
+	}
+	return &overridden, nil
+}
+
 func validateIngressGateway(ingressConfig *IngressConfig) error {
 	if ingressConfig.KserveIngressGateway == "" {
 		return errors.New(ErrKserveIngressGatewayRequired)
@@ -388,6 +1040,534 @@ func NewLocalModelConfig(isvcConfigMap *corev1.ConfigMap) (*LocalModelConfig, er
 	return localModelConfig, nil
 }
 
+func NewCheckpointRestoreConfig(isvcConfigMap *corev1.ConfigMap) (*CheckpointRestoreConfig, error) {
+	checkpointRestoreConfig := &CheckpointRestoreConfig{}
+	if checkpointRestore, ok := isvcConfigMap.Data[CheckpointRestoreConfigName]; ok {
+		err := json.Unmarshal([]byte(checkpointRestore), &checkpointRestoreConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if checkpointRestoreConfig.Enabled && checkpointRestoreConfig.Repository == "" {
+		return nil, errors.New("checkpoint restore requires a repository for the checkpoint images")
+	}
+	return checkpointRestoreConfig, nil
+}
+
+func NewMIGPartitioningConfig(isvcConfigMap *corev1.ConfigMap) (*MIGPartitioningConfig, error) {
+	migPartitioningConfig := &MIGPartitioningConfig{}
+	if migPartitioning, ok := isvcConfigMap.Data[MIGPartitioningConfigName]; ok {
+		err := json.Unmarshal([]byte(migPartitioning), migPartitioningConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse MIG partitioning config json: %w", err)
+		}
+	}
+	if len(migPartitioningConfig.Products) == 0 {
+		migPartitioningConfig.Products = DefaultMIGProducts
+	}
+	for _, product := range migPartitioningConfig.Products {
+		if product == "" {
+			return nil, errors.New("invalid MIG partitioning config - products must not be empty")
+		}
+	}
+	if migPartitioningConfig.DefaultConfig == "" {
+		migPartitioningConfig.DefaultConfig = constants.NvidiaMigDisabledConfig
+	}
+	return migPartitioningConfig, nil
+}
+
+func NewConfigSnapshotConfig(isvcConfigMap *corev1.ConfigMap) (*ConfigSnapshotConfig, error) {
+	configSnapshotConfig := &ConfigSnapshotConfig{}
+	if configSnapshot, ok := isvcConfigMap.Data[ConfigSnapshotConfigName]; ok {
+		err := json.Unmarshal([]byte(configSnapshot), configSnapshotConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse config snapshot config json: %w", err)
+		}
+	}
+	if configSnapshotConfig.Retention < 0 {
+		return nil, errors.New("invalid config snapshot config - retention must not be negative")
+	}
+	if configSnapshotConfig.Retention == 0 {
+		configSnapshotConfig.Retention = DefaultConfigSnapshotRetention
+	}
+	return configSnapshotConfig, nil
+}
+
+func NewCrashDiagnosticsConfig(isvcConfigMap *corev1.ConfigMap) (*CrashDiagnosticsConfig, error) {
+	crashDiagnosticsConfig := &CrashDiagnosticsConfig{}
+	if crashDiagnostics, ok := isvcConfigMap.Data[CrashDiagnosticsConfigName]; ok {
+		err := json.Unmarshal([]byte(crashDiagnostics), crashDiagnosticsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse crash diagnostics config json: %w", err)
+		}
+	}
+	if crashDiagnosticsConfig.LogLines < 0 || crashDiagnosticsConfig.LogLines > MaxCrashLogLines {
+		return nil, fmt.Errorf("invalid crash diagnostics config - logLines must be between 0 and %d", MaxCrashLogLines)
+	}
+	if crashDiagnosticsConfig.LogLines == 0 {
+		crashDiagnosticsConfig.LogLines = DefaultCrashLogLines
+	}
+	return crashDiagnosticsConfig, nil
+}
+
+func NewSharedCacheConfig(isvcConfigMap *corev1.ConfigMap) (*SharedCacheConfig, error) {
+	sharedCacheConfig := &SharedCacheConfig{}
+	if sharedCache, ok := isvcConfigMap.Data[SharedCacheConfigName]; ok {
+		err := json.Unmarshal([]byte(sharedCache), sharedCacheConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse shared cache config json: %w", err)
+		}
+	}
+	for name, value := range map[string]string{
+		"cpuRequest":    sharedCacheConfig.CPURequest,
+		"cpuLimit":      sharedCacheConfig.CPULimit,
+		"memoryRequest": sharedCacheConfig.MemoryRequest,
+		"memoryLimit":   sharedCacheConfig.MemoryLimit,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("invalid shared cache config - %s %q: %w", name, value, err)
+		}
+	}
+	parseQuota := func(name string, value string) (int64, error) {
+		quota, err := resource.ParseQuantity(value)
+		if err != nil || quota.Value() <= 0 {
+			return 0, fmt.Errorf("invalid shared cache config - %s %q must be a positive quantity", name, value)
+		}
+		return quota.Value(), nil
+	}
+	if sharedCacheConfig.DefaultTenantQuota == "" {
+		sharedCacheConfig.DefaultTenantQuota = constants.DefaultSharedCacheTenantQuota
+	}
+	var err error
+	if sharedCacheConfig.DefaultTenantQuotaBytes, err = parseQuota("defaultTenantQuota", sharedCacheConfig.DefaultTenantQuota); err != nil {
+		return nil, err
+	}
+	if len(sharedCacheConfig.TenantQuotas) > 0 {
+		sharedCacheConfig.TenantQuotasBytes = make(map[string]int64, len(sharedCacheConfig.TenantQuotas))
+	}
+	for tenant, value := range sharedCacheConfig.TenantQuotas {
+		if sharedCacheConfig.TenantQuotasBytes[tenant], err = parseQuota("tenantQuotas."+tenant, value); err != nil {
+			return nil, err
+		}
+	}
+	if sharedCacheConfig.MaxTenants < 0 {
+		return nil, fmt.Errorf("invalid shared cache config - maxTenants %d must be positive", sharedCacheConfig.MaxTenants)
+	}
+	if sharedCacheConfig.MaxTenants == 0 {
+		sharedCacheConfig.MaxTenants = constants.DefaultSharedCacheMaxTenants
+	}
+	if sharedCacheConfig.Image == "" {
+		sharedCacheConfig.Image = constants.DefaultSharedCacheImage
+	}
+	return sharedCacheConfig, nil
+}
+
+func NewCostEstimationConfig(isvcConfigMap *corev1.ConfigMap) (*CostEstimationConfig, error) {
+	costEstimationConfig := &CostEstimationConfig{}
+	if costEstimation, ok := isvcConfigMap.Data[CostEstimationConfigName]; ok {
+		err := json.Unmarshal([]byte(costEstimation), &costEstimationConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for name, price := range costEstimationConfig.Prices {
+		if price < 0 {
+			return nil, fmt.Errorf("invalid cost estimation config - the price of %s must not be negative", name)
+		}
+	}
+	if costEstimationConfig.HoursPerMonth < 0 {
+		return nil, errors.New("invalid cost estimation config - hoursPerMonth must not be negative")
+	}
+	if costEstimationConfig.Currency == "" {
+		costEstimationConfig.Currency = DefaultCostCurrency
+	}
+	if costEstimationConfig.HoursPerMonth == 0 {
+		costEstimationConfig.HoursPerMonth = DefaultHoursPerMonth
+	}
+	return costEstimationConfig, nil
+}
+
+func NewCloudEventsConfig(isvcConfigMap *corev1.ConfigMap) (*CloudEventsConfig, error) {
+	cloudEventsConfig := &CloudEventsConfig{}
+	if cloudEvents, ok := isvcConfigMap.Data[CloudEventsConfigName]; ok {
+		err := json.Unmarshal([]byte(cloudEvents), &cloudEventsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cloudEventsConfig.Sink != "" {
+		sink, err := url.Parse(cloudEventsConfig.Sink)
+		if err != nil || (sink.Scheme != "http" && sink.Scheme != "https") || sink.Host == "" {
+			return nil, fmt.Errorf("invalid cloud events config - sink %q must be an http or https URL", cloudEventsConfig.Sink)
+		}
+	}
+	return cloudEventsConfig, nil
+}
+
+func NewKueueConfig(isvcConfigMap *corev1.ConfigMap) (*KueueConfig, error) {
+	kueueConfig := &KueueConfig{}
+	if kueue, ok := isvcConfigMap.Data[KueueConfigName]; ok {
+		err := json.Unmarshal([]byte(kueue), &kueueConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return kueueConfig, nil
+}
+
+func NewPodMonitorConfig(isvcConfigMap *corev1.ConfigMap) (*PodMonitorConfig, error) {
+	podMonitorConfig := &PodMonitorConfig{}
+	if podMonitor, ok := isvcConfigMap.Data[PodMonitorConfigName]; ok {
+		err := json.Unmarshal([]byte(podMonitor), &podMonitorConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if podMonitorConfig.Interval != "" {
+		if _, err := time.ParseDuration(podMonitorConfig.Interval); err != nil {
+			return nil, fmt.Errorf("invalid pod monitor config - interval %q: %w", podMonitorConfig.Interval, err)
+		}
+	}
+	if metricsAggregator, ok := isvcConfigMap.Data[MetricsAggregatorConfigName]; ok {
+		metricsAggregatorConfig := struct {
+			EnableMetricAggregation string `json:"enableMetricAggregation"`
+		}{}
+		if err := json.Unmarshal([]byte(metricsAggregator), &metricsAggregatorConfig); err != nil {
+			return nil, err
+		}
+		podMonitorConfig.DefaultMetricAggregation = metricsAggregatorConfig.EnableMetricAggregation == "true"
+	}
+	return podMonitorConfig, nil
+}
+
+func NewGrafanaDashboardsConfig(isvcConfigMap *corev1.ConfigMap) (*GrafanaDashboardsConfig, error) {
+	grafanaDashboardsConfig := &GrafanaDashboardsConfig{}
+	if grafanaDashboards, ok := isvcConfigMap.Data[GrafanaDashboardsConfigName]; ok {
+		err := json.Unmarshal([]byte(grafanaDashboards), &grafanaDashboardsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if grafanaDashboardsConfig.Label == "" {
+		grafanaDashboardsConfig.Label = constants.DefaultGrafanaDashboardLabel
+	}
+	if grafanaDashboardsConfig.LabelValue == "" {
+		grafanaDashboardsConfig.LabelValue = constants.DefaultGrafanaDashboardLabelValue
+	}
+	if errs := validation.IsQualifiedName(grafanaDashboardsConfig.Label); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid grafana dashboards config - label %q: %s", grafanaDashboardsConfig.Label, strings.Join(errs, ", "))
+	}
+	return grafanaDashboardsConfig, nil
+}
+
+func NewPrometheusConfig(isvcConfigMap *corev1.ConfigMap) (*PrometheusConfig, error) {
+	prometheusConfig := &PrometheusConfig{}
+	if prometheus, ok := isvcConfigMap.Data[PrometheusConfigName]; ok {
+		err := json.Unmarshal([]byte(prometheus), &prometheusConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if prometheusConfig.URL != "" {
+		if _, err := url.ParseRequestURI(prometheusConfig.URL); err != nil {
+			return nil, fmt.Errorf("invalid prometheus config - url %q: %w", prometheusConfig.URL, err)
+		}
+	}
+	return prometheusConfig, nil
+}
+
+func NewIdleTimeoutConfig(isvcConfigMap *corev1.ConfigMap) (*IdleTimeoutConfig, error) {
+	idleTimeoutConfig := &IdleTimeoutConfig{}
+	if idleTimeout, ok := isvcConfigMap.Data[IdleTimeoutConfigName]; ok {
+		err := json.Unmarshal([]byte(idleTimeout), &idleTimeoutConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if idleTimeoutConfig.Query == "" {
+		idleTimeoutConfig.Query = DefaultIdleRequestCountQuery
+	}
+	query, err := RenderIdleRequestCountQuery(idleTimeoutConfig.Query, IdleQueryParameters{
+		Namespace:        "namespace",
+		InferenceService: "inferenceservice",
+		Window:           "1h",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid idle timeout config - query template %q: %w", idleTimeoutConfig.Query, err)
+	}
+	if err := checkQueryDelimiters(query); err != nil {
+		return nil, fmt.Errorf("invalid idle timeout config - prometheus query %q: %w", idleTimeoutConfig.Query, err)
+	}
+	idleTimeoutConfig.CheckIntervalDuration = DefaultIdleCheckInterval
+	if idleTimeoutConfig.CheckInterval != "" {
+		interval, err := time.ParseDuration(idleTimeoutConfig.CheckInterval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid idle timeout config - checkInterval %q must be a positive duration", idleTimeoutConfig.CheckInterval)
+		}
+		idleTimeoutConfig.CheckIntervalDuration = interval
+	}
+	return idleTimeoutConfig, nil
+}
+
+func NewCanaryAnalysisConfig(isvcConfigMap *corev1.ConfigMap) (*CanaryAnalysisConfig, error) {
+	canaryAnalysisConfig := &CanaryAnalysisConfig{}
+	if canaryAnalysis, ok := isvcConfigMap.Data[CanaryAnalysisConfigName]; ok {
+		err := json.Unmarshal([]byte(canaryAnalysis), &canaryAnalysisConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if canaryAnalysisConfig.SuccessRateQuery == "" {
+		canaryAnalysisConfig.SuccessRateQuery = DefaultCanarySuccessRateQuery
+	}
+	if canaryAnalysisConfig.LatencyQuery == "" {
+		canaryAnalysisConfig.LatencyQuery = DefaultCanaryLatencyQuery
+	}
+	for _, query := range []string{canaryAnalysisConfig.SuccessRateQuery, canaryAnalysisConfig.LatencyQuery} {
+		rendered, err := RenderCanaryQuery(query, CanaryQueryParameters{
+			Namespace:        "namespace",
+			InferenceService: "inferenceservice",
+			Component:        string(PredictorComponent),
+			Revision:         "inferenceservice-predictor-00001",
+			Interval:         "1m",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid canary analysis config - query template %q: %w", query, err)
+		}
+		if err := checkQueryDelimiters(rendered); err != nil {
+			return nil, fmt.Errorf("invalid canary analysis config - prometheus query %q: %w", query, err)
+		}
+	}
+	return canaryAnalysisConfig, nil
+}
+
+func NewMonitoringConfig(isvcConfigMap *corev1.ConfigMap) (*MonitoringConfig, error) {
+	monitoringConfig := &MonitoringConfig{}
+	if monitoring, ok := isvcConfigMap.Data[MonitoringConfigName]; ok {
+		err := json.Unmarshal([]byte(monitoring), monitoringConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse monitoring config json: %w", err)
+		}
+	}
+	for name, value := range map[string]string{
+		"cpuRequest":    monitoringConfig.CPURequest,
+		"cpuLimit":      monitoringConfig.CPULimit,
+		"memoryRequest": monitoringConfig.MemoryRequest,
+		"memoryLimit":   monitoringConfig.MemoryLimit,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("invalid monitoring config - %s %q: %w", name, value, err)
+		}
+	}
+	if monitoringConfig.DriftBatchSize < 0 {
+		return nil, errors.New("invalid monitoring config - driftBatchSize must not be negative")
+	}
+	if monitoringConfig.DriftBatchSize == 0 {
+		monitoringConfig.DriftBatchSize = DefaultDriftBatchSize
+	}
+	if monitoringConfig.Image == "" {
+		monitoringConfig.Image = constants.DefaultDetectorImage
+	}
+	if monitoringConfig.DriftQuery == "" {
+		monitoringConfig.DriftQuery = DefaultDriftQuery
+	}
+	query, err := RenderDriftQuery(monitoringConfig.DriftQuery, DriftQueryParameters{
+		Namespace:        "namespace",
+		InferenceService: "inferenceservice",
+		Interval:         "1m",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid monitoring config - drift query template %q: %w", monitoringConfig.DriftQuery, err)
+	}
+	if err := checkQueryDelimiters(query); err != nil {
+		return nil, fmt.Errorf("invalid monitoring config - prometheus query %q: %w", monitoringConfig.DriftQuery, err)
+	}
+	monitoringConfig.CheckIntervalDuration = DefaultDriftCheckInterval
+	if monitoringConfig.CheckInterval != "" {
+		interval, err := time.ParseDuration(monitoringConfig.CheckInterval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid monitoring config - checkInterval %q must be a positive duration", monitoringConfig.CheckInterval)
+		}
+		monitoringConfig.CheckIntervalDuration = interval
+	}
+	return monitoringConfig, nil
+}
+
+func NewCapacityConfig(isvcConfigMap *corev1.ConfigMap) (*CapacityConfig, error) {
+	capacityConfig := &CapacityConfig{}
+	if capacity, ok := isvcConfigMap.Data[CapacityConfigName]; ok {
+		err := json.Unmarshal([]byte(capacity), &capacityConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(capacityConfig.SpotNodeSelector) == 0 {
+		capacityConfig.SpotNodeSelector = map[string]string{DefaultCapacityTypeNodeLabel: "spot"}
+	}
+	if len(capacityConfig.OnDemandNodeSelector) == 0 {
+		capacityConfig.OnDemandNodeSelector = map[string]string{DefaultCapacityTypeNodeLabel: "on-demand"}
+	}
+	return capacityConfig, nil
+}
+
+func NewPodMutatorConfig(isvcConfigMap *corev1.ConfigMap) (*PodMutatorConfig, error) {
+	podMutatorConfig := &PodMutatorConfig{}
+	if podMutator, ok := isvcConfigMap.Data[PodMutatorConfigName]; ok {
+		err := json.Unmarshal([]byte(podMutator), &podMutatorConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := metav1.LabelSelectorAsSelector(podMutatorConfig.NamespaceSelector); err != nil {
+		return nil, fmt.Errorf("invalid pod mutator config - namespaceSelector: %w", err)
+	}
+	return podMutatorConfig, nil
+}
+
+func NewServerTLSConfig(isvcConfigMap *corev1.ConfigMap) (*ServerTLSConfig, error) {
+	serverTLSConfig := &ServerTLSConfig{}
+	if serverTLS, ok := isvcConfigMap.Data[ServerTLSConfigName]; ok {
+		err := json.Unmarshal([]byte(serverTLS), serverTLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse server TLS config json: %w", err)
+		}
+	}
+	for name, value := range map[string]string{"duration": serverTLSConfig.Duration, "renewBefore": serverTLSConfig.RenewBefore} {
+		if value == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid server TLS config - %s %q must be a positive duration", name, value)
+		}
+	}
+	if serverTLSConfig.SpiffeHelperImage == "" {
+		serverTLSConfig.SpiffeHelperImage = constants.DefaultSpiffeHelperImage
+	}
+	if serverTLSConfig.SpiffeCSIDriver == "" {
+		serverTLSConfig.SpiffeCSIDriver = constants.DefaultSpiffeCSIDriver
+	}
+	if serverTLSConfig.SpireAgentSocket == "" {
+		serverTLSConfig.SpireAgentSocket = constants.DefaultSpireAgentSocket
+	}
+	return serverTLSConfig, nil
+}
+
+func NewProvenanceConfig(isvcConfigMap *corev1.ConfigMap) (*ProvenanceConfig, error) {
+	provenanceConfig := &ProvenanceConfig{}
+	if provenance, ok := isvcConfigMap.Data[ProvenanceConfigName]; ok {
+		err := json.Unmarshal([]byte(provenance), provenanceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse provenance config json: %w", err)
+		}
+	}
+	for _, kind := range provenanceConfig.RequiredAttestations {
+		if !slices.Contains(ProvenanceAttestationKinds, kind) {
+			return nil, fmt.Errorf("invalid provenance config - unknown attestation %q, must be one of %v", kind, ProvenanceAttestationKinds)
+		}
+	}
+	return provenanceConfig, nil
+}
+
+func NewActivatorConfig(isvcConfigMap *corev1.ConfigMap) (*ActivatorConfig, error) {
+	activatorConfig := &ActivatorConfig{}
+	if activator, ok := isvcConfigMap.Data[ActivatorConfigName]; ok {
+		err := json.Unmarshal([]byte(activator), activatorConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse activator config json: %w", err)
+		}
+	}
+	for name, value := range map[string]string{
+		"cpuRequest":    activatorConfig.CPURequest,
+		"cpuLimit":      activatorConfig.CPULimit,
+		"memoryRequest": activatorConfig.MemoryRequest,
+		"memoryLimit":   activatorConfig.MemoryLimit,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("invalid activator config - %s %q: %w", name, value, err)
+		}
+	}
+	durations := []struct {
+		name  string
+		value string
+		into  *time.Duration
+	}{
+		{"scaleToZeroGracePeriod", activatorConfig.ScaleToZeroGracePeriod, &activatorConfig.ScaleToZeroGracePeriodDuration},
+		{"requestTimeout", activatorConfig.RequestTimeout, &activatorConfig.RequestTimeoutDuration},
+	}
+	for _, d := range durations {
+		*d.into = DefaultActivatorDuration
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid activator config - %s %q must be a positive duration", d.name, d.value)
+		}
+		*d.into = duration
+	}
+	if activatorConfig.Image == "" {
+		activatorConfig.Image = constants.DefaultActivatorImage
+	}
+	if activatorConfig.KedaHTTPInterceptorService == "" {
+		activatorConfig.KedaHTTPInterceptorService = constants.DefaultKedaHTTPInterceptorService
+	}
+	return activatorConfig, nil
+}
+
+func NewExternalDNSConfig(isvcConfigMap *corev1.ConfigMap) (*ExternalDNSConfig, error) {
+	externalDNSConfig := &ExternalDNSConfig{}
+	if externalDNS, ok := isvcConfigMap.Data[ExternalDNSConfigName]; ok {
+		err := json.Unmarshal([]byte(externalDNS), externalDNSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse external DNS config json: %w", err)
+		}
+	}
+	if externalDNSConfig.Enabled && len(externalDNSConfig.Targets) == 0 {
+		return nil, errors.New("invalid external DNS config - targets are required")
+	}
+	recordType := externalDNSConfig.RecordType()
+	for _, target := range externalDNSConfig.Targets {
+		if (&ExternalDNSConfig{Targets: []string{target}}).RecordType() != recordType {
+			return nil, fmt.Errorf("invalid external DNS config - the targets %v mix IP addresses and hostnames or IP families", externalDNSConfig.Targets)
+		}
+	}
+	if externalDNSConfig.RecordTTL < 0 {
+		return nil, fmt.Errorf("invalid external DNS config - recordTTL %d must not be negative", externalDNSConfig.RecordTTL)
+	}
+	for _, zone := range append([]string{externalDNSConfig.Zone}, slices.Collect(maps.Values(externalDNSConfig.NamespaceZones))...) {
+		if zone == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(zone); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid external DNS config - zone %q: %s", zone, strings.Join(errs, ", "))
+		}
+	}
+	return externalDNSConfig, nil
+}
+
+func NewNamespaceOverridesConfig(isvcConfigMap *corev1.ConfigMap) (*NamespaceOverridesConfig, error) {
+	namespaceOverridesConfig := &NamespaceOverridesConfig{}
+	if namespaceOverrides, ok := isvcConfigMap.Data[NamespaceOverridesConfigName]; ok {
+		err := json.Unmarshal([]byte(namespaceOverrides), namespaceOverridesConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse namespace overrides config json: %w", err)
+		}
+	}
+	if len(namespaceOverridesConfig.Keys) == 0 {
+		namespaceOverridesConfig.Keys = DefaultNamespaceOverridableKeys
+	}
+	return namespaceOverridesConfig, nil
+}
+
 func NewSecurityConfig(isvcConfigMap *corev1.ConfigMap) (*SecurityConfig, error) {
 	securityConfig := &SecurityConfig{}
 	if security, ok := isvcConfigMap.Data[SecurityConfigName]; ok {
@@ -448,3 +1628,55 @@ func GetStorageInitializerConfigs(configMap *corev1.ConfigMap) (*types.StorageIn
 
 	return storageInitializerConfig, nil
 }
+
+// ValidateInferenceServiceConfigMap parses the configurations of the inferenceservice-config ConfigMap, returning the
+// errors of all the invalid ones
+func ValidateInferenceServiceConfigMap(configMap *corev1.ConfigMap) error {
+	errs := []error{
+		validateConfig(configMap, NewInferenceServicesConfig),
+		validateConfig(configMap, NewIngressConfig),
+		validateConfig(configMap, NewDeployConfig),
+		validateConfig(configMap, NewOtelCollectorConfig),
+		validateConfig(configMap, NewAutoscalerConfig),
+		validateConfig(configMap, NewLocalModelConfig),
+		validateConfig(configMap, NewCheckpointRestoreConfig),
+		validateConfig(configMap, NewCostEstimationConfig),
+		validateConfig(configMap, NewCloudEventsConfig),
+		validateConfig(configMap, NewKueueConfig),
+		validateConfig(configMap, NewPodMonitorConfig),
+		validateConfig(configMap, NewGrafanaDashboardsConfig),
+		validateConfig(configMap, NewPrometheusConfig),
+		validateConfig(configMap, NewIdleTimeoutConfig),
+		validateConfig(configMap, NewCanaryAnalysisConfig),
+		validateConfig(configMap, NewCapacityConfig),
+		validateConfig(configMap, NewPodMutatorConfig),
+		validateConfig(configMap, NewServerTLSConfig),
+		validateConfig(configMap, NewProvenanceConfig),
+		validateConfig(configMap, NewActivatorConfig),
+		validateConfig(configMap, NewExternalDNSConfig),
+		validateConfig(configMap, NewMIGPartitioningConfig),
+		validateConfig(configMap, NewConfigSnapshotConfig),
+		validateConfig(configMap, NewMonitoringConfig),
+		validateConfig(configMap, NewCrashDiagnosticsConfig),
+		validateConfig(configMap, NewSharedCacheConfig),
+		validateConfig(configMap, NewNamespaceOverridesConfig),
+		validateConfig(configMap, NewSecurityConfig),
+		validateConfig(configMap, NewServiceConfig),
+		// NewMultiNodeConfig updates the global GPU resource types, only the JSON is validated
+		getComponentConfig(MultiNodeConfigKeyName, configMap, &MultiNodeConfig{}),
+	}
+	// GetStorageInitializerConfigs panics on invalid JSON
+	if _, ok := configMap.Data[StorageInitializerConfigMapKeyName]; ok {
+		if err := getComponentConfig(StorageInitializerConfigMapKeyName, configMap, &types.StorageInitializerConfig{}); err != nil {
+			errs = append(errs, err)
+		} else {
+			errs = append(errs, validateConfig(configMap, GetStorageInitializerConfigs))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateConfig[T any](configMap *corev1.ConfigMap, newConfig func(*corev1.ConfigMap) (T, error)) error {
+	_, err := newConfig(configMap)
+	return err
+}
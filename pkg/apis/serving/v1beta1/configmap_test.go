@@ -19,11 +19,14 @@ package v1beta1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kserve/kserve/pkg/constants"
 )
@@ -337,6 +340,67 @@ func TestValidateIngressGateway(t *testing.T) {
 	}
 }
 
+func TestIngressConfigWithNamespaceOverrides(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	className := "istio"
+	ingressConfig := &IngressConfig{
+		KserveIngressGateway: KserveIngressGateway,
+		IngressGateway:       KnativeIngressGateway,
+		IngressClassName:     &className,
+		IngressDomain:        IngressDomain,
+	}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expected      *IngressConfig
+		expectedError string
+	}{
+		{
+			name:        "no annotations",
+			annotations: nil,
+			expected:    ingressConfig,
+		},
+		{
+			name: "gateways and ingress class overridden",
+			annotations: map[string]string{
+				constants.IngressGatewayNamespaceAnnotationKey:       "tenant-a/knative-ingress-gateway",
+				constants.KServeIngressGatewayNamespaceAnnotationKey: "tenant-a/kserve-ingress-gateway",
+				constants.IngressClassNameNamespaceAnnotationKey:     "nginx",
+			},
+			expected: &IngressConfig{
+				KserveIngressGateway: "tenant-a/kserve-ingress-gateway",
+				IngressGateway:       "tenant-a/knative-ingress-gateway",
+				IngressClassName:     ptr.To("nginx"),
+				IngressDomain:        IngressDomain,
+			},
+		},
+		{
+			name: "invalid kserve ingress gateway",
+			annotations: map[string]string{
+				constants.KServeIngressGatewayNamespaceAnnotationKey: "kserve-ingress-gateway",
+			},
+			expectedError: ErrInvalidKserveIngressGatewayFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overridden, err := ingressConfig.WithNamespaceOverrides(tt.annotations)
+			if tt.expectedError != "" {
+				g.Expect(err).Should(gomega.HaveOccurred())
+				g.Expect(err.Error()).Should(gomega.ContainSubstring(tt.expectedError))
+				return
+			}
+			g.Expect(err).ShouldNot(gomega.HaveOccurred())
+			g.Expect(overridden).Should(gomega.Equal(tt.expected))
+		})
+	}
+	// The cluster wide config must be left untouched
+	g.Expect(ingressConfig.KserveIngressGateway).Should(gomega.Equal(KserveIngressGateway))
+	g.Expect(*ingressConfig.IngressClassName).Should(gomega.Equal("istio"))
+}
+
 func TestNewOtelCollectorConfig(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -380,6 +444,15 @@ func TestNewOtelCollectorConfig(t *testing.T) {
 		g.Expect(err).Should(gomega.HaveOccurred())
 		g.Expect(cfg).To(gomega.BeNil())
 	})
+
+	t.Run("returns the forward endpoint of the sidecar or the config", func(t *testing.T) {
+		cfg := &OtelCollectorConfig{}
+		g.Expect(cfg.ForwardEndpoint(&OtelCollectorSidecarSpec{})).To(gomega.BeEmpty())
+		g.Expect(cfg.ForwardEndpoint(&OtelCollectorSidecarSpec{Endpoint: "collector:4317"})).To(gomega.Equal("collector:4317"))
+		cfg.MetricForwardEndpoint = "default-collector:4317"
+		g.Expect(cfg.ForwardEndpoint(&OtelCollectorSidecarSpec{})).To(gomega.Equal("default-collector:4317"))
+		g.Expect(cfg.ForwardEndpoint(nil)).To(gomega.Equal("default-collector:4317"))
+	})
 }
 
 func TestNewDeployConfig_WithValidConfig(t *testing.T) {
@@ -502,7 +575,9 @@ func TestNewLocalModelConfig(t *testing.T) {
 					"fsGroup": %d,
 					"jobTTLSecondsAfterFinished": %d,
 					"reconcilationFrequencyInSecs": %d,
-					"disableVolumeManagement": true
+					"disableVolumeManagement": true,
+					"peerDistribution": {"enabled": true, "port": 8090},
+					"cachedNodeAffinity": "required"
 				}`, fsGroup, jobTTL, reconFreq),
 			},
 		}
@@ -519,6 +594,8 @@ func TestNewLocalModelConfig(t *testing.T) {
 		g.Expect(cfg.ReconcilationFrequencyInSecs).ToNot(gomega.BeNil())
 		g.Expect(*cfg.ReconcilationFrequencyInSecs).To(gomega.Equal(reconFreq))
 		g.Expect(cfg.DisableVolumeManagement).To(gomega.BeTrue())
+		g.Expect(cfg.PeerDistribution).To(gomega.Equal(&LocalModelPeerDistributionConfig{Enabled: true, Port: 8090}))
+		g.Expect(cfg.CachedNodeAffinity).To(gomega.Equal(CachedNodeAffinityRequired))
 	})
 
 	t.Run("returns error on invalid localModel config json", func(t *testing.T) {
@@ -725,3 +802,595 @@ func TestNewIngressConfig_Validation(t *testing.T) {
 		g.Expect(cfg.UrlScheme).To(gomega.Equal("https"))
 	})
 }
+
+func TestNewCheckpointRestoreConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCheckpointRestoreConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg.Enabled).To(gomega.BeFalse())
+
+	cfg, err = NewCheckpointRestoreConfig(&corev1.ConfigMap{Data: map[string]string{
+		CheckpointRestoreConfigName: `{"enabled": true, "repository": "registry.example.com/kserve/checkpoints"}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CheckpointRestoreConfig{Enabled: true, Repository: "registry.example.com/kserve/checkpoints"}))
+
+	_, err = NewCheckpointRestoreConfig(&corev1.ConfigMap{Data: map[string]string{
+		CheckpointRestoreConfigName: `{"enabled": true}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewMIGPartitioningConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewMIGPartitioningConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&MIGPartitioningConfig{Products: DefaultMIGProducts, DefaultConfig: constants.NvidiaMigDisabledConfig}))
+
+	cfg, err = NewMIGPartitioningConfig(&corev1.ConfigMap{Data: map[string]string{
+		MIGPartitioningConfigName: `{"enabled": true, "products": ["H100"], "defaultConfig": "all-balanced"}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&MIGPartitioningConfig{Enabled: true, Products: []string{"H100"}, DefaultConfig: "all-balanced"}))
+
+	_, err = NewMIGPartitioningConfig(&corev1.ConfigMap{Data: map[string]string{
+		MIGPartitioningConfigName: `{"enabled": true, "products": [""]}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewConfigSnapshotConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewConfigSnapshotConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&ConfigSnapshotConfig{Retention: DefaultConfigSnapshotRetention}))
+
+	cfg, err = NewConfigSnapshotConfig(&corev1.ConfigMap{Data: map[string]string{
+		ConfigSnapshotConfigName: `{"enabled": true, "retention": 3}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&ConfigSnapshotConfig{Enabled: true, Retention: 3}))
+
+	_, err = NewConfigSnapshotConfig(&corev1.ConfigMap{Data: map[string]string{
+		ConfigSnapshotConfigName: `{"enabled": true, "retention": -1}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewCrashDiagnosticsConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCrashDiagnosticsConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CrashDiagnosticsConfig{LogLines: DefaultCrashLogLines}))
+
+	cfg, err = NewCrashDiagnosticsConfig(&corev1.ConfigMap{Data: map[string]string{
+		CrashDiagnosticsConfigName: `{"enabled": true, "logLines": 200}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CrashDiagnosticsConfig{Enabled: true, LogLines: 200}))
+
+	_, err = NewCrashDiagnosticsConfig(&corev1.ConfigMap{Data: map[string]string{
+		CrashDiagnosticsConfigName: `{"enabled": true, "logLines": 5000}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewSharedCacheConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewSharedCacheConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&SharedCacheConfig{
+		Image:                   constants.DefaultSharedCacheImage,
+		DefaultTenantQuota:      constants.DefaultSharedCacheTenantQuota,
+		DefaultTenantQuotaBytes: 64 << 20,
+		MaxTenants:              constants.DefaultSharedCacheMaxTenants,
+	}))
+
+	cfg, err = NewSharedCacheConfig(&corev1.ConfigMap{Data: map[string]string{
+		SharedCacheConfigName: `{"enabled": true, "memoryLimit": "2Gi", "defaultTenantQuota": "128Mi", "tenantQuotas": {"team-a": "1Gi"}, "maxTenants": 32}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&SharedCacheConfig{
+		Enabled:                 true,
+		Image:                   constants.DefaultSharedCacheImage,
+		MemoryLimit:             "2Gi",
+		DefaultTenantQuota:      "128Mi",
+		TenantQuotas:            map[string]string{"team-a": "1Gi"},
+		DefaultTenantQuotaBytes: 128 << 20,
+		TenantQuotasBytes:       map[string]int64{"team-a": 1 << 30},
+		MaxTenants:              32,
+	}))
+
+	for _, invalid := range []string{`{"cpuLimit": "one"}`, `{"defaultTenantQuota": "0"}`, `{"tenantQuotas": {"team-a": "lots"}}`, `{"maxTenants": -1}`} {
+		_, err = NewSharedCacheConfig(&corev1.ConfigMap{Data: map[string]string{SharedCacheConfigName: invalid}})
+		g.Expect(err).Should(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewCostEstimationConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCostEstimationConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CostEstimationConfig{Currency: DefaultCostCurrency, HoursPerMonth: DefaultHoursPerMonth}))
+
+	cfg, err = NewCostEstimationConfig(&corev1.ConfigMap{Data: map[string]string{
+		CostEstimationConfigName: `{"enabled": true, "currency": "EUR", "prices": {"cpu": 0.03, "nvidia.com/gpu": 2.5}, "warningThreshold": 1000}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CostEstimationConfig{
+		Enabled:          true,
+		Currency:         "EUR",
+		HoursPerMonth:    DefaultHoursPerMonth,
+		Prices:           map[corev1.ResourceName]float64{corev1.ResourceCPU: 0.03, "nvidia.com/gpu": 2.5},
+		WarningThreshold: 1000,
+	}))
+
+	_, err = NewCostEstimationConfig(&corev1.ConfigMap{Data: map[string]string{
+		CostEstimationConfigName: `{"enabled": true, "prices": {"cpu": -1}}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewCloudEventsConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCloudEventsConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CloudEventsConfig{}))
+
+	cfg, err = NewCloudEventsConfig(&corev1.ConfigMap{Data: map[string]string{
+		CloudEventsConfigName: `{"enabled": true, "sink": "http://broker-ingress.knative-eventing.svc.cluster.local/default/default"}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CloudEventsConfig{
+		Enabled: true,
+		Sink:    "http://broker-ingress.knative-eventing.svc.cluster.local/default/default",
+	}))
+
+	_, err = NewCloudEventsConfig(&corev1.ConfigMap{Data: map[string]string{
+		CloudEventsConfigName: `{"enabled": true, "sink": "broker.default.svc"}`,
+	}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewKueueConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewKueueConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&KueueConfig{}))
+
+	cfg, err = NewKueueConfig(&corev1.ConfigMap{Data: map[string]string{KueueConfigName: `{"enabled": true}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&KueueConfig{Enabled: true}))
+
+	_, err = NewKueueConfig(&corev1.ConfigMap{Data: map[string]string{KueueConfigName: `{"enabled": "yes"}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewPodMonitorConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewPodMonitorConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PodMonitorConfig{}))
+
+	cfg, err = NewPodMonitorConfig(&corev1.ConfigMap{Data: map[string]string{
+		PodMonitorConfigName:        `{"enabled": true, "interval": "30s", "labels": {"release": "prometheus"}}`,
+		MetricsAggregatorConfigName: `{"enableMetricAggregation": "true", "enablePrometheusScraping": "false"}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PodMonitorConfig{
+		Enabled:                  true,
+		Interval:                 "30s",
+		Labels:                   map[string]string{"release": "prometheus"},
+		DefaultMetricAggregation: true,
+	}))
+
+	_, err = NewPodMonitorConfig(&corev1.ConfigMap{Data: map[string]string{PodMonitorConfigName: `{"enabled": true, "interval": "often"}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewGrafanaDashboardsConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewGrafanaDashboardsConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&GrafanaDashboardsConfig{
+		Label:      constants.DefaultGrafanaDashboardLabel,
+		LabelValue: constants.DefaultGrafanaDashboardLabelValue,
+	}))
+
+	cfg, err = NewGrafanaDashboardsConfig(&corev1.ConfigMap{Data: map[string]string{
+		GrafanaDashboardsConfigName: `{"enabled": true, "label": "dashboards.example.com/grafana", "folderAnnotation": "grafana_folder"}`,
+	}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&GrafanaDashboardsConfig{
+		Enabled:          true,
+		Label:            "dashboards.example.com/grafana",
+		LabelValue:       constants.DefaultGrafanaDashboardLabelValue,
+		FolderAnnotation: "grafana_folder",
+	}))
+
+	_, err = NewGrafanaDashboardsConfig(&corev1.ConfigMap{Data: map[string]string{GrafanaDashboardsConfigName: `{"label": "grafana dashboard"}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewPrometheusConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewPrometheusConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PrometheusConfig{}))
+
+	cfg, err = NewPrometheusConfig(&corev1.ConfigMap{Data: map[string]string{PrometheusConfigName: `{"url": "http://prometheus.monitoring:9090"}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PrometheusConfig{URL: "http://prometheus.monitoring:9090"}))
+
+	_, err = NewPrometheusConfig(&corev1.ConfigMap{Data: map[string]string{PrometheusConfigName: `{"url": "prometheus"}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewIdleTimeoutConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewIdleTimeoutConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&IdleTimeoutConfig{
+		Query:                 DefaultIdleRequestCountQuery,
+		CheckIntervalDuration: DefaultIdleCheckInterval,
+	}))
+
+	cfg, err = NewIdleTimeoutConfig(&corev1.ConfigMap{Data: map[string]string{IdleTimeoutConfigName: `{
+		"query": "sum(increase(http_requests_total{namespace=\"{{ .Namespace }}\", service=~\"{{ .InferenceService }}-.*\"}[{{ .Window }}]))",
+		"checkInterval": "10m"
+	}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&IdleTimeoutConfig{
+		Query:                 `sum(increase(http_requests_total{namespace="{{ .Namespace }}", service=~"{{ .InferenceService }}-.*"}[{{ .Window }}]))`,
+		CheckInterval:         "10m",
+		CheckIntervalDuration: 10 * time.Minute,
+	}))
+
+	for _, invalid := range []string{
+		`{"query": "sum(increase(revision_request_count{revision=\"{{ .Revision }}\"}[1h]))"}`,
+		`{"query": "sum(increase(revision_request_count[{{ .Window }}])"}`,
+		`{"checkInterval": "-5m"}`,
+	} {
+		_, err = NewIdleTimeoutConfig(&corev1.ConfigMap{Data: map[string]string{IdleTimeoutConfigName: invalid}})
+		g.Expect(err).Should(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewMonitoringConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewMonitoringConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&MonitoringConfig{
+		Image:                 constants.DefaultDetectorImage,
+		DriftBatchSize:        DefaultDriftBatchSize,
+		DriftQuery:            DefaultDriftQuery,
+		CheckIntervalDuration: DefaultDriftCheckInterval,
+	}))
+
+	cfg, err = NewMonitoringConfig(&corev1.ConfigMap{Data: map[string]string{MonitoringConfigName: `{
+		"image": "registry.example.com/detectors/drift:v1",
+		"cpuRequest": "500m",
+		"memoryLimit": "2Gi",
+		"driftBatchSize": 200,
+		"driftQuery": "max(drift_p_value{namespace=\"{{ .Namespace }}\", inferenceservice=\"{{ .InferenceService }}\"} < bool 0.05)",
+		"checkInterval": "5m"
+	}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&MonitoringConfig{
+		Image:                 "registry.example.com/detectors/drift:v1",
+		CPURequest:            "500m",
+		MemoryLimit:           "2Gi",
+		DriftBatchSize:        200,
+		DriftQuery:            `max(drift_p_value{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"} < bool 0.05)`,
+		CheckInterval:         "5m",
+		CheckIntervalDuration: 5 * time.Minute,
+	}))
+
+	for _, invalid := range []string{
+		`{"cpuLimit": "one"}`,
+		`{"driftBatchSize": -1}`,
+		`{"driftQuery": "max(is_drift{revision=\"{{ .Revision }}\"})"}`,
+		`{"driftQuery": "max(is_drift[{{ .Interval }}]"}`,
+		`{"checkInterval": "0s"}`,
+	} {
+		_, err = NewMonitoringConfig(&corev1.ConfigMap{Data: map[string]string{MonitoringConfigName: invalid}})
+		g.Expect(err).Should(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewCanaryAnalysisConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCanaryAnalysisConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CanaryAnalysisConfig{
+		SuccessRateQuery: DefaultCanarySuccessRateQuery,
+		LatencyQuery:     DefaultCanaryLatencyQuery,
+	}))
+
+	cfg, err = NewCanaryAnalysisConfig(&corev1.ConfigMap{Data: map[string]string{CanaryAnalysisConfigName: `{
+		"latencyQuery": "histogram_quantile(0.95, sum by (le) (rate(request_duration_milliseconds_bucket{pod=~\"{{ .Revision }}-.*\"}[{{ .Interval }}])))"
+	}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CanaryAnalysisConfig{
+		SuccessRateQuery: DefaultCanarySuccessRateQuery,
+		LatencyQuery:     `histogram_quantile(0.95, sum by (le) (rate(request_duration_milliseconds_bucket{pod=~"{{ .Revision }}-.*"}[{{ .Interval }}])))`,
+	}))
+
+	for _, invalid := range []string{
+		`{"successRateQuery": "sum(rate(revision_request_count{revision=\"{{ .Window }}\"}[1m]))"}`,
+		`{"latencyQuery": "histogram_quantile(0.99, revision_request_latencies_bucket[{{ .Interval }}]"}`,
+	} {
+		_, err = NewCanaryAnalysisConfig(&corev1.ConfigMap{Data: map[string]string{CanaryAnalysisConfigName: invalid}})
+		g.Expect(err).Should(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewCapacityConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewCapacityConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CapacityConfig{
+		SpotNodeSelector:     map[string]string{DefaultCapacityTypeNodeLabel: "spot"},
+		OnDemandNodeSelector: map[string]string{DefaultCapacityTypeNodeLabel: "on-demand"},
+	}))
+
+	cfg, err = NewCapacityConfig(&corev1.ConfigMap{Data: map[string]string{CapacityConfigName: `{
+		"spotNodeSelector": {"cloud.google.com/gke-spot": "true"},
+		"spotTolerations": [{"key": "cloud.google.com/gke-spot", "operator": "Equal", "value": "true", "effect": "NoSchedule"}]
+	}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&CapacityConfig{
+		SpotNodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"},
+		SpotTolerations: []corev1.Toleration{{
+			Key:      "cloud.google.com/gke-spot",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "true",
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
+		OnDemandNodeSelector: map[string]string{DefaultCapacityTypeNodeLabel: "on-demand"},
+	}))
+
+	_, err = NewCapacityConfig(&corev1.ConfigMap{Data: map[string]string{CapacityConfigName: `{"spotNodeSelector": "spot"}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestNewPodMutatorConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cfg, err := NewPodMutatorConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PodMutatorConfig{}))
+
+	cfg, err = NewPodMutatorConfig(&corev1.ConfigMap{Data: map[string]string{PodMutatorConfigName: `{
+		"namespaceSelector": {"matchLabels": {"serving.kserve.io/enabled": "true"}},
+		"failOpen": true
+	}`}})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(cfg).To(gomega.Equal(&PodMutatorConfig{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"serving.kserve.io/enabled": "true"}},
+		FailOpen:          true,
+	}))
+
+	_, err = NewPodMutatorConfig(&corev1.ConfigMap{Data: map[string]string{PodMutatorConfigName: `{
+		"namespaceSelector": {"matchExpressions": [{"key": "team", "operator": "Unknown"}]}
+	}`}})
+	g.Expect(err).Should(gomega.HaveOccurred())
+}
+
+func TestGetInferenceServiceConfigMapForNamespace(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newConfigMap := func(namespace string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: namespace},
+			Data:       data,
+		}
+	}
+	globalData := map[string]string{
+		StorageInitializerConfigMapKeyName: `{"image": "kserve/storage-initializer:latest", "memoryRequest": "100Mi"}`,
+		IngressConfigKeyName:               `{"ingressDomain": "example.com", "domainTemplate": "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}"}`,
+		LoggerConfigName:                   `{"image": "kserve/agent:latest", "defaultUrl": "http://default-broker"}`,
+		SecurityConfigName:                 `{"autoMountServiceAccountToken": false}`,
+		NamespaceOverridesConfigName:       `{"enabled": true}`,
+	}
+	teamData := map[string]string{
+		StorageInitializerConfigMapKeyName: `{"image": "registry.team-a.example.com/storage-initializer:latest"}`,
+		IngressConfigKeyName:               `{"domainTemplate": "{{ .Name }}.team-a.{{ .IngressDomain }}", "ingressDomain": "bank.example"}`,
+		LoggerConfigName:                   `{"defaultUrl": "http://team-a-broker"}`,
+		SecurityConfigName:                 `{"autoMountServiceAccountToken": true}`,
+	}
+	clientset := fakeclientset.NewSimpleClientset(
+		newConfigMap(constants.KServeNamespace, globalData),
+		newConfigMap("team-a", teamData),
+		newConfigMap("team-b", map[string]string{LoggerConfigName: `{"defaultUrl":`}),
+		newConfigMap("team-d", map[string]string{IngressConfigKeyName: `{"domainTemplate": "{{ .Name }}.team-a.{{ .IngressDomain }}"}`}),
+	)
+
+	configMap, err := GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, nil, "team-a")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data[StorageInitializerConfigMapKeyName]).To(gomega.MatchJSON(
+		`{"image": "registry.team-a.example.com/storage-initializer:latest", "memoryRequest": "100Mi"}`))
+	g.Expect(configMap.Data[IngressConfigKeyName]).To(gomega.MatchJSON(
+		`{"ingressDomain": "example.com", "domainTemplate": "{{ .Name }}.team-a.{{ .IngressDomain }}", "namespaceSubdomain": "team-a.example.com"}`))
+	g.Expect(configMap.Data[LoggerConfigName]).To(gomega.MatchJSON(`{"image": "kserve/agent:latest", "defaultUrl": "http://team-a-broker"}`))
+	// the keys and the fields which are not overridable are ignored
+	g.Expect(configMap.Data[SecurityConfigName]).To(gomega.Equal(globalData[SecurityConfigName]))
+
+	// the hosts rendered by a domain template overridden by a namespace must stay in its subdomain
+	configMap, err = GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, nil, "team-d")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data[IngressConfigKeyName]).To(gomega.MatchJSON(
+		`{"ingressDomain": "example.com", "domainTemplate": "{{ .Name }}.team-a.{{ .IngressDomain }}", "namespaceSubdomain": "team-d.example.com"}`))
+
+	// the namespaces without ConfigMap use the global one
+	configMap, err = GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, nil, "team-c")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data).To(gomega.Equal(globalData))
+
+	_, err = GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, nil, "team-b")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid logger config of the team-b/inferenceservice-config ConfigMap")))
+
+	// the namespace ConfigMaps are read from the reader when it is set
+	reader := fake.NewClientBuilder().WithObjects(
+		newConfigMap("team-a", map[string]string{LoggerConfigName: `{"defaultUrl": "http://cached-broker"}`})).Build()
+	configMap, err = GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, reader, "team-a")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data[LoggerConfigName]).To(gomega.MatchJSON(`{"image": "kserve/agent:latest", "defaultUrl": "http://cached-broker"}`))
+	g.Expect(configMap.Data[IngressConfigKeyName]).To(gomega.Equal(globalData[IngressConfigKeyName]))
+
+	// the namespace ConfigMaps are ignored unless the overrides are enabled
+	globalData[NamespaceOverridesConfigName] = `{"enabled": false}`
+	clientset = fakeclientset.NewSimpleClientset(newConfigMap(constants.KServeNamespace, globalData), newConfigMap("team-a", teamData))
+	configMap, err = GetInferenceServiceConfigMapForNamespace(t.Context(), clientset, nil, "team-a")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data).To(gomega.Equal(globalData))
+}
+
+func TestNewNamespaceOverridesConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := NewNamespaceOverridesConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&NamespaceOverridesConfig{Keys: DefaultNamespaceOverridableKeys}))
+
+	config, err = NewNamespaceOverridesConfig(&corev1.ConfigMap{Data: map[string]string{
+		NamespaceOverridesConfigName: `{"enabled": true, "keys": ["batcher"]}`,
+	}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&NamespaceOverridesConfig{Enabled: true, Keys: []string{"batcher"}}))
+
+	_, err = NewNamespaceOverridesConfig(&corev1.ConfigMap{Data: map[string]string{NamespaceOverridesConfigName: `{"enabled": 1}`}})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := NewServerTLSConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ServerTLSConfig{
+		SpiffeHelperImage: constants.DefaultSpiffeHelperImage,
+		SpiffeCSIDriver:   constants.DefaultSpiffeCSIDriver,
+		SpireAgentSocket:  constants.DefaultSpireAgentSocket,
+	}))
+
+	config, err = NewServerTLSConfig(&corev1.ConfigMap{Data: map[string]string{
+		ServerTLSConfigName: `{"issuerRef": {"name": "kserve-ca", "kind": "ClusterIssuer"}, "duration": "2160h", "renewBefore": "360h"}`,
+	}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config.IssuerRef).To(gomega.Equal(&CertificateIssuerReference{Name: "kserve-ca", Kind: "ClusterIssuer"}))
+	g.Expect(config.Duration).To(gomega.Equal("2160h"))
+	g.Expect(config.RenewBefore).To(gomega.Equal("360h"))
+
+	for _, invalid := range []string{`{"duration": "90d"}`, `{"renewBefore": "-1h"}`, `{"issuerRef": "kserve-ca"}`} {
+		_, err = NewServerTLSConfig(&corev1.ConfigMap{Data: map[string]string{ServerTLSConfigName: invalid}})
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewProvenanceConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := NewProvenanceConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ProvenanceConfig{}))
+
+	config, err = NewProvenanceConfig(&corev1.ConfigMap{Data: map[string]string{
+		ProvenanceConfigName: `{"enabled": true, "requiredAttestations": ["sbom", "sig"], "enforce": true}`,
+	}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ProvenanceConfig{Enabled: true, RequiredAttestations: []string{"sbom", "sig"}, Enforce: true}))
+
+	for _, invalid := range []string{`{"requiredAttestations": ["slsa"]}`, `{"requiredAttestations": "sbom"}`} {
+		_, err = NewProvenanceConfig(&corev1.ConfigMap{Data: map[string]string{ProvenanceConfigName: invalid}})
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewActivatorConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := NewActivatorConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ActivatorConfig{
+		Image:                          constants.DefaultActivatorImage,
+		KedaHTTPInterceptorService:     constants.DefaultKedaHTTPInterceptorService,
+		ScaleToZeroGracePeriodDuration: DefaultActivatorDuration,
+		RequestTimeoutDuration:         DefaultActivatorDuration,
+	}))
+
+	config, err = NewActivatorConfig(&corev1.ConfigMap{Data: map[string]string{
+		ActivatorConfigName: `{"image": "kserve/activator:v0.16.0", "cpuRequest": "100m", "memoryLimit": "256Mi", "scaleToZeroGracePeriod": "10m", "requestTimeout": "2m"}`,
+	}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ActivatorConfig{
+		Image:                          "kserve/activator:v0.16.0",
+		CPURequest:                     "100m",
+		MemoryLimit:                    "256Mi",
+		ScaleToZeroGracePeriod:         "10m",
+		RequestTimeout:                 "2m",
+		KedaHTTPInterceptorService:     constants.DefaultKedaHTTPInterceptorService,
+		ScaleToZeroGracePeriodDuration: 10 * time.Minute,
+		RequestTimeoutDuration:         2 * time.Minute,
+	}))
+
+	for _, invalid := range []string{`{"cpuLimit": "one"}`, `{"requestTimeout": "0s"}`, `{"scaleToZeroGracePeriod": "soon"}`} {
+		_, err = NewActivatorConfig(&corev1.ConfigMap{Data: map[string]string{ActivatorConfigName: invalid}})
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestNewExternalDNSConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := NewExternalDNSConfig(&corev1.ConfigMap{Data: map[string]string{}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(&ExternalDNSConfig{}))
+
+	config, err = NewExternalDNSConfig(&corev1.ConfigMap{Data: map[string]string{
+		ExternalDNSConfigName: `{"enabled": true, "targets": ["lb.example.com"], "recordTTL": 300, "zone": "models.example.com", "namespaceZones": {"team-a": "team-a.example.com"}}`,
+	}})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(config.RecordType()).To(gomega.Equal("CNAME"))
+	g.Expect(config.ZoneFor("default")).To(gomega.Equal("models.example.com"))
+	g.Expect(config.ZoneFor("team-a")).To(gomega.Equal("team-a.example.com"))
+	g.Expect((&ExternalDNSConfig{Targets: []string{"10.0.0.1", "10.0.0.2"}}).RecordType()).To(gomega.Equal("A"))
+	g.Expect((&ExternalDNSConfig{Targets: []string{"2001:db8::1"}}).RecordType()).To(gomega.Equal("AAAA"))
+
+	for _, invalid := range []string{
+		`{"enabled": true}`,
+		`{"enabled": true, "targets": ["10.0.0.1", "lb.example.com"]}`,
+		`{"enabled": true, "targets": ["10.0.0.1"], "recordTTL": -1}`,
+		`{"enabled": true, "targets": ["10.0.0.1"], "namespaceZones": {"team-a": "Team_A"}}`,
+	} {
+		_, err = NewExternalDNSConfig(&corev1.ConfigMap{Data: map[string]string{ExternalDNSConfigName: invalid}})
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestValidateInferenceServiceConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(ValidateInferenceServiceConfigMap(&corev1.ConfigMap{Data: map[string]string{
+		DeployConfigName:     `{"defaultDeploymentMode": "Standard"}`,
+		IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway"}`,
+	}})).To(gomega.Succeed())
+
+	// The errors of all the invalid configurations are returned
+	err := ValidateInferenceServiceConfigMap(&corev1.ConfigMap{Data: map[string]string{
+		DeployConfigName:                   `{"defaultDeploymentMode": "Serverful"}`,
+		IngressConfigKeyName:               `{}`,
+		StorageInitializerConfigMapKeyName: `{"memoryRequest": `,
+	}})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid deployment mode")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("ingressGateway is required")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(StorageInitializerConfigMapKeyName)))
+}
@@ -40,6 +40,19 @@ type ExplainerSpec struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MinItems=1
 	StorageUris []StorageUri `json:"storageUris,omitempty"`
+	// Sampling sends a sample of the prediction requests to the explainer, so that explanations are produced and
+	// logged without the clients calling the explain endpoint.
+	// +optional
+	Sampling *ExplainerSamplingSpec `json:"sampling,omitempty"`
+}
+
+// ExplainerSamplingSpec defines the share of the prediction requests explained asynchronously
+type ExplainerSamplingSpec struct {
+	// Percentage of the successful prediction requests sent to the explainer. The explanations are logged to the
+	// logger of the component receiving the prediction requests when it has one, or to the logs of its agent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent int32 `json:"percent"`
 }
 
 // ExplainerExtensionSpec defines configuration shared across all explainer frameworks
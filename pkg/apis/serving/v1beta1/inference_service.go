@@ -17,7 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 // InferenceServiceSpec is the top level type for this resource
@@ -33,6 +38,284 @@ type InferenceServiceSpec struct {
 	// transformer service calls to predictor service.
 	// +optional
 	Transformer *TransformerSpec `json:"transformer,omitempty"`
+	// Failover defines the InferenceService that receives the traffic while this InferenceService is not ready.
+	// +optional
+	Failover *FailoverSpec `json:"failover,omitempty"`
+	// ABTest splits the inference traffic of the InferenceService across named model variants by weight, each served
+	// by this InferenceService or another InferenceService in the same namespace. Only applicable with the Istio
+	// virtual service or the Gateway API HTTPRoute, the failover target takes precedence while it is active.
+	// +optional
+	ABTest *ABTestSpec `json:"abTest,omitempty"`
+	// Observability defines the telemetry collected for the InferenceService.
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+	// ReadinessGates defines functional checks performed by the controller before the InferenceService is marked ready.
+	// +optional
+	ReadinessGates *ReadinessGatesSpec `json:"readinessGates,omitempty"`
+	// DependsOn references the resources in the namespace of the InferenceService which must be ready before the pods
+	// of its components are created. The InferenceService is blocked, instead of its pods failing to start, until then.
+	// +optional
+	// +listType=atomic
+	DependsOn []DependencyReference `json:"dependsOn,omitempty"`
+	// TemplateRef references the ClusterInferenceTemplate the predictor is defaulted with. The fields set by the
+	// InferenceService take precedence over the ones of the template.
+	// +optional
+	TemplateRef *InferenceTemplateReference `json:"templateRef,omitempty"`
+	// IdleTimeout cleans up the InferenceService once it receives no inference requests for the configured duration,
+	// e.g. for the InferenceServices of development or experiments. The requests are counted from the route metrics
+	// queried from the Prometheus instance of the idleTimeout config, only in the Knative deployment mode.
+	// +optional
+	IdleTimeout *IdleTimeoutSpec `json:"idleTimeout,omitempty"`
+	// Transport defines how the requests are transported to the components of the InferenceService.
+	// +optional
+	Transport *TransportSpec `json:"transport,omitempty"`
+}
+
+// TransportSpec defines the transport of the requests to the components of an InferenceService
+type TransportSpec struct {
+	// ServerTLS serves the predictor over HTTPS with a serving certificate provisioned by the controller, only in
+	// Standard deployment mode.
+	// +optional
+	ServerTLS *ServerTLSSpec `json:"serverTLS,omitempty"`
+}
+
+// CertificateProvider provisions the serving certificates
+// +kubebuilder:validation:Enum=CertManager;SPIRE
+type CertificateProvider string
+
+const (
+	// CertManagerCertificateProvider issues the serving certificate with a cert-manager Certificate
+	CertManagerCertificateProvider CertificateProvider = "CertManager"
+	// SPIRECertificateProvider fetches the X.509 SVID of the pods from the SPIRE agent with the spiffe-helper
+	SPIRECertificateProvider CertificateProvider = "SPIRE"
+)
+
+// CertificateRotationPolicy defines how a renewed serving certificate is picked up by the runtime
+// +kubebuilder:validation:Enum=Reload;Restart
+type CertificateRotationPolicy string
+
+const (
+	// ReloadCertificateRotation updates the mounted certificate files in place, for the runtimes reloading them
+	ReloadCertificateRotation CertificateRotationPolicy = "Reload"
+	// RestartCertificateRotation rolls the predictor pods once the certificate is renewed
+	RestartCertificateRotation CertificateRotationPolicy = "Restart"
+)
+
+// ServerTLSSpec defines the serving certificate of the predictor. The certificate, the key and the CA bundle are
+// mounted in the predictor container, at the paths of the KSERVE_TLS_CERT_FILE, KSERVE_TLS_KEY_FILE and
+// KSERVE_TLS_CA_FILE environment variables, and the probes and the service of the predictor use HTTPS.
+type ServerTLSSpec struct {
+	// Provider of the serving certificate
+	Provider CertificateProvider `json:"provider"`
+	// IssuerRef references the cert-manager issuer of the certificate, defaults to the issuer of the serverTLS config.
+	// +optional
+	IssuerRef *CertificateIssuerReference `json:"issuerRef,omitempty"`
+	// Rotation defines how the renewed certificates are picked up, the SPIRE certificates are always reloaded.
+	// Defaults to Reload.
+	// +kubebuilder:default=Reload
+	// +optional
+	Rotation CertificateRotationPolicy `json:"rotation,omitempty"`
+}
+
+// CertificateIssuerReference references a cert-manager Issuer or ClusterIssuer
+type CertificateIssuerReference struct {
+	// Name of the issuer
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer or ClusterIssuer. Defaults to Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. Defaults to cert-manager.io.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// IdlePolicy is the action applied to an idle InferenceService
+// +kubebuilder:validation:Enum=Stop;Delete
+type IdlePolicy string
+
+const (
+	// StopIdlePolicy scales the InferenceService to zero with the serving.kserve.io/stop annotation, the
+	// InferenceService is resumed by removing the annotation
+	StopIdlePolicy IdlePolicy = "Stop"
+	// DeleteIdlePolicy deletes the InferenceService
+	DeleteIdlePolicy IdlePolicy = "Delete"
+)
+
+// IdleTimeoutSpec defines when an InferenceService is idle and how it is cleaned up
+type IdleTimeoutSpec struct {
+	// Duration without inference requests after which the InferenceService is idle, e.g. 24h. The InferenceService
+	// is idle at the earliest once it has been ready for the duration.
+	Duration metav1.Duration `json:"duration"`
+	// Policy applied to the idle InferenceService. Defaults to Stop.
+	// +kubebuilder:default=Stop
+	// +optional
+	Policy IdlePolicy `json:"policy,omitempty"`
+}
+
+// DependencyKind is the kind of a resource an InferenceService depends on
+// +kubebuilder:validation:Enum=Secret;ConfigMap;PersistentVolumeClaim;InferenceService
+type DependencyKind string
+
+const (
+	SecretDependency                DependencyKind = "Secret"
+	ConfigMapDependency             DependencyKind = "ConfigMap"
+	PersistentVolumeClaimDependency DependencyKind = "PersistentVolumeClaim"
+	InferenceServiceDependency      DependencyKind = "InferenceService"
+)
+
+// DependencyReference references a resource in the namespace of the InferenceService. Secrets and ConfigMaps are
+// ready once they exist, PersistentVolumeClaims once they are bound and InferenceServices once they are ready.
+type DependencyReference struct {
+	// Kind of the resource
+	Kind DependencyKind `json:"kind"`
+	// Name of the resource
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// FailoverSpec defines the backup InferenceService for an InferenceService
+type FailoverSpec struct {
+	// TargetRef references the backup InferenceService, e.g. a CPU based or smaller model.
+	// Traffic is shifted to the target when this InferenceService is not ready and the target is ready.
+	TargetRef FailoverTargetReference `json:"targetRef"`
+}
+
+// FailoverTargetReference references an InferenceService in the same namespace
+type FailoverTargetReference struct {
+	// Name of the InferenceService
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ABTestSpec defines the model variants the inference traffic of an InferenceService is split across
+type ABTestSpec struct {
+	// Variants of the model, whose weights must add up to 100
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=5
+	// +listType=map
+	// +listMapKey=name
+	Variants []ModelVariant `json:"variants"`
+	// SessionAffinity pins the sessions to the variant they were first routed to. The responses carry the name of
+	// the variant in the session affinity header or cookie, and the requests sending it back are routed to the same
+	// variant regardless of the weights.
+	// +optional
+	SessionAffinity *SessionAffinitySpec `json:"sessionAffinity,omitempty"`
+}
+
+// ModelVariant is a named model variant of an A/B test
+type ModelVariant struct {
+	// Name of the variant, reported to the clients with the session affinity
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// TargetRef references the InferenceService serving the variant. The variant is served by the components of
+	// this InferenceService when not set.
+	// +optional
+	TargetRef *ModelVariantTargetReference `json:"targetRef,omitempty"`
+	// Weight is the percentage of the traffic routed to the variant
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
+
+// ModelVariantTargetReference references an InferenceService in the same namespace
+type ModelVariantTargetReference struct {
+	// Name of the InferenceService
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// SessionAffinityType is how the clients send back the variant of their session
+// +kubebuilder:validation:Enum=Header;Cookie
+type SessionAffinityType string
+
+const (
+	// HeaderSessionAffinity sets the variant in a response header the clients send back as a request header
+	HeaderSessionAffinity SessionAffinityType = "Header"
+	// CookieSessionAffinity sets the variant in a cookie
+	CookieSessionAffinity SessionAffinityType = "Cookie"
+)
+
+// SessionAffinitySpec defines the key carrying the variant of the sessions of an A/B test
+type SessionAffinitySpec struct {
+	// Type of the session affinity key
+	Type SessionAffinityType `json:"type"`
+	// Name of the header or the cookie, x-kserve-variant for the header and kserve-variant for the cookie by default
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// GetName returns the name of the header or the cookie carrying the variant of the sessions
+func (s *SessionAffinitySpec) GetName() string {
+	switch {
+	case s.Name != "" && s.Type == HeaderSessionAffinity:
+		// the header names are matched in lower case
+		return strings.ToLower(s.Name)
+	case s.Name != "":
+		return s.Name
+	case s.Type == HeaderSessionAffinity:
+		return constants.DefaultVariantHeaderName
+	default:
+		return constants.DefaultVariantCookieName
+	}
+}
+
+// ObservabilitySpec defines the telemetry collection of an InferenceService
+type ObservabilitySpec struct {
+	// OtelCollector provisions an OpenTelemetry Collector sidecar for the predictor, which scrapes the metrics of
+	// the model server and forwards them to a cluster endpoint. It requires the OpenTelemetry operator and is
+	// only supported with the Standard deployment mode.
+	// +optional
+	OtelCollector *OtelCollectorSidecarSpec `json:"otelCollector,omitempty"`
+}
+
+// OtelCollectorSidecarSpec defines the OpenTelemetry Collector sidecar provisioned for an InferenceService
+type OtelCollectorSidecarSpec struct {
+	// Endpoint is the OTLP endpoint the scraped metrics are forwarded to.
+	// Defaults to the metricForwardEndpoint of the opentelemetryCollector config.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// ScrapeInterval is the interval at which the metrics of the model server are scraped.
+	// Defaults to the scrapeInterval of the opentelemetryCollector config.
+	// +optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// MetricNames restricts the forwarded metrics to the given names, all metrics are forwarded when empty.
+	// +optional
+	// +listType=atomic
+	MetricNames []string `json:"metricNames,omitempty"`
+}
+
+// ReadinessGatesSpec defines the checks gating the readiness of an InferenceService
+type ReadinessGatesSpec struct {
+	// InferenceProbe sends a sample inference request to the pods of each predictor revision once they are ready.
+	// The predictor is not marked ready, and does not receive traffic through the ingress, until a first revision
+	// passed. In the Knative deployment mode the traffic stays on the last rolled out revision until the latest passed.
+	// +optional
+	InferenceProbe *InferenceProbeSpec `json:"inferenceProbe,omitempty"`
+}
+
+// InferenceProbeSpec defines the sample inference request used to check that a predictor can serve predictions
+type InferenceProbeSpec struct {
+	// Path of the inference endpoint, e.g. /v1/models/my-model:predict
+	// +kubebuilder:validation:Pattern="^/.*"
+	Path string `json:"path"`
+	// Payload is the JSON body of the sample inference request.
+	// +kubebuilder:validation:MinLength=1
+	Payload string `json:"payload"`
+	// ExpectedStatusCode is the HTTP status code of a successful inference response. Defaults to 200.
+	// +optional
+	ExpectedStatusCode *int32 `json:"expectedStatusCode,omitempty"`
+	// TimeoutSeconds is the timeout of the sample inference request. Defaults to 10 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failed requests after which a revision fails the probe.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
 }
 
 // StorageSpec defines a spec for an object in an object store
@@ -67,9 +350,64 @@ const (
 	LogResponse LoggerType = "response"
 )
 
+// LoggerKafkaSpec specifies the Kafka topic the payload logs are produced to
+type LoggerKafkaSpec struct {
+	// Bootstrap brokers of the Kafka cluster, as host:port.
+	// +kubebuilder:validation:MinItems=1
+	Brokers []string `json:"brokers"`
+	// Topic the payload logs are produced to, one record per request and per response keyed by the request id.
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+	// Reference to a secret in the namespace of the InferenceService with the security settings of the brokers, the
+	// keys of the Knative KafkaSink secrets: "protocol" (PLAINTEXT, SASL_PLAINTEXT, SSL or SASL_SSL),
+	// "sasl.mechanism" (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), "user", "password", "ca.crt", "user.crt" and
+	// "user.key". PLAINTEXT when not set.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// LoggerSamplingSpec specifies the share of the requests logged
+type LoggerSamplingSpec struct {
+	// Percentage of the requests logged along with their responses, sampled at random. Defaults to 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+	// Percentage of the requests answered with an error status code, 4xx or 5xx, logged along with their responses,
+	// e.g. 100 to log all the errors while sampling the successful requests. Defaults to the percent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ErrorPercent *int32 `json:"errorPercent,omitempty"`
+}
+
+// LoggerHeaderFilter selects the requests logged by one of their headers
+type LoggerHeaderFilter struct {
+	// Name of the request header, e.g. one of the metadata headers.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Values the header must have one of, the header only needs to be present when empty.
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// LoggerEncryptionSpec specifies the key encrypting the payloads of the logs. The payloads are envelope encrypted: each
+// one is encrypted with an AES-256-GCM data key, sent along with the payload once encrypted with this key, so the logs
+// can only be decrypted by the holders of the KMS key or of the private key.
+type LoggerEncryptionSpec struct {
+	// ARN, id or alias of the AWS KMS key generating the data keys. The agent calls KMS with the AWS credentials of the
+	// service account of the component.
+	// +optional
+	KMSKeyID *string `json:"kmsKeyId,omitempty"`
+	// Reference to a key of a secret in the namespace of the InferenceService holding the PEM encoded RSA public key the
+	// data keys are encrypted with, using RSA-OAEP with SHA-256.
+	// +optional
+	PublicKeySecretRef *corev1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+}
+
 // LoggerSpec specifies optional payload logging available for all components
 type LoggerSpec struct {
-	// URL to send logging events
+	// URL to send logging events, ignored when the kafka sink is set
 	// +optional
 	URL *string `json:"url,omitempty"`
 	// Specifies the scope of the loggers. <br />
@@ -88,6 +426,28 @@ type LoggerSpec struct {
 	// Specifies the storage location for the inference logger cloud events.
 	// +optional
 	Storage *LoggerStorageSpec `json:"storage,omitempty"`
+	// Produces the inference logger cloud events to a Kafka topic instead of sending them to the URL.
+	// +optional
+	Kafka *LoggerKafkaSpec `json:"kafka,omitempty"`
+	// Samples the requests logged, all the requests are logged when not set.
+	// +optional
+	Sampling *LoggerSamplingSpec `json:"sampling,omitempty"`
+	// Filters on the request headers, the requests are only logged when they match all the filters.
+	// +optional
+	HeaderFilters []LoggerHeaderFilter `json:"headerFilters,omitempty"`
+	// Status codes of the responses whose requests are logged, e.g. "200", "4xx" or "5xx". When the status codes or the
+	// error percent of the sampling are set, the responses of every status code are logged, otherwise only the
+	// successful responses are.
+	// +optional
+	StatusCodes []string `json:"statusCodes,omitempty"`
+	// JSON fields of the request and response payloads redacted before the logs are emitted, as dot separated paths
+	// from the root of the payloads traversing the arrays, e.g. "instances.ssn".
+	// +optional
+	RedactFields []string `json:"redactFields,omitempty"`
+	// Encrypts the payloads of the logs before they are sent to the sink, exactly one of the KMS key and the public key
+	// must be set.
+	// +optional
+	Encryption *LoggerEncryptionSpec `json:"encryption,omitempty"`
 }
 
 // MetricsBackend enum
@@ -118,6 +478,22 @@ type Batcher struct {
 	// Specifies the timeout of a batch
 	// +optional
 	Timeout *int `json:"timeout,omitempty"`
+	// Adaptive adjusts the max batch size and the max latency of the batches to the latency observed by the batcher
+	// to meet a latency SLO. MaxBatchSize and MaxLatency are then the upper bounds of the adjusted values.
+	// +optional
+	Adaptive *AdaptiveBatcher `json:"adaptive,omitempty"`
+}
+
+// AdaptiveBatcher specifies the latency SLO the batches are adjusted to. The batcher grows the batches while the p95
+// latency of the batched requests is well under the SLO, and halves them as soon as it exceeds it.
+type AdaptiveBatcher struct {
+	// Specifies the target p95 latency in milliseconds of the batched requests, including the time they wait for their batch
+	// +kubebuilder:validation:Minimum=1
+	LatencySLO int `json:"latencySLO"`
+	// Specifies the size the max batch size is never shrunk below, defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinBatchSize *int `json:"minBatchSize,omitempty"`
 }
 
 // InferenceService is the Schema for the InferenceServices API
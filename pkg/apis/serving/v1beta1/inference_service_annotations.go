@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	UnknownAnnotationWarning           = "the annotation %q is not recognized by KServe"
+	UnknownAnnotationSuggestionWarning = "the annotation %q is not recognized by KServe, did you mean %q?"
+	// maxAnnotationSuggestionDistance bounds the edit distance of a known annotation suggested for an unknown one
+	maxAnnotationSuggestionDistance = 3
+)
+
+// unknownAnnotationWarnings warns about the serving.kserve.io annotations of the InferenceService which are not
+// registered in the known annotation keys, or rejects the InferenceService when the policy is enforce. A failure to
+// read the policy is only logged.
+func (v *InferenceServiceValidator) unknownAnnotationWarnings(ctx context.Context, isvc *InferenceService) (admission.Warnings, error) {
+	if v.Clientset == nil {
+		return nil, nil
+	}
+	isvcConfigMap, err := GetInferenceServiceConfigMap(ctx, v.Clientset)
+	if err != nil {
+		validatorLogger.Error(err, "Unable to get configmap", "name", constants.InferenceServiceConfigMapName)
+		return nil, nil
+	}
+	isvcConfig, err := NewInferenceServicesConfig(isvcConfigMap)
+	if err != nil {
+		validatorLogger.Error(err, "Unable to create inference service config")
+		return nil, nil
+	}
+	if isvcConfig.UnknownAnnotationValidation == UnknownAnnotationValidationDisabled {
+		return nil, nil
+	}
+	warnings := unknownAnnotations(isvc.Annotations)
+	if len(warnings) > 0 && isvcConfig.UnknownAnnotationValidation == UnknownAnnotationValidationEnforce {
+		return nil, fmt.Errorf(UnknownAnnotationsError, isvc.Name, strings.Join(warnings, "; "))
+	}
+	return warnings, nil
+}
+
+// unknownAnnotations returns a warning for each serving.kserve.io annotation which is not a known annotation key,
+// suggesting the closest known key of a likely typo
+func unknownAnnotations(annotations map[string]string) admission.Warnings {
+	var warnings admission.Warnings
+	for key := range annotations {
+		if !strings.HasPrefix(key, constants.KServeAPIGroupName+"/") ||
+			slices.Contains(constants.KnownInferenceServiceAnnotationKeys, key) {
+			continue
+		}
+		if suggestion := closestAnnotationKey(key); suggestion != "" {
+			warnings = append(warnings, fmt.Sprintf(UnknownAnnotationSuggestionWarning, key, suggestion))
+		} else {
+			warnings = append(warnings, fmt.Sprintf(UnknownAnnotationWarning, key))
+		}
+	}
+	slices.Sort(warnings)
+	return warnings
+}
+
+// closestAnnotationKey returns the known annotation key with the smallest case-insensitive edit distance to the key,
+// or an empty string when no known key is close enough
+func closestAnnotationKey(key string) string {
+	closest, closestDistance := "", maxAnnotationSuggestionDistance+1
+	for _, known := range constants.KnownInferenceServiceAnnotationKeys {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(known)); distance < closestDistance {
+			closest, closestDistance = known, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
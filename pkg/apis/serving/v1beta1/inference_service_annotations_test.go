@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestUnknownAnnotations(t *testing.T) {
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    admission.Warnings
+	}{
+		"KnownAnnotations": {
+			annotations: map[string]string{
+				constants.AutoscalerClass:                                  "hpa",
+				constants.DeploymentMode:                                   "RawDeployment",
+				constants.StorageInitializerSourceUriInternalAnnotationKey: "s3://bucket/model",
+				"autoscaling.knative.dev/target":                           "10",
+			},
+		},
+		"Typo": {
+			annotations: map[string]string{"serving.kserve.io/autoscalerClasss": "hpa"},
+			expected: admission.Warnings{
+				`the annotation "serving.kserve.io/autoscalerClasss" is not recognized by KServe, did you mean "serving.kserve.io/autoscalerClass"?`,
+			},
+		},
+		"CaseTypo": {
+			annotations: map[string]string{"serving.kserve.io/deploymentmode": "Serverless"},
+			expected: admission.Warnings{
+				`the annotation "serving.kserve.io/deploymentmode" is not recognized by KServe, did you mean "serving.kserve.io/deploymentMode"?`,
+			},
+		},
+		"Unknown": {
+			annotations: map[string]string{"serving.kserve.io/team": "ml", "serving.kserve.io/owner": "ml"},
+			expected: admission.Warnings{
+				`the annotation "serving.kserve.io/owner" is not recognized by KServe`,
+				`the annotation "serving.kserve.io/team" is not recognized by KServe`,
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			warnings := unknownAnnotations(scenario.annotations)
+			if scenario.expected == nil {
+				g.Expect(warnings).To(gomega.BeEmpty())
+			} else {
+				g.Expect(warnings).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestUnknownAnnotationWarnings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "default",
+			Annotations: map[string]string{"serving.kserve.io/autoscalerClasss": "hpa"},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{},
+	}
+
+	// The unknown annotations are reported as warnings by default
+	validator := &InferenceServiceValidator{Clientset: fake.NewSimpleClientset(configMap)}
+	warnings, err := validator.unknownAnnotationWarnings(context.Background(), isvc)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(warnings).To(gomega.HaveLen(1))
+
+	configMap.Data[InferenceServiceConfigKeyName] = `{"unknownAnnotationValidation": "enforce"}`
+	validator = &InferenceServiceValidator{Clientset: fake.NewSimpleClientset(configMap)}
+	_, err = validator.unknownAnnotationWarnings(context.Background(), isvc)
+	g.Expect(err).To(gomega.MatchError(`the InferenceService "sklearn" is invalid: the annotation ` +
+		`"serving.kserve.io/autoscalerClasss" is not recognized by KServe, did you mean "serving.kserve.io/autoscalerClass"?`))
+
+	configMap.Data[InferenceServiceConfigKeyName] = `{"unknownAnnotationValidation": "disabled"}`
+	validator = &InferenceServiceValidator{Clientset: fake.NewSimpleClientset(configMap)}
+	warnings, err = validator.unknownAnnotationWarnings(context.Background(), isvc)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(warnings).To(gomega.BeEmpty())
+
+	// The annotations are not validated without clientset
+	warnings, err = (&InferenceServiceValidator{}).unknownAnnotationWarnings(context.Background(), isvc)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(warnings).To(gomega.BeEmpty())
+}
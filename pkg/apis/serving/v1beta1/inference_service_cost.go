@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	CostEstimationWarning           = "estimated steady-state cost of the InferenceService %q: %.2f %s/month for %d pods requesting %s"
+	CostEstimationMaxReplicasSuffix = " (up to %.2f %s/month at the maximum replicas)"
+)
+
+// componentPods are the resources requested by the pods of a replica of a component, and the number of replicas
+type componentPods struct {
+	// requests of all the pods of a replica, e.g. the head and the workers of a multi-node predictor
+	requests    corev1.ResourceList
+	pods        int32
+	minReplicas int32
+	maxReplicas int32
+}
+
+// costEstimationWarnings warns with the estimated monthly cost of the resources requested by the InferenceService
+// when cost estimation is enabled. A failure to read the price table is only logged.
+func (v *InferenceServiceValidator) costEstimationWarnings(ctx context.Context, isvc *InferenceService) admission.Warnings {
+	if v.Clientset == nil {
+		return nil
+	}
+	isvcConfigMap, err := GetInferenceServiceConfigMap(ctx, v.Clientset)
+	if err != nil {
+		validatorLogger.Error(err, "Unable to get configmap", "name", constants.InferenceServiceConfigMapName)
+		return nil
+	}
+	config, err := NewCostEstimationConfig(isvcConfigMap)
+	if err != nil {
+		validatorLogger.Error(err, "Unable to create cost estimation config")
+		return nil
+	}
+	if !config.Enabled {
+		return nil
+	}
+	if warning := estimateCost(isvc, config); warning != "" {
+		return admission.Warnings{warning}
+	}
+	return nil
+}
+
+// estimateCost returns the warning with the cost of the minimum replicas of the components of the InferenceService,
+// or an empty warning when the cost is below the warning threshold. The resources without requests are accounted for
+// with their limits, as the requests default to the limits.
+func estimateCost(isvc *InferenceService, config *CostEstimationConfig) string {
+	components := []componentPods{newComponentPods(&isvc.Spec.Predictor, isvc.Spec.Predictor.GetImplementations(),
+		&isvc.Spec.Predictor.PodSpec, isvc.Spec.Predictor.WorkerSpec)}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, newComponentPods(isvc.Spec.Transformer, isvc.Spec.Transformer.GetImplementations(),
+			&isvc.Spec.Transformer.PodSpec, nil))
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, newComponentPods(isvc.Spec.Explainer, isvc.Spec.Explainer.GetImplementations(),
+			&isvc.Spec.Explainer.PodSpec, nil))
+	}
+
+	minRequests, maxRequests := corev1.ResourceList{}, corev1.ResourceList{}
+	var pods int32
+	for _, component := range components {
+		pods += component.pods * component.minReplicas
+		for name, quantity := range component.requests {
+			addQuantity(minRequests, name, multiplyQuantity(quantity, component.minReplicas))
+			addQuantity(maxRequests, name, multiplyQuantity(quantity, component.maxReplicas))
+		}
+	}
+	minCost, maxCost := monthlyCost(minRequests, config), monthlyCost(maxRequests, config)
+	if maxCost == 0 || maxCost < config.WarningThreshold {
+		return ""
+	}
+
+	names := make([]string, 0, len(minRequests))
+	for name := range minRequests {
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
+	requests := make([]string, 0, len(names))
+	for _, name := range names {
+		quantity := minRequests[corev1.ResourceName(name)]
+		requests = append(requests, fmt.Sprintf("%s: %s", name, quantity.String()))
+	}
+	warning := fmt.Sprintf(CostEstimationWarning, isvc.Name, minCost, config.Currency, pods, strings.Join(requests, ", "))
+	if maxCost > minCost {
+		warning += fmt.Sprintf(CostEstimationMaxReplicasSuffix, maxCost, config.Currency)
+	}
+	return warning
+}
+
+func newComponentPods(component Component, implementations []ComponentImplementation, podSpec *PodSpec, workerSpec *WorkerSpec) componentPods {
+	containers := []corev1.ResourceRequirements{}
+	// The containers of the frameworks are not part of the pod spec, unlike the custom containers
+	for _, implementation := range implementations {
+		if resourced, ok := implementation.(interface {
+			GetResourceRequirements() *corev1.ResourceRequirements
+		}); ok {
+			containers = append(containers, *resourced.GetResourceRequirements())
+		}
+	}
+	for _, container := range podSpec.Containers {
+		containers = append(containers, container.Resources)
+	}
+	requests := podRequests(containers)
+	pods := int32(1)
+	if workerSpec != nil {
+		pipelineParallelSize := constants.DefaultPipelineParallelSize
+		if workerSpec.PipelineParallelSize != nil {
+			pipelineParallelSize = *workerSpec.PipelineParallelSize
+		}
+		// Every pipeline stage but the head runs in a worker pod
+		workers := int32(pipelineParallelSize - 1) //nolint:gosec // the size is validated
+		workerContainers := []corev1.ResourceRequirements{}
+		for _, container := range workerSpec.Containers {
+			workerContainers = append(workerContainers, container.Resources)
+		}
+		for name, quantity := range podRequests(workerContainers) {
+			addQuantity(requests, name, multiplyQuantity(quantity, workers))
+		}
+		pods += workers
+	}
+
+	extensions := component.GetExtensions()
+	minReplicas := int32(1)
+	if extensions.MinReplicas != nil {
+		minReplicas = *extensions.MinReplicas
+	}
+	maxReplicas := max(extensions.MaxReplicas, minReplicas)
+	return componentPods{requests: requests, pods: pods, minReplicas: minReplicas, maxReplicas: maxReplicas}
+}
+
+// podRequests returns the sum of the requests of the containers, falling back to the limits
+func podRequests(containers []corev1.ResourceRequirements) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range containers {
+		for name, quantity := range container.Requests {
+			addQuantity(requests, name, quantity)
+		}
+		for name, quantity := range container.Limits {
+			if _, ok := container.Requests[name]; !ok {
+				addQuantity(requests, name, quantity)
+			}
+		}
+	}
+	return requests
+}
+
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	total := list[name].DeepCopy()
+	total.Add(quantity)
+	list[name] = total
+}
+
+func multiplyQuantity(quantity resource.Quantity, factor int32) resource.Quantity {
+	return *resource.NewMilliQuantity(quantity.MilliValue()*int64(factor), quantity.Format)
+}
+
+// monthlyCost returns the monthly cost of the resources, with the cpu priced per core and the memory and the
+// ephemeral storage priced per GiB
+func monthlyCost(requests corev1.ResourceList, config *CostEstimationConfig) float64 {
+	hourlyCost := 0.0
+	for name, quantity := range requests {
+		units := quantity.AsApproximateFloat64()
+		if name == corev1.ResourceMemory || name == corev1.ResourceEphemeralStorage {
+			units /= 1 << 30
+		}
+		hourlyCost += units * config.Prices[name]
+	}
+	return hourlyCost * config.HoursPerMonth
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestEstimateCost(t *testing.T) {
+	config := &CostEstimationConfig{
+		Enabled:       true,
+		Currency:      "USD",
+		HoursPerMonth: 730,
+		Prices: map[corev1.ResourceName]float64{
+			corev1.ResourceCPU:    0.03,
+			corev1.ResourceMemory: 0.004,
+			"nvidia.com/gpu":      2.5,
+		},
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+			"nvidia.com/gpu":      resource.MustParse("1"),
+		},
+	}
+
+	scenarios := map[string]struct {
+		isvc     *InferenceService
+		config   *CostEstimationConfig
+		expected string
+	}{
+		"PredictorModel": {
+			isvc: &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{
+						Model: &ModelSpec{PredictorExtensionSpec: PredictorExtensionSpec{Container: corev1.Container{Resources: resources}}},
+					},
+				},
+			},
+			config: config,
+			// (2 * 0.03 + 16 * 0.004 + 2.5) * 730
+			expected: `estimated steady-state cost of the InferenceService "llm": 1915.52 USD/month for 1 pods requesting cpu: 2, memory: 16Gi, nvidia.com/gpu: 1`,
+		},
+		"MaxReplicas": {
+			isvc: &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{
+						ComponentExtensionSpec: ComponentExtensionSpec{MinReplicas: ptr.To(int32(2)), MaxReplicas: 4},
+						Model:                  &ModelSpec{PredictorExtensionSpec: PredictorExtensionSpec{Container: corev1.Container{Resources: resources}}},
+					},
+				},
+			},
+			config: config,
+			expected: `estimated steady-state cost of the InferenceService "llm": 3831.04 USD/month for 2 pods requesting cpu: 4, memory: 32Gi, nvidia.com/gpu: 2` +
+				` (up to 7662.08 USD/month at the maximum replicas)`,
+		},
+		"MultiNodeAndTransformer": {
+			isvc: &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{
+						Model: &ModelSpec{PredictorExtensionSpec: PredictorExtensionSpec{Container: corev1.Container{Resources: resources}}},
+						WorkerSpec: &WorkerSpec{
+							PodSpec:              PodSpec{Containers: []corev1.Container{{Name: constants.WorkerContainerName, Resources: resources}}},
+							PipelineParallelSize: ptr.To(3),
+						},
+					},
+					Transformer: &TransformerSpec{
+						PodSpec: PodSpec{Containers: []corev1.Container{{
+							Name: constants.InferenceServiceContainerName,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+							},
+						}}},
+					},
+				},
+			},
+			config: config,
+			// 3 * 1915.52 + 0.5 * 0.03 * 730
+			expected: `estimated steady-state cost of the InferenceService "llm": 5757.51 USD/month for 4 pods requesting cpu: 6500m, memory: 48Gi, nvidia.com/gpu: 3`,
+		},
+		"BelowThreshold": {
+			isvc: &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{
+						Model: &ModelSpec{PredictorExtensionSpec: PredictorExtensionSpec{Container: corev1.Container{Resources: resources}}},
+					},
+				},
+			},
+			config: &CostEstimationConfig{
+				Enabled:          true,
+				Currency:         "USD",
+				HoursPerMonth:    730,
+				Prices:           config.Prices,
+				WarningThreshold: 2000,
+			},
+		},
+		"NoPricedResources": {
+			isvc: &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{SKLearn: &SKLearnSpec{}},
+				},
+			},
+			config: config,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(estimateCost(scenario.isvc, scenario.config)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestCostEstimationWarnings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: InferenceServiceSpec{
+			Predictor: PredictorSpec{
+				SKLearn: &SKLearnSpec{PredictorExtensionSpec: PredictorExtensionSpec{Container: corev1.Container{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				}}},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{CostEstimationConfigName: `{"enabled": true, "prices": {"cpu": 0.05}}`},
+	}
+
+	validator := &InferenceServiceValidator{Clientset: fake.NewSimpleClientset(configMap)}
+	g.Expect(validator.costEstimationWarnings(context.Background(), isvc)).To(gomega.ConsistOf(
+		`estimated steady-state cost of the InferenceService "sklearn": 36.50 USD/month for 1 pods requesting cpu: 1`))
+
+	configMap.Data[CostEstimationConfigName] = `{"enabled": false, "prices": {"cpu": 0.05}}`
+	validator = &InferenceServiceValidator{Clientset: fake.NewSimpleClientset(configMap)}
+	g.Expect(validator.costEstimationWarnings(context.Background(), isvc)).To(gomega.BeEmpty())
+
+	// The cost is not estimated without clientset
+	g.Expect((&InferenceServiceValidator{}).costEstimationWarnings(context.Background(), isvc)).To(gomega.BeEmpty())
+}
@@ -25,7 +25,9 @@ import (
 
 	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,7 +53,12 @@ var mutatorLogger = logf.Log.WithName("inferenceservice-v1beta1-mutating-webhook
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as it is used only for temporary operations and does not need to be deeply copied.
-type InferenceServiceDefaulter struct{}
+type InferenceServiceDefaulter struct {
+	// Client looks up the runtime selected by the predictor, to default the ServiceAccount of the predictor to the one
+	// of the runtime, and the ClusterInferenceTemplate referenced by the InferenceService. Neither is looked up when
+	// the client is unset.
+	Client client.Client
+}
 
 // +kubebuilder:webhook:path=/mutate-inferenceservices,mutating=true,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,verbs=create;update,versions=v1beta1,name=inferenceservice.kserve-webhook-server.defaulter
 var _ webhook.CustomDefaulter = &InferenceServiceDefaulter{}
@@ -149,11 +156,161 @@ func (d *InferenceServiceDefaulter) Default(ctx context.Context, obj runtime.Obj
 		}
 	}
 
+	if err := d.applyInferenceTemplate(ctx, isvc); err != nil {
+		return err
+	}
 	// Pass a list of LocalModelCache resources to set the local model label if there is a match
 	isvc.DefaultInferenceService(isvcConfig, deployConfig, securityConfig, models)
+	return d.defaultServiceAccountNames(ctx, clientSet, isvc, isvcConfig)
+}
+
+// applyInferenceTemplate defaults the predictor with the ClusterInferenceTemplate referenced by the InferenceService.
+// The InferenceService is rejected when the template does not exist.
+func (d *InferenceServiceDefaulter) applyInferenceTemplate(ctx context.Context, isvc *InferenceService) error {
+	if isvc.Spec.TemplateRef == nil || d.Client == nil {
+		return nil
+	}
+	template := &ClusterInferenceTemplate{}
+	if err := d.Client.Get(ctx, client.ObjectKey{Name: isvc.Spec.TemplateRef.Name}, template); err != nil {
+		if apierr.IsNotFound(err) {
+			return fmt.Errorf(InferenceTemplateNotFoundError, isvc.Name, isvc.Spec.TemplateRef.Name)
+		}
+		mutatorLogger.Error(err, "Unable to get the ClusterInferenceTemplate", "name", isvc.Spec.TemplateRef.Name)
+		return err
+	}
+	isvc.setPredictorTemplateDefaults(&template.Spec.Predictor)
 	return nil
 }
 
+// setPredictorTemplateDefaults sets the fields of the predictor which the InferenceService does not set to the ones of
+// a template. The resources are merged per resource name and the environment variables by name. The model format and
+// runtime of the template apply to a model predictor, which is created when the predictor sets no implementation.
+func (isvc *InferenceService) setPredictorTemplateDefaults(template *PredictorTemplateSpec) {
+	predictor := &isvc.Spec.Predictor
+	if template.ModelFormat != nil && len(predictor.GetImplementations()) == 0 {
+		predictor.Model = &ModelSpec{}
+	}
+	var container *corev1.Container
+	switch {
+	case predictor.Model != nil:
+		if predictor.Model.ModelFormat.Name == "" && template.ModelFormat != nil {
+			predictor.Model.ModelFormat = *template.ModelFormat.DeepCopy()
+		}
+		if predictor.Model.Runtime == nil && template.Runtime != nil {
+			predictor.Model.Runtime = proto.String(*template.Runtime)
+		}
+		container = &predictor.Model.Container
+	case len(predictor.Containers) > 0:
+		// The first container is assumed to be the predictor container when there is no kserve-container
+		container = &predictor.Containers[0]
+		for i := range predictor.Containers {
+			if predictor.Containers[i].Name == constants.InferenceServiceContainerName {
+				container = &predictor.Containers[i]
+			}
+		}
+	default:
+		if implementations := predictor.GetImplementations(); len(implementations) > 0 {
+			container = implementations[0].GetContainer(isvc.ObjectMeta, nil, nil)
+		}
+	}
+
+	if container != nil {
+		if template.Resources != nil {
+			container.Resources.Requests = mergeResourceList(container.Resources.Requests, template.Resources.Requests)
+			container.Resources.Limits = mergeResourceList(container.Resources.Limits, template.Resources.Limits)
+		}
+		for _, env := range template.Env {
+			if !slices.ContainsFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name }) {
+				container.Env = append(container.Env, *env.DeepCopy())
+			}
+		}
+	}
+
+	if predictor.MinReplicas == nil && template.MinReplicas != nil {
+		predictor.MinReplicas = proto.Int32(*template.MinReplicas)
+	}
+	if predictor.MaxReplicas == 0 {
+		predictor.MaxReplicas = template.MaxReplicas
+	}
+	if predictor.ScaleTarget == nil && template.ScaleTarget != nil {
+		predictor.ScaleTarget = proto.Int32(*template.ScaleTarget)
+	}
+	if predictor.ScaleMetric == nil && template.ScaleMetric != nil {
+		scaleMetric := *template.ScaleMetric
+		predictor.ScaleMetric = &scaleMetric
+	}
+}
+
+// mergeResourceList adds the resources of the defaults which are not in the resource list
+func mergeResourceList(resources corev1.ResourceList, defaults corev1.ResourceList) corev1.ResourceList {
+	for name, quantity := range defaults {
+		if resources == nil {
+			resources = corev1.ResourceList{}
+		}
+		if _, ok := resources[name]; !ok {
+			resources[name] = quantity.DeepCopy()
+		}
+	}
+	return resources
+}
+
+// defaultServiceAccountNames looks up the ServiceAccount defaults of the predictor runtime and of the namespace of
+// the InferenceService, and sets the ServiceAccount of the components which do not set one.
+func (d *InferenceServiceDefaulter) defaultServiceAccountNames(ctx context.Context, clientSet kubernetes.Interface,
+	isvc *InferenceService, config *InferenceServicesConfig,
+) error {
+	var runtimeServiceAccountName string
+	if d.Client != nil && isvc.GetAnnotations()[constants.DeploymentMode] != string(constants.ModelMeshDeployment) {
+		// A failure to look up the runtime is left to the controller to report
+		if _, spec, err := getPredictorRuntime(ctx, d.Client, isvc); err != nil {
+			mutatorLogger.Error(err, "Unable to get the predictor runtime", "name", isvc.Name)
+		} else if spec != nil {
+			runtimeServiceAccountName = spec.ServiceAccountName
+		}
+	}
+	var namespaceServiceAccountName string
+	namespace, err := clientSet.CoreV1().Namespaces().Get(ctx, isvc.Namespace, metav1.GetOptions{})
+	if err == nil {
+		namespaceServiceAccountName = namespace.Annotations[constants.DefaultServiceAccountNamespaceAnnotationKey]
+	} else if !apierr.IsNotFound(err) {
+		mutatorLogger.Error(err, "Unable to get namespace", "namespace", isvc.Namespace)
+		return err
+	}
+	isvc.setServiceAccountNameDefaults(runtimeServiceAccountName, namespaceServiceAccountName, config)
+	return nil
+}
+
+// setServiceAccountNameDefaults sets the ServiceAccount of the components which do not set one. By precedence, the
+// predictor takes the ServiceAccount of its runtime, then the namespace default, then the default of the
+// inferenceservice config. The transformer and the explainer, which run no runtime, take the namespace default, then
+// the default of the inferenceservice config.
+func (isvc *InferenceService) setServiceAccountNameDefaults(runtimeServiceAccountName string,
+	namespaceServiceAccountName string, config *InferenceServicesConfig,
+) {
+	defaultServiceAccountName := namespaceServiceAccountName
+	if defaultServiceAccountName == "" && config != nil {
+		defaultServiceAccountName = config.DefaultServiceAccountName
+	}
+	predictorServiceAccountName := runtimeServiceAccountName
+	if predictorServiceAccountName == "" {
+		predictorServiceAccountName = defaultServiceAccountName
+	}
+	setServiceAccountNameDefault(&isvc.Spec.Predictor.PodSpec, predictorServiceAccountName)
+	if isvc.Spec.Transformer != nil {
+		setServiceAccountNameDefault(&isvc.Spec.Transformer.PodSpec, defaultServiceAccountName)
+	}
+	if isvc.Spec.Explainer != nil {
+		setServiceAccountNameDefault(&isvc.Spec.Explainer.PodSpec, defaultServiceAccountName)
+	}
+}
+
+func setServiceAccountNameDefault(podSpec *PodSpec, serviceAccountName string) {
+	if serviceAccountName == "" || podSpec.ServiceAccountName != "" || podSpec.DeprecatedServiceAccount != "" {
+		return
+	}
+	podSpec.ServiceAccountName = serviceAccountName
+}
+
 func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesConfig, deployConfig *DeployConfig, securityConfig *SecurityConfig, models *v1alpha1.LocalModelCacheList) {
 	deploymentMode, ok := isvc.ObjectMeta.Annotations[constants.DeploymentMode]
 
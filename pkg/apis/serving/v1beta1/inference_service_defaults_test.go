@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
@@ -30,6 +31,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestInferenceServiceDefaults(t *testing.T) {
@@ -1672,3 +1677,208 @@ func TestDefaultInferenceServiceWithLocalModel(t *testing.T) {
 	g.Expect(isvc.ObjectMeta.Annotations).To(gomega.HaveKeyWithValue(constants.LocalModelSourceUriAnnotationKey, "gs://testbucket/testmodel"))
 	g.Expect(isvc.ObjectMeta.Annotations).To(gomega.HaveKeyWithValue(constants.LocalModelPVCNameAnnotationKey, "local-model-node-group-1"))
 }
+
+func TestDefaultServiceAccountNames(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	sklearnRuntime := &v1alpha1.ServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver", Namespace: "default"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1alpha1.SupportedModelFormat{{Name: "sklearn", AutoSelect: proto.Bool(true)}},
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers:         []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver"}},
+				ServiceAccountName: "runtime-sa",
+			},
+		},
+	}
+	defaultNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "default",
+		Annotations: map[string]string{constants.DefaultServiceAccountNamespaceAnnotationKey: "namespace-sa"},
+	}}
+	makeIsvc := func(predictorServiceAccountName string) *InferenceService {
+		return &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				Predictor: PredictorSpec{
+					PodSpec: PodSpec{ServiceAccountName: predictorServiceAccountName},
+					Model: &ModelSpec{
+						ModelFormat:            ModelFormat{Name: "sklearn"},
+						PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: proto.String("gs://bucket/model")},
+					},
+				},
+				Transformer: &TransformerSpec{},
+				Explainer:   &ExplainerSpec{PodSpec: PodSpec{ServiceAccountName: "explainer-sa"}},
+			},
+		}
+	}
+	config := &InferenceServicesConfig{DefaultServiceAccountName: "cluster-sa"}
+
+	scenarios := map[string]struct {
+		objects               []client.Object
+		namespace             *corev1.Namespace
+		predictorSA           string
+		expectedPredictorSA   string
+		expectedTransformerSA string
+	}{
+		"inference service service account": {
+			objects:               []client.Object{sklearnRuntime},
+			namespace:             defaultNamespace,
+			predictorSA:           "isvc-sa",
+			expectedPredictorSA:   "isvc-sa",
+			expectedTransformerSA: "namespace-sa",
+		},
+		"runtime service account": {
+			objects:               []client.Object{sklearnRuntime},
+			namespace:             defaultNamespace,
+			expectedPredictorSA:   "runtime-sa",
+			expectedTransformerSA: "namespace-sa",
+		},
+		"namespace service account": {
+			namespace:             defaultNamespace,
+			expectedPredictorSA:   "namespace-sa",
+			expectedTransformerSA: "namespace-sa",
+		},
+		"cluster service account": {
+			namespace:             &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			expectedPredictorSA:   "cluster-sa",
+			expectedTransformerSA: "cluster-sa",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			defaulter := &InferenceServiceDefaulter{
+				Client: fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.objects...).Build(),
+			}
+			isvc := makeIsvc(scenario.predictorSA)
+			err := defaulter.defaultServiceAccountNames(context.Background(), fakeclientset.NewSimpleClientset(scenario.namespace), isvc, config)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(isvc.Spec.Predictor.ServiceAccountName).To(gomega.Equal(scenario.expectedPredictorSA))
+			g.Expect(isvc.Spec.Transformer.ServiceAccountName).To(gomega.Equal(scenario.expectedTransformerSA))
+			g.Expect(isvc.Spec.Explainer.ServiceAccountName).To(gomega.Equal("explainer-sa"))
+		})
+	}
+}
+
+func TestApplyInferenceTemplate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	scaleMetric := MetricConcurrency
+	template := &ClusterInferenceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm-small"},
+		Spec: ClusterInferenceTemplateSpec{
+			Predictor: PredictorTemplateSpec{
+				ModelFormat: &ModelFormat{Name: "huggingface"},
+				Runtime:     proto.String("kserve-huggingfaceserver"),
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+					Limits: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+				},
+				Env:         []corev1.EnvVar{{Name: "HF_HUB_OFFLINE", Value: "1"}, {Name: "MAX_MODEL_LEN", Value: "4096"}},
+				MinReplicas: proto.Int32(1),
+				MaxReplicas: 4,
+				ScaleTarget: proto.Int32(10),
+				ScaleMetric: &scaleMetric,
+			},
+		},
+	}
+	defaulter := &InferenceServiceDefaulter{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(template).Build()}
+
+	t.Run("two line manifest", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				TemplateRef: &InferenceTemplateReference{Name: "llm-small"},
+				Predictor: PredictorSpec{
+					Model: &ModelSpec{PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: proto.String("hf://org/model")}},
+				},
+			},
+		}
+		g.Expect(defaulter.applyInferenceTemplate(context.Background(), isvc)).To(gomega.Succeed())
+		predictor := isvc.Spec.Predictor
+		g.Expect(predictor.Model.ModelFormat).To(gomega.Equal(ModelFormat{Name: "huggingface"}))
+		g.Expect(predictor.Model.Runtime).To(gomega.Equal(proto.String("kserve-huggingfaceserver")))
+		g.Expect(predictor.Model.Resources).To(gomega.Equal(*template.Spec.Predictor.Resources))
+		g.Expect(predictor.Model.Env).To(gomega.Equal(template.Spec.Predictor.Env))
+		g.Expect(predictor.MinReplicas).To(gomega.Equal(proto.Int32(1)))
+		g.Expect(predictor.MaxReplicas).To(gomega.Equal(int32(4)))
+		g.Expect(predictor.ScaleTarget).To(gomega.Equal(proto.Int32(10)))
+		g.Expect(*predictor.ScaleMetric).To(gomega.Equal(MetricConcurrency))
+		// The template is not modified through the defaulted InferenceService
+		predictor.Model.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("4")
+		g.Expect(template.Spec.Predictor.Resources.Requests.Cpu().String()).To(gomega.Equal("2"))
+	})
+
+	t.Run("inference service takes precedence", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				TemplateRef: &InferenceTemplateReference{Name: "llm-small"},
+				Predictor: PredictorSpec{
+					ComponentExtensionSpec: ComponentExtensionSpec{MinReplicas: proto.Int32(0)},
+					Model: &ModelSpec{
+						ModelFormat: ModelFormat{Name: "vllm"},
+						PredictorExtensionSpec: PredictorExtensionSpec{
+							StorageURI: proto.String("hf://org/model"),
+							Container: corev1.Container{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+								},
+								Env: []corev1.EnvVar{{Name: "MAX_MODEL_LEN", Value: "8192"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		g.Expect(defaulter.applyInferenceTemplate(context.Background(), isvc)).To(gomega.Succeed())
+		predictor := isvc.Spec.Predictor
+		g.Expect(predictor.Model.ModelFormat.Name).To(gomega.Equal("vllm"))
+		g.Expect(predictor.Model.Resources.Requests).To(gomega.Equal(corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		}))
+		g.Expect(predictor.Model.Env).To(gomega.Equal([]corev1.EnvVar{
+			{Name: "MAX_MODEL_LEN", Value: "8192"},
+			{Name: "HF_HUB_OFFLINE", Value: "1"},
+		}))
+		g.Expect(predictor.MinReplicas).To(gomega.Equal(proto.Int32(0)))
+	})
+
+	t.Run("custom predictor container", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				TemplateRef: &InferenceTemplateReference{Name: "llm-small"},
+				Predictor: PredictorSpec{PodSpec: PodSpec{Containers: []corev1.Container{
+					{Name: "sidecar", Image: "sidecar"},
+					{Name: constants.InferenceServiceContainerName, Image: "custom"},
+				}}},
+			},
+		}
+		g.Expect(defaulter.applyInferenceTemplate(context.Background(), isvc)).To(gomega.Succeed())
+		g.Expect(isvc.Spec.Predictor.Model).To(gomega.BeNil())
+		g.Expect(isvc.Spec.Predictor.Containers[0].Env).To(gomega.BeEmpty())
+		g.Expect(isvc.Spec.Predictor.Containers[1].Env).To(gomega.HaveLen(2))
+	})
+
+	t.Run("missing template", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec:       InferenceServiceSpec{TemplateRef: &InferenceTemplateReference{Name: "llm-large"}},
+		}
+		g.Expect(defaulter.applyInferenceTemplate(context.Background(), isvc)).To(
+			gomega.MatchError(`the InferenceService "llm" is invalid: the ClusterInferenceTemplate "llm-large" does not exist`))
+	})
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+	"net"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -57,6 +61,153 @@ type InferenceServiceStatus struct {
 	ServingRuntimeName string `json:"servingRuntimeName,omitempty"`
 	// ClusterServingRuntimeName is the name of the ClusterServingRuntime that the InferenceService is using
 	ClusterServingRuntimeName string `json:"clusterServingRuntimeName,omitempty"`
+	// Statuses of the models declared in the predictor models
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Models []PredictorModelStatus `json:"models,omitempty"`
+	// Split of the predictor replicas between spot and on-demand nodes when the predictor capacity is set
+	// +optional
+	Capacity *CapacityStatus `json:"capacity,omitempty"`
+	// Addresses are the typed endpoints of the InferenceService, for the infrastructure as code and the service
+	// discovery tools. The names and the types of the addresses are stable across releases, new types may be added
+	// and should be ignored by the consumers which do not know them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Addresses []InferenceServiceAddress `json:"addresses,omitempty"`
+	// Variants reports the URLs and the traffic split of the model variants of the A/B test
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Variants []ModelVariantStatus `json:"variants,omitempty"`
+	// LastCrashInfo holds the diagnostics of the last crash of a runtime container of the InferenceService, captured
+	// when the crash diagnostics are enabled so that they outlive the garbage collection of the crashed pod
+	// +optional
+	LastCrashInfo *CrashInfo `json:"lastCrashInfo,omitempty"`
+}
+
+// CrashInfo is the diagnostics of a crash of the runtime container of a component
+type CrashInfo struct {
+	// Component whose runtime container crashed
+	Component ComponentType `json:"component"`
+	// Pod whose runtime container crashed
+	Pod string `json:"pod"`
+	// Container which crashed
+	Container string `json:"container"`
+	// Time the container terminated
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+	// ExitCode of the container
+	ExitCode int32 `json:"exitCode"`
+	// Signal which killed the container, if any
+	// +optional
+	Signal int32 `json:"signal,omitempty"`
+	// Reason of the termination, e.g. Error or OOMKilled
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// OOMKilled is true when the container was killed for exceeding its memory limit
+	// +optional
+	OOMKilled bool `json:"oomKilled,omitempty"`
+	// RestartCount of the container when the crash was captured
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// GPUXIDErrors are the NVIDIA XID error codes reported in the logs of the crashed container
+	// +optional
+	// +listType=atomic
+	GPUXIDErrors []int32 `json:"gpuXidErrors,omitempty"`
+	// Logs are the last lines of the logs of the crashed container
+	// +optional
+	Logs string `json:"logs,omitempty"`
+}
+
+// ModelVariantStatus is the status of a model variant of an A/B test
+type ModelVariantStatus struct {
+	// Name of the variant
+	Name string `json:"name"`
+	// InferenceService serving the variant
+	InferenceService string `json:"inferenceService"`
+	// URL of the InferenceService serving the variant
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+	// Ready is true when the InferenceService serving the variant is ready
+	Ready bool `json:"ready"`
+	// Percent of the traffic routed to the variant. The weights of the variants which are not ready are shifted to
+	// the ready variants.
+	Percent int32 `json:"percent"`
+}
+
+// AddressType is the type of an address of an InferenceService
+// +kubebuilder:validation:Enum=ExternalURL;ClusterLocalURL;GRPCTarget;RevisionURL
+type AddressType string
+
+const (
+	// ExternalURLAddress is the URL of the InferenceService exposed outside of the cluster by the ingress
+	ExternalURLAddress AddressType = "ExternalURL"
+	// ClusterLocalURLAddress is the URL of the InferenceService inside of the cluster
+	ClusterLocalURLAddress AddressType = "ClusterLocalURL"
+	// GRPCTargetAddress is the host:port target of the gRPC clients of a predictor serving a gRPC protocol
+	GRPCTargetAddress AddressType = "GRPCTarget"
+	// RevisionURLAddress is the URL of a tagged revision of a component in Knative deployment mode
+	RevisionURLAddress AddressType = "RevisionURL"
+)
+
+// Names of the addresses of the InferenceService, the revision addresses are named <component>-<tag>
+const (
+	ExternalAddressName         = "external"
+	ClusterLocalAddressName     = "cluster-local"
+	GRPCAddressName             = "grpc"
+	GRPCClusterLocalAddressName = "grpc-cluster-local"
+)
+
+// InferenceServiceAddress is a typed endpoint of an InferenceService
+type InferenceServiceAddress struct {
+	// Name identifies the address: external, cluster-local, grpc, grpc-cluster-local or <component>-<tag> for the
+	// revision addresses
+	Name string `json:"name"`
+	// Type of the address
+	Type AddressType `json:"type"`
+	// Component the address routes to, only set for the revision addresses
+	// +optional
+	Component ComponentType `json:"component,omitempty"`
+	// Revision the address routes to, only set for the revision addresses
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// URL of the address, set for the URL types
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+	// Target of the address in the host:port form, set for the GRPCTarget type
+	// +optional
+	Target string `json:"target,omitempty"`
+}
+
+// CapacityStatus describes the split of the predictor replicas between spot and on-demand nodes
+type CapacityStatus struct {
+	// SpotReplicas is the number of ready replicas running on spot nodes
+	SpotReplicas int32 `json:"spotReplicas"`
+	// OnDemandReplicas is the number of replicas requested on on-demand nodes, including the replicas
+	// rebalanced from the spot nodes
+	OnDemandReplicas int32 `json:"onDemandReplicas"`
+	// RebalancedReplicas is the number of spot replicas which are preempted or cannot be scheduled, and are
+	// replaced by on-demand replicas
+	RebalancedReplicas int32 `json:"rebalancedReplicas"`
+	// SpotPreemptions is the number of spot replicas preempted since the capacity was set
+	SpotPreemptions int32 `json:"spotPreemptions"`
+	// LastSpotPreemptionTime is the time of the last preemption of a spot replica
+	// +optional
+	LastSpotPreemptionTime *metav1.Time `json:"lastSpotPreemptionTime,omitempty"`
+}
+
+// PredictorModelStatus describes the state of a model declared in the predictor models
+type PredictorModelStatus struct {
+	// Name of the model
+	Name string `json:"name"`
+	// State of the model in the predictor, Pending until the predictor is ready,
+	// then Loaded once the model servers of all the ready pods of the latest revision report it ready, or Loading.
+	State ModelState `json:"state"`
+	// URL of the inference endpoint of the model
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
 }
 
 // ComponentStatusSpec describes the state of the component
@@ -90,6 +241,156 @@ type ComponentStatusSpec struct {
 	// Addressable endpoint for the InferenceService
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+	// WarmStandby describes the previous rolled out revision kept warm after the rollout of the latest revision
+	// +optional
+	WarmStandby *WarmStandbyStatus `json:"warmStandby,omitempty"`
+	// CanaryAnalysis describes the automated promotion of the latest canary revision
+	// +optional
+	CanaryAnalysis *CanaryAnalysisStatus `json:"canaryAnalysis,omitempty"`
+	// BlueGreen describes the blue-green rollout of the component
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+	// ConfigSnapshot is the name of the immutable ConfigMap snapshotting the resolved configuration the latest created
+	// revision was rendered with
+	// +optional
+	ConfigSnapshot string `json:"configSnapshot,omitempty"`
+	// InferenceProbe describes the inference probe of the latest created revision
+	// +optional
+	InferenceProbe *InferenceProbeStatus `json:"inferenceProbe,omitempty"`
+}
+
+// InferenceProbeState is the state of the inference probe of a revision
+type InferenceProbeState string
+
+const (
+	// InferenceProbeInProgress is the state of a revision whose pods are being probed
+	InferenceProbeInProgress InferenceProbeState = "InProgress"
+	// InferenceProbePassed is the state of a revision whose pods all served the sample inference request
+	InferenceProbePassed InferenceProbeState = "Passed"
+	// InferenceProbeFailed is the state of a revision whose pods failed the sample inference request for the
+	// failure threshold of consecutive attempts
+	InferenceProbeFailed InferenceProbeState = "Failed"
+)
+
+// InferenceProbeStatus describes the inference probe of the latest created revision. Each revision is probed once.
+type InferenceProbeStatus struct {
+	// Revision is the name of the latest probed revision
+	Revision string `json:"revision"`
+	// State of the inference probe of the revision
+	State InferenceProbeState `json:"state"`
+	// PassedRevision is the name of the latest revision which passed the inference probe
+	// +optional
+	PassedRevision string `json:"passedRevision,omitempty"`
+}
+
+// WarmStandbyState is the state of the warm standby of a previous revision
+type WarmStandbyState string
+
+const (
+	// WarmStandbySoaking is the state of a previous revision kept warm until the end of the soak period
+	WarmStandbySoaking WarmStandbyState = "Soaking"
+	// WarmStandbyExpired is the state of a previous revision no longer kept warm once the soak period ended
+	WarmStandbyExpired WarmStandbyState = "Expired"
+)
+
+// DefaultWarmStandbyReplicas is the default number of replicas of the previous revision kept warm
+const DefaultWarmStandbyReplicas int32 = 1
+
+// WarmStandbyStatus describes the previous rolled out revision kept warm after a rollout
+type WarmStandbyStatus struct {
+	// Revision is the name of the previous rolled out revision
+	Revision string `json:"revision"`
+	// Replicas is the minimum number of replicas of the revision kept warm
+	Replicas int32 `json:"replicas"`
+	// State is Soaking while the revision is kept warm, and Expired once the soak period ended
+	State WarmStandbyState `json:"state"`
+	// SoakEndTime is the time the revision stops being kept warm
+	SoakEndTime metav1.Time `json:"soakEndTime"`
+}
+
+// IsSoaking returns whether the revision of the warm standby is kept warm at the given time
+func (ws *WarmStandbyStatus) IsSoaking(now time.Time) bool {
+	return ws != nil && ws.State == WarmStandbySoaking && now.Before(ws.SoakEndTime.Time)
+}
+
+// CanaryAnalysisPhase is the phase of the analysis of a canary revision
+type CanaryAnalysisPhase string
+
+const (
+	// CanaryAnalysisProgressing is the phase of a canary revision whose traffic is increased while its metrics meet
+	// the thresholds
+	CanaryAnalysisProgressing CanaryAnalysisPhase = "Progressing"
+	// CanaryAnalysisPromoted is the phase of a canary revision which received all the traffic
+	CanaryAnalysisPromoted CanaryAnalysisPhase = "Promoted"
+	// CanaryAnalysisRolledBack is the phase of a canary revision whose traffic was shifted back to the previous
+	// rolled out revision
+	CanaryAnalysisRolledBack CanaryAnalysisPhase = "RolledBack"
+)
+
+// Defaults of the canary analysis
+const (
+	DefaultCanaryAnalysisInterval               = time.Minute
+	DefaultCanaryAnalysisStepPercent      int64 = 10
+	DefaultCanaryAnalysisFailureThreshold int32 = 1
+)
+
+// CanaryAnalysisStatus describes the analysis of the canary revision of a component
+type CanaryAnalysisStatus struct {
+	// Revision is the name of the canary revision
+	Revision string `json:"revision"`
+	// Phase is Progressing while the canary revision is analysed, then Promoted or RolledBack
+	Phase CanaryAnalysisPhase `json:"phase"`
+	// Steps is the number of successful evaluations of the canary revision
+	Steps int32 `json:"steps"`
+	// FailedEvaluations is the number of evaluations of the canary revision whose metrics missed the thresholds
+	FailedEvaluations int32 `json:"failedEvaluations"`
+	// LastEvaluationTime is the time the metrics of the canary revision were last evaluated, or the time the analysis
+	// started
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime"`
+	// Message describes the outcome of the last evaluation
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BlueGreenPhase is the phase of the blue-green rollout of a component
+type BlueGreenPhase string
+
+const (
+	// BlueGreenActive is the phase of a component whose Service selects the pods of its latest template
+	BlueGreenActive BlueGreenPhase = "Active"
+	// BlueGreenPreviewing is the phase of a component whose new pods are deployed by the preview Deployment, until they
+	// are ready and pass the smoke test
+	BlueGreenPreviewing BlueGreenPhase = "Previewing"
+	// BlueGreenSwitched is the phase of a component whose Service was switched to the new pods, while the previous
+	// pods are kept for the rollback window
+	BlueGreenSwitched BlueGreenPhase = "Switched"
+	// BlueGreenPromoting is the phase of a component whose previous pods are replaced with the new ones once the
+	// rollback window ended
+	BlueGreenPromoting BlueGreenPhase = "Promoting"
+)
+
+// DefaultBlueGreenSmokeTestRetryInterval is the interval the smoke test of the new pods is retried at after it failed
+const DefaultBlueGreenSmokeTestRetryInterval = 30 * time.Second
+
+// BlueGreenStatus describes the blue-green rollout of a component
+type BlueGreenStatus struct {
+	// Phase is Previewing while the new pods are deployed, Switched once the Service selects them, Promoting once the
+	// rollback window ended, then Active
+	Phase BlueGreenPhase `json:"phase"`
+	// ActiveTemplateHash is the hash of the pod template selected by the Service
+	ActiveTemplateHash string `json:"activeTemplateHash"`
+	// PreviewTemplateHash is the hash of the pod template deployed by the preview Deployment
+	// +optional
+	PreviewTemplateHash string `json:"previewTemplateHash,omitempty"`
+	// SwitchTime is the time the Service was switched to the new pods
+	// +optional
+	SwitchTime *metav1.Time `json:"switchTime,omitempty"`
+	// RollbackWindowEndTime is the time the previous pods are replaced with the new ones
+	// +optional
+	RollbackWindowEndTime *metav1.Time `json:"rollbackWindowEndTime,omitempty"`
+	// Message describes the outcome of the last smoke test of the new pods
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // ComponentType contains the different types of components of the service
@@ -130,6 +431,23 @@ const (
 	LatestDeploymentReady apis.ConditionType = "LatestDeploymentReady"
 	// Stopped is set when the inference service has been stopped and all related objects are deleted
 	Stopped apis.ConditionType = "Stopped"
+	// FailoverActive is set when the traffic of the inference service is routed to its failover target
+	FailoverActive apis.ConditionType = "FailoverActive"
+	// InferenceProbeReady is set when the sample inference request of the inference probe readiness gate succeeded
+	InferenceProbeReady apis.ConditionType = "InferenceProbeReady"
+	// DependenciesReady is set when the resources the inference service depends on are ready
+	DependenciesReady apis.ConditionType = "DependenciesReady"
+	// GPUMemoryHealthy is set to false when the agents find the GPU memory of predictor pods fragmented or leaking, and
+	// recommend restarting them
+	GPUMemoryHealthy apis.ConditionType = "GPUMemoryHealthy"
+	// MIGPartitionReady is set when the nodes claimed for the MIG profile requested by the predictor are partitioned
+	MIGPartitionReady apis.ConditionType = "MIGPartitionReady"
+	// DriftDetected is set when the detector of the predictor monitoring reports a drift of the inputs of the predictor
+	// from its reference data
+	DriftDetected apis.ConditionType = "DriftDetected"
+	// ReplicasWithinCeiling is set to false when the replicas of components are clamped to the replica ceiling of the
+	// ServingQuotas of the namespace
+	ReplicasWithinCeiling apis.ConditionType = "ReplicasWithinCeiling"
 )
 
 type ModelStatus struct {
@@ -148,6 +466,11 @@ type ModelStatus struct {
 	// Model copy information of the predictor's model.
 	// +optional
 	ModelCopies *ModelCopies `json:"copies,omitempty"`
+
+	// Commit SHAs the HuggingFace Hub storage URIs of the predictor's model were resolved to and downloaded at by the
+	// storage initializer, by storage URI.
+	// +optional
+	ResolvedRevisions map[string]string `json:"resolvedRevisions,omitempty"`
 }
 
 type ModelRevisionStates struct {
@@ -204,6 +527,9 @@ const (
 // Stopped Inference Service reason
 const StoppedISVCReason = "Stopped"
 
+// KueueAdmissionPendingReason is the reason of the components held until the admission of their Kueue workload
+const KueueAdmissionPendingReason = "KueueAdmissionPending"
+
 // FailureReason enum
 // +kubebuilder:validation:Enum=ModelLoadFailed;RuntimeUnhealthy;RuntimeDisabled;NoSupportingRuntime;RuntimeNotRecognized;InvalidPredictorSpec
 type FailureReason string
@@ -422,6 +748,15 @@ func (ss *InferenceServiceStatus) PropagateRawStatus(
 			Message: replicaFailureCondition.Message,
 		}
 	}
+	// The deployments held at zero replicas are available without serving
+	if deploymentList[0].Annotations[constants.KueueAdmissionHeldAnnotationKey] == "true" {
+		componentReadyCondition = &apis.Condition{
+			Type:    readyCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  KueueAdmissionPendingReason,
+			Message: "Waiting for the admission of the Kueue workload " + deploymentList[0].Name,
+		}
+	}
 	if componentReadyCondition != nil && componentReadyCondition.Status == corev1.ConditionTrue {
 		statusSpec.URL = url
 	}
@@ -541,8 +876,11 @@ func (ss *InferenceServiceStatus) PropagateStatus(component ComponentType, servi
 				}
 			} else {
 				// This is to handle case when the latest ready revision is rolled out with 100% and then rolled back
-				// so here we need to rollback the LatestRolledoutRevision to PreviousRolledoutRevision
-				if serviceStatus.LatestReadyRevisionName == serviceStatus.LatestCreatedRevisionName {
+				// so here we need to rollback the LatestRolledoutRevision to PreviousRolledoutRevision. The rolled out
+				// revision mirroring its own traffic, or held for the inference probe, while no candidate revision is
+				// created is not rolled back.
+				if serviceStatus.LatestReadyRevisionName == serviceStatus.LatestCreatedRevisionName &&
+					traffic.Tag != constants.ShadowTrafficTag && traffic.Tag != constants.InferenceProbeTrafficTag {
 					if traffic.Percent != nil && *traffic.Percent < 100 {
 						// check the possibility that the traffic is split over the same revision
 						if val, ok := revisionTraffic[traffic.RevisionName]; ok {
@@ -701,6 +1039,7 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 	// state to 'ModelLoadFailed' with failure info.
 	for _, cs := range podList.Items[0].Status.InitContainerStatuses {
 		if cs.Name == constants.StorageInitializerContainerName {
+			ss.propagateResolvedRevisions(cs.State)
 			switch {
 			case cs.State.Running != nil:
 				// Double check that we aren't missing an error because the cs is looping between an error state and running
@@ -768,3 +1107,182 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 	}
 	return true
 }
+
+// propagateResolvedRevisions records the commit SHAs of the HuggingFace Hub models the storage initializer reports in
+// its termination message once it succeeded
+func (ss *InferenceServiceStatus) propagateResolvedRevisions(state corev1.ContainerState) {
+	if state.Terminated == nil || state.Terminated.ExitCode != 0 || state.Terminated.Message == "" {
+		return
+	}
+	var message struct {
+		ResolvedRevisions map[string]string `json:"resolvedRevisions"`
+	}
+	if err := json.Unmarshal([]byte(state.Terminated.Message), &message); err != nil || len(message.ResolvedRevisions) == 0 {
+		return
+	}
+	ss.ModelStatus.ResolvedRevisions = message.ResolvedRevisions
+}
+
+// PropagateCapacityStatus updates the split of the predictor replicas between spot and on-demand nodes, and counts the
+// preemptions of the spot pods which happened since the last recorded preemption
+func (ss *InferenceServiceStatus) PropagateCapacityStatus(spotPods *corev1.PodList, onDemandReplicas int32, rebalancedReplicas int32) {
+	if ss.Capacity == nil {
+		ss.Capacity = &CapacityStatus{}
+	}
+	ss.Capacity.SpotReplicas = int32(countReadyPods(spotPods))
+	ss.Capacity.OnDemandReplicas = onDemandReplicas
+	ss.Capacity.RebalancedReplicas = rebalancedReplicas
+	if spotPods == nil {
+		return
+	}
+	since := ss.Capacity.LastSpotPreemptionTime
+	for _, pod := range spotPods.Items {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.DisruptionTarget || cond.Status != corev1.ConditionTrue {
+				continue
+			}
+			if since != nil && !cond.LastTransitionTime.After(since.Time) {
+				continue
+			}
+			ss.Capacity.SpotPreemptions++
+			if ss.Capacity.LastSpotPreemptionTime == nil || cond.LastTransitionTime.After(ss.Capacity.LastSpotPreemptionTime.Time) {
+				ss.Capacity.LastSpotPreemptionTime = cond.LastTransitionTime.DeepCopy()
+			}
+		}
+	}
+}
+
+// PropagateAddresses sets the addresses of the InferenceService from its URL, its cluster local address and the tagged
+// traffic targets of its components. The gRPC targets are only set when the predictor serves a gRPC protocol.
+func (ss *InferenceServiceStatus) PropagateAddresses(grpc bool) {
+	var addresses []InferenceServiceAddress
+	var clusterLocalURL *apis.URL
+	if ss.Address != nil {
+		clusterLocalURL = ss.Address.URL
+	}
+	// the URL of the cluster local InferenceServices is their cluster local URL
+	if ss.URL != nil && (clusterLocalURL == nil || ss.URL.Host != clusterLocalURL.Host) {
+		addresses = append(addresses, InferenceServiceAddress{Name: ExternalAddressName, Type: ExternalURLAddress, URL: ss.URL.DeepCopy()})
+	}
+	if clusterLocalURL != nil {
+		addresses = append(addresses, InferenceServiceAddress{Name: ClusterLocalAddressName, Type: ClusterLocalURLAddress, URL: clusterLocalURL.DeepCopy()})
+	}
+	if grpc {
+		for _, address := range slices.Clone(addresses) {
+			name := GRPCAddressName
+			if address.Type == ClusterLocalURLAddress {
+				name = GRPCClusterLocalAddressName
+			}
+			addresses = append(addresses, InferenceServiceAddress{Name: name, Type: GRPCTargetAddress, Target: grpcTarget(address.URL)})
+		}
+	}
+	for _, component := range []ComponentType{PredictorComponent, TransformerComponent, ExplainerComponent} {
+		for _, traffic := range ss.Components[component].Traffic {
+			if traffic.Tag == "" || traffic.URL == nil {
+				continue
+			}
+			addresses = append(addresses, InferenceServiceAddress{
+				Name:      string(component) + "-" + traffic.Tag,
+				Type:      RevisionURLAddress,
+				Component: component,
+				Revision:  traffic.RevisionName,
+				URL:       traffic.URL.DeepCopy(),
+			})
+		}
+	}
+	ss.Addresses = addresses
+}
+
+// grpcTarget is the host:port target of the gRPC clients of a URL, which defaults to the port of its scheme
+func grpcTarget(url *apis.URL) string {
+	if url.URL().Port() != "" {
+		return url.Host
+	}
+	port := "80"
+	if url.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(url.URL().Hostname(), port)
+}
+
+// PropagateWarmStandby starts the soak period of the previous rolled out revision of a component once the traffic is
+// fully shifted to the latest revision, and expires it at the end of the soak period. The warm standby is removed when
+// it is not configured or there is no previous revision.
+func (ss *InferenceServiceStatus) PropagateWarmStandby(component ComponentType, warmStandby *WarmStandbySpec, now time.Time) {
+	statusSpec, ok := ss.Components[component]
+	if !ok {
+		return
+	}
+	previous := statusSpec.PreviousRolledoutRevision
+	switch {
+	case warmStandby == nil || previous == "" || previous == statusSpec.LatestRolledoutRevision:
+		statusSpec.WarmStandby = nil
+	case statusSpec.WarmStandby == nil || statusSpec.WarmStandby.Revision != previous:
+		statusSpec.WarmStandby = &WarmStandbyStatus{
+			Revision:    previous,
+			State:       WarmStandbySoaking,
+			SoakEndTime: metav1.NewTime(now.Add(time.Duration(warmStandby.SoakSeconds) * time.Second)),
+		}
+	case !statusSpec.WarmStandby.IsSoaking(now):
+		statusSpec.WarmStandby.State = WarmStandbyExpired
+	}
+	if statusSpec.WarmStandby != nil {
+		statusSpec.WarmStandby.Replicas = DefaultWarmStandbyReplicas
+		if warmStandby.Replicas != nil {
+			statusSpec.WarmStandby.Replicas = *warmStandby.Replicas
+		}
+	}
+	ss.Components[component] = statusSpec
+}
+
+// WarmStandbyRequeueAfter returns the time left until the end of the earliest soak period of the components, or zero
+// when no previous revision is kept warm
+func (ss *InferenceServiceStatus) WarmStandbyRequeueAfter(now time.Time) time.Duration {
+	var requeueAfter time.Duration
+	for _, statusSpec := range ss.Components {
+		if !statusSpec.WarmStandby.IsSoaking(now) {
+			continue
+		}
+		if remaining := statusSpec.WarmStandby.SoakEndTime.Sub(now); requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter
+}
+
+// PropagateBlueGreenStatus sets the status of the blue-green rollout of a component, which is removed when the
+// component is not rolled out with the blue-green strategy
+func (ss *InferenceServiceStatus) PropagateBlueGreenStatus(component ComponentType, blueGreen *BlueGreenStatus) {
+	statusSpec, ok := ss.Components[component]
+	if !ok {
+		return
+	}
+	statusSpec.BlueGreen = blueGreen
+	ss.Components[component] = statusSpec
+}
+
+// BlueGreenRequeueAfter returns the time left until the earliest end of the rollback windows of the components, or
+// the retry interval of a failed smoke test, or zero when no blue-green rollout waits on time
+func (ss *InferenceServiceStatus) BlueGreenRequeueAfter(now time.Time) time.Duration {
+	var requeueAfter time.Duration
+	for _, statusSpec := range ss.Components {
+		blueGreen := statusSpec.BlueGreen
+		if blueGreen == nil {
+			continue
+		}
+		var remaining time.Duration
+		switch {
+		case blueGreen.Phase == BlueGreenSwitched && blueGreen.RollbackWindowEndTime != nil:
+			// The rollback window ending now is requeued right away rather than ignored
+			remaining = max(blueGreen.RollbackWindowEndTime.Sub(now), time.Second)
+		case blueGreen.Phase == BlueGreenPreviewing && blueGreen.Message != "":
+			remaining = DefaultBlueGreenSmokeTestRetryInterval
+		default:
+			continue
+		}
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter
+}
@@ -246,6 +246,120 @@ func TestPropagateRawStatus(t *testing.T) {
 	}
 }
 
+func TestPropagateRawStatusHeldForKueueAdmission(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-predictor",
+			Annotations: map[string]string{constants.KueueAdmissionHeldAnnotationKey: "true"},
+		},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+			},
+		},
+	}
+	status := &InferenceServiceStatus{}
+	parsedUrl, _ := url.Parse("http://test-predictor-default.default.example.com")
+	status.PropagateRawStatus(PredictorComponent, []*appsv1.Deployment{deployment}, (*apis.URL)(parsedUrl))
+	g.Expect(status.IsConditionFalse(PredictorReady)).To(gomega.BeTrue())
+	g.Expect(status.GetCondition(PredictorReady).Reason).To(gomega.Equal(KueueAdmissionPendingReason))
+	g.Expect(status.Components[PredictorComponent].URL).To(gomega.BeNil())
+}
+
+func TestPropagateCapacityStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	preemptedAt := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	readyPod := corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	preemptedPod := corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, LastTransitionTime: preemptedAt},
+			},
+		},
+	}
+	spotPods := &corev1.PodList{Items: []corev1.Pod{readyPod, preemptedPod}}
+
+	status := &InferenceServiceStatus{}
+	status.PropagateCapacityStatus(spotPods, 2, 1)
+	g.Expect(status.Capacity).To(gomega.Equal(&CapacityStatus{
+		SpotReplicas:           1,
+		OnDemandReplicas:       2,
+		RebalancedReplicas:     1,
+		SpotPreemptions:        1,
+		LastSpotPreemptionTime: &preemptedAt,
+	}))
+
+	// The preemption is only counted once
+	status.PropagateCapacityStatus(spotPods, 2, 1)
+	g.Expect(status.Capacity.SpotPreemptions).To(gomega.Equal(int32(1)))
+
+	// A later preemption is counted
+	laterPreemptedAt := metav1.NewTime(preemptedAt.Add(time.Minute))
+	readyPod.Status.Conditions = append(readyPod.Status.Conditions, corev1.PodCondition{
+		Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, LastTransitionTime: laterPreemptedAt,
+	})
+	status.PropagateCapacityStatus(&corev1.PodList{Items: []corev1.Pod{readyPod}}, 1, 0)
+	g.Expect(status.Capacity.SpotPreemptions).To(gomega.Equal(int32(2)))
+	g.Expect(status.Capacity.LastSpotPreemptionTime).To(gomega.Equal(&laterPreemptedAt))
+	g.Expect(status.Capacity.RebalancedReplicas).To(gomega.Equal(int32(0)))
+}
+
+func TestPropagateAddresses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	status := &InferenceServiceStatus{
+		URL:     apis.HTTPS("sklearn-default.example.com"),
+		Address: &duckv1.Addressable{URL: apis.HTTP("sklearn.default.svc.cluster.local")},
+		Components: map[ComponentType]ComponentStatusSpec{
+			PredictorComponent: {
+				Traffic: []knservingv1.TrafficTarget{
+					{RevisionName: "sklearn-predictor-00002", Tag: "latest", URL: apis.HTTPS("latest-sklearn-predictor-default.example.com")},
+					{RevisionName: "sklearn-predictor-00001", Tag: "prev", URL: apis.HTTPS("prev-sklearn-predictor-default.example.com")},
+					{RevisionName: "sklearn-predictor-00002"},
+				},
+			},
+		},
+	}
+	status.PropagateAddresses(true)
+	g.Expect(status.Addresses).To(gomega.Equal([]InferenceServiceAddress{
+		{Name: "external", Type: ExternalURLAddress, URL: apis.HTTPS("sklearn-default.example.com")},
+		{Name: "cluster-local", Type: ClusterLocalURLAddress, URL: apis.HTTP("sklearn.default.svc.cluster.local")},
+		{Name: "grpc", Type: GRPCTargetAddress, Target: "sklearn-default.example.com:443"},
+		{Name: "grpc-cluster-local", Type: GRPCTargetAddress, Target: "sklearn.default.svc.cluster.local:80"},
+		{
+			Name: "predictor-latest", Type: RevisionURLAddress, Component: PredictorComponent,
+			Revision: "sklearn-predictor-00002", URL: apis.HTTPS("latest-sklearn-predictor-default.example.com"),
+		},
+		{
+			Name: "predictor-prev", Type: RevisionURLAddress, Component: PredictorComponent,
+			Revision: "sklearn-predictor-00001", URL: apis.HTTPS("prev-sklearn-predictor-default.example.com"),
+		},
+	}))
+
+	// The cluster local InferenceServices have no external address
+	status = &InferenceServiceStatus{
+		URL:     apis.HTTP("sklearn.default.svc.cluster.local"),
+		Address: &duckv1.Addressable{URL: apis.HTTP("sklearn.default.svc.cluster.local")},
+	}
+	status.PropagateAddresses(false)
+	g.Expect(status.Addresses).To(gomega.Equal([]InferenceServiceAddress{
+		{Name: "cluster-local", Type: ClusterLocalURLAddress, URL: apis.HTTP("sklearn.default.svc.cluster.local")},
+	}))
+
+	status = &InferenceServiceStatus{}
+	status.PropagateAddresses(true)
+	g.Expect(status.Addresses).To(gomega.BeEmpty())
+}
+
 func TestPropagateRawStatusWithMessages(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -378,6 +492,89 @@ func TestPropagateStatus(t *testing.T) {
 	}
 }
 
+func TestPropagateStatusShadowTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := InferenceServiceStatus{
+		Components: map[ComponentType]ComponentStatusSpec{
+			PredictorComponent: {
+				PreviousRolledoutRevision: "test-predictor-default-0001",
+				LatestRolledoutRevision:   "test-predictor-default-0002",
+			},
+		},
+	}
+	serviceStatus := func(latestRevision string) *knservingv1.ServiceStatus {
+		return &knservingv1.ServiceStatus{
+			ConfigurationStatusFields: knservingv1.ConfigurationStatusFields{
+				LatestReadyRevisionName:   latestRevision,
+				LatestCreatedRevisionName: latestRevision,
+			},
+			RouteStatusFields: knservingv1.RouteStatusFields{
+				Traffic: []knservingv1.TrafficTarget{
+					{
+						RevisionName:   latestRevision,
+						Percent:        proto.Int64(0),
+						LatestRevision: proto.Bool(true),
+						Tag:            constants.ShadowTrafficTag,
+					},
+					{
+						RevisionName:   "test-predictor-default-0002",
+						Percent:        proto.Int64(100),
+						LatestRevision: proto.Bool(false),
+						Tag:            "prev",
+					},
+				},
+			},
+		}
+	}
+
+	// The rolled out revision is not rolled back while no candidate revision is created
+	status.PropagateStatus(PredictorComponent, serviceStatus("test-predictor-default-0002"))
+	g.Expect(status.Components[PredictorComponent].LatestRolledoutRevision).To(gomega.Equal("test-predictor-default-0002"))
+
+	// The candidate revision receiving the mirrored traffic is not rolled out
+	status.PropagateStatus(PredictorComponent, serviceStatus("test-predictor-default-0003"))
+	g.Expect(status.Components[PredictorComponent].LatestRolledoutRevision).To(gomega.Equal("test-predictor-default-0002"))
+	g.Expect(status.Components[PredictorComponent].LatestReadyRevision).To(gomega.Equal("test-predictor-default-0003"))
+}
+
+func TestPropagateStatusInferenceProbeTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := InferenceServiceStatus{
+		Components: map[ComponentType]ComponentStatusSpec{
+			PredictorComponent: {
+				PreviousRolledoutRevision: "test-predictor-default-0001",
+				LatestRolledoutRevision:   "test-predictor-default-0002",
+			},
+		},
+	}
+	serviceStatus := &knservingv1.ServiceStatus{
+		ConfigurationStatusFields: knservingv1.ConfigurationStatusFields{
+			LatestReadyRevisionName:   "test-predictor-default-0002",
+			LatestCreatedRevisionName: "test-predictor-default-0002",
+		},
+		RouteStatusFields: knservingv1.RouteStatusFields{
+			Traffic: []knservingv1.TrafficTarget{
+				{
+					RevisionName:   "test-predictor-default-0002",
+					Percent:        proto.Int64(0),
+					LatestRevision: proto.Bool(true),
+					Tag:            constants.InferenceProbeTrafficTag,
+				},
+				{
+					RevisionName:   "test-predictor-default-0002",
+					Percent:        proto.Int64(100),
+					LatestRevision: proto.Bool(false),
+					Tag:            "prev",
+				},
+			},
+		},
+	}
+
+	// The rolled out revision held for the inference probe while no candidate revision is created is not rolled back
+	status.PropagateStatus(PredictorComponent, serviceStatus)
+	g.Expect(status.Components[PredictorComponent].LatestRolledoutRevision).To(gomega.Equal("test-predictor-default-0002"))
+}
+
 func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -1915,3 +2112,130 @@ func TestInferenceServiceStatus_ClearCondition(t *testing.T) {
 	// Try clearing a condition that was never set
 	status.ClearCondition(TransformerReady)
 }
+
+func TestPropagateWarmStandby(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	spec := &WarmStandbySpec{SoakSeconds: 600}
+	status := &InferenceServiceStatus{Components: map[ComponentType]ComponentStatusSpec{
+		PredictorComponent: {LatestRolledoutRevision: "sklearn-predictor-00002", PreviousRolledoutRevision: "sklearn-predictor-00001"},
+	}}
+
+	// The soak period starts with the rollout of the latest revision
+	status.PropagateWarmStandby(PredictorComponent, spec, now)
+	g.Expect(status.Components[PredictorComponent].WarmStandby).To(gomega.Equal(&WarmStandbyStatus{
+		Revision:    "sklearn-predictor-00001",
+		Replicas:    DefaultWarmStandbyReplicas,
+		State:       WarmStandbySoaking,
+		SoakEndTime: metav1.NewTime(now.Add(10 * time.Minute)),
+	}))
+	g.Expect(status.WarmStandbyRequeueAfter(now.Add(time.Minute))).To(gomega.Equal(9 * time.Minute))
+
+	// The soak period is not restarted by the following reconciliations
+	spec.Replicas = proto.Int32(2)
+	status.PropagateWarmStandby(PredictorComponent, spec, now.Add(5*time.Minute))
+	g.Expect(status.Components[PredictorComponent].WarmStandby.SoakEndTime.Time).To(gomega.Equal(now.Add(10 * time.Minute)))
+	g.Expect(status.Components[PredictorComponent].WarmStandby.Replicas).To(gomega.Equal(int32(2)))
+
+	// The revision is no longer kept warm once the soak period ended
+	status.PropagateWarmStandby(PredictorComponent, spec, now.Add(10*time.Minute))
+	g.Expect(status.Components[PredictorComponent].WarmStandby.State).To(gomega.Equal(WarmStandbyExpired))
+	g.Expect(status.WarmStandbyRequeueAfter(now.Add(10 * time.Minute))).To(gomega.BeZero())
+
+	// The next rollout starts a new soak period for the revision it replaces
+	predictorStatus := status.Components[PredictorComponent]
+	predictorStatus.PreviousRolledoutRevision = "sklearn-predictor-00002"
+	predictorStatus.LatestRolledoutRevision = "sklearn-predictor-00003"
+	status.Components[PredictorComponent] = predictorStatus
+	status.PropagateWarmStandby(PredictorComponent, spec, now.Add(time.Hour))
+	g.Expect(status.Components[PredictorComponent].WarmStandby.Revision).To(gomega.Equal("sklearn-predictor-00002"))
+	g.Expect(status.Components[PredictorComponent].WarmStandby.IsSoaking(now.Add(time.Hour))).To(gomega.BeTrue())
+
+	// The warm standby is removed when it is no longer configured
+	status.PropagateWarmStandby(PredictorComponent, nil, now.Add(time.Hour))
+	g.Expect(status.Components[PredictorComponent].WarmStandby).To(gomega.BeNil())
+}
+
+func TestBlueGreenRequeueAfter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := &InferenceServiceStatus{Components: map[ComponentType]ComponentStatusSpec{
+		PredictorComponent:   {},
+		TransformerComponent: {},
+	}}
+	g.Expect(status.BlueGreenRequeueAfter(now)).To(gomega.BeZero())
+
+	// The end of the rollback window is waited for
+	windowEnd := metav1.NewTime(now.Add(10 * time.Minute))
+	status.PropagateBlueGreenStatus(PredictorComponent, &BlueGreenStatus{
+		Phase:                 BlueGreenSwitched,
+		ActiveTemplateHash:    "5d9f6c7b8a",
+		SwitchTime:            &metav1.Time{Time: now},
+		RollbackWindowEndTime: &windowEnd,
+	})
+	g.Expect(status.BlueGreenRequeueAfter(now.Add(time.Minute))).To(gomega.Equal(9 * time.Minute))
+	g.Expect(status.BlueGreenRequeueAfter(now.Add(time.Hour))).To(gomega.Equal(time.Second))
+
+	// The failed smoke test is retried
+	status.PropagateBlueGreenStatus(TransformerComponent, &BlueGreenStatus{
+		Phase:               BlueGreenPreviewing,
+		ActiveTemplateHash:  "5d9f6c7b8a",
+		PreviewTemplateHash: "7c4e2a1f9b",
+		Message:             "smoke test failed: GET /v1/models/sklearn returned the status code 503",
+	})
+	g.Expect(status.BlueGreenRequeueAfter(now.Add(time.Minute))).To(gomega.Equal(DefaultBlueGreenSmokeTestRetryInterval))
+
+	// The status is removed once the blue-green strategy is disabled
+	status.PropagateBlueGreenStatus(PredictorComponent, nil)
+	status.PropagateBlueGreenStatus(TransformerComponent, nil)
+	g.Expect(status.Components[PredictorComponent].BlueGreen).To(gomega.BeNil())
+	g.Expect(status.BlueGreenRequeueAfter(now)).To(gomega.BeZero())
+}
+
+func TestPropagateModelStatus_ResolvedRevisions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	storageInitializerPod := func(state corev1.ContainerState) *corev1.PodList {
+		return &corev1.PodList{Items: []corev1.Pod{{
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: constants.StorageInitializerContainerName, State: state},
+				},
+			},
+		}}}
+	}
+	scenarios := map[string]struct {
+		state    corev1.ContainerState
+		expected map[string]string
+	}{
+		"storage initializer succeeded": {
+			state: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 0,
+				Reason:   "Completed",
+				Message:  `{"resolvedRevisions":{"hf://meta-llama/Llama-3.1-8B:main":"0e9e39f249a16976918f6564b8830bc894c89659"}}`,
+			}},
+			expected: map[string]string{"hf://meta-llama/Llama-3.1-8B:main": "0e9e39f249a16976918f6564b8830bc894c89659"},
+		},
+		"storage initializer failed": {
+			state: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1,
+				Reason:   constants.StateReasonError,
+				Message:  "Repository Not Found",
+			}},
+		},
+		"storage initializer without termination message": {
+			state: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+		},
+		"storage initializer running": {
+			state: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			status := &InferenceServiceStatus{}
+			status.PropagateModelStatus(ComponentStatusSpec{}, storageInitializerPod(scenario.state), true, &knservingv1.ServiceStatus{})
+			g.Expect(status.ModelStatus.ResolvedRevisions).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"regexp"
 	"slices"
@@ -28,12 +29,19 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"knative.dev/serving/pkg/apis/autoscaling"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 )
@@ -60,7 +68,15 @@ var (
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
-type InferenceServiceValidator struct{}
+type InferenceServiceValidator struct {
+	// Client looks up the runtime selected by the InferenceService, to warn when the runtime is deprecated.
+	// The runtime is not looked up when the client is unset.
+	Client client.Client
+	// Clientset reads the price table of the cost estimation and the validation policy of the unknown annotations
+	// from the inferenceservice configmap. The cost is not estimated and the annotations are not validated when the
+	// clientset is unset.
+	Clientset kubernetes.Interface
+}
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-inferenceservices,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,versions=v1beta1,name=inferenceservice.kserve-webhook-server.validator
 var _ webhook.CustomValidator = &InferenceServiceValidator{}
@@ -73,7 +89,23 @@ func (v *InferenceServiceValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, err
 	}
 	validatorLogger.Info("validate create", "name", isvc.Name)
-	return validateInferenceService(isvc)
+	warnings, err := validateInferenceService(isvc)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validateSharedModelLibraries(ctx, isvc); err != nil {
+		return warnings, err
+	}
+	if err := v.validateServiceAccounts(ctx, isvc); err != nil {
+		return warnings, err
+	}
+	annotationWarnings, err := v.unknownAnnotationWarnings(ctx, isvc)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, annotationWarnings...)
+	warnings = append(warnings, v.deprecatedRuntimeWarnings(ctx, isvc)...)
+	return append(warnings, v.costEstimationWarnings(ctx, isvc)...), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -92,7 +124,23 @@ func (v *InferenceServiceValidator) ValidateUpdate(ctx context.Context, oldObj,
 	if err != nil {
 		return nil, err
 	}
-	return validateInferenceService(isvc)
+	warnings, err := validateInferenceService(isvc)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validateSharedModelLibraries(ctx, isvc); err != nil {
+		return warnings, err
+	}
+	if err := v.validateServiceAccounts(ctx, isvc); err != nil {
+		return warnings, err
+	}
+	annotationWarnings, err := v.unknownAnnotationWarnings(ctx, isvc)
+	if err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, annotationWarnings...)
+	warnings = append(warnings, v.deprecatedRuntimeWarnings(ctx, isvc)...)
+	return append(warnings, v.costEstimationWarnings(ctx, isvc)...), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -106,6 +154,167 @@ func (v *InferenceServiceValidator) ValidateDelete(ctx context.Context, obj runt
 	return nil, nil
 }
 
+// validateSharedModelLibraries validates the predictor storage URIs on the PVCs shared by many InferenceServices of the
+// namespace, which must be mounted read-only at a sub path not overlapping the paths of the other InferenceServices.
+// The PVCs are not looked up when the client is unset.
+func (v *InferenceServiceValidator) validateSharedModelLibraries(ctx context.Context, isvc *InferenceService) error {
+	if v.Client == nil {
+		return nil
+	}
+	var otherIsvcs *InferenceServiceList
+	for _, storageURI := range predictorPvcStorageURIs(isvc) {
+		pvcName, pvcPath, err := utils.ParsePvcURI(storageURI)
+		if err != nil {
+			return err
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: pvcName}, pvc); err != nil {
+			if apierr.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !utils.IsSharedModelLibrary(pvc) {
+			continue
+		}
+		if !slices.Contains(pvc.Spec.AccessModes, corev1.ReadWriteMany) && !slices.Contains(pvc.Spec.AccessModes, corev1.ReadOnlyMany) {
+			return fmt.Errorf(SharedModelLibraryAccessModeError, isvc.Name, pvcName)
+		}
+		if strings.Trim(pvcPath, "/") == "" {
+			return fmt.Errorf(SharedModelLibrarySubPathError, isvc.Name, storageURI, pvcName)
+		}
+		if isvc.Annotations[constants.StorageReadonlyAnnotationKey] == "false" {
+			return fmt.Errorf(SharedModelLibraryReadOnlyError, isvc.Name, pvcName)
+		}
+
+		if otherIsvcs == nil {
+			otherIsvcs = &InferenceServiceList{}
+			if err := v.Client.List(ctx, otherIsvcs, client.InNamespace(isvc.Namespace)); err != nil {
+				return err
+			}
+		}
+		for i := range otherIsvcs.Items {
+			other := &otherIsvcs.Items[i]
+			if other.Name == isvc.Name {
+				continue
+			}
+			for _, otherStorageURI := range predictorPvcStorageURIs(other) {
+				otherPvcName, otherPvcPath, err := utils.ParsePvcURI(otherStorageURI)
+				if err != nil || otherPvcName != pvcName {
+					continue
+				}
+				if utils.PvcSubPathsCollide(pvcPath, otherPvcPath) {
+					return fmt.Errorf(SharedModelLibraryPathCollisionError, isvc.Name, pvcPath, pvcName, otherPvcPath, other.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// predictorPvcStorageURIs returns the PVC storage URIs of the predictor
+func predictorPvcStorageURIs(isvc *InferenceService) []string {
+	var storageURIs []string
+	if implementations := isvc.Spec.Predictor.GetImplementations(); len(implementations) > 0 {
+		if storageURI := implementations[0].GetStorageUri(); storageURI != nil && strings.HasPrefix(*storageURI, constants.PvcURIPrefix) {
+			storageURIs = append(storageURIs, *storageURI)
+		}
+	}
+	for _, storageURI := range isvc.Spec.Predictor.StorageUris {
+		if strings.HasPrefix(storageURI.Uri, constants.PvcURIPrefix) {
+			storageURIs = append(storageURIs, storageURI.Uri)
+		}
+	}
+	return storageURIs
+}
+
+// deprecatedRuntimeWarnings warns when the runtime selected by the predictor model, either explicitly or by automatic
+// selection, is deprecated. A failure to look up the runtime is left to the controller to report.
+func (v *InferenceServiceValidator) deprecatedRuntimeWarnings(ctx context.Context, isvc *InferenceService) admission.Warnings {
+	if v.Client == nil {
+		return nil
+	}
+	name, spec, err := getPredictorRuntime(ctx, v.Client, isvc)
+	if err != nil {
+		validatorLogger.Error(err, "Unable to get the predictor runtime", "name", isvc.Name)
+		return nil
+	}
+	if spec == nil || !spec.IsDeprecated() {
+		return nil
+	}
+	warning := fmt.Sprintf("the runtime %q selected by the InferenceService %q is deprecated", name, isvc.Name)
+	if spec.ReplacedBy != nil && *spec.ReplacedBy != "" {
+		warning += fmt.Sprintf(", migrate to the runtime %q", *spec.ReplacedBy)
+	}
+	return admission.Warnings{warning}
+}
+
+// getPredictorRuntime returns the name and the spec of the runtime selected by the predictor model, either explicitly
+// or by automatic selection. The spec is nil when the predictor has no model or no runtime is found.
+func getPredictorRuntime(ctx context.Context, cl client.Client, isvc *InferenceService) (string, *v1alpha1.ServingRuntimeSpec, error) {
+	model := isvc.Spec.Predictor.Model
+	if model == nil {
+		return "", nil, nil
+	}
+	if model.Runtime != nil {
+		name := *model.Runtime
+		servingRuntime := &v1alpha1.ServingRuntime{}
+		err := cl.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: name}, servingRuntime)
+		if err == nil {
+			return name, &servingRuntime.Spec, nil
+		} else if !apierr.IsNotFound(err) {
+			return "", nil, err
+		}
+		clusterRuntime := &v1alpha1.ClusterServingRuntime{}
+		err = cl.Get(ctx, types.NamespacedName{Name: name}, clusterRuntime)
+		if err == nil {
+			return name, &clusterRuntime.Spec, nil
+		} else if !apierr.IsNotFound(err) {
+			return "", nil, err
+		}
+		return "", nil, nil
+	}
+	runtimes, err := model.GetSupportingRuntimes(ctx, cl, isvc.Namespace, false, isvc.Spec.Predictor.WorkerSpec != nil)
+	if err != nil || len(runtimes) == 0 {
+		return "", nil, err
+	}
+	return runtimes[0].Name, &runtimes[0].Spec, nil
+}
+
+// validateServiceAccounts validates that the ServiceAccounts of the components exist in the namespace of the
+// InferenceService. The ServiceAccounts are not looked up when the clientset is unset.
+func (v *InferenceServiceValidator) validateServiceAccounts(ctx context.Context, isvc *InferenceService) error {
+	if v.Clientset == nil {
+		return nil
+	}
+	podSpecs := []*PodSpec{&isvc.Spec.Predictor.PodSpec}
+	componentTypes := []ComponentType{PredictorComponent}
+	if isvc.Spec.Transformer != nil {
+		podSpecs = append(podSpecs, &isvc.Spec.Transformer.PodSpec)
+		componentTypes = append(componentTypes, TransformerComponent)
+	}
+	if isvc.Spec.Explainer != nil {
+		podSpecs = append(podSpecs, &isvc.Spec.Explainer.PodSpec)
+		componentTypes = append(componentTypes, ExplainerComponent)
+	}
+	for i, podSpec := range podSpecs {
+		serviceAccountName := podSpec.ServiceAccountName
+		if serviceAccountName == "" {
+			serviceAccountName = podSpec.DeprecatedServiceAccount
+		}
+		if serviceAccountName == "" {
+			continue
+		}
+		_, err := v.Clientset.CoreV1().ServiceAccounts(isvc.Namespace).Get(ctx, serviceAccountName, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			return fmt.Errorf(ServiceAccountNotFoundError, isvc.Name, serviceAccountName, componentTypes[i], isvc.Namespace)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateInferenceService(isvc *InferenceService) (admission.Warnings, error) {
 	var allWarnings admission.Warnings
 	annotations := isvc.Annotations
@@ -134,6 +343,70 @@ func validateInferenceService(isvc *InferenceService) (admission.Warnings, error
 		return allWarnings, err
 	}
 
+	if err := validateFailover(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateABTest(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateDependencies(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validatePredictorModels(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateCapacity(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateStorageFilePatterns(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateDualProtocol(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateIdleTimeout(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateServerTLS(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateActivator(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateContainerLifecycle(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateCanaryAnalysis(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateShadowTraffic(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateBlueGreen(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateMonitoring(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validateObservability(isvc); err != nil {
+		return allWarnings, err
+	}
+
 	for _, component := range []Component{
 		&isvc.Spec.Predictor,
 		isvc.Spec.Transformer,
@@ -257,13 +530,24 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 
 	switch deploymentMode {
 	case string(constants.Standard):
+		if compExtSpec.WarmStandby != nil {
+			return errors.New("warmStandby is only supported for serverless deployment mode")
+		}
 		switch autoscalerClass {
 		case string(constants.AutoscalerClassHPA):
 			return validateScalingHPACompExtension(compExtSpec)
 		case string(constants.AutoscalerClassKeda):
 			return validateScalingKedaCompExtension(compExtSpec)
+		case "":
+		default:
+			if len(compExtSpec.ScaleSchedule) > 0 {
+				return errors.New("scaleSchedule is only supported with the hpa or keda autoscaler class")
+			}
 		}
 	default:
+		if len(compExtSpec.ScaleSchedule) > 0 {
+			return errors.New("scaleSchedule is only supported for Standard deployment mode")
+		}
 		if annotationClass == autoscaling.HPA {
 			return validateScalingHPACompExtension(compExtSpec)
 		}
@@ -280,6 +564,393 @@ func validateInferenceServiceName(isvc *InferenceService) error {
 	return nil
 }
 
+// Validation of the failover target
+func validateFailover(isvc *InferenceService) error {
+	if isvc.Spec.Failover == nil {
+		return nil
+	}
+	targetName := isvc.Spec.Failover.TargetRef.Name
+	if targetName == isvc.Name {
+		return fmt.Errorf(InvalidFailoverTargetSelfError, isvc.Name)
+	}
+	if !IsvcRegexp.MatchString(targetName) {
+		return fmt.Errorf(InvalidISVCNameFormatError, targetName, IsvcNameFmt)
+	}
+	return nil
+}
+
+// Validation of the model variants of the A/B test
+func validateABTest(isvc *InferenceService) error {
+	if isvc.Spec.ABTest == nil {
+		return nil
+	}
+	if constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) == constants.ModelMeshDeployment {
+		return fmt.Errorf(InvalidABTestError, isvc.Name, "is not supported in the ModelMesh deployment mode")
+	}
+	if isvc.Spec.Predictor.ShadowTrafficPercent != nil {
+		return fmt.Errorf(InvalidABTestError, isvc.Name, "can not be set together with the shadow traffic")
+	}
+	names := map[string]bool{}
+	self := false
+	var total int32
+	for _, variant := range isvc.Spec.ABTest.Variants {
+		if names[variant.Name] {
+			return fmt.Errorf(InvalidABTestError, isvc.Name, fmt.Sprintf("variant %q is declared more than once", variant.Name))
+		}
+		names[variant.Name] = true
+		if variant.Weight < 0 || variant.Weight > 100 {
+			return fmt.Errorf(InvalidABTestError, isvc.Name, fmt.Sprintf("weight of the variant %q must be between 0 and 100", variant.Name))
+		}
+		total += variant.Weight
+		if variant.TargetRef == nil {
+			if self {
+				return fmt.Errorf(InvalidABTestError, isvc.Name, "can only declare one variant served by the InferenceService itself")
+			}
+			self = true
+			continue
+		}
+		if variant.TargetRef.Name == isvc.Name {
+			return fmt.Errorf(InvalidABTestError, isvc.Name, fmt.Sprintf("variant %q must not reference the InferenceService itself, omit the targetRef instead", variant.Name))
+		}
+		if !IsvcRegexp.MatchString(variant.TargetRef.Name) {
+			return fmt.Errorf(InvalidISVCNameFormatError, variant.TargetRef.Name, IsvcNameFmt)
+		}
+	}
+	if len(isvc.Spec.ABTest.Variants) < 2 {
+		return fmt.Errorf(InvalidABTestError, isvc.Name, "must declare at least two variants")
+	}
+	if total != 100 {
+		return fmt.Errorf(InvalidABTestError, isvc.Name, fmt.Sprintf("weights of the variants must add up to 100, got %d", total))
+	}
+	return nil
+}
+
+// Validation of the resources the InferenceService depends on
+func validateDependencies(isvc *InferenceService) error {
+	declared := map[DependencyReference]bool{}
+	for _, dependency := range isvc.Spec.DependsOn {
+		if dependency.Kind == InferenceServiceDependency && dependency.Name == isvc.Name {
+			return fmt.Errorf(InvalidDependencySelfError, isvc.Name)
+		}
+		if declared[dependency] {
+			return fmt.Errorf(DuplicateDependencyError, isvc.Name, dependency.Kind, dependency.Name)
+		}
+		declared[dependency] = true
+	}
+	return nil
+}
+
+// Validation of the models declared in the predictor
+func validatePredictorModels(isvc *InferenceService) error {
+	models := isvc.Spec.Predictor.Models
+	if len(models) == 0 {
+		return nil
+	}
+	if len(isvc.Spec.Predictor.GetImplementations()) > 0 && isvc.Spec.Predictor.GetImplementation().GetStorageUri() != nil {
+		return fmt.Errorf(InvalidPredictorModelsStorageUriError, isvc.Name)
+	}
+	names := make(map[string]bool, len(models))
+	for _, model := range models {
+		if names[model.Name] {
+			return fmt.Errorf(DuplicatePredictorModelNameError, isvc.Name, model.Name)
+		}
+		names[model.Name] = true
+	}
+	return nil
+}
+
+// Validation of the split of the predictor replicas between spot and on-demand nodes
+func validateCapacity(isvc *InferenceService) error {
+	if isvc.Spec.Predictor.Capacity == nil {
+		return nil
+	}
+	if isvc.Spec.Predictor.WorkerSpec != nil {
+		return fmt.Errorf(InvalidCapacityWorkerSpecError, isvc.Name)
+	}
+	return nil
+}
+
+// Validation of the protocols served by a dual protocol predictor
+func validateDualProtocol(isvc *InferenceService) error {
+	if !isvc.Spec.Predictor.DualProtocol || len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+		return nil
+	}
+	if _, ok := isvc.Spec.Predictor.GetDualProtocolNativeProtocol(isvc.Annotations); !ok {
+		return fmt.Errorf(UnsupportedDualProtocolError, isvc.Name, isvc.Spec.Predictor.GetImplementation().GetProtocol())
+	}
+	return nil
+}
+
+// Validation of the idle timeout, which must cover a few scrapes of the route metrics
+func validateIdleTimeout(isvc *InferenceService) error {
+	if isvc.Spec.IdleTimeout == nil {
+		return nil
+	}
+	if duration := isvc.Spec.IdleTimeout.Duration.Duration; duration < MinIdleTimeout {
+		return fmt.Errorf(InvalidIdleTimeoutDurationError, isvc.Name, duration, MinIdleTimeout)
+	}
+	// The requests are counted from the metrics of the queue-proxy
+	switch deploymentMode := constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]); deploymentMode {
+	case constants.Standard, constants.LegacyRawDeployment, constants.ModelMeshDeployment:
+		return fmt.Errorf(UnsupportedIdleTimeoutError, isvc.Name, deploymentMode)
+	}
+	return nil
+}
+
+// Validation of the predictor server TLS, the agent and the transformer call the predictor over HTTP
+func validateServerTLS(isvc *InferenceService) error {
+	if isvc.Spec.Transport == nil || isvc.Spec.Transport.ServerTLS == nil {
+		return nil
+	}
+	serverTLS := isvc.Spec.Transport.ServerTLS
+	// The certificate is mounted in the pods of the predictor Deployment, the queue-proxy calls the predictor over HTTP
+	switch deploymentMode := constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]); deploymentMode {
+	case constants.Knative, constants.LegacyServerless, constants.ModelMeshDeployment:
+		return fmt.Errorf(UnsupportedServerTLSDeploymentModeError, isvc.Name, deploymentMode)
+	}
+	if serverTLS.Provider == SPIRECertificateProvider && (serverTLS.IssuerRef != nil || serverTLS.Rotation == RestartCertificateRotation) {
+		return fmt.Errorf(InvalidSPIREServerTLSError, isvc.Name)
+	}
+	var unsupported []string
+	if isvc.Spec.Transformer != nil {
+		unsupported = append(unsupported, "a transformer")
+	}
+	if isvc.Spec.Predictor.Logger != nil {
+		unsupported = append(unsupported, "the logger")
+	}
+	if isvc.Spec.Predictor.Batcher != nil {
+		unsupported = append(unsupported, "the batcher")
+	}
+	if isvc.Spec.Predictor.DualProtocol {
+		unsupported = append(unsupported, "the dual protocol")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf(UnsupportedServerTLSError, isvc.Name, strings.Join(unsupported, " and "))
+	}
+	return nil
+}
+
+// Validation of the activator, which is deployed in front of the raw deployments scaled to zero and scales them from
+// zero either itself with the HPA, or through the KEDA HTTP add-on managing the replicas as an external autoscaler
+func validateActivator(isvc *InferenceService) error {
+	activator, ok := isvc.Annotations[constants.ActivatorAnnotationKey]
+	if !ok {
+		return nil
+	}
+	switch constants.ActivatorType(activator) {
+	case constants.ActivatorBuiltin, constants.ActivatorKedaHTTP:
+	default:
+		return fmt.Errorf(InvalidActivatorTypeError, isvc.Name, activator)
+	}
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Knative, constants.LegacyServerless, constants.ModelMeshDeployment:
+		return fmt.Errorf(UnsupportedActivatorError, isvc.Name, "is only supported in the Standard deployment mode")
+	}
+	if isvc.Spec.Predictor.WorkerSpec != nil {
+		return fmt.Errorf(UnsupportedActivatorError, isvc.Name, "is not supported with workerSpec")
+	}
+	autoscalerClass := constants.AutoscalerClassType(isvc.Annotations[constants.AutoscalerClass])
+	switch constants.ActivatorType(activator) {
+	case constants.ActivatorBuiltin:
+		if autoscalerClass != "" && autoscalerClass != constants.AutoscalerClassHPA {
+			return fmt.Errorf(UnsupportedActivatorError, isvc.Name, "builtin requires the hpa autoscaler class")
+		}
+	case constants.ActivatorKedaHTTP:
+		if autoscalerClass != constants.AutoscalerClassExternal {
+			return fmt.Errorf(UnsupportedActivatorError, isvc.Name,
+				"keda-http requires the external autoscaler class, the replicas are managed by the KEDA HTTP add-on")
+		}
+	}
+	for _, component := range []Component{
+		&isvc.Spec.Predictor,
+		isvc.Spec.Transformer,
+		isvc.Spec.Explainer,
+	} {
+		if reflect.ValueOf(component).IsNil() {
+			continue
+		}
+		if minReplicas := component.GetExtensions().MinReplicas; minReplicas != nil && *minReplicas == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf(UnsupportedActivatorError, isvc.Name, "requires a component with minReplicas 0")
+}
+
+// Validation of the lifecycle hooks and the restart policy of the predictor containers, which Knative does not allow
+func validateContainerLifecycle(isvc *InferenceService) error {
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Knative, constants.LegacyServerless:
+	default:
+		return nil
+	}
+	containers := isvc.Spec.Predictor.Containers
+	if isvc.Spec.Predictor.Model != nil {
+		containers = append([]corev1.Container{isvc.Spec.Predictor.Model.Container}, containers...)
+	}
+	for _, container := range containers {
+		if container.Lifecycle != nil || container.RestartPolicy != nil || len(container.RestartPolicyRules) > 0 {
+			name := container.Name
+			if name == "" {
+				name = constants.InferenceServiceContainerName
+			}
+			return fmt.Errorf(UnsupportedContainerLifecycleError, isvc.Name, name)
+		}
+	}
+	return nil
+}
+
+// Validation of the canary analysis of the components, which promotes the canary revisions of Knative
+func validateCanaryAnalysis(isvc *InferenceService) error {
+	knative := false
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Knative, constants.LegacyServerless:
+		knative = true
+	}
+	for _, component := range []struct {
+		componentType ComponentType
+		component     Component
+	}{
+		{PredictorComponent, &isvc.Spec.Predictor},
+		{TransformerComponent, isvc.Spec.Transformer},
+		{ExplainerComponent, isvc.Spec.Explainer},
+	} {
+		if reflect.ValueOf(component.component).IsNil() || component.component.GetExtensions().CanaryAnalysis == nil {
+			continue
+		}
+		componentType, analysis := component.componentType, component.component.GetExtensions().CanaryAnalysis
+		switch {
+		case !knative:
+			return fmt.Errorf(UnsupportedCanaryAnalysisError, isvc.Name, componentType, "is only supported in the Knative deployment mode")
+		case analysis.MinSuccessRate == nil && analysis.MaxLatencyMilliseconds == nil:
+			return fmt.Errorf(UnsupportedCanaryAnalysisError, isvc.Name, componentType, "requires minSuccessRate or maxLatencyMilliseconds")
+		case analysis.Interval != nil && analysis.Interval.Duration <= 0:
+			return fmt.Errorf(UnsupportedCanaryAnalysisError, isvc.Name, componentType, "requires a positive interval")
+		}
+	}
+	return nil
+}
+
+// Validation of the shadow traffic mirrored to the candidate revision of the predictor by the Istio virtual service
+func validateShadowTraffic(isvc *InferenceService) error {
+	for _, component := range []Component{isvc.Spec.Transformer, isvc.Spec.Explainer} {
+		if !reflect.ValueOf(component).IsNil() && component.GetExtensions().ShadowTrafficPercent != nil {
+			return fmt.Errorf(UnsupportedShadowTrafficError, isvc.Name, "is only supported on the predictor")
+		}
+	}
+	predictor := isvc.Spec.Predictor.GetExtensions()
+	if predictor.ShadowTrafficPercent == nil {
+		return nil
+	}
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Knative, constants.LegacyServerless:
+	default:
+		return fmt.Errorf(UnsupportedShadowTrafficError, isvc.Name, "is only supported in the Knative deployment mode")
+	}
+	switch {
+	case isvc.Spec.Transformer != nil:
+		return fmt.Errorf(UnsupportedShadowTrafficError, isvc.Name, "is not supported with a transformer, which receives the traffic of the InferenceService")
+	case predictor.CanaryTrafficPercent != nil || predictor.CanaryAnalysis != nil:
+		return fmt.Errorf(UnsupportedShadowTrafficError, isvc.Name, "can not be set together with the canary traffic")
+	case *predictor.ShadowTrafficPercent < 0 || *predictor.ShadowTrafficPercent > 100:
+		return fmt.Errorf(UnsupportedShadowTrafficError, isvc.Name, "percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// Validation of the OpenTelemetry Collector sidecar, which the Knative revisions do not inject
+func validateObservability(isvc *InferenceService) error {
+	if isvc.Spec.Observability == nil || isvc.Spec.Observability.OtelCollector == nil {
+		return nil
+	}
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Knative, constants.LegacyServerless:
+		return fmt.Errorf(UnsupportedObservabilityError, isvc.Name)
+	}
+	return nil
+}
+
+// Validation of the blue-green strategy of the components, which switches the Service of the Standard deployment mode
+// between two Deployments
+func validateBlueGreen(isvc *InferenceService) error {
+	standard := false
+	switch constants.DeploymentModeType(isvc.Annotations[constants.DeploymentMode]) {
+	case constants.Standard, constants.LegacyRawDeployment:
+		standard = true
+	}
+	for _, component := range []struct {
+		componentType ComponentType
+		component     Component
+	}{
+		{PredictorComponent, &isvc.Spec.Predictor},
+		{TransformerComponent, isvc.Spec.Transformer},
+		{ExplainerComponent, isvc.Spec.Explainer},
+	} {
+		if reflect.ValueOf(component.component).IsNil() {
+			continue
+		}
+		componentType, extensions := component.componentType, component.component.GetExtensions()
+		if !extensions.IsBlueGreen() {
+			if extensions.BlueGreen != nil {
+				return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "requires the deploymentStrategy type BlueGreen")
+			}
+			continue
+		}
+		switch {
+		case !standard:
+			return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "is only supported in the Standard deployment mode")
+		case extensions.MinReplicas != nil && *extensions.MinReplicas == 0:
+			return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "requires at least one replica")
+		case isvc.Annotations[constants.ActivatorAnnotationKey] != "":
+			// the activator and the blue-green switch would both select the pods of the Service of the component
+			return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "is not supported with the activator")
+		case componentType == PredictorComponent && isvc.Spec.Predictor.WorkerSpec != nil:
+			return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "is not supported with multiple nodes")
+		case componentType == PredictorComponent && isvc.Spec.Predictor.Capacity != nil:
+			return fmt.Errorf(UnsupportedBlueGreenError, isvc.Name, componentType, "is not supported with the spot capacity")
+		}
+	}
+	return nil
+}
+
+// Validation of the predictor monitoring, the detector is fed by the logger of the predictor
+func validateMonitoring(isvc *InferenceService) error {
+	monitoring := isvc.Spec.Predictor.Monitoring
+	if monitoring == nil {
+		return nil
+	}
+	if logger := isvc.Spec.Predictor.Logger; logger != nil && (logger.URL != nil || logger.Kafka != nil || logger.Storage != nil) {
+		return fmt.Errorf(UnsupportedMonitoringError, isvc.Name, "requires a predictor logger without url, kafka or storage sink, the payloads are sent to the detector")
+	}
+	if monitoring.DriftBatchSize != nil && monitoring.GetType() != DriftDetector {
+		return fmt.Errorf(UnsupportedMonitoringError, isvc.Name, "driftBatchSize is only supported by the Drift detector")
+	}
+	return nil
+}
+
+// validateStorageFilePatterns validates the include and exclude glob patterns of the predictor storage spec
+func validateStorageFilePatterns(isvc *InferenceService) error {
+	implementations := isvc.Spec.Predictor.GetImplementations()
+	if len(implementations) == 0 {
+		return nil
+	}
+	storageSpec := implementations[0].GetStorageSpec()
+	if storageSpec == nil {
+		return nil
+	}
+	validate := func(kind string, patterns []string) error {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" || strings.HasPrefix(pattern, "/") {
+				return fmt.Errorf(InvalidStorageFilePatternError, isvc.Name, kind, pattern)
+			}
+		}
+		return nil
+	}
+	return utils.FirstNonNilError([]error{
+		validate("include", storageSpec.Include),
+		validate("exclude", storageSpec.Exclude),
+	})
+}
+
 // Validation of isvc autoscaler class
 func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -412,6 +1083,9 @@ func validateScalingKedaCompExtension(compExtSpec *ComponentExtensionSpec) error
 				if metric.External.Metric.Query == "" {
 					return errors.New("the query should not be empty")
 				}
+				if err := validateExternalMetricQuery(&metric.External.Metric); err != nil {
+					return err
+				}
 				if metric.External.Target.Value == nil {
 					return errors.New("the target threshold value should not be empty")
 				}
@@ -431,9 +1105,19 @@ func validateScalingKedaCompExtension(compExtSpec *ComponentExtensionSpec) error
 				if err := validateScaleTarget(metric.PodMetric.Target); err != nil {
 					return err
 				}
+			case RuntimeMetricSourceType:
+				if metric.Runtime == nil {
+					return errors.New("metricSpec.Runtime is not set for runtime metric source type")
+				}
+				if metric.Runtime.Target.Type != AverageValueMetricType {
+					return errors.New("the runtime metric target value type should be AverageValue")
+				}
+				if err := validateScaleTarget(metric.Runtime.Target); err != nil {
+					return err
+				}
 			default:
 				return fmt.Errorf("unknown KEDA metric type with value [%s]."+
-					"Valid types are Resource,External,PodMetric", metricType)
+					"Valid types are Resource,External,PodMetric,Runtime", metricType)
 			}
 		}
 	}
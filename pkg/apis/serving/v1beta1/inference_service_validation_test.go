@@ -17,9 +17,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kserve/kserve/pkg/constants"
 
@@ -30,7 +32,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 )
 
 func TestInvalidNameInSKLearnPredictor(t *testing.T) {
@@ -604,6 +612,19 @@ func TestCustomizeDeploymentStrategyUnsupportedForServerless(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestWarmStandbyUnsupportedForStandard(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Predictor.WarmStandby = &WarmStandbySpec{SoakSeconds: 600}
+	validator := InferenceServiceValidator{}
+	_, err := validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.Annotations = map[string]string{constants.DeploymentMode: string(constants.Standard)}
+	_, err = validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).Should(gomega.MatchError("warmStandby is only supported for serverless deployment mode"))
+}
+
 func TestModelSpecAndCustomOverridesIsValid(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
@@ -808,6 +829,794 @@ func TestRejectBadNameIncludeDot(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestValidateFailover(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		targetName string
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid failover target": {
+			targetName: "foo-secondary",
+			errMatcher: gomega.Succeed(),
+		},
+		"failover target referencing itself": {
+			targetName: "foo",
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidFailoverTargetSelfError, "foo")),
+		},
+		"failover target with invalid name": {
+			targetName: "foo.secondary",
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Failover = &FailoverSpec{
+				TargetRef: FailoverTargetReference{Name: scenario.targetName},
+			}
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateABTest(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		variants   []ModelVariant
+		shadow     bool
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid variants": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 80},
+				{Name: "candidate", TargetRef: &ModelVariantTargetReference{Name: "foo-candidate"}, Weight: 20},
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"weights not adding up to 100": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 80},
+				{Name: "candidate", TargetRef: &ModelVariantTargetReference{Name: "foo-candidate"}, Weight: 10},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidABTestError, "foo", "weights of the variants must add up to 100, got 90")),
+		},
+		"duplicate variant": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 50},
+				{Name: "control", TargetRef: &ModelVariantTargetReference{Name: "foo-candidate"}, Weight: 50},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidABTestError, "foo", `variant "control" is declared more than once`)),
+		},
+		"two variants served by the InferenceService itself": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 50},
+				{Name: "candidate", Weight: 50},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidABTestError, "foo", "can only declare one variant served by the InferenceService itself")),
+		},
+		"variant referencing itself": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 50},
+				{Name: "candidate", TargetRef: &ModelVariantTargetReference{Name: "foo"}, Weight: 50},
+			},
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"single variant": {
+			variants:   []ModelVariant{{Name: "control", Weight: 100}},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidABTestError, "foo", "must declare at least two variants")),
+		},
+		"with shadow traffic": {
+			variants: []ModelVariant{
+				{Name: "control", Weight: 80},
+				{Name: "candidate", TargetRef: &ModelVariantTargetReference{Name: "foo-candidate"}, Weight: 20},
+			},
+			shadow:     true,
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidABTestError, "foo", "can not be set together with the shadow traffic")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.ABTest = &ABTestSpec{Variants: scenario.variants}
+			if scenario.shadow {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(10))
+			}
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateDependencies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		dependsOn  []DependencyReference
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid dependencies": {
+			dependsOn: []DependencyReference{
+				{Kind: SecretDependency, Name: "foo"},
+				{Kind: ConfigMapDependency, Name: "foo"},
+				{Kind: InferenceServiceDependency, Name: "foo-embeddings"},
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"dependency on itself": {
+			dependsOn:  []DependencyReference{{Kind: InferenceServiceDependency, Name: "foo"}},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidDependencySelfError, "foo")),
+		},
+		"duplicate dependency": {
+			dependsOn: []DependencyReference{
+				{Kind: PersistentVolumeClaimDependency, Name: "models"},
+				{Kind: PersistentVolumeClaimDependency, Name: "models"},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(DuplicateDependencyError, "foo", PersistentVolumeClaimDependency, "models")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.DependsOn = scenario.dependsOn
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateDualProtocol(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		protocol    constants.InferenceServiceProtocol
+		annotations map[string]string
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"v1 predictor": {
+			protocol:   constants.ProtocolV1,
+			errMatcher: gomega.Succeed(),
+		},
+		"v2 predictor": {
+			protocol:   constants.ProtocolV2,
+			errMatcher: gomega.Succeed(),
+		},
+		"transcoded v2 gRPC predictor": {
+			protocol:    constants.ProtocolGRPCV2,
+			annotations: map[string]string{constants.GrpcTranscodingPortAnnotationKey: "8081"},
+			errMatcher:  gomega.Succeed(),
+		},
+		"v2 gRPC predictor": {
+			protocol:   constants.ProtocolGRPCV2,
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedDualProtocolError, "foo", constants.ProtocolGRPCV2)),
+		},
+		"v1 gRPC predictor": {
+			protocol:   constants.ProtocolGRPCV1,
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedDualProtocolError, "foo", constants.ProtocolGRPCV1)),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = scenario.annotations
+			isvc.Spec.Predictor.DualProtocol = true
+			isvc.Spec.Predictor.Tensorflow.ProtocolVersion = &scenario.protocol
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateIdleTimeout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.IdleTimeout = &IdleTimeoutSpec{Duration: metav1.Duration{Duration: 24 * time.Hour}, Policy: DeleteIdlePolicy}
+	validator := InferenceServiceValidator{}
+	_, err := validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	isvc.Spec.IdleTimeout.Duration = metav1.Duration{Duration: 30 * time.Second}
+	_, err = validator.ValidateCreate(t.Context(), &isvc)
+	g.Expect(err).To(gomega.MatchError(fmt.Errorf(InvalidIdleTimeoutDurationError, "foo", 30*time.Second, MinIdleTimeout)))
+
+	isvc.Spec.IdleTimeout.Duration = metav1.Duration{Duration: 24 * time.Hour}
+	for _, deploymentMode := range []constants.DeploymentModeType{constants.Standard, constants.LegacyRawDeployment, constants.ModelMeshDeployment} {
+		isvc.Annotations = map[string]string{constants.DeploymentMode: string(deploymentMode)}
+		_, err = validator.ValidateCreate(t.Context(), &isvc)
+		g.Expect(err).To(gomega.MatchError(fmt.Errorf(UnsupportedIdleTimeoutError, "foo", deploymentMode)))
+	}
+}
+
+func TestValidateServerTLS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		serverTLS  *ServerTLSSpec
+		update     func(isvc *InferenceService)
+		errMatcher gomega.OmegaMatcher
+	}{
+		"cert-manager certificate": {
+			serverTLS:  &ServerTLSSpec{Provider: CertManagerCertificateProvider, IssuerRef: &CertificateIssuerReference{Name: "kserve-ca"}, Rotation: RestartCertificateRotation},
+			errMatcher: gomega.Succeed(),
+		},
+		"SPIRE certificate": {
+			serverTLS:  &ServerTLSSpec{Provider: SPIRECertificateProvider, Rotation: ReloadCertificateRotation},
+			errMatcher: gomega.Succeed(),
+		},
+		"SPIRE certificate with issuer": {
+			serverTLS:  &ServerTLSSpec{Provider: SPIRECertificateProvider, IssuerRef: &CertificateIssuerReference{Name: "kserve-ca"}},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidSPIREServerTLSError, "foo")),
+		},
+		"SPIRE certificate restarting the pods": {
+			serverTLS:  &ServerTLSSpec{Provider: SPIRECertificateProvider, Rotation: RestartCertificateRotation},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidSPIREServerTLSError, "foo")),
+		},
+		"certificate with the logger and the batcher": {
+			serverTLS: &ServerTLSSpec{Provider: CertManagerCertificateProvider},
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.Logger = &LoggerSpec{Mode: LogAll}
+				isvc.Spec.Predictor.Batcher = &Batcher{}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedServerTLSError, "foo", "the logger and the batcher")),
+		},
+		"certificate in the Knative deployment mode": {
+			serverTLS: &ServerTLSSpec{Provider: CertManagerCertificateProvider},
+			update: func(isvc *InferenceService) {
+				isvc.Annotations = map[string]string{constants.DeploymentMode: string(constants.Knative)}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedServerTLSDeploymentModeError, "foo", constants.Knative)),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Transport = &TransportSpec{ServerTLS: scenario.serverTLS}
+			if scenario.update != nil {
+				scenario.update(&isvc)
+			}
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateActivator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		minReplicas *int32
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"builtin activator": {
+			annotations: map[string]string{constants.ActivatorAnnotationKey: "builtin"},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher:  gomega.Succeed(),
+		},
+		"keda-http activator": {
+			annotations: map[string]string{
+				constants.ActivatorAnnotationKey: "keda-http",
+				constants.AutoscalerClass:        string(constants.AutoscalerClassExternal),
+			},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher:  gomega.Succeed(),
+		},
+		"unknown activator": {
+			annotations: map[string]string{constants.ActivatorAnnotationKey: "knative"},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher:  gomega.MatchError(fmt.Errorf(InvalidActivatorTypeError, "foo", "knative")),
+		},
+		"activator in Knative mode": {
+			annotations: map[string]string{
+				constants.ActivatorAnnotationKey: "builtin",
+				constants.DeploymentMode:         string(constants.Knative),
+			},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher:  gomega.MatchError(fmt.Errorf(UnsupportedActivatorError, "foo", "is only supported in the Standard deployment mode")),
+		},
+		"builtin activator with keda": {
+			annotations: map[string]string{
+				constants.ActivatorAnnotationKey: "builtin",
+				constants.AutoscalerClass:        string(constants.AutoscalerClassKeda),
+			},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher:  gomega.MatchError(fmt.Errorf(UnsupportedActivatorError, "foo", "builtin requires the hpa autoscaler class")),
+		},
+		"keda-http activator with hpa": {
+			annotations: map[string]string{constants.ActivatorAnnotationKey: "keda-http"},
+			minReplicas: ptr.To(int32(0)),
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedActivatorError, "foo",
+				"keda-http requires the external autoscaler class, the replicas are managed by the KEDA HTTP add-on")),
+		},
+		"activator without a component scaled to zero": {
+			annotations: map[string]string{constants.ActivatorAnnotationKey: "builtin"},
+			minReplicas: ptr.To(int32(1)),
+			errMatcher:  gomega.MatchError(fmt.Errorf(UnsupportedActivatorError, "foo", "requires a component with minReplicas 0")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = scenario.annotations
+			isvc.Spec.Predictor.MinReplicas = scenario.minReplicas
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateContainerLifecycle(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	postStart := &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/register", Port: intstr.FromInt32(8080)}},
+	}
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		container      corev1.Container
+		errMatcher     gomega.OmegaMatcher
+	}{
+		"post start hook in Standard mode": {
+			deploymentMode: constants.Standard,
+			container:      corev1.Container{Lifecycle: postStart},
+			errMatcher:     gomega.Succeed(),
+		},
+		"restart policy in Standard mode": {
+			deploymentMode: constants.Standard,
+			container:      corev1.Container{RestartPolicy: ptr.To(corev1.ContainerRestartPolicyOnFailure)},
+			errMatcher:     gomega.Succeed(),
+		},
+		"post start hook in Knative mode": {
+			deploymentMode: constants.Knative,
+			container:      corev1.Container{Lifecycle: postStart},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedContainerLifecycleError, "foo", constants.InferenceServiceContainerName)),
+		},
+		"restart policy in Serverless mode": {
+			deploymentMode: constants.LegacyServerless,
+			container:      corev1.Container{RestartPolicy: ptr.To(corev1.ContainerRestartPolicyNever)},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedContainerLifecycleError, "foo", constants.InferenceServiceContainerName)),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			isvc.Spec.Predictor = PredictorSpec{
+				Model: &ModelSpec{
+					ModelFormat: ModelFormat{Name: "sklearn"},
+					PredictorExtensionSpec: PredictorExtensionSpec{
+						StorageURI: proto.String("gs://testbucket/testmodel"),
+						Container:  scenario.container,
+					},
+				},
+			}
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateCanaryAnalysis(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		analysis       *CanaryAnalysisSpec
+		errMatcher     gomega.OmegaMatcher
+	}{
+		"success rate in Knative mode": {
+			deploymentMode: constants.Knative,
+			analysis:       &CanaryAnalysisSpec{MinSuccessRate: ptr.To(int32(99))},
+			errMatcher:     gomega.Succeed(),
+		},
+		"latency in Serverless mode": {
+			deploymentMode: constants.LegacyServerless,
+			analysis:       &CanaryAnalysisSpec{MaxLatencyMilliseconds: ptr.To(int64(500)), Interval: &metav1.Duration{Duration: 30 * time.Second}},
+			errMatcher:     gomega.Succeed(),
+		},
+		"Standard mode": {
+			deploymentMode: constants.Standard,
+			analysis:       &CanaryAnalysisSpec{MinSuccessRate: ptr.To(int32(99))},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedCanaryAnalysisError, "foo", PredictorComponent, "is only supported in the Knative deployment mode")),
+		},
+		"without threshold": {
+			deploymentMode: constants.Knative,
+			analysis:       &CanaryAnalysisSpec{StepPercent: ptr.To(int64(20))},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedCanaryAnalysisError, "foo", PredictorComponent, "requires minSuccessRate or maxLatencyMilliseconds")),
+		},
+		"zero interval": {
+			deploymentMode: constants.Knative,
+			analysis:       &CanaryAnalysisSpec{MinSuccessRate: ptr.To(int32(99)), Interval: &metav1.Duration{}},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedCanaryAnalysisError, "foo", PredictorComponent, "requires a positive interval")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			isvc.Spec.Predictor.CanaryAnalysis = scenario.analysis
+			g.Expect(validateCanaryAnalysis(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateShadowTraffic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		update         func(isvc *InferenceService)
+		errMatcher     gomega.OmegaMatcher
+	}{
+		"predictor in Knative mode": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(20))
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"without shadow traffic in Standard mode": {
+			deploymentMode: constants.Standard,
+			update:         func(isvc *InferenceService) {},
+			errMatcher:     gomega.Succeed(),
+		},
+		"Standard mode": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(20))
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedShadowTrafficError, "foo", "is only supported in the Knative deployment mode")),
+		},
+		"transformer": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Transformer = &TransformerSpec{ComponentExtensionSpec: ComponentExtensionSpec{ShadowTrafficPercent: ptr.To(int64(20))}}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedShadowTrafficError, "foo", "is only supported on the predictor")),
+		},
+		"predictor behind a transformer": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(20))
+				isvc.Spec.Transformer = &TransformerSpec{}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedShadowTrafficError, "foo", "is not supported with a transformer, which receives the traffic of the InferenceService")),
+		},
+		"with canary traffic": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(20))
+				isvc.Spec.Predictor.CanaryTrafficPercent = ptr.To(int64(10))
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedShadowTrafficError, "foo", "can not be set together with the canary traffic")),
+		},
+		"above 100 percent": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.ShadowTrafficPercent = ptr.To(int64(120))
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedShadowTrafficError, "foo", "percent must be between 0 and 100")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			scenario.update(&isvc)
+			g.Expect(validateShadowTraffic(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateObservability(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		observability  *ObservabilitySpec
+		errMatcher     gomega.OmegaMatcher
+	}{
+		"sidecar in Standard mode": {
+			deploymentMode: constants.Standard,
+			observability:  &ObservabilitySpec{OtelCollector: &OtelCollectorSidecarSpec{}},
+			errMatcher:     gomega.Succeed(),
+		},
+		"sidecar in Knative mode": {
+			deploymentMode: constants.Knative,
+			observability:  &ObservabilitySpec{OtelCollector: &OtelCollectorSidecarSpec{}},
+			errMatcher:     gomega.MatchError(fmt.Errorf(UnsupportedObservabilityError, "foo")),
+		},
+		"without sidecar in Serverless mode": {
+			deploymentMode: constants.LegacyServerless,
+			observability:  &ObservabilitySpec{},
+			errMatcher:     gomega.Succeed(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			isvc.Spec.Observability = scenario.observability
+			g.Expect(validateObservability(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateBlueGreen(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	blueGreen := &appsv1.DeploymentStrategy{Type: BlueGreenDeploymentStrategyType}
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		update         func(isvc *InferenceService)
+		errMatcher     gomega.OmegaMatcher
+	}{
+		"predictor in Standard mode": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.DeploymentStrategy = blueGreen
+				isvc.Spec.Predictor.BlueGreen = &BlueGreenSpec{RollbackWindowSeconds: ptr.To(int64(300))}
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"transformer in Standard mode": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Transformer = &TransformerSpec{ComponentExtensionSpec: ComponentExtensionSpec{DeploymentStrategy: blueGreen}}
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"Knative mode": {
+			deploymentMode: constants.Knative,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.DeploymentStrategy = blueGreen
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", PredictorComponent, "is only supported in the Standard deployment mode")),
+		},
+		"blueGreen without the BlueGreen type": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.BlueGreen = &BlueGreenSpec{}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", PredictorComponent, "requires the deploymentStrategy type BlueGreen")),
+		},
+		"scale to zero": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Explainer = &ExplainerSpec{ComponentExtensionSpec: ComponentExtensionSpec{DeploymentStrategy: blueGreen, MinReplicas: ptr.To(int32(0))}}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", ExplainerComponent, "requires at least one replica")),
+		},
+		"activator": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Annotations[constants.ActivatorAnnotationKey] = string(constants.ActivatorBuiltin)
+				isvc.Spec.Predictor.MinReplicas = ptr.To(int32(0))
+				isvc.Spec.Transformer = &TransformerSpec{ComponentExtensionSpec: ComponentExtensionSpec{DeploymentStrategy: blueGreen}}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", TransformerComponent, "is not supported with the activator")),
+		},
+		"multiple nodes": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.DeploymentStrategy = blueGreen
+				isvc.Spec.Predictor.WorkerSpec = &WorkerSpec{}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", PredictorComponent, "is not supported with multiple nodes")),
+		},
+		"spot capacity": {
+			deploymentMode: constants.Standard,
+			update: func(isvc *InferenceService) {
+				isvc.Spec.Predictor.DeploymentStrategy = blueGreen
+				isvc.Spec.Predictor.Capacity = &CapacitySpec{}
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedBlueGreenError, "foo", PredictorComponent, "is not supported with the spot capacity")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			scenario.update(&isvc)
+			g.Expect(validateBlueGreen(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateMonitoring(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		monitoring *MonitoringSpec
+		logger     *LoggerSpec
+		errMatcher gomega.OmegaMatcher
+	}{
+		"drift detector without logger": {
+			monitoring: &MonitoringSpec{StorageURI: "s3://bucket/drift", DriftBatchSize: ptr.To(int32(100))},
+			errMatcher: gomega.Succeed(),
+		},
+		"outlier detector with a logger without sink": {
+			monitoring: &MonitoringSpec{Type: OutlierDetector, StorageURI: "s3://bucket/outlier"},
+			logger:     &LoggerSpec{Mode: LogRequest},
+			errMatcher: gomega.Succeed(),
+		},
+		"logger with a url": {
+			monitoring: &MonitoringSpec{StorageURI: "s3://bucket/drift"},
+			logger:     &LoggerSpec{URL: ptr.To("http://message-dumper.default")},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedMonitoringError, "foo",
+				"requires a predictor logger without url, kafka or storage sink, the payloads are sent to the detector")),
+		},
+		"drift batch size of an outlier detector": {
+			monitoring: &MonitoringSpec{Type: OutlierDetector, StorageURI: "s3://bucket/outlier", DriftBatchSize: ptr.To(int32(100))},
+			errMatcher: gomega.MatchError(fmt.Errorf(UnsupportedMonitoringError, "foo", "driftBatchSize is only supported by the Drift detector")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor.Monitoring = scenario.monitoring
+			isvc.Spec.Predictor.Logger = scenario.logger
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateScaleScheduleDeploymentMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	compExtSpec := &ComponentExtensionSpec{
+		ScaleSchedule: []ScaleScheduleWindow{{Name: "peak", Start: "0 8 * * *", End: "0 18 * * *", MinReplicas: 2}},
+	}
+	scenarios := map[string]struct {
+		annotations map[string]string
+		errMatcher  gomega.OmegaMatcher
+	}{
+		"Standard mode with the default autoscaler": {
+			annotations: map[string]string{constants.DeploymentMode: string(constants.Standard)},
+			errMatcher:  gomega.Succeed(),
+		},
+		"Standard mode with keda": {
+			annotations: map[string]string{
+				constants.DeploymentMode:  string(constants.Standard),
+				constants.AutoscalerClass: string(constants.AutoscalerClassKeda),
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"Standard mode without autoscaler": {
+			annotations: map[string]string{
+				constants.DeploymentMode:  string(constants.Standard),
+				constants.AutoscalerClass: string(constants.AutoscalerClassNone),
+			},
+			errMatcher: gomega.MatchError("scaleSchedule is only supported with the hpa or keda autoscaler class"),
+		},
+		"Knative mode": {
+			annotations: map[string]string{constants.DeploymentMode: string(constants.Knative)},
+			errMatcher:  gomega.MatchError("scaleSchedule is only supported for Standard deployment mode"),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validateAutoScalingCompExtension(scenario.annotations, compExtSpec)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidatePredictorModels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		storageUri *string
+		models     []PredictorModelSpec
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid predictor models": {
+			models: []PredictorModelSpec{
+				{Name: "model1", StorageURI: "s3://bucket/model1", ModelFormat: ModelFormat{Name: "tensorflow"}},
+				{Name: "model2", StorageURI: "s3://bucket/model2", ModelFormat: ModelFormat{Name: "tensorflow"}},
+			},
+			errMatcher: gomega.Succeed(),
+		},
+		"predictor models with predictor storageUri": {
+			storageUri: proto.String("gs://testbucket/testmodel"),
+			models: []PredictorModelSpec{
+				{Name: "model1", StorageURI: "s3://bucket/model1", ModelFormat: ModelFormat{Name: "tensorflow"}},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidPredictorModelsStorageUriError, "foo")),
+		},
+		"duplicate predictor model names": {
+			models: []PredictorModelSpec{
+				{Name: "model1", StorageURI: "s3://bucket/model1", ModelFormat: ModelFormat{Name: "tensorflow"}},
+				{Name: "model1", StorageURI: "s3://bucket/model2", ModelFormat: ModelFormat{Name: "tensorflow"}},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(DuplicatePredictorModelNameError, "foo", "model1")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor.Tensorflow.StorageURI = scenario.storageUri
+			isvc.Spec.Predictor.Models = scenario.models
+			validator := InferenceServiceValidator{}
+			_, err := validator.ValidateCreate(t.Context(), &isvc)
+			g.Expect(err).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateCapacity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		workerSpec *WorkerSpec
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid capacity": {
+			errMatcher: gomega.Succeed(),
+		},
+		"capacity with workerSpec": {
+			workerSpec: &WorkerSpec{},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidCapacityWorkerSpecError, "foo")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor.Capacity = &CapacitySpec{OnDemandReplicas: ptr.To(int32(2))}
+			isvc.Spec.Predictor.WorkerSpec = scenario.workerSpec
+			g.Expect(validateCapacity(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateStorageFilePatterns(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		include    []string
+		exclude    []string
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid patterns": {
+			include:    []string{"*.safetensors", "tokenizer/*"},
+			exclude:    []string{"*.bin", "optimizer*"},
+			errMatcher: gomega.Succeed(),
+		},
+		"malformed include pattern": {
+			include:    []string{"[*.safetensors"},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStorageFilePatternError, "foo", "include", "[*.safetensors")),
+		},
+		"empty exclude pattern": {
+			exclude:    []string{""},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStorageFilePatternError, "foo", "exclude", "")),
+		},
+		"absolute exclude pattern": {
+			exclude:    []string{"/model.bin"},
+			errMatcher: gomega.MatchError(fmt.Errorf(InvalidStorageFilePatternError, "foo", "exclude", "/model.bin")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor.Tensorflow.Storage = &ModelStorageSpec{
+				Include: scenario.include,
+				Exclude: scenario.exclude,
+			}
+			g.Expect(validateStorageFilePatterns(&isvc)).To(scenario.errMatcher)
+		})
+	}
+}
+
 func TestValidateTwoPredictorImplementationCollocation(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := InferenceService{
@@ -1625,6 +2434,47 @@ func TestValidateScalingKedaCompExtension(t *testing.T) {
 			},
 		},
 	}
+	validRuntimeMetric := &ComponentExtensionSpec{
+		AutoScaling: &AutoScalingSpec{
+			Metrics: []MetricsSpec{
+				{
+					Type: RuntimeMetricSourceType,
+					Runtime: &RuntimeMetricSource{
+						Metric: RuntimeMetricQueueDepth,
+						Target: MetricTarget{
+							Type:         AverageValueMetricType,
+							AverageValue: NewMetricQuantity("5"),
+						},
+					},
+				},
+			},
+		},
+	}
+	invalidRuntimeMetric := &ComponentExtensionSpec{
+		AutoScaling: &AutoScalingSpec{
+			Metrics: []MetricsSpec{
+				{
+					Type: RuntimeMetricSourceType,
+					Runtime: &RuntimeMetricSource{
+						Metric: RuntimeMetricKVCacheUsage,
+						Target: MetricTarget{
+							Type:  ValueMetricType,
+							Value: NewMetricQuantity("0.8"),
+						},
+					},
+				},
+			},
+		},
+	}
+	missingRuntimeMetric := &ComponentExtensionSpec{
+		AutoScaling: &AutoScalingSpec{
+			Metrics: []MetricsSpec{
+				{
+					Type: RuntimeMetricSourceType,
+				},
+			},
+		},
+	}
 	unknownMetricType := &ComponentExtensionSpec{
 		AutoScaling: &AutoScalingSpec{
 			Metrics: []MetricsSpec{
@@ -1652,6 +2502,9 @@ func TestValidateScalingKedaCompExtension(t *testing.T) {
 		{"valid: external metric", validExternal, ""},
 		{"invalid: pod metric missing query/value", invalidPodMetric, "the query should not be empty"},
 		{"valid: pod metric", validPodMetric, ""},
+		{"valid: runtime metric", validRuntimeMetric, ""},
+		{"invalid: runtime metric value target", invalidRuntimeMetric, "the runtime metric target value type should be AverageValue"},
+		{"invalid: missing runtime metric", missingRuntimeMetric, "metricSpec.Runtime is not set for runtime metric source type"},
 		{"invalid: unknown metric type", unknownMetricType, "unknown KEDA metric type with value [UnknownType].Valid types are Resource,External,PodMetric"},
 	}
 
@@ -1797,3 +2650,206 @@ func TestValidateStorageUri(t *testing.T) {
 		})
 	}
 }
+
+func TestDeprecatedRuntimeWarnings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	makeRuntimeSpec := func(deprecated bool, replacedBy *string) v1alpha1.ServingRuntimeSpec {
+		return v1alpha1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+				{Name: "sklearn", Version: proto.String("1"), AutoSelect: proto.Bool(true)},
+			},
+			Deprecated: ptr.To(deprecated),
+			ReplacedBy: replacedBy,
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:latest"}},
+			},
+		}
+	}
+	s := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&v1alpha1.ServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-old", Namespace: "default"},
+			Spec:       makeRuntimeSpec(true, ptr.To("kserve-sklearnserver")),
+		},
+		&v1alpha1.ServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-current", Namespace: "default"},
+			Spec:       makeRuntimeSpec(false, nil),
+		},
+		&v1alpha1.ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver-legacy"},
+			Spec:       makeRuntimeSpec(true, nil),
+		},
+	).Build()
+
+	makeIsvc := func(namespace string, runtimeName *string) *InferenceService {
+		return &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: namespace},
+			Spec: InferenceServiceSpec{
+				Predictor: PredictorSpec{
+					Model: &ModelSpec{
+						ModelFormat: ModelFormat{Name: "sklearn", Version: proto.String("1")},
+						Runtime:     runtimeName,
+						PredictorExtensionSpec: PredictorExtensionSpec{
+							StorageURI: proto.String("gs://kfserving-examples/models/sklearn/1.0/model"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		isvc     *InferenceService
+		expected []string
+	}{
+		"deprecated runtime with a replacement": {
+			isvc:     makeIsvc("default", ptr.To("sklearn-old")),
+			expected: []string{`the runtime "sklearn-old" selected by the InferenceService "sklearn" is deprecated, migrate to the runtime "kserve-sklearnserver"`},
+		},
+		"deprecated cluster runtime": {
+			isvc:     makeIsvc("default", ptr.To("kserve-sklearnserver-legacy")),
+			expected: []string{`the runtime "kserve-sklearnserver-legacy" selected by the InferenceService "sklearn" is deprecated`},
+		},
+		"automatically selected deprecated runtime": {
+			isvc:     makeIsvc("other", nil),
+			expected: []string{`the runtime "kserve-sklearnserver-legacy" selected by the InferenceService "sklearn" is deprecated`},
+		},
+		"runtime not deprecated": {
+			isvc: makeIsvc("default", ptr.To("sklearn-current")),
+		},
+		"runtime not found": {
+			isvc: makeIsvc("default", ptr.To("missing")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			validator := InferenceServiceValidator{Client: cl}
+			warnings, err := validator.ValidateCreate(t.Context(), scenario.isvc)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect([]string(warnings)).To(gomega.Equal(scenario.expected))
+		})
+	}
+
+	// The runtime is not looked up without a client
+	warnings, err := (&InferenceServiceValidator{}).ValidateCreate(t.Context(), makeIsvc("default", ptr.To("sklearn-old")))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(warnings).To(gomega.BeEmpty())
+}
+
+func TestValidateSharedModelLibraries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	makePvc := func(name string, shared bool, accessMode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+			},
+		}
+		if shared {
+			pvc.Annotations = map[string]string{constants.SharedModelLibraryAnnotationKey: "true"}
+		}
+		return pvc
+	}
+	makeIsvc := func(name string, storageURI string) *InferenceService {
+		isvc := makeTestInferenceService()
+		isvc.Name = name
+		isvc.Spec.Predictor.Tensorflow.StorageURI = ptr.To(storageURI)
+		return &isvc
+	}
+	s := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+	g.Expect(AddToScheme(s)).To(gomega.Succeed())
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		makePvc("library", true, corev1.ReadOnlyMany),
+		makePvc("rwo-library", true, corev1.ReadWriteOnce),
+		makePvc("dedicated", false, corev1.ReadWriteOnce),
+		makeIsvc("bert", "pvc://library/nlp/bert"),
+		makeIsvc("dedicated", "pvc://dedicated/model"),
+	).Build()
+	validator := &InferenceServiceValidator{Client: cl}
+
+	scenarios := map[string]struct {
+		isvc       *InferenceService
+		errMatcher gomega.OmegaMatcher
+	}{
+		"distinct sub path": {
+			isvc:       makeIsvc("gpt2", "pvc://library/nlp/gpt2"),
+			errMatcher: gomega.Succeed(),
+		},
+		"update of the same InferenceService": {
+			isvc:       makeIsvc("bert", "pvc://library/nlp/bert"),
+			errMatcher: gomega.Succeed(),
+		},
+		"PVC which is not shared": {
+			isvc:       makeIsvc("other", "pvc://dedicated/model"),
+			errMatcher: gomega.Succeed(),
+		},
+		"missing PVC": {
+			isvc:       makeIsvc("other", "pvc://missing/model"),
+			errMatcher: gomega.Succeed(),
+		},
+		"same sub path": {
+			isvc:       makeIsvc("other", "pvc://library/nlp/bert/"),
+			errMatcher: gomega.MatchError(fmt.Errorf(SharedModelLibraryPathCollisionError, "other", "nlp/bert/", "library", "nlp/bert", "bert")),
+		},
+		"parent sub path": {
+			isvc:       makeIsvc("other", "pvc://library/nlp"),
+			errMatcher: gomega.MatchError(fmt.Errorf(SharedModelLibraryPathCollisionError, "other", "nlp", "library", "nlp/bert", "bert")),
+		},
+		"whole shared library": {
+			isvc:       makeIsvc("other", "pvc://library"),
+			errMatcher: gomega.MatchError(fmt.Errorf(SharedModelLibrarySubPathError, "other", "pvc://library", "library")),
+		},
+		"ReadWriteOnce shared library": {
+			isvc:       makeIsvc("other", "pvc://rwo-library/model"),
+			errMatcher: gomega.MatchError(fmt.Errorf(SharedModelLibraryAccessModeError, "other", "rwo-library")),
+		},
+		"writable shared library": {
+			isvc: func() *InferenceService {
+				isvc := makeIsvc("other", "pvc://library/vision/resnet")
+				isvc.Annotations = map[string]string{constants.StorageReadonlyAnnotationKey: "false"}
+				return isvc
+			}(),
+			errMatcher: gomega.MatchError(fmt.Errorf(SharedModelLibraryReadOnlyError, "other", "library")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(validator.validateSharedModelLibraries(t.Context(), scenario.isvc)).To(scenario.errMatcher)
+		})
+	}
+	g.Expect((&InferenceServiceValidator{}).validateSharedModelLibraries(t.Context(), makeIsvc("other", "pvc://library"))).To(gomega.Succeed())
+}
+
+func TestValidateServiceAccounts(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	validator := &InferenceServiceValidator{Clientset: fakeclientset.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "model-sa", Namespace: "default"}},
+	)}
+	makeIsvc := func(predictorServiceAccountName string, transformerServiceAccountName string) *InferenceService {
+		isvc := &InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+			Spec: InferenceServiceSpec{
+				Predictor: PredictorSpec{
+					PodSpec: PodSpec{ServiceAccountName: predictorServiceAccountName},
+					SKLearn: &SKLearnSpec{PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: ptr.To("gs://bucket/model")}},
+				},
+			},
+		}
+		if transformerServiceAccountName != "" {
+			isvc.Spec.Transformer = &TransformerSpec{PodSpec: PodSpec{DeprecatedServiceAccount: transformerServiceAccountName}}
+		}
+		return isvc
+	}
+
+	g.Expect(validator.validateServiceAccounts(context.Background(), makeIsvc("", ""))).To(gomega.Succeed())
+	g.Expect(validator.validateServiceAccounts(context.Background(), makeIsvc("model-sa", "model-sa"))).To(gomega.Succeed())
+	g.Expect(validator.validateServiceAccounts(context.Background(), makeIsvc("missing-sa", ""))).To(gomega.MatchError(
+		fmt.Sprintf(ServiceAccountNotFoundError, "sklearn", "missing-sa", PredictorComponent, "default")))
+	g.Expect(validator.validateServiceAccounts(context.Background(), makeIsvc("model-sa", "missing-sa"))).To(gomega.MatchError(
+		fmt.Sprintf(ServiceAccountNotFoundError, "sklearn", "missing-sa", TransformerComponent, "default")))
+	// the ServiceAccounts are not looked up without a clientset
+	g.Expect((&InferenceServiceValidator{}).validateServiceAccounts(context.Background(), makeIsvc("missing-sa", ""))).To(gomega.Succeed())
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// MetricQueryParameters are the values the template of an external metric query is rendered with, so that the query
+// selects the series of the component without hardcoding the names which change with the InferenceService
+type MetricQueryParameters struct {
+	// Namespace of the InferenceService
+	Namespace string
+	// InferenceService is the name of the InferenceService
+	InferenceService string
+	// Component is the component scaled by the metric, e.g. predictor
+	Component string
+	// Deployment is the name of the Deployment of the component, which prefixes the names of its pods across the
+	// revisions, e.g. pod=~"{{ .Deployment }}-.*"
+	Deployment string
+}
+
+// IdleQueryParameters are the values the template of the query counting the requests of an idle InferenceService is
+// rendered with
+type IdleQueryParameters struct {
+	// Namespace of the InferenceService
+	Namespace string
+	// InferenceService is the name of the InferenceService
+	InferenceService string
+	// Window is the range the requests are counted over, e.g. [{{ .Window }}]
+	Window string
+}
+
+// CanaryQueryParameters are the values the templates of the queries evaluating a canary revision are rendered with
+type CanaryQueryParameters struct {
+	// Namespace of the InferenceService
+	Namespace string
+	// InferenceService is the name of the InferenceService
+	InferenceService string
+	// Component is the component of the canary revision, e.g. predictor
+	Component string
+	// Revision is the name of the canary revision
+	Revision string
+	// Interval is the range the metrics are evaluated over, e.g. [{{ .Interval }}]
+	Interval string
+}
+
+// DriftQueryParameters are the values the template of the query of the drift reported by the detector of an
+// InferenceService is rendered with
+type DriftQueryParameters struct {
+	// Namespace of the InferenceService
+	Namespace string
+	// InferenceService is the name of the InferenceService
+	InferenceService string
+	// Interval is the range the drift is queried over, the check interval of the drift, e.g. [{{ .Interval }}]
+	Interval string
+}
+
+// MinIdleTimeout is the shortest idle timeout, so that the requests are counted over a few scrapes of the metrics
+const MinIdleTimeout = time.Minute
+
+// RenderMetricQuery renders the Go template of an external metric query, e.g.
+// sum(rate(request_total{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"}[1m])).
+// The queries without template are returned unchanged.
+func RenderMetricQuery(query string, parameters MetricQueryParameters) (string, error) {
+	return renderQuery(query, parameters)
+}
+
+// RenderIdleRequestCountQuery renders the Go template of the query counting the requests received by an
+// InferenceService over the idle window
+func RenderIdleRequestCountQuery(query string, parameters IdleQueryParameters) (string, error) {
+	return renderQuery(query, parameters)
+}
+
+// RenderCanaryQuery renders the Go template of a query evaluating the canary revision of a component over the
+// interval of its analysis
+func RenderCanaryQuery(query string, parameters CanaryQueryParameters) (string, error) {
+	return renderQuery(query, parameters)
+}
+
+// RenderDriftQuery renders the Go template of the query of the drift reported by the detector of an InferenceService
+func RenderDriftQuery(query string, parameters DriftQueryParameters) (string, error) {
+	return renderQuery(query, parameters)
+}
+
+func renderQuery(query string, parameters any) (string, error) {
+	if !strings.Contains(query, "{{") {
+		return query, nil
+	}
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(query)
+	if err != nil {
+		return "", err
+	}
+	rendered := &strings.Builder{}
+	if err := tmpl.Execute(rendered, parameters); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// checkQueryDelimiters checks that the brackets of a rendered Prometheus query are balanced and that its string
+// literals are terminated. The expression itself is checked by the Prometheus server when the query is evaluated.
+func checkQueryDelimiters(query string) error {
+	closing := map[rune]rune{'(': ')', '[': ']', '{': '}'}
+	var open []rune
+	var quote rune
+	escaped := false
+	for i, c := range query {
+		switch {
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\' && quote != '`':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case closing[c] != 0:
+			open = append(open, closing[c])
+		case c == ')' || c == ']' || c == '}':
+			if len(open) == 0 || open[len(open)-1] != c {
+				return fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	if quote != 0 {
+		return errors.New("unterminated string literal")
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("missing %q", open[len(open)-1])
+	}
+	return nil
+}
+
+// validateExternalMetricQuery checks that the template of an external metric query renders and that the delimiters of
+// the rendered Prometheus query are balanced
+func validateExternalMetricQuery(metric *ExternalMetrics) error {
+	query, err := RenderMetricQuery(metric.Query, MetricQueryParameters{
+		Namespace:        "namespace",
+		InferenceService: "inferenceservice",
+		Component:        string(PredictorComponent),
+		Deployment:       "inferenceservice-predictor",
+	})
+	if err != nil {
+		return fmt.Errorf("the query template %q is invalid: %w", metric.Query, err)
+	}
+	if metric.Backend == PrometheusBackend {
+		if err := checkQueryDelimiters(query); err != nil {
+			return fmt.Errorf("the prometheus query %q is invalid: %w", metric.Query, err)
+		}
+	}
+	return nil
+}
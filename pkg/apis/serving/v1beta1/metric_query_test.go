@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestRenderMetricQuery(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	parameters := MetricQueryParameters{
+		Namespace:        "default",
+		InferenceService: "sklearn",
+		Component:        "predictor",
+		Deployment:       "sklearn-predictor",
+	}
+
+	query, err := RenderMetricQuery("avg(vllm_requests_running)", parameters)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(query).To(gomega.Equal("avg(vllm_requests_running)"))
+
+	query, err = RenderMetricQuery(`sum(vllm_requests_running{namespace="{{ .Namespace }}", pod=~"{{ .Deployment }}-.*"})`, parameters)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(query).To(gomega.Equal(`sum(vllm_requests_running{namespace="default", pod=~"sklearn-predictor-.*"})`))
+
+	_, err = RenderMetricQuery(`sum(vllm_requests_running{revision="{{ .Revision }}"})`, parameters)
+	g.Expect(err).To(gomega.HaveOccurred())
+	_, err = RenderMetricQuery(`sum(vllm_requests_running{namespace="{{ .Namespace }"})`, parameters)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestCheckQueryDelimiters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, valid := range []string{
+		"avg(vllm_requests_running)",
+		`sum(rate(request_total{namespace="default", path=~"/v1/(predict|explain)"}[1m]))`,
+		`count(up{job='a\'b'}) or vector(0)`,
+		"label_replace(up, \"x\", `)`, \"job\", \"(.*)\")",
+	} {
+		g.Expect(checkQueryDelimiters(valid)).To(gomega.Succeed(), valid)
+	}
+	for _, invalid := range []string{
+		"sum(rate(request_total[1m])",
+		"sum(rate(request_total[1m)))",
+		"up{job=\"a\"]",
+		`up{job="a}`,
+	} {
+		g.Expect(checkQueryDelimiters(invalid)).ToNot(gomega.Succeed(), invalid)
+	}
+}
+
+func TestValidateExternalMetricQuery(t *testing.T) {
+	scenarios := map[string]struct {
+		metric  ExternalMetrics
+		matcher gomega.OmegaMatcher
+	}{
+		"ValidQuery": {
+			metric:  ExternalMetrics{Backend: PrometheusBackend, Query: "avg(vllm_requests_running)"},
+			matcher: gomega.Succeed(),
+		},
+		"ValidTemplate": {
+			metric: ExternalMetrics{
+				Backend: PrometheusBackend,
+				Query:   `sum(rate(request_total{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}"}[1m]))`,
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidTemplate": {
+			metric:  ExternalMetrics{Backend: PrometheusBackend, Query: `request_total{component="{{ .Name }}"}`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("the query template")),
+		},
+		"InvalidPrometheusQuery": {
+			metric:  ExternalMetrics{Backend: PrometheusBackend, Query: `sum(rate(request_total{namespace="{{ .Namespace }}"}[1m])`},
+			matcher: gomega.MatchError(gomega.ContainSubstring("the prometheus query")),
+		},
+		"OtherBackend": {
+			metric:  ExternalMetrics{Backend: GraphiteBackend, Query: "sumSeries(requests.*)"},
+			matcher: gomega.Succeed(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(validateExternalMetricQuery(&scenario.metric)).To(scenario.matcher)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
@@ -59,9 +60,45 @@ type PredictorSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	StorageUris []StorageUri `json:"storageUris,omitempty"`
 
+	// Models declares the models loaded in the predictor by a runtime which can serve multiple models in one process,
+	// e.g. Triton or MLServer. The models are loaded by the model agent, without TrainedModel resources,
+	// and the predictor must not set a storageUri.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Models []PredictorModelSpec `json:"models,omitempty"`
+
 	// WorkerSpec for enabling multi-node/multi-gpu
 	WorkerSpec *WorkerSpec `json:"workerSpec,omitempty"`
 
+	// ZoneSpread controls how the replicas of the predictor are spread across topology zones when more than
+	// one replica is configured. Defaults to preferred, which spreads replicas on a best effort basis.
+	// The default is skipped when the pod spec already declares a zone topology spread constraint or pod anti-affinity.
+	// Only applied in the Standard deployment mode, as Knative rejects topology spread constraints unless the
+	// kubernetes.podspec-topologyspreadconstraints feature is enabled.
+	// +optional
+	ZoneSpread *ZoneSpreadPolicy `json:"zoneSpread,omitempty"`
+
+	// Capacity runs the replicas scaled by the autoscaler on spot or preemptible nodes, while a floor of replicas
+	// is kept on on-demand nodes. The on-demand replicas are raised to replace the spot replicas which are preempted
+	// or cannot be scheduled. Only supported in Standard deployment mode, and not with workerSpec.
+	// +optional
+	Capacity *CapacitySpec `json:"capacity,omitempty"`
+
+	// DualProtocol serves both the v1 (/v1/models/...) and the v2 (/v2/models/...) REST protocols on the predictor
+	// endpoint. The requests of the protocol the model server does not support are translated by the agent, so that
+	// the clients can migrate between the protocol versions without a second InferenceService. Only supported when the
+	// predictor serves the v1 or v2 REST protocol, or the v2 gRPC protocol transcoded by the agent.
+	// +optional
+	DualProtocol bool `json:"dualProtocol,omitempty"`
+
+	// Monitoring deploys a detector next to the predictor which receives the payloads of the predictor from the
+	// logger and detects the drift or the outliers of the inputs. The detections are exported as metrics, and the
+	// drift surfaces as the DriftDetected condition of the InferenceService. The logger of the predictor, if any,
+	// must not have a sink, its payloads are sent to the detector.
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
 	// This spec serves three purposes. <br />
 	// 1) To provide a full PodSpec for a custom predictor.
 	//    The field PodSpec.Containers is mutually exclusive with other predictors (e.g., TFServing). <br />
@@ -89,6 +126,21 @@ type StorageUri struct {
 	MountPath string `json:"mountPath"`
 }
 
+// PredictorModelSpec declares a model loaded in a multi-model predictor
+type PredictorModelSpec struct {
+	// Name of the model, the model is served under this name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// StorageURI is the location of the model.
+	// +kubebuilder:validation:MinLength=1
+	StorageURI string `json:"storageUri"`
+	// ModelFormat is the format of the model.
+	ModelFormat ModelFormat `json:"modelFormat"`
+	// Memory is the maximum memory the model consumes.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+}
+
 type WorkerSpec struct {
 	PodSpec `json:",inline"`
 
@@ -103,6 +155,90 @@ type WorkerSpec struct {
 	TensorParallelSize *int `json:"tensorParallelSize,omitempty"`
 }
 
+// ZoneSpreadPolicy defines how predictor replicas are spread across topology zones
+// +kubebuilder:validation:Enum=preferred;required;disabled
+type ZoneSpreadPolicy string
+
+// ZoneSpreadPolicy Enum
+const (
+	// ZoneSpreadPreferred spreads replicas across zones when possible but still schedules them otherwise
+	ZoneSpreadPreferred ZoneSpreadPolicy = "preferred"
+	// ZoneSpreadRequired does not schedule a replica when it would unbalance the zones
+	ZoneSpreadRequired ZoneSpreadPolicy = "required"
+	// ZoneSpreadDisabled does not add any zone spread constraint
+	ZoneSpreadDisabled ZoneSpreadPolicy = "disabled"
+)
+
+// CapacitySpec splits the predictor replicas between spot and on-demand nodes
+type CapacitySpec struct {
+	// OnDemandReplicas is the floor of replicas always running on on-demand nodes. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	OnDemandReplicas *int32 `json:"onDemandReplicas,omitempty"`
+	// SpotNodeSelector selects the spot nodes. Defaults to the spotNodeSelector of the capacity configuration.
+	// +optional
+	SpotNodeSelector map[string]string `json:"spotNodeSelector,omitempty"`
+	// SpotTolerations are added to the spot replicas so that they tolerate the taints of the spot nodes.
+	// Defaults to the spotTolerations of the capacity configuration.
+	// +optional
+	// +listType=atomic
+	SpotTolerations []corev1.Toleration `json:"spotTolerations,omitempty"`
+	// OnDemandNodeSelector selects the on-demand nodes. Defaults to the onDemandNodeSelector of the capacity
+	// configuration.
+	// +optional
+	OnDemandNodeSelector map[string]string `json:"onDemandNodeSelector,omitempty"`
+}
+
+// DetectorType is the detection the monitoring of the predictor runs on its inputs
+// +kubebuilder:validation:Enum=Drift;Outlier
+type DetectorType string
+
+// DetectorType Enum
+const (
+	// DriftDetector tests whether the distribution of batches of inputs drifted from the reference data
+	DriftDetector DetectorType = "Drift"
+	// OutlierDetector scores whether each input is an outlier of the reference data
+	OutlierDetector DetectorType = "Outlier"
+)
+
+// MonitoringSpec configures the detector monitoring the inputs of the predictor
+type MonitoringSpec struct {
+	// Type of the detection. Defaults to Drift.
+	// +optional
+	Type DetectorType `json:"type,omitempty"`
+	// StorageURI is the location of the detector fitted on the reference data, e.g. saved with Alibi Detect.
+	// +kubebuilder:validation:MinLength=1
+	StorageURI string `json:"storageUri"`
+	// Image of the detector server, overriding the image of the monitoring configuration to plug another detector
+	// taking the same arguments and exporting the same metrics.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// DriftBatchSize is the number of inputs the drift is tested on. Defaults to the driftBatchSize of the monitoring
+	// configuration.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DriftBatchSize *int32 `json:"driftBatchSize,omitempty"`
+	// Resources of the detector container. Defaults to the resources of the monitoring configuration.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// GetType returns the detection of the monitoring
+func (m *MonitoringSpec) GetType() DetectorType {
+	if m.Type == "" {
+		return DriftDetector
+	}
+	return m.Type
+}
+
+// GetOnDemandReplicas returns the floor of replicas always running on on-demand nodes
+func (c *CapacitySpec) GetOnDemandReplicas() int32 {
+	if c.OnDemandReplicas != nil {
+		return *c.OnDemandReplicas
+	}
+	return constants.DefaultOnDemandReplicas
+}
+
 var _ Component = &PredictorSpec{}
 
 // PredictorExtensionSpec defines configuration shared across all predictor frameworks
@@ -130,6 +266,24 @@ type ModelStorageSpec struct {
 	// The path to the model schema file in the storage.
 	// +optional
 	SchemaPath *string `json:"schemaPath,omitempty"`
+	// Glob patterns of the files to download, matched against the file paths relative to the storage location,
+	// e.g. "*.safetensors". All the files are downloaded when empty.
+	// +optional
+	// +listType=atomic
+	Include []string `json:"include,omitempty"`
+	// Glob patterns of the files to skip, matched against the file paths relative to the storage location,
+	// e.g. "*.bin" when safetensors weights exist, or "optimizer*" to skip the optimizer states.
+	// Exclude takes precedence over include.
+	// +optional
+	// +listType=atomic
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// FiltersFilesOnly returns true when the storage spec only filters the downloaded files, leaving the model location and
+// credentials to the storageUri.
+func (s *ModelStorageSpec) FiltersFilesOnly() bool {
+	return s.Path == nil && s.Parameters == nil && s.StorageKey == nil && s.SchemaPath == nil &&
+		(len(s.Include) > 0 || len(s.Exclude) > 0)
 }
 
 // GetImplementations returns the implementations for the component
@@ -163,6 +317,24 @@ func (s *PredictorSpec) GetImplementations() []ComponentImplementation {
 	return implementations
 }
 
+// GetDualProtocolNativeProtocol returns the REST protocol natively served by the predictor, which the requests of the
+// other REST protocol are translated to when the dual protocol is enabled. The v2 gRPC predictors natively serve the v2
+// REST protocol when the agent transcodes it to gRPC.
+func (s *PredictorSpec) GetDualProtocolNativeProtocol(annotations map[string]string) (constants.InferenceServiceProtocol, bool) {
+	if len(s.GetImplementations()) == 0 {
+		return constants.ProtocolUnknown, false
+	}
+	switch protocol := s.GetImplementation().GetProtocol(); protocol {
+	case constants.ProtocolV1, constants.ProtocolV2:
+		return protocol, true
+	case constants.ProtocolGRPCV2:
+		if _, ok := annotations[constants.GrpcTranscodingPortAnnotationKey]; ok {
+			return constants.ProtocolV2, true
+		}
+	}
+	return constants.ProtocolUnknown, false
+}
+
 // GetImplementation returns the implementation for the component
 func (s *PredictorSpec) GetImplementation() ComponentImplementation {
 	return s.GetImplementations()[0]
@@ -191,3 +363,8 @@ func (p *PredictorExtensionSpec) GetStorageUri() *string {
 func (p *PredictorExtensionSpec) GetStorageSpec() *ModelStorageSpec {
 	return p.Storage
 }
+
+// GetResourceRequirements returns the resource requirements of the predictor container
+func (p *PredictorExtensionSpec) GetResourceRequirements() *corev1.ResourceRequirements {
+	return &p.Resources
+}
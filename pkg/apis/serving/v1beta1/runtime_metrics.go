@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ModelServer is a model server whose metrics the runtime metrics are templated for
+type ModelServer string
+
+const (
+	// ModelServerVLLM is vLLM, which is also the default backend of the Hugging Face server
+	ModelServerVLLM ModelServer = "vllm"
+	// ModelServerSGLang is SGLang
+	ModelServerSGLang ModelServer = "sglang"
+	// ModelServerTGI is the Text Generation Inference server of Hugging Face
+	ModelServerTGI ModelServer = "tgi"
+)
+
+// RuntimeMetricNames are the Prometheus metrics of the runtime metrics exported by each model server
+var RuntimeMetricNames = map[ModelServer]map[RuntimeMetric]string{
+	ModelServerVLLM: {
+		RuntimeMetricQueueDepth:     "vllm:num_requests_waiting",
+		RuntimeMetricBatchOccupancy: "vllm:num_requests_running",
+		RuntimeMetricKVCacheUsage:   "vllm:gpu_cache_usage_perc",
+	},
+	ModelServerSGLang: {
+		RuntimeMetricQueueDepth:     "sglang:num_queue_reqs",
+		RuntimeMetricBatchOccupancy: "sglang:num_running_reqs",
+		RuntimeMetricKVCacheUsage:   "sglang:token_usage",
+	},
+	ModelServerTGI: {
+		RuntimeMetricQueueDepth:     "tgi_queue_size",
+		RuntimeMetricBatchOccupancy: "tgi_batch_current_size",
+	},
+}
+
+// DetectModelServer returns the model server run by the container from its image and its command line, or an empty
+// model server when it is not known. The Hugging Face server runs vLLM unless its backend is set to huggingface.
+func DetectModelServer(container *corev1.Container) ModelServer {
+	commandLine := strings.Join(append(append([]string{}, container.Command...), container.Args...), " ")
+	switch {
+	case strings.Contains(container.Image, "huggingfaceserver") || strings.Contains(commandLine, "huggingfaceserver"):
+		if strings.Contains(commandLine, "--backend=huggingface") || strings.Contains(commandLine, "--backend huggingface") {
+			return ""
+		}
+		return ModelServerVLLM
+	case strings.Contains(container.Image, "vllm") || strings.Contains(commandLine, "vllm"):
+		return ModelServerVLLM
+	case strings.Contains(container.Image, "sglang") || strings.Contains(commandLine, "sglang"):
+		return ModelServerSGLang
+	case strings.Contains(container.Image, "text-generation-inference") || strings.Contains(commandLine, "text-generation-launcher"):
+		return ModelServerTGI
+	}
+	return ""
+}
+
+// RenderRuntimeMetricQuery returns the Prometheus query summing the runtime metric across the pods of the component,
+// which the autoscaler divides by the replicas to compare it to the target average value
+func RenderRuntimeMetricQuery(server ModelServer, metric RuntimeMetric, parameters MetricQueryParameters) (string, error) {
+	metrics, ok := RuntimeMetricNames[server]
+	if !ok {
+		return "", fmt.Errorf("the runtime metrics are not supported for the model server of the component %s, "+
+			"the supported model servers are vLLM, SGLang and Text Generation Inference", parameters.Deployment)
+	}
+	name, ok := metrics[metric]
+	if !ok {
+		return "", fmt.Errorf("the runtime metric %s is not exported by %s", metric, server)
+	}
+	return fmt.Sprintf(`sum(%s{namespace="%s", pod=~"%s-.*"})`, name, parameters.Namespace, parameters.Deployment), nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDetectModelServer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		container corev1.Container
+		expected  ModelServer
+	}{
+		"vLLM image": {
+			container: corev1.Container{Image: "vllm/vllm-openai:v0.9.0", Args: []string{"--model", "/mnt/models"}},
+			expected:  ModelServerVLLM,
+		},
+		"Hugging Face server": {
+			container: corev1.Container{Image: "kserve/huggingfaceserver:v0.15.0", Args: []string{"--model_name={{.Name}}"}},
+			expected:  ModelServerVLLM,
+		},
+		"Hugging Face server with the huggingface backend": {
+			container: corev1.Container{Image: "kserve/huggingfaceserver:v0.15.0", Args: []string{"--backend=huggingface"}},
+			expected:  "",
+		},
+		"SGLang launched by a script": {
+			container: corev1.Container{Image: "registry.example.com/llm:v1", Command: []string{"bash", "-c"}, Args: []string{"python3 -m sglang.launch_server --port 8080"}},
+			expected:  ModelServerSGLang,
+		},
+		"Text Generation Inference": {
+			container: corev1.Container{Image: "ghcr.io/huggingface/text-generation-inference:3.0"},
+			expected:  ModelServerTGI,
+		},
+		"sklearn server": {
+			container: corev1.Container{Image: "kserve/sklearnserver:v0.15.0"},
+			expected:  "",
+		},
+	}
+	for name, scenario := range scenarios {
+		g.Expect(DetectModelServer(&scenario.container)).To(gomega.Equal(scenario.expected), name)
+	}
+}
+
+func TestRenderRuntimeMetricQuery(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	parameters := MetricQueryParameters{
+		Namespace:        "default",
+		InferenceService: "llama",
+		Component:        "predictor",
+		Deployment:       "llama-predictor",
+	}
+
+	query, err := RenderRuntimeMetricQuery(ModelServerVLLM, RuntimeMetricQueueDepth, parameters)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(query).To(gomega.Equal(`sum(vllm:num_requests_waiting{namespace="default", pod=~"llama-predictor-.*"})`))
+
+	query, err = RenderRuntimeMetricQuery(ModelServerSGLang, RuntimeMetricKVCacheUsage, parameters)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(query).To(gomega.Equal(`sum(sglang:token_usage{namespace="default", pod=~"llama-predictor-.*"})`))
+
+	_, err = RenderRuntimeMetricQuery(ModelServerTGI, RuntimeMetricKVCacheUsage, parameters)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not exported by tgi")))
+	_, err = RenderRuntimeMetricQuery("", RuntimeMetricQueueDepth, parameters)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not supported for the model server")))
+}
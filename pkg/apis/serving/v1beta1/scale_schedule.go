@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// DefaultScaleScheduleTimezone is the time zone of the scale schedule windows without a time zone
+const DefaultScaleScheduleTimezone = "Etc/UTC"
+
+// cronField is the range of the values of a field of a cron expression, and the names accepted for them
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute     = cronField{min: 0, max: 59}
+	cronHour       = cronField{min: 0, max: 23}
+	cronDayOfMonth = cronField{min: 1, max: 31}
+	cronMonth      = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Sunday is both 0 and 7
+	cronDayOfWeek = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronSchedule is a parsed cron expression, each field is the bit set of the values it matches
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// the day of the month and the day of the week match any day when they are *, otherwise a day matches either
+	anyDayOfMonth, anyDayOfWeek bool
+	location                    *time.Location
+}
+
+// parseCron parses a standard five fields cron expression evaluated in the time zone
+func parseCron(expression string, timezone string) (*cronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("the cron expression %q must have five fields", expression)
+	}
+	if timezone == "" {
+		timezone = DefaultScaleScheduleTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", timezone)
+	}
+	schedule := &cronSchedule{
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+		location:      location,
+	}
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&schedule.minute, cronMinute},
+		{&schedule.hour, cronHour},
+		{&schedule.dayOfMonth, cronDayOfMonth},
+		{&schedule.month, cronMonth},
+		{&schedule.dayOfWeek, cronDayOfWeek},
+	} {
+		bits, err := parseCronField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		*target.bits = bits
+	}
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a comma separated list of *, values and ranges with an optional step
+func parseCronField(expression string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, stepExpression, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpression); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepExpression)
+			}
+		}
+		low, high := field.min, field.max
+		if rangeExpression != "*" {
+			lowExpression, highExpression, isRange := strings.Cut(rangeExpression, "-")
+			var err error
+			if low, err = parseCronValue(lowExpression, field); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseCronValue(highExpression, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				high = field.max
+			}
+			if high < low {
+				return 0, fmt.Errorf("invalid range %q", rangeExpression)
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(expression string, field cronField) (int, error) {
+	if value, ok := field.names[strings.ToLower(expression)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < field.min || value > field.max {
+		return 0, fmt.Errorf("invalid value %q, must be between %d and %d", expression, field.min, field.max)
+	}
+	return value, nil
+}
+
+// next returns the first time matching the schedule strictly after the time
+func (s *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	// a schedule which can never match, such as the 30th of February, is looked up for a few years at most
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Validate validates the cron expressions and the time zone of the window
+func (w *ScaleScheduleWindow) Validate() error {
+	if _, err := parseCron(w.Start, w.Timezone); err != nil {
+		return err
+	}
+	_, err := parseCron(w.End, w.Timezone)
+	return err
+}
+
+// ScaleScheduleState returns the highest minimum replicas of the windows of the schedule open at the time, which is
+// zero when no window is open, and the time of the next opening or closing of a window. A window is open when its
+// next closing comes before its next opening.
+func ScaleScheduleState(schedule []ScaleScheduleWindow, now time.Time) (int32, time.Time, error) {
+	var minReplicas int32
+	var nextTransition time.Time
+	for _, window := range schedule {
+		start, err := parseCron(window.Start, window.Timezone)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		end, err := parseCron(window.End, window.Timezone)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		nextStart, nextEnd := start.next(now), end.next(now)
+		if !nextEnd.IsZero() && (nextStart.IsZero() || nextEnd.Before(nextStart)) {
+			minReplicas = max(minReplicas, window.MinReplicas)
+		}
+		for _, transition := range []time.Time{nextStart, nextEnd} {
+			if !transition.IsZero() && (nextTransition.IsZero() || transition.Before(nextTransition)) {
+				nextTransition = transition
+			}
+		}
+	}
+	return minReplicas, nextTransition, nil
+}
+
+// ScaleScheduleRequeueAfter returns the duration until the next opening or closing of a window of the scale schedules
+// of the components, when the HPA minimum replicas are patched by the controller. KEDA opens and closes the windows
+// itself with cron triggers.
+func (isvc *InferenceService) ScaleScheduleRequeueAfter(now time.Time) time.Duration {
+	if isvc.Annotations[constants.AutoscalerClass] == string(constants.AutoscalerClassKeda) {
+		return 0
+	}
+	var requeueAfter time.Duration
+	for _, componentExt := range []*ComponentExtensionSpec{
+		&isvc.Spec.Predictor.ComponentExtensionSpec,
+		transformerExtensions(isvc),
+		explainerExtensions(isvc),
+	} {
+		if componentExt == nil || len(componentExt.ScaleSchedule) == 0 {
+			continue
+		}
+		_, nextTransition, err := ScaleScheduleState(componentExt.ScaleSchedule, now)
+		if err != nil || nextTransition.IsZero() {
+			continue
+		}
+		if after := nextTransition.Sub(now); requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+	return requeueAfter
+}
+
+func transformerExtensions(isvc *InferenceService) *ComponentExtensionSpec {
+	if isvc.Spec.Transformer == nil {
+		return nil
+	}
+	return &isvc.Spec.Transformer.ComponentExtensionSpec
+}
+
+func explainerExtensions(isvc *InferenceService) *ComponentExtensionSpec {
+	if isvc.Spec.Explainer == nil {
+		return nil
+	}
+	return &isvc.Spec.Explainer.ComponentExtensionSpec
+}
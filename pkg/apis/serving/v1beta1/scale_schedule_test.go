@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// Wednesday 4 June 2025
+	now := time.Date(2025, time.June, 4, 10, 30, 0, 0, time.UTC)
+	scenarios := map[string]struct {
+		expression string
+		timezone   string
+		expected   time.Time
+	}{
+		"every quarter of an hour": {
+			expression: "*/15 * * * *",
+			expected:   time.Date(2025, time.June, 4, 10, 45, 0, 0, time.UTC),
+		},
+		"weekdays by name": {
+			expression: "0 8 * * MON-FRI",
+			expected:   time.Date(2025, time.June, 5, 8, 0, 0, 0, time.UTC),
+		},
+		"Sunday as 7": {
+			expression: "0 0 * * 7",
+			expected:   time.Date(2025, time.June, 8, 0, 0, 0, 0, time.UTC),
+		},
+		"day of the month or day of the week": {
+			expression: "0 0 1 * 5",
+			expected:   time.Date(2025, time.June, 6, 0, 0, 0, 0, time.UTC),
+		},
+		"list of months": {
+			expression: "0 0 1 jan,jul *",
+			expected:   time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"time zone": {
+			expression: "0 18 * * *",
+			timezone:   "America/New_York",
+			expected:   time.Date(2025, time.June, 4, 22, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			expression: "0 0 30 2 *",
+			expected:   time.Time{},
+		},
+	}
+	for name, scenario := range scenarios {
+		schedule, err := parseCron(scenario.expression, scenario.timezone)
+		g.Expect(err).ToNot(gomega.HaveOccurred(), name)
+		g.Expect(schedule.next(now).Equal(scenario.expected)).To(gomega.BeTrue(), "%s: got %s", name, schedule.next(now))
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for _, expression := range []string{"* * * *", "60 * * * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "* * * * funday"} {
+		_, err := parseCron(expression, "")
+		g.Expect(err).To(gomega.HaveOccurred(), expression)
+	}
+	_, err := parseCron("* * * * *", "Mars/Olympus_Mons")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unknown time zone")))
+}
+
+func TestScaleScheduleState(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	schedule := []ScaleScheduleWindow{
+		{Name: "business-hours", Start: "0 8 * * MON-FRI", End: "0 18 * * MON-FRI", MinReplicas: 4},
+		{Name: "launch", Start: "0 9 4 6 *", End: "0 12 4 6 *", MinReplicas: 10},
+	}
+
+	minReplicas, nextTransition, err := ScaleScheduleState(schedule, time.Date(2025, time.June, 4, 10, 0, 0, 0, time.UTC))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(minReplicas).To(gomega.Equal(int32(10)))
+	g.Expect(nextTransition).To(gomega.BeTemporally("==", time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC)))
+
+	minReplicas, nextTransition, err = ScaleScheduleState(schedule, time.Date(2025, time.June, 4, 13, 0, 0, 0, time.UTC))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(minReplicas).To(gomega.Equal(int32(4)))
+	g.Expect(nextTransition).To(gomega.BeTemporally("==", time.Date(2025, time.June, 4, 18, 0, 0, 0, time.UTC)))
+
+	minReplicas, nextTransition, err = ScaleScheduleState(schedule, time.Date(2025, time.June, 7, 10, 0, 0, 0, time.UTC))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(minReplicas).To(gomega.BeZero())
+	g.Expect(nextTransition).To(gomega.BeTemporally("==", time.Date(2025, time.June, 9, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestScaleScheduleRequeueAfter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2025, time.June, 4, 10, 0, 0, 0, time.UTC)
+	isvc := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec: InferenceServiceSpec{
+			Predictor: PredictorSpec{
+				ComponentExtensionSpec: ComponentExtensionSpec{
+					ScaleSchedule: []ScaleScheduleWindow{{Name: "business-hours", Start: "0 8 * * *", End: "0 18 * * *", MinReplicas: 4}},
+				},
+			},
+			Transformer: &TransformerSpec{
+				ComponentExtensionSpec: ComponentExtensionSpec{
+					ScaleSchedule: []ScaleScheduleWindow{{Name: "morning", Start: "0 8 * * *", End: "0 11 * * *", MinReplicas: 2}},
+				},
+			},
+		},
+	}
+	g.Expect(isvc.ScaleScheduleRequeueAfter(now)).To(gomega.Equal(time.Hour))
+
+	isvc.Annotations = map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassKeda)}
+	g.Expect(isvc.ScaleScheduleRequeueAfter(now)).To(gomega.BeZero())
+	g.Expect((&InferenceService{}).ScaleScheduleRequeueAfter(now)).To(gomega.BeZero())
+}
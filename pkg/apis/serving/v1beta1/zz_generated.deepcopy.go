@@ -25,12 +25,40 @@ import (
 	"k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ABTestSpec) DeepCopyInto(out *ABTestSpec) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]ModelVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(SessionAffinitySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ABTestSpec.
+func (in *ABTestSpec) DeepCopy() *ABTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ABTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ARTExplainerSpec) DeepCopyInto(out *ARTExplainerSpec) {
 	*out = *in
@@ -47,6 +75,26 @@ func (in *ARTExplainerSpec) DeepCopy() *ARTExplainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveBatcher) DeepCopyInto(out *AdaptiveBatcher) {
+	*out = *in
+	if in.MinBatchSize != nil {
+		in, out := &in.MinBatchSize, &out.MinBatchSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveBatcher.
+func (in *AdaptiveBatcher) DeepCopy() *AdaptiveBatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveBatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationRef) DeepCopyInto(out *AuthenticationRef) {
 	*out = *in
@@ -107,6 +155,11 @@ func (in *AutoscalerConfig) DeepCopy() *AutoscalerConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Batcher) DeepCopyInto(out *Batcher) {
 	*out = *in
+	if in.Adaptive != nil {
+		in, out := &in.Adaptive, &out.Adaptive
+		*out = new(AdaptiveBatcher)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MaxBatchSize != nil {
 		in, out := &in.MaxBatchSize, &out.MaxBatchSize
 		*out = new(int)
@@ -134,6 +187,285 @@ func (in *Batcher) DeepCopy() *Batcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenSmokeTest) DeepCopyInto(out *BlueGreenSmokeTest) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenSmokeTest.
+func (in *BlueGreenSmokeTest) DeepCopy() *BlueGreenSmokeTest {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenSmokeTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenSpec) DeepCopyInto(out *BlueGreenSpec) {
+	*out = *in
+	if in.RollbackWindowSeconds != nil {
+		in, out := &in.RollbackWindowSeconds, &out.RollbackWindowSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(BlueGreenSmokeTest)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenSpec.
+func (in *BlueGreenSpec) DeepCopy() *BlueGreenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlueGreenStatus) DeepCopyInto(out *BlueGreenStatus) {
+	*out = *in
+	if in.SwitchTime != nil {
+		in, out := &in.SwitchTime, &out.SwitchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RollbackWindowEndTime != nil {
+		in, out := &in.RollbackWindowEndTime, &out.RollbackWindowEndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStatus.
+func (in *BlueGreenStatus) DeepCopy() *BlueGreenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlueGreenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysisSpec) DeepCopyInto(out *CanaryAnalysisSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StepPercent != nil {
+		in, out := &in.StepPercent, &out.StepPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxSteps != nil {
+		in, out := &in.MaxSteps, &out.MaxSteps
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinSuccessRate != nil {
+		in, out := &in.MinSuccessRate, &out.MinSuccessRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxLatencyMilliseconds != nil {
+		in, out := &in.MaxLatencyMilliseconds, &out.MaxLatencyMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisSpec.
+func (in *CanaryAnalysisSpec) DeepCopy() *CanaryAnalysisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysisStatus) DeepCopyInto(out *CanaryAnalysisStatus) {
+	*out = *in
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisStatus.
+func (in *CanaryAnalysisStatus) DeepCopy() *CanaryAnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacitySpec) DeepCopyInto(out *CapacitySpec) {
+	*out = *in
+	if in.OnDemandReplicas != nil {
+		in, out := &in.OnDemandReplicas, &out.OnDemandReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SpotNodeSelector != nil {
+		in, out := &in.SpotNodeSelector, &out.SpotNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SpotTolerations != nil {
+		in, out := &in.SpotTolerations, &out.SpotTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnDemandNodeSelector != nil {
+		in, out := &in.OnDemandNodeSelector, &out.OnDemandNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacitySpec.
+func (in *CapacitySpec) DeepCopy() *CapacitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityStatus) DeepCopyInto(out *CapacityStatus) {
+	*out = *in
+	if in.LastSpotPreemptionTime != nil {
+		in, out := &in.LastSpotPreemptionTime, &out.LastSpotPreemptionTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityStatus.
+func (in *CapacityStatus) DeepCopy() *CapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerReference) DeepCopyInto(out *CertificateIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerReference.
+func (in *CertificateIssuerReference) DeepCopy() *CertificateIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInferenceTemplate) DeepCopyInto(out *ClusterInferenceTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInferenceTemplate.
+func (in *ClusterInferenceTemplate) DeepCopy() *ClusterInferenceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInferenceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInferenceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInferenceTemplateList) DeepCopyInto(out *ClusterInferenceTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterInferenceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInferenceTemplateList.
+func (in *ClusterInferenceTemplateList) DeepCopy() *ClusterInferenceTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInferenceTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInferenceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInferenceTemplateSpec) DeepCopyInto(out *ClusterInferenceTemplateSpec) {
+	*out = *in
+	in.Predictor.DeepCopyInto(&out.Predictor)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInferenceTemplateSpec.
+func (in *ClusterInferenceTemplateSpec) DeepCopy() *ClusterInferenceTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInferenceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 	*out = *in
@@ -162,6 +494,11 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(AutoScalingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScaleSchedule != nil {
+		in, out := &in.ScaleSchedule, &out.ScaleSchedule
+		*out = make([]ScaleScheduleWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.ContainerConcurrency != nil {
 		in, out := &in.ContainerConcurrency, &out.ContainerConcurrency
 		*out = new(int64)
@@ -177,6 +514,26 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CanaryAnalysis != nil {
+		in, out := &in.CanaryAnalysis, &out.CanaryAnalysis
+		*out = new(CanaryAnalysisSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowTrafficPercent != nil {
+		in, out := &in.ShadowTrafficPercent, &out.ShadowTrafficPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WarmStandby != nil {
+		in, out := &in.WarmStandby, &out.WarmStandby
+		*out = new(WarmStandbySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowStart != nil {
+		in, out := &in.SlowStart, &out.SlowStart
+		*out = new(SlowStartSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Logger != nil {
 		in, out := &in.Logger, &out.Logger
 		*out = new(LoggerSpec)
@@ -206,6 +563,11 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(v1.DeploymentStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentExtensionSpec.
@@ -248,6 +610,26 @@ func (in *ComponentStatusSpec) DeepCopyInto(out *ComponentStatusSpec) {
 		*out = new(duckv1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WarmStandby != nil {
+		in, out := &in.WarmStandby, &out.WarmStandby
+		*out = new(WarmStandbyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryAnalysis != nil {
+		in, out := &in.CanaryAnalysis, &out.CanaryAnalysis
+		*out = new(CanaryAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(BlueGreenStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InferenceProbe != nil {
+		in, out := &in.InferenceProbe, &out.InferenceProbe
+		*out = new(InferenceProbeStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusSpec.
@@ -260,6 +642,30 @@ func (in *ComponentStatusSpec) DeepCopy() *ComponentStatusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashInfo) DeepCopyInto(out *CrashInfo) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.GPUXIDErrors != nil {
+		in, out := &in.GPUXIDErrors, &out.GPUXIDErrors
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashInfo.
+func (in *CrashInfo) DeepCopy() *CrashInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomExplainer) DeepCopyInto(out *CustomExplainer) {
 	*out = *in
@@ -308,6 +714,21 @@ func (in *CustomTransformer) DeepCopy() *CustomTransformer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyReference) DeepCopyInto(out *DependencyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyReference.
+func (in *DependencyReference) DeepCopy() *DependencyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentRolloutStrategy) DeepCopyInto(out *DeploymentRolloutStrategy) {
 	*out = *in
@@ -361,6 +782,21 @@ func (in *ExplainerExtensionSpec) DeepCopy() *ExplainerExtensionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExplainerSamplingSpec) DeepCopyInto(out *ExplainerSamplingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExplainerSamplingSpec.
+func (in *ExplainerSamplingSpec) DeepCopy() *ExplainerSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExplainerSamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExplainerSpec) DeepCopyInto(out *ExplainerSpec) {
 	*out = *in
@@ -376,6 +812,11 @@ func (in *ExplainerSpec) DeepCopyInto(out *ExplainerSpec) {
 		*out = make([]StorageUri, len(*in))
 		copy(*out, *in)
 	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(ExplainerSamplingSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExplainerSpec.
@@ -405,73 +846,165 @@ func (in *ExtMetricAuthentication) DeepCopy() *ExtMetricAuthentication {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalMetricSource) DeepCopyInto(out *ExternalMetricSource) {
+func (in *ExternalMetricSource) DeepCopyInto(out *ExternalMetricSource) {
+	*out = *in
+	out.Metric = in.Metric
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ExtMetricAuthentication)
+		**out = **in
+	}
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetricSource.
+func (in *ExternalMetricSource) DeepCopy() *ExternalMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMetrics) DeepCopyInto(out *ExternalMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetrics.
+func (in *ExternalMetrics) DeepCopy() *ExternalMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverSpec) DeepCopyInto(out *FailoverSpec) {
+	*out = *in
+	out.TargetRef = in.TargetRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverSpec.
+func (in *FailoverSpec) DeepCopy() *FailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverTargetReference) DeepCopyInto(out *FailoverTargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverTargetReference.
+func (in *FailoverTargetReference) DeepCopy() *FailoverTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureInfo) DeepCopyInto(out *FailureInfo) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureInfo.
+func (in *FailureInfo) DeepCopy() *FailureInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HuggingFaceRuntimeSpec) DeepCopyInto(out *HuggingFaceRuntimeSpec) {
 	*out = *in
-	out.Metric = in.Metric
-	if in.Authentication != nil {
-		in, out := &in.Authentication, &out.Authentication
-		*out = new(ExtMetricAuthentication)
-		**out = **in
-	}
-	in.Target.DeepCopyInto(&out.Target)
+	in.PredictorExtensionSpec.DeepCopyInto(&out.PredictorExtensionSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetricSource.
-func (in *ExternalMetricSource) DeepCopy() *ExternalMetricSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HuggingFaceRuntimeSpec.
+func (in *HuggingFaceRuntimeSpec) DeepCopy() *HuggingFaceRuntimeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalMetricSource)
+	out := new(HuggingFaceRuntimeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalMetrics) DeepCopyInto(out *ExternalMetrics) {
+func (in *IdleTimeoutSpec) DeepCopyInto(out *IdleTimeoutSpec) {
 	*out = *in
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetrics.
-func (in *ExternalMetrics) DeepCopy() *ExternalMetrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleTimeoutSpec.
+func (in *IdleTimeoutSpec) DeepCopy() *IdleTimeoutSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalMetrics)
+	out := new(IdleTimeoutSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailureInfo) DeepCopyInto(out *FailureInfo) {
+func (in *InferenceProbeSpec) DeepCopyInto(out *InferenceProbeSpec) {
 	*out = *in
-	if in.Time != nil {
-		in, out := &in.Time, &out.Time
-		*out = (*in).DeepCopy()
+	if in.ExpectedStatusCode != nil {
+		in, out := &in.ExpectedStatusCode, &out.ExpectedStatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureInfo.
-func (in *FailureInfo) DeepCopy() *FailureInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProbeSpec.
+func (in *InferenceProbeSpec) DeepCopy() *InferenceProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FailureInfo)
+	out := new(InferenceProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HuggingFaceRuntimeSpec) DeepCopyInto(out *HuggingFaceRuntimeSpec) {
+func (in *InferenceProbeStatus) DeepCopyInto(out *InferenceProbeStatus) {
 	*out = *in
-	in.PredictorExtensionSpec.DeepCopyInto(&out.PredictorExtensionSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HuggingFaceRuntimeSpec.
-func (in *HuggingFaceRuntimeSpec) DeepCopy() *HuggingFaceRuntimeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProbeStatus.
+func (in *InferenceProbeStatus) DeepCopy() *InferenceProbeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HuggingFaceRuntimeSpec)
+	out := new(InferenceProbeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -503,6 +1036,26 @@ func (in *InferenceService) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceServiceAddress) DeepCopyInto(out *InferenceServiceAddress) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceAddress.
+func (in *InferenceServiceAddress) DeepCopy() *InferenceServiceAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceServiceAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceServiceList) DeepCopyInto(out *InferenceServiceList) {
 	*out = *in
@@ -549,6 +1102,46 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(TransformerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverSpec)
+		**out = **in
+	}
+	if in.ABTest != nil {
+		in, out := &in.ABTest, &out.ABTest
+		*out = new(ABTestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(ObservabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = new(ReadinessGatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]DependencyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(InferenceTemplateReference)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(IdleTimeoutSpec)
+		**out = **in
+	}
+	if in.Transport != nil {
+		in, out := &in.Transport, &out.Transport
+		*out = new(TransportSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceSpec.
@@ -583,6 +1176,37 @@ func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
 		}
 	}
 	in.ModelStatus.DeepCopyInto(&out.ModelStatus)
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]PredictorModelStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]InferenceServiceAddress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]ModelVariantStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCrashInfo != nil {
+		in, out := &in.LastCrashInfo, &out.LastCrashInfo
+		*out = new(CrashInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceStatus.
@@ -595,6 +1219,21 @@ func (in *InferenceServiceStatus) DeepCopy() *InferenceServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceTemplateReference) DeepCopyInto(out *InferenceTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceTemplateReference.
+func (in *InferenceTemplateReference) DeepCopy() *InferenceTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LightGBMSpec) DeepCopyInto(out *LightGBMSpec) {
 	*out = *in
@@ -611,6 +1250,101 @@ func (in *LightGBMSpec) DeepCopy() *LightGBMSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerEncryptionSpec) DeepCopyInto(out *LoggerEncryptionSpec) {
+	*out = *in
+	if in.KMSKeyID != nil {
+		in, out := &in.KMSKeyID, &out.KMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicKeySecretRef != nil {
+		in, out := &in.PublicKeySecretRef, &out.PublicKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerEncryptionSpec.
+func (in *LoggerEncryptionSpec) DeepCopy() *LoggerEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerHeaderFilter) DeepCopyInto(out *LoggerHeaderFilter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerHeaderFilter.
+func (in *LoggerHeaderFilter) DeepCopy() *LoggerHeaderFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerHeaderFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerKafkaSpec) DeepCopyInto(out *LoggerKafkaSpec) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerKafkaSpec.
+func (in *LoggerKafkaSpec) DeepCopy() *LoggerKafkaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerKafkaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggerSamplingSpec) DeepCopyInto(out *LoggerSamplingSpec) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ErrorPercent != nil {
+		in, out := &in.ErrorPercent, &out.ErrorPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerSamplingSpec.
+func (in *LoggerSamplingSpec) DeepCopy() *LoggerSamplingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoggerSamplingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 	*out = *in
@@ -634,6 +1368,38 @@ func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 		*out = new(LoggerStorageSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(LoggerKafkaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(LoggerSamplingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderFilters != nil {
+		in, out := &in.HeaderFilters, &out.HeaderFilters
+		*out = make([]LoggerHeaderFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RedactFields != nil {
+		in, out := &in.RedactFields, &out.RedactFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(LoggerEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerSpec.
@@ -719,6 +1485,11 @@ func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
 		*out = new(PodMetricSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(RuntimeMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
@@ -816,40 +1587,133 @@ func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
 		*out = new(FailureInfo)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ModelCopies != nil {
-		in, out := &in.ModelCopies, &out.ModelCopies
-		*out = new(ModelCopies)
-		**out = **in
+	if in.ModelCopies != nil {
+		in, out := &in.ModelCopies, &out.ModelCopies
+		*out = new(ModelCopies)
+		**out = **in
+	}
+	if in.ResolvedRevisions != nil {
+		in, out := &in.ResolvedRevisions, &out.ResolvedRevisions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStorageSpec) DeepCopyInto(out *ModelStorageSpec) {
+	*out = *in
+	in.StorageSpec.DeepCopyInto(&out.StorageSpec)
+	if in.SchemaPath != nil {
+		in, out := &in.SchemaPath, &out.SchemaPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStorageSpec.
+func (in *ModelStorageSpec) DeepCopy() *ModelStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelVariant) DeepCopyInto(out *ModelVariant) {
+	*out = *in
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(ModelVariantTargetReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelVariant.
+func (in *ModelVariant) DeepCopy() *ModelVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelVariantStatus) DeepCopyInto(out *ModelVariantStatus) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelVariantStatus.
+func (in *ModelVariantStatus) DeepCopy() *ModelVariantStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(ModelVariantStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
-func (in *ModelStatus) DeepCopy() *ModelStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelVariantTargetReference) DeepCopyInto(out *ModelVariantTargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelVariantTargetReference.
+func (in *ModelVariantTargetReference) DeepCopy() *ModelVariantTargetReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStatus)
+	out := new(ModelVariantTargetReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelStorageSpec) DeepCopyInto(out *ModelStorageSpec) {
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 	*out = *in
-	in.StorageSpec.DeepCopyInto(&out.StorageSpec)
-	if in.SchemaPath != nil {
-		in, out := &in.SchemaPath, &out.SchemaPath
-		*out = new(string)
+	if in.DriftBatchSize != nil {
+		in, out := &in.DriftBatchSize, &out.DriftBatchSize
+		*out = new(int32)
 		**out = **in
 	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStorageSpec.
-func (in *ModelStorageSpec) DeepCopy() *ModelStorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStorageSpec)
+	out := new(MonitoringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -870,6 +1734,26 @@ func (in *ONNXRuntimeSpec) DeepCopy() *ONNXRuntimeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
+	*out = *in
+	if in.OtelCollector != nil {
+		in, out := &in.OtelCollector, &out.OtelCollector
+		*out = new(OtelCollectorSidecarSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservabilitySpec.
+func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OtelCollectorConfig) DeepCopyInto(out *OtelCollectorConfig) {
 	*out = *in
@@ -886,6 +1770,26 @@ func (in *OtelCollectorConfig) DeepCopy() *OtelCollectorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtelCollectorSidecarSpec) DeepCopyInto(out *OtelCollectorSidecarSpec) {
+	*out = *in
+	if in.MetricNames != nil {
+		in, out := &in.MetricNames, &out.MetricNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtelCollectorSidecarSpec.
+func (in *OtelCollectorSidecarSpec) DeepCopy() *OtelCollectorSidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OtelCollectorSidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PMMLSpec) DeepCopyInto(out *PMMLSpec) {
 	*out = *in
@@ -1171,6 +2075,47 @@ func (in *PredictorExtensionSpec) DeepCopy() *PredictorExtensionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictorModelSpec) DeepCopyInto(out *PredictorModelSpec) {
+	*out = *in
+	in.ModelFormat.DeepCopyInto(&out.ModelFormat)
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictorModelSpec.
+func (in *PredictorModelSpec) DeepCopy() *PredictorModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictorModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictorModelStatus) DeepCopyInto(out *PredictorModelStatus) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictorModelStatus.
+func (in *PredictorModelStatus) DeepCopy() *PredictorModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictorModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PredictorSpec) DeepCopyInto(out *PredictorSpec) {
 	*out = *in
@@ -1234,11 +2179,33 @@ func (in *PredictorSpec) DeepCopyInto(out *PredictorSpec) {
 		*out = make([]StorageUri, len(*in))
 		copy(*out, *in)
 	}
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]PredictorModelSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WorkerSpec != nil {
 		in, out := &in.WorkerSpec, &out.WorkerSpec
 		*out = new(WorkerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ZoneSpread != nil {
+		in, out := &in.ZoneSpread, &out.ZoneSpread
+		*out = new(ZoneSpreadPolicy)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
 	in.ComponentExtensionSpec.DeepCopyInto(&out.ComponentExtensionSpec)
 }
@@ -1253,6 +2220,78 @@ func (in *PredictorSpec) DeepCopy() *PredictorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictorTemplateSpec) DeepCopyInto(out *PredictorTemplateSpec) {
+	*out = *in
+	if in.ModelFormat != nil {
+		in, out := &in.ModelFormat, &out.ModelFormat
+		*out = new(ModelFormat)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleTarget != nil {
+		in, out := &in.ScaleTarget, &out.ScaleTarget
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleMetric != nil {
+		in, out := &in.ScaleMetric, &out.ScaleMetric
+		*out = new(ScaleMetric)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictorTemplateSpec.
+func (in *PredictorTemplateSpec) DeepCopy() *PredictorTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictorTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGatesSpec) DeepCopyInto(out *ReadinessGatesSpec) {
+	*out = *in
+	if in.InferenceProbe != nil {
+		in, out := &in.InferenceProbe, &out.InferenceProbe
+		*out = new(InferenceProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGatesSpec.
+func (in *ReadinessGatesSpec) DeepCopy() *ReadinessGatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceMetricSource) DeepCopyInto(out *ResourceMetricSource) {
 	*out = *in
@@ -1284,6 +2323,27 @@ func (in *RolloutSpec) DeepCopy() *RolloutSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeMetricSource) DeepCopyInto(out *RuntimeMetricSource) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ExtMetricAuthentication)
+		**out = **in
+	}
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeMetricSource.
+func (in *RuntimeMetricSource) DeepCopy() *RuntimeMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SKLearnSpec) DeepCopyInto(out *SKLearnSpec) {
 	*out = *in
@@ -1300,6 +2360,81 @@ func (in *SKLearnSpec) DeepCopy() *SKLearnSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleScheduleWindow) DeepCopyInto(out *ScaleScheduleWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleScheduleWindow.
+func (in *ScaleScheduleWindow) DeepCopy() *ScaleScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerTLSSpec) DeepCopyInto(out *ServerTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertificateIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerTLSSpec.
+func (in *ServerTLSSpec) DeepCopy() *ServerTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionAffinitySpec) DeepCopyInto(out *SessionAffinitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionAffinitySpec.
+func (in *SessionAffinitySpec) DeepCopy() *SessionAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlowStartSpec) DeepCopyInto(out *SlowStartSpec) {
+	*out = *in
+	if in.InitialPercent != nil {
+		in, out := &in.InitialPercent, &out.InitialPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrency != nil {
+		in, out := &in.MaxConcurrency, &out.MaxConcurrency
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlowStartSpec.
+func (in *SlowStartSpec) DeepCopy() *SlowStartSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlowStartSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
@@ -1405,6 +2540,26 @@ func (in *TransformerSpec) DeepCopy() *TransformerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportSpec) DeepCopyInto(out *TransportSpec) {
+	*out = *in
+	if in.ServerTLS != nil {
+		in, out := &in.ServerTLS, &out.ServerTLS
+		*out = new(ServerTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportSpec.
+func (in *TransportSpec) DeepCopy() *TransportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TritonSpec) DeepCopyInto(out *TritonSpec) {
 	*out = *in
@@ -1421,6 +2576,42 @@ func (in *TritonSpec) DeepCopy() *TritonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmStandbySpec) DeepCopyInto(out *WarmStandbySpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmStandbySpec.
+func (in *WarmStandbySpec) DeepCopy() *WarmStandbySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmStandbySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmStandbyStatus) DeepCopyInto(out *WarmStandbyStatus) {
+	*out = *in
+	in.SoakEndTime.DeepCopyInto(&out.SoakEndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmStandbyStatus.
+func (in *WarmStandbyStatus) DeepCopy() *WarmStandbyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmStandbyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 	*out = *in
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// MetricsPath serves the decisions of the adaptive batcher
+	MetricsPath = "/batcher/metrics"
+	// adaptiveWindow is the number of batches the p95 latency is computed over before each decision
+	adaptiveWindow = 50
+	// adaptiveHeadroom is the share of the latency SLO the p95 latency must stay under for the batches to grow
+	adaptiveHeadroom = 0.8
+	// adaptiveSteps is the number of decisions the batches grow from their min to their max limits in
+	adaptiveSteps = 8
+	// minAdaptiveLatency is the max latency the batches are never shrunk below
+	minAdaptiveLatency = time.Millisecond
+)
+
+// Adjustment decisions of the adaptive batcher
+const (
+	adjustmentGrow   = "grow"
+	adjustmentShrink = "shrink"
+	adjustmentHold   = "hold"
+)
+
+// batchLimits are the max batch size and the max latency the batches are flushed at
+type batchLimits interface {
+	// limits returns the current max batch size and max latency
+	limits() (int, time.Duration)
+	// observe records the latency of a flushed batch, from the arrival of its first request to its response
+	observe(latency time.Duration)
+}
+
+// fixedLimits are the limits of the batcher when it is not adaptive
+type fixedLimits struct {
+	maxBatchSize int
+	maxLatency   time.Duration
+}
+
+func (l fixedLimits) limits() (int, time.Duration) {
+	return l.maxBatchSize, l.maxLatency
+}
+
+func (l fixedLimits) observe(time.Duration) {}
+
+// adaptiveLimits adjust the limits of the batches to a latency SLO from the p95 latency of the batched requests. The
+// limits grow additively, from their min to their configured max values, while the p95 latency is well under the SLO
+// and halve as soon as it exceeds it. The latency of a batch is the latency of its first and longest waiting request.
+type adaptiveLimits struct {
+	latencySLO   time.Duration
+	minBatchSize int
+	maxBatchSize int
+	maxLatency   time.Duration
+
+	mu         sync.Mutex
+	batchSize  int
+	latency    time.Duration
+	samples    []time.Duration
+	p95Latency time.Duration
+
+	metrics     http.Handler
+	batches     prometheus.Histogram
+	adjustments *prometheus.CounterVec
+}
+
+func newAdaptiveLimits(maxBatchSize int, maxLatency time.Duration, latencySLO time.Duration, minBatchSize int) *adaptiveLimits {
+	// a batch waiting longer than the SLO always misses it
+	maxLatency = min(maxLatency, latencySLO)
+	minBatchSize = min(max(minBatchSize, 1), maxBatchSize)
+	l := &adaptiveLimits{
+		latencySLO:   latencySLO,
+		minBatchSize: minBatchSize,
+		maxBatchSize: maxBatchSize,
+		maxLatency:   max(maxLatency, minAdaptiveLatency),
+		batchSize:    minBatchSize,
+		latency:      minAdaptiveLatency,
+		batches: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "kserve_agent",
+			Subsystem: "batcher",
+			Name:      "batch_latency_seconds",
+			Help:      "Latency of the batches, from the arrival of their first request to their response",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}),
+		adjustments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "kserve_agent",
+			Subsystem: "batcher",
+			Name:      "adjustments_total",
+			Help:      "Decisions of the adaptive batcher on the limits of the batches",
+		}, []string{"decision"}),
+	}
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(l.batches, l.adjustments)
+	for _, gauge := range []struct {
+		name  string
+		help  string
+		value func() float64
+	}{
+		{"max_batch_size", "Current max batch size of the adaptive batcher", func() float64 {
+			batchSize, _ := l.limits()
+			return float64(batchSize)
+		}},
+		{"max_latency_seconds", "Current max latency of the batches of the adaptive batcher", func() float64 {
+			_, latency := l.limits()
+			return latency.Seconds()
+		}},
+		{"p95_latency_seconds", "p95 latency of the batches the last decision was taken on", func() float64 {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			return l.p95Latency.Seconds()
+		}},
+		{"latency_slo_seconds", "Latency SLO of the adaptive batcher", func() float64 { return l.latencySLO.Seconds() }},
+	} {
+		registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "kserve_agent",
+			Subsystem: "batcher",
+			Name:      gauge.name,
+			Help:      gauge.help,
+		}, gauge.value))
+	}
+	l.metrics = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return l
+}
+
+func (l *adaptiveLimits) limits() (int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.batchSize, l.latency
+}
+
+func (l *adaptiveLimits) observe(latency time.Duration) {
+	l.batches.Observe(latency.Seconds())
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples = append(l.samples, latency)
+	if len(l.samples) < adaptiveWindow {
+		return
+	}
+	l.p95Latency = percentile(l.samples, 0.95)
+	l.samples = l.samples[:0]
+	l.adjustments.WithLabelValues(l.adjust()).Inc()
+}
+
+// adjust grows or shrinks the limits from the p95 latency of the last window and returns the decision
+func (l *adaptiveLimits) adjust() string {
+	switch {
+	case l.p95Latency > l.latencySLO:
+		batchSize, latency := max(l.batchSize/2, l.minBatchSize), max(l.latency/2, minAdaptiveLatency)
+		if batchSize == l.batchSize && latency == l.latency {
+			return adjustmentHold
+		}
+		l.batchSize, l.latency = batchSize, latency
+		return adjustmentShrink
+	case float64(l.p95Latency) < adaptiveHeadroom*float64(l.latencySLO):
+		batchSize := min(l.batchSize+max((l.maxBatchSize-l.minBatchSize)/adaptiveSteps, 1), l.maxBatchSize)
+		latency := min(l.latency+max((l.maxLatency-minAdaptiveLatency)/adaptiveSteps, minAdaptiveLatency), l.maxLatency)
+		if batchSize == l.batchSize && latency == l.latency {
+			return adjustmentHold
+		}
+		l.batchSize, l.latency = batchSize, latency
+		return adjustmentGrow
+	default:
+		return adjustmentHold
+	}
+}
+
+// percentile returns the nearest-rank percentile of the samples, which are sorted in place
+func percentile(samples []time.Duration, p float64) time.Duration {
+	slices.Sort(samples)
+	rank := int(math.Ceil(p*float64(len(samples)))) - 1
+	return samples[min(max(rank, 0), len(samples)-1)]
+}
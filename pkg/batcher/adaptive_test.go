@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+// observeWindow reports a window of batches of the given latency
+func observeWindow(limits *adaptiveLimits, latency time.Duration) {
+	for range adaptiveWindow {
+		limits.observe(latency)
+	}
+}
+
+func TestAdaptiveLimits(t *testing.T) {
+	limits := newAdaptiveLimits(34, 100*time.Millisecond, 200*time.Millisecond, 2)
+
+	batchSize, latency := limits.limits()
+	assert.Equal(t, 2, batchSize)
+	assert.Equal(t, minAdaptiveLatency, latency)
+
+	// the limits grow while the p95 latency is well under the SLO
+	observeWindow(limits, 50*time.Millisecond)
+	batchSize, latency = limits.limits()
+	assert.Equal(t, 6, batchSize)
+	assert.Equal(t, minAdaptiveLatency+99*time.Millisecond/8, latency)
+
+	// up to their max values
+	for range 2 * adaptiveSteps {
+		observeWindow(limits, 50*time.Millisecond)
+	}
+	batchSize, latency = limits.limits()
+	assert.Equal(t, 34, batchSize)
+	assert.Equal(t, 100*time.Millisecond, latency)
+
+	// the limits hold while the p95 latency is close to the SLO, even though most batches are fast
+	for i := range adaptiveWindow {
+		if i < adaptiveWindow/10 {
+			limits.observe(190 * time.Millisecond)
+		} else {
+			limits.observe(10 * time.Millisecond)
+		}
+	}
+	batchSize, latency = limits.limits()
+	assert.Equal(t, 34, batchSize)
+	assert.Equal(t, 100*time.Millisecond, latency)
+	assert.Equal(t, 190*time.Millisecond, limits.p95Latency)
+
+	// and halve as soon as it exceeds the SLO, down to their min values
+	observeWindow(limits, 300*time.Millisecond)
+	batchSize, latency = limits.limits()
+	assert.Equal(t, 17, batchSize)
+	assert.Equal(t, 50*time.Millisecond, latency)
+	for range 10 {
+		observeWindow(limits, 300*time.Millisecond)
+	}
+	batchSize, latency = limits.limits()
+	assert.Equal(t, 2, batchSize)
+	assert.Equal(t, minAdaptiveLatency, latency)
+}
+
+func TestAdaptiveLimitsCapMaxLatency(t *testing.T) {
+	limits := newAdaptiveLimits(8, 5*time.Second, 200*time.Millisecond, 16)
+	assert.Equal(t, 200*time.Millisecond, limits.maxLatency)
+	assert.Equal(t, 8, limits.minBatchSize)
+}
+
+func TestBatcherAdaptiveMetrics(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	batchHandler := NewAdaptive(32, 100, 200, 4, http.NotFoundHandler(), logger)
+	observeWindow(batchHandler.adaptive, 10*time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	batchHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `kserve_agent_batcher_adjustments_total{decision="grow"} 1`)
+	assert.Contains(t, string(body), "kserve_agent_batcher_max_batch_size 7")
+	assert.Contains(t, string(body), "kserve_agent_batcher_latency_slo_seconds 0.2")
+	assert.Contains(t, string(body), "kserve_agent_batcher_batch_latency_seconds_count 50")
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+// grpcModelInferPath is the path of the ModelInfer method of the open inference protocol gRPC service
+const grpcModelInferPath = "/" + protocol.GrpcInferenceServiceName + "/ModelInfer"
+
+// grpcFrameHeaderSize is the size of the header of a gRPC message: the compressed flag and the 4 bytes length
+const grpcFrameHeaderSize = 5
+
+// grpcRequest is a gRPC ModelInfer request waiting in a batch
+type grpcRequest struct {
+	header  http.Header
+	request protoreflect.Message
+	rows    int
+	result  chan grpcResult
+}
+
+// grpcResult is the response of a gRPC ModelInfer request split from the response of its batch, the payload is only
+// set when the status is OK
+type grpcResult struct {
+	status  string
+	message string
+	payload []byte
+}
+
+func get(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+	return m.Get(m.Descriptor().Fields().ByName(name))
+}
+
+func set(m protoreflect.Message, name protoreflect.Name, value protoreflect.Value) {
+	m.Set(m.Descriptor().Fields().ByName(name), value)
+}
+
+func clearField(m protoreflect.Message, name protoreflect.Name) {
+	m.Clear(m.Descriptor().Fields().ByName(name))
+}
+
+func mutable(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+	return m.Mutable(m.Descriptor().Fields().ByName(name))
+}
+
+// isGrpcModelInfer returns whether the request is a gRPC call of ModelInfer
+func isGrpcModelInfer(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.URL.Path == grpcModelInferPath && (contentType == "application/grpc" || strings.HasPrefix(contentType, "application/grpc+"))
+}
+
+// decodeGrpcFrame returns the message of a unary gRPC call, false when it is compressed or not a single message
+func decodeGrpcFrame(body []byte) ([]byte, bool) {
+	if len(body) < grpcFrameHeaderSize || body[0] != 0 {
+		return nil, false
+	}
+	if binary.BigEndian.Uint32(body[1:grpcFrameHeaderSize]) != uint32(len(body)-grpcFrameHeaderSize) {
+		return nil, false
+	}
+	return body[grpcFrameHeaderSize:], true
+}
+
+func encodeGrpcFrame(message []byte) []byte {
+	frame := make([]byte, grpcFrameHeaderSize, grpcFrameHeaderSize+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	return append(frame, message...)
+}
+
+// encodeGrpcMessage percent-encodes the status message of a gRPC call
+func encodeGrpcMessage(message string) string {
+	var encoded strings.Builder
+	for _, c := range []byte(message) {
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		} else {
+			encoded.WriteByte(c)
+		}
+	}
+	return encoded.String()
+}
+
+// serveGrpc batches the gRPC ModelInfer requests of the same model and tensor signature, merging their inputs along
+// the batch dimension. The requests which cannot be merged are passed to next unchanged.
+func (handler *BatchHandler) serveGrpc(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	message, ok := decodeGrpcFrame(body)
+	if !ok {
+		handler.passThrough(w, r, body)
+		return
+	}
+	request := dynamicpb.NewMessage(handler.inferRequest)
+	if err := proto.Unmarshal(message, request); err != nil {
+		handler.passThrough(w, r, body)
+		return
+	}
+	key, rows, ok := grpcBatchKey(request)
+	if !ok {
+		handler.passThrough(w, r, body)
+		return
+	}
+
+	pending := &grpcRequest{
+		header:  r.Header,
+		request: request,
+		rows:    rows,
+		result:  make(chan grpcResult, 1),
+	}
+	handler.grpcBatches.add(key, pending, rows)
+	result := <-pending.result
+	w.Header().Set("Content-Type", "application/grpc")
+	w.WriteHeader(http.StatusOK)
+	if result.payload != nil {
+		if _, err := w.Write(encodeGrpcFrame(result.payload)); err != nil {
+			handler.log.Errorf("failed to write the response of %s: %v", r.URL.Path, err)
+		}
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", result.status)
+	if result.message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", result.message)
+	}
+}
+
+// grpcBatchKey returns what the requests batched with the ModelInfer request share, the request without its id, its
+// data and its batch dimension, and the size of its batch dimension. It returns false when the request cannot be
+// batched.
+func grpcBatchKey(request protoreflect.Message) (string, int, bool) {
+	inputs := get(request, "inputs").List()
+	shapes := make([][]int64, 0, inputs.Len())
+	for i := range inputs.Len() {
+		shape := get(inputs.Get(i).Message(), "shape").List()
+		dims := make([]int64, 0, shape.Len())
+		for j := range shape.Len() {
+			dims = append(dims, shape.Get(j).Int())
+		}
+		shapes = append(shapes, dims)
+	}
+	rows, ok := batchRows(shapes)
+	rawInputs := get(request, "raw_input_contents").List().Len()
+	if !ok || (rawInputs > 0 && rawInputs != inputs.Len()) {
+		return "", 0, false
+	}
+
+	signature := proto.Clone(request.Interface()).ProtoReflect()
+	clearField(signature, "id")
+	clearField(signature, "raw_input_contents")
+	signatureInputs := get(signature, "inputs").List()
+	for i := range signatureInputs.Len() {
+		input := signatureInputs.Get(i).Message()
+		clearField(input, "contents")
+		get(input, "shape").List().Set(0, protoreflect.ValueOfInt64(0))
+	}
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(signature.Interface())
+	if err != nil {
+		return "", 0, false
+	}
+	return strconv.FormatBool(rawInputs > 0) + "/" + string(key), rows, true
+}
+
+// flushGrpc sends the batched ModelInfer requests as one call and splits its response
+func (handler *BatchHandler) flushGrpc(requests []*grpcRequest) {
+	first := requests[0]
+	rows := make([]int, 0, len(requests))
+	total := 0
+	for _, pending := range requests {
+		rows = append(rows, pending.rows)
+		total += pending.rows
+	}
+	merged := proto.Clone(first.request.Interface()).ProtoReflect()
+	set(merged, "id", protoreflect.ValueOfString(GenerateUUID()))
+	inputs := get(merged, "inputs").List()
+	rawInputs := get(merged, "raw_input_contents").List()
+	for i := range inputs.Len() {
+		input := inputs.Get(i).Message()
+		get(input, "shape").List().Set(0, protoreflect.ValueOfInt64(int64(total)))
+		if rawInputs.Len() > 0 {
+			var raw []byte
+			for _, pending := range requests {
+				raw = append(raw, get(pending.request, "raw_input_contents").List().Get(i).Bytes()...)
+			}
+			rawInputs.Set(i, protoreflect.ValueOfBytes(raw))
+			continue
+		}
+		contents := mutable(input, "contents").Message()
+		for _, pending := range requests[1:] {
+			other := get(get(pending.request, "inputs").List().Get(i).Message(), "contents").Message()
+			other.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+				elements := contents.Mutable(field).List()
+				for k := range value.List().Len() {
+					elements.Append(value.List().Get(k))
+				}
+				return true
+			})
+		}
+	}
+	handler.log.Infof("batch infer with size %d %s", total, grpcModelInferPath)
+
+	message, err := proto.Marshal(merged.Interface())
+	if err != nil {
+		respondGrpcError(requests, codes.Internal, err.Error())
+		return
+	}
+	r := httptest.NewRequest(http.MethodPost, grpcModelInferPath, bytes.NewReader(encodeGrpcFrame(message)))
+	r.Proto, r.ProtoMajor, r.ProtoMinor = "HTTP/2.0", 2, 0
+	r.Header = first.header.Clone()
+	r.Header.Del("Content-Length")
+	rr := httptest.NewRecorder()
+	handler.next.ServeHTTP(rr, r)
+	result := rr.Result()
+	if rr.Code != http.StatusOK {
+		respondGrpcError(requests, codes.Unknown, fmt.Sprintf("the batch failed with the HTTP status %d", rr.Code))
+		return
+	}
+	status, statusMessage := result.Trailer.Get("Grpc-Status"), result.Trailer.Get("Grpc-Message")
+	if status == "" {
+		// a trailers-only response
+		status, statusMessage = result.Header.Get("Grpc-Status"), result.Header.Get("Grpc-Message")
+	}
+	if status != strconv.Itoa(int(codes.OK)) {
+		handler.log.Errorf("error response with status %s for the batch of %s", status, grpcModelInferPath)
+		for _, pending := range requests {
+			pending.result <- grpcResult{status: status, message: statusMessage}
+		}
+		return
+	}
+
+	payload, ok := decodeGrpcFrame(rr.Body.Bytes())
+	if !ok {
+		respondGrpcError(requests, codes.Internal, "the batch response is not a single uncompressed message")
+		return
+	}
+	response := dynamicpb.NewMessage(handler.inferResponse)
+	if err := proto.Unmarshal(payload, response); err != nil {
+		respondGrpcError(requests, codes.Internal, "can't Unmarshal the batch response: "+err.Error())
+		return
+	}
+	responses, err := splitModelInferResponse(response, rows)
+	if err != nil {
+		respondGrpcError(requests, codes.Internal, err.Error())
+		return
+	}
+	for j, pending := range requests {
+		set(responses[j], "id", get(pending.request, "id"))
+		payload, err := proto.Marshal(responses[j].Interface())
+		if err != nil {
+			pending.result <- grpcResult{status: strconv.Itoa(int(codes.Internal)), message: encodeGrpcMessage(err.Error())}
+			continue
+		}
+		pending.result <- grpcResult{status: strconv.Itoa(int(codes.OK)), payload: payload}
+	}
+}
+
+// splitModelInferResponse splits the outputs of the ModelInferResponse of a batch along the batch dimension into the
+// responses of the requests of the given rows
+func splitModelInferResponse(response protoreflect.Message, rows []int) ([]protoreflect.Message, error) {
+	total := 0
+	for _, count := range rows {
+		total += count
+	}
+	outputs := get(response, "outputs").List()
+	rawOutputs := get(response, "raw_output_contents").List()
+	rawSplits := make([][][]byte, outputs.Len())
+	contentsSplits := make([]map[protoreflect.FieldDescriptor][][]protoreflect.Value, outputs.Len())
+	for i := range outputs.Len() {
+		output := outputs.Get(i).Message()
+		name := get(output, "name").String()
+		shape := get(output, "shape").List()
+		dims := make([]int64, 0, shape.Len())
+		for j := range shape.Len() {
+			dims = append(dims, shape.Get(j).Int())
+		}
+		if len(dims) == 0 || dims[0] != int64(total) {
+			return nil, fmt.Errorf("the batch dimension of the output %s is not the size of the batch", name)
+		}
+		// The raw contents, when used, hold the outputs in order
+		if i < rawOutputs.Len() {
+			split, err := splitRawContents(get(output, "datatype").String(), rawOutputs.Get(i).Bytes(), rows, rowElements(dims))
+			if err != nil {
+				return nil, fmt.Errorf("output %s: %w", name, err)
+			}
+			rawSplits[i] = split
+			continue
+		}
+		contentsSplits[i] = map[protoreflect.FieldDescriptor][][]protoreflect.Value{}
+		var err error
+		get(output, "contents").Message().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			elements := make([]protoreflect.Value, 0, value.List().Len())
+			for k := range value.List().Len() {
+				elements = append(elements, value.List().Get(k))
+			}
+			contentsSplits[i][field], err = splitElements(elements, rows, rowElements(dims))
+			return err == nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("output %s: %w", name, err)
+		}
+	}
+
+	responses := make([]protoreflect.Message, 0, len(rows))
+	for j, count := range rows {
+		split := proto.Clone(response.Interface()).ProtoReflect()
+		splitOutputs := get(split, "outputs").List()
+		splitRawOutputs := get(split, "raw_output_contents").List()
+		for i := range splitOutputs.Len() {
+			output := splitOutputs.Get(i).Message()
+			get(output, "shape").List().Set(0, protoreflect.ValueOfInt64(int64(count)))
+			if rawSplits[i] != nil {
+				splitRawOutputs.Set(i, protoreflect.ValueOfBytes(rawSplits[i][j]))
+				continue
+			}
+			contents := mutable(output, "contents").Message()
+			for field, values := range contentsSplits[i] {
+				elements := contents.Mutable(field).List()
+				elements.Truncate(0)
+				for _, value := range values[j] {
+					elements.Append(value)
+				}
+			}
+		}
+		responses = append(responses, split)
+	}
+	return responses, nil
+}
+
+func respondGrpcError(requests []*grpcRequest, code codes.Code, message string) {
+	for _, pending := range requests {
+		pending.result <- grpcResult{status: strconv.Itoa(int(code)), message: encodeGrpcMessage(message)}
+	}
+}
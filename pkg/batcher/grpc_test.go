@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+// echoGrpc answers the ModelInfer calls with an output-0 tensor of the contents of their first input
+func echoGrpc(t *testing.T, batcher *BatchHandler, calls *atomic.Int32, status string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/grpc")
+		if status != "0" {
+			w.Header().Set("Grpc-Status", status)
+			w.Header().Set("Grpc-Message", "model%20not%20ready")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		message, ok := decodeGrpcFrame(body)
+		require.True(t, ok)
+		request := dynamicpb.NewMessage(batcher.inferRequest)
+		require.NoError(t, proto.Unmarshal(message, request))
+
+		response := dynamicpb.NewMessage(batcher.inferResponse)
+		set(response, "model_name", get(request, "model_name"))
+		set(response, "id", get(request, "id"))
+		input := get(request, "inputs").List().Get(0).Message()
+		outputs := mutable(response, "outputs").List()
+		output := outputs.NewElement().Message()
+		set(output, "name", protoreflect.ValueOfString("output-0"))
+		set(output, "datatype", get(input, "datatype"))
+		inputShape, shape := get(input, "shape").List(), mutable(output, "shape").List()
+		for i := range inputShape.Len() {
+			shape.Append(inputShape.Get(i))
+		}
+		if rawInputs := get(request, "raw_input_contents").List(); rawInputs.Len() > 0 {
+			mutable(response, "raw_output_contents").List().Append(rawInputs.Get(0))
+		} else {
+			set(output, "contents", get(input, "contents"))
+		}
+		outputs.Append(protoreflect.ValueOfMessage(output))
+		payload, err := proto.Marshal(response)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(encodeGrpcFrame(payload))
+		w.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+	})
+}
+
+func fp32Raw(values ...float32) []byte {
+	raw := make([]byte, 0, 4*len(values))
+	for _, value := range values {
+		raw = binary.LittleEndian.AppendUint32(raw, math.Float32bits(value))
+	}
+	return raw
+}
+
+// newModelInferRequest returns a ModelInfer request of an input of rows of 2 FP32 elements, in the raw contents or
+// in the contents
+func newModelInferRequest(batcher *BatchHandler, id string, raw bool, values ...float32) protoreflect.Message {
+	request := dynamicpb.NewMessage(batcher.inferRequest)
+	set(request, "model_name", protoreflect.ValueOfString("model"))
+	set(request, "id", protoreflect.ValueOfString(id))
+	input := mutable(request, "inputs").List().AppendMutable().Message()
+	set(input, "name", protoreflect.ValueOfString("input-0"))
+	set(input, "datatype", protoreflect.ValueOfString("FP32"))
+	shape := mutable(input, "shape").List()
+	shape.Append(protoreflect.ValueOfInt64(int64(len(values) / 2)))
+	shape.Append(protoreflect.ValueOfInt64(2))
+	if raw {
+		mutable(request, "raw_input_contents").List().Append(protoreflect.ValueOfBytes(fp32Raw(values...)))
+	} else {
+		contents := mutable(mutable(input, "contents").Message(), "fp32_contents").List()
+		for _, value := range values {
+			contents.Append(protoreflect.ValueOfFloat32(value))
+		}
+	}
+	return request
+}
+
+// callModelInfer calls ModelInfer through the batcher and returns the response and the gRPC status
+func callModelInfer(t *testing.T, batcher *BatchHandler, request protoreflect.Message) (protoreflect.Message, string, string) {
+	payload, err := proto.Marshal(request.Interface())
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, grpcModelInferPath, bytes.NewReader(encodeGrpcFrame(payload)))
+	r.Header.Set("Content-Type", "application/grpc")
+	recorder := httptest.NewRecorder()
+	batcher.ServeHTTP(recorder, r)
+	result := recorder.Result()
+	status, message := result.Trailer.Get("Grpc-Status"), result.Trailer.Get("Grpc-Message")
+	if status != "0" {
+		return nil, status, message
+	}
+	frame, ok := decodeGrpcFrame(recorder.Body.Bytes())
+	require.True(t, ok)
+	response := dynamicpb.NewMessage(batcher.inferResponse)
+	require.NoError(t, proto.Unmarshal(frame, response))
+	return response, status, ""
+}
+
+func TestBatcherGrpc(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	for _, raw := range []bool{true, false} {
+		calls := &atomic.Int32{}
+		batcher := New(3, 60000, nil, logger)
+		batcher.next = echoGrpc(t, batcher, calls, "0")
+
+		requests := map[string]protoreflect.Message{
+			"a": newModelInferRequest(batcher, "a", raw, 1, 2),
+			"b": newModelInferRequest(batcher, "b", raw, 3, 4, 5, 6),
+		}
+		responses := map[string]protoreflect.Message{}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for id, request := range requests {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response, status, _ := callModelInfer(t, batcher, request)
+				assert.Equal(t, "0", status)
+				mu.Lock()
+				defer mu.Unlock()
+				responses[id] = response
+			}()
+		}
+		wg.Wait()
+
+		// the requests are merged along the batch dimension into one call filling the batch
+		assert.Equal(t, int32(1), calls.Load())
+		for id, expected := range map[string][]float32{"a": {1, 2}, "b": {3, 4, 5, 6}} {
+			response := responses[id]
+			require.NotNil(t, response)
+			assert.Equal(t, id, get(response, "id").String())
+			output := get(response, "outputs").List().Get(0).Message()
+			assert.Equal(t, int64(len(expected)/2), get(output, "shape").List().Get(0).Int())
+			if raw {
+				assert.Equal(t, fp32Raw(expected...), get(response, "raw_output_contents").List().Get(0).Bytes())
+				continue
+			}
+			contents := get(get(output, "contents").Message(), "fp32_contents").List()
+			actual := []float32{}
+			for i := range contents.Len() {
+				actual = append(actual, float32(contents.Get(i).Float()))
+			}
+			assert.Equal(t, expected, actual)
+		}
+	}
+}
+
+func TestBatcherGrpcError(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	calls := &atomic.Int32{}
+	batcher := New(2, 60000, nil, logger)
+	// UNAVAILABLE
+	batcher.next = echoGrpc(t, batcher, calls, "14")
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, status, message := callModelInfer(t, batcher, newModelInferRequest(batcher, id, true, 1, 2))
+			assert.Equal(t, "14", status)
+			assert.Equal(t, "model%20not%20ready", message)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -28,6 +28,9 @@ import (
 
 	"github.com/gofrs/uuid/v5"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/kserve/kserve/pkg/protocol"
 )
 
 const (
@@ -178,11 +181,19 @@ func (handler *BatchHandler) batch() {
 		case <-time.After(SleepTime):
 		}
 		handler.batcherInfo.Now = GetNowTime()
-		if handler.batcherInfo.CurrentInputLen >= handler.MaxBatchSize ||
-			(handler.batcherInfo.Now.Sub(handler.batcherInfo.Start).Milliseconds() >= int64(handler.MaxLatency) &&
+		maxBatchSize, maxLatency := handler.MaxBatchSize, time.Duration(handler.MaxLatency)*time.Millisecond
+		if handler.adaptive != nil {
+			maxBatchSize, maxLatency = handler.adaptive.limits()
+		}
+		if handler.batcherInfo.CurrentInputLen >= maxBatchSize ||
+			(handler.batcherInfo.Now.Sub(handler.batcherInfo.Start) >= maxLatency &&
 				handler.batcherInfo.CurrentInputLen > 0) {
 			handler.log.Infof("batch predict with size %d %s", len(handler.batcherInfo.Instances), handler.batcherInfo.Path)
+			start := handler.batcherInfo.Start
 			handler.batchPredict()
+			if handler.adaptive != nil {
+				handler.adaptive.observe(GetNowTime().Sub(start))
+			}
 		}
 	}
 }
@@ -205,9 +216,30 @@ type BatchHandler struct {
 	MaxBatchSize int
 	MaxLatency   int
 	batcherInfo  BatcherInfo
+	// adaptive adjusts the limits of the batches to the latency SLO of the adaptive batcher, nil when it is not adaptive
+	adaptive *adaptiveLimits
+	// v2Batches and grpcBatches batch the v2 REST and gRPC inference requests by model and tensor signature
+	v2Batches     *batchQueue[*v2Request]
+	grpcBatches   *batchQueue[*grpcRequest]
+	inferRequest  protoreflect.MessageDescriptor
+	inferResponse protoreflect.MessageDescriptor
 }
 
 func New(maxBatchSize int, maxLatency int, handler http.Handler, logger *zap.SugaredLogger) *BatchHandler {
+	return newBatchHandler(maxBatchSize, maxLatency, 0, 0, handler, logger)
+}
+
+// NewAdaptive creates a batcher adjusting the max batch size and the max latency of the batches, up to the given values,
+// for the p95 latency of the batched requests to meet the latency SLO in milliseconds
+func NewAdaptive(maxBatchSize int, maxLatency int, latencySLO int, minBatchSize int, handler http.Handler,
+	logger *zap.SugaredLogger,
+) *BatchHandler {
+	return newBatchHandler(maxBatchSize, maxLatency, latencySLO, minBatchSize, handler, logger)
+}
+
+func newBatchHandler(maxBatchSize int, maxLatency int, latencySLO int, minBatchSize int, handler http.Handler,
+	logger *zap.SugaredLogger,
+) *BatchHandler {
 	batchHandler := BatchHandler{
 		next:         handler,
 		log:          logger,
@@ -215,11 +247,43 @@ func New(maxBatchSize int, maxLatency int, handler http.Handler, logger *zap.Sug
 		MaxBatchSize: maxBatchSize,
 		MaxLatency:   maxLatency,
 	}
+	if maxBatchSize <= 0 {
+		maxBatchSize = MaxBatchSize
+	}
+	if maxLatency <= 0 {
+		maxLatency = MaxLatency
+	}
+	var limits batchLimits = fixedLimits{maxBatchSize, time.Duration(maxLatency) * time.Millisecond}
+	if latencySLO > 0 {
+		batchHandler.adaptive = newAdaptiveLimits(maxBatchSize, time.Duration(maxLatency)*time.Millisecond,
+			time.Duration(latencySLO)*time.Millisecond, minBatchSize)
+		limits = batchHandler.adaptive
+		logger.Infof("Adapting the batches to the latency SLO %dms, minBatchSize:%d", latencySLO, batchHandler.adaptive.minBatchSize)
+	}
+	batchHandler.v2Batches = newBatchQueue(limits, batchHandler.flushV2)
+	if service, err := protocol.GrpcInferenceService(); err != nil {
+		logger.Errorf("failed to load the open inference protocol descriptors, the gRPC requests are not batched: %v", err)
+	} else {
+		method := service.Methods().ByName("ModelInfer")
+		batchHandler.inferRequest, batchHandler.inferResponse = method.Input(), method.Output()
+		batchHandler.grpcBatches = newBatchQueue(limits, batchHandler.flushGrpc)
+	}
 	go batchHandler.Consume()
 	return &batchHandler
 }
 
 func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case handler.adaptive != nil && r.URL.Path == MetricsPath:
+		handler.adaptive.metrics.ServeHTTP(w, r)
+		return
+	case handler.grpcBatches != nil && isGrpcModelInfer(r):
+		handler.serveGrpc(w, r)
+		return
+	case r.Method == http.MethodPost && v2InferPath.MatchString(r.URL.Path):
+		handler.serveV2(w, r)
+		return
+	}
 	// only batch predict requests
 	predictVerb := regexp.MustCompile(`:predict$`)
 	if !predictVerb.MatchString(r.URL.Path) {
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"sync"
+	"time"
+)
+
+// pendingBatch is the batch of the requests of a key waiting to be flushed
+type pendingBatch[T any] struct {
+	requests []T
+	size     int
+	start    time.Time
+	timer    *time.Timer
+}
+
+// batchQueue groups the requests of the same key, e.g. the same model and tensor signature, into batches flushed once
+// they reach the max batch size or once the oldest request of the batch waited the max latency
+type batchQueue[T any] struct {
+	limits batchLimits
+	flush  func(requests []T)
+
+	mu      sync.Mutex
+	batches map[string]*pendingBatch[T]
+}
+
+func newBatchQueue[T any](limits batchLimits, flush func(requests []T)) *batchQueue[T] {
+	return &batchQueue[T]{
+		limits:  limits,
+		flush:   flush,
+		batches: map[string]*pendingBatch[T]{},
+	}
+}
+
+// add adds the request of the given size, in rows of the batch dimension, to the batch of its key. The batch is
+// flushed by the calling goroutine when the request fills it.
+func (q *batchQueue[T]) add(key string, request T, size int) {
+	maxBatchSize, maxLatency := q.limits.limits()
+	q.mu.Lock()
+	batch, ok := q.batches[key]
+	if !ok {
+		batch = &pendingBatch[T]{start: time.Now()}
+		batch.timer = time.AfterFunc(maxLatency, func() { q.expire(key, batch) })
+		q.batches[key] = batch
+	}
+	batch.requests = append(batch.requests, request)
+	batch.size += size
+	if batch.size < maxBatchSize {
+		q.mu.Unlock()
+		return
+	}
+	delete(q.batches, key)
+	batch.timer.Stop()
+	q.mu.Unlock()
+	q.flushBatch(batch)
+}
+
+// expire flushes the batch once its max latency elapsed, unless it was already flushed full
+func (q *batchQueue[T]) expire(key string, batch *pendingBatch[T]) {
+	q.mu.Lock()
+	if q.batches[key] != batch {
+		q.mu.Unlock()
+		return
+	}
+	delete(q.batches, key)
+	q.mu.Unlock()
+	q.flushBatch(batch)
+}
+
+// flushBatch flushes the batch and reports its latency to the limits
+func (q *batchQueue[T]) flushBatch(batch *pendingBatch[T]) {
+	q.flush(batch.requests)
+	q.limits.observe(time.Since(batch.start))
+}
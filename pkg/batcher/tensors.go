@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// rawSizes are the sizes in bytes of the elements of the fixed size datatypes in the raw contents
+var rawSizes = map[string]int{
+	"BOOL":   1,
+	"INT8":   1,
+	"INT16":  2,
+	"INT32":  4,
+	"INT64":  8,
+	"UINT8":  1,
+	"UINT16": 2,
+	"UINT32": 4,
+	"UINT64": 8,
+	"FP16":   2,
+	"FP32":   4,
+	"FP64":   8,
+}
+
+// batchRows returns the size of the batch dimension shared by the shapes of the inputs of a request, false when the
+// inputs have no batch dimension or disagree on its size
+func batchRows(shapes [][]int64) (int, bool) {
+	rows := int64(-1)
+	for _, shape := range shapes {
+		if len(shape) == 0 || shape[0] <= 0 || (rows >= 0 && shape[0] != rows) {
+			return 0, false
+		}
+		rows = shape[0]
+	}
+	return int(rows), rows > 0
+}
+
+// rowElements returns the number of elements of a row of the batch dimension of the shape
+func rowElements(shape []int64) int {
+	elements := 1
+	for _, dim := range shape[1:] {
+		elements *= int(dim)
+	}
+	return elements
+}
+
+// flatten returns the elements of the data in row-major order, the data of a tensor can be nested
+func flatten(data []any, elements []any) []any {
+	for _, element := range data {
+		if nested, ok := element.([]any); ok {
+			elements = flatten(nested, elements)
+		} else {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// splitElements splits the elements of a batched tensor into the rows of the requests of the batch
+func splitElements[T any](elements []T, rows []int, rowElements int) ([][]T, error) {
+	total := 0
+	for _, count := range rows {
+		total += count
+	}
+	if len(elements) != total*rowElements {
+		return nil, fmt.Errorf("the tensor has %d elements instead of %d for a batch of %d", len(elements), total*rowElements, total)
+	}
+	split := make([][]T, 0, len(rows))
+	for _, count := range rows {
+		split = append(split, elements[:count*rowElements:count*rowElements])
+		elements = elements[count*rowElements:]
+	}
+	return split, nil
+}
+
+// splitRawContents splits the raw contents of a batched tensor of the datatype into the rows of the requests of the
+// batch, the elements of fixed size are little endian and the BYTES elements are prefixed by their 4 bytes length
+func splitRawContents(datatype string, raw []byte, rows []int, rowElements int) ([][]byte, error) {
+	if datatype != "BYTES" {
+		size, ok := rawSizes[datatype]
+		if !ok {
+			return nil, fmt.Errorf("unsupported datatype %s", datatype)
+		}
+		return splitElements(raw, rows, rowElements*size)
+	}
+	split := make([][]byte, 0, len(rows))
+	for _, count := range rows {
+		end := 0
+		for range count * rowElements {
+			if len(raw)-end < 4 {
+				return nil, fmt.Errorf("truncated raw BYTES contents")
+			}
+			size := binary.LittleEndian.Uint32(raw[end:])
+			if uint64(len(raw)-end-4) < uint64(size) {
+				return nil, fmt.Errorf("truncated raw BYTES contents")
+			}
+			end += 4 + int(size)
+		}
+		split = append(split, raw[:end:end])
+		raw = raw[end:]
+	}
+	if len(raw) > 0 {
+		return nil, fmt.Errorf("the raw BYTES contents have %d bytes beyond the batch", len(raw))
+	}
+	return split, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+
+	"github.com/kserve/kserve/pkg/agent/transcoding"
+)
+
+// v2InferPath matches the REST v2 inference path of a model or of a version of a model
+var v2InferPath = regexp.MustCompile(`^/v2/models/[^/]+(/versions/[^/]+)?/infer$`)
+
+// inferenceHeaderContentLength is the header of the binary data extension of the REST v2 protocol, whose requests are
+// not batched
+const inferenceHeaderContentLength = "Inference-Header-Content-Length"
+
+// v2Request is a REST v2 inference request waiting in a batch
+type v2Request struct {
+	path    string
+	header  http.Header
+	request *transcoding.InferRequest
+	rows    int
+	result  chan v2Result
+}
+
+// v2Result is the response of a REST v2 inference request split from the response of its batch
+type v2Result struct {
+	code int
+	body []byte
+}
+
+// v2BatchSignature is what the REST v2 requests batched together share, their inputs only differ by their batch
+// dimension and their data
+type v2BatchSignature struct {
+	Path       string                        `json:"path"`
+	Parameters map[string]any                `json:"parameters,omitempty"`
+	Inputs     []transcoding.InferTensor     `json:"inputs"`
+	Outputs    []transcoding.RequestedOutput `json:"outputs,omitempty"`
+}
+
+// serveV2 batches the REST v2 inference requests of the same model and tensor signature, merging their inputs along
+// the batch dimension. The requests which cannot be merged are passed to next unchanged.
+func (handler *BatchHandler) serveV2(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "can't read body", http.StatusBadRequest)
+		return
+	}
+	request := &transcoding.InferRequest{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if r.Header.Get(inferenceHeaderContentLength) != "" || decoder.Decode(request) != nil {
+		handler.passThrough(w, r, body)
+		return
+	}
+	signature := v2BatchSignature{Path: r.URL.Path, Parameters: request.Parameters, Outputs: request.Outputs}
+	shapes := make([][]int64, 0, len(request.Inputs))
+	for _, input := range request.Inputs {
+		shapes = append(shapes, input.Shape)
+	}
+	rows, ok := batchRows(shapes)
+	if !ok {
+		handler.passThrough(w, r, body)
+		return
+	}
+	for _, input := range request.Inputs {
+		if len(flatten(input.Data, nil)) != rows*rowElements(input.Shape) {
+			handler.passThrough(w, r, body)
+			return
+		}
+		signature.Inputs = append(signature.Inputs, transcoding.InferTensor{
+			Name:       input.Name,
+			Shape:      input.Shape[1:],
+			Datatype:   input.Datatype,
+			Parameters: input.Parameters,
+		})
+	}
+	key, err := json.Marshal(signature)
+	if err != nil {
+		handler.passThrough(w, r, body)
+		return
+	}
+
+	pending := &v2Request{
+		path:    r.URL.Path,
+		header:  r.Header,
+		request: request,
+		rows:    rows,
+		result:  make(chan v2Result, 1),
+	}
+	handler.v2Batches.add(string(key), pending, rows)
+	result := <-pending.result
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(result.code)
+	if _, err := w.Write(result.body); err != nil {
+		handler.log.Errorf("failed to write the response of %s: %v", r.URL.Path, err)
+	}
+}
+
+// passThrough serves a request which is not batched, whose body was read
+func (handler *BatchHandler) passThrough(w http.ResponseWriter, r *http.Request, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	handler.next.ServeHTTP(w, r)
+}
+
+// flushV2 sends the batched REST v2 requests as one request and splits its response
+func (handler *BatchHandler) flushV2(requests []*v2Request) {
+	first := requests[0]
+	rows := make([]int, 0, len(requests))
+	total := 0
+	for _, pending := range requests {
+		rows = append(rows, pending.rows)
+		total += pending.rows
+	}
+	merged := transcoding.InferRequest{
+		ID:         GenerateUUID(),
+		Parameters: first.request.Parameters,
+		Outputs:    first.request.Outputs,
+	}
+	for i, input := range first.request.Inputs {
+		data := make([]any, 0, total*rowElements(input.Shape))
+		for _, pending := range requests {
+			data = flatten(pending.request.Inputs[i].Data, data)
+		}
+		shape := slices.Clone(input.Shape)
+		shape[0] = int64(total)
+		merged.Inputs = append(merged.Inputs, transcoding.InferTensor{
+			Name:       input.Name,
+			Shape:      shape,
+			Datatype:   input.Datatype,
+			Parameters: input.Parameters,
+			Data:       data,
+		})
+	}
+	handler.log.Infof("batch infer with size %d %s", total, first.path)
+
+	body, err := json.Marshal(merged)
+	if err != nil {
+		respondV2Error(requests, http.StatusInternalServerError, err.Error())
+		return
+	}
+	r := httptest.NewRequest(http.MethodPost, first.path, bytes.NewReader(body))
+	r.Header = first.header.Clone()
+	r.Header.Del("Content-Length")
+	rr := httptest.NewRecorder()
+	handler.next.ServeHTTP(rr, r)
+	if rr.Code != http.StatusOK {
+		handler.log.Errorf("error response with code %d for the batch of %s", rr.Code, first.path)
+		for _, pending := range requests {
+			pending.result <- v2Result{code: rr.Code, body: rr.Body.Bytes()}
+		}
+		return
+	}
+
+	response := &transcoding.InferResponse{}
+	decoder := json.NewDecoder(rr.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(response); err != nil {
+		respondV2Error(requests, http.StatusInternalServerError, "can't Unmarshal the batch response: "+err.Error())
+		return
+	}
+	outputs := make([][][]any, 0, len(response.Outputs))
+	for _, output := range response.Outputs {
+		if len(output.Shape) == 0 || output.Shape[0] != int64(total) {
+			respondV2Error(requests, http.StatusInternalServerError, "the batch dimension of the output "+output.Name+
+				" is not the size of the batch")
+			return
+		}
+		split, err := splitElements(flatten(output.Data, nil), rows, rowElements(output.Shape))
+		if err != nil {
+			respondV2Error(requests, http.StatusInternalServerError, "output "+output.Name+": "+err.Error())
+			return
+		}
+		outputs = append(outputs, split)
+	}
+	for j, pending := range requests {
+		split := transcoding.InferResponse{
+			ModelName:    response.ModelName,
+			ModelVersion: response.ModelVersion,
+			ID:           pending.request.ID,
+			Parameters:   response.Parameters,
+			Outputs:      make([]transcoding.InferTensor, 0, len(response.Outputs)),
+		}
+		for i, output := range response.Outputs {
+			shape := slices.Clone(output.Shape)
+			shape[0] = int64(pending.rows)
+			split.Outputs = append(split.Outputs, transcoding.InferTensor{
+				Name:       output.Name,
+				Shape:      shape,
+				Datatype:   output.Datatype,
+				Parameters: output.Parameters,
+				Data:       outputs[i][j],
+			})
+		}
+		body, err := json.Marshal(split)
+		if err != nil {
+			pending.result <- v2ErrorResult(http.StatusInternalServerError, err.Error())
+			continue
+		}
+		pending.result <- v2Result{code: http.StatusOK, body: body}
+	}
+}
+
+func v2ErrorResult(code int, message string) v2Result {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	return v2Result{code: code, body: body}
+}
+
+func respondV2Error(requests []*v2Request, code int, message string) {
+	for _, pending := range requests {
+		pending.result <- v2ErrorResult(code, message)
+	}
+}
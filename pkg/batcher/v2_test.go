@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pkglogging "knative.dev/pkg/logging"
+
+	"github.com/kserve/kserve/pkg/agent/transcoding"
+)
+
+// echoV2 answers the REST v2 inference requests with an output-0 tensor of the data of their first input
+func echoV2(t *testing.T, calls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		request := &transcoding.InferRequest{}
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if !assert.NoError(t, decoder.Decode(request)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		input := request.Inputs[0]
+		_ = json.NewEncoder(w).Encode(transcoding.InferResponse{
+			ModelName: "model",
+			ID:        request.ID,
+			Outputs: []transcoding.InferTensor{
+				{Name: "output-0", Shape: input.Shape, Datatype: input.Datatype, Data: input.Data},
+			},
+		})
+	})
+}
+
+func serveV2(handler http.Handler, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestBatcherV2(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	calls := &atomic.Int32{}
+	batchHandler := New(4, 60000, echoV2(t, calls), logger)
+
+	requests := map[string]string{
+		"a": `{"id": "a", "inputs": [{"name": "input-0", "shape": [1, 2], "datatype": "FP32", "data": [[1, 2]]}]}`,
+		"b": `{"id": "b", "inputs": [{"name": "input-0", "shape": [3, 2], "datatype": "FP32", "data": [3, 4, 5, 6, 7, 8]}]}`,
+	}
+	responses := map[string]transcoding.InferResponse{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for id, body := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder := serveV2(batchHandler, "/v2/models/model/infer", body)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			response := transcoding.InferResponse{}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			mu.Lock()
+			defer mu.Unlock()
+			responses[id] = response
+		}()
+	}
+	wg.Wait()
+
+	// the requests are merged along the batch dimension into one request filling the batch
+	assert.Equal(t, int32(1), calls.Load())
+	require.Len(t, responses, 2)
+	assert.Equal(t, "a", responses["a"].ID)
+	assert.Equal(t, []int64{1, 2}, responses["a"].Outputs[0].Shape)
+	assert.Equal(t, []any{1.0, 2.0}, responses["a"].Outputs[0].Data)
+	assert.Equal(t, "b", responses["b"].ID)
+	assert.Equal(t, []int64{3, 2}, responses["b"].Outputs[0].Shape)
+	assert.Equal(t, []any{3.0, 4.0, 5.0, 6.0, 7.0, 8.0}, responses["b"].Outputs[0].Data)
+}
+
+func TestBatcherV2PassThrough(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	calls := &atomic.Int32{}
+	batchHandler := New(4, 60000, echoV2(t, calls), logger)
+
+	// the inputs disagreeing on the batch dimension are not batched
+	recorder := serveV2(batchHandler, "/v2/models/model/infer", `{"inputs": [
+		{"name": "input-0", "shape": [1, 2], "datatype": "FP32", "data": [1, 2]},
+		{"name": "input-1", "shape": [2], "datatype": "FP32", "data": [1, 2]}]}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestBatcherV2Error(t *testing.T) {
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	batchHandler := New(2, 60000, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid input"}`))
+	}), logger)
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder := serveV2(batchHandler, "/v2/models/model/versions/1/infer",
+				`{"inputs": [{"name": "input-0", "shape": [1], "datatype": "INT64", "data": [1]}]}`)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.JSONEq(t, `{"error": "invalid input"}`, recorder.Body.String())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSplitRawContents(t *testing.T) {
+	// the BYTES elements a, bc, an empty element and d
+	raw := []byte{1, 0, 0, 0, 'a', 2, 0, 0, 0, 'b', 'c', 0, 0, 0, 0, 1, 0, 0, 0, 'd'}
+	split, err := splitRawContents("BYTES", raw, []int{2, 1}, 1)
+	require.Error(t, err)
+	assert.Nil(t, split)
+
+	split, err = splitRawContents("BYTES", raw, []int{2, 2}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{raw[:11], raw[11:]}, split)
+
+	split, err = splitRawContents("FP32", make([]byte, 24), []int{1, 2}, 2)
+	require.NoError(t, err)
+	assert.Len(t, split[0], 8)
+	assert.Len(t, split[1], 16)
+
+	_, err = splitRawContents("FP32", make([]byte, 20), []int{1, 2}, 2)
+	require.Error(t, err)
+}
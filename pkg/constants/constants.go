@@ -40,11 +40,93 @@ const (
 var (
 	KServeNamespace              = GetEnvOrDefault("POD_NAMESPACE", "kserve")
 	AutoscalerConfigmapNamespace = GetEnvOrDefault("KNATIVE_CONFIG_AUTOSCALER_NAMESPACE", DefaultKnServingNamespace)
+	// KServeVersion is the version of KServe, set at build time with
+	// -ldflags "-X github.com/kserve/kserve/pkg/constants.KServeVersion=<version>"
+	KServeVersion = "dev"
 )
 
 // Kueue Constants
 const (
-	KueueAPIGroupName = "kueue.x-k8s.io"
+	KueueAPIGroupName      = "kueue.x-k8s.io"
+	KueueAPIVersion        = KueueAPIGroupName + "/v1beta1"
+	KueueWorkloadKind      = "Workload"
+	KueueQueueNameLabelKey = KueueAPIGroupName + "/queue-name"
+)
+
+// Prometheus Operator Constants
+const (
+	PrometheusOperatorAPIGroupName = "monitoring.coreos.com"
+	PrometheusOperatorAPIVersion   = PrometheusOperatorAPIGroupName + "/v1"
+	PodMonitorKind                 = "PodMonitor"
+)
+
+// cert-manager Constants
+const (
+	CertManagerAPIGroupName = "cert-manager.io"
+	CertManagerAPIVersion   = CertManagerAPIGroupName + "/v1"
+	CertificateKind         = "Certificate"
+	IssuerKind              = "Issuer"
+)
+
+// ExternalDNS Constants, the hosts of the InferenceServices are published with the DNSEndpoints of the CRD source
+const (
+	ExternalDNSAPIGroupName = "externaldns.k8s.io"
+	ExternalDNSAPIVersion   = ExternalDNSAPIGroupName + "/v1alpha1"
+	DNSEndpointKind         = "DNSEndpoint"
+)
+
+// Server TLS Constants, the serving certificate of the predictor is mounted in the predictor container at the
+// ServerTLSCertDir with the file names of the kubernetes.io/tls secrets
+const (
+	ServerTLSVolumeName      = "kserve-server-tls"
+	ServerTLSCertDir         = "/etc/kserve/tls"
+	ServerTLSCertFileEnvVar  = "KSERVE_TLS_CERT_FILE"
+	ServerTLSKeyFileEnvVar   = "KSERVE_TLS_KEY_FILE"
+	ServerTLSCAFileEnvVar    = "KSERVE_TLS_CA_FILE"
+	ServerTLSPortName        = "https"
+	SpiffeHelperContainer    = "spiffe-helper"
+	SpiffeHelperConfigMap    = "kserve-spiffe-helper"
+	SpiffeHelperVolumeName   = "kserve-spiffe-helper"
+	SpiffeHelperConfigDir    = "/etc/spiffe-helper"
+	SpiffeWorkloadAPIVolume  = "spiffe-workload-api"
+	SpiffeWorkloadAPIDir     = "/spiffe-workload-api"
+	DefaultSpiffeCSIDriver   = "csi.spiffe.io"
+	DefaultSpireAgentSocket  = "spire-agent.sock"
+	DefaultSpiffeHelperImage = "ghcr.io/spiffe/spiffe-helper:0.10.0"
+)
+
+// ActivatorType is how the components scaled to zero in Standard deployment mode are scaled from zero: the builtin
+// activator scales the deployment itself, the keda-http activator forwards the requests to the interceptor of the KEDA
+// HTTP add-on, which scales the deployment with an HTTPScaledObject
+type ActivatorType string
+
+const (
+	ActivatorBuiltin  ActivatorType = "builtin"
+	ActivatorKedaHTTP ActivatorType = "keda-http"
+)
+
+// Activator Constants, the activator is deployed in front of the component and buffers its requests while it is
+// scaled from zero. The service of the component selects the activator and the private service the component.
+const (
+	ActivatorContainerName                  = "activator"
+	ActivatorSuffix                         = "activator"
+	ActivatorPrivateServiceSuffix           = "private"
+	ActivatorPort                     int32 = 8012
+	DefaultActivatorImage                   = "kserve/activator:latest"
+	DefaultKedaHTTPInterceptorService       = "keda-add-ons-http-interceptor-proxy.keda:8080"
+	KedaHTTPAPIVersion                      = "http.keda.sh/v1alpha1"
+	HTTPScaledObjectKind                    = "HTTPScaledObject"
+)
+
+// Shared cache Constants, the shared cache is deployed once in the KServe namespace and caches the results of the
+// inference graph nodes and of the LLM routers, e.g. embeddings and tokenizations, across resources
+const (
+	SharedCacheName                     = "kserve-shared-cache"
+	SharedCacheContainerName            = "shared-cache"
+	SharedCachePort               int32 = 8080
+	DefaultSharedCacheImage             = "kserve/shared-cache:latest"
+	DefaultSharedCacheTenantQuota       = "64Mi"
+	DefaultSharedCacheMaxTenants        = 256
 )
 
 // InferenceService Constants
@@ -58,13 +140,25 @@ var (
 
 // InferenceGraph Constants
 const (
-	RouterHeadersPropagateEnvVar = "PROPAGATE_HEADERS"
-	InferenceGraphLabel          = "serving.kserve.io/inferencegraph"
-	RouterReadinessEndpoint      = "/readyz"
-	RouterPort                   = 8080
-	RouterTimeoutsServerRead     = 60
-	RouterTimeoutServerWrite     = 60
-	RouterTimeoutServerIdle      = 180
+	RouterHeadersPropagateEnvVar     = "PROPAGATE_HEADERS"
+	RouterHeadersDenyEnvVar          = "DENY_HEADERS"
+	RouterFaultInjectionEnvVar       = "FAULT_INJECTION"
+	RouterTraceEnvVar                = "ENABLE_TRACE"
+	RouterSharedCacheURLEnvVar       = "SHARED_CACHE_URL"
+	RouterTraceDebugHeader           = "X-Kserve-Graph-Debug"
+	RouterTraceHeader                = "X-Kserve-Graph-Trace"
+	InferenceGraphLabel              = "serving.kserve.io/inferencegraph"
+	RouterReadinessEndpoint          = "/readyz"
+	RouterPort                       = 8080
+	RouterTimeoutsServerRead         = 60
+	RouterTimeoutServerWrite         = 60
+	RouterTimeoutServerIdle          = 180
+	RouterGraphConfigArgName         = "--graph-config-file"
+	RouterGraphConfigVolumeName      = "graph-config"
+	RouterSharedCacheTokenVolumeName = "shared-cache-token"
+	RouterGraphConfigMountPath       = "/etc/kserve/graph"
+	RouterGraphConfigFileName        = "graph.json"
+	RouterCanaryGraphConfigFileName  = "graph-canary.json"
 )
 
 // TrainedModel Constants
@@ -72,11 +166,47 @@ var (
 	TrainedModelAllocated = KServeAPIGroupName + "/" + "trainedmodel-allocated"
 )
 
+// Config snapshot Constants
+var (
+	// ConfigSnapshotRevisionLabel is set on the config snapshot ConfigMaps to the revision they snapshot
+	ConfigSnapshotRevisionLabel = KServeAPIGroupName + "/" + "config-snapshot-revision"
+)
+
+// ServingRuntimeCatalog Constants
+var (
+	// ServingRuntimeCatalogLabel is set on the ClusterServingRuntimes synced from a ServingRuntimeCatalog to the
+	// name of the catalog
+	ServingRuntimeCatalogLabel = KServeAPIGroupName + "/" + "servingruntime-catalog"
+)
+
+// WarmPool Constants
+var (
+	// WarmPoolLabel is set on the warm pods of a WarmPool to the name of the pool, it is removed once claimed
+	WarmPoolLabel = KServeAPIGroupName + "/" + "warmpool"
+	// WarmPoolTemplateHashAnnotation is the pod template hash of the predictor ReplicaSet a warm pod was created from
+	WarmPoolTemplateHashAnnotation = KServeAPIGroupName + "/" + "warmpool-template-hash"
+)
+
+// Persona role Constants
+var (
+	// PersonaRoleLabel is set on the persona ClusterRoles managed by the controller to the name of the persona
+	PersonaRoleLabel = KServeAPIGroupName + "/" + "persona-role"
+)
+
 // InferenceService MultiModel Constants
 var (
 	ModelConfigFileName = "models.json"
 )
 
+// The models of a modelConfig are split across multiple ConfigMaps, the parts, to stay under the ConfigMap size limit
+const (
+	// ModelConfigMaxParts is the maximum number of ConfigMaps holding the models of a modelConfig
+	ModelConfigMaxParts = 16
+	// ModelConfigPartMaxSize is the maximum size in bytes of the models of a modelConfig part, leaving room for the
+	// ConfigMap metadata under the 1MiB ConfigMap size limit
+	ModelConfigPartMaxSize = 900 * 1024
+)
+
 // Remote Storage URI
 const (
 	RemoteStorageEnvVarName = "REMOTE_STORAGE_URI"
@@ -90,6 +220,82 @@ const (
 	AgentConfigDirArgName     = "--config-dir"
 	AgentModelDirArgName      = "--model-dir"
 	AgentComponentPortArgName = "--component-port"
+	AgentDenyHeadersArgName   = "--deny-headers"
+	// explainer sampling flags of the agent
+	AgentExplainerUrlArgName             = "--explainer-url"
+	AgentExplainerSamplingPercentArgName = "--explainer-sampling-percent"
+	// port of the gRPC service of the component the REST v2 requests are transcoded to
+	AgentGrpcTranscodingPortArgName = "--grpc-transcoding-port"
+	// native REST protocol of the component the requests of the other REST protocol are translated to
+	AgentDualProtocolArgName = "--dual-protocol"
+	// artifact upload flags of the agent
+	AgentArtifactOutputUriArgName = "--artifact-output-uri"
+	AgentArtifactDirArgName       = "--artifact-dir"
+	// fault injection rules of the agent, only set when the fault injection is enabled
+	AgentFaultInjectionArgName = "--fault-injection"
+	// request inspector flags of the agent
+	AgentInspectRequestsArgName        = "--inspect-requests"
+	AgentInspectSamplingPercentArgName = "--inspect-sampling-percent"
+	// back-pressure header flags of the agent
+	AgentEnableBackpressureArgName       = "--enable-backpressure"
+	AgentBackpressureSignalsArgName      = "--backpressure-signals"
+	AgentBackpressureOnSuccessArgName    = "--backpressure-on-success"
+	AgentBackpressureQueueMetricsArgName = "--backpressure-queue-metrics"
+	// watchdog detecting the hung runtimes, the JSON watchdog spec of the serving runtime
+	AgentWatchdogArgName = "--watchdog"
+	// slow start flags of the agent ramping up the concurrency admitted by a new replica
+	AgentSlowStartWindowArgName         = "--slow-start-window"
+	AgentSlowStartInitialPercentArgName = "--slow-start-initial-percent"
+	AgentSlowStartMaxConcurrencyArgName = "--slow-start-max-concurrency"
+	// MaxInspectRequests bounds the memory used by the ring buffer of the request inspector
+	MaxInspectRequests = 1000
+)
+
+// Artifact Upload Constants, the agent uploads the artifacts the runtime writes to the artifact directory on
+// shutdown, or on demand when the runtime calls the upload url
+const (
+	ArtifactUploadPort         = 9084
+	ArtifactUploadPath         = "/v1/artifacts/upload"
+	ArtifactVolumeName         = "kserve-artifacts"
+	DefaultArtifactDir         = "/mnt/artifacts"
+	ArtifactDirEnvVarKey       = "KSERVE_ARTIFACT_DIR"
+	ArtifactUploadUrlEnvVarKey = "KSERVE_ARTIFACT_UPLOAD_URL"
+)
+
+// Monitoring Constants, the detector monitoring the inputs of the predictor receives its payloads from the logger of
+// the agent and serves its metrics on the same port
+const (
+	DetectorContainerName = "kserve-detector"
+	DetectorPort          = 9085
+	DetectorPortStr       = "9085"
+	DetectorPortName      = "detector"
+	DefaultDetectorImage  = "seldonio/alibi-detect-server:1.18.0"
+)
+
+// Profiling Constants
+const (
+	EnableProfilingArgName   = "--enable-profiling"
+	ProfilingTokenEnvVarName = "PROFILING_TOKEN"
+	ProfilingTokenSecretName = "kserve-profiling"
+	ProfilingTokenSecretKey  = "token"
+	ProfilingPort            = 6060
+)
+
+// Metrics Relabeling Constants
+const (
+	EnableMetricsRelabelingArgName = "--enable-metrics-relabeling"
+	ComponentMetricsPortArgName    = "--component-metrics-port"
+	ComponentMetricsPathArgName    = "--component-metrics-path"
+	MetricsRelabelingPort          = 9089
+	MetricsRelabelingPortStr       = "9089"
+	MetricsRelabelingPortName      = "relabel-metrics"
+)
+
+// GPU Memory Telemetry Constants, the agent samples the GPU memory reported in the component metrics
+const (
+	EnableGPUMemoryTelemetryArgName = "--enable-gpu-memory-telemetry"
+	GPUMemoryAllocatedMetricArgName = "--gpu-memory-allocated-metric"
+	GPUMemoryReservedMetricArgName  = "--gpu-memory-reserved-metric"
 )
 
 // InferenceLogger Constants
@@ -97,9 +303,18 @@ const (
 	LoggerCaBundleVolume            = "agent-ca-bundle"
 	LoggerCaCertMountPath           = "/etc/tls/logger"
 	LoggerDefaultFormat             = "json"
+	LoggerNDJSONFormat              = "ndjson"
+	LoggerParquetFormat             = "parquet"
 	LoggerFormatKey                 = "format"
+	LoggerFlushIntervalKey          = "flushInterval"
+	LoggerFlushSizeKey              = "flushSize"
 	LoggerDefaultStorageKey         = "credentials"
 	LoggerDefaultServiceAccountName = "logger-sa"
+	LoggerKafkaSecretVolume         = "agent-logger-kafka-secret"
+	LoggerKafkaSecretMountPath      = "/etc/kafka/logger"
+	LoggerEncryptionKeyVolume       = "agent-logger-encryption-key"
+	LoggerEncryptionKeyMountPath    = "/etc/kserve/logger-encryption"
+	LoggerEncryptionPublicKeyFile   = "public-key.pem"
 )
 
 // InferenceService Annotations
@@ -121,6 +336,7 @@ var (
 	PrometheusPortAnnotationKey                 = "prometheus.io/port"
 	PrometheusPathAnnotationKey                 = "prometheus.io/path"
 	StorageReadonlyAnnotationKey                = "storage.kserve.io/readonly"
+	OtelSidecarInjectAnnotationKey              = "sidecar.opentelemetry.io/inject"
 	DefaultPrometheusPath                       = "/metrics"
 	QueueProxyAggregatePrometheusMetricsPort    = "9088"
 	DefaultPodPrometheusPort                    = "9091"
@@ -129,8 +345,83 @@ var (
 	LoggerCredentialPathKey                     = KServeAPIGroupName + "/logger-secret-path"
 	LoggerCredentialFileKey                     = KServeAPIGroupName + "/logger-secret-file"
 	DisableAutoUpdateAnnotationKey              = KServeAPIGroupName + "/disable-auto-update"
+	EnableProfilingAnnotationKey                = KServeAPIGroupName + "/enable-profiling"
+	EnableMetricsRelabelingAnnotationKey        = KServeAPIGroupName + "/enable-metrics-relabeling"
+	EnableGrpcReflectionAnnotationKey           = KServeAPIGroupName + "/enable-grpc-reflection"
+	GrpcTranscodingPortAnnotationKey            = KServeAPIGroupName + "/grpc-transcoding-port"
+	CaptureProfileAnnotationKey                 = KServeAPIGroupName + "/capture-profile"
+	CaptureProfileSecondsAnnotationKey          = KServeAPIGroupName + "/capture-profile-seconds"
+	CaptureProfileStorageUriAnnotationKey       = KServeAPIGroupName + "/capture-profile-storage-uri"
+	CheckpointRestoreAnnotationKey              = KServeAPIGroupName + "/checkpoint-restore"
+	RestoredFromCheckpointAnnotationKey         = KServeAPIGroupName + "/restored-from-checkpoint"
+	InjectedResourceOverheadAnnotationKey       = KServeAPIGroupName + "/injected-resource-overhead"
+	DeductSidecarOverheadAnnotationKey          = KServeAPIGroupName + "/deduct-sidecar-overhead"
+	CloudEventsSinkAnnotationKey                = KServeAPIGroupName + "/cloudevents-sink"
+	KueueAdmissionHeldAnnotationKey             = KServeAPIGroupName + "/held-for-kueue-admission"
+	KueuePodSetsHashAnnotationKey               = KServeAPIGroupName + "/kueue-pod-sets-hash"
+	StorageProfileAnnotationKey                 = KServeAPIGroupName + "/storage-profile"
+	ArtifactOutputUriAnnotationKey              = KServeAPIGroupName + "/artifact-output-uri"
+	FaultInjectionAnnotationKey                 = KServeAPIGroupName + "/fault-injection"
+	InspectRequestsAnnotationKey                = KServeAPIGroupName + "/inspect-requests"
+	InspectSamplingPercentAnnotationKey         = KServeAPIGroupName + "/inspect-sampling-percent"
+	EnableBackpressureHeadersAnnotationKey      = KServeAPIGroupName + "/enable-backpressure-headers"
+	ActivatorAnnotationKey                      = KServeAPIGroupName + "/activator"
+	EnableGPUMemoryTelemetryAnnotationKey       = KServeAPIGroupName + "/enable-gpu-memory-telemetry"
+	GPUMemoryAllocatedMetricAnnotationKey       = KServeAPIGroupName + "/gpu-memory-allocated-metric"
+	GPUMemoryReservedMetricAnnotationKey        = KServeAPIGroupName + "/gpu-memory-reserved-metric"
+	// DefaultStorageSecretNameAnnotationKey is the default storageSecretNameAnnotation of the credentials config
+	DefaultStorageSecretNameAnnotationKey = KServeAPIGroupName + "/storageSecretName"
 )
 
+// KnownInferenceServiceAnnotationKeys are the serving.kserve.io annotations recognized on the InferenceServices, the
+// others are reported by the webhook as likely typos. New InferenceService annotations must be registered here.
+var KnownInferenceServiceAnnotationKeys = []string{
+	InferenceServiceGKEAcceleratorAnnotationKey,
+	DeploymentMode,
+	EnableRoutingTagAnnotationKey,
+	DisableLocalModelKey,
+	AutoscalerClass,
+	AutoscalerMetrics,
+	TargetUtilizationPercentage,
+	StopAnnotationKey,
+	EnableMetricAggregation,
+	SetPrometheusAnnotation,
+	NodeGroupAnnotationKey,
+	LoggerSecretNameKey,
+	LoggerCredentialPathKey,
+	LoggerCredentialFileKey,
+	DisableAutoUpdateAnnotationKey,
+	EnableProfilingAnnotationKey,
+	EnableMetricsRelabelingAnnotationKey,
+	EnableGrpcReflectionAnnotationKey,
+	GrpcTranscodingPortAnnotationKey,
+	CaptureProfileAnnotationKey,
+	CaptureProfileSecondsAnnotationKey,
+	CaptureProfileStorageUriAnnotationKey,
+	CheckpointRestoreAnnotationKey,
+	RestoredFromCheckpointAnnotationKey,
+	InjectedResourceOverheadAnnotationKey,
+	DeductSidecarOverheadAnnotationKey,
+	CloudEventsSinkAnnotationKey,
+	KueueAdmissionHeldAnnotationKey,
+	KueuePodSetsHashAnnotationKey,
+	StorageProfileAnnotationKey,
+	ArtifactOutputUriAnnotationKey,
+	FaultInjectionAnnotationKey,
+	InspectRequestsAnnotationKey,
+	InspectSamplingPercentAnnotationKey,
+	EnableBackpressureHeadersAnnotationKey,
+	EnableGPUMemoryTelemetryAnnotationKey,
+	GPUMemoryAllocatedMetricAnnotationKey,
+	GPUMemoryReservedMetricAnnotationKey,
+	ActivatorAnnotationKey,
+	CustomGPUResourceTypesAnnotationKey,
+	ModelMinGPUMemoryAnnotationKey,
+	ModelDtypeAnnotationKey,
+	DefaultStorageSecretNameAnnotationKey,
+	IstioSidecarUIDAnnotationKey,
+}
+
 // InferenceService Internal Annotations
 var (
 	InferenceServiceInternalAnnotationsPrefix        = "internal." + KServeAPIGroupName
@@ -138,14 +429,35 @@ var (
 	StorageSpecAnnotationKey                         = InferenceServiceInternalAnnotationsPrefix + "/storage-spec"
 	StorageSpecParamAnnotationKey                    = InferenceServiceInternalAnnotationsPrefix + "/storage-spec-param"
 	StorageSpecKeyAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/storage-spec-key"
+	StorageIncludeInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/storage-include"
+	StorageExcludeInternalAnnotationKey              = InferenceServiceInternalAnnotationsPrefix + "/storage-exclude"
 	LoggerInternalAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/logger"
 	LoggerSinkUrlInternalAnnotationKey               = InferenceServiceInternalAnnotationsPrefix + "/logger-sink-url"
 	LoggerModeInternalAnnotationKey                  = InferenceServiceInternalAnnotationsPrefix + "/logger-mode"
 	LoggerMetadataHeadersInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/logger-metadata-headers"
 	LoggerMetadataAnnotationsInternalAnnotationKey   = InferenceServiceInternalAnnotationsPrefix + "/logger-metadata-annotations"
+	LoggerKafkaSecretInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/logger-kafka-secret"
+	LoggerSamplePercentInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/logger-sample-percent"
+	LoggerErrorSamplePercentInternalAnnotationKey    = InferenceServiceInternalAnnotationsPrefix + "/logger-error-sample-percent"
+	LoggerHeaderFiltersInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/logger-header-filters"
+	LoggerStatusCodesInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/logger-status-codes"
+	LoggerRedactFieldsInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/logger-redact-fields"
+	LoggerEncryptionKMSKeyInternalAnnotationKey      = InferenceServiceInternalAnnotationsPrefix + "/logger-encryption-kms-key"
+	LoggerEncryptionSecretInternalAnnotationKey      = InferenceServiceInternalAnnotationsPrefix + "/logger-encryption-secret"
+	LoggerEncryptionSecretKeyInternalAnnotationKey   = InferenceServiceInternalAnnotationsPrefix + "/logger-encryption-secret-key"
+	MonitoringInternalAnnotationKey                  = InferenceServiceInternalAnnotationsPrefix + "/monitoring"
 	BatcherInternalAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/batcher"
 	BatcherMaxBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-batchsize"
 	BatcherMaxLatencyInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-latency"
+	BatcherLatencySLOInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-latency-slo"
+	BatcherMinBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-min-batchsize"
+	ExplainerSamplingPercentInternalAnnotationKey    = InferenceServiceInternalAnnotationsPrefix + "/explainer-sampling-percent"
+	ExplainerSamplingUrlInternalAnnotationKey        = InferenceServiceInternalAnnotationsPrefix + "/explainer-sampling-url"
+	DualProtocolInternalAnnotationKey                = InferenceServiceInternalAnnotationsPrefix + "/dual-protocol"
+	WatchdogInternalAnnotationKey                    = InferenceServiceInternalAnnotationsPrefix + "/watchdog"
+	SlowStartWindowInternalAnnotationKey             = InferenceServiceInternalAnnotationsPrefix + "/slow-start-window-seconds"
+	SlowStartInitialPercentInternalAnnotationKey     = InferenceServiceInternalAnnotationsPrefix + "/slow-start-initial-percent"
+	SlowStartMaxConcurrencyInternalAnnotationKey     = InferenceServiceInternalAnnotationsPrefix + "/slow-start-max-concurrency"
 	AgentShouldInjectAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/agent"
 	AgentModelConfigVolumeNameAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/configVolumeName"
 	AgentModelConfigMountPathAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/configMountPath"
@@ -155,6 +467,44 @@ var (
 	LocalModelLabel                                  = InferenceServiceInternalAnnotationsPrefix + "/localmodel"
 	LocalModelSourceUriAnnotationKey                 = InferenceServiceInternalAnnotationsPrefix + "/localmodel-sourceuri"
 	LocalModelPVCNameAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/localmodel-pvc-name"
+	LocalModelSourceAnnotationKey                    = InferenceServiceInternalAnnotationsPrefix + "/localmodel-source"
+	LocalModelFallbackReasonAnnotationKey            = InferenceServiceInternalAnnotationsPrefix + "/localmodel-fallback-reason"
+	// PodMutatorConfigHashInternalAnnotationKey records the hash of the configuration the containers of the pod were
+	// injected with, so that the stale containers of a pod recreated from the spec of a former pod are injected again
+	PodMutatorConfigHashInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/pod-mutator-config-hash"
+	// PodMutatorConfigHashSinceAnnotationKey records on the mutating webhook configuration the time the mutated pods are
+	// hashed since, the pods created before without the hash were mutated by a former version of the pod mutator
+	PodMutatorConfigHashSinceAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/pod-mutator-config-hash-since"
+	// ServerTLSCertificateHashInternalAnnotationKey records the hash of the serving certificate of the predictor pods,
+	// so that the pods are rolled once the certificate is renewed with the Restart rotation
+	ServerTLSCertificateHashInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/server-tls-certificate-hash"
+	// ModelServerInternalAnnotationKey records the model server detected in the predictor container, which the
+	// queries of the runtime metrics of the KEDA autoscaler are templated for
+	ModelServerInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/model-server"
+	// WarmStandbyMinScaleInternalAnnotationKey records the minimum scale of the autoscaler of a previous revision
+	// raised for its warm standby, restored at the end of the soak period
+	WarmStandbyMinScaleInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/warm-standby-original-min-scale"
+)
+
+// LocalModelSourceAnnotationKey values, recording whether a pod of a cached model mounts the node-local copy of the
+// model or downloads the model
+const (
+	LocalModelSourceLocal    = "local"
+	LocalModelSourceDownload = "download"
+)
+
+// Namespace Annotations
+var (
+	// IngressGatewayNamespaceAnnotationKey overrides the Istio ingress gateway used for the InferenceServices in the namespace
+	IngressGatewayNamespaceAnnotationKey = KServeAPIGroupName + "/ingress-gateway"
+	// KServeIngressGatewayNamespaceAnnotationKey overrides the Gateway API gateway, in the format <namespace>/<name>,
+	// used for the InferenceServices in the namespace
+	KServeIngressGatewayNamespaceAnnotationKey = KServeAPIGroupName + "/kserve-ingress-gateway"
+	// IngressClassNameNamespaceAnnotationKey overrides the ingress class used for the InferenceServices in the namespace
+	IngressClassNameNamespaceAnnotationKey = KServeAPIGroupName + "/ingress-class-name"
+	// DefaultServiceAccountNamespaceAnnotationKey sets the default ServiceAccount of the InferenceService components in
+	// the namespace
+	DefaultServiceAccountNamespaceAnnotationKey = KServeAPIGroupName + "/default-service-account"
 )
 
 // kserve networking constants
@@ -166,6 +516,9 @@ const (
 	IsvcNamespaceHeader    = "KServe-Isvc-Namespace"
 	HostHeader             = "Host"
 	GatewayName            = "kserve-ingress-gateway"
+	// DefaultVariantHeaderName and DefaultVariantCookieName carry the model variant a session of an A/B test is pinned to
+	DefaultVariantHeaderName = "x-kserve-variant"
+	DefaultVariantCookieName = "kserve-variant"
 )
 
 // StorageSpec Constants
@@ -276,6 +629,16 @@ var (
 var (
 	PodMutatorWebhookName              = KServeName + "-pod-mutator-webhook"
 	ServingRuntimeValidatorWebhookName = KServeName + "-servingRuntime-validator-webhook"
+	ServingQuotaValidatorWebhookName   = KServeName + "-servingQuota-validator-webhook"
+	GPUCapabilityValidatorWebhookName  = KServeName + "-gpuCapability-validator-webhook"
+)
+
+// Pod mutating webhook configuration of the install manifests
+const (
+	InferenceServiceMutatingWebhookConfigurationName = "inferenceservice.serving.kserve.io"
+	PodMutatorWebhookConfigName                      = "inferenceservice.kserve-webhook-server.pod-mutator"
+	// ControlPlaneLabel marks the namespaces the pods of which are never mutated, such as the namespace of KServe
+	ControlPlaneLabel = "control-plane"
 )
 
 // GPU Constants
@@ -289,6 +652,61 @@ const (
 
 var CustomGPUResourceTypesAnnotationKey = KServeAPIGroupName + "/gpu-resource-types"
 
+// GPU labels set on the nodes by the NVIDIA GPU feature discovery
+const (
+	NvidiaGPUProductLabel      = "nvidia.com/gpu.product"
+	NvidiaGPUMemoryLabel       = "nvidia.com/gpu.memory" // in MiB
+	NvidiaGPUComputeMajorLabel = "nvidia.com/gpu.compute.major"
+	NvidiaGPUComputeMinorLabel = "nvidia.com/gpu.compute.minor"
+	NvidiaMigCapableLabel      = "nvidia.com/mig.capable"
+)
+
+// MIG labels of the nodes read by the MIG manager of the NVIDIA GPU operator, which partitions the GPUs of a node with
+// the configuration of the nvidia.com/mig.config label and reports the outcome in the nvidia.com/mig.config.state label
+const (
+	NvidiaMigConfigLabel      = "nvidia.com/mig.config"
+	NvidiaMigConfigStateLabel = "nvidia.com/mig.config.state"
+	NvidiaMigConfigPrefix     = "all-"
+	NvidiaMigDisabledConfig   = "all-disabled"
+	NvidiaMigStateSuccess     = "success"
+	NvidiaMigStateFailed      = "failed"
+)
+
+// Annotations of the nodes whose GPUs are partitioned for InferenceServices
+var (
+	// MIGPartitionOwnersAnnotationKey lists the InferenceServices, as namespace/name, using the MIG partitions of the node
+	MIGPartitionOwnersAnnotationKey = KServeAPIGroupName + "/mig-partition-owners"
+	// MIGPartitionPreviousConfigAnnotationKey is the MIG configuration the node is restored to once released
+	MIGPartitionPreviousConfigAnnotationKey = KServeAPIGroupName + "/mig-partition-previous-config"
+)
+
+// Model requirements checked against the GPUs of the targeted nodes
+var (
+	// ModelMinGPUMemoryAnnotationKey is the GPU memory required on each GPU, like 40Gi
+	ModelMinGPUMemoryAnnotationKey = KServeAPIGroupName + "/model-min-gpu-memory"
+	// ModelDtypeAnnotationKey is the data type of the model weights, like bfloat16
+	ModelDtypeAnnotationKey = KServeAPIGroupName + "/model-dtype"
+)
+
+// Provenance of the InferenceService pods, recorded by the pod mutator for the compliance audits
+const (
+	// KServeVersionAnnotationKey is the version of KServe which injected the pod
+	KServeVersionAnnotationKey = KServeAPIGroupName + "/kserve-version"
+	// ImageDigestsAnnotationKey maps the containers of the pod to their images pinned by digest, in JSON
+	ImageDigestsAnnotationKey = KServeAPIGroupName + "/image-digests"
+	// ImageSBOMsAnnotationKey maps the containers of the pod to the SBOMs published for their images, in JSON
+	ImageSBOMsAnnotationKey = KServeAPIGroupName + "/image-sboms"
+	// MissingAttestationsAnnotationKey maps the containers of the pod to the required attestations their images lack,
+	// in JSON
+	MissingAttestationsAnnotationKey = KServeAPIGroupName + "/missing-attestations"
+	// ProvenanceErrorsAnnotationKey maps the containers of the pod to the errors resolving their images when the
+	// provenance is not enforced, in JSON
+	ProvenanceErrorsAnnotationKey = KServeAPIGroupName + "/provenance-errors"
+	// AttestationsVerifiedLabelKey labels the pods whose images all have the required attestations with "true", and
+	// the other pods with "false"
+	AttestationsVerifiedLabelKey = KServeAPIGroupName + "/attestations-verified"
+)
+
 var DefaultGPUResourceTypeList = []string{
 	NvidiaGPUResourceType,
 	AmdGPUResourceType,
@@ -321,6 +739,14 @@ const (
 	KnativeLocalGateway         = "knative-serving/knative-local-gateway"
 	KnativeIngressGateway       = "knative-serving/knative-ingress-gateway"
 	VisibilityLabel             = "networking.knative.dev/visibility"
+	// ShadowTrafficTag is the traffic tag of the candidate revision receiving the mirrored traffic
+	ShadowTrafficTag = "shadow"
+	// InferenceProbeTrafficTag is the traffic tag of the latest revision held without traffic until it passed the
+	// inference probe
+	InferenceProbeTrafficTag = "probe"
+	// The headers the Knative activator routes the requests of the revisions with
+	KnativeRevisionHeader          = "Knative-Serving-Revision"
+	KnativeRevisionNamespaceHeader = "Knative-Serving-Namespace"
 )
 
 var (
@@ -360,6 +786,7 @@ const (
 	InferenceServiceDefaultAgentPort    = 9081
 	CommonDefaultHttpPort               = 80
 	AggregateMetricsPortName            = "aggr-metric"
+	QueueProxyUserMetricsPortName       = "http-usermetric"
 )
 
 // Labels to put on kservice
@@ -419,12 +846,49 @@ const DefaultCaBundleFileName = "cabundle.crt"
 // DefaultGlobalCaBundleConfigMapName Default CA bundle configmap name that will be created in the user namespace.
 const DefaultGlobalCaBundleConfigMapName = "global-ca-bundle"
 
+// GrafanaDashboardsConfigMapName is the configmap with the KServe Grafana dashboards created in the namespaces of the
+// InferenceServices, loaded by the dashboards sidecar of Grafana
+const GrafanaDashboardsConfigMapName = "kserve-grafana-dashboards"
+
+// DefaultGrafanaDashboardLabel is the label of the configmaps loaded by the dashboards sidecar of the Grafana helm chart
+const (
+	DefaultGrafanaDashboardLabel      = "grafana_dashboard"
+	DefaultGrafanaDashboardLabelValue = "1"
+)
+
+// gRPC reflection constants
+const (
+	GrpcDescriptorsVolumeName      = "kserve-grpc-descriptors"
+	GrpcDescriptorsVolumeMountPath = "/mnt/grpc"
+	GrpcDescriptorSetFileName      = "grpc_predict_v2.pb"
+	GrpcReflectionEnvVarKey        = "ENABLE_GRPC_REFLECTION"
+	GrpcDescriptorSetPathEnvVarKey = "GRPC_DESCRIPTOR_SET_PATH"
+)
+
 // Custom CA bundle configmap Environment Variables
 const (
 	CaBundleConfigMapNameEnvVarKey   = "CA_BUNDLE_CONFIGMAP_NAME"
 	CaBundleVolumeMountPathEnvVarKey = "CA_BUNDLE_VOLUME_MOUNT_POINT"
 )
 
+// SharedModelLibraryAnnotationKey marks a ReadWriteMany or ReadOnlyMany PersistentVolumeClaim as a library of models
+// shared by many InferenceServices, each mounting read-only the distinct sub path of its storage URI
+var SharedModelLibraryAnnotationKey = KServeAPIGroupName + "/shared-model-library"
+
+// Storage initializer checksum cache, persisting the model files by checksum on the node so that the re-deployments
+// of a slightly changed model only download the changed files
+const (
+	ChecksumCacheVolumeName      = "kserve-checksum-cache"
+	ChecksumCacheVolumeMountPath = "/mnt/checksum-cache"
+	ChecksumCacheDirEnvVarKey    = "STORAGE_CHECKSUM_CACHE_DIR"
+)
+
+// Glob patterns of the model files downloaded by the storage initializer, as JSON lists
+const (
+	StorageIncludePatternsEnvVarKey = "STORAGE_INCLUDE_PATTERNS"
+	StorageExcludePatternsEnvVarKey = "STORAGE_EXCLUDE_PATTERNS"
+)
+
 // Multi-model InferenceService
 const (
 	ModelConfigVolumeName = "model-config"
@@ -577,11 +1041,55 @@ var (
 	MultiNodeHead         = "head"
 )
 
+// Capacity Labels
+var (
+	CapacityTypeLabelKey     = KServeAPIGroupName + "/capacity-type"
+	CapacityTypeSpot         = "spot"
+	CapacityTypeOnDemand     = "on-demand"
+	OnDemandDeploymentSuffix = "on-demand"
+)
+
+// DefaultOnDemandReplicas is the default floor of predictor replicas running on on-demand nodes
+const DefaultOnDemandReplicas int32 = 1
+
+// Blue-green Labels and Annotations
+var (
+	BlueGreenTemplateHashLabelKey    = KServeAPIGroupName + "/blue-green-template-hash"
+	BlueGreenSwitchTimeAnnotationKey = KServeAPIGroupName + "/blue-green-switch-time"
+	BlueGreenPreviewSuffix           = "preview"
+)
+
 // GetRawServiceLabel generate native service label
 func GetRawServiceLabel(service string) string {
 	return "isvc." + service
 }
 
+// OnDemandDeploymentName generate the name of the deployment running the replicas on on-demand nodes
+func OnDemandDeploymentName(name string) string {
+	return name + "-" + OnDemandDeploymentSuffix
+}
+
+// BlueGreenPreviewDeploymentName generate the name of the deployment running the new pods of a blue-green rollout
+func BlueGreenPreviewDeploymentName(name string) string {
+	return name + "-" + BlueGreenPreviewSuffix
+}
+
+// ActivatorName generate the name of the deployment, the service account, the role and the lease of the activator of a
+// component
+func ActivatorName(name string) string {
+	return name + "-" + ActivatorSuffix
+}
+
+// ActivatorPrivateServiceName generate the name of the service selecting the pods of a component behind its activator
+func ActivatorPrivateServiceName(name string) string {
+	return name + "-" + ActivatorPrivateServiceSuffix
+}
+
+// SharedCacheURL is the address of the shared cache service in the KServe namespace
+func SharedCacheURL() string {
+	return fmt.Sprintf("http://%s:%d", network.GetServiceHostname(SharedCacheName, KServeNamespace), SharedCachePort)
+}
+
 // GetRawWorkerServiceLabel generate native service label for worker
 func GetRawWorkerServiceLabel(service string) string {
 	return "isvc." + service + "-" + WorkerNodeSuffix
@@ -648,10 +1156,42 @@ func CanaryServiceName(name string, component InferenceServiceComponent) string
 	return name + "-" + component.String() + "-" + InferenceServiceCanary
 }
 
+// GrpcDescriptorsConfigMapName is the name of the ConfigMap with the open inference protocol descriptor set of an
+// InferenceService enabling gRPC reflection
+func GrpcDescriptorsConfigMapName(isvcName string) string {
+	return isvcName + "-grpc-descriptors"
+}
+
+// ConfigSnapshotName is the name of the ConfigMap snapshotting the resolved configuration of a revision
+func ConfigSnapshotName(revision string) string {
+	return revision + "-config"
+}
+
+// InferenceGraphConfigMapName is the name of the ConfigMap holding the routing spec of the InferenceGraph router
+func InferenceGraphConfigMapName(inferenceGraphName string) string {
+	return inferenceGraphName + "-graph-config"
+}
+
 func ModelConfigName(inferenceserviceName string, shardId int) string {
 	return fmt.Sprintf("modelconfig-%s-%d", inferenceserviceName, shardId)
 }
 
+// ModelConfigPartName returns the name of a part of the modelConfig, the first part being the modelConfig itself
+func ModelConfigPartName(modelConfigName string, part int) string {
+	if part == 0 {
+		return modelConfigName
+	}
+	return fmt.Sprintf("%s-part-%d", modelConfigName, part)
+}
+
+// ModelConfigPartFileName returns the name of the file holding the models of a part of the modelConfig in the agent
+func ModelConfigPartFileName(part int) string {
+	if part == 0 {
+		return ModelConfigFileName
+	}
+	return fmt.Sprintf("models-%d.json", part)
+}
+
 func InferenceServicePrefix(name string) string {
 	return "/v1/models/" + name
 }
@@ -666,6 +1206,17 @@ func PredictPath(name string, protocol InferenceServiceProtocol) string {
 	return path
 }
 
+// ModelReadyPath returns the path of the model readiness endpoint of the given protocol
+func ModelReadyPath(name string, protocol InferenceServiceProtocol) string {
+	path := ""
+	if protocol == ProtocolV1 {
+		path = fmt.Sprintf("/v1/models/%s", name)
+	} else if protocol == ProtocolV2 {
+		path = fmt.Sprintf("/v2/models/%s/ready", name)
+	}
+	return path
+}
+
 func ExplainPath(name string) string {
 	return fmt.Sprintf("/v1/models/%s:explain", name)
 }
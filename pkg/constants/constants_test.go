@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// notInferenceServiceAnnotationKeys are the serving.kserve.io constants which are not InferenceService annotations:
+// the annotations set on the other objects than the InferenceServices and the labels not named after labels
+var notInferenceServiceAnnotationKeys = []string{
+	// labels
+	"TrainedModelAllocated",
+	"KServeWorkloadKind",
+	// namespaces
+	"IngressGatewayNamespaceAnnotationKey",
+	"KServeIngressGatewayNamespaceAnnotationKey",
+	"IngressClassNameNamespaceAnnotationKey",
+	"DefaultServiceAccountNamespaceAnnotationKey",
+	// pods
+	"WarmPoolTemplateHashAnnotation",
+	"KServeVersionAnnotationKey",
+	"ImageDigestsAnnotationKey",
+	"ImageSBOMsAnnotationKey",
+	"MissingAttestationsAnnotationKey",
+	"ProvenanceErrorsAnnotationKey",
+	// nodes
+	"MIGPartitionOwnersAnnotationKey",
+	"MIGPartitionPreviousConfigAnnotationKey",
+	// deployments
+	"BlueGreenSwitchTimeAnnotationKey",
+	// persistent volume claims
+	"SharedModelLibraryAnnotationKey",
+}
+
+// TestKnownInferenceServiceAnnotationKeys checks that every serving.kserve.io annotation declared in constants.go is
+// registered in KnownInferenceServiceAnnotationKeys, unless it is listed in notInferenceServiceAnnotationKeys
+func TestKnownInferenceServiceAnnotationKeys(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "constants.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse constants.go: %v", err)
+	}
+	values := map[string]ast.Expr{}
+	var names []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if i < len(spec.Values) {
+				values[name.Name] = spec.Values[i]
+				names = append(names, name.Name)
+			}
+		}
+		return false
+	})
+
+	for _, name := range names {
+		value, ok := evalString(values, values[name])
+		// the label constants are named after the labels
+		if !ok || !strings.HasPrefix(value, KServeAPIGroupName+"/") || strings.Contains(name, "Label") {
+			continue
+		}
+		if slices.Contains(notInferenceServiceAnnotationKeys, name) {
+			continue
+		}
+		if !slices.Contains(KnownInferenceServiceAnnotationKeys, value) {
+			t.Errorf("the annotation %s (%s) is not registered in KnownInferenceServiceAnnotationKeys", name, value)
+		}
+	}
+}
+
+// evalString evaluates the string constant expressions made of literals, identifiers and concatenations
+func evalString(values map[string]ast.Expr, expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(expr.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := values[expr.Name]
+		if !ok {
+			return "", false
+		}
+		return evalString(values, value)
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return "", false
+		}
+		x, ok := evalString(values, expr.X)
+		if !ok {
+			return "", false
+		}
+		y, ok := evalString(values, expr.Y)
+		return x + y, ok
+	default:
+		return "", false
+	}
+}
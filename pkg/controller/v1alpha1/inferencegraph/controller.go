@@ -16,6 +16,7 @@ limitations under the License.
 
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferencegraphs;inferencegraphs/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferencegraphs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/status,verbs=get;update;patch
@@ -25,6 +26,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -44,13 +46,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	knutils "github.com/kserve/kserve/pkg/controller/v1alpha1/utils"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -62,6 +68,11 @@ type InferenceGraphReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	Recorder     record.EventRecorder
+	// ConfigReloads receives an event once the inferenceservice-config ConfigMap is reloaded, so that all the
+	// InferenceGraphs are reconciled with the new configuration
+	ConfigReloads <-chan event.GenericEvent
+	// TLSPolicy is passed on to the router
+	TLSPolicy *tlspolicy.Policy
 }
 
 // InferenceGraphState describes the Readiness of the InferenceGraph
@@ -84,8 +95,12 @@ type RouterConfig struct {
 		 "propagate": [
 			"Custom-Header1",
 			"Custom-Header2"
+		  ],
+		 "deny": [
+			"^Cookie$"
 		  ]
 		}
+		The headers matching a "deny" pattern are not propagated, even when they match a "propagate" pattern.
 		Note: Making Headers, a map of strings, gives the flexibility to extend it in the future to support adding more
 		operations on headers. For example: Similar to "propagate" operation, one can add "transform" operation if they
 		want to transform headers keys or values before passing down to nodes.
@@ -93,6 +108,38 @@ type RouterConfig struct {
 	Headers          map[string][]string `json:"headers"`
 	ImagePullPolicy  string              `json:"imagePullPolicy"`
 	ImagePullSecrets []string            `json:"imagePullSecrets"`
+	// EnableFaultInjection allows the serving.kserve.io/fault-injection annotation to inject errors and latency into
+	// the requests of the graph, meant for the resilience testing clusters only
+	EnableFaultInjection bool `json:"enableFaultInjection"`
+	// TraceNamespaces are the namespaces whose inference graphs return the execution trace of the requests carrying
+	// the debug header, "*" allows all the namespaces
+	TraceNamespaces []string `json:"traceNamespaces"`
+	// TLSArgs are the arguments passing the TLS policy of the manager on to the router
+	TLSArgs []string `json:"-"`
+	// SharedCacheURL is the address of the shared cache the nodes setting cache.sharedKey cache their responses in,
+	// empty when the shared cache is not enabled
+	SharedCacheURL string `json:"-"`
+}
+
+// GetHeaderEnvs returns the environment variables configuring the header operations of the router
+func (rc *RouterConfig) GetHeaderEnvs() []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	// Only adding the env variables if router's headers config has the keys "propagate" and "deny"
+	if value, exists := rc.Headers["propagate"]; exists {
+		envs = append(envs, corev1.EnvVar{
+			Name:  constants.RouterHeadersPropagateEnvVar,
+			Value: strings.Join(value, ","),
+		})
+	}
+	// The deny patterns are passed as a JSON array, as the patterns may contain commas
+	if value, exists := rc.Headers["deny"]; exists {
+		denyHeaders, _ := json.Marshal(value) // a list of strings is always marshaled
+		envs = append(envs, corev1.EnvVar{
+			Name:  constants.RouterHeadersDenyEnvVar,
+			Value: string(denyHeaders),
+		})
+	}
+	return envs
 }
 
 func (rc *RouterConfig) GetImagePullSecrets() []corev1.LocalObjectReference {
@@ -155,7 +202,29 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	routerConfig.TLSArgs = r.TLSPolicy.Args()
+	sharedCacheConfig, err := v1beta1.NewSharedCacheConfig(configMap)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if sharedCacheConfig.Enabled {
+		routerConfig.SharedCacheURL = constants.SharedCacheURL()
+	}
+	// create the inline inference services before resolving the service urls
+	if !forceStopRuntime {
+		ready, err := r.reconcileInferenceServices(ctx, graph)
+		if err != nil {
+			r.Recorder.Eventf(graph, corev1.EventTypeWarning, "InternalError", err.Error())
+			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile inference services")
+		}
+		if !ready {
+			// the graph is reconciled again once the owned inference services change
+			r.Log.Info("Waiting for the inference services of the graph to be ready", "graph", graph.Name)
+			return reconcile.Result{}, nil
+		}
+	}
 	// resolve service urls
+	protocols := map[string]constants.InferenceServiceProtocol{}
 	if !forceStopRuntime {
 		for node, router := range graph.Spec.Nodes {
 			for i, route := range router.Steps {
@@ -165,6 +234,7 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				}
 				err := r.Client.Get(ctx, types.NamespacedName{Namespace: graph.Namespace, Name: route.ServiceName}, &isvc)
 				if err == nil {
+					protocols[route.ServiceName] = inferenceServiceProtocol(&isvc)
 					if graph.Spec.Nodes[node].Steps[i].ServiceURL == "" {
 						serviceUrl, err := isvcutils.GetPredictorEndpoint(ctx, r.Client, &isvc)
 						if err == nil {
@@ -182,6 +252,8 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
+	graph.Status.Topology = buildGraphTopology(graph, protocols)
+
 	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, r.Clientset)
 	if err != nil {
 		r.Log.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
@@ -192,6 +264,12 @@ func (r *InferenceGraphReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
 	}
 
+	if !forceStopRuntime {
+		if err := reconcileGraphConfigMap(ctx, r.Client, r.Clientset, r.Scheme, graph); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile inference graph config map")
+		}
+	}
+
 	deploymentMode := isvcutils.GetDeploymentMode(graph.Status.DeploymentMode, graph.ObjectMeta.Annotations, deployConfig)
 	r.Log.Info("Inference graph deployment ", "deployment mode ", deploymentMode)
 	if deploymentMode == constants.Standard {
@@ -371,7 +449,8 @@ func (r *InferenceGraphReconciler) SetupWithManager(mgr ctrl.Manager, deployConf
 
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.InferenceGraph{}).
-		Owns(&appsv1.Deployment{})
+		Owns(&appsv1.Deployment{}).
+		Owns(&v1beta1.InferenceService{})
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -379,5 +458,28 @@ func (r *InferenceGraphReconciler) SetupWithManager(mgr ctrl.Manager, deployConf
 		r.Log.Info("The InferenceGraph controller won't watch serving.knative.dev/v1/Service resources because the CRD is not available.")
 	}
 
+	if r.ConfigReloads != nil {
+		ctrlBuilder = ctrlBuilder.WatchesRawSource(source.Channel(r.ConfigReloads, handler.EnqueueRequestsFromMapFunc(r.configReloadFunc)))
+	}
+
 	return ctrlBuilder.Complete(r)
 }
+
+func (r *InferenceGraphReconciler) configReloadFunc(ctx context.Context, _ client.Object) []reconcile.Request {
+	var graphList v1alpha1.InferenceGraphList
+	if err := r.Client.List(ctx, &graphList); err != nil {
+		r.Log.Error(err, "unable to list InferenceGraphs")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(graphList.Items))
+	for _, graph := range graphList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: graph.Namespace,
+				Name:      graph.Name,
+			},
+		})
+	}
+	return requests
+}
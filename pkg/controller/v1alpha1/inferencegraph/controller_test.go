@@ -29,8 +29,10 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -538,8 +540,15 @@ var _ = Describe("Inference Graph controller test", func() {
 												},
 											},
 											Args: []string{
-												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{}}",
+												constants.RouterGraphConfigArgName,
+												"/etc/kserve/graph/graph.json",
+											},
+											VolumeMounts: []corev1.VolumeMount{
+												{
+													Name:      constants.RouterGraphConfigVolumeName,
+													MountPath: constants.RouterGraphConfigMountPath,
+													ReadOnly:  true,
+												},
 											},
 											Resources: corev1.ResourceRequirements{
 												Limits: corev1.ResourceList{
@@ -564,6 +573,16 @@ var _ = Describe("Inference Graph controller test", func() {
 										},
 									},
 									AutomountServiceAccountToken: proto.Bool(false),
+									Volumes: []corev1.Volume{
+										{
+											Name: constants.RouterGraphConfigVolumeName,
+											VolumeSource: corev1.VolumeSource{
+												ConfigMap: &corev1.ConfigMapVolumeSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: constants.InferenceGraphConfigMapName(graphName)},
+												},
+											},
+										},
+									},
 								},
 							},
 						},
@@ -578,6 +597,11 @@ var _ = Describe("Inference Graph controller test", func() {
 			err := k8sClient.Update(context.TODO(), expectedKnService, client.DryRunAll)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(kmp.SafeDiff(actualKnServiceCreated.Spec, expectedKnService.Spec)).To(Equal(""))
+
+			// The routing spec of the graph is mounted in the router from the graph config map
+			graphConfigMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: constants.InferenceGraphConfigMapName(graphName), Namespace: serviceKey.Namespace}, graphConfigMap)).To(Succeed())
+			Expect(graphConfigMap.Data[constants.RouterGraphConfigFileName]).To(MatchJSON(`{"nodes":{"root":{"routerType":"Sequence","steps":[{"serviceUrl":"http://someservice.example.com"}]}},"resources":{}}`))
 		})
 	})
 
@@ -676,8 +700,15 @@ var _ = Describe("Inference Graph controller test", func() {
 												},
 											},
 											Args: []string{
-												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"},\"requests\":{\"cpu\":\"123m\",\"memory\":\"123Mi\"}}}",
+												constants.RouterGraphConfigArgName,
+												"/etc/kserve/graph/graph.json",
+											},
+											VolumeMounts: []corev1.VolumeMount{
+												{
+													Name:      constants.RouterGraphConfigVolumeName,
+													MountPath: constants.RouterGraphConfigMountPath,
+													ReadOnly:  true,
+												},
 											},
 											Resources: corev1.ResourceRequirements{
 												Limits: corev1.ResourceList{
@@ -702,6 +733,16 @@ var _ = Describe("Inference Graph controller test", func() {
 										},
 									},
 									AutomountServiceAccountToken: proto.Bool(false),
+									Volumes: []corev1.Volume{
+										{
+											Name: constants.RouterGraphConfigVolumeName,
+											VolumeSource: corev1.VolumeSource{
+												ConfigMap: &corev1.ConfigMapVolumeSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: constants.InferenceGraphConfigMapName(graphName)},
+												},
+											},
+										},
+									},
 								},
 							},
 						},
@@ -828,8 +869,15 @@ var _ = Describe("Inference Graph controller test", func() {
 												},
 											},
 											Args: []string{
-												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{},\"affinity\":{\"podAffinity\":{\"preferredDuringSchedulingIgnoredDuringExecution\":[{\"weight\":100,\"podAffinityTerm\":{\"labelSelector\":{\"matchExpressions\":[{\"key\":\"serving.kserve.io/inferencegraph\",\"operator\":\"In\",\"values\":[\"singlenode3\"]}]},\"topologyKey\":\"topology.kubernetes.io/zone\"}}]}}}",
+												constants.RouterGraphConfigArgName,
+												"/etc/kserve/graph/graph.json",
+											},
+											VolumeMounts: []corev1.VolumeMount{
+												{
+													Name:      constants.RouterGraphConfigVolumeName,
+													MountPath: constants.RouterGraphConfigMountPath,
+													ReadOnly:  true,
+												},
 											},
 											Resources: corev1.ResourceRequirements{
 												Limits: corev1.ResourceList{
@@ -877,6 +925,16 @@ var _ = Describe("Inference Graph controller test", func() {
 										},
 									},
 									AutomountServiceAccountToken: proto.Bool(false),
+									Volumes: []corev1.Volume{
+										{
+											Name: constants.RouterGraphConfigVolumeName,
+											VolumeSource: corev1.VolumeSource{
+												ConfigMap: &corev1.ConfigMapVolumeSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: constants.InferenceGraphConfigMapName(graphName)},
+												},
+											},
+										},
+									},
 								},
 							},
 						},
@@ -993,6 +1051,105 @@ var _ = Describe("Inference Graph controller test", func() {
 		})
 	})
 
+	Context("When creating an InferenceGraph with inline InferenceServices", func() {
+		It("Should create the InferenceServices in the order of their dependencies", func() {
+			By("By creating a new InferenceGraph")
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+			graphName := "iginline"
+			ctx := context.Background()
+			isvcSpec := runtime.RawExtension{Raw: []byte(`{"predictor":{"model":{"modelFormat":{"name":"sklearn"},"storageUri":"s3://test/model"}}}`)}
+			ig := &v1alpha1.InferenceGraph{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      graphName,
+					Namespace: "default",
+					Annotations: map[string]string{
+						"serving.kserve.io/deploymentMode": string(constants.Standard),
+					},
+				},
+				Spec: v1alpha1.InferenceGraphSpec{
+					Nodes: map[string]v1alpha1.InferenceRouter{
+						v1alpha1.GraphRootNodeName: {
+							RouterType: v1alpha1.Sequence,
+							Steps: []v1alpha1.InferenceStep{
+								{
+									InferenceTarget: v1alpha1.InferenceTarget{
+										ServiceName: "inline-model",
+									},
+								},
+							},
+						},
+					},
+					InferenceServices: []v1alpha1.InferenceServiceTemplate{
+						{
+							Name:      "inline-model",
+							Labels:    map[string]string{"team": "ml"},
+							Spec:      isvcSpec,
+							DependsOn: []string{"inline-transformer"},
+						},
+						{
+							Name: "inline-transformer",
+							Spec: isvcSpec,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, ig)).Should(Succeed())
+			defer k8sClient.Delete(ctx, ig)
+
+			transformerKey := types.NamespacedName{Name: "inline-transformer", Namespace: "default"}
+			modelKey := types.NamespacedName{Name: "inline-model", Namespace: "default"}
+			transformer := &v1beta1.InferenceService{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, transformerKey, transformer)
+			}, timeout, interval).Should(Succeed())
+			Expect(metav1.IsControlledBy(transformer, ig)).To(BeTrue())
+			Expect(transformer.Spec.Predictor.Model.ModelFormat.Name).To(Equal("sklearn"))
+
+			By("Waiting for the transformer to be ready before creating the model")
+			Consistently(func() bool {
+				return apierr.IsNotFound(k8sClient.Get(ctx, modelKey, &v1beta1.InferenceService{}))
+			}, time.Second*2, interval).Should(BeTrue())
+
+			transformer.Status.Conditions = duckv1.Conditions{
+				{
+					Type:   apis.ConditionReady,
+					Status: corev1.ConditionTrue,
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, transformer)).Should(Succeed())
+
+			model := &v1beta1.InferenceService{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, modelKey, model)
+			}, timeout, interval).Should(Succeed())
+			Expect(metav1.IsControlledBy(model, ig)).To(BeTrue())
+			Expect(model.Labels).To(HaveKeyWithValue("team", "ml"))
+
+			By("Deleting the InferenceServices which are no longer declared")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: graphName, Namespace: "default"}, ig); err != nil {
+					return err
+				}
+				ig.Spec.Nodes[v1alpha1.GraphRootNodeName].Steps[0].ServiceName = "inline-transformer"
+				ig.Spec.InferenceServices = ig.Spec.InferenceServices[1:]
+				return k8sClient.Update(ctx, ig)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, modelKey, &v1beta1.InferenceService{})
+				return apierr.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+			Expect(k8sClient.Get(ctx, transformerKey, &v1beta1.InferenceService{})).Should(Succeed())
+		})
+	})
+
 	Context("When creating an InferenceGraph in Knative mode", func() {
 		It("Should fail if Knative Serving is not installed", func() {
 			// Simulate Knative Serving is absent by setting to false the relevant item in utils.gvResourcesCache variable
@@ -1566,8 +1723,15 @@ var _ = Describe("Inference Graph controller test", func() {
 												},
 											},
 											Args: []string{
-												"--graph-json",
-												"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{},\"tolerations\":[{\"key\":\"key1\",\"operator\":\"Equal\",\"value\":\"value1\",\"effect\":\"NoSchedule\"}]}",
+												constants.RouterGraphConfigArgName,
+												"/etc/kserve/graph/graph.json",
+											},
+											VolumeMounts: []corev1.VolumeMount{
+												{
+													Name:      constants.RouterGraphConfigVolumeName,
+													MountPath: constants.RouterGraphConfigMountPath,
+													ReadOnly:  true,
+												},
 											},
 											Resources: corev1.ResourceRequirements{
 												Limits: corev1.ResourceList{
@@ -1600,6 +1764,16 @@ var _ = Describe("Inference Graph controller test", func() {
 										},
 									},
 									AutomountServiceAccountToken: proto.Bool(false),
+									Volumes: []corev1.Volume{
+										{
+											Name: constants.RouterGraphConfigVolumeName,
+											VolumeSource: corev1.VolumeSource{
+												ConfigMap: &corev1.ConfigMapVolumeSource{
+													LocalObjectReference: corev1.LocalObjectReference{Name: constants.InferenceGraphConfigMapName(graphName)},
+												},
+											},
+										},
+									},
 								},
 							},
 						},
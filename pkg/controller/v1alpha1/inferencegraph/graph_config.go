@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+/*
+The routing spec of the graph is projected into a ConfigMap mounted in the router pods, rather than passed as an
+argument of the router container. The router reloads the spec when the mounted file changes, so graph edits
+propagate to the running router pods without rolling out new pods.
+
+The graph nodes are revisioned by their content. When a canary traffic percent is set, a new revision of the nodes is
+written next to the last rolled out revision, and the router routes the canary percent of the requests to the new
+revision. The new revision is rolled out to all the traffic once the canary traffic percent is removed or set to 100.
+*/
+func createGraphConfigMap(graph *v1alpha1.InferenceGraph, existing *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	bytes, err := json.Marshal(graph.Spec)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := graphRevision(graph.Name, graph.Spec)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{
+		constants.RouterGraphConfigFileName: string(bytes),
+	}
+	rolledoutRevision := revision
+	if stable, stableRevision := rolledoutGraphSpec(graph.Name, existing); isCanaryRollout(graph, stableRevision, revision) {
+		data[constants.RouterGraphConfigFileName] = stable
+		data[constants.RouterCanaryGraphConfigFileName] = string(bytes)
+		rolledoutRevision = stableRevision
+	}
+	graph.Status.LatestCreatedRevision = revision
+	graph.Status.LatestRolledoutRevision = rolledoutRevision
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.InferenceGraphConfigMapName(graph.Name),
+			Namespace: graph.Namespace,
+			Labels: map[string]string{
+				constants.InferenceGraphLabel: graph.Name,
+			},
+		},
+		Data: data,
+	}, nil
+}
+
+// graphRevision returns the name of the revision of the graph nodes, derived from the hash of the nodes
+func graphRevision(graphName string, spec v1alpha1.InferenceGraphSpec) (string, error) {
+	bytes, err := json.Marshal(spec.Nodes)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bytes)
+	return graphName + "-" + hex.EncodeToString(sum[:])[:10], nil
+}
+
+// rolledoutGraphSpec returns the routing spec serving the non canary traffic and its revision, read from the existing
+// graph config map
+func rolledoutGraphSpec(graphName string, existing *corev1.ConfigMap) (string, string) {
+	if existing == nil {
+		return "", ""
+	}
+	stable, ok := existing.Data[constants.RouterGraphConfigFileName]
+	if !ok {
+		return "", ""
+	}
+	spec := v1alpha1.InferenceGraphSpec{}
+	if err := json.Unmarshal([]byte(stable), &spec); err != nil {
+		logger.Error(err, "Failed to unmarshal the rolled out routing spec", "graph", graphName)
+		return "", ""
+	}
+	revision, err := graphRevision(graphName, spec)
+	if err != nil {
+		return "", ""
+	}
+	return stable, revision
+}
+
+func isCanaryRollout(graph *v1alpha1.InferenceGraph, rolledoutRevision string, revision string) bool {
+	return graph.Spec.CanaryTrafficPercent != nil && *graph.Spec.CanaryTrafficPercent < 100 &&
+		rolledoutRevision != "" && rolledoutRevision != revision
+}
+
+// reconcileGraphConfigMap creates or updates the ConfigMap holding the routing spec of the graph, and records the
+// revisions of the graph nodes in the graph status
+func reconcileGraphConfigMap(ctx context.Context, cl client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, graph *v1alpha1.InferenceGraph) error {
+	name := constants.InferenceGraphConfigMapName(graph.Name)
+	existing, err := clientset.CoreV1().ConfigMaps(graph.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+
+	desired, err := createGraphConfigMap(graph, existing)
+	if err != nil {
+		return errors.Wrapf(err, "fails to marshal the spec of inference graph %s", graph.Name)
+	}
+	if err := controllerutil.SetControllerReference(graph, desired, scheme); err != nil {
+		return err
+	}
+
+	if existing == nil {
+		logger.Info("Creating inference graph config map", "namespace", desired.Namespace, "name", desired.Name)
+		return cl.Create(ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(existing.Data, desired.Data) {
+		return nil
+	}
+	logger.Info("Updating inference graph config map", "namespace", desired.Namespace, "name", desired.Name,
+		"latestCreatedRevision", graph.Status.LatestCreatedRevision, "latestRolledoutRevision", graph.Status.LatestRolledoutRevision)
+	existing.Data = desired.Data
+	return cl.Update(ctx, existing)
+}
+
+// addRouterGraphConfig mounts the graph config map in the router container and points the router to the routing spec
+func addRouterGraphConfig(graph *v1alpha1.InferenceGraph, podSpec *corev1.PodSpec) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: constants.RouterGraphConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: constants.InferenceGraphConfigMapName(graph.Name),
+				},
+			},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.RouterGraphConfigVolumeName,
+		MountPath: constants.RouterGraphConfigMountPath,
+		ReadOnly:  true,
+	})
+	container.Args = append(container.Args, constants.RouterGraphConfigArgName,
+		filepath.Join(constants.RouterGraphConfigMountPath, constants.RouterGraphConfigFileName))
+}
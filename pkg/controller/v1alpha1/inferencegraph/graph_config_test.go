@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestCreateGraphConfigMap(t *testing.T) {
+	newGraph := func(serviceURL string, canaryTrafficPercent *int64) *InferenceGraph {
+		return &InferenceGraph{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-ig",
+				Namespace: "basic-ig-namespace",
+			},
+			Spec: InferenceGraphSpec{
+				Nodes: map[string]InferenceRouter{
+					GraphRootNodeName: {
+						RouterType: Sequence,
+						Steps: []InferenceStep{
+							{InferenceTarget: InferenceTarget{ServiceURL: serviceURL}},
+						},
+					},
+				},
+				CanaryTrafficPercent: canaryTrafficPercent,
+			},
+		}
+	}
+	marshal := func(graph *InferenceGraph) string {
+		bytes, err := json.Marshal(graph.Spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(bytes)
+	}
+	revision := func(graph *InferenceGraph) string {
+		revision, err := graphRevision(graph.Name, graph.Spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return revision
+	}
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: data}
+	}
+
+	stable := newGraph("http://model1.example.com", nil)
+	stableData := map[string]string{constants.RouterGraphConfigFileName: marshal(stable)}
+
+	scenarios := map[string]struct {
+		graph                   *InferenceGraph
+		existing                *corev1.ConfigMap
+		expectedData            func(graph *InferenceGraph) map[string]string
+		expectedRolledoutStable bool
+	}{
+		"NewGraph": {
+			graph:    newGraph("http://model2.example.com", ptr.To(int64(20))),
+			existing: nil,
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{constants.RouterGraphConfigFileName: marshal(graph)}
+			},
+		},
+		"UnchangedNodes": {
+			graph:    newGraph("http://model1.example.com", ptr.To(int64(20))),
+			existing: configMap(stableData),
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{constants.RouterGraphConfigFileName: marshal(graph)}
+			},
+		},
+		"CanaryRollout": {
+			graph:    newGraph("http://model2.example.com", ptr.To(int64(20))),
+			existing: configMap(stableData),
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{
+					constants.RouterGraphConfigFileName:       marshal(stable),
+					constants.RouterCanaryGraphConfigFileName: marshal(graph),
+				}
+			},
+			expectedRolledoutStable: true,
+		},
+		"CanaryUpdateKeepsRolledoutRevision": {
+			graph: newGraph("http://model3.example.com", ptr.To(int64(50))),
+			existing: configMap(map[string]string{
+				constants.RouterGraphConfigFileName:       marshal(stable),
+				constants.RouterCanaryGraphConfigFileName: marshal(newGraph("http://model2.example.com", ptr.To(int64(20)))),
+			}),
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{
+					constants.RouterGraphConfigFileName:       marshal(stable),
+					constants.RouterCanaryGraphConfigFileName: marshal(graph),
+				}
+			},
+			expectedRolledoutStable: true,
+		},
+		"CanaryPromotedWith100Percent": {
+			graph:    newGraph("http://model2.example.com", ptr.To(int64(100))),
+			existing: configMap(stableData),
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{constants.RouterGraphConfigFileName: marshal(graph)}
+			},
+		},
+		"RolloutWithoutCanary": {
+			graph:    newGraph("http://model2.example.com", nil),
+			existing: configMap(stableData),
+			expectedData: func(graph *InferenceGraph) map[string]string {
+				return map[string]string{constants.RouterGraphConfigFileName: marshal(graph)}
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			expectedData := scenario.expectedData(scenario.graph)
+			expectedRolledoutRevision := revision(scenario.graph)
+			if scenario.expectedRolledoutStable {
+				expectedRolledoutRevision = revision(stable)
+			}
+
+			res, err := createGraphConfigMap(scenario.graph, scenario.existing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expectedData, res.Data); diff != "" {
+				t.Errorf("Test %q unexpected config map data (-want +got): %v", name, diff)
+			}
+			if scenario.graph.Status.LatestCreatedRevision != revision(scenario.graph) {
+				t.Errorf("Test %q unexpected latest created revision %s", name, scenario.graph.Status.LatestCreatedRevision)
+			}
+			if scenario.graph.Status.LatestRolledoutRevision != expectedRolledoutRevision {
+				t.Errorf("Test %q unexpected latest rolled out revision %s, expected %s", name,
+					scenario.graph.Status.LatestRolledoutRevision, expectedRolledoutRevision)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// inferenceServiceFromTemplate builds the InferenceService declared inline by the graph
+func inferenceServiceFromTemplate(graph *v1alpha1.InferenceGraph, template *v1alpha1.InferenceServiceTemplate) (*v1beta1.InferenceService, error) {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        template.Name,
+			Namespace:   graph.Namespace,
+			Labels:      template.Labels,
+			Annotations: template.Annotations,
+		},
+	}
+	if err := json.Unmarshal(template.Spec.Raw, &isvc.Spec); err != nil {
+		return nil, fmt.Errorf("invalid spec of InferenceService %s: %w", template.Name, err)
+	}
+	return isvc, nil
+}
+
+// reconcileInferenceServices creates and updates the InferenceServices declared inline by the graph in the order of
+// their dependencies, an InferenceService is only created once the ones it depends on are ready. The InferenceServices
+// owned by the graph which are no longer declared are deleted. Returns whether all the declared InferenceServices
+// are ready.
+func (r *InferenceGraphReconciler) reconcileInferenceServices(ctx context.Context, graph *v1alpha1.InferenceGraph) (bool, error) {
+	templates, err := v1alpha1.SortInferenceServiceTemplates(graph.Name, graph.Spec.InferenceServices)
+	if err != nil {
+		return false, err
+	}
+
+	allReady := true
+	ready := make(map[string]bool, len(templates))
+	declared := make(map[string]bool, len(templates))
+	for i := range templates {
+		template := &templates[i]
+		declared[template.Name] = true
+		waiting := false
+		for _, dependency := range template.DependsOn {
+			if !ready[dependency] {
+				waiting = true
+			}
+		}
+		if waiting {
+			r.Log.Info("InferenceService is waiting for its dependencies", "graph", graph.Name,
+				"name", template.Name, "dependsOn", template.DependsOn)
+			allReady = false
+			continue
+		}
+
+		desired, err := inferenceServiceFromTemplate(graph, template)
+		if err != nil {
+			return false, err
+		}
+		if err := controllerutil.SetControllerReference(graph, desired, r.Scheme); err != nil {
+			return false, err
+		}
+
+		existing := &v1beta1.InferenceService{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+		if apierr.IsNotFound(err) {
+			r.Log.Info("Creating InferenceService", "graph", graph.Name, "name", desired.Name)
+			if err := r.Create(ctx, desired); err != nil {
+				return false, errors.Wrapf(err, "fails to create InferenceService %s", desired.Name)
+			}
+			r.Recorder.Eventf(graph, corev1.EventTypeNormal, "InferenceServiceCreated",
+				"Created InferenceService %s", desired.Name)
+			allReady = false
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if !metav1.IsControlledBy(existing, graph) {
+			return false, fmt.Errorf("InferenceService %s already exists and is not owned by InferenceGraph %s",
+				existing.Name, graph.Name)
+		}
+
+		// The InferenceService webhook defaults the spec, so only the fields set by the template are compared
+		if !equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) ||
+			!equality.Semantic.DeepDerivative(desired.Labels, existing.Labels) ||
+			!equality.Semantic.DeepDerivative(desired.Annotations, existing.Annotations) {
+			updated := existing.DeepCopy()
+			updated.Spec = desired.Spec
+			updated.Labels = utils.Union(existing.Labels, desired.Labels)
+			updated.Annotations = utils.Union(existing.Annotations, desired.Annotations)
+			r.Log.Info("Updating InferenceService", "graph", graph.Name, "name", desired.Name)
+			if err := r.Update(ctx, updated); err != nil {
+				return false, errors.Wrapf(err, "fails to update InferenceService %s", desired.Name)
+			}
+			existing = updated
+		}
+		ready[template.Name] = existing.Status.IsReady()
+		allReady = allReady && ready[template.Name]
+	}
+
+	isvcList := &v1beta1.InferenceServiceList{}
+	if err := r.List(ctx, isvcList, client.InNamespace(graph.Namespace)); err != nil {
+		return false, err
+	}
+	for i := range isvcList.Items {
+		isvc := &isvcList.Items[i]
+		if declared[isvc.Name] || !metav1.IsControlledBy(isvc, graph) {
+			continue
+		}
+		r.Log.Info("Deleting InferenceService which is no longer declared", "graph", graph.Name, "name", isvc.Name)
+		if err := r.Delete(ctx, isvc); client.IgnoreNotFound(err) != nil {
+			return false, errors.Wrapf(err, "fails to delete InferenceService %s", isvc.Name)
+		}
+		r.Recorder.Eventf(graph, corev1.EventTypeNormal, "InferenceServiceDeleted",
+			"Deleted InferenceService %s", isvc.Name)
+	}
+	return allReady, nil
+}
@@ -18,7 +18,6 @@ package inferencegraph
 
 import (
 	"context"
-	"encoding/json"
 	"reflect"
 	"strings"
 
@@ -161,10 +160,6 @@ func createKnativeService(
 	graph *v1alpha1.InferenceGraph,
 	config *RouterConfig,
 ) *knservingv1.Service {
-	bytes, err := json.Marshal(graph.Spec)
-	if err != nil {
-		return nil
-	}
 	annotations := componentMeta.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
@@ -213,11 +208,7 @@ func createKnativeService(
 								{
 									Image:           config.Image,
 									ImagePullPolicy: corev1.PullPolicy(config.ImagePullPolicy),
-									Args: []string{
-										"--graph-json",
-										string(bytes),
-									},
-									Resources: constructResourceRequirements(*graph, *config),
+									Resources:       constructResourceRequirements(*graph, *config),
 									SecurityContext: &corev1.SecurityContext{
 										Privileged:               proto.Bool(false),
 										RunAsNonRoot:             proto.Bool(true),
@@ -244,16 +235,13 @@ func createKnativeService(
 		},
 	}
 
-	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate"
-	value, exists := config.Headers["propagate"]
-	if exists {
-		service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env = []corev1.EnvVar{
-			{
-				Name:  constants.RouterHeadersPropagateEnvVar,
-				Value: strings.Join(value, ","),
-			},
-		}
-	}
+	service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0].Env = config.GetHeaderEnvs()
+	addRouterGraphConfig(graph, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec)
+	addRouterProfiling(graph, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0])
+	addRouterFaultInjection(graph, config, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0])
+	addRouterTrace(graph, config, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0])
+	addRouterTLSPolicy(config, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0])
+	addRouterSharedCache(config, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec)
 	return service
 }
 
@@ -18,8 +18,8 @@ package inferencegraph
 
 import (
 	"context"
-	"encoding/json"
-	"strings"
+	"path/filepath"
+	"slices"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	knapis "knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +39,8 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
+	"github.com/kserve/kserve/pkg/profiling"
+	"github.com/kserve/kserve/pkg/sharedcache"
 )
 
 var logger = logf.Log.WithName("InferenceGraphRawDeployer")
@@ -49,11 +52,6 @@ Also propagates headers onto podspec container environment variables.
 This function makes sense to be used in raw k8s deployment mode
 */
 func createInferenceGraphPodSpec(graph *v1alpha1.InferenceGraph, config *RouterConfig) *corev1.PodSpec {
-	bytes, err := json.Marshal(graph.Spec)
-	if err != nil {
-		return nil
-	}
-
 	// Pod spec with 'router container with resource requirements' and 'affinity' as well
 	podSpec := &corev1.PodSpec{
 		Containers: []corev1.Container{
@@ -61,12 +59,8 @@ func createInferenceGraphPodSpec(graph *v1alpha1.InferenceGraph, config *RouterC
 				Name:            graph.ObjectMeta.Name,
 				Image:           config.Image,
 				ImagePullPolicy: corev1.PullPolicy(config.ImagePullPolicy),
-				Args: []string{
-					"--graph-json",
-					string(bytes),
-				},
-				Resources:      constructResourceRequirements(*graph, *config),
-				ReadinessProbe: constants.GetRouterReadinessProbe(),
+				Resources:       constructResourceRequirements(*graph, *config),
+				ReadinessProbe:  constants.GetRouterReadinessProbe(),
 				SecurityContext: &corev1.SecurityContext{
 					Privileged:               proto.Bool(false),
 					RunAsNonRoot:             proto.Bool(true),
@@ -87,20 +81,82 @@ func createInferenceGraphPodSpec(graph *v1alpha1.InferenceGraph, config *RouterC
 		ServiceAccountName:           graph.Spec.ServiceAccountName,
 	}
 
-	// Only adding this env variable "PROPAGATE_HEADERS" if router's headers config has the key "propagate"
-	value, exists := config.Headers["propagate"]
-	if exists {
-		podSpec.Containers[0].Env = []corev1.EnvVar{
-			{
-				Name:  constants.RouterHeadersPropagateEnvVar,
-				Value: strings.Join(value, ","),
-			},
-		}
-	}
+	podSpec.Containers[0].Env = config.GetHeaderEnvs()
+	addRouterGraphConfig(graph, podSpec)
+	addRouterProfiling(graph, &podSpec.Containers[0])
+	addRouterFaultInjection(graph, config, &podSpec.Containers[0])
+	addRouterTrace(graph, config, &podSpec.Containers[0])
+	addRouterTLSPolicy(config, &podSpec.Containers[0])
+	addRouterSharedCache(config, podSpec)
 
 	return podSpec
 }
 
+// addRouterProfiling enables the profiling endpoints of the router container when the inference graph is annotated
+// with serving.kserve.io/enable-profiling: "true"
+func addRouterProfiling(graph *v1alpha1.InferenceGraph, container *corev1.Container) {
+	if graph.ObjectMeta.Annotations[constants.EnableProfilingAnnotationKey] != "true" {
+		return
+	}
+	container.Args = append(container.Args, constants.EnableProfilingArgName)
+	container.Env = append(container.Env, profiling.TokenEnvFromSecret())
+}
+
+// addRouterFaultInjection passes the fault injection rules the inference graph is annotated with to the router
+// container, only when the fault injection is enabled in the router config
+func addRouterFaultInjection(graph *v1alpha1.InferenceGraph, config *RouterConfig, container *corev1.Container) {
+	rules, ok := graph.ObjectMeta.Annotations[constants.FaultInjectionAnnotationKey]
+	if !ok || !config.EnableFaultInjection {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: constants.RouterFaultInjectionEnvVar, Value: rules})
+}
+
+// addRouterTrace allows the router container to return the execution trace of the requests carrying the debug header,
+// only when the namespace of the inference graph is in the trace namespaces of the router config
+func addRouterTrace(graph *v1alpha1.InferenceGraph, config *RouterConfig, container *corev1.Container) {
+	if !slices.Contains(config.TraceNamespaces, graph.Namespace) && !slices.Contains(config.TraceNamespaces, "*") {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: constants.RouterTraceEnvVar, Value: "true"})
+}
+
+// addRouterTLSPolicy passes the TLS policy of the manager on to the router container, so that the router enforces it
+// on its calls to the steps
+func addRouterTLSPolicy(config *RouterConfig, container *corev1.Container) {
+	container.Args = append(container.Args, config.TLSArgs...)
+}
+
+// addRouterSharedCache passes the address of the shared cache on to the router container when the shared cache is
+// enabled, with the projected service account token the router authenticates with. The shared cache derives the
+// tenant of the entries of the graph from the namespace of the token.
+func addRouterSharedCache(config *RouterConfig, podSpec *corev1.PodSpec) {
+	if config.SharedCacheURL == "" {
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: constants.RouterSharedCacheTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          sharedcache.TokenAudience,
+						ExpirationSeconds: ptr.To(int64(3600)),
+						Path:              filepath.Base(sharedcache.TokenPath),
+					},
+				}},
+			},
+		},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.RouterSharedCacheTokenVolumeName,
+		MountPath: filepath.Dir(sharedcache.TokenPath),
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env, corev1.EnvVar{Name: constants.RouterSharedCacheURLEnvVar, Value: config.SharedCacheURL})
+}
+
 /*
 A simple utility to create a basic meta object given name and namespace;  Can be extended to accept labels, annotations as well
 */
@@ -154,7 +210,7 @@ func handleInferenceGraphRawDeployment(ctx context.Context, cl client.Client, cl
 	objectMeta, componentExtSpec := constructForRawDeployment(graph)
 
 	// create the reconciler
-	reconciler, err := raw.NewRawKubeReconciler(ctx, cl, clientset, scheme, objectMeta, metav1.ObjectMeta{}, &componentExtSpec, desiredSvc, nil, nil, nil, nil, nil, nil)
+	reconciler, err := raw.NewRawKubeReconciler(ctx, cl, clientset, nil, scheme, objectMeta, metav1.ObjectMeta{}, &componentExtSpec, desiredSvc, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "fails to create NewRawKubeReconciler for inference graph")
 	}
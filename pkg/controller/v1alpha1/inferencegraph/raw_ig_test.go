@@ -59,6 +59,10 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 				"Authorization",
 				"Intuit_tid",
 			},
+			"deny": {
+				"^Cookie$",
+				"^Proxy-Authorization$",
+			},
 		},
 	}
 
@@ -196,8 +200,15 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Image: "kserve/router:v0.10.0",
 					Name:  "basic-ig",
 					Args: []string{
-						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{}}",
+						constants.RouterGraphConfigArgName,
+						"/etc/kserve/graph/graph.json",
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      constants.RouterGraphConfigVolumeName,
+							MountPath: constants.RouterGraphConfigMountPath,
+							ReadOnly:  true,
+						},
 					},
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
@@ -223,6 +234,16 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 			},
 			AutomountServiceAccountToken: proto.Bool(false),
 			ImagePullSecrets:             []corev1.LocalObjectReference{},
+			Volumes: []corev1.Volume{
+				{
+					Name: constants.RouterGraphConfigVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "basic-ig-graph-config"},
+						},
+					},
+				},
+			},
 		},
 		"basicgraphwithheaders": {
 			Containers: []corev1.Container{
@@ -230,14 +251,25 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Image: "kserve/router:v0.10.0",
 					Name:  "basic-ig",
 					Args: []string{
-						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{}}",
+						constants.RouterGraphConfigArgName,
+						"/etc/kserve/graph/graph.json",
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      constants.RouterGraphConfigVolumeName,
+							MountPath: constants.RouterGraphConfigMountPath,
+							ReadOnly:  true,
+						},
 					},
 					Env: []corev1.EnvVar{
 						{
 							Name:  "PROPAGATE_HEADERS",
 							Value: "Authorization,Intuit_tid",
 						},
+						{
+							Name:  "DENY_HEADERS",
+							Value: `["^Cookie$","^Proxy-Authorization$"]`,
+						},
 					},
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
@@ -263,6 +295,16 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 			},
 			AutomountServiceAccountToken: proto.Bool(false),
 			ImagePullSecrets:             []corev1.LocalObjectReference{},
+			Volumes: []corev1.Volume{
+				{
+					Name: constants.RouterGraphConfigVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "basic-ig-graph-config"},
+						},
+					},
+				},
+			},
 		},
 		"withresource": {
 			Containers: []corev1.Container{
@@ -270,8 +312,15 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Image: "kserve/router:v0.10.0",
 					Name:  "resource-ig",
 					Args: []string{
-						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"100m\",\"memory\":\"500Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"100Mi\"}}}",
+						constants.RouterGraphConfigArgName,
+						"/etc/kserve/graph/graph.json",
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      constants.RouterGraphConfigVolumeName,
+							MountPath: constants.RouterGraphConfigMountPath,
+							ReadOnly:  true,
+						},
 					},
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
@@ -297,6 +346,16 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 			},
 			AutomountServiceAccountToken: proto.Bool(false),
 			ImagePullSecrets:             []corev1.LocalObjectReference{},
+			Volumes: []corev1.Volume{
+				{
+					Name: constants.RouterGraphConfigVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "resource-ig-graph-config"},
+						},
+					},
+				},
+			},
 		},
 		"with tolerations": {
 			Containers: []corev1.Container{
@@ -304,8 +363,15 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 					Image: "kserve/router:v0.10.0",
 					Name:  "resource-ig",
 					Args: []string{
-						"--graph-json",
-						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.example.com\"}]}},\"resources\":{\"limits\":{\"cpu\":\"100m\",\"memory\":\"500Mi\"},\"requests\":{\"cpu\":\"100m\",\"memory\":\"100Mi\"}},\"tolerations\":[{\"key\":\"key1\",\"operator\":\"Equal\",\"value\":\"value1\",\"effect\":\"NoSchedule\"}]}",
+						constants.RouterGraphConfigArgName,
+						"/etc/kserve/graph/graph.json",
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      constants.RouterGraphConfigVolumeName,
+							MountPath: constants.RouterGraphConfigMountPath,
+							ReadOnly:  true,
+						},
 					},
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
@@ -331,6 +397,16 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 			},
 			AutomountServiceAccountToken: proto.Bool(false),
 			ImagePullSecrets:             []corev1.LocalObjectReference{},
+			Volumes: []corev1.Volume{
+				{
+					Name: constants.RouterGraphConfigVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "resource-ig-graph-config"},
+						},
+					},
+				},
+			},
 			Tolerations: []corev1.Toleration{
 				{
 					Key:      "key1",
@@ -385,6 +461,43 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 	}
 }
 
+func TestAddRouterFaultInjection(t *testing.T) {
+	rules := `[{"errorPercent": 5}]`
+	scenarios := []struct {
+		name        string
+		annotations map[string]string
+		config      *RouterConfig
+		expected    []corev1.EnvVar
+	}{
+		{
+			name:        "fault injection enabled",
+			annotations: map[string]string{constants.FaultInjectionAnnotationKey: rules},
+			config:      &RouterConfig{EnableFaultInjection: true},
+			expected:    []corev1.EnvVar{{Name: constants.RouterFaultInjectionEnvVar, Value: rules}},
+		},
+		{
+			name:        "fault injection disabled",
+			annotations: map[string]string{constants.FaultInjectionAnnotationKey: rules},
+			config:      &RouterConfig{},
+		},
+		{
+			name:   "no fault injection annotation",
+			config: &RouterConfig{EnableFaultInjection: true},
+		},
+	}
+
+	for _, tt := range scenarios {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Annotations: tt.annotations}}
+			container := &corev1.Container{}
+			addRouterFaultInjection(graph, tt.config, container)
+			if diff := cmp.Diff(tt.expected, container.Env); diff != "" {
+				t.Errorf("Test %q unexpected result (-want +got): %v", t.Name(), diff)
+			}
+		})
+	}
+}
+
 func TestConstructGraphObjectMeta(t *testing.T) {
 	type args struct {
 		graph *InferenceGraph
@@ -643,3 +756,81 @@ func TestPropagateRawStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestAddRouterTrace(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		config   *RouterConfig
+		expected []corev1.EnvVar
+	}{
+		{
+			name:     "namespace allowed",
+			config:   &RouterConfig{TraceNamespaces: []string{"other", "test"}},
+			expected: []corev1.EnvVar{{Name: constants.RouterTraceEnvVar, Value: "true"}},
+		},
+		{
+			name:     "all namespaces allowed",
+			config:   &RouterConfig{TraceNamespaces: []string{"*"}},
+			expected: []corev1.EnvVar{{Name: constants.RouterTraceEnvVar, Value: "true"}},
+		},
+		{
+			name:   "namespace not allowed",
+			config: &RouterConfig{TraceNamespaces: []string{"other"}},
+		},
+		{
+			name:   "no trace namespaces",
+			config: &RouterConfig{},
+		},
+	}
+
+	for _, tt := range scenarios {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &InferenceGraph{ObjectMeta: metav1.ObjectMeta{Name: "graph", Namespace: "test"}}
+			container := &corev1.Container{}
+			addRouterTrace(graph, tt.config, container)
+			if diff := cmp.Diff(tt.expected, container.Env); diff != "" {
+				t.Errorf("Test %q unexpected result (-want +got): %v", t.Name(), diff)
+			}
+		})
+	}
+}
+
+func TestAddRouterSharedCache(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{}}}
+	addRouterSharedCache(&RouterConfig{}, podSpec)
+	if len(podSpec.Containers[0].Env) != 0 || len(podSpec.Volumes) != 0 {
+		t.Errorf("unexpected pod spec without the shared cache: %v", podSpec)
+	}
+
+	addRouterSharedCache(&RouterConfig{SharedCacheURL: "http://kserve-shared-cache.kserve.svc.cluster.local:8080"}, podSpec)
+	expectedEnv := []corev1.EnvVar{
+		{Name: constants.RouterSharedCacheURLEnvVar, Value: "http://kserve-shared-cache.kserve.svc.cluster.local:8080"},
+	}
+	if diff := cmp.Diff(expectedEnv, podSpec.Containers[0].Env); diff != "" {
+		t.Errorf("unexpected env (-want +got): %v", diff)
+	}
+	// the router authenticates to the shared cache with a token of the service account of the graph
+	expectedVolumes := []corev1.Volume{{
+		Name: constants.RouterSharedCacheTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "kserve-shared-cache",
+						ExpirationSeconds: ptr.To(int64(3600)),
+						Path:              "token",
+					},
+				}},
+			},
+		},
+	}}
+	if diff := cmp.Diff(expectedVolumes, podSpec.Volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want +got): %v", diff)
+	}
+	expectedMounts := []corev1.VolumeMount{
+		{Name: constants.RouterSharedCacheTokenVolumeName, MountPath: "/var/run/secrets/kserve/shared-cache", ReadOnly: true},
+	}
+	if diff := cmp.Diff(expectedMounts, podSpec.Containers[0].VolumeMounts); diff != "" {
+		t.Errorf("unexpected volume mounts (-want +got): %v", diff)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/sharedcache"
+)
+
+var sharedCacheLog = logf.Log.WithName("SharedCacheReconciler")
+
+// sharedCacheRetryInterval is how long the shared cache is reconciled again after a failure
+const sharedCacheRetryInterval = 30 * time.Second
+
+// SharedCacheReconciler deploys the shared cache in the KServe namespace while it is enabled in the
+// inferenceservice-config ConfigMap, and deletes it once disabled. The shared cache is reconciled on start and once
+// the ConfigMap is reloaded. It implements manager.Runnable, and only runs on the leader.
+type SharedCacheReconciler struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	// ConfigReloads receives an event once the inferenceservice-config ConfigMap is reloaded
+	ConfigReloads <-chan event.GenericEvent
+}
+
+// Start reconciles the shared cache until the context is done
+func (r *SharedCacheReconciler) Start(ctx context.Context) error {
+	for {
+		var retry <-chan time.Time
+		if err := r.Reconcile(ctx); err != nil {
+			sharedCacheLog.Error(err, "Failed to reconcile the shared cache, retrying", "retryInterval", sharedCacheRetryInterval)
+			retry = time.After(sharedCacheRetryInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.ConfigReloads:
+		case <-retry:
+		}
+	}
+}
+
+// Reconcile creates or updates the deployment and the service of the shared cache when it is enabled, and deletes
+// them otherwise
+func (r *SharedCacheReconciler) Reconcile(ctx context.Context) error {
+	configMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, r.Clientset)
+	if err != nil {
+		return err
+	}
+	config, err := v1beta1.NewSharedCacheConfig(configMap)
+	if err != nil {
+		return err
+	}
+	for _, desired := range []client.Object{createSharedCacheDeployment(config), createSharedCacheService()} {
+		if config.Enabled {
+			err = r.apply(ctx, desired)
+		} else {
+			err = r.delete(ctx, desired)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sharedCacheMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      constants.SharedCacheName,
+		Namespace: constants.KServeNamespace,
+		Labels: map[string]string{
+			"app":                          constants.SharedCacheName,
+			"app.kubernetes.io/name":       constants.SharedCacheName,
+			"app.kubernetes.io/managed-by": constants.KServeName,
+		},
+	}
+}
+
+// createSharedCacheDeployment creates the deployment of the shared cache, with a single replica as the entries are
+// held in memory and the replicas would not share them. The shared cache runs as the kserve-shared-cache service
+// account, which is allowed to review the tokens of the clients.
+func createSharedCacheDeployment(config *v1beta1.SharedCacheConfig) *appsv1.Deployment {
+	objectMeta := sharedCacheMeta()
+	args := []string{
+		"--port", strconv.Itoa(int(constants.SharedCachePort)),
+		"--default-tenant-quota", strconv.FormatInt(config.DefaultTenantQuotaBytes, 10),
+		"--max-tenants", strconv.Itoa(config.MaxTenants),
+	}
+	if len(config.TenantQuotasBytes) > 0 {
+		quotas := make([]string, 0, len(config.TenantQuotasBytes))
+		for tenant, quota := range config.TenantQuotasBytes {
+			quotas = append(quotas, tenant+"="+strconv.FormatInt(quota, 10))
+		}
+		slices.Sort(quotas)
+		args = append(args, "--tenant-quotas", strings.Join(quotas, ","))
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: objectMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": constants.SharedCacheName},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: objectMeta.Labels,
+					Annotations: map[string]string{
+						constants.PrometheusPortAnnotationKey: strconv.Itoa(int(constants.SharedCachePort)),
+						constants.PrometheusPathAnnotationKey: sharedcache.MetricsPath,
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: constants.SharedCacheName,
+					Containers: []corev1.Container{{
+						Name:  constants.SharedCacheContainerName,
+						Image: config.Image,
+						Args:  args,
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: constants.SharedCachePort,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(constants.SharedCachePort)},
+							},
+						},
+						Resources: createSharedCacheResources(config),
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsNonRoot:             ptr.To(true),
+							ReadOnlyRootFilesystem:   ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func createSharedCacheResources(config *v1beta1.SharedCacheConfig) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+	for _, quantity := range []struct {
+		list  *corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+	}{
+		{&resources.Requests, corev1.ResourceCPU, config.CPURequest},
+		{&resources.Requests, corev1.ResourceMemory, config.MemoryRequest},
+		{&resources.Limits, corev1.ResourceCPU, config.CPULimit},
+		{&resources.Limits, corev1.ResourceMemory, config.MemoryLimit},
+	} {
+		if quantity.value == "" {
+			continue
+		}
+		if *quantity.list == nil {
+			*quantity.list = corev1.ResourceList{}
+		}
+		(*quantity.list)[quantity.name] = resource.MustParse(quantity.value)
+	}
+	return resources
+}
+
+func createSharedCacheService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: sharedCacheMeta(),
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": constants.SharedCacheName},
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       constants.SharedCachePort,
+				TargetPort: intstr.FromInt32(constants.SharedCachePort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+func (r *SharedCacheReconciler) apply(ctx context.Context, desired client.Object) error {
+	existing, ok := desired.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", desired)
+	}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierr.IsNotFound(err) {
+		sharedCacheLog.Info("Creating shared cache object", "kind", fmt.Sprintf("%T", desired), "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.Client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	switch desired := desired.(type) {
+	case *appsv1.Deployment:
+		existing := existing.(*appsv1.Deployment)
+		if equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+			return nil
+		}
+		existing.Spec = desired.Spec
+	case *corev1.Service:
+		existing := existing.(*corev1.Service)
+		if equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) &&
+			equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+			return nil
+		}
+		existing.Spec.Ports = desired.Spec.Ports
+		existing.Spec.Selector = desired.Spec.Selector
+	}
+	sharedCacheLog.Info("Updating shared cache object", "kind", fmt.Sprintf("%T", desired), "namespace", existing.GetNamespace(), "name", existing.GetName())
+	return r.Client.Update(ctx, existing)
+}
+
+// delete deletes the object of the shared cache when it exists and is managed by KServe
+func (r *SharedCacheReconciler) delete(ctx context.Context, desired client.Object) error {
+	existing, ok := desired.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", desired)
+	}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.GetLabels()["app.kubernetes.io/managed-by"] != constants.KServeName {
+		return nil
+	}
+	sharedCacheLog.Info("Deleting shared cache object", "kind", fmt.Sprintf("%T", desired), "namespace", existing.GetNamespace(), "name", existing.GetName())
+	if err := r.Client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
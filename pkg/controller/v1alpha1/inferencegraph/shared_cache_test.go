@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestSharedCacheReconciler(t *testing.T) {
+	ctx := context.Background()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			v1beta1.SharedCacheConfigName: `{"enabled": true, "memoryLimit": "1Gi", "defaultTenantQuota": "1Mi", "tenantQuotas": {"team-b": "2Mi", "team-a": "1Ki"}}`,
+		},
+	}
+	clientset := fake.NewSimpleClientset(configMap)
+	c := ctrlfake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	r := &SharedCacheReconciler{Client: c, Clientset: clientset}
+	key := types.NamespacedName{Name: constants.SharedCacheName, Namespace: constants.KServeNamespace}
+
+	if err := r.Reconcile(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, key, deployment); err != nil {
+		t.Fatalf("the shared cache deployment is not created: %v", err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	expectedArgs := []string{"--port", "8080", "--default-tenant-quota", "1048576", "--max-tenants", "256", "--tenant-quotas", "team-a=1024,team-b=2097152"}
+	if diff := cmp.Diff(expectedArgs, container.Args); diff != "" {
+		t.Errorf("unexpected args (-want +got): %v", diff)
+	}
+	if deployment.Spec.Template.Spec.ServiceAccountName != constants.SharedCacheName {
+		t.Errorf("unexpected service account %q", deployment.Spec.Template.Spec.ServiceAccountName)
+	}
+	if container.Image != constants.DefaultSharedCacheImage {
+		t.Errorf("unexpected image %q", container.Image)
+	}
+	if limit := container.Resources.Limits.Memory().String(); limit != "1Gi" {
+		t.Errorf("unexpected memory limit %q", limit)
+	}
+	if err := c.Get(ctx, key, &corev1.Service{}); err != nil {
+		t.Fatalf("the shared cache service is not created: %v", err)
+	}
+
+	configMap.Data[v1beta1.SharedCacheConfigName] = `{"enabled": false}`
+	if _, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Reconcile(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, key, &appsv1.Deployment{}); !apierr.IsNotFound(err) {
+		t.Errorf("the shared cache deployment is not deleted: %v", err)
+	}
+	if err := c.Get(ctx, key, &corev1.Service{}); !apierr.IsNotFound(err) {
+		t.Errorf("the shared cache service is not deleted: %v", err)
+	}
+}
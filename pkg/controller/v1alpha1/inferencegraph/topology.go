@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"sort"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	topologyRouterIDPrefix           = "router/"
+	topologyInferenceServiceIDPrefix = "isvc/"
+	topologyURLIDPrefix              = "url/"
+)
+
+// inferenceServiceProtocol returns the protocol served by the predictor of the InferenceService
+func inferenceServiceProtocol(isvc *v1beta1.InferenceService) constants.InferenceServiceProtocol {
+	implementations := isvc.Spec.Predictor.GetImplementations()
+	if len(implementations) == 0 {
+		return ""
+	}
+	return implementations[0].GetProtocol()
+}
+
+// buildGraphTopology builds the topology of the graph from its router nodes and the targets of their steps. The
+// service urls of the steps targeting an InferenceService are expected to be resolved already, and protocols holds
+// the protocol of the InferenceServices keyed by name.
+func buildGraphTopology(graph *v1alpha1.InferenceGraph, protocols map[string]constants.InferenceServiceProtocol) *v1alpha1.InferenceGraphTopology {
+	nodeNames := make([]string, 0, len(graph.Spec.Nodes))
+	for name := range graph.Spec.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	nodes := map[string]v1alpha1.InferenceGraphTopologyNode{}
+	edges := []v1alpha1.InferenceGraphTopologyEdge{}
+	for _, name := range nodeNames {
+		router := graph.Spec.Nodes[name]
+		from := topologyRouterIDPrefix + name
+		nodes[from] = v1alpha1.InferenceGraphTopologyNode{
+			ID:         from,
+			Type:       v1alpha1.TopologyRouterNode,
+			Name:       name,
+			RouterType: router.RouterType,
+		}
+		for _, step := range router.Steps {
+			var target v1alpha1.InferenceGraphTopologyNode
+			switch {
+			case step.NodeName != "":
+				target = v1alpha1.InferenceGraphTopologyNode{
+					ID:   topologyRouterIDPrefix + step.NodeName,
+					Type: v1alpha1.TopologyRouterNode,
+					Name: step.NodeName,
+				}
+				if targetRouter, ok := graph.Spec.Nodes[step.NodeName]; ok {
+					target.RouterType = targetRouter.RouterType
+				}
+			case step.ServiceName != "":
+				target = v1alpha1.InferenceGraphTopologyNode{
+					ID:       topologyInferenceServiceIDPrefix + step.ServiceName,
+					Type:     v1alpha1.TopologyInferenceServiceNode,
+					Name:     step.ServiceName,
+					Protocol: protocols[step.ServiceName],
+					URL:      step.ServiceURL,
+				}
+			case step.ServiceURL != "":
+				target = v1alpha1.InferenceGraphTopologyNode{
+					ID:   topologyURLIDPrefix + step.ServiceURL,
+					Type: v1alpha1.TopologyURLNode,
+					URL:  step.ServiceURL,
+				}
+			default:
+				continue
+			}
+			if _, ok := nodes[target.ID]; !ok {
+				nodes[target.ID] = target
+			}
+			edges = append(edges, v1alpha1.InferenceGraphTopologyEdge{
+				From:       from,
+				To:         target.ID,
+				StepName:   step.StepName,
+				Weight:     step.Weight,
+				Condition:  step.Condition,
+				Data:       step.Data,
+				Dependency: step.Dependency,
+			})
+		}
+	}
+
+	topology := &v1alpha1.InferenceGraphTopology{
+		Nodes: make([]v1alpha1.InferenceGraphTopologyNode, 0, len(nodes)),
+		Edges: edges,
+	}
+	for _, node := range nodes {
+		topology.Nodes = append(topology.Nodes, node)
+	}
+	sort.Slice(topology.Nodes, func(i, j int) bool {
+		return topology.Nodes[i].ID < topology.Nodes[j].ID
+	})
+	return topology
+}
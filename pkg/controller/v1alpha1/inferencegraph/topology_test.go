@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferencegraph
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestBuildGraphTopology(t *testing.T) {
+	graph := &InferenceGraph{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dog-breed",
+			Namespace: "default",
+		},
+		Spec: InferenceGraphSpec{
+			Nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName:        "classify",
+							InferenceTarget: InferenceTarget{ServiceName: "cat-dog", ServiceURL: "http://cat-dog.default.svc.cluster.local"},
+						},
+						{
+							InferenceTarget: InferenceTarget{NodeName: "breed"},
+							Data:            "$request",
+							Dependency:      Hard,
+						},
+					},
+				},
+				"breed": {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							InferenceTarget: InferenceTarget{ServiceURL: "http://dog-breed.example.com"},
+							Weight:          ptr.To(int64(80)),
+						},
+						{
+							InferenceTarget: InferenceTarget{ServiceName: "dog-breed-v2"},
+							Weight:          ptr.To(int64(20)),
+						},
+					},
+				},
+			},
+		},
+	}
+	protocols := map[string]constants.InferenceServiceProtocol{
+		"cat-dog": constants.ProtocolV2,
+	}
+
+	expected := &InferenceGraphTopology{
+		Nodes: []InferenceGraphTopologyNode{
+			{ID: "isvc/cat-dog", Type: TopologyInferenceServiceNode, Name: "cat-dog", Protocol: constants.ProtocolV2, URL: "http://cat-dog.default.svc.cluster.local"},
+			{ID: "isvc/dog-breed-v2", Type: TopologyInferenceServiceNode, Name: "dog-breed-v2"},
+			{ID: "router/breed", Type: TopologyRouterNode, Name: "breed", RouterType: Splitter},
+			{ID: "router/root", Type: TopologyRouterNode, Name: GraphRootNodeName, RouterType: Sequence},
+			{ID: "url/http://dog-breed.example.com", Type: TopologyURLNode, URL: "http://dog-breed.example.com"},
+		},
+		Edges: []InferenceGraphTopologyEdge{
+			{From: "router/breed", To: "url/http://dog-breed.example.com", Weight: ptr.To(int64(80))},
+			{From: "router/breed", To: "isvc/dog-breed-v2", Weight: ptr.To(int64(20))},
+			{From: "router/root", To: "isvc/cat-dog", StepName: "classify"},
+			{From: "router/root", To: "router/breed", Data: "$request", Dependency: Hard},
+		},
+	}
+	topology := buildGraphTopology(graph, protocols)
+	if diff := cmp.Diff(expected, topology); diff != "" {
+		t.Errorf("unexpected topology (-want +got):\n%s", diff)
+	}
+}
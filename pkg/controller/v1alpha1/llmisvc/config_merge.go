@@ -21,6 +21,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"text/template"
 
 	corev1 "k8s.io/api/core/v1"
@@ -206,6 +208,21 @@ func (r *LLMISVCReconciler) combineBaseRefsConfig(ctx context.Context, llmSvc *v
 		return llmSvcCfg, err
 	}
 
+	podSpecs := []*corev1.PodSpec{llmSvcCfg.Spec.Template, llmSvcCfg.Spec.Worker}
+	if llmSvcCfg.Spec.Prefill != nil {
+		podSpecs = append(podSpecs, llmSvcCfg.Spec.Prefill.Template, llmSvcCfg.Spec.Prefill.Worker)
+	}
+	for _, podSpec := range podSpecs {
+		// Serve the model under its aliases as well, the model server accepts multiple served model names.
+		if len(llmSvcCfg.Spec.Model.Aliases) > 0 {
+			AddServedModelAliases(podSpec, llmSvcCfg.Spec.Model.Aliases)
+		}
+		// The LoRA adapters are loaded at runtime, so that changing them does not restart the workload.
+		if llmSvcCfg.Spec.Model.LoRA != nil {
+			EnableRuntimeLoRA(podSpec)
+		}
+	}
+
 	// Point HTTPRoute to a Service if there is no Scheduler or InferencePool, and the HTTPRoute uses the default
 	// InferencePool (to handle cases where the HTTPRoute Spec uses a custom BackendRef).
 	if llmSvcCfg.Spec.Router != nil &&
@@ -241,6 +258,33 @@ func (r *LLMISVCReconciler) combineBaseRefsConfig(ctx context.Context, llmSvc *v
 	return llmSvcCfg, nil
 }
 
+// AddServedModelAliases appends the aliases to the names following the "--served-model-name" argument of the
+// containers. The scripts of the multi-node presets pass the aliases in their template instead.
+func AddServedModelAliases(podSpec *corev1.PodSpec, aliases []string) {
+	if podSpec == nil {
+		return
+	}
+	for i := range podSpec.Containers {
+		args := podSpec.Containers[i].Args
+		idx := slices.Index(args, "--served-model-name")
+		if idx < 0 || idx+1 >= len(args) {
+			continue
+		}
+		// The names end with the next flag
+		end := idx + 1
+		for end < len(args) && !strings.HasPrefix(args[end], "-") {
+			end++
+		}
+		var missing []string
+		for _, alias := range aliases {
+			if !slices.Contains(args[idx+1:end], alias) && !slices.Contains(missing, alias) {
+				missing = append(missing, alias)
+			}
+		}
+		podSpec.Containers[i].Args = slices.Insert(args, end, missing...)
+	}
+}
+
 // ReplaceVariables processes the configuration as a Go template to substitute
 // variables with values from the LLM service and global configuration
 func ReplaceVariables(llmSvc *v1alpha1.LLMInferenceService, llmSvcCfg *v1alpha1.LLMInferenceServiceConfig, reconcilerConfig *Config) (*v1alpha1.LLMInferenceServiceConfig, error) {
@@ -1790,3 +1790,40 @@ spec:
 		})
 	}
 }
+
+func TestAddServedModelAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		aliases []string
+		want    []string
+	}{
+		{
+			name:    "aliases are appended to the served model name",
+			args:    []string{"--served-model-name", "facebook/opt-125m", "--port", "8000"},
+			aliases: []string{"gpt-4o-mini", "gpt-4o"},
+			want:    []string{"--served-model-name", "facebook/opt-125m", "gpt-4o-mini", "gpt-4o", "--port", "8000"},
+		},
+		{
+			name:    "aliases already served are not repeated",
+			args:    []string{"--served-model-name", "facebook/opt-125m", "gpt-4o-mini"},
+			aliases: []string{"gpt-4o-mini", "gpt-4o", "gpt-4o"},
+			want:    []string{"--served-model-name", "facebook/opt-125m", "gpt-4o-mini", "gpt-4o"},
+		},
+		{
+			name:    "containers without a served model name are not changed",
+			args:    []string{"--port", "8000"},
+			aliases: []string{"gpt-4o-mini"},
+			want:    []string{"--port", "8000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Args: tt.args}}}
+			llmisvc.AddServedModelAliases(podSpec, tt.aliases)
+			g.Expect(podSpec.Containers[0].Args).To(Equal(tt.want))
+		})
+	}
+}
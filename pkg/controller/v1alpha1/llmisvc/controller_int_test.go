@@ -36,6 +36,7 @@ import (
 	"github.com/onsi/gomega"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -237,6 +238,97 @@ var _ = Describe("LLMInferenceService Controller", func() {
 			Expect(expectedDeployment.Spec.Template.Labels).ToNot(HaveKeyWithValue(testValue, testValue))
 			Expect(expectedDeployment.Spec.Template.Annotations).ToNot(HaveKeyWithValue(testValue, testValue))
 		})
+
+		It("should autoscale the decode and prefill workloads independently", func(ctx SpecContext) {
+			// given
+			svcName := "test-llm-autoscaling"
+			nsName := kmeta.ChildName(svcName, "-test")
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nsName,
+				},
+			}
+
+			Expect(envTest.Client.Create(ctx, namespace)).To(Succeed())
+			Expect(envTest.Client.Create(ctx, IstioShadowService(svcName, nsName))).To(Succeed())
+			defer func() {
+				envTest.DeleteAll(namespace)
+			}()
+
+			llmSvc := LLMInferenceService(svcName,
+				InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				WithModelURI("hf://facebook/opt-125m"),
+				WithManagedRoute(),
+				WithManagedGateway(),
+				WithManagedScheduler(),
+				WithReplicas(3),
+				WithAutoscaling(&v1alpha1.AutoscalingSpec{
+					MinReplicas: ptr.To[int32](2),
+					MaxReplicas: 8,
+					Metric:      "vllm:num_requests_waiting",
+					Target:      ptr.To(resource.MustParse("5")),
+				}),
+				WithPrefill(SimpleWorkerPodSpec()),
+				WithPrefillAutoscaling(&v1alpha1.AutoscalingSpec{
+					MaxReplicas: 4,
+				}),
+			)
+
+			// when
+			Expect(envTest.Create(ctx, llmSvc)).To(Succeed())
+			defer func() {
+				Expect(envTest.Delete(ctx, llmSvc)).To(Succeed())
+			}()
+
+			// then
+			decodeHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func(g Gomega, ctx context.Context) error {
+				return envTest.Get(ctx, types.NamespacedName{
+					Name:      svcName + "-kserve-hpa",
+					Namespace: nsName,
+				}, decodeHPA)
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(decodeHPA).To(BeOwnedBy(llmSvc))
+			Expect(decodeHPA.Spec.ScaleTargetRef.Kind).To(Equal("Deployment"))
+			Expect(decodeHPA.Spec.ScaleTargetRef.Name).To(Equal(svcName + "-kserve"))
+			Expect(decodeHPA.Spec.MinReplicas).To(Equal(ptr.To[int32](2)))
+			Expect(decodeHPA.Spec.MaxReplicas).To(Equal(int32(8)))
+			Expect(decodeHPA.Spec.Metrics).To(HaveLen(1))
+			Expect(decodeHPA.Spec.Metrics[0].Pods.Metric.Name).To(Equal("vllm:num_requests_waiting"))
+
+			prefillHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func(g Gomega, ctx context.Context) error {
+				return envTest.Get(ctx, types.NamespacedName{
+					Name:      svcName + "-kserve-prefill-hpa",
+					Namespace: nsName,
+				}, prefillHPA)
+			}).WithContext(ctx).Should(Succeed())
+
+			Expect(prefillHPA).To(BeOwnedBy(llmSvc))
+			Expect(prefillHPA.Spec.ScaleTargetRef.Name).To(Equal(svcName + "-kserve-prefill"))
+			Expect(prefillHPA.Spec.MinReplicas).To(Equal(ptr.To[int32](1)))
+			Expect(prefillHPA.Spec.MaxReplicas).To(Equal(int32(4)))
+			Expect(prefillHPA.Spec.Metrics[0].Resource.Name).To(Equal(corev1.ResourceCPU))
+
+			decodeDeployment := &appsv1.Deployment{}
+			Eventually(func(g Gomega, ctx context.Context) error {
+				return envTest.Get(ctx, types.NamespacedName{
+					Name:      svcName + "-kserve",
+					Namespace: nsName,
+				}, decodeDeployment)
+			}).WithContext(ctx).Should(Succeed())
+			Expect(decodeDeployment.Spec.Replicas).To(Equal(ptr.To[int32](3)))
+
+			By("keeping the replicas set by the autoscaler")
+			decodeDeployment.Spec.Replicas = ptr.To[int32](6)
+			Expect(envTest.Client.Update(ctx, decodeDeployment)).To(Succeed())
+			Consistently(func(g Gomega, ctx context.Context) {
+				current := &appsv1.Deployment{}
+				g.Expect(envTest.Get(ctx, client.ObjectKeyFromObject(decodeDeployment), current)).To(Succeed())
+				g.Expect(current.Spec.Replicas).To(Equal(ptr.To[int32](6)))
+			}).WithContext(ctx).WithTimeout(2 * time.Second).Should(Succeed())
+		})
 	})
 
 	Context("Routing reconciliation ", func() {
@@ -434,6 +526,65 @@ var _ = Describe("LLMInferenceService Controller", func() {
 					Should(HaveOccurred())
 			})
 
+			It("should create the OpenAI route matching the model name and aliases", func(ctx SpecContext) {
+				// given
+				llmSvcName := "test-llm-create-openai-route"
+				nsName := kmeta.ChildName(llmSvcName, "-test")
+				namespace := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nsName,
+					},
+				}
+				Expect(envTest.Client.Create(ctx, namespace)).To(Succeed())
+				Expect(envTest.Client.Create(ctx, IstioShadowService(llmSvcName, nsName))).To(Succeed())
+				defer func() {
+					envTest.DeleteAll(namespace)
+				}()
+
+				llmSvc := LLMInferenceService(llmSvcName,
+					InNamespace[*v1alpha1.LLMInferenceService](nsName),
+					WithModelURI("hf://facebook/opt-125m"),
+					WithModelName("facebook/opt-125m"),
+					WithModelAliases("gpt-4o-mini"),
+					WithOpenAIRoute(v1alpha1.OpenAIChatCompletionsEndpoint),
+					WithManagedGateway(),
+				)
+
+				// when
+				Expect(envTest.Create(ctx, llmSvc)).To(Succeed())
+				defer func() {
+					Expect(envTest.Delete(ctx, llmSvc)).To(Succeed())
+				}()
+
+				// then
+				openAIRoute := &gwapiv1.HTTPRoute{}
+				Eventually(func(g Gomega, ctx context.Context) error {
+					return envTest.Client.Get(ctx, client.ObjectKey{Name: kmeta.ChildName(llmSvcName, "-kserve-openai-route"), Namespace: nsName}, openAIRoute)
+				}).WithContext(ctx).Should(Succeed())
+
+				Expect(openAIRoute).To(BeControlledBy(llmSvc))
+				Expect(openAIRoute).To(HaveGatewayRefs(gwapiv1.ParentReference{Name: "kserve-ingress-gateway"}))
+				Expect(openAIRoute).To(HaveBackendRefs(BackendRefService(kmeta.ChildName(llmSvcName, "-kserve-workload-svc"))))
+				Expect(openAIRoute.Spec.Rules).To(HaveLen(1))
+				Expect(openAIRoute.Spec.Rules[0].Matches).To(HaveLen(2))
+				for i, modelName := range []string{"facebook/opt-125m", "gpt-4o-mini"} {
+					match := openAIRoute.Spec.Rules[0].Matches[i]
+					Expect(match.Path.Value).To(Equal(ptr.To("/v1/chat/completions")))
+					Expect(match.Headers).To(ConsistOf(gwapiv1.HTTPHeaderMatch{
+						Type:  ptr.To(gwapiv1.HeaderMatchExact),
+						Name:  llmisvc.ModelNameHeader,
+						Value: modelName,
+					}))
+				}
+
+				Eventually(func(g Gomega, ctx context.Context) error {
+					deployment := &appsv1.Deployment{}
+					g.Expect(envTest.Get(ctx, client.ObjectKey{Name: kmeta.ChildName(llmSvcName, "-kserve"), Namespace: nsName}, deployment)).To(Succeed())
+					g.Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElements("facebook/opt-125m", "gpt-4o-mini"))
+					return nil
+				}).WithContext(ctx).Should(Succeed())
+			})
+
 			It("should create HTTPRoute with defined spec", func(ctx SpecContext) {
 				// given
 				svcName := "test-llm-defined-http-route"
@@ -60,6 +60,12 @@ func WithModelName(name string) LLMInferenceServiceOption {
 	}
 }
 
+func WithModelAliases(aliases ...string) LLMInferenceServiceOption {
+	return func(llmSvc *v1alpha1.LLMInferenceService) {
+		llmSvc.Spec.Model.Aliases = aliases
+	}
+}
+
 func WithGatewayRefs(refs ...v1alpha1.UntypedObjectReference) LLMInferenceServiceOption {
 	return func(llmSvc *v1alpha1.LLMInferenceService) {
 		if llmSvc.Spec.Router == nil {
@@ -124,6 +130,13 @@ func WithManagedRoute() LLMInferenceServiceOption {
 	}
 }
 
+func WithOpenAIRoute(endpoints ...v1alpha1.OpenAIEndpoint) LLMInferenceServiceOption {
+	return func(llmSvc *v1alpha1.LLMInferenceService) {
+		WithManagedRoute()(llmSvc)
+		llmSvc.Spec.Router.Route.OpenAI = &v1alpha1.OpenAIRouteSpec{Endpoints: endpoints}
+	}
+}
+
 func WithAnnotations(annotationsToAdd map[string]string) LLMInferenceServiceOption {
 	return func(llmSvc *v1alpha1.LLMInferenceService) {
 		if llmSvc.Annotations == nil {
@@ -185,6 +198,21 @@ func WithPrefillReplicas(replicas int32) LLMInferenceServiceOption {
 	}
 }
 
+func WithAutoscaling(autoscaling *v1alpha1.AutoscalingSpec) LLMInferenceServiceOption {
+	return func(llmSvc *v1alpha1.LLMInferenceService) {
+		llmSvc.Spec.Autoscaling = autoscaling
+	}
+}
+
+func WithPrefillAutoscaling(autoscaling *v1alpha1.AutoscalingSpec) LLMInferenceServiceOption {
+	return func(llmSvc *v1alpha1.LLMInferenceService) {
+		if llmSvc.Spec.Prefill == nil {
+			llmSvc.Spec.Prefill = &v1alpha1.WorkloadSpec{}
+		}
+		llmSvc.Spec.Prefill.Autoscaling = autoscaling
+	}
+}
+
 func WithTemplate(podSpec *corev1.PodSpec) LLMInferenceServiceOption {
 	return func(llmSvc *v1alpha1.LLMInferenceService) {
 		llmSvc.Spec.Template = podSpec
@@ -278,6 +306,18 @@ func WithInferencePoolRef(poolName string) LLMInferenceServiceOption {
 	}
 }
 
+func WithSchedulerScoring(scoring *v1alpha1.SchedulerScoringSpec) LLMInferenceServiceOption {
+	return func(llmSvc *v1alpha1.LLMInferenceService) {
+		if llmSvc.Spec.Router == nil {
+			llmSvc.Spec.Router = &v1alpha1.RouterSpec{}
+		}
+		if llmSvc.Spec.Router.Scheduler == nil {
+			llmSvc.Spec.Router.Scheduler = &v1alpha1.SchedulerSpec{}
+		}
+		llmSvc.Spec.Router.Scheduler.Scoring = scoring
+	}
+}
+
 func SimpleWorkerPodSpec() *corev1.PodSpec {
 	return &corev1.PodSpec{
 		Containers: []corev1.Container{
@@ -95,6 +95,12 @@ func (r *LLMISVCReconciler) reconcileHTTPRoutes(ctx context.Context, llmSvc *v1a
 
 	expectedHTTPRoute := r.expectedHTTPRoute(ctx, llmSvc)
 
+	// The OpenAI route is cleaned up on its own when it is not configured
+	openAIRoute, err := r.reconcileOpenAIHTTPRoute(ctx, llmSvc)
+	if err != nil {
+		return err
+	}
+
 	// Clean up if router or routes are not configured
 	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Route == nil {
 		return Delete(ctx, r, llmSvc, expectedHTTPRoute)
@@ -122,6 +128,10 @@ func (r *LLMISVCReconciler) reconcileHTTPRoutes(ctx context.Context, llmSvc *v1a
 		referencedRoutes = append(referencedRoutes, expectedHTTPRoute)
 	}
 
+	if openAIRoute != nil {
+		referencedRoutes = append(referencedRoutes, openAIRoute)
+	}
+
 	return r.updateRoutingStatus(ctx, llmSvc, referencedRoutes...)
 }
 
@@ -343,7 +353,7 @@ func (r *LLMISVCReconciler) EvaluateHTTPRouteConditions(ctx context.Context, llm
 	logger := log.FromContext(ctx).WithName("evaluateHTTPRouteConditions")
 
 	// If no router or route configuration, mark HTTPRoutes as ready (no routes to evaluate)
-	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Route == nil || llmSvc.Spec.Router.Route.HTTP == nil {
+	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Route == nil || (llmSvc.Spec.Router.Route.HTTP == nil && llmSvc.Spec.Router.Route.OpenAI == nil) {
 		logger.Info("No HTTPRoute configuration found, marking HTTPRoutesReady as True")
 		llmSvc.MarkHTTPRoutesReady()
 		return nil
@@ -380,6 +390,17 @@ func (r *LLMISVCReconciler) EvaluateHTTPRouteConditions(ctx context.Context, llm
 		}
 	}
 
+	// Get managed OpenAI route if it exists
+	if llmSvc.Spec.Router.Route.OpenAI != nil {
+		openAIRoute := &gwapiv1.HTTPRoute{}
+		if err := r.Client.Get(ctx, types.NamespacedName{
+			Namespace: llmSvc.GetNamespace(),
+			Name:      kmeta.ChildName(llmSvc.GetName(), "-kserve-openai-route"),
+		}, openAIRoute); err == nil {
+			allRoutes = append(allRoutes, openAIRoute)
+		}
+	}
+
 	// If no routes found, mark as ready (nothing to evaluate)
 	if len(allRoutes) == 0 {
 		llmSvc.MarkHTTPRoutesReady()
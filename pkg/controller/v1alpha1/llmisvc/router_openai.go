@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/kmeta"
+	igwapi "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// ModelNameHeader is the header the body-based routing extension of the Inference Gateway sets from the "model"
+// parameter of the OpenAI requests.
+const ModelNameHeader = "X-Gateway-Model-Name"
+
+// DefaultOpenAIEndpoints are the endpoints exposed when the OpenAI route does not list any.
+var DefaultOpenAIEndpoints = []v1alpha1.OpenAIEndpoint{
+	v1alpha1.OpenAIChatCompletionsEndpoint,
+	v1alpha1.OpenAICompletionsEndpoint,
+	v1alpha1.OpenAIEmbeddingsEndpoint,
+}
+
+// ServedModelNames returns the name of the model followed by its aliases and the names of its LoRA adapters, which
+// are all served by the model server.
+func ServedModelNames(llmSvc *v1alpha1.LLMInferenceService) []string {
+	names := append([]string{ptr.Deref(llmSvc.Spec.Model.Name, llmSvc.GetName())}, llmSvc.Spec.Model.Aliases...)
+	if llmSvc.Spec.Model.LoRA != nil {
+		for _, adapter := range llmSvc.Spec.Model.LoRA.Adapters {
+			names = append(names, ptr.Deref(adapter.Name, ""))
+		}
+	}
+	return names
+}
+
+// reconcileOpenAIHTTPRoute manages the HTTPRoute exposing the OpenAI-compatible endpoints at the root of the gateway.
+func (r *LLMISVCReconciler) reconcileOpenAIHTTPRoute(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService) (*gwapiv1.HTTPRoute, error) {
+	expected, err := r.expectedOpenAIHTTPRoute(ctx, llmSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Route == nil || llmSvc.Spec.Router.Route.OpenAI == nil {
+		return nil, Delete(ctx, r, llmSvc, expected)
+	}
+
+	if err := Reconcile(ctx, r, llmSvc, &gwapiv1.HTTPRoute{}, expected, semanticHTTPRouteIsEqual); err != nil {
+		return nil, fmt.Errorf("failed to reconcile OpenAI HTTPRoute %s/%s: %w", expected.GetNamespace(), expected.GetName(), err)
+	}
+	return expected, nil
+}
+
+// expectedOpenAIHTTPRoute creates the HTTPRoute matching the OpenAI requests for the model, or any of its aliases and
+// LoRA adapters, on the OpenAI API paths. It is attached to the same gateways as the route of the service.
+func (r *LLMISVCReconciler) expectedOpenAIHTTPRoute(_ context.Context, llmSvc *v1alpha1.LLMInferenceService) (*gwapiv1.HTTPRoute, error) {
+	httpRoute := &gwapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kmeta.ChildName(llmSvc.GetName(), "-kserve-openai-route"),
+			Namespace: llmSvc.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(llmSvc, v1alpha1.LLMInferenceServiceGVK),
+			},
+			Labels: RouterLabels(llmSvc),
+		},
+	}
+
+	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Route == nil || llmSvc.Spec.Router.Route.OpenAI == nil {
+		return httpRoute, nil
+	}
+	router := llmSvc.Spec.Router
+
+	switch {
+	case router.Gateway.HasRefs():
+		for _, ref := range router.Gateway.Refs {
+			httpRoute.Spec.ParentRefs = append(httpRoute.Spec.ParentRefs, toGatewayRef(ref))
+		}
+	case router.Route.HTTP.HasSpec():
+		httpRoute.Spec.ParentRefs = append(httpRoute.Spec.ParentRefs, router.Route.HTTP.Spec.ParentRefs...)
+	}
+	if len(httpRoute.Spec.ParentRefs) == 0 {
+		return nil, errors.New("the OpenAI route requires the gateway refs or the parent refs of the managed route")
+	}
+
+	backendRef := gwapiv1.HTTPBackendRef{
+		BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: gwapiv1.BackendObjectReference{
+				Group: ptr.To[gwapiv1.Group](""),
+				Kind:  ptr.To[gwapiv1.Kind]("Service"),
+				Name:  gwapiv1.ObjectName(kmeta.ChildName(llmSvc.GetName(), "-kserve-workload-svc")),
+				Port:  ptr.To[gwapiv1.PortNumber](8000),
+			},
+			Weight: ptr.To[int32](1),
+		},
+	}
+	if router.Scheduler != nil {
+		backendRef.Group = ptr.To[gwapiv1.Group](igwapi.GroupName)
+		backendRef.Kind = ptr.To[gwapiv1.Kind]("InferencePool")
+		backendRef.Name = gwapiv1.ObjectName(router.Scheduler.InferencePoolName(llmSvc))
+	}
+
+	modelNames := ServedModelNames(llmSvc)
+
+	endpoints := router.Route.OpenAI.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = DefaultOpenAIEndpoints
+	}
+	for _, endpoint := range endpoints {
+		rule := gwapiv1.HTTPRouteRule{
+			BackendRefs: []gwapiv1.HTTPBackendRef{backendRef},
+			Timeouts: &gwapiv1.HTTPRouteTimeouts{
+				BackendRequest: ptr.To[gwapiv1.Duration]("0s"),
+				Request:        ptr.To[gwapiv1.Duration]("0s"),
+			},
+		}
+		for _, modelName := range modelNames {
+			rule.Matches = append(rule.Matches, gwapiv1.HTTPRouteMatch{
+				Path: &gwapiv1.HTTPPathMatch{
+					Type:  ptr.To(gwapiv1.PathMatchExact),
+					Value: ptr.To("/v1/" + string(endpoint)),
+				},
+				Headers: []gwapiv1.HTTPHeaderMatch{{
+					Type:  ptr.To(gwapiv1.HeaderMatchExact),
+					Name:  ModelNameHeader,
+					Value: modelName,
+				}},
+			})
+		}
+		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, rule)
+	}
+
+	return httpRoute, nil
+}
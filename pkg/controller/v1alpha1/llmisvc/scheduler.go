@@ -18,10 +18,12 @@ package llmisvc
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"maps"
 	"slices"
 	"sort"
+	"strings"
 
 	"k8s.io/utils/ptr"
 
@@ -31,9 +33,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	igwapi "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 
@@ -175,17 +179,64 @@ func (r *LLMISVCReconciler) reconcileSchedulerService(ctx context.Context, llmSv
 
 func (r *LLMISVCReconciler) reconcileSchedulerInferenceModel(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService) error {
 	expected := r.expectedSchedulerInferenceModel(ctx, llmSvc)
-	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Scheduler == nil || llmSvc.Spec.Router.Scheduler.Template == nil || llmSvc.Spec.Router.Scheduler.Pool.HasRef() {
-		return Delete(ctx, r, llmSvc, expected)
+	enabled := llmSvc.Spec.Router != nil && llmSvc.Spec.Router.Scheduler != nil && llmSvc.Spec.Router.Scheduler.Template != nil && !llmSvc.Spec.Router.Scheduler.Pool.HasRef()
+	if !enabled {
+		if err := Delete(ctx, r, llmSvc, expected); err != nil {
+			return err
+		}
+	} else if err := Reconcile(ctx, r, llmSvc, &igwapi.InferenceModel{}, expected, semanticInferenceModelIsEqual); err != nil {
+		return err
 	}
 
-	if err := Reconcile(ctx, r, llmSvc, &igwapi.InferenceModel{}, expected, semanticInferenceModelIsEqual); err != nil {
-		return err
+	return r.reconcileSchedulerServedModelInferenceModels(ctx, llmSvc, enabled, expected)
+}
+
+// reconcileSchedulerServedModelInferenceModels manages the InferenceModels of the aliases and the LoRA adapters of
+// the model, so that the scheduler routes their requests to the pool as well. The ones of the names which are not
+// served anymore are deleted.
+func (r *LLMISVCReconciler) reconcileSchedulerServedModelInferenceModels(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService, enabled bool, base *igwapi.InferenceModel) error {
+	expected := make(map[string]*igwapi.InferenceModel)
+	if enabled {
+		for _, modelName := range ServedModelNames(llmSvc)[1:] {
+			im := base.DeepCopy()
+			im.Name = servedModelInferenceModelName(llmSvc, modelName)
+			im.Spec.ModelName = modelName
+			expected[im.Name] = im
+		}
 	}
 
+	current := &igwapi.InferenceModelList{}
+	if err := r.Client.List(ctx, current, client.InNamespace(llmSvc.GetNamespace()), client.MatchingLabels(SchedulerLabels(llmSvc))); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list InferenceModels: %w", err)
+	}
+	for i := range current.Items {
+		im := &current.Items[i]
+		if _, ok := expected[im.Name]; ok || im.Name == base.Name || !metav1.IsControlledBy(im, llmSvc) {
+			continue
+		}
+		if err := Delete(ctx, r, llmSvc, im); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		if err := Reconcile(ctx, r, llmSvc, &igwapi.InferenceModel{}, expected[name], semanticInferenceModelIsEqual); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// servedModelInferenceModelName returns the name of the InferenceModel of an additional served model name. The model
+// names are not necessarily valid resource names, so the InferenceModel is named after their hash.
+func servedModelInferenceModelName(llmSvc *v1alpha1.LLMInferenceService, modelName string) string {
+	hash := sha256.Sum256([]byte(modelName))
+	return kmeta.ChildName(llmSvc.GetName(), fmt.Sprintf("-inference-model-%x", hash[:5]))
+}
+
 func (r *LLMISVCReconciler) expectedSchedulerService(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService) *corev1.Service {
 	logger := log.FromContext(ctx)
 	svc := &corev1.Service{
@@ -336,7 +387,7 @@ func (r *LLMISVCReconciler) expectedSchedulerDeployment(ctx context.Context, llm
 
 			d.Spec.Template.Spec.Containers[i].Args = append(d.Spec.Template.Spec.Containers[i].Args,
 				"--configText",
-				schedulerConfigText(llmSvc),
+				SchedulerConfigText(llmSvc),
 			)
 		}
 	}
@@ -346,59 +397,77 @@ func (r *LLMISVCReconciler) expectedSchedulerDeployment(ctx context.Context, llm
 	return d
 }
 
-func schedulerConfigText(llmSvc *v1alpha1.LLMInferenceService) string {
-	switch {
-	case llmSvc.Spec.Prefill != nil:
-		return `
+// schedulerScorer is a scorer plugin of the Endpoint Picker with its weight in the scheduling profiles
+type schedulerScorer struct {
+	plugin string
+	weight int32
+}
+
+// schedulerScorers returns the scorers of the Endpoint Picker. Without a scoring configuration, the endpoints are
+// scored on the KV-cache locality of the prompt and on their load. Otherwise, the scorers of the KV-cache locality,
+// the queue depth and the KV-cache utilization are weighted as configured, and the ones weighted 0 are left out.
+func schedulerScorers(llmSvc *v1alpha1.LLMInferenceService) []schedulerScorer {
+	scoring := llmSvc.Spec.Router.Scheduler.Scoring
+	if scoring == nil {
+		return []schedulerScorer{
+			{plugin: "prefix-cache-scorer", weight: 2},
+			{plugin: "load-aware-scorer", weight: 1},
+		}
+	}
+	var scorers []schedulerScorer
+	for _, scorer := range []schedulerScorer{
+		{plugin: "prefix-cache-scorer", weight: ptr.Deref(scoring.PrefixCacheWeight, 2)},
+		{plugin: "queue-scorer", weight: ptr.Deref(scoring.QueueDepthWeight, 1)},
+		{plugin: "kv-cache-scorer", weight: ptr.Deref(scoring.KVCacheUtilizationWeight, 1)},
+	} {
+		if scorer.weight > 0 {
+			scorers = append(scorers, scorer)
+		}
+	}
+	return scorers
+}
+
+// SchedulerConfigText returns the configuration of the Endpoint Picker, which routes the requests with the scheduling
+// profiles of the decode and the prefill endpoints of a disaggregated deployment, or with a single profile.
+func SchedulerConfigText(llmSvc *v1alpha1.LLMInferenceService) string {
+	scorers := schedulerScorers(llmSvc)
+
+	var config strings.Builder
+	config.WriteString(`
 apiVersion: inference.networking.x-k8s.io/v1alpha1
 kind: EndpointPickerConfig
 plugins:
-- type: pd-profile-handler
+`)
+	// The profiles are the names of the scheduling profiles with the filter plugin selecting their endpoints
+	var profiles [][2]string
+	if llmSvc.Spec.Prefill != nil {
+		config.WriteString(`- type: pd-profile-handler
   parameters:
     threshold: 100
 - type: prefill-header-handler
 - type: prefill-filter
 - type: decode-filter
-- type: prefix-cache-scorer
-- type: load-aware-scorer
-- type: max-score-picker
-schedulingProfiles:
-- name: prefill
-  plugins:
-  - pluginRef: prefill-filter
-  - pluginRef: prefix-cache-scorer
-    weight: 2.0
-  - pluginRef: load-aware-scorer
-    weight: 1.0
-  - pluginRef: max-score-picker
-- name: decode
-  plugins:
-  - pluginRef: decode-filter
-  - pluginRef: prefix-cache-scorer
-    weight: 2.0
-  - pluginRef: load-aware-scorer
-    weight: 1.0
-  - pluginRef: max-score-picker
-`
-	default:
-		return `
-apiVersion: inference.networking.x-k8s.io/v1alpha1
-kind: EndpointPickerConfig
-plugins:
-- type: single-profile-handler
-- type: prefix-cache-scorer
-- type: load-aware-scorer
-- type: max-score-picker
-schedulingProfiles:
-- name: default
-  plugins:
-  - pluginRef: prefix-cache-scorer
-    weight: 2.0
-  - pluginRef: load-aware-scorer
-    weight: 1.0
-  - pluginRef: max-score-picker
-`
+`)
+		profiles = [][2]string{{"prefill", "prefill-filter"}, {"decode", "decode-filter"}}
+	} else {
+		config.WriteString("- type: single-profile-handler\n")
+		profiles = [][2]string{{"default", ""}}
+	}
+	for _, scorer := range scorers {
+		fmt.Fprintf(&config, "- type: %s\n", scorer.plugin)
+	}
+	config.WriteString("- type: max-score-picker\nschedulingProfiles:\n")
+	for _, profile := range profiles {
+		fmt.Fprintf(&config, "- name: %s\n  plugins:\n", profile[0])
+		if profile[1] != "" {
+			fmt.Fprintf(&config, "  - pluginRef: %s\n", profile[1])
+		}
+		for _, scorer := range scorers {
+			fmt.Fprintf(&config, "  - pluginRef: %s\n    weight: %d.0\n", scorer.plugin, scorer.weight)
+		}
+		config.WriteString("  - pluginRef: max-score-picker\n")
 	}
+	return config.String()
 }
 
 func (r *LLMISVCReconciler) expectedSchedulerServiceAccount(llmSvc *v1alpha1.LLMInferenceService) *corev1.ServiceAccount {
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc"
+	. "github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc/fixture"
+)
+
+func TestSchedulerConfigText(t *testing.T) {
+	type pluginRef struct {
+		PluginRef string   `json:"pluginRef"`
+		Weight    *float64 `json:"weight,omitempty"`
+	}
+	type endpointPickerConfig struct {
+		Plugins []struct {
+			Type string `json:"type"`
+		} `json:"plugins"`
+		SchedulingProfiles []struct {
+			Name    string      `json:"name"`
+			Plugins []pluginRef `json:"plugins"`
+		} `json:"schedulingProfiles"`
+	}
+
+	tests := map[string]struct {
+		llmSvc   *v1alpha1.LLMInferenceService
+		profiles map[string][]pluginRef
+	}{
+		"default scoring": {
+			llmSvc: LLMInferenceService("model", WithManagedScheduler()),
+			profiles: map[string][]pluginRef{
+				"default": {
+					{PluginRef: "prefix-cache-scorer", Weight: ptr.To(2.0)},
+					{PluginRef: "load-aware-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "max-score-picker"},
+				},
+			},
+		},
+		"kv-cache locality and queue depth": {
+			llmSvc: LLMInferenceService("model", WithManagedScheduler(), WithSchedulerScoring(&v1alpha1.SchedulerScoringSpec{
+				PrefixCacheWeight:        ptr.To[int32](3),
+				KVCacheUtilizationWeight: ptr.To[int32](0),
+			})),
+			profiles: map[string][]pluginRef{
+				"default": {
+					{PluginRef: "prefix-cache-scorer", Weight: ptr.To(3.0)},
+					{PluginRef: "queue-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "max-score-picker"},
+				},
+			},
+		},
+		"disaggregated prefill and decode": {
+			llmSvc: LLMInferenceService("model",
+				WithManagedScheduler(),
+				WithPrefill(SimpleWorkerPodSpec()),
+				WithSchedulerScoring(&v1alpha1.SchedulerScoringSpec{}),
+			),
+			profiles: map[string][]pluginRef{
+				"prefill": {
+					{PluginRef: "prefill-filter"},
+					{PluginRef: "prefix-cache-scorer", Weight: ptr.To(2.0)},
+					{PluginRef: "queue-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "kv-cache-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "max-score-picker"},
+				},
+				"decode": {
+					{PluginRef: "decode-filter"},
+					{PluginRef: "prefix-cache-scorer", Weight: ptr.To(2.0)},
+					{PluginRef: "queue-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "kv-cache-scorer", Weight: ptr.To(1.0)},
+					{PluginRef: "max-score-picker"},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			config := &endpointPickerConfig{}
+			g.Expect(yaml.Unmarshal([]byte(llmisvc.SchedulerConfigText(tt.llmSvc)), config)).To(Succeed())
+
+			declared := map[string]bool{}
+			for _, plugin := range config.Plugins {
+				declared[plugin.Type] = true
+			}
+			profiles := map[string][]pluginRef{}
+			for _, profile := range config.SchedulingProfiles {
+				profiles[profile.Name] = profile.Plugins
+				for _, plugin := range profile.Plugins {
+					g.Expect(declared).To(HaveKey(plugin.PluginRef))
+				}
+			}
+			g.Expect(profiles).To(Equal(tt.profiles))
+		})
+	}
+}
@@ -19,9 +19,11 @@ package validation
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/utils/ptr"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -31,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -41,6 +44,9 @@ import (
 // +kubebuilder:object:generate=false
 type LLMInferenceServiceValidator struct{}
 
+// maxOpenAIRouteModelNames is the maximum number of matches of an HTTPRoute rule
+const maxOpenAIRouteModelNames = 64
+
 var _ webhook.CustomValidator = &LLMInferenceServiceValidator{}
 
 func (l *LLMInferenceServiceValidator) SetupWithManager(mgr ctrl.Manager) error {
@@ -85,8 +91,11 @@ func (l *LLMInferenceServiceValidator) validate(ctx context.Context, prev *v1alp
 
 	var allErrs field.ErrorList
 
+	allErrs = append(allErrs, l.validateServedModelNames(llmSvc)...)
 	allErrs = append(allErrs, l.validateRouterCrossFieldConstraints(llmSvc)...)
 	allErrs = append(allErrs, l.validateParallelismConstraints(llmSvc)...)
+	allErrs = append(allErrs, l.validateAutoscaling(llmSvc)...)
+	allErrs = append(allErrs, l.validateSchedulerScoring(llmSvc)...)
 	allErrs = append(allErrs, l.validateImmutable(prev, llmSvc)...)
 
 	if len(allErrs) == 0 {
@@ -137,6 +146,19 @@ func (l *LLMInferenceServiceValidator) validateRouterCrossFieldConstraints(llmSv
 
 	var allErrs field.ErrorList
 
+	// The OpenAI route is attached to the gateways of the managed route, or to the custom gateway refs
+	if router.Route.OpenAI != nil && len(httpRoute.Refs) > 0 && !router.Gateway.HasRefs() {
+		openAIPath := routePath.Child("openai")
+		allErrs = append(allErrs, field.Invalid(
+			openAIPath,
+			router.Route.OpenAI,
+			fmt.Sprintf("unsupported configuration: the OpenAI route ('%s') cannot be used with custom HTTP routes ('%s') "+
+				"without custom gateway refs ('%s'); either remove '%s' or set '%s'",
+				openAIPath, httpRouteRefs, gwRefsPath, openAIPath, gwRefsPath,
+			),
+		))
+	}
+
 	// Both refs and spec cannot be used together
 	if len(httpRoute.Refs) > 0 && httpRoute.Spec != nil {
 		allErrs = append(allErrs, field.Invalid(
@@ -177,6 +199,47 @@ func (l *LLMInferenceServiceValidator) validateRouterCrossFieldConstraints(llmSv
 	return allErrs
 }
 
+// validateServedModelNames validates the aliases and the LoRA adapters of the model, which are served by the model
+// server along with the model name, so all the names must be distinct.
+func (l *LLMInferenceServiceValidator) validateServedModelNames(llmSvc *v1alpha1.LLMInferenceService) field.ErrorList {
+	modelPath := field.NewPath("spec").Child("model")
+	modelName := ptr.Deref(llmSvc.Spec.Model.Name, llmSvc.GetName())
+
+	var allErrs field.ErrorList
+	seen := map[string]struct{}{modelName: {}}
+	validateName := func(path *field.Path, name string) {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(path, "the served model name must not be empty"))
+			return
+		}
+		if _, ok := seen[name]; ok {
+			allErrs = append(allErrs, field.Duplicate(path, name))
+		}
+		seen[name] = struct{}{}
+	}
+
+	for i, alias := range llmSvc.Spec.Model.Aliases {
+		validateName(modelPath.Child("aliases").Index(i), alias)
+	}
+	if llmSvc.Spec.Model.LoRA != nil {
+		adaptersPath := modelPath.Child("lora", "adapters")
+		for i, adapter := range llmSvc.Spec.Model.LoRA.Adapters {
+			validateName(adaptersPath.Index(i).Child("name"), ptr.Deref(adapter.Name, ""))
+			if !strings.HasPrefix(adapter.URI.String(), constants.HfURIPrefix) {
+				allErrs = append(allErrs, field.Invalid(adaptersPath.Index(i).Child("uri"), adapter.URI.String(),
+					fmt.Sprintf("the LoRA adapters are loaded at runtime from %s URIs only", constants.HfURIPrefix)))
+			}
+		}
+	}
+
+	// Each name is matched by the rules of the OpenAI route, which have at most 64 matches
+	router := llmSvc.Spec.Router
+	if router != nil && router.Route != nil && router.Route.OpenAI != nil && len(seen) > maxOpenAIRouteModelNames {
+		allErrs = append(allErrs, field.TooMany(modelPath, len(seen), maxOpenAIRouteModelNames))
+	}
+	return allErrs
+}
+
 func (l *LLMInferenceServiceValidator) validateParallelismConstraints(llmSvc *v1alpha1.LLMInferenceService) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -269,6 +332,71 @@ func (l *LLMInferenceServiceValidator) validateWorkloadParallelism(basePath *fie
 	return allErrs
 }
 
+func (l *LLMInferenceServiceValidator) validateSchedulerScoring(llmSvc *v1alpha1.LLMInferenceService) field.ErrorList {
+	var allErrs field.ErrorList
+	if llmSvc.Spec.Router == nil || llmSvc.Spec.Router.Scheduler == nil || llmSvc.Spec.Router.Scheduler.Scoring == nil {
+		return allErrs
+	}
+
+	scoring := llmSvc.Spec.Router.Scheduler.Scoring
+	if ptr.Deref(scoring.PrefixCacheWeight, 2) == 0 && ptr.Deref(scoring.QueueDepthWeight, 1) == 0 && ptr.Deref(scoring.KVCacheUtilizationWeight, 1) == 0 {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec").Child("router", "scheduler", "scoring"),
+			scoring,
+			"at least one scorer must have a positive weight",
+		))
+	}
+
+	return allErrs
+}
+
+func (l *LLMInferenceServiceValidator) validateAutoscaling(llmSvc *v1alpha1.LLMInferenceService) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, l.validateWorkloadAutoscaling(field.NewPath("spec").Child("autoscaling"), llmSvc.Spec.Autoscaling)...)
+
+	if llmSvc.Spec.Prefill != nil {
+		allErrs = append(allErrs, l.validateWorkloadAutoscaling(field.NewPath("spec").Child("prefill", "autoscaling"), llmSvc.Spec.Prefill.Autoscaling)...)
+	}
+
+	return allErrs
+}
+
+func (l *LLMInferenceServiceValidator) validateWorkloadAutoscaling(path *field.Path, autoscaling *v1alpha1.AutoscalingSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if autoscaling == nil {
+		return allErrs
+	}
+
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("minReplicas"),
+			*autoscaling.MinReplicas,
+			"minReplicas must be less than or equal to maxReplicas",
+		))
+	}
+
+	switch autoscaling.Metric {
+	case "", string(corev1.ResourceCPU), string(corev1.ResourceMemory):
+		if autoscaling.Target != nil && (autoscaling.Target.Sign() <= 0 || autoscaling.Target.MilliValue()%1000 != 0) {
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("target"),
+				autoscaling.Target.String(),
+				"the target of the cpu and memory metrics must be a positive utilization percentage",
+			))
+		}
+	default:
+		if autoscaling.Target == nil || autoscaling.Target.Sign() <= 0 {
+			allErrs = append(allErrs, field.Required(
+				path.Child("target"),
+				fmt.Sprintf("a positive target is required for the metric %q", autoscaling.Metric),
+			))
+		}
+	}
+
+	return allErrs
+}
+
 func (l *LLMInferenceServiceValidator) validateImmutable(prev *v1alpha1.LLMInferenceService, curr *v1alpha1.LLMInferenceService) field.ErrorList {
 	var allErrs field.ErrorList
 	if prev == nil {
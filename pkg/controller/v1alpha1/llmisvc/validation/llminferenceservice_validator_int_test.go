@@ -21,6 +21,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -380,6 +381,105 @@ var _ = Describe("LLMInferenceService webhook validation", func() {
 			Expect(envTest.Client.Create(ctx, llmSvc)).To(Succeed())
 		})
 	})
+
+	Context("scheduler scoring validation", func() {
+		It("should reject LLMInferenceService with all the scorers disabled", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-scoring-disabled",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithSchedulerScoring(&v1alpha1.SchedulerScoringSpec{
+					PrefixCacheWeight:        ptr.To[int32](0),
+					QueueDepthWeight:         ptr.To[int32](0),
+					KVCacheUtilizationWeight: ptr.To[int32](0),
+				}),
+			)
+
+			// when
+			err := envTest.Client.Create(ctx, llmSvc)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least one scorer must have a positive weight"))
+		})
+
+		It("should accept LLMInferenceService routing on the KV-cache locality only", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-scoring-prefix-cache",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithSchedulerScoring(&v1alpha1.SchedulerScoringSpec{
+					QueueDepthWeight:         ptr.To[int32](0),
+					KVCacheUtilizationWeight: ptr.To[int32](0),
+				}),
+			)
+
+			// then
+			Expect(envTest.Client.Create(ctx, llmSvc)).To(Succeed())
+		})
+	})
+
+	Context("autoscaling validation", func() {
+		It("should reject LLMInferenceService with a custom metric but no target", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-autoscaling-no-target",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithAutoscaling(&v1alpha1.AutoscalingSpec{
+					MaxReplicas: 4,
+					Metric:      "vllm:num_requests_waiting",
+				}),
+			)
+
+			// when
+			err := envTest.Client.Create(ctx, llmSvc)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`a positive target is required for the metric "vllm:num_requests_waiting"`))
+		})
+
+		It("should reject LLMInferenceService with a fractional prefill cpu utilization target", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-autoscaling-prefill-target",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithPrefillAutoscaling(&v1alpha1.AutoscalingSpec{
+					MaxReplicas: 4,
+					Target:      ptr.To(resource.MustParse("500m")),
+				}),
+			)
+
+			// when
+			err := envTest.Client.Create(ctx, llmSvc)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.prefill.autoscaling.target"))
+		})
+
+		It("should accept LLMInferenceService with decode and prefill autoscaling", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-autoscaling-valid",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithAutoscaling(&v1alpha1.AutoscalingSpec{
+					MinReplicas: ptr.To[int32](2),
+					MaxReplicas: 8,
+					Metric:      "vllm:num_requests_waiting",
+					Target:      ptr.To(resource.MustParse("5")),
+				}),
+				fixture.WithPrefillAutoscaling(&v1alpha1.AutoscalingSpec{
+					MaxReplicas: 4,
+					Metric:      "memory",
+					Target:      ptr.To(resource.MustParse("70")),
+				}),
+			)
+
+			// then
+			Expect(envTest.Client.Create(ctx, llmSvc)).To(Succeed())
+		})
+	})
 })
 
 var _ = Describe("LLMInferenceService API validation", func() {
@@ -419,6 +519,25 @@ var _ = Describe("LLMInferenceService API validation", func() {
 			Expect(errValidation.Error()).To(ContainSubstring("spec.replicas in body should be greater than or equal to 0"))
 		})
 
+		It("should reject LLMInferenceService with autoscaling minReplicas greater than maxReplicas", func(ctx SpecContext) {
+			// given
+			llmSvc := fixture.LLMInferenceService("test-autoscaling-min-max",
+				fixture.InNamespace[*v1alpha1.LLMInferenceService](nsName),
+				fixture.WithModelURI("hf://facebook/opt-125m"),
+				fixture.WithAutoscaling(&v1alpha1.AutoscalingSpec{
+					MinReplicas: ptr.To[int32](4),
+					MaxReplicas: 2,
+				}),
+			)
+
+			// when
+			errValidation := envTest.Client.Create(ctx, llmSvc)
+
+			// then
+			Expect(errValidation).To(HaveOccurred(), "Expected the Create call to fail due to a validation error, but it succeeded")
+			Expect(errValidation.Error()).To(ContainSubstring("minReplicas must be less than or equal to maxReplicas"))
+		})
+
 		It("should reject LLMInferenceService with negative tensor parallelism", func(ctx SpecContext) {
 			// given
 			llmSvc := fixture.LLMInferenceService("test-negative-int-parallelism",
@@ -76,6 +76,12 @@ func (r *LLMISVCReconciler) reconcileWorkload(ctx context.Context, llmSvc *v1alp
 		return fmt.Errorf("failed to reconcile single node workload: %w", err)
 	}
 
+	// Scale the main and prefill workloads independently
+	if err := r.reconcileWorkloadAutoscalers(ctx, llmSvc); err != nil {
+		llmSvc.MarkMainWorkloadNotReady("ReconcileWorkloadAutoscalersError", err.Error())
+		return fmt.Errorf("failed to reconcile workload autoscalers: %w", err)
+	}
+
 	// Create Service to expose workload pods
 	if err := r.reconcileWorkloadService(ctx, llmSvc); err != nil {
 		llmSvc.MarkMainWorkloadNotReady("ReconcileWorkloadServiceError", err.Error())
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	lwsapi "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// reconcileWorkloadAutoscalers manages the HorizontalPodAutoscalers of the main and prefill workloads, which scale
+// the decode and prefill pools of a disaggregated deployment independently.
+func (r *LLMISVCReconciler) reconcileWorkloadAutoscalers(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService) error {
+	log.FromContext(ctx).Info("Reconciling workload autoscalers")
+
+	if err := r.reconcileWorkloadAutoscaler(ctx, llmSvc, &llmSvc.Spec.WorkloadSpec, "-kserve", "-kserve-mn"); err != nil {
+		return fmt.Errorf("failed to reconcile main workload autoscaler: %w", err)
+	}
+	if err := r.reconcileWorkloadAutoscaler(ctx, llmSvc, llmSvc.Spec.Prefill, "-kserve-prefill", "-kserve-mn-prefill"); err != nil {
+		return fmt.Errorf("failed to reconcile prefill workload autoscaler: %w", err)
+	}
+	return nil
+}
+
+func (r *LLMISVCReconciler) reconcileWorkloadAutoscaler(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService, workload *v1alpha1.WorkloadSpec, suffix string, multiNodeSuffix string) error {
+	expected := expectedWorkloadAutoscaler(llmSvc, workload, suffix, multiNodeSuffix)
+	if workload == nil || workload.Autoscaling == nil {
+		return Delete(ctx, r, llmSvc, expected)
+	}
+	return Reconcile(ctx, r, llmSvc, &autoscalingv2.HorizontalPodAutoscaler{}, expected, semanticHPAIsEqual)
+}
+
+// expectedWorkloadAutoscaler creates the HorizontalPodAutoscaler of the Deployment of a single-node workload, or of
+// the LeaderWorkerSet of a multi-node workload, which scales the groups of leader and worker pods.
+func expectedWorkloadAutoscaler(llmSvc *v1alpha1.LLMInferenceService, workload *v1alpha1.WorkloadSpec, suffix string, multiNodeSuffix string) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kmeta.ChildName(llmSvc.GetName(), suffix+"-hpa"),
+			Namespace: llmSvc.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(llmSvc, v1alpha1.LLMInferenceServiceGVK),
+			},
+			Labels: map[string]string{
+				"app.kubernetes.io/component": "llminferenceservice-workload",
+				"app.kubernetes.io/name":      llmSvc.GetName(),
+				"app.kubernetes.io/part-of":   "llminferenceservice",
+			},
+		},
+	}
+	if workload == nil || workload.Autoscaling == nil {
+		return hpa
+	}
+
+	hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       kmeta.ChildName(llmSvc.GetName(), suffix),
+		},
+		MinReplicas: ptr.To(ptr.Deref(workload.Autoscaling.MinReplicas, 1)),
+		MaxReplicas: workload.Autoscaling.MaxReplicas,
+		Metrics:     WorkloadAutoscalerMetrics(workload.Autoscaling),
+	}
+	if workload.Worker != nil {
+		hpa.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: lwsapi.GroupVersion.String(),
+			Kind:       "LeaderWorkerSet",
+			Name:       kmeta.ChildName(llmSvc.GetName(), multiNodeSuffix),
+		}
+	}
+	return hpa
+}
+
+// WorkloadAutoscalerMetrics returns the metric the autoscaling of a workload scales on, the utilization of the cpu
+// or memory requests of the pods, or the average value of a pods metric of the custom metrics API.
+func WorkloadAutoscalerMetrics(autoscaling *v1alpha1.AutoscalingSpec) []autoscalingv2.MetricSpec {
+	metric := autoscaling.Metric
+	if metric == "" {
+		metric = string(corev1.ResourceCPU)
+	}
+
+	if metric == string(corev1.ResourceCPU) || metric == string(corev1.ResourceMemory) {
+		utilization := constants.DefaultCPUUtilization
+		if autoscaling.Target != nil {
+			utilization = int32(autoscaling.Target.Value())
+		}
+		return []autoscalingv2.MetricSpec{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceName(metric),
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}}
+	}
+
+	target := autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType}
+	if autoscaling.Target != nil {
+		target.AverageValue = ptr.To(autoscaling.Target.DeepCopy())
+	}
+	return []autoscalingv2.MetricSpec{{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: metric},
+			Target: target,
+		},
+	}}
+}
+
+// workloadReplicas returns the replicas of the Deployment or LeaderWorkerSet of a workload. The current replicas are
+// kept when the workload is autoscaled so that the reconciler does not revert the scaling of the autoscaler.
+func (r *LLMISVCReconciler) workloadReplicas(ctx context.Context, workload *v1alpha1.WorkloadSpec, expected client.Object) (*int32, error) {
+	if workload.Autoscaling == nil {
+		return workload.Replicas, nil
+	}
+
+	var replicas *int32
+	curr := expected.DeepCopyObject().(client.Object)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(expected), curr); err == nil {
+		switch curr := curr.(type) {
+		case *appsv1.Deployment:
+			replicas = curr.Spec.Replicas
+		case *lwsapi.LeaderWorkerSet:
+			replicas = curr.Spec.Replicas
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get current %s %s/%s: %w", logLineForObject(expected), expected.GetNamespace(), expected.GetName(), err)
+	}
+	if replicas != nil {
+		return replicas, nil
+	}
+	// The workload is created with its initial replicas, within the bounds of the autoscaler
+	initial := max(ptr.Deref(workload.Replicas, 1), ptr.Deref(workload.Autoscaling.MinReplicas, 1))
+	return ptr.To(min(initial, workload.Autoscaling.MaxReplicas)), nil
+}
+
+func semanticHPAIsEqual(expected *autoscalingv2.HorizontalPodAutoscaler, curr *autoscalingv2.HorizontalPodAutoscaler) bool {
+	return equality.Semantic.DeepDerivative(expected.Spec, curr.Spec) &&
+		equality.Semantic.DeepDerivative(expected.Labels, curr.Labels) &&
+		equality.Semantic.DeepDerivative(expected.Annotations, curr.Annotations)
+}
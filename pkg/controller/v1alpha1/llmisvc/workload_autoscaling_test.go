@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc"
+)
+
+func TestWorkloadAutoscalerMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoscaling *v1alpha1.AutoscalingSpec
+		expected    []autoscalingv2.MetricSpec
+	}{
+		{
+			name:        "default cpu utilization",
+			autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 4},
+			expected: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: ptr.To[int32](80),
+					},
+				},
+			}},
+		},
+		{
+			name:        "memory utilization",
+			autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 4, Metric: "memory", Target: ptr.To(resource.MustParse("70"))},
+			expected: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: ptr.To[int32](70),
+					},
+				},
+			}},
+		},
+		{
+			name:        "pods metric",
+			autoscaling: &v1alpha1.AutoscalingSpec{MaxReplicas: 4, Metric: "vllm:num_requests_waiting", Target: ptr.To(resource.MustParse("5"))},
+			expected: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{Name: "vllm:num_requests_waiting"},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: ptr.To(resource.MustParse("5")),
+					},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(llmisvc.WorkloadAutoscalerMetrics(tt.autoscaling)).To(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	lwsapi "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// runtimeLoRAUpdatingEnvVar enables the admin API of vLLM loading and unloading the LoRA adapters at runtime.
+const runtimeLoRAUpdatingEnvVar = "VLLM_ALLOW_RUNTIME_LORA_UPDATING"
+
+// loraAdminClient calls the admin API of the model servers. Loading an adapter downloads its weights, so the
+// timeout is generous.
+var loraAdminClient = &http.Client{Timeout: 2 * time.Minute}
+
+// EnableRuntimeLoRA enables the LoRA adapters and their runtime loading on the "main" container. The scripts of the
+// multi-node presets pass the --enable-lora argument in their template instead.
+func EnableRuntimeLoRA(podSpec *corev1.PodSpec) {
+	if podSpec == nil {
+		return
+	}
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if container.Name != "main" {
+			continue
+		}
+		if slices.Contains(container.Args, "--served-model-name") && !slices.Contains(container.Args, "--enable-lora") {
+			container.Args = append(container.Args, "--enable-lora")
+		}
+		if !slices.ContainsFunc(container.Env, func(env corev1.EnvVar) bool { return env.Name == runtimeLoRAUpdatingEnvVar }) {
+			container.Env = append(container.Env, corev1.EnvVar{Name: runtimeLoRAUpdatingEnvVar, Value: "True"})
+		}
+	}
+}
+
+// reconcileLoRAAdapters loads the LoRA adapters on every ready replica of the model server, unloads the adapters
+// which are not configured anymore, and reports the readiness of each adapter in the status.
+func (r *LLMISVCReconciler) reconcileLoRAAdapters(ctx context.Context, llmSvc *v1alpha1.LLMInferenceService) error {
+	logger := log.FromContext(ctx).WithName("reconcileLoRAAdapters")
+
+	if llmSvc.Spec.Model.LoRA == nil || len(llmSvc.Spec.Model.LoRA.Adapters) == 0 {
+		llmSvc.ClearLoRAAdapters()
+		return nil
+	}
+	adapters := llmSvc.Spec.Model.LoRA.Adapters
+
+	pods, err := r.Clientset.CoreV1().Pods(llmSvc.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(GetWorkloadLabelSelector(llmSvc.ObjectMeta, &llmSvc.Spec)).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the model server pods: %w", err)
+	}
+	endpoints := ModelServerEndpoints(pods.Items)
+
+	loaded := make(map[string]int, len(adapters))
+	failures := make(map[string]string)
+	var errs []error
+	for _, endpoint := range endpoints {
+		admin := &ModelServerAdmin{Client: loraAdminClient, BaseURL: endpoint}
+		names, err := SyncLoRAAdapters(ctx, admin, adapters)
+		for _, name := range names {
+			loaded[name]++
+		}
+		if err != nil {
+			logger.Error(err, "Failed to sync the LoRA adapters", "endpoint", endpoint)
+			errs = append(errs, err)
+			syncErrs := []error{err}
+			var joined interface{ Unwrap() []error }
+			if errors.As(err, &joined) {
+				syncErrs = joined.Unwrap()
+			}
+			for _, syncErr := range syncErrs {
+				var adapterErr *LoRAAdapterError
+				if errors.As(syncErr, &adapterErr) {
+					failures[adapterErr.Name] = adapterErr.Error()
+				}
+			}
+		}
+	}
+
+	llmSvc.Status.Adapters = loraAdapterStatuses(llmSvc.Status.Adapters, adapters, len(endpoints), loaded, failures)
+
+	var notReady []string
+	for _, status := range llmSvc.Status.Adapters {
+		if cond := status.Conditions[0]; !cond.IsTrue() {
+			notReady = append(notReady, fmt.Sprintf("%s: %s", status.Name, cond.Message))
+		}
+	}
+	if len(notReady) > 0 {
+		llmSvc.MarkLoRAAdaptersNotReady("LoRAAdaptersNotReady", "The following LoRA adapters are not ready: %v", notReady)
+	} else {
+		llmSvc.MarkLoRAAdaptersReady()
+	}
+
+	return errors.Join(errs...)
+}
+
+// loraAdapterStatuses computes the status of the adapters, keeping the transition time of the unchanged conditions.
+func loraAdapterStatuses(previous []v1alpha1.LoRAAdapterStatus, adapters []v1alpha1.LLMModelSpec, replicas int, loaded map[string]int, failures map[string]string) []v1alpha1.LoRAAdapterStatus {
+	statuses := make([]v1alpha1.LoRAAdapterStatus, 0, len(adapters))
+	for _, adapter := range adapters {
+		name := ptr.Deref(adapter.Name, "")
+		cond := apis.Condition{Type: apis.ConditionReady, Status: corev1.ConditionTrue}
+		switch {
+		case failures[name] != "":
+			cond.Status, cond.Reason, cond.Message = corev1.ConditionFalse, "LoadFailed", failures[name]
+		case replicas == 0:
+			cond.Status, cond.Reason, cond.Message = corev1.ConditionUnknown, "ModelServerNotReady", "No replica of the model server is ready"
+		case loaded[name] < replicas:
+			cond.Status, cond.Reason, cond.Message = corev1.ConditionUnknown, "Loading",
+				fmt.Sprintf("Loaded by %d of the %d ready replicas of the model server", loaded[name], replicas)
+		}
+
+		cond.LastTransitionTime = apis.VolatileTime{Inner: metav1.Now()}
+		for _, prev := range previous {
+			if prev.Name == name && len(prev.Conditions) > 0 && prev.Conditions[0].Status == cond.Status {
+				cond.LastTransitionTime = prev.Conditions[0].LastTransitionTime
+			}
+		}
+
+		statuses = append(statuses, v1alpha1.LoRAAdapterStatus{
+			Name:       name,
+			URI:        adapter.URI.String(),
+			Conditions: []apis.Condition{cond},
+		})
+	}
+	return statuses
+}
+
+// ModelServerEndpoints returns the base URLs of the admin API of the ready model server pods. The workers of the
+// multi-node workloads do not serve the API, only their leader does.
+func ModelServerEndpoints(pods []corev1.Pod) []string {
+	var endpoints []string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" || !isPodReady(&pod) {
+			continue
+		}
+		if index, ok := pod.Labels[lwsapi.WorkerIndexLabelKey]; ok && index != "0" {
+			continue
+		}
+		port := int32(8000)
+		for _, container := range pod.Spec.Containers {
+			if container.Name == "main" && len(container.Ports) > 0 {
+				port = container.Ports[0].ContainerPort
+			}
+		}
+		endpoints = append(endpoints, "http://"+net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+	}
+	return endpoints
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// LoRAAdapterError is the failure to load or unload an adapter.
+type LoRAAdapterError struct {
+	Name string
+	Err  error
+}
+
+func (e *LoRAAdapterError) Error() string {
+	return fmt.Sprintf("LoRA adapter %q: %v", e.Name, e.Err)
+}
+
+func (e *LoRAAdapterError) Unwrap() error {
+	return e.Err
+}
+
+// SyncLoRAAdapters loads the missing adapters on a model server and unloads the ones which are not configured
+// anymore, or whose weights changed. It returns the names of the configured adapters which are loaded.
+func SyncLoRAAdapters(ctx context.Context, admin *ModelServerAdmin, adapters []v1alpha1.LLMModelSpec) ([]string, error) {
+	current, err := admin.LoadedAdapters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]string, len(adapters))
+	var errs []error
+	for _, adapter := range adapters {
+		name := ptr.Deref(adapter.Name, "")
+		path, err := loraAdapterPath(adapter.URI)
+		if err != nil {
+			errs = append(errs, &LoRAAdapterError{Name: name, Err: err})
+			continue
+		}
+		expected[name] = path
+	}
+
+	for name, path := range current {
+		if expectedPath, ok := expected[name]; ok && expectedPath == path {
+			continue
+		}
+		if err := admin.UnloadAdapter(ctx, name); err != nil {
+			errs = append(errs, &LoRAAdapterError{Name: name, Err: err})
+			continue
+		}
+		delete(current, name)
+	}
+
+	var loaded []string
+	for _, adapter := range adapters {
+		name := ptr.Deref(adapter.Name, "")
+		path, ok := expected[name]
+		if !ok {
+			continue
+		}
+		if _, ok := current[name]; !ok {
+			if err := admin.LoadAdapter(ctx, name, path); err != nil {
+				errs = append(errs, &LoRAAdapterError{Name: name, Err: err})
+				continue
+			}
+		}
+		loaded = append(loaded, name)
+	}
+
+	return loaded, errors.Join(errs...)
+}
+
+// loraAdapterPath returns the path of the adapter weights passed to the model server, which downloads the
+// Hugging Face repositories itself.
+func loraAdapterPath(uri apis.URL) (string, error) {
+	if !strings.HasPrefix(uri.String(), constants.HfURIPrefix) {
+		return "", fmt.Errorf("unsupported URI %q, the adapters are loaded from %s URIs", uri.String(), constants.HfURIPrefix)
+	}
+	return strings.TrimPrefix(uri.String(), constants.HfURIPrefix), nil
+}
+
+// ModelServerAdmin calls the admin API of a vLLM model server.
+type ModelServerAdmin struct {
+	Client  *http.Client
+	BaseURL string
+}
+
+// LoadedAdapters returns the paths of the LoRA adapters loaded by the model server, by name.
+func (a *ModelServerAdmin) LoadedAdapters(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.BaseURL+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := a.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the models of %s: %w", a.BaseURL, err)
+	}
+
+	models := struct {
+		Data []struct {
+			ID     string  `json:"id"`
+			Root   string  `json:"root"`
+			Parent *string `json:"parent"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &models); err != nil {
+		return nil, fmt.Errorf("failed to decode the models of %s: %w", a.BaseURL, err)
+	}
+
+	adapters := make(map[string]string)
+	for _, model := range models.Data {
+		// The base model and its served names have no parent
+		if model.Parent != nil {
+			adapters[model.ID] = model.Root
+		}
+	}
+	return adapters, nil
+}
+
+// LoadAdapter loads a LoRA adapter.
+func (a *ModelServerAdmin) LoadAdapter(ctx context.Context, name, path string) error {
+	return a.post(ctx, "/v1/load_lora_adapter", map[string]string{"lora_name": name, "lora_path": path})
+}
+
+// UnloadAdapter unloads a LoRA adapter.
+func (a *ModelServerAdmin) UnloadAdapter(ctx context.Context, name string) error {
+	return a.post(ctx, "/v1/unload_lora_adapter", map[string]string{"lora_name": name})
+}
+
+func (a *ModelServerAdmin) post(ctx context.Context, path string, payload map[string]string) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := a.do(req); err != nil {
+		return fmt.Errorf("failed to call %s%s: %w", a.BaseURL, path, err)
+	}
+	return nil
+}
+
+func (a *ModelServerAdmin) do(req *http.Request) ([]byte, error) {
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
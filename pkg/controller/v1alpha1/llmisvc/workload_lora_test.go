@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package llmisvc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/controller/v1alpha1/llmisvc"
+)
+
+// fakeModelServer implements the model listing and the LoRA adapters admin API of vLLM
+type fakeModelServer struct {
+	mu       sync.Mutex
+	adapters map[string]string
+	failing  map[string]bool
+}
+
+func (f *fakeModelServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method == http.MethodGet && r.URL.Path == "/v1/models" {
+		type model struct {
+			ID     string  `json:"id"`
+			Root   string  `json:"root"`
+			Parent *string `json:"parent"`
+		}
+		data := []model{{ID: "base-model", Root: "/mnt/models"}}
+		for name, path := range f.adapters {
+			data = append(data, model{ID: name, Root: path, Parent: ptr.To("base-model")})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+		return
+	}
+
+	payload := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	name := payload["lora_name"]
+	switch r.URL.Path {
+	case "/v1/load_lora_adapter":
+		if f.failing[name] {
+			http.Error(w, "failed to download the adapter", http.StatusBadRequest)
+			return
+		}
+		f.adapters[name] = payload["lora_path"]
+	case "/v1/unload_lora_adapter":
+		delete(f.adapters, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func loraAdapter(name, uri string) v1alpha1.LLMModelSpec {
+	u, _ := apis.ParseURL(uri)
+	return v1alpha1.LLMModelSpec{Name: ptr.To(name), URI: *u}
+}
+
+func TestSyncLoRAAdapters(t *testing.T) {
+	g := NewGomegaWithT(t)
+	server := &fakeModelServer{
+		adapters: map[string]string{
+			"removed": "org/removed",
+			"changed": "org/changed-v1",
+			"kept":    "org/kept",
+		},
+		failing: map[string]bool{"failing": true},
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	admin := &llmisvc.ModelServerAdmin{Client: httpServer.Client(), BaseURL: httpServer.URL}
+
+	loaded, err := llmisvc.SyncLoRAAdapters(t.Context(), admin, []v1alpha1.LLMModelSpec{
+		loraAdapter("kept", "hf://org/kept"),
+		loraAdapter("changed", "hf://org/changed-v2"),
+		loraAdapter("added", "hf://org/added"),
+		loraAdapter("failing", "hf://org/failing"),
+		loraAdapter("unsupported", "s3://bucket/adapter"),
+	})
+
+	g.Expect(loaded).To(Equal([]string{"kept", "changed", "added"}))
+	g.Expect(server.adapters).To(Equal(map[string]string{
+		"kept":    "org/kept",
+		"changed": "org/changed-v2",
+		"added":   "org/added",
+	}))
+
+	var adapterErr *llmisvc.LoRAAdapterError
+	g.Expect(errors.As(err, &adapterErr)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring(`LoRA adapter "failing"`))
+	g.Expect(err.Error()).To(ContainSubstring("failed to download the adapter"))
+	g.Expect(err.Error()).To(ContainSubstring(`LoRA adapter "unsupported": unsupported URI "s3://bucket/adapter"`))
+}
+
+func TestModelServerEndpoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+	pod := func(name, ip string, ready bool, labels map[string]string, port int32) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "main",
+				Ports: []corev1.ContainerPort{{ContainerPort: port}},
+			}}},
+			Status: corev1.PodStatus{
+				PodIP:      ip,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	endpoints := llmisvc.ModelServerEndpoints([]corev1.Pod{
+		pod("ready", "10.0.0.1", true, nil, 8000),
+		pod("decode", "10.0.0.2", true, nil, 8001),
+		pod("not-ready", "10.0.0.3", false, nil, 8000),
+		pod("leader", "10.0.0.4", true, map[string]string{"leaderworkerset.sigs.k8s.io/worker-index": "0"}, 8000),
+		pod("worker", "10.0.0.5", true, map[string]string{"leaderworkerset.sigs.k8s.io/worker-index": "1"}, 8000),
+	})
+
+	g.Expect(endpoints).To(Equal([]string{"http://10.0.0.1:8000", "http://10.0.0.2:8001", "http://10.0.0.4:8000"}))
+}
+
+func TestEnableRuntimeLoRA(t *testing.T) {
+	g := NewGomegaWithT(t)
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "main", Args: []string{"--served-model-name", "base-model"}},
+		{Name: "sidecar"},
+	}}
+
+	llmisvc.EnableRuntimeLoRA(podSpec)
+	llmisvc.EnableRuntimeLoRA(podSpec)
+
+	g.Expect(podSpec.Containers[0].Args).To(Equal([]string{"--served-model-name", "base-model", "--enable-lora"}))
+	g.Expect(podSpec.Containers[0].Env).To(Equal([]corev1.EnvVar{{Name: "VLLM_ALLOW_RUNTIME_LORA_UPDATING", Value: "True"}}))
+	g.Expect(podSpec.Containers[1].Env).To(BeEmpty())
+}
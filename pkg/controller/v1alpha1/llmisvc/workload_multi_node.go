@@ -221,6 +221,14 @@ func (r *LLMISVCReconciler) expectedMainMultiNodeLWS(ctx context.Context, llmSvc
 		}
 	}
 
+	if llmSvc.Spec.Worker != nil {
+		replicas, err := r.workloadReplicas(ctx, &llmSvc.Spec.WorkloadSpec, expected)
+		if err != nil {
+			return nil, err
+		}
+		expected.Spec.Replicas = replicas
+	}
+
 	r.propagateLeaderWorkerSetMetadata(llmSvc, expected)
 
 	log.FromContext(ctx).V(2).Info("Expected main LWS", "leaderworkerset", expected)
@@ -310,6 +318,14 @@ func (r *LLMISVCReconciler) expectedPrefillMultiNodeLWS(ctx context.Context, llm
 		}
 	}
 
+	if llmSvc.Spec.Prefill != nil && llmSvc.Spec.Prefill.Worker != nil {
+		replicas, err := r.workloadReplicas(ctx, llmSvc.Spec.Prefill, expected)
+		if err != nil {
+			return nil, err
+		}
+		expected.Spec.Replicas = replicas
+	}
+
 	r.propagateLeaderWorkerSetMetadata(llmSvc, expected)
 
 	log.FromContext(ctx).V(2).Info("Expected prefill LWS", "leaderworkerset", expected)
@@ -136,6 +136,12 @@ func (r *LLMISVCReconciler) expectedSingleNodeMainDeployment(ctx context.Context
 		}
 	}
 
+	replicas, err := r.workloadReplicas(ctx, &llmSvc.Spec.WorkloadSpec, d)
+	if err != nil {
+		return nil, err
+	}
+	d.Spec.Replicas = replicas
+
 	r.propagateDeploymentMetadata(llmSvc, d)
 
 	log.FromContext(ctx).V(2).Info("Expected main deployment", "deployment", d)
@@ -211,6 +217,14 @@ func (r *LLMISVCReconciler) expectedPrefillMainDeployment(ctx context.Context, l
 		}
 	}
 
+	if llmSvc.Spec.Prefill != nil {
+		replicas, err := r.workloadReplicas(ctx, llmSvc.Spec.Prefill, d)
+		if err != nil {
+			return nil, err
+		}
+		d.Spec.Replicas = replicas
+	}
+
 	r.propagateDeploymentMetadata(llmSvc, d)
 
 	log.FromContext(ctx).V(2).Info("Expected prefill deployment", "deployment", d)
@@ -23,6 +23,7 @@ limitations under the License.
 // +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=get;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 package localmodelnode
 
 import (
@@ -59,6 +60,9 @@ type LocalModelNodeReconciler struct {
 	Clientset *kubernetes.Clientset
 	Log       logr.Logger
 	Scheme    *runtime.Scheme
+	// PeerDistributor fetches the models from the peer nodes which already downloaded them. Peer distribution is
+	// disabled when nil.
+	PeerDistributor *PeerDistributor
 }
 
 const (
@@ -246,6 +250,14 @@ func (c *LocalModelNodeReconciler) downloadModels(ctx context.Context, localMode
 	newStatus := map[string]v1alpha1.ModelStatus{}
 	for _, modelInfo := range localModelNode.Spec.LocalModels {
 		c.Log.Info("checking model from spec", "model", modelInfo.ModelName)
+		// The model folder of a model fetched from the peer nodes is partial until the download is finished
+		if c.PeerDistributor != nil {
+			if status, ok := c.PeerDistributor.takeDownloadStatus(modelInfo.ModelName); ok {
+				newStatus[modelInfo.ModelName] = status
+				c.Log.Info("model status from peer download", "model", modelInfo.ModelName, "status", status)
+				continue
+			}
+		}
 		var job *batchv1.Job
 		folderExists, err := fsHelper.hasModelFolder(modelInfo.ModelName)
 		if err != nil {
@@ -294,6 +306,20 @@ func (c *LocalModelNodeReconciler) downloadModels(ctx context.Context, localMode
 			// If the job has failed, we do not retry here because there are retries on the job.
 			// To retry the download, users can manually fix the issue and delete the failed job.
 			// Add the job count check for protection to ensure not creating more than 2 jobs including the previous one.
+			if job == nil && c.PeerDistributor != nil {
+				// Fetch the model from the peer nodes which already downloaded it, if any
+				peers, err := c.getModelPeers(ctx, modelInfo.ModelName)
+				if err != nil {
+					c.Log.Error(err, "Failed to get peers", "model", modelInfo.ModelName)
+					return err
+				}
+				if len(peers) > 0 {
+					c.PeerDistributor.startDownload(ctx, modelInfo.ModelName, peers)
+					newStatus[modelInfo.ModelName] = v1alpha1.ModelDownloading
+					c.Log.Info("Fetching model from peers", "model", modelInfo.ModelName, "num of peers", len(peers))
+					continue
+				}
+			}
 			if job == nil || (job.Status.Succeeded > 0 && jobCount < 2) {
 				job, err = c.launchJob(ctx, *localModelNode, modelInfo)
 				if err != nil {
@@ -307,6 +333,10 @@ func (c *LocalModelNodeReconciler) downloadModels(ctx context.Context, localMode
 		}
 	}
 
+	if c.PeerDistributor != nil {
+		c.PeerDistributor.setServableModels(newStatus)
+	}
+
 	// Skip update if no changes to status
 	if maps.Equal(localModelNode.Status.ModelStatus, newStatus) {
 		return nil
@@ -322,6 +352,34 @@ func (c *LocalModelNodeReconciler) downloadModels(ctx context.Context, localMode
 	return nil
 }
 
+// Returns the URLs of the agents of the peer nodes which downloaded the model
+func (c *LocalModelNodeReconciler) getModelPeers(ctx context.Context, modelName string) ([]string, error) {
+	localModelNodes := &v1alpha1.LocalModelNodeList{}
+	if err := c.List(ctx, localModelNodes); err != nil {
+		return nil, err
+	}
+	peers := []string{}
+	for _, localModelNode := range localModelNodes.Items {
+		if localModelNode.Name == nodeName || localModelNode.Status.ModelStatus[modelName] != v1alpha1.ModelDownloaded {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := c.Get(ctx, types.NamespacedName{Name: localModelNode.Name}, node); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				peers = append(peers, c.PeerDistributor.peerURL(address.Address))
+				break
+			}
+		}
+	}
+	return peers, nil
+}
+
 // Delete models that are not in the spec
 func (c *LocalModelNodeReconciler) deleteModels(localModelNode v1alpha1.LocalModelNode) error {
 	// 1. Scan model dir and get a list of existing folders representing downloaded models
@@ -0,0 +1,601 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localmodelnode
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// DefaultPeerPort is the port on which the local model agents serve the downloaded models to the peer nodes
+	DefaultPeerPort int32 = 8082
+	// PeerTokenAudience is the audience of the projected service account token the local model agents authenticate
+	// to the peer nodes with
+	PeerTokenAudience = "kserve-localmodel-peer"
+	// PeerTokenPath is the path of the projected service account token sent to the peer nodes
+	PeerTokenPath = "/var/run/secrets/kserve/peer/token"
+	// PeerTLSDir is the folder of the certificate the models are served with, and of the CA certificate the
+	// certificates of the peer nodes are verified with
+	PeerTLSDir = "/etc/kserve/peer-tls"
+	// PeerServerName is the DNS name of the peer certificate, which is verified instead of the address of the node
+	PeerServerName = "kserve-localmodelnode-agent-peer"
+	// PeerServiceAccountName is the service account of the local model agents, the only identity the models are
+	// served to
+	PeerServiceAccountName = "kserve-localmodelnode-agent"
+
+	peerModelsPath   = "/v1/models/"
+	peerManifestPath = "manifest"
+	peerFilesPath    = "files/"
+
+	// peerTokenReviewTTL is the duration a reviewed token is trusted without being reviewed again
+	peerTokenReviewTTL = time.Minute
+	// peerDialTimeout bounds the connection to a peer node, including the TLS handshake
+	peerDialTimeout = 10 * time.Second
+	// peerResponseHeaderTimeout bounds the wait for the response headers of a peer node
+	peerResponseHeaderTimeout = 30 * time.Second
+	// peerRequestTimeout bounds the transfer of a single file, an interrupted transfer is resumed from the partial
+	// file once the model is fetched again
+	peerRequestTimeout = time.Hour
+	// peerDownloadTimeout bounds the download of a model from the peer nodes, the model is then downloaded from the
+	// storage
+	peerDownloadTimeout = 4 * time.Hour
+)
+
+var peerLog = logf.Log.WithName("LocalModelPeerDistributor")
+
+// PeerFile is a file of a model listed in the manifest served to the peer nodes
+type PeerFile struct {
+	// Path of the file relative to the model folder
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// PeerManifest lists the files of a model served to the peer nodes
+type PeerManifest struct {
+	Files []PeerFile `json:"files"`
+}
+
+type peerDownloadState int
+
+const (
+	peerDownloading peerDownloadState = iota
+	peerDownloaded
+	peerDownloadFailed
+)
+
+// PeerDistributor serves the models downloaded on the current node to the local model agents of the peer nodes, and
+// fetches the models from the peer nodes which already downloaded them instead of downloading them from the storage.
+// The files are served over TLS with HTTP range requests, so that an interrupted transfer is resumed, and are verified
+// with the checksums of the manifest of the model. The peer nodes authenticate with a projected token of the service
+// account of the local model agents, which is reviewed with the API server.
+type PeerDistributor struct {
+	modelsRootFolder string
+	port             int32
+	tlsDir           string
+	tokenPath        string
+	clientset        kubernetes.Interface
+	// peerUsername is the user name of the service account of the local model agents
+	peerUsername string
+	httpClient   *http.Client
+
+	mu sync.Mutex
+	// models downloaded on the current node, which are served to the peer nodes
+	servable  map[string]bool
+	manifests map[string]*PeerManifest
+	downloads map[string]peerDownloadState
+	// reviewedTokens holds the expiry of the reviews of the tokens of the peer nodes, keyed on the token hash
+	reviewedTokens map[string]time.Time
+}
+
+// NewPeerDistributor returns a PeerDistributor serving the models of the current node on the given port
+func NewPeerDistributor(clientset kubernetes.Interface, port int32) *PeerDistributor {
+	return newPeerDistributor(clientset, modelsRootFolder, port, PeerTLSDir, PeerTokenPath)
+}
+
+func newPeerDistributor(clientset kubernetes.Interface, modelsRootFolder string, port int32, tlsDir string, tokenPath string) *PeerDistributor {
+	if port == 0 {
+		port = DefaultPeerPort
+	}
+	d := &PeerDistributor{
+		modelsRootFolder: modelsRootFolder,
+		port:             port,
+		tlsDir:           tlsDir,
+		tokenPath:        tokenPath,
+		clientset:        clientset,
+		peerUsername:     fmt.Sprintf("system:serviceaccount:%s:%s", constants.KServeNamespace, PeerServiceAccountName),
+		servable:         map[string]bool{},
+		manifests:        map[string]*PeerManifest{},
+		downloads:        map[string]peerDownloadState{},
+		reviewedTokens:   map[string]time.Time{},
+	}
+	d.httpClient = &http.Client{
+		Timeout: peerRequestTimeout,
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           (&net.Dialer{Timeout: peerDialTimeout}).DialContext,
+			TLSHandshakeTimeout:   peerDialTimeout,
+			ResponseHeaderTimeout: peerResponseHeaderTimeout,
+			// The certificate of the peer is verified against the CA certificate, which is read on each connection
+			// as it is rotated, and the peer certificate name instead of the address of the peer node
+			TLSClientConfig: &tls.Config{
+				MinVersion:         tls.VersionTLS12,
+				InsecureSkipVerify: true, //nolint:gosec // verified by VerifyConnection
+				VerifyConnection:   d.verifyPeerCertificate,
+			},
+		},
+	}
+	return d
+}
+
+// verifyPeerCertificate verifies the certificate of a peer node against the CA certificate of the peer certificate
+func (d *PeerDistributor) verifyPeerCertificate(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("the peer did not present a certificate")
+	}
+	caCert, err := os.ReadFile(filepath.Join(d.tlsDir, "ca.crt"))
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCert) {
+		return errors.New("invalid peer CA certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       PeerServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// Start serves the models to the peer nodes until the context is done. It implements manager.Runnable.
+func (d *PeerDistributor) Start(ctx context.Context) error {
+	watcher, err := certwatcher.New(filepath.Join(d.tlsDir, "tls.crt"), filepath.Join(d.tlsDir, "tls.key"))
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			peerLog.Error(err, "Failed to watch the peer certificate")
+		}
+	}()
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", d.port),
+		Handler:           d,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		},
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			peerLog.Error(err, "Failed to shutdown the peer server")
+		}
+	}()
+	peerLog.Info("Serving the local models to the peer nodes", "port", d.port)
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, as the agent of every node serves its models
+func (d *PeerDistributor) NeedLeaderElection() bool {
+	return false
+}
+
+// setServableModels serves the models downloaded on the current node to the peer nodes
+func (d *PeerDistributor) setServableModels(modelStatus map[string]v1alpha1.ModelStatus) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.servable = map[string]bool{}
+	for modelName, status := range modelStatus {
+		if status == v1alpha1.ModelDownloaded {
+			d.servable[modelName] = true
+		}
+	}
+	for modelName := range d.manifests {
+		if !d.servable[modelName] {
+			delete(d.manifests, modelName)
+		}
+	}
+}
+
+func (d *PeerDistributor) isServable(modelName string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.servable[modelName]
+}
+
+// ServeHTTP serves the manifest of a model on /v1/models/<model>/manifest and its files on
+// /v1/models/<model>/files/<path> to the authenticated local model agents
+func (d *PeerDistributor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if status, err := d.authenticate(r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, peerModelsPath)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	modelName, resource, ok := strings.Cut(path, "/")
+	if !ok || modelName == "" || !d.isServable(modelName) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if resource == peerManifestPath {
+		manifest, err := d.getManifest(modelName)
+		if err != nil {
+			peerLog.Error(err, "Failed to compute the model manifest", "model", modelName)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(manifest); err != nil {
+			peerLog.Error(err, "Failed to write the model manifest", "model", modelName)
+		}
+		return
+	}
+
+	filePath, ok := strings.CutPrefix(resource, peerFilesPath)
+	if !ok || !filepath.IsLocal(filePath) {
+		http.NotFound(w, r)
+		return
+	}
+	// The file is opened in the model folder, so that a symbolic link cannot point outside of it, and the symbolic
+	// links, which are not listed in the manifest, are not served
+	root, err := os.OpenRoot(filepath.Join(d.modelsRootFolder, modelName))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer root.Close()
+	if info, err := root.Lstat(filePath); err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+	file, err := root.Open(filePath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+	// ServeContent handles the range requests
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+}
+
+// authenticate checks that the bearer token of the request is a token of the service account of the local model
+// agents issued for the peer audience. The successful reviews are cached for peerTokenReviewTTL.
+func (d *PeerDistributor) authenticate(r *http.Request) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, errors.New("a bearer token is required")
+	}
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+	d.mu.Lock()
+	expiry, reviewed := d.reviewedTokens[key]
+	d.mu.Unlock()
+	if reviewed && now.Before(expiry) {
+		return http.StatusOK, nil
+	}
+
+	review, err := d.clientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token, Audiences: []string{PeerTokenAudience}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		peerLog.Error(err, "Failed to review the token of a peer")
+		return http.StatusInternalServerError, errors.New("failed to authenticate the request")
+	}
+	if !review.Status.Authenticated {
+		return http.StatusUnauthorized, errors.New("invalid bearer token")
+	}
+	if review.Status.User.Username != d.peerUsername {
+		return http.StatusForbidden, fmt.Errorf("%s is not a local model agent", review.Status.User.Username)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for cached, cachedExpiry := range d.reviewedTokens {
+		if !now.Before(cachedExpiry) {
+			delete(d.reviewedTokens, cached)
+		}
+	}
+	d.reviewedTokens[key] = now.Add(peerTokenReviewTTL)
+	return http.StatusOK, nil
+}
+
+// getManifest returns the manifest of a model, which is computed once while the model is served
+func (d *PeerDistributor) getManifest(modelName string) (*PeerManifest, error) {
+	d.mu.Lock()
+	manifest, ok := d.manifests[modelName]
+	d.mu.Unlock()
+	if ok {
+		return manifest, nil
+	}
+
+	root, err := os.OpenRoot(filepath.Join(d.modelsRootFolder, modelName))
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	manifest = &PeerManifest{Files: []PeerFile{}}
+	err = fs.WalkDir(root.FS(), ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		// The symbolic links and the other non regular files are not served
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		file, err := root.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		checksum, size, err := readerChecksum(file)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, PeerFile{Path: path, Size: size, SHA256: checksum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.servable[modelName] {
+		d.manifests[modelName] = manifest
+	}
+	return manifest, nil
+}
+
+// takeDownloadStatus returns the status of the download of a model from the peer nodes, if any. A finished download
+// is forgotten, so that a failed download falls back to downloading the model from the storage.
+func (d *PeerDistributor) takeDownloadStatus(modelName string) (v1alpha1.ModelStatus, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	state, ok := d.downloads[modelName]
+	if !ok {
+		return "", false
+	}
+	switch state {
+	case peerDownloading:
+		return v1alpha1.ModelDownloading, true
+	case peerDownloaded:
+		delete(d.downloads, modelName)
+		return v1alpha1.ModelDownloaded, true
+	default:
+		delete(d.downloads, modelName)
+		return "", false
+	}
+}
+
+// startDownload fetches a model in the background from the first of the peers which succeeds. The download is bounded
+// by peerDownloadTimeout and is cancelled with the context, the one of the manager for the reconciles.
+func (d *PeerDistributor) startDownload(ctx context.Context, modelName string, peers []string) {
+	d.mu.Lock()
+	if _, ok := d.downloads[modelName]; ok {
+		d.mu.Unlock()
+		return
+	}
+	d.downloads[modelName] = peerDownloading
+	d.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, peerDownloadTimeout)
+		defer cancel()
+		state := peerDownloadFailed
+		for _, peer := range peers {
+			peerLog.Info("Fetching model from peer", "model", modelName, "peer", peer)
+			if err := d.fetchModel(ctx, peer, modelName); err != nil {
+				peerLog.Error(err, "Failed to fetch model from peer", "model", modelName, "peer", peer)
+				continue
+			}
+			peerLog.Info("Fetched model from peer", "model", modelName, "peer", peer)
+			state = peerDownloaded
+			break
+		}
+		d.mu.Lock()
+		d.downloads[modelName] = state
+		d.mu.Unlock()
+	}()
+}
+
+// peerURL returns the URL of the agent of a peer node
+func (d *PeerDistributor) peerURL(address string) string {
+	return "https://" + address + ":" + strconv.Itoa(int(d.port))
+}
+
+// fetchModel fetches all the files of a model from a peer, resuming the files already partially fetched
+func (d *PeerDistributor) fetchModel(ctx context.Context, peer string, modelName string) error {
+	manifest := &PeerManifest{}
+	resp, err := d.get(ctx, peer+peerModelsPath+url.PathEscape(modelName)+"/"+peerManifestPath, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d getting the manifest of model %s", resp.StatusCode, modelName)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(manifest); err != nil {
+		return fmt.Errorf("invalid manifest of model %s: %w", modelName, err)
+	}
+	for _, file := range manifest.Files {
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return fmt.Errorf("invalid file path %s in the manifest of model %s", file.Path, modelName)
+		}
+		if err := d.fetchFile(ctx, peer, modelName, file); err != nil {
+			return fmt.Errorf("failed to fetch file %s of model %s: %w", file.Path, modelName, err)
+		}
+	}
+	return nil
+}
+
+func (d *PeerDistributor) fetchFile(ctx context.Context, peer string, modelName string, file PeerFile) error {
+	dest := filepath.Join(d.modelsRootFolder, modelName, filepath.FromSlash(file.Path))
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	info, err := out.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	if offset == file.Size {
+		if checksum, _, err := fileChecksum(dest); err == nil && checksum == file.SHA256 {
+			return nil
+		}
+		offset = 0
+	}
+	if offset > file.Size {
+		offset = 0
+	}
+
+	fileURL := peer + peerModelsPath + url.PathEscape(modelName) + "/" + peerFilesPath + (&url.URL{Path: file.Path}).EscapedPath()
+	resp, err := d.get(ctx, fileURL, offset)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The peer sent the whole file
+		offset = 0
+	default:
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if err := out.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := out.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	checksum, size, err := fileChecksum(dest)
+	if err != nil {
+		return err
+	}
+	if size != file.Size || checksum != file.SHA256 {
+		// Do not resume a corrupted file
+		if err := os.Remove(dest); err != nil {
+			peerLog.Error(err, "Failed to remove corrupted file", "file", dest)
+		}
+		return fmt.Errorf("checksum mismatch, expected %s got %s", file.SHA256, checksum)
+	}
+	return nil
+}
+
+// get sends a GET request authenticated with the projected token of the agent, requesting the content from the offset
+// when it is positive. The token is read on each request as it is rotated.
+func (d *PeerDistributor) get(ctx context.Context, target string, offset int64) (*http.Response, error) {
+	token, err := os.ReadFile(d.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the peer token: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	return d.httpClient.Do(req)
+}
+
+// fileChecksum returns the hex encoded sha256 checksum and the size of a file
+func fileChecksum(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	return readerChecksum(file)
+}
+
+// readerChecksum returns the hex encoded sha256 checksum and the size of the content of a reader
+func readerChecksum(reader io.Reader) (string, int64, error) {
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
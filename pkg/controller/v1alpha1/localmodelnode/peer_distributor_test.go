@@ -0,0 +1,349 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localmodelnode
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	testPeerToken  = "peer-token"
+	testOtherToken = "other-token"
+)
+
+var (
+	testTLSDirOnce sync.Once
+	testTLSDir     string
+)
+
+// newTestTLSDir returns a folder holding a self-signed peer certificate, which is its own CA certificate
+func newTestTLSDir(t *testing.T) string {
+	testTLSDirOnce.Do(func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: PeerServerName},
+			DNSNames:              []string{PeerServerName},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+		keyDer, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("failed to marshal key: %v", err)
+		}
+		dir, err := os.MkdirTemp("", "peer-tls")
+		if err != nil {
+			t.Fatalf("failed to create folder: %v", err)
+		}
+		certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		writeTestFile(t, filepath.Join(dir, "tls.crt"), certPEM)
+		writeTestFile(t, filepath.Join(dir, "ca.crt"), certPEM)
+		writeTestFile(t, filepath.Join(dir, "tls.key"), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})))
+		testTLSDir = dir
+	})
+	return testTLSDir
+}
+
+// newTestDistributor returns a PeerDistributor authenticating to the peers with the token of the local model agents
+// and reviewing the tokens of the peers with a fake API server
+func newTestDistributor(t *testing.T, rootFolder string) *PeerDistributor {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	writeTestFile(t, tokenPath, testPeerToken)
+	clientset := fake.NewClientset()
+	d := newPeerDistributor(clientset, rootFolder, 0, newTestTLSDir(t), tokenPath)
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		switch review.Spec.Token {
+		case testPeerToken:
+			review.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: d.peerUsername}}
+		case testOtherToken:
+			review.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "system:serviceaccount:default:other"}}
+		}
+		return true, review, nil
+	})
+	return d
+}
+
+// newTestPeer returns a peer server serving the given files of a downloaded model over TLS
+func newTestPeer(t *testing.T, modelName string, files map[string]string) (*PeerDistributor, *httptest.Server) {
+	rootFolder := t.TempDir()
+	for path, content := range files {
+		writeTestFile(t, filepath.Join(rootFolder, modelName, path), content)
+	}
+	peer := newTestDistributor(t, rootFolder)
+	peer.setServableModels(map[string]v1alpha1.ModelStatus{modelName: v1alpha1.ModelDownloaded})
+	cert, err := tls.LoadX509KeyPair(filepath.Join(peer.tlsDir, "tls.crt"), filepath.Join(peer.tlsDir, "tls.key"))
+	if err != nil {
+		t.Fatalf("failed to load the peer certificate: %v", err)
+	}
+	server := httptest.NewUnstartedServer(peer)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return peer, server
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	return string(content)
+}
+
+func TestPeerDistributor_fetchModel(t *testing.T) {
+	files := map[string]string{
+		"model.bin":          strings.Repeat("weights", 1000),
+		"config/config.json": `{"name": "model"}`,
+	}
+	_, server := newTestPeer(t, "model1", files)
+
+	rootFolder := t.TempDir()
+	fetcher := newTestDistributor(t, rootFolder)
+	if err := fetcher.fetchModel(t.Context(), server.URL, "model1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for path, content := range files {
+		if got := readTestFile(t, filepath.Join(rootFolder, "model1", path)); got != content {
+			t.Errorf("unexpected content of %s: %q", path, got)
+		}
+	}
+}
+
+func TestPeerDistributor_fetchModelResumesPartialFile(t *testing.T) {
+	content := strings.Repeat("0123456789", 100)
+	_, server := newTestPeer(t, "model1", map[string]string{"model.bin": content})
+
+	rootFolder := t.TempDir()
+	// A previous transfer was interrupted
+	writeTestFile(t, filepath.Join(rootFolder, "model1", "model.bin"), content[:250])
+	fetcher := newTestDistributor(t, rootFolder)
+	var ranges []string
+	transport := fetcher.httpClient.Transport
+	fetcher.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ranges = append(ranges, req.Header.Get("Range"))
+		return transport.RoundTrip(req)
+	})
+	if err := fetcher.fetchModel(t.Context(), server.URL, "model1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := readTestFile(t, filepath.Join(rootFolder, "model1", "model.bin")); got != content {
+		t.Errorf("unexpected content after resuming: %q", got)
+	}
+	if len(ranges) != 2 || ranges[1] != "bytes=250-" {
+		t.Errorf("expected the file to be requested from the offset of the partial file, got ranges %v", ranges)
+	}
+}
+
+func TestPeerDistributor_fetchModelChecksumMismatch(t *testing.T) {
+	peer, server := newTestPeer(t, "model1", map[string]string{"model.bin": "original"})
+	// compute the manifest before the file is corrupted
+	if _, err := peer.getManifest("model1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writeTestFile(t, filepath.Join(peer.modelsRootFolder, "model1", "model.bin"), "modified")
+
+	rootFolder := t.TempDir()
+	fetcher := newTestDistributor(t, rootFolder)
+	err := fetcher.fetchModel(t.Context(), server.URL, "model1")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootFolder, "model1", "model.bin")); !os.IsNotExist(err) {
+		t.Errorf("expected the corrupted file to be removed, got %v", err)
+	}
+}
+
+func TestPeerDistributor_ServeHTTP(t *testing.T) {
+	peer, server := newTestPeer(t, "model1", map[string]string{"model.bin": "weights"})
+	writeTestFile(t, filepath.Join(peer.modelsRootFolder, "secret"), "secret")
+	writeTestFile(t, filepath.Join(peer.modelsRootFolder, "model2", "model.bin"), "weights")
+	if err := os.Symlink(filepath.Join(peer.modelsRootFolder, "secret"), filepath.Join(peer.modelsRootFolder, "model1", "secret.bin")); err != nil {
+		t.Fatalf("failed to create symbolic link: %v", err)
+	}
+	if err := os.Symlink("model.bin", filepath.Join(peer.modelsRootFolder, "model1", "link.bin")); err != nil {
+		t.Fatalf("failed to create symbolic link: %v", err)
+	}
+	get := func(path string, token string) int {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := peer.httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	scenarios := map[string]struct {
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		"manifest":                       {path: "/v1/models/model1/manifest", token: testPeerToken, expectedStatus: http.StatusOK},
+		"file":                           {path: "/v1/models/model1/files/model.bin", token: testPeerToken, expectedStatus: http.StatusOK},
+		"missing file":                   {path: "/v1/models/model1/files/missing.bin", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"model not servable":             {path: "/v1/models/model2/files/model.bin", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"path traversal":                 {path: "/v1/models/model1/files/%2e%2e/secret", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"symbolic link out of the model": {path: "/v1/models/model1/files/secret.bin", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"symbolic link in the model":     {path: "/v1/models/model1/files/link.bin", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"unknown path":                   {path: "/healthz", token: testPeerToken, expectedStatus: http.StatusNotFound},
+		"no token":                       {path: "/v1/models/model1/files/model.bin", expectedStatus: http.StatusUnauthorized},
+		"invalid token":                  {path: "/v1/models/model1/files/model.bin", token: "invalid", expectedStatus: http.StatusUnauthorized},
+		"not a local agent":              {path: "/v1/models/model1/files/model.bin", token: testOtherToken, expectedStatus: http.StatusForbidden},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if status := get(scenario.path, scenario.token); status != scenario.expectedStatus {
+				t.Errorf("expected status %d, got %d", scenario.expectedStatus, status)
+			}
+		})
+	}
+
+	// The symbolic links are not listed in the manifest
+	manifest, err := peer.getManifest("model1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(manifest.Files) != 1 || manifest.Files[0].Path != "model.bin" {
+		t.Errorf("expected only model.bin in the manifest, got %v", manifest.Files)
+	}
+
+	// The model is no longer served once it is not downloaded on the node
+	peer.setServableModels(map[string]v1alpha1.ModelStatus{"model1": v1alpha1.ModelDownloading})
+	if status := get("/v1/models/model1/manifest", testPeerToken); status != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, status)
+	}
+}
+
+func TestPeerDistributor_startDownloadCancelled(t *testing.T) {
+	_, server := newTestPeer(t, "model1", map[string]string{"model.bin": "weights"})
+	fetcher := newTestDistributor(t, t.TempDir())
+
+	// The download is cancelled with the context it is started with
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	fetcher.startDownload(ctx, "model1", []string{server.URL})
+	if status := waitForPeerDownload(t, fetcher, "model1"); status != "" {
+		t.Errorf("expected no status for the cancelled download, got %s", status)
+	}
+}
+
+func TestPeerDistributor_verifyPeerCertificate(t *testing.T) {
+	// A peer presenting a certificate which is not issued by the peer CA is rejected
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	fetcher := newTestDistributor(t, t.TempDir())
+	if err := fetcher.fetchModel(t.Context(), server.URL, "model1"); err == nil {
+		t.Fatalf("expected the certificate of the peer to be rejected")
+	}
+}
+
+func TestPeerDistributor_startDownload(t *testing.T) {
+	_, server := newTestPeer(t, "model1", map[string]string{"model.bin": "weights"})
+	fetcher := newTestDistributor(t, t.TempDir())
+
+	if _, ok := fetcher.takeDownloadStatus("model1"); ok {
+		t.Fatalf("expected no download status before the download is started")
+	}
+	// The first peer does not have the model, the download falls back to the second peer
+	fetcher.startDownload(t.Context(), "model1", []string{"https://127.0.0.1:1", server.URL})
+	status := waitForPeerDownload(t, fetcher, "model1")
+	if status != v1alpha1.ModelDownloaded {
+		t.Errorf("expected status %s, got %s", v1alpha1.ModelDownloaded, status)
+	}
+	if _, ok := fetcher.takeDownloadStatus("model1"); ok {
+		t.Errorf("expected the finished download to be forgotten")
+	}
+
+	// A failed download is forgotten, so that the model is downloaded from the storage
+	fetcher.startDownload(t.Context(), "model2", []string{server.URL})
+	if status := waitForPeerDownload(t, fetcher, "model2"); status != "" {
+		t.Errorf("expected no status for the failed download, got %s", status)
+	}
+}
+
+// waitForPeerDownload returns the status of the peer download of a model once it is finished
+func waitForPeerDownload(t *testing.T, d *PeerDistributor, modelName string) v1alpha1.ModelStatus {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		d.mu.Lock()
+		state := d.downloads[modelName]
+		d.mu.Unlock()
+		if state != peerDownloading {
+			status, _ := d.takeDownloadStatus(modelName)
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for the download of model %s", modelName)
+	return ""
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=modelcheckpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=modelcheckpoints/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get
+// +kubebuilder:rbac:groups=core,resources=nodes/checkpoint,verbs=create
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+package modelcheckpoint
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// Event reasons
+const (
+	CheckpointCompleted = "CheckpointCompleted"
+	CheckpointFailed    = "CheckpointFailed"
+)
+
+// ModelCheckpointReconciler runs in the checkpoint agent of each node. It checkpoints the containers of the
+// ModelCheckpoints of its node with the kubelet, and pushes the checkpoint archives as checkpoint images.
+// A failed checkpoint is not retried, as checkpointing freezes the model server, until the ModelCheckpoint is deleted
+// and created again by the InferenceService controller.
+type ModelCheckpointReconciler struct {
+	client.Client
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+	NodeName     string
+	Checkpointer Checkpointer
+	Pusher       ImagePusher
+}
+
+func (r *ModelCheckpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	checkpoint := &v1alpha1.ModelCheckpoint{}
+	if err := r.Get(ctx, req.NamespacedName, checkpoint); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if checkpoint.Spec.NodeName != r.NodeName ||
+		checkpoint.Status.Phase == v1alpha1.ModelCheckpointCompleted || checkpoint.Status.Phase == v1alpha1.ModelCheckpointFailed {
+		return ctrl.Result{}, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: checkpoint.Namespace, Name: checkpoint.Spec.PodName}, pod); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, r.fail(ctx, checkpoint, fmt.Errorf("pod %q no longer exists", checkpoint.Spec.PodName))
+		}
+		return ctrl.Result{}, err
+	}
+	if pod.Spec.NodeName != r.NodeName || pod.Status.Phase != corev1.PodRunning {
+		return ctrl.Result{}, r.fail(ctx, checkpoint, fmt.Errorf("pod %q is not running on node %q", pod.Name, r.NodeName))
+	}
+
+	r.Log.Info("Checkpointing container", "namespace", checkpoint.Namespace, "pod", pod.Name,
+		"container", checkpoint.Spec.ContainerName)
+	archive, err := r.Checkpointer.Checkpoint(ctx, checkpoint.Namespace, pod.Name, checkpoint.Spec.ContainerName)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, checkpoint, err)
+	}
+	// The archive holds the memory of the model server and is only kept in the image
+	defer func() {
+		if err := os.Remove(archive); err != nil && !os.IsNotExist(err) {
+			r.Log.Error(err, "Failed to remove the checkpoint archive", "archive", archive)
+		}
+	}()
+
+	r.Log.Info("Pushing checkpoint image", "namespace", checkpoint.Namespace, "checkpoint", checkpoint.Name,
+		"image", checkpoint.Spec.Image)
+	image, err := r.Pusher.Push(ctx, archive, checkpoint.Spec.ContainerName, checkpoint.Spec.Image)
+	if err != nil {
+		return ctrl.Result{}, r.fail(ctx, checkpoint, err)
+	}
+
+	now := metav1.Now()
+	checkpoint.Status.Phase = v1alpha1.ModelCheckpointCompleted
+	checkpoint.Status.Image = image
+	checkpoint.Status.Message = ""
+	checkpoint.Status.CompletionTime = &now
+	if err := r.Status().Update(ctx, checkpoint); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Eventf(checkpoint, corev1.EventTypeNormal, CheckpointCompleted,
+		"Checkpointed container %q of pod %q to %s", checkpoint.Spec.ContainerName, pod.Name, image)
+	return ctrl.Result{}, nil
+}
+
+func (r *ModelCheckpointReconciler) fail(ctx context.Context, checkpoint *v1alpha1.ModelCheckpoint, cause error) error {
+	r.Log.Error(cause, "Failed to checkpoint container", "namespace", checkpoint.Namespace, "checkpoint", checkpoint.Name)
+	r.Recorder.Eventf(checkpoint, corev1.EventTypeWarning, CheckpointFailed, "Failed to checkpoint: %v", cause)
+	checkpoint.Status.Phase = v1alpha1.ModelCheckpointFailed
+	checkpoint.Status.Message = cause.Error()
+	return r.Status().Update(ctx, checkpoint)
+}
+
+func (r *ModelCheckpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ModelCheckpoint{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			checkpoint, ok := obj.(*v1alpha1.ModelCheckpoint)
+			return ok && checkpoint.Spec.NodeName == r.NodeName
+		}))).
+		Complete(r)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelcheckpoint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+type fakeCheckpointer struct {
+	archive string
+	err     error
+	calls   int
+}
+
+func (f *fakeCheckpointer) Checkpoint(ctx context.Context, namespace string, pod string, container string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.archive, os.WriteFile(f.archive, []byte("checkpoint"), 0o600)
+}
+
+type fakePusher struct {
+	err       error
+	container string
+	reference string
+}
+
+func (f *fakePusher) Push(ctx context.Context, archive string, container string, reference string) (string, error) {
+	f.container, f.reference = container, reference
+	return reference + "@sha256:1234", f.err
+}
+
+func newTestReconciler(t *testing.T, checkpointer Checkpointer, pusher ImagePusher, objects ...client.Object) (*ModelCheckpointReconciler, *record.FakeRecorder) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	recorder := record.NewFakeRecorder(10)
+	return &ModelCheckpointReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).
+			WithStatusSubresource(&v1alpha1.ModelCheckpoint{}).Build(),
+		Log:          logr.Discard(),
+		Scheme:       s,
+		Recorder:     recorder,
+		NodeName:     "node-1",
+		Checkpointer: checkpointer,
+		Pusher:       pusher,
+	}, recorder
+}
+
+func makeCheckpoint(nodeName string) *v1alpha1.ModelCheckpoint {
+	return &v1alpha1.ModelCheckpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-5d8f7b9c4", Namespace: "default"},
+		Spec: v1alpha1.ModelCheckpointSpec{
+			PodName:       "sklearn-predictor-5d8f7b9c4-x2k9p",
+			ContainerName: "kserve-container",
+			NodeName:      nodeName,
+			Image:         "registry.example.com/checkpoints:default-sklearn-predictor-5d8f7b9c4",
+		},
+	}
+}
+
+func makePod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-5d8f7b9c4-x2k9p", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestModelCheckpointReconciler_Completed(t *testing.T) {
+	checkpointer := &fakeCheckpointer{archive: filepath.Join(t.TempDir(), "checkpoint.tar")}
+	pusher := &fakePusher{}
+	reconciler, recorder := newTestReconciler(t, checkpointer, pusher, makeCheckpoint("node-1"), makePod())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-5d8f7b9c4"}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "kserve-container", pusher.container)
+	assert.Equal(t, "registry.example.com/checkpoints:default-sklearn-predictor-5d8f7b9c4", pusher.reference)
+	assert.NoFileExists(t, checkpointer.archive)
+	assert.Len(t, recorder.Events, 1)
+
+	completed := &v1alpha1.ModelCheckpoint{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, completed))
+	assert.Equal(t, v1alpha1.ModelCheckpointCompleted, completed.Status.Phase)
+	assert.Equal(t, "registry.example.com/checkpoints:default-sklearn-predictor-5d8f7b9c4@sha256:1234", completed.Status.Image)
+	assert.NotNil(t, completed.Status.CompletionTime)
+
+	// Completed checkpoints are not taken again
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, checkpointer.calls)
+}
+
+func TestModelCheckpointReconciler_Failed(t *testing.T) {
+	checkpointer := &fakeCheckpointer{err: errors.New("kubelet checkpoint API returned 500 Internal Server Error: criu failed")}
+	reconciler, recorder := newTestReconciler(t, checkpointer, &fakePusher{}, makeCheckpoint("node-1"), makePod())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-5d8f7b9c4"}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "Warning CheckpointFailed Failed to checkpoint: kubelet checkpoint API returned 500 Internal Server Error: criu failed",
+		<-recorder.Events)
+
+	failed := &v1alpha1.ModelCheckpoint{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, failed))
+	assert.Equal(t, v1alpha1.ModelCheckpointFailed, failed.Status.Phase)
+	assert.Contains(t, failed.Status.Message, "criu failed")
+
+	// Failed checkpoints are not retried
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, checkpointer.calls)
+}
+
+func TestModelCheckpointReconciler_OtherNode(t *testing.T) {
+	checkpointer := &fakeCheckpointer{}
+	reconciler, _ := newTestReconciler(t, checkpointer, &fakePusher{}, makeCheckpoint("node-2"), makePod())
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-5d8f7b9c4"}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, checkpointer.calls)
+
+	pending := &v1alpha1.ModelCheckpoint{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, pending))
+	assert.Empty(t, pending.Status.Phase)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelcheckpoint
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// CheckpointNameAnnotation is the annotation of the checkpoint images naming the checkpointed container, from which
+// the container runtime recognizes the images to restore
+const CheckpointNameAnnotation = "io.kubernetes.cri-o.annotations.checkpoint.name"
+
+// ImagePusher pushes a checkpoint archive as a checkpoint image, returning the reference of the image pinned by digest
+type ImagePusher interface {
+	Push(ctx context.Context, archive string, container string, reference string) (string, error)
+}
+
+// RegistryPusher pushes the checkpoint images to an OCI registry, authenticated with the docker config of the agent
+type RegistryPusher struct {
+	Keychain authn.Keychain
+	// Insecure pushes the images over plain HTTP
+	Insecure bool
+}
+
+func NewRegistryPusher(insecure bool) *RegistryPusher {
+	return &RegistryPusher{
+		Keychain: authn.DefaultKeychain,
+		Insecure: insecure,
+	}
+}
+
+// Push builds a checkpoint image with a single layer holding the content of the checkpoint archive, in the format of
+// the images built with buildah from the checkpoints of the kubelet
+func (p *RegistryPusher) Push(ctx context.Context, archive string, container string, reference string) (string, error) {
+	var options []name.Option
+	if p.Insecure {
+		options = append(options, name.Insecure)
+	}
+	ref, err := name.ParseReference(reference, options...)
+	if err != nil {
+		return "", fmt.Errorf("invalid checkpoint image reference %q: %w", reference, err)
+	}
+	image, err := checkpointImage(archive, container)
+	if err != nil {
+		return "", err
+	}
+	if err := remote.Write(ref, image, remote.WithAuthFromKeychain(p.Keychain), remote.WithContext(ctx)); err != nil {
+		return "", fmt.Errorf("failed to push the checkpoint image %s: %w", ref, err)
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		return "", err
+	}
+	return ref.Context().Digest(digest.String()).String(), nil
+}
+
+func checkpointImage(archive string, container string) (v1.Image, error) {
+	// The checkpoint archives are mostly made of memory pages, favor speed over size
+	layer, err := tarball.LayerFromFile(archive, tarball.WithCompressionLevel(gzip.BestSpeed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint archive %s: %w", archive, err)
+	}
+	image := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, types.OCIConfigJSON)
+	image, err = mutate.Append(image, mutate.Addendum{Layer: layer, MediaType: types.OCILayer})
+	if err != nil {
+		return nil, err
+	}
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	configFile = configFile.DeepCopy()
+	configFile.OS = runtime.GOOS
+	configFile.Architecture = runtime.GOARCH
+	image, err = mutate.ConfigFile(image, configFile)
+	if err != nil {
+		return nil, err
+	}
+	return mutate.Annotations(image, map[string]string{CheckpointNameAnnotation: container}).(v1.Image), nil
+}
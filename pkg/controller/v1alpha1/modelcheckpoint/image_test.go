@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelcheckpoint
+
+import (
+	"archive/tar"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArchive(t *testing.T, files map[string]string) string {
+	archive := filepath.Join(t.TempDir(), "checkpoint.tar")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return archive
+}
+
+func TestRegistryPusher(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	archive := writeArchive(t, map[string]string{"config.dump": "{}", "checkpoint/pages-1.img": "pages"})
+	pusher := &RegistryPusher{Keychain: authn.NewMultiKeychain(), Insecure: true}
+	image, err := pusher.Push(t.Context(), archive, "kserve-container", host+"/checkpoints:default-sklearn-predictor-5d8f7b9c4")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(image, host+"/checkpoints@sha256:"), image)
+
+	ref, err := name.ParseReference(image, name.Insecure)
+	require.NoError(t, err)
+	pushed, err := remote.Image(ref)
+	require.NoError(t, err)
+	manifest, err := pushed.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, types.OCIManifestSchema1, manifest.MediaType)
+	assert.Equal(t, map[string]string{CheckpointNameAnnotation: "kserve-container"}, manifest.Annotations)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, types.OCILayer, manifest.Layers[0].MediaType)
+
+	// The layer holds the content of the checkpoint archive
+	layers, err := pushed.Layers()
+	require.NoError(t, err)
+	content, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	defer content.Close()
+	tr := tar.NewReader(content)
+	var files []string
+	for header, err := tr.Next(); err == nil; header, err = tr.Next() {
+		files = append(files, header.Name)
+	}
+	assert.ElementsMatch(t, []string{"config.dump", "checkpoint/pages-1.img"}, files)
+}
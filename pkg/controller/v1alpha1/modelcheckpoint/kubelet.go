@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelcheckpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// checkpointTimeout bounds the time the kubelet takes to checkpoint a container, which includes copying the
+	// memory of the GPUs with cuda-checkpoint
+	checkpointTimeout = 30 * time.Minute
+	// maxErrorBodySize is the maximum size of the error body of the kubelet included in the errors
+	maxErrorBodySize = 4 << 10
+)
+
+// Checkpointer checkpoints a container, returning the path of the checkpoint archive on the node
+type Checkpointer interface {
+	Checkpoint(ctx context.Context, namespace string, pod string, container string) (string, error)
+}
+
+// KubeletCheckpointer checkpoints the containers with the checkpoint API of the kubelet, which requires the
+// ContainerCheckpoint feature gate and a container runtime supporting checkpoints, e.g. CRI-O with CRIU.
+type KubeletCheckpointer struct {
+	// Endpoint of the kubelet, e.g. https://10.0.0.1:10250
+	Endpoint  string
+	Client    *http.Client
+	TokenFile string
+}
+
+// NewKubeletCheckpointer returns a checkpointer calling the kubelet of the node at the address, authenticated with the
+// service account token. The kubelet serving certificate is verified with the CA file unless insecure.
+func NewKubeletCheckpointer(address string, port int, caFile string, insecure bool, tokenFile string) (*KubeletCheckpointer, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if insecure {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // the kubelet serving certificates are often self-signed
+	} else if caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the kubelet CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in the kubelet CA file %s", caFile)
+		}
+	}
+	return &KubeletCheckpointer{
+		Endpoint: "https://" + net.JoinHostPort(address, strconv.Itoa(port)),
+		Client: &http.Client{
+			Timeout:   checkpointTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+		TokenFile: tokenFile,
+	}, nil
+}
+
+// checkpointResponse is the response of the kubelet checkpoint API
+type checkpointResponse struct {
+	Items []string `json:"items"`
+}
+
+func (k *KubeletCheckpointer) Checkpoint(ctx context.Context, namespace string, pod string, container string) (string, error) {
+	checkpointURL := fmt.Sprintf("%s/checkpoint/%s/%s/%s?timeout=%d", k.Endpoint, url.PathEscape(namespace),
+		url.PathEscape(pod), url.PathEscape(container), int(checkpointTimeout.Seconds()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, checkpointURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if k.TokenFile != "" {
+		// The token is read on every request as the projected service account tokens are rotated
+		token, err := os.ReadFile(k.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call the kubelet checkpoint API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return "", fmt.Errorf("kubelet checkpoint API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	checkpoint := &checkpointResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(checkpoint); err != nil {
+		return "", fmt.Errorf("invalid response of the kubelet checkpoint API: %w", err)
+	}
+	if len(checkpoint.Items) == 0 {
+		return "", fmt.Errorf("kubelet checkpoint API returned no checkpoint archive")
+	}
+	return checkpoint.Items[0], nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelcheckpoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeletCheckpointer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		switch r.URL.Path {
+		case "/checkpoint/default/sklearn-predictor-x2k9p/kserve-container":
+			assert.Equal(t, "1800", r.URL.Query().Get("timeout"))
+			_, _ = w.Write([]byte(`{"items":["/var/lib/kubelet/checkpoints/checkpoint-sklearn-predictor-x2k9p_default-kserve-container.tar"]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("checkpointing of default/sklearn-predictor-x2k9p/transformer failed"))
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+	checkpointer := &KubeletCheckpointer{Endpoint: server.URL, Client: server.Client(), TokenFile: tokenFile}
+
+	archive, err := checkpointer.Checkpoint(t.Context(), "default", "sklearn-predictor-x2k9p", "kserve-container")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/kubelet/checkpoints/checkpoint-sklearn-predictor-x2k9p_default-kserve-container.tar", archive)
+
+	_, err = checkpointer.Checkpoint(t.Context(), "default", "sklearn-predictor-x2k9p", "transformer")
+	require.EqualError(t, err, "kubelet checkpoint API returned 500 Internal Server Error: "+
+		"checkpointing of default/sklearn-predictor-x2k9p/transformer failed")
+
+	checkpointer.TokenFile = ""
+	_, err = checkpointer.Checkpoint(t.Context(), "default", "sklearn-predictor-x2k9p", "kserve-container")
+	require.EqualError(t, err, "kubelet checkpoint API returned 401 Unauthorized: ")
+}
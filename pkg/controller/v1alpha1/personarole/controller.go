@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=*,verbs=get;list;watch;create;update;patch;delete;deletecollection
+package personarole
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// ViewerRoleName is the persona ClusterRole reading all the KServe resources, aggregated to the view role
+	ViewerRoleName = "kserve-viewer"
+	// EditorRoleName is the persona ClusterRole deploying models with the namespaced KServe resources other than the
+	// runtimes, the quotas and the checkpoints, aggregated to the edit role
+	EditorRoleName = "kserve-editor"
+	// RuntimeAdminRoleName is the persona ClusterRole managing the runtimes and the cluster scoped KServe resources
+	RuntimeAdminRoleName = "kserve-runtime-admin"
+
+	aggregateToViewLabel = "rbac.authorization.k8s.io/aggregate-to-view"
+	aggregateToEditLabel = "rbac.authorization.k8s.io/aggregate-to-edit"
+)
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"create", "delete", "deletecollection", "patch", "update"}
+
+	// adminResources are the namespaced resources managed by the runtime admins rather than the editors: the runtimes,
+	// the quotas limiting the editors of the namespace, and the checkpoints created by the controller. The editors only
+	// read them.
+	adminResources = []string{"modelcheckpoints", "servingquotas", "servingruntimes"}
+
+	// personaRolesRequest is the single request reconciling all the persona ClusterRoles
+	personaRolesRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "kserve-persona-roles"}}
+)
+
+// PersonaRoleReconciler keeps the persona ClusterRoles of KServe in sync with the KServe CRDs installed in the
+// cluster, so that platform teams can bind the personas without tracking the resources added by each release. The
+// ClusterRoles are regenerated whenever a KServe CRD is installed or removed, or when a ClusterRole drifts.
+// The controller holds all the verbs it grants on the KServe resources, so that the ClusterRoles are not escalations.
+type PersonaRoleReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// crdResource is a resource served by a KServe CRD
+type crdResource struct {
+	plural     string
+	namespaced bool
+	status     bool
+}
+
+func (r *PersonaRoleReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := r.List(ctx, crdList); err != nil {
+		return ctrl.Result{}, err
+	}
+	var resources []crdResource
+	for _, crd := range crdList.Items {
+		if crd.Spec.Group != constants.KServeAPIGroupName {
+			continue
+		}
+		resource := crdResource{
+			plural:     crd.Spec.Names.Plural,
+			namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Served && version.Subresources != nil && version.Subresources.Status != nil {
+				resource.status = true
+			}
+		}
+		resources = append(resources, resource)
+	}
+	slices.SortFunc(resources, func(a, b crdResource) int { return cmp.Compare(a.plural, b.plural) })
+
+	for _, desired := range desiredRoles(resources) {
+		if err := r.reconcileRole(ctx, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *PersonaRoleReconciler) reconcileRole(ctx context.Context, desired *rbacv1.ClusterRole) error {
+	existing := &rbacv1.ClusterRole{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+	if apierr.IsNotFound(err) {
+		r.Log.Info("Creating persona ClusterRole", "name", desired.Name)
+		return r.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Rules, desired.Rules) &&
+		equality.Semantic.DeepDerivative(desired.Labels, existing.Labels) {
+		return nil
+	}
+	r.Log.Info("Updating persona ClusterRole", "name", desired.Name)
+	existing.Rules = desired.Rules
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	for key, value := range desired.Labels {
+		existing.Labels[key] = value
+	}
+	return r.Update(ctx, existing)
+}
+
+// desiredRoles returns the persona ClusterRoles granting access to the resources of the KServe CRDs
+func desiredRoles(resources []crdResource) []*rbacv1.ClusterRole {
+	var readable, editable, administrable []string
+	for _, resource := range resources {
+		readable = append(readable, resource.plural)
+		if resource.status {
+			readable = append(readable, resource.plural+"/status")
+		}
+		switch {
+		case !resource.namespaced || slices.Contains(adminResources, resource.plural):
+			administrable = append(administrable, resource.plural)
+		default:
+			editable = append(editable, resource.plural)
+		}
+	}
+
+	return []*rbacv1.ClusterRole{
+		personaRole(ViewerRoleName, map[string]string{aggregateToViewLabel: "true"}, readable, nil),
+		personaRole(EditorRoleName, map[string]string{aggregateToEditLabel: "true"}, readable, editable),
+		personaRole(RuntimeAdminRoleName, nil, readable, administrable),
+	}
+}
+
+func personaRole(name string, aggregationLabels map[string]string, readable []string, writable []string) *rbacv1.ClusterRole {
+	labels := map[string]string{constants.PersonaRoleLabel: name}
+	for key, value := range aggregationLabels {
+		labels[key] = value
+	}
+	rules := []rbacv1.PolicyRule{}
+	if len(readable) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{constants.KServeAPIGroupName},
+			Resources: readable,
+			Verbs:     readVerbs,
+		})
+	}
+	if len(writable) > 0 {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{constants.KServeAPIGroupName},
+			Resources: writable,
+			Verbs:     writeVerbs,
+		})
+	}
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Rules:      rules,
+	}
+}
+
+func (r *PersonaRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueuePersonaRoles := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{personaRolesRequest}
+	})
+	kserveCRDs := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+		return ok && crd.Spec.Group == constants.KServeAPIGroupName
+	})
+	personaClusterRoles := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, ok := obj.GetLabels()[constants.PersonaRoleLabel]
+		return ok
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("personarole").
+		Watches(&apiextensionsv1.CustomResourceDefinition{}, enqueuePersonaRoles, builder.WithPredicates(kserveCRDs)).
+		Watches(&rbacv1.ClusterRole{}, enqueuePersonaRoles, builder.WithPredicates(personaClusterRoles)).
+		Complete(r)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package personarole
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeCRD(group string, plural string, scope apiextensionsv1.ResourceScope, status bool) *apiextensionsv1.CustomResourceDefinition {
+	version := apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true}
+	if status {
+		version.Subresources = &apiextensionsv1.CustomResourceSubresources{Status: &apiextensionsv1.CustomResourceSubresourceStatus{}}
+	}
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural + "." + group},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    group,
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: plural},
+			Scope:    scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{version},
+		},
+	}
+}
+
+func newTestReconciler(t *testing.T, objects ...client.Object) *PersonaRoleReconciler {
+	s := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(s))
+	require.NoError(t, rbacv1.AddToScheme(s))
+	return &PersonaRoleReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+		Log:    logr.Discard(),
+		Scheme: s,
+	}
+}
+
+func getRole(t *testing.T, reconciler *PersonaRoleReconciler, name string) *rbacv1.ClusterRole {
+	t.Helper()
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: name}, role))
+	return role
+}
+
+func TestPersonaRoleReconciler(t *testing.T) {
+	reconciler := newTestReconciler(t,
+		makeCRD(constants.KServeAPIGroupName, "inferenceservices", apiextensionsv1.NamespaceScoped, true),
+		makeCRD(constants.KServeAPIGroupName, "servingruntimes", apiextensionsv1.NamespaceScoped, false),
+		makeCRD(constants.KServeAPIGroupName, "clusterservingruntimes", apiextensionsv1.ClusterScoped, false),
+		makeCRD("keda.sh", "scaledobjects", apiextensionsv1.NamespaceScoped, true),
+	)
+	_, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: personaRolesRequest.NamespacedName})
+	require.NoError(t, err)
+
+	readRule := rbacv1.PolicyRule{
+		APIGroups: []string{constants.KServeAPIGroupName},
+		Resources: []string{"clusterservingruntimes", "inferenceservices", "inferenceservices/status", "servingruntimes"},
+		Verbs:     []string{"get", "list", "watch"},
+	}
+	viewer := getRole(t, reconciler, ViewerRoleName)
+	assert.Equal(t, map[string]string{constants.PersonaRoleLabel: ViewerRoleName, aggregateToViewLabel: "true"}, viewer.Labels)
+	assert.Equal(t, []rbacv1.PolicyRule{readRule}, viewer.Rules)
+
+	editor := getRole(t, reconciler, EditorRoleName)
+	assert.Equal(t, "true", editor.Labels[aggregateToEditLabel])
+	assert.Equal(t, []rbacv1.PolicyRule{readRule, {
+		APIGroups: []string{constants.KServeAPIGroupName},
+		Resources: []string{"inferenceservices"},
+		Verbs:     []string{"create", "delete", "deletecollection", "patch", "update"},
+	}}, editor.Rules)
+
+	runtimeAdmin := getRole(t, reconciler, RuntimeAdminRoleName)
+	assert.Equal(t, map[string]string{constants.PersonaRoleLabel: RuntimeAdminRoleName}, runtimeAdmin.Labels)
+	assert.Equal(t, []string{"clusterservingruntimes", "servingruntimes"}, runtimeAdmin.Rules[1].Resources)
+
+	// the ClusterRoles follow the CRDs installed and revert the changes made to them
+	require.NoError(t, reconciler.Create(t.Context(),
+		makeCRD(constants.KServeAPIGroupName, "inferencegraphs", apiextensionsv1.NamespaceScoped, true)))
+	viewer.Rules = nil
+	require.NoError(t, reconciler.Update(t.Context(), viewer))
+	_, err = reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: personaRolesRequest.NamespacedName})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"clusterservingruntimes", "inferencegraphs", "inferencegraphs/status", "inferenceservices", "inferenceservices/status", "servingruntimes",
+	}, getRole(t, reconciler, ViewerRoleName).Rules[0].Resources)
+	assert.Equal(t, []string{"inferencegraphs", "inferenceservices"}, getRole(t, reconciler, EditorRoleName).Rules[1].Resources)
+}
+
+func TestPersonaRoleEditorCannotWriteQuotas(t *testing.T) {
+	reconciler := newTestReconciler(t,
+		makeCRD(constants.KServeAPIGroupName, "inferenceservices", apiextensionsv1.NamespaceScoped, true),
+		makeCRD(constants.KServeAPIGroupName, "servingquotas", apiextensionsv1.NamespaceScoped, true),
+		makeCRD(constants.KServeAPIGroupName, "modelcheckpoints", apiextensionsv1.NamespaceScoped, true),
+	)
+	_, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: personaRolesRequest.NamespacedName})
+	require.NoError(t, err)
+
+	// the editors of a namespace cannot raise or delete the quotas limiting them
+	for _, rule := range getRole(t, reconciler, EditorRoleName).Rules {
+		for _, resource := range []string{"servingquotas", "modelcheckpoints"} {
+			if slices.Contains(rule.Resources, resource) {
+				assert.Equal(t, readVerbs, rule.Verbs, resource)
+			}
+		}
+	}
+	assert.Equal(t, []string{"modelcheckpoints", "servingquotas"}, getRole(t, reconciler, RuntimeAdminRoleName).Rules[1].Resources)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+package podmutator
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// RetryPeriod is the period an unmutated pod is reconciled again with, while the webhook server has not started,
+	// the eviction is disrupting or the start of the hashing of the mutated pods is not recorded yet
+	RetryPeriod = time.Minute
+	// EvictionInterval is the minimum interval between the evictions of the unmutated pods, so that the pods admitted
+	// without mutation during an outage of the webhook are not all recreated at once
+	EvictionInterval = 5 * time.Second
+)
+
+// UnmutatedPodEvicted is the reason of the events recorded on the pods evicted to be recreated mutated
+const UnmutatedPodEvicted = "UnmutatedPodEvicted"
+
+// PodMutatorReconciler applies the pod mutator configuration to the pod mutating webhook: the namespace selector
+// restricts the namespaces the pods of which are mutated, and the webhook fails open when configured. The pods of
+// InferenceServices admitted without mutation while a fail-open webhook was unavailable are then evicted at a
+// limited rate, so that their controller recreates them through the webhook. The webhook configuration is
+// reconciled with requests without a namespace, once the inferenceservice configmap is reloaded.
+type PodMutatorReconciler struct {
+	client.Client
+	Clientset kubernetes.Interface
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+	// WebhookStarted checks that the webhook server of the manager serves. The unmutated pods are not evicted before,
+	// as they would be recreated without mutation again. The webhook server is assumed to serve when nil.
+	WebhookStarted healthz.Checker
+	// ConfigReloads receives an event once the inferenceservice-config ConfigMap is reloaded, so that the pod mutator
+	// configuration is applied to the webhook configuration
+	ConfigReloads <-chan event.GenericEvent
+	// EvictionLimiter limits the rate of the evictions of the unmutated pods, one every EvictionInterval when nil
+	EvictionLimiter *rate.Limiter
+}
+
+func (r *PodMutatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, r.Clientset)
+	if err != nil {
+		r.Log.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
+		return ctrl.Result{}, err
+	}
+	podMutatorConfig, err := v1beta1.NewPodMutatorConfig(isvcConfigMap)
+	if err != nil {
+		r.Log.Error(err, "unable to get pod mutator config")
+		return ctrl.Result{}, err
+	}
+	if req.Namespace == "" {
+		return ctrl.Result{}, r.reconcileWebhookConfiguration(ctx, podMutatorConfig)
+	}
+	return r.reconcilePod(ctx, req.NamespacedName, podMutatorConfig)
+}
+
+// reconcileWebhookConfiguration updates the namespace selector and the failure policy of the pod mutating webhook,
+// and records the time the mutated pods are hashed since
+func (r *PodMutatorReconciler) reconcileWebhookConfiguration(ctx context.Context, podMutatorConfig *v1beta1.PodMutatorConfig) error {
+	webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := r.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceMutatingWebhookConfigurationName}, webhookConfiguration); err != nil {
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	namespaceSelector := WebhookNamespaceSelector(podMutatorConfig)
+	failurePolicy := admissionregistrationv1.Fail
+	if podMutatorConfig.FailOpen {
+		failurePolicy = admissionregistrationv1.Ignore
+	}
+	updated := false
+	if _, recorded := webhookConfiguration.Annotations[constants.PodMutatorConfigHashSinceAnnotationKey]; !recorded {
+		if webhookConfiguration.Annotations == nil {
+			webhookConfiguration.Annotations = map[string]string{}
+		}
+		webhookConfiguration.Annotations[constants.PodMutatorConfigHashSinceAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+		updated = true
+	}
+	for i := range webhookConfiguration.Webhooks {
+		webhook := &webhookConfiguration.Webhooks[i]
+		if webhook.Name != constants.PodMutatorWebhookConfigName {
+			continue
+		}
+		if equality.Semantic.DeepEqual(webhook.NamespaceSelector, namespaceSelector) &&
+			webhook.FailurePolicy != nil && *webhook.FailurePolicy == failurePolicy {
+			continue
+		}
+		webhook.NamespaceSelector = namespaceSelector
+		webhook.FailurePolicy = &failurePolicy
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	if err := r.Update(ctx, webhookConfiguration); err != nil {
+		return err
+	}
+	r.Log.Info("Updated the pod mutating webhook", "namespaceSelector", metav1.FormatLabelSelector(namespaceSelector), "failurePolicy", failurePolicy)
+	return nil
+}
+
+// WebhookNamespaceSelector returns the namespace selector of the pod mutating webhook, which excludes the namespaces
+// labeled control-plane in addition to the configured selector
+func WebhookNamespaceSelector(podMutatorConfig *v1beta1.PodMutatorConfig) *metav1.LabelSelector {
+	namespaceSelector := &metav1.LabelSelector{}
+	if podMutatorConfig.NamespaceSelector != nil {
+		namespaceSelector = podMutatorConfig.NamespaceSelector.DeepCopy()
+	}
+	namespaceSelector.MatchExpressions = append(namespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      constants.ControlPlaneLabel,
+		Operator: metav1.LabelSelectorOpDoesNotExist,
+	})
+	return namespaceSelector
+}
+
+// reconcilePod evicts a pod of an InferenceService admitted without mutation by the fail-open webhook, once the
+// webhook serves again
+func (r *PodMutatorReconciler) reconcilePod(ctx context.Context, name types.NamespacedName, podMutatorConfig *v1beta1.PodMutatorConfig) (ctrl.Result, error) {
+	// The pods admitted without mutation are rejected by the webhook failing closed
+	if !podMutatorConfig.FailOpen {
+		return ctrl.Result{}, nil
+	}
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, name, pod); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	// The pods without controller would not be recreated
+	owner := metav1.GetControllerOf(pod)
+	if !isUnmutated(pod) || pod.DeletionTimestamp != nil || owner == nil {
+		return ctrl.Result{}, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.Namespace}, namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(WebhookNamespaceSelector(podMutatorConfig))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+		return ctrl.Result{}, nil
+	}
+	// The pods created before the pod mutator hashed the mutated pods were mutated without the hash
+	hashedSince, err := r.hashedSince(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if hashedSince.IsZero() {
+		return ctrl.Result{RequeueAfter: RetryPeriod}, nil
+	}
+	if pod.CreationTimestamp.Time.Before(hashedSince) {
+		return ctrl.Result{}, nil
+	}
+	if r.WebhookStarted != nil {
+		if err := r.WebhookStarted(nil); err != nil {
+			r.Log.Info("Waiting for the webhook server to evict the unmutated pod", "pod", name, "reason", err.Error())
+			return ctrl.Result{RequeueAfter: RetryPeriod}, nil
+		}
+	}
+	reservation := r.EvictionLimiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	// The eviction respects the disruption budget of the InferenceService
+	eviction := &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &pod.UID}},
+	}
+	if err := r.Clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil {
+		if apierr.IsTooManyRequests(err) {
+			r.Log.Info("Waiting for the disruption budget to evict the unmutated pod", "pod", name, "reason", err.Error())
+			return ctrl.Result{RequeueAfter: RetryPeriod}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	r.Log.Info("Evicted the pod admitted without mutation", "pod", name, "owner", owner.Kind+"/"+owner.Name)
+	r.Recorder.Eventf(pod, corev1.EventTypeWarning, UnmutatedPodEvicted,
+		"The pod was admitted without mutation while the pod mutating webhook was unavailable, evicted to be recreated by %s %q",
+		owner.Kind, owner.Name)
+	return ctrl.Result{}, nil
+}
+
+// hashedSince returns the time the mutated pods are hashed since, recorded on the webhook configuration, or the zero
+// time when it is not recorded yet
+func (r *PodMutatorReconciler) hashedSince(ctx context.Context) (time.Time, error) {
+	webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := r.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceMutatingWebhookConfigurationName}, webhookConfiguration); err != nil {
+		return time.Time{}, client.IgnoreNotFound(err)
+	}
+	since, recorded := webhookConfiguration.Annotations[constants.PodMutatorConfigHashSinceAnnotationKey]
+	if !recorded {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, since)
+}
+
+// isUnmutated returns whether a pod of an InferenceService has not been mutated by the pod mutator, which records
+// the hash of its configuration on the mutated pods. The pods created before the hashing are filtered out on
+// reconcile.
+func isUnmutated(obj client.Object) bool {
+	_, isvcPod := obj.GetLabels()[constants.InferenceServicePodLabelKey]
+	_, mutated := obj.GetAnnotations()[constants.PodMutatorConfigHashInternalAnnotationKey]
+	return isvcPod && !mutated
+}
+
+func (r *PodMutatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	webhookConfigurationPredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == constants.InferenceServiceMutatingWebhookConfigurationName
+	})
+	if r.EvictionLimiter == nil {
+		r.EvictionLimiter = rate.NewLimiter(rate.Every(EvictionInterval), 1)
+	}
+	// The pods are cached by the manager with the label of the InferenceServices only
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		Named("podmutator").
+		For(&corev1.Pod{}, builder.WithPredicates(predicate.NewPredicateFuncs(isUnmutated))).
+		Watches(&admissionregistrationv1.MutatingWebhookConfiguration{}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(webhookConfigurationPredicate))
+	if r.ConfigReloads != nil {
+		ctrlBuilder = ctrlBuilder.WatchesRawSource(source.Channel(r.ConfigReloads, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, _ client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{
+					Name: constants.InferenceServiceMutatingWebhookConfigurationName,
+				}}}
+			})))
+	}
+	return ctrlBuilder.Complete(r)
+}
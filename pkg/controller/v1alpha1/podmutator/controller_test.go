@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podmutator
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const namespace = "models"
+
+func newTestReconciler(t *testing.T, podMutatorConfig string, objects ...client.Object) (*PodMutatorReconciler, *record.FakeRecorder) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data:       map[string]string{},
+	}
+	if podMutatorConfig != "" {
+		configMap.Data[v1beta1.PodMutatorConfigName] = podMutatorConfig
+	}
+	clientset := kubefake.NewSimpleClientset(configMap)
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return action.GetSubresource() == "eviction", nil, nil
+	})
+	recorder := record.NewFakeRecorder(10)
+	return &PodMutatorReconciler{
+		Client:          fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+		Clientset:       clientset,
+		Log:             logr.Discard(),
+		Scheme:          s,
+		Recorder:        recorder,
+		EvictionLimiter: rate.NewLimiter(rate.Every(EvictionInterval), 1),
+	}, recorder
+}
+
+// evictions returns the names of the pods evicted through the clientset
+func evictions(r *PodMutatorReconciler) []string {
+	var names []string
+	for _, action := range r.Clientset.(*kubefake.Clientset).Actions() {
+		if create, ok := action.(k8stesting.CreateAction); ok && action.GetSubresource() == "eviction" {
+			names = append(names, create.GetObject().(*policyv1.Eviction).Name)
+		}
+	}
+	return names
+}
+
+func makeWebhookConfiguration() *admissionregistrationv1.MutatingWebhookConfiguration {
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constants.InferenceServiceMutatingWebhookConfigurationName,
+			Annotations: map[string]string{
+				constants.PodMutatorConfigHashSinceAnnotationKey: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			},
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:          "inferenceservice.kserve-webhook-server.defaulter",
+				FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+			},
+			{
+				Name:          constants.PodMutatorWebhookConfigName,
+				FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+			},
+		},
+	}
+}
+
+func makeNamespace(labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: labels}}
+}
+
+func makePod(annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "sklearn-predictor-7d9f8-abcde",
+			Namespace:         namespace,
+			UID:               "pod-uid",
+			CreationTimestamp: metav1.Now(),
+			Labels:            map[string]string{constants.InferenceServicePodLabelKey: "sklearn"},
+			Annotations:       annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "sklearn-predictor-7d9f8",
+				UID:        "rs-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+}
+
+func podRequest() ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "sklearn-predictor-7d9f8-abcde"}}
+}
+
+func TestReconcileWebhookConfiguration(t *testing.T) {
+	unrecorded := makeWebhookConfiguration()
+	unrecorded.Annotations = nil
+	r, _ := newTestReconciler(t, `{"namespaceSelector": {"matchLabels": {"serving.kserve.io/enabled": "true"}}, "failOpen": true}`,
+		unrecorded)
+	result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{
+		Name: constants.InferenceServiceMutatingWebhookConfigurationName,
+	}})
+	require.NoError(t, err)
+	// The webhook configuration is reconciled again once the configmap is reloaded
+	assert.Zero(t, result.RequeueAfter)
+
+	webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: constants.InferenceServiceMutatingWebhookConfigurationName}, webhookConfiguration))
+	since, err := time.Parse(time.RFC3339, webhookConfiguration.Annotations[constants.PodMutatorConfigHashSinceAnnotationKey])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), since, time.Minute)
+	// Only the pod mutating webhook is updated
+	assert.Nil(t, webhookConfiguration.Webhooks[0].NamespaceSelector)
+	assert.Equal(t, admissionregistrationv1.Fail, *webhookConfiguration.Webhooks[0].FailurePolicy)
+	assert.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"serving.kserve.io/enabled": "true"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: constants.ControlPlaneLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	}, webhookConfiguration.Webhooks[1].NamespaceSelector)
+	assert.Equal(t, admissionregistrationv1.Ignore, *webhookConfiguration.Webhooks[1].FailurePolicy)
+}
+
+func TestReconcileWebhookConfigurationDefaults(t *testing.T) {
+	r, _ := newTestReconciler(t, "", makeWebhookConfiguration())
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{
+		Name: constants.InferenceServiceMutatingWebhookConfigurationName,
+	}})
+	require.NoError(t, err)
+
+	webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: constants.InferenceServiceMutatingWebhookConfigurationName}, webhookConfiguration))
+	assert.Equal(t, &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: constants.ControlPlaneLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+	}}, webhookConfiguration.Webhooks[1].NamespaceSelector)
+	assert.Equal(t, admissionregistrationv1.Fail, *webhookConfiguration.Webhooks[1].FailurePolicy)
+}
+
+func TestReconcileUnmutatedPod(t *testing.T) {
+	failOpen := `{"namespaceSelector": {"matchLabels": {"serving.kserve.io/enabled": "true"}}, "failOpen": true}`
+	selected := map[string]string{"serving.kserve.io/enabled": "true"}
+	mutated := map[string]string{constants.PodMutatorConfigHashInternalAnnotationKey: "abc"}
+
+	scenarios := map[string]struct {
+		config               string
+		namespace            *corev1.Namespace
+		pod                  func() *corev1.Pod
+		webhookConfiguration func() *admissionregistrationv1.MutatingWebhookConfiguration
+		started              error
+		evicted              bool
+		requeued             bool
+	}{
+		"UnmutatedPodIsEvicted": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+			evicted:   true,
+		},
+		"PodCreatedBeforeHashing": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod: func() *corev1.Pod {
+				pod := makePod(nil)
+				pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				return pod
+			},
+		},
+		"HashingNotRecorded": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+			webhookConfiguration: func() *admissionregistrationv1.MutatingWebhookConfiguration {
+				webhookConfiguration := makeWebhookConfiguration()
+				webhookConfiguration.Annotations = nil
+				return webhookConfiguration
+			},
+			requeued: true,
+		},
+		"MutatedPodIsKept": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod:       func() *corev1.Pod { return makePod(mutated) },
+		},
+		"FailClosedWebhook": {
+			config:    `{"namespaceSelector": {"matchLabels": {"serving.kserve.io/enabled": "true"}}}`,
+			namespace: makeNamespace(selected),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+		},
+		"NamespaceNotSelected": {
+			config:    failOpen,
+			namespace: makeNamespace(nil),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+		},
+		"ControlPlaneNamespace": {
+			config:    `{"failOpen": true}`,
+			namespace: makeNamespace(map[string]string{constants.ControlPlaneLabel: "kserve-controller-manager"}),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+		},
+		"PodWithoutController": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod: func() *corev1.Pod {
+				pod := makePod(nil)
+				pod.OwnerReferences = nil
+				return pod
+			},
+		},
+		"WebhookServerNotStarted": {
+			config:    failOpen,
+			namespace: makeNamespace(selected),
+			pod:       func() *corev1.Pod { return makePod(nil) },
+			started:   errors.New("webhook server has not been started yet"),
+			requeued:  true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			webhookConfiguration := makeWebhookConfiguration()
+			if scenario.webhookConfiguration != nil {
+				webhookConfiguration = scenario.webhookConfiguration()
+			}
+			r, recorder := newTestReconciler(t, scenario.config, scenario.namespace, scenario.pod(), webhookConfiguration)
+			r.WebhookStarted = func(req *http.Request) error { return scenario.started }
+			result, err := r.Reconcile(t.Context(), podRequest())
+			require.NoError(t, err)
+
+			if scenario.evicted {
+				assert.Equal(t, []string{"sklearn-predictor-7d9f8-abcde"}, evictions(r))
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, UnmutatedPodEvicted)
+			} else {
+				assert.Empty(t, evictions(r))
+				assert.Empty(t, recorder.Events)
+			}
+			if scenario.requeued {
+				assert.Equal(t, RetryPeriod, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestReconcileUnmutatedPodRateLimit(t *testing.T) {
+	r, _ := newTestReconciler(t, `{"failOpen": true}`, makeNamespace(nil), makePod(nil), makeWebhookConfiguration())
+	_, err := r.Reconcile(t.Context(), podRequest())
+	require.NoError(t, err)
+	assert.Len(t, evictions(r), 1)
+
+	// The next eviction waits for the eviction interval
+	result, err := r.Reconcile(t.Context(), podRequest())
+	require.NoError(t, err)
+	assert.Len(t, evictions(r), 1)
+	assert.Positive(t, result.RequeueAfter)
+	assert.LessOrEqual(t, result.RequeueAfter, EvictionInterval)
+}
+
+func TestWebhookNamespaceSelector(t *testing.T) {
+	configured := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}
+	selector := WebhookNamespaceSelector(&v1beta1.PodMutatorConfig{NamespaceSelector: configured})
+	assert.Len(t, selector.MatchExpressions, 1)
+	// The configured selector is not modified
+	assert.Empty(t, configured.MatchExpressions)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=servingruntimes;clusterservingruntimes,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+package servingruntime
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// DeprecatedRuntimeInUse is the reason of the events recorded on a deprecated runtime still used by InferenceServices
+const DeprecatedRuntimeInUse = "DeprecatedRuntimeInUse"
+
+// DeprecatedRuntimeReconciler reports the InferenceServices using a deprecated ServingRuntime or ClusterServingRuntime
+// in the status of the runtime, so that their migration to the replacement runtime can be planned.
+// ClusterServingRuntimes are reconciled with requests without a namespace.
+type DeprecatedRuntimeReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func (r *DeprecatedRuntimeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var runtimeObj client.Object
+	var spec *v1alpha1.ServingRuntimeSpec
+	var status *v1alpha1.ServingRuntimeStatus
+	if req.Namespace == "" {
+		clusterRuntime := &v1alpha1.ClusterServingRuntime{}
+		runtimeObj, spec, status = clusterRuntime, &clusterRuntime.Spec, &clusterRuntime.Status
+	} else {
+		servingRuntime := &v1alpha1.ServingRuntime{}
+		runtimeObj, spec, status = servingRuntime, &servingRuntime.Spec, &servingRuntime.Status
+	}
+	if err := r.Get(ctx, req.NamespacedName, runtimeObj); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	var users []string
+	if spec.IsDeprecated() {
+		isvcList := &v1beta1.InferenceServiceList{}
+		if err := r.List(ctx, isvcList, client.InNamespace(req.Namespace)); err != nil {
+			return ctrl.Result{}, err
+		}
+		for _, isvc := range isvcList.Items {
+			if runtimeRequest(&isvc) == (reconcile.Request{NamespacedName: req.NamespacedName}) {
+				users = append(users, isvc.Namespace+"/"+isvc.Name)
+			}
+		}
+		slices.Sort(users)
+	}
+	if slices.Equal(users, status.DeprecatedRuntimeUsers) {
+		return ctrl.Result{}, nil
+	}
+
+	// The runtimes have no status subresource, the status is updated with the runtime
+	status.DeprecatedRuntimeUsers = users
+	if err := r.Update(ctx, runtimeObj); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Log.Info("Updated the InferenceServices using the deprecated runtime", "runtime", req.NamespacedName, "count", len(users))
+	if len(users) > 0 {
+		replacement := ""
+		if spec.ReplacedBy != nil && *spec.ReplacedBy != "" {
+			replacement = ", they should be migrated to the runtime " + *spec.ReplacedBy
+		}
+		r.Recorder.Eventf(runtimeObj, corev1.EventTypeWarning, DeprecatedRuntimeInUse,
+			"The deprecated runtime is used by %d InferenceServices%s", len(users), replacement)
+	}
+	return ctrl.Result{}, nil
+}
+
+// runtimeRequest returns the request of the runtime used by an InferenceService, which is empty when no runtime has
+// been selected yet
+func runtimeRequest(isvc *v1beta1.InferenceService) reconcile.Request {
+	if isvc.Status.ServingRuntimeName != "" {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Status.ServingRuntimeName}}
+	}
+	if isvc.Status.ClusterServingRuntimeName != "" {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: isvc.Status.ClusterServingRuntimeName}}
+	}
+	return reconcile.Request{}
+}
+
+// enqueueRuntime enqueues the runtime used by an InferenceService
+func enqueueRuntime(obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	isvc, ok := obj.(*v1beta1.InferenceService)
+	if !ok {
+		return
+	}
+	if req := runtimeRequest(isvc); req.Name != "" {
+		q.Add(req)
+	}
+}
+
+func (r *DeprecatedRuntimeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	deprecationPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(deprecation(e.ObjectOld), deprecation(e.ObjectNew))
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool { return false },
+	}
+	// InferenceServices only change the report when they are created, deleted, or switch runtimes
+	isvcHandler := handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueRuntime(e.Object, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			oldIsvc, oldOk := e.ObjectOld.(*v1beta1.InferenceService)
+			newIsvc, newOk := e.ObjectNew.(*v1beta1.InferenceService)
+			if !oldOk || !newOk || runtimeRequest(oldIsvc) == runtimeRequest(newIsvc) {
+				return
+			}
+			enqueueRuntime(oldIsvc, q)
+			enqueueRuntime(newIsvc, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueRuntime(e.Object, q)
+		},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("deprecatedservingruntime").
+		Watches(&v1alpha1.ServingRuntime{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(deprecationPredicate)).
+		Watches(&v1alpha1.ClusterServingRuntime{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(deprecationPredicate)).
+		Watches(&v1beta1.InferenceService{}, isvcHandler).
+		Complete(r)
+}
+
+// deprecation returns the deprecation fields of a runtime
+func deprecation(obj client.Object) []any {
+	switch runtimeObj := obj.(type) {
+	case *v1alpha1.ServingRuntime:
+		return []any{runtimeObj.Spec.Deprecated, runtimeObj.Spec.ReplacedBy}
+	case *v1alpha1.ClusterServingRuntime:
+		return []any{runtimeObj.Spec.Deprecated, runtimeObj.Spec.ReplacedBy}
+	}
+	return nil
+}
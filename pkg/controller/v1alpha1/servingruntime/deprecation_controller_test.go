@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntime
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func makeIsvc(namespace string, name string, servingRuntime string, clusterServingRuntime string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: v1beta1.InferenceServiceStatus{
+			ServingRuntimeName:        servingRuntime,
+			ClusterServingRuntimeName: clusterServingRuntime,
+		},
+	}
+}
+
+func newTestReconciler(t *testing.T, objects ...client.Object) (*DeprecatedRuntimeReconciler, *record.FakeRecorder) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+	recorder := record.NewFakeRecorder(10)
+	return &DeprecatedRuntimeReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+		Log:      logr.Discard(),
+		Scheme:   s,
+		Recorder: recorder,
+	}, recorder
+}
+
+func TestDeprecatedRuntimeReconciler_ClusterServingRuntime(t *testing.T) {
+	clusterRuntime := &v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-legacy"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			Deprecated: ptr.To(true),
+			ReplacedBy: ptr.To("kserve-sklearnserver"),
+		},
+	}
+	reconciler, recorder := newTestReconciler(t, clusterRuntime,
+		makeIsvc("ns2", "b", "", "sklearn-legacy"),
+		makeIsvc("ns1", "a", "", "sklearn-legacy"),
+		// a namespaced runtime with the same name is a different runtime
+		makeIsvc("ns1", "c", "sklearn-legacy", ""),
+		makeIsvc("ns1", "d", "", "kserve-sklearnserver"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sklearn-legacy"}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	updated := &v1alpha1.ClusterServingRuntime{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, updated))
+	assert.Equal(t, []string{"ns1/a", "ns2/b"}, updated.Status.DeprecatedRuntimeUsers)
+	assert.Equal(t, "Warning DeprecatedRuntimeInUse The deprecated runtime is used by 2 InferenceServices, "+
+		"they should be migrated to the runtime kserve-sklearnserver", <-recorder.Events)
+
+	// The report is cleared once the runtime is no longer deprecated
+	updated.Spec.Deprecated = nil
+	require.NoError(t, reconciler.Update(t.Context(), updated))
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, updated))
+	assert.Empty(t, updated.Status.DeprecatedRuntimeUsers)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestDeprecatedRuntimeReconciler_ServingRuntime(t *testing.T) {
+	servingRuntime := &v1alpha1.ServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "sklearn-legacy"},
+		Spec:       v1alpha1.ServingRuntimeSpec{Deprecated: ptr.To(true)},
+	}
+	reconciler, recorder := newTestReconciler(t, servingRuntime,
+		makeIsvc("ns1", "a", "sklearn-legacy", ""),
+		makeIsvc("ns2", "b", "sklearn-legacy", ""),
+		makeIsvc("ns1", "c", "", "sklearn-legacy"),
+	)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "sklearn-legacy"}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	updated := &v1alpha1.ServingRuntime{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, updated))
+	assert.Equal(t, []string{"ns1/a"}, updated.Status.DeprecatedRuntimeUsers)
+	assert.Equal(t, "Warning DeprecatedRuntimeInUse The deprecated runtime is used by 1 InferenceServices", <-recorder.Events)
+
+	// An unchanged report is not updated again
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestRuntimeRequest(t *testing.T) {
+	assert.Equal(t, types.NamespacedName{Namespace: "ns1", Name: "rt"}, runtimeRequest(makeIsvc("ns1", "a", "rt", "")).NamespacedName)
+	assert.Equal(t, types.NamespacedName{Name: "crt"}, runtimeRequest(makeIsvc("ns1", "a", "", "crt")).NamespacedName)
+	assert.Empty(t, runtimeRequest(makeIsvc("ns1", "a", "", "")).Name)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=servingruntimecatalogs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=servingruntimecatalogs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+package servingruntimecatalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/kmp"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// DefaultSyncInterval is the interval between two syncs of a catalog without an interval
+	DefaultSyncInterval = time.Hour
+
+	// CatalogSynced is the condition type reporting whether the last sync of a catalog succeeded
+	CatalogSynced = "Synced"
+
+	// maxEventDiffSize is the maximum size of the diff recorded in an event, events over 1KiB being truncated
+	maxEventDiffSize = 800
+)
+
+// Event reasons
+const (
+	RuntimeCreated    = "RuntimeCreated"
+	RuntimeUpdated    = "RuntimeUpdated"
+	RuntimeDryRun     = "RuntimeDryRun"
+	RuntimeConflict   = "RuntimeConflict"
+	RuntimeRemoved    = "RuntimeRemovedFromCatalog"
+	RuntimeSyncFailed = "RuntimeSyncFailed"
+	CatalogSyncFailed = "CatalogSyncFailed"
+)
+
+// ServingRuntimeCatalogReconciler syncs the ClusterServingRuntimes of a ServingRuntimeCatalog from its source.
+// Every change is first applied with a server-side dry run, and the resulting diff is recorded as an event on the
+// catalog before the change is applied.
+type ServingRuntimeCatalogReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Fetcher  Fetcher
+}
+
+func (r *ServingRuntimeCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	catalog := &v1alpha1.ServingRuntimeCatalog{}
+	if err := r.Get(ctx, req.NamespacedName, catalog); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if catalog.Spec.Suspend {
+		r.Log.Info("Catalog sync is suspended", "catalog", catalog.Name)
+		return ctrl.Result{}, nil
+	}
+
+	interval := DefaultSyncInterval
+	if catalog.Spec.Interval != nil && catalog.Spec.Interval.Duration > 0 {
+		interval = catalog.Spec.Interval.Duration
+	}
+	// Only sync on schedule, unless the spec has changed since the last sync
+	if catalog.Status.LastSyncTime != nil && catalog.Status.ObservedGeneration == catalog.Generation {
+		if wait := time.Until(catalog.Status.LastSyncTime.Add(interval)); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	r.Log.Info("Syncing catalog", "catalog", catalog.Name)
+	fetched, err := r.Fetcher.Fetch(ctx, catalog.Spec.Source)
+	if err != nil {
+		r.Recorder.Eventf(catalog, corev1.EventTypeWarning, CatalogSyncFailed, "Failed to fetch the catalog: %v", err)
+		if statusErr := r.updateStatus(ctx, catalog, metav1.ConditionFalse, "FetchFailed", err.Error()); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	var syncErrs []error
+	changed := 0
+	names := make([]string, 0, len(fetched.Runtimes))
+	for i := range fetched.Runtimes {
+		csr := &fetched.Runtimes[i]
+		names = append(names, csr.Name)
+		updated, err := r.syncRuntime(ctx, catalog, csr)
+		if err != nil {
+			r.Recorder.Eventf(catalog, corev1.EventTypeWarning, RuntimeSyncFailed,
+				"Failed to sync ClusterServingRuntime %q: %v", csr.Name, err)
+			syncErrs = append(syncErrs, err)
+			continue
+		}
+		if updated {
+			changed++
+		}
+	}
+	slices.Sort(names)
+	for _, name := range catalog.Status.Runtimes {
+		if _, found := slices.BinarySearch(names, name); !found {
+			// Removed runtimes are left in place, as InferenceServices may still be using them
+			r.Recorder.Eventf(catalog, corev1.EventTypeNormal, RuntimeRemoved,
+				"ClusterServingRuntime %q was removed from the catalog and is no longer synced", name)
+		}
+	}
+	if err := errors.Join(syncErrs...); err != nil {
+		if statusErr := r.updateStatus(ctx, catalog, metav1.ConditionFalse, "SyncFailed", err.Error()); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	catalog.Status.LastSyncTime = &now
+	catalog.Status.Revision = fetched.Revision
+	catalog.Status.Runtimes = names
+	reason, message := "Synced", fmt.Sprintf("%d ClusterServingRuntimes synced, %d changed", len(names), changed)
+	if catalog.Spec.DryRun {
+		reason, message = "DryRun", fmt.Sprintf("%d ClusterServingRuntimes synced in dry run mode, %d would be changed", len(names), changed)
+	}
+	if err := r.updateStatus(ctx, catalog, metav1.ConditionTrue, reason, message); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// syncRuntime creates or updates a ClusterServingRuntime of the catalog, returning whether it was changed.
+// A runtime that exists but was not synced from this catalog is left untouched.
+func (r *ServingRuntimeCatalogReconciler) syncRuntime(ctx context.Context, catalog *v1alpha1.ServingRuntimeCatalog,
+	csr *v1alpha1.ClusterServingRuntime,
+) (bool, error) {
+	desired := &v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        csr.Name,
+			Labels:      maps.Clone(csr.Labels),
+			Annotations: maps.Clone(csr.Annotations),
+		},
+		Spec: csr.Spec,
+	}
+	if desired.Labels == nil {
+		desired.Labels = map[string]string{}
+	}
+	desired.Labels[constants.ServingRuntimeCatalogLabel] = catalog.Name
+
+	existing := &v1alpha1.ClusterServingRuntime{}
+	err := r.Get(ctx, types.NamespacedName{Name: csr.Name}, existing)
+	if err != nil && !apierr.IsNotFound(err) {
+		return false, err
+	}
+	if apierr.IsNotFound(err) {
+		if err := r.Create(ctx, desired.DeepCopy(), client.DryRunAll); err != nil {
+			return false, err
+		}
+		if catalog.Spec.DryRun {
+			r.Recorder.Eventf(catalog, corev1.EventTypeNormal, RuntimeDryRun,
+				"ClusterServingRuntime %q would be created", csr.Name)
+			return true, nil
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		r.Recorder.Eventf(catalog, corev1.EventTypeNormal, RuntimeCreated, "Created ClusterServingRuntime %q", csr.Name)
+		return true, nil
+	}
+
+	if owner := existing.Labels[constants.ServingRuntimeCatalogLabel]; owner != catalog.Name {
+		r.Recorder.Eventf(catalog, corev1.EventTypeWarning, RuntimeConflict,
+			"ClusterServingRuntime %q already exists and is not managed by this catalog", csr.Name)
+		return false, nil
+	}
+
+	// Keep the labels and annotations added to the runtime by other controllers
+	update := existing.DeepCopy()
+	update.Spec = desired.Spec
+	maps.Copy(update.Labels, desired.Labels)
+	if len(desired.Annotations) > 0 {
+		if update.Annotations == nil {
+			update.Annotations = map[string]string{}
+		}
+		maps.Copy(update.Annotations, desired.Annotations)
+	}
+	dryRun := update.DeepCopy()
+	if err := r.Update(ctx, dryRun, client.DryRunAll); err != nil {
+		return false, err
+	}
+	diff, err := runtimeDiff(existing, dryRun)
+	if err != nil {
+		return false, err
+	}
+	if diff == "" {
+		return false, nil
+	}
+	if catalog.Spec.DryRun {
+		r.Recorder.Eventf(catalog, corev1.EventTypeNormal, RuntimeDryRun,
+			"ClusterServingRuntime %q would be updated: %s", csr.Name, diff)
+		return true, nil
+	}
+	if err := r.Update(ctx, update); err != nil {
+		return false, err
+	}
+	r.Recorder.Eventf(catalog, corev1.EventTypeNormal, RuntimeUpdated,
+		"Updated ClusterServingRuntime %q: %s", csr.Name, diff)
+	return true, nil
+}
+
+// runtimeDiff returns the diff of the labels, annotations and spec of two ClusterServingRuntimes, truncated to fit
+// in an event
+func runtimeDiff(existing, updated *v1alpha1.ClusterServingRuntime) (string, error) {
+	type content struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Spec        v1alpha1.ServingRuntimeSpec
+	}
+	diff, err := kmp.SafeDiff(
+		content{Labels: existing.Labels, Annotations: existing.Annotations, Spec: existing.Spec},
+		content{Labels: updated.Labels, Annotations: updated.Annotations, Spec: updated.Spec},
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(diff) > maxEventDiffSize {
+		diff = diff[:maxEventDiffSize] + "... (truncated)"
+	}
+	return diff, nil
+}
+
+func (r *ServingRuntimeCatalogReconciler) updateStatus(ctx context.Context, catalog *v1alpha1.ServingRuntimeCatalog,
+	status metav1.ConditionStatus, reason string, message string,
+) error {
+	// The generation is only observed once synced, so that a failed sync of a changed spec is retried
+	if status == metav1.ConditionTrue {
+		catalog.Status.ObservedGeneration = catalog.Generation
+	}
+	meta.SetStatusCondition(&catalog.Status.Conditions, metav1.Condition{
+		Type:               CatalogSynced,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: catalog.Generation,
+	})
+	if err := r.Status().Update(ctx, catalog); err != nil {
+		r.Log.Error(err, "Failed to update the status of the catalog", "catalog", catalog.Name)
+		return err
+	}
+	return nil
+}
+
+func (r *ServingRuntimeCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates are ignored, the next sync is scheduled by requeuing the catalog
+		For(&v1alpha1.ServingRuntimeCatalog{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
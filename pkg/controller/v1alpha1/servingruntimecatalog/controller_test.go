@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntimecatalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+type fakeFetcher struct {
+	catalog *Catalog
+	err     error
+	calls   int
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, source v1alpha1.ServingRuntimeCatalogSource) (*Catalog, error) {
+	f.calls++
+	return f.catalog, f.err
+}
+
+func makeRuntime(name string, image string, labels map[string]string) v1alpha1.ClusterServingRuntime {
+	return v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{{Name: "kserve-container", Image: image}},
+			},
+		},
+	}
+}
+
+func newTestReconciler(t *testing.T, fetcher Fetcher, objects ...client.Object) (*ServingRuntimeCatalogReconciler, *record.FakeRecorder) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	recorder := record.NewFakeRecorder(10)
+	return &ServingRuntimeCatalogReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).
+			WithStatusSubresource(&v1alpha1.ServingRuntimeCatalog{}).Build(),
+		Log:      logr.Discard(),
+		Scheme:   s,
+		Recorder: recorder,
+		Fetcher:  fetcher,
+	}, recorder
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestServingRuntimeCatalogReconciler_Reconcile(t *testing.T) {
+	managed := map[string]string{constants.ServingRuntimeCatalogLabel: "catalog"}
+	existing := makeRuntime("kserve-sklearnserver", "kserve/sklearnserver:v0.14.0", managed)
+	unmanaged := makeRuntime("kserve-xgbserver", "acme/xgbserver:latest", nil)
+	catalog := &v1alpha1.ServingRuntimeCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", Generation: 1},
+		Spec: v1alpha1.ServingRuntimeCatalogSpec{
+			Source:   v1alpha1.ServingRuntimeCatalogSource{URL: "https://example.com/runtimes.yaml"},
+			Interval: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+	fetcher := &fakeFetcher{catalog: &Catalog{
+		Revision: "sha256:1234",
+		Runtimes: []v1alpha1.ClusterServingRuntime{
+			makeRuntime("kserve-sklearnserver", "kserve/sklearnserver:v0.15.0", nil),
+			makeRuntime("kserve-xgbserver", "kserve/xgbserver:v0.15.0", nil),
+			makeRuntime("kserve-torchserve", "pytorch/torchserve-kfs:0.9.0", nil),
+		},
+	}}
+	reconciler, recorder := newTestReconciler(t, fetcher, catalog, &existing, &unmanaged)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: catalog.Name}}
+
+	result, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 3)
+	assert.True(t, strings.HasPrefix(events[0], `Normal RuntimeUpdated Updated ClusterServingRuntime "kserve-sklearnserver"`), events[0])
+	assert.Contains(t, events[0], "kserve/sklearnserver:v0.15.0")
+	assert.Equal(t, `Warning RuntimeConflict ClusterServingRuntime "kserve-xgbserver" already exists and is not managed by this catalog`, events[1])
+	assert.Equal(t, `Normal RuntimeCreated Created ClusterServingRuntime "kserve-torchserve"`, events[2])
+
+	updated := &v1alpha1.ClusterServingRuntime{}
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: "kserve-sklearnserver"}, updated))
+	assert.Equal(t, "kserve/sklearnserver:v0.15.0", updated.Spec.Containers[0].Image)
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: "kserve-xgbserver"}, updated))
+	assert.Equal(t, "acme/xgbserver:latest", updated.Spec.Containers[0].Image)
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: "kserve-torchserve"}, updated))
+	assert.Equal(t, "catalog", updated.Labels[constants.ServingRuntimeCatalogLabel])
+
+	synced := &v1alpha1.ServingRuntimeCatalog{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, synced))
+	assert.Equal(t, "sha256:1234", synced.Status.Revision)
+	assert.Equal(t, []string{"kserve-sklearnserver", "kserve-torchserve", "kserve-xgbserver"}, synced.Status.Runtimes)
+	assert.Equal(t, int64(1), synced.Status.ObservedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(synced.Status.Conditions, CatalogSynced))
+
+	// The catalog is not synced again before the interval has elapsed
+	result, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetcher.calls)
+	assert.Positive(t, result.RequeueAfter)
+	assert.LessOrEqual(t, result.RequeueAfter, 10*time.Minute)
+
+	// Unchanged runtimes are not updated, runtimes removed from the catalog are reported
+	synced.Status.LastSyncTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	require.NoError(t, reconciler.Status().Update(t.Context(), synced))
+	fetcher.catalog.Runtimes = fetcher.catalog.Runtimes[:1]
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`Normal RuntimeRemovedFromCatalog ClusterServingRuntime "kserve-torchserve" was removed from the catalog and is no longer synced`,
+		`Normal RuntimeRemovedFromCatalog ClusterServingRuntime "kserve-xgbserver" was removed from the catalog and is no longer synced`,
+	}, drainEvents(recorder))
+}
+
+func TestServingRuntimeCatalogReconciler_DryRun(t *testing.T) {
+	existing := makeRuntime("kserve-sklearnserver", "kserve/sklearnserver:v0.14.0",
+		map[string]string{constants.ServingRuntimeCatalogLabel: "catalog"})
+	catalog := &v1alpha1.ServingRuntimeCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog"},
+		Spec: v1alpha1.ServingRuntimeCatalogSpec{
+			Source: v1alpha1.ServingRuntimeCatalogSource{URL: "https://example.com/runtimes.yaml"},
+			DryRun: true,
+		},
+	}
+	fetcher := &fakeFetcher{catalog: &Catalog{
+		Revision: "sha256:1234",
+		Runtimes: []v1alpha1.ClusterServingRuntime{
+			makeRuntime("kserve-sklearnserver", "kserve/sklearnserver:v0.15.0", nil),
+			makeRuntime("kserve-torchserve", "pytorch/torchserve-kfs:0.9.0", nil),
+		},
+	}}
+	reconciler, recorder := newTestReconciler(t, fetcher, catalog, &existing)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: catalog.Name}}
+
+	result, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSyncInterval, result.RequeueAfter)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.True(t, strings.HasPrefix(events[0], `Normal RuntimeDryRun ClusterServingRuntime "kserve-sklearnserver" would be updated`), events[0])
+	assert.Contains(t, events[0], "kserve/sklearnserver:v0.14.0")
+	assert.Contains(t, events[0], "kserve/sklearnserver:v0.15.0")
+	assert.Equal(t, `Normal RuntimeDryRun ClusterServingRuntime "kserve-torchserve" would be created`, events[1])
+
+	unchanged := &v1alpha1.ClusterServingRuntime{}
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Name: "kserve-sklearnserver"}, unchanged))
+	assert.Equal(t, "kserve/sklearnserver:v0.14.0", unchanged.Spec.Containers[0].Image)
+	err = reconciler.Get(t.Context(), types.NamespacedName{Name: "kserve-torchserve"}, unchanged)
+	assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "expected the runtime not to be created, got %v", err)
+
+	synced := &v1alpha1.ServingRuntimeCatalog{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, synced))
+	condition := meta.FindStatusCondition(synced.Status.Conditions, CatalogSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, "DryRun", condition.Reason)
+}
+
+func TestServingRuntimeCatalogReconciler_FetchFailed(t *testing.T) {
+	catalog := &v1alpha1.ServingRuntimeCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog"},
+		Spec: v1alpha1.ServingRuntimeCatalogSpec{
+			Source: v1alpha1.ServingRuntimeCatalogSource{URL: "https://example.com/runtimes.yaml"},
+		},
+	}
+	reconciler, recorder := newTestReconciler(t, &fakeFetcher{err: errors.New("connection refused")}, catalog)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: catalog.Name}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.EqualError(t, err, "connection refused")
+	assert.Equal(t, []string{"Warning CatalogSyncFailed Failed to fetch the catalog: connection refused"}, drainEvents(recorder))
+
+	failed := &v1alpha1.ServingRuntimeCatalog{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, failed))
+	condition := meta.FindStatusCondition(failed.Status.Conditions, CatalogSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "FetchFailed", condition.Reason)
+	assert.Nil(t, failed.Status.LastSyncTime)
+}
+
+func TestServingRuntimeCatalogReconciler_Suspended(t *testing.T) {
+	catalog := &v1alpha1.ServingRuntimeCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog"},
+		Spec: v1alpha1.ServingRuntimeCatalogSpec{
+			Source:  v1alpha1.ServingRuntimeCatalogSource{URL: "https://example.com/runtimes.yaml"},
+			Suspend: true,
+		},
+	}
+	fetcher := &fakeFetcher{}
+	reconciler, _ := newTestReconciler(t, fetcher, catalog)
+	result, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: catalog.Name}})
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Equal(t, 0, fetcher.calls)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntimecatalog
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	// maxCatalogSize is the maximum size in bytes of a catalog document or layer
+	maxCatalogSize = 64 << 20
+	// fetchTimeout bounds the fetch of a catalog
+	fetchTimeout = 5 * time.Minute
+)
+
+// Catalog is the content of a ServingRuntimeCatalog source
+type Catalog struct {
+	// Revision is the digest of the source content
+	Revision string
+	Runtimes []v1alpha1.ClusterServingRuntime
+}
+
+// Fetcher pulls the ClusterServingRuntimes of a catalog from its source
+type Fetcher interface {
+	Fetch(ctx context.Context, source v1alpha1.ServingRuntimeCatalogSource) (*Catalog, error)
+}
+
+// HTTPFetcher pulls catalogs from OCI registries and HTTP(S) URLs. OCI registries are authenticated with the docker
+// config of the controller, and accessed anonymously otherwise.
+type HTTPFetcher struct {
+	Client   *http.Client
+	Keychain authn.Keychain
+}
+
+var _ Fetcher = &HTTPFetcher{}
+
+// NewHTTPFetcher creates a fetcher using the default transport
+func NewHTTPFetcher() *HTTPFetcher {
+	return &HTTPFetcher{
+		Client:   &http.Client{Timeout: fetchTimeout},
+		Keychain: authn.DefaultKeychain,
+	}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, source v1alpha1.ServingRuntimeCatalogSource) (*Catalog, error) {
+	switch {
+	case source.OCI != nil:
+		return f.fetchOCI(ctx, source.OCI)
+	case source.URL != "":
+		return f.fetchURL(ctx, source.URL)
+	default:
+		return nil, errors.New("the catalog source has neither an OCI artifact nor a URL")
+	}
+}
+
+func (f *HTTPFetcher) fetchURL(ctx context.Context, sourceURL string) (*Catalog, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	content, err := f.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch catalog from %s: %w", sourceURL, err)
+	}
+	runtimes, err := parseContent(content)
+	if err != nil {
+		return nil, err
+	}
+	return &Catalog{Revision: digestOf(content), Runtimes: runtimes}, nil
+}
+
+func (f *HTTPFetcher) fetchOCI(ctx context.Context, source *v1alpha1.OCICatalogSource) (*Catalog, error) {
+	var nameOptions []name.Option
+	if source.Insecure {
+		nameOptions = append(nameOptions, name.Insecure)
+	}
+	ref, err := name.ParseReference(source.Image, nameOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %q: %w", source.Image, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	options := []remote.Option{remote.WithAuthFromKeychain(f.Keychain), remote.WithContext(ctx)}
+	if f.Client.Transport != nil {
+		options = append(options, remote.WithTransport(f.Client.Transport))
+	}
+
+	descriptor, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest of %s: %w", source.Image, err)
+	}
+	if descriptor.MediaType.IsIndex() {
+		return nil, fmt.Errorf("%s is an image index, the catalog must be a single artifact", source.Image)
+	}
+	image, err := descriptor.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", source.Image, err)
+	}
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", source.Image, err)
+	}
+
+	var runtimes []v1alpha1.ClusterServingRuntime
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return nil, err
+		}
+		blob, err := readLayer(layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch layer %s of %s: %w", digest, source.Image, err)
+		}
+		layerRuntimes, err := parseContent(blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse layer %s of %s: %w", digest, source.Image, err)
+		}
+		runtimes = append(runtimes, layerRuntimes...)
+	}
+	if err := checkDuplicates(runtimes); err != nil {
+		return nil, err
+	}
+	return &Catalog{Revision: descriptor.Digest.String(), Runtimes: runtimes}, nil
+}
+
+// readLayer reads the blob of a layer as stored in the registry, up to maxCatalogSize. The digest of the blob is
+// verified once it is fully read.
+func readLayer(layer v1.Layer) ([]byte, error) {
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(io.LimitReader(reader, maxCatalogSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxCatalogSize {
+		return nil, fmt.Errorf("content exceeds the maximum catalog size of %d bytes", maxCatalogSize)
+	}
+	return content, nil
+}
+
+// do sends a request and reads the body of the response, up to maxCatalogSize
+func (f *HTTPFetcher) do(req *http.Request) ([]byte, error) {
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxCatalogSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxCatalogSize {
+		return nil, fmt.Errorf("content exceeds the maximum catalog size of %d bytes", maxCatalogSize)
+	}
+	return content, nil
+}
+
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// parseContent parses the ClusterServingRuntimes of a YAML document, or of the YAML files of a tarball, which may
+// be gzipped
+func parseContent(content []byte) ([]v1alpha1.ClusterServingRuntime, error) {
+	if bytes.HasPrefix(content, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		if content, err = io.ReadAll(io.LimitReader(reader, maxCatalogSize+1)); err != nil {
+			return nil, err
+		}
+		if len(content) > maxCatalogSize {
+			return nil, fmt.Errorf("content exceeds the maximum catalog size of %d bytes", maxCatalogSize)
+		}
+	}
+	// tar archives have the ustar magic in the header of the first entry
+	if len(content) > 262 && string(content[257:262]) == "ustar" {
+		return parseTarball(content)
+	}
+	return parseDocuments(content)
+}
+
+func parseTarball(content []byte) ([]v1alpha1.ClusterServingRuntime, error) {
+	var runtimes []v1alpha1.ClusterServingRuntime
+	reader := tar.NewReader(bytes.NewReader(content))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ext := path.Ext(header.Name)
+		if header.Typeflag != tar.TypeReg || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		fileRuntimes, err := parseDocuments(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", header.Name, err)
+		}
+		runtimes = append(runtimes, fileRuntimes...)
+	}
+	return runtimes, checkDuplicates(runtimes)
+}
+
+// parseDocuments parses the ClusterServingRuntimes of a multi-document YAML, ignoring documents of other kinds
+func parseDocuments(content []byte) ([]v1alpha1.ClusterServingRuntime, error) {
+	var runtimes []v1alpha1.ClusterServingRuntime
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		runtime := v1alpha1.ClusterServingRuntime{}
+		if err := yaml.Unmarshal(document, &runtime); err != nil {
+			return nil, err
+		}
+		if runtime.Kind != "ClusterServingRuntime" || runtime.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+			continue
+		}
+		if runtime.Name == "" {
+			return nil, errors.New("found a ClusterServingRuntime without a name")
+		}
+		runtimes = append(runtimes, runtime)
+	}
+	return runtimes, checkDuplicates(runtimes)
+}
+
+func checkDuplicates(runtimes []v1alpha1.ClusterServingRuntime) error {
+	names := make(map[string]bool, len(runtimes))
+	for _, runtime := range runtimes {
+		if names[runtime.Name] {
+			return fmt.Errorf("ClusterServingRuntime %q is defined more than once", runtime.Name)
+		}
+		names[runtime.Name] = true
+	}
+	return nil
+}
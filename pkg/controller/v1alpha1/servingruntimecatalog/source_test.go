@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntimecatalog
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const testRuntimes = `apiVersion: serving.kserve.io/v1alpha1
+kind: ClusterServingRuntime
+metadata:
+  name: kserve-sklearnserver
+spec:
+  supportedModelFormats:
+    - name: sklearn
+      version: "1"
+  containers:
+    - name: kserve-container
+      image: kserve/sklearnserver:v0.15.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: serving.kserve.io/v1alpha1
+kind: ClusterServingRuntime
+metadata:
+  name: kserve-xgbserver
+spec:
+  supportedModelFormats:
+    - name: xgboost
+      version: "1"
+  containers:
+    - name: kserve-container
+      image: kserve/xgbserver:v0.15.0
+`
+
+func makeTarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func runtimeNames(runtimes []v1alpha1.ClusterServingRuntime) []string {
+	names := make([]string, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		names = append(names, runtime.Name)
+	}
+	return names
+}
+
+// newTestRegistry returns a registry serving an artifact with the given layers, which requires an anonymous
+// bearer token
+func newTestRegistry(t *testing.T, layers ...[]byte) (*httptest.Server, string) {
+	blobs := map[string][]byte{}
+	manifest := map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     string(types.OCIManifestSchema1),
+	}
+	var descriptors []map[string]any
+	for _, layer := range layers {
+		digest := digestOf(layer)
+		blobs[digest] = layer
+		descriptors = append(descriptors, map[string]any{
+			"mediaType": "application/vnd.oci.image.layer.v1.tar",
+			"digest":    digest,
+			"size":      len(layer),
+		})
+	}
+	manifest["layers"] = descriptors
+	manifestContent, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "repository:acme/runtimes:pull", r.URL.Query().Get("scope"))
+			assert.Equal(t, "test-registry", r.URL.Query().Get("service"))
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="test-registry",scope="repository:acme/runtimes:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v2/acme/runtimes/manifests/v1.0.0" || r.URL.Path == "/v2/acme/runtimes/manifests/"+digestOf(manifestContent):
+			assert.Contains(t, r.Header.Get("Accept"), string(types.OCIManifestSchema1))
+			w.Header().Set("Content-Type", string(types.OCIManifestSchema1))
+			w.Header().Set("Docker-Content-Digest", digestOf(manifestContent))
+			w.Write(manifestContent)
+		case strings.HasPrefix(r.URL.Path, "/v2/acme/runtimes/blobs/"):
+			blob, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/acme/runtimes/blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(blob)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, digestOf(manifestContent)
+}
+
+func TestHTTPFetcher_FetchOCI(t *testing.T) {
+	extra := `apiVersion: serving.kserve.io/v1alpha1
+kind: ClusterServingRuntime
+metadata:
+  name: kserve-torchserve
+spec:
+  containers:
+    - name: kserve-container
+      image: pytorch/torchserve-kfs:0.9.0
+`
+	server, manifestDigest := newTestRegistry(t, []byte(testRuntimes), makeTarball(t, map[string]string{
+		"runtimes/torchserve.yaml": extra,
+		"README.md":                "not a runtime",
+	}))
+	host := strings.TrimPrefix(server.URL, "http://")
+	fetcher := NewHTTPFetcher()
+
+	for _, image := range []string{host + "/acme/runtimes:v1.0.0", host + "/acme/runtimes@" + manifestDigest} {
+		catalog, err := fetcher.Fetch(t.Context(), v1alpha1.ServingRuntimeCatalogSource{
+			OCI: &v1alpha1.OCICatalogSource{Image: image, Insecure: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, manifestDigest, catalog.Revision)
+		assert.Equal(t, []string{"kserve-sklearnserver", "kserve-xgbserver", "kserve-torchserve"}, runtimeNames(catalog.Runtimes))
+		assert.Equal(t, "kserve/sklearnserver:v0.15.0", catalog.Runtimes[0].Spec.Containers[0].Image)
+	}
+
+	_, err := fetcher.Fetch(t.Context(), v1alpha1.ServingRuntimeCatalogSource{
+		OCI: &v1alpha1.OCICatalogSource{Image: host + "/acme/runtimes:missing", Insecure: true},
+	})
+	assert.ErrorContains(t, err, "unexpected status code 404")
+}
+
+func TestHTTPFetcher_FetchURL(t *testing.T) {
+	tarball := makeTarball(t, map[string]string{"catalog/runtimes.yml": testRuntimes})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/runtimes.yaml":
+			fmt.Fprint(w, testRuntimes)
+		case "/runtimes.tar.gz":
+			w.Write(tarball)
+		case "/duplicated.yaml":
+			fmt.Fprint(w, testRuntimes+"---\n"+testRuntimes)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	fetcher := NewHTTPFetcher()
+
+	catalog, err := fetcher.Fetch(t.Context(), v1alpha1.ServingRuntimeCatalogSource{URL: server.URL + "/runtimes.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, digestOf([]byte(testRuntimes)), catalog.Revision)
+	assert.Equal(t, []string{"kserve-sklearnserver", "kserve-xgbserver"}, runtimeNames(catalog.Runtimes))
+
+	catalog, err = fetcher.Fetch(t.Context(), v1alpha1.ServingRuntimeCatalogSource{URL: server.URL + "/runtimes.tar.gz"})
+	require.NoError(t, err)
+	assert.Equal(t, digestOf(tarball), catalog.Revision)
+	assert.Equal(t, []string{"kserve-sklearnserver", "kserve-xgbserver"}, runtimeNames(catalog.Runtimes))
+
+	_, err = fetcher.Fetch(t.Context(), v1alpha1.ServingRuntimeCatalogSource{URL: server.URL + "/duplicated.yaml"})
+	assert.ErrorContains(t, err, `ClusterServingRuntime "kserve-sklearnserver" is defined more than once`)
+}
@@ -41,6 +41,7 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -69,6 +70,9 @@ type TrainedModelReconciler struct {
 	Scheme                *runtime.Scheme
 	Recorder              record.EventRecorder
 	ModelConfigReconciler *modelconfig.ModelConfigReconciler
+	// MaxConcurrentReconciles allows the TrainedModels of a modelConfig to be reconciled together, so that their
+	// changes are coalesced by a batching ModelConfigReconciler. Defaults to 1 when unset.
+	MaxConcurrentReconciles int
 }
 
 func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -304,5 +308,6 @@ func (r *TrainedModelReconciler) updateConditions(ctx context.Context, req ctrl.
 func (r *TrainedModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.TrainedModel{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+// flushFunc applies the delta of a batch to the modelConfig
+type flushFunc func(ctx context.Context, modelConfig types.NamespacedName, delta *modelconfig.ConfigsDelta) error
+
+// modelConfigBatch holds the pending model changes of a modelConfig. A nil model config means the model is deleted.
+type modelConfigBatch struct {
+	changes map[string]*modelconfig.ModelConfig
+	done    chan struct{}
+	err     error
+}
+
+// modelConfigBatcher coalesces the model changes of a modelConfig submitted within the debounce window, so that they
+// are written with a single update of the modelConfig instead of one update per TrainedModel.
+type modelConfigBatcher struct {
+	window time.Duration
+	flush  flushFunc
+
+	mu      sync.Mutex
+	batches map[types.NamespacedName]*modelConfigBatch
+	// serializes the flushes, so that a batch does not conflict with the write of the previous batch
+	flushMu sync.Mutex
+}
+
+func newModelConfigBatcher(window time.Duration, flush flushFunc) *modelConfigBatcher {
+	return &modelConfigBatcher{
+		window:  window,
+		flush:   flush,
+		batches: map[types.NamespacedName]*modelConfigBatch{},
+	}
+}
+
+// submit adds the change of a model to the pending batch of the modelConfig, starting the debounce window when there
+// is no pending batch, and waits for the batch to be written. The latest change of a model within a batch wins.
+func (b *modelConfigBatcher) submit(ctx context.Context, modelConfig types.NamespacedName, name string, config *modelconfig.ModelConfig) error {
+	b.mu.Lock()
+	batch, ok := b.batches[modelConfig]
+	if !ok {
+		batch = &modelConfigBatch{
+			changes: map[string]*modelconfig.ModelConfig{},
+			done:    make(chan struct{}),
+		}
+		b.batches[modelConfig] = batch
+		time.AfterFunc(b.window, func() {
+			b.flushBatch(modelConfig, batch)
+		})
+	}
+	batch.changes[name] = config
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *modelConfigBatcher) flushBatch(modelConfig types.NamespacedName, batch *modelConfigBatch) {
+	// Close the batch, the changes submitted from now on start a new batch
+	b.mu.Lock()
+	delete(b.batches, modelConfig)
+	b.mu.Unlock()
+
+	updated := modelconfig.ModelConfigs{}
+	deleted := []string{}
+	for name, config := range batch.changes {
+		if config == nil {
+			deleted = append(deleted, name)
+		} else {
+			updated = append(updated, *config)
+		}
+	}
+	log.Info("Flushing modelConfig batch", "modelConfig", modelConfig.Name, "namespace", modelConfig.Namespace,
+		"updated", len(updated), "deleted", len(deleted))
+
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	batch.err = b.flush(context.Background(), modelConfig, modelconfig.NewConfigsDelta(updated, deleted))
+	close(batch.done)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+// countingClient counts the updates of the ConfigMaps
+type countingClient struct {
+	client.Client
+	updates atomic.Int32
+}
+
+func (c *countingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.updates.Add(1)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestModelConfigBatcher_CoalescesChanges(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test-ns", Name: "modelconfig-my-isvc-0"}
+	var flushes []*modelconfig.ConfigsDelta
+	var mu sync.Mutex
+	batcher := newModelConfigBatcher(50*time.Millisecond, func(ctx context.Context, modelConfig types.NamespacedName, delta *modelconfig.ConfigsDelta) error {
+		assert.Equal(t, key, modelConfig)
+		mu.Lock()
+		defer mu.Unlock()
+		flushes = append(flushes, delta)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("tm%d", i)
+			assert.NoError(t, batcher.submit(t.Context(), key, name, &modelconfig.ModelConfig{Name: name}))
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, batcher.submit(t.Context(), key, "deleted", nil))
+	}()
+	wg.Wait()
+
+	require.Len(t, flushes, 1)
+	cm := makeConfigMap(key.Name, key.Namespace, map[string]string{
+		constants.ModelConfigFileName: `[{"modelName":"deleted","modelSpec":{"storageUri":"gs://foo/bar"}}]`,
+	})
+	require.NoError(t, flushes[0].Process(cm))
+	data := cm.Data[constants.ModelConfigFileName]
+	for i := range 5 {
+		assert.Contains(t, data, fmt.Sprintf(`"modelName":"tm%d"`, i))
+	}
+	assert.NotContains(t, data, "deleted")
+	assert.Empty(t, batcher.batches)
+}
+
+func TestModelConfigBatcher_ReturnsFlushError(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test-ns", Name: "modelconfig-my-isvc-0"}
+	batcher := newModelConfigBatcher(10*time.Millisecond, func(ctx context.Context, modelConfig types.NamespacedName, delta *modelconfig.ConfigsDelta) error {
+		return errors.New("flush error")
+	})
+	err := batcher.submit(t.Context(), key, "tm1", &modelconfig.ModelConfig{Name: "tm1"})
+	assert.EqualError(t, err, "flush error")
+}
+
+func TestModelConfigBatcher_ContextCanceled(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test-ns", Name: "modelconfig-my-isvc-0"}
+	batcher := newModelConfigBatcher(time.Hour, func(ctx context.Context, modelConfig types.NamespacedName, delta *modelconfig.ConfigsDelta) error {
+		return nil
+	})
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err := batcher.submit(ctx, key, "tm1", &modelconfig.ModelConfig{Name: "tm1"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBatchingModelConfigReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+
+	const (
+		ns         = "test-ns"
+		isvc       = "my-isvc"
+		configName = "modelconfig-my-isvc-0"
+	)
+
+	cm := makeConfigMap(configName, ns, map[string]string{})
+	client := &countingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()}
+	clientset := k8sfake.NewSimpleClientset(cm)
+	reconciler := NewBatchingModelConfigReconciler(client, clientset, scheme, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tm := makeTrainedModel(fmt.Sprintf("tm%d", i), isvc, "gs://foo/bar", false)
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: tm.Name}}
+			assert.NoError(t, reconciler.Reconcile(t.Context(), req, tm))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), client.updates.Load())
+	updated := &corev1.ConfigMap{}
+	require.NoError(t, client.Get(t.Context(), types.NamespacedName{Namespace: ns, Name: configName}, updated))
+	for i := range 10 {
+		assert.Contains(t, updated.Data[constants.ModelConfigFileName], fmt.Sprintf(`"modelName":"tm%d"`, i))
+	}
+}
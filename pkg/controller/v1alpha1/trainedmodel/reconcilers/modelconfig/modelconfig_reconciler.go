@@ -19,9 +19,10 @@ package modelconfig
 import (
 	"context"
 	"fmt"
+	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +40,7 @@ type ModelConfigReconciler struct {
 	client    client.Client
 	clientset kubernetes.Interface
 	scheme    *runtime.Scheme
+	batcher   *modelConfigBatcher
 }
 
 func NewModelConfigReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme) *ModelConfigReconciler {
@@ -49,46 +51,51 @@ func NewModelConfigReconciler(client client.Client, clientset kubernetes.Interfa
 	}
 }
 
+// NewBatchingModelConfigReconciler returns a ModelConfigReconciler which coalesces the changes of the TrainedModels of
+// a modelConfig made within the batch window into a single write of the modelConfig. A zero window disables batching.
+func NewBatchingModelConfigReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, batchWindow time.Duration) *ModelConfigReconciler {
+	c := NewModelConfigReconciler(client, clientset, scheme)
+	if batchWindow > 0 {
+		c.batcher = newModelConfigBatcher(batchWindow, c.applyDelta)
+	}
+	return c
+}
+
 func (c *ModelConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request, tm *v1alpha1.TrainedModel) error {
 	log.Info("Reconciling TrainedModel", "apiVersion", tm.APIVersion, "trainedmodel", tm.Spec)
 	shardStrategy := memory.MemoryStrategy{}
 	shardId := shardStrategy.GetOrAssignShard(tm)
 	// Use tm's parent InferenceService field to get the model modelConfig
-	modelConfigName := constants.ModelConfigName(tm.Spec.InferenceService, shardId)
-	log.Info("Reconciling modelConfig", "modelConfigName", modelConfigName, "namespace", req.Namespace)
-	desiredModelConfig, err := c.clientset.CoreV1().ConfigMaps(req.Namespace).Get(ctx, modelConfigName, metav1.GetOptions{})
+	modelConfigName := types.NamespacedName{Namespace: req.Namespace, Name: constants.ModelConfigName(tm.Spec.InferenceService, shardId)}
+	log.Info("Reconciling modelConfig", "modelConfigName", modelConfigName.Name, "namespace", req.Namespace)
+
+	var modelConfig *modelconfig.ModelConfig
+	if tm.DeletionTimestamp == nil {
+		// A TrainedModel is created or updated, add or update the model from the model configmap
+		modelConfig = &modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model, Priority: tm.Spec.Priority}
+	}
+	if c.batcher != nil {
+		return c.batcher.submit(ctx, modelConfigName, tm.Name, modelConfig)
+	}
+	if modelConfig == nil {
+		// A TrainedModel is being deleted, remove the model from the model configmap
+		return c.applyDelta(ctx, modelConfigName, modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{}, []string{tm.Name}))
+	}
+	return c.applyDelta(ctx, modelConfigName, modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{*modelConfig}, nil))
+}
+
+// applyDelta writes the model changes to the parts of the modelConfig
+func (c *ModelConfigReconciler) applyDelta(ctx context.Context, modelConfigName types.NamespacedName, configDelta *modelconfig.ConfigsDelta) error {
+	modelConfigParts, err := modelconfig.GetModelConfigParts(ctx, c.clientset, modelConfigName.Namespace, modelConfigName.Name)
 	if err != nil {
-		log.Error(err, "Failed to find model ConfigMap to reconcile for InferenceService", "name", tm.Spec.Model, "namespace", req.Namespace)
+		log.Error(err, "Failed to find model ConfigMap to reconcile for InferenceService", "name", modelConfigName.Name, "namespace", modelConfigName.Namespace)
 		// Error reading the object - requeue the request.
 		return err
 	}
-	if tm.DeletionTimestamp != nil {
-		// A TrainedModel is being deleted, remove the model from the model configmap
-		deletedConfigs := []string{tm.Name}
-		configDelta := modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{}, deletedConfigs)
-		err := configDelta.Process(desiredModelConfig)
-		if err != nil {
-			return fmt.Errorf("Can not remove model %v from config because of error %w", tm.Name, err)
-		}
-		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(ctx, desiredModelConfig)
-		if err != nil {
-			return err
-		}
-	} else {
-		// A TrainedModel is created or updated, add or update the model from the model configmap
-		modelConfig := modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model}
-		updatedConfigs := []modelconfig.ModelConfig{modelConfig}
-		configDelta := modelconfig.NewConfigsDelta(updatedConfigs, nil)
-		err := configDelta.Process(desiredModelConfig)
-		if err != nil {
-			return fmt.Errorf("Can not add or update a model %v from config because of error %w", tm.Name, err)
-		}
-		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(ctx, desiredModelConfig)
-		if err != nil {
-			return err
-		}
+	updated, created, err := configDelta.ProcessParts(modelConfigParts)
+	if err != nil {
+		return fmt.Errorf("Can not update the models of config %v because of error %w", modelConfigName.Name, err)
 	}
-	return nil
+	// Update the model Config created by the InferenceService controller
+	return modelconfig.SaveModelConfigParts(ctx, c.client, updated, created)
 }
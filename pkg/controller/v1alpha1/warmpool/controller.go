@@ -0,0 +1,322 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=warmpools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=warmpools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+package warmpool
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+const (
+	// WarmPoolReady is the condition type reporting whether all the warm pods of a pool are ready to be claimed
+	WarmPoolReady = "Ready"
+)
+
+// Event reasons
+const (
+	PodClaimed     = "PodClaimed"
+	PodCreated     = "PodCreated"
+	PodDeleted     = "PodDeleted"
+	PodClaimFailed = "PodClaimFailed"
+)
+
+// WarmPoolReconciler keeps the warm pods of a WarmPool. Warm pods are created from the pod template of the current
+// ReplicaSet of the predictor, without the labels selected by the ReplicaSet and the predictor service, so that they
+// pull the image and load the model without receiving traffic. When the predictor scales up, ready warm pods are
+// claimed by relabeling them to match the ReplicaSet, which adopts them and deletes the surplus pods not yet ready.
+type WarmPoolReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func (r *WarmPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pool := &v1alpha1.WarmPool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	isvc := &v1beta1.InferenceService{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Spec.InferenceService}, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, r.updateStatus(ctx, pool, 0, metav1.ConditionFalse, "InferenceServiceNotFound",
+				fmt.Sprintf("InferenceService %q does not exist", pool.Spec.InferenceService))
+		}
+		return ctrl.Result{}, err
+	}
+	deploymentMode := constants.DeploymentModeType(isvc.Status.DeploymentMode)
+	if deploymentMode != constants.Standard && deploymentMode != constants.LegacyRawDeployment {
+		return ctrl.Result{}, r.updateStatus(ctx, pool, 0, metav1.ConditionFalse, "UnsupportedDeploymentMode",
+			fmt.Sprintf("InferenceService %q is deployed in %q mode, only the %q mode is supported",
+				isvc.Name, isvc.Status.DeploymentMode, constants.Standard))
+	}
+
+	replicaSet, err := isvcutils.GetCurrentReplicaSet(ctx, r.Client, pool.Namespace, constants.PredictorServiceName(isvc.Name))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if replicaSet == nil {
+		return ctrl.Result{}, r.updateStatus(ctx, pool, 0, metav1.ConditionFalse, "PredictorNotFound",
+			fmt.Sprintf("The predictor of InferenceService %q has not been deployed yet", isvc.Name))
+	}
+	templateHash := replicaSet.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(pool.Namespace), client.MatchingLabels{constants.WarmPoolLabel: pool.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	var ready, warming []*corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || !metav1.IsControlledBy(pod, pool) {
+			continue
+		}
+		// Pods warmed for a previous revision of the predictor are replaced
+		if pod.Annotations[constants.WarmPoolTemplateHashAnnotation] != templateHash || isTerminated(pod) {
+			if err := r.deletePod(ctx, pool, pod, "is outdated"); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		if isPodReady(pod) {
+			ready = append(ready, pod)
+		} else {
+			warming = append(warming, pod)
+		}
+	}
+
+	// Claim a ready warm pod for each replica of the predictor that is not ready yet. The ready replicas are counted
+	// from the pods selected by the ReplicaSet rather than from its status, which does not report the pods claimed
+	// until the ReplicaSet adopts them.
+	replicaPods := &corev1.PodList{}
+	if err := r.List(ctx, replicaPods, client.InNamespace(pool.Namespace), client.MatchingLabels(replicaSet.Spec.Selector.MatchLabels)); err != nil {
+		return ctrl.Result{}, err
+	}
+	readyReplicas := 0
+	for i := range replicaPods.Items {
+		if pod := &replicaPods.Items[i]; pod.DeletionTimestamp == nil && isPodReady(pod) {
+			readyReplicas++
+		}
+	}
+	desired := 0
+	if replicaSet.Spec.Replicas != nil {
+		desired = int(*replicaSet.Spec.Replicas)
+	}
+	claims := max(min(desired-readyReplicas, len(ready)), 0)
+	for _, pod := range ready[:claims] {
+		if err := r.claimPod(ctx, pool, pod, replicaSet); err != nil {
+			r.Recorder.Eventf(pool, corev1.EventTypeWarning, PodClaimFailed, "Failed to claim warm pod %q: %v", pod.Name, err)
+			return ctrl.Result{}, err
+		}
+	}
+	ready = ready[claims:]
+
+	// Scale the pool to its size, deleting the pods still warming first
+	size := int(pool.Spec.Size)
+	for len(ready)+len(warming) > size {
+		var pod *corev1.Pod
+		if len(warming) > 0 {
+			pod, warming = warming[len(warming)-1], warming[:len(warming)-1]
+		} else {
+			pod, ready = ready[len(ready)-1], ready[:len(ready)-1]
+		}
+		if err := r.deletePod(ctx, pool, pod, "exceeds the size of the pool"); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	for range size - len(ready) - len(warming) {
+		pod, err := r.warmPod(pool, replicaSet, templateHash)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, pod); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(pool, corev1.EventTypeNormal, PodCreated, "Created warm pod %q", pod.Name)
+	}
+
+	readyCount := int32(len(ready)) //nolint:gosec // bounded by the size of the pool
+	if readyCount == pool.Spec.Size {
+		return ctrl.Result{}, r.updateStatus(ctx, pool, readyCount, metav1.ConditionTrue, "PoolReady",
+			fmt.Sprintf("%d warm pods ready to be claimed", readyCount))
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, pool, readyCount, metav1.ConditionFalse, "Warming",
+		fmt.Sprintf("%d of %d warm pods ready to be claimed", readyCount, pool.Spec.Size))
+}
+
+// warmPod returns a warm pod created from the pod template of a ReplicaSet. The labels selected by the ReplicaSet
+// are removed, the other labels are kept for the pod mutator to inject the containers of the predictor.
+func (r *WarmPoolReconciler) warmPod(pool *v1alpha1.WarmPool, replicaSet *appsv1.ReplicaSet, templateHash string) (*corev1.Pod, error) {
+	template := replicaSet.Spec.Template.DeepCopy()
+	labels := map[string]string{}
+	for key, value := range template.Labels {
+		if _, selected := replicaSet.Spec.Selector.MatchLabels[key]; !selected {
+			labels[key] = value
+		}
+	}
+	labels[constants.WarmPoolLabel] = pool.Name
+	annotations := maps.Clone(template.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.WarmPoolTemplateHashAnnotation] = templateHash
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: constants.PredictorServiceName(pool.Spec.InferenceService) + "-warm-",
+			Namespace:    pool.Namespace,
+			Labels:       labels,
+			Annotations:  annotations,
+		},
+		Spec: template.Spec,
+	}
+	if err := controllerutil.SetControllerReference(pool, pod, r.Scheme); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// claimPod hands a ready warm pod over to the ReplicaSet of the predictor
+func (r *WarmPoolReconciler) claimPod(ctx context.Context, pool *v1alpha1.WarmPool, pod *corev1.Pod, replicaSet *appsv1.ReplicaSet) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	delete(pod.Labels, constants.WarmPoolLabel)
+	maps.Copy(pod.Labels, replicaSet.Spec.Template.Labels)
+	maps.Copy(pod.Labels, replicaSet.Spec.Selector.MatchLabels)
+	delete(pod.Annotations, constants.WarmPoolTemplateHashAnnotation)
+	// The ReplicaSet only adopts orphaned pods
+	pod.OwnerReferences = slices.DeleteFunc(pod.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == pool.UID
+	})
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return err
+	}
+	pool.Status.Claimed++
+	r.Recorder.Eventf(pool, corev1.EventTypeNormal, PodClaimed, "Warm pod %q claimed by ReplicaSet %q", pod.Name, replicaSet.Name)
+	return nil
+}
+
+func (r *WarmPoolReconciler) deletePod(ctx context.Context, pool *v1alpha1.WarmPool, pod *corev1.Pod, reason string) error {
+	if err := r.Delete(ctx, pod); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(pool, corev1.EventTypeNormal, PodDeleted, "Deleted warm pod %q which %s", pod.Name, reason)
+	return nil
+}
+
+func (r *WarmPoolReconciler) updateStatus(ctx context.Context, pool *v1alpha1.WarmPool, ready int32,
+	status metav1.ConditionStatus, reason string, message string,
+) error {
+	pool.Status.ObservedGeneration = pool.Generation
+	pool.Status.Ready = ready
+	meta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               WarmPoolReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: pool.Generation,
+	})
+	if err := r.Status().Update(ctx, pool); err != nil {
+		r.Log.Error(err, "Failed to update the status of the warm pool", "warmpool", pool.Name)
+		return err
+	}
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// requestsForInferenceService maps an InferenceService to the WarmPools of its predictor
+func (r *WarmPoolReconciler) requestsForInferenceService(ctx context.Context, namespace string, name string) []reconcile.Request {
+	pools := &v1alpha1.WarmPoolList{}
+	if err := r.List(ctx, pools, client.InNamespace(namespace)); err != nil {
+		r.Log.Error(err, "Failed to list the warm pools", "namespace", namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, pool := range pools.Items {
+		if pool.Spec.InferenceService == name {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name},
+			})
+		}
+	}
+	return requests
+}
+
+func (r *WarmPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.WarmPool{}).
+		Owns(&corev1.Pod{}).
+		Watches(&v1beta1.InferenceService{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.requestsForInferenceService(ctx, obj.GetNamespace(), obj.GetName())
+			})).
+		// The ReplicaSets of the predictor report the replicas waiting for a warm pod
+		Watches(&appsv1.ReplicaSet{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				name := obj.GetLabels()[constants.InferenceServicePodLabelKey]
+				owner := metav1.GetControllerOf(obj)
+				if name == "" || owner == nil || owner.Name != constants.PredictorServiceName(name) {
+					return nil
+				}
+				return r.requestsForInferenceService(ctx, obj.GetNamespace(), name)
+			})).
+		Complete(r)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmpool
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+const (
+	namespace    = "default"
+	templateHash = "5d8f7b9c4"
+)
+
+func newTestReconciler(t *testing.T, objects ...client.Object) (*WarmPoolReconciler, *record.FakeRecorder) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+	recorder := record.NewFakeRecorder(10)
+	return &WarmPoolReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).
+			WithStatusSubresource(&v1alpha1.WarmPool{}).Build(),
+		Log:      logr.Discard(),
+		Scheme:   s,
+		Recorder: recorder,
+	}, recorder
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func makePool(size int32) *v1alpha1.WarmPool {
+	return &v1alpha1.WarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: namespace, UID: "pool-uid", Generation: 1},
+		Spec:       v1alpha1.WarmPoolSpec{InferenceService: "sklearn", Size: size},
+	}
+}
+
+// makePredictor returns an InferenceService and the deployment and current ReplicaSet of its predictor
+func makePredictor(deploymentMode constants.DeploymentModeType, replicas int32, readyReplicas int32) []client.Object {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: namespace},
+		Status:     v1beta1.InferenceServiceStatus{DeploymentMode: string(deploymentMode)},
+	}
+	selector := map[string]string{"app": constants.GetRawServiceLabel("sklearn-predictor")}
+	templateLabels := map[string]string{
+		"app":                                 constants.GetRawServiceLabel("sklearn-predictor"),
+		constants.InferenceServicePodLabelKey: "sklearn",
+		"component":                           "predictor",
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   namespace,
+			UID:         "deployment-uid",
+			Annotations: map[string]string{isvcutils.DeploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+	rsLabels := map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: templateHash}
+	for key, value := range templateLabels {
+		rsLabels[key] = value
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor-" + templateHash,
+			Namespace:   namespace,
+			Labels:      rsLabels,
+			Annotations: map[string]string{isvcutils.DeploymentRevisionAnnotation: "2"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "sklearn-predictor", UID: "deployment-uid", Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app":                                  constants.GetRawServiceLabel("sklearn-predictor"),
+				appsv1.DefaultDeploymentUniqueLabelKey: templateHash,
+			}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: rsLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver"}},
+				},
+			},
+		},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas, ReadyReplicas: readyReplicas},
+	}
+	objects := []client.Object{isvc, deployment, replicaSet}
+	for i := range readyReplicas {
+		objects = append(objects, makeReplicaPod(fmt.Sprintf("sklearn-predictor-%s-%d", templateHash, i), rsLabels))
+	}
+	return objects
+}
+
+// makeReplicaPod returns a ready pod selected by the ReplicaSet of the predictor
+func makeReplicaPod(name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: maps.Clone(labels)},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func makeWarmPod(name string, hash string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				constants.WarmPoolLabel:               "pool",
+				constants.InferenceServicePodLabelKey: "sklearn",
+			},
+			Annotations: map[string]string{constants.WarmPoolTemplateHashAnnotation: hash},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "WarmPool", Name: "pool", UID: "pool-uid", Controller: ptr.To(true),
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func listWarmPods(t *testing.T, reconciler *WarmPoolReconciler) []corev1.Pod {
+	pods := &corev1.PodList{}
+	require.NoError(t, reconciler.List(t.Context(), pods, client.InNamespace(namespace),
+		client.MatchingLabels{constants.WarmPoolLabel: "pool"}))
+	return pods.Items
+}
+
+func TestWarmPoolReconciler_Fill(t *testing.T) {
+	pool := makePool(2)
+	reconciler, recorder := newTestReconciler(t, append(makePredictor(constants.Standard, 1, 1), pool)...)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pool.Name}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Len(t, drainEvents(recorder), 2)
+
+	pods := listWarmPods(t, reconciler)
+	require.Len(t, pods, 2)
+	for _, pod := range pods {
+		// The warm pods are not selected by the ReplicaSet nor the predictor service
+		assert.NotContains(t, pod.Labels, "app")
+		assert.NotContains(t, pod.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		assert.Equal(t, "sklearn", pod.Labels[constants.InferenceServicePodLabelKey])
+		assert.Equal(t, templateHash, pod.Annotations[constants.WarmPoolTemplateHashAnnotation])
+		assert.True(t, metav1.IsControlledBy(&pod, pool))
+		assert.Equal(t, "kserve/sklearnserver", pod.Spec.Containers[0].Image)
+	}
+
+	warming := &v1alpha1.WarmPool{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, warming))
+	assert.Equal(t, int32(0), warming.Status.Ready)
+	condition := meta.FindStatusCondition(warming.Status.Conditions, WarmPoolReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, "Warming", condition.Reason)
+
+	// The pool is not refilled while its pods are warming
+	_, err = reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Len(t, listWarmPods(t, reconciler), 2)
+}
+
+func TestWarmPoolReconciler_Claim(t *testing.T) {
+	pool := makePool(2)
+	objects := append(makePredictor(constants.Standard, 2, 1), pool,
+		makeWarmPod("warm-1", templateHash, true), makeWarmPod("warm-2", templateHash, true))
+	reconciler, recorder := newTestReconciler(t, objects...)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pool.Name}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.Equal(t, `Normal PodClaimed Warm pod "warm-1" claimed by ReplicaSet "sklearn-predictor-5d8f7b9c4"`, events[0])
+	assert.Contains(t, events[1], "Normal PodCreated Created warm pod")
+
+	claimed := &corev1.Pod{}
+	require.NoError(t, reconciler.Get(t.Context(), types.NamespacedName{Namespace: namespace, Name: "warm-1"}, claimed))
+	assert.Equal(t, constants.GetRawServiceLabel("sklearn-predictor"), claimed.Labels["app"])
+	assert.Equal(t, templateHash, claimed.Labels[appsv1.DefaultDeploymentUniqueLabelKey])
+	assert.NotContains(t, claimed.Labels, constants.WarmPoolLabel)
+	assert.Empty(t, claimed.OwnerReferences)
+
+	// The claimed pod is replaced
+	assert.Len(t, listWarmPods(t, reconciler), 2)
+	updated := &v1alpha1.WarmPool{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, updated))
+	assert.Equal(t, int64(1), updated.Status.Claimed)
+	assert.Equal(t, int32(1), updated.Status.Ready)
+}
+
+func TestWarmPoolReconciler_ClaimedNotAdopted(t *testing.T) {
+	pool := makePool(1)
+	objects := append(makePredictor(constants.Standard, 2, 1), pool, makeWarmPod("warm-2", templateHash, true))
+	// A pod claimed by a previous reconcile is not reported yet in the status of the ReplicaSet
+	claimed := makeWarmPod("warm-1", templateHash, true)
+	claimed.Labels = objects[2].(*appsv1.ReplicaSet).Spec.Template.Labels
+	claimed.OwnerReferences = nil
+	reconciler, recorder := newTestReconciler(t, append(objects, claimed)...)
+
+	_, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pool.Name}})
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+
+	pods := listWarmPods(t, reconciler)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "warm-2", pods[0].Name)
+}
+
+func TestWarmPoolReconciler_Outdated(t *testing.T) {
+	pool := makePool(1)
+	objects := append(makePredictor(constants.Standard, 1, 1), pool, makeWarmPod("warm-1", "previous", true))
+	reconciler, recorder := newTestReconciler(t, objects...)
+
+	_, err := reconciler.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pool.Name}})
+	require.NoError(t, err)
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.Equal(t, `Normal PodDeleted Deleted warm pod "warm-1" which is outdated`, events[0])
+
+	pods := listWarmPods(t, reconciler)
+	require.Len(t, pods, 1)
+	assert.Equal(t, templateHash, pods[0].Annotations[constants.WarmPoolTemplateHashAnnotation])
+}
+
+func TestWarmPoolReconciler_UnsupportedDeploymentMode(t *testing.T) {
+	pool := makePool(1)
+	reconciler, _ := newTestReconciler(t, append(makePredictor(constants.Knative, 1, 1), pool)...)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pool.Name}}
+
+	_, err := reconciler.Reconcile(t.Context(), req)
+	require.NoError(t, err)
+	assert.Empty(t, listWarmPods(t, reconciler))
+
+	updated := &v1alpha1.WarmPool{}
+	require.NoError(t, reconciler.Get(t.Context(), req.NamespacedName, updated))
+	condition := meta.FindStatusCondition(updated.Status.Conditions, WarmPoolReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "UnsupportedDeploymentMode", condition.Reason)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configwatcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("ConfigWatcher")
+
+// Event reasons of the config watcher
+const (
+	ConfigReloadedReason        = "ConfigReloaded"
+	InvalidConfigReason         = "InvalidConfig"
+	ConfigRestartRequiredReason = "ConfigRestartRequired"
+)
+
+// ConfigWatcher reloads the inferenceservice-config ConfigMap when it changes, so that the manager does not need to be
+// restarted. A new ConfigMap is validated before it is used: the reconcilers and the webhooks keep the last valid
+// ConfigMap while the new one is invalid, and a warning event is recorded on the ConfigMap. Once a ConfigMap is
+// reloaded, the controllers subscribed to the watcher reconcile all their resources with the new values.
+type ConfigWatcher struct {
+	clientset kubernetes.Interface
+	recorder  record.EventRecorder
+
+	mu sync.Mutex
+	// configMap is the last valid ConfigMap
+	configMap   *corev1.ConfigMap
+	subscribers []chan event.GenericEvent
+}
+
+// NewConfigWatcher returns a watcher starting from the ConfigMap the manager was set up with
+func NewConfigWatcher(clientset kubernetes.Interface, recorder record.EventRecorder, configMap *corev1.ConfigMap) *ConfigWatcher {
+	return &ConfigWatcher{
+		clientset: clientset,
+		recorder:  recorder,
+		configMap: configMap,
+	}
+}
+
+// Subscribe returns a channel receiving an event once a new ConfigMap is reloaded. The events which are not received
+// yet are coalesced, so that a controller which is not started, e.g. without the leader lease, does not block the
+// watcher.
+func (w *ConfigWatcher) Subscribe() <-chan event.GenericEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	subscriber := make(chan event.GenericEvent, 1)
+	w.subscribers = append(w.subscribers, subscriber)
+	return subscriber
+}
+
+// Start watches the ConfigMap until the context is done. It implements manager.Runnable.
+func (w *ConfigWatcher) Start(ctx context.Context) error {
+	w.mu.Lock()
+	v1beta1.SetInferenceServiceConfigMap(w.configMap)
+	w.mu.Unlock()
+	defer v1beta1.SetInferenceServiceConfigMap(nil)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(w.clientset, 0,
+		informers.WithNamespace(constants.KServeNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", constants.InferenceServiceConfigMapName).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				w.reload(configMap)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				w.reload(configMap)
+			}
+		},
+		DeleteFunc: func(_ interface{}) {
+			log.Info("The ConfigMap was deleted, keeping its last valid configuration",
+				"namespace", constants.KServeNamespace, "name", constants.InferenceServiceConfigMapName)
+		},
+	}); err != nil {
+		return err
+	}
+	log.Info("Watching the ConfigMap", "namespace", constants.KServeNamespace, "name", constants.InferenceServiceConfigMapName)
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+// NeedLeaderElection returns false, as the webhooks of every replica of the manager read the configuration
+func (w *ConfigWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload validates the ConfigMap and makes it the configuration of the manager when it is valid
+func (w *ConfigWatcher) reload(configMap *corev1.ConfigMap) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.configMap != nil && equality.Semantic.DeepEqual(w.configMap.Data, configMap.Data) {
+		return
+	}
+	if err := v1beta1.ValidateInferenceServiceConfigMap(configMap); err != nil {
+		log.Error(err, "The ConfigMap is invalid, keeping its last valid configuration",
+			"namespace", configMap.Namespace, "name", configMap.Name, "resourceVersion", configMap.ResourceVersion)
+		w.recorder.Eventf(configMap, corev1.EventTypeWarning, InvalidConfigReason,
+			"The configuration is invalid, the controller keeps its last valid configuration: %v", err)
+		return
+	}
+	// Update the global GPU resource types
+	if _, err := v1beta1.NewMultiNodeConfig(configMap); err != nil {
+		log.Error(err, "Failed to update the GPU resource types")
+	}
+	if settings := restartRequiredSettings(w.configMap, configMap); len(settings) > 0 {
+		w.recorder.Eventf(configMap, corev1.EventTypeWarning, ConfigRestartRequiredReason,
+			"The resources watched by the controller are set up on start, restart the controller to apply %v", settings)
+	}
+
+	w.configMap = configMap.DeepCopy()
+	v1beta1.SetInferenceServiceConfigMap(w.configMap)
+	log.Info("Reloaded the ConfigMap", "namespace", configMap.Namespace, "name", configMap.Name, "resourceVersion", configMap.ResourceVersion)
+	w.recorder.Eventf(configMap, corev1.EventTypeNormal, ConfigReloadedReason,
+		"Reloaded the configuration of resource version %s", configMap.ResourceVersion)
+	for _, subscriber := range w.subscribers {
+		select {
+		case subscriber <- event.GenericEvent{Object: w.configMap}:
+		default:
+		}
+	}
+}
+
+// restartRequiredSettings returns the changed settings which only take effect once the manager is restarted, as they
+// select the resources the controllers watch
+func restartRequiredSettings(old *corev1.ConfigMap, updated *corev1.ConfigMap) []string {
+	if old == nil {
+		return nil
+	}
+	oldIngress, err := v1beta1.NewIngressConfig(old)
+	if err != nil {
+		return nil
+	}
+	newIngress, err := v1beta1.NewIngressConfig(updated)
+	if err != nil {
+		return nil
+	}
+	var settings []string
+	if oldIngress.EnableGatewayAPI != newIngress.EnableGatewayAPI {
+		settings = append(settings, fmt.Sprintf("%s.enableGatewayApi", v1beta1.IngressConfigKeyName))
+	}
+	if oldIngress.DisableIstioVirtualHost != newIngress.DisableIstioVirtualHost {
+		settings = append(settings, fmt.Sprintf("%s.disableIstioVirtualHost", v1beta1.IngressConfigKeyName))
+	}
+	return settings
+}
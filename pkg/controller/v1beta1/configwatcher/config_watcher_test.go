@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configwatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeConfigMap(resourceVersion string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.InferenceServiceConfigMapName,
+			Namespace:       constants.KServeNamespace,
+			ResourceVersion: resourceVersion,
+		},
+		Data: data,
+	}
+}
+
+func TestConfigWatcherReload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	defer v1beta1.SetInferenceServiceConfigMap(nil)
+	recorder := record.NewFakeRecorder(10)
+	initial := makeConfigMap("1", map[string]string{
+		v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Knative"}`,
+		v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway"}`,
+	})
+	watcher := NewConfigWatcher(fake.NewSimpleClientset(), recorder, initial)
+	reloads := watcher.Subscribe()
+
+	// The unchanged ConfigMap is not reloaded
+	watcher.reload(initial)
+	g.Expect(recorder.Events).To(gomega.BeEmpty())
+	g.Expect(reloads).ToNot(gomega.Receive())
+
+	// The invalid ConfigMap is not used
+	watcher.reload(makeConfigMap("2", map[string]string{
+		v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Serverful"}`,
+		v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway"}`,
+	}))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.HavePrefix("Warning InvalidConfig The configuration is invalid")))
+	g.Expect(reloads).ToNot(gomega.Receive())
+	g.Expect(watcher.configMap).To(gomega.Equal(initial))
+
+	// The valid ConfigMap is reloaded and the subscribers are notified
+	watcher.reload(makeConfigMap("3", map[string]string{
+		v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Standard"}`,
+		v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway"}`,
+	}))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal("Normal ConfigReloaded Reloaded the configuration of resource version 3")))
+	g.Expect(reloads).To(gomega.Receive())
+	configMap, err := v1beta1.GetInferenceServiceConfigMap(t.Context(), fake.NewSimpleClientset())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	deployConfig, err := v1beta1.NewDeployConfig(configMap)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(deployConfig.DefaultDeploymentMode).To(gomega.Equal(string(constants.Standard)))
+
+	// The reloads which are not received yet are coalesced, and the settings of the watches require a restart
+	watcher.reload(makeConfigMap("4", map[string]string{
+		v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Standard"}`,
+		v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "disableIstioVirtualHost": true}`,
+	}))
+	watcher.reload(makeConfigMap("5", map[string]string{
+		v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Knative"}`,
+		v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "disableIstioVirtualHost": true}`,
+	}))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+		"Warning ConfigRestartRequired The resources watched by the controller are set up on start, restart the controller to apply [ingress.disableIstioVirtualHost]")))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal("Normal ConfigReloaded Reloaded the configuration of resource version 4")))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal("Normal ConfigReloaded Reloaded the configuration of resource version 5")))
+	g.Expect(reloads).To(gomega.Receive())
+	g.Expect(reloads).ToNot(gomega.Receive())
+}
+
+func TestConfigWatcherStart(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	initial := makeConfigMap("1", map[string]string{v1beta1.DeployConfigName: `{"defaultDeploymentMode": "Knative"}`})
+	clientset := fake.NewSimpleClientset(initial)
+	watcher := NewConfigWatcher(clientset, record.NewFakeRecorder(10), initial)
+	reloads := watcher.Subscribe()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- watcher.Start(ctx)
+	}()
+
+	// The ConfigMap the manager was set up with is used until it changes
+	g.Eventually(func() (string, error) {
+		configMap, err := v1beta1.GetInferenceServiceConfigMap(t.Context(), fake.NewSimpleClientset())
+		if err != nil {
+			return "", err
+		}
+		return configMap.ResourceVersion, nil
+	}).Should(gomega.Equal("1"))
+
+	updated := makeConfigMap("2", map[string]string{v1beta1.DeployConfigName: `{"defaultDeploymentMode": "Standard"}`})
+	_, err := clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Update(t.Context(), updated, metav1.UpdateOptions{})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Eventually(reloads, 5*time.Second).Should(gomega.Receive())
+	configMap, err := v1beta1.GetInferenceServiceConfigMap(t.Context(), fake.NewSimpleClientset())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(configMap.Data).To(gomega.Equal(updated.Data))
+
+	// The ConfigMap is read from the API server again once the watcher is stopped
+	cancel()
+	g.Eventually(done).Should(gomega.Receive(gomega.BeNil()))
+	_, err = v1beta1.GetInferenceServiceConfigMap(t.Context(), fake.NewSimpleClientset())
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configwatcher
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// NamespaceConfigCache caches the inferenceservice-config ConfigMaps of the namespaces for the namespace overrides.
+// Only the ConfigMaps of that name are listed, the other ConfigMaps of the namespaces are not cached.
+type NamespaceConfigCache struct {
+	cache.Cache
+}
+
+// NewNamespaceConfigCache creates the cache of the inferenceservice-config ConfigMaps of the namespaces
+func NewNamespaceConfigCache(config *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper) (*NamespaceConfigCache, error) {
+	configMapCache, err := cache.New(config, cache.Options{
+		Scheme: scheme,
+		Mapper: mapper,
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", constants.InferenceServiceConfigMapName)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &NamespaceConfigCache{Cache: configMapCache}, nil
+}
+
+// NeedLeaderElection returns false, as the webhooks of every replica of the manager read the namespace overrides
+func (c *NamespaceConfigCache) NeedLeaderElection() bool {
+	return false
+}
@@ -20,11 +20,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/network"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/sharding/memory"
@@ -41,6 +45,11 @@ func addStorageSpecAnnotations(storageSpec *v1beta1.ModelStorageSpec, annotation
 	if storageSpec == nil {
 		return false
 	}
+	addStorageFilterAnnotations(storageSpec, annotations)
+	// The storage credentials are still resolved from the storageUri when the storage spec only filters the files
+	if storageSpec.FiltersFilesOnly() {
+		return false
+	}
 	annotations[constants.StorageSpecAnnotationKey] = "true"
 	if storageSpec.Parameters != nil {
 		if jsonParam, err := json.Marshal(storageSpec.Parameters); err == nil {
@@ -57,10 +66,32 @@ func addStorageSpecAnnotations(storageSpec *v1beta1.ModelStorageSpec, annotation
 	return true
 }
 
+// addStorageFilterAnnotations passes the include and exclude glob patterns of the model files down to the storage
+// initializer
+func addStorageFilterAnnotations(storageSpec *v1beta1.ModelStorageSpec, annotations map[string]string) {
+	if len(storageSpec.Include) > 0 {
+		if jsonPatterns, err := json.Marshal(storageSpec.Include); err == nil {
+			annotations[constants.StorageIncludeInternalAnnotationKey] = string(jsonPatterns)
+		}
+	}
+	if len(storageSpec.Exclude) > 0 {
+		if jsonPatterns, err := json.Marshal(storageSpec.Exclude); err == nil {
+			annotations[constants.StorageExcludeInternalAnnotationKey] = string(jsonPatterns)
+		}
+	}
+}
+
 func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]string) {
 	if logger != nil {
 		annotations[constants.LoggerInternalAnnotationKey] = "true"
-		if logger.URL != nil {
+		if logger.Kafka != nil {
+			// the brokers and the topic of the kafka sink are passed on to the agent as a kafka:// log URL
+			annotations[constants.LoggerSinkUrlInternalAnnotationKey] = fmt.Sprintf("kafka://%s/%s",
+				strings.Join(logger.Kafka.Brokers, ","), logger.Kafka.Topic)
+			if logger.Kafka.SecretRef != nil {
+				annotations[constants.LoggerKafkaSecretInternalAnnotationKey] = logger.Kafka.SecretRef.Name
+			}
+		} else if logger.URL != nil {
 			annotations[constants.LoggerSinkUrlInternalAnnotationKey] = *logger.URL
 		}
 		annotations[constants.LoggerModeInternalAnnotationKey] = string(logger.Mode)
@@ -71,6 +102,52 @@ func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]str
 		if logger.MetadataAnnotations != nil {
 			annotations[constants.LoggerMetadataAnnotationsInternalAnnotationKey] = strings.Join(logger.MetadataAnnotations, ",")
 		}
+		addLoggerFilterAnnotations(logger, annotations)
+		addLoggerEncryptionAnnotations(logger.Encryption, annotations)
+	}
+}
+
+// addLoggerEncryptionAnnotations passes on the KMS key, or the secret key of the public key, encrypting the payloads
+// of the logs to the agent
+func addLoggerEncryptionAnnotations(encryption *v1beta1.LoggerEncryptionSpec, annotations map[string]string) {
+	switch {
+	case encryption == nil:
+	case encryption.KMSKeyID != nil:
+		annotations[constants.LoggerEncryptionKMSKeyInternalAnnotationKey] = *encryption.KMSKeyID
+	case encryption.PublicKeySecretRef != nil:
+		annotations[constants.LoggerEncryptionSecretInternalAnnotationKey] = encryption.PublicKeySecretRef.Name
+		annotations[constants.LoggerEncryptionSecretKeyInternalAnnotationKey] = encryption.PublicKeySecretRef.Key
+	}
+}
+
+// addLoggerFilterAnnotations passes on the sampling, the filters and the redacted fields of the logger to the agent,
+// the header filters as a comma separated list of name=value or name pairs
+func addLoggerFilterAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]string) {
+	if logger.Sampling != nil {
+		if logger.Sampling.Percent != nil {
+			annotations[constants.LoggerSamplePercentInternalAnnotationKey] = strconv.Itoa(int(*logger.Sampling.Percent))
+		}
+		if logger.Sampling.ErrorPercent != nil {
+			annotations[constants.LoggerErrorSamplePercentInternalAnnotationKey] = strconv.Itoa(int(*logger.Sampling.ErrorPercent))
+		}
+	}
+	if len(logger.HeaderFilters) > 0 {
+		headerFilters := []string{}
+		for _, filter := range logger.HeaderFilters {
+			if len(filter.Values) == 0 {
+				headerFilters = append(headerFilters, filter.Name)
+			}
+			for _, value := range filter.Values {
+				headerFilters = append(headerFilters, filter.Name+"="+value)
+			}
+		}
+		annotations[constants.LoggerHeaderFiltersInternalAnnotationKey] = strings.Join(headerFilters, ",")
+	}
+	if len(logger.StatusCodes) > 0 {
+		annotations[constants.LoggerStatusCodesInternalAnnotationKey] = strings.Join(logger.StatusCodes, ",")
+	}
+	if len(logger.RedactFields) > 0 {
+		annotations[constants.LoggerRedactFieldsInternalAnnotationKey] = strings.Join(logger.RedactFields, ",")
 	}
 }
 
@@ -86,6 +163,96 @@ func addBatcherAnnotations(batcher *v1beta1.Batcher, annotations map[string]stri
 			s := strconv.Itoa(*batcher.MaxLatency)
 			annotations[constants.BatcherMaxLatencyInternalAnnotationKey] = s
 		}
+		if batcher.Adaptive != nil {
+			annotations[constants.BatcherLatencySLOInternalAnnotationKey] = strconv.Itoa(batcher.Adaptive.LatencySLO)
+			if batcher.Adaptive.MinBatchSize != nil {
+				annotations[constants.BatcherMinBatchSizeInternalAnnotationKey] = strconv.Itoa(*batcher.Adaptive.MinBatchSize)
+			}
+		}
+	}
+}
+
+// addSlowStartAnnotations makes the agent of the component ramp up the concurrency admitted by the new replicas
+func addSlowStartAnnotations(spec *v1beta1.ComponentExtensionSpec, annotations map[string]string) {
+	if spec.SlowStart == nil {
+		return
+	}
+	initialPercent := v1beta1.DefaultSlowStartInitialPercent
+	if spec.SlowStart.InitialPercent != nil {
+		initialPercent = *spec.SlowStart.InitialPercent
+	}
+	annotations[constants.SlowStartWindowInternalAnnotationKey] = strconv.Itoa(int(spec.SlowStart.WindowSeconds))
+	annotations[constants.SlowStartInitialPercentInternalAnnotationKey] = strconv.Itoa(int(initialPercent))
+	annotations[constants.SlowStartMaxConcurrencyInternalAnnotationKey] = strconv.FormatInt(spec.GetSlowStartMaxConcurrency(), 10)
+}
+
+// addExplainerSamplingAnnotations makes the agent of the component receiving the prediction requests, the transformer
+// when there is one or else the predictor, send a sample of them to the explainer
+func addExplainerSamplingAnnotations(isvc *v1beta1.InferenceService, annotations map[string]string) {
+	if isvc.Spec.Explainer == nil || isvc.Spec.Explainer.Sampling == nil || isvc.Spec.Explainer.Sampling.Percent <= 0 {
+		return
+	}
+	annotations[constants.ExplainerSamplingPercentInternalAnnotationKey] = strconv.Itoa(int(isvc.Spec.Explainer.Sampling.Percent))
+	annotations[constants.ExplainerSamplingUrlInternalAnnotationKey] = "http://" +
+		network.GetServiceHostname(constants.ExplainerServiceName(isvc.Name), isvc.Namespace)
+}
+
+// addDualProtocolAnnotations makes the agent of the predictor translate the requests of the REST protocol the model
+// server does not support to its native protocol
+func addDualProtocolAnnotations(isvc *v1beta1.InferenceService, annotations map[string]string) {
+	if !isvc.Spec.Predictor.DualProtocol {
+		return
+	}
+	if protocol, ok := isvc.Spec.Predictor.GetDualProtocolNativeProtocol(isvc.Annotations); ok {
+		annotations[constants.DualProtocolInternalAnnotationKey] = string(protocol)
+	}
+}
+
+// addMonitoringAnnotations has the pod mutator inject the detector of the predictor monitoring, and the agent log the
+// requests of the predictor to the detector, with the logger of the predictor when it has one
+func addMonitoringAnnotations(predictor *v1beta1.PredictorSpec, annotations map[string]string) {
+	if predictor.Monitoring == nil {
+		return
+	}
+	jsonMonitoring, err := json.Marshal(predictor.Monitoring)
+	if err != nil {
+		return
+	}
+	annotations[constants.MonitoringInternalAnnotationKey] = string(jsonMonitoring)
+	if predictor.Logger == nil {
+		annotations[constants.LoggerInternalAnnotationKey] = "true"
+		annotations[constants.LoggerModeInternalAnnotationKey] = string(v1beta1.LogRequest)
+	}
+	annotations[constants.LoggerSinkUrlInternalAnnotationKey] = "http://localhost:" + constants.DetectorPortStr
+}
+
+// addWatchdogAnnotations makes the agent of the predictor watch the runtime for the stalled inference requests, as
+// configured by the serving runtime
+func addWatchdogAnnotations(sRuntime *v1alpha1.ServingRuntimeSpec, annotations map[string]string) {
+	if sRuntime.Watchdog == nil {
+		return
+	}
+	if jsonWatchdog, err := json.Marshal(sRuntime.Watchdog); err == nil {
+		annotations[constants.WatchdogInternalAnnotationKey] = string(jsonWatchdog)
+	}
+}
+
+// addModelServerAnnotations records the model server of the predictor container when the predictor scales on runtime
+// metrics, whose queries are templated for it by the KEDA autoscaler
+func addModelServerAnnotations(isvc *v1beta1.InferenceService, podSpec *corev1.PodSpec, annotations map[string]string) {
+	autoScaling := isvc.Spec.Predictor.AutoScaling
+	if autoScaling == nil || !slices.ContainsFunc(autoScaling.Metrics, func(metric v1beta1.MetricsSpec) bool {
+		return metric.Type == v1beta1.RuntimeMetricSourceType
+	}) {
+		return
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		if server := v1beta1.DetectModelServer(&podSpec.Containers[i]); server != "" {
+			annotations[constants.ModelServerInternalAnnotationKey] = string(server)
+		}
 	}
 }
 
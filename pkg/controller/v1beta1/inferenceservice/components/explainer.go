@@ -19,6 +19,7 @@ package components
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -48,18 +49,20 @@ var _ Component = &Explainer{}
 type Explainer struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
+	namespaceConfigMaps    client.Reader
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewExplainer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
+func NewExplainer(client client.Client, clientset kubernetes.Interface, namespaceConfigMaps client.Reader, scheme *runtime.Scheme,
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType,
 ) Component {
 	return &Explainer{
 		client:                 client,
 		clientset:              clientset,
+		namespaceConfigMaps:    namespaceConfigMaps,
 		scheme:                 scheme,
 		inferenceServiceConfig: inferenceServiceConfig,
 		deploymentMode:         deploymentMode,
@@ -88,6 +91,7 @@ func (e *Explainer) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 	}
 
 	addLoggerAnnotations(isvc.Spec.Explainer.Logger, annotations)
+	addSlowStartAnnotations(&isvc.Spec.Explainer.ComponentExtensionSpec, annotations)
 
 	explainerName := constants.ExplainerServiceName(isvc.Name)
 	predictorName := constants.PredictorServiceName(isvc.Name)
@@ -158,7 +162,7 @@ func (e *Explainer) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 }
 
 func (e *Explainer) reconcileExplainerRawDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) error {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, e.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, e.clientset, e.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -184,8 +188,8 @@ func (e *Explainer) reconcileExplainerRawDeployment(ctx context.Context, isvc *v
 		storageSpec = &modelStorageSpec.StorageSpec
 	}
 
-	r, err := raw.NewRawKubeReconciler(ctx, e.client, e.clientset, e.scheme, *objectMeta, metav1.ObjectMeta{},
-		&isvc.Spec.Explainer.ComponentExtensionSpec, podSpec, nil, &isvc.Spec.Explainer.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec)
+	r, err := raw.NewRawKubeReconciler(ctx, e.client, e.clientset, e.namespaceConfigMaps, e.scheme, *objectMeta, metav1.ObjectMeta{},
+		&isvc.Spec.Explainer.ComponentExtensionSpec, podSpec, nil, &isvc.Spec.Explainer.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec, nil)
 	if err != nil {
 		return errors.Wrapf(err, "fails to create NewRawKubeReconciler for explainer")
 	}
@@ -205,6 +209,10 @@ func (e *Explainer) reconcileExplainerRawDeployment(ctx context.Context, isvc *v
 	if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, e.scheme); err != nil {
 		return errors.Wrapf(err, "fails to set autoscaler owner references for explainer")
 	}
+	// set activator Controller
+	if err := r.Activator.SetControllerReferences(isvc, e.scheme); err != nil {
+		return errors.Wrapf(err, "fails to set activator owner references for explainer")
+	}
 
 	deployment, err := r.Reconcile(ctx)
 	if err != nil {
@@ -212,12 +220,13 @@ func (e *Explainer) reconcileExplainerRawDeployment(ctx context.Context, isvc *v
 	}
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateRawStatus(v1beta1.ExplainerComponent, deployment, r.URL)
+		isvc.Status.PropagateBlueGreenStatus(v1beta1.ExplainerComponent, r.BlueGreen.Status)
 	}
 	return nil
 }
 
 func (e *Explainer) reconcileExplainerKnativeDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) error {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, e.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, e.clientset, e.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -255,6 +264,7 @@ func (e *Explainer) reconcileExplainerKnativeDeployment(ctx context.Context, isv
 	}
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateStatus(v1beta1.ExplainerComponent, status)
+		isvc.Status.PropagateWarmStandby(v1beta1.ExplainerComponent, isvc.Spec.Explainer.WarmStandby, time.Now())
 	}
 	return nil
 }
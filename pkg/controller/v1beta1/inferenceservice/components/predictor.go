@@ -20,14 +20,18 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
@@ -43,6 +47,7 @@ import (
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/servertls"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
@@ -61,20 +66,25 @@ const (
 type Predictor struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
+	namespaceConfigMaps    client.Reader
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
+	localModelConfig       *v1beta1.LocalModelConfig
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewPredictor(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType,
+func NewPredictor(client client.Client, clientset kubernetes.Interface, namespaceConfigMaps client.Reader, scheme *runtime.Scheme,
+	inferenceServiceConfig *v1beta1.InferenceServicesConfig, localModelConfig *v1beta1.LocalModelConfig,
+	deploymentMode constants.DeploymentModeType,
 ) Component {
 	return &Predictor{
 		client:                 client,
 		clientset:              clientset,
+		namespaceConfigMaps:    namespaceConfigMaps,
 		scheme:                 scheme,
 		inferenceServiceConfig: inferenceServiceConfig,
+		localModelConfig:       localModelConfig,
 		deploymentMode:         deploymentMode,
 		Log:                    ctrl.Log.WithName("PredictorReconciler"),
 	}
@@ -105,7 +115,12 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 	p.Log.V(1).Info("Predictor custom labels", "labels", p.inferenceServiceConfig.ServiceLabelDisallowedList)
 
 	addLoggerAnnotations(isvc.Spec.Predictor.Logger, annotations)
+	addMonitoringAnnotations(&isvc.Spec.Predictor, annotations)
+	addSlowStartAnnotations(&isvc.Spec.Predictor.ComponentExtensionSpec, annotations)
 	addBatcherAnnotations(isvc.Spec.Predictor.Batcher, annotations)
+	if isvc.Spec.Transformer == nil {
+		addExplainerSamplingAnnotations(isvc, annotations)
+	}
 	// Add ModelStorageSpec annotations so mutator will mount storage credentials to InferenceService's predictor
 	addStorageSpecAnnotations(isvc.Spec.Predictor.GetImplementation().GetStorageSpec(), annotations)
 	// Add agent annotations so mutator will mount model agent to multi-model InferenceService's predictor
@@ -152,6 +167,11 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 		}
 	}
 
+	// The protocol of the model is only resolved once its runtime is selected
+	addDualProtocolAnnotations(isvc, annotations)
+	addModelServerAnnotations(isvc, &podSpec, annotations)
+	addWatchdogAnnotations(&sRuntime, annotations)
+
 	predictorName := constants.PredictorServiceName(isvc.Name)
 
 	// Labels and annotations from predictor component
@@ -167,6 +187,17 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 		return !utils.Includes(p.inferenceServiceConfig.ServiceAnnotationDisallowedList, key)
 	})
 	objectMeta := p.buildObjectMeta(isvc, predictorName, sRuntimeLabels, predictorLabels, sRuntimeAnnotations, annotations, predictorAnnotations)
+	// Knative rejects topology spread constraints unless the kubernetes.podspec-topologyspreadconstraints feature is enabled
+	if p.deploymentMode == constants.Standard {
+		isvcutils.AddZoneTopologySpreadConstraint(isvc, &podSpec)
+	}
+	isvcutils.AddArchitectureNodeAffinity(&sRuntime, &podSpec)
+	if p.inferenceServiceConfig.ExcludeWindowsNodes {
+		isvcutils.AddLinuxNodeAffinity(&podSpec)
+	}
+	if err := p.addCachedNodeAffinity(ctx, isvc, &podSpec); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// Autoscaler should be ignored when multiNodeEnabled is true
 	if multiNodeEnabled {
@@ -196,6 +227,12 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 	} else {
 		var err error
 		podLabelKey = constants.RevisionLabel
+		if isvc.Spec.Predictor.Capacity != nil {
+			p.Log.Info("Predictor capacity is only supported in Standard deployment mode, ignoring it", "isvc", isvc.Name)
+		}
+		if isvc.Spec.Observability != nil && isvc.Spec.Observability.OtelCollector != nil {
+			p.Log.Info("The OpenTelemetry Collector sidecar is only supported in Standard deployment mode, ignoring it", "isvc", isvc.Name)
+		}
 
 		if kstatus, err = p.reconcileKnativeDeployment(ctx, isvc, &objectMeta, &podSpec); err != nil {
 			return ctrl.Result{}, err
@@ -246,6 +283,31 @@ func (p *Predictor) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServic
 	}
 }
 
+// addCachedNodeAffinity schedules the predictor pods of a cached model on the nodes which downloaded the model
+func (p *Predictor) addCachedNodeAffinity(ctx context.Context, isvc *v1beta1.InferenceService, podSpec *corev1.PodSpec) error {
+	modelName, ok := isvc.Labels[constants.LocalModelLabel]
+	if !ok {
+		return nil
+	}
+	policy := v1beta1.CachedNodeAffinityPreferred
+	if p.localModelConfig != nil && p.localModelConfig.CachedNodeAffinity != "" {
+		policy = p.localModelConfig.CachedNodeAffinity
+	}
+	if policy == v1beta1.CachedNodeAffinityDisabled {
+		return nil
+	}
+	localModel := &v1alpha1.LocalModelCache{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: modelName}, localModel); err != nil {
+		if apierr.IsNotFound(err) {
+			p.Log.Info("LocalModelCache not found, skipping the cached node affinity", "localmodel", modelName)
+			return nil
+		}
+		return errors.Wrapf(err, "fails to get LocalModelCache %s", modelName)
+	}
+	isvcutils.AddCachedNodeAffinity(localModel, policy, podSpec)
+	return nil
+}
+
 func (p *Predictor) reconcileModelConfig(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	configMapReconciler := modelconfig.NewModelConfigReconciler(p.client, p.clientset, p.scheme)
 	return configMapReconciler.Reconcile(ctx, isvc)
@@ -297,6 +359,14 @@ func (p *Predictor) reconcileModel(ctx context.Context, isvc *v1beta1.InferenceS
 			return sRuntime, fmt.Errorf("specified runtime %s does not support specified protocol version", *isvc.Spec.Predictor.Model.Runtime)
 		}
 
+		if architecture := isvcutils.GetTargetArchitecture(isvc); !r.IsArchitectureSupported(architecture) {
+			isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
+				Reason:  v1beta1.NoSupportingRuntime,
+				Message: "Specified runtime does not support the architecture selected by the node selector",
+			})
+			return sRuntime, fmt.Errorf("specified runtime %s does not support architecture %s", *isvc.Spec.Predictor.Model.Runtime, architecture)
+		}
+
 		// Verify that the selected runtime supports the specified framework.
 		if !isvc.Spec.Predictor.Model.RuntimeSupportsModel(r) {
 			isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
@@ -319,6 +389,11 @@ func (p *Predictor) reconcileModel(ctx context.Context, isvc *v1beta1.InferenceS
 		if err != nil {
 			return sRuntime, err
 		}
+		// Skip the runtimes which do not support the architecture selected by the node selector
+		architecture := isvcutils.GetTargetArchitecture(isvc)
+		runtimes = slices.DeleteFunc(runtimes, func(rt v1alpha1.SupportedRuntime) bool {
+			return !rt.Spec.IsArchitectureSupported(architecture)
+		})
 		if len(runtimes) == 0 {
 			isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 				Reason:  v1beta1.NoSupportingRuntime,
@@ -381,6 +456,11 @@ func (p *Predictor) buildPodSpec(isvc *v1beta1.InferenceService, sRuntime v1alph
 		return podSpec, errors.Wrapf(err, ErrInvalidPlaceholder, *isvc.Spec.Predictor.Model.Runtime, predContainer.Name)
 	}
 
+	// Use the image of the architecture selected by the node selector, for the runtimes which are not multi-arch images
+	if image, ok := sRuntime.ArchitectureImages[mergedPodSpec.NodeSelector[corev1.LabelArchStable]]; ok {
+		predContainer.Image = image
+	}
+
 	// Update image tag if GPU is enabled or runtime version is provided
 	isvcutils.UpdateImageTag(predContainer, isvc.Spec.Predictor.Model.RuntimeVersion, isvc.Spec.Predictor.Model.Runtime)
 
@@ -677,7 +757,7 @@ func computeRayNodeAndGPUs(mergedWorkerPodSpec *corev1.PodSpec, totalRequestGPUC
 }
 
 func (p *Predictor) reconcileRawDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta, workerObjectMeta metav1.ObjectMeta, podSpec, workerPodSpec *corev1.PodSpec) error {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, p.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, p.clientset, p.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -703,8 +783,32 @@ func (p *Predictor) reconcileRawDeployment(ctx context.Context, isvc *v1beta1.In
 		storageSpec = &modelStorageSpec.StorageSpec
 	}
 
-	r, err := raw.NewRawKubeReconciler(ctx, p.client, p.clientset, p.scheme, objectMeta, workerObjectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
-		podSpec, workerPodSpec, &isvc.Spec.Predictor.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec)
+	// Inject the OpenTelemetry Collector sidecar provisioned for the predictor, which is named after the predictor
+	if isvc.Spec.Observability != nil && isvc.Spec.Observability.OtelCollector != nil {
+		otelConfig, err := v1beta1.NewOtelCollectorConfig(isvcConfigMap)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get otel collector config")
+		}
+		if otelConfig.ForwardEndpoint(isvc.Spec.Observability.OtelCollector) != "" {
+			objectMeta.Annotations = utils.Union(objectMeta.Annotations, map[string]string{
+				constants.OtelSidecarInjectAnnotationKey: objectMeta.Name,
+			})
+		} else {
+			p.Log.Info("No metric forward endpoint is configured, skipping the OpenTelemetry Collector sidecar", "isvc", isvc.Name)
+		}
+	}
+
+	// Provision the serving certificate of the predictor and serve HTTPS with it
+	serverTLSConfig, err := v1beta1.NewServerTLSConfig(isvcConfigMap)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get server TLS config")
+	}
+	if err := servertls.NewServerTLSReconciler(p.client, p.scheme, serverTLSConfig).Reconcile(ctx, isvc, &objectMeta, podSpec); err != nil {
+		return errors.Wrapf(err, "fails to reconcile the serving certificate of the predictor")
+	}
+
+	r, err := raw.NewRawKubeReconciler(ctx, p.client, p.clientset, p.namespaceConfigMaps, p.scheme, objectMeta, workerObjectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
+		podSpec, workerPodSpec, &isvc.Spec.Predictor.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec, isvc.Spec.Observability)
 	if err != nil {
 		return errors.Wrapf(err, "fails to create NewRawKubeReconciler for predictor")
 	}
@@ -715,6 +819,24 @@ func (p *Predictor) reconcileRawDeployment(ctx context.Context, isvc *v1beta1.In
 			return errors.Wrapf(err, "fails to set deployment owner reference for predictor")
 		}
 	}
+	// split the replicas between spot and on-demand nodes, the on-demand deployment inherits the owner reference
+	capacity := isvc.Spec.Predictor.Capacity
+	var spotPods *corev1.PodList
+	var rebalancedReplicas int32
+	if capacity != nil && workerPodSpec == nil {
+		capacityConfig, err := v1beta1.NewCapacityConfig(isvcConfigMap)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get capacity config")
+		}
+		spotPods, err = isvcutils.ListSpotPods(ctx, p.client, isvc.Namespace, objectMeta.Name)
+		if err != nil {
+			return errors.Wrapf(err, "fails to list spot pods for predictor")
+		}
+		rebalancedReplicas = isvcutils.GetRebalancedReplicas(spotPods)
+		r.SetCapacity(capacity, capacityConfig, rebalancedReplicas)
+	} else if err := r.Deployment.DeleteOnDemandDeployment(ctx); err != nil {
+		return errors.Wrapf(err, "fails to delete on-demand deployment for predictor")
+	}
 	for _, svc := range r.Service.ServiceList {
 		// set Service Controller
 		if err := controllerutil.SetControllerReference(isvc, svc, p.scheme); err != nil {
@@ -731,6 +853,10 @@ func (p *Predictor) reconcileRawDeployment(ctx context.Context, isvc *v1beta1.In
 	if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, p.scheme); err != nil {
 		return errors.Wrapf(err, "fails to set autoscaler owner references for predictor")
 	}
+	// set activator Controller
+	if err := r.Activator.SetControllerReferences(isvc, p.scheme); err != nil {
+		return errors.Wrapf(err, "fails to set activator owner references for predictor")
+	}
 
 	deploymentList, err := r.Reconcile(ctx)
 	if err != nil {
@@ -739,13 +865,19 @@ func (p *Predictor) reconcileRawDeployment(ctx context.Context, isvc *v1beta1.In
 
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateRawStatus(v1beta1.PredictorComponent, deploymentList, r.URL)
+		isvc.Status.PropagateBlueGreenStatus(v1beta1.PredictorComponent, r.BlueGreen.Status)
+		if spotPods != nil {
+			isvc.Status.PropagateCapacityStatus(spotPods, capacity.GetOnDemandReplicas()+rebalancedReplicas, rebalancedReplicas)
+		} else {
+			isvc.Status.Capacity = nil
+		}
 	}
 
 	return nil
 }
 
 func (p *Predictor) reconcileKnativeDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) (*knservingv1.ServiceStatus, error) {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, p.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, p.clientset, p.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -783,6 +915,7 @@ func (p *Predictor) reconcileKnativeDeployment(ctx context.Context, isvc *v1beta
 	}
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateStatus(v1beta1.PredictorComponent, kstatus)
+		isvc.Status.PropagateWarmStandby(v1beta1.PredictorComponent, isvc.Spec.Predictor.WarmStandby, time.Now())
 	}
 	return kstatus, nil
 }
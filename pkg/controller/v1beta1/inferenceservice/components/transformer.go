@@ -50,18 +50,20 @@ var _ Component = &Transformer{}
 type Transformer struct {
 	client                 client.Client
 	clientset              kubernetes.Interface
+	namespaceConfigMaps    client.Reader
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
 	deploymentMode         constants.DeploymentModeType
 	Log                    logr.Logger
 }
 
-func NewTransformer(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
+func NewTransformer(client client.Client, clientset kubernetes.Interface, namespaceConfigMaps client.Reader, scheme *runtime.Scheme,
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType,
 ) Component {
 	return &Transformer{
 		client:                 client,
 		clientset:              clientset,
+		namespaceConfigMaps:    namespaceConfigMaps,
 		scheme:                 scheme,
 		inferenceServiceConfig: inferenceServiceConfig,
 		deploymentMode:         deploymentMode,
@@ -90,7 +92,9 @@ func (p *Transformer) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServ
 	}
 
 	addLoggerAnnotations(isvc.Spec.Transformer.Logger, annotations)
+	addSlowStartAnnotations(&isvc.Spec.Transformer.ComponentExtensionSpec, annotations)
 	addBatcherAnnotations(isvc.Spec.Transformer.Batcher, annotations)
+	addExplainerSamplingAnnotations(isvc, annotations)
 
 	transformerName := constants.TransformerServiceName(isvc.Name)
 	predictorName := constants.PredictorServiceName(isvc.Name)
@@ -187,7 +191,7 @@ func (p *Transformer) Reconcile(ctx context.Context, isvc *v1beta1.InferenceServ
 }
 
 func (p *Transformer) reconcileTransformerRawDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) error {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, p.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, p.clientset, p.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -213,8 +217,8 @@ func (p *Transformer) reconcileTransformerRawDeployment(ctx context.Context, isv
 		storageSpec = &modelStorageSpec.StorageSpec
 	}
 
-	r, err := raw.NewRawKubeReconciler(ctx, p.client, p.clientset, p.scheme, *objectMeta, metav1.ObjectMeta{},
-		&isvc.Spec.Transformer.ComponentExtensionSpec, podSpec, nil, &isvc.Spec.Transformer.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec)
+	r, err := raw.NewRawKubeReconciler(ctx, p.client, p.clientset, p.namespaceConfigMaps, p.scheme, *objectMeta, metav1.ObjectMeta{},
+		&isvc.Spec.Transformer.ComponentExtensionSpec, podSpec, nil, &isvc.Spec.Transformer.StorageUris, storageInitializerConfig, storageSpec, credentialBuilder, storageContainerSpec, nil)
 	if err != nil {
 		return errors.Wrapf(err, "fails to create NewRawKubeReconciler for transformer")
 	}
@@ -234,6 +238,10 @@ func (p *Transformer) reconcileTransformerRawDeployment(ctx context.Context, isv
 	if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, p.scheme); err != nil {
 		return errors.Wrapf(err, "fails to set autoscaler owner references for transformer")
 	}
+	// set activator Controller
+	if err := r.Activator.SetControllerReferences(isvc, p.scheme); err != nil {
+		return errors.Wrapf(err, "fails to set activator owner references for transformer")
+	}
 
 	deployment, err := r.Reconcile(ctx)
 	if err != nil {
@@ -241,12 +249,13 @@ func (p *Transformer) reconcileTransformerRawDeployment(ctx context.Context, isv
 	}
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateRawStatus(v1beta1.TransformerComponent, deployment, r.URL)
+		isvc.Status.PropagateBlueGreenStatus(v1beta1.TransformerComponent, r.BlueGreen.Status)
 	}
 	return nil
 }
 
 func (p *Transformer) reconcileTransformerKnativeDeployment(ctx context.Context, isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) error {
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, p.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, p.clientset, p.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get InferenceService ConfigMap")
 	}
@@ -284,6 +293,7 @@ func (p *Transformer) reconcileTransformerKnativeDeployment(ctx context.Context,
 	}
 	if !utils.GetForceStopRuntime(isvc) {
 		isvc.Status.PropagateStatus(v1beta1.TransformerComponent, kstatus)
+		isvc.Status.PropagateWarmStandby(v1beta1.TransformerComponent, isvc.Spec.Transformer.WarmStandby, time.Now())
 	}
 	return nil
 }
@@ -17,10 +17,13 @@ limitations under the License.
 package inferenceservice
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -33,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -42,12 +46,14 @@ import (
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/yaml"
 
@@ -56,9 +62,27 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	knutils "github.com/kserve/kserve/pkg/controller/v1alpha1/utils"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/lifecycleevents"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/cabundleconfigmap"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/canary"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/checkpoint"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/configsnapshot"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/crashdiagnostics"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/dependency"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/externaldns"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/gpumemory"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/grafanadashboards"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/grpcdescriptors"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/idle"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/kueue"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/migpartition"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/podmonitor"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/readinessgate"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/replicaceiling"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
 )
@@ -70,7 +94,13 @@ import (
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterstoragecontainers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=localmodelcaches,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=servingquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=storageprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterinferencetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=modelcheckpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments/scale,verbs=get;update
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
@@ -81,17 +111,28 @@ import (
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling.internal.knative.dev,resources=podautoscalers,verbs=get;update
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=http.keda.sh,resources=httpscaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;list;watch;create;update;patch;delete
@@ -113,6 +154,17 @@ type InferenceServiceReconciler struct {
 	Log          logr.Logger
 	Scheme       *runtime.Scheme
 	Recorder     record.EventRecorder
+	// ConfigReloads receives an event once the inferenceservice-config ConfigMap is reloaded, so that all the
+	// InferenceServices are reconciled with the new configuration
+	ConfigReloads <-chan event.GenericEvent
+	// NamespaceConfigMaps is the cache of the inferenceservice-config ConfigMaps of the namespaces, the overrides are
+	// read from it and the InferenceServices of a namespace are reconciled once its overrides change
+	NamespaceConfigMaps cache.Cache
+
+	// inferenceProbes probes the predictor revisions in the background across the reconciliations
+	inferenceProbes *readinessgate.InferenceProbeReconciler
+	// prometheusQueriers are shared across the reconciliations, by the address of the Prometheus instance
+	prometheusQueriers *prometheus.Queriers
 }
 
 func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -122,12 +174,13 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if apierr.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			r.inferenceProbes.Forget(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, r.Clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, r.Clientset, r.NamespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		r.Log.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
 		return reconcile.Result{}, err
@@ -147,6 +200,14 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
 	}
+	localModelConfig, err := v1beta1.NewLocalModelConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create LocalModelConfig")
+	}
+	cloudEventsConfig, err := v1beta1.NewCloudEventsConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create CloudEventsConfig")
+	}
 
 	deploymentMode := isvcutils.GetDeploymentMode(isvc.Status.DeploymentMode, annotations, deployConfig)
 	r.Log.Info("Inference service deployment mode ", "deployment mode ", deploymentMode)
@@ -236,27 +297,65 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	r.Log.Info("Reconciling inference service", "apiVersion", isvc.APIVersion, "isvc", isvc.Name)
 
 	// Reconcile cabundleConfigMap
-	caBundleConfigMapReconciler := cabundleconfigmap.NewCaBundleConfigMapReconciler(r.Client, r.Clientset, r.Scheme)
+	caBundleConfigMapReconciler := cabundleconfigmap.NewCaBundleConfigMapReconciler(r.Client, r.Clientset, r.NamespaceConfigMaps, r.Scheme)
 	if err := caBundleConfigMapReconciler.Reconcile(ctx, isvc); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	// Reconcile the gRPC descriptors configmap
+	grpcDescriptorsReconciler := grpcdescriptors.NewGrpcDescriptorsReconciler(r.Clientset, r.Scheme)
+	if err := grpcDescriptorsReconciler.Reconcile(ctx, isvc); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Reconcile the Grafana dashboards configmap
+	grafanaDashboardsConfig, err := v1beta1.NewGrafanaDashboardsConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create GrafanaDashboardsConfig")
+	}
+	grafanaDashboardsReconciler := grafanadashboards.NewGrafanaDashboardsReconciler(r.Clientset, grafanaDashboardsConfig)
+	if err := grafanaDashboardsReconciler.Reconcile(ctx, isvc); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Block the creation of the components until the resources the InferenceService depends on are ready
+	if !forceStopRuntime {
+		dependencyReconciler := dependency.NewDependencyReconciler(r.Client, r.Clientset)
+		dependencyResult, err := dependencyReconciler.Reconcile(ctx, isvc)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile dependencies")
+		}
+		if dependencyResult.RequeueAfter > 0 {
+			r.Recorder.Event(isvc, corev1.EventTypeWarning, "DependenciesNotReady", isvc.Status.GetCondition(v1beta1.DependenciesReady).Message)
+			if err := r.updateStatus(ctx, isvc, deploymentMode, cloudEventsConfig); err != nil {
+				return reconcile.Result{}, err
+			}
+			return dependencyResult, nil
+		}
+	}
+
+	// Clamp the replicas of the components to the replica ceiling of the namespace before the autoscalers are reconciled
+	replicaCeilingReconciler := replicaceiling.NewReplicaCeilingReconciler(r.Client, r.Recorder)
+	if err := replicaCeilingReconciler.Reconcile(ctx, isvc, deploymentMode); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile the replica ceiling")
+	}
+
 	reconcilers := []components.Component{}
 	if deploymentMode != constants.ModelMeshDeployment {
-		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.NamespaceConfigMaps, r.Scheme, isvcConfig, localModelConfig, deploymentMode))
 	}
 	if isvc.Spec.Transformer != nil {
-		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Clientset, r.NamespaceConfigMaps, r.Scheme, isvcConfig, deploymentMode))
 	}
 	if isvc.Spec.Explainer != nil {
-		reconcilers = append(reconcilers, components.NewExplainer(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewExplainer(r.Client, r.Clientset, r.NamespaceConfigMaps, r.Scheme, isvcConfig, deploymentMode))
 	}
 	for _, reconciler := range reconcilers {
 		result, err := reconciler.Reconcile(ctx, isvc)
 		if err != nil {
 			r.Log.Error(err, "Failed to reconcile", "reconciler", reflect.ValueOf(reconciler), "Name", isvc.Name)
 			r.Recorder.Eventf(isvc, corev1.EventTypeWarning, "InternalError", err.Error())
-			if err := r.updateStatus(ctx, isvc, deploymentMode); err != nil {
+			if err := r.updateStatus(ctx, isvc, deploymentMode, cloudEventsConfig); err != nil {
 				r.Log.Error(err, "Error updating status")
 				return result, err
 			}
@@ -313,7 +412,14 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			isvc.Status.PropagateCrossComponentStatus(componentList, v1beta1.LatestDeploymentReady)
 		}
 	}
+	// Gate the predictor readiness on the inference probe before the ingress routes traffic to it
+	requeueResult, err := r.inferenceProbes.Reconcile(ctx, isvc, deploymentMode)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile readiness gates")
+	}
+
 	// Reconcile ingress
+	failoverWasActive := isvc.Status.IsConditionReady(v1beta1.FailoverActive)
 	ingressConfig, err := v1beta1.NewIngressConfig(isvcConfigMap)
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create IngressConfig")
@@ -346,22 +452,177 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	r.recordFailoverEvent(isvc, failoverWasActive)
+
+	// Publish the typed addresses of the InferenceService set by the ingress for the service discovery tools
+	isvc.Status.PropagateAddresses(servesGRPC(isvc))
+
+	// Publish the external hosts of the InferenceService with a DNSEndpoint of ExternalDNS
+	externalDNSConfig, err := v1beta1.NewExternalDNSConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create ExternalDNSConfig")
+	}
+	externalDNSReconciler := externaldns.NewExternalDNSReconciler(r.Client, r.Scheme, externalDNSConfig)
+	if err := externalDNSReconciler.Reconcile(ctx, isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile DNSEndpoint")
+	}
+
 	// Reconcile modelConfig
 	configMapReconciler := modelconfig.NewModelConfigReconciler(r.Client, r.Clientset, r.Scheme)
 	if err := configMapReconciler.Reconcile(ctx, isvc); err != nil {
 		return reconcile.Result{}, err
 	}
+	modelStatusReconciler := modelconfig.NewModelStatusReconciler(r.Client)
+	modelStatusResult, err := modelStatusReconciler.Reconcile(ctx, isvc, deploymentMode)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeueResult.RequeueAfter == 0 {
+		requeueResult = modelStatusResult
+	}
+
+	// The drift, the canary analysis and the idle timeouts query the metrics of the InferenceService from Prometheus
+	prometheusConfig, err := v1beta1.NewPrometheusConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create PrometheusConfig")
+	}
+	prometheusQuerier, err := r.prometheusQueriers.Get(prometheusConfig)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create the Prometheus querier")
+	}
+
+	// Recommend restarting the predictor pods whose GPU memory the agents find fragmented or leaking
+	gpuMemoryResult, err := gpumemory.NewGPUMemoryReconciler(r.Client).Reconcile(ctx, isvc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile GPU memory telemetry")
+	}
+	if gpuMemoryResult.RequeueAfter > 0 && (requeueResult.RequeueAfter == 0 || gpuMemoryResult.RequeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = gpuMemoryResult.RequeueAfter
+	}
+
+	// Surface the drift reported by the detector of the predictor monitoring
+	monitoringConfig, err := v1beta1.NewMonitoringConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create MonitoringConfig")
+	}
+	driftResult, err := drift.NewDriftReconciler(r.Recorder, monitoringConfig, prometheusQuerier).Reconcile(ctx, isvc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile drift")
+	}
+	if driftResult.RequeueAfter > 0 && (requeueResult.RequeueAfter == 0 || driftResult.RequeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = driftResult.RequeueAfter
+	}
+
+	// Have the MIG manager partition nodes for the MIG profile requested by the predictor
+	migPartitioningConfig, err := v1beta1.NewMIGPartitioningConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create MIGPartitioningConfig")
+	}
+	migPartitionResult, err := migpartition.NewMIGPartitionReconciler(r.Client, r.Clientset, migPartitioningConfig).Reconcile(ctx, isvc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile MIG partitions")
+	}
+	if migPartitionResult.RequeueAfter > 0 && (requeueResult.RequeueAfter == 0 || migPartitionResult.RequeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = migPartitionResult.RequeueAfter
+	}
+
+	// Capture the diagnostics of the last crash of the runtime containers before their pods are garbage collected
+	crashDiagnosticsConfig, err := v1beta1.NewCrashDiagnosticsConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create CrashDiagnosticsConfig")
+	}
+	crashDiagnosticsReconciler := crashdiagnostics.NewCrashDiagnosticsReconciler(r.Client, r.Clientset, r.Recorder, crashDiagnosticsConfig)
+	if err := crashDiagnosticsReconciler.Reconcile(ctx, isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile crash diagnostics")
+	}
+
+	checkpointRestoreConfig, err := v1beta1.NewCheckpointRestoreConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create CheckpointRestoreConfig")
+	}
+	checkpointReconciler := checkpoint.NewCheckpointReconciler(r.Client, r.Scheme, checkpointRestoreConfig)
+	if err := checkpointReconciler.Reconcile(ctx, isvc, deploymentMode); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile model checkpoint")
+	}
+
+	configSnapshotConfig, err := v1beta1.NewConfigSnapshotConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create ConfigSnapshotConfig")
+	}
+	configSnapshotReconciler := configsnapshot.NewConfigSnapshotReconciler(r.Client, r.Clientset, r.Scheme, configSnapshotConfig, isvcConfigMap)
+	if err := configSnapshotReconciler.Reconcile(ctx, isvc, deploymentMode); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile config snapshots")
+	}
+
+	podMonitorConfig, err := v1beta1.NewPodMonitorConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create PodMonitorConfig")
+	}
+	podMonitorReconciler := podmonitor.NewPodMonitorReconciler(r.Client, r.Scheme, podMonitorConfig)
+	if err := podMonitorReconciler.Reconcile(ctx, isvc, deploymentMode); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile pod monitor")
+	}
 
-	if err = r.updateStatus(ctx, isvc, deploymentMode); err != nil {
+	if err := r.reconcileProfileCapture(ctx, isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile profile capture")
+	}
+
+	// Promote or roll back the canary revisions from their metrics
+	canaryAnalysisConfig, err := v1beta1.NewCanaryAnalysisConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create CanaryAnalysisConfig")
+	}
+	canaryAnalysisReconciler := canary.NewCanaryAnalysisReconciler(r.Client, r.Recorder, canaryAnalysisConfig, prometheusQuerier)
+	canaryResult, err := canaryAnalysisReconciler.Reconcile(ctx, isvc, deploymentMode)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile canary analysis")
+	}
+	if canaryResult.RequeueAfter > 0 && (requeueResult.RequeueAfter == 0 || canaryResult.RequeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = canaryResult.RequeueAfter
+	}
+
+	if err = r.updateStatus(ctx, isvc, deploymentMode, cloudEventsConfig); err != nil {
 		r.Recorder.Event(isvc, corev1.EventTypeWarning, "InternalError", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue at the end of the soak period of the previous revisions kept warm to release them
+	if requeueAfter := isvc.Status.WarmStandbyRequeueAfter(time.Now()); requeueAfter > 0 &&
+		(requeueResult.RequeueAfter == 0 || requeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = requeueAfter
+	}
+
+	// Requeue at the end of the rollback windows of the blue-green rollouts to replace the previous pods
+	if requeueAfter := isvc.Status.BlueGreenRequeueAfter(time.Now()); requeueAfter > 0 &&
+		(requeueResult.RequeueAfter == 0 || requeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = requeueAfter
+	}
+
+	// Requeue at the next opening or closing of a scale schedule window to patch the minimum replicas of the HPA
+	if requeueAfter := isvc.ScaleScheduleRequeueAfter(time.Now()); requeueAfter > 0 &&
+		(requeueResult.RequeueAfter == 0 || requeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = requeueAfter
+	}
+
+	// Clean up the InferenceService once it has received no requests for its idle timeout
+	idleTimeoutConfig, err := v1beta1.NewIdleTimeoutConfig(isvcConfigMap)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create IdleTimeoutConfig")
+	}
+	idleTimeoutReconciler := idle.NewIdleTimeoutReconciler(r.Client, r.Recorder, idleTimeoutConfig, prometheusQuerier)
+	idleResult, err := idleTimeoutReconciler.Reconcile(ctx, isvc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile idle timeout")
+	}
+	if idleResult.RequeueAfter > 0 && (requeueResult.RequeueAfter == 0 || idleResult.RequeueAfter < requeueResult.RequeueAfter) {
+		requeueResult.RequeueAfter = idleResult.RequeueAfter
+	}
+
+	return requeueResult, nil
 }
 
 func (r *InferenceServiceReconciler) updateStatus(ctx context.Context, desiredService *v1beta1.InferenceService,
-	deploymentMode constants.DeploymentModeType,
+	deploymentMode constants.DeploymentModeType, cloudEventsConfig *v1beta1.CloudEventsConfig,
 ) error {
 	// set the DeploymentMode used for the InferenceService in the status
 	desiredService.Status.DeploymentMode = string(deploymentMode)
@@ -393,10 +654,27 @@ func (r *InferenceServiceReconciler) updateStatus(ctx context.Context, desiredSe
 			r.Recorder.Eventf(desiredService, corev1.EventTypeNormal, string(InferenceServiceReadyState),
 				fmt.Sprintf("InferenceService [%v] is Ready", desiredService.GetName()))
 		}
+		// The status is updated regardless of the delivery of the lifecycle events
+		emitter := lifecycleevents.NewEmitter(r.Client, cloudEventsConfig)
+		if err := emitter.Emit(ctx, existingService, desiredService); err != nil {
+			r.Log.Error(err, "Failed to send the lifecycle events of the InferenceService", "InferenceService", desiredService.Name)
+		}
 	}
 	return nil
 }
 
+// recordFailoverEvent records an event when the traffic of the InferenceService is shifted to or back from its failover target
+func (r *InferenceServiceReconciler) recordFailoverEvent(isvc *v1beta1.InferenceService, wasActive bool) {
+	isActive := isvc.Status.IsConditionReady(v1beta1.FailoverActive)
+	if !wasActive && isActive {
+		r.Recorder.Eventf(isvc, corev1.EventTypeWarning, "FailoverActivated",
+			"InferenceService [%v] is not ready, traffic is routed to failover target [%v]", isvc.GetName(), isvc.Spec.Failover.TargetRef.Name)
+	} else if wasActive && !isActive {
+		r.Recorder.Eventf(isvc, corev1.EventTypeNormal, "FailoverDeactivated",
+			"InferenceService [%v] traffic is routed back from the failover target", isvc.GetName())
+	}
+}
+
 func inferenceServiceReadiness(status v1beta1.InferenceServiceStatus) bool {
 	return status.Conditions != nil &&
 		status.GetCondition(apis.ConditionReady) != nil &&
@@ -421,6 +699,173 @@ func inferenceServiceStatusEqual(s1, s2 v1beta1.InferenceServiceStatus, deployme
 	return equality.Semantic.DeepEqual(s1, s2)
 }
 
+// failoverTargetFunc enqueues the InferenceServices which use the changed InferenceService as failover target or as
+// model variant, or depend on it, so that their traffic is shifted, or their components are created, as soon as its
+// readiness changes.
+func (r *InferenceServiceReconciler) failoverTargetFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	target, ok := obj.(*v1beta1.InferenceService)
+	if !ok || target == nil {
+		return nil
+	}
+
+	var isvcList v1beta1.InferenceServiceList
+	// List all InferenceServices in the same namespace.
+	if err := r.Client.List(ctx, &isvcList, client.InNamespace(target.Namespace)); err != nil {
+		r.Log.Error(err, "unable to list InferenceServices", "failoverTarget", target.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, isvc := range isvcList.Items {
+		dependsOnTarget := slices.Contains(isvc.Spec.DependsOn, v1beta1.DependencyReference{
+			Kind: v1beta1.InferenceServiceDependency,
+			Name: target.Name,
+		})
+		if dependsOnTarget || isvc.Spec.Failover != nil && isvc.Spec.Failover.TargetRef.Name == target.Name ||
+			servesVariant(&isvc, target.Name) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: isvc.Namespace,
+					Name:      isvc.Name,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// servesVariant returns whether the InferenceService of the given name serves a model variant of the A/B test of the
+// InferenceService
+func servesVariant(isvc *v1beta1.InferenceService, name string) bool {
+	if isvc.Spec.ABTest == nil {
+		return false
+	}
+	return slices.ContainsFunc(isvc.Spec.ABTest.Variants, func(variant v1beta1.ModelVariant) bool {
+		return variant.TargetRef != nil && variant.TargetRef.Name == name
+	})
+}
+
+// servesGRPC returns whether the predictor of the InferenceService serves a gRPC protocol
+func servesGRPC(isvc *v1beta1.InferenceService) bool {
+	if len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+		return false
+	}
+	protocol := isvc.Spec.Predictor.GetImplementation().GetProtocol()
+	return protocol == constants.ProtocolGRPCV1 || protocol == constants.ProtocolGRPCV2
+}
+
+// namespaceFunc enqueues all the InferenceServices of a namespace whose ingress gateway selection changed
+func (r *InferenceServiceReconciler) namespaceFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok || namespace == nil {
+		return nil
+	}
+
+	var isvcList v1beta1.InferenceServiceList
+	if err := r.Client.List(ctx, &isvcList, client.InNamespace(namespace.Name)); err != nil {
+		r.Log.Error(err, "unable to list InferenceServices", "namespace", namespace.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(isvcList.Items))
+	for _, isvc := range isvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: isvc.Namespace,
+				Name:      isvc.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// servingCertificateFunc enqueues the InferenceService of a serving certificate secret, so that the predictor pods are
+// rolled once the certificate is renewed with the Restart rotation
+func (r *InferenceServiceReconciler) servingCertificateFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	isvcName := obj.GetLabels()[constants.InferenceServicePodLabelKey]
+	if isvcName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: isvcName}}}
+}
+
+// namespaceConfigMapFunc enqueues all the InferenceServices of the namespace of an inferenceservice-config ConfigMap
+func (r *InferenceServiceReconciler) namespaceConfigMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != constants.InferenceServiceConfigMapName || obj.GetNamespace() == constants.KServeNamespace {
+		return nil
+	}
+	return r.namespaceFunc(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: obj.GetNamespace()}})
+}
+
+// servingQuotaFunc enqueues all the InferenceServices of the namespace of a ServingQuota whose replica ceiling changed
+func (r *InferenceServiceReconciler) servingQuotaFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	var isvcList v1beta1.InferenceServiceList
+	if err := r.Client.List(ctx, &isvcList, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "unable to list InferenceServices", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(isvcList.Items))
+	for _, isvc := range isvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: isvc.Namespace,
+				Name:      isvc.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// runtimeCrashFunc enqueues the InferenceService of a pod whose runtime container terminated
+func (r *InferenceServiceReconciler) runtimeCrashFunc(_ context.Context, obj client.Object) []reconcile.Request {
+	name, ok := obj.GetLabels()[constants.InferenceServicePodLabelKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      name,
+		},
+	}}
+}
+
+// runtimeTerminationTime returns the time the runtime container of the pod last terminated, zero when it never did
+func runtimeTerminationTime(pod *corev1.Pod) metav1.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		if status.State.Terminated != nil {
+			return status.State.Terminated.FinishedAt
+		}
+		if status.LastTerminationState.Terminated != nil {
+			return status.LastTerminationState.Terminated.FinishedAt
+		}
+	}
+	return metav1.Time{}
+}
+
+func (r *InferenceServiceReconciler) configReloadFunc(ctx context.Context, _ client.Object) []reconcile.Request {
+	var isvcList v1beta1.InferenceServiceList
+	if err := r.Client.List(ctx, &isvcList); err != nil {
+		r.Log.Error(err, "unable to list InferenceServices")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(isvcList.Items))
+	for _, isvc := range isvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: isvc.Namespace,
+				Name:      isvc.Name,
+			},
+		})
+	}
+	return requests
+}
+
 func (r *InferenceServiceReconciler) servingRuntimeFunc(ctx context.Context, obj client.Object) []reconcile.Request {
 	runtimeObj, ok := obj.(*v1alpha1.ServingRuntime)
 
@@ -456,6 +901,32 @@ func (r *InferenceServiceReconciler) servingRuntimeFunc(ctx context.Context, obj
 	return requests
 }
 
+// localModelCacheFunc enqueues the InferenceServices of a cached model, so that the node affinity of their predictor
+// pods is updated with the nodes holding the model
+func (r *InferenceServiceReconciler) localModelCacheFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	localModel, ok := obj.(*v1alpha1.LocalModelCache)
+	if !ok || localModel == nil {
+		return nil
+	}
+
+	var isvcList v1beta1.InferenceServiceList
+	if err := r.Client.List(ctx, &isvcList, client.MatchingLabels{constants.LocalModelLabel: localModel.Name}); err != nil {
+		r.Log.Error(err, "unable to list InferenceServices", "localmodel", localModel.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(isvcList.Items))
+	for _, isvc := range isvcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: isvc.Namespace,
+				Name:      isvc.Name,
+			},
+		})
+	}
+	return requests
+}
+
 func (r *InferenceServiceReconciler) clusterServingRuntimeFunc(ctx context.Context, obj client.Object) []reconcile.Request {
 	clusterServingRuntimeObj, ok := obj.(*v1alpha1.ClusterServingRuntime)
 
@@ -490,6 +961,8 @@ func (r *InferenceServiceReconciler) clusterServingRuntimeFunc(ctx context.Conte
 
 func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *v1beta1.DeployConfig, ingressConfig *v1beta1.IngressConfig) error {
 	r.ClientConfig = mgr.GetConfig()
+	r.inferenceProbes = readinessgate.NewInferenceProbeReconciler(r.Client)
+	r.prometheusQueriers = prometheus.NewQueriers()
 	ctx := context.Background()
 
 	ksvcFound, err := utils.IsCrdAvailable(r.ClientConfig, knservingv1.SchemeGroupVersion.String(), constants.KnativeServiceKind)
@@ -512,6 +985,16 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		return err
 	}
 
+	kueueFound, err := utils.IsCrdAvailable(r.ClientConfig, constants.KueueAPIVersion, constants.KueueWorkloadKind)
+	if err != nil {
+		return err
+	}
+
+	podMonitorFound, err := utils.IsCrdAvailable(r.ClientConfig, constants.PrometheusOperatorAPIVersion, constants.PodMonitorKind)
+	if err != nil {
+		return err
+	}
+
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &v1beta1.InferenceService{}, "spec.predictor.model.runtime", func(rawObj client.Object) []string {
 		isvc, ok := rawObj.(*v1beta1.InferenceService)
 		if !ok {
@@ -585,6 +1068,23 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch opentelemetry-collector resources because the CRD is not available.")
 	}
 
+	if kueueFound {
+		// Reconcile on the admission and eviction of the Kueue workloads
+		workload := &unstructured.Unstructured{}
+		workload.SetGroupVersionKind(kueue.WorkloadGVK)
+		ctrlBuilder = ctrlBuilder.Owns(workload)
+	} else {
+		r.Log.Info("The InferenceService controller won't watch kueue.x-k8s.io/v1beta1/Workload resources because the CRD is not available.")
+	}
+
+	if podMonitorFound {
+		podMonitor := &unstructured.Unstructured{}
+		podMonitor.SetGroupVersionKind(podmonitor.PodMonitorGVK)
+		ctrlBuilder = ctrlBuilder.Owns(podMonitor)
+	} else {
+		r.Log.Info("The InferenceService controller won't watch monitoring.coreos.com/v1/PodMonitor resources because the CRD is not available.")
+	}
+
 	if vsFound && !ingressConfig.DisableIstioVirtualHost {
 		ctrlBuilder = ctrlBuilder.Owns(&istioclientv1beta1.VirtualService{})
 	} else {
@@ -607,8 +1107,114 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		ctrlBuilder = ctrlBuilder.Owns(&netv1.Ingress{})
 	}
 
+	failoverTargetPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldIsvc := e.ObjectOld.(*v1beta1.InferenceService)
+			newIsvc := e.ObjectNew.(*v1beta1.InferenceService)
+			return oldIsvc.Status.IsReady() != newIsvc.Status.IsReady()
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	localModelCachePredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldLocalModel := e.ObjectOld.(*v1alpha1.LocalModelCache)
+			newLocalModel := e.ObjectNew.(*v1alpha1.LocalModelCache)
+			return !reflect.DeepEqual(isvcutils.GetCachedNodes(oldLocalModel), isvcutils.GetCachedNodes(newLocalModel))
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	namespacePredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAnnotations := e.ObjectOld.GetAnnotations()
+			newAnnotations := e.ObjectNew.GetAnnotations()
+			for _, key := range []string{
+				constants.IngressGatewayNamespaceAnnotationKey,
+				constants.KServeIngressGatewayNamespaceAnnotationKey,
+				constants.IngressClassNameNamespaceAnnotationKey,
+			} {
+				if oldAnnotations[key] != newAnnotations[key] {
+					return true
+				}
+			}
+			return false
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	servingQuotaPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldQuota, oldOk := e.ObjectOld.(*v1alpha1.ServingQuota)
+			newQuota, newOk := e.ObjectNew.(*v1alpha1.ServingQuota)
+			return oldOk && newOk && !equality.Semantic.DeepEqual(oldQuota.Spec.MaxReplicas, newQuota.Spec.MaxReplicas)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			quota, ok := e.Object.(*v1alpha1.ServingQuota)
+			return ok && quota.Spec.MaxReplicas != nil
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			quota, ok := e.Object.(*v1alpha1.ServingQuota)
+			return ok && quota.Spec.MaxReplicas != nil
+		},
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	runtimeCrashPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, oldOk := e.ObjectOld.(*corev1.Pod)
+			newPod, newOk := e.ObjectNew.(*corev1.Pod)
+			if !oldOk || !newOk || newPod.Labels[constants.InferenceServicePodLabelKey] == "" {
+				return false
+			}
+			oldTime, newTime := runtimeTerminationTime(oldPod), runtimeTerminationTime(newPod)
+			return !newTime.IsZero() && !oldTime.Equal(&newTime)
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	servingCertificatePredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, oldOk := e.ObjectOld.(*corev1.Secret)
+			newSecret, newOk := e.ObjectNew.(*corev1.Secret)
+			return oldOk && newOk && !bytes.Equal(oldSecret.Data[corev1.TLSCertKey], newSecret.Data[corev1.TLSCertKey])
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return true },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	if r.ConfigReloads != nil {
+		ctrlBuilder = ctrlBuilder.WatchesRawSource(source.Channel(r.ConfigReloads, handler.EnqueueRequestsFromMapFunc(r.configReloadFunc)))
+	}
+	if r.NamespaceConfigMaps != nil {
+		namespaceConfigMapPredicate := predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldConfigMap, oldOk := e.ObjectOld.(*corev1.ConfigMap)
+				newConfigMap, newOk := e.ObjectNew.(*corev1.ConfigMap)
+				return oldOk && newOk && !reflect.DeepEqual(oldConfigMap.Data, newConfigMap.Data)
+			},
+		}
+		ctrlBuilder = ctrlBuilder.WatchesRawSource(source.Kind(r.NamespaceConfigMaps, client.Object(&corev1.ConfigMap{}),
+			handler.EnqueueRequestsFromMapFunc(r.namespaceConfigMapFunc), namespaceConfigMapPredicate))
+	}
+
 	return ctrlBuilder.Watches(&v1alpha1.ServingRuntime{}, handler.EnqueueRequestsFromMapFunc(r.servingRuntimeFunc), builder.WithPredicates(servingRuntimesPredicate)).
 		Watches(&v1alpha1.ClusterServingRuntime{}, handler.EnqueueRequestsFromMapFunc(r.clusterServingRuntimeFunc), builder.WithPredicates(clusterServingRuntimesPredicate)).
+		Watches(&v1beta1.InferenceService{}, handler.EnqueueRequestsFromMapFunc(r.failoverTargetFunc), builder.WithPredicates(failoverTargetPredicate)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.namespaceFunc), builder.WithPredicates(namespacePredicate)).
+		Watches(&v1alpha1.LocalModelCache{}, handler.EnqueueRequestsFromMapFunc(r.localModelCacheFunc), builder.WithPredicates(localModelCachePredicate)).
+		Watches(&v1alpha1.ServingQuota{}, handler.EnqueueRequestsFromMapFunc(r.servingQuotaFunc), builder.WithPredicates(servingQuotaPredicate)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.runtimeCrashFunc), builder.WithPredicates(runtimeCrashPredicate)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.servingCertificateFunc), builder.WithPredicates(servingCertificatePredicate)).
 		Complete(r)
 }
 
@@ -631,6 +1237,12 @@ func (r *InferenceServiceReconciler) deleteExternalResources(ctx context.Context
 			r.Log.Error(err, "unable to delete trainedmodel", "trainedmodel", v)
 		}
 	}
+
+	// Release the nodes partitioned for the MIG profile of the predictor
+	if err := migpartition.Release(ctx, r.Client, isvc); err != nil {
+		r.Log.Error(err, "unable to release the MIG partitioned nodes", "inferenceservice", isvc.Name)
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// Types of the lifecycle events of the InferenceServices
+const (
+	RevisionReady  = "org.kserve.inferenceservice.revision.ready"
+	TrafficShifted = "org.kserve.inferenceservice.traffic.shifted"
+	ModelLoaded    = "org.kserve.inferenceservice.model.loaded"
+	ModelUnloaded  = "org.kserve.inferenceservice.model.unloaded"
+	Scaled         = "org.kserve.inferenceservice.scaled"
+)
+
+// cloud events extension attributes have to be lowercase alphanumeric
+const (
+	InferenceServiceAttr = "inferenceservicename"
+	NamespaceAttr        = "namespace"
+	ComponentAttr        = "component"
+)
+
+// sendTimeout bounds the time spent sending an event, as the events are sent while reconciling
+const sendTimeout = 5 * time.Second
+
+// EventData is the data of the lifecycle events
+type EventData struct {
+	InferenceService string `json:"inferenceService"`
+	Namespace        string `json:"namespace"`
+	Generation       int64  `json:"generation"`
+	// Component of the InferenceService the event is about, if any
+	Component string `json:"component,omitempty"`
+	// Revision that became ready. Only set in Knative deployment mode.
+	Revision string `json:"revision,omitempty"`
+	URL      string `json:"url,omitempty"`
+	// Traffic is the new traffic distribution of the component
+	Traffic []knservingv1.TrafficTarget `json:"traffic,omitempty"`
+	// FailoverTarget is the InferenceService the traffic is shifted to, if the failover is activated
+	FailoverTarget string             `json:"failoverTarget,omitempty"`
+	ModelState     v1beta1.ModelState `json:"modelState,omitempty"`
+	// Replicas is the number of ready replicas of the predictor
+	Replicas         *int `json:"replicas,omitempty"`
+	PreviousReplicas *int `json:"previousReplicas,omitempty"`
+}
+
+// Sender sends an event to a sink
+type Sender interface {
+	Send(ctx context.Context, sink string, event cloudevents.Event) error
+}
+
+// Emitter sends the lifecycle events of the InferenceServices, derived from the changes of their status, to the sink
+// of their namespace
+type Emitter struct {
+	client client.Client
+	config *v1beta1.CloudEventsConfig
+	sender Sender
+}
+
+func NewEmitter(client client.Client, config *v1beta1.CloudEventsConfig) *Emitter {
+	return &Emitter{
+		client: client,
+		config: config,
+		sender: defaultSender(),
+	}
+}
+
+// Emit sends the events of the status change of the InferenceService. Failing to deliver an event does not stop
+// sending the next ones.
+func (e *Emitter) Emit(ctx context.Context, existing *v1beta1.InferenceService, desired *v1beta1.InferenceService) error {
+	if !e.config.Enabled {
+		return nil
+	}
+	events, err := Events(existing, desired)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+	sink, err := e.sink(ctx, desired.Namespace)
+	if err != nil || sink == "" {
+		return err
+	}
+	var errs []error
+	for _, event := range events {
+		if err := e.sender.Send(ctx, sink, event); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send event %s to %s: %w", event.Type(), sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sink returns the sink of the events of the namespace, the namespace annotation taking precedence over the sink
+// of the configmap. An empty annotation turns off the events of the namespace.
+func (e *Emitter) sink(ctx context.Context, namespace string) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := e.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return "", err
+	}
+	if sink, ok := ns.Annotations[constants.CloudEventsSinkAnnotationKey]; ok {
+		return sink, nil
+	}
+	return e.config.Sink, nil
+}
+
+// Events returns the lifecycle events of the change of the status of the InferenceService from existing to desired
+func Events(existing *v1beta1.InferenceService, desired *v1beta1.InferenceService) ([]cloudevents.Event, error) {
+	var eventTypes []string
+	var data []EventData
+	add := func(eventType string, d EventData) {
+		eventTypes, data = append(eventTypes, eventType), append(data, d)
+	}
+	newData := func(component v1beta1.ComponentType) EventData {
+		return EventData{
+			InferenceService: desired.Name,
+			Namespace:        desired.Namespace,
+			Generation:       desired.Generation,
+			Component:        string(component),
+		}
+	}
+
+	for _, component := range []v1beta1.ComponentType{v1beta1.PredictorComponent, v1beta1.TransformerComponent, v1beta1.ExplainerComponent} {
+		desiredStatus, ok := desired.Status.Components[component]
+		if !ok {
+			continue
+		}
+		existingStatus := existing.Status.Components[component]
+		if desiredStatus.LatestReadyRevision != "" && desiredStatus.LatestReadyRevision != existingStatus.LatestReadyRevision {
+			d := newData(component)
+			d.Revision = desiredStatus.LatestReadyRevision
+			if desiredStatus.URL != nil {
+				d.URL = desiredStatus.URL.String()
+			}
+			add(RevisionReady, d)
+		}
+		if len(desiredStatus.Traffic) > 0 && !equality.Semantic.DeepEqual(desiredStatus.Traffic, existingStatus.Traffic) {
+			d := newData(component)
+			d.Traffic = desiredStatus.Traffic
+			add(TrafficShifted, d)
+		}
+	}
+
+	// Without revisions, e.g. in Standard deployment mode, the InferenceService becoming ready is the readiness of its
+	// latest revision
+	if !hasRevision(desired.Status) && !isReady(existing.Status) && isReady(desired.Status) {
+		d := newData("")
+		if desired.Status.URL != nil {
+			d.URL = desired.Status.URL.String()
+		}
+		add(RevisionReady, d)
+	}
+
+	wasFailoverActive := existing.Status.IsConditionReady(v1beta1.FailoverActive)
+	isFailoverActive := desired.Status.IsConditionReady(v1beta1.FailoverActive)
+	if wasFailoverActive != isFailoverActive {
+		d := newData("")
+		if isFailoverActive && desired.Spec.Failover != nil {
+			d.FailoverTarget = desired.Spec.Failover.TargetRef.Name
+		}
+		add(TrafficShifted, d)
+	}
+
+	existingState, desiredState := modelState(existing.Status), modelState(desired.Status)
+	if desiredState == v1beta1.Loaded && existingState != v1beta1.Loaded {
+		d := newData(v1beta1.PredictorComponent)
+		d.ModelState = desiredState
+		add(ModelLoaded, d)
+	} else if existingState == v1beta1.Loaded && desiredState != v1beta1.Loaded {
+		d := newData(v1beta1.PredictorComponent)
+		d.ModelState = desiredState
+		add(ModelUnloaded, d)
+	}
+
+	existingReplicas, desiredReplicas := readyReplicas(existing.Status), readyReplicas(desired.Status)
+	if existingReplicas != desiredReplicas {
+		d := newData(v1beta1.PredictorComponent)
+		d.Replicas, d.PreviousReplicas = &desiredReplicas, &existingReplicas
+		add(Scaled, d)
+	}
+
+	now := time.Now()
+	events := make([]cloudevents.Event, 0, len(data))
+	for i, d := range data {
+		event := cloudevents.NewEvent(cloudevents.VersionV1)
+		event.SetID(uuid.NewString())
+		event.SetTime(now)
+		event.SetType(eventTypes[i])
+		event.SetSource(fmt.Sprintf("/apis/%s/namespaces/%s/inferenceservices/%s",
+			v1beta1.SchemeGroupVersion.String(), desired.Namespace, desired.Name))
+		event.SetSubject(desired.Name)
+		event.SetExtension(InferenceServiceAttr, desired.Name)
+		event.SetExtension(NamespaceAttr, desired.Namespace)
+		if d.Component != "" {
+			event.SetExtension(ComponentAttr, d.Component)
+		}
+		if err := event.SetData(cloudevents.ApplicationJSON, d); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func hasRevision(status v1beta1.InferenceServiceStatus) bool {
+	for _, componentStatus := range status.Components {
+		if componentStatus.LatestReadyRevision != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isReady(status v1beta1.InferenceServiceStatus) bool {
+	readyCondition := status.GetCondition(apis.ConditionReady)
+	return readyCondition != nil && readyCondition.Status == corev1.ConditionTrue
+}
+
+func modelState(status v1beta1.InferenceServiceStatus) v1beta1.ModelState {
+	if status.ModelStatus.ModelRevisionStates == nil {
+		return ""
+	}
+	return status.ModelStatus.ModelRevisionStates.TargetModelState
+}
+
+func readyReplicas(status v1beta1.InferenceServiceStatus) int {
+	if status.ModelStatus.ModelCopies == nil {
+		return 0
+	}
+	return status.ModelStatus.ModelCopies.TotalCopies
+}
+
+// httpSender sends the events over HTTP in binary mode
+type httpSender struct {
+	client cloudevents.Client
+}
+
+// defaultSender returns the sender shared by the emitters, so that the connections to the sinks are reused
+var defaultSender = sync.OnceValue(func() Sender {
+	ceClient, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return &failingSender{err: err}
+	}
+	return &httpSender{client: ceClient}
+})
+
+func (s *httpSender) Send(ctx context.Context, sink string, event cloudevents.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	ctx = cloudevents.ContextWithTarget(cloudevents.WithEncodingBinary(ctx), sink)
+	if result := s.client.Send(ctx, event); !cloudevents.IsACK(result) {
+		return result
+	}
+	return nil
+}
+
+type failingSender struct {
+	err error
+}
+
+func (s *failingSender) Send(ctx context.Context, sink string, event cloudevents.Event) error {
+	return fmt.Errorf("failed to create the cloud events client: %w", s.err)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycleevents
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newInferenceService(mutate func(status *v1beta1.InferenceServiceStatus)) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", Generation: 2},
+	}
+	if mutate != nil {
+		mutate(&isvc.Status)
+	}
+	return isvc
+}
+
+func TestEvents(t *testing.T) {
+	scenarios := map[string]struct {
+		existing *v1beta1.InferenceService
+		desired  *v1beta1.InferenceService
+		expected []string
+		check    func(t *testing.T, data []EventData)
+	}{
+		"NoChange": {
+			existing: newInferenceService(nil),
+			desired:  newInferenceService(nil),
+			expected: []string{},
+		},
+		"KnativeRevisionReady": {
+			existing: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+					v1beta1.PredictorComponent: {LatestReadyRevision: "sklearn-predictor-00001"},
+				}
+			}),
+			desired: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+				status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+					v1beta1.PredictorComponent: {
+						LatestReadyRevision: "sklearn-predictor-00002",
+						Traffic: []knservingv1.TrafficTarget{
+							{RevisionName: "sklearn-predictor-00001", Percent: ptr.To(int64(90))},
+							{RevisionName: "sklearn-predictor-00002", Percent: ptr.To(int64(10)), LatestRevision: ptr.To(true)},
+						},
+					},
+				}
+			}),
+			expected: []string{RevisionReady, TrafficShifted},
+			check: func(t *testing.T, data []EventData) {
+				assert.Equal(t, "predictor", data[0].Component)
+				assert.Equal(t, "sklearn-predictor-00002", data[0].Revision)
+				assert.Len(t, data[1].Traffic, 2)
+			},
+		},
+		"StandardReady": {
+			existing: newInferenceService(nil),
+			desired: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+			}),
+			expected: []string{RevisionReady},
+			check: func(t *testing.T, data []EventData) {
+				assert.Equal(t, "sklearn", data[0].InferenceService)
+				assert.Equal(t, int64(2), data[0].Generation)
+				assert.Empty(t, data[0].Revision)
+			},
+		},
+		"FailoverActivated": {
+			existing: newInferenceService(nil),
+			desired: func() *v1beta1.InferenceService {
+				isvc := newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+					status.Conditions = duckv1.Conditions{{Type: v1beta1.FailoverActive, Status: corev1.ConditionTrue}}
+				})
+				isvc.Spec.Failover = &v1beta1.FailoverSpec{TargetRef: v1beta1.FailoverTargetReference{Name: "sklearn-fallback"}}
+				return isvc
+			}(),
+			expected: []string{TrafficShifted},
+			check: func(t *testing.T, data []EventData) {
+				assert.Equal(t, "sklearn-fallback", data[0].FailoverTarget)
+			},
+		},
+		"ModelLoadedAndScaledUp": {
+			existing: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.UpdateModelRevisionStates(v1beta1.Loading, nil)
+			}),
+			desired: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.UpdateModelRevisionStates(v1beta1.Loaded, nil)
+				status.ModelStatus.ModelCopies = &v1beta1.ModelCopies{TotalCopies: 2}
+			}),
+			expected: []string{ModelLoaded, Scaled},
+			check: func(t *testing.T, data []EventData) {
+				assert.Equal(t, v1beta1.Loaded, data[0].ModelState)
+				assert.Equal(t, 2, *data[1].Replicas)
+				assert.Equal(t, 0, *data[1].PreviousReplicas)
+			},
+		},
+		"ModelUnloadedAndScaledToZero": {
+			existing: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.UpdateModelRevisionStates(v1beta1.Loaded, nil)
+				status.ModelStatus.ModelCopies = &v1beta1.ModelCopies{TotalCopies: 1}
+			}),
+			desired: newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+				status.UpdateModelRevisionStates(v1beta1.Pending, nil)
+				status.ModelStatus.ModelCopies = &v1beta1.ModelCopies{TotalCopies: 0}
+			}),
+			expected: []string{ModelUnloaded, Scaled},
+			check: func(t *testing.T, data []EventData) {
+				assert.Equal(t, v1beta1.Pending, data[0].ModelState)
+				assert.Equal(t, 0, *data[1].Replicas)
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			events, err := Events(scenario.existing, scenario.desired)
+			require.NoError(t, err)
+			eventTypes := []string{}
+			data := []EventData{}
+			for _, event := range events {
+				require.NoError(t, event.Validate())
+				assert.Equal(t, "/apis/serving.kserve.io/v1beta1/namespaces/default/inferenceservices/sklearn", event.Source())
+				assert.Equal(t, "sklearn", event.Extensions()[InferenceServiceAttr])
+				eventTypes = append(eventTypes, event.Type())
+				d := EventData{}
+				require.NoError(t, event.DataAs(&d))
+				data = append(data, d)
+			}
+			assert.Equal(t, scenario.expected, eventTypes)
+			if scenario.check != nil {
+				scenario.check(t, data)
+			}
+		})
+	}
+}
+
+type recordingSender struct {
+	sinks  []string
+	events []cloudevents.Event
+}
+
+func (s *recordingSender) Send(ctx context.Context, sink string, event cloudevents.Event) error {
+	s.sinks = append(s.sinks, sink)
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestEmit(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-a",
+			Annotations: map[string]string{constants.CloudEventsSinkAnnotationKey: "http://team-a-broker.team-a.svc"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-b",
+			Annotations: map[string]string{constants.CloudEventsSinkAnnotationKey: ""},
+		}},
+	).Build()
+
+	ready := func(status *v1beta1.InferenceServiceStatus) {
+		status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	}
+	scenarios := map[string]struct {
+		namespace string
+		config    *v1beta1.CloudEventsConfig
+		expected  []string
+	}{
+		"Disabled": {
+			namespace: "default",
+			config:    &v1beta1.CloudEventsConfig{Sink: "http://broker.default.svc"},
+			expected:  nil,
+		},
+		"DefaultSink": {
+			namespace: "default",
+			config:    &v1beta1.CloudEventsConfig{Enabled: true, Sink: "http://broker.default.svc"},
+			expected:  []string{"http://broker.default.svc"},
+		},
+		"NamespaceSink": {
+			namespace: "team-a",
+			config:    &v1beta1.CloudEventsConfig{Enabled: true, Sink: "http://broker.default.svc"},
+			expected:  []string{"http://team-a-broker.team-a.svc"},
+		},
+		"NamespaceOptOut": {
+			namespace: "team-b",
+			config:    &v1beta1.CloudEventsConfig{Enabled: true, Sink: "http://broker.default.svc"},
+			expected:  nil,
+		},
+		"NoSink": {
+			namespace: "default",
+			config:    &v1beta1.CloudEventsConfig{Enabled: true},
+			expected:  nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			sender := &recordingSender{}
+			emitter := &Emitter{client: cl, config: scenario.config, sender: sender}
+			existing, desired := newInferenceService(nil), newInferenceService(ready)
+			existing.Namespace, desired.Namespace = scenario.namespace, scenario.namespace
+			require.NoError(t, emitter.Emit(t.Context(), existing, desired))
+			assert.Equal(t, scenario.expected, sender.sinks)
+		})
+	}
+}
+
+func TestHttpSender(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	events, err := Events(newInferenceService(nil), newInferenceService(func(status *v1beta1.InferenceServiceStatus) {
+		status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}}
+	}))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	require.NoError(t, defaultSender().Send(t.Context(), server.URL, events[0]))
+	req := <-received
+	assert.Equal(t, RevisionReady, req.Header.Get("Ce-Type"))
+	assert.Equal(t, "sklearn", req.Header.Get("Ce-Inferenceservicename"))
+	assert.NotEmpty(t, req.Header.Get("Ce-Id"))
+
+	server.Close()
+	assert.Error(t, defaultSender().Send(t.Context(), server.URL, events[0]))
+}
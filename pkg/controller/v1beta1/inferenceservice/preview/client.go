@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// objectKey identifies a recorded object by kind, namespace and name
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	key types.NamespacedName
+}
+
+// recordingClient reads the objects from the cluster and records the objects written by the reconcilers instead of
+// persisting them, so that the reconcilers render the objects they would create without side effects. The recorded
+// objects are read back by the reconcilers, as if they were persisted.
+type recordingClient struct {
+	client.Client
+	objects map[objectKey]client.Object
+	// order of the first write of the objects
+	order []objectKey
+}
+
+var _ client.Client = &recordingClient{}
+
+func newRecordingClient(c client.Client) *recordingClient {
+	return &recordingClient{
+		Client:  c,
+		objects: map[objectKey]client.Object{},
+	}
+}
+
+func (c *recordingClient) objectKey(obj client.Object, key types.NamespacedName) (objectKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return objectKey{}, err
+	}
+	return objectKey{gvk: gvk, key: key}, nil
+}
+
+func (c *recordingClient) record(obj client.Object) error {
+	key, err := c.objectKey(obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	if _, ok := c.objects[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	recorded := obj.DeepCopyObject().(client.Object)
+	recorded.GetObjectKind().SetGroupVersionKind(key.gvk)
+	c.objects[key] = recorded
+	return nil
+}
+
+// Objects returns the recorded objects in the order they were first written
+func (c *recordingClient) Objects() []client.Object {
+	objects := make([]client.Object, 0, len(c.order))
+	for _, key := range c.order {
+		if obj, ok := c.objects[key]; ok {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+func (c *recordingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	recordedKey, err := c.objectKey(obj, key)
+	if err != nil {
+		return err
+	}
+	if recorded, ok := c.objects[recordedKey]; ok {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(recorded.DeepCopyObject()).Elem())
+		return nil
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.record(obj)
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.record(obj)
+}
+
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.record(obj)
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	key, err := c.objectKey(obj, client.ObjectKeyFromObject(obj))
+	if err != nil {
+		return err
+	}
+	delete(c.objects, key)
+	return nil
+}
+
+func (c *recordingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return nil
+}
+
+func (c *recordingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *recordingClient) SubResource(subResource string) client.SubResourceClient {
+	return &noopSubResourceClient{}
+}
+
+// noopSubResourceClient discards the writes of the sub resources, e.g. the status
+type noopSubResourceClient struct{}
+
+func (*noopSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return nil
+}
+
+func (*noopSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (*noopSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (*noopSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return nil
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("InferenceServicePreview")
+
+const (
+	// PreviewPath is the path of the preview endpoint on the webhook server of the controller
+	PreviewPath = "/preview-inferenceservices"
+	// maxRequestBodySize is the maximum size of the InferenceService manifests
+	maxRequestBodySize = 1 << 20
+)
+
+// Result is the response of the preview endpoint
+type Result struct {
+	// Allowed is whether the InferenceService is admitted by the webhooks
+	Allowed bool `json:"allowed"`
+	// Error is the reason the InferenceService is not admitted, or the error rendering its objects
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	// InferenceService is the InferenceService defaulted by the webhook, with the runtime selected for the predictor
+	InferenceService *v1beta1.InferenceService    `json:"inferenceService,omitempty"`
+	DeploymentMode   constants.DeploymentModeType `json:"deploymentMode,omitempty"`
+	Objects          []*unstructured.Unstructured `json:"objects,omitempty"`
+}
+
+// Previewer defaults, validates and renders an InferenceService manifest as the webhooks and the controller would,
+// without persisting anything, e.g. for IDE plugins and CI checks. The objects of the components, like the
+// deployments, the services and the autoscalers, are rendered with the runtimes and the configuration of the cluster.
+// The requests are authenticated with the bearer token of the caller, who must be allowed to create the
+// InferenceService in its namespace.
+type Previewer struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	// NamespaceConfigMaps reads the inferenceservice-config ConfigMaps of the namespaces, from the API server when nil
+	NamespaceConfigMaps client.Reader
+	Scheme              *runtime.Scheme
+	Defaulter           admission.CustomDefaulter
+	Validator           admission.CustomValidator
+	// Handlers are the other validating webhooks of the InferenceServices, e.g. the quota validator
+	Handlers []admission.Handler
+}
+
+func (p *Previewer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxRequestBodySize {
+		http.Error(w, "the InferenceService manifest is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	// The manifests are accepted in YAML or JSON
+	isvc := &v1beta1.InferenceService{}
+	if err := yaml.Unmarshal(body, isvc); err != nil {
+		http.Error(w, fmt.Sprintf("invalid InferenceService manifest: %v", err), http.StatusBadRequest)
+		return
+	}
+	if isvc.Namespace == "" {
+		isvc.Namespace = r.URL.Query().Get("namespace")
+	}
+	if isvc.Namespace == "" {
+		isvc.Namespace = metav1.NamespaceDefault
+	}
+
+	if status, err := p.authorize(r.Context(), r, isvc); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	result := p.Preview(r.Context(), isvc)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error(err, "Failed to write the preview", "name", isvc.Name, "namespace", isvc.Namespace)
+	}
+}
+
+// authenticate reviews the bearer token of the request and returns the user it belongs to
+func authenticate(ctx context.Context, clientset kubernetes.Interface, r *http.Request) (*authnv1.UserInfo, int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, http.StatusUnauthorized, errors.New("a bearer token is required")
+	}
+	review, err := clientset.AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.Error(err, "Failed to review the token")
+		return nil, http.StatusInternalServerError, errors.New("failed to authenticate the request")
+	}
+	if !review.Status.Authenticated {
+		return nil, http.StatusUnauthorized, errors.New("invalid bearer token")
+	}
+	return &review.Status.User, http.StatusOK, nil
+}
+
+// authorize checks that the bearer token of the request is allowed to create the InferenceService
+func (p *Previewer) authorize(ctx context.Context, r *http.Request, isvc *v1beta1.InferenceService) (int, error) {
+	user, status, err := authenticate(ctx, p.Clientset, r)
+	if err != nil {
+		return status, err
+	}
+
+	extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authzv1.ExtraValue(value)
+	}
+	access, err := p.Clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: isvc.Namespace,
+				Verb:      "create",
+				Group:     v1beta1.SchemeGroupVersion.Group,
+				Resource:  "inferenceservices",
+				Name:      isvc.Name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.Error(err, "Failed to review the access", "user", user.Username)
+		return http.StatusInternalServerError, errors.New("failed to authorize the request")
+	}
+	if !access.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %q cannot create inferenceservices in the namespace %q",
+			user.Username, isvc.Namespace)
+	}
+	return http.StatusOK, nil
+}
+
+// Preview defaults, validates and renders the InferenceService without authorizing the caller, who is authorized by
+// ServeHTTP, or by the API server when the clients of the Previewer use the credentials of the caller.
+func (p *Previewer) Preview(ctx context.Context, isvc *v1beta1.InferenceService) *Result {
+	if err := p.Defaulter.Default(ctx, isvc); err != nil {
+		return &Result{Error: err.Error()}
+	}
+	warnings, err := p.Validator.ValidateCreate(ctx, isvc)
+	if err != nil {
+		return &Result{Error: err.Error(), Warnings: warnings}
+	}
+
+	raw, err := json.Marshal(isvc)
+	if err != nil {
+		return &Result{Error: err.Error(), Warnings: warnings}
+	}
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("InferenceService")),
+		Resource:  metav1.GroupVersionResource(v1beta1.SchemeGroupVersion.WithResource("inferenceservices")),
+		Name:      isvc.Name,
+		Namespace: isvc.Namespace,
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+		DryRun:    ptr.To(true),
+	}}
+	for _, handler := range p.Handlers {
+		response := handler.Handle(ctx, req)
+		warnings = append(warnings, response.Warnings...)
+		if !response.Allowed {
+			message := "denied by a validating webhook"
+			if response.Result != nil && response.Result.Message != "" {
+				message = response.Result.Message
+			}
+			return &Result{Error: message, Warnings: warnings}
+		}
+	}
+
+	result := &Result{Allowed: true, Warnings: warnings, InferenceService: isvc}
+	deploymentMode, objects, err := p.render(ctx, isvc)
+	result.DeploymentMode = deploymentMode
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to render the InferenceService: %v", err)
+		return result
+	}
+	for _, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to render the InferenceService: %v", err)
+			return result
+		}
+		result.Objects = append(result.Objects, &unstructured.Unstructured{Object: content})
+	}
+	return result
+}
+
+// render runs the component reconcilers of the InferenceService controller with a client recording the objects
+func (p *Previewer) render(ctx context.Context, isvc *v1beta1.InferenceService) (constants.DeploymentModeType, []client.Object, error) {
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, p.Clientset, p.NamespaceConfigMaps, isvc.Namespace)
+	if err != nil {
+		return "", nil, err
+	}
+	isvcConfig, err := v1beta1.NewInferenceServicesConfig(isvcConfigMap)
+	if err != nil {
+		return "", nil, err
+	}
+	deployConfig, err := v1beta1.NewDeployConfig(isvcConfigMap)
+	if err != nil {
+		return "", nil, err
+	}
+	localModelConfig, err := v1beta1.NewLocalModelConfig(isvcConfigMap)
+	if err != nil {
+		return "", nil, err
+	}
+	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
+		return !utils.Includes(isvcConfig.ServiceAnnotationDisallowedList, key)
+	})
+	deploymentMode := isvcutils.GetDeploymentMode(isvc.Status.DeploymentMode, annotations, deployConfig)
+
+	recorder := newRecordingClient(p.Client)
+	reconcilers := []components.Component{}
+	if deploymentMode != constants.ModelMeshDeployment {
+		reconcilers = append(reconcilers, components.NewPredictor(recorder, p.Clientset, p.NamespaceConfigMaps, p.Scheme, isvcConfig, localModelConfig, deploymentMode))
+	}
+	if isvc.Spec.Transformer != nil {
+		reconcilers = append(reconcilers, components.NewTransformer(recorder, p.Clientset, p.NamespaceConfigMaps, p.Scheme, isvcConfig, deploymentMode))
+	}
+	if isvc.Spec.Explainer != nil {
+		reconcilers = append(reconcilers, components.NewExplainer(recorder, p.Clientset, p.NamespaceConfigMaps, p.Scheme, isvcConfig, deploymentMode))
+	}
+	for _, reconciler := range reconcilers {
+		if _, err := reconciler.Reconcile(ctx, isvc); err != nil {
+			return deploymentMode, nil, err
+		}
+	}
+	return deploymentMode, recorder.Objects(), nil
+}
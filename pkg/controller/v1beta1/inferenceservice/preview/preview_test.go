@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+type noopDefaulter struct{}
+
+func (noopDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func newTestPreviewer(t *testing.T, allowed bool) (*Previewer, client.Client) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	servingRuntime := &v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1alpha1.SupportedModelFormat{{Name: "sklearn", AutoSelect: ptr.To(true)}},
+			ProtocolVersions:      []constants.InferenceServiceProtocol{constants.ProtocolV1},
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:latest"}},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(servingRuntime).Build()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			v1beta1.DeployConfigName:     `{"defaultDeploymentMode": "Standard"}`,
+			v1beta1.IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "kserveIngressGateway": "kserve/kserve-ingress-gateway", "ingressDomain": "example.com"}`,
+			v1beta1.StorageInitializerConfigMapKeyName: `{"image": "kserve/storage-initializer:latest", "memoryRequest": "100Mi",
+				"memoryLimit": "1Gi", "cpuRequest": "100m", "cpuLimit": "1", "cpuModelcar": "10m", "memoryModelcar": "15Mi"}`,
+		},
+	}
+	clientset := k8sfake.NewSimpleClientset(configMap)
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == "valid-token"
+		review.Status.User = authnv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}}
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+		review.Status.Allowed = allowed && review.Spec.User == "alice" &&
+			review.Spec.ResourceAttributes.Resource == "inferenceservices" && review.Spec.ResourceAttributes.Verb == "create"
+		return true, review, nil
+	})
+
+	return &Previewer{
+		Client:    cl,
+		Clientset: clientset,
+		Scheme:    s,
+		Defaulter: noopDefaulter{},
+		Validator: &v1beta1.InferenceServiceValidator{},
+	}, cl
+}
+
+const sklearnManifest = `
+apiVersion: serving.kserve.io/v1beta1
+kind: InferenceService
+metadata:
+  name: %s
+  namespace: default
+spec:
+  predictor:
+    model:
+      modelFormat:
+        name: sklearn
+      storageUri: gs://kfserving-examples/models/sklearn/1.0/model
+`
+
+func preview(t *testing.T, previewer *Previewer, token string, manifest string) (*httptest.ResponseRecorder, *Result) {
+	req := httptest.NewRequest(http.MethodPost, PreviewPath, strings.NewReader(manifest))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	previewer.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		return recorder, nil
+	}
+	result := &Result{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), result))
+	return recorder, result
+}
+
+func TestPreviewRendersObjects(t *testing.T) {
+	previewer, cl := newTestPreviewer(t, true)
+
+	_, result := preview(t, previewer, "valid-token", strings.Replace(sklearnManifest, "%s", "sklearn", 1))
+	require.NotNil(t, result)
+	assert.True(t, result.Allowed)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, constants.Standard, result.DeploymentMode)
+	assert.Equal(t, "kserve-sklearnserver", result.InferenceService.Status.ClusterServingRuntimeName)
+
+	kinds := map[string]string{}
+	for _, obj := range result.Objects {
+		kinds[obj.GetKind()] = obj.GetName()
+	}
+	assert.Equal(t, "sklearn-predictor", kinds["Deployment"])
+	assert.Equal(t, "sklearn-predictor", kinds["Service"])
+
+	// Nothing is persisted
+	deployments := &appsv1.DeploymentList{}
+	require.NoError(t, cl.List(t.Context(), deployments))
+	assert.Empty(t, deployments.Items)
+}
+
+func TestPreviewValidationError(t *testing.T) {
+	previewer, _ := newTestPreviewer(t, true)
+
+	_, result := preview(t, previewer, "valid-token", strings.Replace(sklearnManifest, "%s", "1-sklearn", 1))
+	require.NotNil(t, result)
+	assert.False(t, result.Allowed)
+	assert.Contains(t, result.Error, "1-sklearn")
+	assert.Empty(t, result.Objects)
+}
+
+func TestPreviewAuthorization(t *testing.T) {
+	manifest := strings.Replace(sklearnManifest, "%s", "sklearn", 1)
+
+	previewer, _ := newTestPreviewer(t, true)
+	response, _ := preview(t, previewer, "", manifest)
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	response, _ = preview(t, previewer, "invalid-token", manifest)
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	previewer, _ = newTestPreviewer(t, false)
+	response, _ = preview(t, previewer, "valid-token", manifest)
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	req := httptest.NewRequest(http.MethodGet, PreviewPath, nil)
+	recorder := httptest.NewRecorder()
+	previewer.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// SimulationPath is the path of the autoscaling simulation endpoint on the webhook server of the controller
+	SimulationPath = "/simulate-autoscaling"
+	// maxSimulationSeconds bounds the duration of the replayed traffic
+	maxSimulationSeconds = 31 * 24 * 60 * 60
+)
+
+// SimulationRequest is the request of the autoscaling simulation endpoint
+type SimulationRequest struct {
+	// Traffic is the historical request rate of the component replayed through the autoscaler
+	Traffic Traffic `json:"traffic"`
+	// Scaling is the proposed scaling configuration of the component
+	Scaling ScalingConfig `json:"scaling"`
+	// Capacity is the measured capacity of a replica of the component
+	Capacity Capacity `json:"capacity"`
+	// SLOTarget is the fraction of the requests to serve within the capacity of the ready replicas, e.g. 0.99
+	SLOTarget float64 `json:"sloTarget,omitempty"`
+}
+
+// Traffic is a series of samples of the request rate, e.g. the result of a Prometheus range query
+type Traffic struct {
+	// IntervalSeconds is the interval between the samples
+	IntervalSeconds int32 `json:"intervalSeconds"`
+	// RequestsPerSecond are the samples of the request rate
+	RequestsPerSecond []float64 `json:"requestsPerSecond"`
+}
+
+// ScalingConfig are the scaling fields of the InferenceService components, with the class of the autoscaler
+type ScalingConfig struct {
+	// AutoscalerClass is hpa, kpa or keda, defaults to hpa
+	AutoscalerClass constants.AutoscalerClassType `json:"autoscalerClass,omitempty"`
+	// MinReplicas defaults to 1, the KPA and KEDA scale to zero when it is 0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas defaults to the minimum replicas, the KPA does not bound the replicas when it is 0
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// ScaleMetric is cpu, rps or concurrency, defaults to concurrency for the KPA and to cpu otherwise
+	ScaleMetric *v1beta1.ScaleMetric `json:"scaleMetric,omitempty"`
+	// ScaleTarget is the target value of the metric per replica
+	ScaleTarget *int32 `json:"scaleTarget,omitempty"`
+	// StabilizationWindowSeconds is the scale down stabilization window of the HPA and KEDA, after which KEDA also
+	// scales to zero without traffic, or the stable window of the KPA
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+}
+
+// Capacity is the capacity of a replica of the component, e.g. measured by a load test
+type Capacity struct {
+	// RequestsPerSecond is the request rate a replica serves within the latency SLO
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// LatencySeconds is the mean latency of the requests, from which the concurrency is derived
+	LatencySeconds float64 `json:"latencySeconds,omitempty"`
+	// StartupSeconds is the time a new replica takes to be ready, including pulling the image and loading the model
+	StartupSeconds int32 `json:"startupSeconds,omitempty"`
+}
+
+// SimulationReport is the response of the autoscaling simulation endpoint
+type SimulationReport struct {
+	// Steps are the replicas at the end of each sample of the traffic
+	Steps []SimulationStep `json:"steps"`
+	// PeakReplicas is the highest number of replicas
+	PeakReplicas int32 `json:"peakReplicas"`
+	// ReplicaSeconds is the sum of the replicas over time, which the cost of the component is proportional to
+	ReplicaSeconds int64 `json:"replicaSeconds"`
+	// ScaleEvents is the number of changes of the replicas
+	ScaleEvents int32 `json:"scaleEvents"`
+	// ColdStarts is the number of scales from zero
+	ColdStarts int32 `json:"coldStarts"`
+	// UnderProvisionedSeconds is the time the request rate exceeds the capacity of the ready replicas
+	UnderProvisionedSeconds int64 `json:"underProvisionedSeconds"`
+	// SLOAttainment is the fraction of the requests served within the capacity of the ready replicas
+	SLOAttainment float64 `json:"sloAttainment"`
+	// SLOMet is whether the SLO attainment reaches the SLO target, when there is one
+	SLOMet *bool `json:"sloMet,omitempty"`
+}
+
+// SimulationStep is the state of the component at the end of a sample of the traffic
+type SimulationStep struct {
+	Seconds           int64   `json:"seconds"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Replicas          int32   `json:"replicas"`
+	ReadyReplicas     int32   `json:"readyReplicas"`
+	SLOAttainment     float64 `json:"sloAttainment"`
+}
+
+// Simulator replays the historical traffic of a component through a model of the KPA, the HPA or KEDA configured
+// with a proposed scaling configuration, and reports the replicas and the SLO attainment over time, so that the
+// scaling parameters are tuned before they are applied. The requests are authenticated with the bearer token of
+// the caller.
+type Simulator struct {
+	Clientset kubernetes.Interface
+}
+
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, status, err := authenticate(r.Context(), s.Clientset, r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxRequestBodySize {
+		http.Error(w, "the simulation request is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	request := &SimulationRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid simulation request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	report, err := Simulate(request)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid simulation request: %v", err), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error(err, "Failed to write the simulation report")
+	}
+}
+
+// Simulate replays the traffic second by second. The component starts with its minimum replicas ready, the new
+// replicas are ready after the startup time, and each ready replica serves up to its capacity.
+func Simulate(request *SimulationRequest) (*SimulationReport, error) {
+	config, err := resolveScalingConfig(request)
+	if err != nil {
+		return nil, err
+	}
+	scaler := newScaler(config)
+	startup := int64(request.Capacity.StartupSeconds)
+	interval := int64(request.Traffic.IntervalSeconds)
+
+	// pods are the start times of the replicas, the newest last
+	pods := make([]int64, config.minReplicas)
+	for i := range pods {
+		pods[i] = -startup
+	}
+	report := &SimulationReport{PeakReplicas: config.minReplicas}
+	var requested, served float64
+	for i, rps := range request.Traffic.RequestsPerSecond {
+		var stepRequested, stepServed float64
+		var ready int32
+		for now := int64(i) * interval; now < int64(i+1)*interval; now++ {
+			ready = int32(sort.Search(len(pods), func(j int) bool { return now-pods[j] < startup }))
+			capacity := float64(ready) * request.Capacity.RequestsPerSecond
+			servedNow := math.Min(rps, capacity)
+			utilization := 0.0
+			if capacity > 0 {
+				utilization = servedNow / capacity * 100
+			}
+			stepRequested += rps
+			stepServed += servedNow
+			if servedNow < rps {
+				report.UnderProvisionedSeconds++
+			}
+			report.ReplicaSeconds += int64(len(pods))
+
+			replicas := int32(len(pods))
+			desired := scaler.scale(now, observation{
+				requests:    rps,
+				concurrency: rps * request.Capacity.LatencySeconds,
+				utilization: utilization,
+				ready:       ready,
+			}, replicas)
+			if desired == replicas {
+				continue
+			}
+			report.ScaleEvents++
+			if replicas == 0 {
+				report.ColdStarts++
+			}
+			if desired > replicas {
+				for range desired - replicas {
+					pods = append(pods, now)
+				}
+			} else {
+				pods = pods[:desired]
+			}
+			report.PeakReplicas = max(report.PeakReplicas, desired)
+		}
+		requested += stepRequested
+		served += stepServed
+		report.Steps = append(report.Steps, SimulationStep{
+			Seconds:           int64(i+1) * interval,
+			RequestsPerSecond: rps,
+			Replicas:          int32(len(pods)),
+			ReadyReplicas:     ready,
+			SLOAttainment:     attainment(stepServed, stepRequested),
+		})
+	}
+
+	report.SLOAttainment = attainment(served, requested)
+	if request.SLOTarget > 0 {
+		report.SLOMet = ptr.To(report.SLOAttainment >= request.SLOTarget)
+	}
+	return report, nil
+}
+
+func attainment(served, requested float64) float64 {
+	if requested == 0 {
+		return 1
+	}
+	return served / requested
+}
+
+// scalingConfig is the scaling configuration with the defaults of the autoscaler class applied
+type scalingConfig struct {
+	class               constants.AutoscalerClassType
+	minReplicas         int32
+	maxReplicas         int32
+	metric              v1beta1.ScaleMetric
+	target              float64
+	stabilizationWindow int64
+}
+
+func resolveScalingConfig(request *SimulationRequest) (*scalingConfig, error) {
+	traffic := request.Traffic
+	if traffic.IntervalSeconds <= 0 {
+		return nil, errors.New("the interval of the traffic samples must be positive")
+	}
+	if len(traffic.RequestsPerSecond) == 0 {
+		return nil, errors.New("the traffic has no samples")
+	}
+	if int64(traffic.IntervalSeconds)*int64(len(traffic.RequestsPerSecond)) > maxSimulationSeconds {
+		return nil, fmt.Errorf("the traffic must not span more than %d seconds", maxSimulationSeconds)
+	}
+	if slices.ContainsFunc(traffic.RequestsPerSecond, func(rps float64) bool { return rps < 0 || math.IsNaN(rps) }) {
+		return nil, errors.New("the request rate must not be negative")
+	}
+	if request.Capacity.RequestsPerSecond <= 0 {
+		return nil, errors.New("the request rate a replica serves must be positive")
+	}
+	if request.Capacity.LatencySeconds < 0 || request.Capacity.StartupSeconds < 0 {
+		return nil, errors.New("the latency and the startup time of a replica must not be negative")
+	}
+	if request.SLOTarget < 0 || request.SLOTarget > 1 {
+		return nil, errors.New("the SLO target must be between 0 and 1")
+	}
+
+	scaling := request.Scaling
+	config := &scalingConfig{class: scaling.AutoscalerClass}
+	if config.class == "" {
+		config.class = constants.DefaultAutoscalerClass
+	}
+	config.minReplicas = ptr.Deref(scaling.MinReplicas, constants.DefaultMinReplicas)
+	if config.minReplicas < 0 {
+		return nil, errors.New("the minimum replicas must not be negative")
+	}
+	config.maxReplicas = scaling.MaxReplicas
+
+	window := int32(hpaStabilizationWindowSeconds)
+	switch config.class {
+	case constants.AutoscalerClassKPA:
+		config.metric = ptr.Deref(scaling.ScaleMetric, v1beta1.MetricConcurrency)
+		if config.metric != v1beta1.MetricConcurrency && config.metric != v1beta1.MetricRPS {
+			return nil, fmt.Errorf("the metric %q cannot be used with the KPA", config.metric)
+		}
+		window = kpaStableWindowSeconds
+	case constants.AutoscalerClassHPA, constants.AutoscalerClassKeda:
+		config.metric = ptr.Deref(scaling.ScaleMetric, v1beta1.MetricCPU)
+		if config.class == constants.AutoscalerClassHPA && config.metric != v1beta1.MetricCPU {
+			return nil, fmt.Errorf("the metric %q cannot be used with the HPA", config.metric)
+		}
+		if config.metric != v1beta1.MetricCPU && config.metric != v1beta1.MetricConcurrency && config.metric != v1beta1.MetricRPS {
+			return nil, fmt.Errorf("the metric %q cannot be simulated from the request rate", config.metric)
+		}
+		// the HPA scales on the cpu of at least one replica
+		if config.class == constants.AutoscalerClassHPA || config.metric == v1beta1.MetricCPU {
+			config.minReplicas = max(config.minReplicas, 1)
+		}
+		if config.maxReplicas == 0 {
+			config.maxReplicas = max(config.minReplicas, 1)
+		}
+	default:
+		return nil, fmt.Errorf("the autoscaler class %q cannot be simulated", config.class)
+	}
+	if config.maxReplicas != 0 && config.maxReplicas < config.minReplicas {
+		return nil, errors.New("the maximum replicas must be greater than or equal to the minimum replicas")
+	}
+	if config.metric == v1beta1.MetricConcurrency && request.Capacity.LatencySeconds == 0 {
+		return nil, errors.New("the latency of the requests is required to simulate the concurrency")
+	}
+
+	switch {
+	case scaling.ScaleTarget != nil:
+		if *scaling.ScaleTarget <= 0 {
+			return nil, errors.New("the scale target must be positive")
+		}
+		config.target = float64(*scaling.ScaleTarget)
+	case config.metric == v1beta1.MetricCPU:
+		config.target = float64(constants.DefaultCPUUtilization)
+	case config.class == constants.AutoscalerClassKPA && config.metric == v1beta1.MetricConcurrency:
+		config.target = kpaConcurrencyTarget
+	case config.class == constants.AutoscalerClassKPA && config.metric == v1beta1.MetricRPS:
+		config.target = kpaRPSTarget
+	default:
+		return nil, fmt.Errorf("the scale target of the metric %q is required", config.metric)
+	}
+
+	config.stabilizationWindow = int64(ptr.Deref(scaling.StabilizationWindowSeconds, window))
+	if config.stabilizationWindow <= 0 {
+		return nil, errors.New("the stabilization window must be positive")
+	}
+	return config, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"math"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// The default parameters of the autoscalers, as configured by Kubernetes, KEDA and Knative out of the box
+const (
+	hpaSyncPeriodSeconds          = 15
+	hpaTolerance                  = 0.1
+	hpaStabilizationWindowSeconds = 300
+	kedaPollingIntervalSeconds    = 30
+
+	kpaTickSeconds                   = 2
+	kpaStableWindowSeconds           = 60
+	kpaPanicWindowPercentage         = 0.1
+	kpaPanicThreshold                = 2.0
+	kpaTargetUtilization             = 0.7
+	kpaMaxScaleUpRate                = 1000.0
+	kpaMaxScaleDownRate              = 2.0
+	kpaScaleToZeroGracePeriodSeconds = 30
+	kpaConcurrencyTarget             = 100
+	kpaRPSTarget                     = 200
+)
+
+// observation is the load observed on the component during a second
+type observation struct {
+	// requests is the request rate
+	requests float64
+	// concurrency is the number of requests in flight
+	concurrency float64
+	// utilization is the average cpu utilization of the ready replicas, in percent
+	utilization float64
+	ready       int32
+}
+
+// scaler models an autoscaler, it is called every second with the load observed on the component
+type scaler interface {
+	scale(now int64, observed observation, replicas int32) int32
+}
+
+func newScaler(config *scalingConfig) scaler {
+	if config.class == constants.AutoscalerClassKPA {
+		return &kpaScaler{config: config}
+	}
+	period := int64(hpaSyncPeriodSeconds)
+	if config.class == constants.AutoscalerClassKeda {
+		period = kedaPollingIntervalSeconds
+	}
+	return &hpaScaler{config: config, period: period, lastActive: -1}
+}
+
+// metricTotal is the value of the metric summed over the replicas
+func metricTotal(metric v1beta1.ScaleMetric, observed observation) float64 {
+	switch metric {
+	case v1beta1.MetricRPS:
+		return observed.requests
+	case v1beta1.MetricConcurrency:
+		return observed.concurrency
+	default:
+		return observed.utilization * float64(observed.ready)
+	}
+}
+
+// hpaScaler models the HorizontalPodAutoscaler, and KEDA which drives one and scales from and to zero itself
+type hpaScaler struct {
+	config *scalingConfig
+	period int64
+	// totals and ready are the metric totals and the ready replicas since the last sync
+	totals []float64
+	ready  []int32
+	// recommendations are the replicas recommended within the stabilization window
+	recommendations []recommendation
+	lastActive      int64
+}
+
+type recommendation struct {
+	time     int64
+	replicas int32
+}
+
+func (h *hpaScaler) scale(now int64, observed observation, replicas int32) int32 {
+	h.totals = append(h.totals, metricTotal(h.config.metric, observed))
+	h.ready = append(h.ready, observed.ready)
+	if observed.requests > 0 {
+		h.lastActive = now
+	}
+	if (now+1)%h.period != 0 {
+		return replicas
+	}
+	total, ready := average(h.totals), average(h.ready)
+	h.totals, h.ready = h.totals[:0], h.ready[:0]
+
+	// KEDA activates the deployment on traffic and scales it to zero once idle for the cooldown period
+	if h.config.minReplicas == 0 {
+		idle := h.lastActive < 0 || now-h.lastActive >= h.config.stabilizationWindow
+		switch {
+		case replicas == 0 && h.lastActive > now-h.period:
+			return 1
+		case replicas == 0 || idle:
+			return 0
+		}
+	}
+	if ready == 0 {
+		// the HPA skips the replicas without metrics
+		return replicas
+	}
+
+	desired := replicas
+	ratio := total / ready / h.config.target
+	if math.Abs(ratio-1) > hpaTolerance {
+		desired = int32(math.Ceil(ratio * ready))
+	}
+	desired = min(max(desired, h.config.minReplicas, 1), h.config.maxReplicas)
+
+	h.recommendations = append(h.recommendations, recommendation{time: now, replicas: desired})
+	for len(h.recommendations) > 0 && h.recommendations[0].time <= now-h.config.stabilizationWindow {
+		h.recommendations = h.recommendations[1:]
+	}
+	switch {
+	case desired < replicas:
+		// scale down to the highest recommendation of the stabilization window
+		for _, recommended := range h.recommendations {
+			desired = max(desired, recommended.replicas)
+		}
+		desired = min(desired, replicas)
+	case desired > replicas:
+		// the scale up policies add up to 100% of the replicas or 4 replicas every 15 seconds
+		desired = min(desired, max(2*replicas, replicas+4))
+	}
+	return desired
+}
+
+// kpaScaler models the Knative Pod Autoscaler, with its stable and panic windows and its scale to zero
+type kpaScaler struct {
+	config *scalingConfig
+	// totals are the metric totals of the stable window
+	totals     []float64
+	panicUntil int64
+	idleSince  int64
+	idle       bool
+}
+
+func (k *kpaScaler) scale(now int64, observed observation, replicas int32) int32 {
+	k.totals = append(k.totals, metricTotal(k.config.metric, observed))
+	if int64(len(k.totals)) > k.config.stabilizationWindow {
+		k.totals = k.totals[1:]
+	}
+	panicWindow := max(int64(float64(k.config.stabilizationWindow)*kpaPanicWindowPercentage), 1)
+	target := k.config.target * kpaTargetUtilization
+
+	// the activator buffers the requests and scales from zero right away
+	if replicas == 0 && observed.requests > 0 {
+		return k.bound(max(int32(math.Ceil(average(k.totals[max(len(k.totals)-int(panicWindow), 0):])/target)), 1))
+	}
+	if (now+1)%kpaTickSeconds != 0 {
+		return replicas
+	}
+
+	desiredStable := int32(math.Ceil(average(k.totals) / target))
+	desiredPanic := int32(math.Ceil(average(k.totals[max(len(k.totals)-int(panicWindow), 0):]) / target))
+	ready := max(observed.ready, 1)
+	if float64(desiredPanic)/float64(ready) >= kpaPanicThreshold {
+		k.panicUntil = now + k.config.stabilizationWindow
+	}
+	desired := desiredStable
+	if now < k.panicUntil {
+		// the KPA does not scale down in panic mode
+		desired = max(desiredPanic, replicas)
+	}
+	desired = min(desired, int32(math.Ceil(kpaMaxScaleUpRate*float64(ready))))
+	desired = max(desired, int32(math.Floor(float64(observed.ready)/kpaMaxScaleDownRate)))
+
+	// the last replica is removed once the scale to zero grace period has elapsed
+	if desired == 0 {
+		if !k.idle {
+			k.idle, k.idleSince = true, now
+		}
+		if now-k.idleSince < kpaScaleToZeroGracePeriodSeconds {
+			desired = min(replicas, 1)
+		}
+	} else {
+		k.idle = false
+	}
+	return k.bound(desired)
+}
+
+func (k *kpaScaler) bound(desired int32) int32 {
+	desired = max(desired, k.config.minReplicas)
+	if k.config.maxReplicas > 0 {
+		desired = min(desired, k.config.maxReplicas)
+	}
+	return desired
+}
+
+func average[T float64 | int32](values []T) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += float64(value)
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preview
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// constantTraffic is a request rate of rps for the given number of minutes
+func constantTraffic(rps float64, minutes int) []float64 {
+	return slices.Repeat([]float64{rps}, minutes)
+}
+
+func TestSimulateHPA(t *testing.T) {
+	// 10 requests per second on replicas serving 5 requests per second converge to 3 replicas at 80% cpu
+	report, err := Simulate(&SimulationRequest{
+		Traffic:  Traffic{IntervalSeconds: 60, RequestsPerSecond: constantTraffic(10, 10)},
+		Scaling:  ScalingConfig{MaxReplicas: 10},
+		Capacity: Capacity{RequestsPerSecond: 5, StartupSeconds: 20},
+	})
+	require.NoError(t, err)
+
+	last := report.Steps[len(report.Steps)-1]
+	assert.Equal(t, int32(3), last.Replicas)
+	assert.Equal(t, int32(3), last.ReadyReplicas)
+	assert.InDelta(t, 1, last.SLOAttainment, 1e-9)
+	assert.Equal(t, int32(3), report.PeakReplicas)
+	assert.Less(t, report.SLOAttainment, 1.0)
+	assert.Positive(t, report.UnderProvisionedSeconds)
+	assert.Zero(t, report.ColdStarts)
+
+	// the replicas are kept for the stabilization window once the traffic drops
+	report, err = Simulate(&SimulationRequest{
+		Traffic:  Traffic{IntervalSeconds: 60, RequestsPerSecond: append(constantTraffic(10, 10), constantTraffic(1, 10)...)},
+		Scaling:  ScalingConfig{MaxReplicas: 10, StabilizationWindowSeconds: ptr.To[int32](300)},
+		Capacity: Capacity{RequestsPerSecond: 5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), report.Steps[12].Replicas)
+	assert.Equal(t, int32(1), report.Steps[19].Replicas)
+}
+
+func TestSimulateKPA(t *testing.T) {
+	// 35 requests per second of 1 second each are 35 requests in flight, 7 per replica at 70% of the target of 10
+	report, err := Simulate(&SimulationRequest{
+		Traffic: Traffic{IntervalSeconds: 60, RequestsPerSecond: append(append(constantTraffic(0, 2), constantTraffic(35, 5)...), constantTraffic(0, 5)...)},
+		Scaling: ScalingConfig{
+			AutoscalerClass: constants.AutoscalerClassKPA,
+			MinReplicas:     ptr.To[int32](0),
+			ScaleTarget:     ptr.To[int32](10),
+		},
+		Capacity:  Capacity{RequestsPerSecond: 10, LatencySeconds: 1, StartupSeconds: 10},
+		SLOTarget: 0.99,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(0), report.Steps[1].Replicas)
+	assert.Equal(t, int32(5), report.Steps[6].Replicas)
+	assert.Equal(t, int32(0), report.Steps[11].Replicas)
+	assert.Equal(t, int32(1), report.ColdStarts)
+	// the requests of the cold start are not served within the capacity
+	assert.Less(t, report.SLOAttainment, 0.99)
+	assert.Equal(t, ptr.To(false), report.SLOMet)
+}
+
+func TestSimulateKEDA(t *testing.T) {
+	report, err := Simulate(&SimulationRequest{
+		Traffic: Traffic{IntervalSeconds: 60, RequestsPerSecond: append(constantTraffic(40, 5), constantTraffic(0, 10)...)},
+		Scaling: ScalingConfig{
+			AutoscalerClass: constants.AutoscalerClassKeda,
+			MinReplicas:     ptr.To[int32](0),
+			MaxReplicas:     8,
+			ScaleMetric:     ptr.To(v1beta1.MetricRPS),
+			ScaleTarget:     ptr.To[int32](10),
+		},
+		Capacity: Capacity{RequestsPerSecond: 20},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(4), report.Steps[4].Replicas)
+	// the deployment is scaled to zero after the cooldown period
+	assert.Equal(t, int32(4), report.Steps[7].Replicas)
+	assert.Equal(t, int32(0), report.Steps[14].Replicas)
+	assert.Equal(t, int32(1), report.ColdStarts)
+}
+
+func TestSimulateInvalidRequest(t *testing.T) {
+	valid := func() *SimulationRequest {
+		return &SimulationRequest{
+			Traffic:  Traffic{IntervalSeconds: 60, RequestsPerSecond: []float64{1}},
+			Capacity: Capacity{RequestsPerSecond: 5},
+		}
+	}
+	tests := map[string]struct {
+		mutate   func(*SimulationRequest)
+		expected string
+	}{
+		"no samples": {
+			mutate:   func(r *SimulationRequest) { r.Traffic.RequestsPerSecond = nil },
+			expected: "the traffic has no samples",
+		},
+		"no capacity": {
+			mutate:   func(r *SimulationRequest) { r.Capacity.RequestsPerSecond = 0 },
+			expected: "the request rate a replica serves must be positive",
+		},
+		"memory metric": {
+			mutate:   func(r *SimulationRequest) { r.Scaling.ScaleMetric = ptr.To(v1beta1.MetricMemory) },
+			expected: `the metric "memory" cannot be used with the HPA`,
+		},
+		"kpa cpu metric": {
+			mutate: func(r *SimulationRequest) {
+				r.Scaling.AutoscalerClass = constants.AutoscalerClassKPA
+				r.Scaling.ScaleMetric = ptr.To(v1beta1.MetricCPU)
+			},
+			expected: `the metric "cpu" cannot be used with the KPA`,
+		},
+		"concurrency without latency": {
+			mutate:   func(r *SimulationRequest) { r.Scaling.AutoscalerClass = constants.AutoscalerClassKPA },
+			expected: "the latency of the requests is required to simulate the concurrency",
+		},
+		"keda rps without target": {
+			mutate: func(r *SimulationRequest) {
+				r.Scaling.AutoscalerClass = constants.AutoscalerClassKeda
+				r.Scaling.ScaleMetric = ptr.To(v1beta1.MetricRPS)
+			},
+			expected: `the scale target of the metric "rps" is required`,
+		},
+		"external autoscaler": {
+			mutate:   func(r *SimulationRequest) { r.Scaling.AutoscalerClass = constants.AutoscalerClassExternal },
+			expected: `the autoscaler class "external" cannot be simulated`,
+		},
+		"max lower than min": {
+			mutate: func(r *SimulationRequest) {
+				r.Scaling.MinReplicas = ptr.To[int32](3)
+				r.Scaling.MaxReplicas = 2
+			},
+			expected: "the maximum replicas must be greater than or equal to the minimum replicas",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := valid()
+			tt.mutate(request)
+			_, err := Simulate(request)
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestSimulatorServeHTTP(t *testing.T) {
+	clientset := k8sfake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		review.Status.Authenticated = review.Spec.Token == "valid-token"
+		return true, review, nil
+	})
+	simulator := &Simulator{Clientset: clientset}
+	simulate := func(token string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, SimulationPath, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		simulator.ServeHTTP(rec, req)
+		return rec
+	}
+	body := `{"traffic": {"intervalSeconds": 60, "requestsPerSecond": [4, 4]}, "capacity": {"requestsPerSecond": 5}, "sloTarget": 0.9}`
+
+	assert.Equal(t, http.StatusUnauthorized, simulate("", body).Code)
+	assert.Equal(t, http.StatusUnauthorized, simulate("invalid-token", body).Code)
+	assert.Equal(t, http.StatusBadRequest, simulate("valid-token", `{"traffic": {}}`).Code)
+
+	rec := simulate("valid-token", body)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	report := &SimulationReport{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), report))
+	assert.Len(t, report.Steps, 2)
+	assert.InDelta(t, 1, report.SLOAttainment, 1e-9)
+	assert.Equal(t, ptr.To(true), report.SLOMet)
+}
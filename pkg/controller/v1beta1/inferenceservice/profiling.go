@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/profiling"
+)
+
+var profileCaptureClient = &http.Client{Timeout: 10 * time.Second}
+
+// reconcileProfileCapture requests a profile capture from the running pods of the InferenceService when it is annotated
+// with serving.kserve.io/capture-profile. The capture annotations are removed once the capture has been requested,
+// the pods upload the profiles to the requested storage uri when the capture completes.
+func (r *InferenceServiceReconciler) reconcileProfileCapture(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	profile, ok := isvc.Annotations[constants.CaptureProfileAnnotationKey]
+	if !ok {
+		return nil
+	}
+	query := url.Values{}
+	query.Set(profiling.ProfileParam, profile)
+	query.Set(profiling.StorageUriParam, isvc.Annotations[constants.CaptureProfileStorageUriAnnotationKey])
+	if seconds, ok := isvc.Annotations[constants.CaptureProfileSecondsAnnotationKey]; ok {
+		query.Set(profiling.SecondsParam, seconds)
+	}
+	if _, err := profiling.ParseCaptureRequest(query); err != nil {
+		r.Recorder.Eventf(isvc, corev1.EventTypeWarning, "ProfileCaptureFailed", "Invalid profile capture request: %v", err)
+		return r.removeProfileCaptureAnnotations(ctx, isvc)
+	}
+
+	secret, err := r.Clientset.CoreV1().Secrets(isvc.Namespace).Get(ctx, constants.ProfilingTokenSecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			r.Recorder.Eventf(isvc, corev1.EventTypeWarning, "ProfileCaptureFailed",
+				"Profiling token secret %q is not found in namespace %q", constants.ProfilingTokenSecretName, isvc.Namespace)
+			return r.removeProfileCaptureAnnotations(ctx, isvc)
+		}
+		return err
+	}
+	token := string(secret.Data[constants.ProfilingTokenSecretKey])
+
+	pods, err := r.Clientset.CoreV1().Pods(isvc.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: constants.InferenceServicePodLabelKey + "=" + isvc.Name,
+	})
+	if err != nil {
+		return err
+	}
+	running := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			running = append(running, pod)
+		}
+	}
+	// The annotations are removed before the capture is requested so that it is only requested once, and the pods are
+	// called in the background so that the reconciliation is not blocked by slow or unreachable pods
+	if err := r.removeProfileCaptureAnnotations(ctx, isvc); err != nil {
+		return err
+	}
+	go r.requestProfileCaptures(isvc.DeepCopy(), profile, token, query, running)
+	return nil
+}
+
+// requestProfileCaptures requests the profile capture from the pods concurrently and records the outcome as events
+func (r *InferenceServiceReconciler) requestProfileCaptures(isvc *v1beta1.InferenceService, profile string, token string,
+	query url.Values, pods []corev1.Pod,
+) {
+	var requested atomic.Int32
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := requestProfileCapture(context.Background(), pod.Status.PodIP, token, query); err != nil {
+				r.Log.Error(err, "Failed to request profile capture", "isvc", isvc.Name, "pod", pod.Name)
+				r.Recorder.Eventf(isvc, corev1.EventTypeWarning, "ProfileCaptureFailed",
+					"Failed to request %s profile capture from pod %q: %v", profile, pod.Name, err)
+				return
+			}
+			requested.Add(1)
+		}()
+	}
+	wg.Wait()
+	r.Recorder.Eventf(isvc, corev1.EventTypeNormal, "ProfileCaptureRequested",
+		"Requested %s profile capture from %d pods", profile, requested.Load())
+}
+
+// removeProfileCaptureAnnotations patches a copy of the InferenceService so that the status computed by the
+// current reconciliation is not overwritten by the patch response.
+func (r *InferenceServiceReconciler) removeProfileCaptureAnnotations(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	updated := isvc.DeepCopy()
+	delete(updated.Annotations, constants.CaptureProfileAnnotationKey)
+	delete(updated.Annotations, constants.CaptureProfileSecondsAnnotationKey)
+	delete(updated.Annotations, constants.CaptureProfileStorageUriAnnotationKey)
+	if err := r.Patch(ctx, updated, client.MergeFrom(isvc)); err != nil {
+		return err
+	}
+	isvc.Annotations = updated.Annotations
+	isvc.ResourceVersion = updated.ResourceVersion
+	return nil
+}
+
+func requestProfileCapture(ctx context.Context, podIP string, token string, query url.Values) error {
+	captureUrl := url.URL{
+		Scheme:   "http",
+		Host:     net.JoinHostPort(podIP, strconv.Itoa(profiling.DefaultPort)),
+		Path:     profiling.CapturePath,
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, captureUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := profileCaptureClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
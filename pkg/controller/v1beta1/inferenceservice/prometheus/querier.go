@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prometheus queries the Prometheus instance of the prometheus config for the reconcilers acting on the
+// metrics of the InferenceServices, e.g. the idle timeouts, the drift and the canary analysis
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// DefaultQueryTimeout bounds the evaluation of a query, so that an unresponsive Prometheus does not block the
+// reconciles
+const DefaultQueryTimeout = 30 * time.Second
+
+// Querier evaluates instant Prometheus queries
+type Querier struct {
+	api     promv1.API
+	timeout time.Duration
+}
+
+// NewQuerier returns a querier of the Prometheus instance of the config, or nil when the config has none
+func NewQuerier(config *v1beta1.PrometheusConfig) (*Querier, error) {
+	if config.URL == "" {
+		return nil, nil
+	}
+	promClient, err := api.NewClient(api.Config{Address: config.URL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Prometheus client of %s: %w", config.URL, err)
+	}
+	return &Querier{api: promv1.NewAPI(promClient), timeout: DefaultQueryTimeout}, nil
+}
+
+// Queriers caches the queriers by the address of their Prometheus instance, so that the reconciles share the clients
+// and their connections while the address still follows the configuration
+type Queriers struct {
+	mu       sync.Mutex
+	queriers map[string]*Querier
+}
+
+// NewQueriers returns an empty cache of queriers
+func NewQueriers() *Queriers {
+	return &Queriers{queriers: map[string]*Querier{}}
+}
+
+// Get returns the querier of the Prometheus instance of the config, or nil when the config has none
+func (q *Queriers) Get(config *v1beta1.PrometheusConfig) (*Querier, error) {
+	if config.URL == "" {
+		return nil, nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if querier, ok := q.queriers[config.URL]; ok {
+		return querier, nil
+	}
+	querier, err := NewQuerier(config)
+	if err != nil {
+		return nil, err
+	}
+	q.queriers[config.URL] = querier
+	return querier, nil
+}
+
+// Query returns the samples of the query evaluated now, a scalar being a single sample. The NaN samples are
+// dropped, e.g. a ratio of the requests of a revision which received none.
+// The evaluation is bounded by the timeout of the querier.
+func (q *Querier) Query(ctx context.Context, query string) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+	value, _, err := q.api.Query(ctx, query, time.Now(), promv1.WithTimeout(q.timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %q: %w", query, err)
+	}
+	var samples []float64
+	switch result := value.(type) {
+	case model.Vector:
+		for _, sample := range result {
+			samples = append(samples, float64(sample.Value))
+		}
+	case *model.Scalar:
+		samples = append(samples, float64(result.Value))
+	default:
+		return nil, fmt.Errorf("the query %q returned a %s, expected a vector or a scalar", query, value.Type())
+	}
+	return slices.DeleteFunc(samples, math.IsNaN), nil
+}
+
+// Validate checks the expression of the query against the Prometheus instance. Only the rejection of the expression is
+// an error: the query is evaluated by another client, e.g. KEDA, which may reach or authenticate to the instance where
+// the querier cannot.
+func (q *Querier) Validate(ctx context.Context, query string) error {
+	ctx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+	_, _, err := q.api.Query(ctx, query, time.Now(), promv1.WithTimeout(q.timeout))
+	var apiErr *promv1.Error
+	if errors.As(err, &apiErr) && apiErr.Type == promv1.ErrBadData {
+		return fmt.Errorf("the query %q is invalid: %s", query, apiErr.Msg)
+	}
+	return nil
+}
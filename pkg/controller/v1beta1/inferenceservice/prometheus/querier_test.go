@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestQuerier(t *testing.T) {
+	querier, err := NewQuerier(&v1beta1.PrometheusConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, querier, "the metrics are not queried without a Prometheus instance")
+
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	querier, err = NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	response = `{"status": "success", "data": {"resultType": "vector", "result": [` +
+		`{"metric": {"pod": "a"}, "value": [1700000000, "1"]}, {"metric": {"pod": "b"}, "value": [1700000000, "NaN"]}]}}`
+	samples, err := querier.Query(t.Context(), "up")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1}, samples, "the NaN samples are dropped")
+
+	response = `{"status": "success", "data": {"resultType": "scalar", "result": [1700000000, "2"]}}`
+	samples, err = querier.Query(t.Context(), "scalar(up)")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2}, samples)
+
+	response = `{"status": "success", "data": {"resultType": "matrix", "result": []}}`
+	_, err = querier.Query(t.Context(), "up[5m]")
+	require.ErrorContains(t, err, "expected a vector or a scalar")
+}
+
+func TestQuerierValidate(t *testing.T) {
+	var status int
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	querier, err := NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	status = http.StatusOK
+	response = `{"status": "success", "data": {"resultType": "vector", "result": []}}`
+	require.NoError(t, querier.Validate(t.Context(), "up"))
+
+	status = http.StatusBadRequest
+	response = `{"status": "error", "errorType": "bad_data", "error": "invalid parameter \"query\": parse error"}`
+	require.ErrorContains(t, querier.Validate(t.Context(), "sum(up"), "parse error")
+
+	status = http.StatusUnauthorized
+	response = "Unauthorized"
+	require.NoError(t, querier.Validate(t.Context(), "up"), "the query is not rejected when the querier is not authorized")
+
+	status = http.StatusUnprocessableEntity
+	response = `{"status": "error", "errorType": "execution", "error": "query timed out"}`
+	require.NoError(t, querier.Validate(t.Context(), "up"), "only the expression is validated")
+}
+
+func TestQueriers(t *testing.T) {
+	queriers := NewQueriers()
+	querier, err := queriers.Get(&v1beta1.PrometheusConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, querier)
+
+	first, err := queriers.Get(&v1beta1.PrometheusConfig{URL: "http://prometheus:9090"})
+	require.NoError(t, err)
+	second, err := queriers.Get(&v1beta1.PrometheusConfig{URL: "http://prometheus:9090"})
+	require.NoError(t, err)
+	assert.Same(t, first, second, "the querier of an address is shared")
+	other, err := queriers.Get(&v1beta1.PrometheusConfig{URL: "http://thanos:9090"})
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+}
+
+func TestQuerierTimeout(t *testing.T) {
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-blocked:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(blocked)
+	querier, err := NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	require.NoError(t, err)
+	querier.timeout = 100 * time.Millisecond
+
+	_, err = querier.Query(t.Context(), "up")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -585,6 +585,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							DNSPolicy:                     "ClusterFirst",
 							SecurityContext:               defaultSecurityContext,
 							AutomountServiceAccountToken:  ptr.To(false),
+							TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 						},
 					},
 					// This is now customized and different from defaults set via `setDefaultDeploymentSpec`.
@@ -1520,6 +1521,181 @@ var _ = Describe("v1beta1 inference service controller", func() {
 		})
 	})
 
+	Context("When creating inference service with a cached model", func() {
+		It("Should prefer the nodes which downloaded the model", func() {
+			ctx := context.Background()
+			// Create configmap
+			configMap := createInferenceServiceConfigMap(configs)
+			Expect(k8sClient.Create(ctx, configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, configMap)
+			// Create ServingRuntime
+			servingRuntime := getServingRuntime("tf-serving-raw", "default")
+			k8sClient.Create(ctx, &servingRuntime)
+			defer k8sClient.Delete(ctx, &servingRuntime)
+
+			localModel := &v1alpha1.LocalModelCache{
+				ObjectMeta: metav1.ObjectMeta{Name: "raw-cached-model"},
+				Spec: v1alpha1.LocalModelCacheSpec{
+					SourceModelUri: storageUri,
+					ModelSize:      resource.MustParse("1Gi"),
+					NodeGroups:     []string{"gpu"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, localModel)).Should(Succeed())
+			defer k8sClient.Delete(ctx, localModel)
+			localModel.Status.NodeStatus = map[string]v1alpha1.NodeStatus{
+				"node-1": v1alpha1.NodeDownloaded,
+				"node-2": v1alpha1.NodeDownloading,
+			}
+			Expect(k8sClient.Status().Update(ctx, localModel)).Should(Succeed())
+
+			serviceKey := types.NamespacedName{Name: "raw-cached", Namespace: "default"}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceKey.Name,
+					Namespace:   serviceKey.Namespace,
+					Annotations: getDefaultAnnotations(constants.AutoscalerClassHPA),
+					Labels: map[string]string{
+						constants.LocalModelLabel: localModel.Name,
+					},
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Tensorflow: &v1beta1.TFServingSpec{
+							PredictorExtensionSpec: getCommonPredictorExtensionSpec(),
+						},
+					},
+				},
+			}
+			isvc.DefaultInferenceService(nil, nil, &v1beta1.SecurityConfig{AutoMountServiceAccountToken: false}, nil)
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			cachedNodeAffinity := func(nodes ...string) *corev1.Affinity {
+				return &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+							Weight: 100,
+							Preference: corev1.NodeSelectorTerm{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{
+									Key:      corev1.LabelHostname,
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   nodes,
+								}},
+							},
+						}},
+					},
+				}
+			}
+			predictorDeploymentKey := types.NamespacedName{
+				Name:      constants.PredictorServiceName(serviceKey.Name),
+				Namespace: serviceKey.Namespace,
+			}
+			deployment := &appsv1.Deployment{}
+			Eventually(func() (*corev1.Affinity, error) {
+				err := k8sClient.Get(ctx, predictorDeploymentKey, deployment)
+				return deployment.Spec.Template.Spec.Affinity, err
+			}, timeout, interval).Should(Equal(cachedNodeAffinity("node-1")))
+
+			// The affinity is updated once the model is downloaded on another node
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: localModel.Name}, localModel)).Should(Succeed())
+			localModel.Status.NodeStatus["node-2"] = v1alpha1.NodeDownloaded
+			Expect(k8sClient.Status().Update(ctx, localModel)).Should(Succeed())
+			Eventually(func() (*corev1.Affinity, error) {
+				err := k8sClient.Get(ctx, predictorDeploymentKey, deployment)
+				return deployment.Spec.Template.Spec.Affinity, err
+			}, timeout, interval).Should(Equal(cachedNodeAffinity("node-1", "node-2")))
+		})
+	})
+
+	Context("When creating inference service with a runtime publishing an image per architecture", func() {
+		It("Should use the image of the architecture selected by the node selector", func() {
+			ctx := context.Background()
+			// Create configmap
+			configMap := createInferenceServiceConfigMap(configs)
+			Expect(k8sClient.Create(ctx, configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(ctx, configMap)
+			// Create ServingRuntime
+			servingRuntime := getServingRuntime("tf-serving-raw-arch", "default")
+			servingRuntime.Spec.SupportedModelFormats[0].AutoSelect = ptr.To(false)
+			servingRuntime.Spec.ArchitectureImages = map[string]string{
+				"amd64": "tensorflow/serving:1.14.0",
+				"arm64": "tensorflow/serving:1.14.0-arm64",
+			}
+			Expect(k8sClient.Create(ctx, &servingRuntime)).Should(Succeed())
+			defer k8sClient.Delete(ctx, &servingRuntime)
+
+			newInferenceService := func(name string, nodeSelector map[string]string) *v1beta1.InferenceService {
+				isvc := &v1beta1.InferenceService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   "default",
+						Annotations: getDefaultAnnotations(constants.AutoscalerClassHPA),
+					},
+					Spec: v1beta1.InferenceServiceSpec{
+						Predictor: v1beta1.PredictorSpec{
+							Model: &v1beta1.ModelSpec{
+								ModelFormat: v1beta1.ModelFormat{Name: "tensorflow"},
+								Runtime:     ptr.To(servingRuntime.Name),
+								PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+									StorageURI: ptr.To(storageUri),
+								},
+							},
+							PodSpec: v1beta1.PodSpec{NodeSelector: nodeSelector},
+						},
+					},
+				}
+				isvc.DefaultInferenceService(nil, nil, &v1beta1.SecurityConfig{AutoMountServiceAccountToken: false}, nil)
+				return isvc
+			}
+
+			armIsvc := newInferenceService("raw-arch-arm", map[string]string{corev1.LabelArchStable: "arm64"})
+			Expect(k8sClient.Create(ctx, armIsvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, armIsvc)
+			deployment := &appsv1.Deployment{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: constants.PredictorServiceName(armIsvc.Name), Namespace: "default"}, deployment)
+			}, timeout, interval).Should(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("tensorflow/serving:1.14.0-arm64"))
+			Expect(deployment.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue(corev1.LabelArchStable, "arm64"))
+			Expect(deployment.Spec.Template.Spec.Affinity).To(BeNil())
+
+			By("Scheduling the pods on the architectures of the runtime when no architecture is selected")
+			anyIsvc := newInferenceService("raw-arch-any", nil)
+			Expect(k8sClient.Create(ctx, anyIsvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, anyIsvc)
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: constants.PredictorServiceName(anyIsvc.Name), Namespace: "default"}, deployment)
+			}, timeout, interval).Should(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("tensorflow/serving:1.14.0"))
+			Expect(deployment.Spec.Template.Spec.Affinity).To(Equal(&corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key:      corev1.LabelArchStable,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{"amd64", "arm64"},
+							}},
+						}},
+					},
+				},
+			}))
+
+			By("Rejecting an architecture which is not supported by the runtime")
+			s390xIsvc := newInferenceService("raw-arch-s390x", map[string]string{corev1.LabelArchStable: "s390x"})
+			Expect(k8sClient.Create(ctx, s390xIsvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, s390xIsvc)
+			Eventually(func() *v1beta1.FailureInfo {
+				updated := &v1beta1.InferenceService{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: s390xIsvc.Name, Namespace: "default"}, updated); err != nil {
+					return nil
+				}
+				return updated.Status.ModelStatus.LastFailureInfo
+			}, timeout, interval).Should(HaveField("Reason", v1beta1.NoSupportingRuntime))
+		})
+	})
+
 	Context("When creating inference service with raw kube predictor and serving.kserve.io/stop", func() {
 		// --- Default values ---
 		configs := map[string]string{
@@ -4779,6 +4955,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							DNSPolicy:                     "ClusterFirst",
 							SecurityContext:               defaultSecurityContext,
 							AutomountServiceAccountToken:  ptr.To(false),
+							TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 						},
 					},
 					Strategy:                getDefaultRollingStrategy(),
@@ -5609,6 +5786,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							DNSPolicy:                     "ClusterFirst",
 							SecurityContext:               defaultSecurityContext,
 							AutomountServiceAccountToken:  ptr.To(false),
+							TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 						},
 					},
 					Strategy:                getDefaultRollingStrategy(),
@@ -6895,6 +7073,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							DNSPolicy:                     "ClusterFirst",
 							SecurityContext:               defaultSecurityContext,
 							AutomountServiceAccountToken:  ptr.To(false),
+							TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 						},
 					},
 					Strategy:                getDefaultRollingStrategy(),
@@ -7772,6 +7951,7 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							DNSPolicy:                     "ClusterFirst",
 							SecurityContext:               defaultSecurityContext,
 							AutomountServiceAccountToken:  ptr.To(false),
+							TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 						},
 					},
 					Strategy:                getDefaultRollingStrategy(),
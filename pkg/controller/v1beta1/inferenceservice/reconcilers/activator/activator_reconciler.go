@@ -0,0 +1,492 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("ActivatorReconciler")
+
+// HTTPScaledObjectGVK is the GroupVersionKind of the HTTPScaledObjects of the KEDA HTTP add-on
+var HTTPScaledObjectGVK = schema.FromAPIVersionAndKind(constants.KedaHTTPAPIVersion, constants.HTTPScaledObjectKind)
+
+const (
+	defaultConcurrencyTarget = 100
+	// activatorReplicas keeps the activator available while one of its pods is disrupted, the replicas of the builtin
+	// activator share the requests they received through the lease of the activator
+	activatorReplicas = 2
+)
+
+// ActivatorReconciler deploys the activator in front of a component scaled to zero in Standard deployment mode. The
+// service of the component is routed to the activator, which proxies the requests to the private service selecting
+// the pods of the component and buffers them while the component is scaled from zero. The builtin activator scales
+// the deployment of the component from zero itself and back to zero once all its replicas are idle, with a service
+// account only allowed to scale that deployment and to record the requests in the lease of the activator. The keda-http activator forwards the requests to the interceptor of the KEDA HTTP add-on,
+// which scales the deployment with an HTTPScaledObject.
+type ActivatorReconciler struct {
+	client         client.Client
+	scheme         *runtime.Scheme
+	activatorType  constants.ActivatorType
+	enabled        bool
+	ServiceAccount *corev1.ServiceAccount
+	Role           *rbacv1.Role
+	RoleBinding    *rbacv1.RoleBinding
+	Lease          *coordinationv1.Lease
+	Deployment     *appsv1.Deployment
+	PrivateService *corev1.Service
+	ScaledObject   *unstructured.Unstructured
+}
+
+// NewActivatorReconciler creates the reconciler of the activator of the component, the activator is enabled by the
+// serving.kserve.io/activator annotation for the components with minReplicas 0. The private service is a copy of the
+// service of the component.
+func NewActivatorReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec,
+	service *corev1.Service,
+	config *v1beta1.ActivatorConfig,
+) *ActivatorReconciler {
+	activatorType := constants.ActivatorType(componentMeta.Annotations[constants.ActivatorAnnotationKey])
+	enabled := activatorType != "" && service != nil && componentExt != nil &&
+		componentExt.MinReplicas != nil && *componentExt.MinReplicas == 0
+	r := &ActivatorReconciler{
+		client:        client,
+		scheme:        scheme,
+		activatorType: activatorType,
+		enabled:       enabled,
+	}
+	if service != nil {
+		r.PrivateService = createPrivateService(componentMeta, service)
+	}
+	r.ServiceAccount, r.Role, r.RoleBinding = createRBAC(componentMeta)
+	r.Lease = &coordinationv1.Lease{ObjectMeta: activatorMeta(componentMeta)}
+	r.Deployment = createDeployment(componentMeta, activatorType, config)
+	r.ScaledObject = createHTTPScaledObject(componentMeta, componentExt)
+	return r
+}
+
+// Enabled returns whether the activator is deployed in front of the component
+func (r *ActivatorReconciler) Enabled() bool {
+	return r.enabled
+}
+
+// Route routes the service of the component to the activator, only the first port of the component is proxied by the
+// activator, the other ports are served by the private service
+func (r *ActivatorReconciler) Route(service *corev1.Service) {
+	if !r.enabled {
+		return
+	}
+	service.Spec.Selector = map[string]string{
+		"app": constants.GetRawServiceLabel(r.Deployment.Name),
+	}
+	if len(service.Spec.Ports) > 0 {
+		port := service.Spec.Ports[0]
+		port.TargetPort = intstr.FromInt32(constants.ActivatorPort)
+		port.AppProtocol = nil
+		service.Spec.Ports = []corev1.ServicePort{port}
+	}
+}
+
+func privateServiceHost(componentMeta metav1.ObjectMeta) string {
+	return network.GetServiceHostname(constants.ActivatorPrivateServiceName(componentMeta.Name), componentMeta.Namespace)
+}
+
+func createPrivateService(componentMeta metav1.ObjectMeta, service *corev1.Service) *corev1.Service {
+	private := service.DeepCopy()
+	private.Name = constants.ActivatorPrivateServiceName(componentMeta.Name)
+	private.OwnerReferences = nil
+	return private
+}
+
+func activatorMeta(componentMeta metav1.ObjectMeta) metav1.ObjectMeta {
+	name := constants.ActivatorName(componentMeta.Name)
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: componentMeta.Namespace,
+		Labels: map[string]string{
+			"app":                                 constants.GetRawServiceLabel(name),
+			constants.InferenceServicePodLabelKey: componentMeta.Labels[constants.InferenceServicePodLabelKey],
+		},
+		Annotations: utils.Filter(componentMeta.Annotations, func(key string) bool {
+			return key == constants.StopAnnotationKey
+		}),
+	}
+}
+
+// createRBAC creates the service account of the builtin activator, which is only allowed to scale the deployment of
+// the component and to update its lease
+func createRBAC(componentMeta metav1.ObjectMeta) (*corev1.ServiceAccount, *rbacv1.Role, *rbacv1.RoleBinding) {
+	objectMeta := activatorMeta(componentMeta)
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: objectMeta}
+	role := &rbacv1.Role{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{appsv1.GroupName},
+				Resources:     []string{"deployments"},
+				ResourceNames: []string{componentMeta.Name},
+				Verbs:         []string{"get"},
+			},
+			{
+				APIGroups:     []string{appsv1.GroupName},
+				Resources:     []string{"deployments/scale"},
+				ResourceNames: []string{componentMeta.Name},
+				Verbs:         []string{"get", "update"},
+			},
+			{
+				APIGroups:     []string{coordinationv1.GroupName},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{objectMeta.Name},
+				Verbs:         []string{"get", "update"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      objectMeta.Name,
+			Namespace: objectMeta.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     objectMeta.Name,
+		},
+	}
+	return serviceAccount, role, roleBinding
+}
+
+func createDeployment(componentMeta metav1.ObjectMeta, activatorType constants.ActivatorType, config *v1beta1.ActivatorConfig) *appsv1.Deployment {
+	objectMeta := activatorMeta(componentMeta)
+	args := []string{
+		"--port", strconv.Itoa(int(constants.ActivatorPort)),
+		"--request-timeout", config.RequestTimeoutDuration.String(),
+	}
+	serviceAccountName := ""
+	switch activatorType {
+	case constants.ActivatorKedaHTTP:
+		// the interceptor routes the requests to the HTTPScaledObject matching their host
+		args = append(args,
+			"--target", "http://"+config.KedaHTTPInterceptorService,
+			"--host", privateServiceHost(componentMeta),
+		)
+	default:
+		serviceAccountName = objectMeta.Name
+		args = append(args,
+			"--target", fmt.Sprintf("http://%s:%d", privateServiceHost(componentMeta), constants.CommonDefaultHttpPort),
+			"--deployment", componentMeta.Name,
+			"--namespace", componentMeta.Namespace,
+			"--lease", objectMeta.Name,
+			"--scale-to-zero-grace-period", config.ScaleToZeroGracePeriodDuration.String(),
+		)
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: objectMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(activatorReplicas)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": objectMeta.Labels["app"]},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: objectMeta.Labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:           serviceAccountName,
+					AutomountServiceAccountToken: ptr.To(serviceAccountName != ""),
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       corev1.LabelHostname,
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": objectMeta.Labels["app"]},
+						},
+					}},
+					Containers: []corev1.Container{{
+						Name:  constants.ActivatorContainerName,
+						Image: config.Image,
+						Args:  args,
+						Ports: []corev1.ContainerPort{{
+							Name:          "http",
+							ContainerPort: constants.ActivatorPort,
+							Protocol:      corev1.ProtocolTCP,
+						}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(constants.ActivatorPort)},
+							},
+						},
+						Resources: createResourceRequirements(config),
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							RunAsNonRoot:             ptr.To(true),
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func createResourceRequirements(config *v1beta1.ActivatorConfig) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+	for _, quantity := range []struct {
+		list  *corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+	}{
+		{&resources.Requests, corev1.ResourceCPU, config.CPURequest},
+		{&resources.Requests, corev1.ResourceMemory, config.MemoryRequest},
+		{&resources.Limits, corev1.ResourceCPU, config.CPULimit},
+		{&resources.Limits, corev1.ResourceMemory, config.MemoryLimit},
+	} {
+		if quantity.value == "" {
+			continue
+		}
+		if *quantity.list == nil {
+			*quantity.list = corev1.ResourceList{}
+		}
+		(*quantity.list)[quantity.name] = resource.MustParse(quantity.value)
+	}
+	return resources
+}
+
+// createHTTPScaledObject creates the HTTPScaledObject scaling the deployment of the component with the requests the
+// interceptor receives for the host of the private service
+func createHTTPScaledObject(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) *unstructured.Unstructured {
+	var maxReplicas int32 = 1
+	target := int64(defaultConcurrencyTarget)
+	scalingMetric := map[string]interface{}{}
+	if componentExt != nil {
+		maxReplicas = max(componentExt.MaxReplicas, maxReplicas)
+		if componentExt.ScaleTarget != nil {
+			target = int64(*componentExt.ScaleTarget)
+		}
+		if componentExt.ScaleMetric != nil && *componentExt.ScaleMetric == v1beta1.MetricRPS {
+			scalingMetric["requestRate"] = map[string]interface{}{
+				"targetValue": target,
+				"granularity": "1s",
+				"window":      "1m",
+			}
+		}
+	}
+	if len(scalingMetric) == 0 {
+		scalingMetric["concurrency"] = map[string]interface{}{"targetValue": target}
+	}
+
+	scaledObject := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hosts": []interface{}{privateServiceHost(componentMeta)},
+			"scaleTargetRef": map[string]interface{}{
+				"name":       componentMeta.Name,
+				"kind":       "Deployment",
+				"apiVersion": appsv1.SchemeGroupVersion.String(),
+				"service":    constants.ActivatorPrivateServiceName(componentMeta.Name),
+				"port":       int64(constants.CommonDefaultHttpPort),
+			},
+			"replicas": map[string]interface{}{
+				"min": int64(0),
+				"max": int64(maxReplicas),
+			},
+			"scalingMetric": scalingMetric,
+		},
+	}}
+	scaledObject.SetGroupVersionKind(HTTPScaledObjectGVK)
+	objectMeta := activatorMeta(componentMeta)
+	scaledObject.SetName(componentMeta.Name)
+	scaledObject.SetNamespace(componentMeta.Namespace)
+	scaledObject.SetLabels(objectMeta.Labels)
+	scaledObject.SetAnnotations(objectMeta.Annotations)
+	return scaledObject
+}
+
+// objects returns the objects of the activator and whether they are desired
+func (r *ActivatorReconciler) objects() []struct {
+	object  client.Object
+	desired bool
+} {
+	builtin := r.enabled && r.activatorType == constants.ActivatorBuiltin
+	kedaHTTP := r.enabled && r.activatorType == constants.ActivatorKedaHTTP
+	objects := []struct {
+		object  client.Object
+		desired bool
+	}{
+		{r.ServiceAccount, builtin},
+		{r.Role, builtin},
+		{r.RoleBinding, builtin},
+		{r.Lease, builtin},
+		{r.Deployment, r.enabled},
+		{r.ScaledObject, kedaHTTP},
+	}
+	if r.PrivateService != nil {
+		objects = append(objects, struct {
+			object  client.Object
+			desired bool
+		}{r.PrivateService, r.enabled})
+	}
+	return objects
+}
+
+// SetControllerReferences sets the owner of the objects of the activator
+func (r *ActivatorReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	for _, o := range r.objects() {
+		if err := controllerutil.SetControllerReference(owner, o.object, scheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconcile creates or updates the objects of the activator when it is enabled, and deletes them otherwise or when
+// the runtime of the InferenceService is stopped
+func (r *ActivatorReconciler) Reconcile(ctx context.Context) error {
+	for _, o := range r.objects() {
+		var err error
+		if o.desired && !utils.GetForceStopRuntime(o.object) {
+			err = r.apply(ctx, o.object)
+		} else {
+			err = r.delete(ctx, o.object)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ActivatorReconciler) apply(ctx context.Context, desired client.Object) error {
+	existing, ok := desired.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", desired)
+	}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if meta.IsNoMatchError(err) {
+		return errors.New("the keda-http activator requires the KEDA HTTP add-on to be installed")
+	}
+	if apierr.IsNotFound(err) {
+		log.Info("Creating activator object", "kind", fmt.Sprintf("%T", desired), "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !update(desired, existing) {
+		return nil
+	}
+	log.Info("Updating activator object", "kind", fmt.Sprintf("%T", desired), "namespace", existing.GetNamespace(), "name", existing.GetName())
+	return r.client.Update(ctx, existing)
+}
+
+// update copies the desired state into the existing object, and returns whether it changed
+func update(desired, existing client.Object) bool {
+	switch desired := desired.(type) {
+	case *corev1.ServiceAccount, *coordinationv1.Lease:
+		// the lease is updated by the activator
+		return false
+	case *rbacv1.Role:
+		existing := existing.(*rbacv1.Role)
+		if equality.Semantic.DeepEqual(desired.Rules, existing.Rules) {
+			return false
+		}
+		existing.Rules = desired.Rules
+	case *rbacv1.RoleBinding:
+		// the role reference is immutable and always the role of the activator
+		existing := existing.(*rbacv1.RoleBinding)
+		if equality.Semantic.DeepEqual(desired.Subjects, existing.Subjects) {
+			return false
+		}
+		existing.Subjects = desired.Subjects
+	case *appsv1.Deployment:
+		existing := existing.(*appsv1.Deployment)
+		if equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) {
+			return false
+		}
+		existing.Spec = desired.Spec
+	case *corev1.Service:
+		existing := existing.(*corev1.Service)
+		if equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) &&
+			equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+			return false
+		}
+		existing.Spec.Ports = desired.Spec.Ports
+		existing.Spec.Selector = desired.Spec.Selector
+	case *unstructured.Unstructured:
+		existing := existing.(*unstructured.Unstructured)
+		if equality.Semantic.DeepEqual(desired.Object["spec"], existing.Object["spec"]) {
+			return false
+		}
+		existing.Object["spec"] = desired.Object["spec"]
+	}
+	return true
+}
+
+// delete deletes the object of the activator when it exists and is owned by the same InferenceService
+func (r *ActivatorReconciler) delete(ctx context.Context, desired client.Object) error {
+	existing, ok := desired.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", desired)
+	}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing)
+	if meta.IsNoMatchError(err) || apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	owner, existingOwner := metav1.GetControllerOf(desired), metav1.GetControllerOf(existing)
+	if owner == nil || existingOwner == nil || owner.UID != existingOwner.UID {
+		return nil
+	}
+	log.Info("Deleting activator object", "kind", fmt.Sprintf("%T", desired), "namespace", existing.GetNamespace(), "name", existing.GetName())
+	if err := r.client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
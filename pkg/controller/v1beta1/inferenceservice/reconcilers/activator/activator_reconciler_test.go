@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+	return scheme
+}
+
+func newService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": constants.GetRawServiceLabel("sklearn-predictor")},
+			Ports: []corev1.ServicePort{
+				{Name: "http1", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+				{Name: "grpc", Port: 8081, TargetPort: intstr.FromInt32(8081), Protocol: corev1.ProtocolTCP, AppProtocol: ptr.To("kubernetes.io/h2c")},
+			},
+		},
+	}
+}
+
+func newActivatorReconciler(t *testing.T, cl client.Client, scheme *runtime.Scheme, activatorType string, minReplicas int32) (*ActivatorReconciler, *corev1.Service) {
+	t.Helper()
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "uid"}}
+	componentMeta := metav1.ObjectMeta{
+		Name:        "sklearn-predictor",
+		Namespace:   "default",
+		Labels:      map[string]string{constants.InferenceServicePodLabelKey: "sklearn"},
+		Annotations: map[string]string{},
+	}
+	if activatorType != "" {
+		componentMeta.Annotations[constants.ActivatorAnnotationKey] = activatorType
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{
+		MinReplicas: ptr.To(minReplicas),
+		MaxReplicas: 4,
+		ScaleMetric: ptr.To(v1beta1.MetricRPS),
+		ScaleTarget: ptr.To(int32(20)),
+	}
+	config, err := v1beta1.NewActivatorConfig(&corev1.ConfigMap{Data: map[string]string{
+		v1beta1.ActivatorConfigName: `{"cpuRequest": "100m", "scaleToZeroGracePeriod": "10m"}`,
+	}})
+	require.NoError(t, err)
+	svc := newService()
+	r := NewActivatorReconciler(cl, scheme, componentMeta, componentExt, svc, config)
+	r.Route(svc)
+	require.NoError(t, r.SetControllerReferences(isvc, scheme))
+	return r, svc
+}
+
+func get(t *testing.T, cl client.Client, name string, obj client.Object) error {
+	t.Helper()
+	return cl.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: name}, obj)
+}
+
+func TestActivatorReconcilerBuiltin(t *testing.T) {
+	scheme := newScheme(t)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r, svc := newActivatorReconciler(t, cl, scheme, "builtin", 0)
+	require.True(t, r.Enabled())
+	require.NoError(t, r.Reconcile(t.Context()))
+
+	// the service of the component selects the activator, the private service the component
+	assert.Equal(t, map[string]string{"app": "isvc.sklearn-predictor-activator"}, svc.Spec.Selector)
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "http1", Port: 80, TargetPort: intstr.FromInt32(constants.ActivatorPort), Protocol: corev1.ProtocolTCP},
+	}, svc.Spec.Ports)
+	private := &corev1.Service{}
+	require.NoError(t, get(t, cl, "sklearn-predictor-private", private))
+	assert.Equal(t, newService().Spec.Selector, private.Spec.Selector)
+	assert.Equal(t, newService().Spec.Ports, private.Spec.Ports)
+	assert.Equal(t, "sklearn", private.OwnerReferences[0].Name)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, "sklearn-predictor-activator", podSpec.ServiceAccountName)
+	container := podSpec.Containers[0]
+	assert.Equal(t, constants.DefaultActivatorImage, container.Image)
+	assert.Equal(t, []string{
+		"--port", "8012",
+		"--request-timeout", "5m0s",
+		"--target", "http://sklearn-predictor-private.default.svc.cluster.local:80",
+		"--deployment", "sklearn-predictor",
+		"--namespace", "default",
+		"--lease", "sklearn-predictor-activator",
+		"--scale-to-zero-grace-period", "10m0s",
+	}, container.Args)
+	assert.Equal(t, "100m", container.Resources.Requests.Cpu().String())
+
+	role := &rbacv1.Role{}
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", role))
+	assert.Equal(t, []string{"sklearn-predictor"}, role.Rules[1].ResourceNames)
+	assert.Equal(t, []string{"deployments/scale"}, role.Rules[1].Resources)
+	assert.Equal(t, []string{"sklearn-predictor-activator"}, role.Rules[2].ResourceNames)
+	assert.Equal(t, []string{"leases"}, role.Rules[2].Resources)
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", &coordinationv1.Lease{}))
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", &rbacv1.RoleBinding{}))
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", &corev1.ServiceAccount{}))
+	assert.True(t, apierr.IsNotFound(get(t, cl, "sklearn-predictor", newHTTPScaledObject())))
+
+	// the objects of the activator are deleted once the component is no longer scaled to zero
+	r, svc = newActivatorReconciler(t, cl, scheme, "builtin", 1)
+	require.False(t, r.Enabled())
+	require.NoError(t, r.Reconcile(t.Context()))
+	assert.Equal(t, newService().Spec, svc.Spec)
+	for _, obj := range []client.Object{&corev1.Service{}, &appsv1.Deployment{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}, &corev1.ServiceAccount{}, &coordinationv1.Lease{}} {
+		name := "sklearn-predictor-activator"
+		if _, ok := obj.(*corev1.Service); ok {
+			name = "sklearn-predictor-private"
+		}
+		assert.True(t, apierr.IsNotFound(get(t, cl, name, obj)), "%T", obj)
+	}
+}
+
+func TestActivatorReconcilerKedaHTTP(t *testing.T) {
+	scheme := newScheme(t)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r, _ := newActivatorReconciler(t, cl, scheme, "keda-http", 0)
+	require.NoError(t, r.Reconcile(t.Context()))
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, get(t, cl, "sklearn-predictor-activator", deployment))
+	assert.Empty(t, deployment.Spec.Template.Spec.ServiceAccountName)
+	assert.Equal(t, []string{
+		"--port", "8012",
+		"--request-timeout", "5m0s",
+		"--target", "http://" + constants.DefaultKedaHTTPInterceptorService,
+		"--host", "sklearn-predictor-private.default.svc.cluster.local",
+	}, deployment.Spec.Template.Spec.Containers[0].Args)
+	assert.True(t, apierr.IsNotFound(get(t, cl, "sklearn-predictor-activator", &rbacv1.Role{})))
+	assert.True(t, apierr.IsNotFound(get(t, cl, "sklearn-predictor-activator", &coordinationv1.Lease{})))
+
+	scaledObject := newHTTPScaledObject()
+	require.NoError(t, get(t, cl, "sklearn-predictor", scaledObject))
+	hosts, _, _ := unstructured.NestedStringSlice(scaledObject.Object, "spec", "hosts")
+	assert.Equal(t, []string{"sklearn-predictor-private.default.svc.cluster.local"}, hosts)
+	scaleTargetRef, _, _ := unstructured.NestedMap(scaledObject.Object, "spec", "scaleTargetRef")
+	assert.Equal(t, map[string]interface{}{
+		"name":       "sklearn-predictor",
+		"kind":       "Deployment",
+		"apiVersion": "apps/v1",
+		"service":    "sklearn-predictor-private",
+		"port":       int64(80),
+	}, scaleTargetRef)
+	maxReplicas, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "replicas", "max")
+	assert.Equal(t, int64(4), maxReplicas)
+	target, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "scalingMetric", "requestRate", "targetValue")
+	assert.Equal(t, int64(20), target)
+}
+
+func TestActivatorReconcilerStopped(t *testing.T) {
+	scheme := newScheme(t)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r, _ := newActivatorReconciler(t, cl, scheme, "builtin", 0)
+	require.NoError(t, r.Reconcile(t.Context()))
+
+	r, _ = newActivatorReconciler(t, cl, scheme, "builtin", 0)
+	for _, o := range r.objects() {
+		o.object.SetAnnotations(map[string]string{constants.StopAnnotationKey: "true"})
+	}
+	require.NoError(t, r.Reconcile(t.Context()))
+	assert.True(t, apierr.IsNotFound(get(t, cl, "sklearn-predictor-activator", &appsv1.Deployment{})))
+	assert.True(t, apierr.IsNotFound(get(t, cl, "sklearn-predictor-private", &corev1.Service{})))
+}
+
+func newHTTPScaledObject() *unstructured.Unstructured {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(HTTPScaledObjectGVK)
+	return scaledObject
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluegreen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("BlueGreenReconciler")
+
+const defaultSmokeTestTimeout = 10 * time.Second
+
+// BlueGreenReconciler rolls out the pods of a component with the blue-green strategy in Standard deployment mode. The
+// pods carry the hash of their template, which the Service selects. When the template changes, the Deployment of the
+// component keeps its pods while a preview Deployment runs the new ones. Once the preview Deployment is ready and its
+// pods pass the smoke test, the Service is switched to them at once. The previous pods keep running for the rollback
+// window, reverting the component switches the Service back to them. At the end of the window the Deployment of the
+// component is rolled out with the new template, then the preview Deployment is deleted.
+type BlueGreenReconciler struct {
+	client       client.Client
+	httpClient   *http.Client
+	now          func() time.Time
+	enabled      bool
+	componentExt *v1beta1.ComponentExtensionSpec
+	templateHash string
+	Deployment   *appsv1.Deployment
+	Service      *corev1.Service
+	Status       *v1beta1.BlueGreenStatus
+}
+
+// NewBlueGreenReconciler creates the reconciler of the blue-green rollout of the component, which labels the pod
+// template of the default deployment with its hash. The blue-green strategy is enabled by the deploymentStrategy of
+// type BlueGreen.
+func NewBlueGreenReconciler(client client.Client,
+	componentExt *v1beta1.ComponentExtensionSpec,
+	deployment *appsv1.Deployment,
+	service *corev1.Service,
+) (*BlueGreenReconciler, error) {
+	r := &BlueGreenReconciler{
+		client:       client,
+		httpClient:   &http.Client{},
+		now:          time.Now,
+		enabled:      componentExt.IsBlueGreen() && deployment != nil && service != nil,
+		componentExt: componentExt,
+		Deployment:   deployment,
+		Service:      service,
+	}
+	if !r.enabled {
+		return r, nil
+	}
+	templateHash, err := hashTemplate(&deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	r.templateHash = templateHash
+	// The labels of the pod template are shared with the deployment
+	deployment.Spec.Template.Labels = utils.Union(deployment.Spec.Template.Labels, map[string]string{
+		constants.BlueGreenTemplateHashLabelKey: templateHash,
+	})
+	return r, nil
+}
+
+// hashTemplate returns the hash of a pod template, which tells apart the pods of the successive templates
+func hashTemplate(template *corev1.PodTemplateSpec) (string, error) {
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(templateJSON)
+	return hex.EncodeToString(hash[:])[:10], nil
+}
+
+// Reconcile reconciles the preview deployment and sets the template of the default deployment and the selector of
+// the service, before they are reconciled
+func (r *BlueGreenReconciler) Reconcile(ctx context.Context) error {
+	existingPreview, err := r.getPreview(ctx)
+	if err != nil {
+		return err
+	}
+	if !r.enabled {
+		r.Status = nil
+		return r.deletePreview(ctx, existingPreview)
+	}
+
+	existing := &appsv1.Deployment{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: r.Deployment.Namespace, Name: r.Deployment.Name}, existing)
+	if apierr.IsNotFound(err) {
+		// The first pods of the component are deployed right away
+		r.route(r.templateHash)
+		r.Status = &v1beta1.BlueGreenStatus{Phase: v1beta1.BlueGreenActive, ActiveTemplateHash: r.templateHash}
+		return r.deletePreview(ctx, existingPreview)
+	} else if err != nil {
+		return err
+	}
+
+	activeHash := existing.Spec.Template.Labels[constants.BlueGreenTemplateHashLabelKey]
+	if activeHash == r.templateHash {
+		r.route(activeHash)
+		r.Status = &v1beta1.BlueGreenStatus{Phase: v1beta1.BlueGreenActive, ActiveTemplateHash: activeHash}
+		// The preview pods of the same template keep serving until the default deployment is rolled out
+		if existingPreview != nil && previewHash(existingPreview) == activeHash && !rolledOut(existing) {
+			r.Status.Phase = v1beta1.BlueGreenPromoting
+			r.Status.PreviewTemplateHash = activeHash
+			return nil
+		}
+		return r.deletePreview(ctx, existingPreview)
+	}
+
+	preview := r.createPreview(existing)
+	if existingPreview != nil && previewHash(existingPreview) == r.templateHash {
+		if switchTime, ok := existingPreview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey]; ok {
+			preview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey] = switchTime
+		}
+	}
+	// The default deployment keeps its pods until the end of the rollback window
+	template := r.Deployment.Spec.Template
+	r.Deployment.Spec.Template = *existing.Spec.Template.DeepCopy()
+
+	status := &v1beta1.BlueGreenStatus{
+		Phase:               v1beta1.BlueGreenPreviewing,
+		ActiveTemplateHash:  activeHash,
+		PreviewTemplateHash: r.templateHash,
+	}
+	now := r.now()
+	switchTime, err := getSwitchTime(preview)
+	if err != nil {
+		return err
+	}
+	if switchTime == nil && existingPreview != nil && previewHash(existingPreview) == r.templateHash && rolledOut(existingPreview) {
+		if err := r.smokeTest(ctx, existingPreview); err != nil {
+			log.Info("Smoke test of the preview pods failed", "namespace", preview.Namespace, "name", preview.Name, "error", err.Error())
+			status.Message = fmt.Sprintf("smoke test failed: %v", err)
+		} else {
+			switchTime = &metav1.Time{Time: now.UTC().Truncate(time.Second)}
+			preview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey] = switchTime.Format(time.RFC3339)
+			log.Info("Switching the service to the preview pods", "namespace", preview.Namespace, "name", preview.Name)
+		}
+	}
+	if switchTime != nil {
+		windowEnd := metav1.NewTime(switchTime.Add(r.componentExt.GetBlueGreenRollbackWindow()))
+		status.ActiveTemplateHash = r.templateHash
+		status.SwitchTime = switchTime
+		status.RollbackWindowEndTime = &windowEnd
+		status.Phase = v1beta1.BlueGreenSwitched
+		if !now.Before(windowEnd.Time) {
+			// The preview pods keep serving while the default deployment is rolled out with the new template
+			r.Deployment.Spec.Template = template
+			status.Phase = v1beta1.BlueGreenPromoting
+		}
+	}
+	r.route(status.ActiveTemplateHash)
+	r.Status = status
+	return r.applyPreview(ctx, preview, existingPreview)
+}
+
+// route selects the pods of the given template hash with the service, the pods of a deployment created before the
+// blue-green strategy was enabled carry no hash and are selected by the default selector
+func (r *BlueGreenReconciler) route(hash string) {
+	if hash == "" {
+		return
+	}
+	r.Service.Spec.Selector = map[string]string{
+		constants.InferenceServicePodLabelKey:   r.Deployment.Spec.Template.Labels[constants.InferenceServicePodLabelKey],
+		constants.BlueGreenTemplateHashLabelKey: hash,
+	}
+}
+
+// createPreview creates the preview deployment running the pods of the desired template, with as many replicas as
+// the default deployment. Its pods carry their own app label so that they are not selected by the default deployment.
+func (r *BlueGreenReconciler) createPreview(existing *appsv1.Deployment) *appsv1.Deployment {
+	preview := r.Deployment.DeepCopy()
+	preview.Name = constants.BlueGreenPreviewDeploymentName(r.Deployment.Name)
+	preview.Annotations = utils.Union(preview.Annotations)
+	app := constants.GetRawServiceLabel(preview.Name)
+	preview.Labels = utils.Union(preview.Labels, map[string]string{constants.RawDeploymentAppLabel: app})
+	preview.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{constants.RawDeploymentAppLabel: app},
+	}
+	preview.Spec.Template.Labels = utils.Union(preview.Spec.Template.Labels, preview.Spec.Selector.MatchLabels)
+	preview.Spec.Replicas = ptr.To(ptr.Deref(existing.Spec.Replicas, 1))
+	return preview
+}
+
+func previewHash(preview *appsv1.Deployment) string {
+	return preview.Spec.Template.Labels[constants.BlueGreenTemplateHashLabelKey]
+}
+
+func getSwitchTime(preview *appsv1.Deployment) (*metav1.Time, error) {
+	value, ok := preview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	switchTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s of the deployment %s: %w", constants.BlueGreenSwitchTimeAnnotationKey, preview.Name, err)
+	}
+	return &metav1.Time{Time: switchTime}, nil
+}
+
+// rolledOut returns whether all the replicas of a deployment run its latest template and are ready
+func rolledOut(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas >= replicas
+}
+
+// smokeTest sends the smoke test request to a ready pod of the preview deployment on the target port of the service,
+// any response with a 2xx status code passes the smoke test
+func (r *BlueGreenReconciler) smokeTest(ctx context.Context, preview *appsv1.Deployment) error {
+	if r.componentExt.BlueGreen == nil || r.componentExt.BlueGreen.SmokeTest == nil {
+		return nil
+	}
+	smokeTest := r.componentExt.BlueGreen.SmokeTest
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(preview.Namespace), client.MatchingLabels(preview.Spec.Selector.MatchLabels)); err != nil {
+		return err
+	}
+	podIP := ""
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" && pod.DeletionTimestamp == nil && isPodReady(&pod) {
+			podIP = pod.Status.PodIP
+			break
+		}
+	}
+	if podIP == "" {
+		return fmt.Errorf("no ready pod of the deployment %s", preview.Name)
+	}
+	port := constants.InferenceServiceDefaultHttpPort
+	if len(r.Service.Spec.Ports) > 0 && r.Service.Spec.Ports[0].TargetPort.IntValue() > 0 {
+		port = strconv.Itoa(r.Service.Spec.Ports[0].TargetPort.IntValue())
+	}
+
+	timeout := defaultSmokeTestTimeout
+	if smokeTest.TimeoutSeconds != nil {
+		timeout = time.Duration(*smokeTest.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	method, body := http.MethodGet, io.Reader(nil)
+	if smokeTest.Body != "" {
+		method, body = http.MethodPost, strings.NewReader(smokeTest.Body)
+	}
+	url := "http://" + net.JoinHostPort(podIP, port) + smokeTest.Path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if smokeTest.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s returned the status code %d", method, smokeTest.Path, resp.StatusCode)
+	}
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (r *BlueGreenReconciler) getPreview(ctx context.Context) (*appsv1.Deployment, error) {
+	if r.Deployment == nil {
+		return nil, nil
+	}
+	preview := &appsv1.Deployment{}
+	err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: r.Deployment.Namespace,
+		Name:      constants.BlueGreenPreviewDeploymentName(r.Deployment.Name),
+	}, preview)
+	if apierr.IsNotFound(err) {
+		return nil, nil
+	}
+	return preview, err
+}
+
+func (r *BlueGreenReconciler) applyPreview(ctx context.Context, preview *appsv1.Deployment, existing *appsv1.Deployment) error {
+	if existing == nil {
+		log.Info("Creating preview deployment", "namespace", preview.Namespace, "name", preview.Name)
+		return r.client.Create(ctx, preview)
+	}
+	_, switched := existing.Annotations[constants.BlueGreenSwitchTimeAnnotationKey]
+	_, desiredSwitched := preview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey]
+	if switched == desiredSwitched && equality.Semantic.DeepDerivative(preview.Spec, existing.Spec) &&
+		equality.Semantic.DeepDerivative(preview.Annotations, existing.Annotations) {
+		return nil
+	}
+	log.Info("Updating preview deployment", "namespace", preview.Namespace, "name", preview.Name)
+	// The annotations set by the deployment controller are kept
+	existing.Annotations = utils.Union(utils.Filter(existing.Annotations, func(key string) bool {
+		return key != constants.BlueGreenSwitchTimeAnnotationKey
+	}), preview.Annotations)
+	existing.Labels = preview.Labels
+	existing.Spec = preview.Spec
+	return r.client.Update(ctx, existing)
+}
+
+// deletePreview deletes the preview deployment owned by the same InferenceService as the default deployment
+func (r *BlueGreenReconciler) deletePreview(ctx context.Context, preview *appsv1.Deployment) error {
+	if preview == nil || preview.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	ctrl := metav1.GetControllerOf(r.Deployment)
+	existingCtrl := metav1.GetControllerOf(preview)
+	if ctrl == nil || existingCtrl == nil || ctrl.UID != existingCtrl.UID {
+		return nil
+	}
+	log.Info("Deleting preview deployment", "namespace", preview.Namespace, "name", preview.Name)
+	return client.IgnoreNotFound(r.client.Delete(ctx, preview))
+}
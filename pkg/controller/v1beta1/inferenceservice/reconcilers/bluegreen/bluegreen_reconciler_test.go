@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluegreen
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var (
+	switchTime = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ownerRef   = metav1.OwnerReference{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       "InferenceService",
+		Name:       "sklearn",
+		UID:        "uid",
+		Controller: ptr.To(true),
+	}
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	return scheme
+}
+
+func newDeployment(image string) *appsv1.Deployment {
+	labels := map[string]string{
+		constants.RawDeploymentAppLabel:       constants.GetRawServiceLabel("sklearn-predictor"),
+		constants.InferenceServicePodLabelKey: "sklearn",
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "sklearn-predictor",
+			Namespace:       "default",
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("sklearn-predictor")},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func newService(targetPort int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("sklearn-predictor")},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(targetPort)}},
+		},
+	}
+}
+
+// existingDeployment returns the deployment of the given image as stored in the cluster, with its pods rolled out
+func existingDeployment(t *testing.T, image string) *appsv1.Deployment {
+	t.Helper()
+	deployment := newDeployment(image)
+	r, err := NewBlueGreenReconciler(nil, &v1beta1.ComponentExtensionSpec{
+		DeploymentStrategy: &appsv1.DeploymentStrategy{Type: v1beta1.BlueGreenDeploymentStrategyType},
+	}, deployment, newService(8080))
+	require.NoError(t, err)
+	deployment.Spec.Replicas = ptr.To(int32(3))
+	deployment.Status = appsv1.DeploymentStatus{UpdatedReplicas: 3, ReadyReplicas: 3}
+	require.NotEmpty(t, r.templateHash)
+	return deployment
+}
+
+// readyPreview returns the preview deployment of the given image with its pods rolled out
+func readyPreview(t *testing.T, image string, annotations map[string]string) *appsv1.Deployment {
+	t.Helper()
+	preview := existingDeployment(t, image)
+	r := &BlueGreenReconciler{Deployment: preview}
+	preview = r.createPreview(preview)
+	preview.Annotations = annotations
+	return preview
+}
+
+func readyPod(ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor-preview-abc",
+			Namespace: "default",
+			Labels:    map[string]string{constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("sklearn-predictor-preview")},
+		},
+		Status: corev1.PodStatus{
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestBlueGreenReconcile(t *testing.T) {
+	smokeTestServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/models/sklearn:predict" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer smokeTestServer.Close()
+	host, port, err := net.SplitHostPort(smokeTestServer.Listener.Addr().String())
+	require.NoError(t, err)
+	smokeTestPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	blueGreen := &v1beta1.ComponentExtensionSpec{
+		DeploymentStrategy: &appsv1.DeploymentStrategy{Type: v1beta1.BlueGreenDeploymentStrategyType},
+		BlueGreen: &v1beta1.BlueGreenSpec{
+			RollbackWindowSeconds: ptr.To(int64(300)),
+			SmokeTest:             &v1beta1.BlueGreenSmokeTest{Path: "/v1/models/sklearn:predict", Body: `{"instances": [[1, 2]]}`},
+		},
+	}
+	failingSmokeTest := blueGreen.DeepCopy()
+	failingSmokeTest.BlueGreen.SmokeTest = &v1beta1.BlueGreenSmokeTest{Path: "/v1/models/sklearn"}
+	oldHash := existingDeployment(t, "sklearn:1").Spec.Template.Labels[constants.BlueGreenTemplateHashLabelKey]
+	newHash := existingDeployment(t, "sklearn:2").Spec.Template.Labels[constants.BlueGreenTemplateHashLabelKey]
+	require.NotEqual(t, oldHash, newHash)
+	switched := map[string]string{constants.BlueGreenSwitchTimeAnnotationKey: switchTime.Format(time.RFC3339)}
+
+	scenarios := map[string]struct {
+		componentExt     *v1beta1.ComponentExtensionSpec
+		objects          []client.Object
+		now              time.Time
+		expectedPhase    v1beta1.BlueGreenPhase
+		expectedSelector string
+		expectedImage    string
+		expectedPreview  bool
+		expectedSwitched bool
+		expectedMessage  string
+	}{
+		"disabled deletes the preview": {
+			componentExt:    &v1beta1.ComponentExtensionSpec{},
+			objects:         []client.Object{existingDeployment(t, "sklearn:1"), readyPreview(t, "sklearn:2", nil)},
+			expectedImage:   "sklearn:2",
+			expectedPreview: false,
+		},
+		"first deployment": {
+			componentExt:     blueGreen,
+			expectedPhase:    v1beta1.BlueGreenActive,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:2",
+		},
+		"new template deploys the preview": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:1")},
+			expectedPhase:    v1beta1.BlueGreenPreviewing,
+			expectedSelector: oldHash,
+			expectedImage:    "sklearn:1",
+			expectedPreview:  true,
+		},
+		"ready preview passing the smoke test is switched to": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:1"), readyPreview(t, "sklearn:2", nil), readyPod(host)},
+			now:              switchTime,
+			expectedPhase:    v1beta1.BlueGreenSwitched,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:1",
+			expectedPreview:  true,
+			expectedSwitched: true,
+		},
+		"ready preview failing the smoke test": {
+			componentExt:     failingSmokeTest,
+			objects:          []client.Object{existingDeployment(t, "sklearn:1"), readyPreview(t, "sklearn:2", nil), readyPod(host)},
+			expectedPhase:    v1beta1.BlueGreenPreviewing,
+			expectedSelector: oldHash,
+			expectedImage:    "sklearn:1",
+			expectedPreview:  true,
+			expectedMessage:  "smoke test failed: GET /v1/models/sklearn returned the status code 503",
+		},
+		"previous pods kept during the rollback window": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:1"), readyPreview(t, "sklearn:2", switched)},
+			now:              switchTime.Add(time.Minute),
+			expectedPhase:    v1beta1.BlueGreenSwitched,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:1",
+			expectedPreview:  true,
+			expectedSwitched: true,
+		},
+		"previous pods replaced at the end of the rollback window": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:1"), readyPreview(t, "sklearn:2", switched)},
+			now:              switchTime.Add(5 * time.Minute),
+			expectedPhase:    v1beta1.BlueGreenPromoting,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:2",
+			expectedPreview:  true,
+			expectedSwitched: true,
+		},
+		"preview deleted once the deployment is rolled out": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:2"), readyPreview(t, "sklearn:2", switched)},
+			expectedPhase:    v1beta1.BlueGreenActive,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:2",
+		},
+		"rollback switches back to the previous pods": {
+			componentExt:     blueGreen,
+			objects:          []client.Object{existingDeployment(t, "sklearn:2"), readyPreview(t, "sklearn:3", switched)},
+			expectedPhase:    v1beta1.BlueGreenActive,
+			expectedSelector: newHash,
+			expectedImage:    "sklearn:2",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(scenario.objects...).Build()
+			deployment := newDeployment("sklearn:2")
+			service := newService(int32(smokeTestPort))
+			r, err := NewBlueGreenReconciler(cl, scenario.componentExt, deployment, service)
+			require.NoError(t, err)
+			if !scenario.now.IsZero() {
+				r.now = func() time.Time { return scenario.now }
+			}
+			require.NoError(t, r.Reconcile(ctx))
+
+			assert.Equal(t, scenario.expectedImage, deployment.Spec.Template.Spec.Containers[0].Image)
+			if scenario.expectedPhase == "" {
+				assert.Nil(t, r.Status)
+				assert.Equal(t, newService(0).Spec.Selector, service.Spec.Selector)
+			} else {
+				require.NotNil(t, r.Status)
+				assert.Equal(t, scenario.expectedPhase, r.Status.Phase)
+				assert.Equal(t, scenario.expectedMessage, r.Status.Message)
+				assert.Equal(t, scenario.expectedSelector, service.Spec.Selector[constants.BlueGreenTemplateHashLabelKey])
+				assert.Equal(t, "sklearn", service.Spec.Selector[constants.InferenceServicePodLabelKey])
+			}
+
+			preview := &appsv1.Deployment{}
+			err = cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-preview"}, preview)
+			if !scenario.expectedPreview {
+				assert.True(t, apierr.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "sklearn:2", preview.Spec.Template.Spec.Containers[0].Image)
+			assert.Equal(t, int32(3), *preview.Spec.Replicas)
+			assert.Equal(t, newHash, preview.Spec.Template.Labels[constants.BlueGreenTemplateHashLabelKey])
+			assert.Equal(t, constants.GetRawServiceLabel("sklearn-predictor-preview"), preview.Spec.Template.Labels[constants.RawDeploymentAppLabel])
+			assert.Equal(t, []metav1.OwnerReference{ownerRef}, preview.OwnerReferences)
+			_, switched := preview.Annotations[constants.BlueGreenSwitchTimeAnnotationKey]
+			assert.Equal(t, scenario.expectedSwitched, switched)
+			if scenario.expectedSwitched {
+				assert.Equal(t, switchTime.Add(5*time.Minute), r.Status.RollbackWindowEndTime.Time)
+			}
+		})
+	}
+}
@@ -40,22 +40,26 @@ import (
 var log = logf.Log.WithName("CaBundleConfigMapReconciler")
 
 type CaBundleConfigMapReconciler struct {
-	client    client.Client
-	clientset kubernetes.Interface
-	scheme    *runtime.Scheme
+	client              client.Client
+	clientset           kubernetes.Interface
+	namespaceConfigMaps client.Reader
+	scheme              *runtime.Scheme
 }
 
-func NewCaBundleConfigMapReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme) *CaBundleConfigMapReconciler {
+func NewCaBundleConfigMapReconciler(client client.Client, clientset kubernetes.Interface, namespaceConfigMaps client.Reader,
+	scheme *runtime.Scheme,
+) *CaBundleConfigMapReconciler {
 	return &CaBundleConfigMapReconciler{
-		client:    client,
-		clientset: clientset,
-		scheme:    scheme,
+		client:              client,
+		clientset:           clientset,
+		namespaceConfigMaps: namespaceConfigMaps,
+		scheme:              scheme,
 	}
 }
 
 func (c *CaBundleConfigMapReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	log.Info("Reconciling CaBundleConfigMap", "namespace", isvc.Namespace)
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, c.clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, c.clientset, c.namespaceConfigMaps, isvc.Namespace)
 	if err != nil {
 		log.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
 		return err
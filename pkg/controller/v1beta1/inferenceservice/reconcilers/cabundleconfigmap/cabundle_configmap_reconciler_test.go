@@ -387,7 +387,7 @@ func TestNewCaBundleConfigMapReconciler(t *testing.T) {
 	clientset := fake.NewSimpleClientset()
 	client := rtesting.NewClientBuilder().WithScheme(scheme).Build()
 
-	reconciler := NewCaBundleConfigMapReconciler(client, clientset, scheme)
+	reconciler := NewCaBundleConfigMapReconciler(client, clientset, nil, scheme)
 	// The constructor should always return a valid non-nil reconciler
 	// with properly initialized fields
 
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("CanaryAnalysisReconciler")
+
+// Canary analysis event reasons
+const (
+	CanaryStepReason           = "CanaryStep"
+	CanaryPromotedReason       = "CanaryPromoted"
+	CanaryRolledBackReason     = "CanaryRolledBack"
+	CanaryAnalysisFailedReason = "CanaryAnalysisFailed"
+)
+
+// MetricsQuerier evaluates the queries of the metrics of the canary revisions
+type MetricsQuerier interface {
+	// Query returns the value of the query, which has no value when the canary revision received no requests
+	Query(ctx context.Context, query string) (float64, bool, error)
+}
+
+// CanaryAnalysisReconciler promotes the canary revisions of the components setting canaryAnalysis. The traffic of
+// a canary revision is increased step by step while its metrics meet the thresholds of the analysis, and shifted back
+// to the previous rolled out revision once they miss them, by patching the canaryTrafficPercent of the component.
+type CanaryAnalysisReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	config   *v1beta1.CanaryAnalysisConfig
+	querier  MetricsQuerier
+}
+
+// NewCanaryAnalysisReconciler returns a reconciler querying the metrics from Prometheus, the canary revisions are not
+// analysed without a Prometheus querier
+func NewCanaryAnalysisReconciler(client client.Client, recorder record.EventRecorder, config *v1beta1.CanaryAnalysisConfig, querier *prometheus.Querier) *CanaryAnalysisReconciler {
+	reconciler := &CanaryAnalysisReconciler{
+		client:   client,
+		recorder: recorder,
+		config:   config,
+	}
+	if querier != nil {
+		reconciler.querier = NewPrometheusMetricsQuerier(querier)
+	}
+	return reconciler
+}
+
+// Reconcile evaluates the canary revisions of the components whose evaluation is due, and returns the time until
+// the next evaluation. The analyses are recorded in the status of the components.
+func (r *CanaryAnalysisReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) (ctrl.Result, error) {
+	if deploymentMode != constants.Knative || utils.GetForceStopRuntime(isvc) {
+		return ctrl.Result{}, nil
+	}
+	componentExts := map[v1beta1.ComponentType]*v1beta1.ComponentExtensionSpec{
+		v1beta1.PredictorComponent: &isvc.Spec.Predictor.ComponentExtensionSpec,
+	}
+	if isvc.Spec.Transformer != nil {
+		componentExts[v1beta1.TransformerComponent] = &isvc.Spec.Transformer.ComponentExtensionSpec
+	}
+	if isvc.Spec.Explainer != nil {
+		componentExts[v1beta1.ExplainerComponent] = &isvc.Spec.Explainer.ComponentExtensionSpec
+	}
+
+	result := ctrl.Result{}
+	for componentType, componentExt := range componentExts {
+		requeueAfter, err := r.reconcileComponent(ctx, isvc, componentType, componentExt, time.Now())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+			result.RequeueAfter = requeueAfter
+		}
+	}
+	return result, nil
+}
+
+func (r *CanaryAnalysisReconciler) reconcileComponent(ctx context.Context, isvc *v1beta1.InferenceService, componentType v1beta1.ComponentType,
+	componentExt *v1beta1.ComponentExtensionSpec, now time.Time,
+) (time.Duration, error) {
+	statusSpec, ok := isvc.Status.Components[componentType]
+	if !ok {
+		return 0, nil
+	}
+	analysis := componentExt.CanaryAnalysis
+	if analysis == nil {
+		if statusSpec.CanaryAnalysis != nil {
+			statusSpec.CanaryAnalysis = nil
+			isvc.Status.Components[componentType] = statusSpec
+		}
+		return 0, nil
+	}
+	if r.querier == nil {
+		log.V(1).Info("No Prometheus instance configured, skipping the canary analysis", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		return 0, nil
+	}
+
+	// A canary revision is ready and splits the traffic with the previous rolled out revision, the canary revisions
+	// without traffic are rolled back
+	canary := statusSpec.LatestReadyRevision
+	if canary == "" || canary != statusSpec.LatestCreatedRevision || statusSpec.LatestRolledoutRevision == "" ||
+		canary == statusSpec.LatestRolledoutRevision || componentExt.CanaryTrafficPercent == nil || *componentExt.CanaryTrafficPercent <= 0 {
+		return 0, nil
+	}
+
+	interval := v1beta1.DefaultCanaryAnalysisInterval
+	if analysis.Interval != nil {
+		interval = analysis.Interval.Duration
+	}
+	status := statusSpec.CanaryAnalysis
+	defer func() {
+		statusSpec.CanaryAnalysis = status
+		isvc.Status.Components[componentType] = statusSpec
+	}()
+	if status == nil || status.Revision != canary {
+		status = &v1beta1.CanaryAnalysisStatus{
+			Revision:           canary,
+			Phase:              v1beta1.CanaryAnalysisProgressing,
+			LastEvaluationTime: metav1.NewTime(now),
+			Message:            fmt.Sprintf("The canary revision receives %d%% of the traffic", *componentExt.CanaryTrafficPercent),
+		}
+		return interval, nil
+	}
+	if status.Phase != v1beta1.CanaryAnalysisProgressing {
+		return 0, nil
+	}
+	if remaining := status.LastEvaluationTime.Add(interval).Sub(now); remaining > 0 {
+		return remaining, nil
+	}
+
+	status.LastEvaluationTime = metav1.NewTime(now)
+	failures, evaluated, err := r.evaluate(ctx, isvc, componentType, canary, analysis, interval)
+	if err != nil {
+		log.Error(err, "Failed to evaluate the canary revision", "isvc", isvc.Name, "namespace", isvc.Namespace, "revision", canary)
+		r.recorder.Eventf(isvc, corev1.EventTypeWarning, CanaryAnalysisFailedReason, "Failed to evaluate the canary revision %s: %v", canary, err)
+		status.Message = fmt.Sprintf("Failed to evaluate the metrics: %v", err)
+		return interval, nil
+	}
+	if !evaluated {
+		status.Message = "The canary revision received no requests over the last interval"
+		return interval, nil
+	}
+
+	if len(failures) > 0 {
+		status.FailedEvaluations++
+		status.Message = strings.Join(failures, ", ")
+		failureThreshold := v1beta1.DefaultCanaryAnalysisFailureThreshold
+		if analysis.FailureThreshold != nil {
+			failureThreshold = *analysis.FailureThreshold
+		}
+		if status.FailedEvaluations < failureThreshold {
+			return interval, nil
+		}
+		log.Info("Rolling back the canary revision", "isvc", isvc.Name, "namespace", isvc.Namespace, "revision", canary)
+		if err := r.setCanaryTrafficPercent(ctx, isvc, componentExt, ptr.To(int64(0))); err != nil {
+			return 0, err
+		}
+		status.Phase = v1beta1.CanaryAnalysisRolledBack
+		r.recorder.Eventf(isvc, corev1.EventTypeWarning, CanaryRolledBackReason,
+			"Rolled back the canary revision %s of the %s as %s", canary, componentType, status.Message)
+		return 0, nil
+	}
+
+	status.Steps++
+	stepPercent := v1beta1.DefaultCanaryAnalysisStepPercent
+	if analysis.StepPercent != nil {
+		stepPercent = *analysis.StepPercent
+	}
+	percent := *componentExt.CanaryTrafficPercent + stepPercent
+	if percent >= 100 || (analysis.MaxSteps != nil && status.Steps >= *analysis.MaxSteps) {
+		log.Info("Promoting the canary revision", "isvc", isvc.Name, "namespace", isvc.Namespace, "revision", canary)
+		if err := r.setCanaryTrafficPercent(ctx, isvc, componentExt, nil); err != nil {
+			return 0, err
+		}
+		status.Phase = v1beta1.CanaryAnalysisPromoted
+		status.Message = fmt.Sprintf("The canary revision was promoted after %d successful evaluations", status.Steps)
+		r.recorder.Eventf(isvc, corev1.EventTypeNormal, CanaryPromotedReason, "Promoted the canary revision %s of the %s", canary, componentType)
+		return 0, nil
+	}
+	if err := r.setCanaryTrafficPercent(ctx, isvc, componentExt, ptr.To(percent)); err != nil {
+		return 0, err
+	}
+	status.Message = fmt.Sprintf("The canary revision receives %d%% of the traffic", percent)
+	r.recorder.Eventf(isvc, corev1.EventTypeNormal, CanaryStepReason,
+		"Shifted %d%% of the traffic of the %s to the canary revision %s", percent, componentType, canary)
+	return interval, nil
+}
+
+// evaluate returns the thresholds of the analysis missed by the canary revision over the interval, and whether the
+// metrics could be evaluated
+func (r *CanaryAnalysisReconciler) evaluate(ctx context.Context, isvc *v1beta1.InferenceService, componentType v1beta1.ComponentType,
+	canary string, analysis *v1beta1.CanaryAnalysisSpec, interval time.Duration,
+) ([]string, bool, error) {
+	parameters := v1beta1.CanaryQueryParameters{
+		Namespace:        isvc.Namespace,
+		InferenceService: isvc.Name,
+		Component:        string(componentType),
+		Revision:         canary,
+		Interval:         model.Duration(interval).String(),
+	}
+	var failures []string
+	if analysis.MinSuccessRate != nil {
+		successRate, found, err := r.query(ctx, r.config.SuccessRateQuery, parameters)
+		if err != nil || !found {
+			return nil, false, err
+		}
+		if successRate < float64(*analysis.MinSuccessRate) {
+			failures = append(failures, fmt.Sprintf("the success rate %.2f%% is below %d%%", successRate, *analysis.MinSuccessRate))
+		}
+	}
+	if analysis.MaxLatencyMilliseconds != nil {
+		latency, found, err := r.query(ctx, r.config.LatencyQuery, parameters)
+		if err != nil || !found {
+			return nil, false, err
+		}
+		if latency > float64(*analysis.MaxLatencyMilliseconds) {
+			failures = append(failures, fmt.Sprintf("the p99 latency %.0fms is above %dms", latency, *analysis.MaxLatencyMilliseconds))
+		}
+	}
+	return failures, true, nil
+}
+
+func (r *CanaryAnalysisReconciler) query(ctx context.Context, query string, parameters v1beta1.CanaryQueryParameters) (float64, bool, error) {
+	rendered, err := v1beta1.RenderCanaryQuery(query, parameters)
+	if err != nil {
+		return 0, false, err
+	}
+	return r.querier.Query(ctx, rendered)
+}
+
+// setCanaryTrafficPercent patches the canaryTrafficPercent of the component, the traffic is shifted by the next
+// reconciliation of the component. The status of the InferenceService is left to the status update.
+func (r *CanaryAnalysisReconciler) setCanaryTrafficPercent(ctx context.Context, isvc *v1beta1.InferenceService,
+	componentExt *v1beta1.ComponentExtensionSpec, percent *int64,
+) error {
+	original := isvc.DeepCopy()
+	previous := componentExt.CanaryTrafficPercent
+	componentExt.CanaryTrafficPercent = percent
+	patched := isvc.DeepCopy()
+	if err := r.client.Patch(ctx, patched, client.MergeFrom(original)); err != nil {
+		componentExt.CanaryTrafficPercent = previous
+		return err
+	}
+	isvc.ResourceVersion = patched.ResourceVersion
+	return nil
+}
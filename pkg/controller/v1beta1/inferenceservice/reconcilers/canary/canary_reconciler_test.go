@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+const (
+	successRateQuery = `success_rate{revision="{{ .Revision }}"}`
+	latencyQuery     = `latency{revision="{{ .Revision }}"}`
+)
+
+type sample struct {
+	value float64
+	found bool
+}
+
+// fakeQuerier returns the samples of the rendered queries
+type fakeQuerier struct {
+	samples map[string]sample
+	err     error
+}
+
+func (q *fakeQuerier) Query(_ context.Context, query string) (float64, bool, error) {
+	return q.samples[query].value, q.samples[query].found, q.err
+}
+
+func makeInferenceService(canaryTrafficPercent int64, status *v1beta1.CanaryAnalysisStatus) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					CanaryTrafficPercent: ptr.To(canaryTrafficPercent),
+					CanaryAnalysis: &v1beta1.CanaryAnalysisSpec{
+						StepPercent:            ptr.To(int64(20)),
+						MaxSteps:               ptr.To(int32(3)),
+						MinSuccessRate:         ptr.To(int32(99)),
+						MaxLatencyMilliseconds: ptr.To(int64(500)),
+						FailureThreshold:       ptr.To(int32(2)),
+					},
+				},
+			},
+		},
+	}
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {
+			LatestCreatedRevision:   "sklearn-predictor-00002",
+			LatestReadyRevision:     "sklearn-predictor-00002",
+			LatestRolledoutRevision: "sklearn-predictor-00001",
+			CanaryAnalysis:          status,
+		},
+	}
+	return isvc
+}
+
+func progressing(steps int32, failedEvaluations int32, lastEvaluation time.Time) *v1beta1.CanaryAnalysisStatus {
+	return &v1beta1.CanaryAnalysisStatus{
+		Revision:           "sklearn-predictor-00002",
+		Phase:              v1beta1.CanaryAnalysisProgressing,
+		Steps:              steps,
+		FailedEvaluations:  failedEvaluations,
+		LastEvaluationTime: metav1.NewTime(lastEvaluation),
+	}
+}
+
+func healthy() *fakeQuerier {
+	return &fakeQuerier{samples: map[string]sample{
+		`success_rate{revision="sklearn-predictor-00002"}`: {value: 99.9, found: true},
+		`latency{revision="sklearn-predictor-00002"}`:      {value: 120, found: true},
+	}}
+}
+
+func TestCanaryAnalysisReconcile(t *testing.T) {
+	config := &v1beta1.CanaryAnalysisConfig{SuccessRateQuery: successRateQuery, LatencyQuery: latencyQuery}
+	due := time.Now().Add(-2 * time.Minute)
+	scenarios := map[string]struct {
+		canaryTrafficPercent int64
+		status               *v1beta1.CanaryAnalysisStatus
+		querier              *fakeQuerier
+		expectedRequeue      time.Duration
+		expectedPercent      *int64
+		expectedPhase        v1beta1.CanaryAnalysisPhase
+		expectedSteps        int32
+		expectedFailures     int32
+		expectedMessage      string
+		expectedEvent        string
+	}{
+		"AnalysisStarted": {
+			canaryTrafficPercent: 10,
+			querier:              healthy(),
+			expectedRequeue:      time.Minute,
+			expectedPercent:      ptr.To(int64(10)),
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedMessage:      "The canary revision receives 10% of the traffic",
+		},
+		"EvaluationNotDue": {
+			canaryTrafficPercent: 10,
+			status:               progressing(0, 0, time.Now().Add(-20*time.Second)),
+			querier:              healthy(),
+			expectedRequeue:      40 * time.Second,
+			expectedPercent:      ptr.To(int64(10)),
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+		},
+		"NoRequests": {
+			canaryTrafficPercent: 10,
+			status:               progressing(0, 0, due),
+			querier:              &fakeQuerier{},
+			expectedRequeue:      time.Minute,
+			expectedPercent:      ptr.To(int64(10)),
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedMessage:      "The canary revision received no requests over the last interval",
+		},
+		"QueryFailed": {
+			canaryTrafficPercent: 10,
+			status:               progressing(0, 0, due),
+			querier:              &fakeQuerier{err: errors.New("connection refused")},
+			expectedRequeue:      time.Minute,
+			expectedPercent:      ptr.To(int64(10)),
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedMessage:      "Failed to evaluate the metrics: connection refused",
+			expectedEvent:        "Warning CanaryAnalysisFailed Failed to evaluate the canary revision sklearn-predictor-00002: connection refused",
+		},
+		"Step": {
+			canaryTrafficPercent: 10,
+			status:               progressing(0, 0, due),
+			querier:              healthy(),
+			expectedRequeue:      time.Minute,
+			expectedPercent:      ptr.To(int64(30)),
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedSteps:        1,
+			expectedMessage:      "The canary revision receives 30% of the traffic",
+			expectedEvent:        "Normal CanaryStep Shifted 30% of the traffic of the predictor to the canary revision sklearn-predictor-00002",
+		},
+		"PromotedWithAllTheTraffic": {
+			canaryTrafficPercent: 90,
+			status:               progressing(0, 0, due),
+			querier:              healthy(),
+			expectedPhase:        v1beta1.CanaryAnalysisPromoted,
+			expectedSteps:        1,
+			expectedMessage:      "The canary revision was promoted after 1 successful evaluations",
+			expectedEvent:        "Normal CanaryPromoted Promoted the canary revision sklearn-predictor-00002 of the predictor",
+		},
+		"PromotedAfterTheMaxSteps": {
+			canaryTrafficPercent: 50,
+			status:               progressing(2, 0, due),
+			querier:              healthy(),
+			expectedPhase:        v1beta1.CanaryAnalysisPromoted,
+			expectedSteps:        3,
+			expectedMessage:      "The canary revision was promoted after 3 successful evaluations",
+			expectedEvent:        "Normal CanaryPromoted Promoted the canary revision sklearn-predictor-00002 of the predictor",
+		},
+		"FailedBelowTheThreshold": {
+			canaryTrafficPercent: 30,
+			status:               progressing(1, 0, due),
+			querier: &fakeQuerier{samples: map[string]sample{
+				`success_rate{revision="sklearn-predictor-00002"}`: {value: 95, found: true},
+				`latency{revision="sklearn-predictor-00002"}`:      {value: 120, found: true},
+			}},
+			expectedRequeue:  time.Minute,
+			expectedPercent:  ptr.To(int64(30)),
+			expectedPhase:    v1beta1.CanaryAnalysisProgressing,
+			expectedSteps:    1,
+			expectedFailures: 1,
+			expectedMessage:  "the success rate 95.00% is below 99%",
+		},
+		"RolledBack": {
+			canaryTrafficPercent: 30,
+			status:               progressing(1, 1, due),
+			querier: &fakeQuerier{samples: map[string]sample{
+				`success_rate{revision="sklearn-predictor-00002"}`: {value: 99.5, found: true},
+				`latency{revision="sklearn-predictor-00002"}`:      {value: 812, found: true},
+			}},
+			expectedPercent:  ptr.To(int64(0)),
+			expectedPhase:    v1beta1.CanaryAnalysisRolledBack,
+			expectedSteps:    1,
+			expectedFailures: 2,
+			expectedMessage:  "the p99 latency 812ms is above 500ms",
+			expectedEvent:    "Warning CanaryRolledBack Rolled back the canary revision sklearn-predictor-00002 of the predictor as the p99 latency 812ms is above 500ms",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			isvc := makeInferenceService(scenario.canaryTrafficPercent, scenario.status)
+			c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(isvc.DeepCopy()).Build()
+			status := isvc.Status
+			g.Expect(c.Get(t.Context(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, isvc)).To(gomega.Succeed())
+			isvc.Status = status
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &CanaryAnalysisReconciler{client: c, recorder: recorder, config: config, querier: scenario.querier}
+
+			result, err := reconciler.Reconcile(t.Context(), isvc, constants.Knative)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(result.RequeueAfter).To(gomega.BeNumerically("~", scenario.expectedRequeue, time.Second))
+			if scenario.expectedEvent != "" {
+				g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(scenario.expectedEvent)))
+			} else {
+				g.Expect(recorder.Events).To(gomega.BeEmpty())
+			}
+
+			analysis := isvc.Status.Components[v1beta1.PredictorComponent].CanaryAnalysis
+			g.Expect(analysis).ToNot(gomega.BeNil())
+			g.Expect(analysis.Phase).To(gomega.Equal(scenario.expectedPhase))
+			g.Expect(analysis.Steps).To(gomega.Equal(scenario.expectedSteps))
+			g.Expect(analysis.FailedEvaluations).To(gomega.Equal(scenario.expectedFailures))
+			if scenario.expectedMessage != "" {
+				g.Expect(analysis.Message).To(gomega.Equal(scenario.expectedMessage))
+			}
+
+			actual := &v1beta1.InferenceService{}
+			g.Expect(c.Get(t.Context(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, actual)).To(gomega.Succeed())
+			g.Expect(actual.Spec.Predictor.CanaryTrafficPercent).To(gomega.Equal(scenario.expectedPercent))
+			g.Expect(isvc.Spec.Predictor.CanaryTrafficPercent).To(gomega.Equal(scenario.expectedPercent))
+			g.Expect(isvc.ResourceVersion).To(gomega.Equal(actual.ResourceVersion))
+		})
+	}
+}
+
+func TestCanaryAnalysisReconcileSkipped(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	querier := healthy()
+	reconciler := &CanaryAnalysisReconciler{recorder: record.NewFakeRecorder(10), config: &v1beta1.CanaryAnalysisConfig{}, querier: querier}
+
+	rolledOut := makeInferenceService(10, nil)
+	rolledOut.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{
+		LatestCreatedRevision:   "sklearn-predictor-00002",
+		LatestReadyRevision:     "sklearn-predictor-00002",
+		LatestRolledoutRevision: "sklearn-predictor-00002",
+	}
+	notReady := makeInferenceService(10, nil)
+	notReady.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{
+		LatestCreatedRevision:   "sklearn-predictor-00003",
+		LatestReadyRevision:     "sklearn-predictor-00002",
+		LatestRolledoutRevision: "sklearn-predictor-00001",
+	}
+	withoutTraffic := makeInferenceService(0, nil)
+	rolledBack := makeInferenceService(10, progressing(0, 1, time.Now().Add(-time.Hour)))
+	rolledBack.Status.Components[v1beta1.PredictorComponent].CanaryAnalysis.Phase = v1beta1.CanaryAnalysisRolledBack
+	stopped := makeInferenceService(10, nil)
+	stopped.Annotations = map[string]string{constants.StopAnnotationKey: "true"}
+
+	for _, isvc := range []*v1beta1.InferenceService{rolledOut, notReady, withoutTraffic, rolledBack, stopped} {
+		result, err := reconciler.Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.BeZero())
+	}
+	result, err := reconciler.Reconcile(t.Context(), makeInferenceService(10, nil), constants.Standard)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(result.RequeueAfter).To(gomega.BeZero())
+
+	// the status of the analysis is removed with the analysis
+	withoutAnalysis := makeInferenceService(10, progressing(1, 0, time.Now()))
+	withoutAnalysis.Spec.Predictor.CanaryAnalysis = nil
+	_, err = reconciler.Reconcile(t.Context(), withoutAnalysis, constants.Knative)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(withoutAnalysis.Status.Components[v1beta1.PredictorComponent].CanaryAnalysis).To(gomega.BeNil())
+}
+
+func TestPrometheusMetricsQuerier(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var query string
+	response := `{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1700000000, "99.5"]}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.ParseForm()).To(gomega.Succeed())
+		query = r.Form.Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	prometheusQuerier, err := prometheus.NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	querier := NewPrometheusMetricsQuerier(prometheusQuerier)
+	rendered, err := v1beta1.RenderCanaryQuery(v1beta1.DefaultCanaryLatencyQuery, v1beta1.CanaryQueryParameters{
+		Namespace: "default",
+		Revision:  "sklearn-predictor-00002",
+		Interval:  "1m",
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	value, found, err := querier.Query(t.Context(), rendered)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(value).To(gomega.Equal(99.5))
+	g.Expect(query).To(gomega.Equal(`histogram_quantile(0.99, sum by (le) (rate(revision_request_latencies_bucket{namespace="default", revision="sklearn-predictor-00002"}[1m])))`))
+
+	for _, response = range []string{
+		`{"status": "success", "data": {"resultType": "vector", "result": []}}`,
+		`{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1700000000, "NaN"]}]}}`,
+	} {
+		_, found, err = querier.Query(t.Context(), rendered)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(found).To(gomega.BeFalse())
+	}
+
+	response = `{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {"pod": "a"}, "value": [1700000000, "1"]}, {"metric": {"pod": "b"}, "value": [1700000000, "2"]}]}}`
+	_, _, err = querier.Query(t.Context(), rendered)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("returned 2 series, expected one")))
+}
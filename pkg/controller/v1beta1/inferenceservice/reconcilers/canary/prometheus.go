@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+// PrometheusMetricsQuerier evaluates the metrics of the canary revisions with instant Prometheus queries
+type PrometheusMetricsQuerier struct {
+	querier *prometheus.Querier
+}
+
+// NewPrometheusMetricsQuerier returns a querier of the metrics of the canary revisions
+func NewPrometheusMetricsQuerier(querier *prometheus.Querier) *PrometheusMetricsQuerier {
+	return &PrometheusMetricsQuerier{querier: querier}
+}
+
+// Query returns the value of the single sample of the query. The query has no value when it returns no sample or
+// NaN, e.g. a ratio of the requests of a revision which received none.
+func (q *PrometheusMetricsQuerier) Query(ctx context.Context, query string) (float64, bool, error) {
+	samples, err := q.querier.Query(ctx, query)
+	if err != nil {
+		return 0, false, err
+	}
+	switch len(samples) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return samples[0], true, nil
+	default:
+		return 0, false, fmt.Errorf("the query %q returned %d series, expected one", query, len(samples))
+	}
+}
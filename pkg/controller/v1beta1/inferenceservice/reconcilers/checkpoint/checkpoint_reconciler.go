@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+var log = logf.Log.WithName("CheckpointReconciler")
+
+// CheckpointReconciler requests a checkpoint of the model server container of the InferenceServices annotated with
+// serving.kserve.io/checkpoint-restore when checkpoint restore is enabled. Once the predictor has a ready pod, a
+// ModelCheckpoint named after the current ReplicaSet of the predictor is created for the checkpoint agent of the node
+// of the pod, and the pod mutator restores the new pods of the ReplicaSet from the checkpoint image once pushed.
+// The ModelCheckpoints of the previous revisions of the predictor are deleted.
+type CheckpointReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	config *v1beta1.CheckpointRestoreConfig
+}
+
+func NewCheckpointReconciler(client client.Client, scheme *runtime.Scheme, config *v1beta1.CheckpointRestoreConfig) *CheckpointReconciler {
+	return &CheckpointReconciler{
+		client: client,
+		scheme: scheme,
+		config: config,
+	}
+}
+
+func (r *CheckpointReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) error {
+	if !r.config.Enabled || isvc.Annotations[constants.CheckpointRestoreAnnotationKey] != "true" {
+		return nil
+	}
+	if deploymentMode != constants.Standard {
+		log.Info("Checkpoint restore is only supported in Standard deployment mode", "isvc", isvc.Name,
+			"deploymentMode", deploymentMode)
+		return nil
+	}
+	replicaSet, err := utils.GetCurrentReplicaSet(ctx, r.client, isvc.Namespace, constants.PredictorServiceName(isvc.Name))
+	if err != nil || replicaSet == nil {
+		return err
+	}
+
+	checkpoints := &v1alpha1.ModelCheckpointList{}
+	if err := r.client.List(ctx, checkpoints, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		return err
+	}
+	found := false
+	for i := range checkpoints.Items {
+		checkpoint := &checkpoints.Items[i]
+		if checkpoint.Name == replicaSet.Name {
+			found = true
+			continue
+		}
+		log.Info("Deleting checkpoint of a previous revision of the predictor", "isvc", isvc.Name, "checkpoint", checkpoint.Name)
+		if err := r.client.Delete(ctx, checkpoint); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	if found {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels(replicaSet.Spec.Selector.MatchLabels)); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isCheckpointable(pod) || !metav1.IsControlledBy(pod, replicaSet) {
+			continue
+		}
+		checkpoint := &v1alpha1.ModelCheckpoint{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      replicaSet.Name,
+				Namespace: isvc.Namespace,
+				Labels:    map[string]string{constants.InferenceServicePodLabelKey: isvc.Name},
+			},
+			Spec: v1alpha1.ModelCheckpointSpec{
+				PodName:       pod.Name,
+				ContainerName: constants.InferenceServiceContainerName,
+				NodeName:      pod.Spec.NodeName,
+				Image:         fmt.Sprintf("%s:%s-%s", r.config.Repository, isvc.Namespace, replicaSet.Name),
+			},
+		}
+		if err := controllerutil.SetControllerReference(isvc, checkpoint, r.scheme); err != nil {
+			return err
+		}
+		log.Info("Creating checkpoint of the predictor", "isvc", isvc.Name, "pod", pod.Name, "node", pod.Spec.NodeName)
+		if err := r.client.Create(ctx, checkpoint); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	return nil
+}
+
+// isCheckpointable returns whether the model server container of a pod is ready to be checkpointed. Pods restored
+// from a checkpoint are not checkpointed again.
+func isCheckpointable(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	if _, restored := pod.Annotations[constants.RestoredFromCheckpointAnnotationKey]; restored {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == constants.InferenceServiceContainerName {
+			return status.Ready
+		}
+	}
+	return false
+}
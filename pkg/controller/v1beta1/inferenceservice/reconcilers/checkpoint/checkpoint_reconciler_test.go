@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+func TestCheckpointReconciler(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	require.NoError(t, v1beta1.AddToScheme(s))
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "default",
+			UID:         "isvc-uid",
+			Annotations: map[string]string{constants.CheckpointRestoreAnnotationKey: "true"},
+		},
+	}
+	selector := map[string]string{"app": "isvc.sklearn-predictor"}
+	controller := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   "default",
+			UID:         "deployment-uid",
+			Annotations: map[string]string{utils.DeploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor-5d8f7b9c4",
+			Namespace:   "default",
+			UID:         "rs-uid",
+			Labels:      selector,
+			Annotations: map[string]string{utils.DeploymentRevisionAnnotation: "2"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "sklearn-predictor", UID: "deployment-uid", Controller: &controller},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+	}
+	makePod := func(name string, ready bool) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    selector,
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet.Name, UID: "rs-uid", Controller: &controller},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: constants.InferenceServiceContainerName, Ready: ready}},
+			},
+		}
+	}
+	staleCheckpoint := &v1alpha1.ModelCheckpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor-6b7c8d9f1",
+			Namespace: "default",
+			Labels:    map[string]string{constants.InferenceServicePodLabelKey: "sklearn"},
+		},
+	}
+	enabled := &v1beta1.CheckpointRestoreConfig{Enabled: true, Repository: "registry.example.com/checkpoints"}
+
+	testCases := []struct {
+		name                string
+		config              *v1beta1.CheckpointRestoreConfig
+		deploymentMode      constants.DeploymentModeType
+		objects             []client.Object
+		expectedCheckpoints []string
+	}{
+		{
+			name:                "checkpoint created for a ready pod",
+			config:              enabled,
+			deploymentMode:      constants.Standard,
+			objects:             []client.Object{deployment, replicaSet, makePod("sklearn-predictor-5d8f7b9c4-x2k9p", true)},
+			expectedCheckpoints: []string{"sklearn-predictor-5d8f7b9c4"},
+		},
+		{
+			name:           "no ready pod",
+			config:         enabled,
+			deploymentMode: constants.Standard,
+			objects:        []client.Object{deployment, replicaSet, makePod("sklearn-predictor-5d8f7b9c4-x2k9p", false)},
+		},
+		{
+			name:           "checkpoint restore disabled",
+			config:         &v1beta1.CheckpointRestoreConfig{},
+			deploymentMode: constants.Standard,
+			objects:        []client.Object{deployment, replicaSet, makePod("sklearn-predictor-5d8f7b9c4-x2k9p", true)},
+		},
+		{
+			name:           "knative deployment mode",
+			config:         enabled,
+			deploymentMode: constants.Knative,
+			objects:        []client.Object{deployment, replicaSet, makePod("sklearn-predictor-5d8f7b9c4-x2k9p", true)},
+		},
+		{
+			name:           "checkpoint of a previous revision deleted",
+			config:         enabled,
+			deploymentMode: constants.Standard,
+			objects: []client.Object{
+				deployment, replicaSet, staleCheckpoint,
+				makePod("sklearn-predictor-5d8f7b9c4-x2k9p", true),
+			},
+			expectedCheckpoints: []string{"sklearn-predictor-5d8f7b9c4"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+			reconciler := NewCheckpointReconciler(cl, s, tt.config)
+			require.NoError(t, reconciler.Reconcile(t.Context(), isvc.DeepCopy(), tt.deploymentMode))
+
+			checkpoints := &v1alpha1.ModelCheckpointList{}
+			require.NoError(t, cl.List(t.Context(), checkpoints, client.InNamespace("default")))
+			names := []string{}
+			for _, checkpoint := range checkpoints.Items {
+				names = append(names, checkpoint.Name)
+				assert.Equal(t, "sklearn-predictor-5d8f7b9c4-x2k9p", checkpoint.Spec.PodName)
+				assert.Equal(t, constants.InferenceServiceContainerName, checkpoint.Spec.ContainerName)
+				assert.Equal(t, "node-1", checkpoint.Spec.NodeName)
+				assert.Equal(t, "registry.example.com/checkpoints:default-sklearn-predictor-5d8f7b9c4", checkpoint.Spec.Image)
+				assert.True(t, metav1.IsControlledBy(&checkpoint, isvc))
+			}
+			assert.ElementsMatch(t, tt.expectedCheckpoints, names)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+var log = logf.Log.WithName("ConfigSnapshotReconciler")
+
+// Keys of the data of the config snapshot ConfigMaps
+const (
+	RevisionKey                  = "revision"
+	DeploymentRevisionKey        = "deploymentRevision"
+	DeploymentModeKey            = "deploymentMode"
+	InferenceServiceKey          = "inferenceservice.json"
+	ServingRuntimeKey            = "servingruntime.json"
+	InferenceServiceConfigMapKey = "inferenceservice-config.json"
+)
+
+// inferenceServiceSnapshot is the part of the InferenceService a revision is rendered from
+type inferenceServiceSnapshot struct {
+	Name        string                       `json:"name"`
+	Namespace   string                       `json:"namespace"`
+	Generation  int64                        `json:"generation"`
+	Labels      map[string]string            `json:"labels,omitempty"`
+	Annotations map[string]string            `json:"annotations,omitempty"`
+	Spec        v1beta1.InferenceServiceSpec `json:"spec"`
+}
+
+// servingRuntimeSnapshot is the spec of the serving runtime of the predictor, with its kind and name
+type servingRuntimeSnapshot struct {
+	Kind string                      `json:"kind"`
+	Name string                      `json:"name"`
+	Spec v1alpha1.ServingRuntimeSpec `json:"spec"`
+}
+
+// ConfigSnapshotReconciler snapshots the resolved configuration each revision of the components is rendered with
+// into an immutable ConfigMap named after the revision, so that the configuration a revision ran with can be looked
+// up once the InferenceService, its serving runtime or the inferenceservice-config ConfigMap changed. The revisions
+// are the Knative revisions in Serverless mode and the ReplicaSets of the Deployments in Standard mode, and a
+// snapshot is taken when a revision is first observed. The snapshots of the revisions beyond the retention are
+// deleted, and the remaining ones are garbage collected with the InferenceService.
+// The snapshots are got and listed through the clientset, a lookup through the client of the manager would start an
+// informer on the ConfigMaps of every namespace only to find the few snapshots of the InferenceService.
+type ConfigSnapshotReconciler struct {
+	client        client.Client
+	clientset     kubernetes.Interface
+	scheme        *runtime.Scheme
+	config        *v1beta1.ConfigSnapshotConfig
+	isvcConfigMap *corev1.ConfigMap
+}
+
+func NewConfigSnapshotReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
+	config *v1beta1.ConfigSnapshotConfig, isvcConfigMap *corev1.ConfigMap,
+) *ConfigSnapshotReconciler {
+	return &ConfigSnapshotReconciler{
+		client:        client,
+		clientset:     clientset,
+		scheme:        scheme,
+		config:        config,
+		isvcConfigMap: isvcConfigMap,
+	}
+}
+
+func (r *ConfigSnapshotReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) error {
+	if !r.config.Enabled {
+		return nil
+	}
+	if deploymentMode != constants.Knative && deploymentMode != constants.Standard {
+		return nil
+	}
+	components := []v1beta1.ComponentType{v1beta1.PredictorComponent}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, v1beta1.TransformerComponent)
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, v1beta1.ExplainerComponent)
+	}
+	for _, component := range components {
+		if err := r.reconcileComponent(ctx, isvc, component, deploymentMode); err != nil {
+			return fmt.Errorf("fails to snapshot the config of the %s: %w", component, err)
+		}
+	}
+	return nil
+}
+
+func (r *ConfigSnapshotReconciler) reconcileComponent(ctx context.Context, isvc *v1beta1.InferenceService,
+	component v1beta1.ComponentType, deploymentMode constants.DeploymentModeType,
+) error {
+	revision, deploymentRevision, err := r.latestRevision(ctx, isvc, component, deploymentMode)
+	if err != nil || revision == "" {
+		return err
+	}
+	name := constants.ConfigSnapshotName(revision)
+	configMaps := r.clientset.CoreV1().ConfigMaps(isvc.Namespace)
+	if _, err := configMaps.Get(ctx, name, metav1.GetOptions{}); apierr.IsNotFound(err) {
+		snapshot, err := r.snapshot(ctx, isvc, component, revision, deploymentRevision, deploymentMode)
+		if err != nil {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(isvc, snapshot, r.scheme); err != nil {
+			return err
+		}
+		log.Info("Creating the config snapshot of the revision", "isvc", isvc.Name, "component", component,
+			"revision", revision)
+		if _, err := configMaps.Create(ctx, snapshot, metav1.CreateOptions{}); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	setConfigSnapshotStatus(isvc, component, name)
+	return r.prune(ctx, isvc, component, name)
+}
+
+// latestRevision returns the latest created revision of a component, and the revision number of its Deployment in
+// Standard mode. It is empty until the revision is created.
+func (r *ConfigSnapshotReconciler) latestRevision(ctx context.Context, isvc *v1beta1.InferenceService,
+	component v1beta1.ComponentType, deploymentMode constants.DeploymentModeType,
+) (string, string, error) {
+	if deploymentMode == constants.Knative {
+		return isvc.Status.Components[component].LatestCreatedRevision, "", nil
+	}
+	replicaSet, err := utils.GetCurrentReplicaSet(ctx, r.client, isvc.Namespace, isvc.Name+"-"+string(component))
+	if err != nil || replicaSet == nil {
+		return "", "", err
+	}
+	return replicaSet.Name, replicaSet.Annotations[utils.DeploymentRevisionAnnotation], nil
+}
+
+// snapshot returns the immutable ConfigMap with the resolved configuration of a revision
+func (r *ConfigSnapshotReconciler) snapshot(ctx context.Context, isvc *v1beta1.InferenceService,
+	component v1beta1.ComponentType, revision string, deploymentRevision string, deploymentMode constants.DeploymentModeType,
+) (*corev1.ConfigMap, error) {
+	data := map[string]string{
+		RevisionKey:       revision,
+		DeploymentModeKey: string(deploymentMode),
+	}
+	if deploymentRevision != "" {
+		data[DeploymentRevisionKey] = deploymentRevision
+	}
+
+	isvcSnapshot, err := json.MarshalIndent(inferenceServiceSnapshot{
+		Name:        isvc.Name,
+		Namespace:   isvc.Namespace,
+		Generation:  isvc.Generation,
+		Labels:      isvc.Labels,
+		Annotations: withoutLastAppliedConfiguration(isvc.Annotations),
+		Spec:        isvc.Spec,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	data[InferenceServiceKey] = string(isvcSnapshot)
+
+	if component == v1beta1.PredictorComponent {
+		runtimeSnapshot, err := r.servingRuntime(ctx, isvc)
+		if err != nil {
+			return nil, err
+		}
+		if runtimeSnapshot != nil {
+			bytes, err := json.MarshalIndent(runtimeSnapshot, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			data[ServingRuntimeKey] = string(bytes)
+		}
+	}
+
+	if r.isvcConfigMap != nil {
+		// the commented examples of the configuration are not part of the resolved configuration
+		config := map[string]string{}
+		for key, value := range r.isvcConfigMap.Data {
+			if !strings.HasPrefix(key, "_") {
+				config[key] = value
+			}
+		}
+		bytes, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		data[InferenceServiceConfigMapKey] = string(bytes)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.ConfigSnapshotName(revision),
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+				constants.KServiceComponentLabel:      string(component),
+				constants.ConfigSnapshotRevisionLabel: revision,
+			},
+		},
+		Data:      data,
+		Immutable: ptr.To(true),
+	}, nil
+}
+
+// servingRuntime returns the spec of the serving runtime resolved for the predictor, or nil when the predictor does
+// not use a serving runtime
+func (r *ConfigSnapshotReconciler) servingRuntime(ctx context.Context, isvc *v1beta1.InferenceService) (*servingRuntimeSnapshot, error) {
+	if name := isvc.Status.ServingRuntimeName; name != "" {
+		runtime := &v1alpha1.ServingRuntime{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: isvc.Namespace, Name: name}, runtime); err != nil {
+			return nil, err
+		}
+		return &servingRuntimeSnapshot{Kind: "ServingRuntime", Name: name, Spec: runtime.Spec}, nil
+	}
+	if name := isvc.Status.ClusterServingRuntimeName; name != "" {
+		runtime := &v1alpha1.ClusterServingRuntime{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: name}, runtime); err != nil {
+			return nil, err
+		}
+		return &servingRuntimeSnapshot{Kind: "ClusterServingRuntime", Name: name, Spec: runtime.Spec}, nil
+	}
+	return nil, nil
+}
+
+// prune deletes the oldest snapshots of a component beyond the retention, the snapshot of the latest revision is
+// always kept
+func (r *ConfigSnapshotReconciler) prune(ctx context.Context, isvc *v1beta1.InferenceService, component v1beta1.ComponentType, latest string) error {
+	requirement, err := labels.NewRequirement(constants.ConfigSnapshotRevisionLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.KServiceComponentLabel:      string(component),
+	}).Add(*requirement)
+	configMaps := r.clientset.CoreV1().ConfigMaps(isvc.Namespace)
+	snapshots, err := configMaps.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	if len(snapshots.Items) <= r.config.Retention {
+		return nil
+	}
+	// newest first, the names of the revisions of a component sort in their creation order
+	sort.Slice(snapshots.Items, func(i, j int) bool {
+		a, b := snapshots.Items[i], snapshots.Items[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+		return a.Name > b.Name
+	})
+	// the snapshot of the latest revision counts towards the retention
+	kept := 1
+	for _, snapshot := range snapshots.Items {
+		if snapshot.Name == latest {
+			continue
+		}
+		if kept < r.config.Retention {
+			kept++
+			continue
+		}
+		if !metav1.IsControlledBy(&snapshot, isvc) {
+			continue
+		}
+		log.Info("Deleting the config snapshot of a revision beyond the retention", "isvc", isvc.Name,
+			"component", component, "snapshot", snapshot.Name)
+		if err := configMaps.Delete(ctx, snapshot.Name, metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func setConfigSnapshotStatus(isvc *v1beta1.InferenceService, component v1beta1.ComponentType, name string) {
+	if isvc.Status.Components == nil {
+		isvc.Status.Components = make(map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec)
+	}
+	statusSpec := isvc.Status.Components[component]
+	statusSpec.ConfigSnapshot = name
+	isvc.Status.Components[component] = statusSpec
+}
+
+// withoutLastAppliedConfiguration drops the copy of the whole object kubectl keeps in the annotations
+func withoutLastAppliedConfiguration(annotations map[string]string) map[string]string {
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; !ok {
+		return annotations
+	}
+	filtered := make(map[string]string, len(annotations)-1)
+	for key, value := range annotations {
+		if key != corev1.LastAppliedConfigAnnotation {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func newInferenceService() *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "default",
+			UID:         "isvc-uid",
+			Generation:  2,
+			Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "team": "ml"},
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "sklearn"},
+				},
+			},
+		},
+		Status: v1beta1.InferenceServiceStatus{
+			ServingRuntimeName: "kserve-sklearnserver",
+		},
+	}
+}
+
+var isvcConfigMap = &corev1.ConfigMap{
+	Data: map[string]string{
+		v1beta1.DeployConfigName: `{"defaultDeploymentMode": "Serverless"}`,
+		"_example":               "# examples",
+	},
+}
+
+func newSnapshot(name string, created time.Time, isvc *v1beta1.InferenceService) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "sklearn",
+				constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+				constants.ConfigSnapshotRevisionLabel: name,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       "InferenceService",
+				Name:       isvc.Name,
+				UID:        isvc.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+}
+
+func TestConfigSnapshotKnative(t *testing.T) {
+	scheme := newScheme(t)
+	servingRuntime := &v1alpha1.ServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver", Namespace: "default"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:v0.15.0"}},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(servingRuntime).Build()
+	isvc := newInferenceService()
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestCreatedRevision: "sklearn-predictor-00002"},
+	}
+	now := time.Now()
+	clientset := fakeclientset.NewSimpleClientset(
+		newSnapshot(constants.ConfigSnapshotName("sklearn-predictor-00000"), now.Add(-2*time.Hour), isvc),
+		newSnapshot(constants.ConfigSnapshotName("sklearn-predictor-00001"), now.Add(-time.Hour), isvc),
+	)
+	config := &v1beta1.ConfigSnapshotConfig{Enabled: true, Retention: 2}
+	reconciler := NewConfigSnapshotReconciler(cl, clientset, scheme, config, isvcConfigMap)
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Knative))
+	assert.Equal(t, "sklearn-predictor-00002-config", isvc.Status.Components[v1beta1.PredictorComponent].ConfigSnapshot)
+
+	snapshot, err := clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "sklearn-predictor-00002-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, *snapshot.Immutable)
+	assert.Equal(t, "sklearn-predictor-00002", snapshot.Labels[constants.ConfigSnapshotRevisionLabel])
+	assert.True(t, metav1.IsControlledBy(snapshot, isvc))
+	assert.Equal(t, "sklearn-predictor-00002", snapshot.Data[RevisionKey])
+	assert.Equal(t, string(constants.Knative), snapshot.Data[DeploymentModeKey])
+
+	isvcSnapshot := inferenceServiceSnapshot{}
+	require.NoError(t, json.Unmarshal([]byte(snapshot.Data[InferenceServiceKey]), &isvcSnapshot))
+	assert.Equal(t, int64(2), isvcSnapshot.Generation)
+	assert.Equal(t, map[string]string{"team": "ml"}, isvcSnapshot.Annotations)
+	assert.Equal(t, isvc.Spec, isvcSnapshot.Spec)
+
+	runtimeSnapshot := servingRuntimeSnapshot{}
+	require.NoError(t, json.Unmarshal([]byte(snapshot.Data[ServingRuntimeKey]), &runtimeSnapshot))
+	assert.Equal(t, "ServingRuntime", runtimeSnapshot.Kind)
+	assert.Equal(t, "kserve/sklearnserver:v0.15.0", runtimeSnapshot.Spec.Containers[0].Image)
+
+	resolvedConfig := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(snapshot.Data[InferenceServiceConfigMapKey]), &resolvedConfig))
+	assert.Equal(t, map[string]string{v1beta1.DeployConfigName: `{"defaultDeploymentMode": "Serverless"}`}, resolvedConfig)
+
+	// the snapshot of the oldest revision is beyond the retention
+	snapshots, err := clientset.CoreV1().ConfigMaps("default").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, item := range snapshots.Items {
+		names = append(names, item.Name)
+	}
+	assert.ElementsMatch(t, []string{"sklearn-predictor-00001-config", "sklearn-predictor-00002-config"}, names)
+
+	// the snapshot of a revision is not changed once taken
+	isvc.Annotations["team"] = "platform"
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Knative))
+	snapshot, err = clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "sklearn-predictor-00002-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, snapshot.Data[InferenceServiceKey], `"team": "ml"`)
+}
+
+func TestConfigSnapshotStandard(t *testing.T) {
+	scheme := newScheme(t)
+	isvc := newInferenceService()
+	isvc.Status.ServingRuntimeName = ""
+	isvc.Status.ClusterServingRuntimeName = "kserve-sklearnserver"
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   "default",
+			UID:         "deployment-uid",
+			Annotations: map[string]string{utils.DeploymentRevisionAnnotation: "7"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sklearn-predictor"}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor-5d8f7b9c6",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "sklearn-predictor"},
+			Annotations: map[string]string{utils.DeploymentRevisionAnnotation: "7"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+				UID:        deployment.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sklearn-predictor"}},
+		},
+	}
+	servingRuntime := &v1alpha1.ClusterServingRuntime{ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment, replicaSet, servingRuntime).Build()
+	clientset := fakeclientset.NewSimpleClientset()
+	config := &v1beta1.ConfigSnapshotConfig{Enabled: true, Retention: v1beta1.DefaultConfigSnapshotRetention}
+
+	require.NoError(t, NewConfigSnapshotReconciler(cl, clientset, scheme, config, isvcConfigMap).
+		Reconcile(context.Background(), isvc, constants.Standard))
+	assert.Equal(t, "sklearn-predictor-5d8f7b9c6-config", isvc.Status.Components[v1beta1.PredictorComponent].ConfigSnapshot)
+	snapshot, err := clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "sklearn-predictor-5d8f7b9c6-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "7", snapshot.Data[DeploymentRevisionKey])
+	assert.Contains(t, snapshot.Data[ServingRuntimeKey], `"kind": "ClusterServingRuntime"`)
+}
+
+func TestConfigSnapshotDisabled(t *testing.T) {
+	scheme := newScheme(t)
+	isvc := newInferenceService()
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestCreatedRevision: "sklearn-predictor-00001"},
+	}
+	clientset := fakeclientset.NewSimpleClientset()
+	reconciler := NewConfigSnapshotReconciler(fake.NewClientBuilder().WithScheme(scheme).Build(), clientset, scheme,
+		&v1beta1.ConfigSnapshotConfig{}, isvcConfigMap)
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Knative))
+	assert.Empty(t, isvc.Status.Components[v1beta1.PredictorComponent].ConfigSnapshot)
+	snapshots, err := clientset.CoreV1().ConfigMaps("default").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, snapshots.Items)
+}
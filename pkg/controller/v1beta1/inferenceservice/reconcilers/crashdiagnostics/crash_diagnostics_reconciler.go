@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crashdiagnostics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("CrashDiagnosticsReconciler")
+
+// RuntimeCrashedReason is the reason of the events reporting the crashes of the runtime containers
+const RuntimeCrashedReason = "RuntimeCrashed"
+
+// OOMKilledReason is the termination reason of the containers killed for exceeding their memory limit
+const OOMKilledReason = "OOMKilled"
+
+// MaxCrashLogBytes bounds the size of the logs stored in the InferenceService status, the first lines are dropped
+const MaxCrashLogBytes = 16 * 1024
+
+// xidRegex matches the NVIDIA GPU XID errors the driver logs, e.g. "NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, ..."
+var xidRegex = regexp.MustCompile(`\bXid\b(?: \([^)]*\))?:? (\d+)`)
+
+// CrashDiagnosticsReconciler captures the diagnostics of the last crash of the runtime containers of an
+// InferenceService into its lastCrashInfo status and an event, so that the exit code and the logs of a crashed
+// runtime are available after its pod is garbage collected.
+type CrashDiagnosticsReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	recorder  record.EventRecorder
+	config    *v1beta1.CrashDiagnosticsConfig
+}
+
+func NewCrashDiagnosticsReconciler(client client.Client, clientset kubernetes.Interface, recorder record.EventRecorder,
+	config *v1beta1.CrashDiagnosticsConfig,
+) *CrashDiagnosticsReconciler {
+	return &CrashDiagnosticsReconciler{
+		client:    client,
+		clientset: clientset,
+		recorder:  recorder,
+		config:    config,
+	}
+}
+
+// crash is a termination of a runtime container which was not a clean exit
+type crash struct {
+	pod        *corev1.Pod
+	status     *corev1.ContainerStatus
+	terminated *corev1.ContainerStateTerminated
+	// previous is true when the container restarted since, its logs are then the logs of the previous instance
+	previous bool
+}
+
+// Reconcile records the latest crash of the runtime containers of the InferenceService pods which is newer than the
+// crash already recorded in the status
+func (r *CrashDiagnosticsReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	if !r.config.Enabled {
+		isvc.Status.LastCrashInfo = nil
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+	}); err != nil {
+		return err
+	}
+	latest := latestCrash(pods.Items)
+	if latest == nil {
+		return nil
+	}
+	if last := isvc.Status.LastCrashInfo; last != nil && last.Time != nil && !latest.terminated.FinishedAt.After(last.Time.Time) {
+		return nil
+	}
+
+	crashInfo := &v1beta1.CrashInfo{
+		Component:    v1beta1.ComponentType(latest.pod.Labels[constants.KServiceComponentLabel]),
+		Pod:          latest.pod.Name,
+		Container:    latest.status.Name,
+		Time:         latest.terminated.FinishedAt.DeepCopy(),
+		ExitCode:     latest.terminated.ExitCode,
+		Signal:       latest.terminated.Signal,
+		Reason:       latest.terminated.Reason,
+		OOMKilled:    latest.terminated.Reason == OOMKilledReason,
+		RestartCount: latest.status.RestartCount,
+		Logs:         r.tailLogs(ctx, latest),
+	}
+	crashInfo.GPUXIDErrors = parseXIDErrors(crashInfo.Logs)
+	isvc.Status.LastCrashInfo = crashInfo
+
+	log.Info("Runtime container crashed", "isvc", isvc.Name, "namespace", isvc.Namespace, "pod", crashInfo.Pod,
+		"exitCode", crashInfo.ExitCode, "reason", crashInfo.Reason)
+	r.recorder.Eventf(isvc, corev1.EventTypeWarning, RuntimeCrashedReason, "The %s container of the pod %s crashed: %s",
+		crashInfo.Container, crashInfo.Pod, summary(crashInfo))
+	return nil
+}
+
+// latestCrash returns the latest termination of a runtime container of the pods which is not a clean exit, ignoring
+// the pods being deleted whose containers are stopped on purpose
+func latestCrash(pods []corev1.Pod) *crash {
+	var latest *crash
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for j := range pod.Status.ContainerStatuses {
+			status := &pod.Status.ContainerStatuses[j]
+			if status.Name != constants.InferenceServiceContainerName {
+				continue
+			}
+			terminated, previous := status.State.Terminated, false
+			if terminated == nil {
+				terminated, previous = status.LastTerminationState.Terminated, true
+			}
+			if terminated == nil || (terminated.ExitCode == 0 && terminated.Reason != OOMKilledReason) {
+				continue
+			}
+			if latest == nil || terminated.FinishedAt.After(latest.terminated.FinishedAt.Time) {
+				latest = &crash{pod: pod, status: status, terminated: terminated, previous: previous}
+			}
+		}
+	}
+	return latest
+}
+
+// tailLogs returns the last lines of the logs of the crashed container, or its termination message when the logs
+// cannot be read
+func (r *CrashDiagnosticsReconciler) tailLogs(ctx context.Context, crashed *crash) string {
+	tailLines := int64(r.config.LogLines)
+	logs, err := r.clientset.CoreV1().Pods(crashed.pod.Namespace).GetLogs(crashed.pod.Name, &corev1.PodLogOptions{
+		Container: crashed.status.Name,
+		Previous:  crashed.previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		log.V(1).Info("Failed to read the logs of the crashed container, using its termination message", "pod",
+			crashed.pod.Name, "namespace", crashed.pod.Namespace, "error", err.Error())
+		return truncate(crashed.terminated.Message)
+	}
+	return truncate(string(logs))
+}
+
+// truncate keeps the end of the logs within MaxCrashLogBytes, starting at a line
+func truncate(logs string) string {
+	if len(logs) <= MaxCrashLogBytes {
+		return logs
+	}
+	logs = logs[len(logs)-MaxCrashLogBytes:]
+	if index := strings.IndexByte(logs, '\n'); index >= 0 {
+		logs = logs[index+1:]
+	}
+	return logs
+}
+
+// parseXIDErrors returns the distinct XID error codes reported in the logs, in the order they are first reported
+func parseXIDErrors(logs string) []int32 {
+	var xids []int32
+	for _, match := range xidRegex.FindAllStringSubmatch(logs, -1) {
+		xid, err := strconv.ParseInt(match[1], 10, 32)
+		if err != nil || slices.Contains(xids, int32(xid)) {
+			continue
+		}
+		xids = append(xids, int32(xid))
+	}
+	return xids
+}
+
+func summary(crashInfo *v1beta1.CrashInfo) string {
+	details := []string{fmt.Sprintf("exit code %d", crashInfo.ExitCode)}
+	if crashInfo.Reason != "" {
+		details = append(details, "reason "+crashInfo.Reason)
+	}
+	if crashInfo.Signal != 0 {
+		details = append(details, fmt.Sprintf("signal %d", crashInfo.Signal))
+	}
+	if crashInfo.OOMKilled {
+		details = append(details, "killed for exceeding its memory limit")
+	}
+	if len(crashInfo.GPUXIDErrors) > 0 {
+		xids := make([]string, 0, len(crashInfo.GPUXIDErrors))
+		for _, xid := range crashInfo.GPUXIDErrors {
+			xids = append(xids, strconv.Itoa(int(xid)))
+		}
+		details = append(details, "GPU XID errors "+strings.Join(xids, ", "))
+	}
+	details = append(details, fmt.Sprintf("restart count %d", crashInfo.RestartCount))
+	return strings.Join(details, ", ") + "; the last lines of its logs are in status.lastCrashInfo"
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crashdiagnostics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newPod(name string, status corev1.ContainerStatus) *corev1.Pod {
+	status.Name = constants.InferenceServiceContainerName
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "sklearn",
+				constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+			},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+	}
+}
+
+func newReconciler(t *testing.T, config *v1beta1.CrashDiagnosticsConfig, pods ...*corev1.Pod) (*CrashDiagnosticsReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, pod := range pods {
+		builder = builder.WithObjects(pod)
+	}
+	recorder := record.NewFakeRecorder(10)
+	return NewCrashDiagnosticsReconciler(builder.Build(), fakeclientset.NewSimpleClientset(), recorder, config), recorder
+}
+
+func TestCrashDiagnosticsReconcile(t *testing.T) {
+	config := &v1beta1.CrashDiagnosticsConfig{Enabled: true, LogLines: v1beta1.DefaultCrashLogLines}
+	finished := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	oomKilled := newPod("sklearn-predictor-1", corev1.ContainerStatus{
+		RestartCount: 3,
+		State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode:   137,
+			Reason:     OOMKilledReason,
+			FinishedAt: finished,
+		}},
+	})
+	// an older crash of another replica is not reported
+	older := newPod("sklearn-predictor-2", corev1.ContainerStatus{
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode:   1,
+			Reason:     "Error",
+			FinishedAt: metav1.NewTime(finished.Add(-time.Hour)),
+		}},
+	})
+	reconciler, recorder := newReconciler(t, config, oomKilled, older)
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"}}
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc))
+	crashInfo := isvc.Status.LastCrashInfo
+	require.NotNil(t, crashInfo)
+	assert.Equal(t, v1beta1.PredictorComponent, crashInfo.Component)
+	assert.Equal(t, "sklearn-predictor-1", crashInfo.Pod)
+	assert.Equal(t, constants.InferenceServiceContainerName, crashInfo.Container)
+	assert.Equal(t, int32(137), crashInfo.ExitCode)
+	assert.True(t, crashInfo.OOMKilled)
+	assert.Equal(t, int32(3), crashInfo.RestartCount)
+	assert.True(t, finished.Equal(crashInfo.Time))
+	assert.Equal(t, "fake logs", crashInfo.Logs)
+	event := <-recorder.Events
+	assert.Contains(t, event, "Warning RuntimeCrashed The kserve-container container of the pod sklearn-predictor-1 crashed: exit code 137")
+	assert.Contains(t, event, "killed for exceeding its memory limit")
+
+	// the crash is reported once
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc))
+	assert.Empty(t, recorder.Events)
+
+	// the crash diagnostics are cleared once disabled
+	reconciler.config = &v1beta1.CrashDiagnosticsConfig{}
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc))
+	assert.Nil(t, isvc.Status.LastCrashInfo)
+}
+
+func TestCrashDiagnosticsIgnoresCleanExits(t *testing.T) {
+	config := &v1beta1.CrashDiagnosticsConfig{Enabled: true, LogLines: v1beta1.DefaultCrashLogLines}
+	completed := newPod("sklearn-predictor-1", corev1.ContainerStatus{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+	})
+	deleting := newPod("sklearn-predictor-2", corev1.ContainerStatus{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 143, Reason: "Error"}},
+	})
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleting.Finalizers = []string{"test"}
+	reconciler, recorder := newReconciler(t, config, completed, deleting)
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"}}
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc))
+	assert.Nil(t, isvc.Status.LastCrashInfo)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestParseXIDErrors(t *testing.T) {
+	logs := strings.Join([]string{
+		"NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, GPU has fallen off the bus.",
+		"NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, An uncorrectable double bit error",
+		"NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, GPU has fallen off the bus.",
+		"RuntimeError: CUDA error: an illegal memory access was encountered",
+	}, "\n")
+	assert.Equal(t, []int32{79, 48}, parseXIDErrors(logs))
+	assert.Empty(t, parseXIDErrors("Traceback (most recent call last):"))
+}
+
+func TestTruncate(t *testing.T) {
+	line := strings.Repeat("x", 1023) + "\n"
+	logs := strings.Repeat(line, 20)
+	truncated := truncate(logs)
+	assert.LessOrEqual(t, len(truncated), MaxCrashLogBytes)
+	assert.True(t, strings.HasPrefix(truncated, "x"))
+	assert.True(t, strings.HasSuffix(logs, truncated))
+	assert.Equal(t, "short", truncate("short"))
+}
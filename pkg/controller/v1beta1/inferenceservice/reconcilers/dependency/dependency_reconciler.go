@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+var log = logf.Log.WithName("DependencyReconciler")
+
+// Dependency condition reasons
+const (
+	DependencyNotFoundReason = "DependencyNotFound"
+	DependencyNotReadyReason = "DependencyNotReady"
+)
+
+// DependencyRetryInterval is the interval at which the dependencies of a blocked InferenceService are checked again,
+// as the Secrets, ConfigMaps and PersistentVolumeClaims are not watched
+const DependencyRetryInterval = 30 * time.Second
+
+// DependencyReconciler checks the resources an InferenceService depends on before the pods of its components are
+// created, so that a missing mount blocks the InferenceService instead of crash looping its pods.
+type DependencyReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+func NewDependencyReconciler(client client.Client, clientset kubernetes.Interface) *DependencyReconciler {
+	return &DependencyReconciler{
+		client:    client,
+		clientset: clientset,
+	}
+}
+
+// Reconcile sets the DependenciesReady condition. When a dependency is missing or not ready the condition is set to
+// false with the blocking dependencies, and the dependencies are checked again after DependencyRetryInterval.
+func (r *DependencyReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	if len(isvc.Spec.DependsOn) == 0 {
+		isvc.Status.ClearCondition(v1beta1.DependenciesReady)
+		return ctrl.Result{}, nil
+	}
+
+	var missing, notReady []string
+	for _, dependency := range isvc.Spec.DependsOn {
+		found, ready, err := r.checkDependency(ctx, isvc.Namespace, dependency)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		switch {
+		case !found:
+			missing = append(missing, fmt.Sprintf("%s %q", dependency.Kind, dependency.Name))
+		case !ready:
+			notReady = append(notReady, fmt.Sprintf("%s %q", dependency.Kind, dependency.Name))
+		}
+	}
+	if len(missing) == 0 && len(notReady) == 0 {
+		isvc.Status.SetCondition(v1beta1.DependenciesReady, &apis.Condition{
+			Type:   v1beta1.DependenciesReady,
+			Status: corev1.ConditionTrue,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	reason := DependencyNotReadyReason
+	var blockers []string
+	if len(missing) > 0 {
+		reason = DependencyNotFoundReason
+		blockers = append(blockers, "missing "+strings.Join(missing, ", "))
+	}
+	if len(notReady) > 0 {
+		blockers = append(blockers, "not ready "+strings.Join(notReady, ", "))
+	}
+	message := "blocked on the dependencies: " + strings.Join(blockers, "; ")
+	log.Info("InferenceService blocked on its dependencies", "isvc", isvc.Name, "namespace", isvc.Namespace, "reason", message)
+	isvc.Status.SetCondition(v1beta1.DependenciesReady, &apis.Condition{
+		Type:    v1beta1.DependenciesReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	return ctrl.Result{RequeueAfter: DependencyRetryInterval}, nil
+}
+
+// checkDependency returns whether a dependency exists and whether it is ready
+func (r *DependencyReconciler) checkDependency(ctx context.Context, namespace string, dependency v1beta1.DependencyReference) (bool, bool, error) {
+	var err error
+	ready := true
+	switch dependency.Kind {
+	case v1beta1.SecretDependency:
+		_, err = r.clientset.CoreV1().Secrets(namespace).Get(ctx, dependency.Name, metav1.GetOptions{})
+	case v1beta1.ConfigMapDependency:
+		_, err = r.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, dependency.Name, metav1.GetOptions{})
+	case v1beta1.PersistentVolumeClaimDependency:
+		var pvc *corev1.PersistentVolumeClaim
+		if pvc, err = r.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, dependency.Name, metav1.GetOptions{}); err == nil {
+			ready = pvc.Status.Phase == corev1.ClaimBound
+		}
+	case v1beta1.InferenceServiceDependency:
+		isvc := &v1beta1.InferenceService{}
+		if err = r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dependency.Name}, isvc); err == nil {
+			ready = isvc.Status.IsReady()
+		}
+	default:
+		return false, false, fmt.Errorf("unsupported dependency kind %q", dependency.Kind)
+	}
+	if apierr.IsNotFound(err) {
+		return false, false, nil
+	}
+	return err == nil, ready, err
+}
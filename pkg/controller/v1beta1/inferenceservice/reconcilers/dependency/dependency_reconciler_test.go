@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+const namespace = "default"
+
+func makeInferenceService(name string, ready bool, dependsOn ...v1beta1.DependencyReference) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1beta1.InferenceServiceSpec{DependsOn: dependsOn},
+	}
+	isvc.Status.InitializeConditions()
+	if ready {
+		for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.IngressReady} {
+			isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+		}
+	}
+	return isvc
+}
+
+func makePersistentVolumeClaim(phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "models", Namespace: namespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestDependencyReconcile(t *testing.T) {
+	dependsOn := []v1beta1.DependencyReference{
+		{Kind: v1beta1.SecretDependency, Name: "storage-config"},
+		{Kind: v1beta1.ConfigMapDependency, Name: "tokenizer"},
+		{Kind: v1beta1.PersistentVolumeClaimDependency, Name: "models"},
+		{Kind: v1beta1.InferenceServiceDependency, Name: "embeddings"},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "storage-config", Namespace: namespace}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tokenizer", Namespace: namespace}}
+
+	scenarios := map[string]struct {
+		dependsOn       []v1beta1.DependencyReference
+		coreObjects     []runtime.Object
+		isvcs           []*v1beta1.InferenceService
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		"NoDependencies": {},
+		"DependenciesReady": {
+			dependsOn:      dependsOn,
+			coreObjects:    []runtime.Object{secret, configMap, makePersistentVolumeClaim(corev1.ClaimBound)},
+			isvcs:          []*v1beta1.InferenceService{makeInferenceService("embeddings", true)},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		"MissingDependencies": {
+			dependsOn:       dependsOn,
+			coreObjects:     []runtime.Object{configMap, makePersistentVolumeClaim(corev1.ClaimPending)},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  DependencyNotFoundReason,
+			expectedMessage: `blocked on the dependencies: missing Secret "storage-config", InferenceService "embeddings"; not ready PersistentVolumeClaim "models"`,
+		},
+		"DependenciesNotReady": {
+			dependsOn:       dependsOn,
+			coreObjects:     []runtime.Object{secret, configMap, makePersistentVolumeClaim(corev1.ClaimBound)},
+			isvcs:           []*v1beta1.InferenceService{makeInferenceService("embeddings", false)},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  DependencyNotReadyReason,
+			expectedMessage: `blocked on the dependencies: not ready InferenceService "embeddings"`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			builder := fakeclient.NewClientBuilder().WithScheme(s)
+			for _, isvc := range scenario.isvcs {
+				builder = builder.WithObjects(isvc).WithStatusSubresource(isvc)
+			}
+			reconciler := NewDependencyReconciler(builder.Build(), fake.NewSimpleClientset(scenario.coreObjects...))
+
+			isvc := makeInferenceService("sklearn", false, scenario.dependsOn...)
+			result, err := reconciler.Reconcile(t.Context(), isvc)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			condition := isvc.Status.GetCondition(v1beta1.DependenciesReady)
+			if scenario.expectedStatus == "" {
+				g.Expect(condition).To(gomega.BeNil())
+				g.Expect(result.RequeueAfter).To(gomega.BeZero())
+				return
+			}
+			g.Expect(condition.Status).To(gomega.Equal(scenario.expectedStatus))
+			g.Expect(condition.Reason).To(gomega.Equal(scenario.expectedReason))
+			g.Expect(condition.Message).To(gomega.Equal(scenario.expectedMessage))
+			if scenario.expectedStatus == corev1.ConditionFalse {
+				g.Expect(result.RequeueAfter).To(gomega.Equal(DependencyRetryInterval))
+			} else {
+				g.Expect(result.RequeueAfter).To(gomega.BeZero())
+			}
+		})
+	}
+}
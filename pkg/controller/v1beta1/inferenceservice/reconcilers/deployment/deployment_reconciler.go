@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +51,8 @@ type DeploymentReconciler struct {
 	scheme         *runtime.Scheme
 	DeploymentList []*appsv1.Deployment
 	componentExt   *v1beta1.ComponentExtensionSpec
+	// kueueAdmitted is whether the Kueue workload of the deployments is admitted, nil when not managed by Kueue
+	kueueAdmitted *bool
 }
 
 func NewDeploymentReconciler(client kclient.Client,
@@ -158,7 +161,8 @@ func createRawDefaultDeployment(componentMeta metav1.ObjectMeta,
 			},
 		},
 	}
-	if componentExt != nil && componentExt.DeploymentStrategy != nil {
+	// The blue-green strategy is carried out by the controller, which rolls out the deployments with the default strategy
+	if componentExt != nil && componentExt.DeploymentStrategy != nil && !componentExt.IsBlueGreen() {
 		// User-specified deployment strategy takes precedence
 		deployment.Spec.Strategy = *componentExt.DeploymentStrategy
 	} else {
@@ -196,7 +200,7 @@ func createRawWorkerDeployment(componentMeta metav1.ObjectMeta,
 			},
 		},
 	}
-	if componentExt != nil && componentExt.DeploymentStrategy != nil {
+	if componentExt != nil && componentExt.DeploymentStrategy != nil && !componentExt.IsBlueGreen() {
 		// User-specified deployment strategy takes precedence
 		deployment.Spec.Strategy = *componentExt.DeploymentStrategy
 	} else {
@@ -218,6 +222,33 @@ func createRawWorkerDeployment(componentMeta metav1.ObjectMeta,
 	return deployment
 }
 
+// SetKueueAdmission sets whether the Kueue workload of the deployments is admitted. The deployments are held at zero
+// replicas until admitted, which also stops the HPA from scaling them, and the replicas of the held deployments are
+// restored once admitted.
+func (r *DeploymentReconciler) SetKueueAdmission(admitted bool) {
+	r.kueueAdmitted = &admitted
+	if admitted {
+		return
+	}
+	for _, deployment := range r.DeploymentList {
+		deployment.Spec.Replicas = ptr.To(int32(0))
+		// The annotations of the deployment are shared with the pod template
+		deployment.Annotations = utils.Union(deployment.Annotations, map[string]string{
+			constants.KueueAdmissionHeldAnnotationKey: "true",
+		})
+	}
+}
+
+// isHeld returns whether the deployments are held at zero replicas until admitted by Kueue
+func (r *DeploymentReconciler) isHeld() bool {
+	return r.kueueAdmitted != nil && !*r.kueueAdmitted
+}
+
+// isReleased returns whether the existing deployment was held at zero replicas and can now be scaled up
+func (r *DeploymentReconciler) isReleased(existingDeployment *appsv1.Deployment) bool {
+	return existingDeployment.Annotations[constants.KueueAdmissionHeldAnnotationKey] == "true" && !r.isHeld()
+}
+
 // checkDeploymentExist checks if the deployment exists?
 func (r *DeploymentReconciler) checkDeploymentExist(ctx context.Context, client kclient.Client, deployment *appsv1.Deployment) (constants.CheckResultType, *appsv1.Deployment, error) {
 	forceStopRuntime := utils.GetForceStopRuntime(deployment)
@@ -247,13 +278,27 @@ func (r *DeploymentReconciler) checkDeploymentExist(ctx context.Context, client
 		}
 	}
 
+	// The selector of a deployment is immutable, the deployment is recreated when the capacity type is added to or
+	// removed from its selector
+	if !equality.Semantic.DeepEqual(deployment.Spec.Selector, existingDeployment.Spec.Selector) {
+		ctrl := metav1.GetControllerOf(deployment)
+		existingCtrl := metav1.GetControllerOf(existingDeployment)
+		if ctrl != nil && existingCtrl != nil && ctrl.UID == existingCtrl.UID {
+			return constants.CheckResultDelete, existingDeployment, nil
+		}
+	}
+
 	// existed, check equivalence
 	// for HPA scaling, we should ignore Replicas of Deployment
 	// for none scaler, we should not ignore Replicas.
 	var ignoreFields cmp.Option = nil // Initialize to nil by default
 
-	// Set ignoreFields if the condition is met
-	if existingDeployment.Annotations[constants.AutoscalerClass] != string(constants.AutoscalerClassNone) {
+	// Set ignoreFields if the condition is met. The replicas held for the admission by Kueue are not ignored.
+	if r.isReleased(existingDeployment) && deployment.Spec.Replicas == nil {
+		deployment.Spec.Replicas = ptr.To(r.initialReplicas())
+	}
+	if existingDeployment.Annotations[constants.AutoscalerClass] != string(constants.AutoscalerClassNone) &&
+		!r.isHeld() && !r.isReleased(existingDeployment) {
 		ignoreFields = cmpopts.IgnoreFields(appsv1.DeploymentSpec{}, "Replicas")
 	}
 
@@ -272,6 +317,87 @@ func (r *DeploymentReconciler) checkDeploymentExist(ctx context.Context, client
 	return constants.CheckResultExisted, existingDeployment, nil
 }
 
+// initialReplicas returns the replicas a deployment released by Kueue is scaled up to, before the HPA takes over
+func (r *DeploymentReconciler) initialReplicas() int32 {
+	if r.componentExt != nil && r.componentExt.MinReplicas != nil && *r.componentExt.MinReplicas > 0 {
+		return *r.componentExt.MinReplicas
+	}
+	return 1
+}
+
+// SetCapacity splits the replicas between spot and on-demand nodes. The default deployment, scaled by the autoscaler,
+// is placed on the spot nodes, while an on-demand deployment runs the floor of on-demand replicas along with the
+// rebalanced replicas replacing the spot replicas which are preempted or cannot be scheduled. The pods of both
+// deployments share the app label, so that they are selected by the same service, and each deployment also selects
+// the capacity type so that it does not adopt the pods of the other.
+func (r *DeploymentReconciler) SetCapacity(capacity *v1beta1.CapacitySpec, capacityConfig *v1beta1.CapacityConfig, rebalancedReplicas int32) {
+	spotDeployment := r.DeploymentList[0]
+	onDemandDeployment := spotDeployment.DeepCopy()
+
+	spotNodeSelector := capacityConfig.SpotNodeSelector
+	if len(capacity.SpotNodeSelector) > 0 {
+		spotNodeSelector = capacity.SpotNodeSelector
+	}
+	spotTolerations := capacityConfig.SpotTolerations
+	if len(capacity.SpotTolerations) > 0 {
+		spotTolerations = capacity.SpotTolerations
+	}
+	onDemandNodeSelector := capacityConfig.OnDemandNodeSelector
+	if len(capacity.OnDemandNodeSelector) > 0 {
+		onDemandNodeSelector = capacity.OnDemandNodeSelector
+	}
+
+	// The labels of the pod template are shared with the deployment
+	spotDeployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			constants.RawDeploymentAppLabel: constants.GetRawServiceLabel(spotDeployment.Name),
+			constants.CapacityTypeLabelKey:  constants.CapacityTypeSpot,
+		},
+	}
+	spotTemplate := &spotDeployment.Spec.Template
+	spotTemplate.Labels = utils.Union(spotTemplate.Labels, spotDeployment.Spec.Selector.MatchLabels)
+	spotTemplate.Spec.NodeSelector = utils.Union(spotTemplate.Spec.NodeSelector, spotNodeSelector)
+	spotTemplate.Spec.Tolerations = append(spotTemplate.Spec.Tolerations, spotTolerations...)
+
+	onDemandDeployment.Name = constants.OnDemandDeploymentName(spotDeployment.Name)
+	// The replicas of the on-demand deployment are set by the controller instead of the autoscaler
+	onDemandDeployment.Annotations = utils.Union(onDemandDeployment.Annotations, map[string]string{
+		constants.AutoscalerClass: string(constants.AutoscalerClassNone),
+	})
+	onDemandDeployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			constants.RawDeploymentAppLabel: constants.GetRawServiceLabel(spotDeployment.Name),
+			constants.CapacityTypeLabelKey:  constants.CapacityTypeOnDemand,
+		},
+	}
+	onDemandTemplate := &onDemandDeployment.Spec.Template
+	onDemandTemplate.Labels = utils.Union(onDemandTemplate.Labels, onDemandDeployment.Spec.Selector.MatchLabels)
+	onDemandTemplate.Spec.NodeSelector = utils.Union(onDemandTemplate.Spec.NodeSelector, onDemandNodeSelector)
+	onDemandDeployment.Spec.Replicas = ptr.To(capacity.GetOnDemandReplicas() + rebalancedReplicas)
+	r.DeploymentList = append(r.DeploymentList, onDemandDeployment)
+}
+
+// DeleteOnDemandDeployment deletes the on-demand deployment left over once the capacity of the default deployment
+// is no longer split between spot and on-demand nodes
+func (r *DeploymentReconciler) DeleteOnDemandDeployment(ctx context.Context) error {
+	defaultDeployment := r.DeploymentList[0]
+	existingDeployment := &appsv1.Deployment{}
+	err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: defaultDeployment.Namespace,
+		Name:      constants.OnDemandDeploymentName(defaultDeployment.Name),
+	}, existingDeployment)
+	if err != nil {
+		return kclient.IgnoreNotFound(err)
+	}
+	ctrl := metav1.GetControllerOf(defaultDeployment)
+	existingCtrl := metav1.GetControllerOf(existingDeployment)
+	if ctrl == nil || existingCtrl == nil || ctrl.UID != existingCtrl.UID || existingDeployment.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	log.Info("Deleting on-demand deployment", "namespace", existingDeployment.Namespace, "name", existingDeployment.Name)
+	return kclient.IgnoreNotFound(r.client.Delete(ctx, existingDeployment))
+}
+
 func setDefaultPodSpec(podSpec *corev1.PodSpec) {
 	if podSpec.DNSPolicy == "" {
 		podSpec.DNSPolicy = corev1.DNSClusterFirst
@@ -466,8 +592,9 @@ func (r *DeploymentReconciler) Reconcile(ctx context.Context) ([]*appsv1.Deploym
 
 			// To avoid the conflict between HPA and Deployment,
 			// we need to remove the Replicas field from the deployment spec
-			// For none autoscaler, it should not remove replicas
-			if modDeployment.Annotations[constants.AutoscalerClass] != string(constants.AutoscalerClassNone) {
+			// For none autoscaler, and for the replicas held for the admission by Kueue, it should not remove replicas
+			if modDeployment.Annotations[constants.AutoscalerClass] != string(constants.AutoscalerClassNone) &&
+				!r.isHeld() && !r.isReleased(existingDep) {
 				modDeployment.Spec.Replicas = nil
 				curDeployment.Spec.Replicas = nil
 			}
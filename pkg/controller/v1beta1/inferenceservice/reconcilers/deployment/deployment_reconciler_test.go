@@ -29,8 +29,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -1440,3 +1443,146 @@ func TestCreateRawDeploymentWithPrecedence(t *testing.T) {
 		})
 	}
 }
+
+func TestKueueAdmission(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, appsv1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).Build()
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(int32(2))}
+
+	reconcile := func(admitted *bool) *appsv1.Deployment {
+		r, err := NewDeploymentReconciler(client, s, metav1.ObjectMeta{
+			Name:        "test-predictor",
+			Namespace:   "test-ns",
+			Labels:      map[string]string{constants.KueueQueueNameLabelKey: "inference"},
+			Annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassHPA)},
+		}, metav1.ObjectMeta{}, componentExt, &corev1.PodSpec{
+			Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "test-image"}},
+		}, nil, nil)
+		assert.NoError(t, err)
+		if admitted != nil {
+			r.SetKueueAdmission(*admitted)
+		}
+		_, err = r.Reconcile(t.Context())
+		assert.NoError(t, err)
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor"}, deployment))
+		return deployment
+	}
+
+	// Held at zero replicas until admitted
+	deployment := reconcile(ptr.To(false))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	assert.Equal(t, "true", deployment.Annotations[constants.KueueAdmissionHeldAnnotationKey])
+	assert.NotContains(t, deployment.Spec.Template.Annotations, constants.KueueAdmissionHeldAnnotationKey)
+	deployment = reconcile(ptr.To(false))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	// Released to the minimum replicas once admitted
+	deployment = reconcile(ptr.To(true))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Annotations, constants.KueueAdmissionHeldAnnotationKey)
+
+	// Then scaled by the HPA
+	deployment.Spec.Replicas = ptr.To(int32(5))
+	assert.NoError(t, client.Update(t.Context(), deployment))
+	deployment = reconcile(ptr.To(true))
+	assert.Equal(t, int32(5), *deployment.Spec.Replicas)
+
+	// Held again when evicted, and released when no longer managed by Kueue
+	deployment = reconcile(ptr.To(false))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	deployment = reconcile(nil)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Annotations, constants.KueueAdmissionHeldAnnotationKey)
+}
+
+func TestSetCapacity(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, appsv1.AddToScheme(s))
+	client := fake.NewClientBuilder().WithScheme(s).Build()
+	capacityConfig := &v1beta1.CapacityConfig{
+		SpotNodeSelector:     map[string]string{v1beta1.DefaultCapacityTypeNodeLabel: "spot"},
+		SpotTolerations:      []corev1.Toleration{{Key: "spot", Operator: corev1.TolerationOpExists}},
+		OnDemandNodeSelector: map[string]string{v1beta1.DefaultCapacityTypeNodeLabel: "on-demand"},
+	}
+
+	newReconciler := func() *DeploymentReconciler {
+		r, err := NewDeploymentReconciler(client, s, metav1.ObjectMeta{
+			Name:        "test-predictor",
+			Namespace:   "test-ns",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassHPA)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "serving.kserve.io/v1beta1",
+				Kind:       "InferenceService",
+				Name:       "test",
+				UID:        "test-uid",
+				Controller: ptr.To(true),
+			}},
+		}, metav1.ObjectMeta{}, &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(int32(2))}, &corev1.PodSpec{
+			Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: "test-image"}},
+		}, nil, nil)
+		assert.NoError(t, err)
+		return r
+	}
+
+	r := newReconciler()
+	_, err := r.Reconcile(t.Context())
+	assert.NoError(t, err)
+
+	// The default deployment is recreated as its selector changes to the spot capacity type
+	r = newReconciler()
+	r.SetCapacity(&v1beta1.CapacitySpec{OnDemandReplicas: ptr.To(int32(2))}, capacityConfig, 1)
+	_, err = r.Reconcile(t.Context())
+	assert.NoError(t, err)
+	spotDeployment := &appsv1.Deployment{}
+	err = client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor"}, spotDeployment)
+	assert.True(t, errors.IsNotFound(err))
+
+	r = newReconciler()
+	r.SetCapacity(&v1beta1.CapacitySpec{OnDemandReplicas: ptr.To(int32(2))}, capacityConfig, 1)
+	deployments, err := r.Reconcile(t.Context())
+	assert.NoError(t, err)
+	assert.Len(t, deployments, 2)
+
+	assert.NoError(t, client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor"}, spotDeployment))
+	assert.Equal(t, map[string]string{
+		constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("test-predictor"),
+		constants.CapacityTypeLabelKey:  constants.CapacityTypeSpot,
+	}, spotDeployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, constants.CapacityTypeSpot, spotDeployment.Spec.Template.Labels[constants.CapacityTypeLabelKey])
+	assert.NotContains(t, spotDeployment.Labels, constants.CapacityTypeLabelKey)
+	assert.Equal(t, capacityConfig.SpotNodeSelector, spotDeployment.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, capacityConfig.SpotTolerations, spotDeployment.Spec.Template.Spec.Tolerations)
+
+	onDemandDeployment := &appsv1.Deployment{}
+	assert.NoError(t, client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor-on-demand"}, onDemandDeployment))
+	assert.Equal(t, int32(3), *onDemandDeployment.Spec.Replicas)
+	assert.Equal(t, map[string]string{
+		constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("test-predictor"),
+		constants.CapacityTypeLabelKey:  constants.CapacityTypeOnDemand,
+	}, onDemandDeployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, constants.GetRawServiceLabel("test-predictor"), onDemandDeployment.Spec.Template.Labels[constants.RawDeploymentAppLabel])
+	assert.Equal(t, capacityConfig.OnDemandNodeSelector, onDemandDeployment.Spec.Template.Spec.NodeSelector)
+	assert.Empty(t, onDemandDeployment.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, types.UID("test-uid"), metav1.GetControllerOf(onDemandDeployment).UID)
+
+	// The on-demand replicas follow the rebalanced replicas, and the spec overrides the configuration
+	r = newReconciler()
+	r.SetCapacity(&v1beta1.CapacitySpec{
+		OnDemandNodeSelector: map[string]string{"pool": "reserved"},
+	}, capacityConfig, 0)
+	_, err = r.Reconcile(t.Context())
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor-on-demand"}, onDemandDeployment))
+	assert.Equal(t, int32(1), *onDemandDeployment.Spec.Replicas)
+	assert.Equal(t, map[string]string{"pool": "reserved"}, onDemandDeployment.Spec.Template.Spec.NodeSelector)
+
+	// The on-demand deployment is deleted once the capacity is removed
+	r = newReconciler()
+	assert.NoError(t, r.DeleteOnDemandDeployment(t.Context()))
+	err = client.Get(t.Context(), types.NamespacedName{Namespace: "test-ns", Name: "test-predictor-on-demand"}, onDemandDeployment)
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.DeleteOnDemandDeployment(t.Context()))
+}
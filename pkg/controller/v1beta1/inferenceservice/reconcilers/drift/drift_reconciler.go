@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+var log = logf.Log.WithName("DriftReconciler")
+
+// Drift condition and event reasons
+const (
+	DriftDetectedReason    = "DriftDetected"
+	NoDriftDetectedReason  = "NoDriftDetected"
+	DriftNotReportedReason = "DriftNotReported"
+	DriftCheckFailedReason = "DriftCheckFailed"
+)
+
+// DriftQuerier queries the drift reported by the detector of an InferenceService over an interval, positive once
+// drifted, and whether the detector reported it
+type DriftQuerier interface {
+	QueryDrift(ctx context.Context, isvc *v1beta1.InferenceService, interval time.Duration) (float64, bool, error)
+}
+
+// DriftReconciler surfaces the drift reported by the detector of the predictor monitoring as the DriftDetected
+// condition, so that the drift of the inputs of a model is visible on the InferenceService without a dashboard.
+type DriftReconciler struct {
+	recorder record.EventRecorder
+	config   *v1beta1.MonitoringConfig
+	querier  DriftQuerier
+}
+
+// NewDriftReconciler returns a reconciler querying the drift from Prometheus, the DriftDetected condition is not set
+// without a Prometheus querier
+func NewDriftReconciler(recorder record.EventRecorder, config *v1beta1.MonitoringConfig, querier *prometheus.Querier) *DriftReconciler {
+	reconciler := &DriftReconciler{
+		recorder: recorder,
+		config:   config,
+	}
+	if querier != nil {
+		reconciler.querier = NewPrometheusDriftQuerier(querier, config.DriftQuery)
+	}
+	return reconciler
+}
+
+// Reconcile sets the DriftDetected condition of the InferenceServices whose predictor is monitored by a drift
+// detector, and checks the drift again after the check interval. The condition is informational and does not affect
+// the readiness of the InferenceService.
+func (r *DriftReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	monitoring := isvc.Spec.Predictor.Monitoring
+	if monitoring == nil || monitoring.GetType() != v1beta1.DriftDetector || r.querier == nil {
+		isvc.Status.ClearCondition(v1beta1.DriftDetected)
+		return ctrl.Result{}, nil
+	}
+
+	drift, reported, err := r.querier.QueryDrift(ctx, isvc, r.config.CheckIntervalDuration)
+	switch {
+	case err != nil:
+		log.Error(err, "Failed to query the drift of the InferenceService", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		r.recorder.Eventf(isvc, corev1.EventTypeWarning, DriftCheckFailedReason, "Failed to query the drift: %v", err)
+		isvc.Status.SetCondition(v1beta1.DriftDetected, &apis.Condition{
+			Type:    v1beta1.DriftDetected,
+			Status:  corev1.ConditionUnknown,
+			Reason:  DriftCheckFailedReason,
+			Message: "the drift reported by the detector could not be queried",
+		})
+	case !reported:
+		isvc.Status.SetCondition(v1beta1.DriftDetected, &apis.Condition{
+			Type:    v1beta1.DriftDetected,
+			Status:  corev1.ConditionUnknown,
+			Reason:  DriftNotReportedReason,
+			Message: "the detector has not reported the drift of the predictor inputs yet",
+		})
+	case drift > 0:
+		if condition := isvc.Status.GetCondition(v1beta1.DriftDetected); condition == nil || condition.Status != corev1.ConditionTrue {
+			log.Info("Drift detected on the inputs of the predictor", "isvc", isvc.Name, "namespace", isvc.Namespace)
+			r.recorder.Eventf(isvc, corev1.EventTypeWarning, DriftDetectedReason,
+				"The detector reported a drift of the inputs of the predictor from the reference data of %s", monitoring.StorageURI)
+		}
+		isvc.Status.SetCondition(v1beta1.DriftDetected, &apis.Condition{
+			Type:   v1beta1.DriftDetected,
+			Status: corev1.ConditionTrue,
+		})
+	default:
+		isvc.Status.SetCondition(v1beta1.DriftDetected, &apis.Condition{
+			Type:    v1beta1.DriftDetected,
+			Status:  corev1.ConditionFalse,
+			Reason:  NoDriftDetectedReason,
+			Message: "the detector reports no drift of the predictor inputs",
+		})
+	}
+	return ctrl.Result{RequeueAfter: r.config.CheckIntervalDuration}, nil
+}
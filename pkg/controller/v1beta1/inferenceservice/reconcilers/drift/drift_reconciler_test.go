@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+// fakeQuerier returns the configured drift, recording the interval it was asked for
+type fakeQuerier struct {
+	drift    float64
+	reported bool
+	err      error
+	interval time.Duration
+}
+
+func (q *fakeQuerier) QueryDrift(_ context.Context, _ *v1beta1.InferenceService, interval time.Duration) (float64, bool, error) {
+	q.interval = interval
+	return q.drift, q.reported, q.err
+}
+
+func makeInferenceService(monitoring *v1beta1.MonitoringSpec) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{Monitoring: monitoring},
+		},
+	}
+	isvc.Status.InitializeConditions()
+	return isvc
+}
+
+func TestDriftReconcile(t *testing.T) {
+	config := &v1beta1.MonitoringConfig{CheckIntervalDuration: time.Minute}
+	drift := &v1beta1.MonitoringSpec{StorageURI: "s3://bucket/drift"}
+	scenarios := map[string]struct {
+		monitoring      *v1beta1.MonitoringSpec
+		querier         *fakeQuerier
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedEvent   string
+		expectedRequeue time.Duration
+	}{
+		"DriftDetected": {
+			monitoring:      drift,
+			querier:         &fakeQuerier{drift: 1, reported: true},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedEvent:   "Warning DriftDetected The detector reported a drift of the inputs of the predictor from the reference data of s3://bucket/drift",
+			expectedRequeue: time.Minute,
+		},
+		"NoDrift": {
+			monitoring:      drift,
+			querier:         &fakeQuerier{reported: true},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  NoDriftDetectedReason,
+			expectedRequeue: time.Minute,
+		},
+		"NotReported": {
+			monitoring:      drift,
+			querier:         &fakeQuerier{},
+			expectedStatus:  corev1.ConditionUnknown,
+			expectedReason:  DriftNotReportedReason,
+			expectedRequeue: time.Minute,
+		},
+		"QueryFailed": {
+			monitoring:      drift,
+			querier:         &fakeQuerier{err: errors.New("connection refused")},
+			expectedStatus:  corev1.ConditionUnknown,
+			expectedReason:  DriftCheckFailedReason,
+			expectedEvent:   "Warning DriftCheckFailed Failed to query the drift: connection refused",
+			expectedRequeue: time.Minute,
+		},
+		"OutlierDetector": {
+			monitoring: &v1beta1.MonitoringSpec{Type: v1beta1.OutlierDetector, StorageURI: "s3://bucket/outlier"},
+			querier:    &fakeQuerier{drift: 1, reported: true},
+		},
+		"NotMonitored": {
+			querier: &fakeQuerier{drift: 1, reported: true},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &DriftReconciler{recorder: recorder, config: config, querier: scenario.querier}
+			isvc := makeInferenceService(scenario.monitoring)
+
+			result, err := reconciler.Reconcile(t.Context(), isvc)
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expectedRequeue, result.RequeueAfter)
+			condition := isvc.Status.GetCondition(v1beta1.DriftDetected)
+			if scenario.expectedStatus == "" {
+				assert.Nil(t, condition)
+			} else {
+				require.NotNil(t, condition)
+				assert.Equal(t, scenario.expectedStatus, condition.Status)
+				assert.Equal(t, scenario.expectedReason, condition.Reason)
+				assert.Equal(t, config.CheckIntervalDuration, scenario.querier.interval)
+			}
+			if scenario.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				assert.Equal(t, scenario.expectedEvent, <-recorder.Events)
+			}
+		})
+	}
+}
+
+func TestDriftReconcileEventOnce(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &DriftReconciler{
+		recorder: recorder,
+		config:   &v1beta1.MonitoringConfig{CheckIntervalDuration: time.Minute},
+		querier:  &fakeQuerier{drift: 1, reported: true},
+	}
+	isvc := makeInferenceService(&v1beta1.MonitoringSpec{StorageURI: "s3://bucket/drift"})
+
+	_, err := reconciler.Reconcile(t.Context(), isvc)
+	require.NoError(t, err)
+	_, err = reconciler.Reconcile(t.Context(), isvc)
+	require.NoError(t, err)
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestPrometheusDriftQuerier(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		query = r.Form.Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("query") == "absent" {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[` +
+			`{"metric":{"pod":"sklearn-predictor-1"},"value":[1700000000,"0"]},` +
+			`{"metric":{"pod":"sklearn-predictor-2"},"value":[1700000000,"1"]}]}}`))
+	}))
+	defer server.Close()
+	isvc := makeInferenceService(&v1beta1.MonitoringSpec{StorageURI: "s3://bucket/drift"})
+
+	prometheusQuerier, err := prometheus.NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	require.NoError(t, err)
+	querier := NewPrometheusDriftQuerier(prometheusQuerier, v1beta1.DefaultDriftQuery)
+	drift, reported, err := querier.QueryDrift(t.Context(), isvc, 5*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reported)
+	assert.InDelta(t, 1.0, drift, 0)
+	assert.Equal(t, `max(max_over_time(is_drift{namespace="default", inferenceservice="sklearn"}[5m]))`, query)
+
+	querier = NewPrometheusDriftQuerier(prometheusQuerier, "absent")
+	_, reported, err = querier.QueryDrift(t.Context(), isvc, 5*time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reported)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+// PrometheusDriftQuerier queries the drift reported by the detectors of the InferenceServices with an instant
+// Prometheus query
+type PrometheusDriftQuerier struct {
+	querier *prometheus.Querier
+	query   string
+}
+
+// NewPrometheusDriftQuerier returns a querier evaluating the query template
+func NewPrometheusDriftQuerier(querier *prometheus.Querier, query string) *PrometheusDriftQuerier {
+	return &PrometheusDriftQuerier{
+		querier: querier,
+		query:   query,
+	}
+}
+
+// QueryDrift returns the highest sample of the query, and false when the query has no samples, e.g. while the
+// detector has not tested a full batch of inputs yet
+func (q *PrometheusDriftQuerier) QueryDrift(ctx context.Context, isvc *v1beta1.InferenceService, interval time.Duration) (float64, bool, error) {
+	query, err := v1beta1.RenderDriftQuery(q.query, v1beta1.DriftQueryParameters{
+		Namespace:        isvc.Namespace,
+		InferenceService: isvc.Name,
+		Interval:         model.Duration(interval).String(),
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	samples, err := q.querier.Query(ctx, query)
+	if err != nil || len(samples) == 0 {
+		return 0, false, err
+	}
+	return slices.Max(samples), true, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("ExternalDNSReconciler")
+
+// DNSEndpointGVK is the GroupVersionKind of the DNSEndpoints of the ExternalDNS CRD source
+var DNSEndpointGVK = schema.FromAPIVersionAndKind(constants.ExternalDNSAPIVersion, constants.DNSEndpointKind)
+
+// ExternalDNSReconciler publishes the external hosts of an InferenceService with a DNSEndpoint named after it, whose
+// records ExternalDNS creates in the DNS provider. The DNSEndpoints are only reconciled when the ExternalDNS CRDs are
+// installed.
+type ExternalDNSReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	config *v1beta1.ExternalDNSConfig
+}
+
+func NewExternalDNSReconciler(client client.Client, scheme *runtime.Scheme, config *v1beta1.ExternalDNSConfig) *ExternalDNSReconciler {
+	return &ExternalDNSReconciler{
+		client: client,
+		scheme: scheme,
+		config: config,
+	}
+}
+
+// Reconcile creates or updates the DNSEndpoint of the hosts of the InferenceService in the zone of its namespace, or
+// deletes it once the InferenceService has no host to publish
+func (r *ExternalDNSReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	var hosts []string
+	if r.config.Enabled {
+		hosts = PublishedHosts(isvc, r.config.ZoneFor(isvc.Namespace))
+	}
+	if len(hosts) == 0 {
+		return r.delete(ctx, isvc)
+	}
+
+	desired, err := r.desiredDNSEndpoint(isvc, hosts)
+	if err != nil {
+		return err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(DNSEndpointGVK)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if meta.IsNoMatchError(err) {
+		log.V(1).Info("ExternalDNS CRDs are not installed, skipping the DNSEndpoint", "namespace", isvc.Namespace, "name", isvc.Name)
+		return nil
+	}
+	if apierr.IsNotFound(err) {
+		log.Info("Creating DNSEndpoint", "namespace", isvc.Namespace, "name", isvc.Name, "hosts", hosts)
+		if err := r.client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) &&
+		equality.Semantic.DeepDerivative(desired.GetLabels(), existing.GetLabels()) {
+		return nil
+	}
+	log.Info("Updating DNSEndpoint", "namespace", isvc.Namespace, "name", isvc.Name, "hosts", hosts)
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetLabels(desired.GetLabels())
+	return r.client.Update(ctx, existing)
+}
+
+func (r *ExternalDNSReconciler) desiredDNSEndpoint(isvc *v1beta1.InferenceService, hosts []string) (*unstructured.Unstructured, error) {
+	targets := make([]interface{}, 0, len(r.config.Targets))
+	for _, target := range r.config.Targets {
+		targets = append(targets, target)
+	}
+	endpoints := make([]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		endpoint := map[string]interface{}{
+			"dnsName":    host,
+			"recordType": r.config.RecordType(),
+			"targets":    targets,
+		}
+		if r.config.RecordTTL > 0 {
+			endpoint["recordTTL"] = r.config.RecordTTL
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	dnsEndpoint := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"endpoints": endpoints},
+	}}
+	dnsEndpoint.SetGroupVersionKind(DNSEndpointGVK)
+	dnsEndpoint.SetName(isvc.Name)
+	dnsEndpoint.SetNamespace(isvc.Namespace)
+	labels := map[string]string{constants.InferenceServicePodLabelKey: isvc.Name}
+	for key, value := range r.config.Labels {
+		labels[key] = value
+	}
+	dnsEndpoint.SetLabels(labels)
+	if err := controllerutil.SetControllerReference(isvc, dnsEndpoint, r.scheme); err != nil {
+		return nil, err
+	}
+	return dnsEndpoint, nil
+}
+
+// delete deletes the DNSEndpoint of the InferenceService, ExternalDNS then removes its records
+func (r *ExternalDNSReconciler) delete(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(DNSEndpointGVK)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if meta.IsNoMatchError(err) || apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, isvc) {
+		return nil
+	}
+	log.Info("Deleting DNSEndpoint", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	if err := r.client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// PublishedHosts returns the sorted external hosts of the InferenceService and of its components in the zone, all the
+// external hosts when the zone is empty. The cluster local hosts are never published.
+func PublishedHosts(isvc *v1beta1.InferenceService, zone string) []string {
+	urls := []*apis.URL{isvc.Status.URL}
+	for _, component := range isvc.Status.Components {
+		urls = append(urls, component.URL)
+		for _, traffic := range component.Traffic {
+			urls = append(urls, traffic.URL)
+		}
+	}
+	var hosts []string
+	for _, url := range urls {
+		if url == nil {
+			continue
+		}
+		host := url.URL().Hostname()
+		if host == "" || isClusterLocal(host) || !inZone(host, zone) || slices.Contains(hosts, host) {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	slices.Sort(hosts)
+	return hosts
+}
+
+func isClusterLocal(host string) bool {
+	return strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.")
+}
+
+func inZone(host, zone string) bool {
+	return zone == "" || host == zone || strings.HasSuffix(host, "."+zone)
+}
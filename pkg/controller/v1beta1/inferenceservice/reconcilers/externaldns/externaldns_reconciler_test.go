@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newInferenceService(namespace string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: namespace, UID: "uid"},
+		Status: v1beta1.InferenceServiceStatus{
+			URL: apis.HTTPS("sklearn." + namespace + ".models.example.com"),
+			Address: &duckv1.Addressable{
+				URL: apis.HTTP("sklearn-predictor." + namespace + ".svc.cluster.local"),
+			},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: apis.HTTPS("sklearn-predictor." + namespace + ".models.example.com"),
+					Traffic: []knservingv1.TrafficTarget{{
+						Tag: "prev",
+						URL: apis.HTTPS("prev-sklearn-predictor." + namespace + ".models.example.com"),
+					}},
+				},
+				v1beta1.TransformerComponent: {
+					URL: apis.HTTP("sklearn-transformer." + namespace + ".svc.cluster.local"),
+				},
+			},
+		},
+	}
+}
+
+func newConfig(t *testing.T, externalDNS string) *v1beta1.ExternalDNSConfig {
+	t.Helper()
+	config, err := v1beta1.NewExternalDNSConfig(&corev1.ConfigMap{Data: map[string]string{
+		v1beta1.ExternalDNSConfigName: externalDNS,
+	}})
+	require.NoError(t, err)
+	return config
+}
+
+func getDNSEndpoint(t *testing.T, cl client.Client, namespace string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	dnsEndpoint := &unstructured.Unstructured{}
+	dnsEndpoint.SetGroupVersionKind(DNSEndpointGVK)
+	err := cl.Get(t.Context(), types.NamespacedName{Namespace: namespace, Name: "sklearn"}, dnsEndpoint)
+	return dnsEndpoint, err
+}
+
+func TestPublishedHosts(t *testing.T) {
+	isvc := newInferenceService("default")
+	assert.Equal(t, []string{
+		"prev-sklearn-predictor.default.models.example.com",
+		"sklearn-predictor.default.models.example.com",
+		"sklearn.default.models.example.com",
+	}, PublishedHosts(isvc, ""))
+	assert.Equal(t, PublishedHosts(isvc, ""), PublishedHosts(isvc, "example.com"))
+	assert.Empty(t, PublishedHosts(isvc, "other.example.com"))
+	assert.Empty(t, PublishedHosts(&v1beta1.InferenceService{}, ""))
+}
+
+func TestExternalDNSReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	config := newConfig(t, `{"enabled": true, "targets": ["lb.example.com"], "recordTTL": 60, "zone": "default.models.example.com",
+		"namespaceZones": {"team-a": "team-a.example.com"}, "labels": {"dns-zone": "models"}}`)
+
+	isvc := newInferenceService("default")
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, config).Reconcile(t.Context(), isvc))
+	dnsEndpoint, err := getDNSEndpoint(t, cl, "default")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{constants.InferenceServicePodLabelKey: "sklearn", "dns-zone": "models"}, dnsEndpoint.GetLabels())
+	assert.Equal(t, "sklearn", dnsEndpoint.GetOwnerReferences()[0].Name)
+	endpoints, _, _ := unstructured.NestedSlice(dnsEndpoint.Object, "spec", "endpoints")
+	require.Len(t, endpoints, 3)
+	assert.Equal(t, map[string]interface{}{
+		"dnsName":    "prev-sklearn-predictor.default.models.example.com",
+		"recordType": "CNAME",
+		"targets":    []interface{}{"lb.example.com"},
+		"recordTTL":  int64(60),
+	}, endpoints[0])
+
+	// the DNSEndpoint is updated with the hosts of the InferenceService
+	isvc.Status.Components[v1beta1.PredictorComponent] = v1beta1.ComponentStatusSpec{
+		URL: apis.HTTPS("sklearn-predictor.default.models.example.com"),
+	}
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, config).Reconcile(t.Context(), isvc))
+	dnsEndpoint, err = getDNSEndpoint(t, cl, "default")
+	require.NoError(t, err)
+	endpoints, _, _ = unstructured.NestedSlice(dnsEndpoint.Object, "spec", "endpoints")
+	assert.Len(t, endpoints, 2)
+
+	// the hosts outside the zone of the namespace are not published
+	teamA := newInferenceService("team-a")
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, config).Reconcile(t.Context(), teamA))
+	_, err = getDNSEndpoint(t, cl, "team-a")
+	assert.True(t, apierr.IsNotFound(err))
+
+	// the DNSEndpoint is deleted once the InferenceService is cluster local
+	isvc.Status.URL = isvc.Status.Address.URL
+	isvc.Status.Components = nil
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, config).Reconcile(t.Context(), isvc))
+	_, err = getDNSEndpoint(t, cl, "default")
+	assert.True(t, apierr.IsNotFound(err))
+}
+
+func TestExternalDNSReconcilerDisabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	isvc := newInferenceService("default")
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, newConfig(t, `{"enabled": true, "targets": ["10.0.0.1"]}`)).Reconcile(t.Context(), isvc))
+	dnsEndpoint, err := getDNSEndpoint(t, cl, "default")
+	require.NoError(t, err)
+	endpoints, _, _ := unstructured.NestedSlice(dnsEndpoint.Object, "spec", "endpoints")
+	recordType, _, _ := unstructured.NestedString(endpoints[0].(map[string]interface{}), "recordType")
+	assert.Equal(t, "A", recordType)
+
+	require.NoError(t, NewExternalDNSReconciler(cl, scheme, newConfig(t, `{}`)).Reconcile(t.Context(), isvc))
+	_, err = getDNSEndpoint(t, cl, "default")
+	assert.True(t, apierr.IsNotFound(err))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpumemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	agentgpumemory "github.com/kserve/kserve/pkg/agent/gpumemory"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("GPUMemoryReconciler")
+
+// GPU memory condition reasons
+const (
+	TelemetryUnavailableReason = "GPUMemoryTelemetryUnavailable"
+	// RestartRecommendedReason is set when the pods are recommended a restart for different reasons
+	RestartRecommendedReason = "RestartRecommended"
+)
+
+const (
+	// PollInterval is the interval at which the GPU memory reports of the agents are polled
+	PollInterval   = 5 * time.Minute
+	requestTimeout = 5 * time.Second
+)
+
+// GPUMemoryReconciler polls the GPU memory reports of the agents of the predictor pods, and recommends restarting the
+// pods whose GPU memory is fragmented or leaking with the GPUMemoryHealthy condition, so that the restarts of the
+// long-running runtimes can be scheduled before they degrade.
+type GPUMemoryReconciler struct {
+	client     client.Client
+	httpClient *http.Client
+	agentPort  string
+}
+
+func NewGPUMemoryReconciler(client client.Client) *GPUMemoryReconciler {
+	return &GPUMemoryReconciler{
+		client:     client,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		agentPort:  constants.InferenceServiceDefaultAgentPortStr,
+	}
+}
+
+// Reconcile sets the GPUMemoryHealthy condition from the reports of the running predictor pods when the GPU memory
+// telemetry is enabled, and clears it otherwise. The condition is informational and does not affect the
+// readiness of the InferenceService.
+func (r *GPUMemoryReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	if isvc.Annotations[constants.EnableGPUMemoryTelemetryAnnotationKey] != "true" {
+		isvc.Status.ClearCondition(v1beta1.GPUMemoryHealthy)
+		return ctrl.Result{}, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	reported := 0
+	var recommendations []string
+	reason := ""
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		report, err := r.report(ctx, pod.Status.PodIP)
+		if err != nil {
+			log.V(1).Info("Failed to get the GPU memory report", "isvc", isvc.Name, "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		reported++
+		if report.RestartRecommended {
+			if reason == "" {
+				reason = report.Reason
+			} else if reason != report.Reason {
+				reason = RestartRecommendedReason
+			}
+			recommendations = append(recommendations, fmt.Sprintf("%s: %s", pod.Name, report.Message))
+		}
+	}
+
+	switch {
+	case len(recommendations) > 0:
+		sort.Strings(recommendations)
+		isvc.Status.SetCondition(v1beta1.GPUMemoryHealthy, &apis.Condition{
+			Type:    v1beta1.GPUMemoryHealthy,
+			Status:  corev1.ConditionFalse,
+			Reason:  reason,
+			Message: "restart recommended for " + strings.Join(recommendations, "; "),
+		})
+	case reported > 0:
+		isvc.Status.SetCondition(v1beta1.GPUMemoryHealthy, &apis.Condition{
+			Type:   v1beta1.GPUMemoryHealthy,
+			Status: corev1.ConditionTrue,
+		})
+	default:
+		isvc.Status.SetCondition(v1beta1.GPUMemoryHealthy, &apis.Condition{
+			Type:    v1beta1.GPUMemoryHealthy,
+			Status:  corev1.ConditionUnknown,
+			Reason:  TelemetryUnavailableReason,
+			Message: "no running predictor pod reported its GPU memory",
+		})
+	}
+	return ctrl.Result{RequeueAfter: PollInterval}, nil
+}
+
+func (r *GPUMemoryReconciler) report(ctx context.Context, podIP string) (*agentgpumemory.Report, error) {
+	url := "http://" + net.JoinHostPort(podIP, r.agentPort) + agentgpumemory.StatusPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	report := &agentgpumemory.Report{}
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpumemory
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	agentgpumemory "github.com/kserve/kserve/pkg/agent/gpumemory"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newPod(name string, component v1beta1.ComponentType, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "llm",
+				constants.KServiceComponentLabel:      string(component),
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase, PodIP: "127.0.0.1"},
+	}
+}
+
+func TestGPUMemoryReconcile(t *testing.T) {
+	scenarios := map[string]struct {
+		disabled bool
+		pods     []client.Object
+		// report is served by the agents, none of them answers when nil
+		report          *agentgpumemory.Report
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		"Disabled": {
+			disabled: true,
+			pods:     []client.Object{newPod("llm-predictor-a", v1beta1.PredictorComponent, corev1.PodRunning)},
+			report:   &agentgpumemory.Report{RestartRecommended: true},
+		},
+		"Healthy": {
+			pods:           []client.Object{newPod("llm-predictor-a", v1beta1.PredictorComponent, corev1.PodRunning)},
+			report:         &agentgpumemory.Report{Samples: 20},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		"Fragmented": {
+			pods: []client.Object{
+				newPod("llm-predictor-b", v1beta1.PredictorComponent, corev1.PodRunning),
+				newPod("llm-predictor-a", v1beta1.PredictorComponent, corev1.PodRunning),
+				newPod("llm-predictor-c", v1beta1.PredictorComponent, corev1.PodPending),
+				newPod("llm-transformer-a", v1beta1.TransformerComponent, corev1.PodRunning),
+			},
+			report: &agentgpumemory.Report{
+				RestartRecommended: true,
+				Reason:             agentgpumemory.FragmentedReason,
+				Message:            "the fragmentation ratio of the reserved GPU memory is 0.40",
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: agentgpumemory.FragmentedReason,
+			expectedMessage: "restart recommended for llm-predictor-a: the fragmentation ratio of the reserved GPU memory " +
+				"is 0.40; llm-predictor-b: the fragmentation ratio of the reserved GPU memory is 0.40",
+		},
+		"Unavailable": {
+			pods:           []client.Object{newPod("llm-predictor-a", v1beta1.PredictorComponent, corev1.PodRunning)},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: TelemetryUnavailableReason,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if scenario.report == nil || r.URL.Path != agentgpumemory.StatusPath {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(scenario.report)
+			}))
+			defer server.Close()
+			_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			require.NoError(t, err)
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			reconciler := NewGPUMemoryReconciler(fake.NewClientBuilder().WithScheme(scheme).WithObjects(scenario.pods...).Build())
+			reconciler.agentPort = port
+
+			isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"}}
+			if !scenario.disabled {
+				isvc.Annotations = map[string]string{constants.EnableGPUMemoryTelemetryAnnotationKey: "true"}
+			}
+			isvc.Status.SetCondition(v1beta1.GPUMemoryHealthy, &apis.Condition{
+				Type:   v1beta1.GPUMemoryHealthy,
+				Status: corev1.ConditionUnknown,
+			})
+
+			result, err := reconciler.Reconcile(context.Background(), isvc)
+			require.NoError(t, err)
+			condition := isvc.Status.GetCondition(v1beta1.GPUMemoryHealthy)
+			if scenario.disabled {
+				assert.Nil(t, condition)
+				assert.Zero(t, result.RequeueAfter)
+				return
+			}
+			require.NotNil(t, condition)
+			assert.Equal(t, scenario.expectedStatus, condition.Status)
+			assert.Equal(t, scenario.expectedReason, condition.Reason)
+			if scenario.expectedMessage != "" {
+				assert.Equal(t, scenario.expectedMessage, condition.Message)
+			}
+			assert.Equal(t, PollInterval, result.RequeueAfter)
+		})
+	}
+}
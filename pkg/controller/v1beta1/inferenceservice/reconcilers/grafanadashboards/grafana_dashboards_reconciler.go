@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafanadashboards
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("GrafanaDashboardsReconciler")
+
+// The dashboards are templated with the [[ ]] delimiters, as the Grafana legends use the {{ }} delimiters
+var (
+	//go:embed dashboards/*.json
+	dashboardsFS embed.FS
+	dashboards   = template.Must(template.New("dashboards").Delims("[[", "]]").ParseFS(dashboardsFS, "dashboards/*.json"))
+)
+
+// dashboardParams are the parameters the dashboards are templated with
+type dashboardParams struct {
+	Namespace string
+	// UID prefixes the uids of the dashboards, which are unique in the Grafana instance and limited to 40 characters
+	UID string
+}
+
+// GrafanaDashboardsReconciler creates the configmap with the KServe serving dashboards in the namespace of the
+// InferenceServices when the Grafana dashboards are enabled. The configmap is labeled so that the dashboards sidecar
+// of Grafana loads the dashboards, and is only created once the sidecar convention is detected, i.e. configmaps of
+// the cluster are labeled with the label of the sidecar. The dashboards are filtered on the namespace and select the
+// InferenceServices and components with the labels of the pod monitors.
+// The detection lists the configmaps of all the namespaces with a limit of one, which the cache of the manager
+// cannot serve, so the configmaps are read through the clientset.
+type GrafanaDashboardsReconciler struct {
+	clientset kubernetes.Interface
+	config    *v1beta1.GrafanaDashboardsConfig
+}
+
+func NewGrafanaDashboardsReconciler(clientset kubernetes.Interface, config *v1beta1.GrafanaDashboardsConfig) *GrafanaDashboardsReconciler {
+	return &GrafanaDashboardsReconciler{
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+func (r *GrafanaDashboardsReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	if !r.config.Enabled {
+		return nil
+	}
+	desired, err := getDesiredGrafanaDashboardsConfigMap(isvc.Namespace, r.config)
+	if err != nil {
+		return err
+	}
+
+	// The configmap is shared by the InferenceServices of the namespace and is not owned by any of them
+	configMaps := r.clientset.CoreV1().ConfigMaps(desired.Namespace)
+	existing, err := configMaps.Get(ctx, desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierr.IsNotFound(err) {
+			return err
+		}
+		detected, err := r.sidecarDetected(ctx)
+		if err != nil || !detected {
+			return err
+		}
+		log.Info("Creating Grafana dashboards configmap", "namespace", desired.Namespace, "name", desired.Name)
+		_, err = configMaps.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if maps.Equal(existing.Data, desired.Data) && hasAll(existing.Labels, desired.Labels) &&
+		hasAll(existing.Annotations, desired.Annotations) {
+		return nil
+	}
+
+	log.Info("Updating Grafana dashboards configmap", "namespace", existing.Namespace, "name", existing.Name)
+	existing.Data = desired.Data
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	maps.Copy(existing.Labels, desired.Labels)
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	maps.Copy(existing.Annotations, desired.Annotations)
+	if _, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("fails to update Grafana dashboards configmap: %w", err)
+	}
+	return nil
+}
+
+// sidecarDetected returns whether configmaps of the cluster are labeled with the label of the dashboards sidecar
+func (r *GrafanaDashboardsReconciler) sidecarDetected(ctx context.Context) (bool, error) {
+	labeled, err := r.clientset.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: r.config.Label,
+		Limit:         1,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(labeled.Items) == 0 {
+		log.V(1).Info("Skipping the Grafana dashboards as no configmap is labeled for the dashboards sidecar", "label", r.config.Label)
+		return false, nil
+	}
+	return true, nil
+}
+
+func getDesiredGrafanaDashboardsConfigMap(namespace string, config *v1beta1.GrafanaDashboardsConfig) (*corev1.ConfigMap, error) {
+	hash := sha256.Sum256([]byte(namespace))
+	params := dashboardParams{Namespace: namespace, UID: "kserve-" + hex.EncodeToString(hash[:])[:12]}
+	data := map[string]string{}
+	for _, dashboard := range dashboards.Templates() {
+		if dashboard.Name() == dashboards.Name() {
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := dashboard.Execute(buf, params); err != nil {
+			return nil, err
+		}
+		data["kserve-"+path.Base(dashboard.Name())] = buf.String()
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.GrafanaDashboardsConfigMapName,
+			Namespace: namespace,
+			Labels:    map[string]string{config.Label: config.LabelValue},
+		},
+		Data: data,
+	}
+	if config.FolderAnnotation != "" {
+		configMap.Annotations = map[string]string{config.FolderAnnotation: namespace}
+	}
+	return configMap, nil
+}
+
+// hasAll returns whether the map holds all the entries of the expected map
+func hasAll(m map[string]string, expected map[string]string) bool {
+	for key, value := range expected {
+		if v, ok := m[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
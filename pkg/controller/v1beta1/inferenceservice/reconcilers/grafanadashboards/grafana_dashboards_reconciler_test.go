@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grafanadashboards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestGrafanaDashboardsReconciler(t *testing.T) {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "ns1"},
+	}
+	config := &v1beta1.GrafanaDashboardsConfig{
+		Enabled:          true,
+		Label:            constants.DefaultGrafanaDashboardLabel,
+		LabelValue:       constants.DefaultGrafanaDashboardLabelValue,
+		FolderAnnotation: "grafana_folder",
+	}
+	sidecarDashboard := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kubernetes-dashboard",
+			Namespace: "monitoring",
+			Labels:    map[string]string{constants.DefaultGrafanaDashboardLabel: "1"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		disabled   bool
+		existing   []*corev1.ConfigMap
+		expectedCM bool
+	}{
+		{
+			name:       "dashboards disabled",
+			disabled:   true,
+			existing:   []*corev1.ConfigMap{sidecarDashboard},
+			expectedCM: false,
+		},
+		{
+			name:       "sidecar not detected",
+			expectedCM: false,
+		},
+		{
+			name:       "configmap created",
+			existing:   []*corev1.ConfigMap{sidecarDashboard},
+			expectedCM: true,
+		},
+		{
+			name: "outdated configmap updated",
+			existing: []*corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.GrafanaDashboardsConfigMapName,
+					Namespace: "ns1",
+					Labels:    map[string]string{"team": "ml"},
+				},
+				Data: map[string]string{"kserve-serving.json": "{}"},
+			}},
+			expectedCM: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			for _, configMap := range tt.existing {
+				require.NoError(t, clientset.Tracker().Add(configMap))
+			}
+			config := *config
+			config.Enabled = !tt.disabled
+
+			require.NoError(t, NewGrafanaDashboardsReconciler(clientset, &config).Reconcile(t.Context(), isvc))
+			configMap, err := clientset.CoreV1().ConfigMaps("ns1").Get(t.Context(), constants.GrafanaDashboardsConfigMapName, metav1.GetOptions{})
+			if !tt.expectedCM {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "1", configMap.Labels[constants.DefaultGrafanaDashboardLabel])
+			assert.Equal(t, "ns1", configMap.Annotations["grafana_folder"])
+			require.Contains(t, configMap.Data, "kserve-serving.json")
+			require.Contains(t, configMap.Data, "kserve-runtime.json")
+			for _, content := range configMap.Data {
+				dashboard := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal([]byte(content), &dashboard))
+				assert.Contains(t, dashboard["title"], "ns1")
+				assert.LessOrEqual(t, len(dashboard["uid"].(string)), 40)
+			}
+		})
+	}
+}
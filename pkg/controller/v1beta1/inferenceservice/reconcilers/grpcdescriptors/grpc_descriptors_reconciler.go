@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcdescriptors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+var log = logf.Log.WithName("GrpcDescriptorsReconciler")
+
+// GrpcDescriptorsReconciler creates the ConfigMap with the open inference protocol descriptor set of the
+// InferenceServices enabling gRPC reflection. The pod mutator mounts it in the model server, which serves the
+// reflection service from it, and gateways use the descriptor set to transcode HTTP/JSON requests to gRPC.
+// The ConfigMap is owned by the InferenceService, and is deleted once the reflection is disabled.
+type GrpcDescriptorsReconciler struct {
+	clientset kubernetes.Interface
+	scheme    *runtime.Scheme
+}
+
+func NewGrpcDescriptorsReconciler(clientset kubernetes.Interface, scheme *runtime.Scheme) *GrpcDescriptorsReconciler {
+	return &GrpcDescriptorsReconciler{
+		clientset: clientset,
+		scheme:    scheme,
+	}
+}
+
+func (r *GrpcDescriptorsReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	configMaps := r.clientset.CoreV1().ConfigMaps(isvc.Namespace)
+	name := constants.GrpcDescriptorsConfigMapName(isvc.Name)
+	existing, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	if isvc.Annotations[constants.EnableGrpcReflectionAnnotationKey] != "true" {
+		if !found || !metav1.IsControlledBy(existing, isvc) {
+			return nil
+		}
+		log.Info("Deleting gRPC descriptors configmap", "namespace", existing.Namespace, "name", existing.Name)
+		if err := configMaps.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierr.IsNotFound(err) {
+			return fmt.Errorf("fails to delete gRPC descriptors configmap: %w", err)
+		}
+		return nil
+	}
+
+	desired := getDesiredGrpcDescriptorsConfigMap(isvc)
+	if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+		return err
+	}
+	if !found {
+		log.Info("Creating gRPC descriptors configmap", "namespace", desired.Namespace, "name", desired.Name)
+		if _, err := configMaps.Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("fails to create gRPC descriptors configmap: %w", err)
+		}
+		return nil
+	}
+	if bytes.Equal(existing.BinaryData[constants.GrpcDescriptorSetFileName], protocol.GrpcPredictV2DescriptorSet) &&
+		metav1.IsControlledBy(existing, isvc) {
+		return nil
+	}
+
+	log.Info("Updating gRPC descriptors configmap", "namespace", existing.Namespace, "name", existing.Name)
+	existing.BinaryData = desired.BinaryData
+	existing.OwnerReferences = desired.OwnerReferences
+	if _, err := configMaps.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("fails to update gRPC descriptors configmap: %w", err)
+	}
+	return nil
+}
+
+func getDesiredGrpcDescriptorsConfigMap(isvc *v1beta1.InferenceService) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.GrpcDescriptorsConfigMapName(isvc.Name),
+			Namespace: isvc.Namespace,
+		},
+		BinaryData: map[string][]byte{
+			constants.GrpcDescriptorSetFileName: protocol.GrpcPredictV2DescriptorSet,
+		},
+	}
+}
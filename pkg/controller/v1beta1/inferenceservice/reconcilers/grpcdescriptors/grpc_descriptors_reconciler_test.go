@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcdescriptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/protocol"
+)
+
+func TestGrpcDescriptorsReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn",
+			Namespace:   "ns1",
+			UID:         "sklearn-uid",
+			Annotations: map[string]string{constants.EnableGrpcReflectionAnnotationKey: "true"},
+		},
+	}
+	owned := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		require.NoError(t, controllerutil.SetControllerReference(isvc, cm, scheme))
+		return cm
+	}
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		existingCM  *corev1.ConfigMap
+		expectedCM  bool
+	}{
+		{
+			name:       "reflection disabled",
+			expectedCM: false,
+		},
+		{
+			name:        "configmap created",
+			annotations: isvc.Annotations,
+			expectedCM:  true,
+		},
+		{
+			name:        "outdated configmap updated",
+			annotations: isvc.Annotations,
+			existingCM: owned(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn-grpc-descriptors", Namespace: "ns1"},
+				BinaryData: map[string][]byte{constants.GrpcDescriptorSetFileName: []byte("outdated")},
+			}),
+			expectedCM: true,
+		},
+		{
+			name: "configmap deleted once the reflection is disabled",
+			existingCM: owned(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn-grpc-descriptors", Namespace: "ns1"},
+				BinaryData: map[string][]byte{constants.GrpcDescriptorSetFileName: protocol.GrpcPredictV2DescriptorSet},
+			}),
+			expectedCM: false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			if tt.existingCM != nil {
+				clientset = fake.NewSimpleClientset(tt.existingCM)
+			}
+			isvc := isvc.DeepCopy()
+			isvc.Annotations = tt.annotations
+
+			require.NoError(t, NewGrpcDescriptorsReconciler(clientset, scheme).Reconcile(t.Context(), isvc))
+			configMap, err := clientset.CoreV1().ConfigMaps("ns1").Get(t.Context(), "sklearn-grpc-descriptors", metav1.GetOptions{})
+			if !tt.expectedCM {
+				assert.True(t, apierr.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, protocol.GrpcPredictV2DescriptorSet, configMap.BinaryData[constants.GrpcDescriptorSetFileName])
+			assert.True(t, metav1.IsControlledBy(configMap, isvc))
+		})
+	}
+}
@@ -16,6 +16,7 @@ package hpa
 import (
 	"context"
 	"strings"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -48,7 +49,7 @@ func NewHPAReconciler(client client.Client,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 ) (*HPAReconciler, error) {
-	hpa := createHPA(componentMeta, componentExt)
+	hpa := createHPA(componentMeta, componentExt, time.Now())
 	return &HPAReconciler{
 		client:       client,
 		scheme:       scheme,
@@ -148,6 +149,7 @@ func getHPAMetrics(componentExt *v1beta1.ComponentExtensionSpec) []autoscalingv2
 
 func createHPA(componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
+	now time.Time,
 ) *autoscalingv2.HorizontalPodAutoscaler {
 	var minReplicas int32
 	if componentExt == nil || componentExt.MinReplicas == nil || (*componentExt.MinReplicas) < constants.DefaultMinReplicas {
@@ -155,6 +157,15 @@ func createHPA(componentMeta metav1.ObjectMeta,
 	} else {
 		minReplicas = *componentExt.MinReplicas
 	}
+	// The open windows of the scale schedule raise the minimum replicas, the InferenceService is requeued to patch
+	// the HPA at the next opening or closing of a window
+	if componentExt != nil && len(componentExt.ScaleSchedule) > 0 {
+		scheduled, _, err := v1beta1.ScaleScheduleState(componentExt.ScaleSchedule, now)
+		if err != nil {
+			log.Error(err, "Ignoring the invalid scale schedule", "name", componentMeta.Name)
+		}
+		minReplicas = max(minReplicas, scheduled)
+	}
 
 	var maxReplicas int32
 	if componentExt != nil {
@@ -219,7 +230,15 @@ func semanticHPAEquals(desired, existing *autoscalingv2.HorizontalPodAutoscaler)
 	} else if hasDesiredAutoscalerClass || hasExistingAutoscalerClass {
 		autoscalerClassChanged = true
 	}
-	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) && !autoscalerClassChanged
+	capacityTypeChanged := desired.Labels[constants.CapacityTypeLabelKey] != existing.Labels[constants.CapacityTypeLabelKey]
+	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) && !autoscalerClassChanged && !capacityTypeChanged
+}
+
+// SetCapacityType labels the HPA with the capacity type of the pods of the deployment it scales
+func (r *HPAReconciler) SetCapacityType(capacityType string) {
+	r.HPA.Labels = utils.Union(r.HPA.Labels, map[string]string{
+		constants.CapacityTypeLabelKey: capacityType,
+	})
 }
 
 func shouldDeleteHPA(existing *autoscalingv2.HorizontalPodAutoscaler, desired *autoscalingv2.HorizontalPodAutoscaler) bool {
@@ -18,6 +18,7 @@ package hpa
 import (
 	"context"
 	"testing"
+	"time"
 
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -413,7 +414,7 @@ func TestCreateHPA(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := createHPA(tt.args.objectMeta, tt.args.componentExt)
+			got := createHPA(tt.args.objectMeta, tt.args.componentExt, time.Now())
 			if diff := cmp.Diff(tt.expected, got); diff != "" {
 				t.Errorf("Test %q unexpected hpa (-want +got): %v", tt.name, diff)
 			}
@@ -421,6 +422,26 @@ func TestCreateHPA(t *testing.T) {
 	}
 }
 
+func TestCreateHPAScaleSchedule(t *testing.T) {
+	componentExt := &v1beta1.ComponentExtensionSpec{
+		MinReplicas: ptr.To(int32(2)),
+		MaxReplicas: 8,
+		ScaleSchedule: []v1beta1.ScaleScheduleWindow{
+			{Name: "business-hours", Start: "0 8 * * MON-FRI", End: "0 18 * * MON-FRI", Timezone: "Europe/Paris", MinReplicas: 6},
+		},
+	}
+	objectMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+
+	// Wednesday 10:00 in Paris, the window is open
+	hpa := createHPA(objectMeta, componentExt, time.Date(2025, time.June, 4, 8, 0, 0, 0, time.UTC))
+	assert.Equal(t, int32(6), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(8), hpa.Spec.MaxReplicas)
+
+	// Saturday 10:00 in Paris, the window is closed
+	hpa = createHPA(objectMeta, componentExt, time.Date(2025, time.June, 7, 8, 0, 0, 0, time.UTC))
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+}
+
 func TestSemanticHPAEquals(t *testing.T) {
 	assert.True(t, semanticHPAEquals(
 		&autoscalingv2.HorizontalPodAutoscaler{
@@ -497,6 +518,15 @@ func TestSemanticHPAEquals(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"unrelated": "false"}},
 			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To(int32(3))},
 		}))
+
+	assert.False(t, semanticHPAEquals(
+		&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{constants.CapacityTypeLabelKey: constants.CapacityTypeSpot}},
+			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To(int32(3))},
+		},
+		&autoscalingv2.HorizontalPodAutoscaler{
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To(int32(3))},
+		}))
 }
 
 func TestCheckHPAExist(t *testing.T) {
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("IdleTimeoutReconciler")
+
+// Idle timeout event reasons
+const (
+	IdleTimeoutStoppedReason     = "IdleTimeoutStopped"
+	IdleTimeoutDeletedReason     = "IdleTimeoutDeleted"
+	IdleTimeoutCheckFailedReason = "IdleTimeoutCheckFailed"
+)
+
+// RequestCounter counts the inference requests received by an InferenceService over a window
+type RequestCounter interface {
+	CountRequests(ctx context.Context, isvc *v1beta1.InferenceService, window time.Duration) (float64, error)
+}
+
+// IdleTimeoutReconciler cleans up the InferenceServices which received no inference requests for the duration of
+// their idle timeout, so that the InferenceServices of development or experiments do not hold resources forever.
+type IdleTimeoutReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+	config   *v1beta1.IdleTimeoutConfig
+	counter  RequestCounter
+}
+
+// NewIdleTimeoutReconciler returns a reconciler counting the requests from Prometheus, the idle timeouts are not
+// enforced without a Prometheus querier
+func NewIdleTimeoutReconciler(client client.Client, recorder record.EventRecorder, config *v1beta1.IdleTimeoutConfig, querier *prometheus.Querier) *IdleTimeoutReconciler {
+	reconciler := &IdleTimeoutReconciler{
+		client:   client,
+		recorder: recorder,
+		config:   config,
+	}
+	if querier != nil {
+		reconciler.counter = NewPrometheusRequestCounter(querier, config.Query)
+	}
+	return reconciler
+}
+
+// Reconcile applies the policy of the idle timeout once the InferenceService has been ready for the idle duration
+// without receiving requests. The active InferenceServices are checked again after the check interval.
+func (r *IdleTimeoutReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	idleTimeout := isvc.Spec.IdleTimeout
+	if idleTimeout == nil || utils.GetForceStopRuntime(isvc) || !isvc.Status.IsReady() {
+		return ctrl.Result{}, nil
+	}
+	if r.counter == nil {
+		log.V(1).Info("No Prometheus instance configured, skipping the idle timeout", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	// The requests are only counted once the InferenceService has been ready for a full window
+	activeSince := isvc.CreationTimestamp.Time
+	if ready := isvc.Status.GetCondition(apis.ConditionReady); ready != nil && ready.LastTransitionTime.Inner.After(activeSince) {
+		activeSince = ready.LastTransitionTime.Inner.Time
+	}
+	if remaining := time.Until(activeSince.Add(idleTimeout.Duration.Duration)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	requests, err := r.counter.CountRequests(ctx, isvc, idleTimeout.Duration.Duration)
+	if errors.Is(err, ErrNoRequestSeries) {
+		log.V(1).Info("The requests of the InferenceService are unknown, skipping the idle timeout", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		return ctrl.Result{RequeueAfter: r.config.CheckIntervalDuration}, nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to count the requests of the InferenceService", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		r.recorder.Eventf(isvc, corev1.EventTypeWarning, IdleTimeoutCheckFailedReason, "Failed to count the inference requests: %v", err)
+		return ctrl.Result{RequeueAfter: r.config.CheckIntervalDuration}, nil
+	}
+	if requests > 0 {
+		return ctrl.Result{RequeueAfter: r.config.CheckIntervalDuration}, nil
+	}
+
+	reason := fmt.Sprintf("no inference requests were received for %s", idleTimeout.Duration.Duration)
+	if idleTimeout.Policy == v1beta1.DeleteIdlePolicy {
+		log.Info("Deleting the idle InferenceService", "isvc", isvc.Name, "namespace", isvc.Namespace)
+		r.recorder.Eventf(isvc, corev1.EventTypeNormal, IdleTimeoutDeletedReason, "Deleting the InferenceService as %s", reason)
+		if err := r.client.Delete(ctx, isvc); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Stopping the idle InferenceService", "isvc", isvc.Name, "namespace", isvc.Namespace)
+	r.recorder.Eventf(isvc, corev1.EventTypeNormal, IdleTimeoutStoppedReason,
+		"Stopping the InferenceService as %s, remove the %s annotation to resume it", reason, constants.StopAnnotationKey)
+	patch := client.MergeFrom(isvc.DeepCopy())
+	if isvc.Annotations == nil {
+		isvc.Annotations = map[string]string{}
+	}
+	isvc.Annotations[constants.StopAnnotationKey] = "true"
+	if err := r.client.Patch(ctx, isvc, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+// fakeCounter returns the configured request count, recording the window it was asked for
+type fakeCounter struct {
+	requests float64
+	err      error
+	window   time.Duration
+}
+
+func (c *fakeCounter) CountRequests(_ context.Context, _ *v1beta1.InferenceService, window time.Duration) (float64, error) {
+	c.window = window
+	return c.requests, c.err
+}
+
+func makeInferenceService(policy v1beta1.IdlePolicy, readySince time.Time) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "sklearn",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(readySince.Add(-time.Hour)),
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			IdleTimeout: &v1beta1.IdleTimeoutSpec{Duration: metav1.Duration{Duration: 24 * time.Hour}, Policy: policy},
+		},
+	}
+	isvc.Status.InitializeConditions()
+	for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.IngressReady} {
+		isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	for i := range isvc.Status.Conditions {
+		if isvc.Status.Conditions[i].Type == apis.ConditionReady {
+			isvc.Status.Conditions[i].LastTransitionTime = apis.VolatileTime{Inner: metav1.NewTime(readySince)}
+		}
+	}
+	return isvc
+}
+
+func TestIdleTimeoutReconcile(t *testing.T) {
+	config := &v1beta1.IdleTimeoutConfig{CheckIntervalDuration: 5 * time.Minute}
+	scenarios := map[string]struct {
+		policy              v1beta1.IdlePolicy
+		readySince          time.Time
+		counter             *fakeCounter
+		expectedRequeue     time.Duration
+		expectedEvent       string
+		expectedStopped     bool
+		expectedDeleted     bool
+		expectedCountWindow time.Duration
+	}{
+		"ReadyForLessThanTheTimeout": {
+			readySince:      time.Now().Add(-23 * time.Hour),
+			counter:         &fakeCounter{},
+			expectedRequeue: time.Hour,
+		},
+		"Active": {
+			readySince:          time.Now().Add(-48 * time.Hour),
+			counter:             &fakeCounter{requests: 3},
+			expectedRequeue:     5 * time.Minute,
+			expectedCountWindow: 24 * time.Hour,
+		},
+		"CountFailed": {
+			readySince:          time.Now().Add(-48 * time.Hour),
+			counter:             &fakeCounter{err: errors.New("connection refused")},
+			expectedRequeue:     5 * time.Minute,
+			expectedEvent:       "Warning IdleTimeoutCheckFailed Failed to count the inference requests: connection refused",
+			expectedCountWindow: 24 * time.Hour,
+		},
+		"RequestsUnknown": {
+			policy:              v1beta1.DeleteIdlePolicy,
+			readySince:          time.Now().Add(-48 * time.Hour),
+			counter:             &fakeCounter{err: fmt.Errorf("%w for the query", ErrNoRequestSeries)},
+			expectedRequeue:     5 * time.Minute,
+			expectedCountWindow: 24 * time.Hour,
+		},
+		"IdleStopped": {
+			policy:              v1beta1.StopIdlePolicy,
+			readySince:          time.Now().Add(-48 * time.Hour),
+			counter:             &fakeCounter{},
+			expectedEvent:       "Normal IdleTimeoutStopped Stopping the InferenceService as no inference requests were received for 24h0m0s, remove the serving.kserve.io/stop annotation to resume it",
+			expectedStopped:     true,
+			expectedCountWindow: 24 * time.Hour,
+		},
+		"IdleDeleted": {
+			policy:              v1beta1.DeleteIdlePolicy,
+			readySince:          time.Now().Add(-48 * time.Hour),
+			counter:             &fakeCounter{},
+			expectedEvent:       "Normal IdleTimeoutDeleted Deleting the InferenceService as no inference requests were received for 24h0m0s",
+			expectedDeleted:     true,
+			expectedCountWindow: 24 * time.Hour,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			isvc := makeInferenceService(scenario.policy, scenario.readySince)
+			c := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(isvc).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &IdleTimeoutReconciler{client: c, recorder: recorder, config: config, counter: scenario.counter}
+
+			result, err := reconciler.Reconcile(t.Context(), isvc)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(result.RequeueAfter).To(gomega.BeNumerically("~", scenario.expectedRequeue, time.Minute))
+			g.Expect(scenario.counter.window).To(gomega.Equal(scenario.expectedCountWindow))
+			if scenario.expectedEvent != "" {
+				g.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(scenario.expectedEvent)))
+			} else {
+				g.Expect(recorder.Events).To(gomega.BeEmpty())
+			}
+
+			actual := &v1beta1.InferenceService{}
+			err = c.Get(t.Context(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, actual)
+			if scenario.expectedDeleted {
+				g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			if scenario.expectedStopped {
+				g.Expect(actual.Annotations).To(gomega.HaveKeyWithValue(constants.StopAnnotationKey, "true"))
+			} else {
+				g.Expect(actual.Annotations).ToNot(gomega.HaveKey(constants.StopAnnotationKey))
+			}
+		})
+	}
+}
+
+func TestIdleTimeoutReconcileSkipped(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	counter := &fakeCounter{}
+	reconciler := &IdleTimeoutReconciler{recorder: record.NewFakeRecorder(10), config: &v1beta1.IdleTimeoutConfig{}, counter: counter}
+
+	stopped := makeInferenceService(v1beta1.StopIdlePolicy, time.Now().Add(-48*time.Hour))
+	stopped.Annotations = map[string]string{constants.StopAnnotationKey: "true"}
+	notReady := makeInferenceService(v1beta1.StopIdlePolicy, time.Now().Add(-48*time.Hour))
+	notReady.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionFalse})
+	noTimeout := makeInferenceService(v1beta1.StopIdlePolicy, time.Now().Add(-48*time.Hour))
+	noTimeout.Spec.IdleTimeout = nil
+
+	for _, isvc := range []*v1beta1.InferenceService{stopped, notReady, noTimeout} {
+		result, err := reconciler.Reconcile(t.Context(), isvc)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.BeZero())
+	}
+	g.Expect(counter.window).To(gomega.BeZero())
+}
+
+func TestPrometheusRequestCounter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var query string
+	response := `{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1700000000, "3"]}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.ParseForm()).To(gomega.Succeed())
+		query = r.Form.Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	querier, err := prometheus.NewQuerier(&v1beta1.PrometheusConfig{URL: server.URL})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	counter := NewPrometheusRequestCounter(querier, v1beta1.DefaultIdleRequestCountQuery)
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"}}
+
+	requests, err := counter.CountRequests(t.Context(), isvc, 24*time.Hour)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(requests).To(gomega.Equal(3.0))
+	g.Expect(query).To(gomega.Equal(`sum(increase(revision_request_count{namespace="default", inferenceservice="sklearn"}[1d]))`))
+
+	// The requests are unknown without series
+	response = `{"status": "success", "data": {"resultType": "vector", "result": []}}`
+	_, err = counter.CountRequests(t.Context(), isvc, 24*time.Hour)
+	g.Expect(err).To(gomega.MatchError(ErrNoRequestSeries))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
+)
+
+// PrometheusRequestCounter counts the requests of the InferenceServices with an instant Prometheus query
+type PrometheusRequestCounter struct {
+	querier *prometheus.Querier
+	query   string
+}
+
+// NewPrometheusRequestCounter returns a counter evaluating the query template
+func NewPrometheusRequestCounter(querier *prometheus.Querier, query string) *PrometheusRequestCounter {
+	return &PrometheusRequestCounter{
+		querier: querier,
+		query:   query,
+	}
+}
+
+// ErrNoRequestSeries is returned when the query has no series, the requests of the InferenceService are unknown
+// rather than zero, e.g. the requests not counted by the query or the series not scraped yet
+var ErrNoRequestSeries = errors.New("the query returned no series")
+
+// CountRequests returns the sum of the samples of the query, or ErrNoRequestSeries when the query has no series
+func (c *PrometheusRequestCounter) CountRequests(ctx context.Context, isvc *v1beta1.InferenceService, window time.Duration) (float64, error) {
+	query, err := v1beta1.RenderIdleRequestCountQuery(c.query, v1beta1.IdleQueryParameters{
+		Namespace:        isvc.Namespace,
+		InferenceService: isvc.Name,
+		Window:           model.Duration(window).String(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	samples, err := c.querier.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	if len(samples) == 0 {
+		return 0, fmt.Errorf("%w for %q", ErrNoRequestSeries, query)
+	}
+	requests := 0.0
+	for _, sample := range samples {
+		requests += sample
+	}
+	return requests, nil
+}
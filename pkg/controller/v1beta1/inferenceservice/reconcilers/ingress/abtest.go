@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// modelVariant is a variant of the A/B test of an InferenceService routed to
+type modelVariant struct {
+	name string
+	// isvc serving the variant, the InferenceService of the A/B test itself for the variant without target
+	isvc    *v1beta1.InferenceService
+	percent int32
+}
+
+// getModelVariants returns the ready variants of the A/B test of the InferenceService with their share of the
+// traffic, the weights of the variants which are not ready being shifted to the ready variants. It returns nil when
+// the InferenceService has no A/B test or none of its variants with a weight is ready, so that the traffic is routed
+// to the InferenceService itself. The variants are reported in the status of the InferenceService.
+func getModelVariants(ctx context.Context, cl client.Client, isvc *v1beta1.InferenceService) ([]modelVariant, error) {
+	if isvc.Spec.ABTest == nil {
+		isvc.Status.Variants = nil
+		return nil, nil
+	}
+	var variants []modelVariant
+	var statuses []v1beta1.ModelVariantStatus
+	var readyWeight int32
+	for _, spec := range isvc.Spec.ABTest.Variants {
+		target := isvc
+		ready := isPrimaryReady(isvc)
+		if spec.TargetRef != nil {
+			target = &v1beta1.InferenceService{}
+			if err := cl.Get(ctx, types.NamespacedName{Name: spec.TargetRef.Name, Namespace: isvc.Namespace}, target); err != nil {
+				if !apierr.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get the InferenceService %s of the variant %s: %w", spec.TargetRef.Name, spec.Name, err)
+				}
+				target = &v1beta1.InferenceService{}
+				target.Name = spec.TargetRef.Name
+				target.Namespace = isvc.Namespace
+			}
+			ready = target.Status.IsReady()
+		}
+		status := v1beta1.ModelVariantStatus{
+			Name:             spec.Name,
+			InferenceService: target.Name,
+			URL:              target.Status.URL.DeepCopy(),
+			Ready:            ready,
+		}
+		statuses = append(statuses, status)
+		if ready {
+			readyWeight += spec.Weight
+			variants = append(variants, modelVariant{name: spec.Name, isvc: target, percent: spec.Weight})
+		}
+	}
+	isvc.Status.Variants = statuses
+	if readyWeight == 0 {
+		return nil, nil
+	}
+
+	// scale the weights of the ready variants to 100, the rounding remainder goes to the first variants with a weight
+	var total int32
+	for i := range variants {
+		variants[i].percent = variants[i].percent * 100 / readyWeight
+		total += variants[i].percent
+	}
+	for i := 0; total < 100; i = (i + 1) % len(variants) {
+		if variants[i].percent > 0 {
+			variants[i].percent++
+			total++
+		}
+	}
+	for _, variant := range variants {
+		for i := range isvc.Status.Variants {
+			if isvc.Status.Variants[i].Name == variant.name {
+				isvc.Status.Variants[i].Percent = variant.percent
+			}
+		}
+	}
+	return variants, nil
+}
+
+// componentBackend returns the name of the service receiving the inference traffic of an InferenceService
+func componentBackend(isvc *v1beta1.InferenceService) string {
+	if isvc.Spec.Transformer != nil {
+		return constants.TransformerServiceName(isvc.Name)
+	}
+	return constants.PredictorServiceName(isvc.Name)
+}
+
+// sessionCookieRegex matches the cookie header carrying the variant of the session
+func sessionCookieRegex(name, variant string) string {
+	return fmt.Sprintf(`^(.*;\s*)?%s=%s(;.*)?$`, regexp.QuoteMeta(name), regexp.QuoteMeta(variant))
+}
+
+// sessionResponseHeader returns the response header pinning the session to the variant
+func sessionResponseHeader(affinity *v1beta1.SessionAffinitySpec, variant string) (string, string) {
+	if affinity.Type == v1beta1.CookieSessionAffinity {
+		return "Set-Cookie", fmt.Sprintf("%s=%s; Path=/", affinity.GetName(), variant)
+	}
+	return affinity.GetName(), variant
+}
+
+// splitVirtualServiceVariants splits the routes of the virtual service to the backend of the InferenceService across
+// the variants of its A/B test. Every variant is routed through the local gateway to the virtual service of its
+// InferenceService, or to the backend of the InferenceService itself. With the session affinity, the requests
+// carrying a variant are routed to it by the routes preceding the weighted route.
+func splitVirtualServiceVariants(isvc *v1beta1.InferenceService, variants []modelVariant, config *v1beta1.IngressConfig,
+	vs *istioclientv1beta1.VirtualService,
+) {
+	if vs == nil || len(variants) == 0 {
+		return
+	}
+	backendHost := network.GetServiceHostname(componentBackend(isvc), isvc.Namespace)
+	affinity := isvc.Spec.ABTest.SessionAffinity
+	destination := func(variant modelVariant) *istiov1beta1.HTTPRouteDestination {
+		host := network.GetServiceHostname(variant.isvc.Name, variant.isvc.Namespace)
+		if variant.isvc == isvc {
+			host = backendHost
+		}
+		routeDestination := createHTTPRouteDestination(config.KnativeLocalGatewayService)
+		routeDestination.Headers = &istiov1beta1.Headers{
+			Request: &istiov1beta1.Headers_HeaderOperations{
+				Set: map[string]string{
+					"Host":                        host,
+					constants.IsvcNameHeader:      variant.isvc.Name,
+					constants.IsvcNamespaceHeader: variant.isvc.Namespace,
+				},
+			},
+		}
+		if affinity != nil {
+			name, value := sessionResponseHeader(affinity, variant.name)
+			routeDestination.Headers.Response = &istiov1beta1.Headers_HeaderOperations{
+				Add: map[string]string{name: value},
+			}
+		}
+		return routeDestination
+	}
+
+	var routes []*istiov1beta1.HTTPRoute
+	for _, route := range vs.Spec.Http {
+		if route.Headers == nil || route.Headers.Request == nil || route.Headers.Request.Set["Host"] != backendHost {
+			routes = append(routes, route)
+			continue
+		}
+		if affinity != nil {
+			for _, variant := range variants {
+				sessionRoute := route.DeepCopy()
+				sessionRoute.Headers = nil
+				sessionRoute.Route = []*istiov1beta1.HTTPRouteDestination{destination(variant)}
+				for _, match := range sessionRoute.Match {
+					if match.Headers == nil {
+						match.Headers = map[string]*istiov1beta1.StringMatch{}
+					}
+					if affinity.Type == v1beta1.CookieSessionAffinity {
+						match.Headers["cookie"] = &istiov1beta1.StringMatch{
+							MatchType: &istiov1beta1.StringMatch_Regex{Regex: sessionCookieRegex(affinity.GetName(), variant.name)},
+						}
+					} else {
+						match.Headers[affinity.GetName()] = &istiov1beta1.StringMatch{
+							MatchType: &istiov1beta1.StringMatch_Exact{Exact: variant.name},
+						}
+					}
+				}
+				routes = append(routes, sessionRoute)
+			}
+		}
+		weightedRoute := route.DeepCopy()
+		weightedRoute.Headers = nil
+		weightedRoute.Route = nil
+		for _, variant := range variants {
+			if variant.percent == 0 {
+				continue
+			}
+			routeDestination := destination(variant)
+			routeDestination.Weight = variant.percent
+			weightedRoute.Route = append(weightedRoute.Route, routeDestination)
+		}
+		routes = append(routes, weightedRoute)
+	}
+	vs.Spec.Http = routes
+}
+
+// splitHTTPRouteVariants splits the rules of the top level http route to the backend of the InferenceService across
+// the variants of its A/B test. Every variant is routed to the backend of its InferenceService. With the session
+// affinity, the requests carrying a variant are routed to it by the rules preceding the weighted rule.
+func splitHTTPRouteVariants(isvc *v1beta1.InferenceService, variants []modelVariant, httpRoute *gwapiv1.HTTPRoute) {
+	if httpRoute == nil || len(variants) == 0 {
+		return
+	}
+	backend := componentBackend(isvc)
+	affinity := isvc.Spec.ABTest.SessionAffinity
+	backendRef := func(variant modelVariant) gwapiv1.HTTPBackendRef {
+		port := gwapiv1.PortNumber(constants.CommonDefaultHttpPort)
+		ref := gwapiv1.HTTPBackendRef{
+			BackendRef: gwapiv1.BackendRef{
+				BackendObjectReference: gwapiv1.BackendObjectReference{
+					Kind:      ptr.To(gwapiv1.Kind(constants.ServiceKind)),
+					Name:      gwapiv1.ObjectName(componentBackend(variant.isvc)),
+					Namespace: ptr.To(gwapiv1.Namespace(variant.isvc.Namespace)),
+					Port:      &port,
+				},
+				Weight: ptr.To(variant.percent),
+			},
+			Filters: []gwapiv1.HTTPRouteFilter{addIsvcHeaders(variant.isvc.Name, variant.isvc.Namespace)},
+		}
+		if affinity != nil {
+			name, value := sessionResponseHeader(affinity, variant.name)
+			ref.Filters = append(ref.Filters, gwapiv1.HTTPRouteFilter{
+				Type: gwapiv1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &gwapiv1.HTTPHeaderFilter{
+					Add: []gwapiv1.HTTPHeader{{Name: gwapiv1.HTTPHeaderName(name), Value: value}},
+				},
+			})
+		}
+		return ref
+	}
+
+	var rules []gwapiv1.HTTPRouteRule
+	for _, rule := range httpRoute.Spec.Rules {
+		if len(rule.BackendRefs) != 1 || string(rule.BackendRefs[0].Name) != backend {
+			rules = append(rules, rule)
+			continue
+		}
+		if affinity != nil {
+			for _, variant := range variants {
+				sessionRule := *rule.DeepCopy()
+				sessionRule.Filters = nil
+				sessionRule.BackendRefs = []gwapiv1.HTTPBackendRef{backendRef(variant)}
+				sessionRule.BackendRefs[0].Weight = nil
+				for i := range sessionRule.Matches {
+					if affinity.Type == v1beta1.CookieSessionAffinity {
+						sessionRule.Matches[i].Headers = append(sessionRule.Matches[i].Headers, gwapiv1.HTTPHeaderMatch{
+							Type:  ptr.To(gwapiv1.HeaderMatchRegularExpression),
+							Name:  "Cookie",
+							Value: sessionCookieRegex(affinity.GetName(), variant.name),
+						})
+					} else {
+						sessionRule.Matches[i].Headers = append(sessionRule.Matches[i].Headers, gwapiv1.HTTPHeaderMatch{
+							Type:  ptr.To(gwapiv1.HeaderMatchExact),
+							Name:  gwapiv1.HTTPHeaderName(affinity.GetName()),
+							Value: variant.name,
+						})
+					}
+				}
+				rules = append(rules, sessionRule)
+			}
+		}
+		weightedRule := *rule.DeepCopy()
+		weightedRule.Filters = nil
+		weightedRule.BackendRefs = nil
+		for _, variant := range variants {
+			if variant.percent > 0 {
+				weightedRule.BackendRefs = append(weightedRule.BackendRefs, backendRef(variant))
+			}
+		}
+		rules = append(rules, weightedRule)
+	}
+	httpRoute.Spec.Rules = rules
+}
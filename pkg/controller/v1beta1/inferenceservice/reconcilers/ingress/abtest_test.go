@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newVariantInferenceService(name string, ready bool) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.IngressReady} {
+		isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: status})
+	}
+	return isvc
+}
+
+func TestGetModelVariants(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		weights  []int32
+		targets  []client.Object
+		expected map[string]int32
+	}{
+		"all variants ready": {
+			weights:  []int32{70, 30},
+			targets:  []client.Object{newVariantInferenceService("model-b", true)},
+			expected: map[string]int32{"a": 70, "b": 30},
+		},
+		"missing variant": {
+			weights:  []int32{70, 30},
+			expected: map[string]int32{"a": 100},
+		},
+		"weights of the variant not ready shifted to the ready variants": {
+			weights: []int32{50, 25, 25},
+			targets: []client.Object{
+				newVariantInferenceService("model-b", true),
+				newVariantInferenceService("model-c", false),
+			},
+			expected: map[string]int32{"a": 67, "b": 33},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.targets...).Build()
+
+			isvc := newVariantInferenceService("model", true)
+			isvc.Spec.ABTest = &v1beta1.ABTestSpec{}
+			for i, weight := range scenario.weights {
+				variant := v1beta1.ModelVariant{Name: string(rune('a' + i)), Weight: weight}
+				if i > 0 {
+					variant.TargetRef = &v1beta1.ModelVariantTargetReference{Name: "model-" + variant.Name}
+				}
+				isvc.Spec.ABTest.Variants = append(isvc.Spec.ABTest.Variants, variant)
+			}
+
+			variants, err := getModelVariants(context.Background(), cl, isvc)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			percents := map[string]int32{}
+			for _, variant := range variants {
+				percents[variant.name] = variant.percent
+			}
+			g.Expect(percents).To(gomega.Equal(scenario.expected))
+			g.Expect(isvc.Status.Variants).To(gomega.HaveLen(len(scenario.weights)))
+			for _, status := range isvc.Status.Variants {
+				g.Expect(status.Ready).To(gomega.Equal(scenario.expected[status.Name] > 0))
+				g.Expect(status.Percent).To(gomega.Equal(scenario.expected[status.Name]))
+			}
+		})
+	}
+}
+
+func TestSplitHTTPRouteVariants(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := newVariantInferenceService("model", true)
+	isvc.Spec.ABTest = &v1beta1.ABTestSpec{
+		SessionAffinity: &v1beta1.SessionAffinitySpec{Type: v1beta1.HeaderSessionAffinity},
+	}
+	candidate := newVariantInferenceService("model-b", true)
+	httpRoute := &gwapiv1.HTTPRoute{
+		Spec: gwapiv1.HTTPRouteSpec{
+			Rules: []gwapiv1.HTTPRouteRule{
+				{
+					Matches: []gwapiv1.HTTPRouteMatch{{Path: &gwapiv1.HTTPPathMatch{Value: ptr.To("/")}}},
+					BackendRefs: []gwapiv1.HTTPBackendRef{{
+						BackendRef: gwapiv1.BackendRef{
+							BackendObjectReference: gwapiv1.BackendObjectReference{
+								Name: gwapiv1.ObjectName(constants.PredictorServiceName("model")),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	splitHTTPRouteVariants(isvc, []modelVariant{
+		{name: "a", isvc: isvc, percent: 80},
+		{name: "b", isvc: candidate, percent: 20},
+	}, httpRoute)
+
+	// a session rule per variant precedes the weighted rule
+	rules := httpRoute.Spec.Rules
+	g.Expect(rules).To(gomega.HaveLen(3))
+	for i, variant := range []string{"a", "b"} {
+		g.Expect(rules[i].Matches[0].Headers).To(gomega.ConsistOf(gwapiv1.HTTPHeaderMatch{
+			Type:  ptr.To(gwapiv1.HeaderMatchExact),
+			Name:  constants.DefaultVariantHeaderName,
+			Value: variant,
+		}))
+		g.Expect(rules[i].BackendRefs).To(gomega.HaveLen(1))
+		g.Expect(rules[i].BackendRefs[0].Weight).To(gomega.BeNil())
+	}
+	g.Expect(rules[1].BackendRefs[0].Name).To(gomega.BeEquivalentTo(constants.PredictorServiceName("model-b")))
+	g.Expect(rules[2].Matches[0].Headers).To(gomega.BeEmpty())
+	g.Expect(rules[2].BackendRefs).To(gomega.HaveLen(2))
+	g.Expect(rules[2].BackendRefs[0].Weight).To(gomega.Equal(ptr.To(int32(80))))
+	g.Expect(rules[2].BackendRefs[1].Weight).To(gomega.Equal(ptr.To(int32(20))))
+	g.Expect(rules[2].BackendRefs[1].Filters[1].ResponseHeaderModifier.Add).To(gomega.ConsistOf(gwapiv1.HTTPHeader{
+		Name:  constants.DefaultVariantHeaderName,
+		Value: "b",
+	}))
+}
@@ -62,6 +62,11 @@ func GenerateDomainName(name string, obj metav1.ObjectMeta, ingressConfig *v1bet
 	if urlErrs != nil {
 		return "", fmt.Errorf("invalid domain name %q: %w", buf.String(), urlErrs.ToAggregate())
 	}
+	// A domain template overridden by a namespace must keep the hosts in the subdomain of the namespace
+	if ingressConfig.NamespaceSubdomain != "" && !strings.HasSuffix(buf.String(), "."+ingressConfig.NamespaceSubdomain) {
+		return "", fmt.Errorf("the domain template %q renders the host %q outside of the %s subdomain",
+			ingressConfig.DomainTemplate, buf.String(), ingressConfig.NamespaceSubdomain)
+	}
 
 	return buf.String(), nil
 }
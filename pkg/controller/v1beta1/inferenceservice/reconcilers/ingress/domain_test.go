@@ -122,6 +122,32 @@ func TestGenerateDomainName(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "namespace template within the namespace subdomain",
+			args: args{
+				name: "model",
+				obj:  obj,
+				ingressConfig: &v1beta1.IngressConfig{
+					IngressDomain:      v1beta1.DefaultIngressDomain,
+					DomainTemplate:     "{{ .Name }}.{{ .Labels.label }}.{{ .Namespace }}.{{ .IngressDomain }}",
+					NamespaceSubdomain: "test.example.com",
+				},
+			},
+			want: "model.label-value.test.example.com",
+		},
+		{
+			name: "namespace template outside of the namespace subdomain",
+			args: args{
+				name: "model",
+				obj:  obj,
+				ingressConfig: &v1beta1.IngressConfig{
+					IngressDomain:      v1beta1.DefaultIngressDomain,
+					DomainTemplate:     "{{ .Name }}.{{ .Annotations.annotation }}.{{ .IngressDomain }}",
+					NamespaceSubdomain: "test.example.com",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// Failover condition reasons
+const (
+	FailoverPrimaryReadyReason   = "PrimaryReady"
+	FailoverTargetNotReadyReason = "FailoverTargetNotReady"
+	FailoverTargetNotFoundReason = "FailoverTargetNotFound"
+)
+
+// isPrimaryReady returns true when the components serving the inference traffic of the InferenceService are ready
+func isPrimaryReady(isvc *v1beta1.InferenceService) bool {
+	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		return false
+	}
+	if isvc.Spec.Transformer != nil && !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
+		return false
+	}
+	return true
+}
+
+// getFailoverTarget returns the failover target of the InferenceService when the InferenceService is not ready and
+// the failover target is ready, otherwise it returns nil. The FailoverActive condition is updated accordingly.
+func getFailoverTarget(ctx context.Context, cl client.Client, isvc *v1beta1.InferenceService) (*v1beta1.InferenceService, error) {
+	if isvc.Spec.Failover == nil {
+		isvc.Status.ClearCondition(v1beta1.FailoverActive)
+		return nil, nil
+	}
+	if isPrimaryReady(isvc) {
+		isvc.Status.SetCondition(v1beta1.FailoverActive, &apis.Condition{
+			Type:   v1beta1.FailoverActive,
+			Status: corev1.ConditionFalse,
+			Reason: FailoverPrimaryReadyReason,
+		})
+		return nil, nil
+	}
+
+	targetName := isvc.Spec.Failover.TargetRef.Name
+	target := &v1beta1.InferenceService{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: targetName, Namespace: isvc.Namespace}, target); err != nil {
+		if apierr.IsNotFound(err) {
+			isvc.Status.SetCondition(v1beta1.FailoverActive, &apis.Condition{
+				Type:    v1beta1.FailoverActive,
+				Status:  corev1.ConditionFalse,
+				Reason:  FailoverTargetNotFoundReason,
+				Message: fmt.Sprintf("failover target %q is not found", targetName),
+			})
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get failover target %s: %w", targetName, err)
+	}
+	if !target.Status.IsReady() {
+		isvc.Status.SetCondition(v1beta1.FailoverActive, &apis.Condition{
+			Type:    v1beta1.FailoverActive,
+			Status:  corev1.ConditionFalse,
+			Reason:  FailoverTargetNotReadyReason,
+			Message: fmt.Sprintf("failover target %q is not ready", targetName),
+		})
+		return nil, nil
+	}
+	log.Info("Routing traffic to the failover target", "isvc", isvc.Name, "namespace", isvc.Namespace, "target", targetName)
+	isvc.Status.SetCondition(v1beta1.FailoverActive, &apis.Condition{
+		Type:   v1beta1.FailoverActive,
+		Status: corev1.ConditionTrue,
+	})
+	return target, nil
+}
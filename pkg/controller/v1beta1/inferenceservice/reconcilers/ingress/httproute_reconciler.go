@@ -344,29 +344,13 @@ func createRawExplainerHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *
 	return &httpRoute, nil
 }
 
-func createRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
-	isvcConfig *v1beta1.InferenceServicesConfig,
-) (*gwapiv1.HTTPRoute, error) {
-	var httpRouteRules []gwapiv1.HTTPRouteRule
-	var allowedHosts []gwapiv1.Hostname
-
-	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
-		isvc.Status.SetCondition(v1beta1.IngressReady, &knapis.Condition{
-			Type:   v1beta1.IngressReady,
-			Status: corev1.ConditionFalse,
-			Reason: "Predictor ingress not created",
-		})
-		return nil, nil
-	}
-	predictorName := constants.PredictorServiceName(isvc.Name)
-	transformerName := constants.TransformerServiceName(isvc.Name)
-	explainerName := constants.ExplainerServiceName(isvc.Name)
-
+// getRawTopLevelHosts returns the top level host and the additional hosts of the InferenceService
+func getRawTopLevelHosts(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) ([]gwapiv1.Hostname, error) {
 	topLevelHost, err := GenerateDomainName(isvc.Name, isvc.ObjectMeta, ingressConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate top level ingress host: %w", err)
 	}
-	allowedHosts = append(allowedHosts, gwapiv1.Hostname(topLevelHost))
+	allowedHosts := []gwapiv1.Hostname{gwapiv1.Hostname(topLevelHost)}
 	domainList := []string{ingressConfig.IngressDomain}
 	additionalHosts := GetAdditionalHosts(&domainList, topLevelHost, ingressConfig)
 	// Add additional hosts to allowed hosts
@@ -382,6 +366,30 @@ func createRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v
 			}
 		}
 	}
+	return allowedHosts, nil
+}
+
+func createRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	isvcConfig *v1beta1.InferenceServicesConfig,
+) (*gwapiv1.HTTPRoute, error) {
+	var httpRouteRules []gwapiv1.HTTPRouteRule
+
+	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		isvc.Status.SetCondition(v1beta1.IngressReady, &knapis.Condition{
+			Type:   v1beta1.IngressReady,
+			Status: corev1.ConditionFalse,
+			Reason: "Predictor ingress not created",
+		})
+		return nil, nil
+	}
+	predictorName := constants.PredictorServiceName(isvc.Name)
+	transformerName := constants.TransformerServiceName(isvc.Name)
+	explainerName := constants.ExplainerServiceName(isvc.Name)
+
+	allowedHosts, err := getRawTopLevelHosts(isvc, ingressConfig)
+	if err != nil {
+		return nil, err
+	}
 	// Add isvc name and namespace headers
 	filters := []gwapiv1.HTTPRouteFilter{addIsvcHeaders(isvc.Name, isvc.Namespace)}
 
@@ -476,6 +484,53 @@ func createRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v
 		}
 	}
 
+	return newRawTopLevelHTTPRoute(isvc, ingressConfig, isvcConfig, allowedHosts, httpRouteRules), nil
+}
+
+// createRawFailoverTopLevelHTTPRoute creates the top level http route which sends all the traffic of the
+// InferenceService to its failover target.
+func createRawFailoverTopLevelHTTPRoute(isvc *v1beta1.InferenceService, target *v1beta1.InferenceService,
+	ingressConfig *v1beta1.IngressConfig, isvcConfig *v1beta1.InferenceServicesConfig,
+) (*gwapiv1.HTTPRoute, error) {
+	allowedHosts, err := getRawTopLevelHosts(isvc, ingressConfig)
+	if err != nil {
+		return nil, err
+	}
+	backend := constants.PredictorServiceName(target.Name)
+	timeout := DefaultTimeout
+	if target.Spec.Predictor.TimeoutSeconds != nil {
+		timeout = toGatewayAPIDuration(*target.Spec.Predictor.TimeoutSeconds)
+	}
+	if target.Spec.Transformer != nil {
+		backend = constants.TransformerServiceName(target.Name)
+		timeout = DefaultTimeout
+		if target.Spec.Transformer.TimeoutSeconds != nil {
+			timeout = toGatewayAPIDuration(*target.Spec.Transformer.TimeoutSeconds)
+		}
+	}
+	filters := []gwapiv1.HTTPRouteFilter{addIsvcHeaders(target.Name, target.Namespace)}
+	routeMatch := []gwapiv1.HTTPRouteMatch{createHTTPRouteMatch(constants.FallbackPrefix())}
+	httpRouteRules := []gwapiv1.HTTPRouteRule{
+		createHTTPRouteRule(routeMatch, filters, backend, target.Namespace, constants.CommonDefaultHttpPort, timeout),
+	}
+	// Add path based routing rule
+	if ingressConfig.PathTemplate != "" {
+		path, err := GenerateUrlPath(isvc.Name, isvc.Namespace, ingressConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate URL from pathTemplate: %w", err)
+		}
+		path = strings.TrimSuffix(path, "/") // remove trailing "/" if present
+		allowedHosts = append(allowedHosts, gwapiv1.Hostname(ingressConfig.IngressDomain))
+		pathRouteMatch := []gwapiv1.HTTPRouteMatch{createHTTPRouteMatch(path + "/")}
+		httpRouteRules = append(httpRouteRules, createHTTPRouteRule(pathRouteMatch, filters, backend, target.Namespace,
+			constants.CommonDefaultHttpPort, timeout))
+	}
+	return newRawTopLevelHTTPRoute(isvc, ingressConfig, isvcConfig, allowedHosts, httpRouteRules), nil
+}
+
+func newRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	isvcConfig *v1beta1.InferenceServicesConfig, allowedHosts []gwapiv1.Hostname, httpRouteRules []gwapiv1.HTTPRouteRule,
+) *gwapiv1.HTTPRoute {
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
 		return !utils.Includes(isvcConfig.ServiceAnnotationDisallowedList, key)
 	})
@@ -505,7 +560,7 @@ func createRawTopLevelHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v
 			},
 		},
 	}
-	return &httpRoute, nil
+	return &httpRoute
 }
 
 func semanticHttpRouteEquals(desired, existing *gwapiv1.HTTPRoute) bool {
@@ -713,7 +768,21 @@ func (r *RawHTTPRouteReconciler) reconcileExplainerHTTPRoute(ctx context.Context
 }
 
 func (r *RawHTTPRouteReconciler) reconcileTopLevelHTTPRoute(ctx context.Context, isvc *v1beta1.InferenceService) error {
-	desired, err := createRawTopLevelHTTPRoute(isvc, r.ingressConfig, r.isvcConfig)
+	failoverTarget, err := getFailoverTarget(ctx, r.client, isvc)
+	if err != nil {
+		return err
+	}
+	variants, err := getModelVariants(ctx, r.client, isvc)
+	if err != nil {
+		return err
+	}
+	var desired *gwapiv1.HTTPRoute
+	if failoverTarget != nil {
+		desired, err = createRawFailoverTopLevelHTTPRoute(isvc, failoverTarget, r.ingressConfig, r.isvcConfig)
+	} else {
+		desired, err = createRawTopLevelHTTPRoute(isvc, r.ingressConfig, r.isvcConfig)
+		splitHTTPRouteVariants(isvc, variants, desired)
+	}
 	if err != nil {
 		return err
 	}
@@ -855,8 +924,18 @@ func (r *RawHTTPRouteReconciler) reconcileHTTPRouteStatus(ctx context.Context, i
 	return ctrl.Result{}, nil
 }
 
-// ReconcileHTTPRoute reconciles the HTTPRoute resource
+// Reconcile reconciles the HTTPRoute resources of the InferenceService using the gateway selected for its namespace
 func (r *RawHTTPRouteReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	ingressConfig, err := resolveNamespaceIngressConfig(ctx, r.client, isvc.Namespace, r.ingressConfig)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	namespaced := *r
+	namespaced.ingressConfig = ingressConfig
+	return namespaced.reconcile(ctx, isvc)
+}
+
+func (r *RawHTTPRouteReconciler) reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
 	var err error
 	isInternal := false
 	// disable ingress creation if service is labelled with cluster local or kserve domain is cluster local
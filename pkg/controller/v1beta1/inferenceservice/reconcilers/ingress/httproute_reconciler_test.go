@@ -2365,6 +2365,105 @@ func TestRawHTTPRouteReconciler_reconcileTopLevelHTTPRoute(t *testing.T) {
 		}, route)
 		g.Expect(apierr.IsNotFound(err)).To(BeTrue())
 	})
+
+	t.Run("routes to the failover target if predictor not ready", func(t *testing.T) {
+		isvc := &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-isvc4",
+				Namespace: "default",
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{},
+				Failover: &v1beta1.FailoverSpec{
+					TargetRef: v1beta1.FailoverTargetReference{Name: "backup-isvc"},
+				},
+			},
+			Status: v1beta1.InferenceServiceStatus{},
+		}
+		target := &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-isvc",
+				Namespace: "default",
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{},
+			},
+			Status: v1beta1.InferenceServiceStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{
+						{
+							Type:   apis.ConditionReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(s).WithObjects(target).Build()
+		reconciler := &RawHTTPRouteReconciler{
+			client:        client,
+			scheme:        s,
+			ingressConfig: ingressConfig,
+			isvcConfig:    isvcConfig,
+		}
+		err := reconciler.reconcileTopLevelHTTPRoute(t.Context(), isvc)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isvc.Status.IsConditionReady(v1beta1.FailoverActive)).To(BeTrue())
+
+		route := &gwapiv1.HTTPRoute{}
+		err = client.Get(t.Context(), types.NamespacedName{
+			Name:      "test-isvc4",
+			Namespace: "default",
+		}, route)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(route.Spec.Hostnames).To(ContainElement(gwapiv1.Hostname("test-isvc4-default.example.com")))
+		g.Expect(route.Spec.Rules).To(HaveLen(1))
+		g.Expect(route.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+		g.Expect(route.Spec.Rules[0].BackendRefs[0].Name).To(Equal(gwapiv1.ObjectName("backup-isvc-predictor")))
+	})
+
+	t.Run("does not route to the failover target if it is not ready", func(t *testing.T) {
+		isvc := &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-isvc5",
+				Namespace: "default",
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{},
+				Failover: &v1beta1.FailoverSpec{
+					TargetRef: v1beta1.FailoverTargetReference{Name: "backup-isvc"},
+				},
+			},
+			Status: v1beta1.InferenceServiceStatus{},
+		}
+		target := &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-isvc",
+				Namespace: "default",
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(s).WithObjects(target).Build()
+		reconciler := &RawHTTPRouteReconciler{
+			client:        client,
+			scheme:        s,
+			ingressConfig: ingressConfig,
+			isvcConfig:    isvcConfig,
+		}
+		err := reconciler.reconcileTopLevelHTTPRoute(t.Context(), isvc)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isvc.Status.IsConditionReady(v1beta1.FailoverActive)).To(BeFalse())
+		g.Expect(isvc.Status.GetCondition(v1beta1.FailoverActive).Reason).To(Equal(FailoverTargetNotReadyReason))
+
+		route := &gwapiv1.HTTPRoute{}
+		err = client.Get(t.Context(), types.NamespacedName{
+			Name:      "test-isvc5",
+			Namespace: "default",
+		}, route)
+		g.Expect(apierr.IsNotFound(err)).To(BeTrue())
+	})
 }
 
 func TestRawHTTPRouteReconciler_Reconcile(t *testing.T) {
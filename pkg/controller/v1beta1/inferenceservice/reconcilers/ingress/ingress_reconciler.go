@@ -74,6 +74,16 @@ func NewIngressReconciler(client client.Client, clientset kubernetes.Interface,
 }
 
 func (ir *IngressReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	ingressConfig, err := resolveNamespaceIngressConfig(ctx, ir.client, isvc.Namespace, ir.ingressConfig)
+	if err != nil {
+		return err
+	}
+	namespaced := *ir
+	namespaced.ingressConfig = ingressConfig
+	return namespaced.reconcile(ctx, isvc)
+}
+
+func (ir *IngressReconciler) reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	disableIstioVirtualHost := ir.ingressConfig.DisableIstioVirtualHost
 
 	if err := ir.reconcileVirtualService(ctx, isvc); err != nil {
@@ -232,7 +242,21 @@ func (ir *IngressReconciler) reconcileVirtualService(ctx context.Context, isvc *
 	disableIstioVirtualHost := ir.ingressConfig.DisableIstioVirtualHost
 
 	domainList := getDomainList(ctx, ir.clientset)
-	desiredIngress := createIngress(isvc, ir.ingressConfig, domainList, ir.isvcConfig) // actually the virtual service
+	failoverTarget, err := getFailoverTarget(ctx, ir.client, isvc)
+	if err != nil {
+		return err
+	}
+	variants, err := getModelVariants(ctx, ir.client, isvc)
+	if err != nil {
+		return err
+	}
+	var desiredIngress *istioclientv1beta1.VirtualService
+	if failoverTarget != nil {
+		desiredIngress = createFailoverIngress(isvc, failoverTarget, ir.ingressConfig, domainList, ir.isvcConfig)
+	} else {
+		desiredIngress = createIngress(isvc, ir.ingressConfig, domainList, ir.isvcConfig)
+		splitVirtualServiceVariants(isvc, variants, ir.ingressConfig, desiredIngress)
+	}
 
 	existing := &istioclientv1beta1.VirtualService{}
 	getExistingErr := ir.client.Get(ctx, types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}, existing)
@@ -539,7 +563,7 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 		httpRoutes = append(httpRoutes, &explainerRouter)
 	}
 	// Add predict route
-	httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
+	predictRoute := &istiov1beta1.HTTPRoute{
 		Match: createHTTPMatchRequest("", serviceHost,
 			network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts, isInternal, config),
 		Route: []*istiov1beta1.HTTPRouteDestination{
@@ -554,7 +578,9 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 				},
 			},
 		},
-	})
+	}
+	setShadowMirror(isvc, predictRoute)
+	httpRoutes = append(httpRoutes, predictRoute)
 
 	gateways := []string{
 		config.LocalGateway,
@@ -612,7 +638,7 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 				},
 			})
 		}
-		httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
+		pathPredictRoute := &istiov1beta1.HTTPRoute{
 			Match: []*istiov1beta1.HTTPMatchRequest{
 				{
 					Uri: &istiov1beta1.StringMatch{
@@ -656,7 +682,9 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 					},
 				},
 			},
-		})
+		}
+		setShadowMirror(isvc, pathPredictRoute)
+		httpRoutes = append(httpRoutes, pathPredictRoute)
 		// Include ingressDomain to the domains (both internal and external) derived by Knative
 		hosts = append(hosts, url.Host)
 	}
@@ -694,6 +722,106 @@ func createIngress(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig
 	return desiredIngress
 }
 
+// createFailoverIngress creates the virtual service which sends all the traffic of the InferenceService to the
+// virtual service of its failover target through the local gateway.
+func createFailoverIngress(isvc *v1beta1.InferenceService, target *v1beta1.InferenceService, config *v1beta1.IngressConfig,
+	domainList *[]string, isvcConfig *v1beta1.InferenceServicesConfig,
+) *istioclientv1beta1.VirtualService {
+	serviceHost := getServiceHost(isvc)
+	if serviceHost == "" {
+		return nil
+	}
+	isInternal := false
+	if val, ok := isvc.Labels[constants.VisibilityLabel]; ok && val == constants.ClusterLocalVisibility {
+		isInternal = true
+	}
+	serviceInternalHostName := network.GetServiceHostname(isvc.Name, isvc.Namespace)
+	if serviceHost == serviceInternalHostName {
+		isInternal = true
+	}
+	var additionalHosts *[]string
+	hosts := []string{serviceInternalHostName}
+	gateways := []string{config.LocalGateway, constants.IstioMeshGateway}
+	if !isInternal {
+		additionalHosts = GetAdditionalHosts(domainList, serviceHost, config)
+		hosts = append(hosts, serviceHost)
+		gateways = append(gateways, config.IngressGateway)
+		if additionalHosts != nil {
+			for _, additionalHost := range *additionalHosts {
+				if !utils.Includes(hosts, additionalHost) {
+					hosts = append(hosts, additionalHost)
+				}
+			}
+		}
+	}
+	headers := &istiov1beta1.Headers{
+		Request: &istiov1beta1.Headers_HeaderOperations{
+			Set: map[string]string{
+				"Host":                        network.GetServiceHostname(target.Name, target.Namespace),
+				constants.IsvcNameHeader:      target.Name,
+				constants.IsvcNamespaceHeader: target.Namespace,
+			},
+		},
+	}
+	httpRoutes := []*istiov1beta1.HTTPRoute{
+		{
+			Match:   createHTTPMatchRequest("", serviceHost, serviceInternalHostName, additionalHosts, isInternal, config),
+			Route:   []*istiov1beta1.HTTPRouteDestination{createHTTPRouteDestination(config.KnativeLocalGatewayService)},
+			Headers: headers,
+		},
+	}
+	if config.PathTemplate != "" {
+		path, err := GenerateUrlPath(isvc.Name, isvc.Namespace, config)
+		if err != nil {
+			log.Error(err, "Failed to generate URL from pathTemplate")
+			return nil
+		}
+		path = strings.TrimSuffix(path, "/") // remove trailing "/" if present
+		httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
+			Match: []*istiov1beta1.HTTPMatchRequest{
+				{
+					Uri: &istiov1beta1.StringMatch{
+						MatchType: &istiov1beta1.StringMatch_Prefix{Prefix: path + "/"},
+					},
+					Authority: &istiov1beta1.StringMatch{
+						MatchType: &istiov1beta1.StringMatch_Regex{Regex: constants.HostRegExp(config.IngressDomain)},
+					},
+					Gateways: []string{config.IngressGateway},
+				},
+				{
+					Uri: &istiov1beta1.StringMatch{
+						MatchType: &istiov1beta1.StringMatch_Exact{Exact: path},
+					},
+					Authority: &istiov1beta1.StringMatch{
+						MatchType: &istiov1beta1.StringMatch_Regex{Regex: constants.HostRegExp(config.IngressDomain)},
+					},
+					Gateways: []string{config.IngressGateway},
+				},
+			},
+			Rewrite: &istiov1beta1.HTTPRewrite{Uri: "/"},
+			Route:   []*istiov1beta1.HTTPRouteDestination{createHTTPRouteDestination(config.KnativeLocalGatewayService)},
+			Headers: headers,
+		})
+		hosts = append(hosts, config.IngressDomain)
+	}
+	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
+		return !utils.Includes(isvcConfig.ServiceAnnotationDisallowedList, key)
+	})
+	return &istioclientv1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        isvc.Name,
+			Namespace:   isvc.Namespace,
+			Annotations: annotations,
+			Labels:      isvc.Labels,
+		},
+		Spec: istiov1beta1.VirtualService{
+			Hosts:    hosts,
+			Gateways: gateways,
+			Http:     httpRoutes,
+		},
+	}
+}
+
 // getDomainList gets all the available domain names available with Knative Serving.
 func getDomainList(ctx context.Context, clientset kubernetes.Interface) *[]string {
 	res := new([]string)
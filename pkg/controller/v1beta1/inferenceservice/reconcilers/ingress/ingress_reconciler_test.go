@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/network"
@@ -1318,6 +1319,77 @@ func TestCreateVirtualService(t *testing.T) {
 	}
 }
 
+func TestCreateVirtualServiceShadowMirror(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:             constants.KnativeIngressGateway,
+		KnativeLocalGatewayService: "someIngressServiceName",
+		LocalGateway:               constants.KnativeLocalGateway,
+		LocalGatewayServiceName:    "knative-local-gateway.istio-system.svc.cluster.local",
+		IngressDomain:              "example.com",
+		DomainTemplate:             "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		PathTemplate:               "/serving/{{ .Namespace }}/{{ .Name }}",
+	}
+	isvcConfig := &v1beta1.InferenceServicesConfig{
+		ServiceAnnotationDisallowedList: constants.ServiceAnnotationDisallowedList,
+	}
+	newIsvc := func(latestReadyRevision string) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-model",
+				Namespace: "test",
+			},
+			Spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{
+					ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+						ShadowTrafficPercent: ptr.To(int64(20)),
+					},
+				},
+			},
+			Status: v1beta1.InferenceServiceStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{
+						{
+							Type:   v1beta1.PredictorReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+					v1beta1.PredictorComponent: {
+						LatestReadyRevision:     latestReadyRevision,
+						LatestRolledoutRevision: "my-model-predictor-00001",
+						URL: &apis.URL{
+							Scheme: "http",
+							Host:   constants.InferenceServiceHostName(constants.PredictorServiceName("my-model"), "test", "example.com"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	virtualService := createIngress(newIsvc("my-model-predictor-00002"), ingressConfig, &[]string{}, isvcConfig)
+	g.Expect(virtualService).NotTo(gomega.BeNil())
+	g.Expect(virtualService.Spec.Http).To(gomega.HaveLen(2))
+	for _, route := range virtualService.Spec.Http {
+		g.Expect(route.Mirrors).To(gomega.HaveLen(1))
+		g.Expect(route.Mirrors[0].Destination.Host).To(gomega.Equal(network.GetServiceHostname("my-model-predictor-00002", "test")))
+		g.Expect(route.Mirrors[0].Destination.Port.Number).To(gomega.Equal(uint32(constants.CommonDefaultHttpPort)))
+		g.Expect(route.Mirrors[0].Percentage.Value).To(gomega.Equal(float64(20)))
+		g.Expect(route.Headers.Request.Set).To(gomega.HaveKeyWithValue(constants.KnativeRevisionHeader, "my-model-predictor-00002"))
+		g.Expect(route.Headers.Request.Set).To(gomega.HaveKeyWithValue(constants.KnativeRevisionNamespaceHeader, "test"))
+	}
+
+	// The traffic is not mirrored once the latest ready revision is rolled out
+	virtualService = createIngress(newIsvc("my-model-predictor-00001"), ingressConfig, &[]string{}, isvcConfig)
+	g.Expect(virtualService).NotTo(gomega.BeNil())
+	for _, route := range virtualService.Spec.Http {
+		g.Expect(route.Mirrors).To(gomega.BeEmpty())
+		g.Expect(route.Headers.Request.Set).NotTo(gomega.HaveKey(constants.KnativeRevisionHeader))
+	}
+}
+
 func TestGetServiceHost(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -59,6 +59,16 @@ func NewRawIngressReconciler(client client.Client,
 }
 
 func (r *RawIngressReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	ingressConfig, err := resolveNamespaceIngressConfig(ctx, r.client, isvc.Namespace, r.ingressConfig)
+	if err != nil {
+		return err
+	}
+	namespaced := *r
+	namespaced.ingressConfig = ingressConfig
+	return namespaced.reconcile(ctx, isvc)
+}
+
+func (r *RawIngressReconciler) reconcile(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	var err error
 	isInternal := false
 	// disable ingress creation if service is labelled with cluster local or kserve domain is cluster local
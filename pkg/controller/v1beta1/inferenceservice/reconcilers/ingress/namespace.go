@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// resolveNamespaceIngressConfig returns the ingress config to use for the InferenceServices of the namespace, with the
+// gateways and the ingress class selected through the namespace annotations applied on top of the cluster wide config.
+func resolveNamespaceIngressConfig(ctx context.Context, cl client.Client, namespace string,
+	ingressConfig *v1beta1.IngressConfig,
+) (*v1beta1.IngressConfig, error) {
+	ns := &corev1.Namespace{}
+	if err := cl.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierr.IsNotFound(err) {
+			return ingressConfig, nil
+		}
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return ingressConfig.WithNamespaceOverrides(ns.Annotations)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	"knative.dev/pkg/network"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// getShadowRevision returns the candidate revision of the predictor the traffic is mirrored to, which is the latest
+// ready revision while it is not rolled out, otherwise it returns an empty string
+func getShadowRevision(isvc *v1beta1.InferenceService) string {
+	if isvc.Spec.Predictor.ShadowTrafficPercent == nil || isvc.Spec.Transformer != nil {
+		return ""
+	}
+	status, ok := isvc.Status.Components[v1beta1.PredictorComponent]
+	if !ok || status.LatestReadyRevision == "" || status.LatestReadyRevision == status.LatestRolledoutRevision {
+		return ""
+	}
+	return status.LatestReadyRevision
+}
+
+// setShadowMirror mirrors the requests of the predict route to the service of the candidate revision of the
+// predictor, whose responses are discarded by Istio. The mirrored requests are sent to the revision directly, as
+// Istio suffixes their host with -shadow, so they carry the headers the Knative activator routes them with. Knative
+// overrides these headers on the requests it routes itself.
+func setShadowMirror(isvc *v1beta1.InferenceService, route *istiov1beta1.HTTPRoute) {
+	revision := getShadowRevision(isvc)
+	if revision == "" {
+		return
+	}
+	route.Mirrors = []*istiov1beta1.HTTPMirrorPolicy{
+		{
+			Destination: &istiov1beta1.Destination{
+				Host: network.GetServiceHostname(revision, isvc.Namespace),
+				Port: &istiov1beta1.PortSelector{
+					Number: constants.CommonDefaultHttpPort,
+				},
+			},
+			Percentage: &istiov1beta1.Percent{
+				Value: float64(*isvc.Spec.Predictor.ShadowTrafficPercent),
+			},
+		},
+	}
+	route.Headers.Request.Set[constants.KnativeRevisionHeader] = revision
+	route.Headers.Request.Set[constants.KnativeRevisionNamespaceHeader] = isvc.Namespace
+}
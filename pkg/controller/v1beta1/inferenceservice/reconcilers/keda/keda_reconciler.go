@@ -37,11 +37,16 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/prometheus"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
 var log = logf.Log.WithName("KedaReconciler")
 
+// prometheusQueriers validate the queries of the Prometheus triggers, shared across the reconciles by the address of
+// the Prometheus instance
+var prometheusQueriers = prometheus.NewQueriers()
+
 type KedaReconciler struct {
 	client       client.Client
 	scheme       *runtime.Scheme
@@ -115,7 +120,15 @@ func getKedaMetrics(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compo
 			case v1beta1.ExternalMetricSourceType:
 				triggerType := string(metric.External.Metric.Backend)
 				serverAddress := metric.External.Metric.ServerAddress
-				query := metric.External.Metric.Query
+				query, err := v1beta1.RenderMetricQuery(metric.External.Metric.Query, v1beta1.MetricQueryParameters{
+					Namespace:        componentMeta.Namespace,
+					InferenceService: componentMeta.Labels[constants.InferenceServicePodLabelKey],
+					Component:        componentMeta.Labels[constants.KServiceComponentLabel],
+					Deployment:       componentMeta.Name,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to render the query of the external metric: %w", err)
+				}
 
 				trigger := kedav1alpha1.ScaleTriggers{
 					Type: triggerType,
@@ -143,6 +156,12 @@ func getKedaMetrics(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compo
 					}
 				}
 				triggers = append(triggers, trigger)
+			case v1beta1.RuntimeMetricSourceType:
+				trigger, err := getRuntimeMetricTrigger(componentMeta, metric.Runtime, configMap)
+				if err != nil {
+					return nil, err
+				}
+				triggers = append(triggers, trigger)
 			case v1beta1.PodMetricSourceType:
 				otelConfig, err := v1beta1.NewOtelCollectorConfig(configMap)
 				if err != nil {
@@ -181,9 +200,77 @@ func getKedaMetrics(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compo
 			}
 		}
 	}
+
+	// the windows of the scale schedule are cron triggers keeping their minimum replicas while they are open
+	if componentExt != nil {
+		for _, window := range componentExt.ScaleSchedule {
+			timezone := window.Timezone
+			if timezone == "" {
+				timezone = v1beta1.DefaultScaleScheduleTimezone
+			}
+			triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+				Type: "cron",
+				Name: window.Name,
+				Metadata: map[string]string{
+					"timezone":        timezone,
+					"start":           window.Start,
+					"end":             window.End,
+					"desiredReplicas": strconv.Itoa(int(window.MinReplicas)),
+				},
+			})
+		}
+	}
 	return triggers, nil
 }
 
+// getRuntimeMetricTrigger returns the Prometheus trigger of a runtime metric, whose query is templated for the model
+// server detected in the predictor container
+func getRuntimeMetricTrigger(componentMeta metav1.ObjectMeta, metric *v1beta1.RuntimeMetricSource, configMap *corev1.ConfigMap,
+) (kedav1alpha1.ScaleTriggers, error) {
+	server := v1beta1.ModelServer(componentMeta.Annotations[constants.ModelServerInternalAnnotationKey])
+	query, err := v1beta1.RenderRuntimeMetricQuery(server, metric.Metric, v1beta1.MetricQueryParameters{
+		Namespace:        componentMeta.Namespace,
+		InferenceService: componentMeta.Labels[constants.InferenceServicePodLabelKey],
+		Component:        componentMeta.Labels[constants.KServiceComponentLabel],
+		Deployment:       componentMeta.Name,
+	})
+	if err != nil {
+		return kedav1alpha1.ScaleTriggers{}, err
+	}
+	serverAddress := metric.ServerAddress
+	if serverAddress == "" {
+		autoscalerConfig, err := v1beta1.NewAutoscalerConfig(configMap)
+		if err != nil {
+			return kedav1alpha1.ScaleTriggers{}, err
+		}
+		serverAddress = autoscalerConfig.PrometheusServerAddress
+	}
+	if serverAddress == "" {
+		return kedav1alpha1.ScaleTriggers{}, fmt.Errorf("the runtime metric %s requires a serverAddress, set in the metric or in the autoscaler config", metric.Metric)
+	}
+
+	trigger := kedav1alpha1.ScaleTriggers{
+		Type: string(constants.AutoScalerMetricsSourcePrometheus),
+		Metadata: map[string]string{
+			"serverAddress": serverAddress,
+			"query":         query,
+			"threshold":     getOriginalStringMQ(metric.Target.AverageValue, "0"),
+		},
+		MetricType: autoscalingv2.AverageValueMetricType,
+	}
+	if metric.Authentication != nil {
+		if metric.Authentication.AuthModes != "" {
+			trigger.Metadata["authModes"] = metric.Authentication.AuthModes
+		}
+		if metric.Authentication.AuthenticationRef.Name != "" {
+			trigger.AuthenticationRef = &kedav1alpha1.AuthenticationRef{
+				Name: metric.Authentication.AuthenticationRef.Name,
+			}
+		}
+	}
+	return trigger, nil
+}
+
 func createKedaScaledObject(componentMeta metav1.ObjectMeta,
 	componentExtension *v1beta1.ComponentExtensionSpec,
 	configMap *corev1.ConfigMap,
@@ -277,6 +364,24 @@ func createKedaScaledObject(componentMeta metav1.ObjectMeta,
 	return scaledobject, nil
 }
 
+// validatePrometheusQueries checks the rendered queries of the Prometheus triggers against their server before the
+// ScaledObject is applied, as the webhook only checks that their templates render
+func validatePrometheusQueries(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) error {
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Type != string(constants.AutoScalerMetricsSourcePrometheus) || trigger.Metadata["serverAddress"] == "" {
+			continue
+		}
+		querier, err := prometheusQueriers.Get(&v1beta1.PrometheusConfig{URL: trigger.Metadata["serverAddress"]})
+		if err != nil {
+			return err
+		}
+		if err := querier.Validate(ctx, trigger.Metadata["query"]); err != nil {
+			return fmt.Errorf("invalid prometheus trigger of the KEDA ScaledObject %s: %w", scaledObject.Name, err)
+		}
+	}
+	return nil
+}
+
 func semanticScaledObjectEquals(desired, existing *kedav1alpha1.ScaledObject) bool {
 	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec)
 }
@@ -313,6 +418,9 @@ func (r *KedaReconciler) Reconcile(ctx context.Context) error {
 
 	// Create or update the keda autoscaler to match the desired state
 	if getExistingErr != nil && kedaIsNotFound {
+		if err := validatePrometheusQueries(ctx, desired); err != nil {
+			return err
+		}
 		log.Info("Creating KEDA ScaledObject resource", "name", desired.Name)
 		if err := r.client.Create(ctx, desired); err != nil {
 			log.Error(err, "Failed to create KEDA ScaledObject", "name", desired.Name)
@@ -332,6 +440,9 @@ func (r *KedaReconciler) Reconcile(ctx context.Context) error {
 		return err
 	}
 	if !semanticScaledObjectEquals(desired, existing) {
+		if err := validatePrometheusQueries(ctx, desired); err != nil {
+			return err
+		}
 		log.Info("Updating KEDA ScaledObject resource", "name", desired.Name)
 		if err := r.client.Update(ctx, desired); err != nil {
 			log.Error(err, "Failed to update KEDA ScaledObject", "name", desired.Name)
@@ -17,6 +17,8 @@ limitations under the License.
 package keda
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 
@@ -255,6 +257,41 @@ func TestReconcile_UpdateScaledObject(t *testing.T) {
 	assert.Equal(t, int32(3), *updatedScaledObject.Spec.MaxReplicaCount)
 }
 
+func TestReconcile_PrometheusQueryValidation(t *testing.T) {
+	_ = kedav1alpha1.AddToScheme(scheme.Scheme)
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if response != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(response))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+	}))
+	defer server.Close()
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	componentMeta := metav1.ObjectMeta{
+		Name:      "test-component",
+		Namespace: "test-namespace",
+	}
+	componentExt := createComponentExtensionWithExternalMetric()
+	componentExt.AutoScaling.Metrics[0].External.Metric.ServerAddress = server.URL
+	key := types.NamespacedName{Name: "test-component", Namespace: "test-namespace"}
+
+	response = `{"status": "error", "errorType": "bad_data", "error": "1:1: parse error: unknown function"}`
+	r, err := NewKedaReconciler(client, scheme.Scheme, componentMeta, componentExt, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	require.ErrorContains(t, r.Reconcile(t.Context()), "parse error")
+	require.Error(t, client.Get(t.Context(), key, &kedav1alpha1.ScaledObject{}), "the ScaledObject is not created")
+
+	response = ""
+	r, err = NewKedaReconciler(client, scheme.Scheme, componentMeta, componentExt, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	require.NoError(t, r.Reconcile(t.Context()))
+	require.NoError(t, client.Get(t.Context(), key, &kedav1alpha1.ScaledObject{}))
+}
+
 func TestGetKedaMetrics_AverageValueMetricSourceType(t *testing.T) {
 	componentMeta := metav1.ObjectMeta{
 		Name:      "test-component",
@@ -618,6 +655,46 @@ func TestGetKedaMetrics_ExternalMetricSourceType_WithoutNamespaceOrAuth(t *testi
 	assert.Nil(t, trigger.AuthenticationRef)
 }
 
+func TestGetKedaMetrics_ExternalMetricSourceType_TemplatedQuery(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "test-namespace",
+		Labels: map[string]string{
+			constants.InferenceServicePodLabelKey: "sklearn",
+			constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+		},
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{
+		AutoScaling: &v1beta1.AutoScalingSpec{
+			Metrics: []v1beta1.MetricsSpec{
+				{
+					Type: v1beta1.ExternalMetricSourceType,
+					External: &v1beta1.ExternalMetricSource{
+						Metric: v1beta1.ExternalMetrics{
+							Backend:       v1beta1.PrometheusBackend,
+							ServerAddress: "http://prometheus-server",
+							Query: `sum(rate(http_requests_total{namespace="{{ .Namespace }}", inferenceservice="{{ .InferenceService }}", ` +
+								`component="{{ .Component }}", pod=~"{{ .Deployment }}-.*"}[1m]))`,
+						},
+						Target: v1beta1.MetricTarget{
+							Value: v1beta1.NewMetricQuantity("99"),
+						},
+					},
+				},
+			},
+		},
+	}
+	triggers, err := getKedaMetrics(componentMeta, componentExt, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, `sum(rate(http_requests_total{namespace="test-namespace", inferenceservice="sklearn", `+
+		`component="predictor", pod=~"sklearn-predictor-.*"}[1m]))`, triggers[0].Metadata["query"])
+
+	componentExt.AutoScaling.Metrics[0].External.Metric.Query = `http_requests_total{pod="{{ .Pod }}"}`
+	_, err = getKedaMetrics(componentMeta, componentExt, &corev1.ConfigMap{})
+	assert.Error(t, err)
+}
+
 func TestGetKedaMetrics_PodMetricSourceType_Success(t *testing.T) {
 	componentMeta := metav1.ObjectMeta{
 		Name:      "test-component",
@@ -1015,3 +1092,112 @@ func TestGetOriginalStringMQ(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKedaMetrics_RuntimeMetricSourceType(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:        "llama-predictor",
+		Namespace:   "test-namespace",
+		Annotations: map[string]string{constants.ModelServerInternalAnnotationKey: string(v1beta1.ModelServerVLLM)},
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{
+		AutoScaling: &v1beta1.AutoScalingSpec{
+			Metrics: []v1beta1.MetricsSpec{
+				{
+					Type: v1beta1.RuntimeMetricSourceType,
+					Runtime: &v1beta1.RuntimeMetricSource{
+						Metric: v1beta1.RuntimeMetricQueueDepth,
+						Authentication: &v1beta1.ExtMetricAuthentication{
+							AuthenticationRef: v1beta1.AuthenticationRef{Name: "prometheus-auth"},
+							AuthModes:         "bearer",
+						},
+						Target: v1beta1.MetricTarget{
+							Type:         v1beta1.AverageValueMetricType,
+							AverageValue: v1beta1.NewMetricQuantity("5"),
+						},
+					},
+				},
+				{
+					Type: v1beta1.RuntimeMetricSourceType,
+					Runtime: &v1beta1.RuntimeMetricSource{
+						Metric:        v1beta1.RuntimeMetricKVCacheUsage,
+						ServerAddress: "http://prometheus.team-a:9090",
+						Target: v1beta1.MetricTarget{
+							Type:         v1beta1.AverageValueMetricType,
+							AverageValue: v1beta1.NewMetricQuantity("0.8"),
+						},
+					},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{Data: map[string]string{
+		v1beta1.AutoscalerConfigName: `{"prometheusServerAddress": "http://prometheus.monitoring:9090"}`,
+	}}
+
+	triggers, err := getKedaMetrics(componentMeta, componentExt, configMap)
+	require.NoError(t, err)
+	assert.Equal(t, []kedav1alpha1.ScaleTriggers{
+		{
+			Type: "prometheus",
+			Metadata: map[string]string{
+				"serverAddress": "http://prometheus.monitoring:9090",
+				"query":         `sum(vllm:num_requests_waiting{namespace="test-namespace", pod=~"llama-predictor-.*"})`,
+				"threshold":     "5",
+				"authModes":     "bearer",
+			},
+			MetricType:        autoscalingv2.AverageValueMetricType,
+			AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "prometheus-auth"},
+		},
+		{
+			Type: "prometheus",
+			Metadata: map[string]string{
+				"serverAddress": "http://prometheus.team-a:9090",
+				"query":         `sum(vllm:gpu_cache_usage_perc{namespace="test-namespace", pod=~"llama-predictor-.*"})`,
+				"threshold":     "0.8",
+			},
+			MetricType: autoscalingv2.AverageValueMetricType,
+		},
+	}, triggers)
+
+	// the runtime metrics require a detected model server and a Prometheus server
+	_, err = getKedaMetrics(componentMeta, componentExt, &corev1.ConfigMap{})
+	require.ErrorContains(t, err, "requires a serverAddress")
+	componentMeta.Annotations = nil
+	_, err = getKedaMetrics(componentMeta, componentExt, configMap)
+	require.ErrorContains(t, err, "not supported for the model server")
+}
+
+func TestGetKedaMetrics_ScaleSchedule(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "test-namespace"}
+	componentExt := &v1beta1.ComponentExtensionSpec{
+		ScaleSchedule: []v1beta1.ScaleScheduleWindow{
+			{Name: "business-hours", Start: "0 8 * * MON-FRI", End: "0 18 * * MON-FRI", Timezone: "Europe/Paris", MinReplicas: 4},
+			{Name: "batch", Start: "0 2 * * *", End: "0 4 * * *", MinReplicas: 2},
+		},
+	}
+
+	triggers, err := getKedaMetrics(componentMeta, componentExt, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	assert.Equal(t, []kedav1alpha1.ScaleTriggers{
+		{
+			Type: "cron",
+			Name: "business-hours",
+			Metadata: map[string]string{
+				"timezone":        "Europe/Paris",
+				"start":           "0 8 * * MON-FRI",
+				"end":             "0 18 * * MON-FRI",
+				"desiredReplicas": "4",
+			},
+		},
+		{
+			Type: "cron",
+			Name: "batch",
+			Metadata: map[string]string{
+				"timezone":        "Etc/UTC",
+				"start":           "0 2 * * *",
+				"end":             "0 4 * * *",
+				"desiredReplicas": "2",
+			},
+		},
+	}, triggers)
+}
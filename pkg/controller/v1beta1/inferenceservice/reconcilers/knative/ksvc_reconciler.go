@@ -18,7 +18,9 @@ package knative
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -26,10 +28,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"knative.dev/pkg/kmp"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	knserving "knative.dev/serving/pkg/apis/serving"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,6 +52,12 @@ import (
 
 var log = logf.Log.WithName("KsvcReconciler")
 
+// WarmStandbyTag is the traffic tag of the previous revision kept warm
+const WarmStandbyTag = "standby"
+
+// PodAutoscalerGVK is the kind of the Knative autoscalers of the revisions
+var PodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.internal.knative.dev", Version: "v1alpha1", Kind: "PodAutoscaler"}
+
 var managedKsvcAnnotations = map[string]bool{
 	constants.RollOutDurationAnnotationKey: true,
 	// Required for the integration of Openshift Knative with Openshift Service Mesh
@@ -127,11 +138,31 @@ func createKnativeService(
 		"LatestReadyRevision", componentStatus.LatestReadyRevision,
 		"LatestCreatedRevision", componentStatus.LatestCreatedRevision,
 		"PreviousRolledoutRevision", componentStatus.PreviousRolledoutRevision,
-		"CanaryTrafficPercent", componentExtension.CanaryTrafficPercent)
+		"CanaryTrafficPercent", componentExtension.CanaryTrafficPercent,
+		"ShadowTrafficPercent", componentExtension.ShadowTrafficPercent)
 
 	trafficTargets := []knservingv1.TrafficTarget{}
+	switch {
+	// Hold the traffic on the last rolled out revision until the latest revision passed the inference probe
+	case holdsTrafficForInferenceProbe(componentStatus):
+		trafficTargets = inferenceProbeTraffic(lastRolledoutRevision)
+	// Keep the traffic on the last rolled out revision when the traffic is mirrored to the candidate revision, which
+	// stays routable without traffic so that the virtual service of the InferenceService can mirror to it
+	case componentExtension.ShadowTrafficPercent != nil && lastRolledoutRevision != "":
+		trafficTargets = append(trafficTargets,
+			knservingv1.TrafficTarget{
+				LatestRevision: proto.Bool(true),
+				Percent:        proto.Int64(0),
+				Tag:            constants.ShadowTrafficTag,
+			},
+			knservingv1.TrafficTarget{
+				RevisionName:   lastRolledoutRevision,
+				LatestRevision: proto.Bool(false),
+				Percent:        proto.Int64(100),
+				Tag:            "prev",
+			})
 	// Split traffic when canary traffic percent is specified
-	if componentExtension.CanaryTrafficPercent != nil && lastRolledoutRevision != "" {
+	case componentExtension.CanaryTrafficPercent != nil && lastRolledoutRevision != "":
 		latestTarget := knservingv1.TrafficTarget{
 			LatestRevision: proto.Bool(true),
 			Percent:        proto.Int64(*componentExtension.CanaryTrafficPercent),
@@ -151,7 +182,7 @@ func createKnativeService(
 			}
 			trafficTargets = append(trafficTargets, canaryTarget)
 		}
-	} else {
+	default:
 		// blue-green rollout
 		latestTarget := knservingv1.TrafficTarget{
 			LatestRevision: proto.Bool(true),
@@ -163,6 +194,17 @@ func createKnativeService(
 		trafficTargets = append(trafficTargets, latestTarget)
 	}
 
+	// The previous revision kept warm stays routed without traffic, as the unreachable revisions are scaled to zero
+	// regardless of their minimum scale
+	if warmStandby := componentStatus.WarmStandby; warmStandby.IsSoaking(time.Now()) && warmStandby.Revision != lastRolledoutRevision {
+		trafficTargets = append(trafficTargets, knservingv1.TrafficTarget{
+			RevisionName:   warmStandby.Revision,
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(0),
+			Tag:            WarmStandbyTag,
+		})
+	}
+
 	labels := utils.Filter(componentMeta.Labels, func(key string) bool {
 		return !utils.Includes(disallowedLabelList, key)
 	})
@@ -223,6 +265,31 @@ func createKnativeService(
 	return service
 }
 
+// holdsTrafficForInferenceProbe returns whether the traffic of a component gated by the inference probe is held on the
+// last rolled out revision, as the latest created revision did not pass the probe yet
+func holdsTrafficForInferenceProbe(componentStatus v1beta1.ComponentStatusSpec) bool {
+	return componentStatus.InferenceProbe != nil && componentStatus.LatestRolledoutRevision != "" &&
+		componentStatus.InferenceProbe.PassedRevision != componentStatus.LatestCreatedRevision
+}
+
+// inferenceProbeTraffic routes the traffic to the last rolled out revision while the latest revision stays routable
+// without traffic, so that it becomes ready and its pods are probed
+func inferenceProbeTraffic(lastRolledoutRevision string) []knservingv1.TrafficTarget {
+	return []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(0),
+			Tag:            constants.InferenceProbeTrafficTag,
+		},
+		{
+			RevisionName:   lastRolledoutRevision,
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100),
+			Tag:            "prev",
+		},
+	}
+}
+
 func reconcileKsvc(desired *knservingv1.Service, existing *knservingv1.Service) error {
 	// Return if no differences to reconcile.
 	if semanticEquals(desired, existing) {
@@ -297,6 +364,18 @@ func (r *KsvcReconciler) Reconcile(ctx context.Context) (*knservingv1.ServiceSta
 			}
 			return err
 		}
+		// The latest created revision in the status is the previous one when the update creates a new revision, whose
+		// traffic is held as well until it passed the inference probe
+		if r.componentStatus.InferenceProbe != nil && r.componentStatus.LatestRolledoutRevision != "" &&
+			!equality.Semantic.DeepEqual(desired.Spec.Template, existing.Spec.Template) {
+			traffic := inferenceProbeTraffic(r.componentStatus.LatestRolledoutRevision)
+			for _, target := range desired.Spec.Traffic {
+				if target.Tag == WarmStandbyTag {
+					traffic = append(traffic, target)
+				}
+			}
+			desired.Spec.Traffic = traffic
+		}
 		if err := reconcileKsvc(desired, existing); err != nil {
 			return err
 		}
@@ -314,9 +393,56 @@ func (r *KsvcReconciler) Reconcile(ctx context.Context) (*knservingv1.ServiceSta
 		}
 		return &existing.Status, errors.Wrapf(err, "fails to reconcile knative service")
 	}
+	if err := r.reconcileWarmStandby(ctx); err != nil {
+		return &existing.Status, errors.Wrapf(err, "fails to reconcile warm standby")
+	}
 	return &existing.Status, nil
 }
 
+// reconcileWarmStandby raises the minimum scale of the autoscaler of the previous revision kept warm to the warm
+// standby replicas, and restores it once the soak period ended. The revision reconciler of Knative does not update
+// the annotations of the autoscalers once created.
+func (r *KsvcReconciler) reconcileWarmStandby(ctx context.Context) error {
+	warmStandby := r.componentStatus.WarmStandby
+	if warmStandby == nil {
+		return nil
+	}
+	podAutoscaler := &unstructured.Unstructured{}
+	podAutoscaler.SetGroupVersionKind(PodAutoscalerGVK)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.Service.Namespace, Name: warmStandby.Revision}, podAutoscaler); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	annotations := podAutoscaler.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	original, raised := annotations[constants.WarmStandbyMinScaleInternalAnnotationKey]
+	if warmStandby.IsSoaking(time.Now()) {
+		minScale := strconv.Itoa(int(warmStandby.Replicas))
+		if raised && annotations[autoscaling.MinScaleAnnotationKey] == minScale {
+			return nil
+		}
+		if !raised {
+			annotations[constants.WarmStandbyMinScaleInternalAnnotationKey] = annotations[autoscaling.MinScaleAnnotationKey]
+		}
+		annotations[autoscaling.MinScaleAnnotationKey] = minScale
+	} else {
+		if !raised {
+			return nil
+		}
+		if original == "" {
+			delete(annotations, autoscaling.MinScaleAnnotationKey)
+		} else {
+			annotations[autoscaling.MinScaleAnnotationKey] = original
+		}
+		delete(annotations, constants.WarmStandbyMinScaleInternalAnnotationKey)
+	}
+	podAutoscaler.SetAnnotations(annotations)
+	log.Info("Updating the minimum scale of the warm standby revision", "namespace", podAutoscaler.GetNamespace(),
+		"revision", warmStandby.Revision, "minScale", annotations[autoscaling.MinScaleAnnotationKey])
+	return r.client.Update(ctx, podAutoscaler)
+}
+
 func semanticEquals(desiredService, service *knservingv1.Service) bool {
 	for ksvcAnnotationKey := range managedKsvcAnnotations {
 		existingValue, ok1 := service.ObjectMeta.Annotations[ksvcAnnotationKey]
@@ -19,6 +19,7 @@ package knative
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -33,6 +34,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -197,6 +199,89 @@ func TestCreateKnativeService(t *testing.T) {
 	}
 }
 
+func TestCreateKnativeServiceShadowTraffic(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = knservingv1.AddToScheme(scheme)
+	client := rtesting.NewClientBuilder().WithScheme(scheme).Build()
+	componentMeta := metav1.ObjectMeta{Name: "test-service", Namespace: "default", Annotations: map[string]string{}}
+	componentExt := &v1beta1.ComponentExtensionSpec{ShadowTrafficPercent: proto.Int64(20)}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "test-image"}}}
+
+	// The last rolled out revision keeps all the traffic while the candidate revision stays routable
+	ksvc := createKnativeService(t.Context(), client, componentMeta, componentExt, podSpec,
+		v1beta1.ComponentStatusSpec{LatestRolledoutRevision: "test-revision-1"}, nil, nil, nil, nil, nil, nil)
+	assert.Equal(t, []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(0),
+			Tag:            constants.ShadowTrafficTag,
+		},
+		{
+			RevisionName:   "test-revision-1",
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100),
+			Tag:            "prev",
+		},
+	}, ksvc.Spec.Traffic)
+
+	// The first revision receives all the traffic
+	ksvc = createKnativeService(t.Context(), client, componentMeta, componentExt, podSpec,
+		v1beta1.ComponentStatusSpec{}, nil, nil, nil, nil, nil, nil)
+	assert.Equal(t, []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(100),
+		},
+	}, ksvc.Spec.Traffic)
+}
+
+func TestCreateKnativeServiceInferenceProbe(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = knservingv1.AddToScheme(scheme)
+	client := rtesting.NewClientBuilder().WithScheme(scheme).Build()
+	componentMeta := metav1.ObjectMeta{Name: "test-service", Namespace: "default", Annotations: map[string]string{}}
+	componentExt := &v1beta1.ComponentExtensionSpec{}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "test-image"}}}
+	componentStatus := v1beta1.ComponentStatusSpec{
+		LatestRolledoutRevision: "test-revision-1",
+		LatestCreatedRevision:   "test-revision-2",
+		InferenceProbe: &v1beta1.InferenceProbeStatus{
+			Revision:       "test-revision-2",
+			State:          v1beta1.InferenceProbeInProgress,
+			PassedRevision: "test-revision-1",
+		},
+	}
+
+	// The last rolled out revision keeps all the traffic until the latest revision passed the inference probe
+	ksvc := createKnativeService(t.Context(), client, componentMeta, componentExt, podSpec,
+		componentStatus, nil, nil, nil, nil, nil, nil)
+	assert.Equal(t, []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(0),
+			Tag:            constants.InferenceProbeTrafficTag,
+		},
+		{
+			RevisionName:   "test-revision-1",
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100),
+			Tag:            "prev",
+		},
+	}, ksvc.Spec.Traffic)
+
+	// The latest revision receives all the traffic once it passed
+	componentStatus.InferenceProbe.State = v1beta1.InferenceProbePassed
+	componentStatus.InferenceProbe.PassedRevision = "test-revision-2"
+	ksvc = createKnativeService(t.Context(), client, componentMeta, componentExt, podSpec,
+		componentStatus, nil, nil, nil, nil, nil, nil)
+	assert.Equal(t, []knservingv1.TrafficTarget{
+		{
+			LatestRevision: proto.Bool(true),
+			Percent:        proto.Int64(100),
+		},
+	}, ksvc.Spec.Traffic)
+}
+
 func TestKsvcReconciler_Reconcile(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = knservingv1.AddToScheme(scheme)
@@ -421,3 +506,62 @@ func TestKsvcReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestKsvcReconciler_WarmStandby(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = knservingv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	componentMeta := metav1.ObjectMeta{Name: "test-service", Namespace: "default", Annotations: map[string]string{}}
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test-container", Image: "test-image"}}}
+	makePodAutoscaler := func() *unstructured.Unstructured {
+		podAutoscaler := &unstructured.Unstructured{}
+		podAutoscaler.SetGroupVersionKind(PodAutoscalerGVK)
+		podAutoscaler.SetName("test-revision-1")
+		podAutoscaler.SetNamespace("default")
+		podAutoscaler.SetAnnotations(map[string]string{autoscaling.MinScaleAnnotationKey: "0"})
+		return podAutoscaler
+	}
+	reconcile := func(podAutoscaler *unstructured.Unstructured, soakEndTime time.Time) (*knservingv1.Service, map[string]string) {
+		existing := &knservingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "default"}}
+		c := rtesting.NewClientBuilder().WithScheme(scheme).WithObjects(existing, podAutoscaler).Build()
+		componentStatus := v1beta1.ComponentStatusSpec{
+			LatestRolledoutRevision:   "test-revision-2",
+			PreviousRolledoutRevision: "test-revision-1",
+			WarmStandby: &v1beta1.WarmStandbyStatus{
+				Revision:    "test-revision-1",
+				Replicas:    2,
+				State:       v1beta1.WarmStandbySoaking,
+				SoakEndTime: metav1.NewTime(soakEndTime),
+			},
+		}
+		reconciler := NewKsvcReconciler(t.Context(), c, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec,
+			componentStatus, nil, nil, nil, nil, nil, nil)
+		_, err := reconciler.Reconcile(t.Context())
+		require.NoError(t, err)
+
+		updated := &unstructured.Unstructured{}
+		updated.SetGroupVersionKind(PodAutoscalerGVK)
+		require.NoError(t, c.Get(t.Context(), types.NamespacedName{Name: "test-revision-1", Namespace: "default"}, updated))
+		return reconciler.Service, updated.GetAnnotations()
+	}
+
+	// The previous revision stays routed without traffic and its minimum scale is raised while soaking
+	service, annotations := reconcile(makePodAutoscaler(), time.Now().Add(time.Hour))
+	require.Len(t, service.Spec.Traffic, 2)
+	assert.Equal(t, knservingv1.TrafficTarget{
+		RevisionName:   "test-revision-1",
+		LatestRevision: proto.Bool(false),
+		Percent:        proto.Int64(0),
+		Tag:            WarmStandbyTag,
+	}, service.Spec.Traffic[1])
+	assert.Equal(t, "2", annotations[autoscaling.MinScaleAnnotationKey])
+	assert.Equal(t, "0", annotations[constants.WarmStandbyMinScaleInternalAnnotationKey])
+
+	// The minimum scale is restored once the soak period ended
+	raised := makePodAutoscaler()
+	raised.SetAnnotations(annotations)
+	service, annotations = reconcile(raised, time.Now().Add(-time.Minute))
+	assert.Len(t, service.Spec.Traffic, 1)
+	assert.Equal(t, map[string]string{autoscaling.MinScaleAnnotationKey: "0"}, annotations)
+}
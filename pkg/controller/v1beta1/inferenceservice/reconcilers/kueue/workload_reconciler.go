@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kueue
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("KueueWorkloadReconciler")
+
+// WorkloadGVK is the GroupVersionKind of the Kueue Workloads
+var WorkloadGVK = schema.FromAPIVersionAndKind(constants.KueueAPIVersion, constants.KueueWorkloadKind)
+
+const (
+	// WorkloadAdmitted is the condition of the Kueue Workloads admitted in their ClusterQueue
+	WorkloadAdmitted = "Admitted"
+	// MainPodSetName is the name of the pod set of the default deployment of a component
+	MainPodSetName = "main"
+	// WorkerPodSetName is the name of the pod set of the worker deployment of a multi-node predictor
+	WorkerPodSetName = "worker"
+)
+
+// podSet is a pod set of a Kueue Workload
+type podSet struct {
+	Name     string                 `json:"name"`
+	Count    int32                  `json:"count"`
+	Template corev1.PodTemplateSpec `json:"template"`
+}
+
+// WorkloadReconciler reconciles the Kueue Workload of the deployments of a component labeled with the
+// kueue.x-k8s.io/queue-name of a LocalQueue. The Workload is named after the component and holds a pod set for each
+// deployment, sized with the minimum replicas. The deployments are held at zero replicas until Kueue admits the
+// Workload, so that the pods of the component only start within the quota of the ClusterQueue.
+type WorkloadReconciler struct {
+	client        client.Client
+	componentMeta metav1.ObjectMeta
+	componentExt  *v1beta1.ComponentExtensionSpec
+	deployments   []*appsv1.Deployment
+}
+
+func NewWorkloadReconciler(client client.Client, componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec,
+	deployments []*appsv1.Deployment,
+) *WorkloadReconciler {
+	return &WorkloadReconciler{
+		client:        client,
+		componentMeta: componentMeta,
+		componentExt:  componentExt,
+		deployments:   deployments,
+	}
+}
+
+// Managed returns whether the admission of the component is managed by Kueue. The components autoscaled with KEDA
+// are not managed, as KEDA scales the deployments up from zero replicas.
+func (r *WorkloadReconciler) Managed() bool {
+	return r.componentMeta.Labels[constants.KueueQueueNameLabelKey] != "" &&
+		r.componentMeta.Annotations[constants.AutoscalerClass] != string(constants.AutoscalerClassKeda) &&
+		!utils.GetForceStopRuntime(&r.componentMeta)
+}
+
+// Reconcile creates the Workload of the component, or recreates it when the resources of the pods change as the pod
+// sets of the admitted Workloads are immutable, and returns whether the Workload is admitted. The Workload of a
+// component no longer managed by Kueue is deleted.
+func (r *WorkloadReconciler) Reconcile(ctx context.Context) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(WorkloadGVK)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.componentMeta.Namespace, Name: r.componentMeta.Name}, existing)
+	if err != nil && !apierr.IsNotFound(err) {
+		return false, err
+	}
+	found := err == nil
+
+	if !r.Managed() {
+		if found {
+			log.Info("Deleting Kueue workload of a component no longer managed by Kueue", "namespace", existing.GetNamespace(), "name", existing.GetName())
+			if err := r.client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	desired, err := r.desiredWorkload()
+	if err != nil {
+		return false, err
+	}
+	if found {
+		if existing.GetAnnotations()[constants.KueuePodSetsHashAnnotationKey] == desired.GetAnnotations()[constants.KueuePodSetsHashAnnotationKey] {
+			return isAdmitted(existing), nil
+		}
+		log.Info("Recreating Kueue workload as the pods of the component changed", "namespace", existing.GetNamespace(), "name", existing.GetName())
+		if err := r.client.Delete(ctx, existing, client.Preconditions{UID: ptr.To(existing.GetUID())}); err != nil && !apierr.IsNotFound(err) {
+			return false, err
+		}
+	}
+	log.Info("Creating Kueue workload", "namespace", desired.GetNamespace(), "name", desired.GetName(),
+		"queue", r.componentMeta.Labels[constants.KueueQueueNameLabelKey])
+	if err := r.client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+		return false, err
+	}
+	return false, nil
+}
+
+func (r *WorkloadReconciler) desiredWorkload() (*unstructured.Unstructured, error) {
+	podSets := make([]podSet, 0, len(r.deployments))
+	for i, deployment := range r.deployments {
+		name := MainPodSetName
+		count := r.minReplicas()
+		if i > 0 {
+			name = WorkerPodSetName
+			count = ptr.Deref(deployment.Spec.Replicas, 1)
+		}
+		podSets = append(podSets, podSet{Name: name, Count: count, Template: resourceTemplate(deployment.Spec.Template)})
+	}
+	queueName := r.componentMeta.Labels[constants.KueueQueueNameLabelKey]
+	spec := map[string]interface{}{"queueName": queueName, "podSets": podSets}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(specJSON)
+	unstructuredSpec := map[string]interface{}{}
+	if err := json.Unmarshal(specJSON, &unstructuredSpec); err != nil {
+		return nil, err
+	}
+
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{"spec": unstructuredSpec}}
+	workload.SetGroupVersionKind(WorkloadGVK)
+	workload.SetName(r.componentMeta.Name)
+	workload.SetNamespace(r.componentMeta.Namespace)
+	workload.SetLabels(map[string]string{
+		constants.InferenceServicePodLabelKey: r.componentMeta.Labels[constants.InferenceServicePodLabelKey],
+		constants.KueueQueueNameLabelKey:      queueName,
+	})
+	workload.SetAnnotations(map[string]string{constants.KueuePodSetsHashAnnotationKey: hex.EncodeToString(hash[:])})
+	// The deployments are owned by the InferenceService by the time the Workload is reconciled
+	if len(r.deployments) > 0 {
+		workload.SetOwnerReferences(r.deployments[0].OwnerReferences)
+	}
+	return workload, nil
+}
+
+// minReplicas returns the number of replicas of the default deployment the quota is reserved for
+func (r *WorkloadReconciler) minReplicas() int32 {
+	if r.componentExt != nil && r.componentExt.MinReplicas != nil && *r.componentExt.MinReplicas > 0 {
+		return *r.componentExt.MinReplicas
+	}
+	return 1
+}
+
+// resourceTemplate returns the part of a pod template Kueue uses to assign the resource flavors and account the quota,
+// so that the Workload is not recreated for changes of the pods not affecting the admission
+func resourceTemplate(template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	spec := corev1.PodSpec{
+		NodeSelector:      template.Spec.NodeSelector,
+		Affinity:          template.Spec.Affinity,
+		Tolerations:       template.Spec.Tolerations,
+		PriorityClassName: template.Spec.PriorityClassName,
+		RuntimeClassName:  template.Spec.RuntimeClassName,
+		Overhead:          template.Spec.Overhead,
+	}
+	for _, container := range template.Spec.InitContainers {
+		spec.InitContainers = append(spec.InitContainers, corev1.Container{
+			Name:          container.Name,
+			Resources:     container.Resources,
+			RestartPolicy: container.RestartPolicy,
+		})
+	}
+	for _, container := range template.Spec.Containers {
+		spec.Containers = append(spec.Containers, corev1.Container{Name: container.Name, Resources: container.Resources})
+	}
+	return corev1.PodTemplateSpec{Spec: spec}
+}
+
+func isAdmitted(workload *unstructured.Unstructured) bool {
+	rawConditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	conditions := make([]metav1.Condition, 0, len(rawConditions))
+	for _, rawCondition := range rawConditions {
+		condition := metav1.Condition{}
+		conditionMap, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(conditionMap, &condition); err != nil {
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+	return meta.IsStatusConditionTrue(conditions, WorkloadAdmitted)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newDeployments(gpus string) []*appsv1.Deployment {
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "isvc.sklearn-predictor"}},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"},
+			Containers: []corev1.Container{{
+				Name:  constants.InferenceServiceContainerName,
+				Image: "kserve/huggingfaceserver:latest",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+					Limits:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+				},
+			}},
+		},
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "InferenceService", Name: "sklearn", UID: "uid", Controller: ptr.To(true),
+	}
+	return []*appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", OwnerReferences: []metav1.OwnerReference{owner}},
+			Spec:       appsv1.DeploymentSpec{Template: template},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-worker", Namespace: "default", OwnerReferences: []metav1.OwnerReference{owner}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3)), Template: template},
+		},
+	}
+}
+
+func newComponentMeta(labels map[string]string, annotations map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        "sklearn-predictor",
+		Namespace:   "default",
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+func getWorkload(t *testing.T, cl client.Client) *unstructured.Unstructured {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(WorkloadGVK)
+	err := cl.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: "sklearn-predictor"}, workload)
+	if err != nil {
+		return nil
+	}
+	return workload
+}
+
+func TestWorkloadReconciler(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	labels := map[string]string{
+		constants.InferenceServicePodLabelKey: "sklearn",
+		constants.KueueQueueNameLabelKey:      "inference",
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(int32(2))}
+
+	// The Workload is created, and not admitted
+	r := NewWorkloadReconciler(cl, newComponentMeta(labels, nil), componentExt, newDeployments("1"))
+	require.True(t, r.Managed())
+	admitted, err := r.Reconcile(t.Context())
+	require.NoError(t, err)
+	assert.False(t, admitted)
+
+	workload := getWorkload(t, cl)
+	require.NotNil(t, workload)
+	queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	assert.Equal(t, "inference", queueName)
+	assert.Equal(t, "sklearn", workload.GetOwnerReferences()[0].Name)
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	require.Len(t, podSets, 2)
+	main := podSets[0].(map[string]interface{})
+	assert.Equal(t, MainPodSetName, main["name"])
+	assert.EqualValues(t, 2, main["count"])
+	worker := podSets[1].(map[string]interface{})
+	assert.Equal(t, WorkerPodSetName, worker["name"])
+	assert.EqualValues(t, 3, worker["count"])
+	containers, _, _ := unstructured.NestedSlice(main, "template", "spec", "containers")
+	require.Len(t, containers, 1)
+	assert.NotContains(t, containers[0], "image")
+	gpus, _, _ := unstructured.NestedString(containers[0].(map[string]interface{}), "resources", "requests", "nvidia.com/gpu")
+	assert.Equal(t, "1", gpus)
+
+	// The Workload is admitted by Kueue
+	require.NoError(t, unstructured.SetNestedSlice(workload.Object, []interface{}{
+		map[string]interface{}{"type": WorkloadAdmitted, "status": "True", "reason": "Admitted", "message": "",
+			"lastTransitionTime": "2025-01-01T00:00:00Z"},
+	}, "status", "conditions"))
+	require.NoError(t, cl.Update(t.Context(), workload))
+	admitted, err = NewWorkloadReconciler(cl, newComponentMeta(labels, nil), componentExt, newDeployments("1")).Reconcile(t.Context())
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	hash := getWorkload(t, cl).GetAnnotations()[constants.KueuePodSetsHashAnnotationKey]
+
+	// Changing the image does not change the admission
+	deployments := newDeployments("1")
+	deployments[0].Spec.Template.Spec.Containers[0].Image = "kserve/huggingfaceserver:v2"
+	admitted, err = NewWorkloadReconciler(cl, newComponentMeta(labels, nil), componentExt, deployments).Reconcile(t.Context())
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	assert.Equal(t, hash, getWorkload(t, cl).GetAnnotations()[constants.KueuePodSetsHashAnnotationKey])
+
+	// Changing the resources recreates the Workload, to be admitted again
+	admitted, err = NewWorkloadReconciler(cl, newComponentMeta(labels, nil), componentExt, newDeployments("2")).Reconcile(t.Context())
+	require.NoError(t, err)
+	assert.False(t, admitted)
+	workload = getWorkload(t, cl)
+	require.NotNil(t, workload)
+	assert.NotEqual(t, hash, workload.GetAnnotations()[constants.KueuePodSetsHashAnnotationKey])
+	_, found, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	assert.False(t, found)
+
+	// The Workload of the components no longer managed by Kueue is deleted
+	r = NewWorkloadReconciler(cl, newComponentMeta(labels, map[string]string{
+		constants.AutoscalerClass: string(constants.AutoscalerClassKeda),
+	}), componentExt, newDeployments("2"))
+	assert.False(t, r.Managed())
+	admitted, err = r.Reconcile(t.Context())
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	assert.Nil(t, getWorkload(t, cl))
+}
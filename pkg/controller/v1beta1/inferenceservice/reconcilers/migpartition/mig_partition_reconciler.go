@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migpartition
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("MIGPartitionReconciler")
+
+// MIG partition condition reasons
+const (
+	PartitionPendingReason     = "MIGPartitionPending"
+	PartitionFailedReason      = "MIGPartitionFailed"
+	PartitionUnavailableReason = "MIGPartitionUnavailable"
+)
+
+// PollInterval is the interval at which the MIG manager state of the claimed nodes is checked until they are
+// partitioned
+const PollInterval = 30 * time.Second
+
+// profileRegexp matches the MIG profiles, e.g. 1g.10gb, 3g.40gb or 1g.10gb+me
+var profileRegexp = regexp.MustCompile(`^([1-7])g\.(\d+)gb(\+[a-z]+)?$`)
+
+// MIGPartitionReconciler claims the nodes with A100 or H100 GPUs for the MIG profile requested by the predictor, and
+// has the MIG manager of the NVIDIA GPU operator partition them by setting their nvidia.com/mig.config label. The
+// nodes already partitioned with the profile are shared by the InferenceServices requesting it. A node is restored
+// to its previous MIG configuration once no InferenceService uses its partitions anymore.
+type MIGPartitionReconciler struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	config    *v1beta1.MIGPartitioningConfig
+}
+
+func NewMIGPartitionReconciler(client client.Client, clientset kubernetes.Interface, config *v1beta1.MIGPartitioningConfig) *MIGPartitionReconciler {
+	return &MIGPartitionReconciler{
+		client:    client,
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+// Reconcile claims and partitions nodes until the claimed nodes provide the MIG partitions of the minimum replicas of
+// the predictor, and reports their state with the MIGPartitionReady condition. The nodes are released when the
+// partitioning is disabled or the predictor does not request a MIG profile anymore.
+func (r *MIGPartitionReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	profile, partitions, err := Profile(isvc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !r.config.Enabled || profile == "" {
+		if isvc.Status.GetCondition(v1beta1.MIGPartitionReady) == nil {
+			return ctrl.Result{}, nil
+		}
+		if err := Release(ctx, r.client, isvc); err != nil {
+			return ctrl.Result{}, err
+		}
+		isvc.Status.ClearCondition(v1beta1.MIGPartitionReady)
+		return ctrl.Result{}, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodes); err != nil {
+		return ctrl.Result{}, err
+	}
+	owner := ownerKey(isvc)
+	desiredConfig := ConfigName(profile)
+	resourceName := corev1.ResourceName(constants.NvidiaMigGPUResourceTypePrefix + "-" + profile)
+	required := partitions * int64(max(ptr.Deref(isvc.Spec.Predictor.MinReplicas, 0), 1))
+
+	var available int64
+	var pending, failed []string
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !slices.Contains(owners(node), owner) {
+			continue
+		}
+		// the profile of the predictor changed
+		if node.Labels[constants.NvidiaMigConfigLabel] != desiredConfig {
+			if err := r.release(ctx, node, owner); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		switch node.Labels[constants.NvidiaMigConfigStateLabel] {
+		case constants.NvidiaMigStateSuccess:
+			if quantity, ok := node.Status.Allocatable[resourceName]; ok {
+				available += quantity.Value()
+			}
+		case constants.NvidiaMigStateFailed:
+			failed = append(failed, node.Name)
+		default:
+			pending = append(pending, node.Name)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		isvc.Status.SetCondition(v1beta1.MIGPartitionReady, &apis.Condition{
+			Type:    v1beta1.MIGPartitionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  PartitionFailedReason,
+			Message: fmt.Sprintf("the MIG manager failed to partition the nodes %s with %s", strings.Join(failed, ", "), desiredConfig),
+		})
+		return ctrl.Result{RequeueAfter: PollInterval}, nil
+	case len(pending) > 0:
+		isvc.Status.SetCondition(v1beta1.MIGPartitionReady, &apis.Condition{
+			Type:    v1beta1.MIGPartitionReady,
+			Status:  corev1.ConditionUnknown,
+			Reason:  PartitionPendingReason,
+			Message: fmt.Sprintf("waiting for the MIG manager to partition the nodes %s with %s", strings.Join(pending, ", "), desiredConfig),
+		})
+		return ctrl.Result{RequeueAfter: PollInterval}, nil
+	case available >= required:
+		isvc.Status.SetCondition(v1beta1.MIGPartitionReady, &apis.Condition{
+			Type:   v1beta1.MIGPartitionReady,
+			Status: corev1.ConditionTrue,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	gpuPodNodes, err := GPUPodNodes(ctx, r.clientset)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	candidates := AvailableNodes(nodes.Items, gpuPodNodes, isvc, r.config, profile)
+	candidates = slices.DeleteFunc(candidates, func(node *corev1.Node) bool {
+		return slices.Contains(owners(node), owner)
+	})
+	if len(candidates) == 0 {
+		isvc.Status.SetCondition(v1beta1.MIGPartitionReady, &apis.Condition{
+			Type:   v1beta1.MIGPartitionReady,
+			Status: corev1.ConditionFalse,
+			Reason: PartitionUnavailableReason,
+			Message: fmt.Sprintf("the predictor requires %d %s partitions, %d are available on the claimed nodes and no other targeted node can be partitioned",
+				required, profile, available),
+		})
+		return ctrl.Result{RequeueAfter: PollInterval}, nil
+	}
+	node := candidates[0]
+	log.Info("Claiming the node for the MIG profile of the predictor", "isvc", isvc.Name, "namespace", isvc.Namespace,
+		"node", node.Name, "config", desiredConfig)
+	if err := r.claim(ctx, node, owner, desiredConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+	isvc.Status.SetCondition(v1beta1.MIGPartitionReady, &apis.Condition{
+		Type:    v1beta1.MIGPartitionReady,
+		Status:  corev1.ConditionUnknown,
+		Reason:  PartitionPendingReason,
+		Message: fmt.Sprintf("waiting for the MIG manager to partition the nodes %s with %s", node.Name, desiredConfig),
+	})
+	return ctrl.Result{RequeueAfter: PollInterval}, nil
+}
+
+// claim adds the InferenceService to the owners of the node, and sets the MIG configuration of the profile when the
+// node is not partitioned with it yet
+func (r *MIGPartitionReconciler) claim(ctx context.Context, node *corev1.Node, owner, desiredConfig string) error {
+	// the owners of the node are merged by the InferenceServices claiming it concurrently, the patch fails on a
+	// conflict and the claim is retried from the updated node
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	if node.Labels[constants.NvidiaMigConfigLabel] != desiredConfig {
+		previous := node.Labels[constants.NvidiaMigConfigLabel]
+		if previous == "" {
+			previous = r.config.DefaultConfig
+		}
+		node.Annotations[constants.MIGPartitionPreviousConfigAnnotationKey] = previous
+		node.Labels[constants.NvidiaMigConfigLabel] = desiredConfig
+		// the state of the previous configuration must not be mistaken for the state of the new one
+		delete(node.Labels, constants.NvidiaMigConfigStateLabel)
+	}
+	setOwners(node, append(owners(node), owner))
+	return r.client.Patch(ctx, node, patch)
+}
+
+func (r *MIGPartitionReconciler) release(ctx context.Context, node *corev1.Node, owner string) error {
+	return release(ctx, r.client, node, owner)
+}
+
+// Release removes the InferenceService from the owners of the nodes it claimed, and restores the previous MIG
+// configuration of the nodes no other InferenceService uses
+func Release(ctx context.Context, cl client.Client, isvc *v1beta1.InferenceService) error {
+	nodes := &corev1.NodeList{}
+	if err := cl.List(ctx, nodes); err != nil {
+		return err
+	}
+	owner := ownerKey(isvc)
+	for i := range nodes.Items {
+		if slices.Contains(owners(&nodes.Items[i]), owner) {
+			if err := release(ctx, cl, &nodes.Items[i], owner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func release(ctx context.Context, cl client.Client, node *corev1.Node, owner string) error {
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	remaining := slices.DeleteFunc(owners(node), func(o string) bool { return o == owner })
+	setOwners(node, remaining)
+	if len(remaining) == 0 {
+		if previous, ok := node.Annotations[constants.MIGPartitionPreviousConfigAnnotationKey]; ok {
+			log.Info("Restoring the MIG configuration of the released node", "node", node.Name, "config", previous)
+			node.Labels[constants.NvidiaMigConfigLabel] = previous
+			delete(node.Labels, constants.NvidiaMigConfigStateLabel)
+			delete(node.Annotations, constants.MIGPartitionPreviousConfigAnnotationKey)
+		}
+	}
+	return client.IgnoreNotFound(cl.Patch(ctx, node, patch))
+}
+
+// AvailableNodes returns the nodes targeted by the predictor which can provide the MIG profile, the nodes already
+// partitioned with the profile first. A node can be partitioned when it has a GPU product of the configuration whose
+// memory fits the profile, and is neither partitioned for another profile, used by another InferenceService nor
+// running pods requesting GPUs, which the MIG manager would evict.
+func AvailableNodes(nodes []corev1.Node, gpuPodNodes sets.Set[string], isvc *v1beta1.InferenceService, config *v1beta1.MIGPartitioningConfig, profile string) []*corev1.Node {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeSelector: isvc.Spec.Predictor.NodeSelector,
+			Affinity:     isvc.Spec.Predictor.Affinity,
+		},
+	}
+	requiredAffinity := nodeaffinity.GetRequiredNodeAffinity(pod)
+	desiredConfig := ConfigName(profile)
+	owner := ownerKey(isvc)
+	var available []*corev1.Node
+	for i := range nodes {
+		node := &nodes[i]
+		if !partitionable(node, config, profile) {
+			continue
+		}
+		if match, err := requiredAffinity.Match(node); err != nil || !match {
+			continue
+		}
+		current := node.Labels[constants.NvidiaMigConfigLabel]
+		nodeOwners := owners(node)
+		shared := current == desiredConfig
+		free := len(nodeOwners) == 0 && (current == "" || current == config.DefaultConfig) && !gpuPodNodes.Has(node.Name)
+		if shared || free || slices.Contains(nodeOwners, owner) {
+			available = append(available, node)
+		}
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		iShared := available[i].Labels[constants.NvidiaMigConfigLabel] == desiredConfig
+		jShared := available[j].Labels[constants.NvidiaMigConfigLabel] == desiredConfig
+		if iShared != jShared {
+			return iShared
+		}
+		return available[i].Name < available[j].Name
+	})
+	return available
+}
+
+// GPUPodNodes returns the nodes running pods which are not terminated and request GPUs. The pods are listed from the
+// API server, the manager only caches the pods of the InferenceServices.
+func GPUPodNodes(ctx context.Context, clientset kubernetes.Interface) (sets.Set[string], error) {
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	nodes := sets.New[string]()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || nodes.Has(pod.Spec.NodeName) ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if slices.ContainsFunc(slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers), func(container corev1.Container) bool {
+			return utils.IsGPUEnabled(container.Resources)
+		}) {
+			nodes.Insert(pod.Spec.NodeName)
+		}
+	}
+	return nodes, nil
+}
+
+// partitionable returns whether the GPUs of the node support MIG and are large enough for the profile
+func partitionable(node *corev1.Node, config *v1beta1.MIGPartitioningConfig, profile string) bool {
+	if node.Labels[constants.NvidiaMigCapableLabel] == "false" {
+		return false
+	}
+	product := node.Labels[constants.NvidiaGPUProductLabel]
+	if !slices.ContainsFunc(config.Products, func(p string) bool { return strings.Contains(product, p) }) {
+		return false
+	}
+	memory, err := strconv.ParseInt(node.Labels[constants.NvidiaGPUMemoryLabel], 10, 64)
+	if err != nil {
+		// the memory of the GPUs is not known
+		return true
+	}
+	// the memory of the profiles is rounded up in their names, e.g. the 7g.80gb partition of a H100 has 79.6GiB
+	return ProfileMemoryGB(profile)*1000 <= memory
+}
+
+// Profile returns the MIG profile requested by the predictor, e.g. 3g.40gb for the nvidia.com/mig-3g.40gb resource,
+// and the number of partitions requested by a predictor pod. It returns an empty profile when the predictor does not
+// request a MIG partition, and an error when it requests several profiles or an invalid one.
+func Profile(isvc *v1beta1.InferenceService) (string, int64, error) {
+	type resourceRequirementsGetter interface {
+		GetResourceRequirements() *corev1.ResourceRequirements
+	}
+	var requirements []corev1.ResourceRequirements
+	predictor := &isvc.Spec.Predictor
+	// Custom implementations are backed by the pod spec containers, which are added below
+	for _, implementation := range predictor.GetImplementations() {
+		if getter, ok := implementation.(resourceRequirementsGetter); ok {
+			requirements = append(requirements, *getter.GetResourceRequirements())
+		}
+	}
+	for _, container := range predictor.Containers {
+		requirements = append(requirements, container.Resources)
+	}
+
+	profiles := map[string]int64{}
+	for _, requirement := range requirements {
+		resources := requirement.Limits
+		if len(resources) == 0 {
+			resources = requirement.Requests
+		}
+		for name, quantity := range resources {
+			if profile, ok := strings.CutPrefix(string(name), constants.NvidiaMigGPUResourceTypePrefix+"-"); ok {
+				profiles[profile] += quantity.Value()
+			}
+		}
+	}
+	switch len(profiles) {
+	case 0:
+		return "", 0, nil
+	case 1:
+		for profile, partitions := range profiles {
+			if !profileRegexp.MatchString(profile) {
+				return "", 0, fmt.Errorf("invalid MIG profile %q requested by the predictor of the InferenceService %s", profile, isvc.Name)
+			}
+			return profile, partitions, nil
+		}
+	}
+	requested := make([]string, 0, len(profiles))
+	for profile := range profiles {
+		requested = append(requested, profile)
+	}
+	sort.Strings(requested)
+	return "", 0, fmt.Errorf("the predictor of the InferenceService %s requests several MIG profiles %s, only one is supported",
+		isvc.Name, strings.Join(requested, ", "))
+}
+
+// ProfileMemoryGB returns the GPU memory of a MIG profile in GB, e.g. 40 for 3g.40gb
+func ProfileMemoryGB(profile string) int64 {
+	match := profileRegexp.FindStringSubmatch(profile)
+	if match == nil {
+		return 0
+	}
+	memory, _ := strconv.ParseInt(match[2], 10, 64)
+	return memory
+}
+
+// ConfigName returns the configuration of the MIG manager partitioning all the GPUs of a node with the profile
+func ConfigName(profile string) string {
+	return constants.NvidiaMigConfigPrefix + profile
+}
+
+func ownerKey(isvc *v1beta1.InferenceService) string {
+	return isvc.Namespace + "/" + isvc.Name
+}
+
+func owners(node *corev1.Node) []string {
+	value := node.Annotations[constants.MIGPartitionOwnersAnnotationKey]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func setOwners(node *corev1.Node, nodeOwners []string) {
+	if len(nodeOwners) == 0 {
+		delete(node.Annotations, constants.MIGPartitionOwnersAnnotationKey)
+		return
+	}
+	sort.Strings(nodeOwners)
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[constants.MIGPartitionOwnersAnnotationKey] = strings.Join(slices.Compact(nodeOwners), ",")
+}
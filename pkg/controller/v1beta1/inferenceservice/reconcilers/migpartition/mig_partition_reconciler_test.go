@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migpartition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var testConfig = &v1beta1.MIGPartitioningConfig{
+	Enabled:       true,
+	Products:      v1beta1.DefaultMIGProducts,
+	DefaultConfig: constants.NvidiaMigDisabledConfig,
+}
+
+func newNode(name, product string, labels map[string]string, owners string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{constants.NvidiaGPUProductLabel: product, constants.NvidiaGPUMemoryLabel: "81559"},
+		},
+	}
+	for key, value := range labels {
+		node.Labels[key] = value
+	}
+	if owners != "" {
+		node.Annotations = map[string]string{constants.MIGPartitionOwnersAnnotationKey: owners}
+	}
+	return node
+}
+
+func newInferenceService(profile string, minReplicas int32) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(minReplicas)},
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "huggingface"},
+				},
+			},
+		},
+	}
+	if profile != "" {
+		isvc.Spec.Predictor.Model.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceName(constants.NvidiaMigGPUResourceTypePrefix + "-" + profile): resource.MustParse("1"),
+		}
+	}
+	return isvc
+}
+
+func newClient(t *testing.T, nodes ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodes...).Build()
+}
+
+func getNode(t *testing.T, cl client.Client, name string) *corev1.Node {
+	node := &corev1.Node{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: name}, node))
+	return node
+}
+
+func TestMIGPartitionReconcile(t *testing.T) {
+	partitioned := func(name, profile, state, owners string, allocatable int64) *corev1.Node {
+		node := newNode(name, "NVIDIA-H100-80GB-HBM3", map[string]string{
+			constants.NvidiaMigConfigLabel:      ConfigName(profile),
+			constants.NvidiaMigConfigStateLabel: state,
+		}, owners)
+		node.Status.Allocatable = corev1.ResourceList{
+			corev1.ResourceName(constants.NvidiaMigGPUResourceTypePrefix + "-" + profile): *resource.NewQuantity(allocatable, resource.DecimalSI),
+		}
+		return node
+	}
+
+	gpuPod := func(name, node string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "training"},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+				Containers: []corev1.Container{{
+					Name: "trainer",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("8")},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	scenarios := map[string]struct {
+		nodes          []client.Object
+		pods           []runtime.Object
+		minReplicas    int32
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+		// claimed is the node expected to be claimed by the reconcile
+		claimed string
+	}{
+		"claims a free node": {
+			nodes: []client.Object{
+				newNode("t4", "Tesla-T4", nil, ""),
+				newNode("h100", "NVIDIA-H100-80GB-HBM3", nil, ""),
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: PartitionPendingReason,
+			claimed:        "h100",
+		},
+		"skips the free nodes running GPU pods": {
+			nodes: []client.Object{
+				newNode("a-h100", "NVIDIA-H100-80GB-HBM3", nil, ""),
+				newNode("b-h100", "NVIDIA-H100-80GB-HBM3", nil, ""),
+			},
+			pods: []runtime.Object{
+				gpuPod("running", "a-h100", corev1.PodRunning),
+				gpuPod("completed", "b-h100", corev1.PodSucceeded),
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: PartitionPendingReason,
+			claimed:        "b-h100",
+		},
+		"prefers a node partitioned with the profile": {
+			nodes: []client.Object{
+				newNode("a-h100", "NVIDIA-H100-80GB-HBM3", nil, ""),
+				partitioned("b-h100", "3g.40gb", constants.NvidiaMigStateSuccess, "default/other", 2),
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: PartitionPendingReason,
+			claimed:        "b-h100",
+		},
+		"waits for the MIG manager": {
+			nodes:          []client.Object{partitioned("h100", "3g.40gb", "pending", "default/llm", 0)},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedReason: PartitionPendingReason,
+		},
+		"partitioned": {
+			nodes:          []client.Object{partitioned("h100", "3g.40gb", constants.NvidiaMigStateSuccess, "default/llm", 2)},
+			minReplicas:    2,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		"partitioning failed": {
+			nodes:          []client.Object{partitioned("h100", "3g.40gb", constants.NvidiaMigStateFailed, "default/llm", 0)},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: PartitionFailedReason,
+		},
+		"not enough partitions and no other node": {
+			nodes: []client.Object{
+				partitioned("h100", "3g.40gb", constants.NvidiaMigStateSuccess, "default/llm", 2),
+				partitioned("h100-other", "1g.10gb", constants.NvidiaMigStateSuccess, "default/other", 7),
+			},
+			minReplicas:    3,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: PartitionUnavailableReason,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			cl := newClient(t, scenario.nodes...)
+			isvc := newInferenceService("3g.40gb", scenario.minReplicas)
+			result, err := NewMIGPartitionReconciler(cl, k8sfake.NewSimpleClientset(scenario.pods...), testConfig).Reconcile(context.Background(), isvc)
+			require.NoError(t, err)
+
+			condition := isvc.Status.GetCondition(v1beta1.MIGPartitionReady)
+			require.NotNil(t, condition)
+			assert.Equal(t, scenario.expectedStatus, condition.Status)
+			assert.Equal(t, scenario.expectedReason, condition.Reason)
+			if scenario.expectedStatus == corev1.ConditionTrue {
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.Equal(t, PollInterval, result.RequeueAfter)
+			}
+			if scenario.claimed != "" {
+				node := getNode(t, cl, scenario.claimed)
+				assert.Equal(t, "all-3g.40gb", node.Labels[constants.NvidiaMigConfigLabel])
+				assert.Contains(t, owners(node), "default/llm")
+			}
+		})
+	}
+}
+
+func TestMIGPartitionRelease(t *testing.T) {
+	cl := newClient(t, newNode("h100", "NVIDIA-H100-80GB-HBM3", map[string]string{constants.NvidiaMigConfigLabel: "all-balanced"}, ""))
+	isvc := newInferenceService("3g.40gb", 1)
+	reconciler := NewMIGPartitionReconciler(cl, k8sfake.NewSimpleClientset(), testConfig)
+
+	// the node is not free, it is partitioned with another configuration
+	_, err := reconciler.Reconcile(context.Background(), isvc)
+	require.NoError(t, err)
+	assert.Equal(t, PartitionUnavailableReason, isvc.Status.GetCondition(v1beta1.MIGPartitionReady).Reason)
+
+	config := *testConfig
+	config.DefaultConfig = "all-balanced"
+	reconciler = NewMIGPartitionReconciler(cl, k8sfake.NewSimpleClientset(), &config)
+	_, err = reconciler.Reconcile(context.Background(), isvc)
+	require.NoError(t, err)
+	node := getNode(t, cl, "h100")
+	assert.Equal(t, "all-3g.40gb", node.Labels[constants.NvidiaMigConfigLabel])
+	assert.Equal(t, "all-balanced", node.Annotations[constants.MIGPartitionPreviousConfigAnnotationKey])
+
+	// the node is shared by another InferenceService, and restored once both released it
+	other := newInferenceService("3g.40gb", 1)
+	other.Name = "other"
+	_, err = reconciler.Reconcile(context.Background(), other)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default/llm", "default/other"}, owners(getNode(t, cl, "h100")))
+
+	require.NoError(t, Release(context.Background(), cl, isvc))
+	node = getNode(t, cl, "h100")
+	assert.Equal(t, "all-3g.40gb", node.Labels[constants.NvidiaMigConfigLabel])
+	assert.Equal(t, []string{"default/other"}, owners(node))
+
+	// the predictor of the other InferenceService does not request a MIG profile anymore
+	other.Spec.Predictor.Model.Resources.Limits = nil
+	_, err = reconciler.Reconcile(context.Background(), other)
+	require.NoError(t, err)
+	assert.Nil(t, other.Status.GetCondition(v1beta1.MIGPartitionReady))
+	node = getNode(t, cl, "h100")
+	assert.Equal(t, "all-balanced", node.Labels[constants.NvidiaMigConfigLabel])
+	assert.Empty(t, node.Annotations)
+}
+
+func TestProfile(t *testing.T) {
+	profile, partitions, err := Profile(newInferenceService("1g.10gb+me", 1))
+	require.NoError(t, err)
+	assert.Equal(t, "1g.10gb+me", profile)
+	assert.Equal(t, int64(1), partitions)
+	assert.Equal(t, int64(10), ProfileMemoryGB(profile))
+
+	profile, _, err = Profile(newInferenceService("", 1))
+	require.NoError(t, err)
+	assert.Empty(t, profile)
+
+	_, _, err = Profile(newInferenceService("mixed", 1))
+	require.Error(t, err)
+}
+
+func TestMIGPartitionClaimConflict(t *testing.T) {
+	cl := newClient(t, newNode("h100", "NVIDIA-H100-80GB-HBM3", nil, ""))
+	reconciler := NewMIGPartitionReconciler(cl, k8sfake.NewSimpleClientset(), testConfig)
+	stale := getNode(t, cl, "h100")
+	require.NoError(t, reconciler.claim(context.Background(), getNode(t, cl, "h100"), "default/other", "all-1g.10gb"))
+
+	// the node claimed concurrently for another profile is not claimed from its stale state
+	err := reconciler.claim(context.Background(), stale, "default/llm", "all-3g.40gb")
+	require.True(t, apierr.IsConflict(err), "%v", err)
+	node := getNode(t, cl, "h100")
+	assert.Equal(t, "all-1g.10gb", node.Labels[constants.NvidiaMigConfigLabel])
+	assert.Equal(t, []string{"default/other"}, owners(node))
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multimodelconfig
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// ModelStatusRetryInterval is the interval at which the statuses of the models which are not loaded yet are refreshed
+const ModelStatusRetryInterval = 10 * time.Second
+
+// ModelStatusReconciler updates the statuses of the models declared in the predictor from the readiness reported
+// by the model servers of the latest predictor revision.
+type ModelStatusReconciler struct {
+	client     client.Client
+	httpClient *http.Client
+}
+
+func NewModelStatusReconciler(client client.Client) *ModelStatusReconciler {
+	return &ModelStatusReconciler{
+		client:     client,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Reconcile sets a status for every model declared in the predictor. The models are Pending until the predictor
+// is ready and the latest revision has ready pods, then Loaded once the model readiness endpoint of every pod of the
+// revision reports the model ready, or Loading otherwise.
+// The statuses are refreshed after ModelStatusRetryInterval until all the models are loaded.
+func (r *ModelStatusReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) (ctrl.Result, error) {
+	models := isvc.Spec.Predictor.Models
+	if len(models) == 0 {
+		isvc.Status.Models = nil
+		return ctrl.Result{}, nil
+	}
+	protocol := constants.ProtocolV1
+	if len(isvc.Spec.Predictor.GetImplementations()) > 0 {
+		protocol = isvc.Spec.Predictor.GetImplementation().GetProtocol()
+	}
+	var podURLs []string
+	if isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		_, pods, err := v1beta1utils.GetPredictorRevisionPods(ctx, r.client, isvc, deploymentMode)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range pods {
+			podURLs = append(podURLs, v1beta1utils.GetPodURL(&pods[i]))
+		}
+	}
+
+	allLoaded := true
+	statuses := make([]v1beta1.PredictorModelStatus, 0, len(models))
+	for _, model := range models {
+		status := v1beta1.PredictorModelStatus{
+			Name:  model.Name,
+			State: v1beta1.Pending,
+		}
+		if isvc.Status.URL != nil {
+			url, err := apis.ParseURL(isvc.Status.URL.String() + constants.PredictPath(model.Name, protocol))
+			if err == nil {
+				status.URL = url
+			}
+		}
+		if len(podURLs) > 0 {
+			status.State = v1beta1.Loaded
+			for _, podURL := range podURLs {
+				if !r.isModelReady(ctx, podURL+constants.ModelReadyPath(model.Name, protocol)) {
+					status.State = v1beta1.Loading
+					break
+				}
+			}
+		}
+		if status.State != v1beta1.Loaded {
+			allLoaded = false
+		}
+		statuses = append(statuses, status)
+	}
+	isvc.Status.Models = statuses
+	if !allLoaded && len(podURLs) > 0 {
+		return ctrl.Result{RequeueAfter: ModelStatusRetryInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *ModelStatusReconciler) isModelReady(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		log.Info("Failed to get the model readiness", "url", url, "error", err.Error())
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
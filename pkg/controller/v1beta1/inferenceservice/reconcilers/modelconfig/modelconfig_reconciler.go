@@ -19,8 +19,8 @@ package multimodelconfig
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,7 +58,7 @@ func (c *ModelConfigReconciler) Reconcile(ctx context.Context, isvc *v1beta1.Inf
 		shardStrategy := memory.MemoryStrategy{}
 		for _, id := range shardStrategy.GetShard(isvc) {
 			modelConfigName := constants.ModelConfigName(isvc.Name, id)
-			_, err := c.clientset.CoreV1().ConfigMaps(isvc.Namespace).Get(ctx, modelConfigName, metav1.GetOptions{})
+			modelConfigParts, err := modelconfig.GetModelConfigParts(ctx, c.clientset, isvc.Namespace, modelConfigName)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					// If the modelConfig does not exist for an InferenceService without storageUri, create an empty modelConfig
@@ -70,15 +70,55 @@ func (c *ModelConfigReconciler) Reconcile(ctx context.Context, isvc *v1beta1.Inf
 					if err := controllerutil.SetControllerReference(isvc, newModelConfig, c.scheme); err != nil {
 						return err
 					}
+					_, created, err := processPredictorModels(isvc, []*corev1.ConfigMap{newModelConfig})
+					if err != nil {
+						return err
+					}
 					err = c.client.Create(ctx, newModelConfig)
 					if err != nil {
 						return err
 					}
+					if err := modelconfig.SaveModelConfigParts(ctx, c.client, nil, created); err != nil {
+						return err
+					}
 				} else {
 					return err
 				}
+			} else {
+				// Add the models declared in the predictor, the models added by TrainedModels are left untouched
+				updated, created, err := processPredictorModels(isvc, modelConfigParts)
+				if err != nil {
+					return err
+				}
+				if len(updated) > 0 || len(created) > 0 {
+					log.Info("Updating modelConfig with the predictor models", "configmap", modelConfigName, "inferenceservice", isvc.Name, "namespace", isvc.Namespace)
+					if err := modelconfig.SaveModelConfigParts(ctx, c.client, updated, created); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
 	return nil
 }
+
+// processPredictorModels adds the models declared in the predictor to the modelConfig parts and removes the models
+// which are no longer declared, as recorded in the model statuses of the InferenceService. It returns the modified
+// parts and the new parts of the modelConfig.
+func processPredictorModels(isvc *v1beta1.InferenceService, modelConfigParts []*corev1.ConfigMap) ([]*corev1.ConfigMap, []*corev1.ConfigMap, error) {
+	declared := make(map[string]bool, len(isvc.Spec.Predictor.Models))
+	for _, model := range isvc.Spec.Predictor.Models {
+		declared[model.Name] = true
+	}
+	var deleted []string
+	for _, status := range isvc.Status.Models {
+		if !declared[status.Name] {
+			deleted = append(deleted, status.Name)
+		}
+	}
+	if len(declared) == 0 && len(deleted) == 0 {
+		return nil, nil, nil
+	}
+	configDelta := modelconfig.NewConfigsDelta(modelconfig.PredictorModelConfigs(isvc.Spec.Predictor.Models), deleted)
+	return configDelta.ProcessParts(modelConfigParts)
+}
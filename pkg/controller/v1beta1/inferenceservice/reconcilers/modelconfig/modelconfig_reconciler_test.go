@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multimodelconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeTestInferenceService(models ...string) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "triton",
+			Namespace: "default",
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "triton"},
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						ProtocolVersion: ptr.To(constants.ProtocolV2),
+					},
+				},
+			},
+		},
+	}
+	for _, model := range models {
+		isvc.Spec.Predictor.Models = append(isvc.Spec.Predictor.Models, v1beta1.PredictorModelSpec{
+			Name:        model,
+			StorageURI:  "s3://bucket/" + model,
+			ModelFormat: v1beta1.ModelFormat{Name: "onnx"},
+		})
+	}
+	return isvc
+}
+
+func TestProcessPredictorModels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService("model1", "model2")
+	modelConfig := &corev1.ConfigMap{
+		Data: map[string]string{
+			constants.ModelConfigFileName: `[{"modelName":"trained","modelSpec":{"storageUri":"s3://bucket/trained","framework":"onnx","memory":"1Gi"}}]`,
+		},
+	}
+
+	updated, created, err := processPredictorModels(isvc, []*corev1.ConfigMap{modelConfig})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(updated).To(gomega.HaveLen(1))
+	g.Expect(created).To(gomega.BeEmpty())
+	g.Expect(modelConfig.Data[constants.ModelConfigFileName]).To(gomega.MatchJSON(`[
+		{"modelName":"model1","modelSpec":{"storageUri":"s3://bucket/model1","framework":"onnx","memory":"0"}},
+		{"modelName":"model2","modelSpec":{"storageUri":"s3://bucket/model2","framework":"onnx","memory":"0"}},
+		{"modelName":"trained","modelSpec":{"storageUri":"s3://bucket/trained","framework":"onnx","memory":"1Gi"}}
+	]`))
+
+	// the modelConfig is unchanged when the declared models are unchanged
+	updated, created, err = processPredictorModels(isvc, []*corev1.ConfigMap{modelConfig})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(updated).To(gomega.BeEmpty())
+	g.Expect(created).To(gomega.BeEmpty())
+
+	// the models which are no longer declared are removed, the models of the TrainedModels are kept
+	isvc.Status.Models = []v1beta1.PredictorModelStatus{{Name: "model1"}, {Name: "model2"}}
+	isvc.Spec.Predictor.Models = isvc.Spec.Predictor.Models[:1]
+	updated, _, err = processPredictorModels(isvc, []*corev1.ConfigMap{modelConfig})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(updated).To(gomega.HaveLen(1))
+	g.Expect(modelConfig.Data[constants.ModelConfigFileName]).To(gomega.MatchJSON(`[
+		{"modelName":"model1","modelSpec":{"storageUri":"s3://bucket/model1","framework":"onnx","memory":"0"}},
+		{"modelName":"trained","modelSpec":{"storageUri":"s3://bucket/trained","framework":"onnx","memory":"1Gi"}}
+	]`))
+}
+
+// makeTestPod returns a ready pod of the latest predictor revision serving on the address of a test server
+func makeTestPod(t *testing.T, name string, serverURL string) *corev1.Pod {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{constants.RevisionLabel: "triton-predictor-00001"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []corev1.ContainerPort{{ContainerPort: int32(port)}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			PodIP:      u.Hostname(),
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestModelStatusReconcile(t *testing.T) {
+	loaded := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/models/model1/ready" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer loaded.Close()
+	loading := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer loading.Close()
+	isvcUrl, _ := apis.ParseURL("http://triton.default.example.com")
+	newReconciler := func(t *testing.T, pods ...client.Object) *ModelStatusReconciler {
+		s := runtime.NewScheme()
+		if err := corev1.AddToScheme(s); err != nil {
+			t.Fatal(err)
+		}
+		return NewModelStatusReconciler(fake.NewClientBuilder().WithScheme(s).WithObjects(pods...).Build())
+	}
+	makeReadyInferenceService := func() *v1beta1.InferenceService {
+		isvc := makeTestInferenceService("model1", "model2")
+		isvc.Status.InitializeConditions()
+		isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+		isvc.Status.URL = isvcUrl
+		isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+			v1beta1.PredictorComponent: {LatestCreatedRevision: "triton-predictor-00001"},
+		}
+		return isvc
+	}
+
+	t.Run("predictor not ready", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeTestInferenceService("model1", "model2")
+		isvc.Status.InitializeConditions()
+		result, err := newReconciler(t).Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.BeZero())
+		g.Expect(isvc.Status.Models).To(gomega.Equal([]v1beta1.PredictorModelStatus{
+			{Name: "model1", State: v1beta1.Pending},
+			{Name: "model2", State: v1beta1.Pending},
+		}))
+	})
+
+	t.Run("predictor ready", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeReadyInferenceService()
+		r := newReconciler(t, makeTestPod(t, "triton-1", loaded.URL), makeTestPod(t, "triton-2", loaded.URL))
+		result, err := r.Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(ModelStatusRetryInterval))
+		g.Expect(isvc.Status.Models).To(gomega.HaveLen(2))
+		g.Expect(isvc.Status.Models[0].State).To(gomega.Equal(v1beta1.Loaded))
+		g.Expect(isvc.Status.Models[0].URL.String()).To(gomega.Equal("http://triton.default.example.com/v2/models/model1/infer"))
+		g.Expect(isvc.Status.Models[1].State).To(gomega.Equal(v1beta1.Loading))
+		g.Expect(isvc.Status.Models[1].URL.String()).To(gomega.Equal("http://triton.default.example.com/v2/models/model2/infer"))
+	})
+
+	t.Run("model loading on a pod of the revision", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeReadyInferenceService()
+		r := newReconciler(t, makeTestPod(t, "triton-1", loaded.URL), makeTestPod(t, "triton-2", loading.URL))
+		result, err := r.Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(ModelStatusRetryInterval))
+		g.Expect(isvc.Status.Models[0].State).To(gomega.Equal(v1beta1.Loading))
+		g.Expect(isvc.Status.Models[1].State).To(gomega.Equal(v1beta1.Loading))
+	})
+
+	t.Run("no ready pods", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeReadyInferenceService()
+		result, err := newReconciler(t).Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.BeZero())
+		g.Expect(isvc.Status.Models[0].State).To(gomega.Equal(v1beta1.Pending))
+	})
+
+	t.Run("no predictor models", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		isvc := makeTestInferenceService()
+		isvc.Status.Models = []v1beta1.PredictorModelStatus{{Name: "model1", State: v1beta1.Loaded}}
+		result, err := newReconciler(t).Reconcile(t.Context(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.BeZero())
+		g.Expect(isvc.Status.Models).To(gomega.BeNil())
+	})
+}
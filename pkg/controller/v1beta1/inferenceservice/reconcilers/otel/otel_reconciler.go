@@ -41,9 +41,11 @@ const (
 	ProcessorResourcedetectionEnv = "resourcedetection/env"
 	ProcessorTransform            = "transform"
 	ProcessorFilterMetrics        = "filter/metrics"
+	ProcessorFilterForward        = "filter/forward"
 	JobNameOtelCollector          = "otel-collector"
 	PrometheusReceiver            = "prometheus"
 	OtlpExporter                  = "otlp"
+	OtlpForwardExporter           = "otlp/forward"
 	ModeSidecar                   = "sidecar"
 
 	AnnotationPrometheusPort = "prometheus.kserve.io/port"
@@ -59,6 +61,7 @@ const (
 
 	MatchTypeStrict = "strict"
 	PipelineMetrics = "metrics"
+	PipelineForward = "metrics/forward"
 	CompressionNone = "none"
 	TlsKey          = "tls"
 	TlsInsecureKey  = "insecure"
@@ -99,11 +102,12 @@ func NewOtelReconciler(client client.Client,
 	componentMeta metav1.ObjectMeta,
 	metricNames []string,
 	otelConfig v1beta1.OtelCollectorConfig,
+	sidecar *v1beta1.OtelCollectorSidecarSpec,
 ) (*OtelReconciler, error) {
 	return &OtelReconciler{
 		client:        client,
 		scheme:        scheme,
-		OTelCollector: createOtelCollector(componentMeta, metricNames, otelConfig),
+		OTelCollector: createOtelCollector(componentMeta, metricNames, otelConfig, sidecar),
 	}, nil
 }
 
@@ -135,9 +139,24 @@ func createResourceRequirements(resourceConfig v1beta1.ResourceConfig) corev1.Re
 	return resourceRequirements
 }
 
+func createFilterProcessor(metricNames []string) map[string]interface{} {
+	return map[string]interface{}{
+		KeyMetrics: map[string]interface{}{
+			KeyInclude: map[string]interface{}{
+				KeyMatchType:   MatchTypeStrict,
+				KeyMetricNames: metricNames,
+			},
+		},
+	}
+}
+
+// createOtelCollector creates a sidecar collector scraping the metrics of the component. The metrics used for
+// autoscaling are exported to the metric receiver, and when an observability sidecar is requested for the
+// InferenceService all the (or the selected) metrics are forwarded to the configured endpoint as well.
 func createOtelCollector(componentMeta metav1.ObjectMeta,
 	metricNames []string,
 	otelConfig v1beta1.OtelCollectorConfig,
+	sidecar *v1beta1.OtelCollectorSidecarSpec,
 ) *otelv1beta1.OpenTelemetryCollector {
 	port, ok := componentMeta.Annotations[AnnotationPrometheusPort]
 	if !ok {
@@ -165,19 +184,52 @@ func createOtelCollector(componentMeta metav1.ObjectMeta,
 		},
 	}
 
-	pipelineProcessors := []string{ProcessorResourcedetectionEnv, ProcessorTransform}
+	exporters := map[string]interface{}{}
+	pipelines := map[string]*otelv1beta1.Pipeline{}
+	scrapeInterval := otelConfig.ScrapeInterval
 
-	// Add filter processor to include all specified metrics
-	if len(metricNames) > 0 {
-		processors[ProcessorFilterMetrics] = map[string]interface{}{
-			KeyMetrics: map[string]interface{}{
-				KeyInclude: map[string]interface{}{
-					KeyMatchType:   MatchTypeStrict,
-					KeyMetricNames: metricNames,
-				},
+	if sidecar == nil || len(metricNames) > 0 {
+		pipelineProcessors := []string{ProcessorResourcedetectionEnv, ProcessorTransform}
+		// Add filter processor to include all specified metrics
+		if len(metricNames) > 0 {
+			processors[ProcessorFilterMetrics] = createFilterProcessor(metricNames)
+			pipelineProcessors = append(pipelineProcessors, ProcessorFilterMetrics)
+		}
+		exporters[OtlpExporter] = map[string]interface{}{
+			KeyEndpoint:    otelConfig.MetricReceiverEndpoint,
+			KeyCompression: CompressionNone,
+			KeyTls: map[string]interface{}{
+				KeyInsecure: true,
+			},
+		}
+		pipelines[PipelineMetrics] = &otelv1beta1.Pipeline{
+			Receivers:  []string{PrometheusReceiver},
+			Processors: pipelineProcessors,
+			Exporters:  []string{OtlpExporter},
+		}
+	}
+
+	if sidecar != nil {
+		if sidecar.ScrapeInterval != "" {
+			scrapeInterval = sidecar.ScrapeInterval
+		}
+		endpoint := otelConfig.ForwardEndpoint(sidecar)
+		pipelineProcessors := []string{ProcessorResourcedetectionEnv, ProcessorTransform}
+		if len(sidecar.MetricNames) > 0 {
+			processors[ProcessorFilterForward] = createFilterProcessor(sidecar.MetricNames)
+			pipelineProcessors = append(pipelineProcessors, ProcessorFilterForward)
+		}
+		exporters[OtlpForwardExporter] = map[string]interface{}{
+			KeyEndpoint: endpoint,
+			KeyTls: map[string]interface{}{
+				KeyInsecure: true,
 			},
 		}
-		pipelineProcessors = append(pipelineProcessors, ProcessorFilterMetrics)
+		pipelines[PipelineForward] = &otelv1beta1.Pipeline{
+			Receivers:  []string{PrometheusReceiver},
+			Processors: pipelineProcessors,
+			Exporters:  []string{OtlpForwardExporter},
+		}
 	}
 
 	otelCollector := &otelv1beta1.OpenTelemetryCollector{
@@ -195,7 +247,7 @@ func createOtelCollector(componentMeta metav1.ObjectMeta,
 							KeyScrapeConfigs: []interface{}{
 								map[string]interface{}{
 									KeyJobName:        JobNameOtelCollector,
-									KeyScrapeInterval: otelConfig.ScrapeInterval,
+									KeyScrapeInterval: scrapeInterval,
 									KeyStaticConfigs: []interface{}{
 										map[string]interface{}{
 											KeyTargets: []interface{}{"localhost:" + port},
@@ -206,24 +258,10 @@ func createOtelCollector(componentMeta metav1.ObjectMeta,
 						},
 					},
 				}},
-				Exporters: otelv1beta1.AnyConfig{Object: map[string]interface{}{
-					OtlpExporter: map[string]interface{}{
-						KeyEndpoint:    otelConfig.MetricReceiverEndpoint,
-						KeyCompression: CompressionNone,
-						KeyTls: map[string]interface{}{
-							KeyInsecure: true,
-						},
-					},
-				}},
+				Exporters:  otelv1beta1.AnyConfig{Object: exporters},
 				Processors: &otelv1beta1.AnyConfig{Object: processors},
 				Service: otelv1beta1.Service{
-					Pipelines: map[string]*otelv1beta1.Pipeline{
-						PipelineMetrics: {
-							Receivers:  []string{PrometheusReceiver},
-							Processors: pipelineProcessors,
-							Exporters:  []string{OtlpExporter},
-						},
-					},
+					Pipelines: pipelines,
 				},
 			},
 			OpenTelemetryCommonFields: otelv1beta1.OpenTelemetryCommonFields{
@@ -89,7 +89,7 @@ func TestCreateOtelCollector(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			collector := createOtelCollector(tc.componentMeta, tc.metricNames, tc.otelConfig)
+			collector := createOtelCollector(tc.componentMeta, tc.metricNames, tc.otelConfig, nil)
 
 			assert.Equal(t, tc.componentMeta.Name, collector.Name)
 			assert.Equal(t, tc.componentMeta.Namespace, collector.Namespace)
@@ -164,7 +164,7 @@ func TestReconcileCreate(t *testing.T) {
 	// Create fake client
 	client := fake.NewClientBuilder().WithScheme(scheme).Build()
 	// Create reconciler
-	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig)
+	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig, nil)
 	require.NoError(t, err)
 
 	// Test reconcile - should create a new resource
@@ -240,7 +240,7 @@ func TestReconcileUpdate(t *testing.T) {
 	// Create fake client with existing collector
 	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingCollector).Build()
 	// Create reconciler
-	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig)
+	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig, nil)
 	require.NoError(t, err)
 
 	// Test reconcile - should update existing resource
@@ -295,7 +295,7 @@ func TestSetControllerReferences(t *testing.T) {
 	// Create fake client
 	client := fake.NewClientBuilder().WithScheme(scheme).Build()
 	// Create reconciler
-	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig)
+	reconciler, err := NewOtelReconciler(client, scheme, componentMeta, []string{}, otelConfig, nil)
 	require.NoError(t, err)
 
 	// Test set controller reference
@@ -327,7 +327,7 @@ func TestCreateOtelCollectorWithResources(t *testing.T) {
 		},
 	}
 
-	collector := createOtelCollector(componentMeta, []string{}, otelConfig)
+	collector := createOtelCollector(componentMeta, []string{}, otelConfig, nil)
 
 	// Verify resource requirements are set correctly
 	resources := collector.Spec.OpenTelemetryCommonFields.Resources
@@ -361,7 +361,7 @@ func TestCreateOtelCollectorWithPartialResources(t *testing.T) {
 		},
 	}
 
-	collector := createOtelCollector(componentMeta, []string{}, otelConfig)
+	collector := createOtelCollector(componentMeta, []string{}, otelConfig, nil)
 
 	// Verify only CPU resource requirements are set
 	resources := collector.Spec.OpenTelemetryCommonFields.Resources
@@ -391,7 +391,7 @@ func TestCreateOtelCollectorWithNoResources(t *testing.T) {
 		Resource:               v1beta1.ResourceConfig{}, // Empty resource config
 	}
 
-	collector := createOtelCollector(componentMeta, []string{}, otelConfig)
+	collector := createOtelCollector(componentMeta, []string{}, otelConfig, nil)
 
 	// Verify no resource requirements are set
 	resources := collector.Spec.OpenTelemetryCommonFields.Resources
@@ -400,3 +400,53 @@ func TestCreateOtelCollectorWithNoResources(t *testing.T) {
 	assert.Empty(t, resources.Limits)
 	assert.Empty(t, resources.Requests)
 }
+
+func TestCreateOtelCollectorWithObservabilitySidecar(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:      "test-service",
+		Namespace: "default",
+	}
+
+	otelConfig := v1beta1.OtelCollectorConfig{
+		ScrapeInterval:         "30s",
+		MetricReceiverEndpoint: "otel-receiver:4317",
+		MetricForwardEndpoint:  "otel-cluster-collector:4317",
+	}
+
+	t.Run("forwards all metrics to the default endpoint", func(t *testing.T) {
+		collector := createOtelCollector(componentMeta, nil, otelConfig, &v1beta1.OtelCollectorSidecarSpec{})
+
+		pipelines := collector.Spec.Config.Service.Pipelines
+		assert.NotContains(t, pipelines, PipelineMetrics)
+		require.Contains(t, pipelines, PipelineForward)
+		assert.Equal(t, []string{OtlpForwardExporter}, pipelines[PipelineForward].Exporters)
+		assert.Equal(t, []string{ProcessorResourcedetectionEnv, ProcessorTransform}, pipelines[PipelineForward].Processors)
+
+		exporter := collector.Spec.Config.Exporters.Object[OtlpForwardExporter].(map[string]interface{})
+		assert.Equal(t, "otel-cluster-collector:4317", exporter[KeyEndpoint])
+	})
+
+	t.Run("forwards the selected metrics along with the autoscaling metrics", func(t *testing.T) {
+		sidecar := &v1beta1.OtelCollectorSidecarSpec{
+			Endpoint:       "tenant-collector:4317",
+			ScrapeInterval: "10s",
+			MetricNames:    []string{"request_latency"},
+		}
+		collector := createOtelCollector(componentMeta, []string{"request_count"}, otelConfig, sidecar)
+
+		pipelines := collector.Spec.Config.Service.Pipelines
+		require.Contains(t, pipelines, PipelineMetrics)
+		require.Contains(t, pipelines, PipelineForward)
+		assert.Equal(t, []string{ProcessorResourcedetectionEnv, ProcessorTransform, ProcessorFilterForward}, pipelines[PipelineForward].Processors)
+
+		processors := collector.Spec.Config.Processors.Object
+		include := processors[ProcessorFilterForward].(map[string]interface{})[KeyMetrics].(map[string]interface{})[KeyInclude].(map[string]interface{})
+		assert.Equal(t, []string{"request_latency"}, include[KeyMetricNames])
+
+		exporter := collector.Spec.Config.Exporters.Object[OtlpForwardExporter].(map[string]interface{})
+		assert.Equal(t, "tenant-collector:4317", exporter[KeyEndpoint])
+
+		scrapeConfig := collector.Spec.Config.Receivers.Object[PrometheusReceiver].(map[string]interface{})[KeyConfig].(map[string]interface{})[KeyScrapeConfigs].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "10s", scrapeConfig[KeyScrapeInterval])
+	})
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("PodMonitorReconciler")
+
+// PodMonitorGVK is the GroupVersionKind of the Prometheus Operator PodMonitors
+var PodMonitorGVK = schema.FromAPIVersionAndKind(constants.PrometheusOperatorAPIVersion, constants.PodMonitorKind)
+
+// relabeling copies a label of the scraped pods to the label of the metrics
+type relabeling struct {
+	SourceLabels []string `json:"sourceLabels"`
+	TargetLabel  string   `json:"targetLabel"`
+}
+
+// podMetricsEndpoint is an endpoint of the pods scraped by a PodMonitor
+type podMetricsEndpoint struct {
+	Port        string       `json:"port"`
+	Path        string       `json:"path"`
+	Interval    string       `json:"interval,omitempty"`
+	Relabelings []relabeling `json:"relabelings"`
+}
+
+// PodMonitorReconciler reconciles the PodMonitor scraping the queue-proxy metrics of the revisions of an
+// InferenceService in Knative deployment mode when the pod monitors are enabled. The PodMonitor is named after the
+// InferenceService and scrapes the aggregated metrics port of queue-proxy when the metrics aggregation is enabled,
+// so that the metrics of the runtime are collected as well, and the user metrics port of queue-proxy otherwise. The
+// metrics port of the detector is scraped as well when the predictor is monitored.
+// The metrics are labeled with the revision, the InferenceService and the component of the pods.
+type PodMonitorReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	config *v1beta1.PodMonitorConfig
+}
+
+func NewPodMonitorReconciler(client client.Client, scheme *runtime.Scheme, config *v1beta1.PodMonitorConfig) *PodMonitorReconciler {
+	return &PodMonitorReconciler{
+		client: client,
+		scheme: scheme,
+		config: config,
+	}
+}
+
+// Reconcile creates or updates the PodMonitor of the InferenceService, and deletes it when the pod monitors are
+// disabled or the InferenceService is no longer deployed with Knative. Nothing is reconciled when the PodMonitor CRD
+// is not installed.
+func (r *PodMonitorReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(PodMonitorGVK)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if meta.IsNoMatchError(err) {
+		if r.config.Enabled {
+			log.Info("Skipping the pod monitor as the PodMonitor CRD is not available", "isvc", isvc.Name)
+		}
+		return nil
+	}
+	if err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	if !r.config.Enabled || deploymentMode != constants.Knative {
+		if found && metav1.IsControlledBy(existing, isvc) {
+			log.Info("Deleting pod monitor", "namespace", existing.GetNamespace(), "name", existing.GetName())
+			if err := r.client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	desired, err := r.desiredPodMonitor(isvc)
+	if err != nil {
+		return err
+	}
+	if !found {
+		log.Info("Creating pod monitor", "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) &&
+		equality.Semantic.DeepEqual(existing.GetLabels(), desired.GetLabels()) {
+		return nil
+	}
+	log.Info("Updating pod monitor", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetLabels(desired.GetLabels())
+	return r.client.Update(ctx, existing)
+}
+
+func (r *PodMonitorReconciler) desiredPodMonitor(isvc *v1beta1.InferenceService) (*unstructured.Unstructured, error) {
+	port := constants.QueueProxyUserMetricsPortName
+	if r.metricAggregationEnabled(isvc) {
+		port = constants.AggregateMetricsPortName
+	}
+	relabelings := []relabeling{
+		{SourceLabels: []string{podLabelMetaName(constants.RevisionLabel)}, TargetLabel: "revision"},
+		{SourceLabels: []string{podLabelMetaName(constants.InferenceServicePodLabelKey)}, TargetLabel: constants.InferenceServiceName},
+		{SourceLabels: []string{podLabelMetaName(constants.KServiceComponentLabel)}, TargetLabel: constants.KServiceComponentLabel},
+	}
+	endpoints := []podMetricsEndpoint{{
+		Port:        port,
+		Path:        constants.DefaultPrometheusPath,
+		Interval:    r.config.Interval,
+		Relabelings: relabelings,
+	}}
+	// The detector of the predictor monitoring serves the drift and outlier metrics on its own port
+	if isvc.Spec.Predictor.Monitoring != nil {
+		endpoints = append(endpoints, podMetricsEndpoint{
+			Port:        constants.DetectorPortName,
+			Path:        constants.DefaultPrometheusPath,
+			Interval:    r.config.Interval,
+			Relabelings: relabelings,
+		})
+	}
+	spec := map[string]interface{}{
+		"selector": metav1.LabelSelector{
+			MatchLabels: map[string]string{constants.InferenceServicePodLabelKey: isvc.Name},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      constants.RevisionLabel,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		},
+		"podMetricsEndpoints": endpoints,
+	}
+	// The spec is converted to the unstructured types returned by the API server to compare it with the existing one
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	unstructuredSpec := map[string]interface{}{}
+	if err := json.Unmarshal(specJSON, &unstructuredSpec); err != nil {
+		return nil, err
+	}
+
+	podMonitor := &unstructured.Unstructured{Object: map[string]interface{}{"spec": unstructuredSpec}}
+	podMonitor.SetGroupVersionKind(PodMonitorGVK)
+	podMonitor.SetName(isvc.Name)
+	podMonitor.SetNamespace(isvc.Namespace)
+	labels := map[string]string{constants.InferenceServicePodLabelKey: isvc.Name}
+	for key, value := range r.config.Labels {
+		labels[key] = value
+	}
+	podMonitor.SetLabels(labels)
+	if err := controllerutil.SetControllerReference(isvc, podMonitor, r.scheme); err != nil {
+		return nil, err
+	}
+	return podMonitor, nil
+}
+
+// metricAggregationEnabled returns whether queue-proxy aggregates the metrics of the runtime, following the
+// serving.kserve.io/enable-metric-aggregation annotation set on the pods by the pod mutator
+func (r *PodMonitorReconciler) metricAggregationEnabled(isvc *v1beta1.InferenceService) bool {
+	if enabled, ok := isvc.Annotations[constants.EnableMetricAggregation]; ok {
+		return enabled == "true"
+	}
+	return r.config.DefaultMetricAggregation
+}
+
+// podLabelMetaName returns the name of the Prometheus meta label of a pod label
+func podLabelMetaName(label string) string {
+	return "__meta_kubernetes_pod_label_" + strings.NewReplacer(".", "_", "/", "_", "-", "_").Replace(label)
+}
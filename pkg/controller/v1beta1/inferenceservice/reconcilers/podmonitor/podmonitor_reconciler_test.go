@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func getPodMonitor(t *testing.T, cl client.Client) *unstructured.Unstructured {
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(PodMonitorGVK)
+	err := cl.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: "sklearn"}, podMonitor)
+	if err != nil {
+		return nil
+	}
+	return podMonitor
+}
+
+func getEndpoint(t *testing.T, podMonitor *unstructured.Unstructured) map[string]interface{} {
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	require.Len(t, endpoints, 1)
+	return endpoints[0].(map[string]interface{})
+}
+
+func TestPodMonitorReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "uid"},
+	}
+	config := &v1beta1.PodMonitorConfig{
+		Enabled:  true,
+		Interval: "30s",
+		Labels:   map[string]string{"release": "prometheus"},
+	}
+
+	// The PodMonitor scrapes the user metrics of queue-proxy
+	r := NewPodMonitorReconciler(cl, scheme, config)
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	podMonitor := getPodMonitor(t, cl)
+	require.NotNil(t, podMonitor)
+	assert.Equal(t, map[string]string{constants.InferenceServicePodLabelKey: "sklearn", "release": "prometheus"}, podMonitor.GetLabels())
+	assert.Equal(t, "sklearn", podMonitor.GetOwnerReferences()[0].Name)
+	matchLabels, _, _ := unstructured.NestedStringMap(podMonitor.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{constants.InferenceServicePodLabelKey: "sklearn"}, matchLabels)
+	endpoint := getEndpoint(t, podMonitor)
+	assert.Equal(t, constants.QueueProxyUserMetricsPortName, endpoint["port"])
+	assert.Equal(t, "30s", endpoint["interval"])
+	relabelings := endpoint["relabelings"].([]interface{})
+	require.Len(t, relabelings, 3)
+	assert.Equal(t, map[string]interface{}{
+		"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_serving_knative_dev_revision"},
+		"targetLabel":  "revision",
+	}, relabelings[0])
+
+	// The aggregated metrics port is scraped once the metrics aggregation is enabled
+	isvc.Annotations = map[string]string{constants.EnableMetricAggregation: "true"}
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	podMonitor = getPodMonitor(t, cl)
+	require.NotNil(t, podMonitor)
+	assert.Equal(t, constants.AggregateMetricsPortName, getEndpoint(t, podMonitor)["port"])
+
+	// The metrics of the detector are scraped when the predictor is monitored
+	isvc.Spec.Predictor.Monitoring = &v1beta1.MonitoringSpec{StorageURI: "s3://bucket/drift"}
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	podMonitor = getPodMonitor(t, cl)
+	require.NotNil(t, podMonitor)
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	require.Len(t, endpoints, 2)
+	detectorEndpoint := endpoints[1].(map[string]interface{})
+	assert.Equal(t, constants.DetectorPortName, detectorEndpoint["port"])
+	assert.Len(t, detectorEndpoint["relabelings"], 3)
+
+	// The PodMonitor is deleted when the InferenceService is no longer deployed with Knative
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Standard))
+	assert.Nil(t, getPodMonitor(t, cl))
+}
+
+func TestPodMonitorReconcilerDefaultMetricAggregation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "uid"},
+	}
+
+	// The pod monitors are disabled
+	r := NewPodMonitorReconciler(cl, scheme, &v1beta1.PodMonitorConfig{DefaultMetricAggregation: true})
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	assert.Nil(t, getPodMonitor(t, cl))
+
+	r = NewPodMonitorReconciler(cl, scheme, &v1beta1.PodMonitorConfig{Enabled: true, DefaultMetricAggregation: true})
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	podMonitor := getPodMonitor(t, cl)
+	require.NotNil(t, podMonitor)
+	endpoint := getEndpoint(t, podMonitor)
+	assert.Equal(t, constants.AggregateMetricsPortName, endpoint["port"])
+	assert.NotContains(t, endpoint, "interval")
+
+	// The annotation takes precedence over the default
+	isvc.Annotations = map[string]string{constants.EnableMetricAggregation: "false"}
+	require.NoError(t, r.Reconcile(t.Context(), isvc, constants.Knative))
+	podMonitor = getPodMonitor(t, cl)
+	require.NotNil(t, podMonitor)
+	assert.Equal(t, constants.QueueProxyUserMetricsPortName, getEndpoint(t, podMonitor)["port"])
+}
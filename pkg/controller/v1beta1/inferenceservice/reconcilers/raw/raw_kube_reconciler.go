@@ -23,9 +23,13 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/activator"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/bluegreen"
 	deployment "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/deployment"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/kueue"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/otel"
 	service "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
 	"github.com/kserve/kserve/pkg/credentials"
@@ -51,7 +55,10 @@ type RawKubeReconciler struct {
 	Deployment    *deployment.DeploymentReconciler
 	Service       *service.ServiceReconciler
 	Scaler        *autoscaler.AutoscalerReconciler
+	Activator     *activator.ActivatorReconciler
+	BlueGreen     *bluegreen.BlueGreenReconciler
 	OtelCollector *otel.OtelReconciler
+	Workload      *kueue.WorkloadReconciler
 	URL           *knapis.URL
 }
 
@@ -59,6 +66,7 @@ type RawKubeReconciler struct {
 func NewRawKubeReconciler(ctx context.Context,
 	client client.Client,
 	clientset kubernetes.Interface,
+	namespaceConfigMaps client.Reader,
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	workerComponentMeta metav1.ObjectMeta,
@@ -70,16 +78,17 @@ func NewRawKubeReconciler(ctx context.Context,
 	storageSpec *v1beta1.StorageSpec,
 	credentialBuilder *credentials.CredentialBuilder,
 	storageContainerSpec *v1alpha1.StorageContainerSpec,
+	observability *v1beta1.ObservabilitySpec,
 ) (*RawKubeReconciler, error) {
 	var otelCollector *otel.OtelReconciler
-	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, clientset)
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, clientset, namespaceConfigMaps, componentMeta.Namespace)
 	if err != nil {
 		log.Error(err, "unable to get configmap", "name", constants.InferenceServiceConfigMapName, "namespace", constants.KServeNamespace)
 		return nil, err
 	}
-	// create OTel Collector if pod metrics is enabled for auto-scaling
+	// create OTel Collector if pod metrics is enabled for auto-scaling or an observability sidecar is requested
+	var metricNames []string
 	if componentExt != nil && componentExt.AutoScaling != nil {
-		var metricNames []string
 		metrics := componentExt.AutoScaling.Metrics
 		for _, metric := range metrics {
 			if metric.Type == v1beta1.PodMetricSourceType {
@@ -88,15 +97,20 @@ func NewRawKubeReconciler(ctx context.Context,
 				}
 			}
 		}
-		if len(metricNames) > 0 {
-			otelConfig, err := v1beta1.NewOtelCollectorConfig(isvcConfigMap)
-			if err != nil {
-				return nil, err
-			}
-			otelCollector, err = otel.NewOtelReconciler(client, scheme, componentMeta, metricNames, *otelConfig)
-			if err != nil {
-				return nil, err
-			}
+	}
+	otelConfig, err := v1beta1.NewOtelCollectorConfig(isvcConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	// The observability sidecar is skipped when there is no endpoint to forward the metrics to
+	var otelSidecar *v1beta1.OtelCollectorSidecarSpec
+	if observability != nil && otelConfig.ForwardEndpoint(observability.OtelCollector) != "" {
+		otelSidecar = observability.OtelCollector
+	}
+	if len(metricNames) > 0 || otelSidecar != nil {
+		otelCollector, err = otel.NewOtelReconciler(client, scheme, componentMeta, metricNames, *otelConfig, otelSidecar)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -179,13 +193,44 @@ func NewRawKubeReconciler(ctx context.Context,
 		return nil, err
 	}
 
+	var workload *kueue.WorkloadReconciler
+	kueueConfig, err := v1beta1.NewKueueConfig(isvcConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	if kueueConfig.Enabled {
+		workload = kueue.NewWorkloadReconciler(client, componentMeta, componentExt, deployment.DeploymentList)
+	}
+
+	activatorConfig, err := v1beta1.NewActivatorConfig(isvcConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	// the activator proxies the requests of the default service to the private copy of the service
+	svc := service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec, multiNodeEnabled, serviceConfig)
+	var defaultSvc *corev1.Service
+	if len(svc.ServiceList) > 0 && !multiNodeEnabled {
+		defaultSvc = svc.ServiceList[0]
+	}
+	act := activator.NewActivatorReconciler(client, scheme, componentMeta, componentExt, defaultSvc, activatorConfig)
+	if defaultSvc != nil {
+		act.Route(defaultSvc)
+	}
+	blueGreen, err := bluegreen.NewBlueGreenReconciler(client, componentExt, deployment.DeploymentList[0], defaultSvc)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RawKubeReconciler{
 		client:        client,
 		scheme:        scheme,
 		Deployment:    deployment,
-		Service:       service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec, multiNodeEnabled, serviceConfig),
+		Service:       svc,
 		Scaler:        as,
+		Activator:     act,
+		BlueGreen:     blueGreen,
 		OtelCollector: otelCollector,
+		Workload:      workload,
 		URL:           url,
 	}, nil
 }
@@ -201,6 +246,15 @@ func createRawURL(ingressConfig *v1beta1.IngressConfig, metadata metav1.ObjectMe
 }
 
 // Reconcile ...
+// SetCapacity splits the replicas of the deployment between spot and on-demand nodes. The HPA only scales the spot
+// deployment, it is labeled with the spot capacity type.
+func (r *RawKubeReconciler) SetCapacity(capacity *v1beta1.CapacitySpec, capacityConfig *v1beta1.CapacityConfig, rebalancedReplicas int32) {
+	r.Deployment.SetCapacity(capacity, capacityConfig, rebalancedReplicas)
+	if hpaReconciler, ok := r.Scaler.Autoscaler.(*hpa.HPAReconciler); ok {
+		hpaReconciler.SetCapacityType(constants.CapacityTypeSpot)
+	}
+}
+
 func (r *RawKubeReconciler) Reconcile(ctx context.Context) ([]*appsv1.Deployment, error) {
 	// reconcile OTel Collector
 	if r.OtelCollector != nil {
@@ -209,12 +263,32 @@ func (r *RawKubeReconciler) Reconcile(ctx context.Context) ([]*appsv1.Deployment
 			return nil, err
 		}
 	}
+	// reconcile the Kueue Workload, holding the Deployment until admitted
+	if r.Workload != nil {
+		admitted, err := r.Workload.Reconcile(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if r.Workload.Managed() {
+			r.Deployment.SetKueueAdmission(admitted)
+		}
+	}
+	// reconcile the preview Deployment of the blue-green rollout, which sets the template of the Deployment and the
+	// selector of the Service
+	if err := r.BlueGreen.Reconcile(ctx); err != nil {
+		return nil, err
+	}
 	// reconcile Deployment
 	deploymentList, err := r.Deployment.Reconcile(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// reconcile the activator before the service is routed to it
+	if err := r.Activator.Reconcile(ctx); err != nil {
+		return nil, err
+	}
+
 	// reconcile Service
 	_, err = r.Service.Reconcile(ctx)
 	if err != nil {
@@ -0,0 +1,319 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readinessgate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+var log = logf.Log.WithName("InferenceProbeReconciler")
+
+// Inference probe condition reasons
+const (
+	PredictorNotReadyReason        = "PredictorNotReady"
+	InferenceProbeInProgressReason = "InferenceProbeInProgress"
+	InferenceProbeFailedReason     = "InferenceProbeFailed"
+)
+
+const (
+	DefaultProbeTimeoutSeconds = 10
+	// DefaultFailureThreshold is the default number of consecutive failed attempts after which a revision fails the
+	// inference probe
+	DefaultFailureThreshold = 3
+	// ProbeRetryInterval is the interval between the attempts of an inference probe, and at which the InferenceService
+	// is reconciled while its revision is being probed
+	ProbeRetryInterval = 10 * time.Second
+)
+
+// InferenceProbeReconciler sends the sample inference request of the inference probe readiness gate to the ready pods
+// of the latest predictor revision and gates the readiness of the predictor on their responses. Each revision is
+// probed once, in the background, and the result is recorded in the predictor status so that it is not probed again.
+type InferenceProbeReconciler struct {
+	client        client.Client
+	httpClient    *http.Client
+	retryInterval time.Duration
+
+	mu   sync.Mutex
+	runs map[types.NamespacedName]*probeRun
+}
+
+// probeRun is an inference probe of a revision running in the background
+type probeRun struct {
+	revision string
+	cancel   context.CancelFunc
+	done     bool
+	err      error
+}
+
+func NewInferenceProbeReconciler(client client.Client) *InferenceProbeReconciler {
+	return &InferenceProbeReconciler{
+		client:        client,
+		httpClient:    &http.Client{},
+		retryInterval: ProbeRetryInterval,
+		runs:          map[types.NamespacedName]*probeRun{},
+	}
+}
+
+// Reconcile probes the latest predictor revision once it is ready and sets the InferenceProbeReady condition. Until
+// a revision passes the probe the PredictorReady condition is unknown, so that the InferenceService is not ready and
+// the ingress does not route traffic to the predictor, and it is set to false once the revision failed the probe.
+// In the Knative deployment mode the traffic is held on the last rolled out revision until the latest revision passed.
+func (r *InferenceProbeReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) (ctrl.Result, error) {
+	key := types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}
+	if isvc.Spec.ReadinessGates == nil || isvc.Spec.ReadinessGates.InferenceProbe == nil {
+		r.Forget(key)
+		isvc.Status.ClearCondition(v1beta1.InferenceProbeReady)
+		setInferenceProbeStatus(isvc, nil)
+		return ctrl.Result{}, nil
+	}
+	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		isvc.Status.SetCondition(v1beta1.InferenceProbeReady, &apis.Condition{
+			Type:   v1beta1.InferenceProbeReady,
+			Status: corev1.ConditionUnknown,
+			Reason: PredictorNotReadyReason,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	revision, pods, err := isvcutils.GetPredictorRevisionPods(ctx, r.client, isvc, deploymentMode)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status := isvc.Status.Components[v1beta1.PredictorComponent].InferenceProbe.DeepCopy()
+	if status == nil {
+		status = &v1beta1.InferenceProbeStatus{}
+	}
+	if revision == "" {
+		markInProgress(isvc, status)
+		return ctrl.Result{RequeueAfter: r.retryInterval}, nil
+	}
+	if status.Revision == revision && status.State != v1beta1.InferenceProbeInProgress {
+		markProbed(isvc, status, "")
+		return ctrl.Result{}, nil
+	}
+
+	var done bool
+	var runErr error
+	r.mu.Lock()
+	run, running := r.runs[key]
+	running = running && run.revision == revision
+	if running && run.done {
+		done, runErr = true, run.err
+		delete(r.runs, key)
+	}
+	r.mu.Unlock()
+	switch {
+	case done:
+		status.Revision = revision
+		if runErr != nil {
+			log.Info("Inference probe failed", "isvc", isvc.Name, "namespace", isvc.Namespace, "revision", revision,
+				"error", runErr.Error())
+			status.State = v1beta1.InferenceProbeFailed
+			markProbed(isvc, status, fmt.Sprintf("inference probe of the revision %s failed: %v", revision, runErr))
+		} else {
+			status.State = v1beta1.InferenceProbePassed
+			status.PassedRevision = revision
+			markProbed(isvc, status, "")
+		}
+		setInferenceProbeStatus(isvc, status)
+		return ctrl.Result{}, nil
+	case running:
+	case len(pods) == 0:
+		// the revision is probed once its pods are ready
+	default:
+		urls := make([]string, 0, len(pods))
+		for i := range pods {
+			urls = append(urls, isvcutils.GetPodURL(&pods[i]))
+		}
+		r.start(key, revision, urls, isvc.Spec.ReadinessGates.InferenceProbe.DeepCopy())
+		status.Revision = revision
+		status.State = v1beta1.InferenceProbeInProgress
+	}
+	markInProgress(isvc, status)
+	if status.Revision == revision {
+		setInferenceProbeStatus(isvc, status)
+	}
+	return ctrl.Result{RequeueAfter: r.retryInterval}, nil
+}
+
+// Forget stops the inference probe running for an InferenceService
+func (r *InferenceProbeReconciler) Forget(key types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run, ok := r.runs[key]; ok {
+		run.cancel()
+		delete(r.runs, key)
+	}
+}
+
+// start probes the pods of a revision in the background, replacing the probe of a previous revision
+func (r *InferenceProbeReconciler) start(key types.NamespacedName, revision string, urls []string, probe *v1beta1.InferenceProbeSpec) {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &probeRun{revision: revision, cancel: cancel}
+	r.mu.Lock()
+	if previous, ok := r.runs[key]; ok {
+		previous.cancel()
+	}
+	r.runs[key] = run
+	r.mu.Unlock()
+
+	log.Info("Probing the predictor revision", "isvc", key.Name, "namespace", key.Namespace, "revision", revision,
+		"pods", len(urls))
+	go func() {
+		defer cancel()
+		err := r.probeRevision(ctx, urls, probe)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		run.done = true
+		run.err = err
+	}()
+}
+
+// probeRevision sends the sample inference request to every pod of a revision until they all succeed, or until the
+// failure threshold of consecutive attempts is reached
+func (r *InferenceProbeReconciler) probeRevision(ctx context.Context, urls []string, probe *v1beta1.InferenceProbeSpec) error {
+	failureThreshold := DefaultFailureThreshold
+	if probe.FailureThreshold != nil {
+		failureThreshold = int(*probe.FailureThreshold)
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = nil
+		for _, url := range urls {
+			if err = r.probe(ctx, url+probe.Path, probe); err != nil {
+				break
+			}
+		}
+		if err == nil || attempt >= failureThreshold {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.retryInterval):
+		}
+	}
+}
+
+// markInProgress sets the InferenceProbeReady condition while the latest revision is being probed. The predictor is
+// not ready until a first revision passed the probe.
+func markInProgress(isvc *v1beta1.InferenceService, status *v1beta1.InferenceProbeStatus) {
+	isvc.Status.SetCondition(v1beta1.InferenceProbeReady, &apis.Condition{
+		Type:   v1beta1.InferenceProbeReady,
+		Status: corev1.ConditionUnknown,
+		Reason: InferenceProbeInProgressReason,
+	})
+	if status.PassedRevision == "" {
+		isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{
+			Type:   v1beta1.PredictorReady,
+			Status: corev1.ConditionUnknown,
+			Reason: InferenceProbeInProgressReason,
+		})
+	}
+}
+
+// markProbed sets the InferenceProbeReady and PredictorReady conditions from the result of the probe of a revision.
+// The message of a failed probe is kept from the previous reconciliation unless it is given.
+func markProbed(isvc *v1beta1.InferenceService, status *v1beta1.InferenceProbeStatus, message string) {
+	if status.State == v1beta1.InferenceProbePassed {
+		isvc.Status.SetCondition(v1beta1.InferenceProbeReady, &apis.Condition{
+			Type:   v1beta1.InferenceProbeReady,
+			Status: corev1.ConditionTrue,
+		})
+		return
+	}
+	if message == "" {
+		if condition := isvc.Status.GetCondition(v1beta1.InferenceProbeReady); condition != nil && condition.IsFalse() {
+			message = condition.Message
+		} else {
+			message = fmt.Sprintf("inference probe of the revision %s failed", status.Revision)
+		}
+	}
+	isvc.Status.SetCondition(v1beta1.InferenceProbeReady, &apis.Condition{
+		Type:    v1beta1.InferenceProbeReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  InferenceProbeFailedReason,
+		Message: message,
+	})
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{
+		Type:    v1beta1.PredictorReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  InferenceProbeFailedReason,
+		Message: message,
+	})
+}
+
+func setInferenceProbeStatus(isvc *v1beta1.InferenceService, status *v1beta1.InferenceProbeStatus) {
+	componentStatus, ok := isvc.Status.Components[v1beta1.PredictorComponent]
+	if !ok {
+		if status == nil {
+			return
+		}
+		if isvc.Status.Components == nil {
+			isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{}
+		}
+	}
+	componentStatus.InferenceProbe = status
+	isvc.Status.Components[v1beta1.PredictorComponent] = componentStatus
+}
+
+func (r *InferenceProbeReconciler) probe(ctx context.Context, url string, probe *v1beta1.InferenceProbeSpec) error {
+	timeout := time.Duration(DefaultProbeTimeoutSeconds) * time.Second
+	if probe.TimeoutSeconds != nil {
+		timeout = time.Duration(*probe.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(probe.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	expectedStatusCode := http.StatusOK
+	if probe.ExpectedStatusCode != nil {
+		expectedStatusCode = int(*probe.ExpectedStatusCode)
+	}
+	if resp.StatusCode != expectedStatusCode {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d, expected %d: %s", resp.StatusCode, expectedStatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readinessgate
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	testPayload  = `{"instances": [[1.0, 2.0]]}`
+	testRevision = "sklearn-predictor-00001"
+)
+
+func makeTestInferenceService(probe *v1beta1.InferenceProbeSpec, predictorReady bool) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn",
+			Namespace: "default",
+		},
+	}
+	if probe != nil {
+		isvc.Spec.ReadinessGates = &v1beta1.ReadinessGatesSpec{InferenceProbe: probe}
+	}
+	isvc.Status.InitializeConditions()
+	predictorStatus := corev1.ConditionFalse
+	if predictorReady {
+		predictorStatus = corev1.ConditionTrue
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: predictorStatus})
+	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{Type: v1beta1.IngressReady, Status: corev1.ConditionTrue})
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {LatestCreatedRevision: testRevision},
+	}
+	return isvc
+}
+
+// makeTestPod returns a ready pod of a revision serving on the address of the test server
+func makeTestPod(t *testing.T, name string, revision string, serverURL string) *corev1.Pod {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{constants.RevisionLabel: revision},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []corev1.ContainerPort{{ContainerPort: int32(port)}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			PodIP:      u.Hostname(),
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newTestReconciler(t *testing.T, objects ...client.Object) *InferenceProbeReconciler {
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	r := NewInferenceProbeReconciler(fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build())
+	r.retryInterval = 10 * time.Millisecond
+	return r
+}
+
+// reconcileUntilProbed reconciles the InferenceService until the inference probe of its revision completed. The
+// PredictorReady condition is propagated from the predictor before each reconciliation, as the controller does.
+func reconcileUntilProbed(g *gomega.WithT, r *InferenceProbeReconciler, isvc *v1beta1.InferenceService, predictorReady bool) {
+	g.Eventually(func() bool {
+		if predictorReady {
+			isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+		}
+		result, err := r.Reconcile(context.Background(), isvc, constants.Knative)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		return result.RequeueAfter == 0
+	}, 10*time.Second, 10*time.Millisecond).Should(gomega.BeTrue())
+}
+
+// newTestServer returns a model server counting the inference requests
+func newTestServer(requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.URL.Path == "/v1/models/sklearn:predict" && string(body) == testPayload:
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v1/models/cold:predict":
+			// simulate a model server which is still loading the model on the first inference request
+			time.Sleep(1500 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestInferenceProbeReconcile(t *testing.T) {
+	scenarios := map[string]struct {
+		probe               *v1beta1.InferenceProbeSpec
+		predictorReady      bool
+		expectedRequests    int32
+		expectedProbeStatus *corev1.ConditionStatus
+		expectedState       v1beta1.InferenceProbeState
+		expectedReady       bool
+	}{
+		"no readiness gates": {
+			predictorReady: true,
+			expectedReady:  true,
+		},
+		"predictor not ready": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: testPayload},
+			expectedProbeStatus: ptr.To(corev1.ConditionUnknown),
+		},
+		"successful inference": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: testPayload},
+			predictorReady:      true,
+			expectedRequests:    2,
+			expectedProbeStatus: ptr.To(corev1.ConditionTrue),
+			expectedState:       v1beta1.InferenceProbePassed,
+			expectedReady:       true,
+		},
+		"failed inference": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: `{}`},
+			predictorReady:      true,
+			expectedRequests:    DefaultFailureThreshold,
+			expectedProbeStatus: ptr.To(corev1.ConditionFalse),
+			expectedState:       v1beta1.InferenceProbeFailed,
+		},
+		"failure threshold": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: `{}`, FailureThreshold: ptr.To(int32(1))},
+			predictorReady:      true,
+			expectedRequests:    1,
+			expectedProbeStatus: ptr.To(corev1.ConditionFalse),
+			expectedState:       v1beta1.InferenceProbeFailed,
+		},
+		"expected status code": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: `{}`, ExpectedStatusCode: ptr.To(int32(500))},
+			predictorReady:      true,
+			expectedRequests:    2,
+			expectedProbeStatus: ptr.To(corev1.ConditionTrue),
+			expectedState:       v1beta1.InferenceProbePassed,
+			expectedReady:       true,
+		},
+		"cold start exceeds timeout": {
+			probe: &v1beta1.InferenceProbeSpec{
+				Path: "/v1/models/cold:predict", Payload: testPayload, TimeoutSeconds: ptr.To(int32(1)), FailureThreshold: ptr.To(int32(1)),
+			},
+			predictorReady:      true,
+			expectedRequests:    1,
+			expectedProbeStatus: ptr.To(corev1.ConditionFalse),
+			expectedState:       v1beta1.InferenceProbeFailed,
+		},
+		"cold start within timeout": {
+			probe:               &v1beta1.InferenceProbeSpec{Path: "/v1/models/cold:predict", Payload: testPayload, TimeoutSeconds: ptr.To(int32(5))},
+			predictorReady:      true,
+			expectedRequests:    2,
+			expectedProbeStatus: ptr.To(corev1.ConditionTrue),
+			expectedState:       v1beta1.InferenceProbePassed,
+			expectedReady:       true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			var requests atomic.Int32
+			server := newTestServer(&requests)
+			defer server.Close()
+			r := newTestReconciler(t,
+				makeTestPod(t, "sklearn-1", testRevision, server.URL),
+				makeTestPod(t, "sklearn-2", testRevision, server.URL),
+				makeTestPod(t, "previous", "sklearn-predictor-00000", server.URL))
+			isvc := makeTestInferenceService(scenario.probe, scenario.predictorReady)
+			reconcileUntilProbed(g, r, isvc, scenario.predictorReady)
+			// the revision is not probed again once the probe completed
+			if scenario.predictorReady {
+				isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+			}
+			result, err := r.Reconcile(t.Context(), isvc, constants.Knative)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(result.RequeueAfter).To(gomega.BeZero())
+			g.Expect(requests.Load()).To(gomega.Equal(scenario.expectedRequests))
+			g.Expect(isvc.Status.IsReady()).To(gomega.Equal(scenario.expectedReady))
+			condition := isvc.Status.GetCondition(v1beta1.InferenceProbeReady)
+			if scenario.expectedProbeStatus == nil {
+				g.Expect(condition).To(gomega.BeNil())
+			} else {
+				g.Expect(condition).ToNot(gomega.BeNil())
+				g.Expect(condition.Status).To(gomega.Equal(*scenario.expectedProbeStatus))
+			}
+			if scenario.expectedProbeStatus != nil && *scenario.expectedProbeStatus == corev1.ConditionFalse {
+				g.Expect(isvc.Status.IsConditionReady(v1beta1.PredictorReady)).To(gomega.BeFalse())
+			}
+			probeStatus := isvc.Status.Components[v1beta1.PredictorComponent].InferenceProbe
+			if scenario.expectedState == "" {
+				g.Expect(probeStatus).To(gomega.BeNil())
+			} else {
+				g.Expect(probeStatus).ToNot(gomega.BeNil())
+				g.Expect(probeStatus.Revision).To(gomega.Equal(testRevision))
+				g.Expect(probeStatus.State).To(gomega.Equal(scenario.expectedState))
+			}
+		})
+	}
+}
+
+func TestInferenceProbeReconcileNewRevision(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	r := newTestReconciler(t, makeTestPod(t, "sklearn-2", "sklearn-predictor-00002", server.URL))
+	isvc := makeTestInferenceService(&v1beta1.InferenceProbeSpec{Path: "/v1/models/sklearn:predict", Payload: testPayload}, true)
+	predictorStatus := isvc.Status.Components[v1beta1.PredictorComponent]
+	predictorStatus.LatestCreatedRevision = "sklearn-predictor-00002"
+	predictorStatus.InferenceProbe = &v1beta1.InferenceProbeStatus{
+		Revision:       testRevision,
+		State:          v1beta1.InferenceProbePassed,
+		PassedRevision: testRevision,
+	}
+	isvc.Status.Components[v1beta1.PredictorComponent] = predictorStatus
+
+	// the predictor stays ready on the revision which passed while the latest revision is probed
+	result, err := r.Reconcile(t.Context(), isvc, constants.Knative)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(result.RequeueAfter).ToNot(gomega.BeZero())
+	g.Expect(isvc.Status.IsConditionReady(v1beta1.PredictorReady)).To(gomega.BeTrue())
+	probeStatus := isvc.Status.Components[v1beta1.PredictorComponent].InferenceProbe
+	g.Expect(probeStatus.Revision).To(gomega.Equal("sklearn-predictor-00002"))
+	g.Expect(probeStatus.State).To(gomega.Equal(v1beta1.InferenceProbeInProgress))
+	g.Expect(probeStatus.PassedRevision).To(gomega.Equal(testRevision))
+
+	close(release)
+	reconcileUntilProbed(g, r, isvc, true)
+	probeStatus = isvc.Status.Components[v1beta1.PredictorComponent].InferenceProbe
+	g.Expect(probeStatus.State).To(gomega.Equal(v1beta1.InferenceProbePassed))
+	g.Expect(probeStatus.PassedRevision).To(gomega.Equal("sklearn-predictor-00002"))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaceiling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("ReplicaCeilingReconciler")
+
+// ReplicasClampedReason is the reason of the ReplicasWithinCeiling condition and of the event recorded when the clamped
+// replicas change
+const ReplicasClampedReason = "ReplicasClamped"
+
+// ReplicaCeilingReconciler clamps the replicas of the components to the replica ceiling of the ServingQuotas of the
+// namespace before the autoscalers are reconciled, so that the HPAs, the KEDA ScaledObjects and the Knative
+// autoscalers created by kserve never scale a component above the ceiling, e.g. on a runaway KEDA query. The ceiling
+// caps each component on its own, the replicas of the components of the namespace are not summed.
+type ReplicaCeilingReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+func NewReplicaCeilingReconciler(client client.Client, recorder record.EventRecorder) *ReplicaCeilingReconciler {
+	return &ReplicaCeilingReconciler{
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+// Reconcile clamps the min and max replicas of the components of the InferenceService in memory, the spec of the
+// InferenceService is never updated. The Knative components without a maxReplicas, which are unbounded, are bounded
+// by the ceiling. As the clamping is repeated on every reconcile, the clamped fields are surfaced as the
+// ReplicasWithinCeiling condition and the event is only recorded when they change.
+func (r *ReplicaCeilingReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) error {
+	quotas := &v1alpha1.ServingQuotaList{}
+	if err := r.client.List(ctx, quotas, client.InNamespace(isvc.Namespace)); err != nil {
+		return fmt.Errorf("failed to list the serving quotas of namespace %s: %w", isvc.Namespace, err)
+	}
+	ceiling := quotas.MaxReplicasCeiling()
+	if ceiling == nil {
+		isvc.Status.ClearCondition(v1beta1.ReplicasWithinCeiling)
+		return nil
+	}
+
+	components := []v1beta1.ComponentType{v1beta1.PredictorComponent}
+	extensions := []*v1beta1.ComponentExtensionSpec{&isvc.Spec.Predictor.ComponentExtensionSpec}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, v1beta1.TransformerComponent)
+		extensions = append(extensions, &isvc.Spec.Transformer.ComponentExtensionSpec)
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, v1beta1.ExplainerComponent)
+		extensions = append(extensions, &isvc.Spec.Explainer.ComponentExtensionSpec)
+	}
+	messages := []string{}
+	for i, extension := range extensions {
+		if clamped := capComponentReplicas(extension, *ceiling, deploymentMode == constants.Knative); len(clamped) > 0 {
+			messages = append(messages, fmt.Sprintf("the %s of the %s were clamped to the replica ceiling %d of the ServingQuotas",
+				strings.Join(clamped, " and "), components[i], *ceiling))
+		}
+	}
+	if len(messages) == 0 {
+		isvc.Status.ClearCondition(v1beta1.ReplicasWithinCeiling)
+		return nil
+	}
+
+	message := strings.Join(messages, ", ")
+	if condition := isvc.Status.GetCondition(v1beta1.ReplicasWithinCeiling); condition == nil || condition.Message != message {
+		log.Info("Clamped the replicas to the replica ceiling", "isvc", isvc.Name, "namespace", isvc.Namespace, "ceiling", *ceiling)
+		r.recorder.Eventf(isvc, corev1.EventTypeWarning, ReplicasClampedReason, "The replicas of the components were clamped: %s", message)
+	}
+	isvc.Status.SetCondition(v1beta1.ReplicasWithinCeiling, &apis.Condition{
+		Type:    v1beta1.ReplicasWithinCeiling,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReplicasClampedReason,
+		Message: message,
+	})
+	return nil
+}
+
+// capComponentReplicas lowers the replicas of a single component above the ceiling, it returns the fields which were
+// clamped
+func capComponentReplicas(extension *v1beta1.ComponentExtensionSpec, ceiling int32, unboundedMax bool) []string {
+	clamped := []string{}
+	if extension.MinReplicas != nil && *extension.MinReplicas > ceiling {
+		extension.MinReplicas = ptr.To(ceiling)
+		clamped = append(clamped, "minReplicas")
+	}
+	if extension.MaxReplicas > ceiling {
+		extension.MaxReplicas = ceiling
+		clamped = append(clamped, "maxReplicas")
+	} else if extension.MaxReplicas == 0 && unboundedMax {
+		// Bounding an unbounded component is not reported, the spec does not ask for more replicas than the ceiling
+		extension.MaxReplicas = ceiling
+	}
+	return clamped
+}
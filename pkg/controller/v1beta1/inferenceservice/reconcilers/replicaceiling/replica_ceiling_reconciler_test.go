@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaceiling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newInferenceService() *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					MinReplicas: ptr.To(int32(2)),
+					MaxReplicas: 50,
+				},
+			},
+			Transformer: &v1beta1.TransformerSpec{},
+		},
+	}
+}
+
+func newQuota(name string, maxReplicas *int32) *v1alpha1.ServingQuota {
+	return &v1alpha1.ServingQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1alpha1.ServingQuotaSpec{MaxReplicas: maxReplicas, Enforcement: v1alpha1.ServingQuotaWarn},
+	}
+}
+
+func newReconciler(t *testing.T, objects ...client.Object) (*ReplicaCeilingReconciler, *record.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return NewReplicaCeilingReconciler(cl, recorder), recorder
+}
+
+func TestReplicaCeilingClamped(t *testing.T) {
+	reconciler, recorder := newReconciler(t, newQuota("gpu-budget", nil), newQuota("ceiling", ptr.To(int32(10))),
+		newQuota("strict-ceiling", ptr.To(int32(4))))
+	isvc := newInferenceService()
+	isvc.Spec.Predictor.MinReplicas = ptr.To(int32(6))
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Standard))
+	assert.Equal(t, int32(4), *isvc.Spec.Predictor.MinReplicas)
+	assert.Equal(t, int32(4), isvc.Spec.Predictor.MaxReplicas)
+	// the max replicas of the standard components default to the min replicas
+	assert.Nil(t, isvc.Spec.Transformer.MinReplicas)
+	assert.Equal(t, int32(0), isvc.Spec.Transformer.MaxReplicas)
+
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning ReplicasClamped The replicas of the components were clamped: the minReplicas and maxReplicas "+
+		"of the predictor were clamped to the replica ceiling 4 of the ServingQuotas", <-recorder.Events)
+	condition := isvc.Status.GetCondition(v1beta1.ReplicasWithinCeiling)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+}
+
+func TestReplicaCeilingEventOnChange(t *testing.T) {
+	quota := newQuota("ceiling", ptr.To(int32(10)))
+	reconciler, recorder := newReconciler(t, quota)
+	isvc := newInferenceService()
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Standard))
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// the spec is clamped again on the next reconcile, the event is not recorded again
+	status := isvc.Status
+	isvc = newInferenceService()
+	isvc.Status = status
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Standard))
+	assert.Empty(t, recorder.Events)
+
+	// a lower ceiling changes the clamped replicas
+	quota.Spec.MaxReplicas = ptr.To(int32(8))
+	require.NoError(t, reconciler.client.Update(context.Background(), quota))
+	isvc = newInferenceService()
+	isvc.Status = status
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Standard))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "replica ceiling 8")
+
+	// the condition is cleared once the replicas are within the ceiling
+	isvc.Spec.Predictor.MaxReplicas = 8
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Standard))
+	assert.Nil(t, isvc.Status.GetCondition(v1beta1.ReplicasWithinCeiling))
+	assert.Empty(t, recorder.Events)
+}
+
+func TestReplicaCeilingKnativeUnbounded(t *testing.T) {
+	reconciler, recorder := newReconciler(t, newQuota("ceiling", ptr.To(int32(60))))
+	isvc := newInferenceService()
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Knative))
+	assert.Equal(t, int32(50), isvc.Spec.Predictor.MaxReplicas)
+	assert.Equal(t, int32(60), isvc.Spec.Transformer.MaxReplicas)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestReplicaCeilingNoQuota(t *testing.T) {
+	reconciler, recorder := newReconciler(t)
+	isvc := newInferenceService()
+
+	require.NoError(t, reconciler.Reconcile(context.Background(), isvc, constants.Knative))
+	assert.Equal(t, newInferenceService().Spec, isvc.Spec)
+	assert.Empty(t, recorder.Events)
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servertls
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("ServerTLSReconciler")
+
+// CertificateGVK is the GroupVersionKind of the cert-manager Certificates
+var CertificateGVK = schema.FromAPIVersionAndKind(constants.CertManagerAPIVersion, constants.CertificateKind)
+
+// ServerTLSReconciler provisions the serving certificate of the predictor of an InferenceService setting
+// spec.transport.serverTLS, and configures the predictor pods to serve HTTPS with it. The cert-manager certificates
+// are issued to a secret mounted in the predictor container, which the kubelet updates in place once renewed. The
+// SPIRE certificates are the X.509 SVIDs of the pods, written to a volume shared with the predictor container by the
+// spiffe-helper sidecar, which rotates them before they expire.
+type ServerTLSReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	config *v1beta1.ServerTLSConfig
+}
+
+func NewServerTLSReconciler(client client.Client, scheme *runtime.Scheme, config *v1beta1.ServerTLSConfig) *ServerTLSReconciler {
+	return &ServerTLSReconciler{
+		client: client,
+		scheme: scheme,
+		config: config,
+	}
+}
+
+// CertificateName returns the name of the cert-manager Certificate and of its secret for the predictor
+func CertificateName(predictorName string) string {
+	return predictorName + "-serving-cert"
+}
+
+// Reconcile provisions the serving certificate of the predictor named by the component meta and adds it to the pod
+// spec, or deletes the cert-manager Certificate of the predictor once the server TLS is removed. With the Restart
+// rotation, the hash of the issued certificate is recorded on the pod template so that the pods are rolled when the
+// certificate is renewed, the InferenceService is reconciled once the issued secret changes.
+func (r *ServerTLSReconciler) Reconcile(ctx context.Context, isvc *v1beta1.InferenceService, componentMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) error {
+	var serverTLS *v1beta1.ServerTLSSpec
+	if isvc.Spec.Transport != nil {
+		serverTLS = isvc.Spec.Transport.ServerTLS
+	}
+	if serverTLS == nil || serverTLS.Provider != v1beta1.CertManagerCertificateProvider {
+		if err := r.deleteCertificate(ctx, isvc, componentMeta); err != nil {
+			return err
+		}
+	}
+	if serverTLS == nil {
+		return nil
+	}
+
+	switch serverTLS.Provider {
+	case v1beta1.CertManagerCertificateProvider:
+		if err := r.reconcileCertificate(ctx, isvc, componentMeta, serverTLS); err != nil {
+			return err
+		}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: constants.ServerTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: CertificateName(componentMeta.Name)},
+			},
+		})
+		if serverTLS.Rotation == v1beta1.RestartCertificateRotation {
+			if err := r.addCertificateHash(ctx, componentMeta); err != nil {
+				return err
+			}
+		}
+	case v1beta1.SPIRECertificateProvider:
+		if err := r.reconcileSpiffeHelperConfig(ctx, componentMeta.Namespace); err != nil {
+			return err
+		}
+		r.addSpiffeHelper(podSpec)
+	default:
+		return fmt.Errorf("unknown serving certificate provider %q", serverTLS.Provider)
+	}
+	configureHTTPS(podSpec)
+	return nil
+}
+
+// reconcileCertificate creates or updates the cert-manager Certificate of the predictor, valid for the hostnames of
+// the predictor service
+func (r *ServerTLSReconciler) reconcileCertificate(ctx context.Context, isvc *v1beta1.InferenceService, componentMeta *metav1.ObjectMeta, serverTLS *v1beta1.ServerTLSSpec) error {
+	desired, err := r.desiredCertificate(isvc, componentMeta, serverTLS)
+	if err != nil {
+		return err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(CertificateGVK)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing)
+	if meta.IsNoMatchError(err) {
+		return errors.New("the serving certificates of the CertManager provider require the cert-manager CRDs to be installed")
+	}
+	if apierr.IsNotFound(err) {
+		log.Info("Creating serving certificate", "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.client.Create(ctx, desired); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	log.Info("Updating serving certificate", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	existing.Object["spec"] = desired.Object["spec"]
+	return r.client.Update(ctx, existing)
+}
+
+func (r *ServerTLSReconciler) desiredCertificate(isvc *v1beta1.InferenceService, componentMeta *metav1.ObjectMeta, serverTLS *v1beta1.ServerTLSSpec) (*unstructured.Unstructured, error) {
+	issuerRef := serverTLS.IssuerRef
+	if issuerRef == nil {
+		issuerRef = r.config.IssuerRef
+	}
+	if issuerRef == nil {
+		return nil, errors.New("the serving certificates of the CertManager provider require an issuerRef, set in the InferenceService or the serverTLS config")
+	}
+	issuer := map[string]interface{}{
+		"name":  issuerRef.Name,
+		"kind":  constants.IssuerKind,
+		"group": constants.CertManagerAPIGroupName,
+	}
+	if issuerRef.Kind != "" {
+		issuer["kind"] = issuerRef.Kind
+	}
+	if issuerRef.Group != "" {
+		issuer["group"] = issuerRef.Group
+	}
+	name, namespace := componentMeta.Name, componentMeta.Namespace
+	spec := map[string]interface{}{
+		"secretName": CertificateName(name),
+		"dnsNames": []interface{}{
+			name,
+			name + "." + namespace,
+			name + "." + namespace + ".svc",
+			network.GetServiceHostname(name, namespace),
+		},
+		"usages":    []interface{}{"server auth", "digital signature", "key encipherment"},
+		"issuerRef": issuer,
+		// the issued secret is labeled with the InferenceService, so that the manager caches and watches it
+		"secretTemplate": map[string]interface{}{
+			"labels": map[string]interface{}{constants.InferenceServicePodLabelKey: isvc.Name},
+		},
+	}
+	if r.config.Duration != "" {
+		spec["duration"] = r.config.Duration
+	}
+	if r.config.RenewBefore != "" {
+		spec["renewBefore"] = r.config.RenewBefore
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(CertificateName(name))
+	certificate.SetNamespace(namespace)
+	certificate.SetLabels(map[string]string{constants.InferenceServicePodLabelKey: isvc.Name})
+	if err := controllerutil.SetControllerReference(isvc, certificate, r.scheme); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// deleteCertificate deletes the cert-manager Certificate of the predictor, the issued secret is left to cert-manager
+func (r *ServerTLSReconciler) deleteCertificate(ctx context.Context, isvc *v1beta1.InferenceService, componentMeta *metav1.ObjectMeta) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(CertificateGVK)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: componentMeta.Namespace, Name: CertificateName(componentMeta.Name)}, existing)
+	if meta.IsNoMatchError(err) || apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, isvc) {
+		return nil
+	}
+	log.Info("Deleting serving certificate", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	if err := r.client.Delete(ctx, existing); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// addCertificateHash records the hash of the issued certificate on the pod template, nothing is recorded until the
+// certificate is issued
+func (r *ServerTLSReconciler) addCertificateHash(ctx context.Context, componentMeta *metav1.ObjectMeta) error {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: componentMeta.Namespace, Name: CertificateName(componentMeta.Name)}, secret)
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(secret.Data[corev1.TLSCertKey])
+	if componentMeta.Annotations == nil {
+		componentMeta.Annotations = map[string]string{}
+	}
+	componentMeta.Annotations[constants.ServerTLSCertificateHashInternalAnnotationKey] = hex.EncodeToString(hash[:])
+	return nil
+}
+
+// spiffeHelperConfig configures the spiffe-helper to write the SVID, its key and the trust bundle with the file names
+// of the kubernetes.io/tls secrets, and to keep them renewed
+func (r *ServerTLSReconciler) spiffeHelperConfig() string {
+	return fmt.Sprintf(`agent_address = %q
+cert_dir = %q
+svid_file_name = %q
+svid_key_file_name = %q
+svid_bundle_file_name = %q
+daemon_mode = true
+`, filepath.Join(constants.SpiffeWorkloadAPIDir, r.config.SpireAgentSocket), constants.ServerTLSCertDir,
+		corev1.TLSCertKey, corev1.TLSPrivateKeyKey, corev1.ServiceAccountRootCAKey)
+}
+
+// reconcileSpiffeHelperConfig creates or updates the spiffe-helper ConfigMap shared by the predictors of the namespace
+func (r *ServerTLSReconciler) reconcileSpiffeHelperConfig(ctx context.Context, namespace string) error {
+	data := map[string]string{"helper.conf": r.spiffeHelperConfig()}
+	existing := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: constants.SpiffeHelperConfigMap}, existing)
+	if apierr.IsNotFound(err) {
+		log.Info("Creating spiffe-helper configmap", "namespace", namespace)
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.SpiffeHelperConfigMap, Namespace: namespace},
+			Data:       data,
+		}
+		if err := r.client.Create(ctx, configMap); err != nil && !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(existing.Data, data) {
+		return nil
+	}
+	existing.Data = data
+	return r.client.Update(ctx, existing)
+}
+
+// addSpiffeHelper adds the spiffe-helper as a native sidecar, started before the predictor container, which fails
+// its probes until the SVID is written
+func (r *ServerTLSReconciler) addSpiffeHelper(podSpec *corev1.PodSpec) {
+	podSpec.Volumes = append(podSpec.Volumes,
+		corev1.Volume{
+			Name:         constants.ServerTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+		},
+		corev1.Volume{
+			Name: constants.SpiffeWorkloadAPIVolume,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{Driver: r.config.SpiffeCSIDriver, ReadOnly: ptr.To(true)},
+			},
+		},
+		corev1.Volume{
+			Name: constants.SpiffeHelperVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: constants.SpiffeHelperConfigMap},
+				},
+			},
+		},
+	)
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:          constants.SpiffeHelperContainer,
+		Image:         r.config.SpiffeHelperImage,
+		Args:          []string{"-config", filepath.Join(constants.SpiffeHelperConfigDir, "helper.conf")},
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: constants.ServerTLSVolumeName, MountPath: constants.ServerTLSCertDir},
+			{Name: constants.SpiffeWorkloadAPIVolume, MountPath: constants.SpiffeWorkloadAPIDir, ReadOnly: true},
+			{Name: constants.SpiffeHelperVolumeName, MountPath: constants.SpiffeHelperConfigDir, ReadOnly: true},
+		},
+	})
+}
+
+// configureHTTPS mounts the serving certificate in the predictor container, tells the runtime where to find it, and
+// switches the probes and the port of the predictor to HTTPS, so that the predictor service routes HTTPS upstream
+func configureHTTPS(podSpec *corev1.PodSpec) {
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if container.Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      constants.ServerTLSVolumeName,
+			MountPath: constants.ServerTLSCertDir,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: constants.ServerTLSCertFileEnvVar, Value: filepath.Join(constants.ServerTLSCertDir, corev1.TLSCertKey)},
+			corev1.EnvVar{Name: constants.ServerTLSKeyFileEnvVar, Value: filepath.Join(constants.ServerTLSCertDir, corev1.TLSPrivateKeyKey)},
+			corev1.EnvVar{Name: constants.ServerTLSCAFileEnvVar, Value: filepath.Join(constants.ServerTLSCertDir, corev1.ServiceAccountRootCAKey)},
+		)
+		for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe, container.StartupProbe} {
+			if probe != nil && probe.HTTPGet != nil {
+				probe.HTTPGet.Scheme = corev1.URISchemeHTTPS
+			}
+		}
+		if len(container.Ports) == 0 {
+			port, _ := utils.StringToInt32(constants.InferenceServiceDefaultHttpPort)
+			container.Ports = []corev1.ContainerPort{{ContainerPort: port, Protocol: corev1.ProtocolTCP}}
+		}
+		container.Ports[0].Name = constants.ServerTLSPortName
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servertls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func getCertificate(t *testing.T, cl client.Client) *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	err := cl.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: "sklearn-predictor-serving-cert"}, certificate)
+	if err != nil {
+		return nil
+	}
+	return certificate
+}
+
+func makePodSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  constants.InferenceServiceContainerName,
+			Ports: []corev1.ContainerPort{{Name: "http1", ContainerPort: 8080}},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/v2/health/ready"}},
+			},
+		}},
+	}
+}
+
+func assertHTTPS(t *testing.T, podSpec *corev1.PodSpec) {
+	container := podSpec.Containers[0]
+	assert.Equal(t, constants.ServerTLSPortName, container.Ports[0].Name)
+	assert.Equal(t, corev1.URISchemeHTTPS, container.ReadinessProbe.HTTPGet.Scheme)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.ServerTLSVolumeName,
+		MountPath: constants.ServerTLSCertDir,
+		ReadOnly:  true,
+	})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.ServerTLSCertFileEnvVar, Value: "/etc/kserve/tls/tls.crt"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.ServerTLSKeyFileEnvVar, Value: "/etc/kserve/tls/tls.key"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: constants.ServerTLSCAFileEnvVar, Value: "/etc/kserve/tls/ca.crt"})
+}
+
+func TestServerTLSReconcilerCertManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "uid"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transport: &v1beta1.TransportSpec{
+				ServerTLS: &v1beta1.ServerTLSSpec{Provider: v1beta1.CertManagerCertificateProvider, Rotation: v1beta1.ReloadCertificateRotation},
+			},
+		},
+	}
+	componentMeta := &metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+
+	// The issuer is required
+	r := NewServerTLSReconciler(cl, scheme, &v1beta1.ServerTLSConfig{})
+	require.Error(t, r.Reconcile(t.Context(), isvc, componentMeta, makePodSpec()))
+
+	// The certificate is issued by the default issuer of the config for the hostnames of the predictor service
+	r = NewServerTLSReconciler(cl, scheme, &v1beta1.ServerTLSConfig{
+		IssuerRef: &v1beta1.CertificateIssuerReference{Name: "kserve-ca", Kind: "ClusterIssuer"},
+		Duration:  "2160h",
+	})
+	podSpec := makePodSpec()
+	require.NoError(t, r.Reconcile(t.Context(), isvc, componentMeta, podSpec))
+	certificate := getCertificate(t, cl)
+	require.NotNil(t, certificate)
+	assert.Equal(t, "sklearn", certificate.GetOwnerReferences()[0].Name)
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	assert.Equal(t, "sklearn-predictor-serving-cert", secretName)
+	dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{
+		"sklearn-predictor",
+		"sklearn-predictor.default",
+		"sklearn-predictor.default.svc",
+		"sklearn-predictor.default.svc.cluster.local",
+	}, dnsNames)
+	issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "kserve-ca", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuerRef)
+	duration, _, _ := unstructured.NestedString(certificate.Object, "spec", "duration")
+	assert.Equal(t, "2160h", duration)
+	secretLabels, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "secretTemplate", "labels")
+	assert.Equal(t, map[string]string{constants.InferenceServicePodLabelKey: "sklearn"}, secretLabels)
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: constants.ServerTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "sklearn-predictor-serving-cert"},
+		},
+	})
+	assertHTTPS(t, podSpec)
+	assert.NotContains(t, componentMeta.Annotations, constants.ServerTLSCertificateHashInternalAnnotationKey)
+
+	// The issuer of the InferenceService takes precedence, and the pods are rolled once the certificate is issued
+	isvc.Spec.Transport.ServerTLS.IssuerRef = &v1beta1.CertificateIssuerReference{Name: "team-ca"}
+	isvc.Spec.Transport.ServerTLS.Rotation = v1beta1.RestartCertificateRotation
+	require.NoError(t, cl.Create(t.Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-serving-cert", Namespace: "default"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("certificate")},
+	}))
+	require.NoError(t, r.Reconcile(t.Context(), isvc, componentMeta, makePodSpec()))
+	certificate = getCertificate(t, cl)
+	require.NotNil(t, certificate)
+	issuerRef, _, _ = unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "team-ca", "kind": "Issuer", "group": "cert-manager.io"}, issuerRef)
+	assert.Equal(t, "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72",
+		componentMeta.Annotations[constants.ServerTLSCertificateHashInternalAnnotationKey])
+
+	// The certificate is deleted once the server TLS is removed
+	isvc.Spec.Transport = nil
+	podSpec = makePodSpec()
+	require.NoError(t, r.Reconcile(t.Context(), isvc, componentMeta, podSpec))
+	assert.Nil(t, getCertificate(t, cl))
+	assert.Equal(t, makePodSpec(), podSpec)
+}
+
+func TestServerTLSReconcilerSPIRE(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "uid"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transport: &v1beta1.TransportSpec{
+				ServerTLS: &v1beta1.ServerTLSSpec{Provider: v1beta1.SPIRECertificateProvider},
+			},
+		},
+	}
+	componentMeta := &metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+	config, err := v1beta1.NewServerTLSConfig(&corev1.ConfigMap{})
+	require.NoError(t, err)
+
+	// The spiffe-helper sidecar writes the SVID of the pod to the certificate files
+	podSpec := makePodSpec()
+	require.NoError(t, NewServerTLSReconciler(cl, scheme, config).Reconcile(t.Context(), isvc, componentMeta, podSpec))
+	assert.Nil(t, getCertificate(t, cl))
+	helperConfig := &corev1.ConfigMap{}
+	require.NoError(t, cl.Get(t.Context(), types.NamespacedName{Namespace: "default", Name: constants.SpiffeHelperConfigMap}, helperConfig))
+	assert.Equal(t, `agent_address = "/spiffe-workload-api/spire-agent.sock"
+cert_dir = "/etc/kserve/tls"
+svid_file_name = "tls.crt"
+svid_key_file_name = "tls.key"
+svid_bundle_file_name = "ca.crt"
+daemon_mode = true
+`, helperConfig.Data["helper.conf"])
+	require.Len(t, podSpec.InitContainers, 1)
+	helper := podSpec.InitContainers[0]
+	assert.Equal(t, constants.SpiffeHelperContainer, helper.Name)
+	assert.Equal(t, constants.DefaultSpiffeHelperImage, helper.Image)
+	assert.Equal(t, corev1.ContainerRestartPolicyAlways, *helper.RestartPolicy)
+	assert.Contains(t, helper.VolumeMounts, corev1.VolumeMount{Name: constants.ServerTLSVolumeName, MountPath: constants.ServerTLSCertDir})
+	volumes := map[string]corev1.VolumeSource{}
+	for _, volume := range podSpec.Volumes {
+		volumes[volume.Name] = volume.VolumeSource
+	}
+	require.NotNil(t, volumes[constants.SpiffeWorkloadAPIVolume].CSI)
+	assert.Equal(t, constants.DefaultSpiffeCSIDriver, volumes[constants.SpiffeWorkloadAPIVolume].CSI.Driver)
+	assert.NotNil(t, volumes[constants.ServerTLSVolumeName].EmptyDir)
+	assertHTTPS(t, podSpec)
+}
@@ -74,6 +74,10 @@ func getAppProtocol(port corev1.ContainerPort) *string {
 	if isGrpcPort(port) {
 		return ptr.To("kubernetes.io/h2c")
 	}
+	// The predictors serving TLS name their port https
+	if port.Name == constants.ServerTLSPortName {
+		return ptr.To("https")
+	}
 	return nil
 }
 
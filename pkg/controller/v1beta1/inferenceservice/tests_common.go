@@ -247,6 +247,23 @@ func getDefaultRollingStrategy() appsv1.DeploymentStrategy {
 	}
 }
 
+// getZoneTopologySpreadConstraints returns the default zone spread constraint of a predictor with multiple replicas
+func getZoneTopologySpreadConstraints(serviceName string) []corev1.TopologySpreadConstraint {
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.InferenceServicePodLabelKey: serviceName,
+					constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+				},
+			},
+		},
+	}
+}
+
 func getExpectedDeployment(explainerDeploymentKey types.NamespacedName, serviceName string, serviceKey types.NamespacedName, predictorServiceKey types.NamespacedName) appsv1.Deployment {
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -390,6 +407,7 @@ func getDeploymentWithKServiceLabel(predictorDeploymentKey types.NamespacedName,
 					DNSPolicy:                     "ClusterFirst",
 					SecurityContext:               defaultSecurityContext,
 					AutomountServiceAccountToken:  ptr.To(false),
+					TopologySpreadConstraints:     getZoneTopologySpreadConstraints(serviceName),
 				},
 			},
 			Strategy:                getDefaultRollingStrategy(),
@@ -22,16 +22,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"net"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	goerrors "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -50,6 +54,9 @@ var (
 const (
 	AzureBlobURL      = "blob.core.windows.net"
 	AzureBlobURIRegEx = "https://(.+?).blob.core.windows.net/(.+)"
+
+	// DeploymentRevisionAnnotation is the revision set by the deployment controller on a deployment and its ReplicaSets
+	DeploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
 )
 
 // IsMMSPredictor Only enable MMS predictor when predictor config sets MMS to true and neither
@@ -80,14 +87,22 @@ func IsMMSPredictor(predictor *v1beta1.PredictorSpec) bool {
 }
 
 func IsMemoryResourceAvailable(isvc *v1beta1.InferenceService, totalReqMemory resource.Quantity) bool {
-	if isvc.Spec.Predictor.GetExtensions() == nil || len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+	predictorMemoryLimit := GetPredictorMemoryLimit(isvc)
+	if predictorMemoryLimit == nil {
 		return false
 	}
+	return predictorMemoryLimit.Cmp(totalReqMemory) >= 0
+}
 
-	container := isvc.Spec.Predictor.GetImplementation().GetContainer(isvc.ObjectMeta, isvc.Spec.Predictor.GetExtensions(), nil)
+// GetPredictorMemoryLimit returns the memory limit of the predictor container, which is the memory capacity of a
+// predictor replica for the Trained Models. Returns nil when the predictor has no implementation.
+func GetPredictorMemoryLimit(isvc *v1beta1.InferenceService) *resource.Quantity {
+	if isvc.Spec.Predictor.GetExtensions() == nil || len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+		return nil
+	}
 
-	predictorMemoryLimit := container.Resources.Limits.Memory()
-	return predictorMemoryLimit.Cmp(totalReqMemory) >= 0
+	container := isvc.Spec.Predictor.GetImplementation().GetContainer(isvc.ObjectMeta, isvc.Spec.Predictor.GetExtensions(), nil)
+	return container.Resources.Limits.Memory()
 }
 
 func getModelNameFromArgs(args []string) string {
@@ -140,6 +155,62 @@ func GetModelName(isvc *v1beta1.InferenceService) string {
 	return isvc.Name
 }
 
+// GetPredictorRevisionPods returns the name of the latest predictor revision and its ready pods. The revision is the
+// latest created Knative revision in the Knative deployment mode, and the current ReplicaSet of the predictor
+// Deployment otherwise. The revision is empty until it is created.
+func GetPredictorRevisionPods(ctx context.Context, cl client.Client, isvc *v1beta1.InferenceService,
+	deploymentMode constants.DeploymentModeType,
+) (string, []corev1.Pod, error) {
+	var revision string
+	var selector client.MatchingLabels
+	if deploymentMode == constants.Knative {
+		revision = isvc.Status.Components[v1beta1.PredictorComponent].LatestCreatedRevision
+		selector = client.MatchingLabels{constants.RevisionLabel: revision}
+	} else {
+		replicaSet, err := GetCurrentReplicaSet(ctx, cl, isvc.Namespace, constants.PredictorServiceName(isvc.Name))
+		if err != nil {
+			return "", nil, err
+		}
+		if replicaSet != nil && replicaSet.Spec.Selector != nil {
+			revision = replicaSet.Name
+			selector = replicaSet.Spec.Selector.MatchLabels
+		}
+	}
+	if revision == "" {
+		return "", nil, nil
+	}
+	pods := &corev1.PodList{}
+	if err := cl.List(ctx, pods, client.InNamespace(isvc.Namespace), selector); err != nil {
+		return "", nil, err
+	}
+	var ready []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready = append(ready, pod)
+				break
+			}
+		}
+	}
+	return revision, ready, nil
+}
+
+// GetPodURL returns the address of the inference server container of a pod, which is reached on its first container
+// port, or on the default HTTP port when it declares none
+func GetPodURL(pod *corev1.Pod) string {
+	port := constants.InferenceServiceDefaultHttpPort
+	for _, container := range pod.Spec.Containers {
+		if container.Name == constants.InferenceServiceContainerName && len(container.Ports) > 0 {
+			port = strconv.Itoa(int(container.Ports[0].ContainerPort))
+			break
+		}
+	}
+	return "http://" + net.JoinHostPort(pod.Status.PodIP, port)
+}
+
 // GetPredictorEndpoint returns the predictor endpoint if status.address.url is not nil else returns empty string with error.
 func GetPredictorEndpoint(ctx context.Context, client client.Client, isvc *v1beta1.InferenceService) (string, error) {
 	if isvc.Status.Address != nil && isvc.Status.Address.URL != nil {
@@ -279,18 +350,43 @@ func MergeRuntimeContainers(runtimeContainer *corev1.Container, isvcContainer *c
 	// Strategic merge patch will replace args but more useful behaviour here is to concatenate
 	mergedContainer.Args = append(append([]string{}, runtimeContainer.Args...), isvcContainer.Args...)
 
+	if isvcContainer.Lifecycle != nil {
+		mergedContainer.Lifecycle = mergeLifecycle(runtimeContainer.Lifecycle, isvcContainer.Lifecycle)
+	}
+
 	return &mergedContainer, nil
 }
 
+// mergeLifecycle merges the lifecycle hooks of the InferenceService container over the hooks of the runtime container.
+// Strategic merge patch would merge the handlers of a hook, which only allows one, so each hook set in the
+// InferenceService replaces the hook of the runtime as a whole.
+func mergeLifecycle(runtimeLifecycle *corev1.Lifecycle, isvcLifecycle *corev1.Lifecycle) *corev1.Lifecycle {
+	merged := &corev1.Lifecycle{}
+	if runtimeLifecycle != nil {
+		merged = runtimeLifecycle.DeepCopy()
+	}
+	if isvcLifecycle.PostStart != nil {
+		merged.PostStart = isvcLifecycle.PostStart.DeepCopy()
+	}
+	if isvcLifecycle.PreStop != nil {
+		merged.PreStop = isvcLifecycle.PreStop.DeepCopy()
+	}
+	if isvcLifecycle.StopSignal != nil {
+		merged.StopSignal = isvcLifecycle.StopSignal
+	}
+	return merged
+}
+
 // MergePodSpec Merge the predictor PodSpec struct with the runtime PodSpec struct, allowing users
 // to override runtime PodSpec settings from the predictor spec.
 func MergePodSpec(runtimePodSpec *v1alpha1.ServingRuntimePodSpec, predictorPodSpec *v1beta1.PodSpec) (*corev1.PodSpec, error) {
 	runtimePodSpecJson, err := json.Marshal(corev1.PodSpec{
-		NodeSelector:     runtimePodSpec.NodeSelector,
-		Affinity:         runtimePodSpec.Affinity,
-		Tolerations:      runtimePodSpec.Tolerations,
-		Volumes:          runtimePodSpec.Volumes,
-		ImagePullSecrets: runtimePodSpec.ImagePullSecrets,
+		NodeSelector:       runtimePodSpec.NodeSelector,
+		Affinity:           runtimePodSpec.Affinity,
+		Tolerations:        runtimePodSpec.Tolerations,
+		Volumes:            runtimePodSpec.Volumes,
+		ImagePullSecrets:   runtimePodSpec.ImagePullSecrets,
+		ServiceAccountName: runtimePodSpec.ServiceAccountName,
 	})
 	if err != nil {
 		return nil, err
@@ -398,6 +494,34 @@ func ListPodsByLabel(ctx context.Context, cl client.Client, namespace string, la
 	return podList, nil
 }
 
+// GetCurrentReplicaSet returns the ReplicaSet of the current revision of a deployment, or nil if the deployment or
+// its ReplicaSet do not exist yet
+func GetCurrentReplicaSet(ctx context.Context, cl client.Client, namespace string, name string) (*appsv1.ReplicaSet, error) {
+	deployment := &appsv1.Deployment{}
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	revision, ok := deployment.Annotations[DeploymentRevisionAnnotation]
+	if !ok || deployment.Spec.Selector == nil {
+		return nil, nil
+	}
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := cl.List(ctx, replicaSets, client.InNamespace(namespace),
+		client.MatchingLabels(deployment.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	for i := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[i]
+		if metav1.IsControlledBy(replicaSet, deployment) && replicaSet.Annotations[DeploymentRevisionAnnotation] == revision {
+			return replicaSet, nil
+		}
+	}
+	return nil, nil
+}
+
 func sortPodsByCreatedTimestampDesc(pods *corev1.PodList) {
 	sort.Slice(pods.Items, func(i, j int) bool {
 		return pods.Items[j].ObjectMeta.CreationTimestamp.Before(&pods.Items[i].ObjectMeta.CreationTimestamp)
@@ -511,3 +635,189 @@ func MergeServingRuntimeAndInferenceServiceSpecs(srContainers []corev1.Container
 	}
 	return containerIndexInSR, mergedContainer, mergedPodSpec, nil
 }
+
+// AddZoneTopologySpreadConstraint spreads the predictor replicas across topology zones when more than one
+// replica may be running. The constraint is only added by default when the pod spec does not already
+// declare a zone topology spread constraint or pod anti-affinity, so users can always override it.
+func AddZoneTopologySpreadConstraint(isvc *v1beta1.InferenceService, podSpec *corev1.PodSpec) {
+	predictor := &isvc.Spec.Predictor
+	policy := v1beta1.ZoneSpreadPreferred
+	if predictor.ZoneSpread != nil {
+		policy = *predictor.ZoneSpread
+	}
+	if policy == v1beta1.ZoneSpreadDisabled {
+		return
+	}
+	minReplicas := constants.DefaultMinReplicas
+	if predictor.MinReplicas != nil {
+		minReplicas = *predictor.MinReplicas
+	}
+	if minReplicas <= 1 && predictor.MaxReplicas <= 1 {
+		return
+	}
+	if podSpec.Affinity != nil && podSpec.Affinity.PodAntiAffinity != nil {
+		return
+	}
+	for _, constraint := range podSpec.TopologySpreadConstraints {
+		if constraint.TopologyKey == corev1.LabelTopologyZone {
+			return
+		}
+	}
+	whenUnsatisfiable := corev1.ScheduleAnyway
+	if policy == v1beta1.ZoneSpreadRequired {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+	podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelTopologyZone,
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+				constants.KServiceComponentLabel:      string(v1beta1.PredictorComponent),
+			},
+		},
+	})
+}
+
+// ListSpotPods returns the pods of the predictor running or pending on spot nodes
+func ListSpotPods(ctx context.Context, cl client.Client, namespace string, predictorName string) (*corev1.PodList, error) {
+	podList := &corev1.PodList{}
+	if err := cl.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabels{
+		constants.RawDeploymentAppLabel: constants.GetRawServiceLabel(predictorName),
+		constants.CapacityTypeLabelKey:  constants.CapacityTypeSpot,
+	}); err != nil {
+		return nil, err
+	}
+	return podList, nil
+}
+
+// GetRebalancedReplicas returns the number of spot pods which are preempted or cannot be scheduled on the spot nodes,
+// and are replaced by on-demand replicas until spot capacity is available again
+func GetRebalancedReplicas(spotPods *corev1.PodList) int32 {
+	var rebalanced int32
+	for _, pod := range spotPods.Items {
+		for _, cond := range pod.Status.Conditions {
+			preempted := cond.Type == corev1.DisruptionTarget && cond.Status == corev1.ConditionTrue
+			unschedulable := cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse &&
+				cond.Reason == corev1.PodReasonUnschedulable
+			if preempted || unschedulable {
+				rebalanced++
+				break
+			}
+		}
+	}
+	return rebalanced
+}
+
+// GetCachedNodes returns the sorted names of the nodes which downloaded the cached model
+func GetCachedNodes(localModel *v1alpha1.LocalModelCache) []string {
+	nodes := []string{}
+	for node, status := range localModel.Status.NodeStatus {
+		if status == v1alpha1.NodeDownloaded {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// AddCachedNodeAffinity adds a node affinity for the nodes which downloaded the cached model, so that the predictor
+// pods are scheduled where the model is already available. The preferred policy favors these nodes, while the
+// required policy restricts the pods to them in addition to the node affinity declared in the pod spec.
+// No affinity is added while the model is not downloaded on any node.
+func AddCachedNodeAffinity(localModel *v1alpha1.LocalModelCache, policy v1beta1.CachedNodeAffinityPolicy, podSpec *corev1.PodSpec) {
+	if policy == v1beta1.CachedNodeAffinityDisabled {
+		return
+	}
+	nodes := GetCachedNodes(localModel)
+	if len(nodes) == 0 {
+		return
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelHostname,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   nodes,
+	}
+
+	if policy == v1beta1.CachedNodeAffinityRequired {
+		addRequiredNodeSelectorRequirement(podSpec, requirement)
+		return
+	}
+	// the affinity may be shared with the pod spec of the InferenceService
+	affinity := podSpec.Affinity.DeepCopy()
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{requirement},
+			},
+		})
+	podSpec.Affinity = affinity
+}
+
+// addRequiredNodeSelectorRequirement restricts the nodes the pod is scheduled on with the requirement
+func addRequiredNodeSelectorRequirement(podSpec *corev1.PodSpec, requirement corev1.NodeSelectorRequirement) {
+	// the affinity may be shared with the pod spec of the InferenceService
+	affinity := podSpec.Affinity.DeepCopy()
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// the terms are ORed, so the requirement is added to each of them
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	podSpec.Affinity = affinity
+}
+
+// GetTargetArchitecture returns the architecture of the nodes selected by the kubernetes.io/arch node selector of
+// the predictor, if any
+func GetTargetArchitecture(isvc *v1beta1.InferenceService) string {
+	return isvc.Spec.Predictor.NodeSelector[corev1.LabelArchStable]
+}
+
+// AddArchitectureNodeAffinity schedules the pods on the nodes of the architectures supported by the runtime, unless
+// the architecture is already selected by the node selector of the pod
+func AddArchitectureNodeAffinity(sRuntime *v1alpha1.ServingRuntimeSpec, podSpec *corev1.PodSpec) {
+	if _, ok := podSpec.NodeSelector[corev1.LabelArchStable]; ok {
+		return
+	}
+	architectures := sRuntime.GetSupportedArchitectures()
+	if len(architectures) == 0 {
+		return
+	}
+	addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   architectures,
+	})
+}
+
+// AddLinuxNodeAffinity keeps the pods off the Windows nodes of mixed-OS clusters, unless the operating system is
+// already selected by the node selector or the OS of the pod
+func AddLinuxNodeAffinity(podSpec *corev1.PodSpec) {
+	if _, ok := podSpec.NodeSelector[corev1.LabelOSStable]; ok || podSpec.OS != nil {
+		return
+	}
+	addRequiredNodeSelectorRequirement(podSpec, corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelOSStable,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{string(corev1.Windows)},
+	})
+}
@@ -28,10 +28,12 @@ import (
 
 	"github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -773,6 +775,42 @@ func TestMergeRuntimeContainers(t *testing.T) {
 				},
 			},
 		},
+		"LifecycleMerge": {
+			containerBase: &corev1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				Lifecycle: &corev1.Lifecycle{
+					PostStart: &corev1.LifecycleHandler{
+						Exec: &corev1.ExecAction{Command: []string{"/bin/register"}},
+					},
+					PreStop: &corev1.LifecycleHandler{
+						Sleep: &corev1.SleepAction{Seconds: 5},
+					},
+				},
+			},
+			containerOverride: &corev1.Container{
+				Lifecycle: &corev1.Lifecycle{
+					PostStart: &corev1.LifecycleHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/register", Port: intstr.FromInt32(8080)},
+					},
+				},
+				RestartPolicy: ptr.To(corev1.ContainerRestartPolicyNever),
+			},
+			expected: &corev1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				Args:  []string{},
+				Lifecycle: &corev1.Lifecycle{
+					PostStart: &corev1.LifecycleHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/register", Port: intstr.FromInt32(8080)},
+					},
+					PreStop: &corev1.LifecycleHandler{
+						Sleep: &corev1.SleepAction{Seconds: 5},
+					},
+				},
+				RestartPolicy: ptr.To(corev1.ContainerRestartPolicyNever),
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -895,6 +933,26 @@ func TestMergePodSpec(t *testing.T) {
 				},
 			},
 		},
+		"RuntimeServiceAccount": {
+			podSpecBase: &v1alpha1.ServingRuntimePodSpec{
+				ServiceAccountName: "runtime-sa",
+			},
+			podSpecOverride: &PodSpec{},
+			expected: &corev1.PodSpec{
+				ServiceAccountName: "runtime-sa",
+			},
+		},
+		"InferenceServiceServiceAccountOverridesRuntime": {
+			podSpecBase: &v1alpha1.ServingRuntimePodSpec{
+				ServiceAccountName: "runtime-sa",
+			},
+			podSpecOverride: &PodSpec{
+				ServiceAccountName: "isvc-sa",
+			},
+			expected: &corev1.PodSpec{
+				ServiceAccountName: "isvc-sa",
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -2400,3 +2458,385 @@ func TestMergeServingRuntimeAndInferenceServiceSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestAddZoneTopologySpreadConstraint(t *testing.T) {
+	zoneConstraint := func(whenUnsatisfiable corev1.UnsatisfiableConstraintAction) corev1.TopologySpreadConstraint {
+		return corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.InferenceServicePodLabelKey: "foo",
+					constants.KServiceComponentLabel:      string(PredictorComponent),
+				},
+			},
+		}
+	}
+	scenarios := map[string]struct {
+		zoneSpread  *ZoneSpreadPolicy
+		minReplicas *int32
+		maxReplicas int32
+		podSpec     corev1.PodSpec
+		expected    []corev1.TopologySpreadConstraint
+	}{
+		"single replica": {
+			minReplicas: ptr.To(int32(1)),
+			maxReplicas: 1,
+			expected:    nil,
+		},
+		"multiple replicas defaults to preferred": {
+			minReplicas: ptr.To(int32(1)),
+			maxReplicas: 3,
+			expected:    []corev1.TopologySpreadConstraint{zoneConstraint(corev1.ScheduleAnyway)},
+		},
+		"multiple replicas with required zone spread": {
+			zoneSpread:  ptr.To(ZoneSpreadRequired),
+			minReplicas: ptr.To(int32(2)),
+			expected:    []corev1.TopologySpreadConstraint{zoneConstraint(corev1.DoNotSchedule)},
+		},
+		"disabled zone spread": {
+			zoneSpread:  ptr.To(ZoneSpreadDisabled),
+			minReplicas: ptr.To(int32(2)),
+			expected:    nil,
+		},
+		"user defined pod anti-affinity is not overridden": {
+			minReplicas: ptr.To(int32(2)),
+			podSpec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{}},
+			},
+			expected: nil,
+		},
+		"user defined zone constraint is not overridden": {
+			zoneSpread:  ptr.To(ZoneSpreadRequired),
+			minReplicas: ptr.To(int32(2)),
+			podSpec: corev1.PodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: corev1.LabelTopologyZone}},
+			},
+			expected: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: corev1.LabelTopologyZone}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := &InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: InferenceServiceSpec{
+					Predictor: PredictorSpec{
+						ZoneSpread: scenario.zoneSpread,
+						ComponentExtensionSpec: ComponentExtensionSpec{
+							MinReplicas: scenario.minReplicas,
+							MaxReplicas: scenario.maxReplicas,
+						},
+					},
+				},
+			}
+			podSpec := scenario.podSpec
+			AddZoneTopologySpreadConstraint(isvc, &podSpec)
+			g.Expect(podSpec.TopologySpreadConstraints).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestGetRebalancedReplicas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := func(name string, capacityType string, conditions ...corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					constants.RawDeploymentAppLabel: constants.GetRawServiceLabel("foo-predictor"),
+					constants.CapacityTypeLabelKey:  capacityType,
+				},
+			},
+			Status: corev1.PodStatus{Conditions: conditions},
+		}
+	}
+	s := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+	mockClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		pod("running", constants.CapacityTypeSpot,
+			corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+			corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue}),
+		pod("preempted", constants.CapacityTypeSpot,
+			corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			corev1.PodCondition{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue}),
+		pod("unschedulable", constants.CapacityTypeSpot,
+			corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}),
+		pod("on-demand", constants.CapacityTypeOnDemand,
+			corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}),
+	).Build()
+
+	spotPods, err := ListSpotPods(t.Context(), mockClient, "default", "foo-predictor")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(spotPods.Items).To(gomega.HaveLen(3))
+	g.Expect(GetRebalancedReplicas(spotPods)).To(gomega.Equal(int32(2)))
+}
+
+func TestAddCachedNodeAffinity(t *testing.T) {
+	localModel := &v1alpha1.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{Name: "iris"},
+		Status: v1alpha1.LocalModelCacheStatus{
+			NodeStatus: map[string]v1alpha1.NodeStatus{
+				"node-b": v1alpha1.NodeDownloaded,
+				"node-a": v1alpha1.NodeDownloaded,
+				"node-c": v1alpha1.NodeDownloading,
+			},
+		},
+	}
+	cachedNodes := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelHostname,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"node-a", "node-b"},
+	}
+	gpuNodes := corev1.NodeSelectorRequirement{
+		Key:      "gpu",
+		Operator: corev1.NodeSelectorOpExists,
+	}
+	scenarios := map[string]struct {
+		localModel *v1alpha1.LocalModelCache
+		policy     CachedNodeAffinityPolicy
+		podSpec    corev1.PodSpec
+		expected   *corev1.Affinity
+	}{
+		"preferred": {
+			localModel: localModel,
+			policy:     CachedNodeAffinityPreferred,
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+						Weight:     100,
+						Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{cachedNodes}},
+					}},
+				},
+			},
+		},
+		"required": {
+			localModel: localModel,
+			policy:     CachedNodeAffinityRequired,
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{cachedNodes}}},
+					},
+				},
+			},
+		},
+		"required is added to the user defined node affinity": {
+			localModel: localModel,
+			policy:     CachedNodeAffinityRequired,
+			podSpec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpuNodes}}},
+						},
+					},
+				},
+			},
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{gpuNodes, cachedNodes}}},
+					},
+				},
+			},
+		},
+		"disabled": {
+			localModel: localModel,
+			policy:     CachedNodeAffinityDisabled,
+			expected:   nil,
+		},
+		"model not downloaded on any node": {
+			localModel: &v1alpha1.LocalModelCache{
+				Status: v1alpha1.LocalModelCacheStatus{
+					NodeStatus: map[string]v1alpha1.NodeStatus{"node-a": v1alpha1.NodeDownloading},
+				},
+			},
+			policy:   CachedNodeAffinityRequired,
+			expected: nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			podSpec := scenario.podSpec
+			original := podSpec.DeepCopy()
+			AddCachedNodeAffinity(scenario.localModel, scenario.policy, &podSpec)
+			g.Expect(podSpec.Affinity).To(gomega.Equal(scenario.expected))
+			// the affinity of the original pod spec is not modified
+			g.Expect(scenario.podSpec).To(gomega.Equal(*original))
+		})
+	}
+}
+
+func TestAddArchitectureNodeAffinity(t *testing.T) {
+	armNodes := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"amd64", "arm64"},
+	}
+	scenarios := map[string]struct {
+		sRuntime v1alpha1.ServingRuntimeSpec
+		podSpec  corev1.PodSpec
+		expected *corev1.Affinity
+	}{
+		"supported architectures": {
+			sRuntime: v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{armNodes}}},
+					},
+				},
+			},
+		},
+		"architectures of the images": {
+			sRuntime: v1alpha1.ServingRuntimeSpec{ArchitectureImages: map[string]string{
+				"arm64": "kserve/sklearnserver:latest-arm64",
+				"amd64": "kserve/sklearnserver:latest-amd64",
+			}},
+			expected: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{armNodes}}},
+					},
+				},
+			},
+		},
+		"architecture selected by the node selector": {
+			sRuntime: v1alpha1.ServingRuntimeSpec{SupportedArchitectures: []string{"amd64", "arm64"}},
+			podSpec:  corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"}},
+			expected: nil,
+		},
+		"runtime supporting all the architectures": {
+			sRuntime: v1alpha1.ServingRuntimeSpec{},
+			expected: nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			podSpec := scenario.podSpec
+			AddArchitectureNodeAffinity(&scenario.sRuntime, &podSpec)
+			g.Expect(podSpec.Affinity).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestAddLinuxNodeAffinity(t *testing.T) {
+	linuxNodes := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      corev1.LabelOSStable,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{"windows"},
+				}}}},
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		podSpec  corev1.PodSpec
+		expected *corev1.Affinity
+	}{
+		"no operating system selected": {
+			podSpec:  corev1.PodSpec{},
+			expected: linuxNodes,
+		},
+		"operating system selected by the node selector": {
+			podSpec:  corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelOSStable: "linux"}},
+			expected: nil,
+		},
+		"operating system of the pod": {
+			podSpec:  corev1.PodSpec{OS: &corev1.PodOS{Name: corev1.Linux}},
+			expected: nil,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			podSpec := scenario.podSpec
+			AddLinuxNodeAffinity(&podSpec)
+			g.Expect(podSpec.Affinity).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestGetPredictorRevisionPods(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ready := corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue}
+	pod := func(name string, labels map[string]string, podIP string, conditions ...corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 9000}},
+				}},
+			},
+			Status: corev1.PodStatus{PodIP: podIP, Conditions: conditions},
+		}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   "default",
+			Annotations: map[string]string{DeploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sklearn-predictor"}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor-7d9f",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "sklearn-predictor"},
+			Annotations: map[string]string{DeploymentRevisionAnnotation: "2"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "sklearn-predictor", Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "sklearn-predictor", "pod-template-hash": "7d9f"}},
+		},
+	}
+	s := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+	g.Expect(appsv1.AddToScheme(s)).To(gomega.Succeed())
+	mockClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		deployment, replicaSet,
+		pod("standard-ready", map[string]string{"app": "sklearn-predictor", "pod-template-hash": "7d9f"}, "10.0.0.1", ready),
+		pod("standard-previous", map[string]string{"app": "sklearn-predictor", "pod-template-hash": "5c4b"}, "10.0.0.2", ready),
+		pod("knative-ready", map[string]string{constants.RevisionLabel: "sklearn-predictor-00002"}, "10.0.0.3", ready),
+		pod("knative-starting", map[string]string{constants.RevisionLabel: "sklearn-predictor-00002"}, "10.0.0.4"),
+	).Build()
+	isvc := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Status: InferenceServiceStatus{
+			Components: map[ComponentType]ComponentStatusSpec{
+				PredictorComponent: {LatestCreatedRevision: "sklearn-predictor-00002"},
+			},
+		},
+	}
+
+	revision, pods, err := GetPredictorRevisionPods(t.Context(), mockClient, isvc, constants.Standard)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(revision).To(gomega.Equal("sklearn-predictor-7d9f"))
+	g.Expect(pods).To(gomega.HaveLen(1))
+	g.Expect(GetPodURL(&pods[0])).To(gomega.Equal("http://10.0.0.1:9000"))
+
+	revision, pods, err = GetPredictorRevisionPods(t.Context(), mockClient, isvc, constants.Knative)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(revision).To(gomega.Equal("sklearn-predictor-00002"))
+	g.Expect(pods).To(gomega.HaveLen(1))
+	g.Expect(pods[0].Name).To(gomega.Equal("knative-ready"))
+
+	isvc.Status.Components = nil
+	revision, pods, err = GetPredictorRevisionPods(t.Context(), mockClient, isvc, constants.Knative)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(revision).To(gomega.BeEmpty())
+	g.Expect(pods).To(gomega.BeEmpty())
+}
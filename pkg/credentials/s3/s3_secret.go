@@ -39,6 +39,7 @@ const (
 	S3VerifySSL            = "S3_VERIFY_SSL"
 	S3UseVirtualBucket     = "S3_USER_VIRTUAL_BUCKET"
 	S3UseAccelerate        = "S3_USE_ACCELERATE"
+	S3SignatureVersion     = "S3_SIGNATURE_VERSION"
 	AWSAnonymousCredential = "awsAnonymousCredential"
 	AWSCABundle            = "AWS_CA_BUNDLE"
 	AWSCABundleConfigMap   = "AWS_CA_BUNDLE_CONFIGMAP"
@@ -52,7 +52,7 @@ const (
 )
 
 var (
-	SupportedStorageSpecTypes = []string{"s3", "hdfs", "webhdfs"}
+	SupportedStorageSpecTypes = []string{"s3", "hdfs", "webhdfs", "hf"}
 	StorageBucketTypes        = []string{"s3"}
 )
 
@@ -1856,6 +1856,51 @@ func TestCredentialBuilder_CreateStorageSpecSecretEnvs(t *testing.T) {
 			shouldFail: true,
 			matcher:    gomega.HaveOccurred(),
 		},
+		"huggingface storage spec": {
+			secret: &corev1.Secret{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Secret",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "storage-config",
+					Namespace: namespace,
+				},
+				StringData: map[string]string{"huggingface": "{\"type\": \"hf\", \"token\": \"hf_token\"}"},
+			},
+			storageKey:     "huggingface",
+			overrideParams: map[string]string{},
+			container: &corev1.Container{
+				Name:  "init-container",
+				Image: "kserve/init-container:latest",
+				Args: []string{
+					"<scheme-placeholder>://meta-llama/Llama-3.1-8B:main",
+					"/mnt/models/",
+				},
+			},
+			shouldFail: false,
+			matcher: gomega.Equal(&corev1.Container{
+				Name:  "init-container",
+				Image: "kserve/init-container:latest",
+				Args: []string{
+					"hf://meta-llama/Llama-3.1-8B:main",
+					"/mnt/models/",
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name: "STORAGE_CONFIG",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "storage-config",
+								},
+								Key: "huggingface",
+							},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	for _, tc := range scenarios {
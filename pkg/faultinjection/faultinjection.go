@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultinjection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultErrorCode is the status code of the injected errors
+	DefaultErrorCode = http.StatusServiceUnavailable
+	// InjectedHeader is the response header set on the responses of the requests a fault is injected into, so that
+	// the injected errors are told apart from the errors of the serving infrastructure during the game days
+	InjectedHeader = "X-Kserve-Fault-Injected"
+	// MaxDelay is the maximum delay injected into the requests
+	MaxDelay = 5 * time.Minute
+)
+
+// Rule injects errors and latency into a percentage of the requests whose path starts with the path of the rule
+type Rule struct {
+	// Path prefix of the requests the faults are injected into, all the requests when empty
+	Path string `json:"path,omitempty"`
+	// ErrorPercent is the percentage of the requests answered with the error code without reaching the component
+	ErrorPercent float64 `json:"errorPercent,omitempty"`
+	// ErrorCode is the status code of the injected errors. Defaults to 503.
+	ErrorCode int `json:"errorCode,omitempty"`
+	// Delay added before the requests are handled
+	Delay *metav1.Duration `json:"delay,omitempty"`
+	// DelayPercent is the percentage of the requests delayed. Defaults to 100 when a delay is set.
+	DelayPercent *float64 `json:"delayPercent,omitempty"`
+}
+
+// ParseRules parses and validates the JSON list of rules set with the serving.kserve.io/fault-injection annotation
+func ParseRules(value string) ([]Rule, error) {
+	var rules []Rule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse the fault injection rules: %w", err)
+	}
+	var allErrors []error
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Path != "" && !strings.HasPrefix(rule.Path, "/") {
+			allErrors = append(allErrors, fmt.Errorf("rule %d: path %q must start with /", i, rule.Path))
+		}
+		if rule.ErrorPercent < 0 || rule.ErrorPercent > 100 {
+			allErrors = append(allErrors, fmt.Errorf("rule %d: errorPercent must be between 0 and 100", i))
+		}
+		if rule.ErrorCode == 0 {
+			rule.ErrorCode = DefaultErrorCode
+		} else if rule.ErrorCode < 400 || rule.ErrorCode > 599 {
+			allErrors = append(allErrors, fmt.Errorf("rule %d: errorCode %d must be a 4xx or 5xx status code", i, rule.ErrorCode))
+		}
+		if rule.Delay != nil && (rule.Delay.Duration < 0 || rule.Delay.Duration > MaxDelay) {
+			allErrors = append(allErrors, fmt.Errorf("rule %d: delay must be between 0 and %s", i, MaxDelay))
+		}
+		if rule.DelayPercent == nil {
+			rule.DelayPercent = new(float64)
+			if rule.Delay != nil {
+				*rule.DelayPercent = 100
+			}
+		} else if *rule.DelayPercent < 0 || *rule.DelayPercent > 100 {
+			allErrors = append(allErrors, fmt.Errorf("rule %d: delayPercent must be between 0 and 100", i))
+		}
+	}
+	if err := errors.Join(allErrors...); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Handler injects the faults of the first rule matching the path of the requests, so that the retries and timeouts
+// of the clients are tested against the serving infrastructure. The requests are delayed before the errors are
+// injected, and the delays end early when the requests are cancelled.
+type Handler struct {
+	next  http.Handler
+	rules []Rule
+	// random returns a number in [0, 100)
+	random func() float64
+}
+
+func NewHandler(rules []Rule, next http.Handler) *Handler {
+	return &Handler{
+		next:   next,
+		rules:  rules,
+		random: func() float64 { return rand.Float64() * 100 },
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rule := h.match(r.URL.Path)
+	if rule == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if rule.Delay != nil && h.random() < *rule.DelayPercent {
+		w.Header().Set(InjectedHeader, "delay")
+		timer := time.NewTimer(rule.Delay.Duration)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+	if h.random() < rule.ErrorPercent {
+		w.Header().Set(InjectedHeader, "error")
+		http.Error(w, "fault injected", rule.ErrorCode)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *Handler) match(path string) *Rule {
+	for i := range h.rules {
+		if strings.HasPrefix(path, h.rules[i].Path) {
+			return &h.rules[i]
+		}
+	}
+	return nil
+}
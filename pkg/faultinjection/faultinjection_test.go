@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultinjection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(`[{"path": "/v1/models/", "errorPercent": 10}, {"delay": "200ms"}]`)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, DefaultErrorCode, rules[0].ErrorCode)
+	assert.Zero(t, *rules[0].DelayPercent)
+	assert.Equal(t, 200*time.Millisecond, rules[1].Delay.Duration)
+	assert.InDelta(t, 100, *rules[1].DelayPercent, 0)
+
+	for name, value := range map[string]string{
+		"invalid json":          `{"errorPercent": 10}`,
+		"relative path":         `[{"path": "v1/models"}]`,
+		"error percent":         `[{"errorPercent": 110}]`,
+		"error code":            `[{"errorPercent": 10, "errorCode": 200}]`,
+		"delay percent":         `[{"delay": "1s", "delayPercent": -1}]`,
+		"delay above the limit": `[{"delay": "1h"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRules(value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rules, err := ParseRules(`[
+		{"path": "/v2/", "errorPercent": 50, "errorCode": 500},
+		{"path": "/v1/", "delay": "10ms"}
+	]`)
+	require.NoError(t, err)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	scenarios := map[string]struct {
+		path           string
+		random         float64
+		expectedCode   int
+		expectedHeader string
+	}{
+		"error injected": {
+			path:           "/v2/models/sklearn/infer",
+			random:         10,
+			expectedCode:   http.StatusInternalServerError,
+			expectedHeader: "error",
+		},
+		"error not drawn": {
+			path:         "/v2/models/sklearn/infer",
+			random:       60,
+			expectedCode: http.StatusOK,
+		},
+		"delay injected": {
+			path:           "/v1/models/sklearn:predict",
+			random:         99,
+			expectedCode:   http.StatusOK,
+			expectedHeader: "delay",
+		},
+		"no matching rule": {
+			path:         "/metrics",
+			random:       0,
+			expectedCode: http.StatusOK,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			handler := NewHandler(rules, next)
+			handler.random = func() float64 { return scenario.random }
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, scenario.path, nil))
+			assert.Equal(t, scenario.expectedCode, recorder.Code)
+			assert.Equal(t, scenario.expectedHeader, recorder.Header().Get(InjectedHeader))
+		})
+	}
+}
+
+func TestHandlerCancelledDelay(t *testing.T) {
+	rules, err := ParseRules(`[{"delay": "1m"}]`)
+	require.NoError(t, err)
+	handler := NewHandler(rules, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the cancelled request must not reach the component")
+	}))
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/models/sklearn:predict", nil).WithContext(ctx))
+	assert.Equal(t, "delay", recorder.Header().Get(InjectedHeader))
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// Formats of the files the BatchStore writes, each one batching many log requests
+const (
+	NDJSONFormat  = constants.LoggerNDJSONFormat
+	ParquetFormat = constants.LoggerParquetFormat
+)
+
+const (
+	// DefaultBatchFlushInterval is the longest time a log request stays buffered before it is uploaded
+	DefaultBatchFlushInterval = time.Minute
+	// DefaultBatchFlushSize is the size of the buffered payloads, in bytes, above which a batch is uploaded
+	DefaultBatchFlushSize = 16 * 1024 * 1024
+)
+
+// IsBatchFormat returns whether the log requests are batched into files of the format
+func IsBatchFormat(format string) bool {
+	return format == NDJSONFormat || format == ParquetFormat
+}
+
+// BatchRecord is a log request as written in the batched files, a line of the NDJSON files or a row of the Parquet
+// files
+type BatchRecord struct {
+	Id               string              `json:"id" parquet:"id"`
+	Type             string              `json:"type" parquet:"type"`
+	Time             time.Time           `json:"time" parquet:"time,timestamp(millisecond)"`
+	Source           string              `json:"source,omitempty" parquet:"source"`
+	InferenceService string              `json:"inferenceService,omitempty" parquet:"inference_service"`
+	Namespace        string              `json:"namespace,omitempty" parquet:"namespace"`
+	Component        string              `json:"component,omitempty" parquet:"component"`
+	Endpoint         string              `json:"endpoint,omitempty" parquet:"endpoint"`
+	ContentType      string              `json:"contentType,omitempty" parquet:"content_type"`
+	Metadata         map[string][]string `json:"metadata,omitempty" parquet:"metadata,optional"`
+	Annotations      map[string]string   `json:"annotations,omitempty" parquet:"annotations,optional"`
+	Payload          []byte              `json:"-" parquet:"payload"`
+}
+
+// ndjsonRecord embeds the JSON payloads as they are, the other payloads are base64 encoded
+type ndjsonRecord struct {
+	BatchRecord
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	PayloadBase64 []byte          `json:"payloadBase64,omitempty"`
+}
+
+func newBatchRecord(logRequest LogRequest) BatchRecord {
+	record := BatchRecord{
+		Id:               logRequest.Id,
+		Type:             logRequest.ReqType,
+		Time:             time.Now().UTC(),
+		InferenceService: logRequest.InferenceService,
+		Namespace:        logRequest.Namespace,
+		Component:        logRequest.Component,
+		Endpoint:         logRequest.Endpoint,
+		ContentType:      logRequest.ContentType,
+		Metadata:         logRequest.Metadata,
+		Annotations:      logRequest.Annotations,
+	}
+	if logRequest.SourceUri != nil {
+		record.Source = logRequest.SourceUri.String()
+	}
+	if logRequest.Bytes != nil {
+		record.Payload = *logRequest.Bytes
+	}
+	return record
+}
+
+func encodeNDJSON(records []BatchRecord) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	for _, record := range records {
+		line := ndjsonRecord{BatchRecord: record}
+		if json.Valid(record.Payload) {
+			line.Payload = record.Payload
+		} else {
+			line.PayloadBase64 = record.Payload
+		}
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("could not encode the log record %s as json: %w", record.Id, err)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func encodeParquet(records []BatchRecord) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := parquet.NewGenericWriter[BatchRecord](buffer, parquet.Compression(&parquet.Snappy))
+	if _, err := writer.Write(records); err != nil {
+		return nil, fmt.Errorf("could not encode the log records as parquet: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("could not encode the log records as parquet: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// batch holds the records buffered for an object prefix
+type batch struct {
+	bucket  string
+	prefix  string
+	records []BatchRecord
+	size    int
+}
+
+// BatchStore buffers the log requests and uploads them to the object storage in NDJSON or Parquet files, one file
+// per object prefix each time the flush interval elapses or the buffered payloads reach the flush size
+type BatchStore struct {
+	storePath     string
+	format        string
+	flushInterval time.Duration
+	flushSize     int
+	provider      storage.Provider
+	log           *zap.SugaredLogger
+	// source tells apart the files of the replicas of a component, the pod name
+	source string
+
+	mutex    sync.Mutex
+	batches  map[string]*batch
+	sequence uint64
+	stop     chan struct{}
+	stopped  sync.WaitGroup
+}
+
+var _ Store = &BatchStore{}
+
+// NewBatchStore creates a BatchStore and starts flushing it on the flush interval, Close flushes the last batches
+func NewBatchStore(logStorePath string, format string, flushInterval time.Duration, flushSize int, provider storage.Provider, log *zap.SugaredLogger) (*BatchStore, error) {
+	if !IsBatchFormat(format) {
+		return nil, fmt.Errorf("unsupported batch format %s, must be %s or %s", format, NDJSONFormat, ParquetFormat)
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultBatchFlushInterval
+	}
+	if flushSize <= 0 {
+		flushSize = DefaultBatchFlushSize
+	}
+	source, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the hostname: %w", err)
+	}
+	s := &BatchStore{
+		storePath:     logStorePath,
+		format:        format,
+		flushInterval: flushInterval,
+		flushSize:     flushSize,
+		provider:      provider,
+		log:           log,
+		source:        source,
+		batches:       map[string]*batch{},
+		stop:          make(chan struct{}),
+	}
+	s.stopped.Add(1)
+	go s.run()
+	return s, nil
+}
+
+func (s *BatchStore) run() {
+	defer s.stopped.Done()
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				s.log.Errorw("Failed to upload the log batches", zap.Error(err))
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *BatchStore) Store(logUrl *url.URL, logRequest LogRequest) error {
+	if logUrl == nil {
+		return errors.New("log url is invalid")
+	}
+	bucket, configPrefix, err := parseBlobStoreURL(logUrl.String(), s.log)
+	if err != nil {
+		return err
+	}
+	if bucket == "" {
+		return errors.New("no bucket specified in url")
+	}
+	prefix, err := getObjectPrefix(configPrefix, s.storePath, &logRequest)
+	if err != nil {
+		return err
+	}
+
+	record := newBatchRecord(logRequest)
+	s.mutex.Lock()
+	key := bucket + "/" + prefix
+	current, ok := s.batches[key]
+	if !ok {
+		current = &batch{bucket: bucket, prefix: prefix}
+		s.batches[key] = current
+	}
+	current.records = append(current.records, record)
+	current.size += len(record.Payload)
+	var full *batch
+	if current.size >= s.flushSize {
+		full = current
+		delete(s.batches, key)
+	}
+	s.mutex.Unlock()
+
+	if full != nil {
+		return s.upload(full)
+	}
+	return nil
+}
+
+// Flush uploads all the buffered batches
+func (s *BatchStore) Flush() error {
+	s.mutex.Lock()
+	batches := s.batches
+	s.batches = map[string]*batch{}
+	s.mutex.Unlock()
+
+	var errs []error
+	for _, pending := range batches {
+		if err := s.upload(pending); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops the periodic flushes and uploads the buffered batches
+func (s *BatchStore) Close() error {
+	close(s.stop)
+	s.stopped.Wait()
+	return s.Flush()
+}
+
+func (s *BatchStore) upload(pending *batch) error {
+	var value []byte
+	var err error
+	if s.format == ParquetFormat {
+		value, err = encodeParquet(pending.records)
+	} else {
+		value, err = encodeNDJSON(pending.records)
+	}
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.sequence++
+	objectKey := fmt.Sprintf("%s/%s-%s-%06d.%s", pending.prefix, time.Now().UTC().Format("20060102T150405Z"),
+		s.source, s.sequence, s.format)
+	s.mutex.Unlock()
+	if err := s.provider.UploadObject(pending.bucket, objectKey, value); err != nil {
+		return fmt.Errorf("failed to upload the %d log records to %s: %w", len(pending.records), objectKey, err)
+	}
+	s.log.Infof("Uploaded %d log records to %s", len(pending.records), objectKey)
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/parquet-go/parquet-go"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type uploadedObject struct {
+	bucket string
+	key    string
+	value  []byte
+}
+
+type fakeProvider struct {
+	mutex   sync.Mutex
+	objects []uploadedObject
+	err     error
+}
+
+func (p *fakeProvider) DownloadModel(_ string, _ string, _ string) error {
+	return nil
+}
+
+func (p *fakeProvider) UploadObject(bucket string, key string, object []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.objects = append(p.objects, uploadedObject{bucket: bucket, key: key, value: object})
+	return nil
+}
+
+func (p *fakeProvider) uploaded() []uploadedObject {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]uploadedObject{}, p.objects...)
+}
+
+func newLogRequest(id string, reqType string, payload []byte) LogRequest {
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	return LogRequest{
+		Bytes:            &payload,
+		ContentType:      "application/json",
+		ReqType:          reqType,
+		Id:               id,
+		SourceUri:        sourceUri,
+		InferenceService: "sklearn",
+		Namespace:        "default",
+		Component:        "predictor",
+		Endpoint:         "default",
+		Metadata:         map[string][]string{"Foo": {"Bar"}},
+	}
+}
+
+func TestBatchStoreNDJSON(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	log, _ := pkglogging.NewLogger("", "INFO")
+	provider := &fakeProvider{}
+	store, err := NewBatchStore("logger", NDJSONFormat, time.Hour, 0, provider, log)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	logUrl, _ := url.Parse("s3://bucket/prefix")
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceRequest, []byte(`{"instances":[[1,2]]}`)))).To(gomega.Succeed())
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceResponse, []byte("not json")))).To(gomega.Succeed())
+	// the requests are buffered until the flush
+	g.Expect(provider.uploaded()).To(gomega.BeEmpty())
+
+	g.Expect(store.Close()).To(gomega.Succeed())
+	objects := provider.uploaded()
+	g.Expect(objects).To(gomega.HaveLen(1))
+	g.Expect(objects[0].bucket).To(gomega.Equal("bucket"))
+	g.Expect(objects[0].key).To(gomega.MatchRegexp(`^prefix/default/sklearn/predictor/logger/\d{8}T\d{6}Z-.+-000001\.ndjson$`))
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(objects[0].value))
+	for scanner.Scan() {
+		line := map[string]interface{}{}
+		g.Expect(json.Unmarshal(scanner.Bytes(), &line)).To(gomega.Succeed())
+		lines = append(lines, line)
+	}
+	g.Expect(lines).To(gomega.HaveLen(2))
+	g.Expect(lines[0]).To(gomega.HaveKeyWithValue("id", "0123"))
+	g.Expect(lines[0]).To(gomega.HaveKeyWithValue("type", CEInferenceRequest))
+	g.Expect(lines[0]).To(gomega.HaveKeyWithValue("source", "http://localhost:9081/"))
+	g.Expect(lines[0]).To(gomega.HaveKeyWithValue("payload", map[string]interface{}{"instances": []interface{}{[]interface{}{1.0, 2.0}}}))
+	g.Expect(lines[1]).To(gomega.HaveKeyWithValue("payloadBase64", "bm90IGpzb24="))
+	g.Expect(lines[1]).ToNot(gomega.HaveKey("payload"))
+}
+
+func TestBatchStoreParquet(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	log, _ := pkglogging.NewLogger("", "INFO")
+	provider := &fakeProvider{}
+	// the batches are uploaded once their payloads reach 10 bytes
+	store, err := NewBatchStore("", ParquetFormat, time.Hour, 10, provider, log)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer store.Close()
+
+	logUrl, _ := url.Parse("gs://bucket")
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceRequest, []byte(`{"a":1}`)))).To(gomega.Succeed())
+	g.Expect(provider.uploaded()).To(gomega.BeEmpty())
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceResponse, []byte(`{"b":2}`)))).To(gomega.Succeed())
+
+	objects := provider.uploaded()
+	g.Expect(objects).To(gomega.HaveLen(1))
+	g.Expect(objects[0].key).To(gomega.MatchRegexp(`^default/sklearn/predictor/.+-000001\.parquet$`))
+	rows, err := parquet.Read[BatchRecord](bytes.NewReader(objects[0].value), int64(len(objects[0].value)))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(rows).To(gomega.HaveLen(2))
+	g.Expect(rows[0].Id).To(gomega.Equal("0123"))
+	g.Expect(rows[0].Type).To(gomega.Equal(CEInferenceRequest))
+	g.Expect(rows[0].Metadata).To(gomega.Equal(map[string][]string{"Foo": {"Bar"}}))
+	g.Expect(string(rows[1].Payload)).To(gomega.Equal(`{"b":2}`))
+}
+
+func TestBatchStoreFlushInterval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	log, _ := pkglogging.NewLogger("", "INFO")
+	provider := &fakeProvider{}
+	store, err := NewBatchStore("", NDJSONFormat, 50*time.Millisecond, 0, provider, log)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer store.Close()
+
+	logUrl, _ := url.Parse("s3://bucket")
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceRequest, []byte(`{}`)))).To(gomega.Succeed())
+	g.Eventually(provider.uploaded).Should(gomega.HaveLen(1))
+}
+
+func TestBatchStoreErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	log, _ := pkglogging.NewLogger("", "INFO")
+
+	_, err := NewBatchStore("", "json", time.Hour, 0, &fakeProvider{}, log)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported batch format json")))
+
+	provider := &fakeProvider{err: errors.New("access denied")}
+	store, err := NewBatchStore("", NDJSONFormat, time.Hour, 1, provider, log)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer store.Close()
+	g.Expect(store.Store(nil, LogRequest{})).To(gomega.MatchError(gomega.ContainSubstring("url")))
+	logUrl, _ := url.Parse("s3://")
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceRequest, []byte(`{}`)))).To(gomega.MatchError(gomega.ContainSubstring("bucket")))
+	logUrl, _ = url.Parse("s3://bucket")
+	g.Expect(store.Store(logUrl, newLogRequest("0123", CEInferenceRequest, []byte(`{}`)))).To(gomega.MatchError(gomega.ContainSubstring("access denied")))
+}
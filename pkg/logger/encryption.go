@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+
+	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
+)
+
+const (
+	// EncryptedContentType is the content type of the payloads of the logs once encrypted, an EncryptedPayload
+	EncryptedContentType = "application/vnd.kserve.encrypted+json"
+	// EncryptionAlgorithm encrypts the payloads with their data key
+	EncryptionAlgorithm = "AES-256-GCM"
+	// KMSKeyProvider encrypts the data keys with an AWS KMS key
+	KMSKeyProvider = "aws-kms"
+	// RSAKeyProvider encrypts the data keys with an RSA public key, using RSA-OAEP with SHA-256
+	RSAKeyProvider = "rsa-oaep-sha256"
+
+	// DataKeyMaxAge and DataKeyMaxPayloads bound the use of a data key, which is not generated for every payload to
+	// spare the KMS calls
+	DataKeyMaxAge      = 5 * time.Minute
+	DataKeyMaxPayloads = 10000
+
+	dataKeySize = 32
+)
+
+// PayloadEncryptor encrypts the payloads of the logs before they are sent, when set before the dispatcher is started
+var PayloadEncryptor *Encryptor
+
+// EncryptedPayload is the envelope of an encrypted payload, the payload is decrypted by decrypting the data key with
+// the KMS key or the private key first
+type EncryptedPayload struct {
+	Algorithm   string `json:"algorithm"`
+	KeyProvider string `json:"keyProvider"`
+	// KeyID is the ARN of the KMS key, or the SHA-256 fingerprint of the DER encoded public key
+	KeyID        string `json:"keyId"`
+	EncryptedKey []byte `json:"encryptedKey"`
+	Nonce        []byte `json:"nonce"`
+	Ciphertext   []byte `json:"ciphertext"`
+	// ContentType is the content type of the decrypted payload
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// dataKey is a data key along with its encrypted copy sent in the envelopes
+type dataKey struct {
+	aead         cipher.AEAD
+	encryptedKey []byte
+	keyID        string
+	created      time.Time
+	payloads     int
+}
+
+// keyEncrypter generates the data keys and encrypts them with the key encryption key
+type keyEncrypter interface {
+	provider() string
+	generate() (plaintext []byte, encrypted []byte, keyID string, err error)
+}
+
+// Encryptor envelope encrypts the payloads of the logs
+type Encryptor struct {
+	keys keyEncrypter
+	now  func() time.Time
+
+	mu  sync.Mutex
+	key *dataKey
+}
+
+// NewKMSEncryptor creates an encryptor generating the data keys with the AWS KMS key of the given ARN, id or alias.
+// The region of the key is the region of its ARN, or else the AWS_REGION.
+func NewKMSEncryptor(keyID string) (*Encryptor, error) {
+	config := aws.NewConfig()
+	if parsed, err := arn.Parse(keyID); err == nil {
+		config = config.WithRegion(parsed.Region)
+	} else if region, ok := os.LookupEnv(s3credential.AWSRegion); ok {
+		config = config.WithRegion(region)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("while creating the AWS session: %w", err)
+	}
+	return newEncryptor(&kmsKeys{client: kms.New(sess), keyID: keyID}), nil
+}
+
+// NewPublicKeyEncryptor creates an encryptor encrypting the data keys with the PEM encoded RSA public key of the file
+func NewPublicKeyEncryptor(path string) (*Encryptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("while reading the public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("the public key is not PEM encoded")
+	}
+	var publicKey any
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the public key: %w", err)
+	}
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("the public key must be an RSA key, got %T", publicKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(rsaKey)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := sha256.Sum256(der)
+	return newEncryptor(&rsaKeys{publicKey: rsaKey, keyID: hex.EncodeToString(fingerprint[:])}), nil
+}
+
+func newEncryptor(keys keyEncrypter) *Encryptor {
+	return &Encryptor{keys: keys, now: time.Now}
+}
+
+// Encrypt returns the log request with its payload replaced by the envelope of the encrypted payload
+func (e *Encryptor) Encrypt(logRequest LogRequest) (LogRequest, error) {
+	if logRequest.Bytes == nil {
+		return logRequest, nil
+	}
+	key, err := e.dataKey()
+	if err != nil {
+		return logRequest, err
+	}
+	nonce := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return logRequest, err
+	}
+	envelope, err := json.Marshal(EncryptedPayload{
+		Algorithm:    EncryptionAlgorithm,
+		KeyProvider:  e.keys.provider(),
+		KeyID:        key.keyID,
+		EncryptedKey: key.encryptedKey,
+		Nonce:        nonce,
+		Ciphertext:   key.aead.Seal(nil, nonce, *logRequest.Bytes, nil),
+		ContentType:  logRequest.ContentType,
+	})
+	if err != nil {
+		return logRequest, err
+	}
+	logRequest.Bytes = &envelope
+	logRequest.ContentType = EncryptedContentType
+	return logRequest, nil
+}
+
+// dataKey returns the current data key, generating a new one once the current one is too old or too used
+func (e *Encryptor) dataKey() (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.key != nil && e.now().Sub(e.key.created) < DataKeyMaxAge && e.key.payloads < DataKeyMaxPayloads {
+		e.key.payloads++
+		return e.key, nil
+	}
+	plaintext, encrypted, keyID, err := e.keys.generate()
+	if err != nil {
+		return nil, fmt.Errorf("while generating the data key: %w", err)
+	}
+	block, err := aes.NewCipher(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	e.key = &dataKey{aead: aead, encryptedKey: encrypted, keyID: keyID, created: e.now(), payloads: 1}
+	return e.key, nil
+}
+
+// kmsKeys generates the data keys with an AWS KMS key
+type kmsKeys struct {
+	client kmsiface.KMSAPI
+	keyID  string
+}
+
+func (k *kmsKeys) provider() string {
+	return KMSKeyProvider
+}
+
+func (k *kmsKeys) generate() ([]byte, []byte, string, error) {
+	output, err := k.client.GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String(k.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return output.Plaintext, output.CiphertextBlob, aws.StringValue(output.KeyId), nil
+}
+
+// rsaKeys generates the data keys locally and encrypts them with an RSA public key
+type rsaKeys struct {
+	publicKey *rsa.PublicKey
+	keyID     string
+}
+
+func (k *rsaKeys) provider() string {
+	return RSAKeyProvider
+}
+
+func (k *rsaKeys) generate() ([]byte, []byte, string, error) {
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, "", err
+	}
+	encrypted, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, k.publicKey, plaintext, nil)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return plaintext, encrypted, k.keyID, nil
+}
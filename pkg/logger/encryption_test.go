@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/onsi/gomega"
+)
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	calls int
+	err   error
+}
+
+func (f *fakeKMS) GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	plaintext := make([]byte, 32)
+	_, _ = rand.Read(plaintext)
+	return &kms.GenerateDataKeyOutput{
+		KeyId:          aws.String("arn:aws:kms:us-west-2:111122223333:key/" + *input.KeyId),
+		Plaintext:      plaintext,
+		CiphertextBlob: append([]byte("wrapped:"), plaintext...),
+	}, nil
+}
+
+// decryptPayload decrypts the envelope with the plaintext data key
+func decryptPayload(t *testing.T, envelope EncryptedPayload, key []byte) []byte {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plaintext
+}
+
+func TestPublicKeyEncryptor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	path := filepath.Join(t.TempDir(), "public-key.pem")
+	g.Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)).To(gomega.Succeed())
+
+	encryptor, err := NewPublicKeyEncryptor(path)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	payload := []byte(`{"instances":[[1,2,3]]}`)
+	encrypted, err := encryptor.Encrypt(LogRequest{Id: "1", Bytes: &payload, ContentType: "application/json"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(encrypted.ContentType).To(gomega.Equal(EncryptedContentType))
+	g.Expect(string(*encrypted.Bytes)).NotTo(gomega.ContainSubstring("instances"))
+
+	envelope := EncryptedPayload{}
+	g.Expect(json.Unmarshal(*encrypted.Bytes, &envelope)).To(gomega.Succeed())
+	g.Expect(envelope.Algorithm).To(gomega.Equal(EncryptionAlgorithm))
+	g.Expect(envelope.KeyProvider).To(gomega.Equal(RSAKeyProvider))
+	g.Expect(envelope.KeyID).To(gomega.HaveLen(64))
+	g.Expect(envelope.ContentType).To(gomega.Equal("application/json"))
+	key, err := rsa.DecryptOAEP(sha256.New(), nil, privateKey, envelope.EncryptedKey, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(decryptPayload(t, envelope, key)).To(gomega.Equal(payload))
+
+	// the original payload is left untouched
+	g.Expect(string(payload)).To(gomega.Equal(`{"instances":[[1,2,3]]}`))
+}
+
+func TestKMSEncryptorReusesDataKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client := &fakeKMS{}
+	encryptor := newEncryptor(&kmsKeys{client: client, keyID: "payload-logs"})
+	now := time.Now()
+	encryptor.now = func() time.Time { return now }
+
+	envelopes := make([]EncryptedPayload, 0, 3)
+	for range 2 {
+		payload := []byte(`{"predictions":[1]}`)
+		encrypted, err := encryptor.Encrypt(LogRequest{Bytes: &payload})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		envelope := EncryptedPayload{}
+		g.Expect(json.Unmarshal(*encrypted.Bytes, &envelope)).To(gomega.Succeed())
+		envelopes = append(envelopes, envelope)
+	}
+	g.Expect(client.calls).To(gomega.Equal(1))
+	g.Expect(envelopes[0].EncryptedKey).To(gomega.Equal(envelopes[1].EncryptedKey))
+	g.Expect(envelopes[0].Nonce).NotTo(gomega.Equal(envelopes[1].Nonce))
+	g.Expect(envelopes[0].KeyProvider).To(gomega.Equal(KMSKeyProvider))
+	g.Expect(envelopes[0].KeyID).To(gomega.Equal("arn:aws:kms:us-west-2:111122223333:key/payload-logs"))
+	key := envelopes[0].EncryptedKey[len("wrapped:"):]
+	g.Expect(decryptPayload(t, envelopes[1], key)).To(gomega.Equal([]byte(`{"predictions":[1]}`)))
+
+	// a new data key is generated once the current one expired
+	now = now.Add(DataKeyMaxAge)
+	payload := []byte(`{}`)
+	encrypted, err := encryptor.Encrypt(LogRequest{Bytes: &payload})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	envelope := EncryptedPayload{}
+	g.Expect(json.Unmarshal(*encrypted.Bytes, &envelope)).To(gomega.Succeed())
+	g.Expect(client.calls).To(gomega.Equal(2))
+	g.Expect(envelope.EncryptedKey).NotTo(gomega.Equal(envelopes[0].EncryptedKey))
+}
+
+func TestEncryptorErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	encryptor := newEncryptor(&kmsKeys{client: &fakeKMS{err: errors.New("access denied")}, keyID: "payload-logs"})
+	payload := []byte(`{}`)
+	_, err := encryptor.Encrypt(LogRequest{Bytes: &payload})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("access denied")))
+
+	path := filepath.Join(t.TempDir(), "public-key.pem")
+	g.Expect(os.WriteFile(path, []byte("not a key"), 0o600)).To(gomega.Succeed())
+	_, err = NewPublicKeyEncryptor(path)
+	g.Expect(err).To(gomega.MatchError("the public key is not PEM encoded"))
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces the values of the redacted fields of the payloads
+const Redacted = "[REDACTED]"
+
+// statusCodeRegex matches a status code or a class of status codes, e.g. 404 or 5xx
+var statusCodeRegex = regexp.MustCompile(`^[1-5]([0-9]{2}|xx)$`)
+
+// Filter samples and filters the requests logged by the LoggerHandler and redacts the JSON fields of their payloads.
+// A nil Filter logs all the requests.
+type Filter struct {
+	samplePercent int
+	// errorSamplePercent is negative when the requests answered with an error are sampled at the sample percent
+	errorSamplePercent int
+	// headers maps the canonical names of the filtered headers to the values they must have one of, the headers
+	// without values only need to be present
+	headers      map[string][]string
+	statusCodes  []string
+	redactFields [][]string
+	random       func() float64
+}
+
+// NewFilter returns a filter logging the sample percent of the requests, or the error sample percent of the requests
+// answered with an error when it is not negative. The header filters are name=value or name pairs, a request must
+// match all the names and one of the values of each name. The status codes, e.g. 200 or 5xx, restrict the responses
+// logged and the redact fields are dot separated paths of the JSON fields of the payloads.
+func NewFilter(samplePercent int, errorSamplePercent int, headerFilters []string, statusCodes []string, redactFields []string) (*Filter, error) {
+	if samplePercent < 0 || samplePercent > 100 {
+		return nil, fmt.Errorf("the sample percent %d must be between 0 and 100", samplePercent)
+	}
+	if errorSamplePercent > 100 {
+		return nil, fmt.Errorf("the error sample percent %d must be at most 100", errorSamplePercent)
+	}
+	filter := &Filter{
+		samplePercent:      samplePercent,
+		errorSamplePercent: errorSamplePercent,
+		headers:            map[string][]string{},
+		random:             rand.Float64,
+	}
+	for _, headerFilter := range headerFilters {
+		name, value, hasValue := strings.Cut(headerFilter, "=")
+		if name == "" {
+			return nil, fmt.Errorf("the header filter %q has no header name", headerFilter)
+		}
+		name = http.CanonicalHeaderKey(name)
+		values := filter.headers[name]
+		if hasValue {
+			values = append(values, value)
+		}
+		filter.headers[name] = values
+	}
+	for _, statusCode := range statusCodes {
+		if !statusCodeRegex.MatchString(strings.ToLower(statusCode)) {
+			return nil, fmt.Errorf("the status code %q must be a status code or a class of status codes, e.g. 404 or 5xx", statusCode)
+		}
+		filter.statusCodes = append(filter.statusCodes, strings.ToLower(statusCode))
+	}
+	for _, field := range redactFields {
+		path := strings.Split(field, ".")
+		if slices.Contains(path, "") {
+			return nil, fmt.Errorf("the redacted field %q must be a dot separated path", field)
+		}
+		filter.redactFields = append(filter.redactFields, path)
+	}
+	return filter, nil
+}
+
+// MatchHeaders returns whether the headers of a request match the header filters
+func (f *Filter) MatchHeaders(header http.Header) bool {
+	if f == nil {
+		return true
+	}
+	for name, values := range f.headers {
+		actual := header.Values(name)
+		if len(actual) == 0 {
+			return false
+		}
+		if len(values) > 0 && !slices.ContainsFunc(actual, func(value string) bool { return slices.Contains(values, value) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ByStatusCode returns whether the requests are logged depending on the status codes of their responses, they are
+// then logged once the response is known and the responses of every status code are logged
+func (f *Filter) ByStatusCode() bool {
+	return f != nil && (len(f.statusCodes) > 0 || f.errorSamplePercent >= 0)
+}
+
+// Sample returns whether a request answered with the status code is logged, the status code is ignored unless the
+// filter samples by status code
+func (f *Filter) Sample(statusCode int) bool {
+	if f == nil {
+		return true
+	}
+	percent := f.samplePercent
+	if f.ByStatusCode() {
+		if len(f.statusCodes) > 0 && !f.matchStatusCode(statusCode) {
+			return false
+		}
+		if f.errorSamplePercent >= 0 && statusCode >= http.StatusBadRequest {
+			percent = f.errorSamplePercent
+		}
+	}
+	return f.random()*100 < float64(percent)
+}
+
+func (f *Filter) matchStatusCode(statusCode int) bool {
+	code := strconv.Itoa(statusCode)
+	for _, pattern := range f.statusCodes {
+		if pattern == code || (strings.HasSuffix(pattern, "xx") && pattern[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns the payload with the values of the redacted fields replaced, the payloads which are not JSON or have
+// none of the redacted fields are returned as they are
+func (f *Filter) Redact(payload []byte) []byte {
+	if f == nil || len(f.redactFields) == 0 || len(payload) == 0 {
+		return payload
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return payload
+	}
+	redacted := false
+	for _, path := range f.redactFields {
+		if redactPath(value, path) {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return payload
+	}
+	result, err := json.Marshal(value)
+	if err != nil {
+		return payload
+	}
+	return result
+}
+
+// redactPath replaces the values at the path, traversing the arrays, and returns whether any value was replaced
+func redactPath(value interface{}, path []string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		field, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) == 1 {
+			v[path[0]] = Redacted
+			return true
+		}
+		return redactPath(field, path[1:])
+	case []interface{}:
+		redacted := false
+		for _, item := range v {
+			if redactPath(item, path) {
+				redacted = true
+			}
+		}
+		return redacted
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatchHeaders(t *testing.T) {
+	filter, err := NewFilter(100, -1, []string{"x-tenant=a", "X-Tenant=b", "X-Debug"}, nil, nil)
+	require.NoError(t, err)
+
+	assert.True(t, filter.MatchHeaders(http.Header{"X-Tenant": {"b"}, "X-Debug": {""}}))
+	assert.False(t, filter.MatchHeaders(http.Header{"X-Tenant": {"c"}, "X-Debug": {"true"}}))
+	assert.False(t, filter.MatchHeaders(http.Header{"X-Tenant": {"a"}}))
+
+	var noFilter *Filter
+	assert.True(t, noFilter.MatchHeaders(http.Header{}))
+	assert.True(t, noFilter.Sample(http.StatusInternalServerError))
+	assert.False(t, noFilter.ByStatusCode())
+}
+
+func TestFilterSample(t *testing.T) {
+	scenarios := map[string]struct {
+		samplePercent      int
+		errorSamplePercent int
+		statusCodes        []string
+		statusCode         int
+		random             float64
+		byStatusCode       bool
+		sampled            bool
+	}{
+		"sampled request": {
+			samplePercent: 10, errorSamplePercent: -1, random: 0.05, sampled: true,
+		},
+		"request not sampled": {
+			samplePercent: 10, errorSamplePercent: -1, random: 0.5, sampled: false,
+		},
+		"successful request at the sample percent": {
+			samplePercent: 1, errorSamplePercent: 100, statusCode: http.StatusOK, random: 0.5, byStatusCode: true, sampled: false,
+		},
+		"error at the error sample percent": {
+			samplePercent: 1, errorSamplePercent: 100, statusCode: http.StatusServiceUnavailable, random: 0.5, byStatusCode: true, sampled: true,
+		},
+		"status code class matching": {
+			samplePercent: 100, errorSamplePercent: -1, statusCodes: []string{"200", "5XX"}, statusCode: http.StatusBadGateway, byStatusCode: true, sampled: true,
+		},
+		"status code not matching": {
+			samplePercent: 100, errorSamplePercent: -1, statusCodes: []string{"200", "5xx"}, statusCode: http.StatusNotFound, byStatusCode: true, sampled: false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			filter, err := NewFilter(scenario.samplePercent, scenario.errorSamplePercent, nil, scenario.statusCodes, nil)
+			require.NoError(t, err)
+			filter.random = func() float64 { return scenario.random }
+			assert.Equal(t, scenario.byStatusCode, filter.ByStatusCode())
+			assert.Equal(t, scenario.sampled, filter.Sample(scenario.statusCode))
+		})
+	}
+}
+
+func TestFilterRedact(t *testing.T) {
+	filter, err := NewFilter(100, -1, nil, nil, []string{"instances.ssn", "parameters.token", "missing.field"})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"instances":[{"ssn":"[REDACTED]","age":42},{"age":7}],"parameters":{"token":"[REDACTED]","temperature":0.1}}`,
+		string(filter.Redact([]byte(`{"instances":[{"ssn":"123","age":42},{"age":7}],"parameters":{"token":"abc","temperature":0.1}}`))))
+	// the payloads without the redacted fields or which are not JSON are not changed
+	assert.Equal(t, `{"inputs": [1, 2]}`, string(filter.Redact([]byte(`{"inputs": [1, 2]}`))))
+	assert.Equal(t, "not json", string(filter.Redact([]byte("not json"))))
+}
+
+func TestNewFilterInvalid(t *testing.T) {
+	_, err := NewFilter(101, -1, nil, nil, nil)
+	require.Error(t, err)
+	_, err = NewFilter(100, -1, []string{"=a"}, nil, nil)
+	require.Error(t, err)
+	_, err = NewFilter(100, -1, nil, []string{"6xx"}, nil)
+	require.Error(t, err)
+	_, err = NewFilter(100, -1, nil, nil, []string{"instances..ssn"})
+	require.Error(t, err)
+}
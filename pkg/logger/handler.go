@@ -86,11 +86,12 @@ type LoggerHandler struct {
 	annotations      map[string]string
 	certName         string
 	tlsSkipVerify    bool
+	filter           *Filter
 }
 
 func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 	inferenceService string, namespace string, endpoint string, component string, next http.Handler, metadataHeaders []string,
-	certName string, annotations map[string]string, tlsSkipVerify bool,
+	certName string, annotations map[string]string, tlsSkipVerify bool, filter *Filter,
 ) http.Handler {
 	logf.SetLogger(zap.New())
 	return &LoggerHandler{
@@ -107,17 +108,24 @@ func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 		metadataHeaders:  metadataHeaders,
 		certName:         certName,
 		tlsSkipVerify:    tlsSkipVerify,
+		filter:           filter,
 	}
 }
 
 // call svc and add send request/responses to logUrl
 func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if network.IsKubeletProbe(r) {
+	if network.IsKubeletProbe(r) || !eh.filter.MatchHeaders(r.Header) {
 		if eh.next != nil {
 			eh.next.ServeHTTP(w, r)
 		}
 		return
 	}
+	// The requests sampled by status code are only logged once their response is known
+	byStatusCode := eh.filter.ByStatusCode()
+	if !byStatusCode && !eh.filter.Sample(0) {
+		eh.next.ServeHTTP(w, r)
+		return
+	}
 	// Read request payload
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -139,11 +147,11 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Get or Create an ID
 	id := getOrCreateID(r)
 	contentType := r.Header.Get("Content-Type")
-	// log Request
-	if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest {
+	logRequest := func() {
+		requestPayload := eh.filter.Redact(body)
 		if err := QueueLogRequest(LogRequest{
 			Url:              eh.logUrl,
-			Bytes:            &body,
+			Bytes:            &requestPayload,
 			ContentType:      contentType,
 			ReqType:          CEInferenceRequest,
 			Id:               id,
@@ -160,6 +168,10 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			eh.log.Error(err, "Failed to log request")
 		}
 	}
+	logRequests := eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest
+	if logRequests && !byStatusCode {
+		logRequest()
+	}
 
 	// Proxy Request
 	r.Body = io.NopCloser(bytes.NewBuffer(body))
@@ -173,12 +185,25 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		eh.log.Error(err, "Failed to read response body")
 	}
+	if byStatusCode {
+		statusCode := lrw.statusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		if !eh.filter.Sample(statusCode) {
+			return
+		}
+		if logRequests {
+			logRequest()
+		}
+	}
 	// log Response
-	if lrw.statusCode == http.StatusOK {
+	if lrw.statusCode == http.StatusOK || byStatusCode {
 		if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse {
+			responsePayload := eh.filter.Redact(responseBody)
 			if err := QueueLogRequest(LogRequest{
 				Url:              eh.logUrl,
-				Bytes:            &responseBody,
+				Bytes:            &responsePayload,
 				ContentType:      contentType,
 				ReqType:          CEInferenceResponse,
 				Id:               id,
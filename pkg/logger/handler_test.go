@@ -79,7 +79,7 @@ func TestLogger(t *testing.T) {
 	StartDispatcher(5, &MockStore{}, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", nil, true)
+		"default", httpProxy, nil, "", nil, true, nil)
 
 	oh.ServeHTTP(w, r)
 
@@ -151,7 +151,7 @@ func TestLoggerWithMetadata(t *testing.T) {
 	StartDispatcher(5, &MockStore{}, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, []string{"Foo", "Fizz"}, "", nil, true)
+		"default", httpProxy, []string{"Foo", "Fizz"}, "", nil, true, nil)
 
 	oh.ServeHTTP(w, r)
 
@@ -223,7 +223,7 @@ func TestLoggerWithAnnotation(t *testing.T) {
 	StartDispatcher(5, &MockStore{}, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", map[string]string{"Foo": "Bar", "Fizz": "Buzz"}, true)
+		"default", httpProxy, nil, "", map[string]string{"Foo": "Bar", "Fizz": "Buzz"}, true, nil)
 
 	oh.ServeHTTP(w, r)
 
@@ -268,7 +268,7 @@ func TestBadResponse(t *testing.T) {
 	StartDispatcher(1, &MockStore{}, logger)
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, nil, "", nil, true)
+		"default", httpProxy, nil, "", nil, true, nil)
 
 	oh.ServeHTTP(w, r)
 	g.Expect(w.Code).To(gomega.Equal(400))
@@ -338,7 +338,7 @@ func TestLoggerWithS3Store(t *testing.T) {
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 
 	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
-		"default", httpProxy, []string{"Foo"}, "", map[string]string{"test-annotation": "test-value"}, true)
+		"default", httpProxy, []string{"Foo"}, "", map[string]string{"test-annotation": "test-value"}, true, nil)
 
 	oh.ServeHTTP(w, r)
 
@@ -368,3 +368,53 @@ func TestLoggerWithS3Store(t *testing.T) {
 	g.Expect(res.Annotations).To(gomega.HaveLen(1))
 	g.Expect(res.Annotations["test-annotation"]).To(gomega.Equal("test-value"))
 }
+
+func TestLoggerFilter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictor := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Fail") != "" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			_, _ = rw.Write([]byte(`{"error":"failed","token":"abc"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions":[1]}`))
+	})
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	store := NewMockStore(nil)
+	StartDispatcher(5, store, logger)
+	logSvcUrl, err := url.Parse("s3://bucket")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// none of the successful requests and all the errors of the tenant are logged
+	filter, err := NewFilter(0, 100, []string{"X-Tenant=a"}, nil, []string{"instances.ssn", "token"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default",
+		"default", predictor, nil, "", nil, true, filter)
+
+	serve := func(headers map[string]string) int {
+		r := httptest.NewRequest(http.MethodPost, "http://a", bytes.NewReader([]byte(`{"instances":[{"ssn":"123","age":42}]}`)))
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		oh.ServeHTTP(w, r)
+		return w.Code
+	}
+	g.Expect(serve(map[string]string{"X-Tenant": "a"})).To(gomega.Equal(http.StatusOK))
+	g.Expect(serve(map[string]string{"X-Tenant": "b", "X-Fail": "true"})).To(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(store.ResponseChan).ToNot(gomega.Receive())
+
+	g.Expect(serve(map[string]string{"X-Tenant": "a", "X-Fail": "true"})).To(gomega.Equal(http.StatusInternalServerError))
+	req := <-store.ResponseChan
+	res := <-store.ResponseChan
+	if req.ReqType != CEInferenceRequest {
+		req, res = res, req
+	}
+	g.Expect(req.ReqType).To(gomega.Equal(CEInferenceRequest))
+	g.Expect(string(*req.Bytes)).To(gomega.Equal(`{"instances":[{"age":42,"ssn":"[REDACTED]"}]}`))
+	g.Expect(res.ReqType).To(gomega.Equal(CEInferenceResponse))
+	g.Expect(string(*res.Bytes)).To(gomega.Equal(`{"error":"failed","token":"[REDACTED]"}`))
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/tlspolicy"
+)
+
+const (
+	// KafkaScheme is the scheme of the kafka://broker1:9092,broker2:9092/topic log URLs
+	KafkaScheme = "kafka"
+	// KafkaProduceTimeout bounds the time a worker waits for the brokers to acknowledge a record
+	KafkaProduceTimeout = 30 * time.Second
+)
+
+// Keys of the secret the Kafka SASL and TLS settings are read from, the ones of the Knative KafkaSink secrets
+const (
+	KafkaProtocolKey      = "protocol"
+	KafkaSASLMechanismKey = "sasl.mechanism"
+	KafkaUserKey          = "user"
+	KafkaPasswordKey      = "password"
+	KafkaCACertKey        = "ca.crt"
+	KafkaUserCertKey      = "user.crt"
+	KafkaUserKeyKey       = "user.key"
+)
+
+// Security protocols of the Kafka brokers
+const (
+	KafkaProtocolPlaintext     = "PLAINTEXT"
+	KafkaProtocolSASLPlaintext = "SASL_PLAINTEXT"
+	KafkaProtocolSSL           = "SSL"
+	KafkaProtocolSASLSSL       = "SASL_SSL"
+)
+
+// SASL mechanisms of the Kafka brokers
+const (
+	KafkaSASLPlain       = "PLAIN"
+	KafkaSASLScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// The record headers carrying the cloud event attributes, following the binary content mode of the CloudEvents
+// Kafka protocol binding
+const (
+	kafkaCEHeaderPrefix     = "ce_"
+	kafkaContentTypeHeader  = "content-type"
+	kafkaDefaultContentType = "application/json"
+)
+
+// kafkaProducer is the part of the franz-go client used by the KafkaStore
+type kafkaProducer interface {
+	ProduceSync(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults
+	Close()
+}
+
+// KafkaStore produces the log requests to a Kafka topic, one record per request keyed by the request id so that the
+// request and the response of an inference land in the same partition
+type KafkaStore struct {
+	topic    string
+	producer kafkaProducer
+	log      *zap.SugaredLogger
+}
+
+var _ Store = &KafkaStore{}
+
+// ParseKafkaURL returns the brokers and the topic of a kafka://broker1:9092,broker2:9092/topic log URL
+func ParseKafkaURL(logUrl *url.URL) ([]string, string, error) {
+	if logUrl == nil || logUrl.Scheme != KafkaScheme {
+		return nil, "", fmt.Errorf("log url %v is not a %s url", logUrl, KafkaScheme)
+	}
+	var brokers []string
+	for _, broker := range strings.Split(logUrl.Host, ",") {
+		if broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return nil, "", fmt.Errorf("no broker specified in the log url %s", logUrl)
+	}
+	topic := strings.Trim(logUrl.Path, "/")
+	if topic == "" || strings.Contains(topic, "/") {
+		return nil, "", fmt.Errorf("the log url %s must specify a single topic", logUrl)
+	}
+	return brokers, topic, nil
+}
+
+// NewKafkaStore connects to the brokers of the log URL, with the SASL and TLS settings read from the files of the
+// mounted secret directory when set
+func NewKafkaStore(logUrl *url.URL, secretDir string, tlsPolicy *tlspolicy.Policy, log *zap.SugaredLogger) (*KafkaStore, error) {
+	brokers, topic, err := ParseKafkaURL(logUrl)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := kafkaClientOptions(secretDir, tlsPolicy)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, kgo.SeedBrokers(brokers...), kgo.DefaultProduceTopic(topic))
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the kafka client: %w", err)
+	}
+	return &KafkaStore{topic: topic, producer: client, log: log}, nil
+}
+
+func (s *KafkaStore) Store(logUrl *url.URL, logRequest LogRequest) error {
+	if logUrl == nil {
+		return errors.New("log url is invalid")
+	}
+	record, err := newKafkaRecord(s.topic, logRequest)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), KafkaProduceTimeout)
+	defer cancel()
+	if err := s.producer.ProduceSync(ctx, record).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce the log record to the kafka topic %s: %w", s.topic, err)
+	}
+	s.log.Infof("Produced the log record %s to the kafka topic %s", logRequest.Id, s.topic)
+	return nil
+}
+
+// Close flushes the buffered records and closes the connections to the brokers
+func (s *KafkaStore) Close() {
+	s.producer.Close()
+}
+
+// newKafkaRecord encodes the log request as a cloud event in the binary content mode: the payload is the value of
+// the record and the attributes are its ce_ headers
+func newKafkaRecord(topic string, logRequest LogRequest) (*kgo.Record, error) {
+	contentType := logRequest.ContentType
+	if contentType == "" {
+		contentType = kafkaDefaultContentType
+	}
+	attributes := [][2]string{
+		{"specversion", cloudevents.VersionV1},
+		{"id", logRequest.Id},
+		{"type", logRequest.ReqType},
+		{"time", time.Now().UTC().Format(time.RFC3339Nano)},
+		{InferenceServiceAttr, logRequest.InferenceService},
+		{NamespaceAttr, logRequest.Namespace},
+		{ComponentAttr, logRequest.Component},
+		{EndpointAttr, logRequest.Endpoint},
+	}
+	if logRequest.SourceUri != nil {
+		attributes = append(attributes, [2]string{"source", logRequest.SourceUri.String()})
+	}
+	encodedMetadata, err := json.Marshal(logRequest.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode metadata as json: %w", err)
+	}
+	attributes = append(attributes, [2]string{MetadataAttr, string(encodedMetadata)})
+	if len(logRequest.Annotations) > 0 {
+		encodedAnnotations, err := json.Marshal(logRequest.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode annotations as json: %w", err)
+		}
+		attributes = append(attributes, [2]string{AnnotationAttr, string(encodedAnnotations)})
+	}
+
+	headers := make([]kgo.RecordHeader, 0, len(attributes)+1)
+	for _, attribute := range attributes {
+		headers = append(headers, kgo.RecordHeader{Key: kafkaCEHeaderPrefix + attribute[0], Value: []byte(attribute[1])})
+	}
+	headers = append(headers, kgo.RecordHeader{Key: kafkaContentTypeHeader, Value: []byte(contentType)})
+
+	var value []byte
+	if logRequest.Bytes != nil {
+		value = *logRequest.Bytes
+	}
+	return &kgo.Record{
+		Topic:   topic,
+		Key:     []byte(logRequest.Id),
+		Value:   value,
+		Headers: headers,
+	}, nil
+}
+
+// kafkaClientOptions returns the SASL and TLS options of the Kafka client from the files of the secret directory,
+// PLAINTEXT when the directory is not set
+func kafkaClientOptions(secretDir string, tlsPolicy *tlspolicy.Policy) ([]kgo.Opt, error) {
+	if secretDir == "" {
+		return nil, nil
+	}
+	read := func(key string) (string, error) {
+		value, err := os.ReadFile(filepath.Join(secretDir, key))
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return strings.TrimSpace(string(value)), err
+	}
+
+	protocol, err := read(KafkaProtocolKey)
+	if err != nil {
+		return nil, err
+	}
+	if protocol == "" {
+		protocol = KafkaProtocolPlaintext
+	}
+	var useSASL, useTLS bool
+	switch protocol {
+	case KafkaProtocolPlaintext:
+	case KafkaProtocolSASLPlaintext:
+		useSASL = true
+	case KafkaProtocolSSL:
+		useTLS = true
+	case KafkaProtocolSASLSSL:
+		useSASL, useTLS = true, true
+	default:
+		return nil, fmt.Errorf("unsupported kafka protocol %s, must be one of %s, %s, %s or %s", protocol,
+			KafkaProtocolPlaintext, KafkaProtocolSASLPlaintext, KafkaProtocolSSL, KafkaProtocolSASLSSL)
+	}
+
+	var opts []kgo.Opt
+	if useSASL {
+		mechanism, err := kafkaSASLMechanism(read)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+	if useTLS {
+		tlsConfig, err := kafkaTLSConfig(read)
+		if err != nil {
+			return nil, err
+		}
+		tlsPolicy.Apply(tlsConfig)
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+	return opts, nil
+}
+
+func kafkaSASLMechanism(read func(key string) (string, error)) (sasl.Mechanism, error) {
+	values := map[string]string{}
+	for _, key := range []string{KafkaSASLMechanismKey, KafkaUserKey, KafkaPasswordKey} {
+		value, err := read(key)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	user, password := values[KafkaUserKey], values[KafkaPasswordKey]
+	if user == "" || password == "" {
+		return nil, fmt.Errorf("the kafka secret must set the %s and %s keys for SASL", KafkaUserKey, KafkaPasswordKey)
+	}
+	switch values[KafkaSASLMechanismKey] {
+	case "", KafkaSASLPlain:
+		return plain.Auth{User: user, Pass: password}.AsMechanism(), nil
+	case KafkaSASLScramSHA256:
+		return scram.Auth{User: user, Pass: password}.AsSha256Mechanism(), nil
+	case KafkaSASLScramSHA512:
+		return scram.Auth{User: user, Pass: password}.AsSha512Mechanism(), nil
+	default:
+		return nil, fmt.Errorf("unsupported kafka SASL mechanism %s, must be one of %s, %s or %s",
+			values[KafkaSASLMechanismKey], KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512)
+	}
+}
+
+func kafkaTLSConfig(read func(key string) (string, error)) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	caCert, err := read(KafkaCACertKey)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("failed to parse the %s of the kafka secret", KafkaCACertKey)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	userCert, err := read(KafkaUserCertKey)
+	if err != nil {
+		return nil, err
+	}
+	userKey, err := read(KafkaUserKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	if userCert != "" || userKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(userCert), []byte(userKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the %s and %s of the kafka secret: %w", KafkaUserCertKey, KafkaUserKeyKey, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
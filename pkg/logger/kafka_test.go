@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/twmb/franz-go/pkg/kgo"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type fakeKafkaProducer struct {
+	records []*kgo.Record
+	err     error
+}
+
+func (p *fakeKafkaProducer) ProduceSync(_ context.Context, records ...*kgo.Record) kgo.ProduceResults {
+	results := make(kgo.ProduceResults, 0, len(records))
+	for _, record := range records {
+		p.records = append(p.records, record)
+		results = append(results, kgo.ProduceResult{Record: record, Err: p.err})
+	}
+	return results
+}
+
+func (p *fakeKafkaProducer) Close() {}
+
+func TestParseKafkaURL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logUrl, err := url.Parse("kafka://kafka-0.kafka:9092,kafka-1.kafka:9092/payloads")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	brokers, topic, err := ParseKafkaURL(logUrl)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(brokers).To(gomega.Equal([]string{"kafka-0.kafka:9092", "kafka-1.kafka:9092"}))
+	g.Expect(topic).To(gomega.Equal("payloads"))
+	g.Expect(GetStorageStrategy(logUrl.String())).To(gomega.Equal(KafkaStorage))
+
+	for _, invalid := range []string{"http://kafka:9092/payloads", "kafka:///payloads", "kafka://kafka:9092", "kafka://kafka:9092/a/b"} {
+		logUrl, err := url.Parse(invalid)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, _, err = ParseKafkaURL(logUrl)
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
+
+func TestKafkaStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	log, _ := pkglogging.NewLogger("", "INFO")
+	producer := &fakeKafkaProducer{}
+	store := &KafkaStore{topic: "payloads", producer: producer, log: log}
+
+	logUrl, _ := url.Parse("kafka://kafka:9092/payloads")
+	sourceUri, _ := url.Parse("http://localhost:9081/")
+	payload := []byte(`{"instances":[[1,2]]}`)
+	err := store.Store(logUrl, LogRequest{
+		Url:              logUrl,
+		Bytes:            &payload,
+		ContentType:      "application/json",
+		ReqType:          CEInferenceRequest,
+		Id:               "0123",
+		SourceUri:        sourceUri,
+		InferenceService: "sklearn",
+		Namespace:        "default",
+		Component:        "predictor",
+		Endpoint:         "default",
+		Metadata:         map[string][]string{"Foo": {"Bar"}},
+		Annotations:      map[string]string{"team": "ml"},
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(producer.records).To(gomega.HaveLen(1))
+
+	record := producer.records[0]
+	g.Expect(record.Topic).To(gomega.Equal("payloads"))
+	g.Expect(string(record.Key)).To(gomega.Equal("0123"))
+	g.Expect(record.Value).To(gomega.Equal(payload))
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_specversion", "1.0"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_id", "0123"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_type", CEInferenceRequest))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_source", "http://localhost:9081/"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+InferenceServiceAttr, "sklearn"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+MetadataAttr, `{"Foo":["Bar"]}`))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_"+AnnotationAttr, `{"team":"ml"}`))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("content-type", "application/json"))
+
+	producer.err = errors.New("broker unavailable")
+	err = store.Store(logUrl, LogRequest{Id: "4567", ReqType: CEInferenceResponse})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("broker unavailable")))
+}
+
+func TestKafkaClientOptions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	writeSecret := func(data map[string]string) string {
+		dir := t.TempDir()
+		for key, value := range data {
+			g.Expect(os.WriteFile(filepath.Join(dir, key), []byte(value), 0o600)).To(gomega.Succeed())
+		}
+		return dir
+	}
+
+	opts, err := kafkaClientOptions("", nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(opts).To(gomega.BeEmpty())
+
+	opts, err = kafkaClientOptions(writeSecret(map[string]string{
+		KafkaProtocolKey:      KafkaProtocolSASLPlaintext,
+		KafkaSASLMechanismKey: KafkaSASLScramSHA512,
+		KafkaUserKey:          "kserve",
+		KafkaPasswordKey:      "secret",
+	}), nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(opts).To(gomega.HaveLen(1))
+
+	opts, err = kafkaClientOptions(writeSecret(map[string]string{KafkaProtocolKey: KafkaProtocolSSL}), nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(opts).To(gomega.HaveLen(1))
+
+	_, err = kafkaClientOptions(writeSecret(map[string]string{KafkaProtocolKey: KafkaProtocolSASLSSL}), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("must set the user and password keys")))
+
+	_, err = kafkaClientOptions(writeSecret(map[string]string{
+		KafkaProtocolKey:      KafkaProtocolSASLPlaintext,
+		KafkaSASLMechanismKey: "GSSAPI",
+		KafkaUserKey:          "kserve",
+		KafkaPasswordKey:      "secret",
+	}), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported kafka SASL mechanism GSSAPI")))
+
+	_, err = kafkaClientOptions(writeSecret(map[string]string{KafkaProtocolKey: "TLS"}), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported kafka protocol TLS")))
+}
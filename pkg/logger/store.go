@@ -20,6 +20,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -33,6 +34,7 @@ const (
 	GCSStorage   StorageStrategy = "gcs"
 	AzureStorage StorageStrategy = "abfs"
 	HttpStorage  StorageStrategy = "http"
+	KafkaStorage StorageStrategy = "kafka"
 )
 
 const (
@@ -55,6 +57,8 @@ func GetStorageStrategy(url string) StorageStrategy {
 		return GCSStorage
 	case strings.HasPrefix(url, "abfs"):
 		return AzureStorage
+	case strings.HasPrefix(url, KafkaScheme+"://"):
+		return KafkaStorage
 	default:
 		return DefaultStorage
 	}
@@ -111,7 +115,23 @@ func NewStoreForScheme(scheme string, logStorePath string, logStoreFormat string
 	if err != nil {
 		return nil, err
 	}
+	provider, err := getProviderForScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlobStore(logStorePath, logStoreFormat, marshaller, provider, log), nil
+}
+
+// NewBatchStoreForScheme creates a BatchStore uploading the NDJSON or Parquet files with the provider of the scheme
+func NewBatchStoreForScheme(scheme string, logStorePath string, format string, flushInterval time.Duration, flushSize int, log *zap.SugaredLogger) (*BatchStore, error) {
+	provider, err := getProviderForScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return NewBatchStore(logStorePath, format, flushInterval, flushSize, provider, log)
+}
 
+func getProviderForScheme(scheme string) (storage.Provider, error) {
 	// Convert to a Protocol to reuse existing types
 	if !strings.HasSuffix(scheme, "://") {
 		scheme += "://"
@@ -127,7 +147,7 @@ func NewStoreForScheme(scheme string, logStorePath string, logStoreFormat string
 	case storage.GCS:
 		fallthrough
 	case storage.S3:
-		return NewBlobStore(logStorePath, logStoreFormat, marshaller, provider, log), nil
+		return provider, nil
 	}
 	return nil, fmt.Errorf("unsupported protocol %s", protocol)
 }
@@ -168,7 +188,8 @@ func (s *BlobStore) Store(logUrl *url.URL, logRequest LogRequest) error {
 	return nil
 }
 
-func (s *BlobStore) getObjectPrefix(configPrefix string, request *LogRequest) (string, error) {
+// getObjectPrefix returns the prefix of the objects of a component, under the prefix of the log url
+func getObjectPrefix(configPrefix string, storePath string, request *LogRequest) (string, error) {
 	if request == nil {
 		return "", errors.New("log request is invalid")
 	}
@@ -187,8 +208,8 @@ func (s *BlobStore) getObjectPrefix(configPrefix string, request *LogRequest) (s
 		parts = append(parts, request.Component)
 	}
 
-	if s.storePath != "" {
-		parts = append(parts, s.storePath)
+	if storePath != "" {
+		parts = append(parts, storePath)
 	}
 	return path.Join(parts...), nil
 }
@@ -198,7 +219,7 @@ func (s *BlobStore) getObjectKey(configPrefix string, request *LogRequest) (stri
 		return "", errors.New("log request is invalid")
 	}
 
-	prefix, err := s.getObjectPrefix(configPrefix, request)
+	prefix, err := getObjectPrefix(configPrefix, s.storePath, request)
 	if err != nil {
 		return "", err
 	}
@@ -32,11 +32,14 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 )
 
 const (
 	CEInferenceRequest  = "org.kubeflow.serving.inference.request"
 	CEInferenceResponse = "org.kubeflow.serving.inference.response"
+	// CEInferenceExplanation is the type of the explanations of the prediction requests sampled for the explainer
+	CEInferenceExplanation = "org.kubeflow.serving.inference.explanation"
 
 	// cloud events extension attributes have to be lowercase alphanumeric
 	// TODO: ideally request id would have its own header but make do with ce-id for now
@@ -55,6 +58,9 @@ const (
 // A buffered channel that we can send work requests on.
 var WorkQueue = make(chan LogRequest, LoggerWorkerQueueSize)
 
+// TLSPolicy is enforced on the TLS connections to the https log URLs, when set before the dispatcher is started
+var TLSPolicy *tlspolicy.Policy
+
 func QueueLogRequest(req LogRequest) error {
 	WorkQueue <- req
 	return nil
@@ -95,6 +101,7 @@ func (w *Worker) sendHttpCloudEvent(logReq LogRequest) error {
 	if logReq.Url.Scheme == "https" {
 		caCertFilePath := filepath.Join(constants.LoggerCaCertMountPath, logReq.CertName)
 		caCertFile, err := os.ReadFile(caCertFilePath)
+		var tlsConfig *tls.Config
 		// Do not fail if certificates not found, for backwards compatibility
 		if err == nil {
 			clientCertPool := x509.NewCertPool()
@@ -102,16 +109,20 @@ func (w *Worker) sendHttpCloudEvent(logReq LogRequest) error {
 				return errors.New("while parsing CA certificate")
 			}
 
-			tlsTransport := &http.Transport{
-				TLSClientConfig: &tls.Config{
-					RootCAs:            clientCertPool,
-					MinVersion:         tls.VersionTLS12,
-					InsecureSkipVerify: logReq.TlsSkipVerify, // #nosec G402
-				},
+			tlsConfig = &tls.Config{
+				RootCAs:            clientCertPool,
+				MinVersion:         tls.VersionTLS12,
+				InsecureSkipVerify: logReq.TlsSkipVerify, // #nosec G402
 			}
-			t.Client.Transport = tlsTransport
 		} else {
 			w.Log.Warnf("using https endpoint but could not find CA cert file %s", caCertFilePath)
+			if TLSPolicy != nil {
+				tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+		}
+		if tlsConfig != nil {
+			TLSPolicy.Apply(tlsConfig)
+			t.Client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 		}
 	}
 
@@ -181,6 +192,16 @@ func (w *Worker) Start() {
 				// Receive a work request.
 				w.Log.Infof("Received work request %d, url: %s, requestId: %s", w.ID, work.Url.String(), work.Id)
 
+				// The payloads are never sent unencrypted when the encryption is enabled
+				if PayloadEncryptor != nil {
+					encrypted, err := PayloadEncryptor.Encrypt(work)
+					if err != nil {
+						w.Log.Errorw("Failed to encrypt the payload, dropping the log", "requestId", work.Id, "error", err)
+						continue
+					}
+					work = encrypted
+				}
+
 				// Determine how we should handle the work request.
 				strategy := GetStorageStrategy(work.Url.String())
 
@@ -18,6 +18,7 @@ package modelconfig
 
 import (
 	"fmt"
+	"sort"
 
 	jsoniter "github.com/json-iterator/go"
 	corev1 "k8s.io/api/core/v1"
@@ -37,6 +38,8 @@ var (
 type ModelConfig struct {
 	Name string             `json:"modelName"`
 	Spec v1alpha1.ModelSpec `json:"modelSpec"`
+	// Priority orders the loading of the models when a replica starts, higher first
+	Priority int32 `json:"priority,omitempty"`
 }
 
 type ModelConfigs []ModelConfig
@@ -67,6 +70,7 @@ func NewConfigsDelta(updatedConfigs ModelConfigs, deletedConfigs []string) *Conf
 //	  [
 //	    {
 //	      "modelName": "model1",
+//	      "priority": 100,
 //	      "modelSpec": {
 //	        "storageUri": "s3://example-bucket/path/to/model1",
 //	        "framework": "sklearn",
@@ -132,6 +136,22 @@ func CreateEmptyModelConfig(isvc *v1beta1.InferenceService, shardId int) (*corev
 	return multiModelConfigMap, nil
 }
 
+// PredictorModelConfigs returns the model configs of the models declared in the predictor
+func PredictorModelConfigs(models []v1beta1.PredictorModelSpec) ModelConfigs {
+	configs := make(ModelConfigs, 0, len(models))
+	for _, model := range models {
+		spec := v1alpha1.ModelSpec{
+			StorageURI: model.StorageURI,
+			Framework:  model.ModelFormat.Name,
+		}
+		if model.Memory != nil {
+			spec.Memory = *model.Memory
+		}
+		configs = append(configs, ModelConfig{Name: model.Name, Spec: spec})
+	}
+	return configs
+}
+
 func slice2Map(from ModelConfigs) map[string]ModelConfig {
 	to := make(map[string]ModelConfig)
 	for _, config := range from {
@@ -145,6 +165,10 @@ func map2Slice(from map[string]ModelConfig) ModelConfigs {
 	for _, config := range from {
 		to = append(to, config)
 	}
+	// sort the models so that the encoded config does not change when the models are unchanged
+	sort.Slice(to, func(i, j int) bool {
+		return to[i].Name < to[j].Name
+	})
 	return to
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -282,3 +283,31 @@ func TestCreateEmptyModelConfig(t *testing.T) {
 	require.NoError(t, err)
 	testify.Equal(t, expected, configMap)
 }
+
+func TestPredictorModelConfigs(t *testing.T) {
+	memory := resource.MustParse("1Gi")
+	models := []v1beta1.PredictorModelSpec{
+		{
+			Name:        "model1",
+			StorageURI:  "s3://example-bucket/path/to/model1",
+			ModelFormat: v1beta1.ModelFormat{Name: "sklearn"},
+			Memory:      &memory,
+		},
+		{
+			Name:        "model2",
+			StorageURI:  "s3://example-bucket/path/to/model2",
+			ModelFormat: v1beta1.ModelFormat{Name: "xgboost"},
+		},
+	}
+	expected := ModelConfigs{
+		{
+			Name: "model1",
+			Spec: v1alpha1.ModelSpec{StorageURI: "s3://example-bucket/path/to/model1", Framework: "sklearn", Memory: memory},
+		},
+		{
+			Name: "model2",
+			Spec: v1alpha1.ModelSpec{StorageURI: "s3://example-bucket/path/to/model2", Framework: "xgboost"},
+		},
+	}
+	testify.Equal(t, expected, PredictorModelConfigs(models))
+}
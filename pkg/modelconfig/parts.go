@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// GetModelConfigParts returns the ConfigMaps holding the models of a modelConfig, starting with the modelConfig
+// itself. The error of getting the modelConfig itself is returned as is, so callers can check if it is not found.
+func GetModelConfigParts(ctx context.Context, clientset kubernetes.Interface, namespace string, modelConfigName string) ([]*corev1.ConfigMap, error) {
+	modelConfig, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, modelConfigName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	parts := []*corev1.ConfigMap{modelConfig}
+	for part := 1; part < constants.ModelConfigMaxParts; part++ {
+		partName := constants.ModelConfigPartName(modelConfigName, part)
+		configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, partName, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		parts = append(parts, configMap)
+	}
+	return parts, nil
+}
+
+// SaveModelConfigParts updates the modified parts of a modelConfig and creates its new parts
+func SaveModelConfigParts(ctx context.Context, cl client.Client, updated []*corev1.ConfigMap, created []*corev1.ConfigMap) error {
+	for _, part := range updated {
+		if err := cl.Update(ctx, part); err != nil {
+			return err
+		}
+	}
+	for _, part := range created {
+		logger.Info("Creating modelConfig part", "ConfigMap", part.Name, "namespace", part.Namespace)
+		if err := cl.Create(ctx, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProcessParts applies the delta to a modelConfig split across multiple ConfigMaps. An updated model stays in the part
+// holding it, so that the other parts are left untouched, and a new model is added to the first part with enough room
+// for it. When no part has enough room, a new part is created with the metadata of the first part.
+// It returns the parts which are modified and the new parts which must be created.
+func (config *ConfigsDelta) ProcessParts(parts []*corev1.ConfigMap) ([]*corev1.ConfigMap, []*corev1.ConfigMap, error) {
+	if len(config.updated) == 0 && len(config.deleted) == 0 {
+		return nil, nil, nil
+	}
+	if len(parts) == 0 {
+		return nil, nil, fmt.Errorf("no modelConfig to process")
+	}
+	data := make([]map[string]ModelConfig, len(parts))
+	sizes := make([]int, len(parts))
+	location := map[string]int{}
+	for i, part := range parts {
+		decoded, err := decode(part.Data[constants.ModelConfigFileName])
+		if err != nil {
+			return nil, nil, fmt.Errorf("while updating %s err %w", part.Name, err)
+		}
+		data[i] = decoded
+		sizes[i] = len(part.Data[constants.ModelConfigFileName])
+		for name := range decoded {
+			location[name] = i
+		}
+	}
+	modified := map[int]bool{}
+
+	// add/update models, in order so that the assignment of the new models to the parts is deterministic
+	names := make([]string, 0, len(config.updated))
+	for name := range config.updated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		modelConfig := config.updated[name]
+		if i, ok := location[name]; ok {
+			data[i][name] = modelConfig
+			modified[i] = true
+			continue
+		}
+		encoded, err := json.Marshal(&modelConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("while updating %s err %w", parts[0].Name, err)
+		}
+		// account for the separator of the entry in the encoded list
+		size := len(encoded) + 1
+		i := 0
+		for i < len(data) && sizes[i]+size > constants.ModelConfigPartMaxSize {
+			i++
+		}
+		if i == len(data) {
+			if i >= constants.ModelConfigMaxParts {
+				return nil, nil, fmt.Errorf("while updating %s err the models exceed the maximum number of %d modelConfig parts",
+					parts[0].Name, constants.ModelConfigMaxParts)
+			}
+			data = append(data, map[string]ModelConfig{})
+			sizes = append(sizes, len("[]"))
+		}
+		data[i][name] = modelConfig
+		sizes[i] += size
+		location[name] = i
+		modified[i] = true
+	}
+	// delete models
+	for _, name := range config.deleted {
+		if i, ok := location[name]; ok {
+			delete(data[i], name)
+			delete(location, name)
+			modified[i] = true
+		} else {
+			logger.Info("Model does not exist in ConfigMap.",
+				"model", name, "ConfigMap", parts[0].Name)
+		}
+	}
+
+	var updated, created []*corev1.ConfigMap
+	for i := range data {
+		if !modified[i] {
+			continue
+		}
+		to, err := encode(data[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("while updating %s err %w", parts[0].Name, err)
+		}
+		if i >= len(parts) {
+			created = append(created, newModelConfigPart(parts[0], i, to))
+			continue
+		}
+		if parts[i].Data == nil {
+			parts[i].Data = make(map[string]string)
+		}
+		if parts[i].Data[constants.ModelConfigFileName] == to {
+			continue
+		}
+		parts[i].Data[constants.ModelConfigFileName] = to
+		updated = append(updated, parts[i])
+	}
+	return updated, created, nil
+}
+
+func newModelConfigPart(modelConfig *corev1.ConfigMap, part int, models string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.ModelConfigPartName(modelConfig.Name, part),
+			Namespace:       modelConfig.Namespace,
+			Labels:          modelConfig.Labels,
+			OwnerReferences: modelConfig.OwnerReferences,
+		},
+		Data: map[string]string{
+			constants.ModelConfigFileName: models,
+		},
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import (
+	"strings"
+	"testing"
+
+	testify "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeModelConfigPart(name string, models ...ModelConfig) *corev1.ConfigMap {
+	data, err := encode(slice2Map(models))
+	if err != nil {
+		panic(err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{"app": "isvc"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "InferenceService", Name: "isvc"},
+			},
+		},
+		Data: map[string]string{
+			constants.ModelConfigFileName: data,
+		},
+	}
+}
+
+func makeModel(name string, storageURI string) ModelConfig {
+	return ModelConfig{Name: name, Spec: v1alpha1.ModelSpec{StorageURI: storageURI, Framework: "sklearn"}}
+}
+
+// makeLargeModel returns a model taking most of the room of a modelConfig part
+func makeLargeModel(name string) ModelConfig {
+	return makeModel(name, "s3://"+strings.Repeat("a", constants.ModelConfigPartMaxSize-120))
+}
+
+func decodePart(t *testing.T, configMap *corev1.ConfigMap) map[string]ModelConfig {
+	data, err := decode(configMap.Data[constants.ModelConfigFileName])
+	require.NoError(t, err)
+	return data
+}
+
+func TestGetModelConfigParts(t *testing.T) {
+	clientset := k8sfake.NewSimpleClientset(
+		makeModelConfigPart("modelconfig-isvc-0"),
+		makeModelConfigPart("modelconfig-isvc-0-part-1"),
+		makeModelConfigPart("modelconfig-isvc-0-part-3"),
+	)
+	parts, err := GetModelConfigParts(t.Context(), clientset, "test", "modelconfig-isvc-0")
+	require.NoError(t, err)
+	names := []string{}
+	for _, part := range parts {
+		names = append(names, part.Name)
+	}
+	testify.Equal(t, []string{"modelconfig-isvc-0", "modelconfig-isvc-0-part-1"}, names)
+
+	_, err = GetModelConfigParts(t.Context(), clientset, "test", "modelconfig-missing-0")
+	testify.True(t, apierr.IsNotFound(err))
+}
+
+func TestProcessParts(t *testing.T) {
+	t.Run("update stays in the part holding the model", func(t *testing.T) {
+		parts := []*corev1.ConfigMap{
+			makeModelConfigPart("modelconfig-isvc-0", makeModel("model1", "s3://model1")),
+			makeModelConfigPart("modelconfig-isvc-0-part-1", makeModel("model2", "s3://model2")),
+		}
+		delta := NewConfigsDelta(ModelConfigs{makeModel("model2", "s3://new-model2")}, nil)
+		updated, created, err := delta.ProcessParts(parts)
+		require.NoError(t, err)
+		testify.Empty(t, created)
+		require.Len(t, updated, 1)
+		testify.Equal(t, "modelconfig-isvc-0-part-1", updated[0].Name)
+		testify.Equal(t, "s3://new-model2", decodePart(t, updated[0])["model2"].Spec.StorageURI)
+	})
+
+	t.Run("new model added to the first part with room", func(t *testing.T) {
+		parts := []*corev1.ConfigMap{
+			makeModelConfigPart("modelconfig-isvc-0", makeLargeModel("model1")),
+			makeModelConfigPart("modelconfig-isvc-0-part-1", makeModel("model2", "s3://model2")),
+		}
+		delta := NewConfigsDelta(ModelConfigs{makeModel("model3", "s3://model3")}, nil)
+		updated, created, err := delta.ProcessParts(parts)
+		require.NoError(t, err)
+		testify.Empty(t, created)
+		require.Len(t, updated, 1)
+		testify.Equal(t, "modelconfig-isvc-0-part-1", updated[0].Name)
+		testify.Len(t, decodePart(t, updated[0]), 2)
+	})
+
+	t.Run("new part created when all the parts are full", func(t *testing.T) {
+		parts := []*corev1.ConfigMap{
+			makeModelConfigPart("modelconfig-isvc-0", makeLargeModel("model1")),
+		}
+		delta := NewConfigsDelta(ModelConfigs{makeModel("model2", "s3://model2"), makeModel("model3", "s3://model3")}, nil)
+		updated, created, err := delta.ProcessParts(parts)
+		require.NoError(t, err)
+		testify.Empty(t, updated)
+		require.Len(t, created, 1)
+		testify.Equal(t, "modelconfig-isvc-0-part-1", created[0].Name)
+		testify.Equal(t, "test", created[0].Namespace)
+		testify.Equal(t, parts[0].Labels, created[0].Labels)
+		testify.Equal(t, parts[0].OwnerReferences, created[0].OwnerReferences)
+		testify.Len(t, decodePart(t, created[0]), 2)
+	})
+
+	t.Run("delete from the part holding the model", func(t *testing.T) {
+		parts := []*corev1.ConfigMap{
+			makeModelConfigPart("modelconfig-isvc-0", makeModel("model1", "s3://model1")),
+			makeModelConfigPart("modelconfig-isvc-0-part-1", makeModel("model2", "s3://model2")),
+		}
+		delta := NewConfigsDelta(nil, []string{"model2", "missing"})
+		updated, created, err := delta.ProcessParts(parts)
+		require.NoError(t, err)
+		testify.Empty(t, created)
+		require.Len(t, updated, 1)
+		testify.Equal(t, "modelconfig-isvc-0-part-1", updated[0].Name)
+		testify.Equal(t, "[]", updated[0].Data[constants.ModelConfigFileName])
+	})
+
+	t.Run("unchanged parts are not updated", func(t *testing.T) {
+		parts := []*corev1.ConfigMap{
+			makeModelConfigPart("modelconfig-isvc-0", makeModel("model1", "s3://model1")),
+		}
+		delta := NewConfigsDelta(ModelConfigs{makeModel("model1", "s3://model1")}, nil)
+		updated, created, err := delta.ProcessParts(parts)
+		require.NoError(t, err)
+		testify.Empty(t, updated)
+		testify.Empty(t, created)
+	})
+
+	t.Run("models exceeding the maximum number of parts", func(t *testing.T) {
+		parts := make([]*corev1.ConfigMap, 0, constants.ModelConfigMaxParts)
+		for part := range constants.ModelConfigMaxParts {
+			parts = append(parts, makeModelConfigPart(constants.ModelConfigPartName("modelconfig-isvc-0", part),
+				makeLargeModel(constants.ModelConfigPartName("model", part))))
+		}
+		delta := NewConfigsDelta(ModelConfigs{makeModel("new-model", "s3://new-model")}, nil)
+		_, _, err := delta.ProcessParts(parts)
+		require.ErrorContains(t, err, "the models exceed the maximum number of 16 modelConfig parts")
+	})
+}
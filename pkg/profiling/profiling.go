@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"os"
+	"path"
+	runtimepprof "runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName("Profiling")
+
+const (
+	// DefaultPort is the port the profiling endpoints are served on
+	DefaultPort = constants.ProfilingPort
+	// TokenEnvVar is the environment variable holding the bearer token required to call the profiling endpoints
+	TokenEnvVar = constants.ProfilingTokenEnvVarName
+	// CapturePath is the endpoint capturing a profile in the background and uploading it to storage
+	CapturePath = "/debug/pprof/capture"
+
+	ProfileParam    = "profile"
+	SecondsParam    = "seconds"
+	StorageUriParam = "storageUri"
+
+	CPUProfile   = "cpu"
+	TraceProfile = "trace"
+
+	DefaultCaptureSeconds = 30
+	MaxCaptureSeconds     = 300
+)
+
+// CaptureRequest describes a profile to capture and the storage location it is uploaded to
+type CaptureRequest struct {
+	Profile    string
+	Duration   time.Duration
+	StorageUri string
+}
+
+// Endpoint is an additional debug endpoint served with the profiling endpoints, behind the same bearer token
+type Endpoint struct {
+	Path    string
+	Handler http.Handler
+}
+
+// Uploader uploads a captured profile to storage
+type Uploader func(storageUri string, name string, data []byte) error
+
+// Server serves the pprof and trace endpoints of a process. Every request must carry the bearer token,
+// the server refuses all requests when no token is configured.
+type Server struct {
+	token    string
+	uploader Uploader
+	// only one capture runs at a time as the cpu profiler and the tracer can not be started twice
+	capturing sync.Mutex
+	mux       *http.ServeMux
+}
+
+// NewServer creates the profiling server, serving the given debug endpoints as well
+func NewServer(token string, uploader Uploader, endpoints ...Endpoint) *Server {
+	s := NewEndpointsServer(token, endpoints...)
+	s.uploader = uploader
+	s.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	s.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	s.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	s.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	s.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	s.mux.HandleFunc(CapturePath, s.captureHandler)
+	return s
+}
+
+// NewEndpointsServer creates a server serving only the given debug endpoints behind the bearer token, without the
+// pprof, trace and capture endpoints, e.g. for the request inspector of an agent with profiling disabled
+func NewEndpointsServer(token string, endpoints ...Endpoint) *Server {
+	s := &Server{
+		token: token,
+		mux:   http.NewServeMux(),
+	}
+	for _, endpoint := range endpoints {
+		s.mux.Handle(endpoint.Path, endpoint.Handler)
+	}
+	return s
+}
+
+// TokenEnvFromSecret returns the environment variable exposing the profiling token of the namespace to a container.
+// The secret is optional, the profiling endpoints are not served when it does not exist.
+func TokenEnvFromSecret() corev1.EnvVar {
+	optional := true
+	return corev1.EnvVar{
+		Name: TokenEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: constants.ProfilingTokenSecretName},
+				Key:                  constants.ProfilingTokenSecretKey,
+				Optional:             &optional,
+			},
+		},
+	}
+}
+
+// NewHTTPServer creates the http server serving the profiling and the given debug endpoints on the given port, or
+// returns nil when profiling can not be enabled because no token is configured.
+func NewHTTPServer(port int, token string, endpoints ...Endpoint) *http.Server {
+	if token == "" {
+		log.Info("Profiling is enabled but no token is set, the profiling endpoints are not served", "env", TokenEnvVar)
+		return nil
+	}
+	return newHTTPServer(port, NewServer(token, Upload, endpoints...))
+}
+
+// NewEndpointsHTTPServer creates the http server serving only the given debug endpoints on the given port, or returns
+// nil when no token is configured.
+func NewEndpointsHTTPServer(port int, token string, endpoints ...Endpoint) *http.Server {
+	if token == "" {
+		log.Info("Debug endpoints are enabled but no token is set, the debug endpoints are not served", "env", TokenEnvVar)
+		return nil
+	}
+	return newHTTPServer(port, NewEndpointsServer(token, endpoints...))
+}
+
+func newHTTPServer(port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           handler,
+		ReadHeaderTimeout: time.Minute,
+		// cpu profiles and traces are streamed for up to MaxCaptureSeconds
+		WriteTimeout: (MaxCaptureSeconds + 60) * time.Second,
+		IdleTimeout:  3 * time.Minute,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func (s *Server) captureHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	request, err := ParseCaptureRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !s.capturing.TryLock() {
+		http.Error(w, "a profile capture is already in progress", http.StatusConflict)
+		return
+	}
+	go func() {
+		defer s.capturing.Unlock()
+		if err := s.capture(request); err != nil {
+			log.Error(err, "Failed to capture profile", "profile", request.Profile, "storageUri", request.StorageUri)
+		}
+	}()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) capture(request *CaptureRequest) error {
+	log.Info("Capturing profile", "profile", request.Profile, "duration", request.Duration)
+	buf := &bytes.Buffer{}
+	if err := Capture(request.Profile, request.Duration, buf); err != nil {
+		return err
+	}
+	hostname, _ := os.Hostname()
+	name := fmt.Sprintf("%s-%s-%s.pprof", hostname, request.Profile, time.Now().UTC().Format("20060102T150405Z"))
+	if request.Profile == TraceProfile {
+		name = strings.TrimSuffix(name, ".pprof") + ".trace"
+	}
+	if err := s.uploader(request.StorageUri, name, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to upload profile %s: %w", name, err)
+	}
+	log.Info("Uploaded profile", "name", name, "storageUri", request.StorageUri)
+	return nil
+}
+
+// ParseCaptureRequest parses and validates the query parameters of a capture request
+func ParseCaptureRequest(query url.Values) (*CaptureRequest, error) {
+	request := &CaptureRequest{
+		Profile:    query.Get(ProfileParam),
+		StorageUri: query.Get(StorageUriParam),
+		Duration:   DefaultCaptureSeconds * time.Second,
+	}
+	if request.Profile == "" {
+		request.Profile = CPUProfile
+	}
+	if request.Profile != CPUProfile && request.Profile != TraceProfile && runtimepprof.Lookup(request.Profile) == nil {
+		return nil, fmt.Errorf("unknown profile %q", request.Profile)
+	}
+	if seconds := query.Get(SecondsParam); seconds != "" {
+		value, err := strconv.Atoi(seconds)
+		if err != nil || value <= 0 || value > MaxCaptureSeconds {
+			return nil, fmt.Errorf("seconds must be between 1 and %d", MaxCaptureSeconds)
+		}
+		request.Duration = time.Duration(value) * time.Second
+	}
+	if request.StorageUri == "" {
+		return nil, errors.New("storageUri is required")
+	}
+	if _, _, err := parseStorageUri(request.StorageUri); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Capture writes the given profile to w. The cpu profile and the execution trace are recorded for the given
+// duration, the other profiles are snapshots taken at the end of the duration.
+func Capture(profile string, duration time.Duration, w io.Writer) error {
+	switch profile {
+	case CPUProfile:
+		if err := runtimepprof.StartCPUProfile(w); err != nil {
+			return err
+		}
+		time.Sleep(duration)
+		runtimepprof.StopCPUProfile()
+	case TraceProfile:
+		if err := trace.Start(w); err != nil {
+			return err
+		}
+		time.Sleep(duration)
+		trace.Stop()
+	default:
+		p := runtimepprof.Lookup(profile)
+		if p == nil {
+			return fmt.Errorf("unknown profile %q", profile)
+		}
+		time.Sleep(duration)
+		return p.WriteTo(w, 0)
+	}
+	return nil
+}
+
+// Upload uploads a profile under the prefix of the storage uri
+func Upload(storageUri string, name string, data []byte) error {
+	protocol, u, err := parseStorageUri(storageUri)
+	if err != nil {
+		return err
+	}
+	provider, err := storage.GetProvider(map[storage.Protocol]storage.Provider{}, protocol)
+	if err != nil {
+		return err
+	}
+	return provider.UploadObject(u.Host, path.Join(strings.TrimPrefix(u.Path, "/"), name), data)
+}
+
+func parseStorageUri(storageUri string) (storage.Protocol, *url.URL, error) {
+	u, err := url.Parse(storageUri)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid storageUri %q: %w", storageUri, err)
+	}
+	protocol := storage.Protocol(u.Scheme + "://")
+	switch protocol {
+	case storage.S3, storage.GCS, storage.AZURE:
+	default:
+		return "", nil, fmt.Errorf("unsupported storageUri %q, supported protocols are %s, %s and %s", storageUri, storage.S3, storage.GCS, storage.AZURE)
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("no bucket specified in storageUri %q", storageUri)
+	}
+	return protocol, u, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestServerAuthorization(t *testing.T) {
+	scenarios := map[string]struct {
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		"valid token": {
+			token:          "secret",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+		},
+		"invalid token": {
+			token:          "secret",
+			authorization:  "Bearer other",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"missing token": {
+			token:          "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"no token configured": {
+			authorization:  "Bearer ",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			server := NewServer(scenario.token, nil)
+			req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+			if scenario.authorization != "" {
+				req.Header.Set("Authorization", scenario.authorization)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			g.Expect(rec.Code).To(gomega.Equal(scenario.expectedStatus))
+		})
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	server := NewServer("secret", nil, Endpoint{
+		Path: "/debug/requests",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/requests", nil)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusUnauthorized))
+
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusTeapot))
+}
+
+func TestParseCaptureRequest(t *testing.T) {
+	scenarios := map[string]struct {
+		query    url.Values
+		expected *CaptureRequest
+	}{
+		"defaults": {
+			query:    url.Values{StorageUriParam: {"s3://bucket/profiles"}},
+			expected: &CaptureRequest{Profile: CPUProfile, Duration: DefaultCaptureSeconds * time.Second, StorageUri: "s3://bucket/profiles"},
+		},
+		"heap profile": {
+			query:    url.Values{ProfileParam: {"heap"}, SecondsParam: {"5"}, StorageUriParam: {"gs://bucket"}},
+			expected: &CaptureRequest{Profile: "heap", Duration: 5 * time.Second, StorageUri: "gs://bucket"},
+		},
+		"unknown profile": {
+			query: url.Values{ProfileParam: {"unknown"}, StorageUriParam: {"s3://bucket"}},
+		},
+		"seconds too large": {
+			query: url.Values{SecondsParam: {"301"}, StorageUriParam: {"s3://bucket"}},
+		},
+		"missing storage uri": {
+			query: url.Values{ProfileParam: {TraceProfile}},
+		},
+		"unsupported storage uri": {
+			query: url.Values{StorageUriParam: {"pvc://claim/profiles"}},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			request, err := ParseCaptureRequest(scenario.query)
+			if scenario.expected == nil {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(request).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestCaptureHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	uploaded := make(chan string, 1)
+	server := NewServer("secret", func(storageUri string, name string, data []byte) error {
+		g.Expect(data).ToNot(gomega.BeEmpty())
+		uploaded <- storageUri
+		return nil
+	})
+
+	query := url.Values{ProfileParam: {"goroutine"}, SecondsParam: {"1"}, StorageUriParam: {"s3://bucket/profiles"}}
+	req := httptest.NewRequest(http.MethodPost, CapturePath+"?"+query.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusAccepted))
+
+	// a second capture is rejected while the first one is running
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusConflict))
+
+	g.Eventually(uploaded, 5*time.Second).Should(gomega.Receive(gomega.Equal("s3://bucket/profiles")))
+
+	req = httptest.NewRequest(http.MethodGet, CapturePath+"?"+query.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusMethodNotAllowed))
+}
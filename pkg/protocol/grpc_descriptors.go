@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protocol provides the descriptors of the open inference protocol gRPC service
+package protocol
+
+import (
+	_ "embed"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// GrpcInferenceServiceName is the full name of the open inference protocol gRPC service
+const GrpcInferenceServiceName = "inference.GRPCInferenceService"
+
+// GrpcPredictV2DescriptorSet is the descriptor set of the open inference protocol proto of the python model server,
+// regenerated with make generate-grpc-descriptors when the proto changes
+//
+//go:generate protoc --include_imports --proto_path=../../python/kserve/kserve/protocol/grpc --descriptor_set_out=grpc_predict_v2.pb grpc_predict_v2.proto
+//go:embed grpc_predict_v2.pb
+var GrpcPredictV2DescriptorSet []byte
+
+var (
+	filesOnce sync.Once
+	files     *protoregistry.Files
+	filesErr  error
+)
+
+// GrpcPredictV2Files returns the registry of the files of the descriptor set
+func GrpcPredictV2Files() (*protoregistry.Files, error) {
+	filesOnce.Do(func() {
+		fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+		if filesErr = proto.Unmarshal(GrpcPredictV2DescriptorSet, fileDescriptorSet); filesErr != nil {
+			return
+		}
+		files, filesErr = protodesc.NewFiles(fileDescriptorSet)
+	})
+	return files, filesErr
+}
+
+// GrpcInferenceService returns the descriptor of the open inference protocol gRPC service
+func GrpcInferenceService() (protoreflect.ServiceDescriptor, error) {
+	files, err := GrpcPredictV2Files()
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := files.FindDescriptorByName(GrpcInferenceServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return descriptor.(protoreflect.ServiceDescriptor), nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestGrpcInferenceService(t *testing.T) {
+	service, err := GrpcInferenceService()
+	require.NoError(t, err)
+
+	assert.Equal(t, "grpc_predict_v2.proto", service.ParentFile().Path())
+	for _, method := range []string{"ServerLive", "ServerReady", "ModelReady", "ServerMetadata", "ModelMetadata", "ModelInfer"} {
+		assert.NotNil(t, service.Methods().ByName(protoreflect.Name(method)), method)
+	}
+}
+
+// TestGrpcPredictV2DescriptorSetUpToDate checks that the descriptor set describes the messages and the methods of the
+// proto of the python model server, run make generate-grpc-descriptors when it fails
+func TestGrpcPredictV2DescriptorSetUpToDate(t *testing.T) {
+	proto, err := os.ReadFile("../../python/kserve/kserve/protocol/grpc/grpc_predict_v2.proto")
+	require.NoError(t, err)
+	files, err := GrpcPredictV2Files()
+	require.NoError(t, err)
+	file, err := files.FindFileByPath("grpc_predict_v2.proto")
+	require.NoError(t, err)
+
+	declaration := regexp.MustCompile(`(?m)^\s*(message|rpc)\s+(\w+)`)
+	var messages, methods []string
+	for _, match := range declaration.FindAllStringSubmatch(string(proto), -1) {
+		if match[1] == "message" {
+			messages = append(messages, match[2])
+		} else {
+			methods = append(methods, match[2])
+		}
+	}
+	var describedMessages, describedMethods []string
+	var collectMessages func(protoreflect.MessageDescriptors)
+	collectMessages = func(descriptors protoreflect.MessageDescriptors) {
+		for i := 0; i < descriptors.Len(); i++ {
+			// The entries of the map fields are not declared in the proto
+			if !descriptors.Get(i).IsMapEntry() {
+				describedMessages = append(describedMessages, string(descriptors.Get(i).Name()))
+			}
+			collectMessages(descriptors.Get(i).Messages())
+		}
+	}
+	collectMessages(file.Messages())
+	for i := 0; i < file.Services().Len(); i++ {
+		serviceMethods := file.Services().Get(i).Methods()
+		for j := 0; j < serviceMethods.Len(); j++ {
+			describedMethods = append(describedMethods, string(serviceMethods.Get(j).Name()))
+		}
+	}
+	assert.ElementsMatch(t, messages, describedMessages)
+	assert.ElementsMatch(t, methods, describedMethods)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// TokenAudience is the audience of the projected service account token the clients authenticate to the shared
+	// cache with
+	TokenAudience = "kserve-shared-cache"
+	// TokenPath is the path of the projected service account token sent to the shared cache
+	TokenPath = "/var/run/secrets/kserve/shared-cache/token"
+
+	// serviceAccountUsernamePrefix prefixes the user names of the service accounts, system:serviceaccount:<ns>:<name>
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	// tokenReviewTTL is the duration a reviewed token is trusted without being reviewed again
+	tokenReviewTTL = time.Minute
+)
+
+type reviewedToken struct {
+	tenant    string
+	expiresAt time.Time
+}
+
+// tenantAuthenticator derives the tenant of a request from its bearer token, a projected token of a service account
+// issued for TokenAudience, which is reviewed with the API server. The tenant is the namespace of the service
+// account, so that a caller only reads and writes the entries of its own namespace.
+type tenantAuthenticator struct {
+	clientset kubernetes.Interface
+	now       func() time.Time
+
+	mu sync.Mutex
+	// reviewedTokens holds the tenant and the expiry of the reviews of the tokens, keyed on the token hash
+	reviewedTokens map[string]reviewedToken
+}
+
+func newTenantAuthenticator(clientset kubernetes.Interface) *tenantAuthenticator {
+	return &tenantAuthenticator{
+		clientset:      clientset,
+		now:            time.Now,
+		reviewedTokens: map[string]reviewedToken{},
+	}
+}
+
+// authenticate returns the tenant of the bearer token of the request. The successful reviews are cached for
+// tokenReviewTTL.
+func (a *tenantAuthenticator) authenticate(r *http.Request) (string, int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", http.StatusUnauthorized, errors.New("a bearer token is required")
+	}
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	now := a.now()
+	a.mu.Lock()
+	reviewed, ok := a.reviewedTokens[key]
+	a.mu.Unlock()
+	if ok && now.Before(reviewed.expiresAt) {
+		return reviewed.tenant, http.StatusOK, nil
+	}
+
+	review, err := a.clientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token, Audiences: []string{TokenAudience}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("failed to authenticate the request: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return "", http.StatusUnauthorized, errors.New("invalid bearer token")
+	}
+	serviceAccount, ok := strings.CutPrefix(review.Status.User.Username, serviceAccountUsernamePrefix)
+	tenant, _, found := strings.Cut(serviceAccount, ":")
+	if !ok || !found || tenant == "" {
+		return "", http.StatusForbidden, fmt.Errorf("%s is not a service account", review.Status.User.Username)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for cached, cachedReview := range a.reviewedTokens {
+		if !now.Before(cachedReview.expiresAt) {
+			delete(a.reviewedTokens, cached)
+		}
+	}
+	a.reviewedTokens[key] = reviewedToken{tenant: tenant, expiresAt: now.Add(tokenReviewTTL)}
+	return tenant, http.StatusOK, nil
+}
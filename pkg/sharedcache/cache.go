@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharedcache caches the results of the inference graph nodes and of the LLM routers, e.g. embeddings and
+// tokenizations, in a service shared by all the resources of the cluster. The entries are partitioned by tenant, the
+// namespace of the service account the caller authenticates with, and the least recently used entries of a tenant are
+// evicted once the tenant exceeds its quota. The number of tenants is bounded, so that the memory of the cache is
+// bounded by their quotas.
+package sharedcache
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The paths the shared cache serves the entries, /v1/cache/<key>, and the metrics on
+const (
+	EntriesPath = "/v1/cache/"
+	MetricsPath = "/metrics"
+)
+
+// TTLParam is the query parameter of the TTL in seconds of an entry, the entries without a TTL are only evicted once
+// their tenant exceeds its quota
+const TTLParam = "ttl"
+
+// Reasons the entries are evicted for
+const (
+	expiredReason = "expired"
+	quotaReason   = "quota"
+)
+
+var (
+	// ErrQuotaExceeded is returned for the entries larger than the quota of their tenant
+	ErrQuotaExceeded = errors.New("the entry is larger than the quota of the tenant")
+	// ErrTooManyTenants is returned for the entries of a new tenant once the cache holds the entries of the maximum
+	// number of tenants
+	ErrTooManyTenants = errors.New("the shared cache holds the entries of the maximum number of tenants")
+)
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// tenantEntries are the entries of a tenant in least recently used order, the most recently used entry first
+type tenantEntries struct {
+	size    int64
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// Cache holds the entries of all the tenants in memory
+type Cache struct {
+	mu           sync.Mutex
+	defaultQuota int64
+	quotas       map[string]int64
+	maxTenants   int
+	tenants      map[string]*tenantEntries
+	now          func() time.Time
+
+	registry  *prometheus.Registry
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	evictions *prometheus.CounterVec
+	rejected  *prometheus.CounterVec
+	size      *prometheus.GaugeVec
+}
+
+// New creates a cache allowing each tenant the default quota in bytes, unless overridden by the quotas of the tenants,
+// and holding the entries of at most maxTenants tenants
+func New(defaultQuota int64, quotas map[string]int64, maxTenants int) *Cache {
+	c := &Cache{
+		defaultQuota: defaultQuota,
+		quotas:       quotas,
+		maxTenants:   maxTenants,
+		tenants:      map[string]*tenantEntries{},
+		now:          time.Now,
+		registry:     prometheus.NewRegistry(),
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kserve_shared_cache_hits_total",
+			Help: "Number of lookups of a tenant served from the shared cache",
+		}, []string{"tenant"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kserve_shared_cache_misses_total",
+			Help: "Number of lookups of a tenant not found in the shared cache",
+		}, []string{"tenant"}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kserve_shared_cache_evictions_total",
+			Help: "Number of entries of a tenant evicted from the shared cache, because they expired or to keep the tenant within its quota",
+		}, []string{"tenant", "reason"}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "kserve_shared_cache_rejected_total",
+			Help: "Number of entries of a tenant rejected by the shared cache because they are larger than the quota of the tenant, or the cache holds the entries of the maximum number of tenants",
+		}, []string{"tenant"}),
+		size: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "kserve_shared_cache_size_bytes",
+			Help: "Size of the entries of a tenant in the shared cache",
+		}, []string{"tenant"}),
+	}
+	quota := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kserve_shared_cache_quota_bytes",
+		Help: "Quota of a tenant in the shared cache, the tenants without a quota of their own are allowed the default quota",
+	}, []string{"tenant"})
+	quota.WithLabelValues("").Set(float64(defaultQuota))
+	for tenant, bytes := range quotas {
+		quota.WithLabelValues(tenant).Set(float64(bytes))
+	}
+	c.registry.MustRegister(c.hits, c.misses, c.evictions, c.rejected, c.size, quota)
+	return c
+}
+
+func (c *Cache) quota(tenant string) int64 {
+	if quota, ok := c.quotas[tenant]; ok {
+		return quota
+	}
+	return c.defaultQuota
+}
+
+// Get returns the value of the key of the tenant, and whether it was found and has not expired
+func (c *Cache) Get(tenant string, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t, ok := c.tenants[tenant]
+	if !ok {
+		c.misses.WithLabelValues(tenant).Inc()
+		return nil, false
+	}
+	element, ok := t.entries[key]
+	if !ok {
+		c.misses.WithLabelValues(tenant).Inc()
+		return nil, false
+	}
+	e := element.Value.(*entry)
+	if e.expired(c.now()) {
+		c.remove(tenant, t, element, expiredReason)
+		c.misses.WithLabelValues(tenant).Inc()
+		return nil, false
+	}
+	t.lru.MoveToFront(element)
+	c.hits.WithLabelValues(tenant).Inc()
+	return e.value, true
+}
+
+// Set stores the value of the key of the tenant, a ttl of 0 never expires. The least recently used entries of the
+// tenant are evicted to keep the tenant within its quota.
+func (c *Cache) Set(tenant string, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	quota := c.quota(tenant)
+	if int64(len(value)) > quota {
+		c.rejected.WithLabelValues(tenant).Inc()
+		return ErrQuotaExceeded
+	}
+	t, ok := c.tenants[tenant]
+	if !ok {
+		if len(c.tenants) >= c.maxTenants && !c.removeExpired() {
+			c.rejected.WithLabelValues(tenant).Inc()
+			return ErrTooManyTenants
+		}
+		t = &tenantEntries{entries: map[string]*list.Element{}, lru: list.New()}
+		c.tenants[tenant] = t
+	}
+	if element, ok := t.entries[key]; ok {
+		c.remove(tenant, t, element, "")
+	}
+	now := c.now()
+	for t.size+int64(len(value)) > quota {
+		oldest := t.lru.Back()
+		reason := quotaReason
+		if oldest.Value.(*entry).expired(now) {
+			reason = expiredReason
+		}
+		c.remove(tenant, t, oldest, reason)
+	}
+	e := &entry{key: key, value: value}
+	if ttl > 0 {
+		e.expiresAt = now.Add(ttl)
+	}
+	t.entries[key] = t.lru.PushFront(e)
+	t.size += int64(len(value))
+	c.size.WithLabelValues(tenant).Set(float64(t.size))
+	return nil
+}
+
+// removeExpired removes the expired entries of all the tenants, and the tenants left without entries. It returns
+// whether a tenant was removed.
+func (c *Cache) removeExpired() bool {
+	now := c.now()
+	removed := false
+	for tenant, t := range c.tenants {
+		for element := t.lru.Back(); element != nil; {
+			previous := element.Prev()
+			if element.Value.(*entry).expired(now) {
+				c.remove(tenant, t, element, expiredReason)
+			}
+			element = previous
+		}
+		if t.lru.Len() == 0 {
+			delete(c.tenants, tenant)
+			c.size.DeleteLabelValues(tenant)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// remove removes the entry from the tenant, the evictions are counted when a reason is given
+func (c *Cache) remove(tenant string, t *tenantEntries, element *list.Element, reason string) {
+	e := t.lru.Remove(element).(*entry)
+	delete(t.entries, e.key)
+	t.size -= int64(len(e.value))
+	c.size.WithLabelValues(tenant).Set(float64(t.size))
+	if reason != "" {
+		c.evictions.WithLabelValues(tenant, reason).Inc()
+	}
+}
+
+// Handler serves the entries and the metrics of the cache. The entries are read with a GET and written with a PUT of
+// <EntriesPath><key>, with the TTL of the entry in seconds in the ttl query parameter. The requests of the entries
+// are authenticated with a projected service account token, whose tokens are reviewed with the clientset.
+func (c *Cache) Handler(clientset kubernetes.Interface) http.Handler {
+	authenticator := newTenantAuthenticator(clientset)
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc(EntriesPath, func(w http.ResponseWriter, r *http.Request) {
+		tenant, status, err := authenticator.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		c.serveEntry(w, r, tenant)
+	})
+	return mux
+}
+
+func (c *Cache) serveEntry(w http.ResponseWriter, r *http.Request, tenant string) {
+	key := strings.TrimPrefix(r.URL.Path, EntriesPath)
+	if key == "" {
+		http.Error(w, "the path must be "+EntriesPath+"<key>", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		value, ok := c.Get(tenant, key)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(value)
+	case http.MethodPut:
+		var ttl time.Duration
+		if param := r.URL.Query().Get(TTLParam); param != "" {
+			seconds, err := strconv.ParseInt(param, 10, 64)
+			if err != nil || seconds < 0 {
+				http.Error(w, "the ttl must be a number of seconds", http.StatusBadRequest)
+				return
+			}
+			ttl = time.Duration(seconds) * time.Second
+		}
+		// the entries larger than the quota are rejected without reading them whole
+		value, err := io.ReadAll(http.MaxBytesReader(w, r.Body, c.quota(tenant)+1))
+		if err != nil {
+			var maxBytesError *http.MaxBytesError
+			if errors.As(err, &maxBytesError) {
+				c.rejected.WithLabelValues(tenant).Inc()
+				http.Error(w, ErrQuotaExceeded.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.Set(tenant, key, value, ttl); err != nil {
+			status := http.StatusRequestEntityTooLarge
+			if errors.Is(err, ErrTooManyTenants) {
+				status = http.StatusInsufficientStorage
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
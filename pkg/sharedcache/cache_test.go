@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCacheTenantQuota(t *testing.T) {
+	c := New(10, map[string]int64{"team-b": 4}, 10)
+
+	require.NoError(t, c.Set("team-a", "a", []byte("aaaa"), 0))
+	require.NoError(t, c.Set("team-a", "b", []byte("bbbb"), 0))
+	// reading a makes b the least recently used entry
+	_, ok := c.Get("team-a", "a")
+	assert.True(t, ok)
+	require.NoError(t, c.Set("team-a", "c", []byte("cccc"), 0))
+
+	_, ok = c.Get("team-a", "b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+	for _, key := range []string{"a", "c"} {
+		_, ok = c.Get("team-a", key)
+		assert.True(t, ok, key)
+	}
+	assert.InDelta(t, 1, testutil.ToFloat64(c.evictions.WithLabelValues("team-a", quotaReason)), 0)
+	assert.InDelta(t, 8, testutil.ToFloat64(c.size.WithLabelValues("team-a")), 0)
+
+	// the quota of a tenant does not evict the entries of the other tenants
+	require.ErrorIs(t, c.Set("team-b", "a", []byte("aaaaa"), 0), ErrQuotaExceeded)
+	require.NoError(t, c.Set("team-b", "a", []byte("aaaa"), 0))
+	_, ok = c.Get("team-a", "a")
+	assert.True(t, ok)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.rejected.WithLabelValues("team-b")), 0)
+}
+
+func TestCacheTTL(t *testing.T) {
+	c := New(100, nil, 10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set("team-a", "expiring", []byte("value"), time.Minute))
+	require.NoError(t, c.Set("team-a", "lasting", []byte("value"), 0))
+	now = now.Add(time.Minute)
+
+	_, ok := c.Get("team-a", "expiring")
+	assert.False(t, ok)
+	_, ok = c.Get("team-a", "lasting")
+	assert.True(t, ok)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.evictions.WithLabelValues("team-a", expiredReason)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.hits.WithLabelValues("team-a")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.misses.WithLabelValues("team-a")), 0)
+}
+
+func TestCacheMaxTenants(t *testing.T) {
+	c := New(100, nil, 2)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set("team-a", "a", []byte("value"), time.Minute))
+	require.NoError(t, c.Set("team-b", "a", []byte("value"), 0))
+	require.ErrorIs(t, c.Set("team-c", "a", []byte("value"), 0), ErrTooManyTenants)
+	// the tenants already holding entries are not limited
+	require.NoError(t, c.Set("team-a", "b", []byte("value"), time.Minute))
+
+	// the tenants left with expired entries only make room for the new tenants
+	now = now.Add(time.Minute)
+	require.NoError(t, c.Set("team-c", "a", []byte("value"), 0))
+	_, ok := c.Get("team-b", "a")
+	assert.True(t, ok)
+	assert.InDelta(t, 2, testutil.ToFloat64(c.evictions.WithLabelValues("team-a", expiredReason)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.rejected.WithLabelValues("team-c")), 0)
+}
+
+// newTestClientset returns a fake API server authenticating the tokens named after the namespace of their service
+// account, and rejecting the others
+func newTestClientset() *fake.Clientset {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		switch {
+		case !slices.Equal(review.Spec.Audiences, []string{TokenAudience}):
+		case review.Spec.Token == "user":
+			review.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "alice"}}
+		case strings.HasPrefix(review.Spec.Token, "team-"):
+			review.Status = authnv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authnv1.UserInfo{Username: "system:serviceaccount:" + review.Spec.Token + ":default"},
+			}
+		}
+		return true, review, nil
+	})
+	return clientset
+}
+
+func newTestClient(t *testing.T, url string, token string) *Client {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte(token+"\n"), 0o600))
+	return NewClient(url, tokenPath)
+}
+
+func TestClient(t *testing.T) {
+	c := New(16, nil, 10)
+	server := httptest.NewServer(c.Handler(newTestClientset()))
+	defer server.Close()
+	ctx := context.Background()
+	client := newTestClient(t, server.URL, "team-a")
+
+	_, ok, err := client.Get(ctx, "embeddings/model:hash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, client.Set(ctx, "embeddings/model:hash", []byte("[0.1, 0.2]"), time.Hour))
+	value, ok, err := client.Get(ctx, "embeddings/model:hash")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "[0.1, 0.2]", string(value))
+
+	// the tenants do not see the entries of each other
+	_, ok, err = newTestClient(t, server.URL, "team-b").Get(ctx, "embeddings/model:hash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.ErrorIs(t, client.Set(ctx, "large", []byte(strings.Repeat("a", 17)), 0), ErrQuotaExceeded)
+
+	resp, err := http.Get(server.URL + MetricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	metrics, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(metrics), `kserve_shared_cache_hits_total{tenant="team-a"} 1`)
+	assert.Contains(t, string(metrics), `kserve_shared_cache_misses_total{tenant="team-b"} 1`)
+	assert.Contains(t, string(metrics), `kserve_shared_cache_rejected_total{tenant="team-a"} 1`)
+}
+
+func TestHandlerInvalidRequests(t *testing.T) {
+	handler := New(16, nil, 10).Handler(newTestClientset())
+	for _, tc := range []struct {
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{http.MethodGet, EntriesPath, "team-a", http.StatusBadRequest},
+		{http.MethodPut, EntriesPath + "key?ttl=soon", "team-a", http.StatusBadRequest},
+		{http.MethodDelete, EntriesPath + "key", "team-a", http.StatusMethodNotAllowed},
+		{http.MethodGet, EntriesPath + "key", "", http.StatusUnauthorized},
+		{http.MethodGet, EntriesPath + "key", "invalid", http.StatusUnauthorized},
+		// the tenants are the namespaces of service accounts
+		{http.MethodGet, EntriesPath + "key", "user", http.StatusForbidden},
+	} {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader("value"))
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, tc.status, recorder.Code, tc.method+" "+tc.path+" "+tc.token)
+	}
+}
+
+func TestTenantAuthenticatorCachesReviews(t *testing.T) {
+	clientset := newTestClientset()
+	authenticator := newTenantAuthenticator(clientset)
+	now := time.Now()
+	authenticator.now = func() time.Time { return now }
+	req := httptest.NewRequest(http.MethodGet, EntriesPath+"key", nil)
+	req.Header.Set("Authorization", "Bearer team-a")
+
+	for range 2 {
+		tenant, _, err := authenticator.authenticate(req)
+		require.NoError(t, err)
+		assert.Equal(t, "team-a", tenant)
+	}
+	assert.Len(t, clientset.Actions(), 1)
+
+	// the token is reviewed again once the review expired
+	now = now.Add(tokenReviewTTL)
+	_, _, err := authenticator.authenticate(req)
+	require.NoError(t, err)
+	assert.Len(t, clientset.Actions(), 2)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultClientTimeout bounds the calls to the shared cache, a slow cache must not slow down the requests it caches
+// the results of
+const DefaultClientTimeout = time.Second
+
+// Client reads and writes the entries of the tenant of the caller in the shared cache
+type Client struct {
+	url        string
+	tokenPath  string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the shared cache served at the url, authenticating with the projected service account
+// token at the token path. The tenant is the namespace of the service account.
+func NewClient(url string, tokenPath string) *Client {
+	return &Client{
+		url:        url,
+		tokenPath:  tokenPath,
+		httpClient: &http.Client{Timeout: DefaultClientTimeout},
+	}
+}
+
+// newRequest returns a request of the entry of the key authenticated with the projected token, the token is read on
+// each request as it is rotated
+func (c *Client) newRequest(ctx context.Context, method string, key string, query string, body io.Reader) (*http.Request, error) {
+	token, err := os.ReadFile(c.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the shared cache token: %w", err)
+	}
+	entryURL := c.url + EntriesPath + url.PathEscape(key)
+	if query != "" {
+		entryURL += "?" + query
+	}
+	req, err := http.NewRequestWithContext(ctx, method, entryURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return req, nil
+}
+
+// Get returns the value of the key, and whether it was found
+func (c *Client) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		value, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, false, err
+		}
+		return value, true, nil
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unexpected status %d of the shared cache", resp.StatusCode)
+	}
+}
+
+// Set stores the value of the key for the ttl, a ttl of 0 never expires
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	query := ""
+	if ttl > 0 {
+		query = TTLParam + "=" + strconv.FormatInt(int64(ttl.Seconds()), 10)
+	}
+	req, err := c.newRequest(ctx, http.MethodPut, key, query, bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusRequestEntityTooLarge:
+		return ErrQuotaExceeded
+	case http.StatusInsufficientStorage:
+		return ErrTooManyTenants
+	default:
+		return fmt.Errorf("unexpected status %d of the shared cache", resp.StatusCode)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlspolicy enforces the minimum TLS version and the cipher suites of the TLS endpoints of the manager, the
+// agent and the router. In FIPS mode, only the FIPS-approved cipher suites and curves are negotiated.
+package tlspolicy
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// MinVersionFlag is the flag setting the minimum TLS version, 1.2 or 1.3
+	MinVersionFlag = "tls-min-version"
+	// CipherSuitesFlag is the flag setting the comma separated TLS 1.2 cipher suites, the Go defaults when empty
+	CipherSuitesFlag = "tls-cipher-suites"
+	// FIPSModeFlag is the flag restricting the cipher suites and curves to the FIPS-approved ones
+	FIPSModeFlag = "fips-mode"
+
+	// DefaultMinVersion is the minimum TLS version when it is not set
+	DefaultMinVersion = "1.2"
+)
+
+// versions are the TLS versions which can be set as the minimum one
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// FIPSCipherSuites are the FIPS-approved TLS 1.2 cipher suites, used by default in FIPS mode. The TLS 1.3 cipher
+// suites are not configurable, and all of them but TLS_CHACHA20_POLY1305_SHA256 are approved.
+var FIPSCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// fipsCurves are the FIPS-approved key exchange curves
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// Options are the TLS policy settings passed on the command line
+type Options struct {
+	MinVersion   string
+	CipherSuites string
+	FIPSMode     bool
+}
+
+// FlagSet is implemented by both the flag and the pflag flag sets
+type FlagSet interface {
+	StringVar(p *string, name string, value string, usage string)
+	BoolVar(p *bool, name string, value bool, usage string)
+}
+
+// BindFlags registers the TLS policy flags
+func (o *Options) BindFlags(fs FlagSet) {
+	fs.StringVar(&o.MinVersion, MinVersionFlag, DefaultMinVersion,
+		"The minimum TLS version of the TLS endpoints, 1.2 or 1.3.")
+	fs.StringVar(&o.CipherSuites, CipherSuitesFlag, "",
+		"Comma separated TLS 1.2 cipher suites of the TLS endpoints, the Go defaults when empty, "+
+			"or the FIPS-approved ones in FIPS mode.")
+	fs.BoolVar(&o.FIPSMode, FIPSModeFlag, false,
+		"Only negotiate the FIPS-approved cipher suites and curves. Always enabled in the fips builds "+
+			"and when the Go FIPS 140-3 mode is on.")
+}
+
+// Policy is a validated TLS policy
+type Policy struct {
+	minVersion   uint16
+	cipherSuites []uint16
+	fips         bool
+}
+
+// New validates the options and returns their policy. The options which would downgrade the security of the
+// endpoints, i.e. a TLS version below 1.2, an insecure cipher suite or a cipher suite which is not FIPS-approved in
+// FIPS mode, are rejected rather than ignored.
+func New(opts Options) (*Policy, error) {
+	policy := &Policy{fips: opts.FIPSMode || fipsBuild || fips140.Enabled()}
+
+	version := opts.MinVersion
+	if version == "" {
+		version = DefaultMinVersion
+	}
+	minVersion, ok := versions[version]
+	if !ok {
+		if version == "1.0" || version == "1.1" {
+			return nil, fmt.Errorf("the minimum TLS version %s is not allowed, it must be 1.2 or higher", version)
+		}
+		return nil, fmt.Errorf("unknown minimum TLS version %q, it must be 1.2 or 1.3", version)
+	}
+	policy.minVersion = minVersion
+
+	var errs []error
+	for _, name := range strings.Split(opts.CipherSuites, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, err := cipherSuiteID(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if policy.fips && !slices.Contains(FIPSCipherSuites, id) {
+			errs = append(errs, fmt.Errorf("the cipher suite %s is not FIPS-approved", name))
+			continue
+		}
+		policy.cipherSuites = append(policy.cipherSuites, id)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(policy.cipherSuites) > 0 && policy.minVersion == tls.VersionTLS13 {
+		return nil, errors.New("the cipher suites cannot be set with the minimum TLS version 1.3, " +
+			"the TLS 1.3 cipher suites are not configurable")
+	}
+	if len(policy.cipherSuites) == 0 && policy.fips && policy.minVersion == tls.VersionTLS12 {
+		policy.cipherSuites = FIPSCipherSuites
+	}
+	return policy, nil
+}
+
+// cipherSuiteID returns the ID of a secure TLS 1.2 cipher suite
+func cipherSuiteID(name string) (uint16, error) {
+	for _, suite := range tls.InsecureCipherSuites() {
+		if suite.Name == name {
+			return 0, fmt.Errorf("the cipher suite %s is insecure", name)
+		}
+	}
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name != name {
+			continue
+		}
+		if !slices.Contains(suite.SupportedVersions, tls.VersionTLS12) {
+			return 0, fmt.Errorf("the cipher suite %s is a TLS 1.3 cipher suite, which is not configurable", name)
+		}
+		return suite.ID, nil
+	}
+	return 0, fmt.Errorf("unknown cipher suite %q", name)
+}
+
+// FIPS returns whether only the FIPS-approved cipher suites and curves are negotiated
+func (p *Policy) FIPS() bool {
+	return p != nil && p.fips
+}
+
+// Apply enforces the policy on a TLS config. A stricter minimum version of the config is kept. It can be passed to
+// the TLSOpts of the controller-runtime servers.
+func (p *Policy) Apply(config *tls.Config) {
+	if p == nil {
+		return
+	}
+	if config.MinVersion < p.minVersion {
+		config.MinVersion = p.minVersion
+	}
+	if len(p.cipherSuites) > 0 {
+		config.CipherSuites = slices.Clone(p.cipherSuites)
+	}
+	if p.fips {
+		config.CurvePreferences = slices.Clone(fipsCurves)
+	}
+}
+
+// Args returns the flags passing the policy on to the agent and the router. The default policy has no flags, so that
+// their pods are not changed.
+func (p *Policy) Args() []string {
+	if p == nil {
+		return nil
+	}
+	var args []string
+	if p.minVersion != versions[DefaultMinVersion] {
+		for version, id := range versions {
+			if id == p.minVersion {
+				args = append(args, "--"+MinVersionFlag, version)
+			}
+		}
+	}
+	if len(p.cipherSuites) > 0 && !(p.fips && slices.Equal(p.cipherSuites, FIPSCipherSuites)) {
+		names := make([]string, 0, len(p.cipherSuites))
+		for _, id := range p.cipherSuites {
+			names = append(names, tls.CipherSuiteName(id))
+		}
+		args = append(args, "--"+CipherSuitesFlag, strings.Join(names, ","))
+	}
+	if p.fips {
+		args = append(args, "--"+FIPSModeFlag)
+	}
+	return args
+}
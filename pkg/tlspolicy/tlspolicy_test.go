@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlspolicy
+
+import (
+	"crypto/tls"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		opts    Options
+		wantErr string
+	}{
+		"default": {
+			opts: Options{},
+		},
+		"tls 1.3": {
+			opts: Options{MinVersion: "1.3"},
+		},
+		"tls 1.1 is a downgrade": {
+			opts:    Options{MinVersion: "1.1"},
+			wantErr: "the minimum TLS version 1.1 is not allowed",
+		},
+		"unknown version": {
+			opts:    Options{MinVersion: "2"},
+			wantErr: `unknown minimum TLS version "2"`,
+		},
+		"secure cipher suites": {
+			opts: Options{CipherSuites: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+		},
+		"insecure cipher suite": {
+			opts:    Options{CipherSuites: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_RSA_WITH_RC4_128_SHA"},
+			wantErr: "the cipher suite TLS_RSA_WITH_RC4_128_SHA is insecure",
+		},
+		"unknown cipher suite": {
+			opts:    Options{CipherSuites: "TLS_NULL"},
+			wantErr: `unknown cipher suite "TLS_NULL"`,
+		},
+		"tls 1.3 cipher suite": {
+			opts:    Options{CipherSuites: "TLS_AES_128_GCM_SHA256"},
+			wantErr: "is a TLS 1.3 cipher suite",
+		},
+		"cipher suites with tls 1.3": {
+			opts:    Options{MinVersion: "1.3", CipherSuites: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			wantErr: "the cipher suites cannot be set with the minimum TLS version 1.3",
+		},
+		"fips cipher suite": {
+			opts: Options{FIPSMode: true, CipherSuites: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+		},
+		"cipher suite which is not fips-approved": {
+			opts:    Options{FIPSMode: true, CipherSuites: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+			wantErr: "the cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not FIPS-approved",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tt.opts)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	policy, err := New(Options{FIPSMode: true})
+	require.NoError(t, err)
+	config := &tls.Config{}
+	policy.Apply(config)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, FIPSCipherSuites, config.CipherSuites)
+	assert.Equal(t, []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}, config.CurvePreferences)
+
+	// A stricter minimum version is kept
+	config = &tls.Config{MinVersion: tls.VersionTLS13}
+	policy.Apply(config)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+
+	// The nil policy does not change the config
+	config = &tls.Config{}
+	(*Policy)(nil).Apply(config)
+	assert.Equal(t, &tls.Config{}, config)
+}
+
+func TestPolicyArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts := Options{}
+	opts.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{"--tls-cipher-suites", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}))
+	policy, err := New(opts)
+	require.NoError(t, err)
+	if policy.FIPS() {
+		t.Skip("the FIPS mode is enabled by the build or the environment")
+	}
+	assert.Equal(t, []string{"--tls-cipher-suites", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}, policy.Args())
+
+	// The default policy has no arguments
+	policy, err = New(Options{MinVersion: DefaultMinVersion})
+	require.NoError(t, err)
+	assert.Empty(t, policy.Args())
+
+	// The arguments of a policy parse back to the same policy
+	policy, err = New(Options{MinVersion: "1.3", FIPSMode: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--tls-min-version", "1.3", "--fips-mode"}, policy.Args())
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	opts = Options{}
+	opts.BindFlags(fs)
+	require.NoError(t, fs.Parse(policy.Args()))
+	parsed, err := New(opts)
+	require.NoError(t, err)
+	assert.Equal(t, policy, parsed)
+}
@@ -28,4 +28,7 @@ type StorageInitializerConfig struct {
 	MemoryModelcar          string `json:"memoryModelcar"`
 	EnableOciImageSource    bool   `json:"enableModelcar"`
 	UidModelcar             *int64 `json:"uidModelcar"`
+	// ChecksumCacheHostPath is the node directory caching the model files by checksum, so that the re-deployments of
+	// a model with a manifest only download the changed files. The checksum cache is disabled when empty.
+	ChecksumCacheHostPath string `json:"checksumCacheHostPath,omitempty"`
 }
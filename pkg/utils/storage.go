@@ -18,6 +18,7 @@ package utils
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +35,23 @@ type StorageMountParams struct {
 	VolumeName string
 	PVCName    string
 	ReadOnly   bool
+	// ReadOnlyClaim mounts the PVC read-only for all the containers of the pod
+	ReadOnlyClaim bool
+}
+
+// IsSharedModelLibrary returns true when the PVC is a library of models shared by many InferenceServices
+func IsSharedModelLibrary(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Annotations[constants.SharedModelLibraryAnnotationKey] == "true"
+}
+
+// PvcSubPathsCollide returns true when the two sub paths of a PVC are the same or when one contains the other.
+func PvcSubPathsCollide(subPath, otherSubPath string) bool {
+	subPath = path.Clean("/" + subPath)
+	otherSubPath = path.Clean("/" + otherSubPath)
+	if subPath == "/" || otherSubPath == "/" || subPath == otherSubPath {
+		return true
+	}
+	return strings.HasPrefix(subPath, otherSubPath+"/") || strings.HasPrefix(otherSubPath, subPath+"/")
 }
 
 // ParsePvcURI parses a PVC URI of the form "pvc://<name>[/path]" into its components.
@@ -110,6 +128,7 @@ func AddModelMount(storageMountParams StorageMountParams, containerName string,
 		volumeSource = corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 				ClaimName: storageMountParams.PVCName,
+				ReadOnly:  storageMountParams.ReadOnlyClaim,
 			},
 		}
 	} else {
@@ -0,0 +1,382 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpucapability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/migpartition"
+)
+
+var log = logf.Log.WithName(constants.GPUCapabilityValidatorWebhookName)
+
+const (
+	GPUCapabilityError       = "the model of the InferenceService %q cannot fit on the GPUs of any targeted node, it requires %s, available GPUs: %s"
+	InvalidGPUMemoryError    = "invalid value %q of annotation %s: %v"
+	gpuMemoryRequirement     = "%s of memory per GPU"
+	computeCapabilityMessage = "compute capability %s for dtype %s"
+)
+
+// MIGPartitionUnavailableError is returned when no targeted node can provide the MIG profile of the predictor
+const MIGPartitionUnavailableError = "the MIG profile %s requested by the InferenceService %q is not available, no targeted node with %s GPUs is partitioned with it or free to be partitioned"
+
+// computeCapability is the CUDA compute capability of a GPU
+type computeCapability struct {
+	major int
+	minor int
+}
+
+func (c computeCapability) less(other computeCapability) bool {
+	return c.major < other.major || (c.major == other.major && c.minor < other.minor)
+}
+
+func (c computeCapability) String() string {
+	return fmt.Sprintf("%d.%d", c.major, c.minor)
+}
+
+// dtypeComputeCapabilities is the minimum compute capability of the GPUs supporting a dtype natively
+var dtypeComputeCapabilities = map[string]computeCapability{
+	"bfloat16": {major: 8, minor: 0},
+	"bf16":     {major: 8, minor: 0},
+	"float8":   {major: 8, minor: 9},
+	"fp8":      {major: 8, minor: 9},
+	"fp8_e4m3": {major: 8, minor: 9},
+	"fp8_e5m2": {major: 8, minor: 9},
+}
+
+// modelRequirements are the requirements of the model on each GPU of the predictor
+type modelRequirements struct {
+	gpuMemory *resource.Quantity
+	dtype     string
+	// minimum compute capability required by the dtype
+	computeCapability *computeCapability
+}
+
+func (r *modelRequirements) String() string {
+	requirements := []string{}
+	if r.gpuMemory != nil {
+		requirements = append(requirements, fmt.Sprintf(gpuMemoryRequirement, r.gpuMemory.String()))
+	}
+	if r.computeCapability != nil {
+		requirements = append(requirements, fmt.Sprintf(computeCapabilityMessage, r.computeCapability, r.dtype))
+	}
+	return strings.Join(requirements, " and ")
+}
+
+// nodeGPU is the GPU of a node, as advertised by the NVIDIA GPU feature discovery labels
+type nodeGPU struct {
+	product           string
+	memory            resource.Quantity
+	computeCapability *computeCapability
+}
+
+func (g *nodeGPU) fits(requirements *modelRequirements) bool {
+	if requirements.gpuMemory != nil && g.memory.Cmp(*requirements.gpuMemory) < 0 {
+		return false
+	}
+	// GPUs without compute capability label are assumed to support the dtype
+	if requirements.computeCapability != nil && g.computeCapability != nil && g.computeCapability.less(*requirements.computeCapability) {
+		return false
+	}
+	return true
+}
+
+func (g *nodeGPU) String() string {
+	if g.computeCapability == nil {
+		return fmt.Sprintf("%s (%s)", g.product, g.memory.String())
+	}
+	return fmt.Sprintf("%s (%s, compute capability %s)", g.product, g.memory.String(), g.computeCapability)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1beta1-inferenceservice-gpu,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,versions=v1beta1,name=inferenceservice.kserve-webhook-server.gpu-validator
+
+// InferenceServiceGPUValidator compares the requirements of the model of an InferenceService, like the GPU memory
+// and the dtype, against the GPUs of the nodes targeted by the predictor, so that a model which cannot fit on any of
+// them fails fast at admission instead of staying unschedulable or crashing at load time. When the MIG partitioning is
+// enabled, it also rejects the InferenceServices requesting a MIG profile no targeted node can provide.
+type InferenceServiceGPUValidator struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	Decoder   admission.Decoder
+}
+
+// Handle validates the model requirements of the incoming InferenceService against the GPUs of the targeted nodes
+func (v *InferenceServiceGPUValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	isvc := &v1beta1.InferenceService{}
+	if err := v.Decoder.Decode(req, isvc); err != nil {
+		log.Error(err, "Failed to decode inference service", "name", isvc.Name, "namespace", isvc.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	isvcConfigMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, v.Clientset)
+	if err != nil {
+		log.Error(err, "Failed to get inference service configmap")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	isvcConfig, err := v1beta1.NewInferenceServicesConfig(isvcConfigMap)
+	if err != nil {
+		log.Error(err, "Failed to create inference service config")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	denied, err := v.validateMIGPartitions(ctx, isvc, isvcConfigMap)
+	if err != nil {
+		log.Error(err, "Failed to validate the MIG partitions")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if denied != "" {
+		return admission.Denied(denied)
+	}
+
+	policy := isvcConfig.GPUCapabilityValidation
+	if policy == v1beta1.GPUCapabilityValidationDisabled {
+		return admission.Allowed("")
+	}
+
+	gpus := predictorGPUs(isvc)
+	if gpus == 0 {
+		return admission.Allowed("")
+	}
+	requirements, err := v.getModelRequirements(ctx, isvc, gpus)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	if requirements.gpuMemory == nil && requirements.computeCapability == nil {
+		return admission.Allowed("")
+	}
+
+	nodeGPUs, err := v.getTargetedNodeGPUs(ctx, isvc)
+	if err != nil {
+		log.Error(err, "Failed to list nodes")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	// The GPUs of the nodes are not known
+	if len(nodeGPUs) == 0 {
+		return admission.Allowed("")
+	}
+	available := []string{}
+	for _, gpu := range nodeGPUs {
+		if gpu.fits(requirements) {
+			return admission.Allowed("")
+		}
+		available = append(available, gpu.String())
+	}
+	msg := fmt.Sprintf(GPUCapabilityError, isvc.Name, requirements, strings.Join(available, ", "))
+	if policy == v1beta1.GPUCapabilityValidationEnforce {
+		return admission.Denied(msg)
+	}
+	return admission.Allowed("").WithWarnings(msg)
+}
+
+// validateMIGPartitions returns why the InferenceService is rejected when the MIG partitioning is enabled and no node
+// targeted by the predictor can provide the MIG profile it requests
+func (v *InferenceServiceGPUValidator) validateMIGPartitions(ctx context.Context, isvc *v1beta1.InferenceService, isvcConfigMap *corev1.ConfigMap) (string, error) {
+	config, err := v1beta1.NewMIGPartitioningConfig(isvcConfigMap)
+	if err != nil {
+		return "", err
+	}
+	if !config.Enabled {
+		return "", nil
+	}
+	profile, _, err := migpartition.Profile(isvc)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if profile == "" {
+		return "", nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := v.Client.List(ctx, nodes); err != nil {
+		return "", err
+	}
+	gpuPodNodes, err := migpartition.GPUPodNodes(ctx, v.Clientset)
+	if err != nil {
+		return "", err
+	}
+	if len(migpartition.AvailableNodes(nodes.Items, gpuPodNodes, isvc, config, profile)) == 0 {
+		return fmt.Sprintf(MIGPartitionUnavailableError, profile, isvc.Name, strings.Join(config.Products, " or ")), nil
+	}
+	return "", nil
+}
+
+// getModelRequirements returns the requirements of the model on each GPU of the predictor. The GPU memory is taken
+// from the annotation, or derived from the size of the cached model when the model is cached on the nodes. The dtype
+// is taken from the annotation, or from the --dtype argument of the predictor.
+func (v *InferenceServiceGPUValidator) getModelRequirements(ctx context.Context, isvc *v1beta1.InferenceService, gpus int64) (*modelRequirements, error) {
+	requirements := &modelRequirements{}
+	if value, ok := isvc.Annotations[constants.ModelMinGPUMemoryAnnotationKey]; ok {
+		gpuMemory, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf(InvalidGPUMemoryError, value, constants.ModelMinGPUMemoryAnnotationKey, err)
+		}
+		requirements.gpuMemory = &gpuMemory
+	} else if modelName, ok := isvc.Labels[constants.LocalModelLabel]; ok {
+		localModel := &v1alpha1.LocalModelCache{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: modelName}, localModel); err != nil {
+			log.Info("Cannot get the LocalModelCache of the InferenceService, skipping the GPU memory validation",
+				"name", isvc.Name, "namespace", isvc.Namespace, "localmodel", modelName, "error", err.Error())
+		} else {
+			// the weights are sharded across all the GPUs of the head and worker nodes
+			totalGPUs := gpus * pipelineParallelSize(isvc)
+			requirements.gpuMemory = resource.NewQuantity(localModel.Spec.ModelSize.Value()/totalGPUs, resource.BinarySI)
+		}
+	}
+
+	dtype, ok := isvc.Annotations[constants.ModelDtypeAnnotationKey]
+	if !ok {
+		dtype = predictorDtypeArg(isvc)
+	}
+	requirements.dtype = strings.ToLower(dtype)
+	if capability, ok := dtypeComputeCapabilities[requirements.dtype]; ok {
+		requirements.computeCapability = &capability
+	}
+	return requirements, nil
+}
+
+// getTargetedNodeGPUs returns the distinct GPUs of the nodes matching the node selector and the required node
+// affinity of the predictor
+func (v *InferenceServiceGPUValidator) getTargetedNodeGPUs(ctx context.Context, isvc *v1beta1.InferenceService) ([]*nodeGPU, error) {
+	nodes := &corev1.NodeList{}
+	if err := v.Client.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeSelector: isvc.Spec.Predictor.NodeSelector,
+			Affinity:     isvc.Spec.Predictor.Affinity,
+		},
+	}
+	requiredAffinity := nodeaffinity.GetRequiredNodeAffinity(pod)
+	gpus := map[string]*nodeGPU{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		gpu := getNodeGPU(node)
+		if gpu == nil {
+			continue
+		}
+		if match, err := requiredAffinity.Match(node); err != nil || !match {
+			continue
+		}
+		gpus[gpu.String()] = gpu
+	}
+	keys := make([]string, 0, len(gpus))
+	for key := range gpus {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]*nodeGPU, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, gpus[key])
+	}
+	return result, nil
+}
+
+// getNodeGPU returns the GPU of a node, or nil when the node does not advertise its GPU memory
+func getNodeGPU(node *corev1.Node) *nodeGPU {
+	memory, err := strconv.ParseInt(node.Labels[constants.NvidiaGPUMemoryLabel], 10, 64)
+	if err != nil {
+		return nil
+	}
+	gpu := &nodeGPU{
+		product: node.Labels[constants.NvidiaGPUProductLabel],
+		memory:  *resource.NewQuantity(memory*1024*1024, resource.BinarySI),
+	}
+	major, majorErr := strconv.Atoi(node.Labels[constants.NvidiaGPUComputeMajorLabel])
+	minor, minorErr := strconv.Atoi(node.Labels[constants.NvidiaGPUComputeMinorLabel])
+	if majorErr == nil && minorErr == nil {
+		gpu.computeCapability = &computeCapability{major: major, minor: minor}
+	}
+	return gpu
+}
+
+// predictorGPUs returns the number of NVIDIA GPUs requested by a predictor pod
+func predictorGPUs(isvc *v1beta1.InferenceService) int64 {
+	type resourceRequirementsGetter interface {
+		GetResourceRequirements() *corev1.ResourceRequirements
+	}
+	gpus := int64(0)
+	predictor := &isvc.Spec.Predictor
+	// Custom implementations are backed by the pod spec containers, which are counted below
+	for _, implementation := range predictor.GetImplementations() {
+		if getter, ok := implementation.(resourceRequirementsGetter); ok {
+			gpus += containerGPUs(*getter.GetResourceRequirements())
+		}
+	}
+	for _, container := range predictor.Containers {
+		gpus += containerGPUs(container.Resources)
+	}
+	return gpus
+}
+
+func containerGPUs(requirements corev1.ResourceRequirements) int64 {
+	if quantity, ok := requirements.Limits[constants.NvidiaGPUResourceType]; ok {
+		return quantity.Value()
+	}
+	if quantity, ok := requirements.Requests[constants.NvidiaGPUResourceType]; ok {
+		return quantity.Value()
+	}
+	return 0
+}
+
+func pipelineParallelSize(isvc *v1beta1.InferenceService) int64 {
+	workerSpec := isvc.Spec.Predictor.WorkerSpec
+	if workerSpec == nil {
+		return 1
+	}
+	if workerSpec.PipelineParallelSize != nil && *workerSpec.PipelineParallelSize > 0 {
+		return int64(*workerSpec.PipelineParallelSize)
+	}
+	return int64(constants.DefaultPipelineParallelSize)
+}
+
+// predictorDtypeArg returns the value of the --dtype argument of the predictor model server
+func predictorDtypeArg(isvc *v1beta1.InferenceService) string {
+	args := []string{}
+	if isvc.Spec.Predictor.Model != nil {
+		args = append(args, isvc.Spec.Predictor.Model.Args...)
+	}
+	for _, container := range isvc.Spec.Predictor.Containers {
+		if container.Name == constants.InferenceServiceContainerName {
+			args = append(args, container.Args...)
+		}
+	}
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--dtype="); ok {
+			return value
+		}
+		if arg == "--dtype" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpucapability
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeTestInferenceService(gpus string, annotations map[string]string, args ...string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "llama",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "huggingface"},
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: ptr.To("hf://meta-llama/llama"),
+						Container: corev1.Container{
+							Args: args,
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									constants.NvidiaGPUResourceType: resource.MustParse(gpus),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeTestNode(name string, product string, memoryMiB int, major int, minor int, labels map[string]string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constants.NvidiaGPUProductLabel:      product,
+				constants.NvidiaGPUMemoryLabel:       fmt.Sprint(memoryMiB),
+				constants.NvidiaGPUComputeMajorLabel: fmt.Sprint(major),
+				constants.NvidiaGPUComputeMinorLabel: fmt.Sprint(minor),
+			},
+		},
+	}
+	for key, value := range labels {
+		node.Labels[key] = value
+	}
+	return node
+}
+
+func makeTestConfigMap(policy v1beta1.GPUCapabilityValidationPolicy) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.InferenceServiceConfigMapName,
+			Namespace: constants.KServeNamespace,
+		},
+		Data: map[string]string{
+			v1beta1.InferenceServiceConfigKeyName: fmt.Sprintf(`{"gpuCapabilityValidation": %q}`, policy),
+		},
+	}
+}
+
+func makeRequest(t *testing.T, isvc *v1beta1.InferenceService) admission.Request {
+	raw, err := json.Marshal(isvc)
+	if err != nil {
+		t.Fatalf("unable to marshal inference service: %v", err)
+	}
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: isvc.Namespace,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestInferenceServiceGPUValidator_Handle(t *testing.T) {
+	t4 := makeTestNode("t4", "Tesla-T4", 15360, 7, 5, nil)
+	a10g := makeTestNode("a10g", "NVIDIA-A10G", 23028, 8, 6, nil)
+	a100 := makeTestNode("a100", "NVIDIA-A100-SXM4-80GB", 81920, 8, 0, map[string]string{"pool": "training"})
+	cpuNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu"}}
+	localModel := &v1alpha1.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama"},
+		Spec: v1alpha1.LocalModelCacheSpec{
+			SourceModelUri: "hf://meta-llama/llama",
+			ModelSize:      resource.MustParse("60Gi"),
+			NodeGroups:     []string{"gpu"},
+		},
+	}
+
+	scenarios := map[string]struct {
+		policy          v1beta1.GPUCapabilityValidationPolicy
+		objects         []client.Object
+		isvc            *v1beta1.InferenceService
+		allowed         bool
+		expectedWarning bool
+	}{
+		"fits on a targeted node": {
+			objects: []client.Object{t4, a10g},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "20Gi"}),
+			allowed: true,
+		},
+		"not enough GPU memory is a warning by default": {
+			objects:         []client.Object{t4, a10g},
+			isvc:            makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"}),
+			allowed:         true,
+			expectedWarning: true,
+		},
+		"not enough GPU memory is rejected when enforced": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{t4, a10g},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"}),
+			allowed: false,
+		},
+		"not validated when disabled": {
+			policy:  v1beta1.GPUCapabilityValidationDisabled,
+			objects: []client.Object{t4, a10g},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"}),
+			allowed: true,
+		},
+		"dtype from the model server arguments is not supported": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{t4},
+			isvc:    makeTestInferenceService("1", nil, "--model_name=llama", "--dtype=bfloat16"),
+			allowed: false,
+		},
+		"dtype annotation is supported": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{t4, a10g},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelDtypeAnnotationKey: "bfloat16"}),
+			allowed: true,
+		},
+		"node selector excludes the fitting nodes": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{a10g, a100},
+			isvc: func() *v1beta1.InferenceService {
+				isvc := makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"})
+				isvc.Spec.Predictor.NodeSelector = map[string]string{"pool": "inference"}
+				return isvc
+			}(),
+			// no node with GPU labels is targeted
+			allowed: true,
+		},
+		"GPU memory derived from the cached model size": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{a10g, localModel},
+			isvc: func() *v1beta1.InferenceService {
+				isvc := makeTestInferenceService("2", nil)
+				isvc.Labels = map[string]string{constants.LocalModelLabel: localModel.Name}
+				return isvc
+			}(),
+			allowed: false,
+		},
+		"cached model sharded across enough GPUs": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{a10g, localModel},
+			isvc: func() *v1beta1.InferenceService {
+				isvc := makeTestInferenceService("4", nil)
+				isvc.Labels = map[string]string{constants.LocalModelLabel: localModel.Name}
+				return isvc
+			}(),
+			allowed: true,
+		},
+		"no GPU requested": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{t4},
+			isvc:    makeTestInferenceService("0", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"}),
+			allowed: true,
+		},
+		"nodes without GPU labels": {
+			policy:  v1beta1.GPUCapabilityValidationEnforce,
+			objects: []client.Object{cpuNode},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "40Gi"}),
+			allowed: true,
+		},
+		"invalid GPU memory annotation": {
+			objects: []client.Object{t4},
+			isvc:    makeTestInferenceService("1", map[string]string{constants.ModelMinGPUMemoryAnnotationKey: "forty"}),
+			allowed: false,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+			g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			validator := &InferenceServiceGPUValidator{
+				Client:    fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.objects...).Build(),
+				Clientset: k8sfake.NewSimpleClientset(makeTestConfigMap(scenario.policy)),
+				Decoder:   admission.NewDecoder(s),
+			}
+			response := validator.Handle(t.Context(), makeRequest(t, scenario.isvc))
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			if scenario.expectedWarning {
+				g.Expect(response.Warnings).To(gomega.HaveLen(1))
+			} else {
+				g.Expect(response.Warnings).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
+
+func TestInferenceServiceGPUValidator_MIGPartitions(t *testing.T) {
+	a100 := makeTestNode("a100", "NVIDIA-A100-SXM4-40GB", 40960, 8, 0, nil)
+	h100 := makeTestNode("h100", "NVIDIA-H100-80GB-HBM3", 81559, 9, 0, nil)
+	t4 := makeTestNode("t4", "Tesla-T4", 15360, 7, 5, nil)
+	claimed := makeTestNode("h100-claimed", "NVIDIA-H100-80GB-HBM3", 81559, 9, 0, map[string]string{
+		constants.NvidiaMigConfigLabel: "all-1g.10gb",
+	})
+	claimed.Annotations = map[string]string{constants.MIGPartitionOwnersAnnotationKey: "default/other"}
+	makeMIGInferenceService := func(resources ...string) *v1beta1.InferenceService {
+		isvc := makeTestInferenceService("0", nil)
+		limits := corev1.ResourceList{}
+		for _, name := range resources {
+			limits[corev1.ResourceName(name)] = resource.MustParse("1")
+		}
+		isvc.Spec.Predictor.Model.Resources.Limits = limits
+		return isvc
+	}
+
+	scenarios := map[string]struct {
+		disabled bool
+		objects  []client.Object
+		isvc     *v1beta1.InferenceService
+		allowed  bool
+	}{
+		"free node can be partitioned": {
+			objects: []client.Object{t4, a100},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-3g.20gb"),
+			allowed: true,
+		},
+		"profile larger than the GPUs": {
+			objects: []client.Object{t4, a100},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-7g.80gb"),
+			allowed: false,
+		},
+		"profile fitting the GPUs of the H100 nodes": {
+			objects: []client.Object{a100, h100},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-7g.80gb"),
+			allowed: true,
+		},
+		"node partitioned with another profile for another InferenceService": {
+			objects: []client.Object{t4, claimed},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-3g.40gb"),
+			allowed: false,
+		},
+		"node partitioned with the profile is shared": {
+			objects: []client.Object{t4, claimed},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-1g.10gb"),
+			allowed: true,
+		},
+		"several profiles": {
+			objects: []client.Object{a100, h100},
+			isvc:    makeMIGInferenceService("nvidia.com/mig-1g.10gb", "nvidia.com/mig-3g.40gb"),
+			allowed: false,
+		},
+		"not validated when disabled": {
+			disabled: true,
+			objects:  []client.Object{t4},
+			isvc:     makeMIGInferenceService("nvidia.com/mig-3g.20gb"),
+			allowed:  true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			configMap := makeTestConfigMap(v1beta1.GPUCapabilityValidationWarn)
+			configMap.Data[v1beta1.MIGPartitioningConfigName] = fmt.Sprintf(`{"enabled": %t}`, !scenario.disabled)
+			validator := &InferenceServiceGPUValidator{
+				Client:    fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.objects...).Build(),
+				Clientset: k8sfake.NewSimpleClientset(configMap),
+				Decoder:   admission.NewDecoder(s),
+			}
+			response := validator.Handle(t.Context(), makeRequest(t, scenario.isvc))
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+		})
+	}
+}
+
+func TestInferenceServiceGPUValidator_Message(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(s)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+	validator := &InferenceServiceGPUValidator{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			makeTestNode("t4-1", "Tesla-T4", 15360, 7, 5, nil),
+			makeTestNode("t4-2", "Tesla-T4", 15360, 7, 5, nil),
+		).Build(),
+		Clientset: k8sfake.NewSimpleClientset(makeTestConfigMap(v1beta1.GPUCapabilityValidationEnforce)),
+		Decoder:   admission.NewDecoder(s),
+	}
+	isvc := makeTestInferenceService("1", map[string]string{
+		constants.ModelMinGPUMemoryAnnotationKey: "24Gi",
+		constants.ModelDtypeAnnotationKey:        "BF16",
+	})
+	response := validator.Handle(t.Context(), makeRequest(t, isvc))
+	g.Expect(response.Allowed).To(gomega.BeFalse())
+	g.Expect(response.Result.Message).To(gomega.Equal(`the model of the InferenceService "llama" cannot fit on the GPUs of any targeted node, ` +
+		`it requires 24Gi of memory per GPU and compute capability 8.0 for dtype bf16, available GPUs: Tesla-T4 (15Gi, compute capability 7.5)`))
+}
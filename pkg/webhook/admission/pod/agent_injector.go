@@ -19,19 +19,32 @@ package pod
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/kserve/kserve/pkg/agent/backpressure"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/agent/watchdog"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/faultinjection"
+	"github.com/kserve/kserve/pkg/profiling"
+	"github.com/kserve/kserve/pkg/tlspolicy"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 const (
@@ -41,6 +54,8 @@ const (
 	LoggerArgumentMode                = "--log-mode"
 	LoggerArgumentStorePath           = "--log-store-path"
 	LoggerArgumentStoreFormat         = "--log-store-format"
+	LoggerArgumentStoreFlushInterval  = "--log-store-flush-interval"
+	LoggerArgumentStoreFlushSize      = "--log-store-flush-size"
 	LoggerArgumentInferenceService    = "--inference-service"
 	LoggerArgumentNamespace           = "--namespace"
 	LoggerArgumentEndpoint            = "--endpoint"
@@ -49,15 +64,49 @@ const (
 	LoggerArgumentTlsSkipVerify       = "--logger-tls-skip-verify"
 	LoggerArgumentMetadataHeaders     = "--metadata-headers"
 	LoggerArgumentMetadataAnnotations = "--metadata-annotations"
+	LoggerArgumentKafkaSecretDir      = "--log-kafka-secret-dir"
+	LoggerArgumentSamplePercent       = "--log-sample-percent"
+	LoggerArgumentErrorSamplePercent  = "--log-error-sample-percent"
+	LoggerArgumentHeaderFilters       = "--log-header-filters"
+	LoggerArgumentStatusCodes         = "--log-status-codes"
+	LoggerArgumentRedactFields        = "--log-redact-fields"
+	LoggerArgumentEncryptionKMSKey    = "--log-encryption-kms-key"
+	LoggerArgumentEncryptionPublicKey = "--log-encryption-public-key"
 	LoggerDefaultServiceAccountName   = "logger-sa"
 )
 
+// The liveness probe of the kserve-container restarting the hung runtime, which fails for 30s before the restart
+const (
+	watchdogLivenessPeriodSeconds    = 10
+	watchdogLivenessFailureThreshold = 3
+)
+
 type AgentConfig struct {
 	Image         string `json:"image"`
 	CpuRequest    string `json:"cpuRequest"`
 	CpuLimit      string `json:"cpuLimit"`
 	MemoryRequest string `json:"memoryRequest"`
 	MemoryLimit   string `json:"memoryLimit"`
+	// Headers configures the operations on the request headers, the headers matching the "deny" patterns are
+	// stripped before the requests reach the component and the logger
+	Headers map[string][]string `json:"headers"`
+	// EnableFaultInjection allows the serving.kserve.io/fault-injection annotation to inject errors and latency into
+	// the requests, meant for the resilience testing clusters only
+	EnableFaultInjection bool `json:"enableFaultInjection"`
+	// Backpressure configures the back-pressure headers added to the responses of the InferenceServices annotated
+	// with serving.kserve.io/enable-backpressure-headers
+	Backpressure BackpressureConfig `json:"backpressure"`
+}
+
+// BackpressureConfig configures which back-pressure signals the agent exposes to the clients
+type BackpressureConfig struct {
+	// Signals are the exposed signals, queue-depth, estimated-wait and retry-after, all of them when empty
+	Signals []string `json:"signals,omitempty"`
+	// OnSuccess adds the headers to the successful responses as well as to the 429 and 503 responses
+	OnSuccess bool `json:"onSuccess,omitempty"`
+	// QueueMetrics are the runtime metrics reporting the requests waiting in the queue of the runtime, the first one
+	// the runtime reports is used, the vLLM, Triton and TGI ones when empty
+	QueueMetrics []string `json:"queueMetrics,omitempty"`
 }
 
 type LoggerConfig struct {
@@ -78,6 +127,8 @@ type AgentInjector struct {
 	agentConfig       *AgentConfig
 	loggerConfig      *LoggerConfig
 	batcherConfig     *BatcherConfig
+	// tlsPolicy is the TLS policy of the manager the agent enforces as well
+	tlsPolicy *tlspolicy.Policy
 }
 
 // TODO agent config
@@ -163,8 +214,23 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 	_, injectLogger := pod.ObjectMeta.Annotations[constants.LoggerInternalAnnotationKey]
 	_, injectPuller := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]
 	_, injectBatcher := pod.ObjectMeta.Annotations[constants.BatcherInternalAnnotationKey]
-
-	if !injectLogger && !injectPuller && !injectBatcher {
+	injectMetricsRelabeling := pod.ObjectMeta.Annotations[constants.EnableMetricsRelabelingAnnotationKey] == "true"
+	_, injectExplainerSampling := pod.ObjectMeta.Annotations[constants.ExplainerSamplingPercentInternalAnnotationKey]
+	grpcTranscodingPort, injectGrpcTranscoding := pod.ObjectMeta.Annotations[constants.GrpcTranscodingPortAnnotationKey]
+	nativeProtocol, injectDualProtocol := pod.ObjectMeta.Annotations[constants.DualProtocolInternalAnnotationKey]
+	artifactOutputUri, injectArtifactUpload := pod.ObjectMeta.Annotations[constants.ArtifactOutputUriAnnotationKey]
+	faultInjection, injectFaults := pod.ObjectMeta.Annotations[constants.FaultInjectionAnnotationKey]
+	injectFaults = injectFaults && ag.agentConfig.EnableFaultInjection
+	inspectRequests, injectInspector := pod.ObjectMeta.Annotations[constants.InspectRequestsAnnotationKey]
+	injectBackpressure := pod.ObjectMeta.Annotations[constants.EnableBackpressureHeadersAnnotationKey] == "true"
+	watchdogConfig, injectWatchdog := pod.ObjectMeta.Annotations[constants.WatchdogInternalAnnotationKey]
+	injectGPUMemoryTelemetry := pod.ObjectMeta.Annotations[constants.EnableGPUMemoryTelemetryAnnotationKey] == "true"
+	_, injectSlowStart := pod.ObjectMeta.Annotations[constants.SlowStartWindowInternalAnnotationKey]
+	enableProfiling := pod.ObjectMeta.Annotations[constants.EnableProfilingAnnotationKey] == "true"
+
+	if !injectLogger && !injectPuller && !injectBatcher && !injectMetricsRelabeling && !injectExplainerSampling &&
+		!injectGrpcTranscoding && !injectDualProtocol && !injectArtifactUpload && !injectFaults && !injectInspector &&
+		!injectBackpressure && !injectWatchdog && !injectGPUMemoryTelemetry && !injectSlowStart && !enableProfiling {
 		return nil
 	}
 
@@ -204,6 +270,7 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			args = append(args, BatcherArgumentMaxLatency)
 			args = append(args, maxLatency)
 		}
+		args = append(args, adaptiveBatcherArgs(pod)...)
 	}
 	// Only inject if the logger required annotations are set
 	if injectLogger {
@@ -228,12 +295,16 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			}
 		}
 		storageFormat := ""
+		storageFlushInterval := ""
+		storageFlushSize := ""
 		if ag.loggerConfig.Store != nil {
 			if ag.loggerConfig.Store.Parameters != nil {
 				format, ok := (*ag.loggerConfig.Store.Parameters)[constants.LoggerFormatKey]
 				if ok {
 					storageFormat = format
 				}
+				storageFlushInterval = (*ag.loggerConfig.Store.Parameters)[constants.LoggerFlushIntervalKey]
+				storageFlushSize = (*ag.loggerConfig.Store.Parameters)[constants.LoggerFlushSizeKey]
 			}
 		}
 		loggerArgs := []string{
@@ -260,6 +331,14 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			loggerArgs = append(loggerArgs, LoggerArgumentStoreFormat)
 			loggerArgs = append(loggerArgs, storageFormat)
 		}
+		if storageFlushInterval != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentStoreFlushInterval)
+			loggerArgs = append(loggerArgs, storageFlushInterval)
+		}
+		if storageFlushSize != "" {
+			loggerArgs = append(loggerArgs, LoggerArgumentStoreFlushSize)
+			loggerArgs = append(loggerArgs, storageFlushSize)
+		}
 		logHeaderMetadata, ok := pod.ObjectMeta.Annotations[constants.LoggerMetadataHeadersInternalAnnotationKey]
 		if ok {
 			loggerArgs = append(loggerArgs, LoggerArgumentMetadataHeaders)
@@ -279,6 +358,29 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			}
 			loggerArgs = append(loggerArgs, LoggerArgumentMetadataAnnotations, strings.Join(kvPairs, ","))
 		}
+		if _, ok := pod.ObjectMeta.Annotations[constants.LoggerKafkaSecretInternalAnnotationKey]; ok {
+			loggerArgs = append(loggerArgs, LoggerArgumentKafkaSecretDir, constants.LoggerKafkaSecretMountPath)
+		}
+		for _, filterArg := range []struct {
+			annotation string
+			argument   string
+		}{
+			{constants.LoggerSamplePercentInternalAnnotationKey, LoggerArgumentSamplePercent},
+			{constants.LoggerErrorSamplePercentInternalAnnotationKey, LoggerArgumentErrorSamplePercent},
+			{constants.LoggerHeaderFiltersInternalAnnotationKey, LoggerArgumentHeaderFilters},
+			{constants.LoggerStatusCodesInternalAnnotationKey, LoggerArgumentStatusCodes},
+			{constants.LoggerRedactFieldsInternalAnnotationKey, LoggerArgumentRedactFields},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[filterArg.annotation]; ok {
+				loggerArgs = append(loggerArgs, filterArg.argument, value)
+			}
+		}
+		if kmsKey, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionKMSKeyInternalAnnotationKey]; ok {
+			loggerArgs = append(loggerArgs, LoggerArgumentEncryptionKMSKey, kmsKey)
+		} else if _, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionSecretInternalAnnotationKey]; ok {
+			loggerArgs = append(loggerArgs, LoggerArgumentEncryptionPublicKey,
+				filepath.Join(constants.LoggerEncryptionKeyMountPath, constants.LoggerEncryptionPublicKeyFile))
+		}
 		args = append(args, loggerArgs...)
 
 		// Add TLS cert name if specified. If not specified it will fall back to the arg's default.
@@ -286,6 +388,9 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			args = append(args, LoggerArgumentCaCertFile, ag.loggerConfig.CaCertFile)
 		}
 		// Whether to skip TLS verification. If not present in the ConfigMap, this will default to `false`
+		if ag.loggerConfig.TlsSkipVerify && ag.tlsPolicy.FIPS() {
+			return errors.New("the logger tlsSkipVerify is not allowed in FIPS mode")
+		}
 		args = append(args, LoggerArgumentTlsSkipVerify, strconv.FormatBool(ag.loggerConfig.TlsSkipVerify))
 	}
 
@@ -323,6 +428,83 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 	}
 	args = append(args, constants.AgentComponentPortArgName, componentPort)
 
+	if injectExplainerSampling {
+		args = append(args,
+			constants.AgentExplainerSamplingPercentArgName, pod.ObjectMeta.Annotations[constants.ExplainerSamplingPercentInternalAnnotationKey],
+			constants.AgentExplainerUrlArgName, pod.ObjectMeta.Annotations[constants.ExplainerSamplingUrlInternalAnnotationKey])
+	}
+
+	if injectGrpcTranscoding {
+		if port, err := strconv.Atoi(grpcTranscodingPort); err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid %s annotation %q, it must be a port number",
+				constants.GrpcTranscodingPortAnnotationKey, grpcTranscodingPort)
+		}
+		args = append(args, constants.AgentGrpcTranscodingPortArgName, grpcTranscodingPort)
+	}
+	if injectDualProtocol {
+		args = append(args, constants.AgentDualProtocolArgName, nativeProtocol)
+	}
+	if injectArtifactUpload {
+		if !isArtifactOutputUri(artifactOutputUri) {
+			return fmt.Errorf("invalid %s annotation %q, it must be an %s, %s or %s uri with a bucket",
+				constants.ArtifactOutputUriAnnotationKey, artifactOutputUri, storage.S3, storage.GCS, storage.AZURE)
+		}
+		args = append(args,
+			constants.AgentArtifactOutputUriArgName, artifactOutputUri,
+			constants.AgentArtifactDirArgName, constants.DefaultArtifactDir)
+	}
+	if injectFaults {
+		if _, err := faultinjection.ParseRules(faultInjection); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", constants.FaultInjectionAnnotationKey, err)
+		}
+		args = append(args, constants.AgentFaultInjectionArgName, faultInjection)
+	}
+	if injectInspector {
+		inspectorArgs, err := requestInspectorArgs(pod, inspectRequests)
+		if err != nil {
+			return err
+		}
+		args = append(args, inspectorArgs...)
+	}
+	if injectBackpressure {
+		backpressureArgs, err := ag.backpressureArgs(pod, injectMetricsRelabeling || injectGPUMemoryTelemetry)
+		if err != nil {
+			return err
+		}
+		args = append(args, backpressureArgs...)
+	}
+	if injectWatchdog {
+		config, err := watchdog.ParseConfig(watchdogConfig)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", constants.WatchdogInternalAnnotationKey, err)
+		}
+		args = append(args, constants.AgentWatchdogArgName, watchdogConfig)
+		if config.Action == v1alpha1.WatchdogRestartAction {
+			addWatchdogLivenessProbe(pod)
+		}
+	}
+	if injectSlowStart {
+		slowStartArgs, err := slowStartArgs(pod)
+		if err != nil {
+			return err
+		}
+		args = append(args, slowStartArgs...)
+	}
+	// Each pattern is passed in its own flag, as the patterns may contain commas
+	for _, pattern := range ag.agentConfig.Headers["deny"] {
+		args = append(args, constants.AgentDenyHeadersArgName, pattern)
+	}
+	if enableProfiling {
+		args = append(args, constants.EnableProfilingArgName)
+	}
+	if injectMetricsRelabeling {
+		args = append(args, metricsRelabelingArgs(pod, injectLogger)...)
+	}
+	if injectGPUMemoryTelemetry {
+		args = append(args, gpuMemoryTelemetryArgs(pod, injectMetricsRelabeling)...)
+	}
+	args = append(args, ag.tlsPolicy.Args()...)
+
 	if !queueProxyAvailable {
 		readinessProbe := pod.Spec.Containers[0].ReadinessProbe
 		// If the transformer container is present, use its readiness probe
@@ -404,6 +586,18 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 		},
 	}
 
+	// the request inspector endpoint is served by the profiling server, behind the profiling token
+	if enableProfiling || injectInspector {
+		agentContainer.Env = append(agentContainer.Env, profiling.TokenEnvFromSecret())
+	}
+	if injectMetricsRelabeling {
+		agentContainer.Ports = append(agentContainer.Ports, corev1.ContainerPort{
+			Name:          constants.MetricsRelabelingPortName,
+			ContainerPort: constants.MetricsRelabelingPort,
+			Protocol:      "TCP",
+		})
+	}
+
 	// If the Logger TLS bundle ConfigMap is specified, mount it
 	if injectLogger && ag.loggerConfig.CaBundle != "" {
 		// Optional. If the ConfigMap is not found, this will not make the Pod fail
@@ -417,7 +611,7 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 			},
 		}
 
-		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, corev1.Volume{
 			Name:         constants.LoggerCaBundleVolume,
 			VolumeSource: configMapVolume,
 		})
@@ -429,6 +623,42 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 		})
 	}
 
+	// Mount the secret with the SASL and TLS settings of the brokers of the kafka sink
+	if kafkaSecret, ok := pod.ObjectMeta.Annotations[constants.LoggerKafkaSecretInternalAnnotationKey]; injectLogger && ok {
+		pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, corev1.Volume{
+			Name: constants.LoggerKafkaSecretVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: kafkaSecret},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      constants.LoggerKafkaSecretVolume,
+			MountPath: constants.LoggerKafkaSecretMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// Mount the public key encrypting the payloads of the logs
+	if encryptionSecret, ok := pod.ObjectMeta.Annotations[constants.LoggerEncryptionSecretInternalAnnotationKey]; injectLogger && ok {
+		pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, corev1.Volume{
+			Name: constants.LoggerEncryptionKeyVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: encryptionSecret,
+					Items: []corev1.KeyToPath{{
+						Key:  pod.ObjectMeta.Annotations[constants.LoggerEncryptionSecretKeyInternalAnnotationKey],
+						Path: constants.LoggerEncryptionPublicKeyFile,
+					}},
+				},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      constants.LoggerEncryptionKeyVolume,
+			MountPath: constants.LoggerEncryptionKeyMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	// Inject credentials
 	if err := ag.credentialBuilder.CreateSecretVolumeAndEnv(
 		context.Background(),
@@ -441,6 +671,26 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 		return err
 	}
 
+	// Reuse the credentials of the storage spec to upload the artifacts
+	if injectArtifactUpload && pod.ObjectMeta.Annotations[constants.StorageSpecAnnotationKey] == "true" {
+		var overrideParams map[string]string
+		if storageSpecParam, ok := pod.ObjectMeta.Annotations[constants.StorageSpecParamAnnotationKey]; ok {
+			if err := json.Unmarshal([]byte(storageSpecParam), &overrideParams); err != nil {
+				return err
+			}
+		}
+		if err := ag.credentialBuilder.CreateStorageSpecSecretEnvs(
+			context.Background(),
+			pod.Namespace,
+			pod.Annotations,
+			pod.ObjectMeta.Annotations[constants.StorageSpecKeyAnnotationKey],
+			overrideParams,
+			agentContainer,
+		); err != nil {
+			return err
+		}
+	}
+
 	if injectLogger && ag.loggerConfig.Store != nil {
 		saName := LoggerDefaultServiceAccountName
 		if ag.loggerConfig.Store.ServiceAccountName != nil {
@@ -462,6 +712,10 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 	// Add container to the spec
 	pod.Spec.Containers = append(pod.Spec.Containers, *agentContainer)
 
+	if injectArtifactUpload {
+		mountArtifactDir(pod)
+	}
+
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentShouldInjectAnnotationKey]; ok {
 		// Mount the modelDir volume to the pod and model agent container
 		err := mountModelDir(pod)
@@ -478,6 +732,132 @@ func (ag *AgentInjector) InjectAgent(pod *corev1.Pod) error {
 	return nil
 }
 
+// requestInspectorArgs returns the agent arguments keeping the last sampled requests for the request inspector
+func requestInspectorArgs(pod *corev1.Pod, inspectRequests string) ([]string, error) {
+	if size, err := strconv.Atoi(inspectRequests); err != nil || size < 1 || size > constants.MaxInspectRequests {
+		return nil, fmt.Errorf("invalid %s annotation %q, it must be a number between 1 and %d",
+			constants.InspectRequestsAnnotationKey, inspectRequests, constants.MaxInspectRequests)
+	}
+	args := []string{constants.AgentInspectRequestsArgName, inspectRequests}
+	if percent, ok := pod.ObjectMeta.Annotations[constants.InspectSamplingPercentAnnotationKey]; ok {
+		if value, err := strconv.Atoi(percent); err != nil || value < 0 || value > 100 {
+			return nil, fmt.Errorf("invalid %s annotation %q, it must be a percentage",
+				constants.InspectSamplingPercentAnnotationKey, percent)
+		}
+		args = append(args, constants.AgentInspectSamplingPercentArgName, percent)
+	}
+	return args, nil
+}
+
+// slowStartArgs returns the agent arguments ramping up the concurrency admitted by a new replica over the window
+func slowStartArgs(pod *corev1.Pod) ([]string, error) {
+	values := map[string]string{}
+	for _, key := range []string{
+		constants.SlowStartWindowInternalAnnotationKey,
+		constants.SlowStartInitialPercentInternalAnnotationKey,
+		constants.SlowStartMaxConcurrencyInternalAnnotationKey,
+	} {
+		value := pod.ObjectMeta.Annotations[key]
+		if number, err := strconv.Atoi(value); err != nil || number < 1 {
+			return nil, fmt.Errorf("invalid %s annotation %q, it must be a positive integer", key, value)
+		}
+		values[key] = value
+	}
+	return []string{
+		constants.AgentSlowStartWindowArgName, values[constants.SlowStartWindowInternalAnnotationKey] + "s",
+		constants.AgentSlowStartInitialPercentArgName, values[constants.SlowStartInitialPercentInternalAnnotationKey],
+		constants.AgentSlowStartMaxConcurrencyArgName, values[constants.SlowStartMaxConcurrencyInternalAnnotationKey],
+	}, nil
+}
+
+// backpressureArgs returns the agent arguments adding the configured back-pressure headers to the responses. The queue
+// depth is read from the kserve-container metrics.
+func (ag *AgentInjector) backpressureArgs(pod *corev1.Pod, componentMetricsInjected bool) ([]string, error) {
+	config := ag.agentConfig.Backpressure
+	if _, err := backpressure.ParseSignals(config.Signals); err != nil {
+		return nil, fmt.Errorf("invalid agent backpressure config: %w", err)
+	}
+	args := []string{constants.AgentEnableBackpressureArgName}
+	if len(config.Signals) > 0 {
+		args = append(args, constants.AgentBackpressureSignalsArgName, strings.Join(config.Signals, ","))
+	}
+	if config.OnSuccess {
+		args = append(args, constants.AgentBackpressureOnSuccessArgName)
+	}
+	if len(config.QueueMetrics) > 0 {
+		args = append(args, constants.AgentBackpressureQueueMetricsArgName, strings.Join(config.QueueMetrics, ","))
+	}
+	// the kserve-container metrics port/path is already passed to the agent for the metrics relabeling or the GPU
+	// memory telemetry
+	if !componentMetricsInjected {
+		args = append(args, componentMetricsArgs(pod)...)
+	}
+	return args, nil
+}
+
+// addWatchdogLivenessProbe restarts the kserve-container when the agent watchdog finds the runtime hung. The probe
+// fails once the requests stalled for the stall threshold of the watchdog already, so it restarts the runtime after
+// a few failures only. The liveness probe the runtime already has is kept.
+func addWatchdogLivenessProbe(pod *corev1.Pod) {
+	for idx := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[idx]
+		if container.Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		if container.LivenessProbe != nil {
+			klog.Infof("Keeping the liveness probe of the kserve-container of pod %s/%s, the hung runtime is not restarted by the watchdog",
+				pod.Namespace, pod.Name)
+			return
+		}
+		container.LivenessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   watchdog.HealthPath,
+					Port:   intstr.FromInt(constants.InferenceServiceDefaultAgentPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+			PeriodSeconds:    watchdogLivenessPeriodSeconds,
+			FailureThreshold: watchdogLivenessFailureThreshold,
+		}
+	}
+}
+
+// isArtifactOutputUri returns true if the artifacts can be uploaded to the uri by the agent
+func isArtifactOutputUri(outputUri string) bool {
+	u, err := url.Parse(outputUri)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	protocol := storage.Protocol(u.Scheme + "://")
+	return protocol == storage.S3 || protocol == storage.GCS || protocol == storage.AZURE
+}
+
+// mountArtifactDir shares the artifact directory between the component and the agent, and tells the component where
+// to write the artifacts and how to request their upload.
+func mountArtifactDir(pod *corev1.Pod) {
+	artifactVolume := corev1.Volume{
+		Name: constants.ArtifactVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+	mountVolumeToContainer(constants.AgentContainerName, pod, artifactVolume, constants.DefaultArtifactDir)
+	mountVolumeToContainer(constants.InferenceServiceContainerName, pod, artifactVolume, constants.DefaultArtifactDir)
+	uploadUrl := (&url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort("127.0.0.1", strconv.Itoa(constants.ArtifactUploadPort)),
+		Path:   constants.ArtifactUploadPath,
+	}).String()
+	for idx := range pod.Spec.Containers {
+		if pod.Spec.Containers[idx].Name == constants.InferenceServiceContainerName {
+			pod.Spec.Containers[idx].Env = utils.AppendEnvVarIfNotExists(pod.Spec.Containers[idx].Env,
+				corev1.EnvVar{Name: constants.ArtifactDirEnvVarKey, Value: constants.DefaultArtifactDir},
+				corev1.EnvVar{Name: constants.ArtifactUploadUrlEnvVarKey, Value: uploadUrl})
+		}
+	}
+}
+
 func mountModelDir(pod *corev1.Pod) error {
 	if _, ok := pod.ObjectMeta.Annotations[constants.AgentModelDirAnnotationKey]; ok {
 		modelDirVolume := corev1.Volume{
@@ -500,10 +880,8 @@ func mountModelConfig(pod *corev1.Pod) error {
 		modelConfigVolume := corev1.Volume{
 			Name: constants.ModelConfigVolumeName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: modelConfigName,
-					},
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: modelConfigPartSources(modelConfigName),
 				},
 			},
 		}
@@ -513,19 +891,38 @@ func mountModelConfig(pod *corev1.Pod) error {
 	return fmt.Errorf("can not find %v label", constants.AgentModelConfigVolumeNameAnnotationKey)
 }
 
+// modelConfigPartSources projects the parts of the model config in the same directory. The parts beyond the first one
+// are optional, so that the parts created as the number of models grows are mounted without updating the pod.
+func modelConfigPartSources(modelConfigName string) []corev1.VolumeProjection {
+	sources := make([]corev1.VolumeProjection, 0, constants.ModelConfigMaxParts)
+	for part := 0; part < constants.ModelConfigMaxParts; part++ {
+		source := &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: constants.ModelConfigPartName(modelConfigName, part),
+			},
+			Items: []corev1.KeyToPath{
+				{
+					Key:  constants.ModelConfigFileName,
+					Path: constants.ModelConfigPartFileName(part),
+				},
+			},
+		}
+		if part > 0 {
+			source.Optional = ptr.To(true)
+		}
+		sources = append(sources, corev1.VolumeProjection{ConfigMap: source})
+	}
+	return sources
+}
+
+// mountVolumeToContainer adds the volume to the pod and mounts it in the container, unless the container already
+// mounts it, e.g. in the pod of a former agent injection
 func mountVolumeToContainer(containerName string, pod *corev1.Pod, additionalVolume corev1.Volume, mountPath string) {
 	pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, additionalVolume)
 	mountedContainers := make([]corev1.Container, 0, len(pod.Spec.Containers))
 	for _, container := range pod.Spec.Containers {
 		if container.Name == containerName {
-			if container.VolumeMounts == nil {
-				container.VolumeMounts = []corev1.VolumeMount{}
-			}
-			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-				Name:      additionalVolume.Name,
-				ReadOnly:  false,
-				MountPath: mountPath,
-			})
+			utils.AddVolumeMountIfNotPresent(&container, additionalVolume.Name, mountPath, false)
 		}
 		mountedContainers = append(mountedContainers, container)
 	}
@@ -544,3 +941,48 @@ func appendVolume(existingVolumes []corev1.Volume, additionalVolume corev1.Volum
 	existingVolumes = append(existingVolumes, additionalVolume)
 	return existingVolumes
 }
+
+// metricsRelabelingArgs returns the agent arguments to serve the metrics of the kserve-container relabeled. The
+// kserve-container metrics port/path is inherited from the ServingRuntime like for the metrics aggregation.
+func metricsRelabelingArgs(pod *corev1.Pod, injectLogger bool) []string {
+	args := append([]string{constants.EnableMetricsRelabelingArgName}, componentMetricsArgs(pod)...)
+	// the inference service name is already passed to the agent for the logger
+	if !injectLogger {
+		args = append(args, LoggerArgumentInferenceService, pod.ObjectMeta.Labels[constants.InferenceServiceLabel])
+	}
+	return args
+}
+
+// gpuMemoryTelemetryArgs returns the agent arguments to sample the GPU memory reported in the kserve-container metrics.
+// The metric names default to the ones of the agent unless overridden by the annotations.
+func gpuMemoryTelemetryArgs(pod *corev1.Pod, injectMetricsRelabeling bool) []string {
+	args := []string{constants.EnableGPUMemoryTelemetryArgName}
+	if metric, ok := pod.ObjectMeta.Annotations[constants.GPUMemoryAllocatedMetricAnnotationKey]; ok {
+		args = append(args, constants.GPUMemoryAllocatedMetricArgName, metric)
+	}
+	if metric, ok := pod.ObjectMeta.Annotations[constants.GPUMemoryReservedMetricAnnotationKey]; ok {
+		args = append(args, constants.GPUMemoryReservedMetricArgName, metric)
+	}
+	// the kserve-container metrics port/path is already passed to the agent for the metrics relabeling
+	if !injectMetricsRelabeling {
+		args = append(args, componentMetricsArgs(pod)...)
+	}
+	return args
+}
+
+// componentMetricsArgs returns the agent arguments of the port/path the kserve-container metrics are scraped from,
+// inherited from the ServingRuntime like for the metrics aggregation.
+func componentMetricsArgs(pod *corev1.Pod) []string {
+	metricsPort := defaultKserveContainerPrometheusPort
+	if port, ok := pod.ObjectMeta.Annotations[constants.KserveContainerPrometheusPortKey]; ok {
+		metricsPort = port
+	}
+	metricsPath := constants.DefaultPrometheusPath
+	if path, ok := pod.ObjectMeta.Annotations[constants.KServeContainerPrometheusPathKey]; ok {
+		metricsPath = path
+	}
+	return []string{
+		constants.ComponentMetricsPortArgName, metricsPort,
+		constants.ComponentMetricsPathArgName, metricsPath,
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/utils/ptr"
 	"knative.dev/pkg/kmp"
 
+	"github.com/kserve/kserve/pkg/agent/watchdog"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/credentials"
 
@@ -36,6 +37,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/profiling"
+	"github.com/kserve/kserve/pkg/tlspolicy"
 )
 
 const (
@@ -228,10 +231,8 @@ func TestAgentInjector(t *testing.T) {
 						{
 							Name: "model-config",
 							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: "modelconfig-deployment-0",
-									},
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: modelConfigPartSources("modelconfig-deployment-0"),
 								},
 							},
 						},
@@ -810,6 +811,97 @@ func TestAgentInjector(t *testing.T) {
 				},
 			},
 		},
+		"AddMetricsRelabeling": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.EnableMetricsRelabelingAnnotationKey: "true",
+						constants.KserveContainerPrometheusPortKey:     "8002",
+					},
+					Labels: map[string]string{
+						"serving.kserve.io/inferenceservice": "triton",
+						constants.KServiceModelLabel:         "triton",
+						constants.KServiceEndpointLabel:      "default",
+						constants.KServiceComponentLabel:     "predictor",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "kserve-container",
+						},
+						{
+							Name: "queue-proxy",
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.EnableMetricsRelabelingAnnotationKey: "true",
+						constants.KserveContainerPrometheusPortKey:     "8002",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "kserve-container",
+						},
+						{
+							Name: "queue-proxy",
+						},
+						{
+							Name:  constants.AgentContainerName,
+							Image: loggerConfig.Image,
+							Args: []string{
+								constants.AgentComponentPortArgName,
+								constants.InferenceServiceDefaultHttpPort,
+								constants.EnableMetricsRelabelingArgName,
+								constants.ComponentMetricsPortArgName,
+								"8002",
+								constants.ComponentMetricsPathArgName,
+								constants.DefaultPrometheusPath,
+								LoggerArgumentInferenceService,
+								"triton",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "agent-port",
+									ContainerPort: constants.InferenceServiceDefaultAgentPort,
+									Protocol:      "TCP",
+								},
+								{
+									Name:          constants.MetricsRelabelingPortName,
+									ContainerPort: constants.MetricsRelabelingPort,
+									Protocol:      "TCP",
+								},
+							},
+							Env:       []corev1.EnvVar{},
+							Resources: agentResourceRequirement,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										HTTPHeaders: []corev1.HTTPHeader{
+											{
+												Name:  "K-Network-Probe",
+												Value: "queue",
+											},
+										},
+										Port:   intstr.FromInt(9081),
+										Path:   "/",
+										Scheme: "HTTP",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"AgentAlreadyInjected": {
 			original: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
@@ -900,10 +992,8 @@ func TestAgentInjector(t *testing.T) {
 						{
 							Name: "model-config",
 							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: "modelconfig-deployment-0",
-									},
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: modelConfigPartSources("modelconfig-deployment-0"),
 								},
 							},
 						},
@@ -991,10 +1081,8 @@ func TestAgentInjector(t *testing.T) {
 						{
 							Name: "model-config",
 							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: "modelconfig-deployment-0",
-									},
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: modelConfigPartSources("modelconfig-deployment-0"),
 								},
 							},
 						},
@@ -1399,10 +1487,8 @@ func TestAgentInjector(t *testing.T) {
 						{
 							Name: "model-config",
 							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: "modelconfig-deployment-0",
-									},
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: modelConfigPartSources("modelconfig-deployment-0"),
 								},
 							},
 						},
@@ -1712,6 +1798,7 @@ func TestAgentInjector(t *testing.T) {
 			agentConfig,
 			loggerConfig,
 			batcherTestConfig,
+			nil,
 		}
 		injector.InjectAgent(scenario.original)
 		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
@@ -1725,6 +1812,7 @@ func TestAgentInjector(t *testing.T) {
 			agentConfig,
 			loggerTLSConfig,
 			batcherTestConfig,
+			nil,
 		}
 		injector.InjectAgent(scenario.original)
 		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
@@ -1738,6 +1826,7 @@ func TestAgentInjector(t *testing.T) {
 			agentConfig,
 			loggerTLSConfig,
 			batcherTestConfig,
+			nil,
 		}
 		injector.InjectAgent(scenario.original)
 		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
@@ -1751,6 +1840,7 @@ func TestAgentInjector(t *testing.T) {
 			agentConfig,
 			loggerConfigWithStorage,
 			batcherTestConfig,
+			nil,
 		}
 		injector.InjectAgent(scenario.original)
 		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
@@ -2056,7 +2146,10 @@ func TestGetAgentConfigs(t *testing.T) {
 						"CpuRequest":    "100m",
 						"CpuLimit":      "1",
 						"MemoryRequest": "200Mi",
-						"MemoryLimit":   "1Gi"
+						"MemoryLimit":   "1Gi",
+						"headers": {
+							"deny": ["^Cookie$", "^Proxy-Authorization$"]
+						}
 					}`,
 				},
 				BinaryData: map[string][]byte{},
@@ -2068,6 +2161,9 @@ func TestGetAgentConfigs(t *testing.T) {
 					CpuLimit:      "1",
 					MemoryRequest: "200Mi",
 					MemoryLimit:   "1Gi",
+					Headers: map[string][]string{
+						"deny": {"^Cookie$", "^Proxy-Authorization$"},
+					},
 				}),
 				gomega.BeNil(),
 			},
@@ -2108,6 +2204,773 @@ func TestGetAgentConfigs(t *testing.T) {
 	}
 }
 
+func TestAgentInjectorExplainerSampling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.ExplainerSamplingPercentInternalAnnotationKey: "10",
+				constants.ExplainerSamplingUrlInternalAnnotationKey:     "http://sklearn-explainer.default",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	agent := pod.Spec.Containers[1]
+	g.Expect(agent.Name).To(gomega.Equal(constants.AgentContainerName))
+	g.Expect(agent.Args).To(gomega.ContainElements(
+		constants.AgentExplainerSamplingPercentArgName, "10",
+		constants.AgentExplainerUrlArgName, "http://sklearn-explainer.default"))
+}
+
+func TestAgentInjectorKafkaLogger(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:            "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:     "kafka://kafka-0.kafka:9092,kafka-1.kafka:9092/payloads",
+				constants.LoggerModeInternalAnnotationKey:        string(v1beta1.LogAll),
+				constants.LoggerKafkaSecretInternalAnnotationKey: "kafka-sasl",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	agent := pod.Spec.Containers[1]
+	g.Expect(agent.Args).To(gomega.ContainElements(
+		LoggerArgumentLogUrl, "kafka://kafka-0.kafka:9092,kafka-1.kafka:9092/payloads",
+		LoggerArgumentKafkaSecretDir, constants.LoggerKafkaSecretMountPath))
+	g.Expect(agent.VolumeMounts).To(gomega.ContainElement(corev1.VolumeMount{
+		Name:      constants.LoggerKafkaSecretVolume,
+		MountPath: constants.LoggerKafkaSecretMountPath,
+		ReadOnly:  true,
+	}))
+	g.Expect(pod.Spec.Volumes).To(gomega.ContainElement(corev1.Volume{
+		Name: constants.LoggerKafkaSecretVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "kafka-sasl"},
+		},
+	}))
+}
+
+func TestAgentInjectorEncryptedLogger(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:                    "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:             "http://message-dumper.default",
+				constants.LoggerModeInternalAnnotationKey:                string(v1beta1.LogAll),
+				constants.LoggerEncryptionSecretInternalAnnotationKey:    "payload-logs",
+				constants.LoggerEncryptionSecretKeyInternalAnnotationKey: "public.pem",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	agent := pod.Spec.Containers[1]
+	g.Expect(agent.Args).To(gomega.ContainElements(
+		LoggerArgumentEncryptionPublicKey, constants.LoggerEncryptionKeyMountPath+"/"+constants.LoggerEncryptionPublicKeyFile))
+	g.Expect(agent.Args).NotTo(gomega.ContainElement(LoggerArgumentEncryptionKMSKey))
+	g.Expect(agent.VolumeMounts).To(gomega.ContainElement(corev1.VolumeMount{
+		Name:      constants.LoggerEncryptionKeyVolume,
+		MountPath: constants.LoggerEncryptionKeyMountPath,
+		ReadOnly:  true,
+	}))
+	g.Expect(pod.Spec.Volumes).To(gomega.ContainElement(corev1.Volume{
+		Name: constants.LoggerEncryptionKeyVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "payload-logs",
+				Items:      []corev1.KeyToPath{{Key: "public.pem", Path: constants.LoggerEncryptionPublicKeyFile}},
+			},
+		},
+	}))
+}
+
+func TestAgentInjectorBatchLoggerStorage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "s3://payloads/sklearn",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+	parameters := map[string]string{
+		constants.LoggerFormatKey:        constants.LoggerParquetFormat,
+		constants.LoggerFlushIntervalKey: "30s",
+		constants.LoggerFlushSizeKey:     "64Mi",
+	}
+	batchLoggerConfig := *loggerConfigWithStorage
+	batchLoggerConfig.Store = &v1beta1.LoggerStorageSpec{
+		StorageSpec: v1beta1.StorageSpec{
+			Path:       &storagePath,
+			Parameters: &parameters,
+			StorageKey: &storageKey,
+		},
+		ServiceAccountName: &saName,
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		&batchLoggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(
+		LoggerArgumentStorePath, storagePath,
+		LoggerArgumentStoreFormat, constants.LoggerParquetFormat,
+		LoggerArgumentStoreFlushInterval, "30s",
+		LoggerArgumentStoreFlushSize, "64Mi"))
+}
+
+func TestAgentInjectorLoggerFilter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:                   "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:            "http://logger.default",
+				constants.LoggerModeInternalAnnotationKey:               string(v1beta1.LogAll),
+				constants.LoggerSamplePercentInternalAnnotationKey:      "1",
+				constants.LoggerErrorSamplePercentInternalAnnotationKey: "100",
+				constants.LoggerHeaderFiltersInternalAnnotationKey:      "X-Tenant=a,X-Tenant=b,X-Debug",
+				constants.LoggerStatusCodesInternalAnnotationKey:        "200,5xx",
+				constants.LoggerRedactFieldsInternalAnnotationKey:       "instances.ssn",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(
+		LoggerArgumentSamplePercent, "1",
+		LoggerArgumentErrorSamplePercent, "100",
+		LoggerArgumentHeaderFilters, "X-Tenant=a,X-Tenant=b,X-Debug",
+		LoggerArgumentStatusCodes, "200,5xx",
+		LoggerArgumentRedactFields, "instances.ssn"))
+}
+
+func TestAgentInjectorSlowStart(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newPod := func(windowSeconds string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sklearn-predictor",
+				Namespace: "default",
+				Annotations: map[string]string{
+					constants.SlowStartWindowInternalAnnotationKey:         windowSeconds,
+					constants.SlowStartInitialPercentInternalAnnotationKey: "10",
+					constants.SlowStartMaxConcurrencyInternalAnnotationKey: "8",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+					},
+				},
+			},
+		}
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+
+	pod := newPod("120")
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(
+		constants.AgentSlowStartWindowArgName, "120s",
+		constants.AgentSlowStartInitialPercentArgName, "10",
+		constants.AgentSlowStartMaxConcurrencyArgName, "8"))
+
+	g.Expect(injector.InjectAgent(newPod("soon"))).To(gomega.MatchError(gomega.ContainSubstring("must be a positive integer")))
+}
+
+func TestAgentInjectorDenyHeaders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config := *agentConfig
+	config.Headers = map[string][]string{"deny": {"^Cookie$", "^X-Key-[0-9]{1,3}$"}}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		&config,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.SlowStartWindowInternalAnnotationKey: "120",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+
+	// Each pattern is passed in its own flag, so that the commas of a pattern are kept
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(
+		constants.AgentDenyHeadersArgName, "^Cookie$",
+		constants.AgentDenyHeadersArgName, "^X-Key-[0-9]{1,3}$"))
+}
+
+func TestAgentInjectorGrpcTranscoding(t *testing.T) {
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	newPod := func(port string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "triton-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.GrpcTranscodingPortAnnotationKey: port},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+
+	t.Run("valid port", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod("9000")
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentGrpcTranscodingPortArgName, "9000"))
+	})
+	t.Run("invalid port", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod("grpc")
+		g.Expect(injector.InjectAgent(pod)).To(gomega.MatchError(gomega.ContainSubstring("it must be a port number")))
+	})
+}
+
+func TestAgentInjectorDualProtocol(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   "default",
+			Annotations: map[string]string{constants.DualProtocolInternalAnnotationKey: string(constants.ProtocolV2)},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+		},
+	}
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentDualProtocolArgName, "v2"))
+}
+
+func TestAgentInjectorProfiling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sklearn-predictor",
+			Namespace:   "default",
+			Annotations: map[string]string{constants.EnableProfilingAnnotationKey: "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+		},
+	}
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+	g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElement(constants.EnableProfilingArgName))
+	g.Expect(pod.Spec.Containers[1].Env).To(gomega.ContainElement(gomega.HaveField("Name", constants.ProfilingTokenEnvVarName)))
+}
+
+func TestAgentInjectorBackpressure(t *testing.T) {
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.EnableBackpressureHeadersAnnotationKey: "true"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+	newInjector := func(config BackpressureConfig) *AgentInjector {
+		backpressureAgentConfig := *agentConfig
+		backpressureAgentConfig.Backpressure = config
+		return &AgentInjector{
+			credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+			&backpressureAgentConfig,
+			loggerConfig,
+			batcherTestConfig,
+			nil,
+		}
+	}
+
+	t.Run("all signals", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod()
+		g.Expect(newInjector(BackpressureConfig{}).InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElement(constants.AgentEnableBackpressureArgName))
+		g.Expect(pod.Spec.Containers[1].Args).ToNot(gomega.ContainElement(constants.AgentBackpressureSignalsArgName))
+		// the queue depth is read from the kserve-container metrics
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.ComponentMetricsPortArgName,
+			defaultKserveContainerPrometheusPort, constants.ComponentMetricsPathArgName, constants.DefaultPrometheusPath))
+	})
+	t.Run("configured signals", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod()
+		injector := newInjector(BackpressureConfig{
+			Signals:      []string{"queue-depth", "retry-after"},
+			OnSuccess:    true,
+			QueueMetrics: []string{"vllm:num_requests_waiting"},
+		})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentEnableBackpressureArgName,
+			constants.AgentBackpressureSignalsArgName, "queue-depth,retry-after", constants.AgentBackpressureOnSuccessArgName,
+			constants.AgentBackpressureQueueMetricsArgName, "vllm:num_requests_waiting"))
+	})
+	t.Run("component metrics passed once", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod()
+		pod.Annotations[constants.EnableGPUMemoryTelemetryAnnotationKey] = "true"
+		g.Expect(newInjector(BackpressureConfig{}).InjectAgent(pod)).To(gomega.Succeed())
+		count := 0
+		for _, arg := range pod.Spec.Containers[1].Args {
+			if arg == constants.ComponentMetricsPortArgName {
+				count++
+			}
+		}
+		g.Expect(count).To(gomega.Equal(1))
+	})
+	t.Run("unknown signal", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		err := newInjector(BackpressureConfig{Signals: []string{"queue-length"}}).InjectAgent(newPod())
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`unknown back-pressure signal "queue-length"`)))
+	})
+}
+
+func TestAgentInjectorWatchdog(t *testing.T) {
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	newPod := func(config string, livenessProbe *corev1.Probe) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.WatchdogInternalAnnotationKey: config},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, LivenessProbe: livenessProbe}},
+			},
+		}
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		config := `{"stallThreshold":"2m"}`
+		pod := newPod(config, nil)
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentWatchdogArgName, config))
+		livenessProbe := pod.Spec.Containers[0].LivenessProbe
+		g.Expect(livenessProbe).ToNot(gomega.BeNil())
+		g.Expect(livenessProbe.HTTPGet.Path).To(gomega.Equal(watchdog.HealthPath))
+		g.Expect(livenessProbe.HTTPGet.Port).To(gomega.Equal(intstr.FromInt(constants.InferenceServiceDefaultAgentPort)))
+		g.Expect(livenessProbe.FailureThreshold).To(gomega.Equal(int32(watchdogLivenessFailureThreshold)))
+	})
+	t.Run("existing liveness probe", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		existing := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)}}}
+		pod := newPod(`{}`, existing)
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers[0].LivenessProbe).To(gomega.Equal(existing))
+	})
+	t.Run("unready", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		config := `{"action":"Unready","probe":{"path":"/v2/health/live"}}`
+		pod := newPod(config, nil)
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentWatchdogArgName, config))
+		g.Expect(pod.Spec.Containers[0].LivenessProbe).To(gomega.BeNil())
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		err := injector.InjectAgent(newPod(`{"action":"Reboot"}`, nil))
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`unknown watchdog action "Reboot"`)))
+	})
+}
+
+func TestAgentInjectorGPUMemoryTelemetry(t *testing.T) {
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		annotations[constants.EnableGPUMemoryTelemetryAnnotationKey] = "true"
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "llm-predictor",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+
+	t.Run("default metrics", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.KserveContainerPrometheusPortKey: "8000"})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		args := pod.Spec.Containers[1].Args
+		g.Expect(args).To(gomega.ContainElements(constants.EnableGPUMemoryTelemetryArgName,
+			constants.ComponentMetricsPortArgName, "8000", constants.ComponentMetricsPathArgName, constants.DefaultPrometheusPath))
+		g.Expect(args).ToNot(gomega.ContainElement(constants.GPUMemoryAllocatedMetricArgName))
+	})
+	t.Run("custom metrics", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{
+			constants.GPUMemoryAllocatedMetricAnnotationKey: "vllm:gpu_memory_allocated_bytes",
+			constants.GPUMemoryReservedMetricAnnotationKey:  "vllm:gpu_memory_reserved_bytes",
+		})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(
+			constants.GPUMemoryAllocatedMetricArgName, "vllm:gpu_memory_allocated_bytes",
+			constants.GPUMemoryReservedMetricArgName, "vllm:gpu_memory_reserved_bytes"))
+	})
+	t.Run("with metrics relabeling", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.EnableMetricsRelabelingAnnotationKey: "true"})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		portArgs := 0
+		for _, arg := range pod.Spec.Containers[1].Args {
+			if arg == constants.ComponentMetricsPortArgName {
+				portArgs++
+			}
+		}
+		g.Expect(portArgs).To(gomega.Equal(1))
+	})
+}
+
+func TestAgentInjectorArtifactUpload(t *testing.T) {
+	storageSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.DefaultStorageSpecSecret, Namespace: "default"},
+		Data: map[string][]byte{
+			"minio": []byte(`{"type": "s3", "access_key_id": "minio", "secret_access_key": "minio123"}`),
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(storageSecret), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "llm-predictor",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+	artifactMount := corev1.VolumeMount{Name: constants.ArtifactVolumeName, MountPath: constants.DefaultArtifactDir}
+
+	t.Run("artifact output uri", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.ArtifactOutputUriAnnotationKey: "s3://artifacts/llm"})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		component, agent := pod.Spec.Containers[0], pod.Spec.Containers[1]
+		g.Expect(agent.Args).To(gomega.ContainElements(
+			constants.AgentArtifactOutputUriArgName, "s3://artifacts/llm",
+			constants.AgentArtifactDirArgName, constants.DefaultArtifactDir))
+		g.Expect(agent.VolumeMounts).To(gomega.ContainElement(artifactMount))
+		g.Expect(component.VolumeMounts).To(gomega.ContainElement(artifactMount))
+		g.Expect(component.Env).To(gomega.ContainElements(
+			corev1.EnvVar{Name: constants.ArtifactDirEnvVarKey, Value: constants.DefaultArtifactDir},
+			corev1.EnvVar{Name: constants.ArtifactUploadUrlEnvVarKey, Value: "http://127.0.0.1:9084/v1/artifacts/upload"}))
+		g.Expect(pod.Spec.Volumes).To(gomega.ContainElement(corev1.Volume{
+			Name:         constants.ArtifactVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}))
+	})
+	t.Run("storage spec credentials", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{
+			constants.ArtifactOutputUriAnnotationKey: "s3://artifacts/llm",
+			constants.StorageSpecAnnotationKey:       "true",
+			constants.StorageSpecKeyAnnotationKey:    "minio",
+		})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers[1].Env).To(gomega.ContainElement(corev1.EnvVar{
+			Name: credentials.StorageConfigEnvKey,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: constants.DefaultStorageSpecSecret},
+					Key:                  "minio",
+				},
+			},
+		}))
+	})
+	t.Run("invalid artifact output uri", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.ArtifactOutputUriAnnotationKey: "https://example.com/artifacts"})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.MatchError(gomega.ContainSubstring("uri with a bucket")))
+	})
+}
+
+func TestAgentInjectorFaultInjection(t *testing.T) {
+	newInjector := func(enableFaultInjection bool) *AgentInjector {
+		config := *agentConfig
+		config.EnableFaultInjection = enableFaultInjection
+		return &AgentInjector{
+			credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+			&config,
+			loggerConfig,
+			batcherTestConfig,
+			nil,
+		}
+	}
+	newPod := func(rules string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.FaultInjectionAnnotationKey: rules},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+	rules := `[{"path": "/v1/models", "errorPercent": 10, "delay": "1s"}]`
+
+	t.Run("fault injection enabled", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(rules)
+		g.Expect(newInjector(true).InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements(constants.AgentFaultInjectionArgName, rules))
+	})
+	t.Run("fault injection disabled", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(rules)
+		g.Expect(newInjector(false).InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(1))
+	})
+	t.Run("invalid rules", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(`[{"errorPercent": 150}]`)
+		g.Expect(newInjector(true).InjectAgent(pod)).To(gomega.MatchError(gomega.ContainSubstring("errorPercent must be between 0 and 100")))
+	})
+}
+
+func TestAgentInjectorRequestInspector(t *testing.T) {
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerConfig,
+		batcherTestConfig,
+		nil,
+	}
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+
+	t.Run("request inspector", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{
+			constants.InspectRequestsAnnotationKey:        "50",
+			constants.InspectSamplingPercentAnnotationKey: "10",
+		})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		agent := pod.Spec.Containers[1]
+		g.Expect(agent.Args).To(gomega.ContainElements(
+			constants.AgentInspectRequestsArgName, "50",
+			constants.AgentInspectSamplingPercentArgName, "10"))
+		g.Expect(agent.Env).To(gomega.ContainElement(profiling.TokenEnvFromSecret()))
+	})
+	t.Run("invalid number of requests", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.InspectRequestsAnnotationKey: "5000"})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.MatchError(gomega.ContainSubstring("must be a number between 1 and 1000")))
+	})
+	t.Run("invalid sampling percent", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{
+			constants.InspectRequestsAnnotationKey:        "50",
+			constants.InspectSamplingPercentAnnotationKey: "all",
+		})
+		g.Expect(injector.InjectAgent(pod)).To(gomega.MatchError(gomega.ContainSubstring("it must be a percentage")))
+	})
+}
+
+func TestModelConfigPartSources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	sources := modelConfigPartSources("modelconfig-sklearn-0")
+	g.Expect(sources).To(gomega.HaveLen(constants.ModelConfigMaxParts))
+	g.Expect(sources[0]).To(gomega.Equal(corev1.VolumeProjection{
+		ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "modelconfig-sklearn-0"},
+			Items:                []corev1.KeyToPath{{Key: "models.json", Path: "models.json"}},
+		},
+	}))
+	g.Expect(sources[1]).To(gomega.Equal(corev1.VolumeProjection{
+		ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "modelconfig-sklearn-0-part-1"},
+			Items:                []corev1.KeyToPath{{Key: "models.json", Path: "models-1.json"}},
+			Optional:             ptr.To(true),
+		},
+	}))
+}
+
 func TestReadinessProbeInheritance(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -2257,3 +3120,44 @@ func marshalReadinessProbe(probe *corev1.Probe) (string, error) {
 
 	return string(probeJson), nil
 }
+
+func TestAgentInjectorTLSPolicy(t *testing.T) {
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.LoggerInternalAnnotationKey: "true"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}},
+			},
+		}
+	}
+	policy, err := tlspolicy.New(tlspolicy.Options{MinVersion: "1.3", FIPSMode: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newInjector := func(loggerConfig *LoggerConfig) *AgentInjector {
+		return &AgentInjector{
+			credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+			agentConfig,
+			loggerConfig,
+			batcherTestConfig,
+			policy,
+		}
+	}
+
+	t.Run("policy args", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod()
+		g.Expect(newInjector(loggerConfig).InjectAgent(pod)).To(gomega.Succeed())
+		g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+		g.Expect(pod.Spec.Containers[1].Args).To(gomega.ContainElements("--tls-min-version", "1.3", "--fips-mode"))
+	})
+	t.Run("tls skip verify in fips mode", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		err := newInjector(loggerTLSConfig).InjectAgent(newPod())
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("tlsSkipVerify is not allowed in FIPS mode")))
+	})
+}
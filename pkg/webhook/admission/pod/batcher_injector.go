@@ -33,6 +33,8 @@ const (
 	BatcherEnableFlag           = "--enable-batcher"
 	BatcherArgumentMaxBatchSize = "--max-batchsize"
 	BatcherArgumentMaxLatency   = "--max-latency"
+	BatcherArgumentLatencySLO   = "--latency-slo"
+	BatcherArgumentMinBatchSize = "--min-batchsize"
 )
 
 type BatcherConfig struct {
@@ -76,6 +78,19 @@ func getBatcherConfigs(configMap *corev1.ConfigMap) (*BatcherConfig, error) {
 	return batcherConfig, nil
 }
 
+// adaptiveBatcherArgs returns the arguments adjusting the batches to the latency SLO of the adaptive batcher
+func adaptiveBatcherArgs(pod *corev1.Pod) []string {
+	latencySLO, ok := pod.ObjectMeta.Annotations[constants.BatcherLatencySLOInternalAnnotationKey]
+	if !ok {
+		return nil
+	}
+	args := []string{BatcherArgumentLatencySLO, latencySLO}
+	if minBatchSize, ok := pod.ObjectMeta.Annotations[constants.BatcherMinBatchSizeInternalAnnotationKey]; ok {
+		args = append(args, BatcherArgumentMinBatchSize, minBatchSize)
+	}
+	return args
+}
+
 func (il *BatcherInjector) InjectBatcher(pod *corev1.Pod) error {
 	// Only inject if the required annotations are set
 	_, ok := pod.ObjectMeta.Annotations[constants.BatcherInternalAnnotationKey]
@@ -102,6 +117,7 @@ func (il *BatcherInjector) InjectBatcher(pod *corev1.Pod) error {
 	}
 	args = append(args, BatcherArgumentMaxLatency)
 	args = append(args, maxLatency)
+	args = append(args, adaptiveBatcherArgs(pod)...)
 
 	// Don't inject if Container already injected
 	for _, container := range pod.Spec.Containers {
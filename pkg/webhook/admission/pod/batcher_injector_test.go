@@ -118,6 +118,60 @@ func TestBatcherInjector(t *testing.T) {
 				},
 			},
 		},
+		"AddAdaptiveBatcher": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:             "true",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey: "32",
+						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
+						constants.BatcherLatencySLOInternalAnnotationKey:   "200",
+						constants.BatcherMinBatchSizeInternalAnnotationKey: "4",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "sklearn",
+					}},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+					Annotations: map[string]string{
+						constants.BatcherInternalAnnotationKey:             "true",
+						constants.BatcherMaxBatchSizeInternalAnnotationKey: "32",
+						constants.BatcherMaxLatencyInternalAnnotationKey:   "100",
+						constants.BatcherLatencySLOInternalAnnotationKey:   "200",
+						constants.BatcherMinBatchSizeInternalAnnotationKey: "4",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "sklearn",
+						},
+						{
+							Name:  BatcherContainerName,
+							Image: batcherConfig.Image,
+							Args: []string{
+								BatcherArgumentMaxBatchSize,
+								"32",
+								BatcherArgumentMaxLatency,
+								"100",
+								BatcherArgumentLatencySLO,
+								"200",
+								BatcherArgumentMinBatchSize,
+								"4",
+							},
+							Resources: batcherResourceRequirement,
+						},
+					},
+				},
+			},
+		},
 		"AddDefaultBatcherConfig": {
 			original: &corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+type CheckpointRestoreInjector struct {
+	client client.Client
+	config *v1beta1.CheckpointRestoreConfig
+}
+
+// InjectCheckpointImage replaces the image of the checkpointed container with the checkpoint image of the ReplicaSet
+// of the pod once pushed by the checkpoint agent, which the container runtime restores instead of starting the
+// model server. The pod is created as is when there is no completed checkpoint, restoring is only an optimization.
+func (ci *CheckpointRestoreInjector) InjectCheckpointImage(ctx context.Context, pod *corev1.Pod) error {
+	if !ci.config.Enabled || pod.ObjectMeta.Annotations[constants.CheckpointRestoreAnnotationKey] != "true" {
+		return nil
+	}
+	if _, ok := pod.ObjectMeta.Annotations[constants.RestoredFromCheckpointAnnotationKey]; ok {
+		return nil
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "ReplicaSet" {
+		return nil
+	}
+
+	checkpoint := &v1alpha1.ModelCheckpoint{}
+	if err := ci.client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}, checkpoint); err != nil {
+		if !apierr.IsNotFound(err) {
+			log.Error(err, "Failed to get the model checkpoint, the pod is not restored", "name", owner.Name)
+		}
+		return nil
+	}
+	if checkpoint.Status.Phase != v1alpha1.ModelCheckpointCompleted || checkpoint.Status.Image == "" {
+		return nil
+	}
+	container := utils.GetContainerWithName(&pod.Spec, checkpoint.Spec.ContainerName)
+	if container == nil {
+		return nil
+	}
+	container.Image = checkpoint.Status.Image
+	pod.ObjectMeta.Annotations[constants.RestoredFromCheckpointAnnotationKey] = checkpoint.Name
+	return nil
+}
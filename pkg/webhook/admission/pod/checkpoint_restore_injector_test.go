@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInjectCheckpointImage(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+	checkpointImage := "registry.example.com/checkpoints@sha256:1234"
+	makeCheckpoint := func(phase v1alpha1.ModelCheckpointPhase) *v1alpha1.ModelCheckpoint {
+		checkpoint := &v1alpha1.ModelCheckpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-5d8f7b9c4", Namespace: "default"},
+			Spec: v1alpha1.ModelCheckpointSpec{
+				PodName:       "sklearn-predictor-5d8f7b9c4-x2k9p",
+				ContainerName: constants.InferenceServiceContainerName,
+				NodeName:      "node-1",
+			},
+			Status: v1alpha1.ModelCheckpointStatus{Phase: phase},
+		}
+		if phase == v1alpha1.ModelCheckpointCompleted {
+			checkpoint.Status.Image = checkpointImage
+		}
+		return checkpoint
+	}
+	makePod := func(annotations map[string]string, ownerKind string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor-5d8f7b9c4-abcde", Namespace: "default", Annotations: annotations},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:latest"},
+					{Name: constants.AgentContainerName, Image: "kserve/agent:latest"},
+				},
+			},
+		}
+		if ownerKind != "" {
+			controller := true
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: ownerKind, Name: "sklearn-predictor-5d8f7b9c4", Controller: &controller},
+			}
+		}
+		return pod
+	}
+	enabled := &v1beta1.CheckpointRestoreConfig{Enabled: true, Repository: "registry.example.com/checkpoints"}
+
+	scenarios := map[string]struct {
+		config        *v1beta1.CheckpointRestoreConfig
+		checkpoint    *v1alpha1.ModelCheckpoint
+		pod           *corev1.Pod
+		expectedImage string
+	}{
+		"Restored": {
+			config:        enabled,
+			checkpoint:    makeCheckpoint(v1alpha1.ModelCheckpointCompleted),
+			pod:           makePod(map[string]string{constants.CheckpointRestoreAnnotationKey: "true"}, "ReplicaSet"),
+			expectedImage: checkpointImage,
+		},
+		"FeatureDisabled": {
+			config:        &v1beta1.CheckpointRestoreConfig{},
+			checkpoint:    makeCheckpoint(v1alpha1.ModelCheckpointCompleted),
+			pod:           makePod(map[string]string{constants.CheckpointRestoreAnnotationKey: "true"}, "ReplicaSet"),
+			expectedImage: "kserve/sklearnserver:latest",
+		},
+		"NotAnnotated": {
+			config:        enabled,
+			checkpoint:    makeCheckpoint(v1alpha1.ModelCheckpointCompleted),
+			pod:           makePod(map[string]string{}, "ReplicaSet"),
+			expectedImage: "kserve/sklearnserver:latest",
+		},
+		"CheckpointPending": {
+			config:        enabled,
+			checkpoint:    makeCheckpoint(v1alpha1.ModelCheckpointPending),
+			pod:           makePod(map[string]string{constants.CheckpointRestoreAnnotationKey: "true"}, "ReplicaSet"),
+			expectedImage: "kserve/sklearnserver:latest",
+		},
+		"NoCheckpoint": {
+			config:        enabled,
+			pod:           makePod(map[string]string{constants.CheckpointRestoreAnnotationKey: "true"}, "ReplicaSet"),
+			expectedImage: "kserve/sklearnserver:latest",
+		},
+		"NotOwnedByReplicaSet": {
+			config:        enabled,
+			checkpoint:    makeCheckpoint(v1alpha1.ModelCheckpointCompleted),
+			pod:           makePod(map[string]string{constants.CheckpointRestoreAnnotationKey: "true"}, "StatefulSet"),
+			expectedImage: "kserve/sklearnserver:latest",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(s)
+			if scenario.checkpoint != nil {
+				builder = builder.WithObjects(scenario.checkpoint)
+			}
+			injector := &CheckpointRestoreInjector{client: builder.Build(), config: scenario.config}
+
+			require.NoError(t, injector.InjectCheckpointImage(t.Context(), scenario.pod))
+			assert.Equal(t, scenario.expectedImage, scenario.pod.Spec.Containers[0].Image)
+			assert.Equal(t, "kserve/agent:latest", scenario.pod.Spec.Containers[1].Image)
+			if scenario.expectedImage == checkpointImage {
+				assert.Equal(t, "sklearn-predictor-5d8f7b9c4", scenario.pod.Annotations[constants.RestoredFromCheckpointAnnotationKey])
+			} else {
+				assert.NotContains(t, scenario.pod.Annotations, constants.RestoredFromCheckpointAnnotationKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// Arguments of the Alibi Detect server, the pluggable detectors take the same arguments
+const (
+	DetectorArgumentModelName      = "--model_name"
+	DetectorArgumentHttpPort       = "--http_port"
+	DetectorArgumentStorageUri     = "--storage_uri"
+	DetectorArgumentDriftBatchSize = "--drift_batch_size"
+	DriftDetectorCommand           = "DriftDetector"
+	OutlierDetectorCommand         = "OutlierDetector"
+)
+
+type DetectorInjector struct {
+	config *v1beta1.MonitoringConfig
+}
+
+// InjectDetector adds the detector of the predictor monitoring to the pod, which the agent logs the requests of the
+// predictor to. The detector serves its metrics on its port, scraped by the pod monitor of the InferenceService.
+func (di *DetectorInjector) InjectDetector(pod *corev1.Pod) error {
+	jsonMonitoring, ok := pod.ObjectMeta.Annotations[constants.MonitoringInternalAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if utils.GetContainerWithName(&pod.Spec, constants.DetectorContainerName) != nil {
+		return nil
+	}
+	monitoring := &v1beta1.MonitoringSpec{}
+	if err := json.Unmarshal([]byte(jsonMonitoring), monitoring); err != nil {
+		return fmt.Errorf("failed to parse the monitoring of the predictor: %w", err)
+	}
+
+	args := []string{
+		DetectorArgumentModelName, pod.ObjectMeta.Labels[constants.InferenceServicePodLabelKey],
+		DetectorArgumentHttpPort, constants.DetectorPortStr,
+		DetectorArgumentStorageUri, monitoring.StorageURI,
+	}
+	if monitoring.GetType() == v1beta1.DriftDetector {
+		driftBatchSize := di.config.DriftBatchSize
+		if monitoring.DriftBatchSize != nil {
+			driftBatchSize = *monitoring.DriftBatchSize
+		}
+		args = append(args, DriftDetectorCommand, DetectorArgumentDriftBatchSize, strconv.Itoa(int(driftBatchSize)))
+	} else {
+		args = append(args, OutlierDetectorCommand)
+	}
+
+	image := di.config.Image
+	if monitoring.Image != "" {
+		image = monitoring.Image
+	}
+	resources := monitoring.Resources
+	if len(resources.Limits) == 0 && len(resources.Requests) == 0 {
+		resources = di.defaultResources()
+	}
+
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+		Name:  constants.DetectorContainerName,
+		Image: image,
+		Args:  args,
+		Ports: []corev1.ContainerPort{{
+			Name:          constants.DetectorPortName,
+			ContainerPort: constants.DetectorPort,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		Resources:       resources,
+		SecurityContext: pod.Spec.Containers[0].SecurityContext.DeepCopy(),
+	})
+	return nil
+}
+
+// defaultResources returns the resources of the monitoring configuration, which are validated by
+// NewMonitoringConfig
+func (di *DetectorInjector) defaultResources() corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+	for _, quantity := range []struct {
+		value string
+		name  corev1.ResourceName
+		into  *corev1.ResourceList
+	}{
+		{di.config.CPURequest, corev1.ResourceCPU, &resources.Requests},
+		{di.config.MemoryRequest, corev1.ResourceMemory, &resources.Requests},
+		{di.config.CPULimit, corev1.ResourceCPU, &resources.Limits},
+		{di.config.MemoryLimit, corev1.ResourceMemory, &resources.Limits},
+	} {
+		if quantity.value == "" {
+			continue
+		}
+		if *quantity.into == nil {
+			*quantity.into = corev1.ResourceList{}
+		}
+		(*quantity.into)[quantity.name] = resource.MustParse(quantity.value)
+	}
+	return resources
+}
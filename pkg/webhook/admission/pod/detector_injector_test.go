@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInjectDetector(t *testing.T) {
+	config, err := v1beta1.NewMonitoringConfig(&corev1.ConfigMap{Data: map[string]string{
+		v1beta1.MonitoringConfigName: `{"cpuRequest": "100m", "memoryLimit": "2Gi", "driftBatchSize": 500}`,
+	}})
+	require.NoError(t, err)
+	makePod := func(monitoring string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor-5d8f7b9c4-abcde",
+				Namespace:   "default",
+				Labels:      map[string]string{constants.InferenceServicePodLabelKey: "sklearn"},
+				Annotations: map[string]string{},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:            constants.InferenceServiceContainerName,
+					SecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)},
+				}},
+			},
+		}
+		if monitoring != "" {
+			pod.Annotations[constants.MonitoringInternalAnnotationKey] = monitoring
+		}
+		return pod
+	}
+
+	scenarios := map[string]struct {
+		monitoring        string
+		expectedImage     string
+		expectedArgs      []string
+		expectedResources corev1.ResourceRequirements
+	}{
+		"NoMonitoring": {},
+		"DriftDetector": {
+			monitoring:    `{"storageUri": "s3://bucket/drift"}`,
+			expectedImage: constants.DefaultDetectorImage,
+			expectedArgs: []string{
+				"--model_name", "sklearn", "--http_port", constants.DetectorPortStr, "--storage_uri", "s3://bucket/drift",
+				"DriftDetector", "--drift_batch_size", "500",
+			},
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		},
+		"PluggableOutlierDetector": {
+			monitoring: `{"type": "Outlier", "storageUri": "gs://bucket/outlier", "image": "registry.example.com/outlier:v1",
+				"resources": {"limits": {"cpu": "2"}}}`,
+			expectedImage: "registry.example.com/outlier:v1",
+			expectedArgs: []string{
+				"--model_name", "sklearn", "--http_port", constants.DetectorPortStr, "--storage_uri", "gs://bucket/outlier",
+				"OutlierDetector",
+			},
+			expectedResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			injector := &DetectorInjector{config: config}
+			pod := makePod(scenario.monitoring)
+
+			require.NoError(t, injector.InjectDetector(pod))
+			if scenario.monitoring == "" {
+				assert.Len(t, pod.Spec.Containers, 1)
+				return
+			}
+			require.Len(t, pod.Spec.Containers, 2)
+			detector := pod.Spec.Containers[1]
+			assert.Equal(t, constants.DetectorContainerName, detector.Name)
+			assert.Equal(t, scenario.expectedImage, detector.Image)
+			assert.Equal(t, scenario.expectedArgs, detector.Args)
+			assert.Equal(t, scenario.expectedResources, detector.Resources)
+			assert.Equal(t, []corev1.ContainerPort{{
+				Name:          constants.DetectorPortName,
+				ContainerPort: constants.DetectorPort,
+				Protocol:      corev1.ProtocolTCP,
+			}}, detector.Ports)
+			assert.Equal(t, pod.Spec.Containers[0].SecurityContext, detector.SecurityContext)
+
+			// the detector is injected once
+			require.NoError(t, injector.InjectDetector(pod))
+			assert.Len(t, pod.Spec.Containers, 2)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+// InjectGrpcReflection mounts the open inference protocol descriptors created by the controller in the model server
+// container and enables its gRPC reflection service, so that clients like grpcurl can discover the services. The
+// reflection service is served next to the inference service on the gRPC port of the container, which must be
+// declared, named grpc or h2c, for the routes of the InferenceService to carry the gRPC requests.
+func InjectGrpcReflection(pod *corev1.Pod) error {
+	if pod.ObjectMeta.Annotations[constants.EnableGrpcReflectionAnnotationKey] != "true" {
+		return nil
+	}
+	isvcName := pod.ObjectMeta.Labels[constants.InferenceServicePodLabelKey]
+	container := utils.GetContainerWithName(&pod.Spec, constants.InferenceServiceContainerName)
+	if isvcName == "" || container == nil {
+		return nil
+	}
+	if !slices.ContainsFunc(container.Ports, func(port corev1.ContainerPort) bool {
+		return strings.Contains(port.Name, "grpc") || strings.Contains(port.Name, "h2c")
+	}) {
+		return fmt.Errorf("%s requires the %s container to declare its gRPC port, named grpc or h2c, for the routes to serve the reflection service",
+			constants.EnableGrpcReflectionAnnotationKey, constants.InferenceServiceContainerName)
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == constants.GrpcDescriptorsVolumeName {
+			return nil
+		}
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: constants.GrpcDescriptorsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: constants.GrpcDescriptorsConfigMapName(isvcName)},
+			},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.GrpcDescriptorsVolumeName,
+		MountPath: constants.GrpcDescriptorsVolumeMountPath,
+		ReadOnly:  true,
+	})
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: constants.GrpcReflectionEnvVarKey, Value: "true"},
+		corev1.EnvVar{
+			Name:  constants.GrpcDescriptorSetPathEnvVarKey,
+			Value: filepath.Join(constants.GrpcDescriptorsVolumeMountPath, constants.GrpcDescriptorSetFileName),
+		},
+	)
+	return nil
+}
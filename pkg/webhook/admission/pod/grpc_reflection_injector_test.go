@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInjectGrpcReflection(t *testing.T) {
+	reflectionMeta := metav1.ObjectMeta{
+		Labels:      map[string]string{constants.InferenceServicePodLabelKey: "sklearn"},
+		Annotations: map[string]string{constants.EnableGrpcReflectionAnnotationKey: "true"},
+	}
+	grpcPorts := []corev1.ContainerPort{{Name: "h2c", ContainerPort: 8081}}
+	injectedSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: grpcPorts,
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      constants.GrpcDescriptorsVolumeName,
+						MountPath: constants.GrpcDescriptorsVolumeMountPath,
+						ReadOnly:  true,
+					},
+				},
+				Env: []corev1.EnvVar{
+					{Name: constants.GrpcReflectionEnvVarKey, Value: "true"},
+					{Name: constants.GrpcDescriptorSetPathEnvVarKey, Value: "/mnt/grpc/grpc_predict_v2.pb"},
+				},
+			},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name: constants.GrpcDescriptorsVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "sklearn-grpc-descriptors"},
+					},
+				},
+			},
+		},
+	}
+
+	scenarios := map[string]struct {
+		original    *corev1.Pod
+		expected    corev1.PodSpec
+		expectedErr bool
+	}{
+		"ReflectionDisabled": {
+			original: &corev1.Pod{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}},
+			},
+			expected: corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}},
+		},
+		"NoModelServerContainer": {
+			original: &corev1.Pod{
+				ObjectMeta: reflectionMeta,
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: constants.TransformerContainerName}}},
+			},
+			expected: corev1.PodSpec{Containers: []corev1.Container{{Name: constants.TransformerContainerName}}},
+		},
+		"ReflectionEnabled": {
+			original: &corev1.Pod{
+				ObjectMeta: reflectionMeta,
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Ports: grpcPorts},
+				}},
+			},
+			expected: injectedSpec,
+		},
+		"NoGrpcPort": {
+			original: &corev1.Pod{
+				ObjectMeta: reflectionMeta,
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}},
+			},
+			expected:    corev1.PodSpec{Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName}}},
+			expectedErr: true,
+		},
+		"AlreadyInjected": {
+			original: &corev1.Pod{
+				ObjectMeta: reflectionMeta,
+				Spec:       *injectedSpec.DeepCopy(),
+			},
+			expected: injectedSpec,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		if err := InjectGrpcReflection(scenario.original); (err != nil) != scenario.expectedErr {
+			t.Errorf("Test %q unexpected error: %v", name, err)
+		}
+		if diff := cmp.Diff(scenario.expected, scenario.original.Spec); diff != "" {
+			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+		}
+	}
+}
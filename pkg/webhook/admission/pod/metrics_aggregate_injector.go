@@ -63,6 +63,11 @@ func setMetricAggregationEnvVarsAndPorts(pod *corev1.Pod) error {
 			if path, ok := pod.ObjectMeta.Annotations[constants.KServeContainerPrometheusPathKey]; ok {
 				kserveContainerPromPath = path
 			}
+			// The agent serves the kserve-container metrics relabeled when metrics relabeling is enabled.
+			if pod.ObjectMeta.Annotations[constants.EnableMetricsRelabelingAnnotationKey] == "true" {
+				kserveContainerPromPort = constants.MetricsRelabelingPortStr
+				kserveContainerPromPath = constants.DefaultPrometheusPath
+			}
 
 			// The kserve container port/path is set as an EnvVar in the queue-proxy container
 			// so that it knows which port/path to scrape from the kserve-container.
@@ -117,9 +122,12 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *corev1.Pod) error {
 	if setPromAnnotation == "true" {
 		// Set prometheus port to default queue proxy prometheus metrics port.
 		// If enableMetricAggregation is true, set it as the queue proxy metrics aggregation port.
+		// Otherwise, if metrics relabeling is enabled, set it as the agent relabeled metrics port.
 		podPromPort := constants.DefaultPodPrometheusPort
 		if enableMetricAggregation == "true" {
 			podPromPort = constants.QueueProxyAggregatePrometheusMetricsPort
+		} else if pod.ObjectMeta.Annotations[constants.EnableMetricsRelabelingAnnotationKey] == "true" {
+			podPromPort = constants.MetricsRelabelingPortStr
 		}
 		pod.ObjectMeta.Annotations[constants.PrometheusPortAnnotationKey] = podPromPort
 		pod.ObjectMeta.Annotations[constants.PrometheusPathAnnotationKey] = constants.DefaultPrometheusPath
@@ -502,6 +502,60 @@ func TestInjectMetricsAggregator(t *testing.T) {
 				},
 			},
 		},
+		"EnableMetricsRelabeling": {
+			original: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.EnableMetricAggregation:              "true",
+						constants.EnableMetricsRelabelingAnnotationKey: "true",
+						constants.KserveContainerPrometheusPortKey:     "8002",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "triton",
+						},
+						{
+							Name:  "queue-proxy",
+							Ports: []corev1.ContainerPort{{Name: "http-usermetric", ContainerPort: 9091, Protocol: "TCP"}},
+						},
+					},
+				},
+			},
+			expected: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constants.EnableMetricAggregation:              "true",
+						constants.EnableMetricsRelabelingAnnotationKey: "true",
+						constants.KserveContainerPrometheusPortKey:     "8002",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "triton",
+						},
+						{
+							Name: "queue-proxy",
+							Env: []corev1.EnvVar{
+								{Name: constants.KServeContainerPrometheusMetricsPortEnvVarKey, Value: constants.MetricsRelabelingPortStr},
+								{Name: constants.KServeContainerPrometheusMetricsPathEnvVarKey, Value: constants.DefaultPrometheusPath},
+								{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: constants.QueueProxyAggregatePrometheusMetricsPort},
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "http-usermetric", ContainerPort: 9091, Protocol: "TCP"},
+								{Name: constants.AggregateMetricsPortName, ContainerPort: qpextAggregateMetricsPort, Protocol: "TCP"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	cfgMap := corev1.ConfigMap{Data: map[string]string{"enableMetricAggregation": "false", "enablePrometheusScraping": "false"}}
@@ -17,8 +17,12 @@ package pod
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,6 +34,8 @@ import (
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/tlspolicy"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 // +kubebuilder:webhook:path=/mutate-pods,mutating=true,failurePolicy=fail,groups="",resources=pods,verbs=create,versions=v1,name=inferenceservice.kserve-webhook-server.pod-mutator,reinvocationPolicy=IfNeeded
@@ -39,7 +45,11 @@ var log = logf.Log.WithName(constants.PodMutatorWebhookName)
 type Mutator struct {
 	Client    client.Client
 	Clientset kubernetes.Interface
-	Decoder   admission.Decoder
+	// NamespaceConfigMaps reads the inferenceservice-config ConfigMaps of the namespaces, from the API server when nil
+	NamespaceConfigMaps client.Reader
+	Decoder             admission.Decoder
+	// TLSPolicy is passed on to the injected agent
+	TLSPolicy *tlspolicy.Policy
 }
 
 // Handle decodes the incoming Pod and executes mutation logic.
@@ -55,7 +65,8 @@ func (mutator *Mutator) Handle(ctx context.Context, req admission.Request) admis
 		return admission.ValidationResponse(true, "")
 	}
 
-	configMap, err := v1beta1.GetInferenceServiceConfigMap(ctx, mutator.Clientset)
+	configMap, err := v1beta1.GetInferenceServiceConfigMapForNamespace(ctx, mutator.Clientset, mutator.NamespaceConfigMaps,
+		req.AdmissionRequest.Namespace)
 	if err != nil {
 		log.Error(err, "Failed to find config map", "name", constants.InferenceServiceConfigMapName)
 		return admission.Errored(http.StatusInternalServerError, err)
@@ -77,6 +88,10 @@ func (mutator *Mutator) Handle(ctx context.Context, req admission.Request) admis
 
 	if err := mutator.mutate(ctx, pod, configMap, isvc); err != nil {
 		log.Error(err, "Failed to mutate pod", "name", pod.Labels[constants.InferenceServicePodLabelKey])
+		var missingAttestationsErr *MissingAttestationsError
+		if errors.As(err, &missingAttestationsErr) {
+			return admission.Denied(err.Error())
+		}
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
@@ -89,7 +104,19 @@ func (mutator *Mutator) Handle(ctx context.Context, req admission.Request) admis
 	return admission.PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, patch)
 }
 
+// mutate injects the pod. The injection is idempotent, as the webhook is reinvoked when the other mutating webhooks
+// change the pod after it, e.g. to add their own sidecars and volumes.
 func (mutator *Mutator) mutate(ctx context.Context, pod *corev1.Pod, configMap *corev1.ConfigMap, isvc *v1beta1.InferenceService) error {
+	configHash, err := configMapHash(configMap)
+	if err != nil {
+		return err
+	}
+	// The containers injected with another configuration, e.g. in the spec of a former pod the pod is recreated
+	// from, are removed to be injected again with the current configuration
+	if pod.ObjectMeta.Annotations[constants.PodMutatorConfigHashInternalAnnotationKey] != configHash {
+		removeInjectedSidecars(pod)
+	}
+
 	credentialBuilder := credentials.NewCredentialBuilder(mutator.Client, mutator.Clientset, configMap)
 
 	storageInitializerConfig, err := v1beta1.GetStorageInitializerConfigs(configMap)
@@ -123,10 +150,40 @@ func (mutator *Mutator) mutate(ctx context.Context, pod *corev1.Pod, configMap *
 		agentConfig:       agentConfig,
 		loggerConfig:      loggerConfig,
 		batcherConfig:     batcherConfig,
+		tlsPolicy:         mutator.TLSPolicy,
 	}
 
 	metricsAggregator := newMetricsAggregator(configMap)
 
+	checkpointRestoreConfig, err := v1beta1.NewCheckpointRestoreConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	checkpointRestoreInjector := &CheckpointRestoreInjector{
+		client: mutator.Client,
+		config: checkpointRestoreConfig,
+	}
+
+	monitoringConfig, err := v1beta1.NewMonitoringConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	detectorInjector := &DetectorInjector{
+		config: monitoringConfig,
+	}
+
+	provenanceConfig, err := v1beta1.NewProvenanceConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	provenanceInjector := &ProvenanceInjector{
+		clientset: mutator.Clientset,
+		config:    provenanceConfig,
+	}
+
 	mutators := []func(pod *corev1.Pod) error{
 		InjectGKEAcceleratorSelector,
 		func(pod *corev1.Pod) error {
@@ -134,19 +191,95 @@ func (mutator *Mutator) mutate(ctx context.Context, pod *corev1.Pod, configMap *
 		},
 		storageInitializer.SetIstioCniSecurityContext,
 		agentInjector.InjectAgent,
+		detectorInjector.InjectDetector,
 		metricsAggregator.InjectMetricsAggregator,
+		InjectGrpcReflection,
+		func(pod *corev1.Pod) error {
+			return checkpointRestoreInjector.InjectCheckpointImage(ctx, pod)
+		},
 	}
 
 	if storageInitializer.config.EnableOciImageSource {
 		mutators = append(mutators, storageInitializer.InjectModelcar)
 	}
 
+	// The provenance is recorded for the final images of the containers, and the overhead accounts for all the
+	// injected containers and is computed last
+	mutators = append(mutators,
+		func(pod *corev1.Pod) error {
+			return provenanceInjector.InjectProvenance(ctx, pod)
+		},
+		OrderInjectedInitContainers, InjectResourceOverhead)
+
 	for _, mutator := range mutators {
 		if err := mutator(pod); err != nil {
 			return err
 		}
 	}
 
+	if pod.ObjectMeta.Annotations == nil {
+		pod.ObjectMeta.Annotations = map[string]string{}
+	}
+	pod.ObjectMeta.Annotations[constants.PodMutatorConfigHashInternalAnnotationKey] = configHash
+	return nil
+}
+
+// configMapHash returns the hash of the configuration the containers are injected with
+func configMapHash(configMap *corev1.ConfigMap) (string, error) {
+	// the keys of the maps are marshaled sorted
+	data, err := json.Marshal(configMap.Data)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// removeInjectedSidecars removes the agent, the detector and the modelcar injected in the pod, restoring the port of
+// the component to the queue proxy. The storage initializer is left as is, as it can be part of the pod template. The
+// resource overhead is computed again, unless it was deducted from the model server container.
+func removeInjectedSidecars(pod *corev1.Pod) {
+	if agent := utils.GetContainerWithName(&pod.Spec, constants.AgentContainerName); agent != nil {
+		// the agent environment is a copy of the queue proxy environment before the port is redirected to the agent
+		if queueProxy := utils.GetContainerWithName(&pod.Spec, "queue-proxy"); queueProxy != nil {
+			for _, envVar := range agent.Env {
+				if envVar.Name == "USER_PORT" {
+					utils.AddOrReplaceEnv(queueProxy, envVar.Name, envVar.Value)
+				}
+			}
+		}
+	}
+	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, func(container corev1.Container) bool {
+		return container.Name == constants.AgentContainerName || container.Name == constants.ModelcarContainerName ||
+			container.Name == constants.DetectorContainerName
+	})
+	pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, func(container corev1.Container) bool {
+		return container.Name == constants.ModelcarInitContainerName
+	})
+	if pod.ObjectMeta.Annotations[constants.DeductSidecarOverheadAnnotationKey] != "true" {
+		delete(pod.ObjectMeta.Annotations, constants.InjectedResourceOverheadAnnotationKey)
+	}
+}
+
+// OrderInjectedInitContainers moves the injected init containers after the init containers of the pod, including
+// those added by the other mutating webhooks, in the order of the injected init containers. The model is then
+// provisioned by the same init container order whether the webhook is reinvoked or not, once the sidecars of a
+// service mesh started as init containers are running.
+func OrderInjectedInitContainers(pod *corev1.Pod) error {
+	initContainers := make([]corev1.Container, 0, len(pod.Spec.InitContainers))
+	for _, container := range pod.Spec.InitContainers {
+		if !slices.Contains(injectedInitContainers, container.Name) {
+			initContainers = append(initContainers, container)
+		}
+	}
+	for _, name := range injectedInitContainers {
+		if container := utils.GetInitContainerWithName(&pod.Spec, name); container != nil {
+			initContainers = append(initContainers, *container)
+		}
+	}
+	if len(initContainers) > 0 {
+		pod.Spec.InitContainers = initContainers
+	}
 	return nil
 }
 
@@ -31,10 +31,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/credentials"
+	"github.com/kserve/kserve/pkg/utils"
 )
 
 func TestMutator_Handle(t *testing.T) {
@@ -56,6 +60,43 @@ func TestMutator_Handle(t *testing.T) {
 	}
 	mutator := Mutator{Client: c, Clientset: clientset, Decoder: admission.NewDecoder(c.Scheme())}
 
+	configData := map[string]string{
+		v1beta1.StorageInitializerConfigMapKeyName: `{
+			"image" : "kserve/storage-initializer:latest",
+			"memoryRequest": "100Mi",
+			"memoryLimit": "1Gi",
+			"cpuRequest": "100m",
+			"cpuLimit": "1",
+			"cpuModelcar": "100m",
+			"memoryModelcar": "50Mi",
+			"storageSpecSecretName": "storage-config"
+		}`,
+		LoggerConfigMapKeyName: `{
+			"image" : "kserve/agent:latest",
+			"memoryRequest": "100Mi",
+			"memoryLimit": "1Gi",
+			"cpuRequest": "100m",
+			"cpuLimit": "1",
+			"defaultUrl": "http://default-broker"
+		}`,
+		BatcherConfigMapKeyName: `{
+			"image" : "kserve/agent:latest",
+			"memoryRequest": "1Gi",
+			"memoryLimit": "1Gi",
+			"cpuRequest": "1",
+			"cpuLimit": "1"
+		}`,
+		constants.AgentConfigMapKeyName: `{
+			"image" : "kserve/agent:latest",
+			"memoryRequest": "100Mi",
+			"memoryLimit": "1Gi",
+			"cpuRequest": "100m",
+			"cpuLimit": "1"
+		}`,
+	}
+	configHash, err := configMapHash(&corev1.ConfigMap{Data: configData})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
 	cases := map[string]struct {
 		configMap corev1.ConfigMap
 		request   admission.Request
@@ -72,41 +113,8 @@ func TestMutator_Handle(t *testing.T) {
 					Name:      constants.InferenceServiceConfigMapName,
 					Namespace: constants.KServeNamespace,
 				},
-				Immutable: nil,
-				Data: map[string]string{
-					v1beta1.StorageInitializerConfigMapKeyName: `{
-						"image" : "kserve/storage-initializer:latest",
-						"memoryRequest": "100Mi",
-						"memoryLimit": "1Gi",
-						"cpuRequest": "100m",
-						"cpuLimit": "1",
-						"cpuModelcar": "100m",
-						"memoryModelcar": "50Mi",
-						"storageSpecSecretName": "storage-config"
-					}`,
-					LoggerConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "100Mi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "100m",
-        				"cpuLimit": "1",
-        				"defaultUrl": "http://default-broker"
-    				}`,
-					BatcherConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "1Gi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "1",
-        				"cpuLimit": "1"
-    				}`,
-					constants.AgentConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "100Mi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "100m",
-        				"cpuLimit": "1"
-    				}`,
-				},
+				Immutable:  nil,
+				Data:       configData,
 				BinaryData: nil,
 			},
 			request: admission.Request{
@@ -180,41 +188,8 @@ func TestMutator_Handle(t *testing.T) {
 					Name:      constants.InferenceServiceConfigMapName,
 					Namespace: constants.KServeNamespace,
 				},
-				Immutable: nil,
-				Data: map[string]string{
-					v1beta1.StorageInitializerConfigMapKeyName: `{
-						"image" : "kserve/storage-initializer:latest",
-						"memoryRequest": "100Mi",
-						"memoryLimit": "1Gi",
-						"cpuRequest": "100m",
-						"cpuLimit": "1",
-						"cpuModelcar": "100m",
-						"memoryModelcar": "50Mi",
-						"storageSpecSecretName": "storage-config"
-					}`,
-					LoggerConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "100Mi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "100m",
-        				"cpuLimit": "1",
-        				"defaultUrl": "http://default-broker"
-    				}`,
-					BatcherConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "1Gi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "1",
-        				"cpuLimit": "1"
-    				}`,
-					constants.AgentConfigMapKeyName: `{
-        				"image" : "kserve/agent:latest",
-        				"memoryRequest": "100Mi",
-        				"memoryLimit": "1Gi",
-        				"cpuRequest": "100m",
-        				"cpuLimit": "1"
-    				}`,
-				},
+				Immutable:  nil,
+				Data:       configData,
 				BinaryData: nil,
 			},
 			request: admission.Request{
@@ -275,8 +250,9 @@ func TestMutator_Handle(t *testing.T) {
 						Operation: "add",
 						Path:      "/metadata/annotations",
 						Value: map[string]interface{}{
-							"serving.kserve.io/enable-metric-aggregation":  "",
-							"serving.kserve.io/enable-prometheus-scraping": "",
+							"serving.kserve.io/enable-metric-aggregation":       "",
+							"serving.kserve.io/enable-prometheus-scraping":      "",
+							constants.PodMutatorConfigHashInternalAnnotationKey: configHash,
 						},
 					},
 					{
@@ -327,3 +303,109 @@ func sortPatches(patches []jsonpatch.JsonPatchOperation) {
 		})
 	}
 }
+
+func TestRemoveInjectedSidecars(t *testing.T) {
+	newPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "user-init"},
+					{Name: constants.ModelcarInitContainerName},
+				},
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName},
+					{Name: "queue-proxy", Env: []corev1.EnvVar{{Name: "USER_PORT", Value: constants.InferenceServiceDefaultAgentPortStr}}},
+					{Name: constants.AgentContainerName, Env: []corev1.EnvVar{{Name: "USER_PORT", Value: "8080"}}},
+					{Name: constants.ModelcarContainerName},
+				},
+			},
+		}
+	}
+
+	t.Run("sidecars removed", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{constants.InjectedResourceOverheadAnnotationKey: `{"cpu":"1"}`})
+		removeInjectedSidecars(pod)
+		g.Expect(pod.Spec.InitContainers).To(gomega.Equal([]corev1.Container{{Name: "user-init"}}))
+		g.Expect(pod.Spec.Containers).To(gomega.Equal([]corev1.Container{
+			{Name: constants.InferenceServiceContainerName},
+			{Name: "queue-proxy", Env: []corev1.EnvVar{{Name: "USER_PORT", Value: "8080"}}},
+		}))
+		g.Expect(pod.ObjectMeta.Annotations).ToNot(gomega.HaveKey(constants.InjectedResourceOverheadAnnotationKey))
+	})
+	t.Run("deducted overhead kept", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+		pod := newPod(map[string]string{
+			constants.InjectedResourceOverheadAnnotationKey: `{"cpu":"1"}`,
+			constants.DeductSidecarOverheadAnnotationKey:    "true",
+		})
+		removeInjectedSidecars(pod)
+		g.Expect(pod.ObjectMeta.Annotations).To(gomega.HaveKey(constants.InjectedResourceOverheadAnnotationKey))
+	})
+}
+
+func TestReinjectAgent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &corev1.ConfigMap{Data: map[string]string{}}),
+		agentConfig,
+		loggerTLSConfig,
+		batcherTestConfig,
+		nil,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-predictor",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:             "true",
+				constants.LoggerSinkUrlInternalAnnotationKey:      "https://logger",
+				constants.LoggerModeInternalAnnotationKey:         string(v1beta1.LogAll),
+				constants.AgentShouldInjectAnnotationKey:          "true",
+				constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-sklearn-0",
+				constants.AgentModelDirAnnotationKey:              "/mnt/models",
+				constants.AgentModelConfigMountPathAnnotationKey:  "/mnt/configs",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: "queue-proxy", Env: []corev1.EnvVar{{Name: "USER_PORT", Value: "8080"}}},
+			},
+		},
+	}
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	injected := pod.DeepCopy()
+
+	// The agent injected again, e.g. in a pod recreated from the spec of a former pod, is injected the same way
+	removeInjectedSidecars(pod)
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	g.Expect(pod).To(gomega.Equal(injected))
+	g.Expect(utils.GetContainerWithName(&pod.Spec, "queue-proxy").Env).To(gomega.ContainElement(
+		corev1.EnvVar{Name: "USER_PORT", Value: constants.InferenceServiceDefaultAgentPortStr}))
+}
+
+func TestOrderInjectedInitContainers(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: constants.StorageInitializerContainerName},
+				{Name: "user-init"},
+				// a sidecar of a service mesh added by another mutating webhook
+				{Name: "istio-proxy", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+			},
+		},
+	}
+	g.Expect(OrderInjectedInitContainers(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.InitContainers).To(gomega.Equal([]corev1.Container{
+		{Name: "user-init"},
+		{Name: "istio-proxy", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)},
+		{Name: constants.StorageInitializerContainerName},
+	}))
+
+	// the order is kept when the webhook is reinvoked
+	g.Expect(OrderInjectedInitContainers(pod)).To(gomega.Succeed())
+	g.Expect(pod.Spec.InitContainers[2].Name).To(gomega.Equal(constants.StorageInitializerContainerName))
+}
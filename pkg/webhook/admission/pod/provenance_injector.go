@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// MissingAttestationsError rejects the pods whose images lack a required attestation when the provenance is enforced
+type MissingAttestationsError struct {
+	Container string
+	Image     string
+	Missing   []string
+}
+
+func (e *MissingAttestationsError) Error() string {
+	return fmt.Sprintf("the image %s of the container %s lacks the required attestations %s",
+		e.Image, e.Container, strings.Join(e.Missing, ", "))
+}
+
+// provenanceResolveTimeout bounds the resolution of the images of a pod, within the timeout of the webhook
+const provenanceResolveTimeout = 5 * time.Second
+
+type ProvenanceInjector struct {
+	clientset kubernetes.Interface
+	config    *v1beta1.ProvenanceConfig
+}
+
+// imageProvenance is the provenance of an image resolved from its registry
+type imageProvenance struct {
+	// digest is the image pinned by digest
+	digest string
+	// sbom is the SBOM of the image pinned by digest, empty when it has none
+	sbom string
+	// missing are the required attestations the image lacks
+	missing []string
+}
+
+// InjectProvenance records the version of KServe, the digests of the images and the SBOMs of the images of the pod in
+// its annotations, and labels the pod with whether its images all have the required attestations, so that the
+// InferenceService pods running unverified images are found with a label selector. The images resolved from their
+// registries are pinned by digest, so that the pod runs the verified images. The pod is rejected when the provenance
+// is enforced and an image lacks a required attestation or cannot be resolved, otherwise the failures are recorded in
+// the annotations of the pod.
+func (pi *ProvenanceInjector) InjectProvenance(ctx context.Context, pod *corev1.Pod) error {
+	if !pi.config.Enabled {
+		return nil
+	}
+	keychain, err := pi.keychain(ctx, pod)
+	if err != nil {
+		if pi.config.Enforce {
+			return err
+		}
+		log.Info("Failed to read the image pull secrets, falling back to the default keychain", "err", err.Error())
+		keychain = authn.DefaultKeychain
+	}
+
+	// The images are resolved concurrently within provenanceResolveTimeout, so that an unreachable registry does not
+	// time the admission of the pod out
+	resolveCtx, cancel := context.WithTimeout(ctx, provenanceResolveTimeout)
+	defer cancel()
+	containers := containerPointers(pod.Spec.InitContainers)
+	containers = append(containers, containerPointers(pod.Spec.Containers)...)
+	provenances := make([]*imageProvenance, len(containers))
+	resolveErrs := make([]error, len(containers))
+	var wg sync.WaitGroup
+	for i, container := range containers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provenances[i], resolveErrs[i] = resolveImageProvenance(resolveCtx, container.Image, pi.config.RequiredAttestations, keychain)
+		}()
+	}
+	wg.Wait()
+
+	digests := map[string]string{}
+	sboms := map[string]string{}
+	missing := map[string][]string{}
+	failures := map[string]string{}
+	for i, container := range containers {
+		provenance, err := provenances[i], resolveErrs[i]
+		if err != nil {
+			if pi.config.Enforce {
+				return fmt.Errorf("failed to resolve the provenance of the image %s of the container %s: %w", container.Image, container.Name, err)
+			}
+			log.Info("Failed to resolve the provenance of the image", "image", container.Image, "container", container.Name, "err", err.Error())
+			failures[container.Name] = err.Error()
+			if len(pi.config.RequiredAttestations) > 0 {
+				missing[container.Name] = pi.config.RequiredAttestations
+			}
+			continue
+		}
+		if len(provenance.missing) > 0 && pi.config.Enforce {
+			return &MissingAttestationsError{Container: container.Name, Image: container.Image, Missing: provenance.missing}
+		}
+		container.Image = provenance.digest
+		digests[container.Name] = provenance.digest
+		if provenance.sbom != "" {
+			sboms[container.Name] = provenance.sbom
+		}
+		if len(provenance.missing) > 0 {
+			missing[container.Name] = provenance.missing
+		}
+	}
+
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, constants.KServeVersionAnnotationKey, constants.KServeVersion)
+	for key, value := range map[string]any{
+		constants.ImageDigestsAnnotationKey:        digests,
+		constants.ImageSBOMsAnnotationKey:          sboms,
+		constants.MissingAttestationsAnnotationKey: missing,
+	} {
+		// the keys of the maps are marshaled sorted, the annotations are stable across reinvocations
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, key, string(data))
+	}
+	if len(failures) > 0 {
+		data, err := json.Marshal(failures)
+		if err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, constants.ProvenanceErrorsAnnotationKey, string(data))
+	} else {
+		delete(pod.Annotations, constants.ProvenanceErrorsAnnotationKey)
+	}
+	if len(pi.config.RequiredAttestations) > 0 {
+		metav1.SetMetaDataLabel(&pod.ObjectMeta, constants.AttestationsVerifiedLabelKey, strconv.FormatBool(len(missing) == 0))
+	}
+	return nil
+}
+
+func containerPointers(containers []corev1.Container) []*corev1.Container {
+	pointers := make([]*corev1.Container, len(containers))
+	for i := range containers {
+		pointers[i] = &containers[i]
+	}
+	return pointers
+}
+
+// resolveImageProvenance resolves the digest of the image and looks up its attestations, published by cosign with
+// the tags sha256-<digest>.sbom, sha256-<digest>.sig and sha256-<digest>.att in the repository of the image
+func resolveImageProvenance(ctx context.Context, image string, required []string, keychain authn.Keychain) (*imageProvenance, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	options := []remote.Option{remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)}
+	descriptor, err := remote.Head(ref, options...)
+	if err != nil {
+		return nil, err
+	}
+	provenance := &imageProvenance{digest: ref.Context().Digest(descriptor.Digest.String()).String()}
+
+	for _, kind := range v1beta1.ProvenanceAttestationKinds {
+		isRequired := slices.Contains(required, kind)
+		if kind != "sbom" && !isRequired {
+			continue
+		}
+		tag := ref.Context().Tag(fmt.Sprintf("%s-%s.%s", descriptor.Digest.Algorithm, descriptor.Digest.Hex, kind))
+		attestation, err := remote.Head(tag, options...)
+		if err != nil {
+			var transportErr *transport.Error
+			if !errors.As(err, &transportErr) || transportErr.StatusCode != http.StatusNotFound {
+				return nil, err
+			}
+			if isRequired {
+				provenance.missing = append(provenance.missing, kind)
+			}
+			continue
+		}
+		if kind == "sbom" {
+			provenance.sbom = ref.Context().Digest(attestation.Digest.String()).String()
+		}
+	}
+	return provenance, nil
+}
+
+// keychain authenticates to the registries with the image pull secrets of the pod, which include the ones
+// of its service account, falling back to the docker config of the manager
+func (pi *ProvenanceInjector) keychain(ctx context.Context, pod *corev1.Pod) (authn.Keychain, error) {
+	auths := map[string]authn.AuthConfig{}
+	for _, pullSecret := range pod.Spec.ImagePullSecrets {
+		secret, err := pi.clientset.CoreV1().Secrets(pod.Namespace).Get(ctx, pullSecret.Name, metav1.GetOptions{})
+		if err != nil {
+			log.Info("Failed to get the image pull secret", "name", pullSecret.Name, "err", err.Error())
+			continue
+		}
+		dockerConfig := struct {
+			Auths map[string]authn.AuthConfig `json:"auths"`
+		}{}
+		if data, ok := secret.Data[corev1.DockerConfigJsonKey]; ok {
+			if err := json.Unmarshal(data, &dockerConfig); err != nil {
+				return nil, fmt.Errorf("invalid image pull secret %s: %w", pullSecret.Name, err)
+			}
+		} else if data, ok := secret.Data[corev1.DockerConfigKey]; ok {
+			if err := json.Unmarshal(data, &dockerConfig.Auths); err != nil {
+				return nil, fmt.Errorf("invalid image pull secret %s: %w", pullSecret.Name, err)
+			}
+		}
+		for server, auth := range dockerConfig.Auths {
+			// the servers are either registry hosts or URLs, e.g. https://index.docker.io/v1/
+			host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+			host, _, _ = strings.Cut(host, "/")
+			if host == "index.docker.io" || host == "docker.io" {
+				host = name.DefaultRegistry
+			}
+			if _, ok := auths[host]; !ok {
+				auths[host] = auth
+			}
+		}
+	}
+	return authn.NewMultiKeychain(pullSecretsKeychain(auths), authn.DefaultKeychain), nil
+}
+
+// pullSecretsKeychain maps the registry hosts to their credentials
+type pullSecretsKeychain map[string]authn.AuthConfig
+
+func (k pullSecretsKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	auth, ok := k[resource.RegistryStr()]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(auth), nil
+}
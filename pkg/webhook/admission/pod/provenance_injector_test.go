@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func pushRandomImage(t *testing.T, reference string) v1.Hash {
+	t.Helper()
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	image, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, image))
+	digest, err := image.Digest()
+	require.NoError(t, err)
+	return digest
+}
+
+func TestInjectProvenance(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	// The model server image has an SBOM and a signature, the storage initializer image has no attestations
+	serverDigest := pushRandomImage(t, host+"/sklearnserver:latest")
+	sbomDigest := pushRandomImage(t, host+"/sklearnserver:sha256-"+serverDigest.Hex+".sbom")
+	pushRandomImage(t, host+"/sklearnserver:sha256-"+serverDigest.Hex+".sig")
+	initializerDigest := pushRandomImage(t, host+"/storage-initializer:latest")
+
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: constants.StorageInitializerContainerName, Image: host + "/storage-initializer:latest"}},
+				Containers:     []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: host + "/sklearnserver:latest"}},
+			},
+		}
+	}
+	newInjector := func(config *v1beta1.ProvenanceConfig) *ProvenanceInjector {
+		return &ProvenanceInjector{clientset: fake.NewSimpleClientset(), config: config}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		pod := newPod()
+		require.NoError(t, newInjector(&v1beta1.ProvenanceConfig{}).InjectProvenance(t.Context(), pod))
+		assert.Empty(t, pod.Annotations)
+		assert.Empty(t, pod.Labels)
+	})
+
+	t.Run("recorded", func(t *testing.T) {
+		pod := newPod()
+		injector := newInjector(&v1beta1.ProvenanceConfig{Enabled: true, RequiredAttestations: []string{"sbom", "sig"}})
+		require.NoError(t, injector.InjectProvenance(t.Context(), pod))
+
+		assert.Equal(t, constants.KServeVersion, pod.Annotations[constants.KServeVersionAnnotationKey])
+		digests := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(pod.Annotations[constants.ImageDigestsAnnotationKey]), &digests))
+		assert.Equal(t, map[string]string{
+			constants.InferenceServiceContainerName:   host + "/sklearnserver@" + serverDigest.String(),
+			constants.StorageInitializerContainerName: host + "/storage-initializer@" + initializerDigest.String(),
+		}, digests)
+		sboms := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(pod.Annotations[constants.ImageSBOMsAnnotationKey]), &sboms))
+		assert.Equal(t, map[string]string{constants.InferenceServiceContainerName: host + "/sklearnserver@" + sbomDigest.String()}, sboms)
+		missing := map[string][]string{}
+		require.NoError(t, json.Unmarshal([]byte(pod.Annotations[constants.MissingAttestationsAnnotationKey]), &missing))
+		assert.Equal(t, map[string][]string{constants.StorageInitializerContainerName: {"sbom", "sig"}}, missing)
+		assert.Equal(t, "false", pod.Labels[constants.AttestationsVerifiedLabelKey])
+		assert.NotContains(t, pod.Annotations, constants.ProvenanceErrorsAnnotationKey)
+
+		// The containers run the images pinned by digest
+		assert.Equal(t, host+"/sklearnserver@"+serverDigest.String(), pod.Spec.Containers[0].Image)
+		assert.Equal(t, host+"/storage-initializer@"+initializerDigest.String(), pod.Spec.InitContainers[0].Image)
+
+		// The pinned images resolve to the same provenance on the reinvocation of the mutator
+		annotations := maps.Clone(pod.Annotations)
+		require.NoError(t, injector.InjectProvenance(t.Context(), pod))
+		assert.Equal(t, annotations, pod.Annotations)
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		pod := newPod()
+		injector := newInjector(&v1beta1.ProvenanceConfig{Enabled: true, RequiredAttestations: []string{"sig"}, Enforce: true})
+		err := injector.InjectProvenance(t.Context(), pod)
+		var missingAttestationsErr *MissingAttestationsError
+		require.True(t, errors.As(err, &missingAttestationsErr), err)
+		assert.Equal(t, constants.StorageInitializerContainerName, missingAttestationsErr.Container)
+		assert.Equal(t, []string{"sig"}, missingAttestationsErr.Missing)
+
+		// The pod is admitted once its images all have the required attestations
+		pod.Spec.InitContainers = nil
+		require.NoError(t, injector.InjectProvenance(t.Context(), pod))
+		assert.Equal(t, "true", pod.Labels[constants.AttestationsVerifiedLabelKey])
+	})
+
+	t.Run("unresolved image", func(t *testing.T) {
+		pod := newPod()
+		pod.Spec.Containers[0].Image = host + "/unknown:latest"
+		injector := newInjector(&v1beta1.ProvenanceConfig{Enabled: true})
+		require.NoError(t, injector.InjectProvenance(t.Context(), pod))
+		assert.NotContains(t, pod.Annotations[constants.ImageDigestsAnnotationKey], constants.InferenceServiceContainerName)
+		assert.Equal(t, "{}", pod.Annotations[constants.MissingAttestationsAnnotationKey])
+		assert.NotContains(t, pod.Labels, constants.AttestationsVerifiedLabelKey)
+		failures := map[string]string{}
+		require.NoError(t, json.Unmarshal([]byte(pod.Annotations[constants.ProvenanceErrorsAnnotationKey]), &failures))
+		assert.Contains(t, failures, constants.InferenceServiceContainerName)
+		assert.Equal(t, host+"/unknown:latest", pod.Spec.Containers[0].Image)
+
+		injector.config.Enforce = true
+		require.ErrorContains(t, injector.InjectProvenance(t.Context(), pod), "failed to resolve the provenance of the image "+host+"/unknown:latest")
+	})
+}
+
+func TestInjectProvenanceUnreachableRegistry(t *testing.T) {
+	// The registry never answers, the resolution is bounded by provenanceResolveTimeout
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer server.Close()
+	defer close(blocked)
+	host := strings.TrimPrefix(server.URL, "http://")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: constants.StorageInitializerContainerName, Image: host + "/storage-initializer:latest"}},
+			Containers:     []corev1.Container{{Name: constants.InferenceServiceContainerName, Image: host + "/sklearnserver:latest"}},
+		},
+	}
+	injector := &ProvenanceInjector{clientset: fake.NewSimpleClientset(), config: &v1beta1.ProvenanceConfig{Enabled: true}}
+
+	start := time.Now()
+	require.NoError(t, injector.InjectProvenance(t.Context(), pod))
+	// The images are resolved concurrently
+	assert.Less(t, time.Since(start), 2*provenanceResolveTimeout)
+	failures := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(pod.Annotations[constants.ProvenanceErrorsAnnotationKey]), &failures))
+	assert.Len(t, failures, 2)
+}
+
+func TestProvenanceKeychain(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths": {
+				"https://index.docker.io/v1/": {"auth": "ZG9ja2VyOmh1Yg=="},
+				"registry.example.com": {"username": "user", "password": "secret"}
+			}}`)},
+		},
+	)
+	injector := &ProvenanceInjector{clientset: clientset, config: &v1beta1.ProvenanceConfig{Enabled: true}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec:       corev1.PodSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "missing"}}},
+	}
+	keychain, err := injector.keychain(t.Context(), pod)
+	require.NoError(t, err)
+
+	for image, expected := range map[string]*authn.AuthConfig{
+		"kserve/sklearnserver:latest":         {Username: "docker", Password: "hub"},
+		"registry.example.com/model:latest":   {Username: "user", Password: "secret"},
+		"other.example.com/model-server:v1.0": nil,
+	} {
+		ref, err := name.ParseReference(image)
+		require.NoError(t, err)
+		authenticator, err := keychain.Resolve(ref.Context())
+		require.NoError(t, err)
+		auth, err := authenticator.Authorization()
+		require.NoError(t, err)
+		if expected == nil {
+			assert.Equal(t, &authn.AuthConfig{}, auth, image)
+		} else {
+			assert.Equal(t, expected.Username, auth.Username, image)
+			assert.Equal(t, expected.Password, auth.Password, image)
+		}
+	}
+}
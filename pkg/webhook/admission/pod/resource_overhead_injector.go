@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var (
+	// injectedContainers are the sidecar containers added to the pods by the mutator
+	injectedContainers = []string{constants.AgentContainerName, constants.ModelcarContainerName}
+	// injectedInitContainers are the init containers added to the pods by the mutator
+	injectedInitContainers = []string{constants.StorageInitializerContainerName, constants.ModelcarInitContainerName}
+)
+
+// InjectResourceOverhead annotates the pod with the resources requested by the containers injected by the mutator, i.e.
+// the difference between the effective requests of the pod with and without the agent, the modelcar and the storage
+// initializer, so that the requests of the pods can be accounted for when sizing the nodes.
+// When the pod is annotated with serving.kserve.io/deduct-sidecar-overhead, the requests and limits of the sidecars
+// are deducted from the model server container, so that the pod requests the resources of the InferenceService.
+// The init containers do not run alongside the model server and are not deducted.
+func InjectResourceOverhead(pod *corev1.Pod) error {
+	if _, ok := pod.ObjectMeta.Annotations[constants.InjectedResourceOverheadAnnotationKey]; ok {
+		return nil
+	}
+
+	var userContainers, sidecars, userInitContainers []corev1.Container
+	for _, container := range pod.Spec.Containers {
+		if slices.Contains(injectedContainers, container.Name) {
+			sidecars = append(sidecars, container)
+		} else {
+			userContainers = append(userContainers, container)
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if !slices.Contains(injectedInitContainers, container.Name) {
+			userInitContainers = append(userInitContainers, container)
+		}
+	}
+
+	overhead := corev1.ResourceList{}
+	withInjected := effectiveRequests(pod.Spec.Containers, pod.Spec.InitContainers)
+	withoutInjected := effectiveRequests(userContainers, userInitContainers)
+	for name, quantity := range withInjected {
+		quantity.Sub(withoutInjected[name])
+		if quantity.Sign() > 0 {
+			overhead[name] = quantity
+		}
+	}
+	if len(overhead) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(overhead)
+	if err != nil {
+		return err
+	}
+	if pod.ObjectMeta.Annotations == nil {
+		pod.ObjectMeta.Annotations = map[string]string{}
+	}
+	pod.ObjectMeta.Annotations[constants.InjectedResourceOverheadAnnotationKey] = string(value)
+
+	if pod.ObjectMeta.Annotations[constants.DeductSidecarOverheadAnnotationKey] != "true" {
+		return nil
+	}
+	container := utils.GetContainerWithName(&pod.Spec, constants.InferenceServiceContainerName)
+	if container == nil {
+		return nil
+	}
+	sidecarRequests, sidecarLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, sidecar := range sidecars {
+		addResources(sidecarRequests, sidecar.Resources.Requests)
+		addResources(sidecarLimits, sidecar.Resources.Limits)
+	}
+	for name, quantity := range sidecarRequests {
+		request, ok := container.Resources.Requests[name]
+		if !ok {
+			continue
+		}
+		if request.Cmp(quantity) <= 0 {
+			log.Info("Model server request too small to deduct the sidecar overhead", "pod", pod.Name,
+				"resource", name, "request", request.String(), "overhead", quantity.String())
+			continue
+		}
+		request.Sub(quantity)
+		container.Resources.Requests[name] = request
+	}
+	for name, quantity := range sidecarLimits {
+		current, ok := container.Resources.Limits[name]
+		if !ok {
+			continue
+		}
+		limit := current.DeepCopy()
+		limit.Sub(quantity)
+		// The limit is left as is rather than set below the request of the model server
+		if request, ok := container.Resources.Requests[name]; limit.Sign() <= 0 || (ok && limit.Cmp(request) < 0) {
+			log.Info("Model server limit too small to deduct the sidecar overhead", "pod", pod.Name,
+				"resource", name, "limit", current.String(), "overhead", quantity.String())
+			continue
+		}
+		container.Resources.Limits[name] = limit
+	}
+	return nil
+}
+
+// effectiveRequests returns the requests of a pod with the containers and init containers, which is the highest of
+// the sum of the requests of the containers and of the requests of each init container
+func effectiveRequests(containers []corev1.Container, initContainers []corev1.Container) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range initContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+func addResources(total corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestInjectResourceOverhead(t *testing.T) {
+	resources := func(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+				corev1.ResourceMemory: resource.MustParse(memoryRequest),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+				corev1.ResourceMemory: resource.MustParse(memoryLimit),
+			},
+		}
+	}
+	makePod := func(annotations map[string]string, modelServer corev1.ResourceRequirements) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: constants.StorageInitializerContainerName, Resources: resources("100m", "100Mi", "1", "1Gi")},
+				},
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Resources: modelServer},
+					{Name: constants.AgentContainerName, Resources: resources("100m", "100Mi", "1", "1Gi")},
+				},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		pod                 *corev1.Pod
+		expectedOverhead    string
+		expectedModelServer corev1.ResourceRequirements
+	}{
+		"NoInjectedContainers": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: constants.InferenceServiceContainerName, Resources: resources("1", "2Gi", "2", "4Gi")},
+					},
+				},
+			},
+			expectedModelServer: resources("1", "2Gi", "2", "4Gi"),
+		},
+		"Annotated": {
+			pod:                 makePod(nil, resources("1", "2Gi", "2", "4Gi")),
+			expectedOverhead:    `{"cpu":"100m","memory":"100Mi"}`,
+			expectedModelServer: resources("1", "2Gi", "2", "4Gi"),
+		},
+		"InitContainerAboveContainers": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Name: constants.StorageInitializerContainerName, Resources: resources("100m", "100Mi", "1", "1Gi")},
+					},
+					Containers: []corev1.Container{
+						{Name: constants.InferenceServiceContainerName, Resources: resources("50m", "2Gi", "1", "4Gi")},
+					},
+				},
+			},
+			expectedOverhead:    `{"cpu":"50m"}`,
+			expectedModelServer: resources("50m", "2Gi", "1", "4Gi"),
+		},
+		"Deducted": {
+			pod: makePod(map[string]string{constants.DeductSidecarOverheadAnnotationKey: "true"},
+				resources("1", "2Gi", "2", "4Gi")),
+			expectedOverhead:    `{"cpu":"100m","memory":"100Mi"}`,
+			expectedModelServer: resources("900m", "1948Mi", "1", "3Gi"),
+		},
+		"DeductionExceedsModelServerResources": {
+			pod: makePod(map[string]string{constants.DeductSidecarOverheadAnnotationKey: "true"},
+				resources("100m", "2Gi", "1", "2Gi")),
+			expectedOverhead:    `{"cpu":"100m","memory":"100Mi"}`,
+			expectedModelServer: resources("100m", "1948Mi", "1", "2Gi"),
+		},
+		"AlreadyAnnotated": {
+			pod: makePod(map[string]string{
+				constants.DeductSidecarOverheadAnnotationKey:    "true",
+				constants.InjectedResourceOverheadAnnotationKey: `{"cpu":"100m","memory":"100Mi"}`,
+			}, resources("900m", "1948Mi", "1", "3Gi")),
+			expectedOverhead:    `{"cpu":"100m","memory":"100Mi"}`,
+			expectedModelServer: resources("900m", "1948Mi", "1", "3Gi"),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, InjectResourceOverhead(scenario.pod))
+			assert.Equal(t, scenario.expectedOverhead, scenario.pod.Annotations[constants.InjectedResourceOverheadAnnotationKey])
+			modelServer := scenario.pod.Spec.Containers[0].Resources
+			for name, quantity := range scenario.expectedModelServer.Requests {
+				assert.Zero(t, quantity.Cmp(modelServer.Requests[name]), "request %s: %s", name, modelServer.Requests.Name(name, resource.DecimalSI))
+			}
+			for name, quantity := range scenario.expectedModelServer.Limits {
+				assert.Zero(t, quantity.Cmp(modelServer.Limits[name]), "limit %s: %s", name, modelServer.Limits.Name(name, resource.DecimalSI))
+			}
+		})
+	}
+}
@@ -21,13 +21,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"knative.dev/pkg/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -287,20 +290,31 @@ func CommonStorageInitialization(ctx context.Context, params *StorageInitializer
 
 		// Mount PVC storage URIs directly as volumes (no init container needed)
 		for _, storageURI := range pvcStorageURIs {
-			for _, containerName := range mountContainerNames {
-				pvcName, _, err := utils.ParsePvcURI(storageURI.Uri)
-				if err != nil {
-					return fmt.Errorf("failed to parse PVC URI %q: %w", storageURI.Uri, err)
-				}
+			pvcName, pvcPath, err := utils.ParsePvcURI(storageURI.Uri)
+			if err != nil {
+				return fmt.Errorf("failed to parse PVC URI %q: %w", storageURI.Uri, err)
+			}
 
-				storageMountParams := utils.StorageMountParams{
-					MountPath:  storageURI.MountPath,
-					SubPath:    "",
-					VolumeName: utils.GetVolumeNameFromPath(storageURI.MountPath),
-					PVCName:    pvcName,
-					ReadOnly:   params.IsReadOnly,
-				}
+			storageMountParams := utils.StorageMountParams{
+				MountPath:  storageURI.MountPath,
+				SubPath:    "",
+				VolumeName: utils.GetVolumeNameFromPath(storageURI.MountPath),
+				PVCName:    pvcName,
+				ReadOnly:   params.IsReadOnly,
+			}
 
+			// Only the sub path of the storage URI is mounted from a shared model library
+			shared, err := isSharedModelLibrary(ctx, params.Client, params.Namespace, pvcName)
+			if err != nil {
+				return err
+			}
+			if shared {
+				storageMountParams.SubPath = pvcPath
+				storageMountParams.ReadOnly = true
+				storageMountParams.ReadOnlyClaim = true
+			}
+
+			for _, containerName := range mountContainerNames {
 				if mountErr := utils.AddModelMount(storageMountParams, containerName, params.PodSpec); mountErr != nil {
 					return fmt.Errorf("failed to add PVC mount for container %q: %w", containerName, mountErr)
 				}
@@ -372,6 +386,17 @@ func CommonStorageInitialization(ctx context.Context, params *StorageInitializer
 			storageMountParams.SubPath = pvcPath
 			storageMountParams.PVCName = pvcName
 			storageMountParams.VolumeName = constants.PvcSourceMountName
+
+			// A shared model library is always mounted read-only, so that an InferenceService can not alter the
+			// models of the others
+			shared, err := isSharedModelLibrary(ctx, params.Client, params.Namespace, pvcName)
+			if err != nil {
+				return err
+			}
+			if shared {
+				storageMountParams.ReadOnly = true
+				storageMountParams.ReadOnlyClaim = true
+			}
 		} else {
 			initContainerArgs = append(initContainerArgs, storageURI.Uri, storageURI.MountPath)
 			initContainer = utils.CreateInitContainerWithConfig(params.Config, initContainerArgs)
@@ -429,6 +454,11 @@ func CommonStorageInitialization(ctx context.Context, params *StorageInitializer
 			}
 		}
 
+		// The StorageProfile connection details take precedence over the ones of the storage secret
+		if err := injectStorageProfile(ctx, params.Client, params.IsvcAnnotations, params.StorageSpec, params.StorageURIs, initContainer); err != nil {
+			return err
+		}
+
 		// Inject CA bundle configMap if caBundleConfigMapName or constants.DefaultGlobalCaBundleConfigMapName annotation is set
 		// Store the CA bundle configuration to be applied after merge to avoid conflicts
 		var caBundleConfigMapName string
@@ -510,10 +540,18 @@ func CommonStorageInitialization(ctx context.Context, params *StorageInitializer
 				ReadOnly:  true,
 			}
 
-			params.PodSpec.Volumes = append(params.PodSpec.Volumes, caBundleVolume)
+			params.PodSpec.Volumes = utils.AppendVolumeIfNotExists(params.PodSpec.Volumes, caBundleVolume)
 			initContainer.VolumeMounts = append(initContainer.VolumeMounts, caBundleVolumeMount)
 		}
 
+		// Only download the model files matching the include and exclude patterns of the storage spec
+		addStorageFilterEnvVars(params.IsvcAnnotations, initContainer)
+
+		// Mount the node-local checksum cache so that only the changed files of a model with a manifest are downloaded
+		if params.Config.ChecksumCacheHostPath != "" {
+			injectChecksumCache(params.PodSpec, initContainer, params.Config.ChecksumCacheHostPath)
+		}
+
 		// Add default HuggingFace optimization environment variables if they don't already exist.
 		// This is done after merging to avoid conflicts with user-defined environment variables.
 		// See https://github.com/kserve/kserve/issues/4761
@@ -523,6 +561,152 @@ func CommonStorageInitialization(ctx context.Context, params *StorageInitializer
 	return nil
 }
 
+// isSharedModelLibrary returns true when the PVC is a model library shared by many InferenceServices. A missing PVC is
+// not shared, the pod then waits for the PVC to be created.
+func isSharedModelLibrary(ctx context.Context, c client.Client, namespace string, pvcName string) (bool, error) {
+	if c == nil || namespace == "" {
+		return false, nil
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: pvcName}, pvc); err != nil {
+		if apierr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return utils.IsSharedModelLibrary(pvc), nil
+}
+
+// injectStorageProfile sets the connection details of the StorageProfile referenced by the storage profile annotation,
+// or else by the storage key, on the storage initializer of the s3 storage URIs. A StorageProfile named after the
+// storage key is optional since the storage key usually only references an entry of the storage secret.
+func injectStorageProfile(ctx context.Context, c client.Client, annotations map[string]string, storageSpec *v1beta1.StorageSpec,
+	storageURIs []v1beta1.StorageUri, initContainer *corev1.Container,
+) error {
+	if c == nil {
+		return nil
+	}
+	profileName, required := annotations[constants.StorageProfileAnnotationKey], true
+	if profileName == "" && storageSpec != nil && storageSpec.StorageKey != nil {
+		profileName, required = *storageSpec.StorageKey, false
+	}
+	if profileName == "" {
+		return nil
+	}
+	hasS3URI := false
+	for _, storageURI := range storageURIs {
+		if strings.HasPrefix(storageURI.Uri, constants.S3URIPrefix) {
+			hasS3URI = true
+			break
+		}
+	}
+	if !hasS3URI {
+		return nil
+	}
+
+	profile := &v1alpha1.StorageProfile{}
+	if err := c.Get(ctx, client.ObjectKey{Name: profileName}, profile); err != nil {
+		if apierr.IsNotFound(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to get StorageProfile %s: %w", profileName, err)
+	}
+	envs, err := storageProfileEnvVars(&profile.Spec)
+	if err != nil {
+		return fmt.Errorf("invalid StorageProfile %s: %w", profileName, err)
+	}
+	for _, env := range envs {
+		utils.AddOrReplaceEnv(initContainer, env.Name, env.Value)
+	}
+	return nil
+}
+
+// storageProfileEnvVars returns the s3 env variables of the storage initializer for the StorageProfile spec.
+func storageProfileEnvVars(spec *v1alpha1.StorageProfileSpec) ([]corev1.EnvVar, error) {
+	envs := []corev1.EnvVar{}
+	if spec.Endpoint != "" {
+		endpoint := spec.Endpoint
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if endpointURL.Host == "" || (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") {
+			return nil, fmt.Errorf("endpoint %s must be an http or https URL", spec.Endpoint)
+		}
+		useHttps := "1"
+		if endpointURL.Scheme == "http" {
+			useHttps = "0"
+		}
+		envs = append(envs,
+			corev1.EnvVar{Name: s3.S3Endpoint, Value: endpointURL.Host},
+			corev1.EnvVar{Name: s3.AWSEndpointUrl, Value: endpointURL.Scheme + "://" + endpointURL.Host},
+			corev1.EnvVar{Name: s3.S3UseHttps, Value: useHttps},
+		)
+	}
+	if spec.Region != "" {
+		envs = append(envs, corev1.EnvVar{Name: s3.AWSRegion, Value: spec.Region})
+	}
+	if spec.SignatureVersion != "" {
+		envs = append(envs, corev1.EnvVar{Name: s3.S3SignatureVersion, Value: string(spec.SignatureVersion)})
+	}
+	if spec.CABundleConfigMap != "" {
+		envs = append(envs, corev1.EnvVar{Name: s3.AWSCABundleConfigMap, Value: spec.CABundleConfigMap})
+	}
+	if spec.Anonymous != nil {
+		envs = append(envs, corev1.EnvVar{Name: s3.AWSAnonymousCredential, Value: strconv.FormatBool(*spec.Anonymous)})
+	}
+	return envs, nil
+}
+
+// addStorageFilterEnvVars sets the include and exclude glob patterns of the model files passed down by the storage spec
+// annotations on the storage initializer, unless they are already customized by the storage container.
+func addStorageFilterEnvVars(annotations map[string]string, initContainer *corev1.Container) {
+	for _, filter := range []struct{ annotationKey, envVarKey string }{
+		{constants.StorageIncludeInternalAnnotationKey, constants.StorageIncludePatternsEnvVarKey},
+		{constants.StorageExcludeInternalAnnotationKey, constants.StorageExcludePatternsEnvVarKey},
+	} {
+		patterns, ok := annotations[filter.annotationKey]
+		if !ok {
+			continue
+		}
+		if _, exists := utils.GetEnvVarValue(initContainer.Env, filter.envVarKey); exists {
+			continue
+		}
+		initContainer.Env = append(initContainer.Env, corev1.EnvVar{Name: filter.envVarKey, Value: patterns})
+	}
+}
+
+// injectChecksumCache mounts the checksum cache host path into the storage initializer, unless the
+// cache directory is already customized by the storage container.
+func injectChecksumCache(podSpec *corev1.PodSpec, initContainer *corev1.Container, hostPath string) {
+	for _, envVar := range initContainer.Env {
+		if envVar.Name == constants.ChecksumCacheDirEnvVarKey {
+			return
+		}
+	}
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	podSpec.Volumes = utils.AppendVolumeIfNotExists(podSpec.Volumes, corev1.Volume{
+		Name: constants.ChecksumCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: hostPath,
+				Type: &hostPathType,
+			},
+		},
+	})
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.ChecksumCacheVolumeName,
+		MountPath: constants.ChecksumCacheVolumeMountPath,
+	})
+	initContainer.Env = append(initContainer.Env, corev1.EnvVar{
+		Name:  constants.ChecksumCacheDirEnvVarKey,
+		Value: constants.ChecksumCacheVolumeMountPath,
+	})
+}
+
 // InjectStorageInitializer injects an init container to provision model data
 // for the serving container in a unified way across storage tech by injecting
 // a provisioning INIT container. This is a workaround because Knative does not
@@ -537,17 +721,29 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(ctx context.Conte
 		return nil
 	}
 
-	// Mount pvc directly if local model label exists
+	// Don't decide again how the model is provisioned when the webhook is reinvoked, the node-local copy of the model
+	// could have become usable or stale in between
+	if utils.GetInitContainerWithName(&pod.Spec, constants.StorageInitializerContainerName) != nil ||
+		slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool { return volume.Name == constants.PvcSourceMountName }) {
+		return nil
+	}
+
+	// Mount pvc directly if local model label exists and the node-local copy of the model is available,
+	// otherwise fall back to downloading the model.
 	// Not supported with multiple storage URIs
-	if modelName, ok := pod.ObjectMeta.Labels[constants.LocalModelLabel]; ok {
-		subPath, _ := strings.CutPrefix(srcURI, pod.ObjectMeta.Annotations[constants.LocalModelSourceUriAnnotationKey])
-		if !strings.HasPrefix(subPath, "/") {
-			subPath = "/" + subPath
+	if _, ok := pod.ObjectMeta.Labels[constants.LocalModelLabel]; ok {
+		localURI, fallbackReason, err := mi.getLocalModelStorageURI(ctx, pod, srcURI)
+		if err != nil {
+			return err
 		}
-		if pvcName, ok := pod.ObjectMeta.Annotations[constants.LocalModelPVCNameAnnotationKey]; ok {
-			srcURI = "pvc://" + pvcName + "/models/" + modelName + subPath
+		if localURI != "" {
+			srcURI = localURI
+			pod.ObjectMeta.Annotations[constants.LocalModelSourceAnnotationKey] = constants.LocalModelSourceLocal
 		} else {
-			return fmt.Errorf("Annotation %s not found", constants.LocalModelPVCNameAnnotationKey)
+			log.Info("Local model copy is not usable, falling back to downloading the model", "pod", pod.Name,
+				"namespace", pod.Namespace, "reason", fallbackReason)
+			pod.ObjectMeta.Annotations[constants.LocalModelSourceAnnotationKey] = constants.LocalModelSourceDownload
+			pod.ObjectMeta.Annotations[constants.LocalModelFallbackReasonAnnotationKey] = fallbackReason
 		}
 	}
 
@@ -594,6 +790,46 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(ctx context.Conte
 	return CommonStorageInitialization(ctx, storageInitializerParams)
 }
 
+// getLocalModelStorageURI returns the pvc URI of the node-local copy of the model cached by the LocalModelCache of the
+// pod. When the local copy is missing or stale, an empty URI is returned along with the reason for downloading the model.
+func (mi *StorageInitializerInjector) getLocalModelStorageURI(ctx context.Context, pod *corev1.Pod, srcURI string) (string, string, error) {
+	modelName := pod.ObjectMeta.Labels[constants.LocalModelLabel]
+	pvcName, ok := pod.ObjectMeta.Annotations[constants.LocalModelPVCNameAnnotationKey]
+	if !ok {
+		return "", fmt.Sprintf("annotation %s not found", constants.LocalModelPVCNameAnnotationKey), nil
+	}
+	sourceURI := pod.ObjectMeta.Annotations[constants.LocalModelSourceUriAnnotationKey]
+
+	localModel := &v1alpha1.LocalModelCache{}
+	if err := mi.client.Get(ctx, client.ObjectKey{Name: modelName}, localModel); err != nil {
+		if apierr.IsNotFound(err) {
+			return "", fmt.Sprintf("LocalModelCache %s not found", modelName), nil
+		}
+		return "", "", err
+	}
+	// The LocalModelCache may have been recreated for another model since the InferenceService was defaulted
+	if localModel.Spec.SourceModelUri != sourceURI || !localModel.Spec.MatchStorageURI(srcURI) {
+		return "", fmt.Sprintf("LocalModelCache %s caches %s", modelName, localModel.Spec.SourceModelUri), nil
+	}
+	copies := localModel.Status.ModelCopies
+	if copies == nil || copies.Total == 0 || copies.Available < copies.Total {
+		return "", fmt.Sprintf("LocalModelCache %s is not downloaded on all the nodes", modelName), nil
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := mi.client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: pvcName}, pvc); err != nil {
+		if apierr.IsNotFound(err) {
+			return "", fmt.Sprintf("PersistentVolumeClaim %s not found", pvcName), nil
+		}
+		return "", "", err
+	}
+
+	subPath, _ := strings.CutPrefix(srcURI, sourceURI)
+	if !strings.HasPrefix(subPath, "/") {
+		subPath = "/" + subPath
+	}
+	return "pvc://" + pvcName + "/models/" + modelName + subPath, "", nil
+}
+
 // SetIstioCniSecurityContext determines if Istio is installed in using the CNI plugin. If so,
 // the UserID of the storage initializer is changed to match the UserID of the Istio sidecar.
 // This is to ensure that the storage initializer can access the network.
@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -4018,6 +4019,81 @@ func TestStorageInitializerUIDForIstioCNI(t *testing.T) {
 	}
 }
 
+// createLocalModel creates a LocalModelCache with the given status of the model copies and the PVC of its node group
+func createLocalModel(t *testing.T, name string, sourceURI string, copies *v1alpha1.ModelCopies, pvcName string) {
+	localModel := &v1alpha1.LocalModelCache{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1alpha1.LocalModelCacheSpec{
+			SourceModelUri: sourceURI,
+			ModelSize:      resource.MustParse("1Gi"),
+			NodeGroups:     []string{"h100"},
+		},
+	}
+	if err := c.Create(t.Context(), localModel); err != nil {
+		t.Fatalf("unable to create local model cache: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Delete(context.Background(), localModel); err != nil {
+			t.Errorf("unable to delete local model cache: %v", err)
+		}
+	})
+	localModel.Status.ModelCopies = copies
+	if err := c.Status().Update(t.Context(), localModel); err != nil {
+		t.Fatalf("unable to update local model cache status: %v", err)
+	}
+	if pvcName == "" {
+		return
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: "default",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	if err := c.Create(t.Context(), pvc); err != nil {
+		t.Fatalf("unable to create pvc: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Delete(context.Background(), pvc); err != nil {
+			t.Errorf("unable to delete pvc: %v", err)
+		}
+	})
+}
+
+func makeLocalModelPod(storageUri string, localModel string, localModelSourceUri string, pvcName string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey: storageUri,
+				constants.LocalModelSourceUriAnnotationKey:                 localModelSourceUri,
+			},
+			Labels: map[string]string{
+				constants.LocalModelLabel: localModel,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: constants.InferenceServiceContainerName,
+				},
+			},
+		},
+	}
+	if pvcName != "" {
+		pod.Annotations[constants.LocalModelPVCNameAnnotationKey] = pvcName
+	}
+	return pod
+}
+
 func TestLocalModelPVC(t *testing.T) {
 	storageConfig := &kserveTypes.StorageInitializerConfig{}
 	scenarios := map[string]struct {
@@ -4031,108 +4107,160 @@ func TestLocalModelPVC(t *testing.T) {
 			storageUri:               "s3://foo",
 			localModelLabel:          "bar",
 			localModelSourceUriLabel: "s3://foo",
-			pvcName:                  "model-h100",
+			pvcName:                  "model-h100-1",
 			expectedSubPath:          "models/bar/",
 		},
 		"extra / at the end": {
 			storageUri:               "s3://foo/",
 			localModelLabel:          "bar",
 			localModelSourceUriLabel: "s3://foo",
-			pvcName:                  "model-h100",
+			pvcName:                  "model-h100-2",
 			expectedSubPath:          "models/bar/",
 		},
 		"subfolder": {
 			storageUri:               "s3://foo/model1",
 			localModelLabel:          "bar",
 			localModelSourceUriLabel: "s3://foo",
-			pvcName:                  "model-h100",
+			pvcName:                  "model-h100-3",
 			expectedSubPath:          "models/bar/model1",
 		},
 		"subfolder2": {
 			storageUri:               "s3://foo/model1",
 			localModelLabel:          "bar",
 			localModelSourceUriLabel: "s3://foo/",
-			pvcName:                  "model-h100",
+			pvcName:                  "model-h100-4",
 			expectedSubPath:          "models/bar/model1",
 		},
 	}
 
-	podScenarios := make(map[string]struct {
-		original *corev1.Pod
-		expected *corev1.Pod
-	})
-
 	for name, scenario := range scenarios {
-		original := &corev1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Annotations: map[string]string{
-					constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
-					constants.LocalModelSourceUriAnnotationKey:                 scenario.localModelSourceUriLabel,
-					constants.LocalModelPVCNameAnnotationKey:                   scenario.pvcName,
-				},
-				Labels: map[string]string{
-					constants.LocalModelLabel: scenario.localModelLabel,
-				},
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{
-					{
-						Name: constants.InferenceServiceContainerName,
-					},
-				},
-			},
-		}
-		expected := &corev1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Annotations: map[string]string{
-					constants.StorageInitializerSourceUriInternalAnnotationKey: scenario.storageUri,
-				},
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{
-					{
-						Name: constants.InferenceServiceContainerName,
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "kserve-pvc-source",
-								MountPath: constants.DefaultModelLocalMountPath,
-								ReadOnly:  true,
-								SubPath:   scenario.expectedSubPath,
+		t.Run(name, func(t *testing.T) {
+			createLocalModel(t, scenario.localModelLabel, scenario.localModelSourceUriLabel,
+				&v1alpha1.ModelCopies{Available: 2, Total: 2}, scenario.pvcName)
+
+			original := makeLocalModelPod(scenario.storageUri, scenario.localModelLabel, scenario.localModelSourceUriLabel, scenario.pvcName)
+			expected := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "kserve-pvc-source",
+									MountPath: constants.DefaultModelLocalMountPath,
+									ReadOnly:  true,
+									SubPath:   scenario.expectedSubPath,
+								},
 							},
 						},
 					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "kserve-pvc-source",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: scenario.pvcName, ReadOnly: false},
+					Volumes: []corev1.Volume{
+						{
+							Name: "kserve-pvc-source",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: scenario.pvcName, ReadOnly: false},
+							},
 						},
 					},
 				},
-			},
-		}
+			}
+
+			injector := &StorageInitializerInjector{
+				credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				config: storageConfig,
+				client: c,
+			}
 
-		podScenarios[name] = struct {
-			original *corev1.Pod
-			expected *corev1.Pod
-		}{original: original, expected: expected}
+			if err := injector.InjectStorageInitializer(t.Context(), original); err != nil {
+				t.Errorf("Test %q unexpected result: %s", name, err)
+			}
+			if diff, _ := kmp.SafeDiff(expected.Spec, original.Spec); diff != "" {
+				t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+			}
+			if source := original.Annotations[constants.LocalModelSourceAnnotationKey]; source != constants.LocalModelSourceLocal {
+				t.Errorf("Test %q unexpected local model source %q", name, source)
+			}
+		})
 	}
-	for name, scenario := range podScenarios {
-		injector := &StorageInitializerInjector{
-			credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{
-				Data: map[string]string{},
-			}),
-			config: storageConfig,
-			client: c,
-		}
+}
 
-		if err := injector.InjectStorageInitializer(t.Context(), scenario.original); err != nil {
-			t.Errorf("Test %q unexpected result: %s", name, err)
-		}
-		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
-			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
-		}
+func TestLocalModelFallbackToDownload(t *testing.T) {
+	scenarios := map[string]struct {
+		localModel     bool
+		copies         *v1alpha1.ModelCopies
+		cachedUri      string
+		pvcName        string
+		podPvcName     string
+		expectedReason string
+	}{
+		"missing pvc annotation": {
+			localModel:     true,
+			copies:         &v1alpha1.ModelCopies{Available: 1, Total: 1},
+			cachedUri:      "s3://foo",
+			pvcName:        "model-h100-fallback-1",
+			expectedReason: "annotation " + constants.LocalModelPVCNameAnnotationKey + " not found",
+		},
+		"missing local model cache": {
+			podPvcName:     "model-h100-fallback-4",
+			expectedReason: "LocalModelCache bar not found",
+		},
+		"stale local model cache": {
+			localModel:     true,
+			copies:         &v1alpha1.ModelCopies{Available: 1, Total: 1},
+			cachedUri:      "s3://other",
+			pvcName:        "model-h100-fallback-2",
+			podPvcName:     "model-h100-fallback-2",
+			expectedReason: "LocalModelCache bar caches s3://other",
+		},
+		"model not downloaded": {
+			localModel:     true,
+			copies:         &v1alpha1.ModelCopies{Available: 1, Total: 2, Failed: 1},
+			cachedUri:      "s3://foo",
+			pvcName:        "model-h100-fallback-3",
+			podPvcName:     "model-h100-fallback-3",
+			expectedReason: "LocalModelCache bar is not downloaded on all the nodes",
+		},
+		"missing pvc": {
+			localModel:     true,
+			copies:         &v1alpha1.ModelCopies{Available: 1, Total: 1},
+			cachedUri:      "s3://foo",
+			podPvcName:     "model-h100-fallback-5",
+			expectedReason: "PersistentVolumeClaim model-h100-fallback-5 not found",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			if scenario.localModel {
+				createLocalModel(t, "bar", scenario.cachedUri, scenario.copies, scenario.pvcName)
+			}
+			pod := makeLocalModelPod("s3://foo/model1", "bar", "s3://foo", scenario.podPvcName)
+			injector := &StorageInitializerInjector{
+				credentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				config: storageInitializerConfig,
+				client: c,
+			}
+
+			if err := injector.InjectStorageInitializer(t.Context(), pod); err != nil {
+				t.Fatalf("Test %q unexpected result: %s", name, err)
+			}
+			if len(pod.Spec.InitContainers) != 1 || pod.Spec.InitContainers[0].Name != constants.StorageInitializerContainerName {
+				t.Fatalf("Test %q expected the storage initializer to be injected, got %v", name, pod.Spec.InitContainers)
+			}
+			if args := pod.Spec.InitContainers[0].Args; len(args) == 0 || args[0] != "s3://foo/model1" {
+				t.Errorf("Test %q expected the model to be downloaded from the source uri, got %v", name, args)
+			}
+			if source := pod.Annotations[constants.LocalModelSourceAnnotationKey]; source != constants.LocalModelSourceDownload {
+				t.Errorf("Test %q unexpected local model source %q", name, source)
+			}
+			if reason := pod.Annotations[constants.LocalModelFallbackReasonAnnotationKey]; reason != scenario.expectedReason {
+				t.Errorf("Test %q unexpected fallback reason %q, expected %q", name, reason, scenario.expectedReason)
+			}
+		})
 	}
 }
 
@@ -4393,6 +4521,323 @@ func TestCommonStorageInitializationWithCustomStorageContainer(t *testing.T) {
 	assert.Equal(t, "/mnt/models", podSpec.Containers[0].VolumeMounts[0].MountPath)
 }
 
+func TestCommonStorageInitializationWithChecksumCache(t *testing.T) {
+	config := *storageInitializerConfig
+	config.ChecksumCacheHostPath = "/var/lib/kserve/checksum-cache"
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  constants.InferenceServiceContainerName,
+				Image: "test-image",
+			},
+		},
+	}
+	params := &StorageInitializerParams{
+		Namespace:         "default",
+		StorageURIs:       []v1beta1.StorageUri{{Uri: "s3://bucket/model", MountPath: constants.DefaultModelLocalMountPath}},
+		IsLegacyURI:       true,
+		PodSpec:           podSpec,
+		CredentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{Data: map[string]string{}}),
+		Client:            c,
+		Config:            &config,
+		IsvcAnnotations:   map[string]string{},
+	}
+
+	err := CommonStorageInitialization(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, podSpec.InitContainers, 1)
+
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	assert.Contains(t, podSpec.Volumes, corev1.Volume{
+		Name: constants.ChecksumCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: "/var/lib/kserve/checksum-cache",
+				Type: &hostPathType,
+			},
+		},
+	})
+	initContainer := podSpec.InitContainers[0]
+	assert.Contains(t, initContainer.VolumeMounts, corev1.VolumeMount{
+		Name:      constants.ChecksumCacheVolumeName,
+		MountPath: constants.ChecksumCacheVolumeMountPath,
+	})
+	assert.Contains(t, initContainer.Env, corev1.EnvVar{
+		Name:  constants.ChecksumCacheDirEnvVarKey,
+		Value: constants.ChecksumCacheVolumeMountPath,
+	})
+	// The checksum cache is only mounted into the storage initializer
+	assert.Empty(t, podSpec.Containers[0].Env)
+}
+
+func TestCommonStorageInitializationWithStorageFilters(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  constants.InferenceServiceContainerName,
+				Image: "test-image",
+			},
+		},
+	}
+	params := &StorageInitializerParams{
+		Namespace:         "default",
+		StorageURIs:       []v1beta1.StorageUri{{Uri: "s3://bucket/model", MountPath: constants.DefaultModelLocalMountPath}},
+		IsLegacyURI:       true,
+		PodSpec:           podSpec,
+		CredentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{Data: map[string]string{}}),
+		Client:            c,
+		Config:            storageInitializerConfig,
+		IsvcAnnotations: map[string]string{
+			constants.StorageIncludeInternalAnnotationKey: `["*.safetensors","*.json"]`,
+			constants.StorageExcludeInternalAnnotationKey: `["optimizer*"]`,
+		},
+	}
+
+	err := CommonStorageInitialization(t.Context(), params)
+	require.NoError(t, err)
+	require.Len(t, podSpec.InitContainers, 1)
+
+	initContainer := podSpec.InitContainers[0]
+	assert.Contains(t, initContainer.Env, corev1.EnvVar{
+		Name:  constants.StorageIncludePatternsEnvVarKey,
+		Value: `["*.safetensors","*.json"]`,
+	})
+	assert.Contains(t, initContainer.Env, corev1.EnvVar{
+		Name:  constants.StorageExcludePatternsEnvVarKey,
+		Value: `["optimizer*"]`,
+	})
+}
+
+func TestCommonStorageInitializationWithSharedModelLibrary(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "model-library",
+			Namespace:   "default",
+			Annotations: map[string]string{constants.SharedModelLibraryAnnotationKey: "true"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	require.NoError(t, c.Create(t.Context(), pvc))
+	defer func() { require.NoError(t, c.Delete(t.Context(), pvc)) }()
+
+	scenarios := map[string]struct {
+		storageURIs     []v1beta1.StorageUri
+		isLegacyURI     bool
+		expectedMount   corev1.VolumeMount
+		expectedVolumes []corev1.Volume
+	}{
+		"Legacy storage URI": {
+			storageURIs: []v1beta1.StorageUri{{Uri: "pvc://model-library/nlp/bert", MountPath: constants.DefaultModelLocalMountPath}},
+			isLegacyURI: true,
+			expectedMount: corev1.VolumeMount{
+				Name:      constants.PvcSourceMountName,
+				MountPath: constants.DefaultModelLocalMountPath,
+				SubPath:   "nlp/bert",
+				ReadOnly:  true,
+			},
+		},
+		"Multiple storage URIs": {
+			storageURIs: []v1beta1.StorageUri{{Uri: "pvc://model-library/nlp/bert", MountPath: "/mnt/models/bert"}},
+			expectedMount: corev1.VolumeMount{
+				Name:      utils.GetVolumeNameFromPath("/mnt/models/bert"),
+				MountPath: "/mnt/models/bert",
+				SubPath:   "nlp/bert",
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "test-image",
+					},
+				},
+			}
+			params := &StorageInitializerParams{
+				Namespace:         "default",
+				StorageURIs:       scenario.storageURIs,
+				IsLegacyURI:       scenario.isLegacyURI,
+				PodSpec:           podSpec,
+				CredentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{Data: map[string]string{}}),
+				Client:            c,
+				Config:            storageInitializerConfig,
+				IsvcAnnotations:   map[string]string{},
+				// Shared model libraries are always mounted read-only
+				IsReadOnly: false,
+			}
+
+			err := CommonStorageInitialization(t.Context(), params)
+			require.NoError(t, err)
+
+			assert.Empty(t, podSpec.InitContainers)
+			assert.Equal(t, []corev1.VolumeMount{scenario.expectedMount}, podSpec.Containers[0].VolumeMounts)
+			assert.Equal(t, []corev1.Volume{{
+				Name: scenario.expectedMount.Name,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "model-library",
+						ReadOnly:  true,
+					},
+				},
+			}}, podSpec.Volumes)
+		})
+	}
+}
+
+func TestCommonStorageInitializationWithStorageProfile(t *testing.T) {
+	profile := &v1alpha1.StorageProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "minio"},
+		Spec: v1alpha1.StorageProfileSpec{
+			Endpoint:          "http://minio.minio-system:9000",
+			Region:            "us-west-1",
+			SignatureVersion:  v1alpha1.S3SignatureV4,
+			CABundleConfigMap: "minio-ca",
+			Anonymous:         ptr.Bool(false),
+		},
+	}
+	require.NoError(t, c.Create(t.Context(), profile))
+	defer func() { require.NoError(t, c.Delete(t.Context(), profile)) }()
+
+	profileEnvs := []corev1.EnvVar{
+		{Name: s3.S3Endpoint, Value: "minio.minio-system:9000"},
+		{Name: s3.AWSEndpointUrl, Value: "http://minio.minio-system:9000"},
+		{Name: s3.S3UseHttps, Value: "0"},
+		{Name: s3.AWSRegion, Value: "us-west-1"},
+		{Name: s3.S3SignatureVersion, Value: "s3v4"},
+		{Name: s3.AWSCABundleConfigMap, Value: "minio-ca"},
+		{Name: s3.AWSAnonymousCredential, Value: "false"},
+	}
+
+	scenarios := map[string]struct {
+		storageURI       string
+		annotations      map[string]string
+		storageKey       string
+		expectProfile    bool
+		expectedErrorMsg string
+	}{
+		"Profile referenced by annotation": {
+			storageURI:    "s3://bucket/model",
+			annotations:   map[string]string{constants.StorageProfileAnnotationKey: "minio"},
+			expectProfile: true,
+		},
+		"Profile referenced by storage key": {
+			storageURI:    "s3://bucket/model",
+			storageKey:    "minio",
+			expectProfile: true,
+		},
+		"Storage key without profile": {
+			storageURI: "s3://bucket/model",
+			storageKey: "localMinIO",
+		},
+		"Missing profile referenced by annotation": {
+			storageURI:       "s3://bucket/model",
+			annotations:      map[string]string{constants.StorageProfileAnnotationKey: "ceph"},
+			expectedErrorMsg: "failed to get StorageProfile ceph",
+		},
+		"Profile ignored for non s3 storage URI": {
+			storageURI:  "gs://bucket/model",
+			annotations: map[string]string{constants.StorageProfileAnnotationKey: "minio"},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "test-image",
+					},
+				},
+			}
+			params := &StorageInitializerParams{
+				Namespace:         "default",
+				StorageURIs:       []v1beta1.StorageUri{{Uri: scenario.storageURI, MountPath: constants.DefaultModelLocalMountPath}},
+				IsLegacyURI:       true,
+				PodSpec:           podSpec,
+				CredentialBuilder: credentials.NewCredentialBuilder(c, clientset, &corev1.ConfigMap{Data: map[string]string{}}),
+				Client:            c,
+				Config:            storageInitializerConfig,
+				IsvcAnnotations:   scenario.annotations,
+			}
+			if scenario.storageKey != "" {
+				params.StorageSpec = &v1beta1.StorageSpec{StorageKey: &scenario.storageKey}
+			}
+
+			err := CommonStorageInitialization(t.Context(), params)
+			if scenario.expectedErrorMsg != "" {
+				require.ErrorContains(t, err, scenario.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, podSpec.InitContainers, 1)
+
+			initContainer := podSpec.InitContainers[0]
+			for _, env := range profileEnvs {
+				if scenario.expectProfile {
+					assert.Contains(t, initContainer.Env, env)
+				} else {
+					_, exists := utils.GetEnvVarValue(initContainer.Env, env.Name)
+					assert.False(t, exists, env.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestStorageProfileEnvVars(t *testing.T) {
+	scenarios := map[string]struct {
+		spec          v1alpha1.StorageProfileSpec
+		expected      []corev1.EnvVar
+		expectedError bool
+	}{
+		"Endpoint without scheme": {
+			spec: v1alpha1.StorageProfileSpec{Endpoint: "rgw.ceph.svc"},
+			expected: []corev1.EnvVar{
+				{Name: s3.S3Endpoint, Value: "rgw.ceph.svc"},
+				{Name: s3.AWSEndpointUrl, Value: "https://rgw.ceph.svc"},
+				{Name: s3.S3UseHttps, Value: "1"},
+			},
+		},
+		"Anonymous profile": {
+			spec: v1alpha1.StorageProfileSpec{Anonymous: ptr.Bool(true), SignatureVersion: v1alpha1.S3SignatureV2},
+			expected: []corev1.EnvVar{
+				{Name: s3.S3SignatureVersion, Value: "s3"},
+				{Name: s3.AWSAnonymousCredential, Value: "true"},
+			},
+		},
+		"Empty profile": {
+			spec:     v1alpha1.StorageProfileSpec{},
+			expected: []corev1.EnvVar{},
+		},
+		"Unsupported endpoint scheme": {
+			spec:          v1alpha1.StorageProfileSpec{Endpoint: "ftp://minio:9000"},
+			expectedError: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			envs, err := storageProfileEnvVars(&scenario.spec)
+			if scenario.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, envs)
+		})
+	}
+}
+
 func TestCommonStorageInitializationErrorCases(t *testing.T) {
 	scenarios := map[string]struct {
 		setupPodSpec     func() *corev1.PodSpec
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingquota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var log = logf.Log.WithName(constants.ServingQuotaValidatorWebhookName)
+
+const (
+	QuotaExceededError    = "the InferenceService %q exceeds ServingQuota %q in namespace %q: %s"
+	QuotaExceededEntry    = "%s requested %s, used %s, limited %s"
+	ReplicasExceededEntry = "%s replicas %d, limited %d"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1beta1-inferenceservice-quota,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,versions=v1beta1,name=inferenceservice.kserve-webhook-server.quota-validator
+
+// InferenceServiceQuotaValidator sums the resources requested by an InferenceService and the other
+// InferenceServices in its namespace, and checks them against the ServingQuotas of the namespace. The replicas of
+// each component are checked against the replica ceilings of the quotas.
+// The check is opt-in: the webhook is only called for the namespaces labeled serving.kserve.io/serving-quota=enabled.
+type InferenceServiceQuotaValidator struct {
+	Client  client.Client
+	Decoder admission.Decoder
+}
+
+// Handle validates the incoming InferenceService against the namespace ServingQuotas
+func (v *InferenceServiceQuotaValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	isvc := &v1beta1.InferenceService{}
+	if err := v.Decoder.Decode(req, isvc); err != nil {
+		log.Error(err, "Failed to decode inference service", "name", isvc.Name, "namespace", isvc.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Removing the finalizers of an InferenceService being deleted must not be blocked by the quotas
+	if isvc.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+	// An update that does not raise the resources or the replicas is allowed, so that an InferenceService admitted
+	// before its namespace went over quota can still be updated
+	if req.Operation == admissionv1.Update {
+		old := &v1beta1.InferenceService{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			log.Error(err, "Failed to decode old inference service", "name", isvc.Name, "namespace", isvc.Namespace)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !raisesUsage(old, isvc) {
+			return admission.Allowed("")
+		}
+	}
+	// Quotas are defined on the request namespace, which may not be set on the decoded object on create
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = isvc.Namespace
+	}
+
+	quotas := &v1alpha1.ServingQuotaList{}
+	if err := v.Client.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "Failed to list serving quotas", "namespace", namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(quotas.Items) == 0 {
+		return admission.Allowed("")
+	}
+
+	existing := &v1beta1.InferenceServiceList{}
+	if err := v.Client.List(ctx, existing, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "Failed to list inference services", "namespace", namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	used := corev1.ResourceList{}
+	for i := range existing.Items {
+		// The previous version of the InferenceService and the InferenceServices being deleted are not counted
+		if existing.Items[i].Name == isvc.Name || existing.Items[i].DeletionTimestamp != nil {
+			continue
+		}
+		addResourceList(used, InferenceServiceResources(&existing.Items[i]))
+	}
+	requested := InferenceServiceResources(isvc)
+
+	warnings := admission.Warnings{}
+	for _, quota := range quotas.Items {
+		exceeded := exceededResources(quota.Spec.Hard, requested, used)
+		exceeded = append(exceeded, exceededReplicas(isvc, quota.Spec.MaxReplicas)...)
+		if len(exceeded) == 0 {
+			continue
+		}
+		msg := fmt.Sprintf(QuotaExceededError, isvc.Name, quota.Name, namespace, strings.Join(exceeded, "; "))
+		if !quota.Spec.IsWarnOnly() {
+			return admission.Denied(msg)
+		}
+		warnings = append(warnings, msg)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// InferenceServiceResources returns the total resources requested by all components of the InferenceService
+// at their maximum replica count. Limits take precedence over requests as accelerators must be set in limits.
+func InferenceServiceResources(isvc *v1beta1.InferenceService) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	predictor := &isvc.Spec.Predictor
+	perReplica := podResources(predictor.GetImplementations(), &predictor.PodSpec)
+	addResourceList(total, scaleResourceList(perReplica, maxReplicas(predictor.GetExtensions())))
+	if predictor.WorkerSpec != nil {
+		workers := int64(constants.DefaultPipelineParallelSize)
+		if predictor.WorkerSpec.PipelineParallelSize != nil {
+			workers = int64(*predictor.WorkerSpec.PipelineParallelSize)
+		}
+		// The head node is counted above, each additional pipeline stage runs on a worker node
+		workerResources := podResources(nil, &predictor.WorkerSpec.PodSpec)
+		addResourceList(total, scaleResourceList(workerResources, (workers-1)*maxReplicas(predictor.GetExtensions())))
+	}
+	if isvc.Spec.Transformer != nil {
+		perReplica := podResources(isvc.Spec.Transformer.GetImplementations(), &isvc.Spec.Transformer.PodSpec)
+		addResourceList(total, scaleResourceList(perReplica, maxReplicas(isvc.Spec.Transformer.GetExtensions())))
+	}
+	if isvc.Spec.Explainer != nil {
+		perReplica := podResources(isvc.Spec.Explainer.GetImplementations(), &isvc.Spec.Explainer.PodSpec)
+		addResourceList(total, scaleResourceList(perReplica, maxReplicas(isvc.Spec.Explainer.GetExtensions())))
+	}
+	return total
+}
+
+func podResources(implementations []v1beta1.ComponentImplementation, podSpec *v1beta1.PodSpec) corev1.ResourceList {
+	type resourceRequirementsGetter interface {
+		GetResourceRequirements() *corev1.ResourceRequirements
+	}
+	total := corev1.ResourceList{}
+	// Custom implementations are backed by the pod spec containers, which are counted below
+	for _, implementation := range implementations {
+		if getter, ok := implementation.(resourceRequirementsGetter); ok {
+			addResourceList(total, containerResources(*getter.GetResourceRequirements()))
+		}
+	}
+	for _, container := range podSpec.Containers {
+		addResourceList(total, containerResources(container.Resources))
+	}
+	return total
+}
+
+func containerResources(requirements corev1.ResourceRequirements) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for name, quantity := range requirements.Requests {
+		resources[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range requirements.Limits {
+		resources[name] = quantity.DeepCopy()
+	}
+	return resources
+}
+
+func maxReplicas(extensions *v1beta1.ComponentExtensionSpec) int64 {
+	replicas := int64(constants.DefaultMinReplicas)
+	if extensions.MinReplicas != nil && int64(*extensions.MinReplicas) > replicas {
+		replicas = int64(*extensions.MinReplicas)
+	}
+	if int64(extensions.MaxReplicas) > replicas {
+		replicas = int64(extensions.MaxReplicas)
+	}
+	return replicas
+}
+
+// componentReplicas returns the maximum replicas of each component of the InferenceService
+func componentReplicas(isvc *v1beta1.InferenceService) map[v1beta1.ComponentType]int64 {
+	replicas := map[v1beta1.ComponentType]int64{
+		v1beta1.PredictorComponent: maxReplicas(isvc.Spec.Predictor.GetExtensions()),
+	}
+	if isvc.Spec.Transformer != nil {
+		replicas[v1beta1.TransformerComponent] = maxReplicas(isvc.Spec.Transformer.GetExtensions())
+	}
+	if isvc.Spec.Explainer != nil {
+		replicas[v1beta1.ExplainerComponent] = maxReplicas(isvc.Spec.Explainer.GetExtensions())
+	}
+	return replicas
+}
+
+// raisesUsage returns whether the updated InferenceService requests more of any resource, or more replicas of any
+// component, than its previous version
+func raisesUsage(old, updated *v1beta1.InferenceService) bool {
+	oldResources := InferenceServiceResources(old)
+	for name, quantity := range InferenceServiceResources(updated) {
+		// A resource missing from the previous version is compared with zero
+		if quantity.Cmp(oldResources[name]) > 0 {
+			return true
+		}
+	}
+	oldReplicas := componentReplicas(old)
+	for component, replicas := range componentReplicas(updated) {
+		// A component added by the update is a raise, even at the default replicas
+		if previous, ok := oldReplicas[component]; !ok || replicas > previous {
+			return true
+		}
+	}
+	return false
+}
+
+// exceededReplicas returns a description of each component whose replicas are above the ceiling of the quota
+func exceededReplicas(isvc *v1beta1.InferenceService, ceiling *int32) []string {
+	if ceiling == nil {
+		return nil
+	}
+	exceeded := []string{}
+	for component, replicas := range componentReplicas(isvc) {
+		if replicas > int64(*ceiling) {
+			exceeded = append(exceeded, fmt.Sprintf(ReplicasExceededEntry, component, replicas, *ceiling))
+		}
+	}
+	sort.Strings(exceeded)
+	return exceeded
+}
+
+func addResourceList(total corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if existing, ok := total[name]; ok {
+			existing.Add(quantity)
+			total[name] = existing
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func scaleResourceList(resources corev1.ResourceList, factor int64) corev1.ResourceList {
+	scaled := corev1.ResourceList{}
+	if factor <= 0 {
+		return scaled
+	}
+	for name, quantity := range resources {
+		// Mul falls back to an arbitrary precision decimal instead of overflowing
+		value := quantity.DeepCopy()
+		value.Mul(factor)
+		scaled[name] = value
+	}
+	return scaled
+}
+
+// exceededResources returns a description of each hard limit that is exceeded by requested + used
+func exceededResources(hard, requested, used corev1.ResourceList) []string {
+	exceeded := []string{}
+	for name, limit := range hard {
+		request, ok := requested[name]
+		if !ok || request.IsZero() {
+			continue
+		}
+		current := used[name]
+		sum := request.DeepCopy()
+		sum.Add(current)
+		if sum.Cmp(limit) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf(QuotaExceededEntry, name, request.String(), current.String(), limit.String()))
+		}
+	}
+	sort.Strings(exceeded)
+	return exceeded
+}
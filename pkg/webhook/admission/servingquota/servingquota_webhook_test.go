@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingquota
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeTestInferenceService(name string, gpus string, maxReplicas int32) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					MinReplicas: ptr.To(int32(1)),
+					MaxReplicas: maxReplicas,
+				},
+				Model: &v1beta1.ModelSpec{
+					ModelFormat: v1beta1.ModelFormat{Name: "huggingface"},
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						StorageURI: ptr.To("hf://meta-llama/llama"),
+						Container: corev1.Container{
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									constants.NvidiaGPUResourceType: resource.MustParse(gpus),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeDeletedInferenceService(name string, gpus string, maxReplicas int32) *v1beta1.InferenceService {
+	isvc := makeTestInferenceService(name, gpus, maxReplicas)
+	isvc.DeletionTimestamp = ptr.To(metav1.Now())
+	isvc.Finalizers = []string{"inferenceservice.finalizers"}
+	return isvc
+}
+
+func makeTestServingQuota(gpus string, enforcement v1alpha1.ServingQuotaEnforcement) *v1alpha1.ServingQuota {
+	return &v1alpha1.ServingQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gpu-budget",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ServingQuotaSpec{
+			Hard: corev1.ResourceList{
+				constants.NvidiaGPUResourceType: resource.MustParse(gpus),
+			},
+			Enforcement: enforcement,
+		},
+	}
+}
+
+func makeTestReplicaQuota(maxReplicas int32, enforcement v1alpha1.ServingQuotaEnforcement) *v1alpha1.ServingQuota {
+	return &v1alpha1.ServingQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "replica-ceiling",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.ServingQuotaSpec{
+			MaxReplicas: ptr.To(maxReplicas),
+			Enforcement: enforcement,
+		},
+	}
+}
+
+func makeRequest(t *testing.T, isvc *v1beta1.InferenceService) admission.Request {
+	raw, err := json.Marshal(isvc)
+	if err != nil {
+		t.Fatalf("unable to marshal inference service: %v", err)
+	}
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: isvc.Namespace,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestInferenceServiceQuotaValidator_Handle(t *testing.T) {
+	scenarios := map[string]struct {
+		objects         []client.Object
+		isvc            *v1beta1.InferenceService
+		oldIsvc         *v1beta1.InferenceService
+		allowed         bool
+		expectedWarning bool
+	}{
+		"no quota in namespace": {
+			objects: []client.Object{makeTestInferenceService("existing", "4", 2)},
+			isvc:    makeTestInferenceService("new", "8", 4),
+			allowed: true,
+		},
+		"within quota": {
+			objects: []client.Object{makeTestServingQuota("8", ""), makeTestInferenceService("existing", "1", 2)},
+			isvc:    makeTestInferenceService("new", "2", 3),
+			allowed: true,
+		},
+		"exceeds quota with reject enforcement": {
+			objects: []client.Object{makeTestServingQuota("8", v1alpha1.ServingQuotaReject), makeTestInferenceService("existing", "2", 2)},
+			isvc:    makeTestInferenceService("new", "2", 3),
+			allowed: false,
+		},
+		"exceeds quota with warn enforcement": {
+			objects:         []client.Object{makeTestServingQuota("8", v1alpha1.ServingQuotaWarn), makeTestInferenceService("existing", "2", 2)},
+			isvc:            makeTestInferenceService("new", "2", 3),
+			allowed:         true,
+			expectedWarning: true,
+		},
+		"exceeds replica ceiling with reject enforcement": {
+			objects: []client.Object{makeTestReplicaQuota(4, v1alpha1.ServingQuotaReject)},
+			isvc:    makeTestInferenceService("new", "1", 8),
+			allowed: false,
+		},
+		"exceeds replica ceiling with warn enforcement": {
+			objects:         []client.Object{makeTestReplicaQuota(4, v1alpha1.ServingQuotaWarn)},
+			isvc:            makeTestInferenceService("new", "1", 8),
+			allowed:         true,
+			expectedWarning: true,
+		},
+		"within replica ceiling": {
+			objects: []client.Object{makeTestReplicaQuota(4, "")},
+			isvc:    makeTestInferenceService("new", "1", 4),
+			allowed: true,
+		},
+		"inference services being deleted are not counted": {
+			objects: []client.Object{makeTestServingQuota("8", ""), makeDeletedInferenceService("deleted", "4", 2)},
+			isvc:    makeTestInferenceService("new", "2", 4),
+			allowed: true,
+		},
+		"update does not count the previous version of the same service": {
+			objects: []client.Object{makeTestServingQuota("8", ""), makeTestInferenceService("new", "4", 2)},
+			isvc:    makeTestInferenceService("new", "2", 4),
+			allowed: true,
+		},
+		"deleting service over quota is allowed": {
+			objects: []client.Object{makeTestServingQuota("2", v1alpha1.ServingQuotaReject), makeTestInferenceService("existing", "2", 2)},
+			isvc:    makeDeletedInferenceService("new", "2", 4),
+			oldIsvc: makeDeletedInferenceService("new", "2", 4),
+			allowed: true,
+		},
+		"update not raising resources is allowed over quota": {
+			objects: []client.Object{makeTestServingQuota("2", v1alpha1.ServingQuotaReject), makeTestInferenceService("existing", "2", 2)},
+			isvc:    makeTestInferenceService("new", "1", 4),
+			oldIsvc: makeTestInferenceService("new", "2", 4),
+			allowed: true,
+		},
+		"update raising resources is denied over quota": {
+			objects: []client.Object{makeTestServingQuota("2", v1alpha1.ServingQuotaReject), makeTestInferenceService("existing", "2", 2)},
+			isvc:    makeTestInferenceService("new", "4", 4),
+			oldIsvc: makeTestInferenceService("new", "2", 4),
+			allowed: false,
+		},
+		"update raising replicas is denied above the replica ceiling": {
+			objects: []client.Object{makeTestReplicaQuota(4, v1alpha1.ServingQuotaReject)},
+			isvc:    makeTestInferenceService("new", "0", 8),
+			oldIsvc: makeTestInferenceService("new", "0", 6),
+			allowed: false,
+		},
+		"update not raising replicas is allowed above the replica ceiling": {
+			objects: []client.Object{makeTestReplicaQuota(4, v1alpha1.ServingQuotaReject)},
+			isvc:    makeTestInferenceService("new", "1", 6),
+			oldIsvc: makeTestInferenceService("new", "1", 8),
+			allowed: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			s := runtime.NewScheme()
+			g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+			g.Expect(v1beta1.AddToScheme(s)).To(gomega.Succeed())
+			validator := &InferenceServiceQuotaValidator{
+				Client:  fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.objects...).Build(),
+				Decoder: admission.NewDecoder(s),
+			}
+			request := makeRequest(t, scenario.isvc)
+			if scenario.oldIsvc != nil {
+				raw, err := json.Marshal(scenario.oldIsvc)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				request.Operation = admissionv1.Update
+				request.OldObject = runtime.RawExtension{Raw: raw}
+			}
+			response := validator.Handle(t.Context(), request)
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			if scenario.expectedWarning {
+				g.Expect(response.Warnings).To(gomega.HaveLen(1))
+			} else {
+				g.Expect(response.Warnings).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
+
+func TestInferenceServiceResources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService("multi-node", "2", 0)
+	isvc.Spec.Predictor.WorkerSpec = &v1beta1.WorkerSpec{
+		PipelineParallelSize: ptr.To(3),
+		PodSpec: v1beta1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: constants.WorkerContainerName,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							constants.NvidiaGPUResourceType: resource.MustParse("2"),
+						},
+					},
+				},
+			},
+		},
+	}
+	resources := InferenceServiceResources(isvc)
+	gpus := resources[constants.NvidiaGPUResourceType]
+	g.Expect(gpus.Value()).To(gomega.Equal(int64(6)))
+}
+
+func TestExceededReplicas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService("replicas", "1", 2)
+	isvc.Spec.Transformer = &v1beta1.TransformerSpec{
+		ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(int32(6))},
+	}
+	g.Expect(exceededReplicas(isvc, nil)).To(gomega.BeEmpty())
+	g.Expect(exceededReplicas(isvc, ptr.To(int32(4)))).To(gomega.Equal([]string{"transformer replicas 6, limited 4"}))
+	g.Expect(exceededReplicas(isvc, ptr.To(int32(1)))).To(gomega.Equal([]string{
+		"predictor replicas 2, limited 1",
+		"transformer replicas 6, limited 1",
+	}))
+}
+
+func TestScaleResourceList(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scaled := scaleResourceList(corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("1Ei"),
+	}, 16)
+	expected := resource.Quantity{}
+	for range 16 {
+		expected.Add(resource.MustParse("1Ei"))
+	}
+	memory := scaled[corev1.ResourceMemory]
+	g.Expect(memory.Cmp(expected)).To(gomega.Equal(0))
+	g.Expect(scaleResourceList(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, 0)).To(gomega.BeEmpty())
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntime
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// RuntimeArgumentLinter checks the command line of a well-known model server, to catch invalid flag combinations at
+// admission instead of when the container crashes
+type RuntimeArgumentLinter interface {
+	// Name of the model server, used in the error messages
+	Name() string
+	// Matches returns whether the container runs the model server
+	Matches(container *corev1.Container, flags RuntimeFlags) bool
+	// Lint returns the problems of the flags of the container, which is empty when the flags are valid
+	Lint(container *corev1.Container, flags RuntimeFlags, spec *v1alpha1.ServingRuntimeSpec) []string
+}
+
+// DefaultRuntimeArgumentLinters are the linters used by the validators without linters
+var DefaultRuntimeArgumentLinters = []RuntimeArgumentLinter{
+	&VLLMArgumentLinter{},
+	&TritonArgumentLinter{},
+	&TorchServeArgumentLinter{},
+}
+
+// RuntimeFlags are the tokens and flags of the command line of a container. The scripts passed to a shell with -c
+// are split into words, so that the flags of the model server started by the script are also found.
+type RuntimeFlags struct {
+	tokens []string
+	values map[string][]string
+}
+
+// ParseRuntimeFlags parses the command and the arguments of a container. A flag is either --name=value, or --name
+// followed by its value, or a boolean --name.
+func ParseRuntimeFlags(container *corev1.Container) RuntimeFlags {
+	flags := RuntimeFlags{values: map[string][]string{}}
+	for _, arg := range append(append([]string{}, container.Command...), container.Args...) {
+		flags.tokens = append(flags.tokens, strings.Fields(arg)...)
+	}
+	for i, token := range flags.tokens {
+		if !strings.HasPrefix(token, "--") || len(token) == 2 {
+			continue
+		}
+		name, value, found := strings.Cut(token, "=")
+		if !found && i+1 < len(flags.tokens) && !strings.HasPrefix(flags.tokens[i+1], "-") {
+			value = flags.tokens[i+1]
+		}
+		flags.values[name] = append(flags.values[name], value)
+	}
+	return flags
+}
+
+// Has returns whether the flag is set
+func (f RuntimeFlags) Has(name string) bool {
+	_, ok := f.values[name]
+	return ok
+}
+
+// Value returns the last value of the flag
+func (f RuntimeFlags) Value(name string) (string, bool) {
+	values, ok := f.values[name]
+	if !ok {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
+// ContainsToken returns whether a word of the command line contains the given string
+func (f RuntimeFlags) ContainsToken(s string) bool {
+	for _, token := range f.tokens {
+		if strings.Contains(token, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// intValue returns the integer value of a flag. Values set from environment variables or templates, e.g.
+// ${TENSOR_PARALLEL_SIZE} or {{.Name}}, are only known at runtime and are not returned.
+func (f RuntimeFlags) intValue(name string) (int, bool, error) {
+	value, ok := f.Value(name)
+	if !ok || !isLiteral(value) {
+		return 0, false, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s must be an integer, got %q", name, value)
+	}
+	return i, true, nil
+}
+
+func isLiteral(value string) bool {
+	return !strings.ContainsAny(value, "${}")
+}
+
+// lintRuntimeArguments runs the linters matching the model server containers of a runtime. On update, only the
+// containers the arguments of which changed from the existing runtime are linted, so that the runtimes admitted before
+// a linter was added can still be updated.
+func lintRuntimeArguments(linters []RuntimeArgumentLinter, spec *v1alpha1.ServingRuntimeSpec, existingSpec *v1alpha1.ServingRuntimeSpec) error {
+	if linters == nil {
+		linters = DefaultRuntimeArgumentLinters
+	}
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		if !argumentsChanged(container, spec, existingSpec) {
+			continue
+		}
+		flags := ParseRuntimeFlags(container)
+		for _, linter := range linters {
+			if !linter.Matches(container, flags) {
+				continue
+			}
+			if problems := linter.Lint(container, flags, spec); len(problems) > 0 {
+				return fmt.Errorf(InvalidRuntimeArgumentsError, container.Name, linter.Name(), strings.Join(problems, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// argumentsChanged returns whether the fields of a container linted by the linters differ from the container of the
+// same name of the existing runtime, which is empty on create
+func argumentsChanged(container *corev1.Container, spec *v1alpha1.ServingRuntimeSpec, existingSpec *v1alpha1.ServingRuntimeSpec) bool {
+	if existingSpec == nil {
+		return true
+	}
+	var existing *corev1.Container
+	for i := range existingSpec.Containers {
+		if existingSpec.Containers[i].Name == container.Name {
+			existing = &existingSpec.Containers[i]
+			break
+		}
+	}
+	if existing == nil || existing.Image != container.Image ||
+		!slices.Equal(existing.Command, container.Command) || !slices.Equal(existing.Args, container.Args) ||
+		!equality.Semantic.DeepEqual(existing.Resources, container.Resources) {
+		return true
+	}
+	// The parallelism of the worker spec is checked against the flags of the container
+	return !equality.Semantic.DeepEqual(workerParallelism(spec), workerParallelism(existingSpec))
+}
+
+// workerParallelism returns the parallel sizes of the worker spec of a runtime
+func workerParallelism(spec *v1alpha1.ServingRuntimeSpec) []*int {
+	if spec.WorkerSpec == nil {
+		return nil
+	}
+	return []*int{spec.WorkerSpec.TensorParallelSize, spec.WorkerSpec.PipelineParallelSize}
+}
+
+// VLLMArgumentLinter lints the parallelism flags of vLLM, which is also the backend of the Hugging Face server
+type VLLMArgumentLinter struct{}
+
+func (l *VLLMArgumentLinter) Name() string {
+	return "vLLM"
+}
+
+func (l *VLLMArgumentLinter) Matches(container *corev1.Container, flags RuntimeFlags) bool {
+	return flags.ContainsToken("vllm") || flags.ContainsToken("huggingfaceserver") || strings.Contains(container.Image, "vllm")
+}
+
+func (l *VLLMArgumentLinter) Lint(container *corev1.Container, flags RuntimeFlags, spec *v1alpha1.ServingRuntimeSpec) []string {
+	var problems []string
+	tensorParallelSize, hasTensorParallelSize, err := flags.intValue("--tensor-parallel-size")
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	pipelineParallelSize, hasPipelineParallelSize, err := flags.intValue("--pipeline-parallel-size")
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if hasTensorParallelSize && tensorParallelSize < 1 {
+		problems = append(problems, "--tensor-parallel-size must be at least 1")
+	}
+	if hasPipelineParallelSize && pipelineParallelSize < 1 {
+		problems = append(problems, "--pipeline-parallel-size must be at least 1")
+	}
+	if value, ok := flags.Value("--gpu-memory-utilization"); ok && isLiteral(value) {
+		if utilization, err := strconv.ParseFloat(value, 64); err != nil || utilization <= 0 || utilization > 1 {
+			problems = append(problems, fmt.Sprintf("--gpu-memory-utilization must be a fraction between 0 and 1, got %q", value))
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	if spec.WorkerSpec != nil {
+		// The parallelism of a multi-node deployment is set from the workerSpec, which the flags must agree with
+		if hasTensorParallelSize && spec.WorkerSpec.TensorParallelSize != nil && tensorParallelSize != *spec.WorkerSpec.TensorParallelSize {
+			problems = append(problems, fmt.Sprintf("--tensor-parallel-size %d does not match the workerSpec tensorParallelSize %d",
+				tensorParallelSize, *spec.WorkerSpec.TensorParallelSize))
+		}
+		if hasPipelineParallelSize && spec.WorkerSpec.PipelineParallelSize != nil && pipelineParallelSize != *spec.WorkerSpec.PipelineParallelSize {
+			problems = append(problems, fmt.Sprintf("--pipeline-parallel-size %d does not match the workerSpec pipelineParallelSize %d",
+				pipelineParallelSize, *spec.WorkerSpec.PipelineParallelSize))
+		}
+		return problems
+	}
+
+	// A single node deployment needs a GPU for each tensor and pipeline parallel rank
+	if hasPipelineParallelSize && pipelineParallelSize > 1 {
+		problems = append(problems, "--pipeline-parallel-size greater than 1 requires a workerSpec")
+	}
+	gpus, hasGPUs := container.Resources.Limits[constants.NvidiaGPUResourceType]
+	if hasTensorParallelSize && hasGPUs && int64(tensorParallelSize) > gpus.Value() {
+		problems = append(problems, fmt.Sprintf("--tensor-parallel-size %d is greater than the %d GPUs of the container",
+			tensorParallelSize, gpus.Value()))
+	}
+	return problems
+}
+
+// TritonArgumentLinter lints the model repository and endpoint flags of the Triton Inference Server
+type TritonArgumentLinter struct{}
+
+func (l *TritonArgumentLinter) Name() string {
+	return "Triton"
+}
+
+func (l *TritonArgumentLinter) Matches(container *corev1.Container, flags RuntimeFlags) bool {
+	return flags.ContainsToken("tritonserver")
+}
+
+func (l *TritonArgumentLinter) Lint(container *corev1.Container, flags RuntimeFlags, spec *v1alpha1.ServingRuntimeSpec) []string {
+	var problems []string
+	if !flags.Has("--model-repository") && !flags.Has("--model-store") {
+		problems = append(problems, "one of --model-repository or --model-store is required")
+	}
+	if flags.Has("--model-repository") && flags.Has("--model-store") {
+		problems = append(problems, "--model-repository and --model-store cannot both be set")
+	}
+	allowHTTP, _ := flags.Value("--allow-http")
+	allowGRPC, _ := flags.Value("--allow-grpc")
+	if allowHTTP == "false" && allowGRPC == "false" {
+		problems = append(problems, "at least one of the HTTP or gRPC endpoints must be allowed")
+	}
+	ports := map[int]string{}
+	for _, name := range []string{"--http-port", "--grpc-port", "--metrics-port"} {
+		port, ok, err := flags.intValue(name)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if port < 1 || port > 65535 {
+			problems = append(problems, fmt.Sprintf("%s must be a valid port, got %d", name, port))
+		} else if other, found := ports[port]; found {
+			problems = append(problems, fmt.Sprintf("%s and %s use the same port %d", other, name, port))
+		}
+		ports[port] = name
+	}
+	return problems
+}
+
+// TorchServeArgumentLinter lints the start flags of TorchServe
+type TorchServeArgumentLinter struct{}
+
+func (l *TorchServeArgumentLinter) Name() string {
+	return "TorchServe"
+}
+
+func (l *TorchServeArgumentLinter) Matches(container *corev1.Container, flags RuntimeFlags) bool {
+	return flags.ContainsToken("torchserve")
+}
+
+func (l *TorchServeArgumentLinter) Lint(container *corev1.Container, flags RuntimeFlags, spec *v1alpha1.ServingRuntimeSpec) []string {
+	var problems []string
+	if flags.Has("--stop") {
+		problems = append(problems, "--stop stops the server instead of starting it")
+	}
+	if !flags.Has("--model-store") {
+		problems = append(problems, "--model-store is required")
+	}
+	for _, name := range []string{"--model-store", "--ts-config"} {
+		if value, ok := flags.Value(name); ok && value == "" {
+			problems = append(problems, name+" requires a value")
+		}
+	}
+	return problems
+}
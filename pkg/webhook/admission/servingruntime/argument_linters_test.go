@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servingruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestParseRuntimeFlags(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	flags := ParseRuntimeFlags(&corev1.Container{
+		Command: []string{"bash", "-c"},
+		Args: []string{
+			"python -m huggingfaceserver --model_dir=/mnt/models --tensor-parallel-size ${TENSOR_PARALLEL_SIZE} --trust-remote-code --dtype auto",
+		},
+	})
+
+	g.Expect(flags.ContainsToken("huggingfaceserver")).To(gomega.BeTrue())
+	g.Expect(flags.values["--model_dir"]).To(gomega.Equal([]string{"/mnt/models"}))
+	g.Expect(flags.values["--tensor-parallel-size"]).To(gomega.Equal([]string{"${TENSOR_PARALLEL_SIZE}"}))
+	g.Expect(flags.values["--trust-remote-code"]).To(gomega.Equal([]string{""}))
+	g.Expect(flags.values["--dtype"]).To(gomega.Equal([]string{"auto"}))
+	g.Expect(flags.Has("--pipeline-parallel-size")).To(gomega.BeFalse())
+	size, ok, err := flags.intValue("--tensor-parallel-size")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(ok).To(gomega.BeFalse())
+	g.Expect(size).To(gomega.Equal(0))
+}
+
+func TestLintRuntimeArguments(t *testing.T) {
+	vllmContainer := func(gpus string, args ...string) corev1.Container {
+		container := corev1.Container{
+			Name:    constants.InferenceServiceContainerName,
+			Image:   "kserve/huggingfaceserver:latest",
+			Command: []string{"python", "-m", "huggingfaceserver"},
+			Args:    args,
+		}
+		if gpus != "" {
+			container.Resources.Limits = corev1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse(gpus)}
+		}
+		return container
+	}
+	workerSpec := func(pipelineParallelSize int, tensorParallelSize int) *v1alpha1.WorkerSpec {
+		return &v1alpha1.WorkerSpec{
+			PipelineParallelSize: intPtr(pipelineParallelSize),
+			TensorParallelSize:   intPtr(tensorParallelSize),
+		}
+	}
+
+	scenarios := map[string]struct {
+		container  corev1.Container
+		workerSpec *v1alpha1.WorkerSpec
+		expected   gomega.OmegaMatcher
+	}{
+		"vLLM with a tensor parallel size within the GPUs of the container": {
+			container: vllmContainer("2", "--model_dir=/mnt/models", "--tensor-parallel-size=2"),
+			expected:  gomega.BeNil(),
+		},
+		"vLLM with a tensor parallel size greater than the GPUs of the container": {
+			container: vllmContainer("1", "--model_dir=/mnt/models", "--tensor-parallel-size=4"),
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "vLLM",
+				"--tensor-parallel-size 4 is greater than the 1 GPUs of the container")),
+		},
+		"vLLM with a pipeline parallel size without a workerSpec": {
+			container: vllmContainer("", "--pipeline-parallel-size", "2"),
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "vLLM",
+				"--pipeline-parallel-size greater than 1 requires a workerSpec")),
+		},
+		"vLLM with a tensor parallel size that is not an integer": {
+			container: vllmContainer("", "--tensor-parallel-size=two"),
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "vLLM",
+				`--tensor-parallel-size must be an integer, got "two"`)),
+		},
+		"vLLM with a GPU memory utilization greater than 1": {
+			container: vllmContainer("", "--gpu-memory-utilization=90"),
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "vLLM",
+				`--gpu-memory-utilization must be a fraction between 0 and 1, got "90"`)),
+		},
+		"vLLM with parallel sizes matching the workerSpec": {
+			container:  vllmContainer("", "--tensor-parallel-size=2", "--pipeline-parallel-size=3"),
+			workerSpec: workerSpec(3, 2),
+			expected:   gomega.BeNil(),
+		},
+		"vLLM with parallel sizes set from the workerSpec environment variables": {
+			container:  vllmContainer("", "--tensor-parallel-size=${TENSOR_PARALLEL_SIZE}", "--pipeline-parallel-size=${PIPELINE_PARALLEL_SIZE}"),
+			workerSpec: workerSpec(3, 2),
+			expected:   gomega.BeNil(),
+		},
+		"vLLM with a tensor parallel size not matching the workerSpec": {
+			container:  vllmContainer("", "--tensor-parallel-size=4"),
+			workerSpec: workerSpec(2, 2),
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "vLLM",
+				"--tensor-parallel-size 4 does not match the workerSpec tensorParallelSize 2")),
+		},
+		"Triton with a model repository": {
+			container: corev1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Args: []string{"tritonserver", "--model-repository=/mnt/models", "--grpc-port=9000", "--http-port=8080"},
+			},
+			expected: gomega.BeNil(),
+		},
+		"Triton without a model repository and with the same ports": {
+			container: corev1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Args: []string{"tritonserver", "--grpc-port=8080", "--http-port=8080"},
+			},
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "Triton",
+				"one of --model-repository or --model-store is required, --http-port and --grpc-port use the same port 8080")),
+		},
+		"Triton with both endpoints disabled": {
+			container: corev1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Args: []string{"tritonserver", "--model-store=/mnt/models", "--allow-http=false", "--allow-grpc=false"},
+			},
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "Triton",
+				"at least one of the HTTP or gRPC endpoints must be allowed")),
+		},
+		"TorchServe started with a model store": {
+			container: corev1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Args: []string{"torchserve", "--start", "--model-store=/mnt/models/model-store", "--ts-config=/mnt/models/config/config.properties"},
+			},
+			expected: gomega.BeNil(),
+		},
+		"TorchServe stopped without a model store": {
+			container: corev1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Args: []string{"torchserve", "--stop"},
+			},
+			expected: gomega.Equal(fmt.Errorf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "TorchServe",
+				"--stop stops the server instead of starting it, --model-store is required")),
+		},
+		"Unknown model server": {
+			container: corev1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Image: "kserve/sklearnserver:latest",
+				Args:  []string{"--model_name={{.Name}}", "--model_dir=/mnt/models", "--http_port=8080"},
+			},
+			expected: gomega.BeNil(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := &v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{Containers: []corev1.Container{scenario.container}},
+				WorkerSpec:            scenario.workerSpec,
+			}
+			g.Expect(lintRuntimeArguments(nil, spec, nil)).To(scenario.expected)
+		})
+	}
+}
+
+func TestLintRuntimeArguments_CustomLinters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	spec := &v1alpha1.ServingRuntimeSpec{
+		ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+			Containers: []corev1.Container{{Name: constants.InferenceServiceContainerName, Args: []string{"torchserve", "--stop"}}},
+		},
+	}
+
+	// Only the configured linters are run
+	g.Expect(lintRuntimeArguments([]RuntimeArgumentLinter{&TritonArgumentLinter{}}, spec, nil)).To(gomega.Succeed())
+	g.Expect(lintRuntimeArguments([]RuntimeArgumentLinter{&TorchServeArgumentLinter{}}, spec, nil)).ToNot(gomega.Succeed())
+}
+
+func TestLintRuntimeArguments_DefaultRuntimes(t *testing.T) {
+	files, err := filepath.Glob("../../../../config/runtimes/kserve-*.yaml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find the runtimes: %v", err)
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			data, err := os.ReadFile(file)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			clusterServingRuntime := &v1alpha1.ClusterServingRuntime{}
+			g.Expect(yaml.Unmarshal(data, clusterServingRuntime)).To(gomega.Succeed())
+			g.Expect(lintRuntimeArguments(nil, &clusterServingRuntime.Spec, nil)).To(gomega.Succeed())
+		})
+	}
+}
+
+func TestServingRuntimeValidator_HandleInvalidArguments(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	servingRuntime := &v1alpha1.ServingRuntime{
+		TypeMeta:   metav1.TypeMeta{Kind: "ServingRuntime"},
+		ObjectMeta: metav1.ObjectMeta{Name: "triton", Namespace: "ns1"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+						Args: []string{"tritonserver", "--grpc-port=9000", "--http-port=8080"},
+					},
+				},
+			},
+		},
+	}
+	validator := &ServingRuntimeValidator{
+		Client:  &fakeClient{},
+		Decoder: &fakeDecoder{obj: servingRuntime},
+	}
+
+	resp := validator.Handle(t.Context(), admission.Request{})
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.Equal(fmt.Sprintf(InvalidRuntimeArgumentsServingRuntimeError,
+		fmt.Sprintf(InvalidRuntimeArgumentsError, constants.InferenceServiceContainerName, "Triton",
+			"one of --model-repository or --model-store is required"), "ServingRuntime", "triton")))
+
+	// The runtimes admitted before the linters are updated when their arguments are unchanged
+	existing := servingRuntime.DeepCopy()
+	validator.Client = &fakeClient{objs: []client.Object{existing}}
+	servingRuntime.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "LOG_VERBOSE", Value: "1"}}
+	resp = validator.Handle(t.Context(), admission.Request{})
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	servingRuntime.Spec.Containers[0].Args = append(servingRuntime.Spec.Containers[0].Args, "--log-verbose=1")
+	resp = validator.Handle(t.Context(), admission.Request{})
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	validator.Client = &fakeClient{}
+
+	// The linters can be replaced, e.g. to disable them
+	validator.Linters = []RuntimeArgumentLinter{}
+	resp = validator.Handle(t.Context(), admission.Request{})
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+}
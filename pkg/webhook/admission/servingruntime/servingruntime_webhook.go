@@ -18,6 +18,7 @@ package servingruntime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,10 +26,12 @@ import (
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/kserve/kserve/pkg/agent/watchdog"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
@@ -51,6 +54,9 @@ const (
 	DisallowedRemovingWorkerSpecFromServingRuntimeError = "removing workerSpec where it already exists is not allowed"
 	DisallowedWorkerSpecPipelineParallelSizeEnvError    = "setting PIPELINE_PARALLEL_SIZE in environment variables is not allowed"
 	DisallowedWorkerSpecTensorParallelSizeEnvError      = "setting TENSOR_PARALLEL_SIZE in environment variables is not allowed"
+	InvalidRuntimeArgumentsError                        = "the arguments of the container %s are invalid for %s: %s"
+	InvalidRuntimeArgumentsServingRuntimeError          = "%s in the %s %s"
+	InvalidOperatingSystemError                         = "the runtime targets the %s nodes, only the linux nodes are supported"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1alpha1-clusterservingruntime,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=clusterservingruntimes,versions=v1alpha1,name=clusterservingruntime.kserve-webhook-server.validator
@@ -58,6 +64,8 @@ const (
 type ClusterServingRuntimeValidator struct {
 	Client  client.Client
 	Decoder admission.Decoder
+	// Linters check the arguments of the containers, DefaultRuntimeArgumentLinters are used when nil
+	Linters []RuntimeArgumentLinter
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1alpha1-servingruntime,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=servingruntimes,versions=v1alpha1,name=servingruntime.kserve-webhook-server.validator
@@ -65,6 +73,8 @@ type ClusterServingRuntimeValidator struct {
 type ServingRuntimeValidator struct {
 	Client  client.Client
 	Decoder admission.Decoder
+	// Linters check the arguments of the containers, DefaultRuntimeArgumentLinters are used when nil
+	Linters []RuntimeArgumentLinter
 }
 
 func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
@@ -102,6 +112,18 @@ func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Req
 		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, servingRuntime.Kind, servingRuntime.Name, err.Error()))
 	}
 
+	if err := validateOperatingSystem(&servingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, servingRuntime.Kind, servingRuntime.Name, err.Error()))
+	}
+
+	if err := validateWatchdog(&servingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, servingRuntime.Kind, servingRuntime.Name, err.Error()))
+	}
+
+	if err := lintRuntimeArguments(sr.Linters, &servingRuntime.Spec, &existingRuntimeSpec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidRuntimeArgumentsServingRuntimeError, err.Error(), servingRuntime.Kind, servingRuntime.Name))
+	}
+
 	return admission.Allowed("")
 }
 
@@ -139,6 +161,18 @@ func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admis
 	if err := validateMultiNodeSpec(&clusterServingRuntime.Spec, &existingRuntimeSpec); err != nil {
 		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, clusterServingRuntime.Kind, clusterServingRuntime.Name, err.Error()))
 	}
+
+	if err := validateOperatingSystem(&clusterServingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, clusterServingRuntime.Kind, clusterServingRuntime.Name, err.Error()))
+	}
+
+	if err := validateWatchdog(&clusterServingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidMultiNodeSpecError, clusterServingRuntime.Kind, clusterServingRuntime.Name, err.Error()))
+	}
+
+	if err := lintRuntimeArguments(csr.Linters, &clusterServingRuntime.Spec, &existingRuntimeSpec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidRuntimeArgumentsServingRuntimeError, err.Error(), clusterServingRuntime.Kind, clusterServingRuntime.Name))
+	}
 	return admission.Allowed("")
 }
 
@@ -257,3 +291,31 @@ func validateMultiNodeSpec(newSpec *v1alpha1.ServingRuntimeSpec, existingSpec *v
 	}
 	return nil
 }
+
+// validateOperatingSystem rejects the runtimes explicitly targeting the Windows nodes, the model server images and
+// the containers injected by kserve only run on Linux nodes
+func validateOperatingSystem(newSpec *v1alpha1.ServingRuntimeSpec) error {
+	nodeSelectors := []map[string]string{newSpec.NodeSelector}
+	if newSpec.WorkerSpec != nil {
+		nodeSelectors = append(nodeSelectors, newSpec.WorkerSpec.NodeSelector)
+	}
+	for _, nodeSelector := range nodeSelectors {
+		if os, ok := nodeSelector[corev1.LabelOSStable]; ok && os != string(corev1.Linux) {
+			return fmt.Errorf(InvalidOperatingSystemError, os)
+		}
+	}
+	return nil
+}
+
+// validateWatchdog rejects the watchdog specs the agent of the predictor pods would fail to start with
+func validateWatchdog(newSpec *v1alpha1.ServingRuntimeSpec) error {
+	if newSpec.Watchdog == nil {
+		return nil
+	}
+	config, err := json.Marshal(newSpec.Watchdog)
+	if err != nil {
+		return err
+	}
+	_, err = watchdog.ParseConfig(string(config))
+	return err
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
@@ -1735,6 +1736,87 @@ func TestValidateMultiNodeVariables(t *testing.T) {
 	}
 }
 
+func TestValidateOperatingSystem(t *testing.T) {
+	scenarios := map[string]struct {
+		spec     v1alpha1.ServingRuntimeSpec
+		expected gomega.OmegaMatcher
+	}{
+		"no operating system selected": {
+			spec:     v1alpha1.ServingRuntimeSpec{},
+			expected: gomega.BeNil(),
+		},
+		"linux nodes": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					NodeSelector: map[string]string{corev1.LabelOSStable: "linux"},
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+		"windows nodes": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					NodeSelector: map[string]string{corev1.LabelOSStable: "windows"},
+				},
+			},
+			expected: gomega.MatchError(fmt.Sprintf(InvalidOperatingSystemError, "windows")),
+		},
+		"windows worker nodes": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				WorkerSpec: &v1alpha1.WorkerSpec{
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						NodeSelector: map[string]string{corev1.LabelOSStable: "windows"},
+					},
+				},
+			},
+			expected: gomega.MatchError(fmt.Sprintf(InvalidOperatingSystemError, "windows")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(validateOperatingSystem(&scenario.spec)).To(scenario.expected)
+		})
+	}
+}
+
+func TestValidateWatchdog(t *testing.T) {
+	scenarios := map[string]struct {
+		watchdog *v1alpha1.WatchdogSpec
+		expected gomega.OmegaMatcher
+	}{
+		"no watchdog": {
+			expected: gomega.BeNil(),
+		},
+		"defaults": {
+			watchdog: &v1alpha1.WatchdogSpec{},
+			expected: gomega.BeNil(),
+		},
+		"probe": {
+			watchdog: &v1alpha1.WatchdogSpec{
+				StallThreshold: &metav1.Duration{Duration: time.Minute},
+				Action:         v1alpha1.WatchdogUnreadyAction,
+				Probe:          &v1alpha1.WatchdogProbe{Path: "/v2/models/model/infer", Body: `{"inputs": []}`},
+			},
+			expected: gomega.BeNil(),
+		},
+		"negative stall threshold": {
+			watchdog: &v1alpha1.WatchdogSpec{StallThreshold: &metav1.Duration{Duration: -time.Minute}},
+			expected: gomega.MatchError(gomega.ContainSubstring("stallThreshold must be positive")),
+		},
+		"relative probe path": {
+			watchdog: &v1alpha1.WatchdogSpec{Probe: &v1alpha1.WatchdogProbe{Path: "v2/health/live"}},
+			expected: gomega.MatchError(gomega.ContainSubstring(`probe path "v2/health/live" must start with /`)),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(validateWatchdog(&v1alpha1.ServingRuntimeSpec{Watchdog: scenario.watchdog})).To(scenario.expected)
+		})
+	}
+}
+
 func TestServingRuntimeValidator_Handle(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
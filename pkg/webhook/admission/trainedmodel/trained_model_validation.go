@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	InsufficientMemoryCapacityError = "the Trained Model \"%s\" requires %s of memory, which exceeds the memory capacity of the InferenceService \"%s\": the capacity per replica is %s and %s is used by the existing Trained Models [%s]"
+)
+
+// logger for the validation webhook.
+var trainedModelValidatorLogger = logf.Log.WithName("trainedmodel-v1alpha1-validation-webhook")
+
+// +kubebuilder:object:generate=false
+// +k8s:openapi-gen=false
+// TrainedModelValidator extends the format validation of the TrainedModel resources with the checks requiring
+// the parent InferenceService and the other TrainedModels, such as the memory capacity of the parent InferenceService.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type TrainedModelValidator struct {
+	v1alpha1.TrainedModelValidator
+	client.Client
+}
+
+var _ webhook.CustomValidator = &TrainedModelValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (v *TrainedModelValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := v.TrainedModelValidator.ValidateCreate(ctx, obj)
+	if err != nil {
+		return warnings, err
+	}
+	tm, err := utils.Convert[*v1alpha1.TrainedModel](obj)
+	if err != nil {
+		trainedModelValidatorLogger.Error(err, "Unable to convert object to TrainedModel")
+		return warnings, err
+	}
+	return warnings, v.validateMemoryCapacity(ctx, tm)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (v *TrainedModelValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	warnings, err := v.TrainedModelValidator.ValidateUpdate(ctx, oldObj, newObj)
+	if err != nil {
+		return warnings, err
+	}
+	newTm, err := utils.Convert[*v1alpha1.TrainedModel](newObj)
+	if err != nil {
+		trainedModelValidatorLogger.Error(err, "Unable to convert object to TrainedModel")
+		return warnings, err
+	}
+	oldTm, err := utils.Convert[*v1alpha1.TrainedModel](oldObj)
+	if err != nil {
+		trainedModelValidatorLogger.Error(err, "Unable to convert object to TrainedModel")
+		return warnings, err
+	}
+	// The memory of a TrainedModel is immutable, the capacity only needs to be checked when the model moves to
+	// another InferenceService
+	if newTm.Spec.InferenceService == oldTm.Spec.InferenceService {
+		return warnings, nil
+	}
+	return warnings, v.validateMemoryCapacity(ctx, newTm)
+}
+
+// Validates the memory of the TrainedModel fits within the memory capacity of a replica of the parent
+// InferenceService, along with the memory of the other TrainedModels of the InferenceService
+func (v *TrainedModelValidator) validateMemoryCapacity(ctx context.Context, tm *v1alpha1.TrainedModel) error {
+	isvc := &v1beta1.InferenceService{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Namespace: tm.Namespace, Name: tm.Spec.InferenceService}, isvc); err != nil {
+		if apierr.IsNotFound(err) {
+			// The TrainedModel controller reports the missing InferenceService in the TrainedModel status
+			trainedModelValidatorLogger.Info("Parent InferenceService not found, skipping the memory capacity check",
+				"name", tm.Name, "inferenceService", tm.Spec.InferenceService)
+			return nil
+		}
+		trainedModelValidatorLogger.Error(err, "Unable to get the parent InferenceService", "name", tm.Name)
+		return err
+	}
+	capacity := v1beta1utils.GetPredictorMemoryLimit(isvc)
+	if capacity == nil || capacity.IsZero() {
+		// The InferenceService does not declare a memory capacity for the models
+		return nil
+	}
+
+	trainedModels := &v1alpha1.TrainedModelList{}
+	if err := v.Client.List(ctx, trainedModels, client.InNamespace(tm.Namespace)); err != nil {
+		trainedModelValidatorLogger.Error(err, "Unable to list TrainedModels", "namespace", tm.Namespace)
+		return err
+	}
+	existing := &v1alpha1.TrainedModelList{}
+	usage := []string{}
+	for _, trainedModel := range trainedModels.Items {
+		if trainedModel.Name == tm.Name || trainedModel.Spec.InferenceService != isvc.Name ||
+			trainedModel.DeletionTimestamp != nil {
+			continue
+		}
+		existing.Items = append(existing.Items, trainedModel)
+		usage = append(usage, fmt.Sprintf("%s: %s", trainedModel.Name, trainedModel.Spec.Model.Memory.String()))
+	}
+	sort.Strings(usage)
+
+	used := existing.TotalRequestedMemory()
+	requested := used.DeepCopy()
+	requested.Add(tm.Spec.Model.Memory)
+	if capacity.Cmp(requested) < 0 {
+		return fmt.Errorf(InsufficientMemoryCapacityError, tm.Name, tm.Spec.Model.Memory.String(), isvc.Name,
+			capacity.String(), used.String(), strings.Join(usage, v1alpha1.CommaSpaceSeparator))
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func makeTestInferenceService(name string, memoryLimit string) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				SKLearn: &v1beta1.SKLearnSpec{
+					PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+						Container: corev1.Container{
+							Name: "kserve-container",
+						},
+					},
+				},
+			},
+		},
+	}
+	if memoryLimit != "" {
+		isvc.Spec.Predictor.SKLearn.Resources = corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse(memoryLimit),
+			},
+		}
+	}
+	return isvc
+}
+
+func makeTestTrainedModel(name string, isvcName string, memory string) *v1alpha1.TrainedModel {
+	return &v1alpha1.TrainedModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1alpha1.TrainedModelSpec{
+			InferenceService: isvcName,
+			Model: v1alpha1.ModelSpec{
+				StorageURI: "gs://kfserving/sklearn/iris",
+				Framework:  "sklearn",
+				Memory:     resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func newTestValidator(t *testing.T, objects ...client.Object) *TrainedModelValidator {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	if err := v1beta1.AddToScheme(s); err != nil {
+		t.Errorf("unable to add scheme : %v", err)
+	}
+	return &TrainedModelValidator{Client: fake.NewClientBuilder().WithObjects(objects...).WithScheme(s).Build()}
+}
+
+func TestValidateCreate_MemoryCapacity(t *testing.T) {
+	scenarios := map[string]struct {
+		objects       []client.Object
+		trainedModel  *v1alpha1.TrainedModel
+		expectedError error
+	}{
+		"FitsWithinCapacity": {
+			objects: []client.Object{
+				makeTestInferenceService("sklearn-iris", "4Gi"),
+				makeTestTrainedModel("model1", "sklearn-iris", "1Gi"),
+				makeTestTrainedModel("model2", "sklearn-iris", "2Gi"),
+			},
+			trainedModel: makeTestTrainedModel("model3", "sklearn-iris", "1Gi"),
+		},
+		"ExceedsCapacity": {
+			objects: []client.Object{
+				makeTestInferenceService("sklearn-iris", "4Gi"),
+				makeTestTrainedModel("model2", "sklearn-iris", "2Gi"),
+				makeTestTrainedModel("model1", "sklearn-iris", "1Gi"),
+				makeTestTrainedModel("other", "other-isvc", "3Gi"),
+			},
+			trainedModel: makeTestTrainedModel("model3", "sklearn-iris", "2Gi"),
+			expectedError: fmt.Errorf(InsufficientMemoryCapacityError, "model3", "2Gi", "sklearn-iris", "4Gi", "3Gi",
+				"model1: 1Gi, model2: 2Gi"),
+		},
+		"ExceedsCapacityWithoutOtherModels": {
+			objects: []client.Object{
+				makeTestInferenceService("sklearn-iris", "1Gi"),
+			},
+			trainedModel:  makeTestTrainedModel("model1", "sklearn-iris", "2Gi"),
+			expectedError: fmt.Errorf(InsufficientMemoryCapacityError, "model1", "2Gi", "sklearn-iris", "1Gi", "0", ""),
+		},
+		"NoDeclaredCapacity": {
+			objects: []client.Object{
+				makeTestInferenceService("sklearn-iris", ""),
+			},
+			trainedModel: makeTestTrainedModel("model1", "sklearn-iris", "2Gi"),
+		},
+		"InferenceServiceNotFound": {
+			trainedModel: makeTestTrainedModel("model1", "sklearn-iris", "2Gi"),
+		},
+		"InvalidName": {
+			objects: []client.Object{
+				makeTestInferenceService("sklearn-iris", "1Gi"),
+			},
+			trainedModel:  makeTestTrainedModel("model.1", "sklearn-iris", "2Gi"),
+			expectedError: fmt.Errorf(v1alpha1.InvalidTmNameFormatError, "model.1", v1alpha1.TmRegexp),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			validator := newTestValidator(t, scenario.objects...)
+			_, err := validator.ValidateCreate(t.Context(), scenario.trainedModel)
+			if scenario.expectedError == nil {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).To(gomega.MatchError(scenario.expectedError))
+			}
+		})
+	}
+}
+
+func TestValidateUpdate_MemoryCapacity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	validator := newTestValidator(t,
+		makeTestInferenceService("sklearn-iris", "4Gi"),
+		makeTestInferenceService("sklearn-iris-small", "1Gi"),
+		makeTestTrainedModel("model1", "sklearn-iris", "3Gi"),
+		makeTestTrainedModel("model2", "sklearn-iris", "3Gi"),
+	)
+
+	// the capacity is not checked again when the parent InferenceService is unchanged
+	oldTm := makeTestTrainedModel("model2", "sklearn-iris", "3Gi")
+	newTm := makeTestTrainedModel("model2", "sklearn-iris", "3Gi")
+	newTm.Spec.Model.StorageURI = "gs://kfserving/sklearn/iris-v2"
+	_, err := validator.ValidateUpdate(t.Context(), oldTm, newTm)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// the capacity of the new parent InferenceService is checked when the model is moved
+	newTm = makeTestTrainedModel("model2", "sklearn-iris-small", "3Gi")
+	_, err = validator.ValidateUpdate(t.Context(), oldTm, newTm)
+	g.Expect(err).To(gomega.MatchError(fmt.Errorf(InsufficientMemoryCapacityError, "model2", "3Gi", "sklearn-iris-small",
+		"1Gi", "0", "")))
+
+	// the memory is immutable
+	newTm = makeTestTrainedModel("model2", "sklearn-iris", "1Gi")
+	_, err = validator.ValidateUpdate(t.Context(), oldTm, newTm)
+	g.Expect(err).To(gomega.MatchError(fmt.Errorf(v1alpha1.InvalidTmMemoryModification, "model2", "3Gi", "1Gi")))
+}
+
+func TestValidateCreate_InvalidObjectType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	validator := newTestValidator(t)
+	_, err := validator.ValidateCreate(t.Context(), &v1beta1.InferenceService{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("expected *v1alpha1.TrainedModel"))
+}
@@ -37,6 +37,10 @@ KSERVE_V1ALPHA1 = KSERVE_GROUP + "/" + KSERVE_V1ALPHA1_VERSION
 
 KSERVE_LOGLEVEL = os.environ.get("KSERVE_LOGLEVEL", "INFO").upper()
 
+# Serving certificate mounted by the controller when the InferenceService sets spec.transport.serverTLS
+KSERVE_TLS_CERT_FILE_ENV = "KSERVE_TLS_CERT_FILE"
+KSERVE_TLS_KEY_FILE_ENV = "KSERVE_TLS_KEY_FILE"
+
 # INFERENCESERVICE credentials common constants
 INFERENCESERVICE_CONFIG_MAP_NAME = "inferenceservice-config"
 INFERENCESERVICE_SYSTEM_NAMESPACE = "kserve"
@@ -15,6 +15,7 @@
 import argparse
 import asyncio
 import concurrent.futures
+import os
 import signal
 import sys
 from importlib import metadata
@@ -85,6 +86,19 @@ parser.add_argument(
     type=lambda x: utils.strtobool(x),
     help="Enable gRPC for the model server.",
 )
+parser.add_argument(
+    "--enable_grpc_reflection",
+    default=os.environ.get("ENABLE_GRPC_REFLECTION", "false"),
+    type=lambda x: utils.strtobool(x),
+    help="Enable the gRPC server reflection service, so that clients like grpcurl can discover the services.",
+)
+parser.add_argument(
+    "--grpc_descriptor_set_path",
+    default=os.environ.get("GRPC_DESCRIPTOR_SET_PATH"),
+    type=str,
+    help="Path of the descriptor set the gRPC reflection service describes the services from. "
+    "The descriptors of the model server are used when not set.",
+)
 parser.add_argument(
     "--enable_docs_url",
     default=False,
@@ -16,17 +16,29 @@ import asyncio
 import multiprocessing
 from concurrent import futures
 
+from google.protobuf import descriptor_pb2, descriptor_pool
 from grpc import aio
 
 from kserve.logging import logger
 from kserve.protocol.dataplane import DataPlane
 from kserve.protocol.model_repository_extension import ModelRepositoryExtension
 
-from . import grpc_predict_v2_pb2_grpc
+from . import grpc_predict_v2_pb2, grpc_predict_v2_pb2_grpc
 from .interceptors import LoggingInterceptor, ExceptionToStatusInterceptor
 from .servicer import InferenceServicer
 
 
+def load_descriptor_pool(path: str) -> descriptor_pool.DescriptorPool:
+    """Loads the files of a descriptor set, e.g. generated with protoc --descriptor_set_out, into a new pool."""
+    file_descriptor_set = descriptor_pb2.FileDescriptorSet()
+    with open(path, "rb") as f:
+        file_descriptor_set.ParseFromString(f.read())
+    pool = descriptor_pool.DescriptorPool()
+    for file_descriptor in file_descriptor_set.file:
+        pool.Add(file_descriptor)
+    return pool
+
+
 class GRPCServer:
     def __init__(
         self,
@@ -64,6 +76,8 @@ class GRPCServer:
         grpc_predict_v2_pb2_grpc.add_GRPCInferenceServiceServicer_to_server(
             inference_servicer, self._server
         )
+        if self._kwargs.get("enable_grpc_reflection"):
+            self._enable_reflection()
 
         listen_addr = f"[::]:{self._port}"
         self._server.add_insecure_port(listen_addr)
@@ -72,6 +86,22 @@ class GRPCServer:
         await self._server.start()
         await self._server.wait_for_termination()
 
+    def _enable_reflection(self):
+        from grpc_reflection.v1alpha import reflection
+
+        service_names = (
+            grpc_predict_v2_pb2.DESCRIPTOR.services_by_name[
+                "GRPCInferenceService"
+            ].full_name,
+            reflection.SERVICE_NAME,
+        )
+        pool = None
+        descriptor_set_path = self._kwargs.get("grpc_descriptor_set_path")
+        if descriptor_set_path:
+            pool = load_descriptor_pool(descriptor_set_path)
+        reflection.enable_server_reflection(service_names, self._server, pool=pool)
+        logger.info("Enabled gRPC reflection for %s", ", ".join(service_names))
+
     async def stop(self, sig: int = None):
         if self._server:
             logger.info("Waiting for gRPC server shutdown")
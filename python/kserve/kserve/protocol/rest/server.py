@@ -13,6 +13,7 @@
 # limitations under the License.
 
 import logging
+import os
 from socket import socket
 import sys
 from typing import List, Optional
@@ -26,6 +27,7 @@ from timing_asgi import TimingClient, TimingMiddleware
 from timing_asgi.integrations import StarletteScopeToName
 from uvicorn.importer import import_from_string, ImportFromStringError
 
+from kserve.constants.constants import KSERVE_TLS_CERT_FILE_ENV, KSERVE_TLS_KEY_FILE_ENV
 from kserve.errors import (
     InferenceError,
     InvalidInput,
@@ -77,6 +79,12 @@ class RESTServer:
         self.dataplane = data_plane
         self.model_repository_extension = model_repository_extension
         self.access_log_format = access_log_format
+        # Serve HTTPS with the serving certificate the controller mounts for spec.transport.serverTLS. The
+        # certificate is loaded once at startup, the Restart rotation rolls the pods when it is renewed.
+        ssl_certfile = os.environ.get(KSERVE_TLS_CERT_FILE_ENV)
+        ssl_keyfile = os.environ.get(KSERVE_TLS_KEY_FILE_ENV)
+        if ssl_certfile:
+            logger.info("Serving HTTPS with the certificate %s", ssl_certfile)
         self.config = uvicorn.Config(
             app,
             host="0.0.0.0",
@@ -87,6 +95,8 @@ class RESTServer:
             log_config=None,
             timeout_graceful_shutdown=grace_period,
             loop="asyncio",
+            ssl_certfile=ssl_certfile,
+            ssl_keyfile=ssl_keyfile,
         )
         self._server = uvicorn.Server(self.config)
 
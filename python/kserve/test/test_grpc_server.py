@@ -678,3 +678,18 @@ async def test_grpc_raw_inputs_with_contents_specified(mock_to_headers, server):
     with pytest.raises(InvalidInput):
         response, _, _, _ = model_infer_method.termination()
         _ = await response
+
+
+def test_load_descriptor_pool(tmp_path):
+    from google.protobuf import descriptor_pb2
+
+    from kserve.protocol.grpc.server import load_descriptor_pool
+
+    file_descriptor_set = descriptor_pb2.FileDescriptorSet()
+    grpc_predict_v2_pb2.DESCRIPTOR.CopyToProto(file_descriptor_set.file.add())
+    path = tmp_path / "grpc_predict_v2.pb"
+    path.write_bytes(file_descriptor_set.SerializeToString())
+
+    pool = load_descriptor_pool(str(path))
+    service = pool.FindServiceByName("inference.GRPCInferenceService")
+    assert "ModelInfer" in service.methods_by_name
@@ -13,7 +13,13 @@
 # limitations under the License.
 
 import pytest
-from kserve import ModelServer
+from kserve import ModelServer, ModelRepository
+from kserve.constants.constants import (
+    FASTAPI_APP_IMPORT_STRING,
+    KSERVE_TLS_CERT_FILE_ENV,
+    KSERVE_TLS_KEY_FILE_ENV,
+)
+from kserve.protocol.rest.server import RESTServer
 
 UNKNOWN_MODEL_TYPE_ERR_MESSAGE = "Unknown model collection type"
 
@@ -25,3 +31,30 @@ def test_model_server_start_no_models():
         server.start(models=None)
 
     assert exc.value.args[0] == UNKNOWN_MODEL_TYPE_ERR_MESSAGE
+
+
+def test_rest_server_serving_certificate(monkeypatch):
+    monkeypatch.setenv(KSERVE_TLS_CERT_FILE_ENV, "/etc/kserve/tls/tls.crt")
+    monkeypatch.setenv(KSERVE_TLS_KEY_FILE_ENV, "/etc/kserve/tls/tls.key")
+    server = ModelServer(registered_models=ModelRepository())
+    rest_server = RESTServer(
+        FASTAPI_APP_IMPORT_STRING,
+        server.dataplane,
+        server.model_repository_extension,
+        http_port=8080,
+    )
+    assert rest_server.config.ssl_certfile == "/etc/kserve/tls/tls.crt"
+    assert rest_server.config.ssl_keyfile == "/etc/kserve/tls/tls.key"
+
+
+def test_rest_server_without_serving_certificate(monkeypatch):
+    monkeypatch.delenv(KSERVE_TLS_CERT_FILE_ENV, raising=False)
+    monkeypatch.delenv(KSERVE_TLS_KEY_FILE_ENV, raising=False)
+    server = ModelServer(registered_models=ModelRepository())
+    rest_server = RESTServer(
+        FASTAPI_APP_IMPORT_STRING,
+        server.dataplane,
+        server.model_repository_extension,
+        http_port=8080,
+    )
+    assert rest_server.config.ssl_certfile is None
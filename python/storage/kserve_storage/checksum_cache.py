@@ -0,0 +1,212 @@
+# Copyright 2026 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import hashlib
+import json
+import os
+import re
+import shutil
+import tempfile
+from concurrent.futures import ThreadPoolExecutor
+from typing import Callable, Optional, Tuple
+
+from kserve_storage.file_filter import FileFilter
+from kserve_storage.logging import logger
+
+# Manifest listing the files of a model along with their sha256 checksums, stored at
+# the root of the storage uri:
+# {"files": [{"path": "model.safetensors", "sha256": "<hex digest>"}]}
+MANIFEST_FILE = "kserve-manifest.json"
+# Directory of the checksum cache, set by the storage initializer injector when a cache
+# is configured
+CHECKSUM_CACHE_DIR_ENV = "STORAGE_CHECKSUM_CACHE_DIR"
+
+_SHA256_RE = re.compile("^[0-9a-f]{64}$")
+_TMP_PREFIX = ".tmp-"
+
+
+def get_checksum_cache_dir() -> Optional[str]:
+    return os.getenv(CHECKSUM_CACHE_DIR_ENV) or None
+
+
+def parse_manifest(content: bytes) -> list[dict]:
+    """
+    Parses the manifest of a model, rejecting the paths escaping the model directory.
+    """
+    manifest = json.loads(content)
+    files = manifest.get("files") if isinstance(manifest, dict) else None
+    if not isinstance(files, list) or len(files) == 0:
+        raise ValueError(f"Invalid {MANIFEST_FILE}: files must be a non empty list")
+    for entry in files:
+        path = entry.get("path", "") if isinstance(entry, dict) else ""
+        normalized = os.path.normpath(path)
+        if (
+            path == ""
+            or os.path.isabs(path)
+            or normalized == ".."
+            or normalized.startswith("../")
+        ):
+            raise ValueError(f"Invalid {MANIFEST_FILE}: invalid file path {path!r}")
+        if not _SHA256_RE.match(str(entry.get("sha256", "")).lower()):
+            raise ValueError(
+                f"Invalid {MANIFEST_FILE}: invalid sha256 checksum of {path!r}"
+            )
+    return files
+
+
+def _sha256(path: str) -> str:
+    digest = hashlib.sha256()
+    with open(path, "rb") as f:
+        for chunk in iter(lambda: f.read(1024 * 1024), b""):
+            digest.update(chunk)
+    return digest.hexdigest()
+
+
+class ChecksumCache:
+    """
+    Content addressed cache of the model files keyed by their sha256 checksum,
+    typically on a node-local volume, so that a re-deployment of a slightly changed
+    model only downloads the files whose checksum changed. The manifests of the
+    downloaded models are persisted along with the files, and the files which are no
+    longer listed by any persisted manifest are pruned once a download completes.
+    """
+
+    def __init__(self, cache_dir: str):
+        self.blobs_dir = os.path.join(cache_dir, "blobs", "sha256")
+        self.manifests_dir = os.path.join(cache_dir, "manifests")
+        os.makedirs(self.blobs_dir, exist_ok=True)
+        os.makedirs(self.manifests_dir, exist_ok=True)
+
+    def _manifest_path(self, uri: str) -> str:
+        return os.path.join(
+            self.manifests_dir, hashlib.sha256(uri.encode()).hexdigest() + ".json"
+        )
+
+    def _blob_path(self, checksum: str) -> str:
+        return os.path.join(self.blobs_dir, checksum)
+
+    def download(
+        self,
+        uri: str,
+        manifest: bytes,
+        out_dir: str,
+        fetch: Callable[[str, str], None],
+        max_workers: int = 4,
+        file_filter: Optional[FileFilter] = None,
+    ) -> Tuple[int, int]:
+        """
+        Copies the files listed by the manifest into out_dir, fetching the files
+        missing from the cache with fetch(path, target), where path is relative to
+        the storage uri. The files which are not selected by file_filter are skipped.
+
+        Returns:
+            Tuple of (downloaded files: int, files reused from the cache: int)
+        """
+        files = parse_manifest(manifest)
+        manifest_path = self._manifest_path(uri)
+        previous = {}
+        if os.path.exists(manifest_path):
+            with open(manifest_path, "rb") as f:
+                previous = {
+                    e["path"]: e["sha256"].lower() for e in parse_manifest(f.read())
+                }
+        # The manifest is persisted first, so that its files are not pruned by a
+        # concurrent download
+        self._write_atomic(manifest_path, manifest)
+
+        if file_filter is not None:
+            files = [e for e in files if file_filter.matches(e["path"])]
+        changed = [
+            e["path"] for e in files if previous.get(e["path"]) != e["sha256"].lower()
+        ]
+        if previous:
+            logger.info(
+                "%d of %d files changed since the last download of %s",
+                len(changed),
+                len(files),
+                uri,
+            )
+
+        def materialize(entry: dict) -> bool:
+            checksum = entry["sha256"].lower()
+            blob_path = self._blob_path(checksum)
+            downloaded = False
+            if not os.path.exists(blob_path):
+                fd, tmp_path = tempfile.mkstemp(prefix=_TMP_PREFIX, dir=self.blobs_dir)
+                os.close(fd)
+                try:
+                    logger.info("Downloading: %s", entry["path"])
+                    fetch(entry["path"], tmp_path)
+                    actual = _sha256(tmp_path)
+                    if actual != checksum:
+                        raise RuntimeError(
+                            f"Checksum mismatch for {entry['path']}: "
+                            f"expected {checksum}, got {actual}"
+                        )
+                    os.replace(tmp_path, blob_path)
+                finally:
+                    if os.path.exists(tmp_path):
+                        os.remove(tmp_path)
+                downloaded = True
+            target_path = os.path.join(out_dir, entry["path"])
+            os.makedirs(os.path.dirname(target_path), exist_ok=True)
+            try:
+                os.link(blob_path, target_path)
+            except OSError:
+                # The cache is usually on another filesystem than the model directory
+                shutil.copyfile(blob_path, target_path)
+            return downloaded
+
+        with ThreadPoolExecutor(max_workers=max(1, max_workers)) as executor:
+            results = list(executor.map(materialize, files))
+        downloaded = sum(results)
+        logger.info(
+            "Downloaded %d files of %s, reused %d files from the checksum cache",
+            downloaded,
+            uri,
+            len(files) - downloaded,
+        )
+        self.prune()
+        return downloaded, len(files) - downloaded
+
+    def prune(self):
+        """
+        Removes the cached files which are not listed by any persisted manifest.
+        """
+        referenced = set()
+        for name in os.listdir(self.manifests_dir):
+            if name.startswith(_TMP_PREFIX):
+                continue
+            try:
+                with open(os.path.join(self.manifests_dir, name), "rb") as f:
+                    referenced.update(
+                        e["sha256"].lower() for e in parse_manifest(f.read())
+                    )
+            except (OSError, ValueError) as e:
+                logger.warning("Ignoring the cached manifest %s: %s", name, e)
+        for name in os.listdir(self.blobs_dir):
+            if name.startswith(_TMP_PREFIX) or name in referenced:
+                continue
+            logger.debug("Pruning cached file %s", name)
+            try:
+                os.remove(self._blob_path(name))
+            except FileNotFoundError:
+                pass
+
+    @staticmethod
+    def _write_atomic(path: str, content: bytes):
+        fd, tmp_path = tempfile.mkstemp(prefix=_TMP_PREFIX, dir=os.path.dirname(path))
+        with os.fdopen(fd, "wb") as f:
+            f.write(content)
+        os.replace(tmp_path, path)
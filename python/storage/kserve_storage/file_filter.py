@@ -0,0 +1,95 @@
+# Copyright 2026 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import fnmatch
+import json
+import os
+from typing import Optional
+
+from kserve_storage.logging import logger
+
+# JSON lists of the glob patterns of the model files to download and to skip, set by
+# the storage initializer injector from the storage spec of the InferenceService
+STORAGE_INCLUDE_PATTERNS_ENV = "STORAGE_INCLUDE_PATTERNS"
+STORAGE_EXCLUDE_PATTERNS_ENV = "STORAGE_EXCLUDE_PATTERNS"
+
+
+def _load_patterns(env: str) -> list[str]:
+    value = os.getenv(env, "")
+    if not value:
+        return []
+    patterns = json.loads(value)
+    if not isinstance(patterns, list) or not all(
+        isinstance(p, str) for p in patterns
+    ):
+        raise ValueError(f"{env} must be a JSON list of glob patterns")
+    return patterns
+
+
+class FileFilter:
+    """
+    Selects the model files to download with glob patterns matched against the file
+    paths relative to the storage uri, where the exclude patterns take precedence over
+    the include patterns. All the files are selected when there is no pattern.
+    """
+
+    def __init__(
+        self,
+        include: Optional[list[str]] = None,
+        exclude: Optional[list[str]] = None,
+    ):
+        self.include = include or []
+        self.exclude = exclude or []
+
+    @staticmethod
+    def from_env() -> "FileFilter":
+        return FileFilter(
+            _load_patterns(STORAGE_INCLUDE_PATTERNS_ENV),
+            _load_patterns(STORAGE_EXCLUDE_PATTERNS_ENV),
+        )
+
+    def is_enabled(self) -> bool:
+        return len(self.include) > 0 or len(self.exclude) > 0
+
+    def matches(self, path: str) -> bool:
+        path = path.replace(os.sep, "/").lstrip("/")
+        if self.include and not any(
+            fnmatch.fnmatchcase(path, pattern) for pattern in self.include
+        ):
+            return False
+        return not any(fnmatch.fnmatchcase(path, pattern) for pattern in self.exclude)
+
+    def prune(self, out_dir: str) -> int:
+        """
+        Removes the downloaded files of out_dir which are not selected, for the storage
+        types which can not list the files before downloading them.
+
+        Returns:
+            The number of removed files
+        """
+        if not self.is_enabled() or not os.path.isdir(out_dir):
+            return 0
+        removed = 0
+        for root, dirs, files in os.walk(out_dir, topdown=False):
+            for name in files:
+                file_path = os.path.join(root, name)
+                if not self.matches(os.path.relpath(file_path, out_dir)):
+                    logger.info("Removing filtered file: %s", file_path)
+                    os.remove(file_path)
+                    removed += 1
+            for name in dirs:
+                dir_path = os.path.join(root, name)
+                if not os.path.islink(dir_path) and not os.listdir(dir_path):
+                    os.rmdir(dir_path)
+        return removed
@@ -33,6 +33,12 @@ from typing import Tuple
 from urllib.parse import urlparse
 import requests
 
+from kserve_storage.checksum_cache import (
+    MANIFEST_FILE,
+    ChecksumCache,
+    get_checksum_cache_dir,
+)
+from kserve_storage.file_filter import FileFilter
 from kserve_storage.logging import logger
 
 MODEL_MOUNT_DIRS = "/mnt/models"
@@ -55,6 +61,9 @@ _HTTP_PREFIX = "http(s)://"
 _HEADERS_SUFFIX = "-headers"
 _PVC_PREFIX = "/mnt/pvc"
 _HF_PREFIX = "hf://"
+_HF_MAX_RETRIES = int(os.getenv("HF_MAX_RETRIES", "5"))
+_HF_RETRY_BACKOFF_SECONDS = 2
+_TERMINATION_LOG = "/dev/termination-log"
 
 _HDFS_SECRET_DIRECTORY = "/var/secrets/kserve-hdfscreds"
 _HDFS_FILE_SECRETS = ["KERBEROS_KEYTAB", "TLS_CERT", "TLS_KEY", "TLS_CA"]
@@ -66,6 +75,8 @@ _worker_s3_resource = None
 # Azure async download configuration
 _AZURE_MAX_FILE_CONCURRENCY = int(os.getenv("AZURE_MAX_FILE_CONCURRENCY", "4"))
 _AZURE_MAX_CHUNK_CONCURRENCY = int(os.getenv("AZURE_MAX_CHUNK_CONCURRENCY", "4"))
+# Commit SHAs the hf:// URIs were resolved to, keyed by URI
+_resolved_revisions = {}
 
 
 class Storage(object):
@@ -75,6 +86,18 @@ class Storage(object):
             model_dirs = list(executor.map(Storage.download, source_uris, out_dirs))
         return model_dirs
 
+    @staticmethod
+    def write_termination_message(path: str = _TERMINATION_LOG):
+        """Records the resolved revisions in the termination message of the container so that
+        the controller can surface them in the InferenceService status."""
+        if not _resolved_revisions:
+            return
+        try:
+            with open(path, "w") as f:
+                json.dump({"resolvedRevisions": _resolved_revisions}, f)
+        except OSError as e:
+            logger.warning("Failed to write the termination message to %s: %s", path, e)
+
     @staticmethod
     def download(uri: str, out_dir: Optional[str] = None) -> str:
         start = time.monotonic()
@@ -152,6 +175,14 @@ class Storage(object):
                 if key in storage_secret_json:
                     os.environ[env_var] = storage_secret_json.get(key)
 
+        if storage_secret_json.get("type", "") == "hf":
+            for env_var, key in (
+                ("HF_TOKEN", "token"),
+                ("HF_ENDPOINT", "endpoint"),
+            ):
+                if key in storage_secret_json:
+                    os.environ[env_var] = storage_secret_json.get(key)
+
         if (
             storage_secret_json.get("type", "") == "hdfs"
             or storage_secret_json.get("type", "") == "webhdfs"
@@ -186,9 +217,14 @@ class Storage(object):
         # S3UseAccelerate environment variable defined in s3_secret.go
         # use transfer acceleration if enabled
         accelerate = "true" == os.getenv("S3_USE_ACCELERATE", "false").lower()
+        # S3SignatureVersion environment variable defined in s3_secret.go
+        # set from the StorageProfile of S3 compatible stores requiring a given signature
+        signature_version = os.getenv("S3_SIGNATURE_VERSION")
 
         if anon:
             c = c.merge(Config(signature_version=UNSIGNED))
+        elif signature_version:
+            c = c.merge(Config(signature_version=signature_version))
         if virtual:
             c = c.merge(Config(s3={"addressing_style": "virtual"}))
         if accelerate:
@@ -311,6 +347,27 @@ class Storage(object):
         parsed = urlparse(uri, scheme="s3")
         bucket_name = parsed.netloc
         bucket_path = parsed.path.lstrip("/")
+        file_filter = FileFilter.from_env()
+
+        # Only download the changed files when the model has a manifest and a checksum
+        # cache is configured
+        cache_dir = get_checksum_cache_dir()
+        if cache_dir:
+            prefix = bucket_path.rstrip("/")
+            manifest = Storage._get_s3_manifest(s3, bucket_name, prefix)
+            if manifest is not None:
+                client = s3.meta.client
+                ChecksumCache(cache_dir).download(
+                    uri,
+                    manifest,
+                    temp_dir,
+                    lambda path, target: client.download_file(
+                        bucket_name, f"{prefix}/{path}".lstrip("/"), target
+                    ),
+                    _S3_MAX_FILE_CONCURRENCY,
+                    file_filter,
+                )
+                return temp_dir
 
         # Collect all objects to download
         download_tasks = []
@@ -330,6 +387,10 @@ class Storage(object):
             else:
                 target_key = obj.key.removeprefix(bucket_path).lstrip("/")
 
+            if not file_filter.matches(target_key):
+                logger.debug("Skipping filtered object: %s", obj.key)
+                continue
+
             target_path = f"{temp_dir}/{target_key}"
 
             # Create target directory if it doesn't exist
@@ -384,9 +445,21 @@ class Storage(object):
                 temp_dir = Storage._unpack_archive_file(target_path, mimetype, temp_dir)
         return temp_dir
 
+    @staticmethod
+    def _get_s3_manifest(s3, bucket_name: str, prefix: str) -> Optional[bytes]:
+        from botocore.exceptions import ClientError
+
+        manifest_key = f"{prefix}/{MANIFEST_FILE}".lstrip("/")
+        try:
+            return s3.Object(bucket_name, manifest_key).get()["Body"].read()
+        except ClientError as e:
+            if e.response.get("Error", {}).get("Code") in ("NoSuchKey", "404"):
+                return None
+            raise
+
     @staticmethod
     def _download_hf(uri, temp_dir: str) -> str:
-        from huggingface_hub import snapshot_download
+        from huggingface_hub import HfApi, snapshot_download
 
         components = uri[len(_HF_PREFIX) :].split("/")
 
@@ -410,12 +483,65 @@ class Storage(object):
             raise ValueError("Model name cannot be empty")
 
         revision = hash_value if hash_value else None
+        repo_id = f"{repo}/{model}"
 
-        snapshot_download(
-            repo_id=f"{repo}/{model}", revision=revision, local_dir=temp_dir
+        # Pin the download to the commit the revision currently points to so that a branch or
+        # tag moving mid-download cannot mix files of different commits.
+        sha = Storage._retry_hf(
+            lambda: HfApi().model_info(repo_id, revision=revision).sha
+        )
+        logger.info("Resolved revision %s of %s to %s", revision, repo_id, sha)
+
+        file_filter = FileFilter.from_env()
+        # snapshot_download skips the files already in local_dir and resumes the partial ones,
+        # so a retry picks up where the interrupted download stopped.
+        Storage._retry_hf(
+            lambda: snapshot_download(
+                repo_id=repo_id,
+                revision=sha,
+                local_dir=temp_dir,
+                allow_patterns=file_filter.include or None,
+                ignore_patterns=file_filter.exclude or None,
+            )
         )
+        _resolved_revisions[uri] = sha
         return temp_dir
 
+    @staticmethod
+    def _retry_hf(fn):
+        for attempt in range(_HF_MAX_RETRIES + 1):
+            try:
+                return fn()
+            except Exception as e:
+                if attempt == _HF_MAX_RETRIES or not Storage._is_transient_hf_error(e):
+                    raise
+                backoff = _HF_RETRY_BACKOFF_SECONDS * 2**attempt
+                logger.warning(
+                    "HuggingFace Hub request failed: %s, retrying in %s seconds",
+                    e,
+                    backoff,
+                )
+                time.sleep(backoff)
+
+    @staticmethod
+    def _is_transient_hf_error(e: Exception) -> bool:
+        from huggingface_hub.utils import HfHubHTTPError
+
+        if isinstance(
+            e,
+            (
+                ConnectionError,
+                TimeoutError,
+                requests.exceptions.ConnectionError,
+                requests.exceptions.Timeout,
+            ),
+        ):
+            return True
+        if isinstance(e, HfHubHTTPError) and e.response is not None:
+            status = e.response.status_code
+            return status == 429 or status >= 500
+        return False
+
     @staticmethod
     def _download_gcs(uri, temp_dir: str) -> str:
         from google.auth import exceptions
@@ -433,6 +559,24 @@ class Storage(object):
         prefix = bucket_path
         if not prefix.endswith("/"):
             prefix = prefix + "/"
+        file_filter = FileFilter.from_env()
+
+        # Only download the changed files when the model has a manifest and a checksum
+        # cache is configured
+        cache_dir = get_checksum_cache_dir()
+        if cache_dir:
+            manifest_blob = bucket.blob(f"{prefix}{MANIFEST_FILE}".lstrip("/"))
+            if manifest_blob.exists():
+                ChecksumCache(cache_dir).download(
+                    uri,
+                    manifest_blob.download_as_bytes(),
+                    temp_dir,
+                    lambda path, target: bucket.blob(
+                        f"{prefix}{path}".lstrip("/")
+                    ).download_to_filename(target),
+                    file_filter=file_filter,
+                )
+                return temp_dir
         blobs = bucket.list_blobs(prefix=prefix)
         file_count = 0
 
@@ -450,6 +594,9 @@ class Storage(object):
             for blob in blobs:
                 # Replace any prefix from the object key with temp_dir
                 subdir_object_key = blob.name.replace(bucket_path, "", 1).lstrip("/")
+                if not file_filter.matches(subdir_object_key):
+                    logger.debug("Skipping filtered blob: %s", blob.name)
+                    continue
                 # Create necessary subdirectory to store the object locally
                 if "/" in subdir_object_key:
                     local_object_dir = os.path.join(
@@ -590,6 +737,8 @@ class Storage(object):
                 out_dir = Storage._unpack_archive_file(
                     dest_file_path, mimetype, out_dir
                 )
+        # The files of the directory can not be filtered before downloading them
+        FileFilter.from_env().prune(out_dir)
         return out_dir
 
     @staticmethod
@@ -618,6 +767,7 @@ class Storage(object):
 
         # File-level semaphore to control concurrent file downloads
         file_semaphore = asyncio.Semaphore(_AZURE_MAX_FILE_CONCURRENCY)
+        file_filter = FileFilter.from_env()
 
         async with BlobServiceClient(
             account_url, credential=token
@@ -629,7 +779,9 @@ class Storage(object):
             logger.info("Listing blobs with prefix: %s", prefix)
             async for blob in container_client.list_blobs(name_starts_with=prefix):
                 logger.info("Found blob: %s (%d bytes)", blob.name, blob.size)
-                if blob.size > 0:
+                if blob.size > 0 and file_filter.matches(
+                    blob.name.replace(prefix, "", 1).lstrip("/")
+                ):
                     blobs.append(blob)
 
             if not blobs:
@@ -719,6 +871,7 @@ class Storage(object):
 
         share_service_client = ShareServiceClient(account_url, credential=access_key)
         share_client = share_service_client.get_share_client(share_name)
+        file_filter = FileFilter.from_env()
         file_count = 0
         share_files = []
         max_depth = 5
@@ -735,7 +888,9 @@ class Storage(object):
                         ("/".join([curr_prefix, item.name]).strip("/"), depth - 1)
                     )
                 else:
-                    share_files.append((curr_prefix, item))
+                    file_path = "/".join([curr_prefix, item.name]).strip("/")
+                    if file_filter.matches(file_path.removeprefix(prefix).lstrip("/")):
+                        share_files.append((curr_prefix, item))
         for prefix, file_item in share_files:
             parts = [prefix] if prefix else []
             parts.append(file_item.name)
@@ -911,6 +1066,8 @@ class Storage(object):
 
         if mimetype in ["application/x-tar", "application/zip"]:
             out_dir = Storage._unpack_archive_file(local_path, mimetype, out_dir)
+            # The files of the archive can only be filtered once unpacked
+            FileFilter.from_env().prune(out_dir)
         return out_dir
 
     @staticmethod
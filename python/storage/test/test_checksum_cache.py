@@ -0,0 +1,127 @@
+# Copyright 2026 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import hashlib
+import json
+import os
+import unittest.mock as mock
+
+import pytest
+
+from kserve_storage import Storage
+from kserve_storage.checksum_cache import (
+    CHECKSUM_CACHE_DIR_ENV,
+    MANIFEST_FILE,
+    ChecksumCache,
+    parse_manifest,
+)
+
+
+def make_manifest(files: dict[str, bytes]) -> bytes:
+    return json.dumps(
+        {
+            "files": [
+                {"path": path, "sha256": hashlib.sha256(content).hexdigest()}
+                for path, content in files.items()
+            ]
+        }
+    ).encode()
+
+
+class Source:
+    """Model files served by a fake storage, recording the fetched paths."""
+
+    def __init__(self, files: dict[str, bytes]):
+        self.files = files
+        self.fetched = []
+
+    def fetch(self, path: str, target: str):
+        self.fetched.append(path)
+        with open(target, "wb") as f:
+            f.write(self.files[path])
+
+
+def test_download_only_changed_files(tmp_path):
+    cache = ChecksumCache(str(tmp_path / "cache"))
+    files = {"config.json": b"{}", "weights/model.bin": b"weights-v1"}
+    source = Source(files)
+
+    downloaded, reused = cache.download(
+        "s3://foo/model", make_manifest(files), str(tmp_path / "v1"), source.fetch
+    )
+    assert (downloaded, reused) == (2, 0)
+    assert (tmp_path / "v1" / "weights" / "model.bin").read_bytes() == b"weights-v1"
+
+    # The re-deployment of the retrained model only downloads the changed weights
+    files = {"config.json": b"{}", "weights/model.bin": b"weights-v2"}
+    source = Source(files)
+    downloaded, reused = cache.download(
+        "s3://foo/model", make_manifest(files), str(tmp_path / "v2"), source.fetch
+    )
+    assert (downloaded, reused) == (1, 1)
+    assert source.fetched == ["weights/model.bin"]
+    assert (tmp_path / "v2" / "config.json").read_bytes() == b"{}"
+    assert (tmp_path / "v2" / "weights" / "model.bin").read_bytes() == b"weights-v2"
+
+    # The weights of the previous model are pruned from the cache
+    assert sorted(os.listdir(cache.blobs_dir)) == sorted(
+        hashlib.sha256(content).hexdigest() for content in files.values()
+    )
+
+
+def test_download_checksum_mismatch(tmp_path):
+    cache = ChecksumCache(str(tmp_path / "cache"))
+    manifest = make_manifest({"model.bin": b"expected"})
+    source = Source({"model.bin": b"corrupted"})
+
+    with pytest.raises(RuntimeError, match="Checksum mismatch for model.bin"):
+        cache.download("gs://foo/model", manifest, str(tmp_path / "out"), source.fetch)
+    assert os.listdir(cache.blobs_dir) == []
+
+
+@pytest.mark.parametrize(
+    "manifest",
+    [
+        b"[]",
+        b'{"files": []}',
+        b'{"files": [{"path": "../model.bin", "sha256": "' + b"0" * 64 + b'"}]}',
+        b'{"files": [{"path": "/model.bin", "sha256": "' + b"0" * 64 + b'"}]}',
+        b'{"files": [{"path": "model.bin", "sha256": "abc"}]}',
+    ],
+)
+def test_parse_invalid_manifest(manifest):
+    with pytest.raises(ValueError):
+        parse_manifest(manifest)
+
+
+@mock.patch("boto3.resource")
+def test_download_s3_with_manifest(mock_resource, tmp_path):
+    files = {"model.bin": b"weights"}
+    source = Source(files)
+    mock_s3_resource = mock.MagicMock()
+    body = mock.MagicMock()
+    body.read.return_value = make_manifest(files)
+    mock_s3_resource.Object.return_value.get.return_value = {"Body": body}
+    mock_s3_resource.meta.client.download_file.side_effect = (
+        lambda bucket, key, target: source.fetch(key.removeprefix("bar/"), target)
+    )
+    mock_resource.return_value = mock_s3_resource
+
+    cache_dir = str(tmp_path / "cache")
+    with mock.patch.dict(os.environ, {CHECKSUM_CACHE_DIR_ENV: cache_dir}):
+        Storage._download_s3("s3://foo/bar", str(tmp_path / "out"))
+
+    mock_s3_resource.Object.assert_called_with("foo", f"bar/{MANIFEST_FILE}")
+    mock_s3_resource.Bucket.return_value.objects.filter.assert_not_called()
+    assert (tmp_path / "out" / "model.bin").read_bytes() == b"weights"
@@ -0,0 +1,65 @@
+# Copyright 2026 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import pytest
+
+from kserve_storage.file_filter import (
+    STORAGE_EXCLUDE_PATTERNS_ENV,
+    STORAGE_INCLUDE_PATTERNS_ENV,
+    FileFilter,
+)
+
+
+@pytest.mark.parametrize(
+    "include, exclude, path, expected",
+    [
+        ([], [], "pytorch_model.bin", True),
+        (["*.safetensors"], [], "model-00001-of-00002.safetensors", True),
+        (["*.safetensors"], [], "pytorch_model.bin", False),
+        (["*.safetensors"], [], "shards/model.safetensors", True),
+        ([], ["*.bin"], "pytorch_model.bin", False),
+        ([], ["optimizer*"], "optimizer.pt", False),
+        (["*.safetensors"], ["optimizer/*"], "optimizer/state.safetensors", False),
+    ],
+)
+def test_matches(include, exclude, path, expected):
+    assert FileFilter(include, exclude).matches(path) == expected
+
+
+def test_from_env(monkeypatch):
+    monkeypatch.setenv(STORAGE_INCLUDE_PATTERNS_ENV, '["*.safetensors"]')
+    monkeypatch.setenv(STORAGE_EXCLUDE_PATTERNS_ENV, '["optimizer*"]')
+    file_filter = FileFilter.from_env()
+    assert file_filter.include == ["*.safetensors"]
+    assert file_filter.exclude == ["optimizer*"]
+
+
+def test_from_env_invalid_patterns(monkeypatch):
+    monkeypatch.setenv(STORAGE_INCLUDE_PATTERNS_ENV, '"*.safetensors"')
+    with pytest.raises(ValueError):
+        FileFilter.from_env()
+
+
+def test_prune(tmp_path):
+    for path in ["config.json", "model.safetensors", "pytorch_model.bin", "opt/a.pt"]:
+        (tmp_path / path).parent.mkdir(parents=True, exist_ok=True)
+        (tmp_path / path).write_bytes(b"")
+
+    removed = FileFilter(exclude=["*.bin", "opt/*"]).prune(str(tmp_path))
+
+    assert removed == 2
+    assert sorted(p.name for p in tmp_path.iterdir()) == [
+        "config.json",
+        "model.safetensors",
+    ]
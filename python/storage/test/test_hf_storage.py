@@ -12,26 +12,101 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import json
+import os
 import unittest.mock as mock
 import pytest
+import requests
 
 from kserve_storage import Storage
+from kserve_storage import kserve_storage
 
+RESOLVED_SHA = "0123456789abcdef0123456789abcdef01234567"
 
+
+@mock.patch("huggingface_hub.HfApi")
 @mock.patch("huggingface_hub.snapshot_download")
-def test_download_model(mock_snapshot_download):
+def test_download_model(mock_snapshot_download, mock_hf_api):
     uri = "hf://example.com/model:hash_value"
     repo = "example.com"
     model = "model"
     revision = "hash_value"
+    mock_hf_api.return_value.model_info.return_value.sha = RESOLVED_SHA
 
     Storage.download(uri)
 
+    mock_hf_api.return_value.model_info.assert_called_once_with(
+        f"{repo}/{model}", revision=revision
+    )
     mock_snapshot_download.assert_called_once_with(
         repo_id=f"{repo}/{model}",
-        revision=revision,
+        revision=RESOLVED_SHA,
         local_dir=mock.ANY,
+        allow_patterns=None,
+        ignore_patterns=None,
     )
+    assert kserve_storage._resolved_revisions[uri] == RESOLVED_SHA
+
+
+@mock.patch("kserve_storage.kserve_storage.time.sleep")
+@mock.patch("huggingface_hub.HfApi")
+@mock.patch("huggingface_hub.snapshot_download")
+def test_download_model_retries_transient_errors(
+    mock_snapshot_download, mock_hf_api, mock_sleep
+):
+    mock_hf_api.return_value.model_info.return_value.sha = RESOLVED_SHA
+    mock_snapshot_download.side_effect = [
+        requests.exceptions.ConnectionError("connection reset"),
+        None,
+    ]
+
+    Storage.download("hf://example.com/model")
+
+    assert mock_snapshot_download.call_count == 2
+    mock_sleep.assert_called_once()
+
+
+@mock.patch("kserve_storage.kserve_storage.time.sleep")
+@mock.patch("huggingface_hub.HfApi")
+@mock.patch("huggingface_hub.snapshot_download")
+def test_download_model_does_not_retry_permanent_errors(
+    mock_snapshot_download, mock_hf_api, mock_sleep
+):
+    mock_hf_api.return_value.model_info.side_effect = ValueError("repository not found")
+
+    with pytest.raises(ValueError, match="repository not found"):
+        Storage.download("hf://example.com/model")
+
+    mock_snapshot_download.assert_not_called()
+    mock_sleep.assert_not_called()
+
+
+@mock.patch.dict(
+    os.environ,
+    {"STORAGE_CONFIG": json.dumps({"type": "hf", "token": "hf_token"})},
+)
+@mock.patch("huggingface_hub.HfApi")
+@mock.patch("huggingface_hub.snapshot_download")
+def test_download_model_with_storage_spec(mock_snapshot_download, mock_hf_api):
+    mock_hf_api.return_value.model_info.return_value.sha = RESOLVED_SHA
+
+    Storage.download("hf://example.com/model")
+
+    assert os.environ["HF_TOKEN"] == "hf_token"
+
+
+def test_write_termination_message(tmp_path):
+    path = tmp_path / "termination-log"
+    with mock.patch.dict(
+        kserve_storage._resolved_revisions,
+        {"hf://example.com/model": RESOLVED_SHA},
+        clear=True,
+    ):
+        Storage.write_termination_message(str(path))
+
+    assert json.loads(path.read_text()) == {
+        "resolvedRevisions": {"hf://example.com/model": RESOLVED_SHA}
+    }
 
 
 @mock.patch("huggingface_hub.snapshot_download")
@@ -109,6 +109,31 @@ def test_parent_key(mock_storage):
     mock_boto3_bucket.objects.filter.assert_called_with(Prefix="bar")
 
 
+@mock.patch("boto3.resource")
+def test_include_exclude_patterns(mock_storage, monkeypatch):
+    # given
+    bucket_name = "foo"
+    paths = [
+        "config.json",
+        "model.safetensors",
+        "pytorch_model.bin",
+        "optimizer/state.safetensors",
+    ]
+    object_paths = ["bar/" + p for p in paths]
+    monkeypatch.setenv("STORAGE_INCLUDE_PATTERNS", '["*.json", "*.safetensors"]')
+    monkeypatch.setenv("STORAGE_EXCLUDE_PATTERNS", '["optimizer/*"]')
+
+    # when
+    mock_boto3_bucket = create_mock_boto3_bucket(mock_storage, object_paths)
+    Storage._download_s3(f"s3://{bucket_name}/bar", "dest_path")
+
+    # then
+    arg_list = get_call_args(mock_boto3_bucket.download_file.call_args_list)
+    assert arg_list == expected_call_args_list(
+        "bar", "dest_path", ["config.json", "model.safetensors"]
+    )
+
+
 @mock.patch("boto3.resource")
 def test_no_key(mock_storage):
     # given
@@ -295,6 +320,19 @@ def test_get_S3_config():
         config8 = Storage.get_S3_config()
     assert config8.s3["addressing_style"] == VIRTUAL_CONFIG.s3["addressing_style"]
 
+    # tests the signature version of the StorageProfile
+    with mock.patch.dict(os.environ, {"S3_SIGNATURE_VERSION": "s3"}):
+        config9 = Storage.get_S3_config()
+    assert config9.signature_version == "s3"
+
+    anon_and_signature = {
+        "awsAnonymousCredential": "True",
+        "S3_SIGNATURE_VERSION": "s3",
+    }
+    with mock.patch.dict(os.environ, anon_and_signature):
+        config10 = Storage.get_S3_config()
+    assert config10.signature_version == ANON_CONFIG.signature_version
+
 
 def test_update_with_storage_spec_s3(monkeypatch):
     # save the environment and restore it after the test to avoid mutating it